@@ -0,0 +1,240 @@
+// Package artifact provides the micro artifact command which pushes the built binaries and images
+// of services to the runtime, so they can be run by version without building them from source
+package artifact
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/micro/v3/client/cli/namespace"
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/cmd"
+	"github.com/micro/micro/v3/internal/artifact"
+	"github.com/micro/micro/v3/internal/helper"
+	pb "github.com/micro/micro/v3/proto/runtime"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// chunkSize is the size of the chunks the binary is streamed in
+const chunkSize = 64 * 1024
+
+func init() {
+	cmd.Register(&cli.Command{
+		Name:  "artifact",
+		Usage: "Manage the artifacts of services",
+		Description: `Artifacts are the versioned binaries or images of services, pushed by CI once they're built.
+	They're run by version, so a version can be promoted or rolled back without building it again.
+
+	micro artifact push helloworld@v1.2.0 ./bin/helloworld
+	micro artifact push --image example.com/helloworld:v1.2.0 helloworld@v1.2.0
+	micro run --artifact helloworld@v1.2.0 # promote
+	micro run --artifact helloworld@v1.1.0 # roll back`,
+		Action: helper.UnexpectedSubcommand,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "push",
+				Usage:     "Push the binary or image of a version of a service",
+				UsageText: "micro artifact push [options] name@version [binary]",
+				Action:    push,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "image",
+						Usage: "Set the image the service is run with rather than a binary",
+					},
+					&cli.StringFlag{
+						Name:  "commit",
+						Usage: "Set the commit the artifact was built from",
+					},
+					&cli.StringFlag{
+						Name:  "digest",
+						Usage: "Set the sha256 the binary must match",
+					},
+					&cli.StringSliceFlag{
+						Name:  "metadata",
+						Usage: "Set metadata of the artifact e.g. pipeline=1234",
+					},
+				},
+			},
+			{
+				Name:      "list",
+				Usage:     "List the artifacts, newest first",
+				UsageText: "micro artifact list [name]",
+				Action:    list,
+			},
+			{
+				Name:      "delete",
+				Usage:     "Delete the artifact of a version of a service",
+				UsageText: "micro artifact delete name@version",
+				Action:    del,
+			},
+		},
+	})
+}
+
+func getNamespace(ctx *cli.Context) (string, error) {
+	env, err := util.GetEnv(ctx)
+	if err != nil {
+		return "", err
+	}
+	return namespace.Get(env.Name)
+}
+
+func cliError(err error) error {
+	if verr := errors.FromError(err); verr != nil {
+		return fmt.Errorf("Error: %v", verr.Detail)
+	}
+	return err
+}
+
+func push(ctx *cli.Context) error {
+	if ctx.Args().Len() < 1 || ctx.Args().Len() > 2 {
+		return fmt.Errorf("Expected arguments: name@version [binary]")
+	}
+	name, version, err := artifact.ParseRef(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	image := ctx.String("image")
+	if ctx.Args().Len() == 1 && len(image) == 0 {
+		return fmt.Errorf("Expected a binary or an image")
+	}
+	ns, err := getNamespace(ctx)
+	if err != nil {
+		return err
+	}
+
+	info := &pb.ArtifactInfo{
+		Name:      name,
+		Version:   version,
+		Namespace: ns,
+		Image:     image,
+		Digest:    ctx.String("digest"),
+		Commit:    ctx.String("commit"),
+	}
+	for _, md := range ctx.StringSlice("metadata") {
+		parts := strings.SplitN(md, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("Invalid metadata %v, expected key=value", md)
+		}
+		if info.Metadata == nil {
+			info.Metadata = make(map[string]string)
+		}
+		info.Metadata[parts[0]] = parts[1]
+	}
+
+	var bin io.Reader
+	if path := ctx.Args().Get(1); len(path) > 0 {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		bin = f
+	}
+
+	cli := pb.NewArtifactService("runtime", client.DefaultClient)
+	stream, err := cli.Push(context.DefaultContext, client.WithAuthToken())
+	if err != nil {
+		return cliError(err)
+	}
+
+	// the artifact is sent on the first message, followed by the chunks of the binary
+	req := &pb.PushArtifactRequest{Artifact: info}
+	buffer := make([]byte, chunkSize)
+	for bin != nil {
+		num, err := bin.Read(buffer)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		req.Data = buffer[:num]
+		if err := stream.Send(req); err != nil {
+			return cliError(err)
+		}
+		req = &pb.PushArtifactRequest{}
+	}
+	if req.Artifact != nil {
+		if err := stream.Send(req); err != nil {
+			return cliError(err)
+		}
+	}
+
+	rsp, err := stream.CloseAndRecv()
+	if err != nil {
+		return cliError(err)
+	}
+	if len(rsp.Artifact.Digest) > 0 {
+		fmt.Printf("Pushed %v@%v sha256:%v\n", name, version, rsp.Artifact.Digest)
+	} else {
+		fmt.Printf("Pushed %v@%v %v\n", name, version, rsp.Artifact.Image)
+	}
+	return nil
+}
+
+func list(ctx *cli.Context) error {
+	if ctx.Args().Len() > 1 {
+		return fmt.Errorf("Expected at most one argument: name")
+	}
+	ns, err := getNamespace(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli := pb.NewArtifactService("runtime", client.DefaultClient)
+	rsp, err := cli.List(context.DefaultContext, &pb.ListArtifactsRequest{
+		Name:      ctx.Args().First(),
+		Namespace: ns,
+	}, client.WithAuthToken())
+	if err != nil {
+		return cliError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, strings.Join([]string{"NAME", "VERSION", "ARTIFACT", "COMMIT", "CREATED"}, "\t"))
+	for _, a := range rsp.Artifacts {
+		art := a.Image
+		if len(a.Digest) > 0 {
+			art = fmt.Sprintf("sha256:%.12s (%d bytes)", a.Digest, a.Size)
+		}
+		created := time.Unix(a.Created, 0).Format(time.RFC3339)
+		fmt.Fprintln(w, strings.Join([]string{a.Name, a.Version, art, a.Commit, created}, "\t"))
+	}
+	return nil
+}
+
+func del(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Expected one argument: name@version")
+	}
+	name, version, err := artifact.ParseRef(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	ns, err := getNamespace(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli := pb.NewArtifactService("runtime", client.DefaultClient)
+	_, err = cli.Delete(context.DefaultContext, &pb.DeleteArtifactRequest{
+		Name:      name,
+		Version:   version,
+		Namespace: ns,
+	}, client.WithAuthToken())
+	if err != nil {
+		return cliError(err)
+	}
+
+	fmt.Printf("Deleted %v@%v\n", name, version)
+	return nil
+}
@@ -40,6 +40,17 @@ var (
 			Usage: "Comma seperated list of scopes to give the account",
 		},
 	}
+	// quotaFlags are provided to the commands which set or delete quotas
+	quotaFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "namespace",
+			Usage: "Namespace of the quota, defaults to the namespace of the environment",
+		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "ID of the account the quota applies to, leave blank to limit the whole namespace",
+		},
+	}
 )
 
 func init() {
@@ -63,6 +74,17 @@ func init() {
 							Usage:  "List auth accounts",
 							Action: listAccounts,
 						},
+						{
+							Name:  "quotas",
+							Usage: "List the quotas of the requests made through the api and proxy",
+							Flags: []cli.Flag{
+								&cli.BoolFlag{
+									Name:  "all",
+									Usage: "List the quotas of all the namespaces",
+								},
+							},
+							Action: listQuotas,
+						},
 					},
 				},
 				{
@@ -84,6 +106,21 @@ func init() {
 							}),
 							Action: createAccount,
 						},
+						{
+							Name:  "quota",
+							Usage: "Set the quota of an account or namespace, requests over it are rejected with a 429",
+							Flags: append(quotaFlags,
+								&cli.Int64Flag{
+									Name:  "per_minute",
+									Usage: "Maximum number of requests per minute, unlimited if zero",
+								},
+								&cli.Int64Flag{
+									Name:  "per_day",
+									Usage: "Maximum number of requests per day, unlimited if zero",
+								},
+							),
+							Action: createQuota,
+						},
 					},
 				},
 				{
@@ -102,6 +139,12 @@ func init() {
 							Flags:  ruleFlags,
 							Action: deleteAccount,
 						},
+						{
+							Name:   "quota",
+							Usage:  "Delete the quota of an account or namespace",
+							Flags:  quotaFlags,
+							Action: deleteQuota,
+						},
 					},
 				},
 			},
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/micro/micro/v3/client/cli/namespace"
+	"github.com/micro/micro/v3/client/cli/util"
+	pb "github.com/micro/micro/v3/proto/auth"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// quotaNamespace returns the namespace flag or the namespace of the environment
+func quotaNamespace(ctx *cli.Context) (string, error) {
+	if ns := ctx.String("namespace"); len(ns) > 0 {
+		return ns, nil
+	}
+	env, err := util.GetEnv(ctx)
+	if err != nil {
+		return "", err
+	}
+	ns, err := namespace.Get(env.Name)
+	if err != nil {
+		return "", fmt.Errorf("Error getting namespace: %v", err)
+	}
+	return ns, nil
+}
+
+func listQuotas(ctx *cli.Context) error {
+	var ns string
+	if !ctx.Bool("all") {
+		var err error
+		if ns, err = quotaNamespace(ctx); err != nil {
+			return err
+		}
+	}
+
+	cli := pb.NewQuotasService("auth", client.DefaultClient)
+	rsp, err := cli.List(context.DefaultContext, &pb.ListQuotasRequest{Namespace: ns}, client.WithAuthToken())
+	if err != nil {
+		return fmt.Errorf("Error listing quotas: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	defer w.Flush()
+
+	limit := func(l int64) string {
+		if l == 0 {
+			return "<unlimited>"
+		}
+		return fmt.Sprintf("%d", l)
+	}
+
+	fmt.Fprintln(w, strings.Join([]string{"Namespace", "Account", "Per Minute", "Per Day"}, "\t\t"))
+	for _, q := range rsp.Quotas {
+		if q.Account == "" {
+			q.Account = "<namespace>"
+		}
+		fmt.Fprintln(w, strings.Join([]string{q.Namespace, q.Account, limit(q.PerMinute), limit(q.PerDay)}, "\t\t"))
+	}
+	return nil
+}
+
+func createQuota(ctx *cli.Context) error {
+	ns, err := quotaNamespace(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli := pb.NewQuotasService("auth", client.DefaultClient)
+	_, err = cli.Set(context.DefaultContext, &pb.SetQuotaRequest{
+		Quota: &pb.Quota{
+			Namespace: ns,
+			Account:   ctx.String("account"),
+			PerMinute: ctx.Int64("per_minute"),
+			PerDay:    ctx.Int64("per_day"),
+		},
+	}, client.WithAuthToken())
+	if verr := errors.FromError(err); verr != nil {
+		return fmt.Errorf("Error: %v", verr.Detail)
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Println("Quota set")
+	return nil
+}
+
+func deleteQuota(ctx *cli.Context) error {
+	ns, err := quotaNamespace(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli := pb.NewQuotasService("auth", client.DefaultClient)
+	_, err = cli.Delete(context.DefaultContext, &pb.DeleteQuotaRequest{
+		Namespace: ns, Account: ctx.String("account"),
+	}, client.WithAuthToken())
+	if verr := errors.FromError(err); verr != nil {
+		return fmt.Errorf("Error: %v", verr.Detail)
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Println("Quota deleted")
+	return nil
+}
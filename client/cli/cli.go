@@ -12,15 +12,22 @@ import (
 	"github.com/micro/micro/v3/cmd"
 	"github.com/urfave/cli/v2"
 
+	_ "github.com/micro/micro/v3/client/cli/artifact"
 	_ "github.com/micro/micro/v3/client/cli/auth"
 	_ "github.com/micro/micro/v3/client/cli/config"
+	_ "github.com/micro/micro/v3/client/cli/debug"
+	_ "github.com/micro/micro/v3/client/cli/gen"
 	_ "github.com/micro/micro/v3/client/cli/init"
+	_ "github.com/micro/micro/v3/client/cli/install"
+	_ "github.com/micro/micro/v3/client/cli/maintenance"
 	_ "github.com/micro/micro/v3/client/cli/network"
 	_ "github.com/micro/micro/v3/client/cli/new"
+	_ "github.com/micro/micro/v3/client/cli/report"
 	_ "github.com/micro/micro/v3/client/cli/run"
 	_ "github.com/micro/micro/v3/client/cli/signup"
 	_ "github.com/micro/micro/v3/client/cli/store"
 	_ "github.com/micro/micro/v3/client/cli/user"
+	_ "github.com/micro/micro/v3/client/cli/workflow"
 )
 
 var (
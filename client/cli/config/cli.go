@@ -154,6 +154,40 @@ func delConfig(ctx *cli.Context) error {
 	return util.CliError(err)
 }
 
+func watchConfig(ctx *cli.Context) error {
+	args := ctx.Args()
+
+	env, err := util.GetEnv(ctx)
+	if err != nil {
+		return err
+	}
+	ns, err := namespace.Get(env.Name)
+	if err != nil {
+		return err
+	}
+
+	pb := proto.NewConfigService("config", client.DefaultClient)
+	stream, err := pb.Watch(context.DefaultContext, &proto.WatchRequest{
+		// The current namespace
+		Namespace: ns,
+		// The key to watch, all of the config if blank
+		Path: args.Get(0),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+	defer stream.Close()
+
+	// print the value each time it's changed
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			return util.CliError(err)
+		}
+		fmt.Printf("%s %s %s\n", rsp.Type, rsp.Path, rsp.Value.Data)
+	}
+}
+
 func init() {
 	cmd.Register(
 		&cli.Command{
@@ -190,6 +224,11 @@ func init() {
 					Usage:  "Delete a value; micro config del key",
 					Action: delConfig,
 				},
+				{
+					Name:   "watch",
+					Usage:  "Watch a value for changes; micro config watch [key]",
+					Action: watchConfig,
+				},
 			},
 		},
 	)
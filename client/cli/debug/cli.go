@@ -0,0 +1,1000 @@
+// Package debug implements the `micro debug` subcommands e.g. micro debug profile
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/cmd"
+	"github.com/micro/micro/v3/internal/debug/profile/flamegraph"
+	"github.com/micro/micro/v3/internal/helper"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	cmd.Register(&cli.Command{
+		Name:   "debug",
+		Usage:  "Commands for debugging services",
+		Action: helper.UnexpectedSubcommand,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "profile",
+				Usage:     "Get a profile collected from a service",
+				UsageText: "micro debug profile [options] service",
+				Description: `Examples:
+			micro debug profile helloworld # get the latest cpu profile of helloworld
+			micro debug profile helloworld --type heap --at 1h # get the heap profile from an hour ago
+			micro debug profile helloworld --at 2020-11-01T09:00:00Z --flamegraph # export a flame graph
+			micro debug profile helloworld --list # list the profiles collected from helloworld`,
+				Action: getProfile,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Set the type of profile e.g. cpu, heap",
+						Value: "cpu",
+					},
+					&cli.StringFlag{
+						Name:  "at",
+						Usage: "Get the profile collected at a time, e.g. 2020-11-01T09:00:00Z, or a duration ago, e.g. 1h",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Set the file to write the profile to, defaults to [service].[type].pprof",
+					},
+					&cli.BoolFlag{
+						Name:  "flamegraph",
+						Usage: "Export the profile as folded stacks to render a flame graph from",
+					},
+					&cli.BoolFlag{
+						Name:  "list",
+						Usage: "List the profiles collected rather than getting one",
+					},
+				},
+			},
+			{
+				Name:      "dump",
+				Usage:     "Dump the goroutines or heap of a running service, requires admin access",
+				UsageText: "micro debug dump [options] service",
+				Description: `Examples:
+			micro debug dump helloworld # print the stacks of every goroutine of helloworld
+			micro debug dump helloworld --type heap # write a full heap dump of helloworld to helloworld.heap.dump
+			micro debug dump helloworld --address 10.0.0.1:8080 --output stacks.txt # dump a node of helloworld`,
+				Action: dump,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Set the type of dump, goroutine or heap",
+						Value: "goroutine",
+					},
+					&cli.StringFlag{
+						Name:  "address",
+						Usage: "Set the address of the node to dump, defaults to any node of the service",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Set the file to write the dump to, defaults to stdout for goroutine dumps and [service].heap.dump for heap dumps",
+					},
+				},
+			},
+			{
+				Name:      "metrics",
+				Usage:     "Get the metrics scraped from services",
+				UsageText: "micro debug metrics [options] [service]",
+				Description: `Examples:
+			micro debug metrics # get the latest metrics of every service
+			micro debug metrics helloworld --metric requests --since 10m # get the requests of helloworld over the last 10 minutes
+			micro debug metrics helloworld --metric memory --aggregate # get the memory of helloworld summed across its nodes`,
+				Action: getMetrics,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "metric",
+						Usage: "Get the values of a metric over time e.g. uptime, memory, threads, gc, requests, errors",
+					},
+					&cli.DurationFlag{
+						Name:  "since",
+						Usage: "Get the values since a duration ago",
+						Value: time.Hour,
+					},
+					&cli.BoolFlag{
+						Name:  "aggregate",
+						Usage: "Sum the values of the nodes of each service",
+					},
+				},
+			},
+			{
+				Name:      "endpoints",
+				Usage:     "Get the latency of the requests to the endpoints of services",
+				UsageText: "micro debug endpoints [options] [service]",
+				Description: `Examples:
+			micro debug endpoints # get the latency of the endpoints of every service over the last hour
+			micro debug endpoints helloworld --endpoint Helloworld.Call --since 10m # get the latency histogram of an endpoint`,
+				Action: getEndpoints,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "endpoint",
+						Usage: "Get the latency histogram of an endpoint e.g. Helloworld.Call",
+					},
+					&cli.DurationFlag{
+						Name:  "since",
+						Usage: "Count the requests since a duration ago",
+						Value: time.Hour,
+					},
+				},
+			},
+			{
+				Name:      "slo",
+				Usage:     "Get the status of the service level objectives",
+				UsageText: "micro debug slo [service]",
+				Description: `Objectives are set in config, keyed by name, with the service, the target percentage of requests which should be good
+and optionally the endpoint, the latency requests should be served within and the window the objective is measured over.
+
+Examples:
+			micro config set debug.slos.helloworld '{"service": "helloworld", "target": 99.9}'
+			micro config set debug.slos.helloworld-latency '{"service": "helloworld", "endpoint": "Helloworld.Call", "target": 99, "latency": "250ms", "window": "168h"}'
+			micro debug slo # get the status of the objectives`,
+				Action: getObjectives,
+			},
+			{
+				Name:      "logs",
+				Usage:     "Query the logs shipped by services",
+				UsageText: "micro debug logs [options] [query]",
+				Description: `Services ship their logs when started with --log_shipping broker or rpc. The query selects the records by
+their labels, e.g. service, version, node and level, and optionally filters them by their message.
+
+Examples:
+			micro debug logs '{service="helloworld"}' # get the logs of helloworld from the last hour
+			micro debug logs '{service="helloworld", level=~"warn|error"} |= "timeout"' --since 24h # get the warnings and errors about timeouts
+			micro debug logs '{service=~"hello.*"} != "health"' --limit 1000`,
+				Action: getLogs,
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "since",
+						Usage: "Get the records logged since a duration ago",
+						Value: time.Hour,
+					},
+					&cli.Int64Flag{
+						Name:  "limit",
+						Usage: "Limit the number of records, returning the latest",
+						Value: 100,
+					},
+				},
+			},
+			{
+				Name:      "labels",
+				Usage:     "List the labels of the logs shipped by services, or the values of a label",
+				UsageText: "micro debug labels [label]",
+				Action:    getLabels,
+			},
+			{
+				Name:      "probes",
+				Usage:     "Get the results of the probes run by the debug service",
+				UsageText: "micro debug probes [options] [name]",
+				Description: `Probes are set in config, keyed by name. RPC probes call an endpoint of a service with a request, optionally
+checking the response contains the fields expected. HTTP probes request a url, or a route of the api gateway if
+the url is a path, checking the status and optionally the body of the response.
+
+Examples:
+			micro config set debug.probes.helloworld '{"service": "helloworld", "endpoint": "Helloworld.Call", "request": {"name": "John"}, "response": {"msg": "Hello John"}}'
+			micro config set debug.probes.helloworld-api '{"url": "/helloworld/call?name=John", "contains": "Hello John", "interval": "30s"}'
+			micro debug probes # get the status of the probes at each location
+			micro debug probes helloworld --since 24h # get the results of the helloworld probe`,
+				Action: getProbes,
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "since",
+						Usage: "Get the results since a duration ago",
+						Value: time.Hour,
+					},
+					&cli.StringFlag{
+						Name:  "location",
+						Usage: "Get the results of probes run from a location",
+					},
+				},
+			},
+			{
+				Name:      "capture",
+				Usage:     "List the captured requests or get the spans of a captured request",
+				UsageText: "micro debug capture [options] [id]",
+				Description: `Requests are captured when the Micro-Debug header is true, every hop of the request then records its span with
+the timings and sizes of the request and response. The id of a capture is the trace id of the request.
+
+Examples:
+			micro call --metadata Micro-Debug=true helloworld Helloworld.Call '{"name": "John"}' # capture a request
+			curl -H 'Micro-Debug: true' http://localhost:8080/helloworld/call?name=John # capture a request via the api
+			micro debug capture # list the latest captures
+			micro debug capture 0b9a3c1e-... # get the spans of a capture
+			micro debug capture 0b9a3c1e-... --output capture.json # write the spans of a capture to a file`,
+				Action: getCapture,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "service",
+						Usage: "List the captures which passed through a service",
+					},
+					&cli.Int64Flag{
+						Name:  "limit",
+						Usage: "Limit the number of captures listed",
+						Value: 20,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Write the spans of the capture to a file as JSON",
+					},
+				},
+			},
+			{
+				Name:   "chaos",
+				Usage:  "Manage chaos experiments which inject faults into services for resilience testing",
+				Action: helper.UnexpectedSubcommand,
+				Description: `Faults are only injected into the services started with --chaos, or MICRO_CHAOS=true, so experiments can't affect
+other environments. Experiments expire automatically and every change is audited.`,
+				Subcommands: []*cli.Command{
+					{
+						Name:      "create",
+						Usage:     "Create an experiment",
+						UsageText: "micro debug chaos create [options] service",
+						Description: `Examples:
+			micro debug chaos create helloworld --type latency --latency 500ms --percentage 10 # delay 10% of requests by 500ms
+			micro debug chaos create helloworld --endpoint Helloworld.Call --type error --code 503 --percentage 50 --duration 1h
+			micro debug chaos create helloworld --type reset --percentage 5`,
+						Action: createExperiment,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "endpoint",
+								Usage: "Inject faults into an endpoint of the service, by default every endpoint",
+							},
+							&cli.StringFlag{
+								Name:  "type",
+								Usage: "Type of fault, latency, error or reset",
+								Value: "error",
+							},
+							&cli.Float64Flag{
+								Name:  "percentage",
+								Usage: "Percentage of requests to inject the fault into",
+								Value: 10,
+							},
+							&cli.StringFlag{
+								Name:  "latency",
+								Usage: "Latency to add to requests e.g. 500ms, for latency experiments",
+							},
+							&cli.IntFlag{
+								Name:  "code",
+								Usage: "Code of the error to return, for error experiments",
+								Value: 500,
+							},
+							&cli.DurationFlag{
+								Name:  "duration",
+								Usage: "How long the experiment runs for",
+								Value: time.Minute * 10,
+							},
+						},
+					},
+					{
+						Name:   "list",
+						Usage:  "List the experiments which are running",
+						Action: listExperiments,
+					},
+					{
+						Name:      "delete",
+						Usage:     "Delete an experiment, stopping it before it expires",
+						UsageText: "micro debug chaos delete id",
+						Action:    deleteExperiment,
+					},
+					{
+						Name:   "audit",
+						Usage:  "Get the audit of the experiments created, deleted and expired",
+						Action: auditExperiments,
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:  "limit",
+								Usage: "Limit the number of entries, returning the latest",
+								Value: 100,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:      "sampling",
+				Usage:     "Get or set the sampling of the traces exported by services",
+				UsageText: "micro debug sampling [options]",
+				Description: `The sampling is published to the services so it's changed without restarting them. Until it's set services
+sample as configured by the --tracing_sample_rate and --tracing_sample_errors flags.
+
+Examples:
+			micro debug sampling # get the sampling
+			micro debug sampling --rate 0.01 --errors # export 1% of traces and every trace with an error
+			micro debug sampling --service helloworld=1 # export every trace of helloworld
+			micro debug sampling --remove helloworld # sample helloworld at the global rate`,
+				Action: sampling,
+				Flags: []cli.Flag{
+					&cli.Float64Flag{
+						Name:  "rate",
+						Usage: "Set the ratio of traces exported, between 0 and 1",
+					},
+					&cli.StringSliceFlag{
+						Name:  "service",
+						Usage: "Set the rate of a service which overrides the rate e.g. helloworld=0.5",
+					},
+					&cli.StringSliceFlag{
+						Name:  "remove",
+						Usage: "Remove the rate of a service",
+					},
+					&cli.BoolFlag{
+						Name:  "errors",
+						Usage: "Export the traces with errors regardless of the rate, --errors=false to disable",
+					},
+				},
+			},
+			{
+				Name:      "timeline",
+				Usage:     "Get the deploys, route changes, config changes and alerts recorded by the debug service",
+				UsageText: "micro debug timeline [options] [service]",
+				Description: `Examples:
+			micro debug timeline # get the changes of the last hour
+			micro debug timeline --at 14:32 # get the changes within 15 minutes of 14:32 today
+			micro debug timeline helloworld --at 2020-11-01T14:32:00Z --window 1h --source runtime --source config`,
+				Action: getTimeline,
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "since",
+						Usage: "Get the changes since a duration ago",
+						Value: time.Hour,
+					},
+					&cli.StringFlag{
+						Name:  "at",
+						Usage: "Get the changes around a time, e.g. 14:32 or 2020-11-01T14:32:00Z, rather than since a duration ago",
+					},
+					&cli.DurationFlag{
+						Name:  "window",
+						Usage: "Set how long before and after the time the changes are got from",
+						Value: time.Minute * 15,
+					},
+					&cli.StringSliceFlag{
+						Name:  "source",
+						Usage: "Get the changes from a source e.g. runtime, router, config, alert",
+					},
+					&cli.Int64Flag{
+						Name:  "limit",
+						Usage: "Limit the number of changes, returning the latest",
+						Value: 500,
+					},
+				},
+			},
+		},
+	})
+}
+
+func getProfile(ctx *cli.Context) error {
+	if ctx.Args().Len() == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	service := ctx.Args().First()
+	profiles := pb.NewProfilesService("debug", client.DefaultClient)
+
+	if ctx.Bool("list") {
+		typ := ""
+		if ctx.IsSet("type") {
+			typ = ctx.String("type")
+		}
+		rsp, err := profiles.List(context.DefaultContext, &pb.ListProfilesRequest{
+			Service: service,
+			Type:    typ,
+		}, client.WithAuthToken())
+		if err != nil {
+			return util.CliError(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+		fmt.Fprintln(w, "TYPE\tCOLLECTED\tVERSION\tNODE\tSIZE")
+		for _, p := range rsp.Profiles {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", p.Type, time.Unix(p.Timestamp, 0).Format(time.RFC3339), p.Version, p.Node, p.Size)
+		}
+		return w.Flush()
+	}
+
+	at, err := parseTime(ctx.String("at"))
+	if err != nil {
+		return err
+	}
+
+	rsp, err := profiles.Read(context.DefaultContext, &pb.ReadProfileRequest{
+		Service: service,
+		Type:    ctx.String("type"),
+		At:      at,
+	}, client.WithAuthToken(), client.WithRequestTimeout(time.Minute))
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	data := rsp.Data
+	output := ctx.String("output")
+	if ctx.Bool("flamegraph") {
+		if data, err = flamegraph.Fold(data); err != nil {
+			return err
+		}
+		if len(output) == 0 {
+			output = fmt.Sprintf("%v.%v.folded", service, ctx.String("type"))
+		}
+	}
+	if len(output) == 0 {
+		output = fmt.Sprintf("%v.%v.pprof", service, ctx.String("type"))
+	}
+
+	if err := ioutil.WriteFile(output, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote the %v profile of %v collected at %v to %v\n",
+		rsp.Info.Type, service, time.Unix(rsp.Info.Timestamp, 0).Format(time.RFC3339), output)
+	return nil
+}
+
+func dump(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	service := ctx.Args().First()
+	typ := ctx.String("type")
+
+	opts := []client.CallOption{client.WithAuthToken()}
+	if addr := ctx.String("address"); len(addr) > 0 {
+		opts = append(opts, client.WithAddress(addr))
+	}
+	// dumps can be large, so don't time out while they're streamed
+	opts = append(opts, client.WithRequestTimeout(time.Minute*10))
+
+	stream, err := pb.NewDebugService(service, client.DefaultClient).Dump(context.DefaultContext, &pb.DumpRequest{
+		Type: typ,
+	}, opts...)
+	if err != nil {
+		return util.CliError(err)
+	}
+	defer stream.Close()
+
+	out := os.Stdout
+	output := ctx.String("output")
+	if len(output) == 0 && typ == "heap" {
+		output = service + ".heap.dump"
+	}
+	if len(output) > 0 {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return util.CliError(err)
+		}
+		if _, err := out.Write(rsp.Data); err != nil {
+			return err
+		}
+	}
+
+	if len(output) > 0 {
+		fmt.Printf("Wrote %v dump of %v to %v\n", typ, service, output)
+	}
+	return nil
+}
+
+// parseTime parses a time or a duration ago, returning it as a unix timestamp. A blank value
+// is zero, i.e. the latest.
+func parseTime(v string) (int64, error) {
+	if len(v) == 0 {
+		return 0, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.Unix(), nil
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return time.Now().Add(-d).Unix(), nil
+	}
+	return 0, fmt.Errorf("Invalid time %v, expected e.g. 2020-11-01T09:00:00Z or 1h", v)
+}
+
+func getMetrics(ctx *cli.Context) error {
+	metrics := pb.NewMetricsService("debug", client.DefaultClient)
+	rsp, err := metrics.Query(context.DefaultContext, &pb.QueryMetricsRequest{
+		Service:   ctx.Args().First(),
+		Metric:    ctx.String("metric"),
+		Start:     time.Now().Add(-ctx.Duration("since")).Unix(),
+		Aggregate: ctx.Bool("aggregate"),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+
+	// print the values of the metric over time
+	if ctx.IsSet("metric") {
+		fmt.Fprintln(w, "SERVICE\tNODE\tTIME\tVALUE")
+		for _, s := range rsp.Series {
+			for _, p := range s.Points {
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", s.Service, s.Node, time.Unix(p.Timestamp, 0).Format(time.RFC3339), p.Value)
+			}
+		}
+		return w.Flush()
+	}
+
+	// otherwise the latest value of each metric of the nodes
+	names := []string{"uptime", "memory", "threads", "gc", "requests", "errors"}
+	type node struct{ service, version, id string }
+	var nodes []node
+	latest := make(map[node]map[string]float64)
+	for _, s := range rsp.Series {
+		if len(s.Points) == 0 {
+			continue
+		}
+		n := node{s.Service, s.Version, s.Node}
+		if _, ok := latest[n]; !ok {
+			latest[n] = make(map[string]float64)
+			nodes = append(nodes, n)
+		}
+		latest[n][s.Metric] = s.Points[len(s.Points)-1].Value
+	}
+
+	fmt.Fprintln(w, "SERVICE\tVERSION\tNODE\tUPTIME\tMEMORY\tTHREADS\tGC\tREQUESTS\tERRORS")
+	for _, n := range nodes {
+		fmt.Fprintf(w, "%v\t%v\t%v", n.service, n.version, n.id)
+		for _, name := range names {
+			fmt.Fprintf(w, "\t%v", formatMetric(name, latest[n][name]))
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
+
+// formatMetric formats the value of a metric for display
+func formatMetric(name string, v float64) string {
+	switch name {
+	case "uptime":
+		return (time.Duration(v) * time.Second).String()
+	case "memory":
+		return fmt.Sprintf("%.1fMB", v/1024/1024)
+	case "gc":
+		return fmt.Sprintf("%.3fs", v)
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
+
+func getEndpoints(ctx *cli.Context) error {
+	metrics := pb.NewMetricsService("debug", client.DefaultClient)
+	rsp, err := metrics.Endpoints(context.DefaultContext, &pb.EndpointsRequest{
+		Service:  ctx.Args().First(),
+		Endpoint: ctx.String("endpoint"),
+		Start:    time.Now().Add(-ctx.Duration("since")).Unix(),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "SERVICE\tENDPOINT\tREQUESTS\tERRORS\tP50\tP90\tP99")
+	for _, e := range rsp.Endpoints {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", e.Service, e.Endpoint, e.Requests, e.Errors,
+			formatLatency(e.P50), formatLatency(e.P90), formatLatency(e.P99))
+	}
+	if err := w.Flush(); err != nil || !ctx.IsSet("endpoint") {
+		return err
+	}
+
+	// print the histogram of the endpoint, the requests within each bucket being cumulative
+	for _, e := range rsp.Endpoints {
+		fmt.Printf("\n%v %v\n", e.Service, e.Endpoint)
+		var prev uint64
+		for i, b := range e.Buckets {
+			if i >= len(e.Latency) {
+				break
+			}
+			count := e.Latency[i] - prev
+			prev = e.Latency[i]
+			fmt.Fprintf(w, "<%v\t%v\t%v\n", time.Duration(b*float64(time.Second)), count, histogramBar(count, e.Requests))
+		}
+		fmt.Fprintf(w, ">max\t%v\t%v\n", e.Requests-prev, histogramBar(e.Requests-prev, e.Requests))
+		w.Flush()
+	}
+	return nil
+}
+
+// histogramBar returns a bar the length of the proportion of the total
+func histogramBar(count, total uint64) string {
+	if total == 0 {
+		return ""
+	}
+	return strings.Repeat("#", int(count*50/total))
+}
+
+func getObjectives(ctx *cli.Context) error {
+	metrics := pb.NewMetricsService("debug", client.DefaultClient)
+	rsp, err := metrics.Objectives(context.DefaultContext, &pb.ObjectivesRequest{
+		Service: ctx.Args().First(),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "NAME\tSERVICE\tENDPOINT\tOBJECTIVE\tWINDOW\tREQUESTS\tCOMPLIANCE\tBUDGET\tBURN RATE")
+	for _, o := range rsp.Objectives {
+		endpoint := o.Endpoint
+		if len(endpoint) == 0 {
+			endpoint = "*"
+		}
+		objective := fmt.Sprintf("%v%% available", o.Target)
+		if len(o.Latency) > 0 {
+			objective = fmt.Sprintf("%v%% within %v", o.Target, o.Latency)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%.3f%%\t%.1f%%\t%.1fx\n", o.Name, o.Service, endpoint,
+			objective, o.Window, o.Total, o.Compliance, o.BudgetRemaining, o.BurnRate)
+	}
+	return w.Flush()
+}
+
+func getLogs(ctx *cli.Context) error {
+	logs := pb.NewLogsService("debug", client.DefaultClient)
+	rsp, err := logs.Query(context.DefaultContext, &pb.QueryLogsRequest{
+		Query: ctx.Args().First(),
+		Start: time.Now().Add(-ctx.Duration("since")).Unix(),
+		Limit: ctx.Int64("limit"),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	for _, r := range rsp.Records {
+		fmt.Printf("%v %v %v %v\n", time.Unix(0, r.Timestamp).Format(time.RFC3339),
+			r.Labels["service"], r.Labels["level"], r.Message)
+	}
+	return nil
+}
+
+func getLabels(ctx *cli.Context) error {
+	logs := pb.NewLogsService("debug", client.DefaultClient)
+	rsp, err := logs.Labels(context.DefaultContext, &pb.LabelsRequest{
+		Name: ctx.Args().First(),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	for _, v := range rsp.Values {
+		fmt.Println(v)
+	}
+	return nil
+}
+
+func getProbes(ctx *cli.Context) error {
+	probes := pb.NewProbesService("debug", client.DefaultClient)
+	rsp, err := probes.Results(context.DefaultContext, &pb.ProbeResultsRequest{
+		Name:     ctx.Args().First(),
+		Location: ctx.String("location"),
+		Start:    time.Now().Add(-ctx.Duration("since")).Unix(),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+
+	// print each result of the probe
+	if ctx.Args().Len() > 0 {
+		fmt.Fprintln(w, "TIME\tLOCATION\tSUCCESS\tLATENCY\tERROR")
+		for _, r := range rsp.Results {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", time.Unix(r.Timestamp, 0).Format(time.RFC3339), r.Location,
+				r.Success, time.Duration(r.Latency).Round(time.Millisecond), r.Error)
+		}
+		return w.Flush()
+	}
+
+	fmt.Fprintln(w, "NAME\tTYPE\tTARGET\tLOCATION\tRUNS\tSUCCESS RATE\tLATENCY\tLAST RUN\tLAST ERROR")
+	for _, p := range rsp.Probes {
+		rate := 100 * float64(p.Total-p.Failures) / float64(p.Total)
+		last, lastErr := "", ""
+		if p.Last != nil {
+			last = time.Unix(p.Last.Timestamp, 0).Format(time.RFC3339)
+			lastErr = p.Last.Error
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%.1f%%\t%v\t%v\t%v\n", p.Name, p.Type, p.Target, p.Location,
+			p.Total, rate, time.Duration(p.Latency).Round(time.Millisecond), last, lastErr)
+	}
+	return w.Flush()
+}
+
+func sampling(ctx *cli.Context) error {
+	tracing := pb.NewTracingService("debug", client.DefaultClient)
+	rsp, err := tracing.Sampling(context.DefaultContext, &pb.SamplingRequest{}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+	s := rsp.Sampling
+
+	// update the sampling if any of the flags are set
+	if ctx.NumFlags() > 0 {
+		if ctx.IsSet("rate") {
+			s.Rate = ctx.Float64("rate")
+		}
+		if ctx.IsSet("errors") {
+			s.SampleErrors = ctx.Bool("errors")
+		}
+		if s.Services == nil {
+			s.Services = make(map[string]float64)
+		}
+		for _, v := range ctx.StringSlice("service") {
+			parts := strings.SplitN(v, "=", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("Invalid service rate %v, expected e.g. helloworld=0.5", v)
+			}
+			rate, err := strconv.ParseFloat(parts[1], 64)
+			if err != nil {
+				return fmt.Errorf("Invalid service rate %v, expected e.g. helloworld=0.5", v)
+			}
+			s.Services[parts[0]] = rate
+		}
+		for _, name := range ctx.StringSlice("remove") {
+			delete(s.Services, name)
+		}
+
+		_, err := tracing.SetSampling(context.DefaultContext, &pb.SetSamplingRequest{Sampling: s}, client.WithAuthToken())
+		if err != nil {
+			return util.CliError(err)
+		}
+	}
+
+	if s.Updated == 0 {
+		fmt.Println("The sampling hasn't been set, services sample as configured when they were started")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "SERVICE\tRATE")
+	fmt.Fprintf(w, "*\t%v\n", s.Rate)
+	names := make([]string, 0, len(s.Services))
+	for name := range s.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "%v\t%v\n", name, s.Services[name])
+	}
+	w.Flush()
+	fmt.Printf("\nTraces with errors are sampled: %v\n", s.SampleErrors)
+	return nil
+}
+
+func getCapture(ctx *cli.Context) error {
+	captures := pb.NewCapturesService("debug", client.DefaultClient)
+
+	// list the captures if no id is given
+	if ctx.Args().Len() == 0 {
+		rsp, err := captures.List(context.DefaultContext, &pb.ListCapturesRequest{
+			Service: ctx.String("service"),
+			Limit:   ctx.Int64("limit"),
+		}, client.WithAuthToken())
+		if err != nil {
+			return util.CliError(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSTARTED\tDURATION\tSPANS\tERRORS\tSERVICES")
+		for _, c := range rsp.Captures {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", c.Id, c.Name, time.Unix(0, c.Started).Format(time.RFC3339),
+				time.Duration(c.Duration), c.Spans, c.Errors, strings.Join(c.Services, ","))
+		}
+		return w.Flush()
+	}
+
+	rsp, err := captures.Read(context.DefaultContext, &pb.ReadCaptureRequest{
+		Id: ctx.Args().First(),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	if output := ctx.String("output"); len(output) > 0 {
+		b, err := json.MarshalIndent(rsp.Spans, "", "\t")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(output, b, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote the %v spans of the capture to %v\n", len(rsp.Spans), output)
+		return nil
+	}
+
+	// print the spans as a tree, each indented under its parent
+	ids := make(map[string]bool, len(rsp.Spans))
+	children := make(map[string][]*pb.CaptureSpan)
+	for _, s := range rsp.Spans {
+		ids[s.Id] = true
+	}
+	var roots []*pb.CaptureSpan
+	for _, s := range rsp.Spans {
+		if ids[s.Parent] {
+			children[s.Parent] = append(children[s.Parent], s)
+		} else {
+			roots = append(roots, s)
+		}
+	}
+
+	start := rsp.Spans[0].Started
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "SPAN\tSERVICE\tNODE\tOFFSET\tDURATION\tREQUEST\tRESPONSE\tERROR")
+	var printSpan func(s *pb.CaptureSpan, depth int)
+	printSpan = func(s *pb.CaptureSpan, depth int) {
+		arrow := "<-"
+		if s.Type == "outbound" {
+			arrow = "->"
+		}
+		fmt.Fprintf(w, "%v%v %v\t%v\t%v\t%v\t%v\t%vB\t%vB\t%v\n", strings.Repeat("  ", depth), arrow, s.Name,
+			s.Service, s.Node, time.Duration(s.Started-start), time.Duration(s.Duration), s.RequestSize, s.ResponseSize, s.Error)
+		for _, c := range children[s.Id] {
+			printSpan(c, depth+1)
+		}
+	}
+	for _, s := range roots {
+		printSpan(s, 0)
+	}
+	return w.Flush()
+}
+
+func createExperiment(ctx *cli.Context) error {
+	if ctx.Args().Len() == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	exp := &pb.ChaosExperiment{
+		Service:    ctx.Args().First(),
+		Endpoint:   ctx.String("endpoint"),
+		Type:       ctx.String("type"),
+		Percentage: ctx.Float64("percentage"),
+		Latency:    ctx.String("latency"),
+		Duration:   ctx.Duration("duration").String(),
+	}
+	if exp.Type == "error" {
+		exp.ErrorCode = int32(ctx.Int("code"))
+	}
+
+	chaos := pb.NewChaosService("debug", client.DefaultClient)
+	rsp, err := chaos.Create(context.DefaultContext, &pb.CreateExperimentRequest{Experiment: exp}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+	fmt.Printf("Created experiment %v, which expires at %v\n", rsp.Experiment.Id,
+		time.Unix(rsp.Experiment.Expires, 0).Format(time.RFC3339))
+	return nil
+}
+
+// formatExperiment describes the fault an experiment injects
+func formatExperiment(exp *pb.ChaosExperiment) string {
+	switch exp.Type {
+	case "latency":
+		return fmt.Sprintf("%v latency into %v%% of requests", exp.Latency, exp.Percentage)
+	case "error":
+		return fmt.Sprintf("%v errors into %v%% of requests", exp.ErrorCode, exp.Percentage)
+	default:
+		return fmt.Sprintf("%v into %v%% of requests", exp.Type, exp.Percentage)
+	}
+}
+
+func listExperiments(ctx *cli.Context) error {
+	chaos := pb.NewChaosService("debug", client.DefaultClient)
+	rsp, err := chaos.List(context.DefaultContext, &pb.ListExperimentsRequest{}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "ID\tSERVICE\tENDPOINT\tFAULT\tCREATED BY\tEXPIRES")
+	for _, exp := range rsp.Experiments {
+		endpoint := exp.Endpoint
+		if len(endpoint) == 0 {
+			endpoint = "*"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", exp.Id, exp.Service, endpoint, formatExperiment(exp),
+			exp.CreatedBy, time.Unix(exp.Expires, 0).Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+func deleteExperiment(ctx *cli.Context) error {
+	if ctx.Args().Len() == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	chaos := pb.NewChaosService("debug", client.DefaultClient)
+	_, err := chaos.Delete(context.DefaultContext, &pb.DeleteExperimentRequest{
+		Id: ctx.Args().First(),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+	return nil
+}
+
+func auditExperiments(ctx *cli.Context) error {
+	chaos := pb.NewChaosService("debug", client.DefaultClient)
+	rsp, err := chaos.Audit(context.DefaultContext, &pb.ChaosAuditRequest{
+		Limit: ctx.Int64("limit"),
+	}, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "TIME\tACTION\tACCOUNT\tID\tSERVICE\tFAULT")
+	for _, e := range rsp.Entries {
+		exp := e.Experiment
+		if exp == nil {
+			exp = new(pb.ChaosExperiment)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", time.Unix(e.Timestamp, 0).Format(time.RFC3339), e.Action,
+			e.Account, exp.Id, exp.Service, formatExperiment(exp))
+	}
+	return w.Flush()
+}
+
+func getTimeline(ctx *cli.Context) error {
+	req := &pb.ReadTimelineRequest{
+		Start:   time.Now().Add(-ctx.Duration("since")).Unix(),
+		Sources: ctx.StringSlice("source"),
+		Service: ctx.Args().First(),
+		Limit:   ctx.Int64("limit"),
+	}
+	if at := ctx.String("at"); len(at) > 0 {
+		t, err := parseClock(at)
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		req.Start = t.Add(-ctx.Duration("window")).Unix()
+		req.End = t.Add(ctx.Duration("window")).Unix()
+	}
+
+	timeline := pb.NewTimelineService("debug", client.DefaultClient)
+	rsp, err := timeline.Read(context.DefaultContext, req, client.WithAuthToken())
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "TIME\tSOURCE\tTYPE\tSERVICE\tDESCRIPTION")
+	for _, e := range rsp.Events {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", time.Unix(0, e.Timestamp).Format("2006-01-02 15:04:05"), e.Source,
+			e.Type, e.Service, e.Description)
+	}
+	return w.Flush()
+}
+
+// parseClock parses a time, either as RFC3339 or a time of day today e.g. 14:32
+func parseClock(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	now := time.Now()
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.ParseInLocation(layout, v, now.Location()); err == nil {
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid time %v, expected e.g. 14:32 or 2020-11-01T14:32:00Z", v)
+}
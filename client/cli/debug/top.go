@@ -0,0 +1,187 @@
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/cmd"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+func init() {
+	cmd.Register(&cli.Command{
+		Name:      "top",
+		Usage:     "Show a live view of the stats of services",
+		UsageText: "micro top [options] [service]",
+		Description: `The stats are those scraped by the debug service, so they change at its scrape interval.
+			Press the number of a column to sort by it, or q to quit.
+
+			Examples:
+			micro top # show the stats of every service
+			micro top --sort memory # show the services using the most memory first`,
+		Action: top,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "sort",
+				Usage: "Set the column to sort by e.g. service, nodes, requests, errors, p99, memory, threads",
+				Value: "requests",
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Usage: "Set how often the view is refreshed",
+				Value: time.Second * 5,
+			},
+		},
+	})
+}
+
+// topColumn is a column of the view, the services are sorted by it in descending order other than
+// by name
+type topColumn struct {
+	name   string
+	format func(*pb.ServiceStats) string
+	less   func(a, b *pb.ServiceStats) bool
+}
+
+var topColumns = []topColumn{
+	{"service", func(s *pb.ServiceStats) string { return s.Service },
+		func(a, b *pb.ServiceStats) bool { return a.Service < b.Service }},
+	{"nodes", func(s *pb.ServiceStats) string { return fmt.Sprint(s.Nodes) },
+		func(a, b *pb.ServiceStats) bool { return a.Nodes > b.Nodes }},
+	{"requests", func(s *pb.ServiceStats) string { return fmt.Sprintf("%.1f/s", s.Requests) },
+		func(a, b *pb.ServiceStats) bool { return a.Requests > b.Requests }},
+	{"errors", func(s *pb.ServiceStats) string { return fmt.Sprintf("%.2f%%", s.ErrorRate) },
+		func(a, b *pb.ServiceStats) bool { return a.ErrorRate > b.ErrorRate }},
+	{"p99", func(s *pb.ServiceStats) string { return formatLatency(s.P99) },
+		func(a, b *pb.ServiceStats) bool { return p99Order(a.P99) > p99Order(b.P99) }},
+	{"memory", func(s *pb.ServiceStats) string { return formatMetric("memory", float64(s.Memory)) },
+		func(a, b *pb.ServiceStats) bool { return a.Memory > b.Memory }},
+	{"threads", func(s *pb.ServiceStats) string { return fmt.Sprint(s.Threads) },
+		func(a, b *pb.ServiceStats) bool { return a.Threads > b.Threads }},
+}
+
+// formatLatency formats the upper bound in seconds of the latency bucket a percentile of requests
+// were served within for display
+func formatLatency(v float64) string {
+	switch {
+	case v < 0:
+		return ">max"
+	case v == 0:
+		return "-"
+	default:
+		return "<" + time.Duration(v*float64(time.Second)).String()
+	}
+}
+
+// p99Order returns the value latencies are sorted by, those over the largest bucket being slowest
+func p99Order(v float64) float64 {
+	if v < 0 {
+		return float64(time.Hour)
+	}
+	return v
+}
+
+func top(ctx *cli.Context) error {
+	column := -1
+	for i, c := range topColumns {
+		if c.name == ctx.String("sort") {
+			column = i
+		}
+	}
+	if column < 0 {
+		return cli.Exit(fmt.Sprintf("Invalid sort column %v", ctx.String("sort")), 1)
+	}
+
+	// read the keys pressed if the view is shown in a terminal
+	keys := make(chan byte)
+	fd := int(syscall.Stdin)
+	if terminal.IsTerminal(fd) {
+		state, err := terminal.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer terminal.Restore(fd, state)
+
+		go func() {
+			b := make([]byte, 1)
+			for {
+				if _, err := os.Stdin.Read(b); err != nil {
+					close(keys)
+					return
+				}
+				keys <- b[0]
+			}
+		}()
+	}
+
+	metrics := pb.NewMetricsService("debug", client.DefaultClient)
+	ticker := time.NewTicker(ctx.Duration("interval"))
+	defer ticker.Stop()
+
+	var rsp *pb.TopResponse
+	for {
+		if rsp == nil {
+			var err error
+			rsp, err = metrics.Top(context.DefaultContext, &pb.TopRequest{
+				Service: ctx.Args().First(),
+			}, client.WithAuthToken())
+			if err != nil {
+				return util.CliError(err)
+			}
+		}
+		renderTop(rsp, column)
+
+		select {
+		case <-ticker.C:
+			rsp = nil
+		case k, ok := <-keys:
+			switch {
+			// q, ctrl+c or ctrl+d
+			case !ok || k == 'q' || k == 3 || k == 4:
+				return nil
+			case k >= '1' && int(k-'1') < len(topColumns):
+				column = int(k - '1')
+			}
+		}
+	}
+}
+
+// renderTop clears the screen and shows the stats sorted by the column
+func renderTop(rsp *pb.TopResponse, column int) {
+	services := rsp.Services
+	sort.SliceStable(services, func(i, j int) bool {
+		return topColumns[column].less(services[i], services[j])
+	})
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "Services at %v, sorted by %v\n\n", time.Unix(rsp.Timestamp, 0).Format(time.RFC3339),
+		topColumns[column].name)
+
+	w := tabwriter.NewWriter(buf, 0, 8, 1, '\t', 0)
+	for i, c := range topColumns {
+		fmt.Fprintf(w, "%d %v\t", i+1, strings.ToUpper(c.name))
+	}
+	fmt.Fprintln(w)
+	for _, s := range services {
+		for _, c := range topColumns {
+			fmt.Fprintf(w, "%v\t", c.format(s))
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+
+	// the terminal is in raw mode so lines must be returned to the start too
+	out := strings.Replace(buf.String(), "\n", "\r\n", -1)
+	fmt.Print("\033[H\033[2J" + out)
+}
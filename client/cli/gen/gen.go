@@ -0,0 +1,266 @@
+// Package gen provides the micro gen command which generates typed clients of services
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/micro/micro/v3/client/cli/namespace"
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/cmd"
+	"github.com/micro/micro/v3/internal/helper"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func init() {
+	cmd.Register(&cli.Command{
+		Name:   "gen",
+		Usage:  "Generate code for services",
+		Action: helper.UnexpectedSubcommand,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "client",
+				Usage:     "Generate a typed client of a service which calls it through the api gateway",
+				UsageText: "micro gen client [options] service",
+				Description: `The client is generated from the endpoints the service registered or the services of a
+	protobuf descriptor set created with protoc --include_imports -o service.pb service.proto.
+	Go, typescript and python clients only depend on the standard library of the language.
+
+	micro gen client --lang ts helloworld`,
+				Action: Client,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "lang",
+						Usage: "Language of the client: go, ts or python",
+						Value: "go",
+					},
+					&cli.StringFlag{
+						Name:  "descriptor",
+						Usage: "Generate from the services of a protobuf descriptor set instead of the registry",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Directory the client is written to",
+						Value:   ".",
+					},
+					&cli.StringFlag{
+						Name:  "package",
+						Usage: "Package of the go client, defaults to the service name",
+					},
+				},
+			},
+		},
+	})
+}
+
+// languages of the clients and their file extensions
+var languages = map[string]string{
+	"go":     ".go",
+	"ts":     ".ts",
+	"python": ".py",
+}
+
+// Client generates the client of the service
+func Client(ctx *cli.Context) error {
+	service := ctx.Args().First()
+	if len(service) == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	lang := ctx.String("lang")
+	ext, ok := languages[lang]
+	if !ok {
+		return fmt.Errorf("Unsupported language %v, expected go, ts or python", lang)
+	}
+
+	var s *schema
+	if path := ctx.String("descriptor"); len(path) > 0 {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		set := &descriptorpb.FileDescriptorSet{}
+		if err := proto.Unmarshal(b, set); err != nil {
+			return fmt.Errorf("Error parsing the descriptor set %v: %v", path, err)
+		}
+		s = fromDescriptors(service, set)
+	} else {
+		env, err := util.GetEnv(ctx)
+		if err != nil {
+			return err
+		}
+		ns, err := namespace.Get(env.Name)
+		if err != nil {
+			return err
+		}
+		srvs, err := registry.DefaultRegistry.GetService(service, registry.GetDomain(ns))
+		if err == registry.ErrNotFound {
+			return fmt.Errorf("Service %v not found", service)
+		} else if err != nil {
+			return err
+		}
+		s = fromRegistry(service, srvs[0].Endpoints)
+	}
+	if len(s.Methods) == 0 {
+		return fmt.Errorf("Service %v has no endpoints the gateway can call", service)
+	}
+
+	pkg := ctx.String("package")
+	if len(pkg) == 0 {
+		pkg = strings.ToLower(s.Name)
+	}
+	b, err := generate(lang, pkg, s)
+	if err != nil {
+		return err
+	}
+
+	dir := ctx.String("output")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	file := filepath.Join(dir, strings.ToLower(s.Name)+ext)
+	if err := ioutil.WriteFile(file, b, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Generated the %v client of %v in %v\n", lang, service, file)
+	return nil
+}
+
+// generate the source of the client in the language
+func generate(lang, pkg string, s *schema) ([]byte, error) {
+	tmpls := map[string]string{"go": goTemplate, "ts": tsTemplate, "python": pyTemplate}
+
+	t, err := template.New(lang).Funcs(funcs).Parse(tmpls[lang])
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	data := struct {
+		*schema
+		Package string
+	}{s, pkg}
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	if lang != "go" {
+		return buf.Bytes(), nil
+	}
+	return format.Source(buf.Bytes())
+}
+
+var funcs = template.FuncMap{
+	"title": func(s string) string {
+		return strings.Title(s)
+	},
+	"lowerFirst": func(s string) string {
+		r := []rune(s)
+		r[0] = unicode.ToLower(r[0])
+		return string(r)
+	},
+	"snake": func(s string) string {
+		var b strings.Builder
+		for i, r := range s {
+			if unicode.IsUpper(r) && i > 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		}
+		return b.String()
+	},
+	// 64 bit integers are encoded as strings by the gateway
+	"goString": func(f *field) bool {
+		return !f.Repeated && (f.Type == "int64" || f.Type == "uint64")
+	},
+	"goType": func(f *field) string {
+		var t string
+		switch f.Type {
+		case "message":
+			t = "*" + f.Message
+		case "any":
+			t = "interface{}"
+		case "bytes":
+			t = "[]byte"
+		case "int64", "uint64":
+			if f.Repeated {
+				t = "json.Number"
+			} else {
+				t = f.Type
+			}
+		default:
+			t = f.Type
+		}
+		if f.Repeated {
+			return "[]" + t
+		}
+		return t
+	},
+	"goMessage": func(name string) string {
+		if len(name) == 0 {
+			return "map[string]interface{}"
+		}
+		return "*" + name
+	},
+	"tsType": func(f *field) string {
+		var t string
+		switch f.Type {
+		case "message":
+			t = f.Message
+		case "any":
+			t = "any"
+		case "bool":
+			t = "boolean"
+		case "string", "bytes", "int64", "uint64":
+			t = "string"
+		default:
+			t = "number"
+		}
+		if f.Repeated {
+			return t + "[]"
+		}
+		return t
+	},
+	"tsMessage": func(name string) string {
+		if len(name) == 0 {
+			return "Record<string, any>"
+		}
+		return name
+	},
+	"pyType": func(f *field) string {
+		var t string
+		switch f.Type {
+		case "message":
+			t = f.Message
+		case "any":
+			t = "Any"
+		case "bool":
+			t = "bool"
+		case "string", "bytes", "int64", "uint64":
+			t = "str"
+		case "float32", "float64":
+			t = "float"
+		default:
+			t = "int"
+		}
+		if f.Repeated {
+			return "List[" + t + "]"
+		}
+		return t
+	},
+	"pyMessage": func(name string) string {
+		if len(name) == 0 {
+			return "Dict[str, Any]"
+		}
+		return name
+	},
+}
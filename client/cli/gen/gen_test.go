@@ -0,0 +1,149 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/micro/micro/v3/service/registry"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// testEndpoints are registered like the handler of micro new
+var testEndpoints = []*registry.Endpoint{
+	{
+		Name: "Helloworld.Call",
+		Request: &registry.Value{Name: "Request", Type: "Request", Values: []*registry.Value{
+			{Name: "name", Type: "string"},
+		}},
+		Response: &registry.Value{Name: "Response", Type: "Response", Values: []*registry.Value{
+			{Name: "msg", Type: "string"},
+			{Name: "created_at", Type: "int64"},
+			{Name: "tags", Type: "[]string"},
+			{Name: "meta", Type: "Meta", Values: []*registry.Value{{Name: "count", Type: "int32"}}},
+		}},
+	},
+	{
+		Name:     "Helloworld.Stream",
+		Request:  &registry.Value{Name: "StreamingRequest", Type: "StreamingRequest"},
+		Response: &registry.Value{Name: "StreamingResponse", Type: "StreamingResponse"},
+		Metadata: map[string]string{"stream": "true"},
+	},
+	{
+		Name:     "Stats.Get",
+		Request:  &registry.Value{Name: "StatsRequest", Type: "StatsRequest"},
+		Response: &registry.Value{Name: "StatsResponse", Type: "StatsResponse", Values: []*registry.Value{{Name: "requests", Type: "uint64"}}},
+	},
+}
+
+func TestFromRegistry(t *testing.T) {
+	s := fromRegistry("helloworld", testEndpoints)
+	if s.Name != "Helloworld" {
+		t.Fatalf("Expected the name Helloworld, got %v", s.Name)
+	}
+
+	// the stream is skipped
+	if len(s.Methods) != 2 {
+		t.Fatalf("Expected 2 methods, got %v", len(s.Methods))
+	}
+	call := s.Methods[0]
+	if call.Name != "Call" || call.Path != "/helloworld/Helloworld/Call" || call.Request != "Request" || call.Response != "Response" {
+		t.Errorf("Unexpected method %+v", call)
+	}
+	get := s.Methods[1]
+	if get.Name != "StatsGet" || get.Path != "/helloworld/Stats/Get" || get.Request != "" || get.Response != "StatsResponse" {
+		t.Errorf("Unexpected method %+v", get)
+	}
+
+	var names []string
+	for _, m := range s.Messages {
+		names = append(names, m.Name)
+	}
+	if strings.Join(names, ",") != "Meta,Request,Response,StatsResponse" {
+		t.Errorf("Unexpected messages %v", names)
+	}
+	rsp := s.Messages[2]
+	if f := rsp.Fields[1]; f.Name != "createdAt" || f.Type != "int64" {
+		t.Errorf("Unexpected field %+v", f)
+	}
+	if f := rsp.Fields[2]; f.Type != "string" || !f.Repeated {
+		t.Errorf("Unexpected field %+v", f)
+	}
+}
+
+func TestFromDescriptors(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("helloworld.proto"),
+		Package: proto.String("helloworld"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Request"), Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("name"), JsonName: proto.String("name"), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+			}},
+			{Name: proto.String("Response"), Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("msgs"), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					TypeName: proto.String(".helloworld.Response.Msg"), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()},
+			}, NestedType: []*descriptorpb.DescriptorProto{
+				{Name: proto.String("Msg"), Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("sent_at"), Type: descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()},
+				}},
+			}},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Helloworld"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("Call"), InputType: proto.String(".helloworld.Request"), OutputType: proto.String(".helloworld.Response")},
+				{Name: proto.String("Stream"), InputType: proto.String(".helloworld.Request"), OutputType: proto.String(".helloworld.Response"), ServerStreaming: proto.Bool(true)},
+			},
+		}},
+	}}}
+
+	s := fromDescriptors("helloworld", set)
+	if len(s.Methods) != 1 || s.Methods[0].Name != "Call" || s.Methods[0].Response != "Response" {
+		t.Fatalf("Unexpected methods %+v", s.Methods)
+	}
+	if len(s.Messages) != 3 {
+		t.Fatalf("Expected 3 messages, got %v", len(s.Messages))
+	}
+	msg := s.Messages[0]
+	if msg.Name != "Msg" || msg.Fields[0].Name != "sentAt" || msg.Fields[0].Type != "int64" {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+	if f := s.Messages[2].Fields[0]; f.Type != "message" || f.Message != "Msg" || !f.Repeated {
+		t.Errorf("Unexpected field %+v", f)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s := fromRegistry("helloworld", testEndpoints)
+
+	tt := map[string][]string{
+		"go": {
+			"package helloworld",
+			"CreatedAt int64    `json:\"createdAt,string,omitempty\"`",
+			"func (c *HelloworldClient) Call(req *Request) (*Response, error) {",
+			"func (c *HelloworldClient) StatsGet(req map[string]interface{}) (*StatsResponse, error) {",
+		},
+		"ts": {
+			"createdAt?: string;",
+			"call(req: Request): Promise<Response> {",
+			`return this.request<StatsResponse>("/helloworld/Stats/Get", req);`,
+		},
+		"python": {
+			"class Response(TypedDict, total=False):",
+			"    tags: List[str]",
+			"    def stats_get(self, req: Dict[str, Any]) -> StatsResponse:",
+		},
+	}
+
+	for lang, expected := range tt {
+		b, err := generate(lang, "helloworld", s)
+		if err != nil {
+			t.Fatalf("Error generating the %v client: %v", lang, err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(b), e) {
+				t.Errorf("Expected the %v client to contain %q, got:\n%s", lang, e, b)
+			}
+		}
+	}
+}
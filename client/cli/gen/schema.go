@@ -0,0 +1,303 @@
+package gen
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/micro/micro/v3/service/registry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// schema of the service a client is generated for
+type schema struct {
+	// Service name e.g. helloworld
+	Service string
+	// Name of the client e.g. Helloworld
+	Name string
+	// Methods of the client, one per endpoint
+	Methods []*method
+	// Messages are the request and response types
+	Messages []*message
+}
+
+// method of the client which calls an endpoint through the gateway
+type method struct {
+	// Name of the method e.g. Call
+	Name string
+	// Endpoint called e.g. Helloworld.Call
+	Endpoint string
+	// Path of the endpoint on the gateway e.g. /helloworld/Helloworld/Call
+	Path string
+	// Request and Response are the message names, blank if the schema is unknown
+	Request  string
+	Response string
+}
+
+type message struct {
+	Name   string
+	Fields []*field
+}
+
+type field struct {
+	// Name is the json name used by the gateway e.g. createdAt
+	Name string
+	// Type is a scalar (string, bool, int32, int64, uint32, uint64, float32, float64, bytes),
+	// message, or any if the type is unknown
+	Type string
+	// Message is the name of the message type
+	Message  string
+	Repeated bool
+}
+
+// scalars are the types of the registry values which map to the scalar types of the clients
+var scalars = map[string]string{
+	"string":  "string",
+	"bool":    "bool",
+	"int":     "int64",
+	"int32":   "int32",
+	"int64":   "int64",
+	"uint":    "uint64",
+	"uint32":  "uint32",
+	"uint64":  "uint64",
+	"float32": "float32",
+	"float64": "float64",
+	"[]uint8": "bytes",
+}
+
+// jsonName returns the name of the field the gateway encodes, protobuf json uses lower camel case
+func jsonName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// methodName returns the name of the method for the endpoint, the handler is dropped if it has
+// the name of the service e.g. Helloworld.Call is Call and Helloworld.Stats.Get is StatsGet
+func methodName(service, endpoint string) string {
+	parts := strings.Split(endpoint, ".")
+	if len(parts) > 1 && strings.EqualFold(parts[0], strings.ReplaceAll(service, "-", "")) {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// newSchema returns the schema of the service with the endpoints and messages
+func newSchema(service string, methods []*method, messages map[string]*message) *schema {
+	s := &schema{
+		Service: service,
+		Name:    typeName(service),
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+	for _, m := range methods {
+		m.Path = "/" + strings.ReplaceAll(service, ".", "/") + "/" + strings.ReplaceAll(m.Endpoint, ".", "/")
+		s.Methods = append(s.Methods, m)
+	}
+
+	for _, m := range messages {
+		s.Messages = append(s.Messages, m)
+	}
+	sort.Slice(s.Messages, func(i, j int) bool { return s.Messages[i].Name < s.Messages[j].Name })
+	return s
+}
+
+// typeName returns an exported identifier for the name e.g. foo-bar.baz is FooBarBaz
+func typeName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// fromRegistry returns the schema of the endpoints registered by the service. The values of the
+// endpoints are extracted from the handler types so the depth of the messages is limited.
+func fromRegistry(service string, endpoints []*registry.Endpoint) *schema {
+	messages := make(map[string]*message)
+
+	var methods []*method
+	for _, ep := range endpoints {
+		// streams aren't supported by the gateway over plain http
+		if ep.Metadata["stream"] == "true" {
+			continue
+		}
+		m := &method{
+			Name:     methodName(service, ep.Name),
+			Endpoint: ep.Name,
+		}
+		if f := registryField(ep.Request, messages); f.Type == "message" {
+			m.Request = f.Message
+		}
+		if f := registryField(ep.Response, messages); f.Type == "message" {
+			m.Response = f.Message
+		}
+		methods = append(methods, m)
+	}
+
+	return newSchema(service, methods, messages)
+}
+
+// registryField converts the value to a field and adds its message to the messages
+func registryField(v *registry.Value, messages map[string]*message) *field {
+	if v == nil {
+		return &field{Type: "any"}
+	}
+	f := &field{Name: jsonName(v.Name)}
+
+	typ := v.Type
+	if typ != "[]uint8" && strings.HasPrefix(typ, "[]") {
+		f.Repeated = true
+		typ = strings.TrimPrefix(typ, "[]")
+	}
+
+	if s, ok := scalars[typ]; ok {
+		f.Type = s
+		return f
+	}
+
+	// the values of repeated messages, maps, enums and messages past the depth of the registry
+	// aren't known
+	if len(v.Values) == 0 || f.Repeated || len(typ) == 0 {
+		f.Type = "any"
+		return f
+	}
+
+	f.Type = "message"
+	f.Message = typeName(typ)
+
+	msg := &message{Name: f.Message}
+	for _, val := range v.Values {
+		msg.Fields = append(msg.Fields, registryField(val, messages))
+	}
+	// the same message may have been truncated by the depth elsewhere
+	if prev, ok := messages[msg.Name]; !ok || len(prev.Fields) < len(msg.Fields) {
+		messages[msg.Name] = msg
+	}
+	return f
+}
+
+// protoScalars are the scalar types of the proto fields, 64 bit integers are encoded as strings by
+// the gateway so are kept as their type for the clients to decode
+var protoScalars = map[descriptorpb.FieldDescriptorProto_Type]string{
+	descriptorpb.FieldDescriptorProto_TYPE_STRING:   "string",
+	descriptorpb.FieldDescriptorProto_TYPE_BOOL:     "bool",
+	descriptorpb.FieldDescriptorProto_TYPE_INT32:    "int32",
+	descriptorpb.FieldDescriptorProto_TYPE_SINT32:   "int32",
+	descriptorpb.FieldDescriptorProto_TYPE_SFIXED32: "int32",
+	descriptorpb.FieldDescriptorProto_TYPE_INT64:    "int64",
+	descriptorpb.FieldDescriptorProto_TYPE_SINT64:   "int64",
+	descriptorpb.FieldDescriptorProto_TYPE_SFIXED64: "int64",
+	descriptorpb.FieldDescriptorProto_TYPE_UINT32:   "uint32",
+	descriptorpb.FieldDescriptorProto_TYPE_FIXED32:  "uint32",
+	descriptorpb.FieldDescriptorProto_TYPE_UINT64:   "uint64",
+	descriptorpb.FieldDescriptorProto_TYPE_FIXED64:  "uint64",
+	descriptorpb.FieldDescriptorProto_TYPE_FLOAT:    "float32",
+	descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:   "float64",
+	descriptorpb.FieldDescriptorProto_TYPE_BYTES:    "bytes",
+	// enums are encoded by name
+	descriptorpb.FieldDescriptorProto_TYPE_ENUM: "string",
+}
+
+// fromDescriptors returns the schema of the services of the descriptor set e.g. generated with
+// protoc --include_imports -o service.pb service.proto
+func fromDescriptors(service string, set *descriptorpb.FileDescriptorSet) *schema {
+	// index the messages by their full name e.g. .helloworld.Request
+	descs := make(map[string]*descriptorpb.DescriptorProto)
+	var index func(prefix string, msgs []*descriptorpb.DescriptorProto)
+	index = func(prefix string, msgs []*descriptorpb.DescriptorProto) {
+		for _, m := range msgs {
+			name := prefix + "." + m.GetName()
+			descs[name] = m
+			index(name, m.GetNestedType())
+		}
+	}
+	for _, f := range set.GetFile() {
+		prefix := ""
+		if len(f.GetPackage()) > 0 {
+			prefix = "." + f.GetPackage()
+		}
+		index(prefix, f.GetMessageType())
+	}
+
+	messages := make(map[string]*message)
+	var addMessage func(name string) string
+	addMessage = func(name string) string {
+		desc, ok := descs[name]
+		if !ok {
+			return ""
+		}
+		msgName := typeName(desc.GetName())
+		if _, ok := messages[msgName]; ok {
+			return msgName
+		}
+		msg := &message{Name: msgName}
+		// set before the fields so recursive messages terminate
+		messages[msgName] = msg
+
+		for _, fd := range desc.GetField() {
+			f := &field{
+				Name:     fd.GetJsonName(),
+				Repeated: fd.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+			}
+			if len(f.Name) == 0 {
+				f.Name = jsonName(fd.GetName())
+			}
+
+			if s, ok := protoScalars[fd.GetType()]; ok {
+				f.Type = s
+			} else if m, ok := descs[fd.GetTypeName()]; ok && m.GetOptions().GetMapEntry() {
+				// maps are encoded as objects
+				f.Type = "any"
+				f.Repeated = false
+			} else if n := addMessage(fd.GetTypeName()); len(n) > 0 {
+				f.Type = "message"
+				f.Message = n
+			} else {
+				f.Type = "any"
+			}
+			msg.Fields = append(msg.Fields, f)
+		}
+		return msgName
+	}
+
+	var methods []*method
+	for _, f := range set.GetFile() {
+		for _, svc := range f.GetService() {
+			for _, md := range svc.GetMethod() {
+				if md.GetClientStreaming() || md.GetServerStreaming() {
+					continue
+				}
+				endpoint := svc.GetName() + "." + md.GetName()
+				methods = append(methods, &method{
+					Name:     methodName(service, endpoint),
+					Endpoint: endpoint,
+					Request:  addMessage(md.GetInputType()),
+					Response: addMessage(md.GetOutputType()),
+				})
+			}
+		}
+	}
+
+	return newSchema(service, methods, messages)
+}
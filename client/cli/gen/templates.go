@@ -0,0 +1,229 @@
+package gen
+
+var goTemplate = `// Code generated by micro gen client. DO NOT EDIT.
+
+// Package {{.Package}} is a client of the {{.Service}} service which calls it through the micro api gateway
+package {{.Package}}
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+{{range .Messages}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{title .Name}} {{goType .}} ` + "`json:\"{{.Name}}{{if goString .}},string{{end}},omitempty\"`" + `
+{{- end}}
+}
+{{end}}
+// MicroError is the error returned by the service
+type MicroError struct {
+	Id     string ` + "`json:\"id\"`" + `
+	Code   int32  ` + "`json:\"code\"`" + `
+	Detail string ` + "`json:\"detail\"`" + `
+	Status string ` + "`json:\"status\"`" + `
+}
+
+func (e *MicroError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Code, e.Status, e.Detail)
+}
+
+// {{.Name}}Client calls the {{.Service}} service through the gateway
+type {{.Name}}Client struct {
+	// Address of the gateway e.g. http://localhost:8080
+	Address string
+	// Token sent as the bearer token, optional
+	Token string
+	// Namespace of the service, optional
+	Namespace string
+	// Client used for the requests, defaults to http.DefaultClient
+	Client *http.Client
+}
+
+// New{{.Name}}Client returns a client which calls the gateway at the address
+func New{{.Name}}Client(address, token string) *{{.Name}}Client {
+	return &{{.Name}}Client{Address: strings.TrimSuffix(address, "/"), Token: token}
+}
+
+func (c *{{.Name}}Client) call(path string, req, rsp interface{}) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	hr, err := http.NewRequest("POST", c.Address+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	hr.Header.Set("Content-Type", "application/json")
+	if len(c.Token) > 0 {
+		hr.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if len(c.Namespace) > 0 {
+		hr.Header.Set("Micro-Namespace", c.Namespace)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	hrsp, err := client.Do(hr)
+	if err != nil {
+		return err
+	}
+	defer hrsp.Body.Close()
+	body, err := ioutil.ReadAll(hrsp.Body)
+	if err != nil {
+		return err
+	}
+
+	if hrsp.StatusCode >= 300 {
+		e := &MicroError{Code: int32(hrsp.StatusCode), Status: http.StatusText(hrsp.StatusCode), Detail: string(body)}
+		json.Unmarshal(body, e)
+		return e
+	}
+	return json.Unmarshal(body, rsp)
+}
+{{range .Methods}}
+// {{.Name}} calls {{.Endpoint}}
+func (c *{{$.Name}}Client) {{.Name}}(req {{goMessage .Request}}) ({{goMessage .Response}}, error) {
+	{{- if .Response}}
+	rsp := new({{.Response}})
+	{{- else}}
+	rsp := map[string]interface{}{}
+	{{- end}}
+	return rsp, c.call("{{.Path}}", req, {{if not .Response}}&{{end}}rsp)
+}
+{{end}}`
+
+var tsTemplate = `// Code generated by micro gen client. DO NOT EDIT.
+// Client of the {{.Service}} service which calls it through the micro api gateway.
+{{range .Messages}}
+export interface {{.Name}} {
+{{- range .Fields}}
+  {{.Name}}?: {{tsType .}};
+{{- end}}
+}
+{{end}}
+// Error returned by the service
+export interface MicroError {
+  id?: string;
+  code?: number;
+  detail?: string;
+  status?: string;
+}
+
+export interface {{.Name}}ClientOptions {
+  // Token sent as the bearer token
+  token?: string;
+  // Namespace of the service
+  namespace?: string;
+}
+
+export class {{.Name}}Client {
+  private address: string;
+  private options: {{.Name}}ClientOptions;
+
+  // address of the gateway e.g. http://localhost:8080
+  constructor(address: string, options: {{.Name}}ClientOptions = {}) {
+    this.address = address.replace(/\/$/, "");
+    this.options = options;
+  }
+
+  private async request<T>(path: string, req: any): Promise<T> {
+    const headers: Record<string, string> = { "Content-Type": "application/json" };
+    if (this.options.token) {
+      headers["Authorization"] = "Bearer " + this.options.token;
+    }
+    if (this.options.namespace) {
+      headers["Micro-Namespace"] = this.options.namespace;
+    }
+    const rsp = await fetch(this.address + path, {
+      method: "POST",
+      headers: headers,
+      body: JSON.stringify(req || {}),
+    });
+    const body = await rsp.text();
+    if (!rsp.ok) {
+      let err: MicroError = { code: rsp.status, status: rsp.statusText, detail: body };
+      try {
+        err = JSON.parse(body);
+      } catch (e) {}
+      throw err;
+    }
+    return JSON.parse(body) as T;
+  }
+{{range .Methods}}
+  // {{.Endpoint}}
+  {{lowerFirst .Name}}(req: {{tsMessage .Request}}): Promise<{{tsMessage .Response}}> {
+    return this.request<{{tsMessage .Response}}>("{{.Path}}", req);
+  }
+{{end -}}
+}
+`
+
+var pyTemplate = `# Code generated by micro gen client. DO NOT EDIT.
+"""Client of the {{.Service}} service which calls it through the micro api gateway."""
+
+from __future__ import annotations
+
+import json
+import urllib.error
+import urllib.request
+from typing import Any, Dict, List, TypedDict
+{{range .Messages}}
+
+class {{.Name}}(TypedDict, total=False):
+{{- range .Fields}}
+    {{.Name}}: {{pyType .}}
+{{- else}}
+    pass
+{{- end}}
+{{end}}
+
+class MicroError(Exception):
+    """Error returned by the service."""
+
+    def __init__(self, code: int, status: str, detail: str, id: str = ""):
+        super().__init__("%d %s: %s" % (code, status, detail))
+        self.id = id
+        self.code = code
+        self.status = status
+        self.detail = detail
+
+
+class {{.Name}}Client:
+    """Calls the {{.Service}} service through the gateway at the address e.g. http://localhost:8080."""
+
+    def __init__(self, address: str, token: str = "", namespace: str = ""):
+        self.address = address.rstrip("/")
+        self.token = token
+        self.namespace = namespace
+
+    def _request(self, path: str, req: Any) -> Any:
+        headers = {"Content-Type": "application/json"}
+        if self.token:
+            headers["Authorization"] = "Bearer " + self.token
+        if self.namespace:
+            headers["Micro-Namespace"] = self.namespace
+        data = json.dumps(req or {}).encode("utf-8")
+        request = urllib.request.Request(self.address + path, data=data, headers=headers, method="POST")
+        try:
+            with urllib.request.urlopen(request) as rsp:
+                return json.loads(rsp.read().decode("utf-8"))
+        except urllib.error.HTTPError as e:
+            body = e.read().decode("utf-8")
+            try:
+                err = json.loads(body)
+            except ValueError:
+                err = {}
+            raise MicroError(err.get("code", e.code), err.get("status", e.reason), err.get("detail", body), err.get("id", ""))
+{{range .Methods}}
+    def {{snake .Name}}(self, req: {{pyMessage .Request}}) -> {{pyMessage .Response}}:
+        """Calls {{.Endpoint}}."""
+        return self._request("{{.Path}}", req)
+{{end -}}
+`
@@ -31,8 +31,9 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
+	// without profiles run the setup wizard
 	if len(profiles) == 0 {
-		return nil
+		return Setup(ctx)
 	}
 
 	f := os.Stdout
@@ -61,10 +62,13 @@ func Run(ctx *cli.Context) error {
 
 func init() {
 	cmd.Register(&cli.Command{
-		Name:        "init",
-		Usage:       "Generate a profile for micro plugins",
-		Description: `'micro init' generates a profile.go file defining plugins and profiles`,
-		Action:      Run,
+		Name:  "init",
+		Usage: "Set up micro or generate a profile for micro plugins",
+		Description: `'micro init' asks how micro should be run e.g. the profile, broker, addresses and TLS,
+	validates the connectivity and writes the answers to the flags file as the defaults of the flags,
+	~/.micro/micro.toml or the file set with --config.
+	'micro init --profile' generates a profile.go file defining plugins and profiles`,
+		Action: Run,
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{
 				Name:  "profile",
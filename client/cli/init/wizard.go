@@ -0,0 +1,202 @@
+package init
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/micro/micro/v3/cmd"
+	"github.com/urfave/cli/v2"
+)
+
+// DialTimeout is how long to wait when validating the connectivity to an address
+var DialTimeout = 5 * time.Second
+
+// profiles the server can be configured with and the backends they use
+var profiles = []string{"local", "memory", "kubernetes"}
+
+var profileUsage = `The profile sets the registry, store and auth backends of the server and services:
+  local       mdns registry, file store and jwt auth
+  memory      in memory registry, broker and store, auth disabled
+  kubernetes  the registry and store services for the micro helm chart, jwt auth`
+
+// wizard asks the setup questions and returns the flags to configure
+type wizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask a question, the default is returned for a blank answer. If options are set the answer must
+// be one of them.
+func (w *wizard) ask(question, def string, options ...string) (string, error) {
+	if len(options) > 0 {
+		question = fmt.Sprintf("%s (%s)", question, strings.Join(options, "/"))
+	}
+	if len(def) > 0 {
+		question = fmt.Sprintf("%s [%s]", question, def)
+	}
+
+	for {
+		fmt.Fprint(w.out, question+": ")
+
+		answer, err := w.in.ReadString('\n')
+		if err != nil && (err != io.EOF || len(answer) == 0) {
+			return "", err
+		}
+		answer = strings.TrimSpace(answer)
+		if len(answer) == 0 {
+			answer = def
+		}
+		if len(options) == 0 {
+			return answer, nil
+		}
+		for _, o := range options {
+			if answer == o {
+				return answer, nil
+			}
+		}
+		fmt.Fprintf(w.out, "Answer %q is invalid, valid answers are %s\n", answer, strings.Join(options, ", "))
+	}
+}
+
+// run the wizard, the current values are used as the defaults
+func (w *wizard) run(current map[string]string) (map[string]string, error) {
+	flags := make(map[string]string)
+
+	fmt.Fprintln(w.out, profileUsage)
+	prof := current["profile"]
+	if len(prof) == 0 {
+		prof = "local"
+	}
+	v, err := w.ask("Profile", prof, profiles...)
+	if err != nil {
+		return nil, err
+	}
+	flags["profile"] = v
+
+	broker := current["broker"]
+	if len(broker) == 0 {
+		broker = "memory"
+	}
+	if v, err = w.ask("Broker used by the broker service, embedded requires the nats plugin", broker, "memory", "embedded"); err != nil {
+		return nil, err
+	}
+	flags["broker"] = v
+
+	fmt.Fprintln(w.out, "Leave the addresses blank to use the defaults of the profile")
+	for _, name := range []string{"registry", "broker", "store", "auth"} {
+		flag := name + "_address"
+		if v, err = w.ask(fmt.Sprintf("Address of the %s", name), current[flag]); err != nil {
+			return nil, err
+		}
+		flags[flag] = v
+	}
+
+	useTLS := "no"
+	if len(current["registry_tls_cert"]) > 0 {
+		useTLS = "yes"
+	}
+	if v, err = w.ask("Use TLS for the registry and broker", useTLS, "yes", "no"); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"ca", "cert", "key"} {
+		var path string
+		if v == "yes" {
+			if path, err = w.ask(fmt.Sprintf("Path of the TLS %s", name), current["registry_tls_"+name]); err != nil {
+				return nil, err
+			}
+		}
+		flags["registry_tls_"+name] = path
+		flags["broker_tls_"+name] = path
+	}
+
+	return flags, nil
+}
+
+// validate the connectivity to the addresses and the tls files of the flags
+func validate(flags map[string]string) []error {
+	var errs []error
+
+	for _, name := range []string{"registry", "broker", "store", "auth"} {
+		for _, address := range strings.Split(flags[name+"_address"], ",") {
+			address = strings.TrimPrefix(strings.TrimSpace(address), "nats://")
+			if len(address) == 0 {
+				continue
+			}
+			conn, err := net.DialTimeout("tcp", address, DialTimeout)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Failed to connect to the %s at %s: %v", name, address, err))
+				continue
+			}
+			conn.Close()
+		}
+	}
+
+	cert, key := flags["registry_tls_cert"], flags["registry_tls_key"]
+	if len(cert) > 0 || len(key) > 0 {
+		if _, err := tls.LoadX509KeyPair(cert, key); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to load the TLS cert and key: %v", err))
+		}
+	}
+	if ca := flags["registry_tls_ca"]; len(ca) > 0 {
+		if _, err := ioutil.ReadFile(ca); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to read the TLS ca: %v", err))
+		}
+	}
+
+	return errs
+}
+
+// currentFlags returns the flags configured in the flags file e.g. by a previous run of the wizard
+func currentFlags(path string) map[string]string {
+	flags := make(map[string]string)
+	vals, err := cmd.LoadFlagsFile(path)
+	if err != nil {
+		return flags
+	}
+	for _, name := range []string{"profile", "broker", "registry_address", "broker_address", "store_address",
+		"auth_address", "registry_tls_ca", "registry_tls_cert", "registry_tls_key"} {
+		if v, ok := vals[name]; ok {
+			flags[name] = fmt.Sprint(v)
+		}
+	}
+	return flags
+}
+
+// Setup runs the setup wizard which asks how micro should be run, validates the connectivity to
+// the addresses and writes the answers to the flags file as the defaults of the flags
+func Setup(ctx *cli.Context) error {
+	w := &wizard{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+	path := cmd.FlagsFile(ctx)
+
+	flags, err := w.run(currentFlags(path))
+	if err != nil {
+		return err
+	}
+
+	if errs := validate(flags); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Println(err)
+		}
+		v, err := w.ask("Write the config anyway", "no", "yes", "no")
+		if err != nil {
+			return err
+		}
+		if v == "no" {
+			return fmt.Errorf("Setup cancelled, the config wasn't written")
+		}
+	}
+
+	if err := cmd.WriteFlagsFile(path, flags); err != nil {
+		return err
+	}
+
+	fmt.Printf("Config written to %s, flags and env vars take precedence over it\n", path)
+	return nil
+}
@@ -0,0 +1,81 @@
+package init
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/micro/micro/v3/cmd"
+)
+
+func TestWizard(t *testing.T) {
+	answers := strings.Join([]string{
+		"bogus",          // invalid profile is asked again
+		"memory",         // profile
+		"",               // default broker
+		"127.0.0.1:8000", // registry address
+		"", "", "",       // broker, store and auth addresses
+		"yes",                           // tls
+		"ca.pem", "cert.pem", "key.pem", // tls files
+	}, "\n")
+
+	w := &wizard{in: bufio.NewReader(strings.NewReader(answers)), out: ioutil.Discard}
+	flags, err := w.run(map[string]string{"broker": "embedded"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"profile":          "memory",
+		"broker":           "embedded",
+		"registry_address": "127.0.0.1:8000",
+		"broker_address":   "",
+		"registry_tls_ca":  "ca.pem",
+		"broker_tls_key":   "key.pem",
+	}
+	for k, v := range expected {
+		if flags[k] != v {
+			t.Errorf("Expected %v to be %q, got %q", k, v, flags[k])
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if errs := validate(map[string]string{"registry_address": l.Addr().String()}); len(errs) > 0 {
+		t.Fatalf("Unexpected errors %v", errs)
+	}
+
+	errs := validate(map[string]string{
+		"broker_address":    "nats://127.0.0.1:1",
+		"registry_tls_cert": "missing.pem",
+		"registry_tls_key":  "missing.pem",
+	})
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+}
+
+func TestCurrentFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "micro.toml")
+	if len(currentFlags(path)) > 0 {
+		t.Fatal("Expected no flags without a file")
+	}
+
+	flags := map[string]string{"profile": "memory", "registry_address": "127.0.0.1:8000", "broker_tls_ca": ""}
+	if err := cmd.WriteFlagsFile(path, flags); err != nil {
+		t.Fatal(err)
+	}
+	current := currentFlags(path)
+	if current["profile"] != "memory" || current["registry_address"] != "127.0.0.1:8000" {
+		t.Fatalf("Expected the flags written by the wizard, got %v", current)
+	}
+}
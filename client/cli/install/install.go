@@ -0,0 +1,178 @@
+// Package install provides the micro install command which installs micro as a systemd unit or
+// windows service
+package install
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/micro/micro/v3/cmd"
+	"github.com/urfave/cli/v2"
+)
+
+// Config of the installed service
+type Config struct {
+	// Name of the service e.g. micro-api
+	Name string
+	// Description of the service
+	Description string
+	// Exec is the path of the micro binary
+	Exec string
+	// Args micro is run with e.g. service api
+	Args []string
+	// Env vars micro is run with
+	Env []string
+	// User the service is run as, systemd only
+	User string
+	// Restart policy e.g. on-failure
+	Restart string
+	// RestartDelay is how long to wait before restarting
+	RestartDelay time.Duration
+	// LogFile the logs are written to, systemd defaults to the journal
+	LogFile string
+}
+
+// modes micro can be installed in and the args they're run with
+var modes = map[string][]string{
+	"server": {"server"},
+	"api":    {"service", "api"},
+	"proxy":  {"service", "proxy"},
+}
+
+// restart policies supported by systemd, windows restarts on any failure
+var restarts = []string{"no", "always", "on-failure", "on-abnormal"}
+
+func newConfig(ctx *cli.Context) (*Config, error) {
+	// flags after the mode aren't parsed so they'd be ignored
+	if ctx.Args().Len() > 1 {
+		return nil, fmt.Errorf("Unexpected args %v, flags must be set before the mode", ctx.Args().Tail())
+	}
+
+	mode := ctx.Args().First()
+	if len(mode) == 0 {
+		mode = "server"
+	}
+	args, ok := modes[mode]
+	if !ok {
+		return nil, fmt.Errorf("Unknown mode %s, expected server, api or proxy", mode)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	if exe, err = filepath.Abs(exe); err != nil {
+		return nil, err
+	}
+
+	name := ctx.String("name")
+	if len(name) == 0 {
+		name = "micro"
+		if mode != "server" {
+			name = "micro-" + mode
+		}
+	}
+
+	restart := ctx.String("restart")
+	valid := false
+	for _, r := range restarts {
+		valid = valid || r == restart
+	}
+	if !valid {
+		return nil, fmt.Errorf("Unknown restart policy %s, expected one of %s", restart, strings.Join(restarts, ", "))
+	}
+
+	for _, env := range ctx.StringSlice("env_vars") {
+		if !strings.Contains(env, "=") {
+			return nil, fmt.Errorf("Invalid env var %s, expected key=value", env)
+		}
+	}
+
+	return &Config{
+		Name:         name,
+		Description:  fmt.Sprintf("Micro %s", mode),
+		Exec:         exe,
+		Args:         args,
+		Env:          ctx.StringSlice("env_vars"),
+		User:         ctx.String("user"),
+		Restart:      restart,
+		RestartDelay: ctx.Duration("restart_delay"),
+		LogFile:      ctx.String("log_file"),
+	}, nil
+}
+
+// Run installs or uninstalls micro
+func Run(ctx *cli.Context) error {
+	c, err := newConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("uninstall") {
+		if err := uninstall(c); err != nil {
+			return err
+		}
+		fmt.Printf("Uninstalled %s\n", c.Name)
+		return nil
+	}
+
+	if ctx.Bool("dry_run") {
+		return dryRun(c)
+	}
+
+	if err := install(c); err != nil {
+		return err
+	}
+	fmt.Printf("Installed %s\n", c.Name)
+	return nil
+}
+
+func init() {
+	cmd.Register(&cli.Command{
+		Name:      "install",
+		Usage:     "Install micro as a systemd unit or windows service",
+		ArgsUsage: "[server|api|proxy]",
+		Description: `'micro install' installs micro as a service which is started on boot and restarted when it fails.
+	Systemd is used on linux and the service control manager on windows. Defaults to the server.`,
+		Action: Run,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "Name of the service, defaults to micro for the server and micro-[mode] otherwise",
+			},
+			&cli.StringFlag{
+				Name:  "user",
+				Usage: "User to run the service as (systemd only)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "env_vars",
+				Usage: "Comma-separated list of env vars to run the service with e.g. MICRO_PROFILE=local",
+			},
+			&cli.StringFlag{
+				Name:  "restart",
+				Usage: "When to restart the service: no, always, on-failure or on-abnormal",
+				Value: "on-failure",
+			},
+			&cli.DurationFlag{
+				Name:  "restart_delay",
+				Usage: "How long to wait before restarting the service",
+				Value: 5 * time.Second,
+			},
+			&cli.StringFlag{
+				Name:  "log_file",
+				Usage: "File to write the logs to, defaults to the journal with systemd and micro.log next to the binary on windows",
+			},
+			&cli.BoolFlag{
+				Name:  "dry_run",
+				Usage: "Print the service definition without installing it",
+			},
+			&cli.BoolFlag{
+				Name:  "uninstall",
+				Usage: "Stop and remove the service",
+			},
+		},
+	})
+}
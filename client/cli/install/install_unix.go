@@ -0,0 +1,56 @@
+// +build !windows
+
+package install
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// UnitDir is the directory the systemd units are installed to
+var UnitDir = "/etc/systemd/system"
+
+func unitPath(c *Config) string {
+	return filepath.Join(UnitDir, c.Name+".service")
+}
+
+func dryRun(c *Config) error {
+	fmt.Printf("# %s\n%s", unitPath(c), unit(c))
+	return nil
+}
+
+func install(c *Config) error {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return fmt.Errorf("systemctl not found, micro can only be installed with systemd")
+	}
+
+	if err := ioutil.WriteFile(unitPath(c), []byte(unit(c)), 0644); err != nil {
+		return fmt.Errorf("Error writing the unit: %v", err)
+	}
+
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	return systemctl("enable", "--now", c.Name)
+}
+
+func uninstall(c *Config) error {
+	if err := systemctl("disable", "--now", c.Name); err != nil {
+		return err
+	}
+	if err := os.Remove(unitPath(c)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return systemctl("daemon-reload")
+}
+
+func systemctl(args ...string) error {
+	out, err := exec.Command("systemctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("Error running systemctl %v: %s", args, out)
+	}
+	return nil
+}
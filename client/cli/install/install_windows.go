@@ -0,0 +1,104 @@
+// +build windows
+
+package install
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// logFile returns the file the logs are written to, services don't have a console
+func logFile(c *Config) string {
+	if len(c.LogFile) > 0 {
+		return c.LogFile
+	}
+	return filepath.Join(filepath.Dir(c.Exec), c.Name+".log")
+}
+
+// args returns the args the service is run with
+func args(c *Config) []string {
+	return append([]string{"--log_file", logFile(c)}, c.Args...)
+}
+
+func dryRun(c *Config) error {
+	fmt.Printf("Service: %s\n", c.Name)
+	fmt.Printf("Command: %s\n", command(c.Exec, args(c)))
+	fmt.Printf("Environment: %s\n", strings.Join(c.Env, " "))
+	fmt.Printf("Restart: %s after %v\n", c.Restart, c.RestartDelay)
+	return nil
+}
+
+func install(c *Config) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(c.Name); err == nil {
+		s.Close()
+		return fmt.Errorf("Service %s already exists", c.Name)
+	}
+
+	s, err := m.CreateService(c.Name, c.Exec, mgr.Config{
+		DisplayName: c.Description,
+		Description: c.Description,
+		StartType:   mgr.StartAutomatic,
+	}, args(c)...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	// the service control manager reads the env vars of the service from the registry
+	if len(c.Env) > 0 {
+		k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\`+c.Name, registry.SET_VALUE)
+		if err != nil {
+			return err
+		}
+		defer k.Close()
+		if err := k.SetStringsValue("Environment", c.Env); err != nil {
+			return err
+		}
+	}
+
+	// restart the service when it fails, windows doesn't distinguish the reasons
+	if c.Restart != "no" {
+		actions := []mgr.RecoveryAction{
+			{Type: mgr.ServiceRestart, Delay: c.RestartDelay},
+			{Type: mgr.ServiceRestart, Delay: c.RestartDelay},
+			{Type: mgr.ServiceRestart, Delay: c.RestartDelay},
+		}
+		// reset the failure count after a day
+		if err := s.SetRecoveryActions(actions, uint32((24 * time.Hour).Seconds())); err != nil {
+			return err
+		}
+	}
+
+	return s.Start()
+}
+
+func uninstall(c *Config) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(c.Name)
+	if err != nil {
+		return fmt.Errorf("Service %s not installed", c.Name)
+	}
+	defer s.Close()
+
+	// stop the service before deleting it, ignoring the error if it isn't running
+	s.Control(svc.Stop)
+
+	return s.Delete()
+}
@@ -0,0 +1,53 @@
+package install
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unit returns the systemd unit of the service. It's of Type=notify so systemd waits for micro
+// to tell it it's started, see internal/daemon.
+func unit(c *Config) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[Unit]\n")
+	fmt.Fprintf(&b, "Description=%s\n", c.Description)
+	fmt.Fprintf(&b, "Wants=network-online.target\n")
+	fmt.Fprintf(&b, "After=network-online.target\n")
+	fmt.Fprintf(&b, "\n[Service]\n")
+	fmt.Fprintf(&b, "Type=notify\n")
+	fmt.Fprintf(&b, "ExecStart=%s\n", command(c.Exec, c.Args))
+	if len(c.User) > 0 {
+		fmt.Fprintf(&b, "User=%s\n", c.User)
+	}
+	for _, env := range c.Env {
+		fmt.Fprintf(&b, "Environment=%s\n", quote(env))
+	}
+	if len(c.LogFile) > 0 {
+		fmt.Fprintf(&b, "Environment=%s\n", quote("MICRO_LOG_FILE="+c.LogFile))
+	}
+	fmt.Fprintf(&b, "Restart=%s\n", c.Restart)
+	fmt.Fprintf(&b, "RestartSec=%d\n", int(c.RestartDelay.Seconds()))
+	fmt.Fprintf(&b, "KillMode=mixed\n")
+	fmt.Fprintf(&b, "\n[Install]\n")
+	fmt.Fprintf(&b, "WantedBy=multi-user.target\n")
+
+	return b.String()
+}
+
+// command returns the command line of the exec
+func command(exe string, args []string) string {
+	parts := []string{quote(exe)}
+	for _, a := range args {
+		parts = append(parts, quote(a))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quote quotes the value if it contains spaces or quotes
+func quote(v string) string {
+	if !strings.ContainsAny(v, " \t\"\\") {
+		return v
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
@@ -0,0 +1,36 @@
+package install
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnit(t *testing.T) {
+	u := unit(&Config{
+		Name:         "micro-api",
+		Description:  "Micro api",
+		Exec:         "/opt/micro bin/micro",
+		Args:         []string{"service", "api"},
+		Env:          []string{"MICRO_PROFILE=local"},
+		User:         "micro",
+		Restart:      "on-failure",
+		RestartDelay: 5 * time.Second,
+		LogFile:      "/var/log/micro.log",
+	})
+
+	for _, line := range []string{
+		"Type=notify",
+		`ExecStart="/opt/micro bin/micro" service api`,
+		"User=micro",
+		"Environment=MICRO_PROFILE=local",
+		"Environment=MICRO_LOG_FILE=/var/log/micro.log",
+		"Restart=on-failure",
+		"RestartSec=5",
+		"WantedBy=multi-user.target",
+	} {
+		if !strings.Contains(u, line+"\n") {
+			t.Fatalf("Expected unit to contain %q, got:\n%s", line, u)
+		}
+	}
+}
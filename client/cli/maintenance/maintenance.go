@@ -0,0 +1,139 @@
+// Package maintenance provides the micro maintenance command which puts services in maintenance
+package maintenance
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/micro/v3/client/cli/namespace"
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/cmd"
+	"github.com/micro/micro/v3/internal/helper"
+	pb "github.com/micro/micro/v3/proto/runtime"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	cmd.Register(&cli.Command{
+		Name:  "maintenance",
+		Usage: "Put services in maintenance",
+		Description: `The api gateway and proxy return a 503 with the message for the requests to a service in
+	maintenance, other than its health checks.
+
+	micro maintenance enable helloworld --message "Back at 10:00 UTC"`,
+		Action: helper.UnexpectedSubcommand,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "enable",
+				Usage:     "Enable the maintenance of a service",
+				UsageText: "micro maintenance enable [options] service",
+				Action:    enable,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "message",
+						Usage: "Message returned to the callers of the service",
+					},
+				},
+			},
+			{
+				Name:      "disable",
+				Usage:     "Disable the maintenance of a service",
+				UsageText: "micro maintenance disable service",
+				Action:    disable,
+			},
+			{
+				Name:   "list",
+				Usage:  "List the services in maintenance",
+				Action: list,
+			},
+		},
+	})
+}
+
+func getNamespace(ctx *cli.Context) (string, error) {
+	env, err := util.GetEnv(ctx)
+	if err != nil {
+		return "", err
+	}
+	return namespace.Get(env.Name)
+}
+
+func enable(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Expected one argument: service")
+	}
+	ns, err := getNamespace(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli := pb.NewMaintenanceService("runtime", client.DefaultClient)
+	_, err = cli.Enable(context.DefaultContext, &pb.EnableMaintenanceRequest{
+		Service:   ctx.Args().First(),
+		Namespace: ns,
+		Message:   ctx.String("message"),
+	}, client.WithAuthToken())
+	if verr := errors.FromError(err); verr != nil {
+		return fmt.Errorf("Error: %v", verr.Detail)
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Printf("Service %v is in maintenance\n", ctx.Args().First())
+	return nil
+}
+
+func disable(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Expected one argument: service")
+	}
+	ns, err := getNamespace(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli := pb.NewMaintenanceService("runtime", client.DefaultClient)
+	_, err = cli.Disable(context.DefaultContext, &pb.DisableMaintenanceRequest{
+		Service:   ctx.Args().First(),
+		Namespace: ns,
+	}, client.WithAuthToken())
+	if verr := errors.FromError(err); verr != nil {
+		return fmt.Errorf("Error: %v", verr.Detail)
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Printf("Service %v is no longer in maintenance\n", ctx.Args().First())
+	return nil
+}
+
+func list(ctx *cli.Context) error {
+	ns, err := getNamespace(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli := pb.NewMaintenanceService("runtime", client.DefaultClient)
+	rsp, err := cli.List(context.DefaultContext, &pb.ListMaintenanceRequest{Namespace: ns}, client.WithAuthToken())
+	if verr := errors.FromError(err); verr != nil {
+		return fmt.Errorf("Error: %v", verr.Detail)
+	} else if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, strings.Join([]string{"SERVICE", "SINCE", "OWNER", "MESSAGE"}, "\t"))
+	for _, s := range rsp.Services {
+		since := time.Unix(s.Started, 0).Format(time.RFC3339)
+		fmt.Fprintln(w, strings.Join([]string{s.Service, since, s.Owner, s.Message}, "\t"))
+	}
+	return nil
+}
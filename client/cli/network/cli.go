@@ -9,10 +9,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/micro/micro/v3/client/cli/util"
 	"github.com/micro/micro/v3/cmd"
 	clic "github.com/micro/micro/v3/internal/command"
+	pb "github.com/micro/micro/v3/proto/network"
 	"github.com/micro/micro/v3/service/client"
 	"github.com/micro/micro/v3/service/context"
 	"github.com/olekukonko/tablewriter"
@@ -29,6 +31,11 @@ func init() {
 				Usage:  "connect to the network. specify nodes e.g connect ip:port",
 				Action: util.Print(networkConnect),
 			},
+			{
+				Name:   "disconnect",
+				Usage:  "disconnect from a node of the network. specify nodes e.g disconnect ip:port",
+				Action: util.Print(networkDisconnect),
+			},
 			{
 				Name:   "connections",
 				Usage:  "List the immediate connections to the network",
@@ -36,8 +43,16 @@ func init() {
 			},
 			{
 				Name:   "graph",
-				Usage:  "Get the network graph",
+				Usage:  "Get the network graph, as dot to render it with graphviz e.g. micro network graph -o dot | dot -Tsvg > network.svg",
 				Action: util.Print(networkGraph),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "output format (json, dot)",
+						Value:   "json",
+					},
+				},
 			},
 			{
 				Name:   "nodes",
@@ -69,6 +84,11 @@ func init() {
 						Name:  "network",
 						Usage: "Filter by network",
 					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "output format (json, table)",
+						Value: "table",
+					},
 				},
 			},
 			{
@@ -76,6 +96,28 @@ func init() {
 				Usage:  "Get the network services",
 				Action: util.Print(networkServices),
 			},
+			{
+				Name:   "map",
+				Usage:  "Show the latency between the network nodes",
+				Action: util.Print(networkMap),
+			},
+			{
+				Name:   "trace",
+				Usage:  "Trace the path of a request to a service through the network e.g micro network trace helloworld",
+				Action: util.Print(networkTrace),
+			},
+			{
+				Name:   "partitions",
+				Usage:  "Show the partitions the network has split into",
+				Action: util.Print(networkPartitions),
+			},
+			{
+				Name:   "rotate",
+				Usage:  "Force the rotation of the network tunnel keys",
+				Action: util.Print(networkRotate),
+			},
+			policyCommand,
+			simulateCommand,
 			// TODO: duplicates call. Move so we reuse same stuff.
 			{
 				Name:   "call",
@@ -128,6 +170,31 @@ func networkConnect(c *cli.Context, args []string) ([]byte, error) {
 	return b, nil
 }
 
+func networkDisconnect(c *cli.Context, args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	request := map[string]interface{}{
+		"nodes": []interface{}{
+			map[string]interface{}{
+				"address": args[0],
+			},
+		},
+	}
+
+	var rsp map[string]interface{}
+
+	req := client.DefaultClient.NewRequest("network", "Network.Disconnect", request, client.WithContentType("application/json"))
+	err := client.DefaultClient.Call(context.DefaultContext, req, &rsp, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ := json.MarshalIndent(rsp, "", "\t")
+	return b, nil
+}
+
 func networkConnections(c *cli.Context, args []string) ([]byte, error) {
 
 	request := map[string]interface{}{
@@ -174,6 +241,9 @@ func networkConnections(c *cli.Context, args []string) ([]byte, error) {
 }
 
 func networkGraph(c *cli.Context, args []string) ([]byte, error) {
+	if c.String("output") == "dot" {
+		return networkDot()
+	}
 
 	var rsp map[string]interface{}
 
@@ -187,6 +257,32 @@ func networkGraph(c *cli.Context, args []string) ([]byte, error) {
 	return b, nil
 }
 
+// networkDot renders the nodes and links of the network in the graphviz dot format, the links are
+// labelled with their latency
+func networkDot() ([]byte, error) {
+	netSrv := pb.NewNetworkService("network", client.DefaultClient)
+	rsp, err := netSrv.Graph(context.DefaultContext, &pb.GraphRequest{}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBuffer(nil)
+	fmt.Fprintln(b, "graph network {")
+	for _, node := range rsp.Nodes {
+		fmt.Fprintf(b, "\t%q [label=%q];\n", node.Id, node.Id+"\n"+node.Address)
+	}
+	for _, e := range rsp.Edges {
+		if e.Latency > 0 {
+			fmt.Fprintf(b, "\t%q -- %q [label=%q];\n", e.From, e.To, formatLatency(e.Latency))
+		} else {
+			fmt.Fprintf(b, "\t%q -- %q [style=dashed];\n", e.From, e.To)
+		}
+	}
+	fmt.Fprint(b, "}")
+
+	return b.Bytes(), nil
+}
+
 func networkNodes(c *cli.Context, args []string) ([]byte, error) {
 
 	var rsp map[string]interface{}
@@ -251,6 +347,9 @@ func networkRoutes(c *cli.Context, args []string) ([]byte, error) {
 	}
 
 	if len(rsp) == 0 {
+		if c.String("output") == "json" {
+			return []byte(`[]`), nil
+		}
 		return []byte(``), nil
 	}
 
@@ -268,6 +367,7 @@ func networkRoutes(c *cli.Context, args []string) ([]byte, error) {
 	}
 
 	var sortedRoutes [][]string
+	var jsonRoutes []map[string]interface{}
 
 	for _, r := range routes {
 		route := r.(map[string]interface{})
@@ -284,6 +384,16 @@ func networkRoutes(c *cli.Context, args []string) ([]byte, error) {
 			metInt, _ = strconv.ParseInt(route["metric"].(string), 10, 64)
 		}
 
+		jsonRoutes = append(jsonRoutes, map[string]interface{}{
+			"service": val(service),
+			"address": val(address),
+			"gateway": gateway,
+			"router":  val(router),
+			"network": val(network),
+			"metric":  metInt,
+			"link":    val(link),
+		})
+
 		// set max int64 metric to infinity
 		if metInt == math.MaxInt64 {
 			metric = "∞"
@@ -302,6 +412,13 @@ func networkRoutes(c *cli.Context, args []string) ([]byte, error) {
 		})
 	}
 
+	if c.String("output") == "json" {
+		sort.SliceStable(jsonRoutes, func(i, j int) bool {
+			return jsonRoutes[i]["service"].(string) < jsonRoutes[j]["service"].(string)
+		})
+		return json.MarshalIndent(jsonRoutes, "", "  ")
+	}
+
 	sort.Slice(sortedRoutes, func(i, j int) bool { return sortedRoutes[i][0] < sortedRoutes[j][0] })
 
 	table.AppendBulk(sortedRoutes)
@@ -339,6 +456,120 @@ func networkServices(c *cli.Context, args []string) ([]byte, error) {
 	return []byte(strings.Join(services, "\n")), nil
 }
 
+// formatLatency formats a round trip time in nanoseconds, 0 being not measured
+func formatLatency(ns int64) string {
+	if ns <= 0 {
+		return "-"
+	}
+	return time.Duration(ns).Round(time.Microsecond).String()
+}
+
+func networkMap(c *cli.Context, args []string) ([]byte, error) {
+	netSrv := pb.NewNetworkService("network", client.DefaultClient)
+	rsp, err := netSrv.Map(context.DefaultContext, &pb.MapRequest{}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make(map[string]string, len(rsp.Nodes))
+	for _, node := range rsp.Nodes {
+		addresses[node.Id] = node.Address
+	}
+
+	b := bytes.NewBuffer(nil)
+	table := tablewriter.NewWriter(b)
+	table.SetHeader([]string{"NODE", "ADDRESS", "PEER", "PEER ADDRESS", "LATENCY"})
+
+	for _, node := range rsp.Nodes {
+		peers := make([]string, 0, len(node.Latency))
+		for id := range node.Latency {
+			peers = append(peers, id)
+		}
+		sort.Strings(peers)
+
+		for _, id := range peers {
+			table.Append([]string{node.Id, node.Address, id, addresses[id], formatLatency(node.Latency[id])})
+		}
+	}
+
+	// render table into b
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	return b.Bytes(), nil
+}
+
+func networkTrace(c *cli.Context, args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("Require service to trace e.g micro network trace helloworld")
+	}
+
+	netSrv := pb.NewNetworkService("network", client.DefaultClient)
+	rsp, err := netSrv.Trace(context.DefaultContext, &pb.TraceRequest{Service: args[0]}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBuffer(nil)
+	table := tablewriter.NewWriter(b)
+	table.SetHeader([]string{"HOP", "NODE", "ADDRESS", "LATENCY"})
+
+	var total int64
+	for i, hop := range rsp.Hops {
+		total += hop.Latency
+		table.Append([]string{strconv.Itoa(i), hop.Node.Id, hop.Node.Address, formatLatency(hop.Latency)})
+	}
+
+	// render table into b
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	fmt.Fprintf(b, "%s reached at %s after %d hops, %s measured", args[0], rsp.Address, len(rsp.Hops)-1, formatLatency(total))
+	return b.Bytes(), nil
+}
+
+func networkPartitions(c *cli.Context, args []string) ([]byte, error) {
+	netSrv := pb.NewNetworkService("network", client.DefaultClient)
+	rsp, err := netSrv.Partitions(context.DefaultContext, &pb.PartitionsRequest{}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBuffer(nil)
+	table := tablewriter.NewWriter(b)
+	table.SetHeader([]string{"PARTITION", "REACHABLE", "NODE", "ADDRESS"})
+
+	for i, partition := range rsp.Partitions {
+		for _, node := range partition.Nodes {
+			table.Append([]string{strconv.Itoa(i), strconv.FormatBool(partition.Reachable), node.Id, node.Address})
+		}
+	}
+
+	// render table into b
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	if len(rsp.Partitions) > 1 {
+		fmt.Fprintf(b, "Network split into %d partitions", len(rsp.Partitions))
+	} else {
+		fmt.Fprintf(b, "Network has no partitions")
+	}
+	return b.Bytes(), nil
+}
+
+func networkRotate(c *cli.Context, args []string) ([]byte, error) {
+	var rsp map[string]interface{}
+
+	req := client.DefaultClient.NewRequest("network", "Network.Rotate", map[string]interface{}{}, client.WithContentType("application/json"))
+	err := client.DefaultClient.Call(context.DefaultContext, req, &rsp, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// the generation is a uint64 so it's encoded as a string
+	return []byte(fmt.Sprintf("Rotated the network keys to generation %v", rsp["generation"])), nil
+}
+
 // netCall calls services through the network
 func netCall(c *cli.Context, args []string) ([]byte, error) {
 	os.Setenv("MICRO_PROXY", "network")
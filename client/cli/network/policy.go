@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/micro/micro/v3/client/cli/util"
+	pb "github.com/micro/micro/v3/proto/network"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+)
+
+// policyCommand manages the network policies which allow or deny the calls between services
+var policyCommand = &cli.Command{
+	Name:  "policy",
+	Usage: "Manage the policies allowing or denying the calls between services",
+	Description: `The proxy and the network reject the calls denied by a policy with a 403. The policy with the
+	highest priority which matches a call applies, then the most specific, and the calls no
+	policy matches are allowed. The services are named exactly, by a prefix ending in * or by *.
+
+	micro network policy add --to billing --action deny
+	micro network policy add --from checkout --to billing --action allow`,
+	Subcommands: []*cli.Command{
+		{
+			Name:   "add",
+			Usage:  "Add a policy",
+			Action: util.Print(networkPolicyAdd),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "from",
+					Usage: "Set the service the calls are from, any service if blank",
+				},
+				&cli.StringFlag{
+					Name:  "to",
+					Usage: "Set the service the calls are to, any service if blank",
+				},
+				&cli.StringFlag{
+					Name:  "action",
+					Usage: "Set whether the calls are allowed or denied: allow or deny",
+					Value: "deny",
+				},
+				&cli.IntFlag{
+					Name:  "priority",
+					Usage: "Set the priority of the policy, the policies with a higher priority are checked first",
+				},
+			},
+		},
+		{
+			Name:      "delete",
+			Usage:     "Delete a policy",
+			UsageText: "micro network policy delete id",
+			Action:    util.Print(networkPolicyDelete),
+		},
+		{
+			Name:   "list",
+			Usage:  "List the policies in the order they're checked",
+			Action: util.Print(networkPolicyList),
+		},
+	},
+}
+
+func networkPolicyAdd(c *cli.Context, args []string) ([]byte, error) {
+	netSrv := pb.NewNetworkService("network", client.DefaultClient)
+	rsp, err := netSrv.AddPolicy(context.DefaultContext, &pb.AddPolicyRequest{Policy: &pb.Policy{
+		From:     c.String("from"),
+		To:       c.String("to"),
+		Action:   c.String("action"),
+		Priority: int32(c.Int("priority")),
+	}}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("Added policy %v", rsp.Policy.Id)), nil
+}
+
+func networkPolicyDelete(c *cli.Context, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("Expected one argument: id")
+	}
+	netSrv := pb.NewNetworkService("network", client.DefaultClient)
+	if _, err := netSrv.DeletePolicy(context.DefaultContext, &pb.DeletePolicyRequest{Id: args[0]}, client.WithAuthToken()); err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("Deleted policy %v", args[0])), nil
+}
+
+func networkPolicyList(c *cli.Context, args []string) ([]byte, error) {
+	netSrv := pb.NewNetworkService("network", client.DefaultClient)
+	rsp, err := netSrv.ListPolicies(context.DefaultContext, &pb.ListPoliciesRequest{}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBuffer(nil)
+	table := tablewriter.NewWriter(b)
+	table.SetHeader([]string{"ID", "FROM", "TO", "ACTION", "PRIORITY", "CREATED"})
+
+	for _, p := range rsp.Policies {
+		created := time.Unix(p.Created, 0).Format(time.RFC3339)
+		table.Append([]string{p.Id, p.From, p.To, p.Action, strconv.Itoa(int(p.Priority)), created})
+	}
+
+	// render table into b
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	return b.Bytes(), nil
+}
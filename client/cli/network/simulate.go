@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/service/network/simulator"
+	"github.com/micro/micro/v3/service/router"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+)
+
+// simulateCommand runs a network in memory to validate the routing and adverts of a topology
+var simulateCommand = &cli.Command{
+	Name:  "simulate",
+	Usage: "Simulate a network in memory to validate its routing and adverts",
+	Description: `Runs the nodes of a topology in memory, connected by links with the latency and loss set,
+	registers a service named after each node and reports how long the routes take to reach
+	every node. With a partition the nodes listed are cut off from the rest until the routes
+	across it expire, then the network is healed. The logs of the nodes are hidden with
+	--log_level=error.
+
+	micro network simulate --topology ring --nodes 5 --link latency=20ms,jitter=5ms,loss=0.01
+	micro network simulate --topology line --partition node3 --advertise_ttl 10s`,
+	Action: util.Print(networkSimulate),
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "topology",
+			Usage: "Set the topology of the network: mesh, ring, line or star",
+			Value: "mesh",
+		},
+		&cli.IntFlag{
+			Name:  "nodes",
+			Usage: "Set the number of nodes, named node1 to nodeN",
+			Value: 3,
+		},
+		&cli.StringFlag{
+			Name:  "link",
+			Usage: "Set the conditions of the links between the nodes e.g. latency=20ms,jitter=5ms,loss=0.01",
+		},
+		&cli.Int64Flag{
+			Name:  "seed",
+			Usage: "Set the seed of the random latency and loss to repeat a run, random if 0",
+		},
+		&cli.StringFlag{
+			Name:  "advertise_strategy",
+			Usage: "Set the strategy the routes are advertised to the network with: all, best, local or none",
+			Value: "all",
+		},
+		&cli.DurationFlag{
+			Name:  "advertise_ttl",
+			Usage: "Set how long the routes learned from the network live unless advertised again, 0 keeps them until their node leaves",
+			Value: 10 * time.Second,
+		},
+		&cli.StringFlag{
+			Name:  "advertise_filter",
+			Usage: "Filter the routes advertised to the network by service e.g. service=node1.*",
+		},
+		&cli.StringFlag{
+			Name:  "accept_filter",
+			Usage: "Filter the routes accepted from the network by service e.g. deny=node2.*",
+		},
+		&cli.StringFlag{
+			Name:  "partition",
+			Usage: "Set the nodes to cut off from the rest of the network once converged e.g. node1,node2",
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "Set how long to wait for the network to connect and converge",
+			Value: time.Minute,
+		},
+	},
+}
+
+func networkSimulate(c *cli.Context, args []string) ([]byte, error) {
+	top, err := simulator.NewTopology(c.String("topology"), c.Int("nodes"))
+	if err != nil {
+		return nil, err
+	}
+	link, err := simulator.ParseLink(c.String("link"))
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := router.ParseStrategy(c.String("advertise_strategy"))
+	if err != nil {
+		return nil, err
+	}
+	advertiseFilter, err := router.ParseServiceFilter(c.String("advertise_filter"))
+	if err != nil {
+		return nil, err
+	}
+	acceptFilter, err := router.ParseServiceFilter(c.String("accept_filter"))
+	if err != nil {
+		return nil, err
+	}
+
+	var partition []string
+	for _, name := range strings.Split(c.String("partition"), ",") {
+		if name = strings.TrimSpace(name); len(name) == 0 {
+			continue
+		}
+		if _, ok := top[name]; !ok {
+			return nil, fmt.Errorf("unknown node %s to partition", name)
+		}
+		partition = append(partition, name)
+	}
+
+	opts := []simulator.Option{simulator.DefaultLink(link)}
+	if seed := c.Int64("seed"); seed != 0 {
+		opts = append(opts, simulator.Seed(seed))
+	}
+	sim := simulator.New(opts...)
+	defer sim.Close()
+
+	timeout := c.Duration("timeout")
+	b := bytes.NewBuffer(nil)
+	fmt.Fprintf(b, "Simulating a %s of %d nodes with %v\n", c.String("topology"), len(top), link)
+
+	start := time.Now()
+	err = sim.Start(top,
+		router.Advertise(strategy),
+		router.AdvertiseTTL(c.Duration("advertise_ttl")),
+		router.AdvertiseFilter(advertiseFilter),
+		router.AcceptFilter(acceptFilter),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := sim.WaitFor(timeout, sim.Connected); err != nil {
+		return nil, fmt.Errorf("the nodes didn't connect within %v", timeout)
+	}
+	fmt.Fprintf(b, "Connected in %v\n", time.Since(start).Round(time.Millisecond))
+
+	for _, name := range sim.Nodes() {
+		node, _ := sim.Node(name)
+		if err := node.Register(name + ".service"); err != nil {
+			return nil, err
+		}
+	}
+
+	// the filters can keep the network from ever converging, so it's reported rather than an error
+	start = time.Now()
+	if err := sim.WaitFor(timeout, sim.Converged); err != nil {
+		fmt.Fprintf(b, "Not converged within %v\n", timeout)
+	} else {
+		fmt.Fprintf(b, "Converged in %v\n", time.Since(start).Round(time.Millisecond))
+	}
+	renderNodes(b, sim)
+
+	if len(partition) == 0 {
+		return b.Bytes(), nil
+	}
+
+	sim.Partition(partition)
+	start = time.Now()
+	if err := sim.WaitFor(timeout, func() bool { return partitioned(sim) }); err != nil {
+		fmt.Fprintf(b, "\nPartitioned %s, the routes across it didn't expire within %v\n", strings.Join(partition, ","), timeout)
+	} else {
+		fmt.Fprintf(b, "\nPartitioned %s, the routes across it expired in %v\n", strings.Join(partition, ","), time.Since(start).Round(time.Millisecond))
+	}
+	renderNodes(b, sim)
+
+	sim.Heal()
+	start = time.Now()
+	if err := sim.WaitFor(timeout, sim.Converged); err != nil {
+		fmt.Fprintf(b, "\nHealed, not converged within %v\n", timeout)
+	} else {
+		fmt.Fprintf(b, "\nHealed, converged in %v\n", time.Since(start).Round(time.Millisecond))
+	}
+	renderNodes(b, sim)
+
+	return b.Bytes(), nil
+}
+
+// partitioned returns true if no node has a route to the service of a node it can't reach
+func partitioned(sim *simulator.Simulator) bool {
+	for _, name := range sim.Nodes() {
+		node, _ := sim.Node(name)
+		for _, other := range sim.Nodes() {
+			if sim.Reachable(name, other) {
+				continue
+			}
+			if routes, err := node.Routes(other + ".service"); err != nil || len(routes) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func renderNodes(b *bytes.Buffer, sim *simulator.Simulator) {
+	services := sim.Services()
+
+	table := tablewriter.NewWriter(b)
+	table.SetHeader([]string{"NODE", "ADDRESS", "PEERS", "ROUTES", "SERVICES", "ADVERTS SENT", "ADVERTS RECEIVED"})
+
+	for _, name := range sim.Nodes() {
+		node, _ := sim.Node(name)
+		var routes, reached int
+		for _, service := range services {
+			rts, _ := node.Routes(service)
+			routes += len(rts)
+			if len(rts) > 0 {
+				reached++
+			}
+		}
+		stats := node.Stats()
+		table.Append([]string{
+			name,
+			node.Address,
+			strings.Join(node.Peers(), ","),
+			strconv.Itoa(routes),
+			fmt.Sprintf("%d/%d", reached, len(services)),
+			strconv.FormatUint(stats.AdvertsSent, 10),
+			strconv.FormatUint(stats.AdvertsReceived, 10),
+		})
+	}
+
+	// render table into b
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+}
@@ -0,0 +1,51 @@
+// Package report provides the micro report command which dumps the usage recorded locally
+// with --telemetry
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/micro/micro/v3/cmd"
+	"github.com/micro/micro/v3/internal/telemetry"
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	cmd.Register(&cli.Command{
+		Name:  "report",
+		Usage: "Dump the anonymous usage recorded locally with --telemetry",
+		Description: `'micro report' prints the usage aggregated in ~/.micro/telemetry.json as json.
+	Usage is only recorded when micro is run with --telemetry or MICRO_TELEMETRY=true and is never sent.`,
+		Action: Run,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "reset",
+				Usage: "Delete the usage recorded",
+			},
+		},
+	})
+}
+
+// Run the report command
+func Run(ctx *cli.Context) error {
+	if ctx.Bool("reset") {
+		return telemetry.Reset()
+	}
+
+	r, err := telemetry.Load()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+
+	if !telemetry.Enabled() {
+		fmt.Fprintln(os.Stderr, "Telemetry is disabled, enable it with --telemetry or MICRO_TELEMETRY=true")
+	}
+	return nil
+}
@@ -28,10 +28,59 @@ var flags = []cli.Flag{
 		Name:  "type",
 		Usage: "The type of service operate on",
 	},
+	&cli.StringFlag{
+		Name:  "schedule",
+		Usage: "Set the cron schedule to run a job on e.g. \"0 3 * * *\"",
+	},
 	&cli.StringSliceFlag{
 		Name:  "env_vars",
 		Usage: "Set the environment variables e.g. foo=bar",
 	},
+	&cli.StringSliceFlag{
+		Name:  "config_refs",
+		Usage: "Set environment variables from config when the service is started e.g. DB_ADDRESS=db.address",
+	},
+	&cli.StringSliceFlag{
+		Name:  "secret_refs",
+		Usage: "Set secrets from config secrets when the service is started e.g. DB_PASSWORD=db.password",
+	},
+	&cli.BoolFlag{
+		Name:  "rotate",
+		Usage: "Restart the service when the secrets it references are changed",
+	},
+	&cli.StringSliceFlag{
+		Name:  "dependencies",
+		Usage: "Set the services which must be running before the service is started e.g. store",
+	},
+	&cli.StringFlag{
+		Name:  "prebuilt",
+		Usage: "Set the url or blob store key of a prebuilt binary to run instead of building the source",
+	},
+	&cli.StringFlag{
+		Name:  "os",
+		Usage: "Set the OS to cross compile the service for e.g. linux, the OS of the server if blank",
+	},
+	&cli.StringFlag{
+		Name:  "arch",
+		Usage: "Set the architecture to cross compile the service for e.g. arm64, the architecture of the server if blank",
+	},
+	&cli.StringFlag{
+		Name:  "build_flags",
+		Usage: "Set the flags to pass to the build of the service e.g. \"-trimpath -tags netgo\"",
+	},
+	&cli.StringFlag{
+		Name:  "artifact",
+		Usage: "Run the artifact of a version of a service pushed with micro artifact push e.g. helloworld@v1.2.0",
+	},
+	&cli.BoolFlag{
+		Name:  "watch",
+		Usage: "Rebuild and restart the service when its local source changes",
+	},
+	&cli.StringFlag{
+		Name:    "file",
+		Aliases: []string{"f"},
+		Usage:   "Set the manifest file declaring the service e.g. micro.yaml",
+	},
 }
 
 func init() {
@@ -46,7 +95,14 @@ func init() {
 			micro run ../path/to/folder # deploy local folder to your local micro server
 			micro run helloworld # deploy latest version, translates to micro run github.com/micro/services/helloworld
 			micro run helloworld@9342934e6180 # deploy certain version
-			micro run helloworld@branchname	# deploy certain branch`,
+			micro run helloworld@branchname	# deploy certain branch
+			micro run --type job --schedule "0 3 * * *" ./cleanup # run a job every day at 3am
+			micro run -f micro.yaml # run the service declared in the manifest, replacing it if the manifest changed
+			micro run --prebuilt https://example.com/helloworld helloworld # run a prebuilt binary
+			micro run --artifact helloworld@v1.2.0 # run a version pushed with micro artifact push
+			micro run --os linux --arch arm64 ./helloworld # cross compile for an arm64 linux runtime
+			micro run --secret_refs DB_PASSWORD=db.password --rotate . # restart when the secret is changed
+			micro run --watch ./helloworld # rebuild and restart when the source changes`,
 			Flags:  flags,
 			Action: runService,
 		},
@@ -75,8 +131,14 @@ func init() {
 			Action: killService,
 		},
 		&cli.Command{
-			Name:   "status",
-			Usage:  GetUsage,
+			Name:    "status",
+			Aliases: []string{"ps"},
+			Usage:   GetUsage,
+			Description: `Examples:
+			micro status # get the status of all services
+			micro ps # the same as micro status
+			micro status helloworld # get the status of a service
+			micro status -f micro.yaml # compare the service declared in the manifest to the running service`,
 			Flags:  flags,
 			Action: getService,
 		},
@@ -110,5 +172,52 @@ func init() {
 				},
 			},
 		},
+		&cli.Command{
+			Name:  "export",
+			Usage: "Export the deployment manifests of a service",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "kubernetes",
+					Usage: ExportUsage,
+					Description: `Examples:
+			micro export kubernetes helloworld # print the manifests of the running helloworld service
+			micro export kubernetes -f micro.yaml -o deploy # write the manifests of the service declared in the manifest
+			micro export kubernetes --helm --replicas 3 helloworld # write a helm chart to ./helloworld`,
+					Flags:  exportFlags,
+					Action: exportKubernetes,
+				},
+			},
+		},
+		&cli.Command{
+			Name:  "scale",
+			Usage: ScaleUsage,
+			Description: `Examples:
+			micro scale helloworld --replicas 3 # run 3 instances of helloworld
+			micro scale helloworld --min 1 --max 5 --metric requests --threshold 100 # scale helloworld at 100 requests per second per instance`,
+			Action: scaleService,
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  "replicas",
+					Usage: "Set the number of instances of the service to run",
+				},
+				&cli.IntFlag{
+					Name:  "min",
+					Usage: "Set the minimum number of instances when autoscaling",
+					Value: 1,
+				},
+				&cli.IntFlag{
+					Name:  "max",
+					Usage: "Set the maximum number of instances when autoscaling",
+				},
+				&cli.StringFlag{
+					Name:  "metric",
+					Usage: "Set the metric to autoscale on: memory (MiB), threads, requests or errors (per second)",
+				},
+				&cli.Float64Flag{
+					Name:  "threshold",
+					Usage: "Set the value of the metric per instance at which to scale up",
+				},
+			},
+		},
 	)
 }
@@ -0,0 +1,306 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/micro/micro/v3/client/cli/namespace"
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/internal/kubernetes/client"
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/micro/micro/v3/service/runtime/local/source/git"
+	"github.com/urfave/cli/v2"
+)
+
+// ExportUsage message for the export kubernetes command
+const ExportUsage = "Export the kubernetes manifests of a service: micro export kubernetes [service]"
+
+// exportFlags are the flags of the export kubernetes command
+var exportFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "file",
+		Aliases: []string{"f"},
+		Usage:   "Set the manifest file declaring the service e.g. micro.yaml",
+	},
+	&cli.StringFlag{
+		Name:  "source",
+		Usage: "Set the source url the service is built from e.g github.com/micro/services/helloworld",
+	},
+	&cli.StringFlag{
+		Name:  "image",
+		Usage: "Set the image to use for the container",
+	},
+	&cli.StringSliceFlag{
+		Name:  "env_vars",
+		Usage: "Set the environment variables e.g. foo=bar",
+	},
+	&cli.IntFlag{
+		Name:  "replicas",
+		Usage: "Set the number of instances of the service to run",
+	},
+	&cli.StringFlag{
+		Name:  "port",
+		Usage: "Set the port the service listens on",
+	},
+	&cli.StringFlag{
+		Name:  "kube_namespace",
+		Usage: "Set the kubernetes namespace, defaults to the micro namespace",
+	},
+	&cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Usage:   "Set the directory to write the manifests to rather than stdout",
+	},
+	&cli.BoolFlag{
+		Name:  "helm",
+		Usage: "Write a helm chart to the output directory, which defaults to the service name",
+	},
+}
+
+// exportKubernetes renders the deployment, service and config map of a service from its runtime
+// spec, a manifest and the flags
+func exportKubernetes(ctx *cli.Context) error {
+	srv, opts, err := exportService(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the env vars and secrets which are resolved by the micro runtime aren't available
+	if len(opts.ConfigRefs) > 0 {
+		fmt.Fprintln(os.Stderr, "Config references aren't exported, set them with --env_vars")
+	}
+	if len(opts.SecretRefs) > 0 {
+		opts.Secrets = make(map[string]string, len(opts.SecretRefs))
+		for key := range opts.SecretRefs {
+			opts.Secrets[key] = ""
+		}
+		fmt.Fprintf(os.Stderr, "Create the secret %v-%v with the keys of the secret references\n",
+			client.Format(srv.Name), client.Format(srv.Version))
+	}
+
+	if ctx.Bool("helm") {
+		dir := ctx.String("output")
+		if len(dir) == 0 {
+			dir = client.Format(srv.Name)
+		}
+		return writeChart(dir, srv, opts)
+	}
+
+	resources := kubernetesResources(srv, opts)
+
+	// write each resource to a file in the output dir
+	if dir := ctx.String("output"); len(dir) > 0 {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		for _, r := range resources {
+			b := new(bytes.Buffer)
+			if err := client.Render(b, r); err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filepath.Join(dir, r.Kind+".yaml"), b.Bytes(), 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return renderResources(os.Stdout, resources)
+}
+
+// exportService returns the service and the options to export. The spec of the running service
+// is used, or that of the manifest if one is provided, and the flags are then applied.
+func exportService(ctx *cli.Context) (*runtime.Service, *runtime.CreateOptions, error) {
+	opts := &runtime.CreateOptions{
+		Namespace: client.DefaultNamespace,
+	}
+
+	var srv *runtime.Service
+
+	if file := ctx.String("file"); len(file) > 0 {
+		m, err := loadManifest(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		source, err := git.ParseSourceLocal(m.dir, appendSourceBase(ctx, m.dir, m.Source, false))
+		if err != nil {
+			return nil, nil, err
+		}
+		srv = &runtime.Service{
+			Name:    m.Name,
+			Version: source.Ref,
+			Source:  source.RuntimeSource(),
+		}
+		if len(srv.Name) == 0 {
+			srv.Name = source.RuntimeName()
+		}
+		for _, o := range m.Options() {
+			o(opts)
+		}
+	} else if ctx.Args().Len() == 0 {
+		return nil, nil, cli.ShowSubcommandHelp(ctx)
+	} else {
+		env, err := util.GetEnv(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		ns, err := namespace.Get(env.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.Namespace = ns
+
+		// use the spec of the running service, otherwise the arg is the source
+		arg := prebuiltService(ctx.Args().Get(0))
+		services, err := runtime.Read(runtime.ReadService(arg.Name), runtime.ReadNamespace(ns))
+		if err != nil {
+			return nil, nil, util.CliError(err)
+		}
+		version := ""
+		if strings.Contains(ctx.Args().Get(0), "@") {
+			version = arg.Version
+		}
+		if s := findService(services, arg.Name, version); s != nil {
+			srv = &runtime.Service{
+				Name:    s.Name,
+				Version: s.Version,
+				Source:  s.Source,
+			}
+			// local source is uploaded so use the source it was run from
+			if src := s.Metadata["source"]; len(src) > 0 {
+				srv.Source = src
+			}
+		} else {
+			srv = &runtime.Service{
+				Name:    arg.Name,
+				Version: arg.Version,
+				Source:  arg.Name,
+			}
+		}
+	}
+
+	if len(srv.Version) == 0 {
+		srv.Version = "latest"
+	}
+	if len(opts.Type) == 0 {
+		opts.Type = "service"
+	}
+
+	// apply the flags
+	if source := ctx.String("source"); len(source) > 0 {
+		srv.Source = source
+	}
+	if image := ctx.String("image"); len(image) > 0 {
+		opts.Image = image
+	}
+	for _, evar := range ctx.StringSlice("env_vars") {
+		for _, e := range strings.Split(evar, ",") {
+			if len(e) > 0 {
+				opts.Env = append(opts.Env, strings.TrimSpace(e))
+			}
+		}
+	}
+	if replicas := ctx.Int("replicas"); replicas > 0 {
+		opts.Replicas = replicas
+	}
+	if port := ctx.String("port"); len(port) > 0 {
+		opts.Port = port
+	}
+	if ns := ctx.String("kube_namespace"); len(ns) > 0 {
+		opts.Namespace = ns
+	}
+
+	if _, err := os.Stat(srv.Source); err == nil {
+		fmt.Fprintf(os.Stderr, "%v is a local folder, set a git source with --source to build it in the cluster\n", srv.Source)
+	}
+
+	return srv, opts, nil
+}
+
+// kubernetesResources returns the resources the kubernetes runtime creates for the service, with
+// the env vars moved to a config map
+func kubernetesResources(srv *runtime.Service, opts *runtime.CreateOptions) []*client.Resource {
+	deployment := client.NewDeployment(srv, opts)
+	service := client.NewService(srv, opts)
+	if len(opts.Env) == 0 {
+		return []*client.Resource{deployment, service}
+	}
+
+	configMap := client.NewConfigMap(srv, opts)
+	client.FromConfigMap(deployment, configMap)
+	return []*client.Resource{configMap, deployment, service}
+}
+
+// renderResources writes the resources as a multi document yaml stream
+func renderResources(w io.Writer, resources []*client.Resource) error {
+	for _, r := range resources {
+		if _, err := fmt.Fprint(w, "---"); err != nil {
+			return err
+		}
+		if err := client.Render(w, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeChart writes a helm chart for the service to dir. The image and replicas are set from the
+// chart's values and the resources are created in the release namespace.
+func writeChart(dir string, srv *runtime.Service, opts *runtime.CreateOptions) error {
+	image := opts.Image
+	if len(image) == 0 {
+		image = client.DefaultImage
+	}
+	replicas := opts.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	// render the templates with placeholders for the values
+	opts.Image = "{{ .Values.image }}"
+	resources := kubernetesResources(srv, opts)
+
+	files := map[string]string{
+		"Chart.yaml": fmt.Sprintf("apiVersion: v2\nname: %s\ndescription: The %s micro service\n"+
+			"type: application\nversion: 0.1.0\nappVersion: %q\n", client.Format(srv.Name), srv.Name, srv.Version),
+		"values.yaml": fmt.Sprintf("image: %s\nreplicas: %d\n", image, replicas),
+	}
+
+	for _, r := range resources {
+		switch v := r.Value.(type) {
+		case *client.Deployment:
+			v.Metadata.Namespace = "{{ .Release.Namespace }}"
+		case *client.Service:
+			v.Metadata.Namespace = "{{ .Release.Namespace }}"
+		case *client.ConfigMap:
+			v.Metadata.Namespace = "{{ .Release.Namespace }}"
+		}
+
+		b := new(bytes.Buffer)
+		if err := client.Render(b, r); err != nil {
+			return err
+		}
+		tmpl := b.String()
+		if r.Kind == "deployment" {
+			tmpl = strings.Replace(tmpl, fmt.Sprintf("\n  replicas: %d\n", replicas),
+				"\n  replicas: {{ .Values.replicas }}\n", 1)
+		}
+		files[filepath.Join("templates", r.Kind+".yaml")] = tmpl
+	}
+
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package runtime
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderResources(t *testing.T) {
+	srv := &runtime.Service{Name: "helloworld", Version: "latest", Source: "github.com/micro/services/helloworld"}
+	opts := &runtime.CreateOptions{
+		Type:      "service",
+		Namespace: "default",
+		Env:       []string{"FOO=bar=baz"},
+		Replicas:  2,
+	}
+
+	resources := kubernetesResources(srv, opts)
+	if !assert.Len(t, resources, 3) {
+		return
+	}
+	assert.Equal(t, "configmap", resources[0].Kind)
+
+	b := new(bytes.Buffer)
+	if !assert.NoError(t, renderResources(b, resources)) {
+		return
+	}
+	out := b.String()
+	assert.Equal(t, 3, strings.Count(out, "---"))
+	assert.Contains(t, out, `FOO: "bar=baz"`)
+	assert.Contains(t, out, "configMapKeyRef:")
+	assert.Contains(t, out, "replicas: 2")
+}
+
+func TestWriteChart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chart")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	srv := &runtime.Service{Name: "helloworld", Version: "latest"}
+	opts := &runtime.CreateOptions{Type: "service", Namespace: "default", Replicas: 3}
+	if !assert.NoError(t, writeChart(dir, srv, opts)) {
+		return
+	}
+
+	values, err := ioutil.ReadFile(filepath.Join(dir, "values.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "image: micro/cells:v3\nreplicas: 3\n", string(values))
+
+	dep, err := ioutil.ReadFile(filepath.Join(dir, "templates", "deployment.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Contains(t, string(dep), "replicas: {{ .Values.replicas }}")
+	assert.Contains(t, string(dep), "image: {{ .Values.image }}")
+	assert.Contains(t, string(dep), `namespace: "{{ .Release.Namespace }}"`)
+
+	_, err = os.Stat(filepath.Join(dir, "Chart.yaml"))
+	assert.NoError(t, err)
+}
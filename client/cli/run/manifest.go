@@ -0,0 +1,319 @@
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/micro/micro/v3/client/cli/namespace"
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/micro/micro/v3/service/runtime/local/source/git"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestKey is the metadata key which holds the checksum of the manifest a service was run from
+const manifestKey = "manifest"
+
+// Manifest declares the desired state of a service, e.g.
+//
+//	name: helloworld
+//	source: ./helloworld
+//	env:
+//	  FOO: bar
+//	replicas: 2
+//	resources:
+//	  cpu: 200
+//	  mem: 128
+//	dependencies:
+//	  - greeter
+//	secrets:
+//	  DB_PASSWORD: db.password
+//	rotate: true
+type Manifest struct {
+	// Name of the service, defaults to the name derived from the source
+	Name string `yaml:"name" json:"name"`
+	// Source of the service, local paths are relative to the manifest
+	Source string `yaml:"source" json:"source"`
+	// Type of service, e.g. job
+	Type string `yaml:"type" json:"type"`
+	// Schedule to run a job on
+	Schedule string `yaml:"schedule" json:"schedule"`
+	// Image to run the service with
+	Image string `yaml:"image" json:"image"`
+	// Command and args to exec
+	Command string `yaml:"command" json:"command"`
+	Args    string `yaml:"args" json:"args"`
+	// Env vars to set for the service
+	Env map[string]string `yaml:"env" json:"env"`
+	// Replicas of the service to run
+	Replicas int `yaml:"replicas" json:"replicas"`
+	// Resources to allocate the service
+	Resources *ManifestResources `yaml:"resources" json:"resources"`
+	// Dependencies are the services which must be healthy before this one is started
+	Dependencies []string `yaml:"dependencies" json:"dependencies"`
+	// Config paths to set env vars from, keyed by the env var
+	Config map[string]string `yaml:"config" json:"config"`
+	// Secrets are the config secret paths to set secrets from, keyed by the env var
+	Secrets map[string]string `yaml:"secrets" json:"secrets"`
+	// Rotate restarts the service when its secrets change
+	Rotate bool `yaml:"rotate" json:"rotate"`
+
+	// dir the manifest was loaded from
+	dir string
+}
+
+// ManifestResources are the resource limits of a service in a manifest
+type ManifestResources struct {
+	CPU  int `yaml:"cpu" json:"cpu"`
+	Mem  int `yaml:"mem" json:"mem"`
+	Disk int `yaml:"disk" json:"disk"`
+}
+
+// loadManifest reads and validates a manifest file
+func loadManifest(path string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := parseManifest(b)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing %v: %v", path, err)
+	}
+	if m.dir, err = filepath.Abs(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func parseManifest(b []byte) (*Manifest, error) {
+	var m Manifest
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if len(m.Source) == 0 {
+		return nil, fmt.Errorf("missing source")
+	}
+	if m.Replicas < 0 {
+		return nil, fmt.Errorf("invalid replicas %v", m.Replicas)
+	}
+	return &m, nil
+}
+
+// Checksum of the manifest, used to determine if a service matches it
+func (m *Manifest) Checksum() string {
+	// json marshals the env in a consistent order
+	b, _ := json.Marshal(m)
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:16]
+}
+
+// Options to create the service with
+func (m *Manifest) Options() []runtime.CreateOption {
+	opts := []runtime.CreateOption{
+		runtime.CreateType(m.Type),
+		runtime.CreateImage(m.Image),
+	}
+	if len(m.Schedule) > 0 {
+		opts = append(opts, runtime.WithSchedule(m.Schedule))
+	}
+	if len(m.Command) > 0 {
+		opts = append(opts, runtime.WithCommand(strings.Split(m.Command, " ")...))
+	}
+	if len(m.Args) > 0 {
+		opts = append(opts, runtime.WithArgs(strings.Split(m.Args, " ")...))
+	}
+	if len(m.Env) > 0 {
+		env := make([]string, 0, len(m.Env))
+		for k, v := range m.Env {
+			env = append(env, k+"="+v)
+		}
+		sort.Strings(env)
+		opts = append(opts, runtime.WithEnv(env))
+	}
+	if m.Replicas > 0 {
+		opts = append(opts, runtime.WithReplicas(m.Replicas))
+	}
+	if r := m.Resources; r != nil {
+		opts = append(opts, runtime.ResourceLimits(&runtime.Resources{CPU: r.CPU, Mem: r.Mem, Disk: r.Disk}))
+	}
+	if len(m.Dependencies) > 0 {
+		opts = append(opts, runtime.WithDependencies(m.Dependencies...))
+	}
+	for env, path := range m.Config {
+		opts = append(opts, runtime.WithConfigRef(env, path))
+	}
+	for env, path := range m.Secrets {
+		opts = append(opts, runtime.WithSecretRef(env, path))
+	}
+	if m.Rotate {
+		opts = append(opts, runtime.WithRotation(true))
+	}
+	return opts
+}
+
+// Diff returns the differences between the manifest and the running services, the service
+// matching the manifest must already have been resolved
+func (m *Manifest) Diff(srv *runtime.Service, services []*runtime.Service) []string {
+	var diff []string
+	for _, dep := range m.Dependencies {
+		if findService(services, dep, "") == nil {
+			diff = append(diff, fmt.Sprintf("dependency %v is not running", dep))
+		}
+	}
+
+	if srv == nil {
+		return append(diff, "service is not running")
+	}
+	if srv.Metadata[manifestKey] != m.Checksum() {
+		diff = append(diff, "manifest has changed since the service was run")
+	}
+	if srv.Status != runtime.Running && srv.Status != runtime.Starting {
+		diff = append(diff, fmt.Sprintf("service is %v", humanizeStatus(srv.Status)))
+	}
+	return diff
+}
+
+// findService returns the service matching the name and, if set, the version
+func findService(services []*runtime.Service, name, version string) *runtime.Service {
+	for _, s := range services {
+		if s.Name != name {
+			continue
+		}
+		if len(version) > 0 && s.Version != version {
+			continue
+		}
+		return s
+	}
+	return nil
+}
+
+// resolve the name and version of the service the manifest declares
+func (m *Manifest) resolve(ctx *cli.Context) (string, string, error) {
+	source, err := git.ParseSourceLocal(m.dir, appendSourceBase(ctx, m.dir, m.Source, false))
+	if err != nil {
+		return "", "", err
+	}
+	name := m.Name
+	if len(name) == 0 {
+		name = source.RuntimeName()
+	}
+	return name, source.Ref, nil
+}
+
+// applyManifest runs the service declared in the manifest. If the service is already running from
+// the same manifest nothing is changed, otherwise the service is replaced.
+func applyManifest(ctx *cli.Context, path string) error {
+	m, err := loadManifest(path)
+	if err != nil {
+		return err
+	}
+	name, version, err := m.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	// determine the namespace
+	env, err := util.GetEnv(ctx)
+	if err != nil {
+		return err
+	}
+	ns, err := namespace.Get(env.Name)
+	if err != nil {
+		return err
+	}
+
+	services, err := runtime.Read(runtime.ReadNamespace(ns))
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	// nothing to do if the service was run from the same manifest
+	existing := findService(services, name, version)
+	if existing != nil && existing.Metadata[manifestKey] == m.Checksum() {
+		fmt.Printf("%v is up to date\n", name)
+		return nil
+	}
+
+	srv, source, err := sourceService(ctx, m.dir, m.Source)
+	if err != nil {
+		return err
+	}
+	srv.Name = name
+	srv.Metadata[manifestKey] = m.Checksum()
+
+	opts := append(m.Options(),
+		runtime.WithOutput(os.Stdout),
+		runtime.WithRetries(DefaultRetries),
+		runtime.CreateNamespace(ns),
+	)
+	if source.Local && source.LocalRepoRoot != source.FullPath {
+		ep, _ := filepath.Rel(source.LocalRepoRoot, source.FullPath)
+		opts = append(opts, runtime.CreateEntrypoint(ep))
+	}
+	if gitCreds, ok := getGitCredentials(source.Repo); ok {
+		opts = append(opts, runtime.WithSecret(credentialsKey, gitCreds))
+	}
+
+	// the options of a service can't be updated so replace the existing one
+	if existing != nil {
+		if err := runtime.Delete(existing, runtime.DeleteNamespace(ns)); err != nil {
+			return util.CliError(err)
+		}
+	}
+	if err := runtime.Create(srv, opts...); err != nil {
+		return util.CliError(err)
+	}
+
+	if existing != nil {
+		fmt.Printf("%v updated\n", name)
+	} else {
+		fmt.Printf("%v created\n", name)
+	}
+	return nil
+}
+
+// diffManifest prints the differences between the desired state in the manifest and the actual
+// state of the service
+func diffManifest(ctx *cli.Context, path string) error {
+	m, err := loadManifest(path)
+	if err != nil {
+		return err
+	}
+	name, version, err := m.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	// determine the namespace
+	env, err := util.GetEnv(ctx)
+	if err != nil {
+		return err
+	}
+	ns, err := namespace.Get(env.Name)
+	if err != nil {
+		return err
+	}
+
+	services, err := runtime.Read(runtime.ReadNamespace(ns))
+	if err != nil {
+		return util.CliError(err)
+	}
+
+	diff := m.Diff(findService(services, name, version), services)
+	state := "in sync"
+	if len(diff) > 0 {
+		state = "out of sync"
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAME\tVERSION\tSTATE\tDIFF")
+	fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", name, version, state, strings.Join(diff, ", "))
+	return writer.Flush()
+}
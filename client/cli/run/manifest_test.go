@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+const testManifest = `
+name: helloworld
+source: ./helloworld
+command: go run .
+env:
+  FOO: bar
+  BAZ: qux
+replicas: 2
+resources:
+  cpu: 200
+  mem: 128
+dependencies:
+  - greeter
+secrets:
+  DB_PASSWORD: db.password
+rotate: true
+`
+
+func TestParseManifest(t *testing.T) {
+	m, err := parseManifest([]byte(testManifest))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "helloworld", m.Name)
+	assert.Equal(t, 2, m.Replicas)
+	assert.Equal(t, &ManifestResources{CPU: 200, Mem: 128}, m.Resources)
+	assert.Equal(t, []string{"greeter"}, m.Dependencies)
+
+	var opts runtime.CreateOptions
+	for _, o := range m.Options() {
+		o(&opts)
+	}
+	assert.Equal(t, []string{"go", "run", "."}, opts.Command)
+	assert.Equal(t, []string{"BAZ=qux", "FOO=bar"}, opts.Env)
+	assert.Equal(t, 2, opts.Replicas)
+	assert.Equal(t, 200, opts.Resources.CPU)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": "db.password"}, opts.SecretRefs)
+	assert.True(t, opts.Rotate)
+
+	// the checksum only changes when the manifest does
+	m2, _ := parseManifest([]byte(testManifest))
+	assert.Equal(t, m.Checksum(), m2.Checksum())
+	m2.Replicas = 3
+	assert.NotEqual(t, m.Checksum(), m2.Checksum())
+
+	_, err = parseManifest([]byte("name: foo"))
+	assert.Error(t, err, "Expected an error for a manifest without a source")
+}
+
+func TestManifestDiff(t *testing.T) {
+	m, err := parseManifest([]byte(testManifest))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	greeter := &runtime.Service{Name: "greeter", Status: runtime.Running}
+	srv := &runtime.Service{
+		Name:     "helloworld",
+		Status:   runtime.Running,
+		Metadata: map[string]string{manifestKey: m.Checksum()},
+	}
+
+	assert.Empty(t, m.Diff(srv, []*runtime.Service{greeter, srv}))
+	assert.Equal(t, []string{"dependency greeter is not running"}, m.Diff(srv, []*runtime.Service{srv}))
+	assert.Equal(t, []string{"service is not running"}, m.Diff(nil, []*runtime.Service{greeter}))
+
+	srv.Metadata[manifestKey] = "old"
+	srv.Status = runtime.Error
+	assert.Len(t, m.Diff(srv, []*runtime.Service{greeter, srv}), 2)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/micro/micro/v3/client/cli/namespace"
 	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/internal/artifact"
 	"github.com/micro/micro/v3/internal/config"
 	run "github.com/micro/micro/v3/internal/runtime"
 	"github.com/micro/micro/v3/service/logger"
@@ -33,6 +34,8 @@ const (
 	KillUsage = "Kill a service: micro kill [source]"
 	// UpdateUsage message for the update command
 	UpdateUsage = "Update a service: micro update [source]"
+	// ScaleUsage message for the scale command
+	ScaleUsage = "Scale a service: micro scale [service] --replicas N"
 	// GetUsage message for micro get command
 	GetUsage = "Get the status of services"
 	// ServicesUsage message for micro services command
@@ -198,43 +201,22 @@ func appendSourceBase(ctx *cli.Context, workDir, source string, matchExistingSer
 	return source
 }
 
-func runService(ctx *cli.Context) error {
-	// we need some args to run
-	if ctx.Args().Len() == 0 {
-		return cli.ShowSubcommandHelp(ctx)
-	}
-
-	wd, err := os.Getwd()
-	if err != nil {
-		return err
-	}
-
+// sourceService parses the source the service is run from and constructs the service. Local
+// source is uploaded to the server.
+func sourceService(ctx *cli.Context, wd, arg string) (*runtime.Service, *git.Source, error) {
 	// determine the type of source input, i.e. is it a local folder or a remote git repo
-	source, err := git.ParseSourceLocal(wd, appendSourceBase(ctx, wd, ctx.Args().Get(0), false))
+	source, err := git.ParseSourceLocal(wd, appendSourceBase(ctx, wd, arg, false))
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// if the source isn't local, ensure it exists
 	if !source.Local {
 		if err := sourceExists(source); err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
-	// parse the various flags
-	typ := ctx.String("type")
-	command := strings.TrimSpace(ctx.String("command"))
-	args := strings.TrimSpace(ctx.String("args"))
-	retries := DefaultRetries
-	image := ""
-	if ctx.IsSet("retries") {
-		retries = ctx.Int("retries")
-	}
-	if ctx.IsSet("image") {
-		image = ctx.String("image")
-	}
-
 	// construct the service
 	srv := &runtime.Service{
 		Name:    source.RuntimeName(),
@@ -248,18 +230,18 @@ func runService(ctx *cli.Context) error {
 		if _, err := os.Stat(vendorDir); os.IsNotExist(err) {
 			defer os.RemoveAll(vendorDir)
 		} else if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		// vendor the dependencies
 		if err := run.VendorDependencies(source.LocalRepoRoot); err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		// for local source, upload it to the server and use the resulting source ID
 		srv.Source, err = upload(ctx, srv, source)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	} else {
 		// if we're running a remote git repository, pass this as the source
@@ -273,6 +255,38 @@ func runService(ctx *cli.Context) error {
 		"source": source.RuntimeSource(),
 	}
 
+	return srv, source, nil
+}
+
+func runService(ctx *cli.Context) error {
+	// apply a manifest if one was provided
+	if file := ctx.String("file"); len(file) > 0 {
+		return applyManifest(ctx, file)
+	}
+
+	// we need some args to run
+	if ctx.Args().Len() == 0 && !ctx.IsSet("artifact") {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	// parse the various flags
+	typ := ctx.String("type")
+	command := strings.TrimSpace(ctx.String("command"))
+	args := strings.TrimSpace(ctx.String("args"))
+	retries := DefaultRetries
+	image := ""
+	if ctx.IsSet("retries") {
+		retries = ctx.Int("retries")
+	}
+	if ctx.IsSet("image") {
+		image = ctx.String("image")
+	}
+
 	// specify the options
 	opts := []runtime.CreateOption{
 		runtime.WithOutput(os.Stdout),
@@ -280,6 +294,30 @@ func runService(ctx *cli.Context) error {
 		runtime.CreateImage(image),
 		runtime.CreateType(typ),
 	}
+
+	// construct the service, a prebuilt binary is run by name rather than from source
+	var srv *runtime.Service
+	var source *git.Source
+	if ref := ctx.String("artifact"); len(ref) > 0 {
+		name, version, err := artifact.ParseRef(ref)
+		if err != nil {
+			return err
+		}
+		srv = prebuiltService(name + "@" + version)
+		opts = append(opts, runtime.WithPrebuilt(artifact.Prefix+name+"@"+version))
+	} else if prebuilt := ctx.String("prebuilt"); len(prebuilt) > 0 {
+		srv = prebuiltService(ctx.Args().Get(0))
+		opts = append(opts, runtime.WithPrebuilt(prebuilt))
+	} else if srv, source, err = sourceService(ctx, wd, ctx.Args().Get(0)); err != nil {
+		return err
+	}
+
+	if schedule := ctx.String("schedule"); len(schedule) > 0 {
+		opts = append(opts, runtime.WithSchedule(schedule))
+	}
+	if deps := ctx.StringSlice("dependencies"); len(deps) > 0 {
+		opts = append(opts, runtime.WithDependencies(deps...))
+	}
 	if len(command) > 0 {
 		opts = append(opts, runtime.WithCommand(strings.Split(command, " ")...))
 	}
@@ -289,7 +327,7 @@ func runService(ctx *cli.Context) error {
 
 	// when the repo root doesn't match the full path (e.g. in cases where a mono-repo is being
 	// used), find the relative path and pass this in the metadata as entrypoint.
-	if source.Local && source.LocalRepoRoot != source.FullPath {
+	if source != nil && source.Local && source.LocalRepoRoot != source.FullPath {
 		ep, _ := filepath.Rel(source.LocalRepoRoot, source.FullPath)
 		opts = append(opts, runtime.CreateEntrypoint(ep))
 	}
@@ -306,6 +344,30 @@ func runService(ctx *cli.Context) error {
 	if len(environment) > 0 {
 		opts = append(opts, runtime.WithEnv(environment))
 	}
+
+	// add the config and secret references
+	refs, err := parseRefs(ctx.StringSlice("config_refs"))
+	if err != nil {
+		return err
+	}
+	for env, path := range refs {
+		opts = append(opts, runtime.WithConfigRef(env, path))
+	}
+	if refs, err = parseRefs(ctx.StringSlice("secret_refs")); err != nil {
+		return err
+	}
+	for env, path := range refs {
+		opts = append(opts, runtime.WithSecretRef(env, path))
+	}
+	if ctx.Bool("rotate") {
+		opts = append(opts, runtime.WithRotation(true))
+	}
+	if ctx.IsSet("os") || ctx.IsSet("arch") {
+		opts = append(opts, runtime.WithTarget(ctx.String("os"), ctx.String("arch")))
+	}
+	if flags := strings.Fields(ctx.String("build_flags")); len(flags) > 0 {
+		opts = append(opts, runtime.WithBuildFlags(flags...))
+	}
 	if len(command) > 0 {
 		opts = append(opts, runtime.WithCommand(strings.Split(command, " ")...))
 	}
@@ -325,14 +387,61 @@ func runService(ctx *cli.Context) error {
 	}
 
 	opts = append(opts, runtime.CreateNamespace(ns))
-	gitCreds, ok := getGitCredentials(source.Repo)
-	if ok {
-		opts = append(opts, runtime.WithSecret(credentialsKey, gitCreds))
+	if source != nil {
+		if gitCreds, ok := getGitCredentials(source.Repo); ok {
+			opts = append(opts, runtime.WithSecret(credentialsKey, gitCreds))
+		}
+	}
+
+	// only local source can be watched for changes
+	watch := ctx.Bool("watch")
+	if watch && (source == nil || !source.Local) {
+		return cli.Exit("Only services run from a local folder can be watched", 1)
 	}
 
 	// run the service
-	err = runtime.Create(srv, opts...)
-	return util.CliError(err)
+	if err := runtime.Create(srv, opts...); err != nil {
+		return util.CliError(err)
+	}
+
+	// rebuild and restart the service when its source changes
+	if watch {
+		return watchService(ctx, wd, ctx.Args().Get(0), source, ns)
+	}
+	return nil
+}
+
+// prebuiltService returns the service for a prebuilt binary, the arg is the name of the service
+// optionally followed by the version, e.g. helloworld@v1
+func prebuiltService(arg string) *runtime.Service {
+	srv := &runtime.Service{
+		Name:     arg,
+		Version:  "latest",
+		Metadata: map[string]string{},
+	}
+	if parts := strings.Split(arg, "@"); len(parts) > 1 {
+		srv.Name = parts[0]
+		srv.Version = parts[1]
+	}
+	return srv
+}
+
+// parseRefs parses references in the format ENV=path, where multiple can be comma separated
+func parseRefs(values []string) (map[string]string, error) {
+	refs := make(map[string]string)
+	for _, val := range values {
+		for _, ref := range strings.Split(val, ",") {
+			if len(ref) == 0 {
+				continue
+			}
+			parts := strings.SplitN(strings.TrimSpace(ref), "=", 2)
+			if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+				return nil, fmt.Errorf("Invalid reference %v, expected the format ENV=path", ref)
+			}
+			refs[parts[0]] = parts[1]
+		}
+	}
+	return refs, nil
 }
 
 func getGitCredentials(repo string) (string, bool) {
@@ -479,7 +588,60 @@ func updateService(ctx *cli.Context) error {
 	return util.CliError(err)
 }
 
+func scaleService(ctx *cli.Context) error {
+	// we need some args to scale
+	if ctx.Args().Len() == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	name := ctx.Args().Get(0)
+	ref := "latest"
+	if parts := strings.Split(name, "@"); len(parts) > 1 {
+		name = parts[0]
+		ref = parts[1]
+	}
+
+	var opts []runtime.UpdateOption
+	if n := ctx.Int("replicas"); n > 0 {
+		opts = append(opts, runtime.UpdateReplicas(n))
+	}
+	if metric := ctx.String("metric"); len(metric) > 0 {
+		if ctx.Float64("threshold") <= 0 {
+			return cli.Exit("A threshold is required to autoscale", 1)
+		}
+		opts = append(opts, runtime.UpdateAutoscale(&runtime.ScalePolicy{
+			Min:       ctx.Int("min"),
+			Max:       ctx.Int("max"),
+			Metric:    metric,
+			Threshold: ctx.Float64("threshold"),
+		}))
+	}
+	if len(opts) == 0 {
+		return cli.Exit("Either --replicas or --metric must be set", 1)
+	}
+
+	// determine the namespace
+	env, err := util.GetEnv(ctx)
+	if err != nil {
+		return err
+	}
+	ns, err := namespace.Get(env.Name)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, runtime.UpdateNamespace(ns))
+
+	// the source isn't set so only the number of instances is changed
+	err = runtime.Update(&runtime.Service{Name: name, Version: ref}, opts...)
+	return util.CliError(err)
+}
+
 func getService(ctx *cli.Context) error {
+	// compare the running service to the manifest if one was provided
+	if file := ctx.String("file"); len(file) > 0 {
+		return diffManifest(ctx, file)
+	}
+
 	name := ""
 	version := "latest"
 	typ := ctx.String("type")
@@ -585,6 +747,28 @@ func getService(ctx *cli.Context) error {
 			metadata = fmt.Sprintf("%v, error=%v", metadata, parse(service.Metadata["error"]))
 		}
 
+		// jobs show when they're scheduled and the result of the last run
+		if schedule, ok := service.Metadata["schedule"]; ok {
+			metadata = fmt.Sprintf("%v, schedule=%v, next=%v", metadata, schedule, parse(service.Metadata["nextRun"]))
+		}
+		if exit, ok := service.Metadata["exitStatus"]; ok {
+			metadata = fmt.Sprintf("%v, exit=%v", metadata, exit)
+		}
+		if replicas, ok := service.Metadata["replicas"]; ok {
+			metadata = fmt.Sprintf("%v, replicas=%v", metadata, replicas)
+		}
+
+		// services which crashed show how often they've been restarted and how they last exited
+		if restarts, ok := service.Metadata["restarts"]; ok {
+			metadata = fmt.Sprintf("%v, restarts=%v", metadata, restarts)
+		}
+		if code, ok := service.Metadata["exitCode"]; ok {
+			metadata = fmt.Sprintf("%v, exitCode=%v", metadata, code)
+		}
+		if service.Metadata["backoff"] == "true" {
+			metadata = fmt.Sprintf("%v, backoff=%v", metadata, backoffStatus(service.Metadata["nextRestart"]))
+		}
+
 		// parse when the service was started
 		updated := parse(timeAgo(service.Metadata["started"]))
 
@@ -604,9 +788,37 @@ func getService(ctx *cli.Context) error {
 			metadata)
 	}
 	writer.Flush()
+
+	// the output of the last crash is only shown for a single service as it can be long
+	if list {
+		return nil
+	}
+	for _, service := range services {
+		output, ok := service.Metadata["crashOutput"]
+		if !ok {
+			continue
+		}
+		fmt.Printf("\nLast crash of %v %v", service.Name, parse(timeAgo(service.Metadata["lastCrash"])))
+		if code, ok := service.Metadata["exitCode"]; ok {
+			fmt.Printf(" (exit code %v)", code)
+		}
+		fmt.Printf(":\n%v\n", output)
+	}
 	return nil
 }
 
+// backoffStatus returns how long until a service in crash-loop backoff is restarted
+func backoffStatus(nextRestart string) string {
+	t, err := time.Parse(time.RFC3339, nextRestart)
+	if err != nil {
+		return "true"
+	}
+	if d := time.Until(t); d > 0 {
+		return "restart in " + fmtDuration(d)
+	}
+	return "restarting"
+}
+
 const (
 	// logUsage message for logs command
 	logUsage = "Required usage: micro log example"
@@ -629,10 +841,11 @@ func getLogs(ctx *cli.Context) error {
 	// get the args
 	options := []runtime.LogsOption{}
 
+	// default to the last 15 lines unless reading logs since a point in time
 	count := ctx.Int("lines")
 	if count > 0 {
 		options = append(options, runtime.LogsCount(int64(count)))
-	} else {
+	} else if !ctx.IsSet("since") {
 		options = append(options, runtime.LogsCount(int64(15)))
 	}
 
@@ -642,13 +855,13 @@ func getLogs(ctx *cli.Context) error {
 		options = append(options, runtime.LogsStream(follow))
 	}
 
-	// @todo reintroduce since
-	//since := ctx.String("since")
-	//var readSince time.Time
-	//d, err := time.ParseDuration(since)
-	//if err == nil {
-	//	readSince = time.Now().Add(-d)
-	//}
+	if since := ctx.String("since"); len(since) > 0 {
+		d, err := time.ParseDuration(since)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("Invalid since duration %q", since), 1)
+		}
+		options = append(options, runtime.LogsSince(time.Now().Add(-d)))
+	}
 
 	// determine the namespace
 	env, err := util.GetEnv(ctx)
@@ -0,0 +1,145 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/micro/micro/v3/service/runtime/local/source/git"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// WatchDebounce is how long to wait for changes to the source to stop before the service is
+	// rebuilt, so saving several files at once only restarts it once
+	WatchDebounce = time.Millisecond * 500
+)
+
+// watchService rebuilds and restarts the service each time its local source changes, until
+// interrupted
+func watchService(ctx *cli.Context, wd, arg string, source *git.Source, ns string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watchDirs(watcher, source.FullPath, source.FullPath); err != nil {
+		return err
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	fmt.Printf("Watching %v for changes\n", source.FullPath)
+
+	// the timer fires once the changes have stopped for the debounce period
+	timer := time.NewTimer(WatchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-sig:
+			return nil
+		case err := <-watcher.Errors:
+			fmt.Fprintf(os.Stderr, "Error watching %v: %v\n", source.FullPath, err)
+		case ev := <-watcher.Events:
+			if !watchable(source.FullPath, ev.Name) {
+				continue
+			}
+
+			// directories created since the watch started need to be watched too
+			if ev.Op&fsnotify.Create == fsnotify.Create {
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					watchDirs(watcher, source.FullPath, ev.Name)
+				}
+			}
+
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(WatchDebounce)
+		case <-timer.C:
+			if err := reloadService(ctx, wd, arg, source, ns); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+}
+
+// watchDirs adds the directory and those nested within it to the watcher
+func watchDirs(watcher *fsnotify.Watcher, root, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if !watchable(root, path) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// watchable returns true if changes to the path should restart the service. Hidden files, e.g.
+// .git or editor swap files, vendored dependencies and editor backups are ignored.
+func watchable(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	if rel == "." {
+		return true
+	}
+
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == "vendor" || strings.HasPrefix(part, ".") {
+			return false
+		}
+	}
+	return !strings.HasSuffix(rel, "~")
+}
+
+// reloadService builds the changed source and updates the service with it. The source is built
+// locally first so errors are surfaced immediately and a service which doesn't compile isn't
+// restarted.
+func reloadService(ctx *cli.Context, wd, arg string, source *git.Source, ns string) error {
+	fmt.Println("Source changed, rebuilding")
+
+	build := exec.Command("go", "build", "-o", os.DevNull, ".")
+	build.Dir = source.FullPath
+	if out, err := build.CombinedOutput(); err != nil {
+		return fmt.Errorf("Build failed, the service was not restarted:\n%s", out)
+	}
+
+	srv, _, err := sourceService(ctx, wd, arg)
+	if err != nil {
+		return err
+	}
+
+	opts := []runtime.UpdateOption{runtime.UpdateNamespace(ns)}
+	if source.LocalRepoRoot != source.FullPath {
+		ep, _ := filepath.Rel(source.LocalRepoRoot, source.FullPath)
+		opts = append(opts, runtime.UpdateEntrypoint(ep))
+	}
+	if err := runtime.Update(srv, opts...); err != nil {
+		return util.CliError(err)
+	}
+
+	fmt.Printf("Restarting %v\n", srv.Name)
+	return nil
+}
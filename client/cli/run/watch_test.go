@@ -0,0 +1,30 @@
+package runtime
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWatchable(t *testing.T) {
+	root := filepath.FromSlash("/src/helloworld")
+	tcs := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "/src/helloworld", expected: true},
+		{path: "/src/helloworld/main.go", expected: true},
+		{path: "/src/helloworld/handler/handler.go", expected: true},
+		{path: "/src/helloworld/.git/index", expected: false},
+		{path: "/src/helloworld/.main.go.swp", expected: false},
+		{path: "/src/helloworld/main.go~", expected: false},
+		{path: "/src/helloworld/vendor/github.com/foo/bar.go", expected: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.path, func(t *testing.T) {
+			if res := watchable(root, filepath.FromSlash(tc.path)); res != tc.expected {
+				t.Errorf("Expected %v but got %v", tc.expected, res)
+			}
+		})
+	}
+}
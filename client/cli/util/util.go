@@ -5,10 +5,12 @@ package util
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
 	merrors "github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/server"
 
 	"github.com/micro/micro/v3/internal/config"
 	"github.com/urfave/cli/v2"
@@ -47,6 +49,7 @@ var (
 		"auth",     // :8010
 		"proxy",    // :8081
 		"api",      // :8080
+		"debug",    // :unset
 	}
 )
 
@@ -77,6 +80,19 @@ func IsBuiltInService(command string) bool {
 	return false
 }
 
+// IsAllServices returns true for micro service --all, which runs the services in a single process
+func IsAllServices(ctx *cli.Context) bool {
+	if ctx.Args().First() != "service" {
+		return false
+	}
+	for _, arg := range ctx.Args().Tail() {
+		if arg == "--all" || arg == "-all" {
+			return true
+		}
+	}
+	return false
+}
+
 // CLIProxyAddress returns the proxy address which should be set for the client
 func CLIProxyAddress(ctx *cli.Context) (string, error) {
 	switch ctx.Args().First() {
@@ -89,6 +105,11 @@ func CLIProxyAddress(ctx *cli.Context) (string, error) {
 		return "", nil
 	}
 
+	// the services run in a single process call each other directly
+	if IsAllServices(ctx) {
+		return "", nil
+	}
+
 	// don't set the proxy address on the proxy
 	if ctx.Args().First() == "proxy" {
 		return "", nil
@@ -299,3 +320,16 @@ func CliError(err error) cli.ExitCoder {
 	// fallback to using the detail from the merr
 	return cli.Exit(merr.Detail, 127)
 }
+
+// WarnDeprecated warns on stderr when the header of the response marks the endpoint called
+// deprecated, so it's noticed before the endpoint is removed
+func WarnDeprecated(service, endpoint string, header map[string]string) {
+	if _, ok := header[server.DeprecationHeader]; !ok {
+		return
+	}
+	msg := fmt.Sprintf("Warning: %s %s is deprecated", service, endpoint)
+	if sunset, ok := header[server.SunsetHeader]; ok {
+		msg += fmt.Sprintf(" and will be removed on %s", sunset)
+	}
+	fmt.Fprintln(os.Stderr, msg)
+}
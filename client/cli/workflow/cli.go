@@ -0,0 +1,223 @@
+// Package cli implements the `micro workflow` subcommands which start, inspect and resume sagas
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/cmd"
+	"github.com/micro/micro/v3/internal/helper"
+	pb "github.com/micro/micro/v3/proto/workflow"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	cmd.Register(&cli.Command{
+		Name:   "workflow",
+		Usage:  "Start, inspect and resume sagas",
+		Action: helper.UnexpectedSubcommand,
+		Description: `A saga calls its steps in order and when one of them fails after its retries the
+	compensations of the steps before it are called in reverse. A saga stops when a compensation
+	fails, or when the workflow service stops, and is listed as stuck until it's resumed.`,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "start",
+				Usage:     "Start a saga declared in a yaml or json file",
+				UsageText: "micro workflow start saga.yaml",
+				Action:    util.Print(start),
+			},
+			{
+				Name:   "list",
+				Usage:  "List the sagas, most recently updated first",
+				Action: util.Print(list),
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "stuck",
+						Usage: "Only list the stuck sagas",
+					},
+				},
+			},
+			{
+				Name:      "read",
+				Usage:     "Show a saga and the state of its steps",
+				UsageText: "micro workflow read id",
+				Action:    util.Print(read),
+			},
+			{
+				Name:      "resume",
+				Usage:     "Resume a stuck saga from the step it stopped at",
+				UsageText: "micro workflow resume id",
+				Action:    util.Print(resume),
+			},
+		},
+	})
+}
+
+// Saga declares the steps of a saga, e.g.
+//
+//	name: order
+//	steps:
+//	  - name: reserve
+//	    service: inventory
+//	    endpoint: Inventory.Reserve
+//	    request: {item: book}
+//	    retries: 3
+//	    timeout: 5s
+//	    compensation:
+//	      service: inventory
+//	      endpoint: Inventory.Release
+//	      request: {item: book}
+type Saga struct {
+	Name  string `yaml:"name" json:"name"`
+	Steps []Step `yaml:"steps" json:"steps"`
+}
+
+// Step of a saga
+type Step struct {
+	Name string `yaml:"name" json:"name"`
+	Call `yaml:",inline"`
+	// Compensation undoes the step when a step after it fails
+	Compensation *Call `yaml:"compensation" json:"compensation"`
+	// Retries are the number of times the calls are retried after failing
+	Retries int `yaml:"retries" json:"retries"`
+	// Timeout of each attempt at the calls
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+// Call is an rpc call
+type Call struct {
+	Service  string      `yaml:"service" json:"service"`
+	Endpoint string      `yaml:"endpoint" json:"endpoint"`
+	Request  interface{} `yaml:"request" json:"request"`
+}
+
+func (c *Call) serialize() (*pb.Call, error) {
+	req, err := json.Marshal(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	if c.Request == nil {
+		req = nil
+	}
+	return &pb.Call{Service: c.Service, Endpoint: c.Endpoint, Request: req}, nil
+}
+
+// loadSaga reads a saga file, json being valid yaml
+func loadSaga(path string) (*pb.StartRequest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var saga Saga
+	if err := yaml.Unmarshal(b, &saga); err != nil {
+		return nil, fmt.Errorf("Error parsing %v: %v", path, err)
+	}
+
+	req := &pb.StartRequest{Name: saga.Name}
+	for _, s := range saga.Steps {
+		c, err := s.Call.serialize()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid request of step %v: %v", s.Name, err)
+		}
+		step := &pb.Step{
+			Name:    s.Name,
+			Call:    c,
+			Retries: int32(s.Retries),
+			Timeout: s.Timeout.Milliseconds(),
+		}
+		if s.Compensation != nil {
+			if step.Compensation, err = s.Compensation.serialize(); err != nil {
+				return nil, fmt.Errorf("Invalid request of the compensation of step %v: %v", s.Name, err)
+			}
+		}
+		req.Steps = append(req.Steps, step)
+	}
+	return req, nil
+}
+
+func workflowService() pb.WorkflowService {
+	return pb.NewWorkflowService("workflow", client.DefaultClient)
+}
+
+func start(c *cli.Context, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("Expected one argument: the saga file")
+	}
+	req, err := loadSaga(args[0])
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := workflowService().Start(context.DefaultContext, req, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("Started saga %v", rsp.Saga.Id)), nil
+}
+
+func list(c *cli.Context, args []string) ([]byte, error) {
+	rsp, err := workflowService().List(context.DefaultContext, &pb.ListRequest{Stuck: c.Bool("stuck")}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBuffer(nil)
+	table := tablewriter.NewWriter(b)
+	table.SetHeader([]string{"ID", "NAME", "STATUS", "STUCK", "UPDATED", "ERROR"})
+	for _, s := range rsp.Sagas {
+		updated := time.Unix(s.Updated, 0).Format(time.RFC3339)
+		table.Append([]string{s.Id, s.Name, s.Status, strconv.FormatBool(s.Stuck), updated, s.Error})
+	}
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	return b.Bytes(), nil
+}
+
+func read(c *cli.Context, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("Expected one argument: id")
+	}
+	rsp, err := workflowService().Read(context.DefaultContext, &pb.ReadRequest{Id: args[0]}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+	s := rsp.Saga
+
+	b := bytes.NewBuffer(nil)
+	fmt.Fprintf(b, "ID:      %v\nName:    %v\nStatus:  %v\nStuck:   %v\n", s.Id, s.Name, s.Status, s.Stuck)
+	fmt.Fprintf(b, "Created: %v\nUpdated: %v\n", time.Unix(s.Created, 0).Format(time.RFC3339), time.Unix(s.Updated, 0).Format(time.RFC3339))
+	if len(s.Error) > 0 {
+		fmt.Fprintf(b, "Error:   %v\n", s.Error)
+	}
+	fmt.Fprintln(b)
+
+	table := tablewriter.NewWriter(b)
+	table.SetHeader([]string{"STEP", "NAME", "CALL", "STATUS", "ATTEMPTS", "RESPONSE", "ERROR"})
+	for i, step := range s.Steps {
+		call := step.Call.Service + " " + step.Call.Endpoint
+		table.Append([]string{strconv.Itoa(i + 1), step.Name, call, step.Status, strconv.Itoa(int(step.Attempts)), string(step.Response), step.Error})
+	}
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	return b.Bytes(), nil
+}
+
+func resume(c *cli.Context, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("Expected one argument: id")
+	}
+	if _, err := workflowService().Resume(context.DefaultContext, &pb.ResumeRequest{Id: args[0]}, client.WithAuthToken()); err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("Resumed saga %v", args[0])), nil
+}
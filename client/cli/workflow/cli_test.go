@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSaga(t *testing.T) {
+	dir, err := ioutil.TempDir("", "saga")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "saga.yaml")
+	err = ioutil.WriteFile(path, []byte(`name: order
+steps:
+  - name: reserve
+    service: inventory
+    endpoint: Inventory.Reserve
+    request: {item: book, count: 2}
+    retries: 3
+    timeout: 5s
+    compensation:
+      service: inventory
+      endpoint: Inventory.Release
+      request: {item: book}
+  - service: payment
+    endpoint: Payment.Charge
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := loadSaga(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Name != "order" || len(req.Steps) != 2 {
+		t.Fatalf("Expected the order saga with 2 steps, got %v with %d", req.Name, len(req.Steps))
+	}
+	s := req.Steps[0]
+	if s.Call.Service != "inventory" || s.Call.Endpoint != "Inventory.Reserve" || string(s.Call.Request) != `{"count":2,"item":"book"}` {
+		t.Fatalf("Unexpected call %+v", s.Call)
+	}
+	if s.Retries != 3 || s.Timeout != 5000 {
+		t.Fatalf("Expected 3 retries with a 5s timeout, got %v and %vms", s.Retries, s.Timeout)
+	}
+	if s.Compensation == nil || s.Compensation.Endpoint != "Inventory.Release" || string(s.Compensation.Request) != `{"item":"book"}` {
+		t.Fatalf("Unexpected compensation %+v", s.Compensation)
+	}
+	if s = req.Steps[1]; s.Compensation != nil || len(s.Call.Request) > 0 {
+		t.Fatalf("Expected the step without a request or compensation to have none, got %+v", s)
+	}
+}
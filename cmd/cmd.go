@@ -14,10 +14,17 @@ import (
 	"time"
 
 	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/internal/addr"
+	"github.com/micro/micro/v3/internal/codec/compress"
 	uconf "github.com/micro/micro/v3/internal/config"
+	"github.com/micro/micro/v3/internal/debug/trace/otlp"
 	"github.com/micro/micro/v3/internal/helper"
+	mnet "github.com/micro/micro/v3/internal/net"
 	"github.com/micro/micro/v3/internal/network"
+	"github.com/micro/micro/v3/internal/overload"
 	"github.com/micro/micro/v3/internal/report"
+	"github.com/micro/micro/v3/internal/residency"
+	"github.com/micro/micro/v3/internal/telemetry"
 	_ "github.com/micro/micro/v3/internal/usage"
 	"github.com/micro/micro/v3/internal/user"
 	"github.com/micro/micro/v3/internal/wrapper"
@@ -32,6 +39,7 @@ import (
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/registry"
 	"github.com/micro/micro/v3/service/server"
+	sgrpc "github.com/micro/micro/v3/service/server/grpc"
 	"github.com/micro/micro/v3/service/store"
 	"github.com/urfave/cli/v2"
 
@@ -80,6 +88,16 @@ var (
 			Usage:   "Set the config file: Defaults to ~/.micro/config.json",
 			EnvVars: []string{"MICRO_CONFIG_FILE"},
 		},
+		&cli.StringFlag{
+			Name:    "config",
+			Usage:   "Set the file the flags of all commands are loaded from e.g. micro.toml, micro.yaml or micro.json. Defaults to ~/.micro/micro.toml written by micro init",
+			EnvVars: []string{"MICRO_CONFIG"},
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "Set the output format of --version: text or json",
+			Value: "text",
+		},
 		&cli.StringFlag{
 			Name:    "env",
 			Aliases: []string{"e"},
@@ -122,6 +140,11 @@ var (
 			EnvVars: []string{"MICRO_AUTH_PRIVATE_KEY"},
 			Usage:   "Private key for JWT auth (base64 encoded PEM)",
 		},
+		&cli.StringFlag{
+			Name:    "registry",
+			Usage:   "Set the registry instead of the one of the profile: service to register via the registry service e.g. through the network, file to share a local file, mdns, memory or a registered registry",
+			EnvVars: []string{"MICRO_REGISTRY"},
+		},
 		&cli.StringFlag{
 			Name:    "registry_address",
 			EnvVars: []string{"MICRO_REGISTRY_ADDRESS"},
@@ -142,6 +165,11 @@ var (
 			Usage:   "Client key for TLS with registry",
 			EnvVars: []string{"MICRO_REGISTRY_TLS_KEY"},
 		},
+		&cli.StringFlag{
+			Name:    "broker",
+			Usage:   "Set the broker the broker service uses: memory or a registered broker e.g. embedded",
+			EnvVars: []string{"MICRO_BROKER"},
+		},
 		&cli.StringFlag{
 			Name:    "broker_address",
 			EnvVars: []string{"MICRO_BROKER_ADDRESS"},
@@ -177,11 +205,36 @@ var (
 			Usage:   "Client key for TLS with broker",
 			EnvVars: []string{"MICRO_BROKER_TLS_KEY"},
 		},
+		&cli.StringFlag{
+			Name:    "store",
+			Usage:   "Set the store the server persists to instead of the one of the profile: file or bolt to persist to bolt databases, memory or a registered store e.g. cockroach",
+			EnvVars: []string{"MICRO_STORE"},
+		},
 		&cli.StringFlag{
 			Name:    "store_address",
 			EnvVars: []string{"MICRO_STORE_ADDRESS"},
 			Usage:   "Comma-separated list of store addresses",
 		},
+		&cli.StringFlag{
+			Name:    "region",
+			Usage:   "Set the region the node is in, the services refuse the data of the namespaces pinned to other regions",
+			EnvVars: []string{"MICRO_REGION"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "residency",
+			Usage:   "Pin the data and services of a namespace to a region e.g. foo=eu-west-1",
+			EnvVars: []string{"MICRO_RESIDENCY"},
+		},
+		&cli.StringFlag{
+			Name:    "platform",
+			Usage:   "Set the platform the runtime runs the services on instead of the one of the profile: local to run them as processes, kubernetes to run them as deployments",
+			EnvVars: []string{"MICRO_PLATFORM"},
+		},
+		&cli.BoolFlag{
+			Name:    "runtime_operator",
+			Usage:   "Run the kubernetes runtime as an operator which reconciles MicroService custom resources",
+			EnvVars: []string{"MICRO_RUNTIME_OPERATOR"},
+		},
 		&cli.StringFlag{
 			Name:    "proxy_address",
 			Usage:   "Proxy requests via the HTTP address specified",
@@ -193,6 +246,11 @@ var (
 			EnvVars: []string{"MICRO_REPORT_USAGE"},
 			Value:   true,
 		},
+		&cli.BoolFlag{
+			Name:    "telemetry",
+			Usage:   "Record anonymous usage locally, it's never sent and is dumped with micro report",
+			EnvVars: []string{"MICRO_TELEMETRY"},
+		},
 		&cli.StringFlag{
 			Name:    "service_name",
 			Usage:   "Name of the micro service",
@@ -220,6 +278,130 @@ var (
 			Value:   "",
 			EnvVars: []string{"MICRO_CONFIG_SECRET_KEY"},
 		},
+		&cli.StringFlag{
+			Name:    "tracing_endpoint",
+			Usage:   "Export traces to the OTLP collector at the endpoint e.g. http://localhost:4318, such as Jaeger or the OpenTelemetry collector",
+			EnvVars: []string{"MICRO_TRACING_ENDPOINT"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "tracing_headers",
+			Usage:   "Set headers sent when exporting traces e.g. api-key=xxx",
+			EnvVars: []string{"MICRO_TRACING_HEADERS"},
+		},
+		&cli.Float64Flag{
+			Name:    "tracing_sample_rate",
+			Usage:   "Set the ratio of traces exported, between 0 and 1",
+			EnvVars: []string{"MICRO_TRACING_SAMPLE_RATE"},
+			Value:   1,
+		},
+		&cli.BoolFlag{
+			Name:    "tracing_sample_errors",
+			Usage:   "Export the traces with errors regardless of the sample rate",
+			EnvVars: []string{"MICRO_TRACING_SAMPLE_ERRORS"},
+		},
+		&cli.BoolFlag{
+			Name:    "chaos",
+			Usage:   "Inject the faults of the chaos experiments set via the debug service, for resilience testing",
+			EnvVars: []string{"MICRO_CHAOS"},
+		},
+		&cli.StringFlag{
+			Name:    "log_shipping",
+			Usage:   "Ship logs to the debug service via the broker or rpc",
+			EnvVars: []string{"MICRO_LOG_SHIPPING"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "stats_latency_buckets",
+			Usage:   "Set the upper bounds of the latency buckets of the requests to each endpoint e.g. 10ms,100ms,1s",
+			EnvVars: []string{"MICRO_STATS_LATENCY_BUCKETS"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "compression",
+			Usage:   "Compress request and response payloads with the services which accept it e.g. gzip",
+			EnvVars: []string{"MICRO_COMPRESSION"},
+		},
+		&cli.IntFlag{
+			Name:    "compression_threshold",
+			Usage:   "Set the size in bytes above which payloads are compressed",
+			EnvVars: []string{"MICRO_COMPRESSION_THRESHOLD"},
+			Value:   compress.DefaultThreshold,
+		},
+		&cli.IntFlag{
+			Name:    "priority_limit",
+			Usage:   "Set the concurrent bulk requests such as watches the registry, router and config serve, control-plane requests of the runtime and cli are always served. 0 for unlimited",
+			EnvVars: []string{"MICRO_PRIORITY_LIMIT"},
+		},
+		&cli.DurationFlag{
+			Name:    "graceful_timeout",
+			Usage:   "Set how long services drain in-flight requests for when stopping e.g. 30s",
+			EnvVars: []string{"MICRO_GRACEFUL_TIMEOUT"},
+		},
+		&cli.BoolFlag{
+			Name:    "reuse_port",
+			Usage:   "Listen with SO_REUSEPORT so a new process can start on the same address while the old one drains, for upgrades without downtime",
+			EnvVars: []string{"MICRO_REUSE_PORT"},
+		},
+		&cli.IntFlag{
+			Name:    "max_connections",
+			Usage:   "Set the max concurrent connections the servers of the services, the api and proxy accept. 0 for unlimited",
+			EnvVars: []string{"MICRO_MAX_CONNECTIONS"},
+		},
+		&cli.IntFlag{
+			Name:    "max_in_flight",
+			Usage:   "Set the max requests the servers of the services, the api and proxy serve concurrently, others are queued. 0 for unlimited",
+			EnvVars: []string{"MICRO_MAX_IN_FLIGHT"},
+		},
+		&cli.IntFlag{
+			Name:    "max_queue_depth",
+			Usage:   "Set the max requests queued once --max_in_flight is reached, the requests over it are shed with a 503",
+			EnvVars: []string{"MICRO_MAX_QUEUE_DEPTH"},
+		},
+		&cli.IntFlag{
+			Name:    "register_ttl",
+			Usage:   "Set the TTL in seconds the services are registered with, reloaded on SIGHUP",
+			EnvVars: []string{"MICRO_REGISTER_TTL"},
+		},
+		&cli.IntFlag{
+			Name:    "register_interval",
+			Usage:   "Set the interval in seconds the services re-register at, reloaded on SIGHUP",
+			EnvVars: []string{"MICRO_REGISTER_INTERVAL"},
+		},
+		&cli.StringFlag{
+			Name:    "log_file",
+			Usage:   "Set the file logs are appended to rather than written to stderr",
+			EnvVars: []string{"MICRO_LOG_FILE"},
+		},
+		&cli.StringFlag{
+			Name:    "log_level",
+			Usage:   "Set the level logged at: trace, debug, info, warn, error or fatal",
+			EnvVars: []string{"MICRO_LOG_LEVEL"},
+			Value:   "info",
+		},
+		&cli.StringFlag{
+			Name:    "log_format",
+			Usage:   "Set the format of the logs: text or json, one record per line for log aggregators to parse",
+			EnvVars: []string{"MICRO_LOG_FORMAT"},
+			Value:   "text",
+		},
+		&cli.StringFlag{
+			Name:    "selector",
+			Usage:   "Set the selector used to balance requests: random, roundrobin, leastconn or locality",
+			EnvVars: []string{"MICRO_SELECTOR"},
+		},
+		&cli.StringFlag{
+			Name:    "zone",
+			Usage:   "Set the zone the service is running in, preferred by the locality selector",
+			EnvVars: []string{"MICRO_ZONE"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "wrappers",
+			Usage:   "Comma-separated list of the registered wrappers to apply, in order. Defaults to all",
+			EnvVars: []string{"MICRO_WRAPPERS"},
+		},
+		&cli.StringFlag{
+			Name:    "ip_family",
+			Usage:   "Set the ip family preferred for advertised and resolved addresses: ipv4 or ipv6",
+			EnvVars: []string{"MICRO_IP_FAMILY"},
+		},
 	}
 )
 
@@ -228,6 +410,11 @@ func init() {
 
 	// configure defaults for all packages
 	setupDefaults()
+
+	// load the plugins before the commands add their flags
+	if err := setupPlugins(); err != nil {
+		logger.Fatal(err)
+	}
 }
 
 func action(c *cli.Context) error {
@@ -341,6 +528,41 @@ func (c *command) Before(ctx *cli.Context) error {
 		uconf.SetConfig(cf)
 	}
 
+	// load the flags file, its values are set on the global flags here and on the flags of the
+	// commands when they're run
+	track(ctx, "")
+	if _, err := readFlagsFile(ctx); err != nil {
+		return err
+	}
+	if err := setFlagsFromFile(ctx, ""); err != nil {
+		return err
+	}
+
+	// set the level and format of the logs
+	level, err := logger.GetLevel(ctx.String("log_level"))
+	if err != nil {
+		return err
+	}
+	format, err := logger.GetFormat(ctx.String("log_format"))
+	if err != nil {
+		return err
+	}
+	logger.DefaultLogger.Init(logger.WithLevel(level), logger.WithFormat(format))
+
+	// write the logs to a file e.g. when run as a windows service
+	if lf := ctx.String("log_file"); len(lf) > 0 {
+		f, err := os.OpenFile(lf, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		logger.DefaultLogger.Init(logger.WithOutput(f))
+	}
+
+	// prefer the ip family when advertising addresses
+	if err := addr.SetFamily(ctx.String("ip_family")); err != nil {
+		return err
+	}
+
 	// initialize plugins
 	for _, p := range plugin.Plugins() {
 		if err := p.Init(ctx); err != nil {
@@ -356,6 +578,14 @@ func (c *command) Before(ctx *cli.Context) error {
 		switch ctx.Args().First() {
 		case "service", "server":
 			prof = "local"
+			// services run in a single process share in memory defaults
+			if util.IsAllServices(ctx) {
+				prof = "memory"
+			}
+			// the profile of the flags file is only used by the server and services
+			if v, ok := fileFlags["profile"]; ok {
+				prof = fmt.Sprint(v)
+			}
 		default:
 			prof = "client"
 		}
@@ -366,7 +596,20 @@ func (c *command) Before(ctx *cli.Context) error {
 		logger.Fatal(err)
 	} else {
 		// load the profile
-		profile.Setup(ctx)
+		if err := profile.Setup(ctx); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	// pin the namespaces to their regions
+	residency.Region = ctx.String("region")
+	if err := residency.Parse(ctx.StringSlice("residency")); err != nil {
+		logger.Fatal(err)
+	}
+
+	// the registry flag overrides the registry of the profile
+	if err := setupRegistry(ctx); err != nil {
+		logger.Fatal(err)
 	}
 
 	// set the proxy address
@@ -393,6 +636,54 @@ func (c *command) Before(ctx *cli.Context) error {
 		client.Lookup(network.Lookup),
 	)
 
+	// compress payloads with the services which accept it
+	if names := ctx.StringSlice("compression"); len(names) > 0 {
+		for _, name := range names {
+			if _, ok := compress.Compressors[name]; !ok {
+				logger.Fatalf("Unsupported compression: %s", name)
+			}
+		}
+		threshold := ctx.Int("compression_threshold")
+		client.DefaultClient.Init(client.Compression(names...), client.CompressionThreshold(threshold))
+		server.DefaultServer.Init(server.Compression(names...), server.CompressionThreshold(threshold))
+	}
+
+	// share the addresses listened on with the process replacing this one
+	mnet.ReusePort = ctx.Bool("reuse_port")
+
+	// register the services for the ttl and interval set
+	setupRegister(ctx)
+
+	// drain the in-flight requests when stopping
+	if timeout := ctx.Duration("graceful_timeout"); timeout > 0 {
+		server.DefaultServer.Init(server.GracefulTimeout(timeout))
+	}
+
+	// balance requests with the selector and advertise the zone
+	if err := setupSelector(ctx); err != nil {
+		logger.Fatal(err)
+	}
+
+	// export traces to the collector
+	var sampler otlp.Sampler
+	if len(ctx.String("tracing_endpoint")) > 0 {
+		sampler = setupTracing(ctx)
+	}
+
+	// count the latency of requests in the buckets set
+	if len(ctx.StringSlice("stats_latency_buckets")) > 0 {
+		if err := setupStats(ctx); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	// ship logs to the debug service
+	if len(ctx.String("log_shipping")) > 0 {
+		if err := setupLogShipping(ctx); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
 	// wrap the client
 	client.DefaultClient = wrapper.AuthClient(client.DefaultClient)
 	client.DefaultClient = wrapper.CacheClient(client.DefaultClient)
@@ -400,14 +691,37 @@ func (c *command) Before(ctx *cli.Context) error {
 	client.DefaultClient = wrapper.FromService(client.DefaultClient)
 	client.DefaultClient = wrapper.LogClient(client.DefaultClient)
 
+	// limit the connections and shed the requests over the limits before they're handled
+	if n := ctx.Int("max_connections"); n > 0 {
+		server.DefaultServer.Init(sgrpc.MaxConn(n))
+	}
+	if n := ctx.Int("max_in_flight"); n > 0 {
+		limiter := overload.NewLimiter("server", n, ctx.Int("max_queue_depth"))
+		server.DefaultServer.Init(server.WrapHandler(wrapper.LimitHandler(limiter)))
+	}
+
 	// wrap the server
 	server.DefaultServer.Init(
 		server.WrapHandler(wrapper.AuthHandler()),
+		server.WrapHandler(wrapper.IdempotencyHandler()),
 		server.WrapHandler(wrapper.TraceHandler()),
 		server.WrapHandler(wrapper.HandlerStats()),
 		server.WrapHandler(wrapper.LogHandler()),
 		server.WrapHandler(wrapper.MetricsHandler()),
+		server.WrapHandler(wrapper.DeprecationHandler()),
+		server.WrapHandler(wrapper.DeadlineHandler()),
 	)
+	if ctx.Bool("chaos") {
+		server.DefaultServer.Init(server.WrapHandler(wrapper.ChaosHandler()))
+	}
+
+	// apply the wrappers registered by plugins
+	if names := ctx.StringSlice("wrappers"); len(names) > 0 {
+		if err := plugin.EnableWrappers(names...); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	setupWrappers()
 
 	// setup auth
 	authOpts := []auth.Option{}
@@ -441,7 +755,6 @@ func (c *command) Before(ctx *cli.Context) error {
 
 	// setup auth credentials, use local credentials for the CLI and injected creds
 	// for the service.
-	var err error
 	if c.service {
 		err = setupAuthForService()
 	} else {
@@ -519,6 +832,20 @@ func (c *command) Before(ctx *cli.Context) error {
 		logger.Fatalf("Error connecting to broker: %v", err)
 	}
 
+	// update the trace sampling when it's set via the debug service
+	if sampler != nil {
+		if err := subscribeSampling(ctx, sampler); err != nil {
+			logger.Warnf("Error subscribing to trace sampling: %v", err)
+		}
+	}
+
+	// inject the faults of the chaos experiments set via the debug service
+	if ctx.Bool("chaos") {
+		if err := subscribeChaos(); err != nil {
+			logger.Warnf("Error subscribing to chaos experiments: %v", err)
+		}
+	}
+
 	// Setup runtime. This is a temporary fix to trigger the runtime to recreate
 	// its client now the client has been replaced with a wrapped one.
 	if err := muruntime.DefaultRuntime.Init(); err != nil {
@@ -594,6 +921,12 @@ func (c *command) Run() error {
 			panic(r)
 		}
 	}()
+
+	// set the flags of the commands from the flags file
+	for _, cmd := range c.app.Commands {
+		wrapBefore(cmd, cmd.Name)
+	}
+
 	return c.app.Run(os.Args)
 }
 
@@ -601,6 +934,24 @@ func (c *command) String() string {
 	return "micro"
 }
 
+// setupRegistry sets the registry of the registry flag, if set, instead of the one of the profile
+func setupRegistry(ctx *cli.Context) error {
+	name := ctx.String("registry")
+	if len(name) == 0 {
+		return nil
+	}
+	// the registry service would call itself
+	if name == "service" && ctx.Args().First() == "service" && ctx.Args().Get(1) == "registry" {
+		return fmt.Errorf("The registry service can't use the service registry")
+	}
+	reg, err := profile.LoadRegistry(name)
+	if err != nil {
+		return err
+	}
+	profile.SetupRegistry(reg)
+	return nil
+}
+
 // Register CLI commands
 func Register(cmds ...*cli.Command) {
 	app := DefaultCmd.App()
@@ -617,6 +968,8 @@ func Register(cmds ...*cli.Command) {
 // Run the default command
 func Run() {
 	if err := DefaultCmd.Run(); err != nil {
+		// record the class of the error if telemetry is enabled
+		telemetry.Error(err)
 		fmt.Println(formatErr(err))
 		os.Exit(1)
 	}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/micro/micro/v3/internal/user"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFlagsFile is the flags file loaded if --config isn't set, micro init writes it
+var DefaultFlagsFile = filepath.Join(user.Dir, "micro.toml")
+
+// fileFlags are the flag values loaded from the file set with --config, keyed by the command
+// path and flag name e.g. registry_address or export.kubernetes.replicas
+var fileFlags map[string]interface{}
+
+// fileSet are the flags of each context set from the file, which are set again when it's reloaded
+var fileSet = map[*cli.Context]map[string]bool{}
+
+// FlagsFile returns the path of the flags file, the one set with --config or the default one
+func FlagsFile(ctx *cli.Context) string {
+	if path := ctx.String("config"); len(path) > 0 {
+		return path
+	}
+	return DefaultFlagsFile
+}
+
+// readFlagsFile loads the flags file into fileFlags, returning its path or a blank one if the
+// default file doesn't exist
+func readFlagsFile(ctx *cli.Context) (string, error) {
+	path := FlagsFile(ctx)
+	if _, err := os.Stat(path); os.IsNotExist(err) && !ctx.IsSet("config") {
+		return "", nil
+	}
+	flags, err := LoadFlagsFile(path)
+	if err != nil {
+		return "", err
+	}
+	fileFlags = flags
+	return path, nil
+}
+
+// LoadFlagsFile loads the flag values of a toml, yaml or json file. The top level values are the
+// global flags and the tables are the flags of the commands e.g.
+//
+//	registry_address = "10.0.0.1:8000"
+//
+//	[run]
+//	image = "micro/cells:go"
+//
+// The profile only applies to the server and services, the other commands use the client profile.
+func LoadFlagsFile(path string) (map[string]interface{}, error) {
+	vals, err := decodeFlagsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	flags := make(map[string]interface{})
+	flatten("", vals, flags)
+	return flags, nil
+}
+
+// decodeFlagsFile decodes the toml, yaml or json file into its values
+func decodeFlagsFile(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	vals := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(b, &vals)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &vals)
+	case ".json":
+		err = json.Unmarshal(b, &vals)
+	default:
+		return nil, fmt.Errorf("Unsupported config file %v, expected a toml, yaml or json file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing config file %v: %v", path, err)
+	}
+	return vals, nil
+}
+
+// WriteFlagsFile sets the values of the global flags in the toml, yaml or json file, keeping the
+// other values of the file. Blank values are removed from the file.
+func WriteFlagsFile(path string, flags map[string]string) error {
+	vals, err := decodeFlagsFile(path)
+	if os.IsNotExist(err) {
+		vals = make(map[string]interface{})
+	} else if err != nil {
+		return err
+	}
+	for name, val := range flags {
+		if len(val) == 0 {
+			delete(vals, name)
+			continue
+		}
+		vals[name] = val
+	}
+
+	var b []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		buf := new(bytes.Buffer)
+		err = toml.NewEncoder(buf).Encode(vals)
+		b = buf.Bytes()
+	case ".yaml", ".yml":
+		b, err = yaml.Marshal(vals)
+	case ".json":
+		b, err = json.MarshalIndent(vals, "", "  ")
+	default:
+		return fmt.Errorf("Unsupported config file %v, expected a toml, yaml or json file", path)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// flatten the tables of the commands into keys prefixed by the command path
+func flatten(prefix string, vals, flags map[string]interface{}) {
+	for k, v := range vals {
+		if m, ok := v.(map[string]interface{}); ok {
+			flatten(prefix+k+".", m, flags)
+			continue
+		}
+		flags[prefix+k] = v
+	}
+}
+
+// setFlagsFromFile sets the flags of the command from the file values which aren't set on the
+// command line or by env vars. The path is the command path e.g. export.kubernetes, blank for
+// the global flags.
+func setFlagsFromFile(ctx *cli.Context, path string) error {
+	prefix := path
+	if len(prefix) > 0 {
+		prefix += "."
+	}
+	set, ok := fileSet[ctx]
+	if !ok {
+		set = make(map[string]bool)
+		fileSet[ctx] = set
+	}
+
+	for key, val := range fileFlags {
+		name := strings.TrimPrefix(key, prefix)
+		// the flags of other commands and subcommands
+		if !strings.HasPrefix(key, prefix) || strings.Contains(name, ".") {
+			continue
+		}
+		// the profile is set for the server and services only
+		if key == "profile" {
+			continue
+		}
+		if ctx.IsSet(name) && !set[name] {
+			continue
+		}
+
+		// lists set each value e.g. for string slice flags, setting them again would append to the
+		// values so they're not reloaded
+		vals, ok := val.([]interface{})
+		if ok && set[name] {
+			continue
+		} else if !ok {
+			vals = []interface{}{val}
+		}
+		for _, v := range vals {
+			if err := ctx.Set(name, fmt.Sprint(v)); err != nil {
+				return fmt.Errorf("Invalid flag %v in the config file: %v", key, err)
+			}
+		}
+		set[name] = true
+	}
+	return nil
+}
+
+// wrapBefore sets the flags of the command and its subcommands from the file before they're run
+func wrapBefore(c *cli.Command, path string) {
+	before := c.Before
+	c.Before = func(ctx *cli.Context) error {
+		track(ctx, path)
+		if err := setFlagsFromFile(ctx, path); err != nil {
+			return err
+		}
+		if before != nil {
+			return before(ctx)
+		}
+		return nil
+	}
+
+	for _, sub := range c.Subcommands {
+		wrapBefore(sub, path+"."+sub.Name)
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestFlagsFile(t *testing.T) {
+	files := map[string]string{
+		"micro.toml": "address = \"file\"\nname = \"file\"\n\n[run]\nreplicas = 3\nenv_vars = [\"a=b\", \"c=d\"]\n",
+		"micro.yaml": "address: file\nname: file\nrun:\n  replicas: 3\n  env_vars:\n  - a=b\n  - c=d\n",
+		"micro.json": `{"address": "file", "name": "file", "run": {"replicas": 3, "env_vars": ["a=b", "c=d"]}}`,
+	}
+
+	for file, data := range files {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file)
+			if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			flags, err := LoadFlagsFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fileFlags = flags
+			defer func() { fileFlags = nil }()
+
+			os.Setenv("TEST_FLAGS_FILE_NAME", "env")
+			defer os.Unsetenv("TEST_FLAGS_FILE_NAME")
+
+			var global, name string
+			var replicas int
+			var envVars []string
+
+			run := &cli.Command{
+				Name: "run",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "replicas"},
+					&cli.StringSliceFlag{Name: "env_vars"},
+				},
+				Action: func(ctx *cli.Context) error {
+					replicas = ctx.Int("replicas")
+					envVars = ctx.StringSlice("env_vars")
+					return nil
+				},
+			}
+			wrapBefore(run, run.Name)
+
+			app := &cli.App{
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "address"},
+					&cli.StringFlag{Name: "name", EnvVars: []string{"TEST_FLAGS_FILE_NAME"}},
+				},
+				Before: func(ctx *cli.Context) error {
+					if err := setFlagsFromFile(ctx, ""); err != nil {
+						return err
+					}
+					global = ctx.String("address")
+					name = ctx.String("name")
+					return nil
+				},
+				Commands: []*cli.Command{run},
+			}
+			if err := app.Run([]string{"micro", "run", "--replicas", "5"}); err != nil {
+				t.Fatal(err)
+			}
+
+			if global != "file" {
+				t.Errorf("Expected the global flag from the file, got %q", global)
+			}
+			if name != "env" {
+				t.Errorf("Expected the env var to take precedence, got %q", name)
+			}
+			if replicas != 5 {
+				t.Errorf("Expected the command line to take precedence, got %v", replicas)
+			}
+			if !reflect.DeepEqual(envVars, []string{"a=b", "c=d"}) {
+				t.Errorf("Expected the command flags from the file, got %v", envVars)
+			}
+		})
+	}
+}
+
+func TestWriteFlagsFile(t *testing.T) {
+	files := map[string]string{
+		"micro.toml": "registry_address = \"10.0.0.1:8000\"\n\n[run]\nimage = \"micro/cells:go\"\n",
+		"micro.yaml": "registry_address: 10.0.0.1:8000\nrun:\n  image: micro/cells:go\n",
+		"micro.json": `{"registry_address": "10.0.0.1:8000", "run": {"image": "micro/cells:go"}}`,
+	}
+
+	for file, data := range files {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file)
+			if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// the flags are set, the blank ones removed and the tables of the commands kept
+			if err := WriteFlagsFile(path, map[string]string{"profile": "local", "registry_address": ""}); err != nil {
+				t.Fatal(err)
+			}
+			flags, err := LoadFlagsFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := map[string]interface{}{"profile": "local", "run.image": "micro/cells:go"}
+			if !reflect.DeepEqual(flags, expected) {
+				t.Fatalf("Expected %v, got %v", expected, flags)
+			}
+		})
+	}
+
+	// the file is created if it doesn't exist
+	path := filepath.Join(t.TempDir(), "micro", "micro.toml")
+	if err := WriteFlagsFile(path, map[string]string{"broker": "memory"}); err != nil {
+		t.Fatal(err)
+	}
+	if flags, err := LoadFlagsFile(path); err != nil || flags["broker"] != "memory" {
+		t.Fatalf("Expected the file to be written, got %v %v", flags, err)
+	}
+
+	if err := WriteFlagsFile(filepath.Join(t.TempDir(), "micro.ini"), nil); err == nil {
+		t.Fatal("Expected an error writing an unsupported file")
+	}
+}
+
+func TestFlagsFileProfile(t *testing.T) {
+	fileFlags = map[string]interface{}{"profile": "local", "address": "file"}
+	defer func() { fileFlags = nil }()
+
+	var prof, address string
+	app := &cli.App{
+		Flags: []cli.Flag{&cli.StringFlag{Name: "profile"}, &cli.StringFlag{Name: "address"}},
+		Action: func(ctx *cli.Context) error {
+			if err := setFlagsFromFile(ctx, ""); err != nil {
+				return err
+			}
+			prof, address = ctx.String("profile"), ctx.String("address")
+			return nil
+		},
+	}
+	if err := app.Run([]string{"micro"}); err != nil {
+		t.Fatal(err)
+	}
+	// the profile only applies to the server and services so isn't set on the global flag
+	if prof != "" || address != "file" {
+		t.Fatalf("Expected only the address to be set, got %q and %q", prof, address)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	grpcCli "github.com/micro/micro/v3/service/client/grpc"
 	"github.com/micro/micro/v3/service/events"
 	eventsSrv "github.com/micro/micro/v3/service/events/client"
+	"github.com/micro/micro/v3/service/id"
+	idSrv "github.com/micro/micro/v3/service/id/client"
 	"github.com/micro/micro/v3/service/metrics"
 	noopMet "github.com/micro/micro/v3/service/metrics/noop"
 	"github.com/micro/micro/v3/service/network"
@@ -23,6 +25,8 @@ import (
 	grpcSvr "github.com/micro/micro/v3/service/server/grpc"
 	"github.com/micro/micro/v3/service/store"
 	storeSrv "github.com/micro/micro/v3/service/store/client"
+	"github.com/micro/micro/v3/service/sync"
+	syncSrv "github.com/micro/micro/v3/service/sync/client"
 )
 
 // setupDefaults sets the default auth, broker etc implementations incase they arent configured by
@@ -38,9 +42,11 @@ func setupDefaults() {
 	broker.DefaultBroker = brokerSrv.NewBroker()
 	events.DefaultStream = eventsSrv.NewStream()
 	events.DefaultStore = eventsSrv.NewStore()
+	id.DefaultGenerator = idSrv.NewGenerator()
 	registry.DefaultRegistry = registrySrv.NewRegistry()
 	router.DefaultRouter = routerSrv.NewRouter()
 	store.DefaultStore = storeSrv.NewStore()
 	store.DefaultBlobStore = storeSrv.NewBlobStore()
+	sync.DefaultSync = syncSrv.NewSync()
 	runtime.DefaultRuntime = runtimeSrv.NewRuntime()
 }
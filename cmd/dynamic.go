@@ -190,9 +190,11 @@ func callService(srv *goregistry.Service, namespace string, ctx *cli.Context) er
 	// construct and execute the request using the json content type
 	req := client.DefaultClient.NewRequest(srv.Name, endpoint, body, client.WithContentType("application/json"))
 	var rsp json.RawMessage
-	if err := client.DefaultClient.Call(callCtx, req, &rsp, client.WithAuthToken()); err != nil {
+	header := make(map[string]string)
+	if err := client.DefaultClient.Call(callCtx, req, &rsp, client.WithAuthToken(), client.WithResponseHeader(header)); err != nil {
 		return err
 	}
+	util.WarnDeprecated(srv.Name, endpoint, header)
 
 	// format the response
 	var out bytes.Buffer
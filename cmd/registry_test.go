@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/micro/micro/v3/profile"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/micro/micro/v3/service/router"
+	"github.com/micro/micro/v3/service/server"
+	"github.com/urfave/cli/v2"
+)
+
+func TestRegistryFlag(t *testing.T) {
+	defer func(reg registry.Registry, rtr router.Router) {
+		profile.SetupRegistry(reg)
+		router.DefaultRouter = rtr
+	}(registry.DefaultRegistry, router.DefaultRouter)
+
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("micro", flag.ContinueOnError)
+		set.String("registry", "", "")
+		if err := set.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+
+	// the registry of the profile is kept unless the flag is set
+	def := registry.DefaultRegistry
+	if err := setupRegistry(newContext()); err != nil {
+		t.Fatal(err)
+	}
+	if registry.DefaultRegistry != def {
+		t.Fatal("Expected the registry of the profile to be kept")
+	}
+
+	// the flag selects the registry the services use
+	for _, name := range []string{"memory", "file"} {
+		if err := setupRegistry(newContext("--registry", name)); err != nil {
+			t.Fatal(err)
+		}
+		if got := registry.DefaultRegistry.String(); got != name {
+			t.Fatalf("Expected the %s registry, got %s", name, got)
+		}
+		if got := server.DefaultServer.Options().Registry.String(); got != name {
+			t.Fatalf("Expected the server to use the %s registry, got %s", name, got)
+		}
+	}
+
+	// the unknown registries are rejected
+	if err := setupRegistry(newContext("--registry", "unknown")); err == nil {
+		t.Fatal("Expected an error selecting an unknown registry")
+	}
+	if got := registry.DefaultRegistry.String(); got != "file" {
+		t.Fatalf("Expected the registry to be kept, got %s", got)
+	}
+
+	// the registry service can't register via itself
+	if err := setupRegistry(newContext("--registry", "service", "service", "registry")); err == nil {
+		t.Fatal("Expected an error running the registry service with the service registry")
+	}
+}
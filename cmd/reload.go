@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/plugin"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/server"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	reloadMu sync.Mutex
+	// reloadFuncs are called with the context of the command being run when the flags are reloaded
+	reloadFuncs []ReloadFunc
+	// contexts are the contexts of the commands being run from the global one down, with their path
+	// in the flags file
+	contexts []commandContext
+)
+
+// ReloadFunc applies the flags which changed when they're reloaded, returning the options of the
+// server which changed as they only apply once it's restarted
+type ReloadFunc func(ctx *cli.Context) ([]server.Option, error)
+
+type commandContext struct {
+	ctx  *cli.Context
+	path string
+}
+
+// track records the context of a command being run so its flags can be reloaded
+func track(ctx *cli.Context, path string) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	// services setup micro again when they're created, parsing the global flags only, so only the
+	// contexts of the command being run are kept
+	if len(path) == 0 && len(contexts) > 0 {
+		return
+	}
+	contexts = append(contexts, commandContext{ctx, path})
+}
+
+// OnReload registers a func which is called with the context of the command being run once its
+// flags are reloaded, to apply the flags which changed without restarting
+func OnReload(fn ReloadFunc) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	reloadFuncs = append(reloadFuncs, fn)
+}
+
+// Reload re-reads the flags file and re-initialises the plugins then calls the funcs registered
+// with OnReload. The flags set on the command line or by env vars take precedence over the file
+// as they do on start. The options of the server which changed are returned, the
+// caller restarts the server with them.
+func Reload() ([]server.Option, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if len(contexts) == 0 {
+		return nil, nil
+	}
+	global := contexts[0].ctx
+
+	path, err := readFlagsFile(global)
+	if err != nil {
+		return nil, err
+	}
+	if len(path) > 0 {
+		for _, c := range contexts {
+			if err := setFlagsFromFile(c.ctx, c.path); err != nil {
+				return nil, err
+			}
+		}
+		logger.Infof("Reloaded the flags from %v", path)
+	}
+
+	for _, p := range plugin.Plugins() {
+		if err := p.Init(global); err != nil {
+			return nil, err
+		}
+	}
+
+	// the register ttl and interval of the server
+	var opts []server.Option
+	current := server.DefaultServer.Options()
+	if ttl := time.Duration(global.Int("register_ttl")) * time.Second; ttl > 0 && ttl != current.RegisterTTL {
+		opts = append(opts, server.RegisterTTL(ttl))
+	}
+	if interval := time.Duration(global.Int("register_interval")) * time.Second; interval > 0 && interval != current.RegisterInterval {
+		opts = append(opts, server.RegisterInterval(interval))
+	}
+
+	ctx := contexts[len(contexts)-1].ctx
+	for _, fn := range reloadFuncs {
+		o, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, o...)
+	}
+	return opts, nil
+}
+
+// setupRegister sets the ttl the server registers its service with and the interval it re-registers at
+func setupRegister(ctx *cli.Context) {
+	if ttl := ctx.Int("register_ttl"); ttl > 0 {
+		server.DefaultServer.Init(server.RegisterTTL(time.Duration(ttl) * time.Second))
+	}
+	if interval := ctx.Int("register_interval"); interval > 0 {
+		server.DefaultServer.Init(server.RegisterInterval(time.Duration(interval) * time.Second))
+	}
+}
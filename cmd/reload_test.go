@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/micro/micro/v3/service/server"
+	"github.com/urfave/cli/v2"
+)
+
+func TestReload(t *testing.T) {
+	reset := func() {
+		contexts, reloadFuncs, fileFlags = nil, nil, nil
+	}
+	reset()
+	defer reset()
+
+	path := filepath.Join(t.TempDir(), "micro.toml")
+	write := func(data string) {
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("[network]\nnodes = \"10.0.0.1:8085\"\ngateway = \"10.0.0.2\"\n")
+
+	var nodes, gateway string
+	network := &cli.Command{
+		Name: "network",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "nodes"},
+			&cli.StringFlag{Name: "gateway"},
+		},
+		Action: func(ctx *cli.Context) error {
+			nodes = ctx.String("nodes")
+			OnReload(func(ctx *cli.Context) ([]server.Option, error) {
+				nodes = ctx.String("nodes")
+				gateway = ctx.String("gateway")
+				return []server.Option{server.Address(":9000")}, nil
+			})
+			return nil
+		},
+	}
+	wrapBefore(network, network.Name)
+
+	app := &cli.App{
+		Flags: []cli.Flag{&cli.StringFlag{Name: "config"}},
+		Before: func(ctx *cli.Context) error {
+			track(ctx, "")
+			flags, err := LoadFlagsFile(ctx.String("config"))
+			if err != nil {
+				return err
+			}
+			fileFlags = flags
+			return setFlagsFromFile(ctx, "")
+		},
+		Commands: []*cli.Command{network},
+	}
+	if err := app.Run([]string{"micro", "--config", path, "network", "--gateway", "10.0.0.3"}); err != nil {
+		t.Fatal(err)
+	}
+	if nodes != "10.0.0.1:8085" {
+		t.Fatalf("Expected the nodes from the file, got %q", nodes)
+	}
+
+	write("[network]\nnodes = \"10.0.0.4:8085\"\ngateway = \"10.0.0.2\"\n")
+	opts, err := Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nodes != "10.0.0.4:8085" {
+		t.Errorf("Expected the nodes to be reloaded from the file, got %q", nodes)
+	}
+	if gateway != "10.0.0.3" {
+		t.Errorf("Expected the command line to take precedence on reload, got %q", gateway)
+	}
+	if len(opts) != 1 {
+		t.Errorf("Expected the options of the server, got %v", len(opts))
+	}
+}
@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 	"unicode"
 
@@ -8,9 +13,26 @@ import (
 	"github.com/micro/micro/v3/client/cli/namespace"
 	clitoken "github.com/micro/micro/v3/client/cli/token"
 	"github.com/micro/micro/v3/client/cli/util"
+	"github.com/micro/micro/v3/internal/debug/chaos"
+	"github.com/micro/micro/v3/internal/debug/log/shipper"
+	"github.com/micro/micro/v3/internal/debug/stats"
+	memStats "github.com/micro/micro/v3/internal/debug/stats/memory"
+	"github.com/micro/micro/v3/internal/debug/trace/otlp"
+	"github.com/micro/micro/v3/internal/selector"
+	"github.com/micro/micro/v3/internal/selector/leastconn"
+	"github.com/micro/micro/v3/internal/selector/locality"
+	"github.com/micro/micro/v3/internal/selector/random"
+	"github.com/micro/micro/v3/internal/selector/roundrobin"
+	"github.com/micro/micro/v3/internal/user"
+	"github.com/micro/micro/v3/plugin"
+	pb "github.com/micro/micro/v3/proto/debug"
 	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/debug"
 	"github.com/micro/micro/v3/service/errors"
 	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/server"
 	"github.com/urfave/cli/v2"
 )
 
@@ -171,3 +193,212 @@ func refreshAuthToken() {
 		}
 	}
 }
+
+// serviceName returns the name of the service being run. The runtime passes the name to the
+// services it runs, the core services are named by the command e.g. micro service runtime.
+func serviceName(ctx *cli.Context) string {
+	name := ctx.String("service_name")
+	if len(name) == 0 && ctx.Args().First() == "service" {
+		name = ctx.Args().Get(1)
+	}
+	return name
+}
+
+// setupTracing exports the traces of the service to the collector as well as recording them in
+// memory so they can still be read via the debug handler. The sampler returned changes the
+// sampling of the traces exported.
+func setupTracing(ctx *cli.Context) otlp.Sampler {
+	headers := make(map[string]string)
+	for _, h := range ctx.StringSlice("tracing_headers") {
+		parts := strings.SplitN(h, "=", 2)
+		if len(parts) != 2 {
+			logger.Warnf("Invalid tracing header %v, expected key=value", h)
+			continue
+		}
+		headers[parts[0]] = parts[1]
+	}
+
+	tracer := otlp.NewTracer(
+		otlp.Endpoint(ctx.String("tracing_endpoint")),
+		otlp.Headers(headers),
+		otlp.Name(serviceName(ctx)),
+		otlp.SampleRate(ctx.Float64("tracing_sample_rate")),
+		otlp.SampleErrors(ctx.Bool("tracing_sample_errors")),
+		otlp.Tracer(debug.DefaultTracer),
+	)
+	debug.DefaultTracer = tracer
+
+	sampler, _ := tracer.(otlp.Sampler)
+	return sampler
+}
+
+// subscribeSampling updates the sampling of the traces exported when it's set via the debug
+// service, the rate of the service overriding the global rate
+func subscribeSampling(ctx *cli.Context, sampler otlp.Sampler) error {
+	name := serviceName(ctx)
+	_, err := broker.Subscribe(debug.SamplingTopic, func(msg *broker.Message) error {
+		var s pb.TraceSampling
+		if err := json.Unmarshal(msg.Body, &s); err != nil {
+			logger.Warnf("Error decoding trace sampling: %v", err)
+			return nil
+		}
+		if s.Updated == 0 {
+			return nil
+		}
+
+		rate := s.Rate
+		if r, ok := s.Services[name]; ok {
+			rate = r
+		}
+		sampler.SetSampling(rate, s.SampleErrors)
+		return nil
+	})
+	return err
+}
+
+// subscribeChaos updates the chaos experiments injected into the requests served when they're set
+// via the debug service
+func subscribeChaos() error {
+	_, err := broker.Subscribe(debug.ChaosTopic, func(msg *broker.Message) error {
+		var rsp pb.ListExperimentsResponse
+		if err := json.Unmarshal(msg.Body, &rsp); err != nil {
+			logger.Warnf("Error decoding chaos experiments: %v", err)
+			return nil
+		}
+		chaos.DefaultInjector.Set(rsp.Experiments)
+		return nil
+	})
+	return err
+}
+
+// setupStats records the stats of the requests served with the latency buckets set
+func setupStats(ctx *cli.Context) error {
+	var buckets []time.Duration
+	for _, v := range ctx.StringSlice("stats_latency_buckets") {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("Invalid latency bucket %v, expected a duration e.g. 100ms", v)
+		}
+		buckets = append(buckets, d)
+	}
+	debug.DefaultStats = memStats.NewStats(stats.LatencyBuckets(buckets...))
+	return nil
+}
+
+// setupLogShipping ships the logs of the service to the debug service as well as recording them
+// in memory so they can still be read via the debug handler
+func setupLogShipping(ctx *cli.Context) error {
+	transport := ctx.String("log_shipping")
+	if transport != shipper.TransportBroker && transport != shipper.TransportRPC {
+		return fmt.Errorf("Invalid log shipping transport %v, expected broker or rpc", transport)
+	}
+
+	// the debug service doesn't ship its own logs since it would be writing them as it ships them
+	name := serviceName(ctx)
+	if len(name) == 0 || name == "debug" {
+		return nil
+	}
+
+	labels := map[string]string{"service": name}
+	if v := ctx.String("service_version"); len(v) > 0 {
+		labels["version"] = v
+	}
+	if host, err := os.Hostname(); err == nil {
+		labels["node"] = host
+	}
+
+	return logger.DefaultLogger.Init(logger.WithLog(shipper.NewLog(
+		shipper.Transport(transport),
+		shipper.Labels(labels),
+		shipper.Log(debug.DefaultLog),
+	)))
+}
+
+// setupPlugins loads the plugins from the comma-separated shared objects and manifests in
+// MICRO_PLUGINS, or from the plugins.json manifest in the micro dir if the env var isn't set.
+// The env var is used rather than a flag as plugins can add flags of their own.
+func setupPlugins() error {
+	paths := os.Getenv("MICRO_PLUGINS")
+	if len(paths) == 0 {
+		manifest := filepath.Join(user.Dir, "plugins.json")
+		if _, err := os.Stat(manifest); err != nil {
+			return nil
+		}
+		return plugin.LoadManifest(manifest)
+	}
+
+	for _, path := range strings.Split(paths, ",") {
+		path = strings.TrimSpace(path)
+		if len(path) == 0 {
+			continue
+		}
+
+		var err error
+		if filepath.Ext(path) == ".json" {
+			err = plugin.LoadManifest(path)
+		} else {
+			err = plugin.Load(path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setupWrappers applies the global wrappers to the default client and server
+func setupWrappers() {
+	ws := plugin.Wrappers()
+
+	// wrap in reverse so the first wrapper is the outermost
+	for i := len(ws); i > 0; i-- {
+		if w := ws[i-1]; w.Client != nil {
+			client.DefaultClient = w.Client(client.DefaultClient)
+		}
+	}
+
+	var opts []server.Option
+	for _, w := range ws {
+		if w.Handler != nil {
+			opts = append(opts, server.WrapHandler(w.Handler))
+		}
+		if w.Subscriber != nil {
+			opts = append(opts, server.WrapSubscriber(w.Subscriber))
+		}
+	}
+	server.DefaultServer.Init(opts...)
+}
+
+// setupSelector sets the selector of the default client and adds the zone to the metadata of
+// the default server, so the locality selector of other services can prefer it
+func setupSelector(ctx *cli.Context) error {
+	zone := ctx.String("zone")
+
+	if len(zone) > 0 {
+		md := map[string]string{"zone": zone}
+		for k, v := range server.DefaultServer.Options().Metadata {
+			md[k] = v
+		}
+		server.DefaultServer.Init(server.Metadata(md))
+	}
+
+	var s selector.Selector
+
+	switch name := ctx.String("selector"); name {
+	case "":
+		return nil
+	case "random":
+		s = random.NewSelector()
+	case "roundrobin":
+		s = roundrobin.NewSelector()
+	case "leastconn":
+		s = leastconn.NewSelector()
+	case "locality":
+		s = locality.NewSelector(selector.Zone(zone))
+	default:
+		return fmt.Errorf("Unsupported selector: %s", name)
+	}
+
+	return client.DefaultClient.Init(client.Selector(s))
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 
 	ver "github.com/hashicorp/go-version"
+	"github.com/micro/micro/v3/internal/buildinfo"
+	"github.com/urfave/cli/v2"
 )
 
 var (
@@ -31,3 +34,28 @@ func buildVersion() string {
 	ver.Must(ver.NewVersion(verStr))
 	return verStr
 }
+
+func init() {
+	buildinfo.Version = buildVersion()
+	buildinfo.Commit = GitCommit
+	buildinfo.Date = BuildDate
+
+	cli.VersionPrinter = printVersion
+}
+
+// printVersion prints the version, or the build metadata with --output json
+func printVersion(ctx *cli.Context) {
+	if ctx.String("output") != "json" {
+		fmt.Fprintf(ctx.App.Writer, "%v version %v\n", ctx.App.Name, ctx.App.Version)
+		return
+	}
+
+	info := buildinfo.Get()
+	info.Version = ctx.App.Version
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		fmt.Fprintln(ctx.App.ErrWriter, err)
+		return
+	}
+	fmt.Fprintln(ctx.App.Writer, string(b))
+}
@@ -17,10 +17,22 @@ package addr
 import (
 	"fmt"
 	"net"
+	"sort"
+	"strings"
+)
+
+const (
+	// IPv4 is the family of ipv4 addresses
+	IPv4 = "ipv4"
+	// IPv6 is the family of ipv6 addresses
+	IPv6 = "ipv6"
 )
 
 var (
 	privateBlocks []*net.IPNet
+
+	// the preferred ip family, blank for no preference
+	family string
 )
 
 func init() {
@@ -57,6 +69,50 @@ func isPrivateIP(ipAddr string) bool {
 	return false
 }
 
+// SetFamily sets the ip family preferred when extracting and sorting addresses, either
+// ipv4, ipv6 or blank for no preference
+func SetFamily(f string) error {
+	switch f {
+	case "", IPv4, IPv6:
+		family = f
+		return nil
+	}
+	return fmt.Errorf("Unsupported ip family: %s", f)
+}
+
+// Family returns the preferred ip family
+func Family() string {
+	return family
+}
+
+// familyOf returns the family of the ip
+func familyOf(ip net.IP) string {
+	if ip.To4() != nil {
+		return IPv4
+	}
+	return IPv6
+}
+
+// Sort orders the addresses so the ips of the preferred family come first, the order is
+// otherwise kept. Addresses may include a port.
+func Sort(addrs []string) {
+	if len(family) == 0 {
+		return
+	}
+	rank := func(addr string) int {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		if ip := net.ParseIP(addr); ip != nil && familyOf(ip) != family {
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return rank(addrs[i]) < rank(addrs[j])
+	})
+}
+
 func addrToIP(addr net.Addr) net.IP {
 	switch v := addr.(type) {
 	case *net.IPAddr:
@@ -99,6 +155,7 @@ func IsLocal(addr string) bool {
 	if err == nil {
 		addr = host
 	}
+	addr = strings.Trim(addr, "[]")
 
 	// check if its localhost
 	if addr == "localhost" {
@@ -115,18 +172,17 @@ func IsLocal(addr string) bool {
 	return false
 }
 
-// Extract returns a real ip
+// Extract returns a real ip. When none is specified the ips of the interfaces are used,
+// preferring those of the preferred family then private, public and loopback ips.
 func Extract(addr string) (string, error) {
 	// if addr specified then its returned
 	if len(addr) > 0 {
 		if addr != "0.0.0.0" && addr != "[::]" && addr != "::" {
-			return addr, nil
+			return strings.Trim(addr, "[]"), nil
 		}
 	}
 
-	var privateAddrs []string
-	var publicAddrs []string
-	var loopbackAddrs []string
+	var addrs []net.IP
 
 	for _, ipAddr := range localIPs() {
 		ip := net.ParseIP(ipAddr)
@@ -134,25 +190,33 @@ func Extract(addr string) (string, error) {
 			continue
 		}
 
-		if ip.IsUnspecified() {
+		// link local addresses can't be dialled without the zone
+		if ip.IsUnspecified() || ip.IsLinkLocalUnicast() {
 			continue
 		}
 
+		addrs = append(addrs, ip)
+	}
+
+	rank := func(ip net.IP) int {
+		var r int
 		if ip.IsLoopback() {
-			loopbackAddrs = append(loopbackAddrs, ipAddr)
-		} else if isPrivateIP(ipAddr) {
-			privateAddrs = append(privateAddrs, ipAddr)
-		} else {
-			publicAddrs = append(publicAddrs, ipAddr)
+			r = 4
+		} else if !isPrivateIP(ip.String()) {
+			r = 1
 		}
+		if len(family) > 0 && familyOf(ip) != family {
+			r += 2
+		}
+		return r
 	}
 
-	if len(privateAddrs) > 0 {
-		return privateAddrs[0], nil
-	} else if len(publicAddrs) > 0 {
-		return publicAddrs[0], nil
-	} else if len(loopbackAddrs) > 0 {
-		return loopbackAddrs[0], nil
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return rank(addrs[i]) < rank(addrs[j])
+	})
+
+	if len(addrs) > 0 {
+		return addrs[0].String(), nil
 	}
 
 	return "", fmt.Errorf("No IP address found, and explicit IP not provided")
@@ -47,6 +47,7 @@ func TestExtractor(t *testing.T) {
 	}{
 		{"127.0.0.1", "127.0.0.1", false},
 		{"10.0.0.1", "10.0.0.1", false},
+		{"[::1]", "::1", false},
 		{"", "", true},
 		{"0.0.0.0", "", true},
 		{"[::]", "", true},
@@ -91,3 +92,25 @@ func TestAppendPrivateBlocks(t *testing.T) {
 		})
 	}
 }
+
+func TestSort(t *testing.T) {
+	addrs := []string{"10.0.0.1", "[fd00::1]:8080", "10.0.0.2:8080", "::1"}
+
+	if err := SetFamily(IPv6); err != nil {
+		t.Fatal(err)
+	}
+	defer SetFamily("")
+
+	Sort(addrs)
+
+	expect := []string{"[fd00::1]:8080", "::1", "10.0.0.1", "10.0.0.2:8080"}
+	for i, a := range addrs {
+		if a != expect[i] {
+			t.Fatalf("expected %v got %v", expect, addrs)
+		}
+	}
+
+	if err := SetFamily("ipx"); err == nil {
+		t.Fatal("expected error setting an unsupported family")
+	}
+}
@@ -24,13 +24,17 @@ import (
 
 var (
 	DefaultMaxRecvSize int64 = 1024 * 1024 * 100 // 10Mb
+	// DefaultStreamThreshold is the size of the bodies above which they're streamed in chunks to the
+	// endpoints which support it, the default max size of the grpc messages
+	DefaultStreamThreshold int64 = 1024 * 1024 * 4
 )
 
 type Options struct {
-	MaxRecvSize int64
-	Namespace   string
-	Router      router.Router
-	Client      client.Client
+	MaxRecvSize     int64
+	StreamThreshold int64
+	Namespace       string
+	Router          router.Router
+	Client          client.Client
 }
 
 type Option func(o *Options)
@@ -55,6 +59,10 @@ func NewOptions(opts ...Option) Options {
 		options.MaxRecvSize = DefaultMaxRecvSize
 	}
 
+	if options.StreamThreshold == 0 {
+		options.StreamThreshold = DefaultStreamThreshold
+	}
+
 	return options
 }
 
@@ -84,3 +92,10 @@ func WithMaxRecvSize(size int64) Option {
 		o.MaxRecvSize = size
 	}
 }
+
+// WithStreamThreshold specifies the body size above which it's streamed to the endpoint in chunks
+func WithStreamThreshold(size int64) Option {
+	return func(o *Options) {
+		o.StreamThreshold = size
+	}
+}
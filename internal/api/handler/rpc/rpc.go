@@ -18,26 +18,34 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/micro/micro/v3/internal/api/handler"
 	"github.com/micro/micro/v3/internal/codec"
 	"github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/cbor"
 	"github.com/micro/micro/v3/internal/codec/jsonrpc"
+	"github.com/micro/micro/v3/internal/codec/msgpack"
 	"github.com/micro/micro/v3/internal/codec/protorpc"
+	"github.com/micro/micro/v3/internal/codec/transcode"
 	"github.com/micro/micro/v3/internal/ctx"
 	"github.com/micro/micro/v3/internal/qson"
 	"github.com/micro/micro/v3/internal/router"
 	"github.com/micro/micro/v3/service/api"
 	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/client/cache"
 	"github.com/micro/micro/v3/service/context/metadata"
 	"github.com/micro/micro/v3/service/errors"
 	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/server"
 	"github.com/oxtoacart/bpool"
 )
 
@@ -63,9 +71,21 @@ var (
 		"application/octet-stream",
 	}
 
+	// transcoders convert the formats which share the json data model to and from json
+	transcoders = map[string]transcoder{
+		"application/msgpack": {"application/msgpack", msgpack.Encode, msgpack.Decode},
+		"application/cbor":    {"application/cbor", cbor.Encode, cbor.Decode},
+	}
+
 	bufferPool = bpool.NewSizedBufferPool(1024, 8)
 )
 
+type transcoder struct {
+	contentType string
+	encode      func(interface{}) ([]byte, error)
+	decode      func([]byte) (interface{}, error)
+}
+
 type rpcHandler struct {
 	opts handler.Options
 	s    *api.Service
@@ -85,8 +105,6 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		bsize = h.opts.MaxRecvSize
 	}
 
-	r.Body = http.MaxBytesReader(w, r.Body, bsize)
-
 	defer r.Body.Close()
 	var service *api.Service
 
@@ -114,6 +132,28 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ct = ct[:idx]
 	}
 
+	// large bodies are streamed to the endpoints which support it rather than read into memory
+	if isUpload(r, service, h.opts.StreamThreshold) {
+		serveUpload(ctx.FromRequest(r), w, r, service, h.opts.Client, ct)
+		return
+	}
+
+	if r.ContentLength > bsize {
+		writeError(w, r, errors.New("go.micro.api", "request body too large", http.StatusRequestEntityTooLarge))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, bsize)
+
+	// msgpack and cbor are transcoded to json for the service and the response back
+	tc, transcoded := transcoders[ct]
+	if transcoded {
+		if err := transcodeRequest(r, tc); err != nil {
+			writeError(w, r, errors.BadRequest("go.micro.api", "Error decoding %s: %v", ct, err))
+			return
+		}
+		ct = "application/json"
+	}
+
 	// micro client
 	c := h.opts.Client
 
@@ -128,8 +168,18 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// set the deadline of the request, the services it calls get what's left of it
+	if timeout, _ := strconv.Atoi(r.Header.Get("Timeout")); timeout > 0 {
+		var cancel context.CancelFunc
+		cx, cancel = context.WithTimeout(cx, time.Duration(timeout)*time.Second)
+		defer cancel()
+	}
+
 	// create custom router
 	callOpt := client.WithRouter(router.New(service.Services))
+	// the header of the response, the cache control and deprecation set by the service are passed on
+	header := make(map[string]string)
+	hdrOpt := client.WithResponseHeader(header)
 
 	// walk the standard call path
 	// get payload
@@ -160,7 +210,7 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 		// make the call
 		var response *bytes.Frame
-		if err := c.Call(cx, req, response, callOpt); err != nil {
+		if err := c.Call(cx, req, response, callOpt, hdrOpt); err != nil {
 			writeError(w, r, err)
 			return
 		}
@@ -188,7 +238,7 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			client.WithContentType(ct),
 		)
 		// make the call
-		if err := c.Call(cx, req, &response, callOpt); err != nil {
+		if err := c.Call(cx, req, &response, callOpt, hdrOpt); err != nil {
 			writeError(w, r, err)
 			return
 		}
@@ -199,6 +249,24 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			writeError(w, r, err)
 			return
 		}
+
+		if transcoded {
+			if rsp, err = transcodeResponse(rsp, tc); err != nil {
+				writeError(w, r, errors.InternalServerError("go.micro.api", "Error encoding response: %v", err))
+				return
+			}
+			r.Header.Set("Content-Type", tc.contentType)
+		}
+	}
+
+	if cc, ok := header[cache.CacheControlHeader]; ok {
+		w.Header().Set("Cache-Control", cc)
+	}
+	// let the callers know the endpoint is deprecated and when it goes away
+	for _, k := range []string{server.DeprecationHeader, server.SunsetHeader} {
+		if v, ok := header[k]; ok {
+			w.Header().Set(k, v)
+		}
 	}
 
 	// write the response
@@ -209,6 +277,44 @@ func (rh *rpcHandler) String() string {
 	return "rpc"
 }
 
+// transcodeRequest replaces the body of the request with its json equivalent
+func transcodeRequest(r *http.Request, tc transcoder) error {
+	if r.Body == nil {
+		r.Header.Set("Content-Type", "application/json")
+		return nil
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return err
+	}
+	if len(b) > 0 {
+		val, err := tc.decode(b)
+		if err != nil {
+			return err
+		}
+		if b, err = transcode.ToJSON(val); err != nil {
+			return err
+		}
+	}
+	r.Body = ioutil.NopCloser(strings.NewReader(string(b)))
+	r.ContentLength = int64(len(b))
+	r.Header.Set("Content-Type", "application/json")
+	return nil
+}
+
+// transcodeResponse encodes the json response as the format of the request
+func transcodeResponse(rsp []byte, tc transcoder) ([]byte, error) {
+	if len(rsp) == 0 {
+		return rsp, nil
+	}
+	val, err := transcode.FromJSON(rsp)
+	if err != nil {
+		return nil, err
+	}
+	return tc.encode(val)
+}
+
 func hasCodec(ct string, codecs []string) bool {
 	for _, codec := range codecs {
 		if ct == codec {
@@ -242,7 +242,11 @@ func isStream(r *http.Request, srv *api.Service) bool {
 	if !isWebSocket(r) {
 		return false
 	}
-	// check if the endpoint supports streaming
+	return isStreamEndpoint(srv)
+}
+
+// isStreamEndpoint returns true if the endpoint of the service supports streaming
+func isStreamEndpoint(srv *api.Service) bool {
 	for _, service := range srv.Services {
 		for _, ep := range service.Endpoints {
 			// skip if it doesn't match the name
@@ -0,0 +1,149 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	raw "github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/router"
+	"github.com/micro/micro/v3/service/api"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// chunkSize is the size of the chunks of the bodies streamed to the endpoints
+const chunkSize = 1024 * 256
+
+// isUpload returns true if the body of the request is streamed to the endpoint in chunks rather than
+// read into memory, which is the case for the bodies over the threshold or of an unknown length sent
+// to an endpoint which supports streaming
+func isUpload(r *http.Request, srv *api.Service, threshold int64) bool {
+	if r.Body == nil || r.Body == http.NoBody || isWebSocket(r) {
+		return false
+	}
+	switch r.Method {
+	case "POST", "PUT", "PATCH":
+	default:
+		return false
+	}
+	if r.ContentLength >= 0 && r.ContentLength <= threshold {
+		return false
+	}
+	return isStreamEndpoint(srv)
+}
+
+// chunk encodes the data as a message with the data as its first field, e.g.
+// message Chunk { bytes data = 1; }
+func chunk(ct string, data []byte) (interface{}, error) {
+	if hasCodec(ct, protoCodecs) {
+		b := protowire.AppendTag(nil, 1, protowire.BytesType)
+		return &raw.Frame{Data: protowire.AppendBytes(b, data)}, nil
+	}
+	b, err := json.Marshal(map[string][]byte{"data": data})
+	if err != nil {
+		return nil, err
+	}
+	m := json.RawMessage(b)
+	return &m, nil
+}
+
+// streamError converts the error of a stream to the error of the service
+func streamError(err error) error {
+	if s, ok := status.FromError(err); ok {
+		if e := errors.Parse(s.Message()); e.Code > 0 {
+			return e
+		}
+		return errors.InternalServerError("go.micro.api", s.Message())
+	}
+	return err
+}
+
+// serveUpload streams the body of the request to the endpoint in chunks and writes the responses
+// as they're received. Sending blocks while the flow control window of the stream is full, so the
+// body is read no faster than the service consumes it and at most a chunk is held in memory.
+func serveUpload(ctx context.Context, w http.ResponseWriter, r *http.Request, service *api.Service, c client.Client, ct string) {
+	// binary bodies are sent as proto messages, json as json messages
+	if !hasCodec(ct, jsonCodecs) && !hasCodec(ct, protoCodecs) {
+		ct = "application/octet-stream"
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req := c.NewRequest(
+		service.Name,
+		service.Endpoint.Name,
+		nil,
+		client.WithContentType(ct),
+		client.StreamingRequest(),
+	)
+	stream, err := c.Stream(ctx, req, client.WithRouter(router.New(service.Services)))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(r.Body, buf)
+		if n > 0 {
+			msg, cerr := chunk(ct, buf[:n])
+			if cerr != nil {
+				writeError(w, r, errors.InternalServerError("go.micro.api", "Error encoding chunk: %v", cerr))
+				return
+			}
+			if serr := stream.Send(msg); serr != nil {
+				writeError(w, r, streamError(serr))
+				return
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			writeError(w, r, errors.BadRequest("go.micro.api", "Error reading body: %v", err))
+			return
+		}
+	}
+
+	// close the sending side so the service knows the body is complete
+	if err := stream.Close(); err != nil {
+		writeError(w, r, streamError(err))
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	rsp := stream.Response()
+	for wrote := false; ; wrote = true {
+		b, err := rsp.Read()
+		if err == io.EOF {
+			if !wrote {
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		} else if err != nil {
+			// the status has been written with the first response
+			if wrote {
+				logger.Errorf("Error reading the response of %v: %v", service.Endpoint.Name, err)
+				return
+			}
+			writeError(w, r, streamError(err))
+			return
+		}
+
+		if !wrote {
+			w.Header().Set("Content-Type", ct)
+		}
+		if _, err := w.Write(b); err != nil {
+			logger.Errorf("Error writing the response of %v: %v", service.Endpoint.Name, err)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	raw "github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/service/api"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/registry"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type testClient struct {
+	client.Client
+	stream *testStream
+}
+
+func (t *testClient) NewRequest(service, endpoint string, req interface{}, opts ...client.RequestOption) client.Request {
+	return nil
+}
+
+func (t *testClient) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Stream, error) {
+	return t.stream, nil
+}
+
+type testStream struct {
+	client.Stream
+	sent   [][]byte
+	closed bool
+}
+
+func (t *testStream) Send(msg interface{}) error {
+	t.sent = append(t.sent, msg.(*raw.Frame).Data)
+	return nil
+}
+
+func (t *testStream) Close() error {
+	t.closed = true
+	return nil
+}
+
+func (t *testStream) Response() client.Response {
+	return &testResponse{rsps: [][]byte{[]byte("done")}}
+}
+
+type testResponse struct {
+	client.Response
+	rsps [][]byte
+}
+
+func (t *testResponse) Read() ([]byte, error) {
+	if len(t.rsps) == 0 {
+		return nil, io.EOF
+	}
+	b := t.rsps[0]
+	t.rsps = t.rsps[1:]
+	return b, nil
+}
+
+func TestUpload(t *testing.T) {
+	service := &api.Service{
+		Name:     "files",
+		Endpoint: &api.Endpoint{Name: "Files.Upload"},
+		Services: []*registry.Service{{
+			Name: "files",
+			Endpoints: []*registry.Endpoint{{
+				Name:     "Files.Upload",
+				Metadata: map[string]string{"stream": "true"},
+			}},
+		}},
+	}
+
+	body := bytes.Repeat([]byte("a"), chunkSize+10)
+	r := httptest.NewRequest("POST", "/files/upload", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/octet-stream")
+	if isUpload(r, service, int64(len(body))) {
+		t.Fatal("Expected a body within the threshold not to be streamed")
+	}
+	if !isUpload(r, service, 10) {
+		t.Fatal("Expected a body over the threshold to be streamed")
+	}
+	service.Services[0].Endpoints[0].Metadata = nil
+	if isUpload(r, service, 10) {
+		t.Fatal("Expected a body not to be streamed to an endpoint which doesn't support streaming")
+	}
+
+	stream := new(testStream)
+	w := httptest.NewRecorder()
+	serveUpload(context.Background(), w, r, service, &testClient{stream: stream}, "application/octet-stream")
+
+	if w.Code != http.StatusOK || w.Body.String() != "done" {
+		t.Fatalf("Expected the response of the service, got %v: %v", w.Code, w.Body)
+	}
+	if !stream.closed {
+		t.Fatal("Expected the stream to be closed once the body was sent")
+	}
+	if len(stream.sent) != 2 {
+		t.Fatalf("Expected the body to be sent in 2 chunks, got %v", len(stream.sent))
+	}
+	var got []byte
+	for _, msg := range stream.sent {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if num != 1 || typ != protowire.BytesType {
+			t.Fatalf("Expected the chunk as the first field, got %v %v", num, typ)
+		}
+		data, m := protowire.ConsumeBytes(msg[n:])
+		if m < 0 {
+			t.Fatal("Expected the chunk to be valid")
+		}
+		got = append(got, data...)
+	}
+	if !bytes.Equal(got, body) {
+		t.Fatal("Expected the chunks to make up the body")
+	}
+}
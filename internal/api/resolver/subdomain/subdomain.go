@@ -53,7 +53,7 @@ func (r *Resolver) Domain(req *http.Request) string {
 		if h, _, err := net.SplitHostPort(req.Host); err == nil {
 			host = h // host does contain a port
 		} else if strings.Contains(err.Error(), "missing port in address") {
-			host = req.Host // host does not contain a port
+			host = strings.Trim(req.Host, "[]") // host does not contain a port
 		}
 	}
 
@@ -72,6 +72,11 @@ func TestResolve(t *testing.T) {
 			Host:   "81.151.101.146",
 			Result: "micro",
 		},
+		{
+			Name:   "IPv6 host",
+			Host:   "[2001:db8::1]:8080",
+			Result: "micro",
+		},
 	}
 
 	for _, tc := range tt {
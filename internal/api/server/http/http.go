@@ -18,6 +18,7 @@
 package http
 
 import (
+	"context"
 	"crypto/tls"
 	"net"
 	"net/http"
@@ -27,7 +28,9 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/micro/micro/v3/internal/api/server"
 	"github.com/micro/micro/v3/internal/api/server/cors"
+	mnet "github.com/micro/micro/v3/internal/net"
 	"github.com/micro/micro/v3/service/logger"
+	"golang.org/x/net/netutil"
 )
 
 type httpServer struct {
@@ -92,15 +95,19 @@ func (s *httpServer) Start() error {
 	if s.opts.EnableACME && s.opts.ACMEProvider != nil {
 		// should we check the address to make sure its using :443?
 		l, err = s.opts.ACMEProvider.Listen(s.opts.ACMEHosts...)
-	} else if s.opts.EnableTLS && s.opts.TLSConfig != nil {
-		l, err = tls.Listen("tcp", s.address, s.opts.TLSConfig)
 	} else {
-		// otherwise plain listen
-		l, err = net.Listen("tcp", s.address)
+		// listen with the inherited socket or SO_REUSEPORT if set
+		l, err = mnet.ListenTCP(s.address)
+		if err == nil && s.opts.EnableTLS && s.opts.TLSConfig != nil {
+			l = tls.NewListener(l, s.opts.TLSConfig)
+		}
 	}
 	if err != nil {
 		return err
 	}
+	if s.opts.MaxConnections > 0 {
+		l = netutil.LimitListener(l, s.opts.MaxConnections)
+	}
 
 	if logger.V(logger.InfoLevel, logger.DefaultLogger) {
 		logger.Infof("HTTP API Listening on %s", l.Addr().String())
@@ -110,8 +117,9 @@ func (s *httpServer) Start() error {
 	s.address = l.Addr().String()
 	s.mtx.Unlock()
 
+	srv := &http.Server{Handler: s.mux}
 	go func() {
-		if err := http.Serve(l, s.mux); err != nil {
+		if err := srv.Serve(l); err != nil {
 			// temporary fix
 			//logger.Fatal(err)
 		}
@@ -119,7 +127,19 @@ func (s *httpServer) Start() error {
 
 	go func() {
 		ch := <-s.exit
-		ch <- l.Close()
+		if s.opts.GracefulTimeout <= 0 {
+			ch <- l.Close()
+			return
+		}
+
+		// stop accepting connections and drain the in-flight requests
+		ctx, cancel := context.WithTimeout(context.Background(), s.opts.GracefulTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Warnf("HTTP API stopped before draining the requests: %v", err)
+			srv.Close()
+		}
+		ch <- nil
 	}()
 
 	return nil
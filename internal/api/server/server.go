@@ -19,6 +19,7 @@ package server
 import (
 	"crypto/tls"
 	"net/http"
+	"time"
 
 	"github.com/micro/micro/v3/internal/api/resolver"
 	"github.com/micro/micro/v3/internal/api/server/acme"
@@ -44,6 +45,10 @@ type Options struct {
 	TLSConfig    *tls.Config
 	Resolver     resolver.Resolver
 	Wrappers     []Wrapper
+	// GracefulTimeout is how long the in-flight requests are drained for when stopping
+	GracefulTimeout time.Duration
+	// MaxConnections is the max concurrent connections accepted, 0 for unlimited
+	MaxConnections int
 }
 
 type Wrapper func(h http.Handler) http.Handler
@@ -95,3 +100,17 @@ func Resolver(r resolver.Resolver) Option {
 		o.Resolver = r
 	}
 }
+
+// GracefulTimeout sets how long the in-flight requests are drained for when stopping
+func GracefulTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.GracefulTimeout = d
+	}
+}
+
+// MaxConnections sets the max concurrent connections accepted
+func MaxConnections(n int) Option {
+	return func(o *Options) {
+		o.MaxConnections = n
+	}
+}
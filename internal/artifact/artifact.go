@@ -0,0 +1,246 @@
+// Package artifact is a registry of the versioned binaries and images of services. CI pushes the
+// artifacts once they're built and the runtime pulls them by name@version, so a version can be
+// promoted or rolled back without building it from git again.
+package artifact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// Database and Table the metadata of the artifacts is stored in, the binaries are stored in
+	// the blob store
+	Database = "micro"
+	Table    = "artifacts"
+	// MaxSize is the max size of a binary in bytes
+	MaxSize int64 = 1024 * 1024 * 512
+
+	// ErrNotFound is returned when the artifact doesn't exist
+	ErrNotFound = errors.New("artifact not found")
+	// ErrDigestMismatch is returned when the binary doesn't match the digest of the artifact
+	ErrDigestMismatch = errors.New("digest mismatch")
+	// ErrTooLarge is returned when the binary is larger than the MaxSize
+	ErrTooLarge = errors.New("binary too large")
+)
+
+// Prefix of the references to artifacts e.g. artifact://helloworld@v1.2.0
+const Prefix = "artifact://"
+
+// Artifact is a version of a service, built as a binary stored in the blob store or as an image
+type Artifact struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Namespace string `json:"namespace"`
+	// Image the service is run with, blank if it's run as a binary
+	Image string `json:"image,omitempty"`
+	// Digest is the sha256 of the binary and Size its length in bytes, blank if there's no binary
+	Digest string `json:"digest,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+	// Blob is the key of the binary in the blob store, the reference of the artifacts pushed before
+	// it was set
+	Blob string `json:"blob,omitempty"`
+	// Commit the artifact was built from
+	Commit   string            `json:"commit,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Created  time.Time         `json:"created"`
+}
+
+// Ref returns the reference to the artifact e.g. artifact://helloworld@v1.2.0
+func (a *Artifact) Ref() string {
+	return Prefix + a.Name + "@" + a.Version
+}
+
+// Binary returns true if the artifact has a binary in the blob store
+func (a *Artifact) Binary() bool {
+	return len(a.Digest) > 0
+}
+
+// ParseRef returns the name and version of a reference to an artifact, with or without the
+// prefix, e.g. artifact://helloworld@v1.2.0 or helloworld@v1.2.0
+func ParseRef(ref string) (name, version string, err error) {
+	parts := strings.SplitN(strings.TrimPrefix(ref, Prefix), "@", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("invalid artifact %v, expected name@version", ref)
+	}
+	return parts[0], parts[1], nil
+}
+
+// IsRef returns true if the key is a reference to an artifact
+func IsRef(key string) bool {
+	return strings.HasPrefix(key, Prefix)
+}
+
+func key(ns, name, version string) string {
+	return ns + "/" + name + "/" + version
+}
+
+// blobKey returns the key of the binary in the blob store
+func (a *Artifact) blobKey() string {
+	if len(a.Blob) > 0 {
+		return a.Blob
+	}
+	return a.Ref()
+}
+
+// sizeReader hashes and counts the bytes read, failing once there are more than the MaxSize
+type sizeReader struct {
+	r      io.Reader
+	size   int64
+	hash   hash.Hash
+	tooBig bool
+}
+
+func (s *sizeReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.size += int64(n)
+	s.hash.Write(p[:n])
+	if s.size > MaxSize {
+		s.tooBig = true
+		return n, ErrTooLarge
+	}
+	return n, err
+}
+
+// Push the artifact with its binary, which may be nil if it's an image. The binary is streamed to
+// the blob store, its digest is computed as it's written and if the artifact has a digest already
+// the binary must match it. Pushing a version which exists replaces it, its binary is only deleted
+// once the new one has been written.
+func Push(a *Artifact, bin io.Reader) error {
+	if len(a.Name) == 0 || len(a.Version) == 0 || len(a.Namespace) == 0 {
+		return errors.New("missing name, version or namespace")
+	}
+	if bin == nil && len(a.Image) == 0 {
+		return errors.New("missing binary or image")
+	}
+	if strings.Contains(a.Name, "/") || strings.Contains(a.Version, "/") {
+		return errors.New("name and version can't contain /")
+	}
+
+	prev, err := Get(a.Namespace, a.Name, a.Version)
+	if err != nil && err != ErrNotFound {
+		return err
+	}
+
+	a.Blob = ""
+	if bin != nil {
+		// each push is written to its own key, so a failed push doesn't replace the binary
+		a.Blob = a.Ref() + "/" + uuid.New().String()
+		r := &sizeReader{r: bin, hash: sha256.New()}
+		err := store.DefaultBlobStore.Write(a.Blob, r, store.BlobNamespace(a.Namespace))
+		digest := hex.EncodeToString(r.hash.Sum(nil))
+		switch {
+		case r.tooBig:
+			err = ErrTooLarge
+		case err == nil && len(a.Digest) > 0 && strings.TrimPrefix(a.Digest, "sha256:") != digest:
+			err = ErrDigestMismatch
+		}
+		if err != nil {
+			store.DefaultBlobStore.Delete(a.Blob, store.BlobNamespace(a.Namespace))
+			return err
+		}
+		a.Digest, a.Size = digest, r.size
+	}
+
+	if a.Created.IsZero() {
+		a.Created = time.Now()
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	rec := &store.Record{Key: key(a.Namespace, a.Name, a.Version), Value: b}
+	if err := store.DefaultStore.Write(rec, store.WriteTo(Database, Table)); err != nil {
+		return err
+	}
+
+	// delete the binary of the version replaced
+	if prev != nil && prev.Binary() && prev.blobKey() != a.blobKey() {
+		err := store.DefaultBlobStore.Delete(prev.blobKey(), store.BlobNamespace(a.Namespace))
+		if err != nil && err != store.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the artifact of the version of a service
+func Get(ns, name, version string) (*Artifact, error) {
+	recs, err := store.DefaultStore.Read(key(ns, name, version), store.ReadFrom(Database, Table))
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	var a *Artifact
+	if err := json.Unmarshal(recs[0].Value, &a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Read the binary of the artifact
+func Read(a *Artifact) (io.Reader, error) {
+	if !a.Binary() {
+		return nil, fmt.Errorf("artifact %v@%v has no binary", a.Name, a.Version)
+	}
+	return store.DefaultBlobStore.Read(a.blobKey(), store.BlobNamespace(a.Namespace))
+}
+
+// List the artifacts in the namespace, of all the services if the name is blank. They're ordered
+// by name and then newest first, so the previous version to roll back to follows the current one.
+func List(ns, name string) ([]*Artifact, error) {
+	prefix := ns + "/"
+	if len(name) > 0 {
+		prefix = key(ns, name, "")
+	}
+	recs, err := store.DefaultStore.Read(prefix, store.ReadFrom(Database, Table), store.ReadPrefix())
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Artifact, 0, len(recs))
+	for _, r := range recs {
+		var a *Artifact
+		if err := json.Unmarshal(r.Value, &a); err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Created.After(result[j].Created)
+	})
+	return result, nil
+}
+
+// Delete the artifact and its binary
+func Delete(ns, name, version string) error {
+	// not all the stores return an error when deleting a key which doesn't exist
+	a, err := Get(ns, name, version)
+	if err != nil {
+		return err
+	}
+	if a.Binary() {
+		err := store.DefaultBlobStore.Delete(a.blobKey(), store.BlobNamespace(ns))
+		if err != nil && err != store.ErrNotFound {
+			return err
+		}
+	}
+	return store.DefaultStore.Delete(key(ns, name, version), store.DeleteFrom(Database, Table))
+}
@@ -0,0 +1,187 @@
+package artifact
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+type testBlobStore struct {
+	blobs map[string][]byte
+}
+
+func (t *testBlobStore) Read(key string, opts ...store.BlobOption) (io.Reader, error) {
+	var options store.BlobOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	b, ok := t.blobs[options.Namespace+key]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return bytes.NewReader(b), nil
+}
+
+func (t *testBlobStore) Write(key string, blob io.Reader, opts ...store.BlobOption) error {
+	var options store.BlobOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	b, err := ioutil.ReadAll(blob)
+	t.blobs[options.Namespace+key] = b
+	return err
+}
+
+func (t *testBlobStore) Delete(key string, opts ...store.BlobOption) error {
+	var options store.BlobOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	delete(t.blobs, options.Namespace+key)
+	return nil
+}
+
+func TestArtifacts(t *testing.T) {
+	defaultStore, defaultBlobStore := store.DefaultStore, store.DefaultBlobStore
+	defer func() {
+		store.DefaultStore, store.DefaultBlobStore = defaultStore, defaultBlobStore
+	}()
+	blobs := &testBlobStore{blobs: make(map[string][]byte)}
+	store.DefaultStore, store.DefaultBlobStore = memory.NewStore(), blobs
+
+	now := time.Now()
+	v1 := &Artifact{Name: "helloworld", Version: "v1", Namespace: "foo", Created: now.Add(-time.Hour)}
+	if err := Push(v1, strings.NewReader("v1 binary")); err != nil {
+		t.Fatal(err)
+	}
+	if !v1.Binary() || v1.Size != 9 {
+		t.Fatalf("Expected the digest and size of the binary to be set, got %+v", v1)
+	}
+	v2 := &Artifact{Name: "helloworld", Version: "v2", Namespace: "foo", Digest: "sha256:abc"}
+	if err := Push(v2, strings.NewReader("v2 binary")); err != ErrDigestMismatch {
+		t.Fatalf("Expected the binary not matching the digest to be rejected, got %v", err)
+	}
+	v2.Digest = ""
+	if err := Push(v2, strings.NewReader("v2 binary")); err != nil {
+		t.Fatal(err)
+	}
+	img := &Artifact{Name: "greeter", Version: "v1", Namespace: "foo", Image: "example.com/greeter:v1"}
+	if err := Push(img, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := Push(&Artifact{Name: "greeter", Version: "v2", Namespace: "foo"}, nil); err == nil {
+		t.Fatal("Expected an artifact without a binary or image to be rejected")
+	}
+
+	a, err := Get("foo", "helloworld", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin, err := Read(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadAll(bin); string(b) != "v1 binary" {
+		t.Fatalf("Expected the binary of v1, got %q", b)
+	}
+	if _, err := Get("bar", "helloworld", "v1"); err != ErrNotFound {
+		t.Fatalf("Expected the artifact in another namespace not to be found, got %v", err)
+	}
+	if _, err := Read(img); err == nil {
+		t.Fatal("Expected reading the binary of an image to fail")
+	}
+
+	all, err := List("foo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 || all[0].Name != "greeter" || all[1].Version != "v2" || all[2].Version != "v1" {
+		t.Fatalf("Expected the artifacts by name and newest first, got %+v", all)
+	}
+	if hw, _ := List("foo", "helloworld"); len(hw) != 2 {
+		t.Fatalf("Expected the 2 versions of helloworld, got %v", len(hw))
+	}
+
+	if err := Delete("foo", "helloworld", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Get("foo", "helloworld", "v1"); err != ErrNotFound {
+		t.Fatalf("Expected the deleted artifact not to be found, got %v", err)
+	}
+	if _, ok := blobs.blobs["foo"+v1.Blob]; ok {
+		t.Fatal("Expected the binary of the deleted artifact to be deleted")
+	}
+	if err := Delete("foo", "helloworld", "v1"); err != ErrNotFound {
+		t.Fatalf("Expected deleting a missing artifact to fail, got %v", err)
+	}
+}
+
+func TestPushBinary(t *testing.T) {
+	defaultStore, defaultBlobStore := store.DefaultStore, store.DefaultBlobStore
+	defer func(size int64) {
+		store.DefaultStore, store.DefaultBlobStore = defaultStore, defaultBlobStore
+		MaxSize = size
+	}(MaxSize)
+	blobs := &testBlobStore{blobs: make(map[string][]byte)}
+	store.DefaultStore, store.DefaultBlobStore = memory.NewStore(), blobs
+	MaxSize = 16
+
+	read := func() string {
+		a, err := Get("foo", "helloworld", "v1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		bin, err := Read(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(bin)
+		return string(b)
+	}
+
+	if err := Push(&Artifact{Name: "helloworld", Version: "v1", Namespace: "foo"}, strings.NewReader("first")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the binary of a failed push is deleted and the version keeps its binary
+	err := Push(&Artifact{Name: "helloworld", Version: "v1", Namespace: "foo"}, strings.NewReader("a binary over the max size"))
+	if err != ErrTooLarge {
+		t.Fatalf("Expected the binary over the max size to be rejected, got %v", err)
+	}
+	err = Push(&Artifact{Name: "helloworld", Version: "v1", Namespace: "foo", Digest: "sha256:abc"}, strings.NewReader("second"))
+	if err != ErrDigestMismatch {
+		t.Fatalf("Expected the binary not matching the digest to be rejected, got %v", err)
+	}
+	if b := read(); b != "first" || len(blobs.blobs) != 1 {
+		t.Fatalf("Expected only the first binary to be kept, got %q and %v blobs", b, len(blobs.blobs))
+	}
+
+	// replacing the version deletes its previous binary
+	if err := Push(&Artifact{Name: "helloworld", Version: "v1", Namespace: "foo"}, strings.NewReader("second")); err != nil {
+		t.Fatal(err)
+	}
+	if b := read(); b != "second" || len(blobs.blobs) != 1 {
+		t.Fatalf("Expected only the second binary to be kept, got %q and %v blobs", b, len(blobs.blobs))
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	name, version, err := ParseRef("artifact://helloworld@v1.2.0")
+	if err != nil || name != "helloworld" || version != "v1.2.0" {
+		t.Fatalf("Expected helloworld v1.2.0, got %v %v %v", name, version, err)
+	}
+	if _, _, err := ParseRef("helloworld@v1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{"helloworld", "@v1", "helloworld@"} {
+		if _, _, err := ParseRef(ref); err == nil {
+			t.Fatalf("Expected %v to be invalid", ref)
+		}
+	}
+}
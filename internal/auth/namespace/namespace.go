@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/micro/micro/v3/service/auth"
+	merrors "github.com/micro/micro/v3/service/errors"
 )
 
 var (
@@ -55,6 +56,26 @@ func Authorize(ctx context.Context, namespace string, opts ...AuthorizeOption) e
 	return nil
 }
 
+// AuthorizeRequest defaults a blank namespace to the one of the account of the caller, or to the
+// DefaultNamespace without one, and returns it if the caller can access it. The errors are those
+// the handler of the method returns to the caller.
+func AuthorizeRequest(ctx context.Context, method, namespace string) (string, error) {
+	if len(namespace) == 0 {
+		namespace = DefaultNamespace
+		if acc, ok := auth.AccountFromContext(ctx); ok && len(acc.Issuer) > 0 {
+			namespace = acc.Issuer
+		}
+	}
+	if err := Authorize(ctx, namespace); err == ErrForbidden {
+		return "", merrors.Forbidden(method, err.Error())
+	} else if err == ErrUnauthorized {
+		return "", merrors.Unauthorized(method, err.Error())
+	} else if err != nil {
+		return "", merrors.InternalServerError(method, err.Error())
+	}
+	return namespace, nil
+}
+
 // AuthorizeOptions are used to configure the Authorize method
 type AuthorizeOptions struct {
 	PublicNamespace string
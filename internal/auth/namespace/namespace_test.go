@@ -0,0 +1,39 @@
+package namespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+)
+
+func TestAuthorizeRequest(t *testing.T) {
+	foo := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "alice", Issuer: "foo"})
+	admin := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "admin", Issuer: DefaultNamespace})
+
+	for _, c := range []struct {
+		ctx       context.Context
+		namespace string
+		expected  string
+		code      int32
+	}{
+		{foo, "", "foo", 0},
+		{foo, "foo", "foo", 0},
+		{foo, "bar", "", 403},
+		{admin, "", DefaultNamespace, 0},
+		{admin, "bar", "bar", 0},
+		{context.TODO(), "", "", 401},
+	} {
+		ns, err := AuthorizeRequest(c.ctx, "test.Test.Call", c.namespace)
+		if c.code > 0 {
+			if merr := errors.FromError(err); merr.Code != c.code {
+				t.Fatalf("Expected a %v for the namespace %q, got %v", c.code, c.namespace, err)
+			}
+			continue
+		}
+		if err != nil || ns != c.expected {
+			t.Fatalf("Expected the namespace %q, got %q %v", c.expected, ns, err)
+		}
+	}
+}
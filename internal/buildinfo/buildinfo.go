@@ -0,0 +1,45 @@
+// Package buildinfo is the build metadata of the micro binary, embedded at compile time
+package buildinfo
+
+import (
+	"runtime"
+
+	"github.com/micro/micro/v3/plugin"
+)
+
+var (
+	// Version of micro, set by the cmd package from the git tag or release version
+	Version string
+	// Commit the binary was built from, set by the cmd package from ldflags
+	Commit string
+	// Date the binary was built, set by the cmd package from ldflags
+	Date string
+)
+
+// Info is the build metadata
+type Info struct {
+	Version   string   `json:"version"`
+	Commit    string   `json:"commit"`
+	Date      string   `json:"date"`
+	GoVersion string   `json:"go_version"`
+	OS        string   `json:"os"`
+	Arch      string   `json:"arch"`
+	Plugins   []string `json:"plugins"`
+}
+
+// Get returns the build metadata and the plugins registered
+func Get() *Info {
+	info := &Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Plugins:   []string{},
+	}
+	for _, p := range plugin.Plugins() {
+		info.Plugins = append(info.Plugins, p.String())
+	}
+	return info
+}
@@ -0,0 +1,112 @@
+package cbor
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	testData := []struct {
+		val    interface{}
+		expect []byte
+	}{
+		{nil, []byte{0xf6}},
+		{true, []byte{0xf5}},
+		{int64(10), []byte{0x0a}},
+		{int64(-1), []byte{0x20}},
+		{int64(1000), []byte{0x19, 0x03, 0xe8}},
+		{"a", []byte{0x61, 'a'}},
+		{[]interface{}{int64(1), "a"}, []byte{0x82, 0x01, 0x61, 'a'}},
+		{map[string]interface{}{"a": false}, []byte{0xa1, 0x61, 'a', 0xf4}},
+	}
+
+	for _, d := range testData {
+		b, err := Encode(d.val)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, d.expect) {
+			t.Fatalf("Expected %x for %v, got %x", d.expect, d.val, b)
+		}
+		v, err := Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, d.val) {
+			t.Fatalf("Expected %v decoding %x, got %v", d.val, b, v)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testData := []struct {
+		data   []byte
+		expect interface{}
+	}{
+		// half precision float
+		{[]byte{0xf9, 0x3c, 0x00}, float64(1)},
+		// indefinite length text
+		{[]byte{0x7f, 0x61, 'a', 0x61, 'b', 0xff}, "ab"},
+		// indefinite length array
+		{[]byte{0x9f, 0x01, 0x02, 0xff}, []interface{}{int64(1), int64(2)}},
+		// tags are ignored
+		{[]byte{0xc1, 0x01}, int64(1)},
+		// integer keys are formatted as strings
+		{[]byte{0xa1, 0x01, 0x02}, map[string]interface{}{"1": int64(2)}},
+	}
+
+	for _, d := range testData {
+		v, err := Decode(d.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, d.expect) {
+			t.Fatalf("Expected %v decoding %x, got %v", d.expect, d.data, v)
+		}
+	}
+
+	for _, b := range [][]byte{{}, {0x19, 0x03}, {0x82, 0x01}, {0xe0}, {0x01, 0x01}} {
+		if _, err := Decode(b); err == nil {
+			t.Fatalf("Expected error decoding %x", b)
+		}
+	}
+}
+
+func TestDecodeDepth(t *testing.T) {
+	// arrays of one array nested as deep as allowed around a null
+	b := append(bytes.Repeat([]byte{0x81}, MaxDepth-1), 0xf6)
+	if _, err := Decode(b); err != nil {
+		t.Fatalf("Unexpected error decoding %d nested arrays: %v", MaxDepth-1, err)
+	}
+
+	// the nesting of a large body errors rather than overflowing the stack, tags nest too
+	for _, c := range []byte{0x81, 0xa1, 0xc1} {
+		if _, err := Decode(bytes.Repeat([]byte{c}, 4<<20)); err != ErrTooDeep {
+			t.Fatalf("Expected ErrTooDeep decoding nested %x, got %v", c, err)
+		}
+	}
+}
+
+func TestMarshaler(t *testing.T) {
+	type message struct {
+		Name  string   `json:"name"`
+		Count int      `json:"count"`
+		Tags  []string `json:"tags"`
+	}
+
+	in := message{Name: "micro", Count: 3, Tags: []string{"a", "b"}}
+
+	var m Marshaler
+	b, err := m.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out message
+	if err := m.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("Expected %+v, got %+v", in, out)
+	}
+}
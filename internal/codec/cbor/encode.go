@@ -0,0 +1,341 @@
+package cbor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// the major types of data items
+const (
+	majorUint   = 0
+	majorNegint = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+)
+
+// the additional information which marks an indefinite length
+const indefinite = 31
+
+var (
+	// ErrUnsupported is returned when decoding types outside the json data model e.g. simple values
+	ErrUnsupported = errors.New("unsupported cbor type")
+	// ErrTruncated is returned when the data ends before the value
+	ErrTruncated = errors.New("truncated cbor data")
+	// ErrTooDeep is returned when the values are nested deeper than MaxDepth
+	ErrTooDeep = errors.New("cbor data nested too deeply")
+)
+
+// MaxDepth is how deeply the values decoded can be nested, as encoding/json allows
+const MaxDepth = 10000
+
+// Encode encodes the generic value as cbor
+func Encode(val interface{}) ([]byte, error) {
+	return encode(nil, val)
+}
+
+func encode(b []byte, val interface{}) ([]byte, error) {
+	switch v := val.(type) {
+	case nil:
+		return append(b, 0xf6), nil
+	case bool:
+		if v {
+			return append(b, 0xf5), nil
+		}
+		return append(b, 0xf4), nil
+	case int64:
+		if v < 0 {
+			return encodeHead(b, majorNegint, uint64(-1-v)), nil
+		}
+		return encodeHead(b, majorUint, uint64(v)), nil
+	case uint64:
+		return encodeHead(b, majorUint, v), nil
+	case float64:
+		b = append(b, majorSimple<<5|27)
+		buf := make([]byte, 8)
+		binary.BigEndian.PutUint64(buf, math.Float64bits(v))
+		return append(b, buf...), nil
+	case string:
+		b = encodeHead(b, majorText, uint64(len(v)))
+		return append(b, v...), nil
+	case []byte:
+		b = encodeHead(b, majorBytes, uint64(len(v)))
+		return append(b, v...), nil
+	case []interface{}:
+		b = encodeHead(b, majorArray, uint64(len(v)))
+		for _, e := range v {
+			var err error
+			if b, err = encode(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]interface{}:
+		b = encodeHead(b, majorMap, uint64(len(v)))
+		for k, e := range v {
+			var err error
+			if b, err = encode(b, k); err != nil {
+				return nil, err
+			}
+			if b, err = encode(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("cbor: can't encode %T", val)
+}
+
+// encodeHead encodes the major type and argument in the fewest bytes
+func encodeHead(b []byte, major byte, v uint64) []byte {
+	switch {
+	case v < 24:
+		return append(b, major<<5|byte(v))
+	case v <= math.MaxUint8:
+		return append(b, major<<5|24, byte(v))
+	case v <= math.MaxUint16:
+		buf := make([]byte, 2)
+		binary.BigEndian.PutUint16(buf, uint16(v))
+		return append(append(b, major<<5|25), buf...)
+	case v <= math.MaxUint32:
+		buf := make([]byte, 4)
+		binary.BigEndian.PutUint32(buf, uint32(v))
+		return append(append(b, major<<5|26), buf...)
+	}
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	return append(append(b, major<<5|27), buf...)
+}
+
+// Decode decodes cbor into its generic value. Maps with keys which aren't strings have
+// their keys formatted as strings, as json objects only have string keys. Tags are ignored.
+func Decode(b []byte) (interface{}, error) {
+	d := &decoder{b: b}
+	val, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(d.b) {
+		return nil, fmt.Errorf("cbor: %d bytes after value", len(d.b)-d.off)
+	}
+	return val, nil
+}
+
+type decoder struct {
+	b   []byte
+	off int
+	// depth of the value decoded, the arrays and maps are decoded recursively
+	depth int
+}
+
+// next returns the next n bytes
+func (d *decoder) next(n uint64) ([]byte, error) {
+	if uint64(len(d.b)-d.off) < n {
+		return nil, ErrTruncated
+	}
+	b := d.b[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// head reads the major type, additional information and argument of the next data item
+func (d *decoder) head() (byte, byte, uint64, error) {
+	t, err := d.next(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info := t[0]>>5, t[0]&0x1f
+
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		b, err := d.next(1 << (info - 24))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		var v uint64
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+		return major, info, v, nil
+	case info == indefinite && major >= majorBytes && major <= majorMap:
+		return major, info, 0, nil
+	}
+	return 0, 0, 0, ErrUnsupported
+}
+
+// isBreak returns true and skips the break if it's next
+func (d *decoder) isBreak() bool {
+	if d.off < len(d.b) && d.b[d.off] == 0xff {
+		d.off++
+		return true
+	}
+	return false
+}
+
+func (d *decoder) decode() (interface{}, error) {
+	if d.depth++; d.depth > MaxDepth {
+		return nil, ErrTooDeep
+	}
+	defer func() { d.depth-- }()
+
+	major, info, v, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUint:
+		if v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+		return v, nil
+	case majorNegint:
+		if v <= math.MaxInt64 {
+			return -1 - int64(v), nil
+		}
+		return -1 - float64(v), nil
+	case majorBytes, majorText:
+		b, err := d.decodeString(major, info, v)
+		if err != nil {
+			return nil, err
+		}
+		if major == majorText {
+			return string(b), nil
+		}
+		return b, nil
+	case majorArray:
+		return d.decodeArray(info, v)
+	case majorMap:
+		return d.decodeMap(info, v)
+	case majorTag:
+		// the tagged value is used as is
+		return d.decode()
+	}
+
+	// simple values and floats
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		return halfFloat(uint16(v)), nil
+	case 26:
+		return float64(math.Float32frombits(uint32(v))), nil
+	case 27:
+		return math.Float64frombits(v), nil
+	}
+	return nil, ErrUnsupported
+}
+
+// decodeString decodes a byte or text string, joining the chunks of indefinite length strings
+func (d *decoder) decodeString(major, info byte, n uint64) ([]byte, error) {
+	if info != indefinite {
+		b, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	}
+
+	var s []byte
+	for !d.isBreak() {
+		m, i, n, err := d.head()
+		if err != nil {
+			return nil, err
+		}
+		// the chunks are definite length strings of the same type
+		if m != major || i == indefinite {
+			return nil, fmt.Errorf("cbor: invalid string chunk")
+		}
+		b, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, b...)
+	}
+	return s, nil
+}
+
+func (d *decoder) decodeArray(info byte, n uint64) (interface{}, error) {
+	// each element is at least a byte
+	if info != indefinite && n > uint64(len(d.b)-d.off) {
+		return nil, ErrTruncated
+	}
+	v := make([]interface{}, 0, n)
+	for i := uint64(0); info == indefinite || i < n; i++ {
+		if info == indefinite && d.isBreak() {
+			break
+		}
+		e, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, e)
+	}
+	return v, nil
+}
+
+func (d *decoder) decodeMap(info byte, n uint64) (interface{}, error) {
+	// each key and value is at least a byte
+	if info != indefinite && n > uint64(len(d.b)-d.off)/2 {
+		return nil, ErrTruncated
+	}
+	v := make(map[string]interface{}, n)
+	for i := uint64(0); info == indefinite || i < n; i++ {
+		if info == indefinite && d.isBreak() {
+			break
+		}
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		e, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		switch k := k.(type) {
+		case string:
+			v[k] = e
+		case []byte:
+			v[string(k)] = e
+		default:
+			v[fmt.Sprint(k)] = e
+		}
+	}
+	return v, nil
+}
+
+// halfFloat converts a half precision float
+func halfFloat(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,40 @@
+package cbor
+
+import (
+	"github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/transcode"
+)
+
+// Marshaler encodes values as cbor with the field names they have in json
+type Marshaler struct{}
+
+func (Marshaler) Marshal(v interface{}) ([]byte, error) {
+	if f, ok := v.(*bytes.Frame); ok {
+		return f.Data, nil
+	}
+	val, err := transcode.ToValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(val)
+}
+
+func (Marshaler) Unmarshal(d []byte, v interface{}) error {
+	if f, ok := v.(*bytes.Frame); ok {
+		f.Data = d
+		return nil
+	}
+	// nothing to decode
+	if len(d) == 0 {
+		return nil
+	}
+	val, err := Decode(d)
+	if err != nil {
+		return err
+	}
+	return transcode.FromValue(val, v)
+}
+
+func (Marshaler) String() string {
+	return "cbor"
+}
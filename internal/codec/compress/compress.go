@@ -0,0 +1,94 @@
+// Package compress compresses the encoded payload of messages
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// AcceptHeader lists the compression a peer accepts in order of preference
+	AcceptHeader = "Micro-Accept-Encoding"
+	// EncodingHeader is the compression the payload of a message is encoded with
+	EncodingHeader = "Micro-Content-Encoding"
+)
+
+var (
+	// DefaultThreshold is the size in bytes above which payloads are compressed
+	DefaultThreshold = 1024
+
+	// Compressors are the supported compression keyed by name
+	Compressors = map[string]Compressor{
+		"gzip": gzipCompressor{},
+	}
+
+	// ErrUnsupported is returned when the compression isn't supported
+	ErrUnsupported = errors.New("unsupported compression")
+)
+
+// Compressor compresses and decompresses payloads
+type Compressor interface {
+	Compress([]byte) ([]byte, error)
+	Decompress([]byte) ([]byte, error)
+}
+
+// Negotiate returns the first compression of those accepted, a comma separated
+// list, which is also in the supported list. It returns blank if there's none.
+func Negotiate(accept string, supported []string) string {
+	for _, name := range strings.Split(accept, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := Compressors[name]; !ok {
+			continue
+		}
+		for _, s := range supported {
+			if s == name {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// Compress compresses the payload
+func Compress(name string, b []byte) ([]byte, error) {
+	c, ok := Compressors[name]
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	return c.Compress(b)
+}
+
+// Decompress decompresses the payload
+func Decompress(name string, b []byte) ([]byte, error) {
+	c, ok := Compressors[name]
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	return c.Decompress(b)
+}
+
+type gzipCompressor struct{}
+
+func (gzipCompressor) Compress(b []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCompressor) Decompress(b []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,54 @@
+package compress
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	testData := []struct {
+		accept    string
+		supported []string
+		expect    string
+	}{
+		{"gzip", []string{"gzip"}, "gzip"},
+		{"snappy, gzip", []string{"gzip"}, "gzip"},
+		{"gzip", nil, ""},
+		{"", []string{"gzip"}, ""},
+		// unknown compression is never picked even if both sides list it
+		{"zstd", []string{"zstd"}, ""},
+	}
+
+	for _, d := range testData {
+		if got := Negotiate(d.accept, d.supported); got != d.expect {
+			t.Fatalf("Expected %q for %q and %v, got %q", d.expect, d.accept, d.supported, got)
+		}
+	}
+}
+
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte("micro"), 100)
+
+	b, err := Compress("gzip", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) >= len(data) {
+		t.Fatalf("Expected compressed data to be smaller than %d, got %d", len(data), len(b))
+	}
+
+	d, err := Decompress("gzip", b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(d, data) {
+		t.Fatal("Expected decompressed data to match")
+	}
+
+	if _, err := Compress("zstd", data); err != ErrUnsupported {
+		t.Fatalf("Expected unsupported error, got %v", err)
+	}
+	if _, err := Decompress("gzip", data); err == nil {
+		t.Fatal("Expected error decompressing invalid data")
+	}
+}
@@ -0,0 +1,322 @@
+package msgpack
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrUnsupported is returned when decoding types outside the json data model e.g. extensions
+	ErrUnsupported = errors.New("unsupported msgpack type")
+	// ErrTruncated is returned when the data ends before the value
+	ErrTruncated = errors.New("truncated msgpack data")
+	// ErrTooDeep is returned when the values are nested deeper than MaxDepth
+	ErrTooDeep = errors.New("msgpack data nested too deeply")
+)
+
+// MaxDepth is how deeply the values decoded can be nested, as encoding/json allows
+const MaxDepth = 10000
+
+// Encode encodes the generic value as msgpack
+func Encode(val interface{}) ([]byte, error) {
+	return encode(nil, val)
+}
+
+func encode(b []byte, val interface{}) ([]byte, error) {
+	switch v := val.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if v {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case int64:
+		return encodeInt(b, v), nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return encodeInt(b, int64(v)), nil
+		}
+		return append(append(b, 0xcf), uint64Bytes(v)...), nil
+	case float64:
+		return append(append(b, 0xcb), uint64Bytes(math.Float64bits(v))...), nil
+	case string:
+		n := len(v)
+		switch {
+		case n < 32:
+			b = append(b, 0xa0|byte(n))
+		case n <= math.MaxUint8:
+			b = append(b, 0xd9, byte(n))
+		case n <= math.MaxUint16:
+			b = append(append(b, 0xda), uint16Bytes(uint16(n))...)
+		default:
+			b = append(append(b, 0xdb), uint32Bytes(uint32(n))...)
+		}
+		return append(b, v...), nil
+	case []byte:
+		n := len(v)
+		switch {
+		case n <= math.MaxUint8:
+			b = append(b, 0xc4, byte(n))
+		case n <= math.MaxUint16:
+			b = append(append(b, 0xc5), uint16Bytes(uint16(n))...)
+		default:
+			b = append(append(b, 0xc6), uint32Bytes(uint32(n))...)
+		}
+		return append(b, v...), nil
+	case []interface{}:
+		b = encodeLen(b, len(v), 0x90, 0xdc, 0xdd)
+		for _, e := range v {
+			var err error
+			if b, err = encode(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]interface{}:
+		b = encodeLen(b, len(v), 0x80, 0xde, 0xdf)
+		for k, e := range v {
+			var err error
+			if b, err = encode(b, k); err != nil {
+				return nil, err
+			}
+			if b, err = encode(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("msgpack: can't encode %T", val)
+}
+
+// encodeInt encodes the integer in the fewest bytes
+func encodeInt(b []byte, v int64) []byte {
+	switch {
+	case v >= 0 && v <= 127:
+		return append(b, byte(v))
+	case v < 0 && v >= -32:
+		return append(b, byte(v))
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return append(b, 0xd0, byte(v))
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return append(append(b, 0xd1), uint16Bytes(uint16(v))...)
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return append(append(b, 0xd2), uint32Bytes(uint32(v))...)
+	}
+	return append(append(b, 0xd3), uint64Bytes(uint64(v))...)
+}
+
+// encodeLen encodes the length of an array or map with the fixed, 16 and 32 bit prefixes
+func encodeLen(b []byte, n int, fix, b16, b32 byte) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return append(append(b, b16), uint16Bytes(uint16(n))...)
+	}
+	return append(append(b, b32), uint32Bytes(uint32(n))...)
+}
+
+func uint16Bytes(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	return b
+}
+
+func uint32Bytes(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
+
+func uint64Bytes(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+// Decode decodes msgpack into its generic value. Maps with keys which aren't strings
+// have their keys formatted as strings, as json objects only have string keys.
+func Decode(b []byte) (interface{}, error) {
+	d := &decoder{b: b}
+	val, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(d.b) {
+		return nil, fmt.Errorf("msgpack: %d bytes after value", len(d.b)-d.off)
+	}
+	return val, nil
+}
+
+type decoder struct {
+	b   []byte
+	off int
+	// depth of the value decoded, the arrays and maps are decoded recursively
+	depth int
+}
+
+// next returns the next n bytes
+func (d *decoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.b)-d.off < n {
+		return nil, ErrTruncated
+	}
+	b := d.b[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+// uint reads an unsigned big endian integer of n bytes
+func (d *decoder) uint(n int) (uint64, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+func (d *decoder) decode() (interface{}, error) {
+	if d.depth++; d.depth > MaxDepth {
+		return nil, ErrTooDeep
+	}
+	defer func() { d.depth-- }()
+
+	t, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+	c := t[0]
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == 0x80:
+		return d.decodeMap(int(c & 0x0f))
+	case c&0xf0 == 0x90:
+		return d.decodeArray(int(c & 0x0f))
+	case c&0xe0 == 0xa0:
+		return d.decodeString(int(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.uint(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.next(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case 0xca:
+		v, err := d.uint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 0xcb:
+		v, err := d.uint(8)
+		return math.Float64frombits(v), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := d.uint(1 << (c - 0xcc))
+		if v <= math.MaxInt64 {
+			return int64(v), err
+		}
+		return v, err
+	case 0xd0:
+		v, err := d.uint(1)
+		return int64(int8(v)), err
+	case 0xd1:
+		v, err := d.uint(2)
+		return int64(int16(v)), err
+	case 0xd2:
+		v, err := d.uint(4)
+		return int64(int32(v)), err
+	case 0xd3:
+		v, err := d.uint(8)
+		return int64(v), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.uint(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(int(n))
+	case 0xdc, 0xdd:
+		n, err := d.uint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(int(n))
+	case 0xde, 0xdf:
+		n, err := d.uint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(int(n))
+	}
+
+	return nil, ErrUnsupported
+}
+
+func (d *decoder) decodeString(n int) (interface{}, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (d *decoder) decodeArray(n int) (interface{}, error) {
+	// each element is at least a byte
+	if n > len(d.b)-d.off {
+		return nil, ErrTruncated
+	}
+	v := make([]interface{}, n)
+	for i := range v {
+		e, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		v[i] = e
+	}
+	return v, nil
+}
+
+func (d *decoder) decodeMap(n int) (interface{}, error) {
+	// each key and value is at least a byte
+	if n > (len(d.b)-d.off)/2 {
+		return nil, ErrTruncated
+	}
+	v := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		e, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		switch k := k.(type) {
+		case string:
+			v[k] = e
+		case []byte:
+			v[string(k)] = e
+		default:
+			v[fmt.Sprint(k)] = e
+		}
+	}
+	return v, nil
+}
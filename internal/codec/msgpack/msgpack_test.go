@@ -0,0 +1,86 @@
+package msgpack
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	testData := []struct {
+		val    interface{}
+		expect []byte
+	}{
+		{nil, []byte{0xc0}},
+		{true, []byte{0xc3}},
+		{int64(1), []byte{0x01}},
+		{int64(-1), []byte{0xff}},
+		{int64(1000), []byte{0xd1, 0x03, 0xe8}},
+		{"a", []byte{0xa1, 'a'}},
+		{[]interface{}{int64(1), "a"}, []byte{0x92, 0x01, 0xa1, 'a'}},
+		{map[string]interface{}{"a": false}, []byte{0x81, 0xa1, 'a', 0xc2}},
+	}
+
+	for _, d := range testData {
+		b, err := Encode(d.val)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, d.expect) {
+			t.Fatalf("Expected %x for %v, got %x", d.expect, d.val, b)
+		}
+		v, err := Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, d.val) {
+			t.Fatalf("Expected %v decoding %x, got %v", d.val, b, v)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, b := range [][]byte{{}, {0xd1, 0x03}, {0x92, 0x01}, {0xc7, 0x01, 0x01, 0x00}, {0x01, 0x01}} {
+		if _, err := Decode(b); err == nil {
+			t.Fatalf("Expected error decoding %x", b)
+		}
+	}
+}
+
+func TestDecodeDepth(t *testing.T) {
+	// arrays of one array nested as deep as allowed around a nil
+	b := append(bytes.Repeat([]byte{0x91}, MaxDepth-1), 0xc0)
+	if _, err := Decode(b); err != nil {
+		t.Fatalf("Unexpected error decoding %d nested arrays: %v", MaxDepth-1, err)
+	}
+
+	// the nesting of a large body errors rather than overflowing the stack
+	for _, c := range []byte{0x91, 0x81} {
+		if _, err := Decode(bytes.Repeat([]byte{c}, 4<<20)); err != ErrTooDeep {
+			t.Fatalf("Expected ErrTooDeep decoding nested %x, got %v", c, err)
+		}
+	}
+}
+
+func TestMarshaler(t *testing.T) {
+	type message struct {
+		Name  string   `json:"name"`
+		Count int      `json:"count"`
+		Tags  []string `json:"tags"`
+	}
+
+	in := message{Name: "micro", Count: 3, Tags: []string{"a", "b"}}
+
+	var m Marshaler
+	b, err := m.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out message
+	if err := m.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("Expected %+v, got %+v", in, out)
+	}
+}
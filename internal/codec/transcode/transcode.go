@@ -0,0 +1,100 @@
+// Package transcode converts values to and from the generic values of the JSON data model so
+// formats sharing the data model e.g. msgpack and cbor encode values the same way as json,
+// including the field names of protobuf messages.
+package transcode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	jsonc "github.com/micro/micro/v3/internal/codec/json"
+)
+
+// ToValue converts v to its generic value. Objects are map[string]interface{}, arrays are
+// []interface{} and numbers are int64, uint64 or float64.
+func ToValue(v interface{}) (interface{}, error) {
+	b, err := jsonc.Marshaler{}.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return FromJSON(b)
+}
+
+// FromValue sets v from the generic value
+func FromValue(val interface{}, v interface{}) error {
+	b, err := ToJSON(val)
+	if err != nil {
+		return err
+	}
+	return jsonc.Marshaler{}.Unmarshal(b, v)
+}
+
+// FromJSON decodes json into its generic value
+func FromJSON(b []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var val interface{}
+	if err := d.Decode(&val); err != nil {
+		return nil, err
+	}
+	return number(val), nil
+}
+
+// ToJSON encodes the generic value as json, binary data is base64 encoded
+func ToJSON(val interface{}) ([]byte, error) {
+	if err := validate(val); err != nil {
+		return nil, err
+	}
+	return json.Marshal(val)
+}
+
+// number replaces the json numbers in the value with int64, uint64 or float64
+func number(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = number(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = number(v[k])
+		}
+	}
+	return val
+}
+
+// validate checks the floats in the value can be encoded as json
+func validate(val interface{}) error {
+	switch v := val.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("unsupported float %v", v)
+		}
+	case []interface{}:
+		for _, e := range v {
+			if err := validate(e); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			if err := validate(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
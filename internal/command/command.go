@@ -239,7 +239,8 @@ func CallService(c *cli.Context, args []string) ([]byte, error) {
 
 	creq := client.DefaultClient.NewRequest(service, endpoint, request, client.WithContentType("application/json"))
 
-	opts := []client.CallOption{client.WithAuthToken()}
+	header := make(map[string]string)
+	opts := []client.CallOption{client.WithAuthToken(), client.WithResponseHeader(header)}
 	if timeout := c.String("request_timeout"); timeout != "" {
 		duration, err := time.ParseDuration(timeout)
 		if err != nil {
@@ -275,6 +276,7 @@ func CallService(c *cli.Context, args []string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	util.WarnDeprecated(service, endpoint, header)
 
 	return response, nil
 }
@@ -307,7 +309,7 @@ func QueryHealth(c *cli.Context, args []string) ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
-		return []byte(rsp.Status), nil
+		return []byte(healthStatus(rsp)), nil
 	}
 
 	// otherwise get the service and call each instance individually
@@ -348,7 +350,7 @@ func QueryHealth(c *cli.Context, args []string) ([]byte, error) {
 			if err != nil {
 				status = err.Error()
 			} else {
-				status = rsp.Status
+				status = healthStatus(rsp)
 			}
 			output = append(output, fmt.Sprintf("%s\t\t%s\t\t%s", node.Id, node.Address, status))
 		}
@@ -356,3 +358,17 @@ func QueryHealth(c *cli.Context, args []string) ([]byte, error) {
 
 	return []byte(strings.Join(output, "\n")), nil
 }
+
+// healthStatus returns the status of a service followed by its failing checks, if any
+func healthStatus(rsp *proto.HealthResponse) string {
+	var failing []string
+	for _, c := range rsp.Checks {
+		if c.Status != "ok" {
+			failing = append(failing, c.Name+": "+c.Error)
+		}
+	}
+	if len(failing) == 0 {
+		return rsp.Status
+	}
+	return rsp.Status + " (" + strings.Join(failing, ", ") + ")"
+}
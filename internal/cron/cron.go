@@ -0,0 +1,213 @@
+// Package cron parses standard five field cron expressions
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSpec is returned when a cron expression can't be parsed
+	ErrInvalidSpec = errors.New("invalid cron expression")
+
+	// shorthands for commonly used expressions
+	macros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// bounds of a single field in the expression
+type bounds struct {
+	min, max int
+}
+
+var (
+	minutes = bounds{0, 59}
+	hours   = bounds{0, 23}
+	days    = bounds{1, 31}
+	months  = bounds{1, 12}
+	weekday = bounds{0, 7}
+)
+
+// Schedule is a parsed cron expression. Each field is a bitset of the values which match.
+type Schedule struct {
+	spec    string
+	minute  uint64
+	hour    uint64
+	day     uint64
+	month   uint64
+	weekday uint64
+	// dayStar and weekdayStar track wildcards since a restricted day of month and day of week
+	// are matched using OR, as is the convention with cron
+	dayStar     bool
+	weekdayStar bool
+}
+
+// Parse a cron expression in the format "minute hour day-of-month month day-of-week", e.g.
+// "0 3 * * *" for 3am every day. Lists (1,2), ranges (1-5), steps (*/15) and the macros such
+// as @daily are supported.
+func Parse(spec string) (*Schedule, error) {
+	expr := strings.TrimSpace(spec)
+	if m, ok := macros[expr]; ok {
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidSpec, len(fields))
+	}
+
+	s := &Schedule{
+		spec:        spec,
+		dayStar:     fields[2] == "*",
+		weekdayStar: fields[4] == "*",
+	}
+
+	var err error
+	if s.minute, err = parseField(fields[0], minutes); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hours); err != nil {
+		return nil, err
+	}
+	if s.day, err = parseField(fields[2], days); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], months); err != nil {
+		return nil, err
+	}
+	if s.weekday, err = parseField(fields[4], weekday); err != nil {
+		return nil, err
+	}
+	// sunday can be written as 0 or 7
+	if has(s.weekday, 7) {
+		s.weekday |= 1
+	}
+
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time after t which matches the schedule. A zero time is returned if
+// no match is found within the next five years (e.g. for "0 0 30 2 *").
+func (s *Schedule) Next(t time.Time) time.Time {
+	// cron has a resolution of a minute, start at the beginning of the next one
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	dom := has(s.day, t.Day())
+	dow := has(s.weekday, int(t.Weekday()))
+
+	switch {
+	case s.dayStar && s.weekdayStar:
+		return true
+	case s.dayStar:
+		return dow
+	case s.weekdayStar:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) > 0
+}
+
+// parseField parses a single comma separated field into a bitset
+func parseField(field string, b bounds) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: invalid step in %q", ErrInvalidSpec, field)
+			}
+			part = part[:idx]
+		}
+
+		start, end := b.min, b.max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			rng := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = parseValue(rng[0], b); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(rng[1], b); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("%w: invalid range %q", ErrInvalidSpec, part)
+			}
+		default:
+			v, err := parseValue(part, b)
+			if err != nil {
+				return 0, err
+			}
+			start = v
+			// a step on a single value applies until the end of the range, e.g. 5/15
+			if step == 1 {
+				end = v
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			set |= 1 << uint(i)
+		}
+	}
+
+	return set, nil
+}
+
+func parseValue(v string, b bounds) (int, error) {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid value %q", ErrInvalidSpec, v)
+	}
+	if i < b.min || i > b.max {
+		return 0, fmt.Errorf("%w: %d out of range %d-%d", ErrInvalidSpec, i, b.min, b.max)
+	}
+	return i, nil
+}
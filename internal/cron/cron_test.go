@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	valid := []string{"* * * * *", "0 3 * * *", "*/15 * * * *", "0 9-17 * * 1-5", "0 0 1,15 * *", "@daily", "0 0 * * 7"}
+	for _, spec := range valid {
+		if _, err := Parse(spec); err != nil {
+			t.Errorf("Expected %q to parse, got %v", spec, err)
+		}
+	}
+
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"}
+	for _, spec := range invalid {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Expected %q to fail to parse", spec)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// a wednesday
+	now := time.Date(2020, 10, 14, 10, 30, 15, 0, time.UTC)
+
+	tt := []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2020, 10, 14, 10, 31, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2020, 10, 15, 3, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, 10, 14, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * 1", time.Date(2020, 10, 19, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2020, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, 10, 18, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tt {
+		s, err := Parse(tc.spec)
+		if err != nil {
+			t.Fatalf("Error parsing %q: %v", tc.spec, err)
+		}
+		if next := s.Next(now); !next.Equal(tc.next) {
+			t.Errorf("Expected next run of %q to be %v, got %v", tc.spec, tc.next, next)
+		}
+	}
+
+	// the 30th of february never happens
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := s.Next(now); !next.IsZero() {
+		t.Errorf("Expected zero time, got %v", next)
+	}
+}
@@ -0,0 +1,25 @@
+// Package daemon integrates micro with the service managers it's installed with by micro install,
+// notifying systemd of the state of the service on unix and running as a service of the service
+// control manager on windows. The functions are no-ops when micro isn't run by a service manager.
+package daemon
+
+// Ready tells the service manager the service has started
+func Ready() error {
+	return ready()
+}
+
+// Stopping tells the service manager the service is shutting down
+func Stopping() error {
+	return stopping()
+}
+
+// Stopped tells the service manager the service has shut down
+func Stopped() error {
+	return stopped()
+}
+
+// Stop returns a channel which is closed when the service manager stops the service. Systemd
+// stops services with a signal so the channel is only closed on windows.
+func Stop() <-chan struct{} {
+	return stop
+}
@@ -0,0 +1,48 @@
+// +build !windows
+
+package daemon
+
+import (
+	"net"
+	"os"
+)
+
+var (
+	// socket systemd listens for notifications on, set for services of Type=notify
+	socket = os.Getenv("NOTIFY_SOCKET")
+
+	stop = make(chan struct{})
+)
+
+func init() {
+	// the processes started by micro e.g. the services run by the server mustn't notify systemd
+	os.Unsetenv("NOTIFY_SOCKET")
+}
+
+// notify sends the state to systemd, see sd_notify(3)
+func notify(state string) error {
+	if len(socket) == 0 {
+		return nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+func ready() error {
+	return notify("READY=1")
+}
+
+func stopping() error {
+	return notify("STOPPING=1")
+}
+
+func stopped() error {
+	return nil
+}
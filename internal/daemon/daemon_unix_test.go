@@ -0,0 +1,50 @@
+// +build !windows
+
+package daemon
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	// not run by systemd
+	if err := Ready(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "daemon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	addr := &net.UnixAddr{Name: filepath.Join(dir, "notify.sock"), Net: "unixgram"}
+	conn, err := net.ListenUnixgram("unixgram", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	socket = addr.Name
+	defer func() {
+		socket = ""
+	}()
+
+	for state, fn := range map[string]func() error{"READY=1": Ready, "STOPPING=1": Stopping} {
+		if err := fn(); err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, 64)
+		n, err := conn.Read(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b[:n]) != state {
+			t.Fatalf("Expected %s, got %s", state, b[:n])
+		}
+	}
+}
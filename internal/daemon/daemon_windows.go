@@ -0,0 +1,77 @@
+// +build windows
+
+package daemon
+
+import (
+	"sync"
+
+	"golang.org/x/sys/windows/svc"
+)
+
+var (
+	stop = make(chan struct{})
+
+	stopOnce, readyOnce, stoppedOnce sync.Once
+
+	readyCh   = make(chan struct{})
+	stoppedCh = make(chan struct{})
+)
+
+func init() {
+	// services aren't run in an interactive session
+	interactive, err := svc.IsAnInteractiveSession()
+	if err != nil || interactive {
+		return
+	}
+
+	// the dispatcher fails to start for processes which aren't a service, such as the
+	// services run by the server, in which case the state isn't reported
+	go svc.Run("micro", new(handler))
+}
+
+type handler struct{}
+
+func (h *handler) Execute(args []string, reqs <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+
+	accepts := svc.AcceptStop | svc.AcceptShutdown
+	started := readyCh
+	running := false
+
+	for {
+		select {
+		case <-started:
+			started = nil
+			running = true
+			status <- svc.Status{State: svc.Running, Accepts: accepts}
+		case <-stoppedCh:
+			return false, 0
+		case req := <-reqs:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending}
+				stopOnce.Do(func() { close(stop) })
+				// the service never started so there's nothing to wait for
+				if !running {
+					return false, 0
+				}
+			}
+		}
+	}
+}
+
+func ready() error {
+	readyOnce.Do(func() { close(readyCh) })
+	return nil
+}
+
+func stopping() error {
+	return nil
+}
+
+func stopped() error {
+	stoppedOnce.Do(func() { close(stoppedCh) })
+	return nil
+}
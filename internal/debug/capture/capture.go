@@ -0,0 +1,122 @@
+// Package capture records the spans of requests flagged with the Micro-Debug header in detail and
+// publishes them to the debug service, which bundles the spans of every hop of the request
+package capture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/debug/trace"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/server"
+)
+
+// Capturing returns true if the request has been flagged for capture
+func Capturing(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	v, ok := metadata.Get(ctx, debug.CaptureHeader)
+	return ok && strings.EqualFold(v, "true")
+}
+
+// Annotate the span of a captured request with the sizes of the request and response, before it's
+// finished so the tracer records them too
+func Annotate(s *trace.Span, req, rsp interface{}) {
+	if s == nil {
+		return
+	}
+	s.Metadata["debug"] = "true"
+	s.Metadata["request.size"] = strconv.Itoa(Size(req))
+	s.Metadata["response.size"] = strconv.Itoa(Size(rsp))
+}
+
+// Publish the finished span of a captured request to the debug service. The span is published
+// asynchronously so capturing doesn't slow the request down.
+func Publish(ctx context.Context, s *trace.Span) {
+	if s == nil {
+		return
+	}
+	reqSize, _ := strconv.ParseInt(s.Metadata["request.size"], 10, 64)
+	rspSize, _ := strconv.ParseInt(s.Metadata["response.size"], 10, 64)
+
+	span := &pb.CaptureSpan{
+		Trace:        s.Trace,
+		Id:           s.Id,
+		Parent:       s.Parent,
+		Name:         s.Name,
+		Type:         "inbound",
+		Started:      s.Started.UnixNano(),
+		Duration:     int64(s.Duration),
+		RequestSize:  reqSize,
+		ResponseSize: rspSize,
+		Error:        s.Metadata["error"],
+		Metadata:     make(map[string]string),
+	}
+	if s.Type == trace.SpanTypeRequestOutbound {
+		span.Type = "outbound"
+	}
+	if server.DefaultServer != nil {
+		opts := server.DefaultServer.Options()
+		span.Service, span.Node = opts.Name, opts.Id
+	}
+	if md, ok := metadata.FromContext(ctx); ok {
+		for k, v := range md {
+			// don't publish the credentials of the request
+			if strings.EqualFold(k, "Authorization") || strings.EqualFold(k, "Cookie") {
+				continue
+			}
+			span.Metadata[k] = v
+		}
+	}
+
+	go func() {
+		b, err := json.Marshal(span)
+		if err != nil {
+			return
+		}
+		// errors aren't logged since the request being captured may be logging too
+		if err := broker.Publish(debug.CaptureTopic, &broker.Message{
+			Header: map[string]string{"Content-Type": "application/json"},
+			Body:   b,
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error publishing captured span %v: %v\n", span.Name, err)
+		}
+	}()
+}
+
+// Size returns the size of a request or response in bytes, as encoded on the wire where possible
+func Size(v interface{}) int {
+	switch m := v.(type) {
+	case nil:
+		return 0
+	case *bytes.Frame:
+		return len(m.Data)
+	case *json.RawMessage:
+		if m == nil {
+			return 0
+		}
+		return len(*m)
+	case json.RawMessage:
+		return len(m)
+	case []byte:
+		return len(m)
+	case proto.Message:
+		return proto.Size(m)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
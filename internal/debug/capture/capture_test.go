@@ -0,0 +1,98 @@
+package capture
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/debug/trace"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/broker/memory"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/debug"
+)
+
+func TestCapturing(t *testing.T) {
+	if Capturing(context.TODO()) {
+		t.Errorf("Expected a request without the header not to be captured")
+	}
+	ctx := metadata.Set(context.TODO(), debug.CaptureHeader, "true")
+	if !Capturing(ctx) {
+		t.Errorf("Expected a request with the header to be captured")
+	}
+	ctx = metadata.Set(context.TODO(), debug.CaptureHeader, "false")
+	if Capturing(ctx) {
+		t.Errorf("Expected a request with the header set to false not to be captured")
+	}
+}
+
+func TestSize(t *testing.T) {
+	raw := json.RawMessage(`{"name":"John"}`)
+	tests := []struct {
+		v    interface{}
+		size int
+	}{
+		{nil, 0},
+		{&bytes.Frame{Data: []byte("hello")}, 5},
+		{&raw, 15},
+		{&pb.LabelsRequest{Name: "service"}, 9},
+		{map[string]string{"name": "John"}, 15},
+	}
+	for _, tc := range tests {
+		if got := Size(tc.v); got != tc.size {
+			t.Errorf("Expected the size of %v to be %v, got %v", tc.v, tc.size, got)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	defaultBroker := broker.DefaultBroker
+	defer func() { broker.DefaultBroker = defaultBroker }()
+	broker.DefaultBroker = memory.NewBroker()
+	broker.DefaultBroker.Connect()
+
+	spans := make(chan *pb.CaptureSpan, 1)
+	broker.Subscribe(debug.CaptureTopic, func(msg *broker.Message) error {
+		s := new(pb.CaptureSpan)
+		json.Unmarshal(msg.Body, s)
+		spans <- s
+		return nil
+	})
+
+	ctx := metadata.NewContext(context.TODO(), map[string]string{
+		debug.CaptureHeader: "true",
+		"Authorization":     "Bearer secret",
+	})
+	s := &trace.Span{
+		Trace:    "trace",
+		Id:       "span",
+		Name:     "helloworld.Helloworld.Call",
+		Started:  time.Now(),
+		Duration: time.Millisecond,
+		Metadata: map[string]string{"error": "not found"},
+		Type:     trace.SpanTypeRequestOutbound,
+	}
+	Annotate(s, map[string]string{"name": "John"}, nil)
+	if s.Metadata["debug"] != "true" || s.Metadata["request.size"] != "15" {
+		t.Errorf("Expected the span to be annotated, got %v", s.Metadata)
+	}
+	Publish(ctx, s)
+
+	select {
+	case span := <-spans:
+		if span.Trace != "trace" || span.Type != "outbound" || span.RequestSize != 15 || span.Error != "not found" {
+			t.Errorf("Unexpected span %+v", span)
+		}
+		if span.Duration != int64(time.Millisecond) {
+			t.Errorf("Expected the duration of the span, got %v", span.Duration)
+		}
+		if _, ok := span.Metadata["Authorization"]; ok {
+			t.Errorf("Expected the credentials not to be published")
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("Expected the span to be published")
+	}
+}
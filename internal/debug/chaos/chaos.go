@@ -0,0 +1,93 @@
+// Package chaos injects the faults of the chaos experiments set via the debug service into the
+// requests served by a service
+package chaos
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/errors"
+)
+
+// DefaultInjector is the injector used by the chaos handler wrapper
+var DefaultInjector = NewInjector()
+
+// Injector injects the faults of the experiments into requests
+type Injector struct {
+	sync.RWMutex
+	experiments []*pb.ChaosExperiment
+	// random returns a number in [0, 100) which decides whether a fault is injected
+	random func() float64
+}
+
+// NewInjector returns an injector without any experiments
+func NewInjector() *Injector {
+	return &Injector{
+		random: func() float64 { return rand.Float64() * 100 },
+	}
+}
+
+// Set the experiments, replacing those set before
+func (i *Injector) Set(experiments []*pb.ChaosExperiment) {
+	i.Lock()
+	defer i.Unlock()
+	i.experiments = experiments
+}
+
+// Inject the fault of the first experiment which matches the request, if any. Latency is injected
+// by waiting, for the latency or until the context is done, before returning nil. Errors and
+// resets are returned as the error the request should fail with.
+func (i *Injector) Inject(ctx context.Context, service, endpoint string) error {
+	exp := i.match(service, endpoint)
+	if exp == nil {
+		return nil
+	}
+
+	switch exp.Type {
+	case debug.ChaosLatency:
+		d, err := time.ParseDuration(exp.Latency)
+		if err != nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(d):
+		}
+		return nil
+	case debug.ChaosError:
+		code := exp.ErrorCode
+		if code == 0 {
+			code = 500
+		}
+		return errors.New(service, "Error injected by chaos experiment "+exp.Id, code)
+	case debug.ChaosReset:
+		// the error the client returns when the connection is reset
+		return errors.InternalServerError("go.micro.client", "Error sending request: connection reset by peer")
+	}
+	return nil
+}
+
+// match returns the experiment which injects a fault into the request, if any
+func (i *Injector) match(service, endpoint string) *pb.ChaosExperiment {
+	i.RLock()
+	defer i.RUnlock()
+
+	now := time.Now().Unix()
+	for _, exp := range i.experiments {
+		// experiments expire even if the debug service is unavailable
+		if exp.Expires <= now {
+			continue
+		}
+		if exp.Service != service || (len(exp.Endpoint) > 0 && exp.Endpoint != endpoint) {
+			continue
+		}
+		if i.random() < exp.Percentage {
+			return exp
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/errors"
+)
+
+func TestInject(t *testing.T) {
+	expires := time.Now().Add(time.Minute).Unix()
+	i := NewInjector()
+	i.Set([]*pb.ChaosExperiment{
+		{Id: "1", Service: "foo", Endpoint: "Foo.Error", Type: debug.ChaosError, Percentage: 50, ErrorCode: 503, Expires: expires},
+		{Id: "2", Service: "foo", Endpoint: "Foo.Reset", Type: debug.ChaosReset, Percentage: 100, Expires: expires},
+		{Id: "3", Service: "foo", Endpoint: "Foo.Latency", Type: debug.ChaosLatency, Percentage: 100, Latency: "20ms", Expires: expires},
+		{Id: "4", Service: "bar", Type: debug.ChaosError, Percentage: 100, Expires: time.Now().Add(-time.Minute).Unix()},
+	})
+
+	// a fault is injected into the percentage of requests
+	i.random = func() float64 { return 49 }
+	err := errors.FromError(i.Inject(context.TODO(), "foo", "Foo.Error"))
+	if err == nil || err.Code != 503 {
+		t.Errorf("Expected a 503 error to be injected, got %v", err)
+	}
+	i.random = func() float64 { return 50 }
+	if err := i.Inject(context.TODO(), "foo", "Foo.Error"); err != nil {
+		t.Errorf("Expected no fault outside the percentage, got %v", err)
+	}
+
+	if err := i.Inject(context.TODO(), "foo", "Foo.Reset"); err == nil {
+		t.Errorf("Expected a reset to be injected")
+	}
+
+	start := time.Now()
+	if err := i.Inject(context.TODO(), "foo", "Foo.Latency"); err != nil {
+		t.Errorf("Expected latency to be injected without an error, got %v", err)
+	}
+	if time.Since(start) < time.Millisecond*20 {
+		t.Errorf("Expected latency to be injected")
+	}
+
+	// the latency is cut short when the request is cancelled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	i.Set([]*pb.ChaosExperiment{
+		{Id: "5", Service: "foo", Type: debug.ChaosLatency, Percentage: 100, Latency: "1h", Expires: expires},
+	})
+	i.random = func() float64 { return 0 }
+	if err := i.Inject(ctx, "foo", "Foo.Call"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	// expired experiments and other services are left alone
+	i.Set([]*pb.ChaosExperiment{
+		{Id: "4", Service: "bar", Type: debug.ChaosError, Percentage: 100, Expires: time.Now().Add(-time.Minute).Unix()},
+	})
+	if err := i.Inject(context.TODO(), "bar", "Bar.Call"); err != nil {
+		t.Errorf("Expected no fault from an expired experiment, got %v", err)
+	}
+	if err := i.Inject(context.TODO(), "baz", "Baz.Call"); err != nil {
+		t.Errorf("Expected no fault for another service, got %v", err)
+	}
+}
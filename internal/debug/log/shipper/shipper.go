@@ -0,0 +1,181 @@
+// Package shipper is a log which ships the records written to it to the debug service, via the
+// broker or directly, so the logs of every service can be queried in one place
+package shipper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/debug/log"
+	"github.com/micro/micro/v3/internal/debug/log/memory"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/debug"
+)
+
+const (
+	// TransportBroker ships the records by publishing them to the broker
+	TransportBroker = "broker"
+	// TransportRPC ships the records by calling the debug service
+	TransportRPC = "rpc"
+
+	// DefaultBatchSize is the number of records which trigger shipping
+	DefaultBatchSize = 100
+	// DefaultInterval records are shipped at if the batch size isn't reached
+	DefaultInterval = time.Second
+	// MaxQueueSize is the number of records queued after which new records are dropped, so an
+	// unavailable debug service doesn't exhaust memory
+	MaxQueueSize = 10000
+)
+
+// Options for the shipper
+type Options struct {
+	// Transport the records are shipped with, broker or rpc
+	Transport string
+	// Labels added to every record e.g. the service name
+	Labels map[string]string
+	// Log the records are also written to, so they can still be read via the debug handler
+	Log log.Log
+}
+
+type Option func(o *Options)
+
+// Transport the records are shipped with
+func Transport(t string) Option {
+	return func(o *Options) {
+		o.Transport = t
+	}
+}
+
+// Labels added to every record
+func Labels(l map[string]string) Option {
+	return func(o *Options) {
+		o.Labels = l
+	}
+}
+
+// Log the records are also written to
+func Log(l log.Log) Option {
+	return func(o *Options) {
+		o.Log = l
+	}
+}
+
+type shipper struct {
+	opts Options
+
+	sync.Mutex
+	queue []*pb.LogRecord
+	flush chan bool
+}
+
+// NewLog returns a log which ships the records written to it in batches
+func NewLog(opts ...Option) log.Log {
+	options := Options{Transport: TransportBroker}
+	for _, o := range opts {
+		o(&options)
+	}
+	if options.Log == nil {
+		options.Log = memory.NewLog()
+	}
+
+	s := &shipper{
+		opts:  options,
+		flush: make(chan bool, 1),
+	}
+	go s.run()
+	return s
+}
+
+func (s *shipper) Read(opts ...log.ReadOption) ([]log.Record, error) {
+	return s.opts.Log.Read(opts...)
+}
+
+func (s *shipper) Stream() (log.Stream, error) {
+	return s.opts.Log.Stream()
+}
+
+func (s *shipper) Write(r log.Record) error {
+	if err := s.opts.Log.Write(r); err != nil {
+		return err
+	}
+
+	rec := &pb.LogRecord{
+		Timestamp: r.Timestamp.UnixNano(),
+		Labels:    make(map[string]string, len(r.Metadata)+len(s.opts.Labels)),
+		Message:   fmt.Sprintf("%v", r.Message),
+	}
+	for k, v := range r.Metadata {
+		rec.Labels[k] = v
+	}
+	for k, v := range s.opts.Labels {
+		rec.Labels[k] = v
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.queue) >= MaxQueueSize {
+		return nil
+	}
+	s.queue = append(s.queue, rec)
+	if len(s.queue) >= DefaultBatchSize {
+		select {
+		case s.flush <- true:
+		default:
+		}
+	}
+	return nil
+}
+
+// run ships the queued records at each interval or once the batch size is reached
+func (s *shipper) run() {
+	ticker := time.NewTicker(DefaultInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.flush:
+		}
+
+		s.Lock()
+		records := s.queue
+		s.queue = nil
+		s.Unlock()
+
+		if len(records) == 0 {
+			continue
+		}
+
+		// errors aren't logged since they'd be shipped too
+		if err := s.ship(records); err != nil {
+			fmt.Fprintf(os.Stderr, "Error shipping %v log records: %v\n", len(records), err)
+		}
+	}
+}
+
+// ship the records with the transport
+func (s *shipper) ship(records []*pb.LogRecord) error {
+	req := &pb.WriteLogsRequest{Records: records}
+
+	if s.opts.Transport == TransportRPC {
+		logs := pb.NewLogsService("debug", client.DefaultClient)
+		_, err := logs.Write(context.Background(), req, client.WithAuthToken())
+		return err
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return broker.Publish(debug.LogsTopic, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body:   b,
+	})
+}
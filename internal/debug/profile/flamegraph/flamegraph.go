@@ -0,0 +1,229 @@
+// Package flamegraph converts profiles to the folded stack format flame graphs are rendered from
+package flamegraph
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Fold converts a profile in pprof format to folded stacks, one line per unique stack with its
+// frames separated by semicolons followed by its value e.g. main.main;main.foo 42. The output can
+// be rendered by flamegraph.pl, speedscope or inferno.
+func Fold(data []byte) ([]byte, error) {
+	// profiles are usually gzipped
+	if len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b {
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = ioutil.ReadAll(gr); err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing profile: %v", err)
+	}
+
+	// the default sample type is the last unless it's set
+	idx := len(p.sampleTypes) - 1
+	for i, t := range p.sampleTypes {
+		if p.defaultType != 0 && t == p.defaultType {
+			idx = i
+		}
+	}
+
+	values := make(map[string]int64)
+	for _, s := range p.samples {
+		if idx < 0 || idx >= len(s.values) || s.values[idx] == 0 {
+			continue
+		}
+
+		// the locations are ordered from the leaf and the functions inlined at a location are
+		// ordered from the callee, stacks are folded from the root
+		var frames []string
+		for i := len(s.locations) - 1; i >= 0; i-- {
+			fns := p.locations[s.locations[i]]
+			if len(fns) == 0 {
+				frames = append(frames, "unknown")
+			}
+			for j := len(fns) - 1; j >= 0; j-- {
+				frames = append(frames, p.str(p.functions[fns[j]]))
+			}
+		}
+		values[strings.Join(frames, ";")] += s.values[idx]
+	}
+
+	stacks := make([]string, 0, len(values))
+	for stack := range values {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+
+	buf := new(bytes.Buffer)
+	for _, stack := range stacks {
+		fmt.Fprintf(buf, "%s %d\n", stack, values[stack])
+	}
+	return buf.Bytes(), nil
+}
+
+// profile is the subset of a pprof profile needed to fold its stacks, see
+// https://github.com/google/pprof/blob/master/proto/profile.proto
+type profile struct {
+	// sampleTypes are the string indexes of the type of each sample value
+	sampleTypes []int64
+	defaultType int64
+	samples     []sample
+	// locations are the ids of the functions at each location
+	locations map[uint64][]uint64
+	// functions are the string indexes of the name of each function
+	functions map[uint64]int64
+	strings   []string
+}
+
+type sample struct {
+	locations []uint64
+	values    []int64
+}
+
+func (p *profile) str(i int64) string {
+	if i < 0 || int(i) >= len(p.strings) {
+		return ""
+	}
+	return p.strings[i]
+}
+
+func parse(data []byte) (*profile, error) {
+	p := &profile{
+		locations: make(map[uint64][]uint64),
+		functions: make(map[uint64]int64),
+	}
+
+	err := walk(data, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+		switch num {
+		case 1: // sample_type
+			return walk(b, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+				if num == 1 {
+					p.sampleTypes = append(p.sampleTypes, int64(v))
+				}
+				return nil
+			})
+		case 2: // sample
+			var s sample
+			err := walk(b, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+				vals, err := varints(typ, v, b)
+				switch num {
+				case 1:
+					s.locations = append(s.locations, vals...)
+				case 2:
+					for _, val := range vals {
+						s.values = append(s.values, int64(val))
+					}
+				}
+				return err
+			})
+			p.samples = append(p.samples, s)
+			return err
+		case 4: // location
+			var id uint64
+			var fns []uint64
+			err := walk(b, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					id = v
+				case 4: // line
+					return walk(b, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+						if num == 1 {
+							fns = append(fns, v)
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+			p.locations[id] = fns
+			return err
+		case 5: // function
+			var id uint64
+			var name int64
+			err := walk(b, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					id = v
+				case 2:
+					name = int64(v)
+				}
+				return nil
+			})
+			p.functions[id] = name
+			return err
+		case 6: // string_table
+			p.strings = append(p.strings, string(b))
+		case 14: // default_sample_type
+			p.defaultType = int64(v)
+		}
+		return nil
+	})
+
+	return p, err
+}
+
+// walk calls fn with each field of the message, passing the value of varints or the bytes of
+// length delimited fields. Fields of other types are skipped.
+func walk(data []byte, fn func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var v uint64
+		var b []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if typ != protowire.VarintType && typ != protowire.BytesType {
+			continue
+		}
+		if err := fn(num, typ, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// varints returns the values of a repeated varint field which may or may not be packed
+func varints(typ protowire.Type, v uint64, b []byte) ([]uint64, error) {
+	if typ == protowire.VarintType {
+		return []uint64{v}, nil
+	}
+
+	var vals []uint64
+	for len(b) > 0 {
+		val, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		vals = append(vals, val)
+		b = b[n:]
+	}
+	return vals, nil
+}
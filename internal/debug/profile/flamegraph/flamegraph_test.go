@@ -0,0 +1,39 @@
+package flamegraph
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := pprof.Lookup("goroutine").WriteTo(buf, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := Fold(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error folding profile: %v", err)
+	}
+
+	// the goroutine running the test should be in the profile, folded from the root
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		idx := strings.LastIndex(line, " ")
+		if idx < 0 {
+			t.Fatalf("Expected a value at the end of the line %v", line)
+		}
+		if strings.HasPrefix(line, "testing.tRunner;") && strings.Contains(line, "flamegraph.TestFold;") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the stack of the test in the output, got %s", out)
+	}
+
+	if _, err := Fold([]byte("not a profile")); err == nil {
+		t.Error("Expected an error folding an invalid profile")
+	}
+}
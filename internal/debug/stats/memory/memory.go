@@ -16,6 +16,7 @@ package stats
 
 import (
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -26,11 +27,14 @@ import (
 type memoryStats struct {
 	// used to store past stats
 	buffer *ring.Buffer
+	// buckets are the upper bounds of the latency buckets, in ascending order
+	buckets []time.Duration
 
 	sync.RWMutex
-	started  int64
-	requests uint64
-	errors   uint64
+	started   int64
+	requests  uint64
+	errors    uint64
+	endpoints map[string]*stats.EndpointStat
 }
 
 func (s *memoryStats) snapshot() *stats.Stat {
@@ -42,15 +46,27 @@ func (s *memoryStats) snapshot() *stats.Stat {
 
 	now := time.Now().Unix()
 
+	// copy the endpoint stats since they continue to be recorded to
+	endpoints := make(map[string]*stats.EndpointStat, len(s.endpoints))
+	for name, e := range s.endpoints {
+		endpoints[name] = &stats.EndpointStat{
+			Requests: e.Requests,
+			Errors:   e.Errors,
+			Latency:  append([]uint64{}, e.Latency...),
+		}
+	}
+
 	return &stats.Stat{
-		Timestamp: now,
-		Started:   s.started,
-		Uptime:    now - s.started,
-		Memory:    mstat.Alloc,
-		GC:        mstat.PauseTotalNs,
-		Threads:   uint64(runtime.NumGoroutine()),
-		Requests:  s.requests,
-		Errors:    s.errors,
+		Timestamp:      now,
+		Started:        s.started,
+		Uptime:         now - s.started,
+		Memory:         mstat.Alloc,
+		GC:             mstat.PauseTotalNs,
+		Threads:        uint64(runtime.NumGoroutine()),
+		Requests:       s.requests,
+		Errors:         s.errors,
+		Endpoints:      endpoints,
+		LatencyBuckets: s.buckets,
 	}
 }
 
@@ -93,11 +109,46 @@ func (s *memoryStats) Record(err error) error {
 	return nil
 }
 
+func (s *memoryStats) RecordRequest(endpoint string, latency time.Duration, err error) error {
+	s.Record(err)
+
+	s.Lock()
+	defer s.Unlock()
+
+	e, ok := s.endpoints[endpoint]
+	if !ok {
+		e = &stats.EndpointStat{Latency: make([]uint64, len(s.buckets))}
+		s.endpoints[endpoint] = e
+	}
+	e.Requests++
+	if err != nil {
+		e.Errors++
+	}
+	for i, b := range s.buckets {
+		if latency <= b {
+			e.Latency[i]++
+		}
+	}
+
+	return nil
+}
+
 // NewStats returns a new in memory stats buffer
-// TODO add options
-func NewStats() stats.Stats {
+func NewStats(opts ...stats.Option) stats.Stats {
+	options := stats.DefaultOptions()
+	for _, o := range opts {
+		o(&options)
+	}
+
+	buckets := append([]time.Duration{}, options.LatencyBuckets...)
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i] < buckets[j]
+	})
+
 	return &memoryStats{
-		started: time.Now().Unix(),
-		buffer:  ring.New(1),
+		started:   time.Now().Unix(),
+		buffer:    ring.New(1),
+		buckets:   buckets,
+		endpoints: make(map[string]*stats.EndpointStat),
 	}
 }
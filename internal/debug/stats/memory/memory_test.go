@@ -0,0 +1,38 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/internal/debug/stats"
+)
+
+func TestLatencyBuckets(t *testing.T) {
+	s := NewStats(stats.LatencyBuckets(time.Second, time.Millisecond*100))
+	s.RecordRequest("Foo.Call", time.Millisecond*50, nil)
+	s.RecordRequest("Foo.Call", time.Millisecond*500, errors.New("timeout"))
+	s.RecordRequest("Foo.Call", time.Second*2, nil)
+
+	snap, err := s.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat := snap[len(snap)-1]
+
+	// the buckets are sorted
+	if len(stat.LatencyBuckets) != 2 || stat.LatencyBuckets[0] != time.Millisecond*100 {
+		t.Fatalf("Expected the buckets to be sorted, got %v", stat.LatencyBuckets)
+	}
+
+	e := stat.Endpoints["Foo.Call"]
+	if e == nil {
+		t.Fatal("Expected stats of Foo.Call")
+	}
+	if e.Requests != 3 || e.Errors != 1 {
+		t.Fatalf("Expected 3 requests and 1 error, got %v and %v", e.Requests, e.Errors)
+	}
+	if len(e.Latency) != 2 || e.Latency[0] != 1 || e.Latency[1] != 2 {
+		t.Fatalf("Expected latency [1 2], got %v", e.Latency)
+	}
+}
@@ -0,0 +1,26 @@
+package stats
+
+import "time"
+
+// Options are the stats options
+type Options struct {
+	// LatencyBuckets are the upper bounds of the latency of requests which are counted
+	LatencyBuckets []time.Duration
+}
+
+// Option sets an option
+type Option func(o *Options)
+
+// LatencyBuckets sets the upper bounds of the latency of requests which are counted
+func LatencyBuckets(b ...time.Duration) Option {
+	return func(o *Options) {
+		o.LatencyBuckets = b
+	}
+}
+
+// DefaultOptions returns the default options
+func DefaultOptions() Options {
+	return Options{
+		LatencyBuckets: DefaultLatencyBuckets,
+	}
+}
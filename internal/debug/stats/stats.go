@@ -15,6 +15,8 @@
 // Package stats provides runtime stats
 package stats
 
+import "time"
+
 // Stats provides stats interface
 type Stats interface {
 	// Read stat snapshot
@@ -23,6 +25,8 @@ type Stats interface {
 	Write(*Stat) error
 	// Record a request
 	Record(error) error
+	// RecordRequest records a request to an endpoint and how long it took to serve
+	RecordRequest(endpoint string, latency time.Duration, err error) error
 }
 
 // A runtime stat
@@ -43,4 +47,34 @@ type Stat struct {
 	Requests uint64
 	// Total errors
 	Errors uint64
+	// Endpoints are the stats of the requests to each endpoint
+	Endpoints map[string]*EndpointStat
+	// LatencyBuckets are the upper bounds of the latency buckets of the endpoints
+	LatencyBuckets []time.Duration
+}
+
+// EndpointStat are the stats of the requests to an endpoint
+type EndpointStat struct {
+	// Total requests
+	Requests uint64
+	// Total errors
+	Errors uint64
+	// Latency is the total requests served within each of the latency buckets
+	Latency []uint64
+}
+
+// DefaultLatencyBuckets are the upper bounds of the latency of requests which are counted, unless
+// the stats are created with other buckets
+var DefaultLatencyBuckets = []time.Duration{
+	time.Millisecond * 5,
+	time.Millisecond * 10,
+	time.Millisecond * 25,
+	time.Millisecond * 50,
+	time.Millisecond * 100,
+	time.Millisecond * 250,
+	time.Millisecond * 500,
+	time.Second,
+	time.Millisecond * 2500,
+	time.Second * 5,
+	time.Second * 10,
 }
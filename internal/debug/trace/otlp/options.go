@@ -0,0 +1,98 @@
+package otlp
+
+import (
+	"time"
+
+	"github.com/micro/micro/v3/internal/debug/trace"
+)
+
+// Options for the otlp tracer
+type Options struct {
+	// Endpoint of the collector, e.g. http://localhost:4318
+	Endpoint string
+	// Headers sent with each export, e.g. to authenticate with the collector
+	Headers map[string]string
+	// Name of the service the spans are exported as
+	Name string
+	// SampleRate is the ratio of traces exported, between 0 and 1
+	SampleRate float64
+	// SampleErrors exports the traces with errors regardless of the sample rate
+	SampleErrors bool
+	// BatchSize is the number of spans which trigger an export
+	BatchSize int
+	// Interval spans are exported at if the batch size isn't reached
+	Interval time.Duration
+	// Tracer the spans are also recorded by, so they can still be read via the debug handler
+	Tracer trace.Tracer
+}
+
+type Option func(o *Options)
+
+// Endpoint of the collector to export spans to
+func Endpoint(e string) Option {
+	return func(o *Options) {
+		o.Endpoint = e
+	}
+}
+
+// Headers to send with each export
+func Headers(h map[string]string) Option {
+	return func(o *Options) {
+		o.Headers = h
+	}
+}
+
+// Name of the service the spans are exported as
+func Name(n string) Option {
+	return func(o *Options) {
+		o.Name = n
+	}
+}
+
+// SampleRate sets the ratio of traces exported, 1 exports every trace
+func SampleRate(r float64) Option {
+	return func(o *Options) {
+		o.SampleRate = r
+	}
+}
+
+// SampleErrors exports the traces with errors regardless of the sample rate
+func SampleErrors(b bool) Option {
+	return func(o *Options) {
+		o.SampleErrors = b
+	}
+}
+
+// BatchSize sets the number of spans which trigger an export
+func BatchSize(s int) Option {
+	return func(o *Options) {
+		o.BatchSize = s
+	}
+}
+
+// Interval sets how often spans are exported if the batch size isn't reached
+func Interval(i time.Duration) Option {
+	return func(o *Options) {
+		o.Interval = i
+	}
+}
+
+// Tracer the spans are also recorded by
+func Tracer(t trace.Tracer) Option {
+	return func(o *Options) {
+		o.Tracer = t
+	}
+}
+
+const (
+	// DefaultBatchSize is the number of spans which trigger an export
+	DefaultBatchSize = 512
+	// DefaultInterval spans are exported at
+	DefaultInterval = time.Second * 5
+	// MaxQueueSize is the number of spans queued for export after which new spans are dropped,
+	// so an unavailable collector doesn't exhaust memory
+	MaxQueueSize = 4096
+	// MaxErrorTraces is the number of traces with errors remembered so their remaining spans are
+	// exported, after which they're forgotten
+	MaxErrorTraces = 10000
+)
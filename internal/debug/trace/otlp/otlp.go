@@ -0,0 +1,318 @@
+// Package otlp is a tracer which exports spans to an OpenTelemetry collector, or any backend which
+// accepts OTLP over HTTP e.g. Jaeger, Tempo or the Datadog agent
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/debug/trace"
+	"github.com/micro/micro/v3/internal/debug/trace/memory"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+// tracePath is the path spans are exported to if the endpoint doesn't specify one
+const tracePath = "/v1/traces"
+
+// Sampler is implemented by the tracer so the sampling can be changed while the service runs
+type Sampler interface {
+	// SetSampling sets the ratio of traces exported and whether the traces with errors are
+	// always exported
+	SetSampling(rate float64, errors bool)
+}
+
+type otlpTracer struct {
+	opts   Options
+	url    string
+	client *http.Client
+
+	sync.Mutex
+	// spans waiting to be exported
+	queue []*trace.Span
+	// flush triggers an export once the batch size is reached
+	flush chan bool
+	// errored are the traces with errors, so the spans of the trace which finish after the
+	// error e.g. its parents are exported too
+	errored map[string]bool
+}
+
+// NewTracer returns a tracer which exports the sampled spans to the collector in batches. Spans
+// are also recorded by the wrapped tracer, by default in memory, so they can be read as before.
+func NewTracer(opts ...Option) trace.Tracer {
+	options := Options{
+		SampleRate: 1,
+		BatchSize:  DefaultBatchSize,
+		Interval:   DefaultInterval,
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	if options.Tracer == nil {
+		options.Tracer = memory.NewTracer()
+	}
+	if len(options.Name) == 0 {
+		options.Name = "micro"
+	}
+
+	t := &otlpTracer{
+		opts:    options,
+		url:     exportURL(options.Endpoint),
+		client:  &http.Client{Timeout: time.Second * 10},
+		flush:   make(chan bool, 1),
+		errored: make(map[string]bool),
+	}
+	go t.run()
+	return t
+}
+
+// exportURL returns the url spans are exported to from the endpoint. The scheme defaults to http
+// since the collector is usually an agent running alongside the service.
+func exportURL(endpoint string) string {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	parts := strings.SplitN(strings.SplitN(endpoint, "://", 2)[1], "/", 2)
+	if len(parts) == 1 || len(parts[1]) == 0 {
+		return strings.TrimSuffix(endpoint, "/") + tracePath
+	}
+	return endpoint
+}
+
+func (t *otlpTracer) Start(ctx context.Context, name string) (context.Context, *trace.Span) {
+	return t.opts.Tracer.Start(ctx, name)
+}
+
+func (t *otlpTracer) Finish(s *trace.Span) error {
+	if err := t.opts.Tracer.Finish(s); err != nil {
+		return err
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.sample(s) {
+		return nil
+	}
+	if len(t.queue) >= MaxQueueSize {
+		return nil
+	}
+	t.queue = append(t.queue, s)
+	if len(t.queue) >= t.opts.BatchSize {
+		select {
+		case t.flush <- true:
+		default:
+		}
+	}
+	return nil
+}
+
+// sample returns true if the span should be exported, the spans of captured requests always are
+func (t *otlpTracer) sample(s *trace.Span) bool {
+	if s.Metadata["debug"] == "true" {
+		return true
+	}
+	if t.opts.SampleErrors {
+		if t.errored[s.Trace] {
+			return true
+		}
+		if _, ok := s.Metadata["error"]; ok {
+			if len(t.errored) >= MaxErrorTraces {
+				t.errored = make(map[string]bool)
+			}
+			t.errored[s.Trace] = true
+			return true
+		}
+	}
+	return sampled(s.Trace, t.opts.SampleRate)
+}
+
+func (t *otlpTracer) SetSampling(rate float64, errors bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.opts.SampleRate = rate
+	t.opts.SampleErrors = errors
+}
+
+func (t *otlpTracer) Read(opts ...trace.ReadOption) ([]*trace.Span, error) {
+	return t.opts.Tracer.Read(opts...)
+}
+
+// run exports the queued spans at each interval or once the batch size is reached
+func (t *otlpTracer) run() {
+	ticker := time.NewTicker(t.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-t.flush:
+		}
+
+		t.Lock()
+		spans := t.queue
+		t.queue = nil
+		t.Unlock()
+
+		if len(spans) == 0 {
+			continue
+		}
+		if err := t.export(spans); err != nil {
+			logger.Warnf("Error exporting %v spans to %v: %v", len(spans), t.url, err)
+		}
+	}
+}
+
+// export the spans to the collector
+func (t *otlpTracer) export(spans []*trace.Span) error {
+	b, err := json.Marshal(t.request(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	rsp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return fmt.Errorf("%v: %s", rsp.Status, body)
+	}
+	return nil
+}
+
+// the types below are the subset of the OTLP/JSON encoding of an ExportTraceServiceRequest
+// needed to export spans, see https://github.com/open-telemetry/opentelemetry-proto
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []attribute `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type span struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	ParentSpanID      string      `json:"parentSpanId,omitempty"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []attribute `json:"attributes,omitempty"`
+	Status            status      `json:"status"`
+}
+
+type attribute struct {
+	Key   string         `json:"key"`
+	Value attributeValue `json:"value"`
+}
+
+type attributeValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	spanKindServer = 2
+	spanKindClient = 3
+
+	statusCodeOk    = 1
+	statusCodeError = 2
+)
+
+// request converts the spans to an export request
+func (t *otlpTracer) request(spans []*trace.Span) *exportRequest {
+	out := make([]span, 0, len(spans))
+	for _, s := range spans {
+		sp := span{
+			TraceID:           trace.HexID(s.Trace, 16),
+			SpanID:            trace.HexID(s.Id, 8),
+			Name:              s.Name,
+			Kind:              spanKindServer,
+			StartTimeUnixNano: strconv.FormatInt(s.Started.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.Started.Add(s.Duration).UnixNano(), 10),
+			Status:            status{Code: statusCodeOk},
+		}
+		if len(s.Parent) > 0 {
+			sp.ParentSpanID = trace.HexID(s.Parent, 8)
+		}
+		if s.Type == trace.SpanTypeRequestOutbound {
+			sp.Kind = spanKindClient
+		}
+		for k, v := range s.Metadata {
+			if k == "error" {
+				sp.Status = status{Code: statusCodeError, Message: v}
+				continue
+			}
+			sp.Attributes = append(sp.Attributes, attribute{Key: k, Value: attributeValue{v}})
+		}
+		out = append(out, sp)
+	}
+
+	return &exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{Attributes: []attribute{
+				{Key: "service.name", Value: attributeValue{t.opts.Name}},
+			}},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: "micro"},
+				Spans: out,
+			}},
+		}},
+	}
+}
+
+// sampled returns true if the trace should be exported. The decision is made from a hash of the
+// trace id so each service samples the same traces and exported traces are complete.
+func sampled(traceID string, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	h := fnv.New64a()
+	h.Write([]byte(traceID))
+	return float64(h.Sum64()) < rate*math.MaxUint64
+}
@@ -0,0 +1,153 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/internal/debug/trace"
+)
+
+func TestExport(t *testing.T) {
+	reqs := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		reqs <- r
+		bodies <- b
+	}))
+	defer srv.Close()
+
+	tr := NewTracer(
+		Endpoint(srv.URL),
+		Name("helloworld"),
+		Headers(map[string]string{"Api-Key": "secret"}),
+		BatchSize(2),
+	)
+
+	ctx, parent := tr.Start(context.TODO(), "helloworld.Greeter.Call")
+	_, child := tr.Start(ctx, "store.Store.Read")
+	child.Type = trace.SpanTypeRequestOutbound
+	child.Metadata["error"] = "not found"
+	tr.Finish(child)
+	tr.Finish(parent)
+
+	var r *http.Request
+	var body []byte
+	select {
+	case r = <-reqs:
+		body = <-bodies
+	case <-time.After(time.Second * 5):
+		t.Fatal("Expected the spans to be exported")
+	}
+
+	if r.URL.Path != "/v1/traces" {
+		t.Errorf("Expected the spans to be exported to /v1/traces, got %v", r.URL.Path)
+	}
+	if r.Header.Get("Api-Key") != "secret" {
+		t.Errorf("Expected the headers to be sent")
+	}
+
+	var req exportRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("Error decoding export request: %v", err)
+	}
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("Expected one set of spans, got %+v", req)
+	}
+	if v := req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue; v != "helloworld" {
+		t.Errorf("Expected the service name helloworld, got %v", v)
+	}
+
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %v", len(spans))
+	}
+	c, p := spans[0], spans[1]
+	if c.TraceID != p.TraceID || len(c.TraceID) != 32 {
+		t.Errorf("Expected the spans to share a 16 byte trace id, got %v and %v", c.TraceID, p.TraceID)
+	}
+	if c.ParentSpanID != p.SpanID || len(p.SpanID) != 16 {
+		t.Errorf("Expected the child's parent to be %v, got %v", p.SpanID, c.ParentSpanID)
+	}
+	if c.Kind != spanKindClient || p.Kind != spanKindServer {
+		t.Errorf("Expected client and server spans, got %v and %v", c.Kind, p.Kind)
+	}
+	if c.Status.Code != statusCodeError || c.Status.Message != "not found" {
+		t.Errorf("Expected the child to have an error status, got %+v", c.Status)
+	}
+
+	// the spans can still be read
+	read, err := tr.Read()
+	if err != nil || len(read) != 2 {
+		t.Errorf("Expected to read 2 spans, got %v, %v", len(read), err)
+	}
+}
+
+func TestSampled(t *testing.T) {
+	var count int
+	for i := 0; i < 10000; i++ {
+		id := uuid.New().String()
+		if sampled(id, 0.25) {
+			count++
+		}
+		if sampled(id, 0.25) != sampled(id, 0.25) {
+			t.Fatalf("Expected the sampling of %v to be consistent", id)
+		}
+	}
+	if count < 2000 || count > 3000 {
+		t.Errorf("Expected about a quarter of the traces to be sampled, got %v", count)
+	}
+	if !sampled("foo", 1) || sampled("foo", 0) {
+		t.Errorf("Expected a rate of 1 to sample every trace and 0 none")
+	}
+}
+
+func TestExportURL(t *testing.T) {
+	tests := map[string]string{
+		"localhost:4318":                     "http://localhost:4318/v1/traces",
+		"http://collector:4318/":             "http://collector:4318/v1/traces",
+		"https://otlp.example.com/v1/traces": "https://otlp.example.com/v1/traces",
+		"https://otlp.example.com/custom":    "https://otlp.example.com/custom",
+	}
+	for endpoint, url := range tests {
+		if got := exportURL(endpoint); got != url {
+			t.Errorf("Expected %v to export to %v, got %v", endpoint, url, got)
+		}
+	}
+}
+
+func TestSampleErrors(t *testing.T) {
+	tr := NewTracer(Endpoint("localhost:4318"), SampleRate(0), SampleErrors(true)).(*otlpTracer)
+
+	ctx, parent := tr.Start(context.TODO(), "helloworld.Greeter.Call")
+	_, child := tr.Start(ctx, "store.Store.Read")
+	_, other := tr.Start(context.TODO(), "helloworld.Greeter.Call")
+	if tr.sample(child) || tr.sample(other) {
+		t.Errorf("Expected the spans without errors not to be sampled")
+	}
+
+	// once a span of the trace errors, the spans of the trace finished after are sampled too
+	child.Metadata["error"] = "not found"
+	if !tr.sample(child) || !tr.sample(parent) {
+		t.Errorf("Expected the spans of the trace with an error to be sampled")
+	}
+	if tr.sample(other) {
+		t.Errorf("Expected the spans of other traces not to be sampled")
+	}
+
+	// the sampling can be changed while the tracer runs
+	tr.SetSampling(1, false)
+	if !tr.sample(other) {
+		t.Errorf("Expected every trace to be sampled at a rate of 1")
+	}
+	tr.SetSampling(0, false)
+	if tr.sample(child) {
+		t.Errorf("Expected the spans with errors not to be sampled once disabled")
+	}
+}
@@ -17,6 +17,10 @@ package trace
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/micro/micro/v3/service/context/metadata"
@@ -65,11 +69,22 @@ type Span struct {
 const (
 	traceIDKey = "Micro-Trace-Id"
 	spanIDKey  = "Micro-Span-Id"
+	// traceParentKey is the W3C trace context header, propagated so the traces continue through
+	// the services and clients instrumented with OpenTelemetry
+	traceParentKey = "Traceparent"
 )
 
 // FromContext returns a span from context
 func FromContext(ctx context.Context) (traceID string, parentSpanID string, isFound bool) {
 	traceID, traceOk := metadata.Get(ctx, traceIDKey)
+	if !traceOk {
+		// continue the trace of a caller instrumented with OpenTelemetry
+		if tp, ok := metadata.Get(ctx, traceParentKey); ok {
+			if id, parent, ok := parseTraceParent(tp); ok {
+				return id, parent, true
+			}
+		}
+	}
 	microID, microOk := metadata.Get(ctx, "Micro-Id")
 	if !traceOk && !microOk {
 		isFound = false
@@ -85,11 +100,47 @@ func FromContext(ctx context.Context) (traceID string, parentSpanID string, isFo
 // ToContext saves the trace and span ids in the context
 func ToContext(ctx context.Context, traceID, parentSpanID string) context.Context {
 	return metadata.MergeContext(ctx, map[string]string{
-		traceIDKey: traceID,
-		spanIDKey:  parentSpanID,
+		traceIDKey:                      traceID,
+		spanIDKey:                       parentSpanID,
+		strings.ToLower(traceParentKey): "",
+		traceParentKey:                  TraceParent(traceID, parentSpanID),
 	}, true)
 }
 
+// TraceParent returns the W3C trace context header of the span
+func TraceParent(traceID, spanID string) string {
+	return fmt.Sprintf("00-%s-%s-01", HexID(traceID, 16), HexID(spanID, 8))
+}
+
+// parseTraceParent returns the trace and parent span ids of a W3C trace context header
+func parseTraceParent(tp string) (traceID string, parentSpanID string, isFound bool) {
+	parts := strings.Split(strings.TrimSpace(tp), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", "", false
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", "", false
+	}
+	for _, id := range parts[1:3] {
+		if _, err := hex.DecodeString(id); err != nil || strings.Trim(id, "0") == "" {
+			return "", "", false
+		}
+	}
+	return strings.ToLower(parts[1]), strings.ToLower(parts[2]), true
+}
+
+// HexID converts an id to the hex encoded id of n bytes OTLP and the W3C trace context expect.
+// The ids are usually uuids so the hex digits are used as they are, any other id is hashed so
+// it's converted consistently by every service the trace passes through.
+func HexID(id string, n int) string {
+	h := strings.Replace(id, "-", "", -1)
+	if _, err := hex.DecodeString(h); err == nil && len(h) >= n*2 {
+		return strings.ToLower(h[:n*2])
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:n])
+}
+
 var (
 	DefaultTracer Tracer = new(noop)
 )
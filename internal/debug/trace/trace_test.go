@@ -0,0 +1,51 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/service/context/metadata"
+)
+
+func TestTraceParent(t *testing.T) {
+	// a caller instrumented with OpenTelemetry only sends the trace context
+	ctx := metadata.NewContext(context.TODO(), metadata.Metadata{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	traceID, spanID, ok := FromContext(ctx)
+	if !ok {
+		t.Fatalf("Expected the trace to be continued from the trace context")
+	}
+	if traceID != "4bf92f3577b34da6a3ce929d0e0e4736" || spanID != "00f067aa0ba902b7" {
+		t.Errorf("Expected the ids of the trace context, got %v %v", traceID, spanID)
+	}
+
+	// the trace context is sent on with the ids of the span
+	trace, span := uuid.New().String(), uuid.New().String()
+	ctx = ToContext(ctx, trace, span)
+	md, _ := metadata.FromContext(ctx)
+	if tp := md["Traceparent"]; tp != TraceParent(trace, span) {
+		t.Errorf("Expected the trace context %v, got %v", TraceParent(trace, span), tp)
+	}
+	if traceID, spanID, _ := FromContext(ctx); traceID != trace || spanID != span {
+		t.Errorf("Expected the micro ids to take precedence, got %v %v", traceID, spanID)
+	}
+	if got, _, _ := parseTraceParent(TraceParent(trace, span)); got != HexID(trace, 16) {
+		t.Errorf("Expected the trace id %v, got %v", HexID(trace, 16), got)
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-zzf067aa0ba902b7-01",
+	}
+	for _, tp := range invalid {
+		ctx := metadata.NewContext(context.TODO(), metadata.Metadata{"traceparent": tp})
+		if _, _, ok := FromContext(ctx); ok {
+			t.Errorf("Expected the trace context %q to be ignored", tp)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -133,10 +134,12 @@ func (h *rpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	var opts []client.CallOption
 
+	// set the deadline of the request, the services it calls get what's left of it
 	timeout, _ := strconv.Atoi(r.Header.Get("Timeout"))
-	// set timeout
 	if timeout > 0 {
-		opts = append(opts, client.WithRequestTimeout(time.Duration(timeout)*time.Second))
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancel()
 	}
 
 	// remote call
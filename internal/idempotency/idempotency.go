@@ -0,0 +1,130 @@
+// Package idempotency records the responses to the requests with an idempotency key, so the retries
+// of a caller get the response to the first request rather than processing it again. It's used by
+// the IdempotencyHandler of the services and by the api gateway.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// PendingExpiry is how long a request is recorded as in progress, after which it can be retried
+	// in case the instance serving it stopped before it finished
+	PendingExpiry = time.Minute
+
+	// ErrMismatch is returned when the key was used for a different request
+	ErrMismatch = errors.New("idempotency key was used for a different request")
+	// ErrInProgress is returned when a request with the key is still being served
+	ErrInProgress = errors.New("a request with the idempotency key is in progress")
+)
+
+// Response to a request with an idempotency key
+type Response struct {
+	// Hash of the request so a key can't be reused for a different request
+	Hash string `json:"hash"`
+	// Pending is true while the request is being served
+	Pending bool              `json:"pending,omitempty"`
+	Status  int               `json:"status,omitempty"`
+	Header  map[string]string `json:"header,omitempty"`
+	Body    []byte            `json:"body,omitempty"`
+}
+
+// Hash returns the hash of the parts of a request
+func Hash(parts ...[]byte) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+var (
+	mtx sync.Mutex
+	// inflight are the keys of the requests being served
+	inflight = make(map[string]bool)
+)
+
+// Begin starts serving the request with the key and hash. The response to a previous request with
+// the key is returned to be replayed, otherwise nil is returned and the request must be finished
+// by calling Complete or Abort. The request is recorded as pending before it's served, so the
+// retries made meanwhile, to this instance or any other, get ErrInProgress.
+func Begin(key, hash string) (*Response, error) {
+	mtx.Lock()
+	if inflight[key] {
+		mtx.Unlock()
+		return nil, ErrInProgress
+	}
+	inflight[key] = true
+	mtx.Unlock()
+
+	prev, err := read(key)
+	if err != nil || prev != nil {
+		finish(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil && prev.Hash != hash {
+		return nil, ErrMismatch
+	} else if prev != nil && prev.Pending {
+		return nil, ErrInProgress
+	} else if prev != nil {
+		return prev, nil
+	}
+
+	if err := write(key, &Response{Hash: hash, Pending: true}, PendingExpiry); err != nil {
+		finish(key)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// Complete records the response to the request with the key, it's replayed for the expiry
+func Complete(key string, rsp *Response, expiry time.Duration) error {
+	defer finish(key)
+	return write(key, rsp, expiry)
+}
+
+// Abort the request with the key without recording the response, so it can be retried
+func Abort(key string) error {
+	defer finish(key)
+	if err := store.DefaultStore.Delete(key); err != nil && err != store.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+func finish(key string) {
+	mtx.Lock()
+	delete(inflight, key)
+	mtx.Unlock()
+}
+
+func read(key string) (*Response, error) {
+	recs, err := store.DefaultStore.Read(key)
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var rsp *Response
+	if err := json.Unmarshal(recs[0].Value, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func write(key string, rsp *Response, expiry time.Duration) error {
+	b, err := json.Marshal(rsp)
+	if err != nil {
+		return err
+	}
+	return store.DefaultStore.Write(&store.Record{Key: key, Value: b, Expiry: expiry})
+}
@@ -0,0 +1,49 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+func TestIdempotency(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+
+	hash := Hash([]byte("POST /foo\n"), []byte(`{"name":"bar"}`))
+	if prev, err := Begin("key", hash); err != nil || prev != nil {
+		t.Fatalf("Expected the first request to be served, got %v %v", prev, err)
+	}
+
+	// a retry while the request is served can't be replayed yet
+	if _, err := Begin("key", hash); err != ErrInProgress {
+		t.Fatalf("Expected %v, got %v", ErrInProgress, err)
+	}
+
+	// the request is pending for the other instances too
+	finish("key")
+	if _, err := Begin("key", hash); err != ErrInProgress {
+		t.Fatalf("Expected %v from another instance, got %v", ErrInProgress, err)
+	}
+
+	// an aborted request can be retried
+	if err := Abort("key"); err != nil {
+		t.Fatal(err)
+	}
+	if prev, err := Begin("key", hash); err != nil || prev != nil {
+		t.Fatalf("Expected the aborted request to be served again, got %v %v", prev, err)
+	}
+	if err := Complete("key", &Response{Hash: hash, Status: 201, Body: []byte("created")}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	prev, err := Begin("key", hash)
+	if err != nil || prev == nil || prev.Status != 201 || string(prev.Body) != "created" {
+		t.Fatalf("Expected the response to be replayed, got %+v %v", prev, err)
+	}
+	if _, err := Begin("key", Hash([]byte("POST /foo\n"), []byte(`{"name":"baz"}`))); err != ErrMismatch {
+		t.Fatalf("Expected %v, got %v", ErrMismatch, err)
+	}
+}
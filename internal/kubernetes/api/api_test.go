@@ -70,6 +70,13 @@ var tests = []testcase{
 		Method: "GET",
 		URI:    "/apis/apps/v1/namespaces/test/deployments/foo",
 	},
+	testcase{
+		ReqFn: func(opts *Options) *Request {
+			return NewRequest(opts).Get().Resource("microservice").Namespace("test").Name("foo")
+		},
+		Method: "GET",
+		URI:    "/apis/micro.mu/v1alpha1/namespaces/test/microservices/foo",
+	},
 	testcase{
 		ReqFn: func(opts *Options) *Request {
 			return NewRequest(opts).Get().Resource("pod").Params(&Params{LabelSelector: map[string]string{"foo": "bar"}})
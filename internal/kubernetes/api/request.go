@@ -185,6 +185,9 @@ func (r *Request) request() (*http.Request, error) {
 	case "networkpolicy", "networkpolicies":
 		// /apis/networking.k8s.io/v1/namespaces/{namespace}/networkpolicies
 		url = fmt.Sprintf("%s/apis/networking.k8s.io/v1/namespaces/%s/networkpolicies/", r.host, r.namespace)
+	case "microservice":
+		// /apis/micro.mu/v1alpha1/namespaces/{namespace}/microservices
+		url = fmt.Sprintf("%s/apis/micro.mu/v1alpha1/namespaces/%s/microservices/", r.host, r.namespace)
 	default:
 		// /api/v1/namespaces/{namespace}/{resource}
 		url = fmt.Sprintf("%s/api/v1/namespaces/%s/%ss/", r.host, r.namespace, r.resource)
@@ -26,6 +26,7 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -45,6 +46,8 @@ var (
 	DefaultNamespace = "default"
 	// DefaultPort to expose on a service
 	DefaultPort = 8080
+	// RegionLabel is the label of the nodes the services pinned to a region are placed on
+	RegionLabel = "topology.kubernetes.io/region"
 )
 
 // Client ...
@@ -79,6 +82,18 @@ func (c *client) Create(r *Resource, opts ...CreateOption) error {
 		o(&options)
 	}
 
+	// custom resources are encoded as json rather than rendered from a template
+	if r.Kind == "microservice" {
+		return api.NewRequest(c.opts).
+			Post().
+			SetHeader("Content-Type", "application/json").
+			Namespace(options.Namespace).
+			Resource(r.Kind).
+			Body(r.Value).
+			Do().
+			Error()
+	}
+
 	b := new(bytes.Buffer)
 	if err := renderTemplate(r.Kind, b, r.Value); err != nil {
 		return err
@@ -156,9 +171,15 @@ func (c *client) Update(r *Resource, opts ...UpdateOption) error {
 		o(&options)
 	}
 
+	// custom resources don't support strategic merge patches
+	contentType := "application/strategic-merge-patch+json"
+	if r.Kind == "microservice" {
+		contentType = "application/merge-patch+json"
+	}
+
 	req := api.NewRequest(c.opts).
 		Patch().
-		SetHeader("Content-Type", "application/strategic-merge-patch+json").
+		SetHeader("Content-Type", contentType).
 		Resource(r.Kind).
 		Name(r.Name).
 		Namespace(options.Namespace)
@@ -172,6 +193,8 @@ func (c *client) Update(r *Resource, opts ...UpdateOption) error {
 		req.Body(r.Value.(*Pod))
 	case "networkpolicy", "networkpolicies":
 		req.Body(r.Value.(*NetworkPolicy))
+	case "microservice":
+		req.Body(r.Value.(*MicroService))
 	default:
 		return errors.New("unsupported resource")
 	}
@@ -276,6 +299,68 @@ func NewService(s *runtime.Service, opts *runtime.CreateOptions) *Resource {
 	}
 }
 
+// NewConfigMap returns the config map holding the env vars of the service, the deployment
+// references them when the env vars are moved to it with FromConfigMap
+func NewConfigMap(s *runtime.Service, opts *runtime.CreateOptions) *Resource {
+	metadata := &Metadata{
+		Name:      fmt.Sprintf("%v-%v", Format(s.Name), Format(s.Version)),
+		Namespace: Format(opts.Namespace),
+		Version:   Format(s.Version),
+		Labels: map[string]string{
+			"name":    Format(s.Name),
+			"version": Format(s.Version),
+			"micro":   Format(opts.Type),
+		},
+	}
+
+	data := make(map[string]string, len(opts.Env))
+	for _, evar := range opts.Env {
+		if comps := strings.SplitN(evar, "=", 2); len(comps) == 2 {
+			data[comps[0]] = comps[1]
+		}
+	}
+
+	return &Resource{
+		Kind: "configmap",
+		Name: metadata.Name,
+		Value: &ConfigMap{
+			Metadata: metadata,
+			Data:     data,
+		},
+	}
+}
+
+// FromConfigMap sets the env vars of the deployment's containers from the config map rather
+// than their values
+func FromConfigMap(deployment, configMap *Resource) {
+	d, ok := deployment.Value.(*Deployment)
+	if !ok || d.Spec == nil || d.Spec.Template == nil || d.Spec.Template.PodSpec == nil {
+		return
+	}
+	cm, ok := configMap.Value.(*ConfigMap)
+	if !ok {
+		return
+	}
+
+	containers := d.Spec.Template.PodSpec.Containers
+	for i := range containers {
+		for j, env := range containers[i].Env {
+			if _, ok := cm.Data[env.Name]; !ok || env.ValueFrom != nil {
+				continue
+			}
+			containers[i].Env[j] = EnvVar{
+				Name: env.Name,
+				ValueFrom: &EnvVarSource{
+					ConfigMapKeyRef: &ConfigMapKeySelector{
+						Name: configMap.Name,
+						Key:  env.Name,
+					},
+				},
+			}
+		}
+	}
+}
+
 // NewDeployment returns default micro kubernetes deployment definition
 func NewDeployment(s *runtime.Service, opts *runtime.CreateOptions) *Resource {
 	labels := map[string]string{
@@ -312,7 +397,7 @@ func NewDeployment(s *runtime.Service, opts *runtime.CreateOptions) *Resource {
 	// pass the env vars
 	env := make([]EnvVar, 0, len(opts.Env))
 	for _, evar := range opts.Env {
-		if comps := strings.Split(evar, "="); len(comps) == 2 {
+		if comps := strings.SplitN(evar, "=", 2); len(comps) == 2 {
 			env = append(env, EnvVar{Name: comps[0], Value: comps[1]})
 		}
 	}
@@ -352,13 +437,25 @@ func NewDeployment(s *runtime.Service, opts *runtime.CreateOptions) *Resource {
 		port, _ = strconv.Atoi(opts.Port)
 	}
 
+	// run a single instance unless otherwise specified
+	replicas := 1
+	if opts.Replicas > 0 {
+		replicas = opts.Replicas
+	}
+
+	// place the service on the nodes of its region
+	var nodeSelector map[string]string
+	if len(opts.Region) > 0 {
+		nodeSelector = map[string]string{RegionLabel: opts.Region}
+	}
+
 	return &Resource{
 		Kind: "deployment",
 		Name: metadata.Name,
 		Value: &Deployment{
 			Metadata: metadata,
 			Spec: &DeploymentSpec{
-				Replicas: 1,
+				Replicas: replicas,
 				Selector: &LabelSelector{
 					MatchLabels: labels,
 				},
@@ -366,6 +463,7 @@ func NewDeployment(s *runtime.Service, opts *runtime.CreateOptions) *Resource {
 					Metadata: metadata,
 					PodSpec: &PodSpec{
 						ServiceAccountName: opts.ServiceAccount,
+						NodeSelector:       nodeSelector,
 						Containers: []Container{{
 							Name:    Format(s.Name),
 							Image:   image,
@@ -383,7 +481,8 @@ func NewDeployment(s *runtime.Service, opts *runtime.CreateOptions) *Resource {
 								PeriodSeconds:       10,
 								InitialDelaySeconds: 10,
 							},
-							Resources: resReqs,
+							Resources:                resReqs,
+							TerminationMessagePolicy: "FallbackToLogsOnError",
 						}},
 					},
 				},
@@ -447,3 +546,96 @@ func NewClusterClient() *client {
 		},
 	}
 }
+
+// NewMicroService returns the micro service custom resource declaring the service
+func NewMicroService(s *runtime.Service, opts *runtime.CreateOptions) *Resource {
+	metadata := &Metadata{
+		Name:      fmt.Sprintf("%v-%v", Format(s.Name), Format(s.Version)),
+		Namespace: Format(opts.Namespace),
+		Labels: map[string]string{
+			"name":    Format(s.Name),
+			"version": Format(s.Version),
+			"micro":   Format(opts.Type),
+		},
+	}
+
+	spec := &MicroServiceSpec{
+		Name:     s.Name,
+		Version:  s.Version,
+		Source:   s.Source,
+		Type:     opts.Type,
+		Image:    opts.Image,
+		Command:  opts.Command,
+		Args:     opts.Args,
+		Env:      opts.Env,
+		Replicas: opts.Replicas,
+		Metadata: s.Metadata,
+		Region:   opts.Region,
+	}
+
+	// only the keys of the secrets are declared, the values are stored in a kubernetes secret
+	for key := range opts.Secrets {
+		spec.Secrets = append(spec.Secrets, key)
+	}
+	sort.Strings(spec.Secrets)
+
+	if r := opts.Resources; r != nil {
+		spec.Resources = &MicroServiceResources{CPU: r.CPU, Mem: r.Mem, Disk: r.Disk}
+	}
+
+	return &Resource{
+		Kind: "microservice",
+		Name: metadata.Name,
+		Value: &MicroService{
+			APIVersion: "micro.mu/v1alpha1",
+			Kind:       "MicroService",
+			Metadata:   metadata,
+			Spec:       spec,
+		},
+	}
+}
+
+// Service returns the service and the options to create it with declared by the micro service
+func (m *MicroService) Service() (*runtime.Service, *runtime.CreateOptions) {
+	spec := m.Spec
+	if spec == nil {
+		spec = &MicroServiceSpec{}
+	}
+
+	srv := &runtime.Service{
+		Name:     spec.Name,
+		Version:  spec.Version,
+		Source:   spec.Source,
+		Metadata: make(map[string]string, len(spec.Metadata)),
+	}
+	for k, v := range spec.Metadata {
+		srv.Metadata[k] = v
+	}
+	if len(srv.Version) == 0 {
+		srv.Version = "latest"
+	}
+
+	opts := &runtime.CreateOptions{
+		Type:     spec.Type,
+		Image:    spec.Image,
+		Command:  spec.Command,
+		Args:     spec.Args,
+		Env:      spec.Env,
+		Replicas: spec.Replicas,
+		Region:   spec.Region,
+	}
+	if m.Metadata != nil {
+		opts.Namespace = m.Metadata.Namespace
+	}
+	if len(spec.Secrets) > 0 {
+		opts.Secrets = make(map[string]string, len(spec.Secrets))
+		for _, key := range spec.Secrets {
+			opts.Secrets[key] = ""
+		}
+	}
+	if r := spec.Resources; r != nil {
+		opts.Resources = &runtime.Resources{CPU: r.CPU, Mem: r.Mem, Disk: r.Disk}
+	}
+
+	return srv, opts
+}
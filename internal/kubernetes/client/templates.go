@@ -19,6 +19,7 @@ var templates = map[string]string{
 	"service":         serviceTmpl,
 	"namespace":       namespaceTmpl,
 	"secret":          secretTmpl,
+	"configmap":       configMapTmpl,
 	"serviceaccount":  serviceAccountTmpl,
 	"networkpolicies": networkPolicyTmpl,
 	"networkpolicy":   networkPolicyTmpl,
@@ -68,6 +69,12 @@ spec:
         {{- end }}
     spec: 
       serviceAccountName: {{ .Spec.Template.PodSpec.ServiceAccountName }}
+      {{- with .Spec.Template.PodSpec.NodeSelector }}
+      nodeSelector:
+        {{- range $key, $value := . }}
+        {{ $key }}: "{{ $value }}"
+        {{- end }}
+      {{- end }}
       containers:
       {{- with .Spec.Template.PodSpec.Containers }}
       {{- range . }}
@@ -88,6 +95,13 @@ spec:
                 optional: {{ .Optional }}
               {{- end }}
               {{- end }}
+              {{- if .ConfigMapKeyRef }}
+              {{- with .ConfigMapKeyRef }}
+              configMapKeyRef:
+                key: {{ .Key }}
+                name: {{ .Name }}
+              {{- end }}
+              {{- end }}
           {{- end }}
           {{- end }}
           {{- end }}
@@ -102,6 +116,9 @@ spec:
           {{- end }}
           image: {{ .Image }}
           imagePullPolicy: IfNotPresent
+          {{- if .TerminationMessagePolicy }}
+          terminationMessagePolicy: {{ .TerminationMessagePolicy }}
+          {{- end }}
           ports:
           {{- with .Ports }}
           {{- range . }}
@@ -238,6 +255,26 @@ data:
   {{- end }}
 `
 
+var configMapTmpl = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: "{{ .Metadata.Name }}"
+  namespace: "{{ .Metadata.Namespace }}"
+  labels:
+    {{- with .Metadata.Labels }}
+    {{- range $key, $value := . }}
+    {{ $key }}: "{{ $value }}"
+    {{- end }}
+    {{- end }}
+data:
+  {{- with .Data }}
+  {{- range $key, $value := . }}
+  {{ $key }}: {{ printf "%q" $value }}
+  {{- end }}
+  {{- end }}
+`
+
 var serviceAccountTmpl = `
 apiVersion: v1
 kind: ServiceAccount
@@ -31,7 +31,8 @@ type EnvVar struct {
 
 // EnvVarSource represents a source for the value of an EnvVar.
 type EnvVarSource struct {
-	SecretKeyRef *SecretKeySelector `json:"secretKeyRef,omitempty"`
+	SecretKeyRef    *SecretKeySelector    `json:"secretKeyRef,omitempty"`
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
 }
 
 // SecretKeySelector selects a key of a Secret.
@@ -41,10 +42,18 @@ type SecretKeySelector struct {
 	Optional bool   `json:"optional,omitempty"`
 }
 
+// ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
 type Condition struct {
-	Started string `json:"startedAt,omitempty"`
-	Reason  string `json:"reason,omitempty"`
-	Message string `json:"message,omitempty"`
+	Started  string `json:"startedAt,omitempty"`
+	Finished string `json:"finishedAt,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ExitCode *int   `json:"exitCode,omitempty"`
 }
 
 // Container defined container runtime values
@@ -58,6 +67,9 @@ type Container struct {
 	ReadinessProbe *Probe                `json:"readinessProbe,omitempty"`
 	Resources      *ResourceRequirements `json:"resources,omitempty"`
 	VolumeMounts   []VolumeMount         `json:"volumeMounts,omitempty"`
+	// TerminationMessagePolicy set to FallbackToLogsOnError keeps the end of the logs of a
+	// container which crashed
+	TerminationMessagePolicy string `json:"terminationMessagePolicy,omitempty"`
 }
 
 // DeploymentSpec defines micro deployment spec
@@ -123,9 +135,10 @@ type Metadata struct {
 
 // PodSpec is a pod
 type PodSpec struct {
-	Containers         []Container `json:"containers"`
-	ServiceAccountName string      `json:"serviceAccountName"`
-	Volumes            []Volume    `json:"volumes"`
+	Containers         []Container       `json:"containers"`
+	ServiceAccountName string            `json:"serviceAccountName"`
+	Volumes            []Volume          `json:"volumes"`
+	NodeSelector       map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // PodList
@@ -157,7 +170,9 @@ type PodCondition struct {
 }
 
 type ContainerStatus struct {
-	State ContainerState `json:"state"`
+	State        ContainerState `json:"state"`
+	LastState    ContainerState `json:"lastState"`
+	RestartCount int            `json:"restartCount"`
 }
 
 type ContainerState struct {
@@ -233,6 +248,12 @@ type Secret struct {
 	Metadata *Metadata         `json:"metadata,omitempty"`
 }
 
+// ConfigMap holds configuration data
+type ConfigMap struct {
+	Data     map[string]string `json:"data"`
+	Metadata *Metadata         `json:"metadata,omitempty"`
+}
+
 // ServiceAccount
 type ServiceAccount struct {
 	Metadata         *Metadata         `json:"metadata,omitempty"`
@@ -294,3 +315,41 @@ type ResourceQuota struct {
 	Limits   *ResourceLimits `json:"limits,omitempty"`
 	Metadata *Metadata       `json:"metadata,omitempty"`
 }
+
+// MicroService is the custom resource which declares a micro service, the runtime operator
+// reconciles the deployment and service from it
+type MicroService struct {
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Kind       string            `json:"kind,omitempty"`
+	Metadata   *Metadata         `json:"metadata,omitempty"`
+	Spec       *MicroServiceSpec `json:"spec,omitempty"`
+}
+
+// MicroServiceSpec is the desired state of a micro service
+type MicroServiceSpec struct {
+	Name      string                 `json:"name"`
+	Version   string                 `json:"version,omitempty"`
+	Source    string                 `json:"source,omitempty"`
+	Type      string                 `json:"type,omitempty"`
+	Image     string                 `json:"image,omitempty"`
+	Command   []string               `json:"command,omitempty"`
+	Args      []string               `json:"args,omitempty"`
+	Env       []string               `json:"env,omitempty"`
+	Secrets   []string               `json:"secrets,omitempty"`
+	Replicas  int                    `json:"replicas,omitempty"`
+	Resources *MicroServiceResources `json:"resources,omitempty"`
+	Metadata  map[string]string      `json:"metadata,omitempty"`
+	Region    string                 `json:"region,omitempty"`
+}
+
+// MicroServiceResources are the resource limits of a micro service
+type MicroServiceResources struct {
+	CPU  int `json:"cpu,omitempty"`
+	Mem  int `json:"mem,omitempty"`
+	Disk int `json:"disk,omitempty"`
+}
+
+// MicroServiceList
+type MicroServiceList struct {
+	Items []MicroService `json:"items"`
+}
@@ -36,6 +36,14 @@ func renderTemplate(resource string, w io.Writer, data interface{}) error {
 	return nil
 }
 
+// Render renders the resource as yaml into writer w
+func Render(w io.Writer, r *Resource) error {
+	if _, ok := templates[r.Kind]; !ok {
+		return fmt.Errorf("unsupported resource kind: %s", r.Kind)
+	}
+	return renderTemplate(r.Kind, w, r.Value)
+}
+
 // COPIED FROM
 // https://github.com/kubernetes/kubernetes/blob/7a725418af4661067b56506faabc2d44c6d7703a/pkg/util/crypto/crypto.go
 
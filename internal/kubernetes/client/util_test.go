@@ -16,6 +16,7 @@ package client
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/micro/micro/v3/service/runtime"
@@ -40,6 +41,83 @@ func TestTemplates(t *testing.T) {
 	}
 }
 
+func TestDeploymentRegion(t *testing.T) {
+	srv := &runtime.Service{Name: "foo", Version: "123"}
+	opts := &runtime.CreateOptions{Type: "service", Namespace: "default", Region: "eu-west-1"}
+
+	b := new(bytes.Buffer)
+	if err := Render(b, NewDeployment(srv, opts)); err != nil {
+		t.Fatalf("Failed to render kubernetes deployment: %v", err)
+	}
+	if !strings.Contains(b.String(), "nodeSelector:\n        topology.kubernetes.io/region: \"eu-west-1\"") {
+		t.Fatalf("Expected the deployment to be placed in the region, got %s", b.String())
+	}
+
+	// the region is kept by the micro service declaring the service
+	ms := NewMicroService(srv, opts).Value.(*MicroService)
+	if _, o := ms.Service(); o.Region != "eu-west-1" {
+		t.Fatalf("Expected the micro service to keep the region, got %q", o.Region)
+	}
+}
+
+func TestConfigMap(t *testing.T) {
+	srv := &runtime.Service{Name: "foo", Version: "123"}
+	opts := &runtime.CreateOptions{Type: "service", Namespace: "default", Env: []string{"FOO=bar", "BAZ=a=b"}}
+
+	cm := NewConfigMap(srv, opts)
+	data := cm.Value.(*ConfigMap).Data
+	if data["FOO"] != "bar" || data["BAZ"] != "a=b" {
+		t.Fatalf("Unexpected config map data %v", data)
+	}
+
+	d := NewDeployment(srv, opts)
+	FromConfigMap(d, cm)
+	for _, env := range d.Value.(*Deployment).Spec.Template.PodSpec.Containers[0].Env {
+		if len(env.Value) > 0 || env.ValueFrom == nil || env.ValueFrom.ConfigMapKeyRef == nil {
+			t.Fatalf("Expected %v to be set from the config map", env.Name)
+		}
+	}
+
+	b := new(bytes.Buffer)
+	if err := Render(b, cm); err != nil {
+		t.Fatalf("Failed to render kubernetes config map: %v", err)
+	}
+}
+
+func TestMicroService(t *testing.T) {
+	srv := &runtime.Service{Name: "foo.bar", Version: "v1", Metadata: map[string]string{"owner": "baz"}}
+	opts := &runtime.CreateOptions{
+		Type:      "service",
+		Namespace: "test",
+		Env:       []string{"FOO=bar"},
+		Secrets:   map[string]string{"TOKEN": "secret"},
+		Replicas:  2,
+		Resources: &runtime.Resources{CPU: 200},
+	}
+
+	res := NewMicroService(srv, opts)
+	if res.Name != "foo-bar-v1" {
+		t.Fatalf("Expected name foo-bar-v1, got %v", res.Name)
+	}
+
+	ms := res.Value.(*MicroService)
+	if len(ms.Spec.Secrets) != 1 || ms.Spec.Secrets[0] != "TOKEN" {
+		t.Fatalf("Expected only the secret keys to be declared, got %v", ms.Spec.Secrets)
+	}
+
+	// the service and options should survive the round trip, except for the secret values
+	s, o := ms.Service()
+	if s.Name != srv.Name || s.Version != srv.Version || s.Metadata["owner"] != "baz" {
+		t.Fatalf("Unexpected service %+v", s)
+	}
+	if o.Namespace != "test" || o.Replicas != 2 || o.Resources.CPU != 200 || o.Env[0] != "FOO=bar" {
+		t.Fatalf("Unexpected options %+v", o)
+	}
+	if v, ok := o.Secrets["TOKEN"]; !ok || len(v) > 0 {
+		t.Fatalf("Unexpected secrets %v", o.Secrets)
+	}
+}
+
 func TestFormatName(t *testing.T) {
 	testCases := []struct {
 		name   string
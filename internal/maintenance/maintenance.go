@@ -0,0 +1,161 @@
+// Package maintenance puts services in maintenance. The api gateway and proxy return a 503 with the
+// message of the maintenance for the requests to a service in maintenance, other than the health
+// checks, so the routes of the service don't have to be deleted during a maintenance window.
+package maintenance
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// Database and Table the maintenance is stored in
+	Database = "micro"
+	Table    = "maintenance"
+	// CacheTTL is how long the maintenance of a service is cached before it's read from the store
+	// again, the gateway and proxy take up to this long to enable or disable it
+	CacheTTL = time.Second * 10
+
+	// ErrNotFound is returned when the service isn't in maintenance
+	ErrNotFound = errors.New("service not in maintenance")
+)
+
+// DefaultMessage is returned to the callers if the maintenance has no message
+const DefaultMessage = "The service is down for maintenance"
+
+// Maintenance of a service
+type Maintenance struct {
+	Service   string    `json:"service"`
+	Namespace string    `json:"namespace"`
+	Message   string    `json:"message,omitempty"`
+	Started   time.Time `json:"started"`
+	// Owner is the account which enabled the maintenance
+	Owner string `json:"owner,omitempty"`
+}
+
+func key(ns, service string) string {
+	return ns + "/" + service
+}
+
+// HealthCheck returns true if the endpoint is a health check, they're allowed during maintenance
+func HealthCheck(endpoint string) bool {
+	return endpoint == "Debug.Health" || strings.HasSuffix(strings.ToLower(endpoint), "/debug/health")
+}
+
+// Enable the maintenance of the service
+func Enable(m *Maintenance) error {
+	if len(m.Service) == 0 || len(m.Namespace) == 0 {
+		return errors.New("missing service or namespace")
+	}
+	if m.Started.IsZero() {
+		m.Started = time.Now()
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	rec := &store.Record{Key: key(m.Namespace, m.Service), Value: b}
+	if err := store.DefaultStore.Write(rec, store.WriteTo(Database, Table)); err != nil {
+		return err
+	}
+	cache.set(m.Namespace, m.Service, m)
+	return nil
+}
+
+// Disable the maintenance of the service
+func Disable(ns, service string) error {
+	// not all the stores return an error when deleting a key which doesn't exist
+	if _, err := Get(ns, service); err != nil {
+		return err
+	}
+	if err := store.DefaultStore.Delete(key(ns, service), store.DeleteFrom(Database, Table)); err != nil {
+		return err
+	}
+	cache.set(ns, service, nil)
+	return nil
+}
+
+// Get the maintenance of the service
+func Get(ns, service string) (*Maintenance, error) {
+	recs, err := store.DefaultStore.Read(key(ns, service), store.ReadFrom(Database, Table))
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	var m *Maintenance
+	if err := json.Unmarshal(recs[0].Value, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// List the services in maintenance in the namespace ordered by name
+func List(ns string) ([]*Maintenance, error) {
+	recs, err := store.DefaultStore.Read(key(ns, ""), store.ReadFrom(Database, Table), store.ReadPrefix())
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Maintenance, 0, len(recs))
+	for _, r := range recs {
+		var m *Maintenance
+		if err := json.Unmarshal(r.Value, &m); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Service < result[j].Service })
+	return result, nil
+}
+
+// Check returns the maintenance of the service, or nil if it's not in maintenance. The result is
+// cached for the CacheTTL since it's checked for every request.
+func Check(ns, service string) (*Maintenance, error) {
+	return cache.get(ns, service)
+}
+
+type cached struct {
+	maintenance *Maintenance
+	expiry      time.Time
+}
+
+// maintenanceCache caches the maintenance of the services, including the lack of one
+type maintenanceCache struct {
+	sync.RWMutex
+	services map[string]*cached
+}
+
+var cache = &maintenanceCache{services: make(map[string]*cached)}
+
+func (c *maintenanceCache) get(ns, service string) (*Maintenance, error) {
+	c.RLock()
+	v, ok := c.services[key(ns, service)]
+	c.RUnlock()
+	if ok && time.Now().Before(v.expiry) {
+		return v.maintenance, nil
+	}
+
+	m, err := Get(ns, service)
+	if err == ErrNotFound {
+		m = nil
+	} else if err != nil {
+		return nil, err
+	}
+	c.set(ns, service, m)
+	return m, nil
+}
+
+func (c *maintenanceCache) set(ns, service string, m *Maintenance) {
+	c.Lock()
+	c.services[key(ns, service)] = &cached{maintenance: m, expiry: time.Now().Add(CacheTTL)}
+	c.Unlock()
+}
@@ -0,0 +1,67 @@
+package maintenance
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+func TestMaintenance(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+
+	if m, err := Check("foo", "helloworld"); err != nil || m != nil {
+		t.Fatalf("Expected the service not to be in maintenance, got %v %v", m, err)
+	}
+
+	if err := Enable(&Maintenance{Service: "helloworld", Namespace: "foo", Message: "Upgrading"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Enable(&Maintenance{Service: "greeter", Namespace: "foo"}); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := Check("foo", "helloworld")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil || m.Message != "Upgrading" || m.Started.IsZero() {
+		t.Fatalf("Expected the maintenance of helloworld, got %+v", m)
+	}
+	if m, _ := Check("bar", "helloworld"); m != nil {
+		t.Fatalf("Expected the service in another namespace not to be in maintenance")
+	}
+
+	ms, err := List("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 2 || ms[0].Service != "greeter" || ms[1].Service != "helloworld" {
+		t.Fatalf("Expected greeter and helloworld in maintenance, got %+v", ms)
+	}
+
+	if err := Disable("foo", "helloworld"); err != nil {
+		t.Fatal(err)
+	}
+	if m, err := Check("foo", "helloworld"); err != nil || m != nil {
+		t.Fatalf("Expected the maintenance to be disabled, got %v %v", m, err)
+	}
+	if err := Disable("foo", "helloworld"); err != ErrNotFound {
+		t.Fatalf("Expected %v, got %v", ErrNotFound, err)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	for endpoint, expected := range map[string]bool{
+		"Debug.Health":                true,
+		"/helloworld/debug/health":    true,
+		"/helloworld/Debug/Health":    true,
+		"Helloworld.Call":             false,
+		"/helloworld/Helloworld/Call": false,
+	} {
+		if HealthCheck(endpoint) != expected {
+			t.Errorf("Expected HealthCheck(%v) to be %v", endpoint, expected)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package net
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+)
+
+var (
+	// ReusePort sets SO_REUSEPORT on the listeners so a new process can listen on the same address
+	// while the old one drains its connections, for upgrades without downtime
+	ReusePort = false
+
+	inheritOnce sync.Once
+	inherited   []net.Listener
+	inheritMtx  sync.Mutex
+)
+
+// ListenTCP listens on the tcp address, using the listener of the address inherited from systemd
+// socket activation if there is one so the socket outlives the process
+func ListenTCP(addr string) (net.Listener, error) {
+	if l := inheritedListener(addr); l != nil {
+		return l, nil
+	}
+	var lc net.ListenConfig
+	if ReusePort {
+		lc.Control = reusePort
+	}
+	return lc.Listen(context.Background(), "tcp", addr)
+}
+
+// inheritedListener returns the listener passed to the process for the address, see
+// sd_listen_fds(3). Each listener is only returned once.
+func inheritedListener(addr string) net.Listener {
+	inheritOnce.Do(func() {
+		pid, _ := strconv.Atoi(os.Getenv("LISTEN_PID"))
+		fds, _ := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+		if pid != os.Getpid() || fds <= 0 {
+			return
+		}
+		// the processes started by micro mustn't inherit the listeners
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+
+		// the fds passed start after stdin, stdout and stderr
+		for fd := 3; fd < 3+fds; fd++ {
+			f := os.NewFile(uintptr(fd), "listener")
+			l, err := net.FileListener(f)
+			f.Close()
+			if err != nil {
+				continue
+			}
+			inherited = append(inherited, l)
+		}
+	})
+
+	want, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		return nil
+	}
+
+	inheritMtx.Lock()
+	defer inheritMtx.Unlock()
+	for i, l := range inherited {
+		got, ok := l.Addr().(*net.TCPAddr)
+		if !ok || got.Port != want.Port {
+			continue
+		}
+		if want.IP != nil && !want.IP.IsUnspecified() && !want.IP.Equal(got.IP) {
+			continue
+		}
+		inherited = append(inherited[:i], inherited[i+1:]...)
+		return l
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package net
+
+import (
+	"net"
+	"testing"
+)
+
+func TestListenTCP(t *testing.T) {
+	defer func(r bool) { ReusePort = r }(ReusePort)
+
+	ReusePort = false
+	l, err := ListenTCP("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	if l2, err := ListenTCP(addr); err == nil {
+		l2.Close()
+		t.Fatal("Expected the address to be in use without SO_REUSEPORT")
+	}
+	l.Close()
+
+	ReusePort = true
+	l, err = ListenTCP("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l2, err := ListenTCP(l.Addr().String())
+	if err != nil {
+		t.Fatalf("Expected to listen on the same address with SO_REUSEPORT, got %v", err)
+	}
+	l2.Close()
+}
+
+func TestInheritedListener(t *testing.T) {
+	inheritOnce.Do(func() {})
+	defer func() { inherited = nil }()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	inherited = []net.Listener{l}
+	port := l.Addr().(*net.TCPAddr).Port
+
+	if got := inheritedListener("127.0.0.1:1"); got != nil {
+		t.Fatal("Expected no listener for another port")
+	}
+	if got := inheritedListener(HostPort("", port)); got != l {
+		t.Fatal("Expected the inherited listener for the port on all interfaces")
+	}
+	if got := inheritedListener(HostPort("127.0.0.1", port)); got != nil {
+		t.Fatal("Expected the inherited listener to only be returned once")
+	}
+}
@@ -25,19 +25,27 @@ import (
 // HostPort format addr and port suitable for dial
 func HostPort(addr string, port interface{}) string {
 	host := addr
-	if strings.Count(addr, ":") > 0 {
+	// ipv6 literals are bracketed unless they already are
+	if strings.Count(addr, ":") > 0 && !strings.HasPrefix(addr, "[") {
 		host = fmt.Sprintf("[%s]", addr)
 	}
 	// when port is blank or 0, host is a queue name
 	if v, ok := port.(string); ok && v == "" {
 		return host
-	} else if v, ok := port.(int); ok && v == 0 && net.ParseIP(host) == nil {
+	} else if v, ok := port.(int); ok && v == 0 && net.ParseIP(strings.Trim(host, "[]")) == nil {
 		return host
 	}
 
 	return fmt.Sprintf("%s:%v", host, port)
 }
 
+// IsHostPort returns true if the addr is a host and port e.g. localhost:9090 or [::1]:9090,
+// ipv6 literals must be bracketed
+func IsHostPort(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	return err == nil && len(port) > 0
+}
+
 // Listen takes addr:portmin-portmax and binds to the first available port
 // Example: Listen("localhost:5000-6000", fn)
 func Listen(addr string, fn func(string) (net.Listener, error)) (net.Listener, error) {
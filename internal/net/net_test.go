@@ -19,6 +19,44 @@ import (
 	"testing"
 )
 
+func TestHostPort(t *testing.T) {
+	testData := []struct {
+		addr   string
+		port   interface{}
+		expect string
+	}{
+		{"10.0.0.1", 8080, "10.0.0.1:8080"},
+		{"::1", 8080, "[::1]:8080"},
+		{"[::1]", "8080", "[::1]:8080"},
+		{"::1", 0, "[::1]:0"},
+		{"queue", 0, "queue"},
+	}
+
+	for _, d := range testData {
+		if got := HostPort(d.addr, d.port); got != d.expect {
+			t.Fatalf("expected %s got %s", d.expect, got)
+		}
+	}
+}
+
+func TestIsHostPort(t *testing.T) {
+	testData := []struct {
+		addr   string
+		expect bool
+	}{
+		{"localhost:9090", true},
+		{"[::1]:9090", true},
+		{"go.micro.service.foo", false},
+		{"::1", false},
+	}
+
+	for _, d := range testData {
+		if got := IsHostPort(d.addr); got != d.expect {
+			t.Fatalf("expected %t for %s got %t", d.expect, d.addr, got)
+		}
+	}
+}
+
 func TestListen(t *testing.T) {
 	fn := func(addr string) (net.Listener, error) {
 		return net.Listen("tcp", addr)
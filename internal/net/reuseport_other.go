@@ -0,0 +1,13 @@
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package net
+
+import (
+	"errors"
+	"syscall"
+)
+
+// reusePort returns an error as SO_REUSEPORT isn't supported on the platform
+func reusePort(network, address string, c syscall.RawConn) error {
+	return errors.New("SO_REUSEPORT is not supported on this platform")
+}
@@ -0,0 +1,240 @@
+// Package policy provides the network policies which allow or deny the calls between services. The
+// proxy and the network enforce them as they forward the calls, for a coarse segmentation of the
+// services independent of the rules of auth. The caller is identified by the service account it
+// authenticated with, see Caller, so it can't be spoofed by setting the headers of the call.
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// Database and Table the policies are stored in
+	Database = "micro"
+	Table    = "network_policies"
+	// CacheTTL is how long the policies are cached before they're read from the store again, the
+	// proxy and network take up to this long to enforce a change
+	CacheTTL = time.Second * 10
+
+	// ErrNotFound is returned when the policy doesn't exist
+	ErrNotFound = errors.New("policy not found")
+)
+
+const (
+	// Allow the calls matched by the policy
+	Allow = "allow"
+	// Deny the calls matched by the policy
+	Deny = "deny"
+	// Any matches all the services
+	Any = "*"
+)
+
+// Policy allows or denies the calls from a service to another. The services are matched exactly,
+// by a prefix ending in * e.g. billing.* or by * for any service.
+type Policy struct {
+	ID     string `json:"id"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Action string `json:"action"`
+	// Priority of the policy, the policies with a higher priority are checked first
+	Priority int32     `json:"priority"`
+	Created  time.Time `json:"created"`
+}
+
+// match returns true if the service matches the pattern
+func match(pattern, service string) bool {
+	switch {
+	case pattern == Any:
+		return true
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(service, strings.TrimSuffix(pattern, "*"))
+	default:
+		return pattern == service
+	}
+}
+
+// Matches returns true if the policy applies to the calls from a service to another
+func (p *Policy) Matches(from, to string) bool {
+	return match(p.From, from) && match(p.To, to)
+}
+
+// specificity scores how narrowly the policy matches the services, the exact services score
+// higher than the prefixes which score higher than any service
+func (p *Policy) specificity() int {
+	var n int
+	for _, s := range []string{p.From, p.To} {
+		switch {
+		case s == Any:
+		case strings.HasSuffix(s, "*"):
+			n++
+		default:
+			n += 2
+		}
+	}
+	return n
+}
+
+// sortPolicies orders the policies by priority, then the more specific ones and then the denies
+// first, so the first policy which matches a call applies
+func sortPolicies(policies []*Policy) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		a, b := policies[i], policies[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if a.specificity() != b.specificity() {
+			return a.specificity() > b.specificity()
+		}
+		if a.Action != b.Action {
+			return a.Action == Deny
+		}
+		return a.Created.Before(b.Created)
+	})
+}
+
+// Add a policy
+func Add(p *Policy) error {
+	if p.Action != Allow && p.Action != Deny {
+		return errors.New("action must be allow or deny")
+	}
+	if len(p.From) == 0 {
+		p.From = Any
+	}
+	if len(p.To) == 0 {
+		p.To = Any
+	}
+	if len(p.ID) == 0 {
+		p.ID = uuid.New().String()
+	}
+	if p.Created.IsZero() {
+		p.Created = time.Now()
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if err := store.DefaultStore.Write(&store.Record{Key: p.ID, Value: b}, store.WriteTo(Database, Table)); err != nil {
+		return err
+	}
+	cache.reset()
+	return nil
+}
+
+// Delete a policy
+func Delete(id string) error {
+	// not all the stores return an error when deleting a key which doesn't exist
+	recs, err := store.DefaultStore.Read(id, store.ReadFrom(Database, Table))
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	if err := store.DefaultStore.Delete(id, store.DeleteFrom(Database, Table)); err != nil {
+		return err
+	}
+	cache.reset()
+	return nil
+}
+
+// List the policies in the order they're checked
+func List() ([]*Policy, error) {
+	recs, err := store.DefaultStore.Read("", store.ReadFrom(Database, Table), store.ReadPrefix())
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Policy, 0, len(recs))
+	for _, r := range recs {
+		var p *Policy
+		if err := json.Unmarshal(r.Value, &p); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	sortPolicies(result)
+	return result, nil
+}
+
+// Caller returns the service which made a call with the account, the name of the service accounts
+// the runtime generates for the services or the ID of the other service accounts. The calls of the
+// users, and those made without an account, are from no service so only the policies from any
+// service apply to them.
+func Caller(acc *auth.Account) string {
+	if acc == nil || acc.Type != "service" {
+		return ""
+	}
+	if len(acc.Name) > 0 {
+		return acc.Name
+	}
+	return acc.ID
+}
+
+// Check returns whether the calls from a service to another are allowed, and the policy which
+// applies to them. The calls no policy applies to are allowed. The policies are cached for the
+// CacheTTL since they're checked for every call, when they can't be read from the store the last
+// ones read are checked and the error returned, the calls are denied if none were read.
+func Check(from, to string) (bool, *Policy, error) {
+	policies, err := cache.get()
+	if policies == nil {
+		return false, nil, err
+	}
+	for _, p := range policies {
+		if p.Matches(from, to) {
+			return p.Action == Allow, p, err
+		}
+	}
+	return true, nil, err
+}
+
+// policyCache caches the list of policies
+type policyCache struct {
+	sync.RWMutex
+	policies []*Policy
+	expiry   time.Time
+}
+
+var cache = new(policyCache)
+
+// get returns the policies, or the last ones read and the error when they can't be read. The
+// policies are nil if they've never been read.
+func (c *policyCache) get() ([]*Policy, error) {
+	c.RLock()
+	policies, expiry := c.policies, c.expiry
+	c.RUnlock()
+	if policies != nil && time.Now().Before(expiry) {
+		return policies, nil
+	}
+
+	latest, err := List()
+	if err != nil {
+		return policies, err
+	}
+	if latest == nil {
+		latest = []*Policy{}
+	}
+	policies = latest
+	c.Lock()
+	c.policies, c.expiry = policies, time.Now().Add(CacheTTL)
+	c.Unlock()
+	return policies, nil
+}
+
+// reset expires the policies so they're read again, the last ones read are kept in case the store
+// can't be read
+func (c *policyCache) reset() {
+	c.Lock()
+	c.expiry = time.Time{}
+	c.Unlock()
+}
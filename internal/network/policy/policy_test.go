@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+func TestPolicies(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+	cache.reset()
+
+	if ok, p, err := Check("frontend", "billing"); err != nil || !ok || p != nil {
+		t.Fatalf("Expected the calls to be allowed without policies, got %v %v %v", ok, p, err)
+	}
+
+	if err := Add(&Policy{To: "billing", Action: Deny}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Add(&Policy{From: "checkout", To: "billing", Action: Allow}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Add(&Policy{From: "payments.*", To: "billing", Action: Allow}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Add(&Policy{From: "checkout", To: "billing", Action: "maybe"}); err == nil {
+		t.Fatal("Expected a policy with an invalid action to be rejected")
+	}
+
+	for _, c := range []struct {
+		from, to string
+		allowed  bool
+	}{
+		{"frontend", "billing", false},
+		{"checkout", "billing", true},
+		{"payments.stripe", "billing", true},
+		{"frontend", "checkout", true},
+	} {
+		if ok, _, err := Check(c.from, c.to); err != nil || ok != c.allowed {
+			t.Fatalf("Expected the calls from %v to %v allowed to be %v, got %v %v", c.from, c.to, c.allowed, ok, err)
+		}
+	}
+
+	// a higher priority applies before the more specific policies
+	if err := Add(&Policy{From: "*", To: "*", Action: Deny, Priority: 10}); err != nil {
+		t.Fatal(err)
+	}
+	ok, p, err := Check("checkout", "billing")
+	if err != nil || ok || p.Priority != 10 {
+		t.Fatalf("Expected the higher priority policy to deny the call, got %v %+v %v", ok, p, err)
+	}
+
+	policies, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies) != 4 || policies[0].Priority != 10 || policies[3].From != Any {
+		t.Fatalf("Expected the policies in the order they're checked, got %+v", policies)
+	}
+
+	if err := Delete(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _, _ := Check("checkout", "billing"); !ok {
+		t.Fatal("Expected the call to be allowed once the policy denying it was deleted")
+	}
+	if err := Delete(p.ID); err != ErrNotFound {
+		t.Fatalf("Expected deleting a missing policy to fail, got %v", err)
+	}
+}
+
+// failingStore fails to read the records
+type failingStore struct {
+	store.Store
+}
+
+func (f *failingStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func TestCheckStoreError(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	mem := memory.NewStore()
+	store.DefaultStore = &failingStore{mem}
+	cache.reset()
+	cache.policies = nil
+
+	// the calls are denied until the policies have been read
+	if ok, p, err := Check("frontend", "billing"); err == nil || ok || p != nil {
+		t.Fatalf("Expected the call to be denied when the policies can't be read, got %v %v %v", ok, p, err)
+	}
+
+	store.DefaultStore = mem
+	if err := Add(&Policy{From: "frontend", To: "billing", Action: Deny}); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _, err := Check("checkout", "billing"); err != nil || !ok {
+		t.Fatalf("Expected the call to be allowed, got %v %v", ok, err)
+	}
+
+	// the last policies read are checked while the store fails
+	store.DefaultStore = &failingStore{mem}
+	cache.reset()
+	if ok, p, err := Check("frontend", "billing"); err == nil || ok || p == nil {
+		t.Fatalf("Expected the last policies read to deny the call, got %v %v %v", ok, p, err)
+	}
+	if ok, _, err := Check("checkout", "billing"); err == nil || !ok {
+		t.Fatalf("Expected the last policies read to allow the call, got %v %v", ok, err)
+	}
+}
+
+func TestCaller(t *testing.T) {
+	for _, c := range []struct {
+		acc    *auth.Account
+		caller string
+	}{
+		{nil, ""},
+		{&auth.Account{ID: "alice", Type: "user", Name: "billing"}, ""},
+		{&auth.Account{ID: "billing-latest", Type: "service", Name: "billing"}, "billing"},
+		{&auth.Account{ID: "c0ffee", Type: "service"}, "c0ffee"},
+	} {
+		if caller := Caller(c.acc); caller != c.caller {
+			t.Fatalf("Expected the caller of %+v to be %q, got %q", c.acc, c.caller, caller)
+		}
+	}
+}
@@ -18,7 +18,9 @@ package dns
 import (
 	"context"
 	"net"
+	"strings"
 
+	"github.com/micro/micro/v3/internal/addr"
 	"github.com/micro/micro/v3/internal/network/resolver"
 	"github.com/miekg/dns"
 )
@@ -33,7 +35,7 @@ type Resolver struct {
 func (r *Resolver) Resolve(name string) ([]*resolver.Record, error) {
 	host, port, err := net.SplitHostPort(name)
 	if err != nil {
-		host = name
+		host = strings.Trim(name, "[]")
 		port = "8085"
 	}
 
@@ -41,7 +43,9 @@ func (r *Resolver) Resolve(name string) ([]*resolver.Record, error) {
 		host = "localhost"
 	}
 
-	if len(r.Address) == 0 {
+	if len(r.Address) == 0 && addr.Family() == addr.IPv6 {
+		r.Address = "[2606:4700:4700::1001]:53"
+	} else if len(r.Address) == 0 {
 		r.Address = "1.0.0.1:53"
 	}
 
@@ -56,28 +60,34 @@ func (r *Resolver) Resolve(name string) ([]*resolver.Record, error) {
 		return records, nil
 	}
 
-	m := new(dns.Msg)
-	m.SetQuestion(dns.Fqdn(host), dns.TypeA)
-	rec, err := dns.ExchangeContext(context.Background(), m, r.Address)
-	if err != nil {
-		return nil, err
-	}
+	// lookup both ipv4 and ipv6 addresses so ipv6 only hosts resolve
+	var addrs []string
 
-	for _, answer := range rec.Answer {
-		h := answer.Header()
-		// check record type matches
-		if h.Rrtype != dns.TypeA {
-			continue
+	for _, typ := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		m := new(dns.Msg)
+		m.SetQuestion(dns.Fqdn(host), typ)
+		rec, err := dns.ExchangeContext(context.Background(), m, r.Address)
+		if err != nil {
+			return nil, err
 		}
 
-		arec, _ := answer.(*dns.A)
-		addr := arec.A.String()
+		for _, answer := range rec.Answer {
+			switch v := answer.(type) {
+			case *dns.A:
+				addrs = append(addrs, v.A.String())
+			case *dns.AAAA:
+				addrs = append(addrs, v.AAAA.String())
+			}
+		}
+	}
+
+	// order the addresses by the preferred family
+	addr.Sort(addrs)
 
+	for _, a := range addrs {
 		// join resolved record with port
-		address := net.JoinHostPort(addr, port)
-		// append to record set
 		records = append(records, &resolver.Record{
-			Address: address,
+			Address: net.JoinHostPort(a, port),
 		})
 	}
 
@@ -0,0 +1,114 @@
+// Package fallback provides a transport which falls back to another when dialing fails e.g. when
+// the udp packets of a quic handshake are blocked by a firewall
+package fallback
+
+import (
+	"net"
+	"sync"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+var (
+	// create a logger
+	log = logger.NewHelper(logger.DefaultLogger).WithFields(map[string]interface{}{"package": "transport"})
+)
+
+type fallbackTransport struct {
+	primary  transport.Transport
+	fallback transport.Transport
+}
+
+type fallbackListener struct {
+	primary  transport.Listener
+	fallback transport.Listener
+}
+
+func (f *fallbackListener) Addr() string {
+	return f.primary.Addr()
+}
+
+func (f *fallbackListener) Close() error {
+	err := f.primary.Close()
+	if f.fallback != nil {
+		f.fallback.Close()
+	}
+	return err
+}
+
+// Accept the connections of both listeners, it returns when the primary listener stops
+func (f *fallbackListener) Accept(fn func(transport.Socket)) error {
+	var wg sync.WaitGroup
+	if f.fallback != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f.fallback.Accept(fn); err != nil {
+				log.Debugf("Fallback listener on %s stopped: %v", f.fallback.Addr(), err)
+			}
+		}()
+	}
+
+	err := f.primary.Accept(fn)
+	if f.fallback != nil {
+		f.fallback.Close()
+	}
+	wg.Wait()
+	return err
+}
+
+func (f *fallbackTransport) Dial(addr string, opts ...transport.DialOption) (transport.Client, error) {
+	c, err := f.primary.Dial(addr, opts...)
+	if err == nil {
+		return c, nil
+	}
+	log.Debugf("Dialing %s with %s failed, falling back to %s: %v", addr, f.primary, f.fallback, err)
+	return f.fallback.Dial(addr, opts...)
+}
+
+// Listen on the address with both transports. The fallback listens on the port the primary got so
+// they can only be combined if they don't use the same network protocol e.g. quic over udp and grpc
+// over tcp. If the fallback can't listen the peers can only connect with the primary.
+func (f *fallbackTransport) Listen(addr string, opts ...transport.ListenOption) (transport.Listener, error) {
+	pl, err := f.primary.Listen(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	faddr := addr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		if _, port, err := net.SplitHostPort(pl.Addr()); err == nil {
+			faddr = net.JoinHostPort(host, port)
+		}
+	}
+
+	fl, err := f.fallback.Listen(faddr, opts...)
+	if err != nil {
+		log.Warnf("Failed to listen on %s with the %s fallback transport: %v", faddr, f.fallback, err)
+		return &fallbackListener{primary: pl}, nil
+	}
+	return &fallbackListener{primary: pl, fallback: fl}, nil
+}
+
+func (f *fallbackTransport) Init(opts ...transport.Option) error {
+	if err := f.primary.Init(opts...); err != nil {
+		return err
+	}
+	return f.fallback.Init(opts...)
+}
+
+func (f *fallbackTransport) Options() transport.Options {
+	return f.primary.Options()
+}
+
+func (f *fallbackTransport) String() string {
+	return f.primary.String()
+}
+
+// NewTransport returns a transport which uses the primary transport and falls back to the fallback
+// transport when dialing with the primary fails. Both accept connections so peers which don't have
+// the primary transport can still connect.
+func NewTransport(primary, fallback transport.Transport) transport.Transport {
+	return &fallbackTransport{primary: primary, fallback: fallback}
+}
@@ -0,0 +1,70 @@
+package fallback
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/internal/network/transport/grpc"
+	"github.com/micro/micro/v3/internal/network/transport/quic"
+)
+
+func echo(sock transport.Socket) {
+	defer sock.Close()
+	for {
+		var m transport.Message
+		if err := sock.Recv(&m); err != nil {
+			return
+		}
+		if err := sock.Send(&m); err != nil {
+			return
+		}
+	}
+}
+
+func roundTrip(t *testing.T, tr transport.Transport, addr string) {
+	c, err := tr.Dial(addr, transport.WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("Unexpected dial err: %v", err)
+	}
+	defer c.Close()
+
+	m := transport.Message{Body: []byte("hello")}
+	if err := c.Send(&m); err != nil {
+		t.Fatalf("Unexpected send err: %v", err)
+	}
+	var rm transport.Message
+	if err := c.Recv(&rm); err != nil {
+		t.Fatalf("Unexpected recv err: %v", err)
+	}
+	if string(rm.Body) != "hello" {
+		t.Fatalf("Expected hello, got %s", rm.Body)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	tr := NewTransport(quic.NewTransport(), grpc.NewTransport())
+
+	l, err := tr.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected listen err: %v", err)
+	}
+	defer l.Close()
+	go l.Accept(echo)
+
+	// nodes with both transports connect with quic
+	roundTrip(t, tr, l.Addr())
+
+	// nodes with only grpc connect to the fallback listener on the same port
+	roundTrip(t, grpc.NewTransport(), l.Addr())
+
+	// dialing nodes which only listen with grpc falls back to it
+	gl, err := grpc.NewTransport().Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected listen err: %v", err)
+	}
+	defer gl.Close()
+	go gl.Accept(echo)
+
+	roundTrip(t, tr, gl.Addr())
+}
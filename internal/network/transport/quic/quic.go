@@ -0,0 +1,187 @@
+// Package quic provides a QUIC transport, messages are sent over a single stream of a connection
+// which recovers from packet loss and survives changes of address better than TCP on lossy links
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/gob"
+	"net"
+	"time"
+
+	maddr "github.com/micro/micro/v3/internal/addr"
+	"github.com/micro/micro/v3/internal/network/transport"
+	mls "github.com/micro/micro/v3/internal/tls"
+	"github.com/quic-go/quic-go"
+)
+
+// protocol negotiated with ALPN, QUIC requires one
+const protocol = "micro-quic"
+
+// KeepAlive is how often packets are sent to keep idle connections open through NATs
+var KeepAlive = time.Second * 15
+
+type quicTransport struct {
+	opts transport.Options
+}
+
+type quicListener struct {
+	listener *quic.Listener
+}
+
+type quicSocket struct {
+	conn   quic.Connection
+	stream quic.Stream
+	enc    *gob.Encoder
+	dec    *gob.Decoder
+}
+
+func newSocket(conn quic.Connection, stream quic.Stream) *quicSocket {
+	return &quicSocket{
+		conn:   conn,
+		stream: stream,
+		enc:    gob.NewEncoder(stream),
+		dec:    gob.NewDecoder(stream),
+	}
+}
+
+func (q *quicSocket) Recv(m *transport.Message) error {
+	return q.dec.Decode(m)
+}
+
+func (q *quicSocket) Send(m *transport.Message) error {
+	return q.enc.Encode(m)
+}
+
+func (q *quicSocket) Close() error {
+	return q.conn.CloseWithError(0, "EOF")
+}
+
+func (q *quicSocket) Local() string {
+	return q.conn.LocalAddr().String()
+}
+
+func (q *quicSocket) Remote() string {
+	return q.conn.RemoteAddr().String()
+}
+
+func (q *quicListener) Addr() string {
+	return q.listener.Addr().String()
+}
+
+func (q *quicListener) Close() error {
+	return q.listener.Close()
+}
+
+func (q *quicListener) Accept(fn func(transport.Socket)) error {
+	for {
+		conn, err := q.listener.Accept(context.Background())
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			// the dialer opens a single stream for the messages
+			stream, err := conn.AcceptStream(context.Background())
+			if err != nil {
+				conn.CloseWithError(1, err.Error())
+				return
+			}
+			fn(newSocket(conn, stream))
+		}()
+	}
+}
+
+// tlsConfig returns the configured tls config or one with a generated certificate for the address
+func (q *quicTransport) tlsConfig(addr string) (*tls.Config, error) {
+	if q.opts.TLSConfig != nil {
+		config := q.opts.TLSConfig.Clone()
+		config.NextProtos = []string{protocol}
+		return config, nil
+	}
+
+	hosts := []string{addr}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		if len(host) == 0 {
+			hosts = maddr.IPs()
+		} else {
+			hosts = []string{host}
+		}
+	}
+	cert, err := mls.Certificate(hosts...)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}, NextProtos: []string{protocol}}, nil
+}
+
+func (q *quicTransport) Dial(addr string, opts ...transport.DialOption) (transport.Client, error) {
+	dopts := transport.DialOptions{
+		Timeout: transport.DefaultDialTimeout,
+	}
+	for _, o := range opts {
+		o(&dopts)
+	}
+
+	config := &tls.Config{InsecureSkipVerify: true, NextProtos: []string{protocol}}
+	if q.opts.TLSConfig != nil {
+		config = q.opts.TLSConfig.Clone()
+		config.NextProtos = []string{protocol}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dopts.Timeout)
+	defer cancel()
+
+	conn, err := quic.DialAddr(ctx, addr, config, &quic.Config{
+		HandshakeIdleTimeout: dopts.Timeout,
+		KeepAlivePeriod:      KeepAlive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		conn.CloseWithError(1, err.Error())
+		return nil, err
+	}
+
+	return newSocket(conn, stream), nil
+}
+
+func (q *quicTransport) Listen(addr string, opts ...transport.ListenOption) (transport.Listener, error) {
+	config, err := q.tlsConfig(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := quic.ListenAddr(addr, config, &quic.Config{KeepAlivePeriod: KeepAlive})
+	if err != nil {
+		return nil, err
+	}
+	return &quicListener{listener: l}, nil
+}
+
+func (q *quicTransport) Init(opts ...transport.Option) error {
+	for _, o := range opts {
+		o(&q.opts)
+	}
+	return nil
+}
+
+func (q *quicTransport) Options() transport.Options {
+	return q.opts
+}
+
+func (q *quicTransport) String() string {
+	return "quic"
+}
+
+// NewTransport returns a QUIC transport. TLS is always used, a certificate is generated for the
+// listener if the TLSConfig option isn't set.
+func NewTransport(opts ...transport.Option) transport.Transport {
+	var options transport.Options
+	for _, o := range opts {
+		o(&options)
+	}
+	return &quicTransport{opts: options}
+}
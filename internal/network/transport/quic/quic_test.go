@@ -0,0 +1,55 @@
+package quic
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+)
+
+func TestQUICTransportCommunication(t *testing.T) {
+	tr := NewTransport()
+
+	l, err := tr.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected listen err: %v", err)
+	}
+	defer l.Close()
+
+	go l.Accept(func(sock transport.Socket) {
+		defer sock.Close()
+
+		for {
+			var m transport.Message
+			if err := sock.Recv(&m); err != nil {
+				return
+			}
+			if err := sock.Send(&m); err != nil {
+				return
+			}
+		}
+	})
+
+	c, err := tr.Dial(l.Addr())
+	if err != nil {
+		t.Fatalf("Unexpected dial err: %v", err)
+	}
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		m := transport.Message{
+			Header: map[string]string{"X-Content-Type": "application/json"},
+			Body:   []byte(`{"message": "Hello World"}`),
+		}
+		if err := c.Send(&m); err != nil {
+			t.Fatalf("Unexpected send err: %v", err)
+		}
+
+		var rm transport.Message
+		if err := c.Recv(&rm); err != nil {
+			t.Fatalf("Unexpected recv err: %v", err)
+		}
+		if string(rm.Body) != string(m.Body) || rm.Header["X-Content-Type"] != "application/json" {
+			t.Fatalf("Expected %v, got %v", m, rm)
+		}
+	}
+}
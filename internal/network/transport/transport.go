@@ -21,7 +21,7 @@ import (
 
 // Transport is an interface which is used for communication between
 // services. It uses connection based socket send/recv semantics and
-// has various implementations; http, grpc, quic.
+// has various implementations; http, grpc, quic, wireguard.
 type Transport interface {
 	Init(...Option) error
 	Options() Options
@@ -0,0 +1,402 @@
+// Package wireguard provides a userspace WireGuard transport. Each node runs a wireguard-go device
+// with its own key pair and the public keys of the nodes it peers with, the messages are sent over
+// tcp connections within the tunnel. Each node has the address in the tunnel derived from its
+// public key, so the peers are addressed by their endpoints rather than their tunnel addresses.
+package wireguard
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/service/logger"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/curve25519"
+	"golang.zx2c4.com/wireguard/conn"
+	"golang.zx2c4.com/wireguard/device"
+	"golang.zx2c4.com/wireguard/tun/netstack"
+)
+
+// KeepAlive is how often keepalives are sent to the peers dialed, to keep the connections open
+// through NATs
+var KeepAlive = time.Second * 15
+
+// mtu of the tunnel, the default of WireGuard
+const mtu = 1420
+
+var log = logger.NewHelper(logger.DefaultLogger).WithFields(map[string]interface{}{"package": "transport"})
+
+type privateKeyKey struct{}
+type peersKey struct{}
+type tokenKey struct{}
+
+// peer is a node the device exchanges packets with
+type peer struct {
+	public [32]byte
+	// endpoint is the udp address of the peer, blank for the peers which only connect to us
+	endpoint string
+}
+
+type wireguardTransport struct {
+	opts transport.Options
+
+	sync.Mutex
+	dev   *device.Device
+	tnet  *netstack.Net
+	local netip.Addr
+	peers []peer
+}
+
+type wireguardListener struct {
+	addr      string
+	listener  net.Listener
+	transport *wireguardTransport
+}
+
+type wireguardSocket struct {
+	conn net.Conn
+	enc  *gob.Encoder
+	dec  *gob.Decoder
+}
+
+func newSocket(conn net.Conn) *wireguardSocket {
+	return &wireguardSocket{
+		conn: conn,
+		enc:  gob.NewEncoder(conn),
+		dec:  gob.NewDecoder(conn),
+	}
+}
+
+func (w *wireguardSocket) Recv(m *transport.Message) error {
+	return w.dec.Decode(m)
+}
+
+func (w *wireguardSocket) Send(m *transport.Message) error {
+	return w.enc.Encode(m)
+}
+
+func (w *wireguardSocket) Close() error {
+	return w.conn.Close()
+}
+
+func (w *wireguardSocket) Local() string {
+	return w.conn.LocalAddr().String()
+}
+
+func (w *wireguardSocket) Remote() string {
+	return w.conn.RemoteAddr().String()
+}
+
+func (w *wireguardListener) Addr() string {
+	return w.addr
+}
+
+// Close stops listening and closes the device, the next dial or listen starts it again
+func (w *wireguardListener) Close() error {
+	err := w.listener.Close()
+	w.transport.stop()
+	return err
+}
+
+func (w *wireguardListener) Accept(fn func(transport.Socket)) error {
+	for {
+		conn, err := w.listener.Accept()
+		if err != nil {
+			return err
+		}
+		go fn(newSocket(conn))
+	}
+}
+
+// parseKey decodes a base64 key as generated by wg genkey and wg pubkey
+func parseKey(s string) ([32]byte, error) {
+	var key [32]byte
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(b) != len(key) {
+		return key, fmt.Errorf("invalid wireguard key %q", s)
+	}
+	copy(key[:], b)
+	return key, nil
+}
+
+// publicKey returns the public key of the private key
+func publicKey(private [32]byte) ([32]byte, error) {
+	var public [32]byte
+	b, err := curve25519.X25519(private[:], curve25519.Basepoint)
+	if err != nil {
+		return public, err
+	}
+	copy(public[:], b)
+	return public, nil
+}
+
+// tunnelAddr returns the address in the tunnel of the node with the public key, a unique local
+// ipv6 address derived from the key
+func tunnelAddr(public [32]byte) netip.Addr {
+	sum := blake2s.Sum256(public[:])
+	var a [16]byte
+	a[0] = 0xfd
+	copy(a[1:], sum[:15])
+	return netip.AddrFrom16(a)
+}
+
+// start creates the device the first time it's used, listening for the packets of the peers on
+// the port. The lock must be held.
+func (w *wireguardTransport) start(port int) error {
+	if w.dev != nil {
+		return nil
+	}
+
+	var ctx context.Context = context.Background()
+	if w.opts.Context != nil {
+		ctx = w.opts.Context
+	}
+	key, _ := ctx.Value(privateKeyKey{}).(string)
+	if len(key) == 0 {
+		return errors.New("wireguard transport requires a private key")
+	}
+	private, err := parseKey(key)
+	if err != nil {
+		return err
+	}
+	public, err := publicKey(private)
+	if err != nil {
+		return err
+	}
+
+	// the preshared key derived from the token adds a layer of symmetric encryption
+	var psk [32]byte
+	if token, _ := ctx.Value(tokenKey{}).(string); len(token) > 0 {
+		mac := hmac.New(sha256.New, []byte(token))
+		mac.Write([]byte("micro wireguard psk"))
+		copy(psk[:], mac.Sum(nil))
+	}
+
+	config := fmt.Sprintf("private_key=%s\nlisten_port=%d\n", hex.EncodeToString(private[:]), port)
+	var peers []peer
+	list, _ := ctx.Value(peersKey{}).([]string)
+	for _, p := range list {
+		key, endpoint := p, ""
+		if i := strings.Index(p, "@"); i >= 0 {
+			key, endpoint = p[:i], p[i+1:]
+		}
+		pub, err := parseKey(key)
+		if err != nil {
+			return err
+		}
+		if pub == public {
+			continue
+		}
+		peers = append(peers, peer{public: pub, endpoint: endpoint})
+		config += fmt.Sprintf("public_key=%s\npreshared_key=%s\nallowed_ip=%s/128\n",
+			hex.EncodeToString(pub[:]), hex.EncodeToString(psk[:]), tunnelAddr(pub))
+	}
+
+	local := tunnelAddr(public)
+	tdev, tnet, err := netstack.CreateNetTUN([]netip.Addr{local}, nil, mtu)
+	if err != nil {
+		return err
+	}
+	dev := device.NewDevice(tdev, conn.NewDefaultBind(), &device.Logger{
+		Verbosef: func(format string, args ...interface{}) {
+			if logger.V(logger.TraceLevel, log) {
+				log.Tracef("WireGuard "+format, args...)
+			}
+		},
+		Errorf: func(format string, args ...interface{}) {
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("WireGuard "+format, args...)
+			}
+		},
+	})
+	if err := dev.IpcSet(config); err != nil {
+		dev.Close()
+		return err
+	}
+	if err := dev.Up(); err != nil {
+		dev.Close()
+		return err
+	}
+
+	w.dev, w.tnet, w.local, w.peers = dev, tnet, local, peers
+	return nil
+}
+
+// stop closes the device and the connections through it
+func (w *wireguardTransport) stop() {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.dev != nil {
+		w.dev.Close()
+		w.dev, w.tnet, w.peers = nil, nil, nil
+	}
+}
+
+// port returns the udp port the device listens on. The lock must be held.
+func (w *wireguardTransport) port() (int, error) {
+	config, err := w.dev.IpcGet()
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(config, "\n") {
+		if strings.HasPrefix(line, "listen_port=") {
+			return strconv.Atoi(strings.TrimPrefix(line, "listen_port="))
+		}
+	}
+	return 0, errors.New("wireguard device isn't listening")
+}
+
+// peer returns the peer with the endpoint of the address
+func (w *wireguardTransport) peer(addr *net.UDPAddr) (peer, error) {
+	for _, p := range w.peers {
+		if len(p.endpoint) == 0 {
+			continue
+		}
+		endpoint, err := net.ResolveUDPAddr("udp", p.endpoint)
+		if err != nil {
+			continue
+		}
+		if endpoint.IP.Equal(addr.IP) && endpoint.Port == addr.Port {
+			return p, nil
+		}
+	}
+	return peer{}, fmt.Errorf("no wireguard peer has the endpoint %s", addr)
+}
+
+func (w *wireguardTransport) Dial(addr string, opts ...transport.DialOption) (transport.Client, error) {
+	dopts := transport.DialOptions{
+		Timeout: transport.DefaultDialTimeout,
+	}
+	for _, o := range opts {
+		o(&dopts)
+	}
+
+	raddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Lock()
+	if err := w.start(0); err != nil {
+		w.Unlock()
+		return nil, err
+	}
+	p, err := w.peer(raddr)
+	if err == nil {
+		// the peer may have roamed since, its endpoint is set to the one dialed
+		err = w.dev.IpcSet(fmt.Sprintf("public_key=%s\nupdate_only=true\nendpoint=%s\npersistent_keepalive_interval=%d\n",
+			hex.EncodeToString(p.public[:]), raddr, int(KeepAlive.Seconds())))
+	}
+	tnet := w.tnet
+	w.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// the peers listen within the tunnel on the port of their endpoint
+	ctx, cancel := context.WithTimeout(context.Background(), dopts.Timeout)
+	defer cancel()
+
+	c, err := tnet.DialContextTCPAddrPort(ctx, netip.AddrPortFrom(tunnelAddr(p.public), uint16(raddr.Port)))
+	if err != nil {
+		return nil, err
+	}
+	return newSocket(c), nil
+}
+
+func (w *wireguardTransport) Listen(addr string, opts ...transport.ListenOption) (transport.Listener, error) {
+	host, p, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	if w.dev == nil {
+		err = w.start(port)
+	} else if port > 0 {
+		// the device started by dialing listens on the port from now on
+		err = w.dev.IpcSet(fmt.Sprintf("listen_port=%d\n", port))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if port, err = w.port(); err != nil {
+		return nil, err
+	}
+
+	l, err := w.tnet.ListenTCPAddrPort(netip.AddrPortFrom(w.local, uint16(port)))
+	if err != nil {
+		return nil, err
+	}
+	return &wireguardListener{addr: net.JoinHostPort(host, strconv.Itoa(port)), listener: l, transport: w}, nil
+}
+
+func (w *wireguardTransport) Init(opts ...transport.Option) error {
+	for _, o := range opts {
+		o(&w.opts)
+	}
+	return nil
+}
+
+func (w *wireguardTransport) Options() transport.Options {
+	return w.opts
+}
+
+func (w *wireguardTransport) String() string {
+	return "wireguard"
+}
+
+// PrivateKey sets the private key of the node, as generated by wg genkey
+func PrivateKey(key string) transport.Option {
+	return setOption(privateKeyKey{}, key)
+}
+
+// Peers sets the public keys of the nodes allowed to connect, as generated by wg pubkey. The
+// nodes which are dialed are followed by their endpoint e.g. key@10.0.0.1:8085
+func Peers(peers ...string) transport.Option {
+	return setOption(peersKey{}, peers)
+}
+
+// Token sets the network token the preshared key of the peers is derived from
+func Token(token string) transport.Option {
+	return setOption(tokenKey{}, token)
+}
+
+func setOption(k, v interface{}) transport.Option {
+	return func(o *transport.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, k, v)
+	}
+}
+
+// NewTransport returns a WireGuard transport, the PrivateKey option is required. The device is
+// started by the first dial or listen and closed with the listener, it listens on the port of the
+// address listened on. The packets are encrypted by WireGuard so the TLS options aren't used.
+func NewTransport(opts ...transport.Option) transport.Transport {
+	var options transport.Options
+	for _, o := range opts {
+		o(&options)
+	}
+	return &wireguardTransport{opts: options}
+}
@@ -0,0 +1,118 @@
+package wireguard
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+)
+
+// newKey returns a private key and its public key encoded as by wg genkey and wg pubkey
+func newKey(t *testing.T) (string, string) {
+	var private [32]byte
+	if _, err := rand.Read(private[:]); err != nil {
+		t.Fatal(err)
+	}
+	private[0] &= 248
+	private[31] = (private[31] & 127) | 64
+	public, err := publicKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(private[:]), base64.StdEncoding.EncodeToString(public[:])
+}
+
+func echo(t *testing.T, tr transport.Transport) transport.Listener {
+	l, err := tr.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected listen err: %v", err)
+	}
+
+	go l.Accept(func(sock transport.Socket) {
+		defer sock.Close()
+
+		for {
+			var m transport.Message
+			if err := sock.Recv(&m); err != nil {
+				return
+			}
+			if err := sock.Send(&m); err != nil {
+				return
+			}
+		}
+	})
+	return l
+}
+
+func TestWireGuardTransportCommunication(t *testing.T) {
+	serverKey, serverPub := newKey(t)
+	clientKey, clientPub := newKey(t)
+
+	l := echo(t, NewTransport(PrivateKey(serverKey), Peers(clientPub), Token("secret")))
+	defer l.Close()
+
+	tr := NewTransport(PrivateKey(clientKey), Peers(serverPub+"@"+l.Addr()), Token("secret"))
+	c, err := tr.Dial(l.Addr())
+	if err != nil {
+		t.Fatalf("Unexpected dial err: %v", err)
+	}
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		m := transport.Message{
+			Header: map[string]string{"X-Content-Type": "application/json"},
+			Body:   []byte(`{"message": "Hello World"}`),
+		}
+		if err := c.Send(&m); err != nil {
+			t.Fatalf("Unexpected send err: %v", err)
+		}
+
+		var rm transport.Message
+		if err := c.Recv(&rm); err != nil {
+			t.Fatalf("Unexpected recv err: %v", err)
+		}
+		if string(rm.Body) != string(m.Body) || rm.Header["X-Content-Type"] != "application/json" {
+			t.Fatalf("Expected %v, got %v", m, rm)
+		}
+	}
+
+	// a message larger than the mtu
+	body := make([]byte, 1<<20)
+	rand.Read(body)
+	if err := c.Send(&transport.Message{Body: body}); err != nil {
+		t.Fatalf("Unexpected send err: %v", err)
+	}
+	var rm transport.Message
+	if err := c.Recv(&rm); err != nil {
+		t.Fatalf("Unexpected recv err: %v", err)
+	}
+	if !bytes.Equal(rm.Body, body) {
+		t.Fatalf("Expected the body of %d bytes, got %d bytes", len(body), len(rm.Body))
+	}
+}
+
+func TestWireGuardTransportPeers(t *testing.T) {
+	serverKey, serverPub := newKey(t)
+	clientKey, clientPub := newKey(t)
+	otherKey, _ := newKey(t)
+
+	l := echo(t, NewTransport(PrivateKey(serverKey), Peers(clientPub), Token("secret")))
+	defer l.Close()
+
+	timeout := transport.WithTimeout(time.Second)
+	if _, err := NewTransport(PrivateKey(otherKey), Peers(serverPub+"@"+l.Addr()), Token("secret")).Dial(l.Addr(), timeout); err == nil {
+		t.Fatal("Expected the dial with a key the listener doesn't know to fail")
+	}
+	if _, err := NewTransport(PrivateKey(clientKey), Peers(serverPub+"@"+l.Addr()), Token("wrong")).Dial(l.Addr(), timeout); err == nil {
+		t.Fatal("Expected the dial with the wrong token to fail")
+	}
+	if _, err := NewTransport(PrivateKey(clientKey), Peers(serverPub), Token("secret")).Dial(l.Addr(), timeout); err == nil {
+		t.Fatal("Expected the dial of an address without a peer to fail")
+	}
+	if _, err := NewTransport(Token("secret")).Listen("127.0.0.1:0"); err == nil {
+		t.Fatal("Expected the listen without a private key to fail")
+	}
+}
@@ -0,0 +1,57 @@
+package mucp
+
+import (
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket limiting the bytes sent on a link. It fills at the rate
+// up to a burst of one second of data. Messages larger than the tokens available
+// are sent once the bucket has refilled, so a message is never held back forever.
+type bucket struct {
+	sync.Mutex
+	// rate in bytes per second
+	rate float64
+	// tokens available, negative while paying back a large message
+	tokens float64
+	// last time the bucket was filled
+	last time.Time
+}
+
+// newBucket returns a bucket for the rate in bytes per second or nil if unlimited
+func newBucket(rate int64) *bucket {
+	if rate <= 0 {
+		return nil
+	}
+	return &bucket{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes the tokens to send n bytes and returns how long to wait before sending
+func (b *bucket) reserve(n int, now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	// fill the bucket for the time elapsed
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		b.last = now
+	}
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -0,0 +1,41 @@
+package mucp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucket(t *testing.T) {
+	// no bucket is unlimited
+	if b := newBucket(0); b != nil || b.reserve(1<<30, time.Now()) != 0 {
+		t.Fatal("Expected no limit for zero rate")
+	}
+
+	now := time.Now()
+	b := newBucket(1000)
+	b.last = now
+
+	// the burst is sent straight away
+	if d := b.reserve(1000, now); d != 0 {
+		t.Fatalf("Expected no wait for burst, got %v", d)
+	}
+	// then we wait for the bucket to fill
+	if d := b.reserve(500, now); d != 500*time.Millisecond {
+		t.Fatalf("Expected wait of 500ms, got %v", d)
+	}
+	// which it does at the rate
+	if d := b.reserve(500, now.Add(time.Second)); d != 0 {
+		t.Fatalf("Expected no wait after filling, got %v", d)
+	}
+	// large messages wait for as long as they take to send
+	if d := b.reserve(3000, now.Add(2*time.Second)); d != 2*time.Second {
+		t.Fatalf("Expected wait of 2s, got %v", d)
+	}
+	// and the bucket never holds more than the burst
+	if d := b.reserve(1000, now.Add(time.Hour)); d != 0 {
+		t.Fatalf("Expected no wait for burst, got %v", d)
+	}
+	if d := b.reserve(1000, now.Add(time.Hour)); d != time.Second {
+		t.Fatalf("Expected wait of 1s, got %v", d)
+	}
+}
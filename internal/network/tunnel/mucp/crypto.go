@@ -17,9 +17,14 @@ package mucp
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"sort"
 
+	"github.com/micro/micro/v3/internal/network/transport"
 	"github.com/micro/micro/v3/internal/network/tunnel"
 	"github.com/oxtoacart/bpool"
 )
@@ -38,6 +43,99 @@ func hash(key []byte) []byte {
 	return sum[:]
 }
 
+// linkKey derives the key the link handshake messages are signed with from the key of a generation
+func linkKey(key string) []byte {
+	return hash([]byte("link/" + key))
+}
+
+// sign returns the message authentication code of the headers and body of the message
+func sign(key []byte, m *transport.Message) string {
+	keys := make([]string, 0, len(m.Header))
+	for k := range m.Header {
+		if k != "Micro-Tunnel-Mac" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	mac := hmac.New(sha256.New, key)
+	for _, k := range keys {
+		mac.Write([]byte(k))
+		mac.Write([]byte{0})
+		mac.Write([]byte(m.Header[k]))
+		mac.Write([]byte{0})
+	}
+	mac.Write(m.Body)
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks the message was signed with the key
+func verify(key []byte, m *transport.Message) bool {
+	return hmac.Equal([]byte(m.Header["Micro-Tunnel-Mac"]), []byte(sign(key, m)))
+}
+
+// marshalMessage encodes the headers and body of the message so they can be sealed together
+func marshalMessage(m *transport.Message) []byte {
+	b := make([]byte, 0, size(m)+binary.MaxVarintLen64*(2*len(m.Header)+2))
+	n := make([]byte, binary.MaxVarintLen64)
+
+	// field appends a length prefixed field
+	field := func(v []byte) {
+		b = append(b, n[:binary.PutUvarint(n, uint64(len(v)))]...)
+		b = append(b, v...)
+	}
+
+	b = append(b, n[:binary.PutUvarint(n, uint64(len(m.Header)))]...)
+	for k, v := range m.Header {
+		field([]byte(k))
+		field([]byte(v))
+	}
+	field(m.Body)
+	return b
+}
+
+// unmarshalMessage decodes the headers and body encoded by marshalMessage into the message
+func unmarshalMessage(b []byte, m *transport.Message) error {
+	// next returns the next length prefixed field
+	next := func() ([]byte, error) {
+		n, i := binary.Uvarint(b)
+		if i <= 0 || n > uint64(len(b)-i) {
+			return nil, tunnel.ErrDecryptingData
+		}
+		v := b[i : i+int(n)]
+		b = b[i+int(n):]
+		return v, nil
+	}
+
+	count, i := binary.Uvarint(b)
+	if i <= 0 || count > uint64(len(b)) {
+		return tunnel.ErrDecryptingData
+	}
+	b = b[i:]
+
+	header := make(map[string]string, count)
+	for j := uint64(0); j < count; j++ {
+		k, err := next()
+		if err != nil {
+			return err
+		}
+		v, err := next()
+		if err != nil {
+			return err
+		}
+		header[string(k)] = string(v)
+	}
+	body, err := next()
+	if err != nil {
+		return err
+	}
+
+	m.Header = header
+	m.Body = body
+	return nil
+}
+
 // Encrypt encrypts data and returns the encrypted data
 func Encrypt(gcm cipher.AEAD, data []byte) ([]byte, error) {
 	var err error
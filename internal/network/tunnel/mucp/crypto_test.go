@@ -17,6 +17,8 @@ package mucp
 import (
 	"bytes"
 	"testing"
+
+	"github.com/micro/micro/v3/internal/network/transport"
 )
 
 func TestEncrypt(t *testing.T) {
@@ -63,3 +65,45 @@ func TestDecrypt(t *testing.T) {
 		t.Error("decrypted data not the same as plaintext")
 	}
 }
+
+func TestSign(t *testing.T) {
+	key := linkKey("tokenpassphrase")
+
+	m := &transport.Message{
+		Header: map[string]string{"Micro-Tunnel": "connect"},
+		Body:   []byte("supersecret"),
+	}
+	m.Header["Micro-Tunnel-Mac"] = sign(key, m)
+
+	if !verify(key, m) {
+		t.Fatal("expected the signed message to verify")
+	}
+	if verify(linkKey("another"), m) {
+		t.Fatal("expected the message not to verify with another token")
+	}
+
+	m.Header["Micro-Tunnel"] = "announce"
+	if verify(key, m) {
+		t.Fatal("expected the tampered message not to verify")
+	}
+}
+
+func TestMarshalMessage(t *testing.T) {
+	m := &transport.Message{
+		Header: map[string]string{"Micro-Tunnel": "session", "Micro-Tunnel-Channel": "foo"},
+		Body:   []byte("supersecret"),
+	}
+
+	var got transport.Message
+	if err := unmarshalMessage(marshalMessage(m), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Header) != len(m.Header) || got.Header["Micro-Tunnel-Channel"] != "foo" || !bytes.Equal(got.Body, m.Body) {
+		t.Fatalf("Expected %+v, got %+v", m, got)
+	}
+
+	b := marshalMessage(m)
+	if err := unmarshalMessage(b[:len(b)-1], &got); err == nil {
+		t.Fatal("Expected a truncated message to fail")
+	}
+}
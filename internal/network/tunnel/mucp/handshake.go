@@ -0,0 +1,310 @@
+package mucp
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/internal/network/tunnel"
+	"golang.org/x/crypto/curve25519"
+)
+
+var (
+	// HandshakeTimeout is how long a link waits for the keys to be exchanged before it's closed
+	HandshakeTimeout = time.Second * 30
+
+	errNoKeys = errors.New("link keys not exchanged")
+)
+
+// handshake exchanges the keys a link seals its messages with. For every generation of the
+// keyring both ends of the link send an ephemeral curve25519 public key signed with the key of
+// the generation and derive the keys of the generation from the shared secret, so the messages
+// can't be read or forged by someone who only has the token. The keys of the generations which
+// have been rotated out are accepted for the overlap of the keyring. Every message sealed
+// carries a sequence number, which is the nonce it's sealed with, and the messages which don't
+// follow the last one received are dropped so they can't be replayed.
+type handshake struct {
+	sync.Mutex
+	ring *keyring
+	// private and public are our keys, peer the public keys of the other end, by generation
+	private map[uint64][]byte
+	public  map[uint64][]byte
+	peer    map[uint64][]byte
+	// send and recv are the ciphers of the generations established, by generation
+	send map[uint64]cipher.AEAD
+	recv map[uint64]cipher.AEAD
+	// current is the latest generation established
+	current uint64
+	// retired is the time each generation was rotated out
+	retired map[uint64]time.Time
+	// seq is the sequence number of the last message sealed, last of the last one opened
+	seq  uint64
+	last uint64
+	// ready is closed once the first generation is established
+	ready chan bool
+	// out queues the handshake messages to send ahead of anything else
+	out chan *transport.Message
+}
+
+func newHandshake(ring *keyring) *handshake {
+	return &handshake{
+		ring:    ring,
+		private: make(map[uint64][]byte),
+		public:  make(map[uint64][]byte),
+		peer:    make(map[uint64][]byte),
+		send:    make(map[uint64]cipher.AEAD),
+		recv:    make(map[uint64]cipher.AEAD),
+		retired: make(map[uint64]time.Time),
+		ready:   make(chan bool),
+		out:     make(chan *transport.Message, 16),
+	}
+}
+
+// isHandshake returns true if the message carries the key of the other end of the link
+func isHandshake(m *transport.Message) bool {
+	_, ok := m.Header["Micro-Link-Key"]
+	return ok
+}
+
+// established returns true once the keys of a generation have been exchanged
+func (h *handshake) established() bool {
+	select {
+	case <-h.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// message queues our handshake message of the generation, generating our keys if need be.
+// The other end replies with its key to the messages which aren't themselves replies. The
+// message is dropped if the queue is full, it's sent again until the keys are exchanged.
+// The lock must be held.
+func (h *handshake) message(gen uint64, reply bool) error {
+	if _, ok := h.public[gen]; !ok {
+		priv := make([]byte, curve25519.ScalarSize)
+		if _, err := rand.Read(priv); err != nil {
+			return err
+		}
+		pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+		if err != nil {
+			return err
+		}
+		h.private[gen] = priv
+		h.public[gen] = pub
+	}
+
+	m := &transport.Message{
+		Header: map[string]string{
+			"Micro-Link-Key": strconv.FormatUint(gen, 10),
+		},
+		Body: h.public[gen],
+	}
+	if reply {
+		m.Header["Micro-Link-Reply"] = "true"
+	}
+	m.Header["Micro-Tunnel-Mac"] = sign(h.auth(gen), m)
+
+	select {
+	case h.out <- m:
+	default:
+	}
+	return nil
+}
+
+// due queues the handshake messages to send: those of the generations we've started but which
+// haven't been established yet, e.g. if a message was lost, and the one of the generation of the
+// keyring if the link hasn't moved to it yet
+func (h *handshake) due() error {
+	gen := h.ring.generation()
+
+	h.Lock()
+	defer h.Unlock()
+
+	if _, ok := h.send[gen]; !ok && (gen > h.current || !h.established()) {
+		if _, ok := h.private[gen]; !ok {
+			if err := h.message(gen, false); err != nil {
+				return err
+			}
+		}
+	}
+	for g := range h.private {
+		if err := h.message(g, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// receive processes the handshake message of the other end, queueing our handshake message if
+// the other end needs it. Our message is queued before the keys are used so the other end
+// always has them before the messages sealed with them.
+func (h *handshake) receive(m *transport.Message) error {
+	gen, err := strconv.ParseUint(m.Header["Micro-Link-Key"], 10, 64)
+	if err != nil || len(m.Body) != curve25519.PointSize {
+		return tunnel.ErrUnauthenticated
+	}
+	if !verify(h.auth(gen), m) {
+		return tunnel.ErrUnauthenticated
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	// the other end sends its key again until it has ours
+	if peer, ok := h.peer[gen]; ok {
+		if bytes.Equal(peer, m.Body) && m.Header["Micro-Link-Reply"] != "true" {
+			return h.message(gen, true)
+		}
+		return nil
+	}
+
+	// ignore the generations which have been rotated out
+	if _, ok := h.retired[gen]; ok || (h.established() && gen < h.current) {
+		return nil
+	}
+
+	// send our key if we haven't yet
+	if _, ok := h.private[gen]; !ok {
+		if err := h.message(gen, true); err != nil {
+			return err
+		}
+	}
+
+	shared, err := curve25519.X25519(h.private[gen], m.Body)
+	if err != nil {
+		return tunnel.ErrUnauthenticated
+	}
+	send, err := newCipher(h.derive(gen, shared, h.public[gen], m.Body))
+	if err != nil {
+		return err
+	}
+	recv, err := newCipher(h.derive(gen, shared, m.Body, h.public[gen]))
+	if err != nil {
+		return err
+	}
+
+	h.peer[gen] = m.Body
+	h.send[gen] = send
+	h.recv[gen] = recv
+	delete(h.private, gen)
+
+	// move to the generation, retiring the previous one
+	now := time.Now()
+	if !h.established() {
+		h.current = gen
+		close(h.ready)
+	} else if gen > h.current {
+		h.retired[h.current] = now
+		h.current = gen
+	}
+	h.expire(now)
+
+	return nil
+}
+
+// auth returns the key the handshake messages of the generation are signed with
+func (h *handshake) auth(gen uint64) []byte {
+	return linkKey(string(h.ring.key(gen)))
+}
+
+// derive returns the key of the generation the messages from one end to the other are sealed
+// with, each direction has its own key
+func (h *handshake) derive(gen uint64, shared, from, to []byte) []byte {
+	mac := hmac.New(sha256.New, h.auth(gen))
+	mac.Write([]byte(strconv.FormatUint(gen, 10)))
+	mac.Write(shared)
+	mac.Write(from)
+	mac.Write(to)
+	return mac.Sum(nil)
+}
+
+// expire forgets the keys of the generations retired for longer than the overlap.
+// The lock must be held.
+func (h *handshake) expire(now time.Time) {
+	for gen, t := range h.retired {
+		if now.Sub(t) <= h.ring.overlap {
+			continue
+		}
+		delete(h.retired, gen)
+		delete(h.send, gen)
+		delete(h.recv, gen)
+		delete(h.peer, gen)
+		delete(h.public, gen)
+	}
+	// the keys of generations passed over before being established
+	for gen := range h.private {
+		if gen < h.current {
+			delete(h.private, gen)
+			delete(h.public, gen)
+		}
+	}
+}
+
+// nonce returns the nonce the message with the sequence number is sealed with
+func nonce(gcm cipher.AEAD, seq uint64) []byte {
+	n := make([]byte, gcm.NonceSize())
+	binary.BigEndian.PutUint64(n[len(n)-8:], seq)
+	return n
+}
+
+// seal encrypts the headers and body of the message with the keys of the current generation
+func (h *handshake) seal(m *transport.Message) (*transport.Message, error) {
+	h.Lock()
+	gcm, ok := h.send[h.current]
+	gen := h.current
+	h.seq++
+	seq := h.seq
+	h.Unlock()
+	if !ok {
+		return nil, errNoKeys
+	}
+
+	return &transport.Message{
+		Header: map[string]string{
+			"Micro-Link-Gen": strconv.FormatUint(gen, 10),
+			"Micro-Link-Seq": strconv.FormatUint(seq, 10),
+		},
+		Body: gcm.Seal(nil, nonce(gcm, seq), marshalMessage(m), nil),
+	}, nil
+}
+
+// open decrypts the message sealed by the other end, rejecting it unless it follows the last
+// message opened
+func (h *handshake) open(m *transport.Message) error {
+	gen, err := strconv.ParseUint(m.Header["Micro-Link-Gen"], 10, 64)
+	if err != nil {
+		return tunnel.ErrUnauthenticated
+	}
+	seq, err := strconv.ParseUint(m.Header["Micro-Link-Seq"], 10, 64)
+	if err != nil {
+		return tunnel.ErrUnauthenticated
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	h.expire(time.Now())
+	gcm, ok := h.recv[gen]
+	if !ok || seq <= h.last {
+		return tunnel.ErrUnauthenticated
+	}
+
+	data, err := gcm.Open(nil, nonce(gcm, seq), m.Body, nil)
+	if err != nil {
+		return tunnel.ErrUnauthenticated
+	}
+	if err := unmarshalMessage(data, m); err != nil {
+		return err
+	}
+	h.last = seq
+	return nil
+}
@@ -0,0 +1,194 @@
+package mucp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+)
+
+// deliver passes the handshake messages queued by each end to the other until there are none
+func deliver(t *testing.T, a, b *handshake) {
+	for {
+		select {
+		case m := <-a.out:
+			if err := b.receive(m); err != nil {
+				t.Fatalf("Expected the key to be received: %v", err)
+			}
+		case m := <-b.out:
+			if err := a.receive(m); err != nil {
+				t.Fatalf("Expected the key to be received: %v", err)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	ka := newKeyring("token", 0, time.Minute)
+	kb := newKeyring("token", 0, time.Minute)
+	a, b := newHandshake(ka), newHandshake(kb)
+
+	// nothing is sealed before the keys are exchanged
+	if _, err := a.seal(&transport.Message{}); err != errNoKeys {
+		t.Fatalf("Expected %v, got %v", errNoKeys, err)
+	}
+
+	if err := a.due(); err != nil {
+		t.Fatal(err)
+	}
+	deliver(t, a, b)
+	if !a.established() || !b.established() {
+		t.Fatal("Expected the keys to be exchanged")
+	}
+
+	m := &transport.Message{
+		Header: map[string]string{"Micro-Tunnel": "connect"},
+		Body:   []byte("supersecret"),
+	}
+	sealed, err := a.seal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sealed.Header["Micro-Tunnel"]; ok {
+		t.Fatal("Expected the headers to be sealed")
+	}
+	if err := b.open(sealed); err != nil {
+		t.Fatal(err)
+	}
+	if sealed.Header["Micro-Tunnel"] != "connect" || string(sealed.Body) != "supersecret" {
+		t.Fatalf("Expected the message to be opened, got %+v", sealed)
+	}
+
+	// each direction has its own keys
+	sealed, _ = a.seal(m)
+	if err := a.open(sealed); err == nil {
+		t.Fatal("Expected our own message not to open")
+	}
+
+	// the keys are exchanged again once rotated, the previous ones are accepted for the overlap
+	old, _ := a.seal(m)
+	ka.force()
+	kb.adopt(1)
+	if err := a.due(); err != nil {
+		t.Fatal(err)
+	}
+	deliver(t, a, b)
+	if a.current != 1 || b.current != 1 {
+		t.Fatalf("Expected the keys of generation 1 to be exchanged, got %d and %d", a.current, b.current)
+	}
+	if err := b.open(old); err != nil {
+		t.Fatalf("Expected the previous keys to be accepted for the overlap: %v", err)
+	}
+	b.Lock()
+	b.expire(time.Now().Add(time.Minute * 2))
+	b.Unlock()
+	if err := b.open(old); err == nil {
+		t.Fatal("Expected the previous keys to be forgotten after the overlap")
+	}
+
+	// the keys are ephemeral, a node with the token can't open the messages of another link
+	c := newHandshake(newKeyring("token", 0, time.Minute))
+	d := newHandshake(newKeyring("token", 0, time.Minute))
+	c.due()
+	deliver(t, c, d)
+	sealed, _ = c.seal(m)
+	if err := b.open(sealed); err == nil {
+		t.Fatal("Expected the message of another link not to open")
+	}
+
+	// the keys of a node without the token are rejected
+	e := newHandshake(newKeyring("another", 0, time.Minute))
+	e.due()
+	if err := a.receive(<-e.out); err == nil {
+		t.Fatal("Expected the key of a node without the token to be rejected")
+	}
+}
+
+func TestHandshakeRetry(t *testing.T) {
+	a := newHandshake(newKeyring("token", 0, time.Minute))
+	b := newHandshake(newKeyring("token", 0, time.Minute))
+
+	// the reply of b is lost so a sends its key again
+	a.due()
+	key := <-a.out
+	if err := b.receive(key); err != nil {
+		t.Fatal(err)
+	}
+	<-b.out
+	if a.established() || !b.established() {
+		t.Fatal("Expected only b to have the keys")
+	}
+
+	a.due()
+	deliver(t, a, b)
+	if !a.established() {
+		t.Fatal("Expected b to reply to the key sent again")
+	}
+
+	// the replies aren't replied to
+	b.Lock()
+	b.message(0, true)
+	b.Unlock()
+	if err := a.receive(<-b.out); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case m := <-a.out:
+		t.Fatalf("Expected no reply to a reply, got %+v", m.Header)
+	default:
+	}
+}
+
+func TestHandshakeReplay(t *testing.T) {
+	a := newHandshake(newKeyring("token", 0, time.Minute))
+	b := newHandshake(newKeyring("token", 0, time.Minute))
+	a.due()
+	deliver(t, a, b)
+
+	seal := func(body string) *transport.Message {
+		m, err := a.seal(&transport.Message{Header: map[string]string{}, Body: []byte(body)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	copyOf := func(m *transport.Message) *transport.Message {
+		c := &transport.Message{Header: make(map[string]string), Body: append([]byte(nil), m.Body...)}
+		for k, v := range m.Header {
+			c.Header[k] = v
+		}
+		return c
+	}
+
+	first, second := seal("first"), seal("second")
+	replay := copyOf(second)
+	if err := b.open(second); err != nil {
+		t.Fatal(err)
+	}
+	// the messages received again or out of order are dropped
+	if err := b.open(replay); err == nil {
+		t.Fatal("Expected a replayed message to be rejected")
+	}
+	if err := b.open(first); err == nil {
+		t.Fatal("Expected an older message to be rejected")
+	}
+
+	// the sequence number is sealed with the message
+	third := seal("third")
+	third.Header["Micro-Link-Seq"] = "100"
+	if err := b.open(third); err == nil {
+		t.Fatal("Expected a message with a forged sequence number to be rejected")
+	}
+
+	// the handshake messages are signed with the key of their generation
+	a.Lock()
+	a.message(6, false)
+	a.Unlock()
+	key := <-a.out
+	key.Header["Micro-Tunnel-Mac"] = sign(a.auth(5), key)
+	if err := b.receive(key); err == nil {
+		t.Fatal("Expected a key signed with another generation to be rejected")
+	}
+}
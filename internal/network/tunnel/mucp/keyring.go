@@ -0,0 +1,120 @@
+package mucp
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// keyring holds the keys sessions are encrypted with. Every generation of key is derived from the
+// tunnel token, so the nodes sharing the token agree on the keys without exchanging them. The
+// generation is the number of intervals elapsed plus the number of forced rotations, the latter
+// being shared over the links. Keys which have been rotated out are accepted for the overlap.
+// The links exchange ephemeral keys for every generation, see handshake.
+type keyring struct {
+	sync.Mutex
+	token    string
+	interval time.Duration
+	overlap  time.Duration
+	// forced is the number of forced rotations
+	forced uint64
+	// current is the current generation
+	current uint64
+	// retired is the time each generation was rotated out
+	retired map[uint64]time.Time
+}
+
+func newKeyring(token string, interval, overlap time.Duration) *keyring {
+	k := &keyring{
+		token:    token,
+		interval: interval,
+		overlap:  overlap,
+		retired:  make(map[uint64]time.Time),
+	}
+	k.current = k.epoch(time.Now())
+	return k
+}
+
+// epoch returns the generation at the time
+func (k *keyring) epoch(now time.Time) uint64 {
+	gen := k.forced
+	if k.interval > 0 {
+		gen += uint64(now.UnixNano() / int64(k.interval))
+	}
+	return gen
+}
+
+// rotate moves to the generation at the time, retiring the current one.
+// The lock must be held.
+func (k *keyring) rotate(now time.Time) uint64 {
+	// the generation never goes back e.g. if the clock does
+	if gen := k.epoch(now); gen > k.current {
+		k.retired[k.current] = now
+		k.current = gen
+	}
+	for gen, t := range k.retired {
+		if now.Sub(t) > k.overlap {
+			delete(k.retired, gen)
+		}
+	}
+	return k.current
+}
+
+// generation returns the generation of key to encrypt with
+func (k *keyring) generation() uint64 {
+	k.Lock()
+	defer k.Unlock()
+	return k.rotate(time.Now())
+}
+
+// valid returns true if the generation of key can be decrypted with. The next generation is
+// accepted since the clocks of the nodes may be skewed or a forced rotation may not have reached
+// us yet.
+func (k *keyring) valid(gen uint64) bool {
+	k.Lock()
+	defer k.Unlock()
+
+	current := k.rotate(time.Now())
+	if gen == current || gen == current+1 {
+		return true
+	}
+	_, ok := k.retired[gen]
+	return ok
+}
+
+// key returns the key of the generation. The first generation is the
+// token itself so tunnels which don't rotate keys remain compatible.
+func (k *keyring) key(gen uint64) []byte {
+	if gen == 0 {
+		return []byte(k.token)
+	}
+	return hash([]byte(k.token + "/" + strconv.FormatUint(gen, 10)))
+}
+
+// rotations returns the number of forced rotations
+func (k *keyring) rotations() uint64 {
+	k.Lock()
+	defer k.Unlock()
+	return k.forced
+}
+
+// force rotates the keys, returning the new generation
+func (k *keyring) force() uint64 {
+	k.Lock()
+	defer k.Unlock()
+	k.forced++
+	return k.rotate(time.Now())
+}
+
+// adopt the number of forced rotations of another node if it's rotated
+// further than us, returning true if the keys were rotated
+func (k *keyring) adopt(forced uint64) bool {
+	k.Lock()
+	defer k.Unlock()
+	if forced <= k.forced {
+		return false
+	}
+	k.forced = forced
+	k.rotate(time.Now())
+	return true
+}
@@ -0,0 +1,49 @@
+package mucp
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestKeyring(t *testing.T) {
+	k := newKeyring("token", time.Hour, time.Minute*10)
+
+	// the keys of the generations differ
+	gen := k.generation()
+	if bytes.Equal(k.key(gen), k.key(gen+1)) {
+		t.Fatal("Expected the keys of each generation to differ")
+	}
+	// the first generation is the token to remain compatible
+	if string(k.key(0)) != "token" {
+		t.Fatal("Expected the first generation to be the token")
+	}
+	// the next generation is accepted for skewed clocks
+	if !k.valid(gen) || !k.valid(gen+1) || k.valid(gen+2) || k.valid(gen-1) {
+		t.Fatal("Expected only the current and next generation to be valid")
+	}
+
+	// the keys rotate every interval and the retired keys are accepted for the overlap
+	now := time.Unix(0, int64(gen+1)*int64(time.Hour))
+	k.Lock()
+	if next := k.rotate(now); next != gen+1 {
+		t.Fatalf("Expected generation %d, got %d", gen+1, next)
+	}
+	if _, ok := k.retired[gen]; !ok {
+		t.Fatal("Expected the previous generation to be retired")
+	}
+	k.rotate(now.Add(time.Minute * 11))
+	if _, ok := k.retired[gen]; ok {
+		t.Fatal("Expected the previous generation to be forgotten after the overlap")
+	}
+	k.Unlock()
+
+	// forced rotations are adopted from nodes which are ahead
+	forced := newKeyring("token", 0, time.Minute)
+	if gen := forced.force(); gen != 1 || !forced.valid(0) {
+		t.Fatalf("Expected generation 1 with 0 retired, got %d", gen)
+	}
+	if forced.adopt(1) || !forced.adopt(3) || forced.generation() != 3 {
+		t.Fatal("Expected only rotations ahead of the keyring to be adopted")
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/internal/network/tunnel"
 	"github.com/micro/micro/v3/service/logger"
 )
 
@@ -42,6 +43,14 @@ type link struct {
 	state chan *packet
 	// send queue for sending packets
 	sendQueue chan *packet
+	// control queue for packets sent ahead of the send queue
+	controlQueue chan *packet
+	// bulk queue for packets sent once the others are empty
+	bulkQueue chan *packet
+	// limit is the bandwidth the link sends at, nil if unlimited
+	limit *bucket
+	// keys the messages are sealed with, exchanged with the other end
+	keys *handshake
 	// receive queue for receiving packets
 	recvQueue chan *packet
 	// unique id of this link e.g uuid
@@ -98,9 +107,10 @@ var (
 	linkResponse = []byte{1, 1, 1, 1}
 
 	ErrLinkConnectTimeout = errors.New("link connect timeout")
+	ErrHandshakeTimeout   = errors.New("link handshake timeout")
 )
 
-func newLink(s transport.Socket) *link {
+func newLink(s transport.Socket, bandwidth int64, keys *keyring) *link {
 	l := &link{
 		Socket:        s,
 		id:            uuid.New().String(),
@@ -109,8 +119,12 @@ func newLink(s transport.Socket) *link {
 		channels:      make(map[string]time.Time),
 		state:         make(chan *packet, 64),
 		sendQueue:     make(chan *packet, 128),
+		controlQueue:  make(chan *packet, 128),
+		bulkQueue:     make(chan *packet, 128),
 		recvQueue:     make(chan *packet, 128),
 		metric:        make(chan *metric, 128),
+		limit:         newBucket(bandwidth),
+		keys:          newHandshake(keys),
 	}
 
 	// process inbound/outbound packets
@@ -189,6 +203,22 @@ func (l *link) process() {
 		for {
 			m := new(transport.Message)
 			err := l.recv(m)
+
+			// the keys are exchanged on the link itself
+			if err == nil && isHandshake(m) {
+				if err := l.keys.receive(m); err != nil {
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Link %s key exchange failed: %v", l.Remote(), err)
+					}
+					// the other end doesn't have the token
+					if !l.keys.established() {
+						l.Close()
+						return
+					}
+				}
+				continue
+			}
+
 			if err != nil {
 				// record the metric
 				select {
@@ -223,23 +253,121 @@ func (l *link) process() {
 		}
 	}()
 
+	// exchange the keys before sending anything
+	if err := l.exchange(); err != nil {
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Link %s failed to exchange keys: %v", l.Remote(), err)
+		}
+		l.Close()
+		return
+	}
+
 	// send messages
 
 	for {
-		select {
-		case pk := <-l.sendQueue:
-			// send the message
+		pk := l.next()
+		if pk == nil {
+			return
+		}
+
+		// wait for the bandwidth to send the packet
+		if d := l.limit.reserve(size(pk.message), time.Now()); d > 0 {
 			select {
-			case pk.status <- l.send(pk.message):
+			case <-time.After(d):
 			case <-l.closed:
 				return
 			}
+		}
+
+		// send the message
+		select {
+		case pk.status <- l.send(pk.message):
 		case <-l.closed:
 			return
 		}
 	}
 }
 
+// exchange sends our keys until the keys of a generation are exchanged with the other end
+func (l *link) exchange() error {
+	timeout := time.NewTimer(HandshakeTimeout)
+	defer timeout.Stop()
+
+	// send the keys again in case they were lost
+	retry := time.NewTicker(time.Second)
+	defer retry.Stop()
+
+	if err := l.keys.due(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case m := <-l.keys.out:
+			if err := l.Socket.Send(m); err != nil {
+				return err
+			}
+		case <-l.keys.ready:
+			return nil
+		case <-retry.C:
+			if err := l.keys.due(); err != nil {
+				return err
+			}
+		case <-timeout.C:
+			return ErrHandshakeTimeout
+		case <-l.closed:
+			return io.EOF
+		}
+	}
+}
+
+// size returns the bytes sent for the message
+func size(m *transport.Message) int {
+	n := len(m.Body)
+	for k, v := range m.Header {
+		n += len(k) + len(v)
+	}
+	return n
+}
+
+// next returns the next packet to send, taking the highest priority
+// packet queued. It returns nil once the link is closed.
+func (l *link) next() *packet {
+	select {
+	case m := <-l.keys.out:
+		return &packet{message: m, status: make(chan error, 1)}
+	default:
+	}
+
+	select {
+	case pk := <-l.controlQueue:
+		return pk
+	default:
+	}
+
+	select {
+	case pk := <-l.controlQueue:
+		return pk
+	case pk := <-l.sendQueue:
+		return pk
+	default:
+	}
+
+	// nothing else is queued so block for the next packet
+	select {
+	case m := <-l.keys.out:
+		return &packet{message: m, status: make(chan error, 1)}
+	case pk := <-l.controlQueue:
+		return pk
+	case pk := <-l.sendQueue:
+		return pk
+	case pk := <-l.bulkQueue:
+		return pk
+	case <-l.closed:
+		return nil
+	}
+}
+
 // manage manages the link state including rtt packets and channel mapping expiry
 func (l *link) manage() {
 	// tick over every minute to expire and fire rtt packets
@@ -250,6 +378,10 @@ func (l *link) manage() {
 	t2 := time.NewTicker(time.Second * 5)
 	defer t2.Stop()
 
+	// used to exchange the keys of the next generation once the keys are rotated
+	t3 := time.NewTicker(time.Second)
+	defer t3.Stop()
+
 	// get link id
 	linkId := l.Id()
 
@@ -343,6 +475,12 @@ func (l *link) manage() {
 				l.record(metric)
 			}
 			l.Unlock()
+		case <-t3.C:
+			if err := l.keys.due(); err != nil {
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Link %s failed to exchange keys: %v", linkId, err)
+				}
+			}
 		}
 	}
 }
@@ -383,11 +521,29 @@ func (l *link) record(m *metric) {
 }
 
 func (l *link) send(m *transport.Message) error {
-	if m.Header == nil {
-		m.Header = make(map[string]string)
+	// the handshake messages are signed with the token and sent as is
+	if isHandshake(m) {
+		return l.Socket.Send(m)
+	}
+	// seal the message so only the other end can read it and tell it comes from a peer
+	// with the token. The keys we replied with are sent first so the other end has them.
+	sealed, err := l.keys.seal(m)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case k := <-l.keys.out:
+			if err := l.Socket.Send(k); err != nil {
+				return err
+			}
+			continue
+		default:
+		}
+		break
 	}
 	// send the message
-	return l.Socket.Send(m)
+	return l.Socket.Send(sealed)
 }
 
 // recv a message on the link
@@ -396,12 +552,24 @@ func (l *link) recv(m *transport.Message) error {
 		m.Header = make(map[string]string)
 	}
 	// receive the transport message
-	return l.Socket.Recv(m)
+	if err := l.Socket.Recv(m); err != nil {
+		return err
+	}
+	if isHandshake(m) {
+		return nil
+	}
+	// reject the messages of peers we haven't exchanged keys with, so
+	// they can't connect or inject anything into the network
+	if err := l.keys.open(m); err != nil {
+		*m = transport.Message{Header: make(map[string]string)}
+		return tunnel.ErrUnauthenticated
+	}
+	return nil
 }
 
 // Delay is the current load on the link
 func (l *link) Delay() int64 {
-	return int64(len(l.sendQueue) + len(l.recvQueue))
+	return int64(len(l.controlQueue) + len(l.sendQueue) + len(l.bulkQueue) + len(l.recvQueue))
 }
 
 // Current transfer rate as bits per second (lower is better)
@@ -450,8 +618,13 @@ func (l *link) Close() error {
 	return nil
 }
 
-// Send sencs a message on the link
+// Send sencs a message on the link ahead of any session data
 func (l *link) Send(m *transport.Message) error {
+	return l.queue(m, tunnel.PriorityControl)
+}
+
+// queue sends the message in turn with others of the same priority
+func (l *link) queue(m *transport.Message, pr tunnel.Priority) error {
 	// create a new packet to send over the link
 	p := &packet{
 		message: m,
@@ -459,19 +632,24 @@ func (l *link) Send(m *transport.Message) error {
 	}
 
 	// calculate the data sent
-	dataSent := len(m.Body)
-
-	// set header length
-	for k, v := range m.Header {
-		dataSent += (len(k) + len(v))
-	}
+	dataSent := size(m)
 
 	// get time now
 	now := time.Now()
 
+	// the queue for the priority
+	queue := l.sendQueue
+
+	switch pr {
+	case tunnel.PriorityControl:
+		queue = l.controlQueue
+	case tunnel.PriorityBulk:
+		queue = l.bulkQueue
+	}
+
 	// queue the message
 	select {
-	case l.sendQueue <- p:
+	case queue <- p:
 		// in the send queue
 	case <-l.closed:
 		return io.EOF
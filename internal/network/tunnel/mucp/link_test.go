@@ -0,0 +1,71 @@
+package mucp
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/internal/network/tunnel"
+)
+
+func TestLinkPriority(t *testing.T) {
+	l := &link{
+		closed:       make(chan bool),
+		sendQueue:    make(chan *packet, 128),
+		controlQueue: make(chan *packet, 128),
+		bulkQueue:    make(chan *packet, 128),
+		keys:         newHandshake(newKeyring("token", 0, 0)),
+	}
+
+	// queue the packets lowest priority first
+	queued := []struct {
+		name     string
+		priority tunnel.Priority
+	}{
+		{"bulk", tunnel.PriorityBulk},
+		{"normal", tunnel.PriorityNormal},
+		{"control", tunnel.PriorityControl},
+		{"bulk", tunnel.PriorityBulk},
+		{"control", tunnel.PriorityControl},
+	}
+
+	for _, q := range queued {
+		pk := &packet{
+			message: &transport.Message{
+				Header: map[string]string{"Name": q.name},
+			},
+		}
+
+		switch q.priority {
+		case tunnel.PriorityControl:
+			l.controlQueue <- pk
+		case tunnel.PriorityBulk:
+			l.bulkQueue <- pk
+		default:
+			l.sendQueue <- pk
+		}
+	}
+
+	if d := l.Delay(); d != int64(len(queued)) {
+		t.Fatalf("Expected delay of %d, got %d", len(queued), d)
+	}
+
+	// the packets are sent highest priority first
+	expect := []string{"control", "control", "normal", "bulk", "bulk"}
+
+	for _, name := range expect {
+		pk := l.next()
+		if pk == nil {
+			t.Fatal("Expected a packet, got nil")
+		}
+		if got := pk.message.Header["Name"]; got != name {
+			t.Fatalf("Expected %s packet, got %s", name, got)
+		}
+	}
+
+	// nothing is returned once the link is closed
+	close(l.closed)
+
+	if pk := l.next(); pk != nil {
+		t.Fatalf("Expected no packet on closed link, got %+v", pk.message.Header)
+	}
+}
@@ -25,8 +25,8 @@ import (
 type tunListener struct {
 	// address of the listener
 	channel string
-	// token is the tunnel token
-	token string
+	// keys are the keys sessions are encrypted with
+	keys *keyring
 	// the accept channel
 	accept chan *session
 	// the tunnel closed channel
@@ -94,22 +94,22 @@ func (t *tunListener) process() {
 
 				// create a new session session
 				sess = &session{
-					// the session key
-					key: []byte(t.token + m.channel + sessionId),
 					// the id of the remote side
 					tunnel: m.tunnel,
 					// the channel
 					channel: m.channel,
 					// the session id
 					session: sessionId,
-					// tunnel token
-					token: t.token,
+					// the session keys
+					keys: t.keys,
 					// is loopback conn
 					loopback: m.loopback,
 					// the link the message was received on
 					link: linkId,
 					// set the connection mode
 					mode: t.session.mode,
+					// set the priority of the session
+					priority: t.session.priority,
 					// close chan
 					closed: make(chan bool),
 					// recv called by the acceptor
@@ -118,6 +118,8 @@ func (t *tunListener) process() {
 					send: t.session.send,
 					// error channel
 					errChan: make(chan error, 1),
+					// resumed channel
+					resumed: make(chan uint64, 1),
 					// set the read timeout
 					readTimeout: t.session.readTimeout,
 				}
@@ -157,6 +159,12 @@ func (t *tunListener) process() {
 
 				// continue
 				continue
+			case "resume":
+				// the link of the session dropped and the dialling side resumed it
+				if sess.mode == tunnel.Unicast {
+					go sess.resumeFrom(m)
+				}
+				continue
 			case "session":
 				// operate on this
 			default:
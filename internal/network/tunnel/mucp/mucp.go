@@ -17,6 +17,7 @@ package mucp
 import (
 	"errors"
 	"math/rand"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -48,8 +49,8 @@ type tun struct {
 	// the unique id for this tunnel
 	id string
 
-	// tunnel token for session encryption
-	token string
+	// keys for session encryption
+	keys *keyring
 
 	// to indicate if we're connected or not
 	connected bool
@@ -81,7 +82,7 @@ func NewTunnel(opts ...tunnel.Option) *tun {
 	return &tun{
 		options:  options,
 		id:       options.Id,
-		token:    options.Token,
+		keys:     newKeyring(options.Token, options.RotateInterval, options.RotateOverlap),
 		send:     make(chan *message, 128),
 		closed:   make(chan bool),
 		sessions: make(map[string]*session),
@@ -92,10 +93,28 @@ func NewTunnel(opts ...tunnel.Option) *tun {
 // Init initializes tunnel options
 func (t *tun) Init(opts ...tunnel.Option) error {
 	t.Lock()
+	defer t.Unlock()
+
+	nodes := t.options.Nodes
 	for _, o := range opts {
 		o(&t.options)
 	}
-	t.Unlock()
+
+	// close the links to the nodes which were removed
+	keep := make(map[string]bool)
+	for _, node := range t.options.Nodes {
+		keep[node] = true
+	}
+	for _, node := range nodes {
+		if link, ok := t.links[node]; ok && !keep[node] {
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Tunnel closing link to removed node %s", node)
+			}
+			link.Close()
+			delete(t.links, node)
+		}
+	}
+
 	return nil
 }
 
@@ -145,14 +164,15 @@ func (t *tun) newSession(channel, sessionId string) (*session, bool, error) {
 		tunnel:  t.id,
 		channel: channel,
 		session: sessionId,
-		token:   t.token,
+		keys:    t.keys,
 		closed:  make(chan bool),
 		recv:    make(chan *message, 128),
 		send:    t.send,
 		errChan: make(chan error, 1),
-		key:     []byte(t.token + channel + sessionId),
+		resumed: make(chan uint64, 1),
 	}
-	gcm, err := newCipher(s.key)
+	s.gen = t.keys.generation()
+	gcm, err := s.cipher(s.gen, sessionId)
 	if err != nil {
 		return nil, false, err
 	}
@@ -493,11 +513,18 @@ func (t *tun) respond(msg *message, err error) {
 
 // sendTo sends a message to the chosen links
 func (t *tun) sendTo(links []*link, msg *message) error {
+	// only session data is queued at the session priority,
+	// everything else keeps the tunnel running so goes first
+	priority := tunnel.PriorityControl
+	if msg.typ == "session" {
+		priority = msg.priority
+	}
+
 	// the function that sends the actual message
-	send := func(link *link, msg *transport.Message) error {
-		if err := link.Send(msg); err != nil {
+	send := func(link *link, m *transport.Message) error {
+		if err := link.queue(m, priority); err != nil {
 			if logger.V(logger.DebugLevel, log) {
-				log.Debugf("Tunnel error sending %+v to %s: %v", msg.Header, link.Remote(), err)
+				log.Debugf("Tunnel error sending %+v to %s: %v", m.Header, link.Remote(), err)
 			}
 			t.delLink(link.Remote())
 			return err
@@ -525,6 +552,14 @@ func (t *tun) sendTo(links []*link, msg *message) error {
 	newMsg.Header["Micro-Tunnel-Channel"] = msg.channel
 	// set the session id
 	newMsg.Header["Micro-Tunnel-Session"] = msg.session
+	// set the generation of key the session data is encrypted with
+	if msg.typ == "session" {
+		newMsg.Header["Micro-Tunnel-Key"] = strconv.FormatUint(msg.key, 10)
+	}
+	// set the sequence number of unicast session data, or the last received when resuming
+	if msg.seq > 0 || msg.typ == "resume" || msg.typ == "resumed" {
+		newMsg.Header["Micro-Tunnel-Seq"] = strconv.FormatUint(msg.seq, 10)
+	}
 
 	// error channel for call
 	errChan := make(chan error, len(links))
@@ -593,6 +628,7 @@ func (t *tun) delLink(remote string) {
 	t.Lock()
 
 	// get the link
+	var deleted []string
 	for id, link := range t.links {
 		if link.id != remote {
 			continue
@@ -603,9 +639,27 @@ func (t *tun) delLink(remote string) {
 		}
 		link.Close()
 		delete(t.links, id)
+		deleted = append(deleted, link.id)
+	}
+
+	// the sessions dialled over the link are resumed once it's restored
+	var resume []*session
+	for _, id := range deleted {
+		for _, s := range t.sessions {
+			s.RLock()
+			dropped := s.outbound && s.accepted && s.mode == tunnel.Unicast && s.link == id
+			s.RUnlock()
+			if dropped {
+				resume = append(resume, s)
+			}
+		}
 	}
 
 	t.Unlock()
+
+	for _, s := range resume {
+		go s.resume(remote)
+	}
 }
 
 // process incoming messages
@@ -632,11 +686,12 @@ func (t *tun) listen(link *link) {
 			return
 		}
 
-		// TODO: figure out network authentication
-		// for now we use tunnel token to encrypt/decrypt
-		// session communication, but we will probably need
-		// some sort of network authentication (token) to avoid
-		// having rogue actors spamming the network
+		// NOTE: the messages are sealed with ephemeral keys the link
+		// exchanges with the other end, signed with the tunnel token,
+		// and the link drops the ones which aren't, so only the peers
+		// with the token can connect, announce channels or send
+		// anything on the network. The session data is also encrypted
+		// with keys derived from the token.
 
 		// message type
 		mtype := msg.Header["Micro-Tunnel"]
@@ -646,6 +701,10 @@ func (t *tun) listen(link *link) {
 		channel := msg.Header["Micro-Tunnel-Channel"]
 		// the session id
 		sessionId := msg.Header["Micro-Tunnel-Session"]
+		// the generation of key the session data is encrypted with
+		key, _ := strconv.ParseUint(msg.Header["Micro-Tunnel-Key"], 10, 64)
+		// the sequence number of the session data
+		seq, _ := strconv.ParseUint(msg.Header["Micro-Tunnel-Seq"], 10, 64)
 
 		// if its not connected throw away the link
 		// the first message we process needs to be connect
@@ -656,9 +715,19 @@ func (t *tun) listen(link *link) {
 			return
 		}
 
+		// the control messages carry the number of forced key rotations
+		// of the other side, which we rotate to if they're ahead of us
+		if v, ok := msg.Header["Micro-Tunnel-Rotations"]; ok {
+			if n, err := strconv.ParseUint(v, 10, 64); err == nil && t.keys.adopt(n) {
+				log.Infof("Tunnel rotated keys following %s", link.Remote())
+				// pass the rotation on to the other links
+				go t.rotated(link)
+			}
+		}
+
 		// this state machine block handles the only message types
 		// that we know or care about; connect, close, open, accept,
-		// discover, announce, session, keepalive
+		// discover, announce, session, keepalive, rotate
 		switch mtype {
 		case "connect":
 			if logger.V(logger.DebugLevel, log) {
@@ -735,6 +804,9 @@ func (t *tun) listen(link *link) {
 			// save the keepalive
 			link.keepalive()
 			continue
+		// the keys were rotated and handled above
+		case "rotate":
+			continue
 		// a new connection dialled outbound
 		case "open":
 			if logger.V(logger.DebugLevel, log) {
@@ -754,6 +826,24 @@ func (t *tun) listen(link *link) {
 				continue
 			}
 			// otherwise we're going to process to accept
+		// the dialling side of a session asks to resume it after its link dropped
+		case "resume":
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Tunnel link %s received resume %s %s", link.id, channel, sessionId)
+			}
+		// the other side resumed a session we dialled
+		case "resumed":
+			s, exists := t.getSession(channel, sessionId)
+			if exists && s.mode == tunnel.Unicast {
+				s.Lock()
+				s.link = link.id
+				s.Unlock()
+				select {
+				case s.resumed <- seq:
+				default:
+				}
+			}
+			continue
 		// a continued session
 		case "session":
 			// process message
@@ -891,6 +981,8 @@ func (t *tun) listen(link *link) {
 			channel:  channel,
 			session:  sessionId,
 			mode:     s.mode,
+			key:      key,
+			seq:      seq,
 			data:     tmsg,
 			link:     link.id,
 			loopback: loopback,
@@ -909,12 +1001,34 @@ func (t *tun) listen(link *link) {
 func (t *tun) sendMsg(method string, link *link) error {
 	return link.Send(&transport.Message{
 		Header: map[string]string{
-			"Micro-Tunnel":    method,
-			"Micro-Tunnel-Id": t.id,
+			"Micro-Tunnel":           method,
+			"Micro-Tunnel-Id":        t.id,
+			"Micro-Tunnel-Rotations": strconv.FormatUint(t.keys.rotations(), 10),
 		},
 	})
 }
 
+// rotated sends the number of forced key rotations to all the links
+// other than the one the rotation came from
+func (t *tun) rotated(from *link) {
+	t.RLock()
+	var links []*link
+	for _, link := range t.links {
+		if link != from && !link.Loopback() {
+			links = append(links, link)
+		}
+	}
+	t.RUnlock()
+
+	for _, link := range links {
+		if err := t.sendMsg("rotate", link); err != nil {
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Tunnel failed to send rotation to %s: %v", link.Remote(), err)
+			}
+		}
+	}
+}
+
 // setupLink connects to node and returns link if successful
 // It returns error if the link failed to be established
 func (t *tun) setupLink(node string) (*link, error) {
@@ -932,7 +1046,7 @@ func (t *tun) setupLink(node string) (*link, error) {
 		log.Debugf("Tunnel connected to %s", node)
 	}
 	// create a new link
-	link := newLink(c)
+	link := newLink(c, t.options.Bandwidth, t.keys)
 
 	// set link id to remote side
 	link.Lock()
@@ -992,6 +1106,11 @@ func (t *tun) setupLinks() {
 
 // connect the tunnel to all the nodes and listen for incoming tunnel connections
 func (t *tun) connect() error {
+	// edge tunnels only dial out to the nodes
+	if t.options.Edge {
+		return nil
+	}
+
 	l, err := t.options.Transport.Listen(t.options.Address)
 	if err != nil {
 		return err
@@ -1007,7 +1126,7 @@ func (t *tun) connect() error {
 				log.Debugf("Tunnel accepted connection from %s", sock.Remote())
 			}
 			// create a new link
-			link := newLink(sock)
+			link := newLink(sock, t.options.Bandwidth, t.keys)
 
 			// manage the link
 			go t.manageLink(link)
@@ -1085,6 +1204,11 @@ func (t *tun) close() error {
 		delete(t.links, node)
 	}
 
+	// edge tunnels have no listener
+	if t.listener == nil {
+		return nil
+	}
+
 	// close the listener
 	// this appears to be blocking
 	return t.listener.Close()
@@ -1146,7 +1270,7 @@ func (t *tun) Address() string {
 	t.RLock()
 	defer t.RUnlock()
 
-	if !t.connected {
+	if !t.connected || t.listener == nil {
 		return t.options.Address
 	}
 
@@ -1215,6 +1339,8 @@ func (t *tun) Dial(channel string, opts ...tunnel.DialOption) (tunnel.Session, e
 	c.outbound = true
 	// set the mode of connection unicast/multicast/broadcast
 	c.mode = options.Mode
+	// set the priority of the session
+	c.priority = options.Priority
 	// set the dial timeout
 	c.dialTimeout = options.Timeout
 	// set read timeout set to never
@@ -1391,13 +1517,15 @@ func (t *tun) Listen(channel string, opts ...tunnel.ListenOption) (tunnel.Listen
 	c.local = channel
 	// set mode
 	c.mode = options.Mode
+	// set the priority
+	c.priority = options.Priority
 	// set the timeout
 	c.readTimeout = options.Timeout
 
 	tl := &tunListener{
 		channel: channel,
-		// tunnel token
-		token: t.token,
+		// the session keys
+		keys: t.keys,
 		// the accept channel
 		accept: make(chan *session, 128),
 		// the channel to close
@@ -1420,6 +1548,14 @@ func (t *tun) Listen(channel string, opts ...tunnel.ListenOption) (tunnel.Listen
 	return tl, nil
 }
 
+// Rotate forces the rotation of the session keys. Keys which have been rotated
+// out are accepted for the overlap so sessions in flight aren't broken.
+func (t *tun) Rotate() uint64 {
+	gen := t.keys.force()
+	go t.rotated(nil)
+	return gen
+}
+
 func (t *tun) Links() []tunnel.Link {
 	t.RLock()
 	links := make([]tunnel.Link, 0, len(t.links))
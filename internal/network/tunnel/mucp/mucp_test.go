@@ -15,6 +15,7 @@
 package mucp
 
 import (
+	"net"
 	"os"
 	"sync"
 	"testing"
@@ -359,3 +360,289 @@ func TestReconnectTunnel(t *testing.T) {
 	// wait until done
 	wg.Wait()
 }
+
+func TestEdgeTunnel(t *testing.T) {
+	// create a new edge tunnel which only dials out
+	tunA := NewTunnel(
+		tunnel.Address("127.0.0.1:9098"),
+		tunnel.Nodes("127.0.0.1:9099"),
+		tunnel.Edge(true),
+	)
+
+	// create a new tunnel server
+	tunB := NewTunnel(
+		tunnel.Address("127.0.0.1:9099"),
+	)
+
+	// start tunB
+	err := tunB.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tunB.Close()
+
+	// start tunA
+	err = tunA.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tunA.Close()
+
+	// the edge tunnel must not accept connections
+	if _, err := net.DialTimeout("tcp", "127.0.0.1:9098", time.Second); err == nil {
+		t.Fatal("Expected the edge tunnel not to listen")
+	}
+
+	// wait for the server to accept the link from the edge
+	for i := 0; len(tunB.Links()) == 0; i++ {
+		if i == 100 {
+			t.Fatal("Expected the edge to link to the server")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	// listen on the edge
+	tl, err := tunA.Listen("test-tunnel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tl.Close()
+
+	// dial the edge over its outbound link
+	errChan := make(chan error, 1)
+	go func() {
+		c, err := tunB.Dial("test-tunnel")
+		if err != nil {
+			errChan <- err
+			return
+		}
+		defer c.Close()
+
+		errChan <- c.Send(&transport.Message{Header: map[string]string{"test": "send"}})
+	}()
+
+	c, err := tl.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errChan; err != nil {
+		t.Fatal(err)
+	}
+
+	m := new(transport.Message)
+	if err := c.Recv(m); err != nil {
+		t.Fatal(err)
+	}
+	if v := m.Header["test"]; v != "send" {
+		t.Fatalf("Edge expected test:send header. Received: %s", v)
+	}
+}
+
+func TestRotateTunnel(t *testing.T) {
+	// create a new tunnel client
+	tunA := NewTunnel(
+		tunnel.Address("127.0.0.1:9100"),
+		tunnel.Nodes("127.0.0.1:9101"),
+	)
+
+	// create a new tunnel server
+	tunB := NewTunnel(
+		tunnel.Address("127.0.0.1:9101"),
+	)
+
+	if err := tunB.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunB.Close()
+
+	if err := tunA.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunA.Close()
+
+	tl, err := tunB.Listen("test-tunnel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tl.Close()
+
+	// the dial waits for the session to be accepted
+	accepted := make(chan tunnel.Session, 1)
+	go func() {
+		s, err := tl.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+		accepted <- s
+	}()
+
+	c, err := tunA.Dial("test-tunnel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	send := func(v string) {
+		if err := c.Send(&transport.Message{Header: map[string]string{"test": v}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, ok := <-accepted
+	if !ok {
+		t.Fatal("Expected the session to be accepted")
+	}
+
+	send("before")
+
+	// rotate the keys while the session is open
+	if gen := tunA.Rotate(); gen != 1 {
+		t.Fatalf("Expected generation 1, got %d", gen)
+	}
+	send("after")
+
+	for _, v := range []string{"before", "after"} {
+		m := new(transport.Message)
+		if err := s.Recv(m); err != nil {
+			t.Fatal(err)
+		}
+		if m.Header["test"] != v {
+			t.Fatalf("Expected test:%s header. Received: %s", v, m.Header["test"])
+		}
+	}
+
+	// the rotation is passed over the link
+	for i := 0; tunB.keys.rotations() != 1; i++ {
+		if i == 100 {
+			t.Fatal("Expected the server to rotate its keys")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestResumeTunnel(t *testing.T) {
+	ReconnectTime = 200 * time.Millisecond
+
+	tunA := NewTunnel(
+		tunnel.Address("127.0.0.1:9102"),
+		tunnel.Nodes("127.0.0.1:9103"),
+	)
+	tunB := NewTunnel(
+		tunnel.Address("127.0.0.1:9103"),
+	)
+
+	if err := tunB.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunB.Close()
+
+	if err := tunA.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunA.Close()
+
+	tl, err := tunB.Listen("test-tunnel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tl.Close()
+
+	accepted := make(chan tunnel.Session, 1)
+	go func() {
+		s, err := tl.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+		accepted <- s
+	}()
+
+	c, err := tunA.Dial("test-tunnel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	s, ok := <-accepted
+	if !ok {
+		t.Fatal("Expected the session to be accepted")
+	}
+
+	recv := func(sess tunnel.Session, v string) {
+		m := new(transport.Message)
+		if err := sess.Recv(m); err != nil {
+			t.Fatal(err)
+		}
+		if m.Header["test"] != v {
+			t.Fatalf("Expected test:%s header. Received: %s", v, m.Header["test"])
+		}
+	}
+
+	if err := c.Send(&transport.Message{Header: map[string]string{"test": "before"}}); err != nil {
+		t.Fatal(err)
+	}
+	recv(s, "before")
+
+	// drop the links of the dialling side while the other side sends
+	for _, link := range tunA.Links() {
+		link.Close()
+	}
+	sent := make(chan error, 1)
+	go func() {
+		sent <- s.Send(&transport.Message{Header: map[string]string{"test": "dropped"}})
+	}()
+
+	// the session is resumed once the link is restored, the message sent meanwhile is replayed
+	recv(c, "dropped")
+	if err := <-sent; err != nil {
+		t.Fatal(err)
+	}
+
+	// the session carries on in both directions without the messages being repeated
+	if err := c.Send(&transport.Message{Header: map[string]string{"test": "after"}}); err != nil {
+		t.Fatal(err)
+	}
+	recv(s, "after")
+}
+
+func TestTokenTunnel(t *testing.T) {
+	// create a new tunnel client with another token
+	tunA := NewTunnel(
+		tunnel.Address("127.0.0.1:9104"),
+		tunnel.Nodes("127.0.0.1:9105"),
+		tunnel.Token("rogue"),
+	)
+
+	// create a new tunnel server
+	tunB := NewTunnel(
+		tunnel.Address("127.0.0.1:9105"),
+		tunnel.Token("secret"),
+	)
+
+	if err := tunB.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunB.Close()
+
+	if err := tunA.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunA.Close()
+
+	tl, err := tunB.Listen("test-tunnel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tl.Close()
+
+	// the server drops the link of the peer without the token
+	if _, err := tunA.Dial("test-tunnel", tunnel.DialTimeout(time.Second)); err == nil {
+		t.Fatal("expected the dial of a peer without the token to fail")
+	}
+	for _, link := range tunB.Links() {
+		if !link.Loopback() {
+			t.Fatalf("expected no link to the peer without the token, got %s", link.Remote())
+		}
+	}
+}
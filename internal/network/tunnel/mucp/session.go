@@ -26,6 +26,17 @@ import (
 	"github.com/micro/micro/v3/service/logger"
 )
 
+var (
+	// ResumeBacklog is the number of messages a unicast session keeps to replay to the other side
+	// when it's resumed, the messages sent before these can't be recovered
+	ResumeBacklog = 64
+	// ResumeTimeout is how long a unicast session waits for its link to be restored and the session
+	// resumed before the messages sent fail
+	ResumeTimeout = time.Second * 30
+	// resumeInterval is how often the dialling side asks the other side to resume the session
+	resumeInterval = time.Second
+)
+
 // session is our pseudo session for transport.Socket
 type session struct {
 	// the tunnel id
@@ -34,8 +45,8 @@ type session struct {
 	channel string
 	// the session id based on Micro.Tunnel-Session
 	session string
-	// token is the session token
-	token string
+	// keys are the keys the session is encrypted with
+	keys *keyring
 	// closed
 	closed chan bool
 	// remote addr
@@ -56,6 +67,8 @@ type session struct {
 	loopback bool
 	// mode of the connection
 	mode tunnel.Mode
+	// priority of the session on the links
+	priority tunnel.Priority
 	// the dial timeout
 	dialTimeout time.Duration
 	// the read timeout
@@ -64,10 +77,20 @@ type session struct {
 	link string
 	// the error response
 	errChan chan error
-	// key for session encryption
-	key []byte
 	// cipher for session
 	gcm cipher.AEAD
+	// the generation of key of the cipher
+	gen uint64
+	// seq is the sequence number of the last message sent
+	seq uint64
+	// recvSeq is the sequence number of the last message received
+	recvSeq uint64
+	// backlog of the messages last sent, replayed when the session is resumed
+	backlog []*message
+	// resumed receives the sequence number the other side received up to when resumed
+	resumed chan uint64
+	// resumeMtx is held while resuming the session
+	resumeMtx sync.Mutex
 	sync.RWMutex
 }
 
@@ -87,8 +110,14 @@ type message struct {
 	loopback bool
 	// mode of the connection
 	mode tunnel.Mode
+	// priority of the message on the link
+	priority tunnel.Priority
 	// the link to send the message on
 	link string
+	// the generation of key the data is encrypted with
+	key uint64
+	// the sequence number of session data, or the last received when resuming
+	seq uint64
 	// transport data
 	data *transport.Message
 	// the error channel
@@ -117,6 +146,10 @@ func (s *session) Channel() string {
 
 // newMessage creates a new message based on the session
 func (s *session) newMessage(typ string) *message {
+	s.RLock()
+	link := s.link
+	s.RUnlock()
+
 	return &message{
 		typ:      typ,
 		tunnel:   s.tunnel,
@@ -125,7 +158,8 @@ func (s *session) newMessage(typ string) *message {
 		outbound: s.outbound,
 		loopback: s.loopback,
 		mode:     s.mode,
-		link:     s.link,
+		priority: s.priority,
+		link:     link,
 		errChan:  s.errChan,
 	}
 }
@@ -316,7 +350,9 @@ func (s *session) Open() error {
 	// set to accepted
 	s.accepted = true
 	// set link
+	s.Lock()
 	s.link = msg.link
+	s.Unlock()
 
 	return nil
 }
@@ -353,17 +389,22 @@ func (s *session) Announce() error {
 func (s *session) Send(m *transport.Message) error {
 	var err error
 
+	// encrypt with the current generation of key
+	gen := s.keys.generation()
+
 	s.RLock()
 	gcm := s.gcm
+	cached := s.gen
 	s.RUnlock()
 
-	if gcm == nil {
-		gcm, err = newCipher(s.key)
+	if gcm == nil || cached != gen {
+		gcm, err = s.cipher(gen, s.session)
 		if err != nil {
 			return err
 		}
 		s.Lock()
 		s.gcm = gcm
+		s.gen = gen
 		s.Unlock()
 	}
 	// encrypt the transport message payload
@@ -382,7 +423,7 @@ func (s *session) Send(m *transport.Message) error {
 	// encrypt all the headers
 	for k, v := range m.Header {
 		// encrypt the transport message payload
-		val, err := Encrypt(s.gcm, []byte(v))
+		val, err := Encrypt(gcm, []byte(v))
 		if err != nil {
 			log.Debugf("failed to encrypt message header %s: %v", k, err)
 			return err
@@ -395,12 +436,30 @@ func (s *session) Send(m *transport.Message) error {
 	msg := s.newMessage("session")
 	// set the data
 	msg.data = data
+	// set the generation of key
+	msg.key = gen
 
 	// if multicast don't set the link
 	if s.mode != tunnel.Unicast {
 		msg.link = ""
 	}
 
+	// unicast messages are numbered and kept so they can be replayed if the link drops
+	if s.mode == tunnel.Unicast {
+		// wait for the session to be resumed if it's resuming
+		s.resumeMtx.Lock()
+		s.resumeMtx.Unlock()
+
+		s.Lock()
+		s.seq++
+		msg.seq = s.seq
+		s.backlog = append(s.backlog, msg)
+		if len(s.backlog) > ResumeBacklog {
+			s.backlog = s.backlog[len(s.backlog)-ResumeBacklog:]
+		}
+		s.Unlock()
+	}
+
 	if logger.V(logger.TraceLevel, log) {
 		log.Tracef("Appending to send backlog: %v", msg)
 	}
@@ -410,16 +469,163 @@ func (s *session) Send(m *transport.Message) error {
 	}
 
 	// wait for an error response
-	return s.wait(msg)
+	err = s.wait(msg)
+	if err == nil || err == io.EOF || s.mode != tunnel.Unicast {
+		return err
+	}
+
+	// the link dropped, the message is replayed once the session is resumed on another link
+	if s.outbound {
+		return s.resume(msg.link)
+	}
+	select {
+	case <-s.resumed:
+		return nil
+	case <-s.closed:
+		return io.EOF
+	case <-time.After(ResumeTimeout):
+		return err
+	}
+}
+
+// resume the session after its link dropped. The dialling side asks the other side to resume
+// the session on whichever link it now has to it, then both sides replay the messages the other
+// didn't receive.
+func (s *session) resume(link string) error {
+	s.resumeMtx.Lock()
+	defer s.resumeMtx.Unlock()
+
+	// resumed while waiting
+	s.RLock()
+	current := s.link
+	s.RUnlock()
+	if current != link {
+		return nil
+	}
+
+	// drop the signal of a previous resume
+	select {
+	case <-s.resumed:
+	default:
+	}
+
+	deadline := time.After(ResumeTimeout)
+	for {
+		msg := s.newMessage("resume")
+		// the other side may be on any link
+		msg.mode = tunnel.Broadcast
+		msg.link = ""
+		msg.outbound = true
+		msg.errChan = nil
+		s.RLock()
+		msg.seq = s.recvSeq
+		s.RUnlock()
+
+		if err := s.sendMsg(msg); err != nil {
+			return err
+		}
+
+		select {
+		case seq := <-s.resumed:
+			return s.replay(seq)
+		case <-time.After(resumeInterval):
+		case <-deadline:
+			return tunnel.ErrLinkDisconnected
+		case <-s.closed:
+			return io.EOF
+		}
+	}
+}
+
+// resumeFrom resumes the session after the dialling side asked to, on the link it asked on
+func (s *session) resumeFrom(m *message) {
+	s.Lock()
+	s.link = m.link
+	seq := s.recvSeq
+	s.Unlock()
+
+	msg := s.newMessage("resumed")
+	msg.seq = seq
+	msg.errChan = nil
+	if err := s.sendMsg(msg); err != nil {
+		return
+	}
+	if err := s.replay(m.seq); err != nil {
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Tunnel failed to resume session %s: %v", s.session, err)
+		}
+		return
+	}
+
+	// the messages waiting for the session to be resumed were replayed
+	select {
+	case s.resumed <- m.seq:
+	default:
+	}
+}
+
+// replay the messages sent after the sequence number the other side received
+func (s *session) replay(after uint64) error {
+	s.RLock()
+	link := s.link
+	var msgs []*message
+	for _, m := range s.backlog {
+		if m.seq > after {
+			msgs = append(msgs, m)
+		}
+	}
+	lost := len(msgs) > 0 && msgs[0].seq > after+1
+	s.RUnlock()
+
+	// the messages the other side is missing are no longer kept
+	if lost {
+		s.Close()
+		return tunnel.ErrLinkDisconnected
+	}
+
+	for _, m := range msgs {
+		msg := *m
+		msg.link = link
+		msg.errChan = make(chan error, 1)
+		if err := s.sendMsg(&msg); err != nil {
+			return err
+		}
+		if err := s.wait(&msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cipher returns the cipher for a generation of key and a session
+func (s *session) cipher(gen uint64, session string) (cipher.AEAD, error) {
+	return newCipher(append(s.keys.key(gen), s.channel+session...))
 }
 
 // Recv is used to receive a message
 func (s *session) Recv(m *transport.Message) error {
 	var msg *message
+	var err error
 
-	msg, err := s.waitFor("", s.readTimeout)
-	if err != nil {
-		return err
+	for {
+		msg, err = s.waitFor("", s.readTimeout)
+		if err != nil {
+			return err
+		}
+		if s.mode != tunnel.Unicast || msg.seq == 0 {
+			break
+		}
+
+		// drop the messages replayed which were received before the link dropped
+		s.Lock()
+		dup := msg.seq <= s.recvSeq
+		if !dup {
+			s.recvSeq = msg.seq
+		}
+		s.Unlock()
+		if !dup {
+			break
+		}
 	}
 
 	// check the error if one exists
@@ -433,7 +639,15 @@ func (s *session) Recv(m *transport.Message) error {
 		log.Tracef("Received from recv backlog: %v", msg)
 	}
 
-	gcm, err := newCipher([]byte(s.token + s.channel + msg.session))
+	// reject keys which have been rotated out
+	if !s.keys.valid(msg.key) {
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Tunnel received message encrypted with retired key %d", msg.key)
+		}
+		return tunnel.ErrKeyRetired
+	}
+
+	gcm, err := s.cipher(msg.key, msg.session)
 	if err != nil {
 		if logger.V(logger.ErrorLevel, log) {
 			log.Errorf("unable to create cipher: %v", err)
@@ -27,6 +27,8 @@ var (
 	DefaultAddress = ":0"
 	// The shared default token
 	DefaultToken = "go.micro.tunnel"
+	// DefaultRotateOverlap is how long session keys are accepted after being rotated
+	DefaultRotateOverlap = 10 * time.Minute
 )
 
 type Option func(*Options)
@@ -43,6 +45,16 @@ type Options struct {
 	Token string
 	// Transport listens to incoming connections
 	Transport transport.Transport
+	// Edge only dials out to the nodes, without listening for incoming
+	// connections, so the tunnel can run behind NAT or a firewall
+	Edge bool
+	// RotateInterval is how often the session keys are rotated.
+	// The keys are derived from the token alone if not set.
+	RotateInterval time.Duration
+	// RotateOverlap is how long keys are accepted after being rotated
+	RotateOverlap time.Duration
+	// Bandwidth is the bytes per second each link sends at, unlimited if not set
+	Bandwidth int64
 }
 
 type DialOption func(*DialOptions)
@@ -56,6 +68,8 @@ type DialOptions struct {
 	Wait bool
 	// the dial timeout
 	Timeout time.Duration
+	// Priority of the session
+	Priority Priority
 }
 
 type ListenOption func(*ListenOptions)
@@ -65,6 +79,8 @@ type ListenOptions struct {
 	Mode Mode
 	// The read timeout
 	Timeout time.Duration
+	// Priority of the sessions accepted
+	Priority Priority
 }
 
 // The tunnel id
@@ -102,6 +118,38 @@ func Transport(t transport.Transport) Option {
 	}
 }
 
+// Edge sets the tunnel to only dial out to the nodes. Inbound sessions
+// are multiplexed over the outbound links, so nodes behind NAT can be
+// dialled without opening a port for them.
+func Edge(b bool) Option {
+	return func(o *Options) {
+		o.Edge = b
+	}
+}
+
+// RotateInterval sets how often the session keys are rotated
+func RotateInterval(d time.Duration) Option {
+	return func(o *Options) {
+		o.RotateInterval = d
+	}
+}
+
+// RotateOverlap sets how long keys are accepted after being rotated
+// so messages in flight and nodes with skewed clocks are still read
+func RotateOverlap(d time.Duration) Option {
+	return func(o *Options) {
+		o.RotateOverlap = d
+	}
+}
+
+// Bandwidth limits the bytes per second sent on each link so the
+// tunnel doesn't saturate constrained uplinks. Zero is unlimited.
+func Bandwidth(b int64) Option {
+	return func(o *Options) {
+		o.Bandwidth = b
+	}
+}
+
 // Listen options
 func ListenMode(m Mode) ListenOption {
 	return func(o *ListenOptions) {
@@ -116,6 +164,13 @@ func ListenTimeout(t time.Duration) ListenOption {
 	}
 }
 
+// ListenPriority sets the priority of the sessions accepted by the listener
+func ListenPriority(p Priority) ListenOption {
+	return func(o *ListenOptions) {
+		o.Priority = p
+	}
+}
+
 // Dial options
 
 // Dial multicast sets the multicast option to send only to those mapped
@@ -148,12 +203,21 @@ func DialWait(b bool) DialOption {
 	}
 }
 
+// DialPriority sets the priority of the session. Messages are queued
+// on each link by priority so control traffic isn't held up behind data.
+func DialPriority(p Priority) DialOption {
+	return func(o *DialOptions) {
+		o.Priority = p
+	}
+}
+
 // DefaultOptions returns router default options
 func DefaultOptions() Options {
 	return Options{
-		Id:        uuid.New().String(),
-		Address:   DefaultAddress,
-		Token:     DefaultToken,
-		Transport: grpc.NewTransport(),
+		Id:            uuid.New().String(),
+		Address:       DefaultAddress,
+		Token:         DefaultToken,
+		Transport:     grpc.NewTransport(),
+		RotateOverlap: DefaultRotateOverlap,
 	}
 }
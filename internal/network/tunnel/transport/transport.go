@@ -66,7 +66,18 @@ func (t *tunTransport) Dial(addr string, opts ...transport.DialOption) (transpor
 		return nil, err
 	}
 
-	c, err := t.tunnel.Dial(addr)
+	var options transport.DialOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	// streams carry bulk data so let requests go ahead of them
+	var dopts []tunnel.DialOption
+	if options.Stream {
+		dopts = append(dopts, tunnel.DialPriority(tunnel.PriorityBulk))
+	}
+
+	c, err := t.tunnel.Dial(addr, dopts...)
 	if err != nil {
 		return nil, err
 	}
@@ -31,6 +31,15 @@ const (
 	Broadcast
 )
 
+const (
+	// send in turn with other sessions
+	PriorityNormal Priority = iota
+	// send ahead of sessions e.g. control plane adverts
+	PriorityControl
+	// send once nothing else is queued e.g. bulk data
+	PriorityBulk
+)
+
 var (
 	// DefaultDialTimeout is the dial timeout if none is specified
 	DefaultDialTimeout = time.Second * 5
@@ -50,11 +59,18 @@ var (
 	ErrReadTimeout = errors.New("read timeout")
 	// ErrDecryptingData is for when theres a nonce error
 	ErrDecryptingData = errors.New("error decrypting data")
+	// ErrKeyRetired is returned when data is encrypted with a key which has been rotated out
+	ErrKeyRetired = errors.New("key retired")
+	// ErrUnauthenticated is returned when a message received on a link isn't signed with the token
+	ErrUnauthenticated = errors.New("message not authenticated")
 )
 
 // Mode of the session
 type Mode uint8
 
+// Priority of the session on the links it's multiplexed over
+type Priority uint8
+
 // Tunnel creates a gre tunnel on top of the go-micro/transport.
 // It establishes multiple streams using the Micro-Tunnel-Channel header
 // and Micro-Tunnel-Session header. The tunnel id is a hash of
@@ -74,6 +90,8 @@ type Tunnel interface {
 	Dial(channel string, opts ...DialOption) (Session, error)
 	// Listen allows to accept connections on a channel
 	Listen(channel string, opts ...ListenOption) (Listener, error)
+	// Rotate forces the rotation of the session keys and returns the new generation
+	Rotate() uint64
 	// String returns the name of the tunnel implementation
 	String() string
 }
@@ -0,0 +1,97 @@
+// Package overload limits the requests served concurrently, shedding the requests over the limits
+package overload
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/micro/micro/v3/service/metrics"
+)
+
+var (
+	// QueueTimeout is how long the queued requests wait for a slot before they're shed
+	QueueTimeout = time.Second
+	// ErrOverloaded is returned for the requests which are shed
+	ErrOverloaded = errors.New("overloaded")
+)
+
+// Limiter limits the requests served concurrently, queueing the requests over the limit up to the
+// queue depth. The requests which don't fit in the queue or wait longer than the QueueTimeout are
+// shed, so an overloaded server degrades by rejecting requests rather than piling up goroutines.
+type Limiter struct {
+	name  string
+	slots chan struct{}
+	queue chan struct{}
+}
+
+// NewLimiter returns a limiter of the requests in flight and the depth of the queue of requests
+// waiting for a slot, the name tags its metrics
+func NewLimiter(name string, inFlight, queue int) *Limiter {
+	return &Limiter{
+		name:  name,
+		slots: make(chan struct{}, inFlight),
+		queue: make(chan struct{}, queue),
+	}
+}
+
+// Acquire waits for a slot to serve a request, returning ErrOverloaded if the request is shed or
+// the error of the context if it's done first. Release must be called once the request is served.
+func (l *Limiter) Acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		l.report()
+		return nil
+	default:
+	}
+
+	select {
+	case l.queue <- struct{}{}:
+	default:
+		l.shed("queue_full")
+		return ErrOverloaded
+	}
+	defer func() { <-l.queue }()
+
+	t := time.NewTimer(QueueTimeout)
+	defer t.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		l.report()
+		return nil
+	case <-t.C:
+		l.shed("queue_timeout")
+		return ErrOverloaded
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees the slot of a request served
+func (l *Limiter) Release() {
+	<-l.slots
+	l.report()
+}
+
+// InFlight returns the number of requests being served
+func (l *Limiter) InFlight() int {
+	return len(l.slots)
+}
+
+// Queued returns the number of requests waiting for a slot
+func (l *Limiter) Queued() int {
+	return len(l.queue)
+}
+
+func (l *Limiter) report() {
+	if metrics.IsSet() {
+		metrics.Gauge("overload.in_flight", float64(len(l.slots)), metrics.Tags{"server": l.name})
+	}
+}
+
+func (l *Limiter) shed(reason string) {
+	if metrics.IsSet() {
+		metrics.Count("overload.shed", 1, metrics.Tags{"server": l.name, "reason": reason})
+	}
+}
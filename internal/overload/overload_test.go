@@ -0,0 +1,50 @@
+package overload
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	QueueTimeout = time.Millisecond * 50
+	l := NewLimiter("test", 1, 1)
+
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected a slot, got %v", err)
+	}
+	if l.InFlight() != 1 {
+		t.Fatalf("Expected 1 request in flight, got %v", l.InFlight())
+	}
+
+	// the request waiting in the queue fills it
+	done := make(chan error)
+	go func() { done <- l.Acquire(context.Background()) }()
+	for l.Queued() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := l.Acquire(context.Background()); err != ErrOverloaded {
+		t.Fatalf("Expected a request over the queue depth to be shed, got %v", err)
+	}
+
+	// the queued request gets the slot once it's released
+	l.Release()
+	if err := <-done; err != nil {
+		t.Fatalf("Expected the queued request to get the slot, got %v", err)
+	}
+
+	// a queued request is shed once it times out
+	if err := l.Acquire(context.Background()); err != ErrOverloaded {
+		t.Fatalf("Expected the queued request to time out, got %v", err)
+	}
+	if l.Queued() != 0 {
+		t.Fatalf("Expected the queue to be empty, got %v", l.Queued())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Acquire(ctx); err != context.Canceled {
+		t.Fatalf("Expected the error of the context, got %v", err)
+	}
+	l.Release()
+}
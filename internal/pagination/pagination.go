@@ -0,0 +1,20 @@
+// Package pagination pages the results of list endpoints
+package pagination
+
+// Bounds returns the start and end of the page of the results which skips the offset and contains
+// at most limit results, all the remaining results are included if the limit is zero. The bounds
+// are always valid for a slice of the total length.
+func Bounds(total int, offset, limit int64) (int, int) {
+	var start int64
+	if offset > 0 {
+		start = offset
+	}
+	if start > int64(total) {
+		start = int64(total)
+	}
+	end := int64(total)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+	return int(start), int(end)
+}
@@ -0,0 +1,30 @@
+package pagination
+
+import "testing"
+
+func TestBounds(t *testing.T) {
+	tt := []struct {
+		name          string
+		total         int
+		offset, limit int64
+		start, end    int
+	}{
+		{"all", 5, 0, 0, 0, 5},
+		{"limit", 5, 0, 2, 0, 2},
+		{"offset", 5, 3, 0, 3, 5},
+		{"page", 5, 2, 2, 2, 4},
+		{"last page", 5, 4, 2, 4, 5},
+		{"past the end", 5, 10, 2, 5, 5},
+		{"negative offset", 5, -1, 2, 0, 2},
+		{"empty", 0, 0, 10, 0, 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := Bounds(tc.total, tc.offset, tc.limit)
+			if start != tc.start || end != tc.end {
+				t.Errorf("Expected bounds %v:%v, got %v:%v", tc.start, tc.end, start, end)
+			}
+		})
+	}
+}
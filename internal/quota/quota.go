@@ -0,0 +1,378 @@
+// Package quota limits the number of requests accounts and namespaces can make per minute and per
+// day. The quotas are kept in the store so they're shared by all the instances of the api gateway
+// and proxy. Each instance counts its requests in memory and writes its counters to the store every
+// FlushInterval, reading those of the other instances, so together the instances may exceed a quota
+// by the requests made since the last flush.
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// Database and Table the quotas and counters are stored in
+	Database = "micro"
+	Table    = "quota"
+	// CacheTTL is how long a quota is cached before it's read from the store again, changes to the
+	// quotas take up to this long to be enforced
+	CacheTTL = time.Minute
+	// FlushInterval is how often the counters are written to the store and the counts of the other
+	// instances read
+	FlushInterval = time.Second
+
+	// ErrExceeded is returned when a request is over a quota
+	ErrExceeded = errors.New("quota exceeded")
+	// ErrNotFound is returned when there is no quota
+	ErrNotFound = errors.New("quota not found")
+)
+
+const (
+	prefixQuota = "quota/"
+	prefixCount = "count/"
+)
+
+// Quota limits the requests of an account, or of a namespace if the account is blank
+type Quota struct {
+	Namespace string `json:"namespace"`
+	Account   string `json:"account,omitempty"`
+	// PerMinute and PerDay are the maximum number of requests, unlimited if zero
+	PerMinute int64 `json:"per_minute,omitempty"`
+	PerDay    int64 `json:"per_day,omitempty"`
+}
+
+// Usage of a quota in the current window
+type Usage struct {
+	// Limit is the number of requests allowed in the window
+	Limit int64
+	// Remaining is the number of requests which can still be made in the window
+	Remaining int64
+	// Reset is when the window ends and the requests are counted from zero again
+	Reset time.Time
+}
+
+// Headers returns the rate limit headers of the usage, Retry-After is set if none remain
+func (u *Usage) Headers() map[string]string {
+	h := map[string]string{
+		"X-RateLimit-Limit":     strconv.FormatInt(u.Limit, 10),
+		"X-RateLimit-Remaining": strconv.FormatInt(u.Remaining, 10),
+		"X-RateLimit-Reset":     strconv.FormatInt(u.Reset.Unix(), 10),
+	}
+	if u.Remaining <= 0 {
+		secs := int64(time.Until(u.Reset).Seconds()) + 1
+		h["Retry-After"] = strconv.FormatInt(secs, 10)
+	}
+	return h
+}
+
+func quotaKey(ns, account string) string {
+	return prefixQuota + ns + "/" + account
+}
+
+// Set the quota of the account or namespace
+func Set(q *Quota) error {
+	if len(q.Namespace) == 0 {
+		return errors.New("missing namespace")
+	}
+	if q.PerMinute < 0 || q.PerDay < 0 {
+		return errors.New("limits can't be negative")
+	}
+	b, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+	rec := &store.Record{Key: quotaKey(q.Namespace, q.Account), Value: b}
+	if err := store.DefaultStore.Write(rec, store.WriteTo(Database, Table)); err != nil {
+		return err
+	}
+	cache.set(q.Namespace, q.Account, q)
+	return nil
+}
+
+// Get the quota of the account or namespace
+func Get(ns, account string) (*Quota, error) {
+	recs, err := store.DefaultStore.Read(quotaKey(ns, account), store.ReadFrom(Database, Table))
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	var q *Quota
+	if err := json.Unmarshal(recs[0].Value, &q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// List the quotas of the namespace ordered by account, the quotas of all namespaces are listed if
+// the namespace is blank
+func List(ns string) ([]*Quota, error) {
+	prefix := prefixQuota
+	if len(ns) > 0 {
+		prefix = quotaKey(ns, "")
+	}
+	recs, err := store.DefaultStore.Read(prefix, store.ReadFrom(Database, Table), store.ReadPrefix())
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	quotas := make([]*Quota, 0, len(recs))
+	for _, r := range recs {
+		var q *Quota
+		if err := json.Unmarshal(r.Value, &q); err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, q)
+	}
+	sort.Slice(quotas, func(i, j int) bool {
+		if quotas[i].Namespace != quotas[j].Namespace {
+			return quotas[i].Namespace < quotas[j].Namespace
+		}
+		return quotas[i].Account < quotas[j].Account
+	})
+	return quotas, nil
+}
+
+// Delete the quota of the account or namespace
+func Delete(ns, account string) error {
+	// not all the stores return an error when deleting a key which doesn't exist
+	if _, err := Get(ns, account); err != nil {
+		return err
+	}
+	if err := store.DefaultStore.Delete(quotaKey(ns, account), store.DeleteFrom(Database, Table)); err != nil {
+		return err
+	}
+	cache.set(ns, account, nil)
+	return nil
+}
+
+// window of time the requests are counted in
+type window struct {
+	name   string
+	length time.Duration
+	limit  func(q *Quota) int64
+}
+
+var windows = []window{
+	{"minute", time.Minute, func(q *Quota) int64 { return q.PerMinute }},
+	{"day", time.Hour * 24, func(q *Quota) int64 { return q.PerDay }},
+}
+
+// counter of the requests of a quota in a window
+type counter struct {
+	key   string
+	limit int64
+	reset time.Time
+	// count is the number of requests of this instance, others the number of the other instances
+	// when the counters were last flushed
+	count  int64
+	others int64
+	dirty  bool
+}
+
+// counters of the requests of this instance, keyed by the window of the quota
+type counters struct {
+	sync.Mutex
+	counters map[string]*counter
+	once     sync.Once
+}
+
+var (
+	// instance identifies the counters of this instance in the store
+	instance = uuid.New().String()
+	counts   = &counters{counters: make(map[string]*counter)}
+)
+
+// Check counts a request made by the account in the namespace against the quotas of the account
+// and of the namespace. The usage of the quota closest to its limit is returned, or nil if there
+// are no quotas. ErrExceeded is returned along with the usage if the request is over a quota, the
+// request isn't counted then.
+func Check(ns, account string) (*Usage, error) {
+	var quotas []*Quota
+	if len(account) > 0 {
+		q, err := cache.get(ns, account)
+		if err != nil {
+			return nil, err
+		}
+		if q != nil {
+			quotas = append(quotas, q)
+		}
+	}
+	q, err := cache.get(ns, "")
+	if err != nil {
+		return nil, err
+	}
+	if q != nil {
+		quotas = append(quotas, q)
+	}
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+	counts.once.Do(func() { go counts.run(FlushInterval) })
+
+	now := time.Now()
+	counts.Lock()
+	defer counts.Unlock()
+
+	var cs []*counter
+	for _, q := range quotas {
+		for _, w := range windows {
+			limit := w.limit(q)
+			if limit <= 0 {
+				continue
+			}
+			start := now.Truncate(w.length)
+			key := prefixCount + strings.Join([]string{q.Namespace, q.Account, w.name, strconv.FormatInt(start.Unix(), 10)}, "/")
+			c, ok := counts.counters[key]
+			if !ok {
+				c = &counter{key: key, reset: start.Add(w.length)}
+				counts.counters[key] = c
+			}
+			// the quota may have changed since the window started
+			c.limit = limit
+			if c.count+c.others >= c.limit {
+				return &Usage{Limit: c.limit, Remaining: 0, Reset: c.reset}, ErrExceeded
+			}
+			cs = append(cs, c)
+		}
+	}
+
+	var usage *Usage
+	for _, c := range cs {
+		c.count++
+		c.dirty = true
+		if remaining := c.limit - c.count - c.others; usage == nil || remaining < usage.Remaining {
+			usage = &Usage{Limit: c.limit, Remaining: remaining, Reset: c.reset}
+		}
+	}
+	return usage, nil
+}
+
+// run flushes the counters every interval
+func (c *counters) run(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for range t.C {
+		if err := c.flush(); err != nil {
+			logger.Errorf("Error flushing the quota counters: %v", err)
+		}
+	}
+}
+
+// flush writes the counts of this instance which changed to the store and reads those of the other
+// instances. The counters of the windows which have ended are dropped.
+func (c *counters) flush() error {
+	type snapshot struct {
+		key   string
+		count int64
+		reset time.Time
+		dirty bool
+	}
+
+	now := time.Now()
+	c.Lock()
+	snapshots := make([]snapshot, 0, len(c.counters))
+	for key, v := range c.counters {
+		if !now.Before(v.reset) {
+			delete(c.counters, key)
+			continue
+		}
+		snapshots = append(snapshots, snapshot{key, v.count, v.reset, v.dirty})
+		v.dirty = false
+	}
+	c.Unlock()
+
+	for _, s := range snapshots {
+		own := s.key + "/" + instance
+		if s.dirty {
+			rec := &store.Record{
+				Key:    own,
+				Value:  []byte(strconv.FormatInt(s.count, 10)),
+				Expiry: s.reset.Sub(now),
+			}
+			if err := store.DefaultStore.Write(rec, store.WriteTo(Database, Table)); err != nil {
+				c.markDirty(s.key)
+				return err
+			}
+		}
+
+		recs, err := store.DefaultStore.Read(s.key+"/", store.ReadFrom(Database, Table), store.ReadPrefix())
+		if err != nil && err != store.ErrNotFound {
+			return err
+		}
+		var others int64
+		for _, r := range recs {
+			if r.Key == own {
+				continue
+			}
+			n, _ := strconv.ParseInt(string(r.Value), 10, 64)
+			others += n
+		}
+
+		c.Lock()
+		if v, ok := c.counters[s.key]; ok {
+			v.others = others
+		}
+		c.Unlock()
+	}
+	return nil
+}
+
+// markDirty marks the counter to be written by the next flush
+func (c *counters) markDirty(key string) {
+	c.Lock()
+	if v, ok := c.counters[key]; ok {
+		v.dirty = true
+	}
+	c.Unlock()
+}
+
+type cached struct {
+	quota  *Quota
+	expiry time.Time
+}
+
+// quotaCache caches the quotas, including the lack of one, so requests don't read them each time
+type quotaCache struct {
+	sync.RWMutex
+	quotas map[string]*cached
+}
+
+var cache = &quotaCache{quotas: make(map[string]*cached)}
+
+func (c *quotaCache) get(ns, account string) (*Quota, error) {
+	key := quotaKey(ns, account)
+	c.RLock()
+	v, ok := c.quotas[key]
+	c.RUnlock()
+	if ok && time.Now().Before(v.expiry) {
+		return v.quota, nil
+	}
+
+	q, err := Get(ns, account)
+	if err == ErrNotFound {
+		q = nil
+	} else if err != nil {
+		return nil, err
+	}
+	c.set(ns, account, q)
+	return q, nil
+}
+
+func (c *quotaCache) set(ns, account string, q *Quota) {
+	c.Lock()
+	c.quotas[quotaKey(ns, account)] = &cached{quota: q, expiry: time.Now().Add(CacheTTL)}
+	c.Unlock()
+}
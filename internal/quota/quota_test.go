@@ -0,0 +1,156 @@
+package quota
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+// reset the quotas and counters of the tests, the counters are only flushed by the tests
+func reset(t *testing.T) {
+	s := store.DefaultStore
+	t.Cleanup(func() { store.DefaultStore = s })
+	store.DefaultStore = memory.NewStore()
+
+	FlushInterval = time.Hour
+	cache = &quotaCache{quotas: make(map[string]*cached)}
+	counts.Lock()
+	counts.counters = make(map[string]*counter)
+	counts.Unlock()
+}
+
+func TestQuota(t *testing.T) {
+	reset(t)
+
+	if u, err := Check("foo", "alice"); err != nil || u != nil {
+		t.Fatalf("Expected no usage without quotas, got %v %v", u, err)
+	}
+
+	if err := Set(&Quota{Namespace: "foo", Account: "alice", PerMinute: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(&Quota{Namespace: "foo", PerMinute: 10, PerDay: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := Check("foo", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Limit != 2 || u.Remaining != 1 {
+		t.Fatalf("Expected 1 of 2 requests remaining, got %v of %v", u.Remaining, u.Limit)
+	}
+	if _, err := Check("foo", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	u, err = Check("foo", "alice")
+	if err != ErrExceeded {
+		t.Fatalf("Expected the account quota to be exceeded, got %v", err)
+	}
+	if u.Remaining != 0 || len(u.Headers()["Retry-After"]) == 0 {
+		t.Fatalf("Expected no requests remaining and a retry, got %v", u.Headers())
+	}
+
+	// the namespace counted the two requests of alice
+	if u, err = Check("foo", "bob"); err != nil || u.Limit != 3 || u.Remaining != 0 {
+		t.Fatalf("Expected the last request of the namespace, got %+v %v", u, err)
+	}
+	if _, err = Check("foo", "bob"); err != ErrExceeded {
+		t.Fatalf("Expected the namespace quota to be exceeded, got %v", err)
+	}
+
+	qs, err := List("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qs) != 2 || qs[0].Account != "" || qs[1].Account != "alice" {
+		t.Fatalf("Expected the quotas of the namespace and alice, got %+v", qs)
+	}
+
+	if err := Delete("foo", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Check("foo", "bob"); err != nil {
+		t.Fatalf("Expected no quota for bob, got %v", err)
+	}
+	if err := Delete("foo", ""); err != ErrNotFound {
+		t.Fatalf("Expected %v, got %v", ErrNotFound, err)
+	}
+}
+
+func TestCheckConcurrent(t *testing.T) {
+	reset(t)
+
+	if err := Set(&Quota{Namespace: "foo", PerMinute: 50}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	var allowed int
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Check("foo", "alice"); err == nil {
+				mtx.Lock()
+				allowed++
+				mtx.Unlock()
+			} else if err != ErrExceeded {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed != 50 {
+		t.Fatalf("Expected 50 of the concurrent requests to be allowed, got %v", allowed)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	reset(t)
+
+	if err := Set(&Quota{Namespace: "foo", PerMinute: 10}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := Check("foo", "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// another instance counted 5 requests in the window
+	var key string
+	counts.Lock()
+	for k := range counts.counters {
+		key = k
+	}
+	counts.Unlock()
+	other := &store.Record{Key: key + "/other", Value: []byte("5")}
+	if err := store.DefaultStore.Write(other, store.WriteTo(Database, Table)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := counts.flush(); err != nil {
+		t.Fatal(err)
+	}
+	recs, err := store.DefaultStore.Read(key+"/"+instance, store.ReadFrom(Database, Table))
+	if err != nil || len(recs) != 1 || string(recs[0].Value) != strconv.Itoa(3) {
+		t.Fatalf("Expected the count of the instance to be written, got %v %v", recs, err)
+	}
+
+	u, err := Check("foo", "alice")
+	if err != nil || u.Remaining != 1 {
+		t.Fatalf("Expected the requests of both instances to be counted, got %+v %v", u, err)
+	}
+	if _, err := Check("foo", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Check("foo", "alice"); err != ErrExceeded {
+		t.Fatalf("Expected the quota to be exceeded, got %v", err)
+	}
+}
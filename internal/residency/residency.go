@@ -0,0 +1,66 @@
+// Package residency pins the data and services of namespaces to a region, for namespaces which
+// are required to keep their data in a region. The store and broker services refuse the requests
+// for the namespaces pinned to another region than their own, and the runtime places the services
+// of the namespaces on the nodes of the region.
+package residency
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	// Region the node is in, set with the region flag
+	Region string
+
+	// ErrWrongRegion is returned when a namespace is pinned to another region than the node's
+	ErrWrongRegion = errors.New("namespace is pinned to another region")
+
+	mtx  sync.RWMutex
+	pins = map[string]string{}
+)
+
+// Pin the namespace to the region, a blank region removes the pin
+func Pin(namespace, region string) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if len(region) == 0 {
+		delete(pins, namespace)
+		return
+	}
+	pins[namespace] = region
+}
+
+// Parse pins the namespaces to the regions of the values in the format namespace=region
+func Parse(values []string) error {
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return fmt.Errorf("invalid residency %q, expected namespace=region", v)
+		}
+		Pin(parts[0], parts[1])
+	}
+	return nil
+}
+
+// Lookup returns the region the namespace is pinned to
+func Lookup(namespace string) (string, bool) {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	region, ok := pins[namespace]
+	return region, ok
+}
+
+// Check returns ErrWrongRegion if the namespace is pinned to another region than the node's. The
+// namespaces which aren't pinned can be served in any region.
+func Check(namespace string) error {
+	region, ok := Lookup(namespace)
+	if !ok || region == Region {
+		return nil
+	}
+	return ErrWrongRegion
+}
@@ -0,0 +1,36 @@
+package residency
+
+import "testing"
+
+func TestResidency(t *testing.T) {
+	defer func(r string) { Region = r }(Region)
+	Region = "eu-west-1"
+
+	if err := Parse([]string{"foo=eu-west-1", "bar=us-east-1"}); err != nil {
+		t.Fatal(err)
+	}
+	defer Pin("foo", "")
+	defer Pin("bar", "")
+
+	if err := Check("foo"); err != nil {
+		t.Fatalf("Expected the namespace pinned to the region of the node to be allowed, got %v", err)
+	}
+	if err := Check("bar"); err != ErrWrongRegion {
+		t.Fatalf("Expected the namespace pinned to another region to be refused, got %v", err)
+	}
+	if err := Check("baz"); err != nil {
+		t.Fatalf("Expected the namespace which isn't pinned to be allowed, got %v", err)
+	}
+
+	// the pins are removed with a blank region
+	Pin("bar", "")
+	if _, ok := Lookup("bar"); ok {
+		t.Fatalf("Expected the pin to be removed")
+	}
+
+	for _, v := range []string{"foo", "=eu-west-1", "foo="} {
+		if err := Parse([]string{v}); err == nil {
+			t.Fatalf("Expected %q to be invalid", v)
+		}
+	}
+}
@@ -22,6 +22,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// StreamSize is the number of entries a stream can fall behind before it's closed
+var StreamSize = 128
+
 // Buffer is ring buffer
 type Buffer struct {
 	size int
@@ -64,13 +67,21 @@ func (b *Buffer) Put(v interface{}) {
 		b.vals = b.vals[1:]
 	}
 
-	// send to every stream
+	// send to every stream, the streams which have fallen behind are closed rather than blocking
+	// the writer
 	for _, stream := range b.streams {
 		select {
 		case <-stream.Stop:
 			delete(b.streams, stream.Id)
 			close(stream.Entries)
+			continue
+		default:
+		}
+		select {
 		case stream.Entries <- entry:
+		default:
+			delete(b.streams, stream.Id)
+			close(stream.Entries)
 		}
 	}
 }
@@ -121,12 +132,13 @@ func (b *Buffer) Since(t time.Time) []*Entry {
 }
 
 // Stream logs from the buffer
-// Close the channel when you want to stop
+// Close the channel when you want to stop. The entries channel is closed if
+// the stream falls more than StreamSize entries behind.
 func (b *Buffer) Stream() (<-chan *Entry, chan bool) {
 	b.Lock()
 	defer b.Unlock()
 
-	entries := make(chan *Entry, 128)
+	entries := make(chan *Entry, StreamSize)
 	id := uuid.New().String()
 	stop := make(chan bool)
 
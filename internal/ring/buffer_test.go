@@ -91,3 +91,33 @@ func TestBuffer(t *testing.T) {
 		t.Fatalf("expected value 100 got %v", v[0])
 	}
 }
+
+func TestBufferSlowStream(t *testing.T) {
+	b := New(10)
+
+	entries, stop := b.Stream()
+	defer close(stop)
+
+	// the writer isn't blocked by a stream which isn't read
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < StreamSize+10; i++ {
+			b.Put(i)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the writer not to be blocked by the stream")
+	}
+
+	// the stream has the entries until it fell behind and is then closed
+	var n int
+	for range entries {
+		n++
+	}
+	if n != StreamSize {
+		t.Fatalf("expected %d entries before the stream was closed, got %d", StreamSize, n)
+	}
+}
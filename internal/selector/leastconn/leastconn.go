@@ -0,0 +1,84 @@
+// Package leastconn is a selector which prefers the routes with the fewest outstanding requests
+package leastconn
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/micro/micro/v3/internal/selector"
+)
+
+// NewSelector returns a selector which picks the route with the fewest outstanding requests.
+// A request is outstanding from when its route is selected until its result is recorded.
+func NewSelector(opts ...selector.Option) selector.Selector {
+	return &leastconn{
+		outstanding: make(map[string]int),
+	}
+}
+
+type leastconn struct {
+	sync.Mutex
+	// outstanding requests by route
+	outstanding map[string]int
+}
+
+func (l *leastconn) Select(routes []string, opts ...selector.SelectOption) (selector.Next, error) {
+	if len(routes) == 0 {
+		return nil, selector.ErrNoneAvailable
+	}
+
+	// the routes already returned, retries prefer the routes not yet tried
+	tried := make(map[string]bool, len(routes))
+
+	return func() string {
+		l.Lock()
+		defer l.Unlock()
+
+		if len(tried) == len(routes) {
+			tried = make(map[string]bool, len(routes))
+		}
+
+		// start at a random offset so ties are spread across the routes
+		offset := rand.Intn(len(routes))
+
+		var route string
+		min := -1
+		for i := range routes {
+			r := routes[(offset+i)%len(routes)]
+			if tried[r] {
+				continue
+			}
+			if n := l.outstanding[r]; min < 0 || n < min {
+				route, min = r, n
+			}
+		}
+
+		tried[route] = true
+		l.outstanding[route]++
+		return route
+	}, nil
+}
+
+func (l *leastconn) Record(addr string, err error) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.outstanding[addr] <= 1 {
+		delete(l.outstanding, addr)
+	} else {
+		l.outstanding[addr]--
+	}
+	return nil
+}
+
+func (l *leastconn) Reset() error {
+	l.Lock()
+	defer l.Unlock()
+
+	l.outstanding = make(map[string]int)
+	return nil
+}
+
+func (l *leastconn) String() string {
+	return "leastconn"
+}
@@ -0,0 +1,36 @@
+package leastconn
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/internal/selector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeastConn(t *testing.T) {
+	selector.Tests(t, NewSelector())
+
+	r1 := "127.0.0.1:8000"
+	r2 := "127.0.0.1:8001"
+
+	sel := NewSelector()
+
+	// r1 has an outstanding request so r2 should be chosen
+	next, err := sel.Select([]string{r1})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, r1, next(), "Expected route to be r1")
+
+	next, err = sel.Select([]string{r1, r2})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, r2, next(), "Expected route to be r2")
+	// retries prefer the routes not yet tried
+	assert.Equal(t, r1, next(), "Expected retry to be r1")
+
+	// once the requests complete r1 has the fewest outstanding
+	sel.Record(r1, nil)
+	sel.Record(r1, nil)
+
+	next, err = sel.Select([]string{r1, r2})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, r1, next(), "Expected route to be r1")
+}
@@ -0,0 +1,94 @@
+// Package locality is a selector which prefers the routes in its own zone
+package locality
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/micro/micro/v3/internal/selector"
+)
+
+// NewSelector returns a selector which prefers the routes in the zone set by selector.Zone,
+// falling back to the routes in other zones when retrying. Routes are shuffled within each zone.
+func NewSelector(opts ...selector.Option) selector.Selector {
+	var options selector.Options
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return &locality{
+		zone:  options.Zone,
+		zones: make(map[string]string),
+	}
+}
+
+type locality struct {
+	// zone of the selector
+	zone string
+
+	sync.RWMutex
+	// zones of the route addresses
+	zones map[string]string
+}
+
+func (l *locality) Locate(addr, zone string) {
+	l.Lock()
+	defer l.Unlock()
+
+	if len(zone) == 0 {
+		delete(l.zones, addr)
+		return
+	}
+	l.zones[addr] = zone
+}
+
+func (l *locality) Select(routes []string, opts ...selector.SelectOption) (selector.Next, error) {
+	if len(routes) == 0 {
+		return nil, selector.ErrNoneAvailable
+	}
+
+	// split the routes into those in our zone and the rest
+	var local, remote []string
+
+	l.RLock()
+	for _, r := range routes {
+		if l.zones[r] == l.zone {
+			local = append(local, r)
+		} else {
+			remote = append(remote, r)
+		}
+	}
+	l.RUnlock()
+
+	shuffle(local)
+	shuffle(remote)
+	ordered := append(local, remote...)
+
+	var i int
+
+	return func() string {
+		route := ordered[i%len(ordered)]
+		i++
+		return route
+	}, nil
+}
+
+func (l *locality) Record(addr string, err error) error { return nil }
+
+func (l *locality) Reset() error {
+	l.Lock()
+	defer l.Unlock()
+
+	l.zones = make(map[string]string)
+	return nil
+}
+
+func (l *locality) String() string {
+	return "locality"
+}
+
+func shuffle(routes []string) {
+	rand.Shuffle(len(routes), func(i, j int) {
+		routes[i], routes[j] = routes[j], routes[i]
+	})
+}
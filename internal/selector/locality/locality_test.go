@@ -0,0 +1,39 @@
+package locality
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/internal/selector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocality(t *testing.T) {
+	selector.Tests(t, NewSelector())
+
+	r1 := "127.0.0.1:8000"
+	r2 := "127.0.0.1:8001"
+	r3 := "127.0.0.1:8002"
+
+	sel := NewSelector(selector.Zone("eu-west-1a"))
+	loc := sel.(selector.Locator)
+	loc.Locate(r1, "eu-west-1b")
+	loc.Locate(r2, "eu-west-1a")
+	loc.Locate(r3, "eu-west-1a")
+
+	for i := 0; i < 10; i++ {
+		next, err := sel.Select([]string{r1, r2, r3})
+		assert.Nil(t, err, "Error should be nil")
+
+		// the local routes are tried before falling back to the other zone
+		n1, n2, n3 := next(), next(), next()
+		assert.Contains(t, []string{r2, r3}, n1, "Expected a local route")
+		assert.Contains(t, []string{r2, r3}, n2, "Expected a local route")
+		assert.NotEqual(t, n1, n2, "Expected a different local route on retry")
+		assert.Equal(t, r1, n3, "Expected the remote route last")
+	}
+
+	// with no local routes the others are used
+	next, err := sel.Select([]string{r1})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, r1, next(), "Expected route to be r1")
+}
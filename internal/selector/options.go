@@ -15,11 +15,21 @@
 package selector
 
 // Options used to configure a selector
-type Options struct{}
+type Options struct {
+	// Zone the selector is running in, used to prefer routes in the same zone
+	Zone string
+}
 
 // Option updates the options
 type Option func(*Options)
 
+// Zone sets the zone the selector is running in
+func Zone(z string) Option {
+	return func(o *Options) {
+		o.Zone = z
+	}
+}
+
 // SelectOptions used to configure selection
 type SelectOptions struct{}
 
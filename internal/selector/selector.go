@@ -36,5 +36,12 @@ type Selector interface {
 	String() string
 }
 
+// Locator is implemented by selectors which select routes by the zone they're in. The zones
+// of the routes are passed to the selector before selecting from them.
+type Locator interface {
+	// Locate sets the zone of the route address
+	Locate(addr, zone string)
+}
+
 // Next returns the next node
 type Next func() string
@@ -25,3 +25,8 @@ func Shutdown() []os.Signal {
 		syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGKILL,
 	}
 }
+
+// Reload returns the signals that are watched for to reload the flags of services.
+func Reload() []os.Signal {
+	return []os.Signal{syscall.SIGHUP}
+}
@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"github.com/micro/micro/v3/plugin"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	plugin.Register(Plugin())
+}
+
+// Plugin records the command and service run when enabled with --telemetry
+func Plugin() plugin.Plugin {
+	return plugin.NewPlugin(
+		plugin.WithName("telemetry"),
+		plugin.WithInit(func(c *cli.Context) error {
+			// only do if enabled
+			if !c.Bool("telemetry") {
+				return nil
+			}
+			Enable()
+
+			if len(c.App.Version) > 0 {
+				Version = c.App.Version
+			}
+
+			// failing to record shouldn't fail the command
+			command := c.Args().First()
+			if err := Command(command); err != nil {
+				logger.Debugf("Error recording telemetry: %v", err)
+				return nil
+			}
+
+			// the service run e.g. micro service api
+			service := c.String("service_name")
+			if command == "service" && len(c.Args().Get(1)) > 0 {
+				service = c.Args().Get(1)
+			}
+			if err := Service(service); err != nil {
+				logger.Debugf("Error recording telemetry: %v", err)
+			}
+			return nil
+		}),
+	)
+}
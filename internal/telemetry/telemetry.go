@@ -0,0 +1,189 @@
+// Package telemetry aggregates anonymous usage locally when enabled with --telemetry. Nothing is
+// sent anywhere, the report is written to the micro dir and dumped with micro report.
+package telemetry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/user"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// Report of the usage aggregated locally
+type Report struct {
+	// Version of micro which last updated the report
+	Version string `json:"version"`
+	// OS and Arch micro runs on
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	// Since is when the report was started
+	Since time.Time `json:"since"`
+	// Updated is when the report was last updated
+	Updated time.Time `json:"updated"`
+	// Commands is the number of times each command was run
+	Commands map[string]uint64 `json:"commands"`
+	// Services is the number of distinct services run, their names are hashed
+	Services map[string]bool `json:"services"`
+	// Errors is the number of errors of each class e.g. 404 Not Found
+	Errors map[string]uint64 `json:"errors"`
+}
+
+var (
+	// Path of the report, defaults to telemetry.json in the micro dir
+	Path = filepath.Join(user.Dir, "telemetry.json")
+	// Version of micro set in the report
+	Version = "latest"
+
+	mtx     sync.Mutex
+	enabled bool
+)
+
+// Enable recording to the report, it's off by default
+func Enable() {
+	mtx.Lock()
+	enabled = true
+	mtx.Unlock()
+}
+
+// Enabled returns true if recording is enabled
+func Enabled() bool {
+	mtx.Lock()
+	defer mtx.Unlock()
+	return enabled
+}
+
+// Command records a run of the command
+func Command(name string) error {
+	if len(name) == 0 {
+		return nil
+	}
+	return update(func(r *Report) {
+		r.Commands[name]++
+	})
+}
+
+// Service records a run of the service. Only a hash of the name is stored.
+func Service(name string) error {
+	if len(name) == 0 {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(name))
+	return update(func(r *Report) {
+		r.Services[hex.EncodeToString(sum[:8])] = true
+	})
+}
+
+// Error records the class of the error, the message isn't stored
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	class := Class(err)
+	return update(func(r *Report) {
+		r.Errors[class]++
+	})
+}
+
+// Class returns the class of an error, the status of micro errors and the exit code of cli
+// errors, otherwise unknown
+func Class(err error) string {
+	if merr, ok := err.(*errors.Error); ok && merr.Code > 0 {
+		return fmt.Sprintf("%d %s", merr.Code, http.StatusText(int(merr.Code)))
+	}
+	if cerr, ok := err.(cli.ExitCoder); ok {
+		return fmt.Sprintf("exit %d", cerr.ExitCode())
+	}
+	return "unknown"
+}
+
+// Load the report, a new report is returned if there isn't one
+func Load() (*Report, error) {
+	r := &Report{}
+
+	b, err := ioutil.ReadFile(Path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	} else if err == nil {
+		if err := json.Unmarshal(b, r); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Since.IsZero() {
+		r.Since = time.Now()
+	}
+	if r.Commands == nil {
+		r.Commands = make(map[string]uint64)
+	}
+	if r.Services == nil {
+		r.Services = make(map[string]bool)
+	}
+	if r.Errors == nil {
+		r.Errors = make(map[string]uint64)
+	}
+	return r, nil
+}
+
+// Reset deletes the report
+func Reset() error {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if err := os.Remove(Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// update the report if enabled, it's written to a temp file first so it's never partially written
+func update(fn func(r *Report)) error {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if !enabled {
+		return nil
+	}
+
+	r, err := Load()
+	if err != nil {
+		return err
+	}
+	fn(r)
+	r.Version = Version
+	r.OS = runtime.GOOS
+	r.Arch = runtime.GOARCH
+	r.Updated = time.Now()
+
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(Path), 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(Path), "telemetry-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), Path)
+}
@@ -0,0 +1,82 @@
+package telemetry
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	merrors "github.com/micro/micro/v3/service/errors"
+	"github.com/urfave/cli/v2"
+)
+
+func TestTelemetry(t *testing.T) {
+	Path = filepath.Join(t.TempDir(), "telemetry.json")
+
+	// nothing is recorded unless enabled
+	if err := Command("run"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Commands) > 0 {
+		t.Fatalf("Expected nothing recorded, got %v", r.Commands)
+	}
+
+	Enable()
+	defer func() { enabled = false }()
+
+	Command("run")
+	Command("run")
+	Command("status")
+	Service("helloworld")
+	Service("helloworld")
+	Service("example")
+	Error(merrors.NotFound("helloworld", "not found"))
+	Error(errors.New("boom"))
+
+	r, err = Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Commands["run"] != 2 || r.Commands["status"] != 1 {
+		t.Fatalf("Unexpected commands %v", r.Commands)
+	}
+	if len(r.Services) != 2 {
+		t.Fatalf("Expected 2 services, got %v", r.Services)
+	}
+	if r.Services["helloworld"] {
+		t.Fatal("Expected the service names to be hashed")
+	}
+	if r.Errors["404 Not Found"] != 1 || r.Errors["unknown"] != 1 {
+		t.Fatalf("Unexpected errors %v", r.Errors)
+	}
+
+	if err := Reset(); err != nil {
+		t.Fatal(err)
+	}
+	r, err = Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Commands) > 0 || len(r.Services) > 0 || len(r.Errors) > 0 {
+		t.Fatalf("Expected the report to be reset, got %+v", r)
+	}
+}
+
+func TestClass(t *testing.T) {
+	tests := []struct {
+		err   error
+		class string
+	}{
+		{merrors.InternalServerError("foo", "bar"), "500 Internal Server Error"},
+		{cli.Exit("failed", 4), "exit 4"},
+		{errors.New("failed"), "unknown"},
+	}
+	for _, tc := range tests {
+		if c := Class(tc.err); c != tc.class {
+			t.Errorf("Expected %v, got %v", tc.class, c)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeCertificate generates a self signed certificate and writes it and its key to the dir
+func writeCertificate(t *testing.T, dir, name string) (string, string) {
+	cert, err := Certificate("127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key})
+	if err := ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// handshake connects a client and server with the configs, returning the errors of both sides
+func handshake(t *testing.T, client, server *tls.Config) (error, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	errc := make(chan error, 1)
+	go func() {
+		s, err := l.Accept()
+		if err != nil {
+			errc <- err
+			return
+		}
+		errc <- tls.Server(s, server).Handshake()
+		s.Close()
+	}()
+
+	c, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cerr := tls.Client(c, client).Handshake()
+	c.Close()
+	return cerr, <-errc
+}
+
+func TestMutualConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the self signed certificate is its own CA
+	cert, key := writeCertificate(t, dir, "node")
+	otherCert, otherKey := writeCertificate(t, dir, "other")
+
+	node, err := MutualConfig(cert, key, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cerr, serr := handshake(t, node, node); cerr != nil || serr != nil {
+		t.Fatalf("Expected the peers with the same CA to connect, got %v %v", cerr, serr)
+	}
+
+	// a peer with a certificate of another CA is rejected by either side
+	other, err := MutualConfig(otherCert, otherKey, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, serr := handshake(t, other, node); serr == nil {
+		t.Fatal("Expected the server to reject the client certificate")
+	}
+	if cerr, _ := handshake(t, node, other); cerr == nil {
+		t.Fatal("Expected the client to reject the server certificate")
+	}
+
+	// a peer without a certificate is rejected
+	if _, serr := handshake(t, &tls.Config{InsecureSkipVerify: true}, node); serr == nil {
+		t.Fatal("Expected the server to require a client certificate")
+	}
+
+	if _, err := MutualConfig(cert, key, ""); err == nil {
+		t.Fatal("Expected an error without the CA")
+	}
+}
@@ -2,13 +2,22 @@ package wrapper
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"reflect"
+	rdebug "runtime/debug"
 	"strings"
+	"sync"
 	"time"
 
 	inauth "github.com/micro/micro/v3/internal/auth"
 	"github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/debug/capture"
+	"github.com/micro/micro/v3/internal/debug/chaos"
 	"github.com/micro/micro/v3/internal/debug/trace"
+	"github.com/micro/micro/v3/internal/idempotency"
+	"github.com/micro/micro/v3/internal/overload"
 	"github.com/micro/micro/v3/service/auth"
 	"github.com/micro/micro/v3/service/client"
 	"github.com/micro/micro/v3/service/client/cache"
@@ -17,7 +26,9 @@ import (
 	"github.com/micro/micro/v3/service/errors"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/metrics"
+	"github.com/micro/micro/v3/service/registry"
 	"github.com/micro/micro/v3/service/server"
+	"github.com/micro/micro/v3/service/store"
 )
 
 type authWrapper struct {
@@ -199,9 +210,10 @@ func HandlerStats() server.HandlerWrapper {
 		// return a function that returns a function
 		return func(ctx context.Context, req server.Request, rsp interface{}) error {
 			// execute the handler
+			started := time.Now()
 			err := h(ctx, req, rsp)
 			// record the stats
-			debug.DefaultStats.Record(err)
+			debug.DefaultStats.RecordRequest(req.Endpoint(), time.Since(started), err)
 			// return the error
 			return err
 		}
@@ -221,9 +233,19 @@ func (c *traceWrapper) Call(ctx context.Context, req client.Request, rsp interfa
 		s.Metadata["error"] = err.Error()
 	}
 
+	// record the call in detail if the request is being captured
+	capturing := capture.Capturing(ctx)
+	if capturing {
+		capture.Annotate(s, req.Body(), rsp)
+	}
+
 	// finish the trace
 	debug.DefaultTracer.Finish(s)
 
+	if capturing {
+		capture.Publish(newCtx, s)
+	}
+
 	return err
 }
 
@@ -254,9 +276,19 @@ func TraceHandler() server.HandlerWrapper {
 				s.Metadata["error"] = err.Error()
 			}
 
+			// record the request in detail if it's being captured
+			capturing := capture.Capturing(ctx)
+			if capturing {
+				capture.Annotate(s, req.Body(), rsp)
+			}
+
 			// finish
 			debug.DefaultTracer.Finish(s)
 
+			if capturing {
+				capture.Publish(ctx, s)
+			}
+
 			return err
 		}
 	}
@@ -268,7 +300,8 @@ type cacheWrapper struct {
 }
 
 // Call executes the request. If the CacheExpiry option was set, the response will be cached using
-// a hash of the metadata and request as the key.
+// a hash of the metadata and request as the key. Otherwise the response is cached for as long as
+// the cache control header of the response allows.
 func (c *cacheWrapper) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
 	// parse the options
 	var options client.CallOptions
@@ -281,30 +314,45 @@ func (c *cacheWrapper) Call(ctx context.Context, req client.Request, rsp interfa
 		return c.Client.Call(ctx, req, rsp, opts...)
 	}
 
-	cacheOpts, ok := cache.GetOptions(options.Context)
-	if !ok {
-		return c.Client.Call(ctx, req, rsp, opts...)
-	}
-
-	// if the cache expiry is not set, execute the call without the cache
-	if cacheOpts.Expiry == 0 || rsp == nil {
+	// the response must be a pointer the cached response can be assigned to
+	if rv := reflect.ValueOf(rsp); rv.Kind() != reflect.Ptr || rv.IsNil() {
 		return c.Client.Call(ctx, req, rsp, opts...)
 	}
 
 	// check to see if there is a response cached, if there is assign it
-	if r, ok := c.Cache.Get(ctx, req); ok {
+	if r, expiry, ok := c.Cache.GetWithExpiry(ctx, req); ok && reflect.TypeOf(r) == reflect.TypeOf(rsp) {
 		val := reflect.ValueOf(rsp).Elem()
 		val.Set(reflect.ValueOf(r).Elem())
+		if options.ResponseHeader != nil && !expiry.IsZero() {
+			options.ResponseHeader[cache.CacheControlHeader] = fmt.Sprintf("max-age=%d", int64(time.Until(expiry)/time.Second))
+		}
+		return nil
+	}
+
+	// the expiry set by the caller takes precedence over the one set by the service
+	if cacheOpts, ok := cache.GetOptions(options.Context); ok && cacheOpts.Expiry != 0 {
+		// don't cache the result if there was an error
+		if err := c.Client.Call(ctx, req, rsp, opts...); err != nil {
+			return err
+		}
+
+		// set the result in the cache
+		c.Cache.Set(ctx, req, rsp, cacheOpts.Expiry)
 		return nil
 	}
 
-	// don't cache the result if there was an error
+	// get the header of the response to check if it can be cached
+	header := options.ResponseHeader
+	if header == nil {
+		header = make(map[string]string)
+		opts = append(opts, client.WithResponseHeader(header))
+	}
 	if err := c.Client.Call(ctx, req, rsp, opts...); err != nil {
 		return err
 	}
-
-	// set the result in the cache
-	c.Cache.Set(ctx, req, rsp, cacheOpts.Expiry)
+	if expiry, ok := cache.MaxAge(header); ok {
+		c.Cache.Set(ctx, req, rsp, expiry)
+	}
 	return nil
 }
 
@@ -353,3 +401,315 @@ func MetricsHandler() server.HandlerWrapper {
 		}
 	}
 }
+
+// ChaosHandler wraps a server handler to inject the faults of chaos experiments
+func ChaosHandler() server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			// the debug endpoints are left alone so the service can still be debugged
+			if strings.HasPrefix(req.Endpoint(), "Debug.") {
+				return h(ctx, req, rsp)
+			}
+			if err := chaos.DefaultInjector.Inject(ctx, req.Service(), req.Endpoint()); err != nil {
+				return err
+			}
+			return h(ctx, req, rsp)
+		}
+	}
+}
+
+// DeadlineHandler wraps a server handler to enforce the deadline of the requests, the caller gets
+// a timeout once the deadline passes rather than waiting on the handler. The context of the handler
+// is cancelled at the deadline so it can give up on its work and the calls it makes.
+func DeadlineHandler() server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if _, ok := ctx.Deadline(); !ok || req.Stream() {
+				return h(ctx, req, rsp)
+			}
+			if ctx.Err() != nil {
+				return errors.Timeout(req.Service(), "Deadline exceeded before the request was handled")
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				// the handler no longer runs on the goroutine of the server which recovers panics
+				defer func() {
+					if r := recover(); r != nil {
+						logger.Errorf("panic recovered: %v\n%s", r, rdebug.Stack())
+						done <- errors.InternalServerError(req.Service(), "panic recovered: %v", r)
+					}
+				}()
+				done <- h(ctx, req, rsp)
+			}()
+
+			select {
+			case err := <-done:
+				return err
+			case <-ctx.Done():
+				return errors.Timeout(req.Service(), "Deadline exceeded")
+			}
+		}
+	}
+}
+
+// IdempotencyExpiry is how long the responses of requests with an idempotency key are kept
+var IdempotencyExpiry = time.Hour * 24
+
+// IdempotencyHandler wraps a server handler so requests with the Idempotency-Key header are only
+// processed once, retries with the same key get the response of the first request. The responses
+// are kept in the store of the service for the IdempotencyExpiry.
+func IdempotencyHandler() server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			idemKey, ok := metadata.Get(ctx, "Idempotency-Key")
+			if !ok || len(idemKey) == 0 || req.Stream() || store.DefaultStore == nil {
+				return h(ctx, req, rsp)
+			}
+
+			// the keys are scoped to the caller so they can't read the responses of other accounts
+			var caller string
+			if acc, ok := auth.AccountFromContext(ctx); ok {
+				caller = acc.Issuer + ":" + acc.ID
+			}
+			key := strings.Join([]string{"idempotency", req.Endpoint(), caller, idemKey}, "/")
+
+			body, err := json.Marshal(req.Body())
+			if err != nil {
+				return h(ctx, req, rsp)
+			}
+			hash := idempotency.Hash(body)
+
+			// replay the response of a previous request with the key
+			prev, err := idempotency.Begin(key, hash)
+			switch err {
+			case nil:
+			case idempotency.ErrMismatch:
+				return errors.Conflict(req.Service(), "Idempotency key %v was used for a different request", idemKey)
+			case idempotency.ErrInProgress:
+				return errors.Conflict(req.Service(), "A request with the idempotency key %v is in progress", idemKey)
+			default:
+				return errors.InternalServerError(req.Service(), "Error reading idempotent response: %v", err)
+			}
+			if prev != nil {
+				return json.Unmarshal(prev.Body, rsp)
+			}
+
+			// failed requests aren't recorded so they can be retried
+			recorded := false
+			defer func() {
+				if recorded {
+					return
+				}
+				if err := idempotency.Abort(key); err != nil {
+					logger.Errorf("Error deleting idempotent request: %v", err)
+				}
+			}()
+			if err := h(ctx, req, rsp); err != nil {
+				return err
+			}
+
+			b, err := json.Marshal(rsp)
+			if err != nil {
+				logger.Errorf("Error marshaling idempotent response: %v", err)
+				return nil
+			}
+			recorded = true
+			if err := idempotency.Complete(key, &idempotency.Response{Hash: hash, Body: b}, IdempotencyExpiry); err != nil {
+				logger.Errorf("Error writing idempotent response: %v", err)
+			}
+			return nil
+		}
+	}
+}
+
+// PriorityWait is how long bulk requests wait for a slot before being rejected when the limit of
+// the PriorityHandler is reached
+var PriorityWait = time.Millisecond * 100
+
+// controlPlane returns true if the request is a control-plane operation made by the runtime, the
+// cli or an admin. Streams such as watches are always bulk traffic.
+func controlPlane(ctx context.Context, req server.Request) bool {
+	if req.Stream() {
+		return false
+	}
+	acc, ok := auth.AccountFromContext(ctx)
+	if ok {
+		for _, s := range acc.Scopes {
+			if s == "admin" {
+				return true
+			}
+		}
+		// any caller can set the header so it's only trusted for the accounts of the server
+		if acc.Issuer != namespace.DefaultNamespace {
+			return false
+		}
+	}
+	// the cli doesn't name its server so it calls as the default
+	from, _ := metadata.Get(ctx, HeaderPrefix+"From-Service")
+	return from == "" || from == server.DefaultName || from == "runtime"
+}
+
+// priorityLimiter limits the concurrent bulk requests, a caller can hold at most half the slots
+// so others still get a share
+type priorityLimiter struct {
+	sync.Mutex
+	slots     chan struct{}
+	perCaller int
+	callers   map[string]int
+}
+
+func (p *priorityLimiter) acquire(caller string) bool {
+	p.Lock()
+	if p.callers[caller] >= p.perCaller {
+		p.Unlock()
+		return false
+	}
+	p.callers[caller]++
+	p.Unlock()
+
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-time.After(PriorityWait):
+		p.done(caller)
+		return false
+	}
+}
+
+func (p *priorityLimiter) release(caller string) {
+	<-p.slots
+	p.done(caller)
+}
+
+func (p *priorityLimiter) done(caller string) {
+	p.Lock()
+	if p.callers[caller]--; p.callers[caller] <= 0 {
+		delete(p.callers, caller)
+	}
+	p.Unlock()
+}
+
+// PriorityHandler wraps a server handler to serve at most limit bulk requests concurrently, such as
+// the watches and lookups of services, while the control-plane requests of the runtime, the cli
+// and admins are always served so they aren't locked out when the service is overloaded. Bulk
+// requests over the limit are rejected with a 503 for the callers to retry.
+func PriorityHandler(limit int) server.HandlerWrapper {
+	perCaller := limit / 2
+	if perCaller < 1 {
+		perCaller = 1
+	}
+	p := &priorityLimiter{
+		slots:     make(chan struct{}, limit),
+		perCaller: perCaller,
+		callers:   make(map[string]int),
+	}
+
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if controlPlane(ctx, req) {
+				return h(ctx, req, rsp)
+			}
+
+			caller, _ := metadata.Get(ctx, HeaderPrefix+"From-Service")
+			if acc, ok := auth.AccountFromContext(ctx); ok {
+				caller += "/" + acc.Issuer + ":" + acc.ID
+			}
+			if !p.acquire(caller) {
+				return errors.ServiceUnavailable(req.Service(), "Too many requests, retry later")
+			}
+			defer p.release(caller)
+			return h(ctx, req, rsp)
+		}
+	}
+}
+
+// LimitHandler wraps a server handler to serve the requests within the limits of the limiter,
+// the requests it sheds are rejected with a 503 for the callers to retry
+func LimitHandler(l *overload.Limiter) server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if err := l.Acquire(ctx); err == overload.ErrOverloaded {
+				return errors.ServiceUnavailable(req.Service(), "Overloaded, retry later")
+			} else if err != nil {
+				return errors.Timeout(req.Service(), "%v", err)
+			}
+			defer l.Release()
+			return h(ctx, req, rsp)
+		}
+	}
+}
+
+// DeprecationRefresh is how often the DeprecationHandler looks up the endpoints of the service
+// marked deprecated in the registry
+var DeprecationRefresh = time.Minute
+
+// DeprecationHandler wraps a server handler to signal the calls to the deprecated endpoints of the
+// service, the responses get the Deprecation and Sunset headers and the remaining callers are
+// counted by the service.deprecated metric so the endpoints can be retired once they're gone
+func DeprecationHandler() server.HandlerWrapper {
+	var (
+		mtx     sync.Mutex
+		service *registry.Service
+		updated time.Time
+	)
+
+	// lookup returns the record of the service in the registry, refreshed periodically
+	lookup := func(opts server.Options) *registry.Service {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		if time.Since(updated) < DeprecationRefresh {
+			return service
+		}
+		updated = time.Now()
+
+		services, err := opts.Registry.GetService(opts.Name)
+		if err != nil || len(services) == 0 {
+			service = nil
+			return nil
+		}
+		for _, s := range services {
+			if s.Version == opts.Version {
+				service = s
+				return s
+			}
+		}
+		service = services[0]
+		return service
+	}
+
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if strings.HasPrefix(req.Endpoint(), "Debug.") {
+				return h(ctx, req, rsp)
+			}
+
+			opts := server.DefaultServer.Options()
+			sunset, ok := registry.Deprecated(opts.Metadata)
+			if !ok && opts.Registry != nil {
+				if s := lookup(opts); s != nil {
+					sunset, ok = registry.EndpointDeprecated(s, req.Endpoint())
+				}
+			}
+			if !ok {
+				return h(ctx, req, rsp)
+			}
+
+			from, _ := metadata.Get(ctx, HeaderPrefix+"From-Service")
+			if len(from) == 0 {
+				from = "unknown"
+			}
+			metrics.Count("service.deprecated", 1, metrics.Tags{
+				"endpoint": req.Endpoint(),
+				"caller":   from,
+			})
+
+			server.SetResponseHeader(ctx, server.DeprecationHeader, "true")
+			if !sunset.IsZero() {
+				server.SetResponseHeader(ctx, server.SunsetHeader, sunset.UTC().Format(http.TimeFormat))
+			}
+			return h(ctx, req, rsp)
+		}
+	}
+}
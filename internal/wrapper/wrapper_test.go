@@ -0,0 +1,329 @@
+package wrapper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/metrics"
+	"github.com/micro/micro/v3/service/registry"
+	rmemory "github.com/micro/micro/v3/service/registry/memory"
+	"github.com/micro/micro/v3/service/server"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+type testRequest struct {
+	server.Request
+	body interface{}
+}
+
+func (r *testRequest) Service() string   { return "test" }
+func (r *testRequest) Endpoint() string  { return "Test.Create" }
+func (r *testRequest) Body() interface{} { return r.body }
+func (r *testRequest) Stream() bool      { return false }
+
+type testStream struct {
+	testRequest
+}
+
+func (r *testStream) Endpoint() string { return "Test.Watch" }
+func (r *testStream) Stream() bool     { return true }
+
+type testMessage struct {
+	Value string `json:"value"`
+}
+
+func TestIdempotencyHandler(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+
+	var calls int
+	h := IdempotencyHandler()(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		calls++
+		rsp.(*testMessage).Value = req.Body().(*testMessage).Value + "-created"
+		return nil
+	})
+
+	call := func(ctx context.Context, value string) (*testMessage, error) {
+		rsp := &testMessage{}
+		err := h(ctx, &testRequest{body: &testMessage{Value: value}}, rsp)
+		return rsp, err
+	}
+
+	// requests without a key are always processed
+	call(context.TODO(), "foo")
+	call(context.TODO(), "foo")
+	if calls != 2 {
+		t.Fatalf("Expected 2 calls without a key, got %v", calls)
+	}
+
+	// retries with the key get the first response
+	ctx := metadata.Set(context.TODO(), "Idempotency-Key", "abc")
+	for i := 0; i < 3; i++ {
+		rsp, err := call(ctx, "bar")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if rsp.Value != "bar-created" {
+			t.Fatalf("Expected the response bar-created, got %v", rsp.Value)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("Expected 3 calls with retries, got %v", calls)
+	}
+
+	// reusing the key for a different request is a conflict
+	_, err := call(ctx, "baz")
+	if merr, ok := err.(*errors.Error); !ok || merr.Code != 409 {
+		t.Fatalf("Expected a conflict error, got %v", err)
+	}
+
+	// a duplicate made while the first request is served is a conflict
+	started, release := make(chan bool), make(chan bool)
+	slow := IdempotencyHandler()(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		started <- true
+		<-release
+		return nil
+	})
+	ctx = metadata.Set(context.TODO(), "Idempotency-Key", "def")
+	errc := make(chan error, 1)
+	go func() { errc <- slow(ctx, &testRequest{body: &testMessage{Value: "qux"}}, &testMessage{}) }()
+	<-started
+	err = slow(ctx, &testRequest{body: &testMessage{Value: "qux"}}, &testMessage{})
+	if merr, ok := err.(*errors.Error); !ok || merr.Code != 409 {
+		t.Fatalf("Expected a conflict error for the duplicate in progress, got %v", err)
+	}
+	close(release)
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPriorityHandler(t *testing.T) {
+	release := make(chan bool)
+	h := PriorityHandler(2)(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		if req.Stream() {
+			<-release
+		}
+		return nil
+	})
+	defer close(release)
+
+	// each watch holds a slot until it's released
+	watch := func(from string) chan error {
+		errc := make(chan error, 1)
+		ctx := metadata.Set(context.TODO(), "Micro-From-Service", from)
+		go func() { errc <- h(ctx, &testStream{}, nil) }()
+		return errc
+	}
+	expectOverloaded := func(errc chan error, msg string) {
+		err := <-errc
+		if merr, ok := err.(*errors.Error); !ok || merr.Code != 503 {
+			t.Fatalf("Expected %v to be rejected with a 503, got %v", msg, err)
+		}
+	}
+
+	// a caller can only hold half the slots
+	watch("foo")
+	time.Sleep(time.Millisecond * 10)
+	expectOverloaded(watch("foo"), "the second watch of foo")
+
+	// the other half is left for other callers, after which the service is overloaded
+	watch("bar")
+	time.Sleep(time.Millisecond * 10)
+	expectOverloaded(watch("baz"), "the watch of baz")
+
+	// control-plane requests of the cli and runtime are still served
+	for _, from := range []string{"", "runtime"} {
+		ctx := metadata.Set(context.TODO(), "Micro-From-Service", from)
+		if err := h(ctx, &testRequest{}, nil); err != nil {
+			t.Fatalf("Expected the request from %q to be served, got %v", from, err)
+		}
+	}
+
+	// an admin is served even when calling from a service
+	ctx := metadata.Set(context.TODO(), "Micro-From-Service", "baz")
+	ctx = auth.ContextWithAccount(ctx, &auth.Account{ID: "john", Issuer: "foo", Scopes: []string{"admin"}})
+	if err := h(ctx, &testRequest{}, nil); err != nil {
+		t.Fatalf("Expected the request of the admin to be served, got %v", err)
+	}
+
+	// the header isn't trusted for the accounts of other namespaces
+	ctx = metadata.Set(context.TODO(), "Micro-From-Service", "runtime")
+	ctx = auth.ContextWithAccount(ctx, &auth.Account{ID: "jane", Issuer: "foo"})
+	expectOverloaded(func() chan error {
+		errc := make(chan error, 1)
+		errc <- h(ctx, &testRequest{}, nil)
+		return errc
+	}(), "the request of jane")
+}
+
+func TestDeadlineHandler(t *testing.T) {
+	h := DeadlineHandler()(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		select {
+		case <-time.After(time.Second):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	// the caller gets a timeout at the deadline rather than waiting on the handler
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond*50)
+	defer cancel()
+	start := time.Now()
+	err := h(ctx, &testRequest{}, nil)
+	if merr, ok := err.(*errors.Error); !ok || merr.Code != 408 {
+		t.Fatalf("Expected a timeout, got %v", err)
+	}
+	if time.Since(start) > time.Millisecond*500 {
+		t.Fatalf("Expected the request to be cancelled at the deadline, took %v", time.Since(start))
+	}
+
+	// the requests past their deadline aren't handled
+	called := false
+	h = DeadlineHandler()(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		called = true
+		return nil
+	})
+	if err := h(ctx, &testRequest{}, nil); err == nil || called {
+		t.Fatalf("Expected the request past its deadline not to be handled, got %v", err)
+	}
+
+	// the requests without a deadline are handled as usual
+	if err := h(context.TODO(), &testRequest{}, nil); err != nil || !called {
+		t.Fatalf("Expected the request without a deadline to be handled, got %v", err)
+	}
+}
+
+type testCall struct {
+	client.Request
+	body interface{}
+}
+
+func (r *testCall) Service() string   { return "test" }
+func (r *testCall) Endpoint() string  { return "Test.Read" }
+func (r *testCall) Method() string    { return "Test.Read" }
+func (r *testCall) Body() interface{} { return r.body }
+
+type testClient struct {
+	client.Client
+	calls        int
+	cacheControl string
+}
+
+func (c *testClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	var options client.CallOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	c.calls++
+	rsp.(*testMessage).Value = "read"
+	if options.ResponseHeader != nil && len(c.cacheControl) > 0 {
+		options.ResponseHeader["Cache-Control"] = c.cacheControl
+	}
+	return nil
+}
+
+func TestCacheClient(t *testing.T) {
+	// the responses are cached for as long as the service allows
+	tc := &testClient{cacheControl: "max-age=60"}
+	c := CacheClient(tc)
+	for i := 0; i < 2; i++ {
+		header := make(map[string]string)
+		var rsp testMessage
+		if err := c.Call(context.TODO(), &testCall{body: "a"}, &rsp, client.WithResponseHeader(header)); err != nil {
+			t.Fatal(err)
+		}
+		if rsp.Value != "read" || len(header["Cache-Control"]) == 0 {
+			t.Fatalf("Expected the response and its cache control, got %v %v", rsp, header)
+		}
+	}
+	if tc.calls != 1 {
+		t.Fatalf("Expected the response to be cached, got %d calls", tc.calls)
+	}
+
+	// the responses aren't cached without the service allowing it
+	tc = &testClient{cacheControl: "no-store"}
+	c = CacheClient(tc)
+	for i := 0; i < 2; i++ {
+		var rsp testMessage
+		if err := c.Call(context.TODO(), &testCall{body: "a"}, &rsp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tc.calls != 2 {
+		t.Fatalf("Expected the response not to be cached, got %d calls", tc.calls)
+	}
+}
+
+type testServer struct {
+	server.Server
+	opts server.Options
+}
+
+func (s *testServer) Options() server.Options { return s.opts }
+
+type testReporter struct {
+	metrics.Reporter
+	counts map[string]int64
+}
+
+func (r *testReporter) Count(id string, value int64, tags metrics.Tags) error {
+	r.counts[tags["endpoint"]+" "+tags["caller"]] += value
+	return nil
+}
+
+func TestDeprecationHandler(t *testing.T) {
+	defer func(s server.Server) { server.DefaultServer = s }(server.DefaultServer)
+	defer func(r metrics.Reporter) { metrics.DefaultMetricsReporter = r }(metrics.DefaultMetricsReporter)
+
+	reg := rmemory.NewRegistry()
+	sunset := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	reg.Register(&registry.Service{
+		Name:    "test",
+		Version: "latest",
+		Endpoints: []*registry.Endpoint{
+			{Name: "Test.Create", Metadata: registry.Deprecate(nil, sunset)},
+			{Name: "Test.Watch"},
+		},
+		Nodes: []*registry.Node{{Id: "test-1", Address: "localhost:9999"}},
+	})
+	server.DefaultServer = &testServer{opts: server.Options{Name: "test", Version: "latest", Registry: reg}}
+	reporter := &testReporter{counts: make(map[string]int64)}
+	metrics.DefaultMetricsReporter = reporter
+
+	h := DeprecationHandler()(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		return nil
+	})
+
+	// the calls to the deprecated endpoint are signalled and counted by caller
+	ctx, rh := server.NewResponseContext(metadata.Set(context.TODO(), HeaderPrefix+"From-Service", "caller"))
+	if err := h(ctx, &testRequest{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	hdr := rh.Header()
+	if hdr[server.DeprecationHeader] != "true" || hdr[server.SunsetHeader] != "Tue, 01 Jun 2021 00:00:00 GMT" {
+		t.Fatalf("Expected the deprecation headers, got %v", hdr)
+	}
+	if c := reporter.counts["Test.Create caller"]; c != 1 {
+		t.Fatalf("Expected the caller to be counted once, got %v", reporter.counts)
+	}
+
+	// the other endpoints are left alone
+	ctx, rh = server.NewResponseContext(context.TODO())
+	if err := h(ctx, &testStream{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if hdr := rh.Header(); len(hdr) > 0 {
+		t.Fatalf("Expected no headers for an endpoint which isn't deprecated, got %v", hdr)
+	}
+	if len(reporter.counts) != 1 {
+		t.Fatalf("Expected only the deprecated endpoint to be counted, got %v", reporter.counts)
+	}
+}
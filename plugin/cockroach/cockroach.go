@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/micro/micro/v3/profile"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/store"
 	"github.com/pkg/errors"
@@ -52,6 +53,11 @@ var (
 	}
 )
 
+func init() {
+	// the server persists to cockroach with --store=cockroach
+	profile.RegisterStore("cockroach", NewStore)
+}
+
 type sqlStore struct {
 	options store.StoreOptions
 	db      *sql.DB
@@ -32,6 +32,7 @@ import (
 	"github.com/coreos/etcd/clientv3"
 	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/micro/micro/v3/profile"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/registry"
 	hash "github.com/mitchellh/hashstructure"
@@ -43,6 +44,11 @@ const (
 	defaultDomain = "micro"
 )
 
+func init() {
+	// the services register in etcd with --registry=etcd
+	profile.RegisterRegistry("etcd", NewRegistry)
+}
+
 type etcdRegistry struct {
 	client  *clientv3.Client
 	options registry.Options
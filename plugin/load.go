@@ -0,0 +1,93 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	goplugin "plugin"
+)
+
+// Symbol is the name a shared object can export its plugin as, either as a variable
+// e.g. var Plugin = plugin.NewPlugin(...) or as a func() plugin.Plugin
+const Symbol = "Plugin"
+
+// Manifest lists the shared objects to load plugins from e.g. plugins.json
+type Manifest struct {
+	Plugins []Entry `json:"plugins"`
+}
+
+// Entry is a shared object in the manifest
+type Entry struct {
+	// Path of the shared object, relative paths are relative to the manifest
+	Path string `json:"path"`
+	// Module the exported plugin is scoped to e.g. api, defaults to micro
+	Module string `json:"module,omitempty"`
+}
+
+// Load opens a shared object built with -buildmode=plugin. Shared objects can register
+// their plugins in init like those compiled in, which happens when they're opened. If the
+// object exports a Plugin symbol, it's registered as well.
+func Load(path string, opts ...PluginOption) error {
+	p, err := goplugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("Error loading plugin %s: %v", path, err)
+	}
+
+	sym, err := p.Lookup(Symbol)
+	if err != nil {
+		// the plugins were registered in init
+		return nil
+	}
+
+	pl, err := fromSymbol(sym)
+	if err != nil {
+		return fmt.Errorf("Error loading plugin %s: %v", path, err)
+	}
+	return Register(pl, opts...)
+}
+
+// LoadManifest loads the shared objects listed in the manifest file
+func LoadManifest(file string) error {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("Error reading plugin manifest %s: %v", file, err)
+	}
+
+	for _, e := range m.Plugins {
+		path := e.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+
+		var opts []PluginOption
+		if len(e.Module) > 0 {
+			opts = append(opts, Module(e.Module))
+		}
+
+		if err := Load(path, opts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fromSymbol returns the plugin exported by a shared object
+func fromSymbol(sym goplugin.Symbol) (Plugin, error) {
+	switch v := sym.(type) {
+	case *Plugin:
+		if *v == nil {
+			return nil, fmt.Errorf("%s is nil", Symbol)
+		}
+		return *v, nil
+	case func() Plugin:
+		return v(), nil
+	}
+	return nil, fmt.Errorf("%s has unexpected type %T", Symbol, sym)
+}
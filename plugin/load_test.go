@@ -0,0 +1,52 @@
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFromSymbol(t *testing.T) {
+	pl := NewPlugin(WithName("test"))
+
+	if p, err := fromSymbol(&pl); err != nil || p != pl {
+		t.Fatalf("Expected plugin from variable, got %v %v", p, err)
+	}
+	if p, err := fromSymbol(func() Plugin { return pl }); err != nil || p != pl {
+		t.Fatalf("Expected plugin from func, got %v %v", p, err)
+	}
+
+	var empty Plugin
+	if _, err := fromSymbol(&empty); err == nil {
+		t.Fatal("Expected error for nil plugin")
+	}
+	if _, err := fromSymbol(pl); err == nil {
+		t.Fatal("Expected error for unexpected type")
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "plugins.json")
+
+	if err := ioutil.WriteFile(file, []byte(`{"plugins":[`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadManifest(file); err == nil {
+		t.Fatal("Expected error for invalid manifest")
+	}
+
+	// the missing shared object is looked up next to the manifest
+	if err := ioutil.WriteFile(file, []byte(`{"plugins":[{"path":"missing.so"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadManifest(file); err == nil {
+		t.Fatal("Expected error for missing shared object")
+	}
+}
@@ -0,0 +1,111 @@
+package nats
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/profile"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+var (
+	// DefaultEmbeddedAddress is the address the embedded nats server listens on
+	DefaultEmbeddedAddress = "127.0.0.1:4222"
+	// ErrNotReady is returned when the embedded nats server doesn't start accepting connections
+	ErrNotReady = errors.New("embedded nats server not ready")
+)
+
+func init() {
+	// the broker service runs the embedded server with --broker=embedded
+	profile.RegisterBroker("embedded", NewEmbeddedBroker)
+}
+
+type embeddedBroker struct {
+	broker.Broker
+	sync.Mutex
+
+	address string
+	server  *server.Server
+}
+
+// NewEmbeddedBroker returns a nats broker which starts an embedded nats server when connected,
+// listening on the first address set. Other services connect to it with the nats broker.
+func NewEmbeddedBroker(opts ...broker.Option) broker.Broker {
+	e := &embeddedBroker{
+		Broker:  NewBroker(),
+		address: DefaultEmbeddedAddress,
+	}
+	e.Init(opts...)
+	return e
+}
+
+func (e *embeddedBroker) Init(opts ...broker.Option) error {
+	e.Lock()
+	defer e.Unlock()
+
+	if err := e.Broker.Init(opts...); err != nil {
+		return err
+	}
+	if addrs := e.Broker.Options().Addrs; len(addrs) > 0 {
+		e.address = strings.TrimPrefix(addrs[0], "nats://")
+	}
+	return nil
+}
+
+func (e *embeddedBroker) Connect() error {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.server == nil {
+		host, p, err := net.SplitHostPort(e.address)
+		if err != nil {
+			return err
+		}
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return err
+		}
+
+		srv, err := server.NewServer(&server.Options{
+			Host:   host,
+			Port:   port,
+			NoLog:  true,
+			NoSigs: true,
+		})
+		if err != nil {
+			return err
+		}
+		go srv.Start()
+
+		if !srv.ReadyForConnections(10 * time.Second) {
+			srv.Shutdown()
+			return ErrNotReady
+		}
+		e.server = srv
+	}
+
+	// the client always connects to the embedded server
+	if err := e.Broker.Init(broker.Addrs(e.server.ClientURL())); err != nil {
+		return err
+	}
+	return e.Broker.Connect()
+}
+
+func (e *embeddedBroker) Disconnect() error {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.server == nil {
+		return nil
+	}
+
+	err := e.Broker.Disconnect()
+	e.server.Shutdown()
+	e.server = nil
+	return err
+}
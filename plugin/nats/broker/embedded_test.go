@@ -0,0 +1,40 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/broker"
+)
+
+func TestEmbeddedBroker(t *testing.T) {
+	// listen on a random port
+	b := NewEmbeddedBroker(broker.Addrs("127.0.0.1:-1"))
+	if err := b.Connect(); err != nil {
+		t.Fatalf("Unexpected connect error %v", err)
+	}
+	defer b.Disconnect()
+
+	done := make(chan *broker.Message, 1)
+	sub, err := b.Subscribe("test", func(m *broker.Message) error {
+		done <- m
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected subscribe error %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	if err := b.Publish("test", &broker.Message{Body: []byte("hello")}); err != nil {
+		t.Fatalf("Unexpected publish error %v", err)
+	}
+
+	select {
+	case m := <-done:
+		if string(m.Body) != "hello" {
+			t.Fatalf("Expected hello, got %s", m.Body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the message")
+	}
+}
@@ -0,0 +1,89 @@
+package plugin
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/server"
+)
+
+// Wrapper is a named set of middleware applied uniformly to the services run by micro, the
+// api, proxy and the services themselves, so it's configured once rather than per service.
+// Any of the wrappers can be nil.
+type Wrapper struct {
+	// Name of the wrapper e.g. metrics
+	Name string
+	// Client wraps the outbound calls
+	Client client.Wrapper
+	// Handler wraps the inbound requests
+	Handler server.HandlerWrapper
+	// Subscriber wraps the inbound messages
+	Subscriber server.SubscriberWrapper
+	// HTTP wraps the requests to the api
+	HTTP Handler
+}
+
+var (
+	wrapperMtx sync.RWMutex
+	// wrappers in the order they were registered
+	wrappers []Wrapper
+	// enabled are the names of the wrappers applied, all are applied if nil
+	enabled []string
+)
+
+// RegisterWrapper registers a global wrapper, normally called in init
+func RegisterWrapper(w Wrapper) error {
+	wrapperMtx.Lock()
+	defer wrapperMtx.Unlock()
+
+	for _, r := range wrappers {
+		if r.Name == w.Name {
+			return fmt.Errorf("Wrapper with name %s already registered", w.Name)
+		}
+	}
+	wrappers = append(wrappers, w)
+	return nil
+}
+
+// EnableWrappers sets the wrappers applied and their order, by default all the registered
+// wrappers are applied in the order they were registered
+func EnableWrappers(names ...string) error {
+	wrapperMtx.Lock()
+	defer wrapperMtx.Unlock()
+
+	for _, n := range names {
+		if _, ok := findWrapper(n); !ok {
+			return fmt.Errorf("Wrapper %s not registered", n)
+		}
+	}
+	enabled = names
+	return nil
+}
+
+// Wrappers returns the wrappers to apply, outermost first
+func Wrappers() []Wrapper {
+	wrapperMtx.RLock()
+	defer wrapperMtx.RUnlock()
+
+	if enabled == nil {
+		return append([]Wrapper(nil), wrappers...)
+	}
+
+	ws := make([]Wrapper, 0, len(enabled))
+	for _, n := range enabled {
+		if w, ok := findWrapper(n); ok {
+			ws = append(ws, w)
+		}
+	}
+	return ws
+}
+
+func findWrapper(name string) (Wrapper, bool) {
+	for _, w := range wrappers {
+		if w.Name == name {
+			return w, true
+		}
+	}
+	return Wrapper{}, false
+}
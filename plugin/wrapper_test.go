@@ -0,0 +1,45 @@
+package plugin
+
+import (
+	"testing"
+)
+
+func TestWrappers(t *testing.T) {
+	defer func() {
+		wrappers = nil
+		enabled = nil
+	}()
+
+	for _, name := range []string{"log", "metrics"} {
+		if err := RegisterWrapper(Wrapper{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := RegisterWrapper(Wrapper{Name: "log"}); err == nil {
+		t.Fatal("Expected error registering duplicate wrapper")
+	}
+
+	names := func() []string {
+		var n []string
+		for _, w := range Wrappers() {
+			n = append(n, w.Name)
+		}
+		return n
+	}
+
+	// all wrappers are applied by default
+	if n := names(); len(n) != 2 || n[0] != "log" || n[1] != "metrics" {
+		t.Fatalf("Expected [log metrics], got %v", n)
+	}
+
+	if err := EnableWrappers("metrics", "log"); err != nil {
+		t.Fatal(err)
+	}
+	if n := names(); len(n) != 2 || n[0] != "metrics" || n[1] != "log" {
+		t.Fatalf("Expected [metrics log], got %v", n)
+	}
+
+	if err := EnableWrappers("trace"); err == nil {
+		t.Fatal("Expected error enabling unregistered wrapper")
+	}
+}
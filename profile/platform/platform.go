@@ -12,6 +12,7 @@ import (
 	"github.com/micro/micro/v3/service/auth/jwt"
 	"github.com/micro/micro/v3/service/broker"
 	microBuilder "github.com/micro/micro/v3/service/build"
+	buildCache "github.com/micro/micro/v3/service/build/cache"
 	"github.com/micro/micro/v3/service/build/golang"
 	"github.com/micro/micro/v3/service/config"
 	storeConfig "github.com/micro/micro/v3/service/config/store"
@@ -84,11 +85,17 @@ var Profile = &profile.Profile{
 			}
 		}
 
-		microRuntime.DefaultRuntime = kubernetes.NewRuntime()
-		microBuilder.DefaultBuilder, err = golang.NewBuilder()
+		if ctx.Bool("runtime_operator") {
+			microRuntime.DefaultRuntime = kubernetes.NewOperator()
+		} else {
+			microRuntime.DefaultRuntime = kubernetes.NewRuntime()
+		}
+		builder, err := golang.NewBuilder()
 		if err != nil {
 			logger.Fatalf("Error configuring golang builder: %v", err)
 		}
+		// cache the builds in the default blob store
+		microBuilder.DefaultBuilder = buildCache.NewBuilder(builder, nil)
 		events.DefaultStore = evStore.NewStore(evStore.WithStore(store.DefaultStore))
 
 		kubernetes.DefaultImage = "ghcr.io/m3o/cells:v3"
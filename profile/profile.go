@@ -18,8 +18,11 @@ import (
 	storeConfig "github.com/micro/micro/v3/service/config/store"
 	evStore "github.com/micro/micro/v3/service/events/store"
 	memStream "github.com/micro/micro/v3/service/events/stream/memory"
+	"github.com/micro/micro/v3/service/id/snowflake"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/registry"
+	regClient "github.com/micro/micro/v3/service/registry/client"
+	regFile "github.com/micro/micro/v3/service/registry/file"
 	"github.com/micro/micro/v3/service/registry/mdns"
 	"github.com/micro/micro/v3/service/registry/memory"
 	"github.com/micro/micro/v3/service/router"
@@ -30,17 +33,21 @@ import (
 	"github.com/micro/micro/v3/service/server"
 	"github.com/micro/micro/v3/service/store/file"
 	mem "github.com/micro/micro/v3/service/store/memory"
+	syncStore "github.com/micro/micro/v3/service/sync/store"
 	"github.com/urfave/cli/v2"
 
 	inAuth "github.com/micro/micro/v3/internal/auth"
 	"github.com/micro/micro/v3/internal/user"
 	microAuth "github.com/micro/micro/v3/service/auth"
 	microBuilder "github.com/micro/micro/v3/service/build"
+	buildCache "github.com/micro/micro/v3/service/build/cache"
 	microEvents "github.com/micro/micro/v3/service/events"
+	microID "github.com/micro/micro/v3/service/id"
 	microRegistry "github.com/micro/micro/v3/service/registry"
 	microRouter "github.com/micro/micro/v3/service/router"
 	microRuntime "github.com/micro/micro/v3/service/runtime"
 	microStore "github.com/micro/micro/v3/service/store"
+	microSync "github.com/micro/micro/v3/service/sync"
 )
 
 // profiles which when called will configure micro to run in that environment
@@ -51,6 +58,41 @@ var profiles = map[string]*Profile{
 	"test":       Test,
 	"local":      Local,
 	"kubernetes": Kubernetes,
+	"memory":     Memory,
+}
+
+// brokers which the broker service can use, selected with the broker flag
+var brokers = map[string]func(...broker.Option) broker.Broker{
+	"memory": memBroker.NewBroker,
+}
+
+// registries which can be used instead of the registry of the profile, selected with the registry flag
+var registries = map[string]func(...registry.Option) registry.Registry{}
+
+// stores which the server can persist to, selected with the store flag. Stores such as cockroach
+// are registered by plugins so their drivers are only built in when used.
+var stores = map[string]func(...microStore.StoreOption) microStore.Store{}
+
+// runtimes the services can be run on, selected with the platform flag
+var runtimes = map[string]func(...microRuntime.Option) microRuntime.Runtime{}
+
+func init() {
+	// the service registry calls the registry service e.g. via the network, so the services at the
+	// edge register without access to mdns or the registry of the platform. The file registry shares
+	// the registrations of the services run locally via a file.
+	RegisterRegistry("file", regFile.NewRegistry)
+	RegisterRegistry("mdns", mdns.NewRegistry)
+	RegisterRegistry("memory", memory.NewRegistry)
+	RegisterRegistry("service", regClient.NewRegistry)
+
+	// the file store persists to bolt databases in its directory
+	RegisterStore("bolt", file.NewStore)
+	RegisterStore("file", file.NewStore)
+	RegisterStore("memory", mem.NewStore)
+
+	// the local runtime runs the services as processes, the kubernetes one as deployments
+	RegisterRuntime("kubernetes", kubernetes.NewRuntime)
+	RegisterRuntime("local", local.NewRuntime)
 }
 
 // Profile configures an environment
@@ -81,6 +123,81 @@ func Load(name string) (*Profile, error) {
 	return v, nil
 }
 
+// RegisterBroker registers a broker the broker service can use e.g. the embedded nats broker
+func RegisterBroker(name string, fn func(...broker.Option) broker.Broker) error {
+	if _, ok := brokers[name]; ok {
+		return fmt.Errorf("broker %s already exists", name)
+	}
+	brokers[name] = fn
+	return nil
+}
+
+// LoadBroker returns a new broker of the name, the memory broker if the name is blank
+func LoadBroker(name string) (broker.Broker, error) {
+	if len(name) == 0 {
+		name = "memory"
+	}
+	fn, ok := brokers[name]
+	if !ok {
+		return nil, fmt.Errorf("broker %s does not exist", name)
+	}
+	return fn(), nil
+}
+
+// RegisterRegistry registers a registry which can be selected with the registry flag
+func RegisterRegistry(name string, fn func(...registry.Option) registry.Registry) error {
+	if _, ok := registries[name]; ok {
+		return fmt.Errorf("registry %s already exists", name)
+	}
+	registries[name] = fn
+	return nil
+}
+
+// LoadRegistry returns a new registry of the name
+func LoadRegistry(name string) (registry.Registry, error) {
+	fn, ok := registries[name]
+	if !ok {
+		return nil, fmt.Errorf("registry %s does not exist", name)
+	}
+	return fn(), nil
+}
+
+// RegisterStore registers a store which can be selected with the store flag e.g. cockroach
+func RegisterStore(name string, fn func(...microStore.StoreOption) microStore.Store) error {
+	if _, ok := stores[name]; ok {
+		return fmt.Errorf("store %s already exists", name)
+	}
+	stores[name] = fn
+	return nil
+}
+
+// LoadStore returns a new store of the name
+func LoadStore(name string, opts ...microStore.StoreOption) (microStore.Store, error) {
+	fn, ok := stores[name]
+	if !ok {
+		return nil, fmt.Errorf("store %s does not exist", name)
+	}
+	return fn(opts...), nil
+}
+
+// RegisterRuntime registers a runtime which can be selected with the platform flag
+func RegisterRuntime(name string, fn func(...microRuntime.Option) microRuntime.Runtime) error {
+	if _, ok := runtimes[name]; ok {
+		return fmt.Errorf("runtime %s already exists", name)
+	}
+	runtimes[name] = fn
+	return nil
+}
+
+// LoadRuntime returns a new runtime of the name
+func LoadRuntime(name string, opts ...microRuntime.Option) (microRuntime.Runtime, error) {
+	fn, ok := runtimes[name]
+	if !ok {
+		return nil, fmt.Errorf("runtime %s does not exist", name)
+	}
+	return fn(opts...), nil
+}
+
 // Client profile is for any entrypoint that behaves as a client
 var Client = &Profile{
 	Name:  "client",
@@ -92,16 +209,29 @@ var Local = &Profile{
 	Name: "local",
 	Setup: func(ctx *cli.Context) error {
 		microAuth.DefaultAuth = jwt.NewAuth()
-		microStore.DefaultStore = file.NewStore(file.WithDir(filepath.Join(user.Dir, "server", "store")))
+		if err := setupStore(ctx, "file", file.WithDir(filepath.Join(user.Dir, "server", "store"))); err != nil {
+			return err
+		}
 		SetupConfigSecretKey(ctx)
 		config.DefaultConfig, _ = storeConfig.NewConfig(microStore.DefaultStore, "")
 		SetupBroker(memBroker.NewBroker())
 		SetupRegistry(mdns.NewRegistry())
 		SetupJWT(ctx)
 
-		// use the local runtime, note: the local runtime is designed to run source code directly so
-		// the runtime builder should NOT be set when using this implementation
-		microRuntime.DefaultRuntime = local.NewRuntime()
+		// the broker service uses the broker set by the flag
+		if ctx.Args().Get(1) == "broker" {
+			b, err := LoadBroker(ctx.String("broker"))
+			if err != nil {
+				return err
+			}
+			SetupBroker(b)
+		}
+
+		// use the local runtime unless another platform is set, note: the local runtime is designed
+		// to run source code directly so the runtime builder should NOT be set when using it
+		if _, err := setupRuntime(ctx, "local"); err != nil {
+			return err
+		}
 
 		var err error
 		microEvents.DefaultStream, err = memStream.NewStream()
@@ -121,6 +251,46 @@ var Local = &Profile{
 	},
 }
 
+// Memory profile to run all the services in a single process e.g. micro service --all. The
+// registry, broker and store are in memory and shared by the services. Auth is disabled.
+var Memory = &Profile{
+	Name: "memory",
+	Setup: func(ctx *cli.Context) error {
+		microAuth.DefaultAuth = noop.NewAuth()
+		if err := setupStore(ctx, "memory"); err != nil {
+			return err
+		}
+		SetupConfigSecretKey(ctx)
+		config.DefaultConfig, _ = storeConfig.NewConfig(microStore.DefaultStore, "")
+		b, err := LoadBroker(ctx.String("broker"))
+		if err != nil {
+			return err
+		}
+		SetupBroker(b)
+		SetupRegistry(memory.NewRegistry())
+
+		// the local runtime runs the services created with micro run
+		if _, err := setupRuntime(ctx, "local"); err != nil {
+			return err
+		}
+
+		microEvents.DefaultStream, err = memStream.NewStream()
+		if err != nil {
+			logger.Fatalf("Error configuring stream: %v", err)
+		}
+		microEvents.DefaultStore = evStore.NewStore(
+			evStore.WithStore(microStore.DefaultStore),
+		)
+
+		microStore.DefaultBlobStore, err = file.NewBlobStore()
+		if err != nil {
+			logger.Fatalf("Error configuring file blob store: %v", err)
+		}
+
+		return nil
+	},
+}
+
 // Kubernetes profile to run on kubernetes with zero deps. Designed for use with the micro helm chart
 var Kubernetes = &Profile{
 	Name: "kubernetes",
@@ -128,10 +298,20 @@ var Kubernetes = &Profile{
 		microAuth.DefaultAuth = jwt.NewAuth()
 		SetupJWT(ctx)
 
-		microRuntime.DefaultRuntime = kubernetes.NewRuntime()
-		microBuilder.DefaultBuilder, err = golang.NewBuilder()
-		if err != nil {
-			logger.Fatalf("Error configuring golang builder: %v", err)
+		platform := "kubernetes"
+		if ctx.Bool("runtime_operator") {
+			microRuntime.DefaultRuntime = kubernetes.NewOperator()
+		} else if platform, err = setupRuntime(ctx, platform); err != nil {
+			return err
+		}
+		// the local runtime builds the source itself
+		if platform != "local" {
+			builder, err := golang.NewBuilder()
+			if err != nil {
+				logger.Fatalf("Error configuring golang builder: %v", err)
+			}
+			// cache the builds in the default blob store
+			microBuilder.DefaultBuilder = buildCache.NewBuilder(builder, nil)
 		}
 
 		microEvents.DefaultStream, err = memStream.NewStream()
@@ -139,7 +319,9 @@ var Kubernetes = &Profile{
 			logger.Fatalf("Error configuring stream: %v", err)
 		}
 
-		microStore.DefaultStore = file.NewStore(file.WithDir("/store"))
+		if err := setupStore(ctx, "file", file.WithDir("/store")); err != nil {
+			return err
+		}
 		microStore.DefaultBlobStore, err = file.NewBlobStore(file.WithDir("/store/blob"))
 		if err != nil {
 			logger.Fatalf("Error configuring file blob store: %v", err)
@@ -151,10 +333,14 @@ var Kubernetes = &Profile{
 			SetupRegistry(memory.NewRegistry())
 		}
 
-		// the broker service uses the memory broker, the other core services will use the default
-		// rpc client and call the broker service
+		// the broker service uses the broker set by the flag, the memory broker by default. The
+		// other core services will use the default rpc client and call the broker service
 		if ctx.Args().Get(1) == "broker" {
-			SetupBroker(memBroker.NewBroker())
+			b, err := LoadBroker(ctx.String("broker"))
+			if err != nil {
+				return err
+			}
+			SetupBroker(b)
 		}
 
 		config.DefaultConfig, err = storeConfig.NewConfig(microStore.DefaultStore, "")
@@ -184,6 +370,8 @@ var Test = &Profile{
 		microStore.DefaultBlobStore, _ = file.NewBlobStore()
 		config.DefaultConfig, _ = storeConfig.NewConfig(microStore.DefaultStore, "")
 		SetupRegistry(memory.NewRegistry())
+		microID.DefaultGenerator, _ = snowflake.NewGenerator(0)
+		microSync.DefaultSync = syncStore.NewSync()
 		return nil
 	},
 }
@@ -203,6 +391,36 @@ func SetupBroker(b broker.Broker) {
 	server.DefaultServer.Init(server.Broker(b))
 }
 
+// setupStore configures the store set by the store flag, or the store of the profile if not set.
+// The options such as the directory of the file store are those of the profile.
+func setupStore(ctx *cli.Context, def string, opts ...microStore.StoreOption) error {
+	name := ctx.String("store")
+	if len(name) == 0 {
+		name = def
+	}
+	s, err := LoadStore(name, opts...)
+	if err != nil {
+		return err
+	}
+	microStore.DefaultStore = s
+	return nil
+}
+
+// setupRuntime sets the default runtime to the one of the platform flag, or def if it isn't set,
+// returning the name of the runtime
+func setupRuntime(ctx *cli.Context, def string) (string, error) {
+	name := ctx.String("platform")
+	if len(name) == 0 {
+		name = def
+	}
+	r, err := LoadRuntime(name)
+	if err != nil {
+		return "", err
+	}
+	microRuntime.DefaultRuntime = r
+	return name, nil
+}
+
 // SetupJWT configures the default internal system rules
 func SetupJWT(ctx *cli.Context) {
 	for _, rule := range inAuth.SystemRules {
@@ -0,0 +1,102 @@
+package profile
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/file"
+	"github.com/urfave/cli/v2"
+)
+
+func newContext(t *testing.T, args ...string) *cli.Context {
+	set := flag.NewFlagSet("micro", flag.ContinueOnError)
+	set.String("platform", "", "")
+	set.String("store", "", "")
+	if err := set.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+type testRuntime struct {
+	runtime.Runtime
+}
+
+func (t *testRuntime) String() string { return "test" }
+
+func TestPlatformFlag(t *testing.T) {
+	defer func(r runtime.Runtime) { runtime.DefaultRuntime = r }(runtime.DefaultRuntime)
+	defer delete(runtimes, "test")
+
+	if err := RegisterRuntime("test", func(...runtime.Option) runtime.Runtime { return new(testRuntime) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterRuntime("local", func(...runtime.Option) runtime.Runtime { return new(testRuntime) }); err == nil {
+		t.Fatal("Expected an error registering the local runtime again")
+	}
+
+	// the runtime of the profile is used unless the flag is set
+	name, err := setupRuntime(newContext(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "test" || runtime.DefaultRuntime.String() != "test" {
+		t.Fatalf("Expected the test runtime, got %s", runtime.DefaultRuntime.String())
+	}
+
+	// the flag selects the runtime
+	name, err = setupRuntime(newContext(t, "--platform", "local"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "local" || runtime.DefaultRuntime.String() != "local" {
+		t.Fatalf("Expected the local runtime, got %s", runtime.DefaultRuntime.String())
+	}
+
+	// the unknown runtimes are rejected
+	if _, err := setupRuntime(newContext(t, "--platform", "unknown"), "test"); err == nil {
+		t.Fatal("Expected an error selecting an unknown runtime")
+	}
+	if runtime.DefaultRuntime.String() != "local" {
+		t.Fatalf("Expected the runtime to be kept, got %s", runtime.DefaultRuntime.String())
+	}
+}
+
+func TestStoreFlag(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+
+	// the store of the profile is used unless the flag is set
+	if err := setupStore(newContext(t), "memory"); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.DefaultStore.String(); got != "memory" {
+		t.Fatalf("Expected the memory store, got %s", got)
+	}
+
+	// the flag selects the store, with the options of the profile
+	dir := t.TempDir()
+	if err := setupStore(newContext(t, "--store", "bolt"), "memory", file.WithDir(dir)); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.DefaultStore.String(); got != "file" {
+		t.Fatalf("Expected the bolt backed file store, got %s", got)
+	}
+	if err := store.DefaultStore.Write(&store.Record{Key: "key", Value: []byte("value")}); err != nil {
+		t.Fatal(err)
+	}
+	if files, err := ioutil.ReadDir(dir); err != nil || len(files) == 0 {
+		t.Fatalf("Expected the store to persist to %s: %v", dir, err)
+	}
+	store.DefaultStore.Close()
+
+	// the unknown stores are rejected
+	if err := setupStore(newContext(t, "--store", "unknown"), "memory"); err == nil {
+		t.Fatal("Expected an error selecting an unknown store")
+	}
+	if got := store.DefaultStore.String(); got != "file" {
+		t.Fatalf("Expected the store to be kept, got %s", got)
+	}
+}
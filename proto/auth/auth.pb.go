@@ -51,7 +51,11 @@ func (Access) EnumDescriptor() ([]byte, []int) {
 }
 
 type ListAccountsRequest struct {
-	Options              *Options `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	Options *Options `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	// maximum number of accounts to return, all if zero
+	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// number of accounts to skip, they're ordered by id
+	Offset               int64    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -89,11 +93,27 @@ func (m *ListAccountsRequest) GetOptions() *Options {
 	return nil
 }
 
+func (m *ListAccountsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListAccountsRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
 type ListAccountsResponse struct {
-	Accounts             []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Accounts []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	// total number of accounts in the namespace
+	Total                int64    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListAccountsResponse) Reset()         { *m = ListAccountsResponse{} }
@@ -128,6 +148,13 @@ func (m *ListAccountsResponse) GetAccounts() []*Account {
 	return nil
 }
 
+func (m *ListAccountsResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
 type DeleteAccountRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Options              *Options `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
@@ -1180,7 +1207,11 @@ func (m *DeleteResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
 
 type ListRequest struct {
-	Options              *Options `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	Options *Options `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	// maximum number of rules to return, all if zero
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// number of rules to skip, they're ordered by id
+	Offset               int64    `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1218,8 +1249,24 @@ func (m *ListRequest) GetOptions() *Options {
 	return nil
 }
 
+func (m *ListRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
 type ListResponse struct {
-	Rules                []*Rule  `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Rules []*Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// total number of rules in the namespace
+	Total                int64    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1257,6 +1304,501 @@ func (m *ListResponse) GetRules() []*Rule {
 	return nil
 }
 
+func (m *ListResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type ListNamespacesRequest struct {
+	// maximum number of namespaces to return, all if zero
+	Limit int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// number of namespaces to skip, they're ordered by name
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListNamespacesRequest) Reset()         { *m = ListNamespacesRequest{} }
+func (m *ListNamespacesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListNamespacesRequest) ProtoMessage()    {}
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{25}
+}
+
+func (m *ListNamespacesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNamespacesRequest.Unmarshal(m, b)
+}
+func (m *ListNamespacesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNamespacesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListNamespacesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNamespacesRequest.Merge(m, src)
+}
+func (m *ListNamespacesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListNamespacesRequest.Size(m)
+}
+func (m *ListNamespacesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNamespacesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNamespacesRequest proto.InternalMessageInfo
+
+func (m *ListNamespacesRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListNamespacesRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListNamespacesResponse struct {
+	Namespaces []string `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	// total number of namespaces
+	Total                int64    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListNamespacesResponse) Reset()         { *m = ListNamespacesResponse{} }
+func (m *ListNamespacesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListNamespacesResponse) ProtoMessage()    {}
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{26}
+}
+
+func (m *ListNamespacesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNamespacesResponse.Unmarshal(m, b)
+}
+func (m *ListNamespacesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNamespacesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListNamespacesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNamespacesResponse.Merge(m, src)
+}
+func (m *ListNamespacesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListNamespacesResponse.Size(m)
+}
+func (m *ListNamespacesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNamespacesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNamespacesResponse proto.InternalMessageInfo
+
+func (m *ListNamespacesResponse) GetNamespaces() []string {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+func (m *ListNamespacesResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type DeleteNamespaceRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteNamespaceRequest) Reset()         { *m = DeleteNamespaceRequest{} }
+func (m *DeleteNamespaceRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteNamespaceRequest) ProtoMessage()    {}
+func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{27}
+}
+
+func (m *DeleteNamespaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteNamespaceRequest.Unmarshal(m, b)
+}
+func (m *DeleteNamespaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteNamespaceRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteNamespaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteNamespaceRequest.Merge(m, src)
+}
+func (m *DeleteNamespaceRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteNamespaceRequest.Size(m)
+}
+func (m *DeleteNamespaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteNamespaceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteNamespaceRequest proto.InternalMessageInfo
+
+func (m *DeleteNamespaceRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type DeleteNamespaceResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteNamespaceResponse) Reset()         { *m = DeleteNamespaceResponse{} }
+func (m *DeleteNamespaceResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteNamespaceResponse) ProtoMessage()    {}
+func (*DeleteNamespaceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{28}
+}
+
+func (m *DeleteNamespaceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteNamespaceResponse.Unmarshal(m, b)
+}
+func (m *DeleteNamespaceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteNamespaceResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteNamespaceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteNamespaceResponse.Merge(m, src)
+}
+func (m *DeleteNamespaceResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteNamespaceResponse.Size(m)
+}
+func (m *DeleteNamespaceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteNamespaceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteNamespaceResponse proto.InternalMessageInfo
+
+// Quota limits the requests made through the api gateway and proxy
+type Quota struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// account the quota applies to, the requests of the whole namespace are limited if blank
+	Account string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	// maximum number of requests per minute, unlimited if zero
+	PerMinute int64 `protobuf:"varint,3,opt,name=per_minute,json=perMinute,proto3" json:"per_minute,omitempty"`
+	// maximum number of requests per day, unlimited if zero
+	PerDay               int64    `protobuf:"varint,4,opt,name=per_day,json=perDay,proto3" json:"per_day,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Quota) Reset()         { *m = Quota{} }
+func (m *Quota) String() string { return proto.CompactTextString(m) }
+func (*Quota) ProtoMessage()    {}
+func (*Quota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{29}
+}
+
+func (m *Quota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Quota.Unmarshal(m, b)
+}
+func (m *Quota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Quota.Marshal(b, m, deterministic)
+}
+func (m *Quota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Quota.Merge(m, src)
+}
+func (m *Quota) XXX_Size() int {
+	return xxx_messageInfo_Quota.Size(m)
+}
+func (m *Quota) XXX_DiscardUnknown() {
+	xxx_messageInfo_Quota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Quota proto.InternalMessageInfo
+
+func (m *Quota) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Quota) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *Quota) GetPerMinute() int64 {
+	if m != nil {
+		return m.PerMinute
+	}
+	return 0
+}
+
+func (m *Quota) GetPerDay() int64 {
+	if m != nil {
+		return m.PerDay
+	}
+	return 0
+}
+
+type SetQuotaRequest struct {
+	Quota                *Quota   `protobuf:"bytes,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetQuotaRequest) Reset()         { *m = SetQuotaRequest{} }
+func (m *SetQuotaRequest) String() string { return proto.CompactTextString(m) }
+func (*SetQuotaRequest) ProtoMessage()    {}
+func (*SetQuotaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{30}
+}
+
+func (m *SetQuotaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetQuotaRequest.Unmarshal(m, b)
+}
+func (m *SetQuotaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetQuotaRequest.Marshal(b, m, deterministic)
+}
+func (m *SetQuotaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetQuotaRequest.Merge(m, src)
+}
+func (m *SetQuotaRequest) XXX_Size() int {
+	return xxx_messageInfo_SetQuotaRequest.Size(m)
+}
+func (m *SetQuotaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetQuotaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetQuotaRequest proto.InternalMessageInfo
+
+func (m *SetQuotaRequest) GetQuota() *Quota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+type SetQuotaResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetQuotaResponse) Reset()         { *m = SetQuotaResponse{} }
+func (m *SetQuotaResponse) String() string { return proto.CompactTextString(m) }
+func (*SetQuotaResponse) ProtoMessage()    {}
+func (*SetQuotaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{31}
+}
+
+func (m *SetQuotaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetQuotaResponse.Unmarshal(m, b)
+}
+func (m *SetQuotaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetQuotaResponse.Marshal(b, m, deterministic)
+}
+func (m *SetQuotaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetQuotaResponse.Merge(m, src)
+}
+func (m *SetQuotaResponse) XXX_Size() int {
+	return xxx_messageInfo_SetQuotaResponse.Size(m)
+}
+func (m *SetQuotaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetQuotaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetQuotaResponse proto.InternalMessageInfo
+
+type ListQuotasRequest struct {
+	// namespace to list the quotas of, all if blank
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// maximum number of quotas to return, all if zero
+	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// number of quotas to skip, they're ordered by namespace and account
+	Offset               int64    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListQuotasRequest) Reset()         { *m = ListQuotasRequest{} }
+func (m *ListQuotasRequest) String() string { return proto.CompactTextString(m) }
+func (*ListQuotasRequest) ProtoMessage()    {}
+func (*ListQuotasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{32}
+}
+
+func (m *ListQuotasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListQuotasRequest.Unmarshal(m, b)
+}
+func (m *ListQuotasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListQuotasRequest.Marshal(b, m, deterministic)
+}
+func (m *ListQuotasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListQuotasRequest.Merge(m, src)
+}
+func (m *ListQuotasRequest) XXX_Size() int {
+	return xxx_messageInfo_ListQuotasRequest.Size(m)
+}
+func (m *ListQuotasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListQuotasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListQuotasRequest proto.InternalMessageInfo
+
+func (m *ListQuotasRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ListQuotasRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListQuotasRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListQuotasResponse struct {
+	Quotas []*Quota `protobuf:"bytes,1,rep,name=quotas,proto3" json:"quotas,omitempty"`
+	// total number of quotas
+	Total                int64    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListQuotasResponse) Reset()         { *m = ListQuotasResponse{} }
+func (m *ListQuotasResponse) String() string { return proto.CompactTextString(m) }
+func (*ListQuotasResponse) ProtoMessage()    {}
+func (*ListQuotasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{33}
+}
+
+func (m *ListQuotasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListQuotasResponse.Unmarshal(m, b)
+}
+func (m *ListQuotasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListQuotasResponse.Marshal(b, m, deterministic)
+}
+func (m *ListQuotasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListQuotasResponse.Merge(m, src)
+}
+func (m *ListQuotasResponse) XXX_Size() int {
+	return xxx_messageInfo_ListQuotasResponse.Size(m)
+}
+func (m *ListQuotasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListQuotasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListQuotasResponse proto.InternalMessageInfo
+
+func (m *ListQuotasResponse) GetQuotas() []*Quota {
+	if m != nil {
+		return m.Quotas
+	}
+	return nil
+}
+
+func (m *ListQuotasResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type DeleteQuotaRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteQuotaRequest) Reset()         { *m = DeleteQuotaRequest{} }
+func (m *DeleteQuotaRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteQuotaRequest) ProtoMessage()    {}
+func (*DeleteQuotaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{34}
+}
+
+func (m *DeleteQuotaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteQuotaRequest.Unmarshal(m, b)
+}
+func (m *DeleteQuotaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteQuotaRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteQuotaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteQuotaRequest.Merge(m, src)
+}
+func (m *DeleteQuotaRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteQuotaRequest.Size(m)
+}
+func (m *DeleteQuotaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteQuotaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteQuotaRequest proto.InternalMessageInfo
+
+func (m *DeleteQuotaRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DeleteQuotaRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+type DeleteQuotaResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteQuotaResponse) Reset()         { *m = DeleteQuotaResponse{} }
+func (m *DeleteQuotaResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteQuotaResponse) ProtoMessage()    {}
+func (*DeleteQuotaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{35}
+}
+
+func (m *DeleteQuotaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteQuotaResponse.Unmarshal(m, b)
+}
+func (m *DeleteQuotaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteQuotaResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteQuotaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteQuotaResponse.Merge(m, src)
+}
+func (m *DeleteQuotaResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteQuotaResponse.Size(m)
+}
+func (m *DeleteQuotaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteQuotaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteQuotaResponse proto.InternalMessageInfo
+
 type ChangeSecretRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	OldSecret            string   `protobuf:"bytes,2,opt,name=old_secret,json=oldSecret,proto3" json:"old_secret,omitempty"`
@@ -1271,7 +1813,7 @@ func (m *ChangeSecretRequest) Reset()         { *m = ChangeSecretRequest{} }
 func (m *ChangeSecretRequest) String() string { return proto.CompactTextString(m) }
 func (*ChangeSecretRequest) ProtoMessage()    {}
 func (*ChangeSecretRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_712ec48c1eaf43a2, []int{25}
+	return fileDescriptor_712ec48c1eaf43a2, []int{36}
 }
 
 func (m *ChangeSecretRequest) XXX_Unmarshal(b []byte) error {
@@ -1330,7 +1872,7 @@ func (m *ChangeSecretResponse) Reset()         { *m = ChangeSecretResponse{} }
 func (m *ChangeSecretResponse) String() string { return proto.CompactTextString(m) }
 func (*ChangeSecretResponse) ProtoMessage()    {}
 func (*ChangeSecretResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_712ec48c1eaf43a2, []int{26}
+	return fileDescriptor_712ec48c1eaf43a2, []int{37}
 }
 
 func (m *ChangeSecretResponse) XXX_Unmarshal(b []byte) error {
@@ -1380,6 +1922,17 @@ func init() {
 	proto.RegisterType((*DeleteResponse)(nil), "auth.DeleteResponse")
 	proto.RegisterType((*ListRequest)(nil), "auth.ListRequest")
 	proto.RegisterType((*ListResponse)(nil), "auth.ListResponse")
+	proto.RegisterType((*ListNamespacesRequest)(nil), "auth.ListNamespacesRequest")
+	proto.RegisterType((*ListNamespacesResponse)(nil), "auth.ListNamespacesResponse")
+	proto.RegisterType((*DeleteNamespaceRequest)(nil), "auth.DeleteNamespaceRequest")
+	proto.RegisterType((*DeleteNamespaceResponse)(nil), "auth.DeleteNamespaceResponse")
+	proto.RegisterType((*Quota)(nil), "auth.Quota")
+	proto.RegisterType((*SetQuotaRequest)(nil), "auth.SetQuotaRequest")
+	proto.RegisterType((*SetQuotaResponse)(nil), "auth.SetQuotaResponse")
+	proto.RegisterType((*ListQuotasRequest)(nil), "auth.ListQuotasRequest")
+	proto.RegisterType((*ListQuotasResponse)(nil), "auth.ListQuotasResponse")
+	proto.RegisterType((*DeleteQuotaRequest)(nil), "auth.DeleteQuotaRequest")
+	proto.RegisterType((*DeleteQuotaResponse)(nil), "auth.DeleteQuotaResponse")
 	proto.RegisterType((*ChangeSecretRequest)(nil), "auth.ChangeSecretRequest")
 	proto.RegisterType((*ChangeSecretResponse)(nil), "auth.ChangeSecretResponse")
 }
@@ -1387,73 +1940,92 @@ func init() {
 func init() { proto.RegisterFile("auth/auth.proto", fileDescriptor_712ec48c1eaf43a2) }
 
 var fileDescriptor_712ec48c1eaf43a2 = []byte{
-	// 1052 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x6e, 0xe3, 0xc4,
-	0x17, 0xaf, 0x63, 0xe7, 0xeb, 0xe4, 0xa3, 0xf9, 0x4f, 0xd3, 0xfe, 0x4d, 0x16, 0x50, 0xeb, 0x45,
-	0x6a, 0x77, 0x91, 0x52, 0x94, 0xd5, 0xc2, 0x6a, 0x2b, 0xb1, 0x2a, 0x6d, 0x54, 0x56, 0x40, 0x2a,
-	0x99, 0x45, 0x20, 0x6e, 0x2a, 0xaf, 0x73, 0xd8, 0x58, 0x4d, 0x6d, 0x63, 0x8f, 0xbb, 0x84, 0x3b,
-	0xee, 0x79, 0x02, 0x1e, 0x00, 0xae, 0xb9, 0xe7, 0x45, 0x78, 0x1a, 0x90, 0x67, 0xce, 0x38, 0x76,
-	0xea, 0x86, 0x22, 0x90, 0xb8, 0xb1, 0x7c, 0x3e, 0xe7, 0xfc, 0x7e, 0x73, 0xce, 0xcc, 0xc0, 0xa6,
-	0x93, 0xf0, 0xd9, 0x61, 0xfa, 0x19, 0x86, 0x51, 0xc0, 0x03, 0x66, 0xa4, 0xff, 0xd6, 0x87, 0xb0,
-	0xf5, 0xa9, 0x17, 0xf3, 0x63, 0xd7, 0x0d, 0x12, 0x9f, 0xc7, 0x36, 0x7e, 0x9b, 0x60, 0xcc, 0xd9,
-	0x3e, 0xd4, 0x83, 0x90, 0x7b, 0x81, 0x1f, 0x9b, 0xda, 0xae, 0x76, 0xd0, 0x1a, 0x75, 0x86, 0x22,
-	0xf4, 0x5c, 0x2a, 0x6d, 0x65, 0xb5, 0x8e, 0xa1, 0x5f, 0x8c, 0x8f, 0xc3, 0xc0, 0x8f, 0x91, 0x3d,
-	0x80, 0x86, 0x43, 0x3a, 0x53, 0xdb, 0xd5, 0x97, 0x19, 0xc8, 0xd3, 0xce, 0xcc, 0xd6, 0x39, 0xf4,
-	0x4f, 0x71, 0x8e, 0x1c, 0x95, 0x89, 0x6a, 0xe8, 0x42, 0xc5, 0x9b, 0x8a, 0xe5, 0x9b, 0x76, 0xc5,
-	0x9b, 0xe6, 0x6b, 0xaa, 0xac, 0xad, 0xe9, 0xff, 0xb0, 0xbd, 0x92, 0x50, 0x16, 0x65, 0xfd, 0xa0,
-	0x41, 0xf5, 0x45, 0x70, 0x89, 0x3e, 0xdb, 0x83, 0xb6, 0xe3, 0xba, 0x18, 0xc7, 0x17, 0x3c, 0x95,
-	0x69, 0x95, 0x96, 0xd4, 0x49, 0x97, 0xfb, 0xd0, 0x89, 0xf0, 0x9b, 0x08, 0xe3, 0x19, 0xf9, 0x54,
-	0x84, 0x4f, 0x9b, 0x94, 0xd2, 0xc9, 0x84, 0xba, 0x1b, 0xa1, 0xc3, 0x71, 0x6a, 0xea, 0xbb, 0xda,
-	0x81, 0x6e, 0x2b, 0x91, 0xed, 0x40, 0x0d, 0xbf, 0x0b, 0xbd, 0x68, 0x61, 0x1a, 0xc2, 0x40, 0x92,
-	0xf5, 0x87, 0x06, 0x75, 0xaa, 0xeb, 0x06, 0x42, 0x06, 0x06, 0x5f, 0x84, 0x48, 0x2b, 0x89, 0x7f,
-	0xf6, 0x01, 0x34, 0xae, 0x90, 0x3b, 0x53, 0x87, 0x3b, 0xa6, 0x21, 0x88, 0xbc, 0x57, 0x20, 0x72,
-	0xf8, 0x19, 0x59, 0xc7, 0x3e, 0x8f, 0x16, 0x76, 0xe6, 0x9c, 0x16, 0x10, 0xbb, 0x41, 0x88, 0xb1,
-	0x59, 0xdd, 0xd5, 0x0f, 0x9a, 0x36, 0x49, 0xa9, 0xde, 0x8b, 0xe3, 0x04, 0x23, 0xb3, 0x26, 0x96,
-	0x21, 0x49, 0xf8, 0xa3, 0x1b, 0x21, 0x37, 0xeb, 0x52, 0x2f, 0xa5, 0xb4, 0x28, 0xdf, 0xb9, 0x42,
-	0xb3, 0x21, 0x8b, 0x4a, 0xff, 0x07, 0x47, 0xd0, 0x29, 0x2c, 0xcb, 0x7a, 0xa0, 0x5f, 0xe2, 0x82,
-	0xa0, 0xa4, 0xbf, 0xac, 0x0f, 0xd5, 0x6b, 0x67, 0x9e, 0x28, 0x30, 0x52, 0x78, 0x5a, 0x79, 0xa2,
-	0x59, 0x13, 0x68, 0xd8, 0x18, 0x07, 0x49, 0xe4, 0x62, 0x96, 0x5c, 0x5b, 0x26, 0x2f, 0x65, 0x61,
-	0x00, 0x0d, 0xf4, 0xa7, 0x61, 0xe0, 0xf9, 0x5c, 0x10, 0xdd, 0xb4, 0x33, 0xd9, 0xfa, 0xad, 0x02,
-	0x9b, 0x67, 0xe8, 0x63, 0xe4, 0x70, 0xbc, 0xad, 0x77, 0x9e, 0xe5, 0x58, 0xd4, 0x05, 0x8b, 0xf7,
-	0x25, 0x8b, 0x2b, 0x81, 0x77, 0x60, 0xd3, 0x58, 0x65, 0x93, 0x58, 0xab, 0xae, 0xb2, 0x26, 0x40,
-	0xd4, 0x8a, 0x20, 0xc2, 0x28, 0xb8, 0xf6, 0xa6, 0x18, 0x11, 0xc7, 0x99, 0x9c, 0x6f, 0xee, 0xc6,
-	0xba, 0xe6, 0xce, 0x18, 0x6b, 0xfe, 0x5b, 0xdb, 0x71, 0x04, 0xbd, 0x25, 0x09, 0x34, 0xbd, 0xfb,
-	0x50, 0xa7, 0xf1, 0x2c, 0x8e, 0xbf, 0x1a, 0x28, 0x65, 0xb5, 0x16, 0xd0, 0x3e, 0x8b, 0x9c, 0xe5,
-	0xcc, 0xf6, 0xa1, 0x2a, 0x88, 0xa1, 0xa5, 0xa5, 0xc0, 0x1e, 0x42, 0x23, 0xa2, 0x1d, 0xa7, 0xd1,
-	0xed, 0xca, 0x7c, 0xaa, 0x0f, 0xec, 0xcc, 0x9e, 0x27, 0x42, 0x5f, 0x3b, 0xe5, 0x9b, 0xd0, 0xa1,
-	0xa5, 0x69, 0xba, 0xbf, 0x87, 0x8e, 0x8d, 0xd7, 0xc1, 0x25, 0xfe, 0x07, 0xc5, 0xf4, 0xa0, 0xab,
-	0xd6, 0xa6, 0x6a, 0xce, 0xa1, 0xfb, 0xdc, 0x8f, 0x43, 0x74, 0xf3, 0xdc, 0xe4, 0x0f, 0x1b, 0x29,
-	0xdc, 0xfd, 0x54, 0x7b, 0x0a, 0x9b, 0x59, 0xc2, 0xbf, 0xbb, 0x4d, 0xbf, 0x68, 0xd0, 0x16, 0x07,
-	0xd6, 0x6d, 0xf3, 0xb1, 0x6c, 0xe3, 0x4a, 0xa1, 0x8d, 0x6f, 0x1c, 0x82, 0x7a, 0xc9, 0x21, 0xb8,
-	0x07, 0x6d, 0x61, 0xbc, 0x28, 0x1c, 0x78, 0x2d, 0xa1, 0x1b, 0x0b, 0x55, 0x1e, 0x65, 0x75, 0x2d,
-	0xca, 0x11, 0x74, 0xa8, 0x50, 0xc2, 0xb8, 0x97, 0x67, 0xad, 0x35, 0x6a, 0xc9, 0x38, 0xe9, 0x23,
-	0x2d, 0xd6, 0x4f, 0x1a, 0x18, 0x76, 0x32, 0xc7, 0x1b, 0xa8, 0xb2, 0x06, 0xa8, 0xdc, 0xd6, 0x00,
-	0xfa, 0x5f, 0x34, 0xc0, 0x3b, 0x50, 0x93, 0x77, 0x82, 0x00, 0xd5, 0x1d, 0xb5, 0x33, 0x82, 0x31,
-	0x8e, 0x6d, 0xb2, 0xc9, 0xc1, 0xf6, 0x82, 0xc8, 0xe3, 0x0b, 0x01, 0xaf, 0x6a, 0x67, 0xb2, 0xb5,
-	0x0f, 0x75, 0x02, 0xc9, 0xde, 0x84, 0x66, 0x3a, 0xae, 0x71, 0xe8, 0xb8, 0xaa, 0x27, 0x97, 0x0a,
-	0xeb, 0x2b, 0xe8, 0x9c, 0x88, 0xbb, 0x43, 0xed, 0xd1, 0xdb, 0x60, 0x44, 0xc9, 0x1c, 0x09, 0x38,
-	0x50, 0x8d, 0xc9, 0x1c, 0x6d, 0xa1, 0xbf, 0x7b, 0xe7, 0xf4, 0xa0, 0xab, 0x32, 0x53, 0x73, 0x7e,
-	0x0c, 0x1d, 0x79, 0x43, 0xfe, 0xe3, 0xbb, 0xb6, 0x07, 0x5d, 0x95, 0x89, 0x72, 0xbf, 0x0f, 0xad,
-	0xf4, 0x45, 0x50, 0xf2, 0x92, 0x58, 0x9f, 0xe9, 0x3d, 0x68, 0xcb, 0x38, 0xda, 0xf8, 0x5d, 0xa8,
-	0xa6, 0x30, 0xd5, 0xf3, 0x21, 0x8f, 0x5f, 0x1a, 0xac, 0x1f, 0x35, 0xd8, 0x3a, 0x99, 0x39, 0xfe,
-	0x2b, 0xfc, 0x5c, 0x74, 0xeb, 0x6d, 0x60, 0xde, 0x02, 0x08, 0xe6, 0xd3, 0x8b, 0x42, 0x83, 0x37,
-	0x83, 0xf9, 0x54, 0x46, 0xa5, 0x66, 0x1f, 0x5f, 0x2b, 0xb3, 0x4e, 0xfb, 0x82, 0xaf, 0xc9, 0x9c,
-	0x03, 0x60, 0xac, 0x05, 0xb0, 0x03, 0xfd, 0x62, 0x35, 0x12, 0xc8, 0xc3, 0x21, 0xd4, 0x64, 0xbf,
-	0xb0, 0x16, 0xd4, 0xbf, 0x98, 0x7c, 0x32, 0x39, 0xff, 0x72, 0xd2, 0xdb, 0x48, 0x85, 0x33, 0xfb,
-	0x78, 0xf2, 0x62, 0x7c, 0xda, 0xd3, 0x18, 0x40, 0xed, 0x74, 0x3c, 0x79, 0x3e, 0x3e, 0xed, 0x55,
-	0x46, 0xbf, 0x6a, 0x60, 0x1c, 0x27, 0x7c, 0xc6, 0x8e, 0xa0, 0xa1, 0x4e, 0x66, 0xb6, 0x5d, 0x7a,
-	0x5d, 0x0d, 0x76, 0x56, 0xd5, 0xb4, 0x09, 0x1b, 0xec, 0x09, 0xd4, 0xe9, 0xb8, 0x60, 0x7d, 0xe9,
-	0x54, 0x3c, 0x8e, 0x06, 0xdb, 0x2b, 0xda, 0x2c, 0x72, 0xa4, 0x1e, 0x49, 0x2c, 0x3f, 0x6b, 0x14,
-	0xb5, 0x55, 0xd0, 0xa9, 0x98, 0xd1, 0xef, 0x1a, 0x34, 0xd4, 0x1b, 0x90, 0x3d, 0x03, 0x23, 0xdd,
-	0x49, 0xf6, 0x86, 0xf4, 0x2d, 0x79, 0x5f, 0x0e, 0x06, 0x65, 0xa6, 0xac, 0x82, 0x13, 0xa8, 0xc9,
-	0xa6, 0x62, 0xe4, 0x57, 0xf6, 0x3e, 0x1c, 0xdc, 0x2b, 0xb5, 0x65, 0x49, 0xce, 0xa0, 0x9d, 0xdf,
-	0x0e, 0x55, 0x4d, 0x49, 0xc3, 0xa8, 0x6a, 0xca, 0x76, 0xcf, 0xda, 0x18, 0xfd, 0xac, 0x41, 0x35,
-	0x6d, 0xbb, 0x98, 0x3d, 0x86, 0x9a, 0x1c, 0x24, 0x46, 0x34, 0x14, 0x06, 0x76, 0xd0, 0x2f, 0x2a,
-	0xb3, 0x4a, 0x1e, 0x67, 0x70, 0xb6, 0xf2, 0x25, 0xaf, 0x84, 0xad, 0x8c, 0xd1, 0x06, 0x3b, 0x24,
-	0x1a, 0xff, 0xb7, 0xe4, 0x4a, 0x85, 0xb0, 0xbc, 0x4a, 0x05, 0x7c, 0xf4, 0xee, 0xd7, 0x0f, 0x5e,
-	0x79, 0x7c, 0x96, 0xbc, 0x1c, 0xba, 0xc1, 0xd5, 0xe1, 0x95, 0xe7, 0x46, 0x01, 0x7d, 0xaf, 0x1f,
-	0x1d, 0x8a, 0x47, 0xbf, 0x78, 0xff, 0x1f, 0xa5, 0x9f, 0x97, 0x35, 0xa1, 0x78, 0xf4, 0x67, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x59, 0x5b, 0x5f, 0xcd, 0x18, 0x0c, 0x00, 0x00,
+	// 1356 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0x0e, 0x45, 0x1d, 0x47, 0x07, 0x2b, 0x6b, 0xd9, 0x66, 0x98, 0x03, 0x6c, 0xe6, 0x07, 0x72,
+	0xf8, 0x01, 0x1b, 0x50, 0x9a, 0x34, 0x88, 0x2f, 0x82, 0xd4, 0x76, 0xdd, 0xa0, 0x89, 0x8c, 0x32,
+	0x29, 0x52, 0xf4, 0xc6, 0x60, 0xa8, 0x4d, 0x4c, 0x44, 0x26, 0x19, 0x72, 0x69, 0x57, 0xbd, 0xeb,
+	0x7d, 0x9f, 0x20, 0x0f, 0xd0, 0x5e, 0xf7, 0xbe, 0xe8, 0x7b, 0xf4, 0x69, 0x5a, 0x70, 0x77, 0x76,
+	0x45, 0x52, 0x94, 0xea, 0xb4, 0x05, 0x7a, 0x23, 0x68, 0x66, 0x76, 0x77, 0xbe, 0xf9, 0xe6, 0xb0,
+	0x2b, 0xc1, 0x8a, 0x93, 0xb0, 0x93, 0x9d, 0xf4, 0x63, 0x3b, 0x8c, 0x02, 0x16, 0x90, 0x6a, 0xfa,
+	0xdd, 0x9a, 0xc0, 0xea, 0x33, 0x2f, 0x66, 0x4f, 0x5c, 0x37, 0x48, 0x7c, 0x16, 0xdb, 0xf4, 0x7d,
+	0x42, 0x63, 0x46, 0x6e, 0x41, 0x23, 0x08, 0x99, 0x17, 0xf8, 0xb1, 0xa1, 0x6d, 0x6a, 0xb7, 0xdb,
+	0xc3, 0xee, 0x36, 0xdf, 0x7a, 0x24, 0x94, 0xb6, 0xb4, 0x92, 0x01, 0xd4, 0x26, 0xde, 0xa9, 0xc7,
+	0x8c, 0xca, 0xa6, 0x76, 0x5b, 0xb7, 0x85, 0x40, 0xd6, 0xa1, 0x1e, 0xbc, 0x79, 0x13, 0x53, 0x66,
+	0xe8, 0x5c, 0x8d, 0x92, 0xf5, 0x0a, 0x06, 0x79, 0x6f, 0x71, 0x18, 0xf8, 0x31, 0x25, 0x77, 0xa0,
+	0xe9, 0xa0, 0xce, 0xd0, 0x36, 0xf5, 0x99, 0x3f, 0x5c, 0x69, 0x2b, 0x73, 0xea, 0x90, 0x05, 0xcc,
+	0x99, 0x48, 0x87, 0x5c, 0xb0, 0x8e, 0x60, 0xb0, 0x4f, 0x27, 0x94, 0x51, 0xb9, 0x01, 0xe3, 0xe8,
+	0x41, 0xc5, 0x1b, 0xf3, 0x10, 0x5a, 0x76, 0xc5, 0x1b, 0x67, 0xe3, 0xaa, 0x2c, 0x8b, 0xcb, 0xda,
+	0x80, 0xb5, 0xc2, 0x81, 0x02, 0xaa, 0xf5, 0x83, 0x06, 0xb5, 0x97, 0xc1, 0x3b, 0xea, 0x93, 0x2d,
+	0xe8, 0x38, 0xae, 0x4b, 0xe3, 0xf8, 0x98, 0xa5, 0x32, 0x7a, 0x69, 0x0b, 0x9d, 0x58, 0x72, 0x13,
+	0xba, 0x11, 0x7d, 0x13, 0xd1, 0xf8, 0x04, 0xd7, 0x54, 0xf8, 0x9a, 0x0e, 0x2a, 0xc5, 0x22, 0x03,
+	0x1a, 0x6e, 0x44, 0x1d, 0x46, 0xc7, 0xc8, 0x96, 0x14, 0x53, 0x1a, 0xe9, 0x77, 0xa1, 0x17, 0x4d,
+	0x8d, 0xaa, 0xa0, 0x51, 0x48, 0xd6, 0x1f, 0x1a, 0x34, 0x10, 0xd7, 0x5c, 0x84, 0x04, 0xaa, 0x6c,
+	0x1a, 0x52, 0xf4, 0xc4, 0xbf, 0x93, 0x4f, 0xa1, 0x79, 0x4a, 0x99, 0x33, 0x76, 0x98, 0x63, 0x54,
+	0x39, 0xbd, 0x57, 0x73, 0xf4, 0x6e, 0x3f, 0x47, 0xeb, 0x81, 0xcf, 0xa2, 0xa9, 0xad, 0x16, 0xa7,
+	0x00, 0x62, 0x37, 0x08, 0x69, 0x6c, 0xd4, 0x36, 0xf5, 0xdb, 0x2d, 0x1b, 0xa5, 0x54, 0xef, 0xc5,
+	0x71, 0x42, 0x23, 0xa3, 0xce, 0xdd, 0xa0, 0xc4, 0xd7, 0x53, 0x37, 0xa2, 0xcc, 0x68, 0x08, 0xbd,
+	0x90, 0x52, 0x50, 0xbe, 0x73, 0x4a, 0x8d, 0xa6, 0x00, 0x95, 0x7e, 0x37, 0x77, 0xa1, 0x9b, 0x73,
+	0x4b, 0xfa, 0xa0, 0xbf, 0xa3, 0x53, 0x0c, 0x25, 0xfd, 0x9a, 0xe6, 0xfa, 0xcc, 0x99, 0x24, 0x32,
+	0x18, 0x21, 0x3c, 0xaa, 0x3c, 0xd4, 0xac, 0x11, 0x34, 0x6d, 0x1a, 0x07, 0x49, 0xe4, 0x52, 0x75,
+	0xb8, 0x36, 0x3b, 0xbc, 0x94, 0x05, 0x13, 0x9a, 0xd4, 0x1f, 0x87, 0x81, 0xe7, 0x8b, 0xb2, 0x6c,
+	0xd9, 0x4a, 0xb6, 0x7e, 0xad, 0xc0, 0xca, 0x21, 0xf5, 0x69, 0xe4, 0x30, 0xba, 0xa8, 0x76, 0x1e,
+	0x67, 0x58, 0xd4, 0x39, 0x8b, 0x37, 0x05, 0x8b, 0x85, 0x8d, 0x17, 0x60, 0xb3, 0x5a, 0x64, 0x13,
+	0x59, 0xab, 0x15, 0x59, 0xe3, 0x41, 0xd4, 0xf3, 0x41, 0x84, 0x51, 0x70, 0xe6, 0x8d, 0x69, 0x84,
+	0x1c, 0x2b, 0x39, 0x5b, 0xdc, 0xcd, 0xa5, 0x4d, 0x2b, 0x19, 0x6b, 0xfd, 0x5b, 0xe9, 0xd8, 0x85,
+	0xfe, 0x8c, 0x04, 0xec, 0xe9, 0x5b, 0xd0, 0xc0, 0xa6, 0xcd, 0x8f, 0x10, 0xd9, 0x50, 0xd2, 0x6a,
+	0x4d, 0xa1, 0x73, 0x18, 0x39, 0xb3, 0x9e, 0x1d, 0x40, 0x8d, 0x13, 0x83, 0xae, 0x85, 0x40, 0xee,
+	0x42, 0x33, 0xc2, 0x8c, 0x63, 0xeb, 0xf6, 0xc4, 0x79, 0xb2, 0x0e, 0x6c, 0x65, 0xcf, 0x12, 0xa1,
+	0x2f, 0xed, 0xf2, 0x15, 0xe8, 0xa2, 0x6b, 0xec, 0xee, 0xef, 0xa1, 0x6b, 0xd3, 0xb3, 0xe0, 0x1d,
+	0xfd, 0x0f, 0xc0, 0xf4, 0xa1, 0x27, 0x7d, 0x23, 0x9a, 0x23, 0xe8, 0x3d, 0xf5, 0xe3, 0x90, 0xba,
+	0x59, 0x6e, 0xb2, 0xc3, 0x46, 0x08, 0x17, 0x9f, 0x6a, 0x8f, 0x60, 0x45, 0x1d, 0xf8, 0xb1, 0x69,
+	0xfa, 0x59, 0x83, 0x0e, 0x1f, 0x58, 0x8b, 0xfa, 0x63, 0x56, 0xc6, 0x95, 0x5c, 0x19, 0xcf, 0x0d,
+	0x41, 0xbd, 0x64, 0x08, 0x6e, 0x41, 0x87, 0x1b, 0x8f, 0x73, 0x03, 0xaf, 0xcd, 0x75, 0x07, 0x5c,
+	0x95, 0x8d, 0xb2, 0xb6, 0x34, 0xca, 0x21, 0x74, 0x11, 0x28, 0xc6, 0xb8, 0x95, 0x65, 0xad, 0x3d,
+	0x6c, 0x8b, 0x7d, 0x62, 0x8d, 0xb0, 0x58, 0x1f, 0x34, 0xa8, 0xda, 0xc9, 0x84, 0xce, 0x45, 0xa5,
+	0x0a, 0xa0, 0xb2, 0xa8, 0x00, 0xf4, 0xbf, 0x28, 0x80, 0xff, 0x41, 0x5d, 0xdc, 0x09, 0x3c, 0xa8,
+	0xde, 0xb0, 0xa3, 0x08, 0xa6, 0x71, 0x6c, 0xa3, 0x4d, 0x34, 0xb6, 0x17, 0x44, 0x1e, 0x9b, 0xf2,
+	0xf0, 0x6a, 0xb6, 0x92, 0xad, 0x5b, 0xd0, 0xc0, 0x20, 0xc9, 0x35, 0x68, 0xa5, 0xed, 0x1a, 0x87,
+	0x8e, 0x2b, 0x6b, 0x72, 0xa6, 0xb0, 0xbe, 0x81, 0xee, 0x1e, 0xbf, 0x3b, 0x64, 0x8e, 0x6e, 0x40,
+	0x35, 0x4a, 0x26, 0x14, 0x03, 0x07, 0xc4, 0x98, 0x4c, 0xa8, 0xcd, 0xf5, 0x17, 0xaf, 0x9c, 0x3e,
+	0xf4, 0xe4, 0xc9, 0x58, 0x9c, 0x5f, 0x40, 0x57, 0xdc, 0x90, 0xff, 0xf8, 0xae, 0xed, 0x43, 0x4f,
+	0x9e, 0x84, 0x67, 0x8f, 0xa1, 0x9d, 0xbe, 0x13, 0x4a, 0x5e, 0x23, 0x95, 0x8b, 0xbd, 0x46, 0xf4,
+	0xf2, 0xd7, 0x48, 0x35, 0xf7, 0x1a, 0xf9, 0x1c, 0x3a, 0xc2, 0x0b, 0x96, 0xc9, 0x26, 0xd4, 0x52,
+	0x52, 0xe4, 0x13, 0x24, 0xcb, 0x96, 0x30, 0x2c, 0x78, 0x7c, 0x1c, 0xc0, 0x5a, 0x7a, 0xce, 0x48,
+	0xa6, 0x21, 0xce, 0x74, 0xab, 0x80, 0xa3, 0x95, 0xc3, 0xa9, 0xe4, 0xe0, 0x8c, 0x60, 0xbd, 0x78,
+	0x0c, 0x02, 0xbb, 0x01, 0xa0, 0x72, 0x2c, 0xd0, 0xb5, 0xec, 0x8c, 0x66, 0x01, 0xac, 0x07, 0xb0,
+	0x2e, 0x68, 0x55, 0x27, 0x4a, 0x5c, 0xcb, 0x8b, 0xe8, 0x0a, 0x6c, 0xcc, 0xed, 0xc3, 0xbc, 0x9c,
+	0x43, 0xed, 0xab, 0x24, 0x60, 0xce, 0xf2, 0x13, 0xd2, 0x17, 0x8d, 0x9c, 0x29, 0xa2, 0x6b, 0xa4,
+	0x48, 0xae, 0x03, 0x84, 0x34, 0x3a, 0x3e, 0xf5, 0xfc, 0x84, 0x51, 0xcc, 0x52, 0x2b, 0xa4, 0xd1,
+	0x73, 0xae, 0x20, 0x1b, 0xd0, 0x48, 0xcd, 0x63, 0x47, 0xbd, 0x78, 0x42, 0x1a, 0xed, 0x3b, 0x53,
+	0xeb, 0x13, 0x58, 0x79, 0x41, 0x19, 0xf7, 0x2d, 0x83, 0xd8, 0x82, 0xda, 0xfb, 0x54, 0xce, 0x37,
+	0xb5, 0x58, 0x22, 0x2c, 0x16, 0x81, 0xfe, 0x6c, 0x17, 0x86, 0x70, 0x0c, 0x97, 0x53, 0x96, 0xb9,
+	0x32, 0xbe, 0x10, 0x21, 0x1f, 0xf9, 0xc6, 0x3d, 0x02, 0x92, 0x75, 0x80, 0x29, 0xbc, 0x09, 0x75,
+	0x8e, 0x49, 0x16, 0x57, 0x0e, 0x2e, 0x9a, 0x16, 0xe4, 0xf1, 0x19, 0x10, 0x91, 0x8f, 0x5c, 0xf8,
+	0x7f, 0x33, 0x03, 0xd6, 0x1a, 0xac, 0xe6, 0x4e, 0x43, 0x5a, 0x7e, 0xd4, 0x60, 0x75, 0xef, 0xc4,
+	0xf1, 0xdf, 0xd2, 0x17, 0x7c, 0x6a, 0x2f, 0x6a, 0xea, 0xeb, 0x00, 0xc1, 0x64, 0x7c, 0x9c, 0x1b,
+	0xf4, 0xad, 0x60, 0x32, 0x16, 0xbb, 0x52, 0xb3, 0x4f, 0xcf, 0xa5, 0x59, 0x47, 0x58, 0xf4, 0x1c,
+	0xcd, 0x99, 0x46, 0xae, 0x2e, 0x1d, 0x09, 0xeb, 0x30, 0xc8, 0xa3, 0x11, 0x30, 0xef, 0x6e, 0x43,
+	0x5d, 0xcc, 0x4d, 0xd2, 0x86, 0xc6, 0xd7, 0xa3, 0x2f, 0x47, 0x47, 0xaf, 0x46, 0xfd, 0x4b, 0xa9,
+	0x70, 0x68, 0x3f, 0x19, 0xbd, 0x3c, 0xd8, 0xef, 0x6b, 0x04, 0xa0, 0xbe, 0x7f, 0x30, 0x7a, 0x7a,
+	0xb0, 0xdf, 0xaf, 0x0c, 0x7f, 0xd1, 0xa0, 0xfa, 0x24, 0x61, 0x27, 0x64, 0x17, 0x9a, 0xf2, 0x85,
+	0x42, 0xd6, 0x4a, 0x9f, 0x6d, 0xe6, 0x7a, 0x51, 0x8d, 0xd4, 0x5c, 0x22, 0x0f, 0xa1, 0x81, 0xd7,
+	0x26, 0x19, 0x88, 0x45, 0xf9, 0x6b, 0xd9, 0x5c, 0x2b, 0x68, 0xd5, 0xce, 0xa1, 0xfc, 0xb1, 0x40,
+	0xb2, 0x77, 0x0e, 0xee, 0x5a, 0xcd, 0xe9, 0xe4, 0x9e, 0xe1, 0xef, 0x1a, 0x34, 0xe5, 0x2f, 0x24,
+	0xf2, 0x18, 0xaa, 0x69, 0x35, 0x91, 0x2b, 0x62, 0x6d, 0xc9, 0x6f, 0x35, 0xd3, 0x2c, 0x33, 0x29,
+	0x04, 0x7b, 0x50, 0x17, 0xf9, 0x26, 0xb8, 0xae, 0xec, 0x77, 0x92, 0x79, 0xb5, 0xd4, 0xa6, 0x0e,
+	0x39, 0x84, 0x4e, 0x36, 0x1d, 0x12, 0x4d, 0x49, 0xc1, 0x98, 0x66, 0x99, 0x49, 0xc5, 0xf6, 0x93,
+	0x06, 0x35, 0x9b, 0x8f, 0xd2, 0xfb, 0x50, 0x17, 0x17, 0x0a, 0x41, 0x1a, 0x72, 0x17, 0x97, 0x39,
+	0xc8, 0x2b, 0x15, 0x92, 0xfb, 0x2a, 0x9c, 0xd5, 0x2c, 0xe4, 0xc2, 0xb6, 0xc2, 0x75, 0x72, 0x89,
+	0xec, 0x20, 0x8d, 0x97, 0x67, 0x5c, 0xc9, 0x2d, 0x24, 0xab, 0x52, 0x40, 0x3f, 0x68, 0x00, 0xb3,
+	0x49, 0x4c, 0xf6, 0x70, 0xff, 0xd5, 0xd9, 0xe2, 0xb9, 0x71, 0x6f, 0x5e, 0x2b, 0x37, 0x66, 0x58,
+	0x94, 0xd8, 0xaf, 0x65, 0x61, 0x16, 0xc7, 0xb3, 0x79, 0x7d, 0x81, 0x55, 0x81, 0xfb, 0x4d, 0x83,
+	0xba, 0x98, 0x2f, 0xe4, 0x01, 0xe8, 0x2f, 0x28, 0x93, 0x25, 0x5d, 0x98, 0x91, 0xe6, 0x7a, 0x51,
+	0xad, 0xb0, 0xec, 0x62, 0x40, 0x1b, 0x33, 0xcc, 0xb9, 0x91, 0x68, 0x1a, 0xf3, 0x06, 0xb5, 0xf9,
+	0xb1, 0x0a, 0xc4, 0xc8, 0x42, 0xcd, 0xb9, 0xbe, 0x52, 0x62, 0x91, 0x07, 0x7c, 0xf6, 0xff, 0x6f,
+	0xef, 0xbc, 0xf5, 0xd8, 0x49, 0xf2, 0x7a, 0xdb, 0x0d, 0x4e, 0x77, 0x4e, 0x3d, 0x37, 0x0a, 0xf0,
+	0xf3, 0xec, 0xde, 0x0e, 0xff, 0x7b, 0x82, 0xff, 0x53, 0xb1, 0x9b, 0x7e, 0xbc, 0xae, 0x73, 0xc5,
+	0xbd, 0x3f, 0x07, 0x00, 0x6e, 0xb3, 0xc7, 0x2d, 0xc2, 0x10, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1853,3 +2425,230 @@ var _Rules_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth/auth.proto",
 }
+
+// NamespacesClient is the client API for Namespaces service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NamespacesClient interface {
+	List(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error)
+	Delete(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*DeleteNamespaceResponse, error)
+}
+
+type namespacesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNamespacesClient(cc *grpc.ClientConn) NamespacesClient {
+	return &namespacesClient{cc}
+}
+
+func (c *namespacesClient) List(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error) {
+	out := new(ListNamespacesResponse)
+	err := c.cc.Invoke(ctx, "/auth.Namespaces/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namespacesClient) Delete(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*DeleteNamespaceResponse, error) {
+	out := new(DeleteNamespaceResponse)
+	err := c.cc.Invoke(ctx, "/auth.Namespaces/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NamespacesServer is the server API for Namespaces service.
+type NamespacesServer interface {
+	List(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	Delete(context.Context, *DeleteNamespaceRequest) (*DeleteNamespaceResponse, error)
+}
+
+func RegisterNamespacesServer(s *grpc.Server, srv NamespacesServer) {
+	s.RegisterService(&_Namespaces_serviceDesc, srv)
+}
+
+func _Namespaces_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespacesServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.Namespaces/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespacesServer).List(ctx, req.(*ListNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namespaces_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespacesServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.Namespaces/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespacesServer).Delete(ctx, req.(*DeleteNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Namespaces_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.Namespaces",
+	HandlerType: (*NamespacesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Namespaces_List_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Namespaces_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auth/auth.proto",
+}
+
+// QuotasClient is the client API for Quotas service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type QuotasClient interface {
+	Set(ctx context.Context, in *SetQuotaRequest, opts ...grpc.CallOption) (*SetQuotaResponse, error)
+	List(ctx context.Context, in *ListQuotasRequest, opts ...grpc.CallOption) (*ListQuotasResponse, error)
+	Delete(ctx context.Context, in *DeleteQuotaRequest, opts ...grpc.CallOption) (*DeleteQuotaResponse, error)
+}
+
+type quotasClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewQuotasClient(cc *grpc.ClientConn) QuotasClient {
+	return &quotasClient{cc}
+}
+
+func (c *quotasClient) Set(ctx context.Context, in *SetQuotaRequest, opts ...grpc.CallOption) (*SetQuotaResponse, error) {
+	out := new(SetQuotaResponse)
+	err := c.cc.Invoke(ctx, "/auth.Quotas/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotasClient) List(ctx context.Context, in *ListQuotasRequest, opts ...grpc.CallOption) (*ListQuotasResponse, error) {
+	out := new(ListQuotasResponse)
+	err := c.cc.Invoke(ctx, "/auth.Quotas/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotasClient) Delete(ctx context.Context, in *DeleteQuotaRequest, opts ...grpc.CallOption) (*DeleteQuotaResponse, error) {
+	out := new(DeleteQuotaResponse)
+	err := c.cc.Invoke(ctx, "/auth.Quotas/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QuotasServer is the server API for Quotas service.
+type QuotasServer interface {
+	Set(context.Context, *SetQuotaRequest) (*SetQuotaResponse, error)
+	List(context.Context, *ListQuotasRequest) (*ListQuotasResponse, error)
+	Delete(context.Context, *DeleteQuotaRequest) (*DeleteQuotaResponse, error)
+}
+
+func RegisterQuotasServer(s *grpc.Server, srv QuotasServer) {
+	s.RegisterService(&_Quotas_serviceDesc, srv)
+}
+
+func _Quotas_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotasServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.Quotas/Set",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotasServer).Set(ctx, req.(*SetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quotas_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuotasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotasServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.Quotas/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotasServer).List(ctx, req.(*ListQuotasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quotas_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotasServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.Quotas/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotasServer).Delete(ctx, req.(*DeleteQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Quotas_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.Quotas",
+	HandlerType: (*QuotasServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Set",
+			Handler:    _Quotas_Set_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Quotas_List_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Quotas_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auth/auth.proto",
+}
@@ -311,3 +311,172 @@ func (h *rulesHandler) Delete(ctx context.Context, in *DeleteRequest, out *Delet
 func (h *rulesHandler) List(ctx context.Context, in *ListRequest, out *ListResponse) error {
 	return h.RulesHandler.List(ctx, in, out)
 }
+
+// Api Endpoints for Namespaces service
+
+func NewNamespacesEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Namespaces service
+
+type NamespacesService interface {
+	List(ctx context.Context, in *ListNamespacesRequest, opts ...client.CallOption) (*ListNamespacesResponse, error)
+	Delete(ctx context.Context, in *DeleteNamespaceRequest, opts ...client.CallOption) (*DeleteNamespaceResponse, error)
+}
+
+type namespacesService struct {
+	c    client.Client
+	name string
+}
+
+func NewNamespacesService(name string, c client.Client) NamespacesService {
+	return &namespacesService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *namespacesService) List(ctx context.Context, in *ListNamespacesRequest, opts ...client.CallOption) (*ListNamespacesResponse, error) {
+	req := c.c.NewRequest(c.name, "Namespaces.List", in)
+	out := new(ListNamespacesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namespacesService) Delete(ctx context.Context, in *DeleteNamespaceRequest, opts ...client.CallOption) (*DeleteNamespaceResponse, error) {
+	req := c.c.NewRequest(c.name, "Namespaces.Delete", in)
+	out := new(DeleteNamespaceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Namespaces service
+
+type NamespacesHandler interface {
+	List(context.Context, *ListNamespacesRequest, *ListNamespacesResponse) error
+	Delete(context.Context, *DeleteNamespaceRequest, *DeleteNamespaceResponse) error
+}
+
+func RegisterNamespacesHandler(s server.Server, hdlr NamespacesHandler, opts ...server.HandlerOption) error {
+	type namespaces interface {
+		List(ctx context.Context, in *ListNamespacesRequest, out *ListNamespacesResponse) error
+		Delete(ctx context.Context, in *DeleteNamespaceRequest, out *DeleteNamespaceResponse) error
+	}
+	type Namespaces struct {
+		namespaces
+	}
+	h := &namespacesHandler{hdlr}
+	return s.Handle(s.NewHandler(&Namespaces{h}, opts...))
+}
+
+type namespacesHandler struct {
+	NamespacesHandler
+}
+
+func (h *namespacesHandler) List(ctx context.Context, in *ListNamespacesRequest, out *ListNamespacesResponse) error {
+	return h.NamespacesHandler.List(ctx, in, out)
+}
+
+func (h *namespacesHandler) Delete(ctx context.Context, in *DeleteNamespaceRequest, out *DeleteNamespaceResponse) error {
+	return h.NamespacesHandler.Delete(ctx, in, out)
+}
+
+// Api Endpoints for Quotas service
+
+func NewQuotasEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Quotas service
+
+type QuotasService interface {
+	Set(ctx context.Context, in *SetQuotaRequest, opts ...client.CallOption) (*SetQuotaResponse, error)
+	List(ctx context.Context, in *ListQuotasRequest, opts ...client.CallOption) (*ListQuotasResponse, error)
+	Delete(ctx context.Context, in *DeleteQuotaRequest, opts ...client.CallOption) (*DeleteQuotaResponse, error)
+}
+
+type quotasService struct {
+	c    client.Client
+	name string
+}
+
+func NewQuotasService(name string, c client.Client) QuotasService {
+	return &quotasService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *quotasService) Set(ctx context.Context, in *SetQuotaRequest, opts ...client.CallOption) (*SetQuotaResponse, error) {
+	req := c.c.NewRequest(c.name, "Quotas.Set", in)
+	out := new(SetQuotaResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotasService) List(ctx context.Context, in *ListQuotasRequest, opts ...client.CallOption) (*ListQuotasResponse, error) {
+	req := c.c.NewRequest(c.name, "Quotas.List", in)
+	out := new(ListQuotasResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotasService) Delete(ctx context.Context, in *DeleteQuotaRequest, opts ...client.CallOption) (*DeleteQuotaResponse, error) {
+	req := c.c.NewRequest(c.name, "Quotas.Delete", in)
+	out := new(DeleteQuotaResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Quotas service
+
+type QuotasHandler interface {
+	Set(context.Context, *SetQuotaRequest, *SetQuotaResponse) error
+	List(context.Context, *ListQuotasRequest, *ListQuotasResponse) error
+	Delete(context.Context, *DeleteQuotaRequest, *DeleteQuotaResponse) error
+}
+
+func RegisterQuotasHandler(s server.Server, hdlr QuotasHandler, opts ...server.HandlerOption) error {
+	type quotas interface {
+		Set(ctx context.Context, in *SetQuotaRequest, out *SetQuotaResponse) error
+		List(ctx context.Context, in *ListQuotasRequest, out *ListQuotasResponse) error
+		Delete(ctx context.Context, in *DeleteQuotaRequest, out *DeleteQuotaResponse) error
+	}
+	type Quotas struct {
+		quotas
+	}
+	h := &quotasHandler{hdlr}
+	return s.Handle(s.NewHandler(&Quotas{h}, opts...))
+}
+
+type quotasHandler struct {
+	QuotasHandler
+}
+
+func (h *quotasHandler) Set(ctx context.Context, in *SetQuotaRequest, out *SetQuotaResponse) error {
+	return h.QuotasHandler.Set(ctx, in, out)
+}
+
+func (h *quotasHandler) List(ctx context.Context, in *ListQuotasRequest, out *ListQuotasResponse) error {
+	return h.QuotasHandler.List(ctx, in, out)
+}
+
+func (h *quotasHandler) Delete(ctx context.Context, in *DeleteQuotaRequest, out *DeleteQuotaResponse) error {
+	return h.QuotasHandler.Delete(ctx, in, out)
+}
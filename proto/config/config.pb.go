@@ -375,6 +375,112 @@ func (m *GetResponse) GetValue() *Value {
 	return nil
 }
 
+type WatchRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// path to watch, the whole config of the namespace if blank
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc332a44e926b360, []int{8}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type WatchResponse struct {
+	// type of change e.g. value.set or value.deleted
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// path which was changed
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// value at the path watched after the change, secrets aren't included
+	Value                *Value   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchResponse) Reset()         { *m = WatchResponse{} }
+func (m *WatchResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchResponse) ProtoMessage()    {}
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc332a44e926b360, []int{9}
+}
+
+func (m *WatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchResponse.Unmarshal(m, b)
+}
+func (m *WatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchResponse.Merge(m, src)
+}
+func (m *WatchResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchResponse.Size(m)
+}
+func (m *WatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchResponse proto.InternalMessageInfo
+
+func (m *WatchResponse) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *WatchResponse) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *WatchResponse) GetValue() *Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
 type ReadRequest struct {
 	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -387,7 +493,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc332a44e926b360, []int{8}
+	return fileDescriptor_cc332a44e926b360, []int{10}
 }
 
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
@@ -433,7 +539,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc332a44e926b360, []int{9}
+	return fileDescriptor_cc332a44e926b360, []int{11}
 }
 
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
@@ -474,7 +580,7 @@ func (m *Change) Reset()         { *m = Change{} }
 func (m *Change) String() string { return proto.CompactTextString(m) }
 func (*Change) ProtoMessage()    {}
 func (*Change) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc332a44e926b360, []int{10}
+	return fileDescriptor_cc332a44e926b360, []int{12}
 }
 
 func (m *Change) XXX_Unmarshal(b []byte) error {
@@ -531,7 +637,7 @@ func (m *ChangeSet) Reset()         { *m = ChangeSet{} }
 func (m *ChangeSet) String() string { return proto.CompactTextString(m) }
 func (*ChangeSet) ProtoMessage()    {}
 func (*ChangeSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc332a44e926b360, []int{11}
+	return fileDescriptor_cc332a44e926b360, []int{13}
 }
 
 func (m *ChangeSet) XXX_Unmarshal(b []byte) error {
@@ -596,6 +702,8 @@ func init() {
 	proto.RegisterType((*DeleteResponse)(nil), "config.DeleteResponse")
 	proto.RegisterType((*GetRequest)(nil), "config.GetRequest")
 	proto.RegisterType((*GetResponse)(nil), "config.GetResponse")
+	proto.RegisterType((*WatchRequest)(nil), "config.WatchRequest")
+	proto.RegisterType((*WatchResponse)(nil), "config.WatchResponse")
 	proto.RegisterType((*ReadRequest)(nil), "config.ReadRequest")
 	proto.RegisterType((*ReadResponse)(nil), "config.ReadResponse")
 	proto.RegisterType((*Change)(nil), "config.Change")
@@ -605,37 +713,39 @@ func init() {
 func init() { proto.RegisterFile("config/config.proto", fileDescriptor_cc332a44e926b360) }
 
 var fileDescriptor_cc332a44e926b360 = []byte{
-	// 465 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xc5, 0x38, 0x71, 0xeb, 0x09, 0x29, 0xb0, 0x85, 0xca, 0x8a, 0x38, 0x84, 0x45, 0x42, 0xe1,
-	0x40, 0x8c, 0x12, 0x09, 0x09, 0x71, 0x40, 0x50, 0xa4, 0x1c, 0x91, 0xd6, 0x12, 0x07, 0x6e, 0xdb,
-	0xed, 0x34, 0xb1, 0x5a, 0x7b, 0x8d, 0xbd, 0xee, 0x7f, 0xe0, 0xc2, 0x2f, 0xe4, 0xc7, 0xa0, 0xfd,
-	0x8a, 0x6d, 0x54, 0x84, 0x14, 0x2e, 0xb6, 0xe7, 0xed, 0xcc, 0xbc, 0x37, 0xe3, 0xa7, 0x85, 0x53,
-	0x21, 0xcb, 0xab, 0x7c, 0x9b, 0xda, 0xd7, 0xb2, 0xaa, 0xa5, 0x92, 0x24, 0xb2, 0x11, 0x5d, 0xc3,
-	0xf8, 0x2b, 0xbf, 0x69, 0x91, 0x10, 0x18, 0x5d, 0x72, 0xc5, 0x93, 0x60, 0x1e, 0x2c, 0x62, 0x66,
-	0xbe, 0xc9, 0x19, 0x44, 0x57, 0xb2, 0x2e, 0xb8, 0x4a, 0xee, 0x1b, 0xd4, 0x45, 0xf4, 0x39, 0x1c,
-	0x7d, 0xa9, 0x54, 0x2e, 0xcb, 0x46, 0xa7, 0x34, 0x28, 0x6a, 0x54, 0xa6, 0xf0, 0x98, 0xb9, 0x88,
-	0xfe, 0x0c, 0x00, 0x32, 0x54, 0x0c, 0xbf, 0xb7, 0xd8, 0x28, 0xf2, 0x0c, 0xe2, 0x92, 0x17, 0xd8,
-	0x54, 0x5c, 0xa0, 0xa3, 0xe8, 0x00, 0xcd, 0x5d, 0x71, 0xb5, 0x73, 0x2c, 0xe6, 0x9b, 0xbc, 0x80,
-	0xf1, 0xad, 0x16, 0x96, 0x84, 0xf3, 0x60, 0x31, 0x59, 0x4d, 0x97, 0x4e, 0xbe, 0x51, 0xcb, 0xec,
-	0x19, 0x79, 0x05, 0x47, 0xd2, 0x0a, 0x49, 0x46, 0x26, 0xed, 0xa1, 0x4f, 0x73, 0xfa, 0x98, 0x3f,
-	0xa7, 0x53, 0x98, 0x18, 0x3d, 0x4d, 0x25, 0xcb, 0x06, 0xe9, 0x47, 0x98, 0x7e, 0xc6, 0x1b, 0x54,
-	0x78, 0xb0, 0x42, 0xfa, 0x08, 0x4e, 0x7c, 0x0b, 0xd7, 0x34, 0x07, 0xd8, 0xfc, 0xcf, 0xcc, 0xbd,
-	0x71, 0xc2, 0x7f, 0x8c, 0xb3, 0x82, 0xc9, 0xa6, 0x1b, 0xa7, 0xdb, 0x56, 0xf0, 0xf7, 0x6d, 0xd1,
-	0x0f, 0x30, 0x61, 0xc8, 0x2f, 0x0f, 0x9f, 0xf8, 0x2d, 0x3c, 0xb0, 0x0d, 0x1c, 0xeb, 0x4b, 0x88,
-	0xc4, 0x8e, 0x97, 0x5b, 0x4f, 0x7b, 0xe2, 0x69, 0xcf, 0x0d, 0xca, 0xdc, 0x29, 0xbd, 0x86, 0xc8,
-	0x22, 0x07, 0xec, 0x24, 0x85, 0xd8, 0x76, 0xc9, 0x50, 0xb9, 0xad, 0x3c, 0x1e, 0xd2, 0xe8, 0xdf,
-	0xda, 0xe5, 0xd0, 0x1f, 0x01, 0xc4, 0xfb, 0x83, 0x3b, 0x6d, 0x3d, 0x83, 0x63, 0xb1, 0x43, 0x71,
-	0xdd, 0xb4, 0x85, 0xa3, 0xda, 0xc7, 0x3d, 0xcb, 0x87, 0x7d, 0xcb, 0x1b, 0x9f, 0xcb, 0xb6, 0x16,
-	0x68, 0x8c, 0x16, 0x33, 0x17, 0xe9, 0x81, 0x54, 0x5e, 0x60, 0xa3, 0x78, 0x51, 0x25, 0xe3, 0x79,
-	0xb0, 0x08, 0x59, 0x07, 0xac, 0x7e, 0x05, 0x10, 0x9d, 0x1b, 0xad, 0xe4, 0x0d, 0x84, 0x1b, 0xad,
-	0xc7, 0x6b, 0xef, 0x8c, 0x32, 0x3b, 0x1d, 0x60, 0xce, 0x4b, 0xf7, 0x74, 0x45, 0xd6, 0xaf, 0xc8,
-	0xee, 0xa8, 0xc8, 0x06, 0x15, 0xef, 0x20, 0xb2, 0x8e, 0x24, 0x4f, 0x7d, 0xc2, 0xc0, 0xe4, 0xb3,
-	0xb3, 0x3f, 0xe1, 0x7d, 0xe9, 0x1a, 0x46, 0xfa, 0xd7, 0x92, 0x7d, 0xe7, 0x9e, 0x53, 0x66, 0x4f,
-	0x86, 0xa0, 0x2f, 0xfa, 0x94, 0x7e, 0x7b, 0xbd, 0xcd, 0xd5, 0xae, 0xbd, 0x58, 0x0a, 0x59, 0xa4,
-	0x45, 0x2e, 0x6a, 0xe9, 0x9e, 0xb7, 0xeb, 0xd4, 0xdc, 0x33, 0xee, 0xd2, 0x79, 0x6f, 0x5f, 0x17,
-	0x91, 0x01, 0xd7, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x00, 0x7b, 0x80, 0x90, 0x93, 0x04, 0x00,
-	0x00,
+	// 509 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6b, 0xd4, 0x40,
+	0x10, 0x37, 0xcd, 0x5d, 0xda, 0xcc, 0xf5, 0xaa, 0x6e, 0x3f, 0x08, 0xc1, 0x87, 0x73, 0x05, 0x39,
+	0x1f, 0xbc, 0x94, 0x3b, 0x10, 0xc5, 0x07, 0x3f, 0x2a, 0xdc, 0xa3, 0x90, 0x80, 0x82, 0xf8, 0xb2,
+	0xdd, 0x6e, 0x2f, 0xa1, 0x4d, 0x36, 0x26, 0x9b, 0x82, 0x7f, 0x82, 0x2f, 0x3e, 0xfa, 0xf7, 0xca,
+	0x7e, 0x5d, 0x92, 0x52, 0x11, 0xd2, 0x97, 0x64, 0xe7, 0x37, 0xf3, 0x9b, 0xf9, 0xcd, 0x66, 0x26,
+	0x70, 0x48, 0x79, 0x71, 0x99, 0x6d, 0x22, 0xfd, 0x5a, 0x94, 0x15, 0x17, 0x1c, 0x79, 0xda, 0xc2,
+	0x2b, 0x18, 0x7f, 0x21, 0xd7, 0x0d, 0x43, 0x08, 0x46, 0x17, 0x44, 0x90, 0xc0, 0x99, 0x39, 0x73,
+	0x3f, 0x56, 0x67, 0x74, 0x02, 0xde, 0x25, 0xaf, 0x72, 0x22, 0x82, 0x1d, 0x85, 0x1a, 0x0b, 0x3f,
+	0x85, 0xdd, 0xcf, 0xa5, 0xc8, 0x78, 0x51, 0xcb, 0x90, 0x9a, 0xd1, 0x8a, 0x09, 0x45, 0xdc, 0x8b,
+	0x8d, 0x85, 0x7f, 0x3b, 0x00, 0x09, 0x13, 0x31, 0xfb, 0xd1, 0xb0, 0x5a, 0xa0, 0x27, 0xe0, 0x17,
+	0x24, 0x67, 0x75, 0x49, 0x28, 0x33, 0x25, 0x5a, 0x40, 0xd6, 0x2e, 0x89, 0x48, 0x4d, 0x15, 0x75,
+	0x46, 0xcf, 0x60, 0x7c, 0x23, 0x85, 0x05, 0xee, 0xcc, 0x99, 0x4f, 0x96, 0xd3, 0x85, 0x91, 0xaf,
+	0xd4, 0xc6, 0xda, 0x87, 0x5e, 0xc0, 0x2e, 0xd7, 0x42, 0x82, 0x91, 0x0a, 0x7b, 0x68, 0xc3, 0x8c,
+	0xbe, 0xd8, 0xfa, 0xf1, 0x14, 0x26, 0x4a, 0x4f, 0x5d, 0xf2, 0xa2, 0x66, 0xf8, 0x03, 0x4c, 0x3f,
+	0xb1, 0x6b, 0x26, 0xd8, 0x60, 0x85, 0xf8, 0x11, 0x1c, 0xd8, 0x14, 0x26, 0x69, 0x06, 0xb0, 0xbe,
+	0x4f, 0xcf, 0x9d, 0x76, 0xdc, 0xff, 0xb4, 0xb3, 0x84, 0xc9, 0xba, 0x6d, 0xa7, 0xbd, 0x2d, 0xe7,
+	0xdf, 0xb7, 0x85, 0xdf, 0xc3, 0xfe, 0x57, 0x22, 0x68, 0x3a, 0xbc, 0xe5, 0xef, 0x30, 0x35, 0x19,
+	0x4c, 0x5d, 0x04, 0x23, 0xf1, 0xb3, 0xb4, 0x6c, 0x75, 0x1e, 0xfc, 0x35, 0xf1, 0x3b, 0x98, 0xc4,
+	0x8c, 0x5c, 0x0c, 0x97, 0xf7, 0x0a, 0xf6, 0x75, 0x02, 0xa3, 0xee, 0x39, 0x78, 0x34, 0x25, 0xc5,
+	0xc6, 0x5e, 0xcb, 0x81, 0x2d, 0x7b, 0xa6, 0xd0, 0xd8, 0x78, 0xf1, 0x15, 0x78, 0x1a, 0x19, 0xf0,
+	0xcd, 0x22, 0xf0, 0x75, 0x96, 0x84, 0x09, 0xd3, 0xdd, 0xe3, 0x7e, 0x19, 0x39, 0x76, 0x6d, 0x0c,
+	0xfe, 0xe5, 0x80, 0xbf, 0x75, 0xdc, 0xb9, 0x76, 0x21, 0xec, 0xd1, 0x94, 0xd1, 0xab, 0xba, 0xc9,
+	0x4d, 0xa9, 0xad, 0xdd, 0x59, 0x49, 0xb7, 0xbb, 0x92, 0x12, 0xaf, 0x79, 0x53, 0x51, 0xa6, 0x16,
+	0xc1, 0x8f, 0x8d, 0x25, 0x1b, 0x12, 0x59, 0xce, 0x6a, 0x41, 0xf2, 0x32, 0x18, 0xcf, 0x9c, 0xb9,
+	0x1b, 0xb7, 0xc0, 0xf2, 0xcf, 0x0e, 0x78, 0x67, 0x4a, 0x2b, 0x3a, 0x05, 0x77, 0x2d, 0xf5, 0x58,
+	0xed, 0xed, 0x20, 0x87, 0x87, 0x3d, 0xcc, 0xcc, 0xfa, 0x03, 0xc9, 0x48, 0xba, 0x8c, 0xe4, 0x0e,
+	0x46, 0xd2, 0x63, 0xbc, 0x01, 0x4f, 0x6f, 0x0c, 0x3a, 0xb6, 0x01, 0xbd, 0x25, 0x0c, 0x4f, 0x6e,
+	0xc3, 0x5b, 0xea, 0x6b, 0x18, 0xab, 0xc9, 0x43, 0x47, 0x36, 0xa4, 0x3b, 0xca, 0xe1, 0xf1, 0x2d,
+	0xd4, 0xf2, 0x4e, 0x1d, 0xb4, 0x82, 0x91, 0x1c, 0x0a, 0xb4, 0xd5, 0xd4, 0x99, 0xb1, 0xf0, 0xa8,
+	0x0f, 0x5a, 0xda, 0xc7, 0xe8, 0xdb, 0xcb, 0x4d, 0x26, 0xd2, 0xe6, 0x7c, 0x41, 0x79, 0x1e, 0xe5,
+	0x19, 0xad, 0xb8, 0x79, 0xde, 0xac, 0x22, 0xf5, 0x07, 0x35, 0xbf, 0xd3, 0xb7, 0xfa, 0x75, 0xee,
+	0x29, 0x70, 0xf5, 0x77, 0x00, 0x02, 0x34, 0x97, 0x97, 0x6d, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -653,6 +763,8 @@ type ConfigClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Watch the changes to the values at a path
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Config_WatchClient, error)
 	// These methods are here for backwards compatibility reasons
 	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
 }
@@ -692,6 +804,38 @@ func (c *configClient) Delete(ctx context.Context, in *DeleteRequest, opts ...gr
 	return out, nil
 }
 
+func (c *configClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Config_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Config_serviceDesc.Streams[0], "/config.Config/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &configWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Config_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type configWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *configWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *configClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
 	out := new(ReadResponse)
 	err := c.cc.Invoke(ctx, "/config.Config/Read", in, out, opts...)
@@ -706,6 +850,8 @@ type ConfigServer interface {
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Set(context.Context, *SetRequest) (*SetResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Watch the changes to the values at a path
+	Watch(*WatchRequest, Config_WatchServer) error
 	// These methods are here for backwards compatibility reasons
 	Read(context.Context, *ReadRequest) (*ReadResponse, error)
 }
@@ -768,6 +914,27 @@ func _Config_Delete_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Config_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigServer).Watch(m, &configWatchServer{stream})
+}
+
+type Config_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type configWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *configWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Config_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReadRequest)
 	if err := dec(in); err != nil {
@@ -807,6 +974,12 @@ var _Config_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Config_Read_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Config_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "config/config.proto",
 }
@@ -45,6 +45,8 @@ type ConfigService interface {
 	Get(ctx context.Context, in *GetRequest, opts ...client.CallOption) (*GetResponse, error)
 	Set(ctx context.Context, in *SetRequest, opts ...client.CallOption) (*SetResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...client.CallOption) (*DeleteResponse, error)
+	// Watch the changes to the values at a path
+	Watch(ctx context.Context, in *WatchRequest, opts ...client.CallOption) (Config_WatchService, error)
 	// These methods are here for backwards compatibility reasons
 	Read(ctx context.Context, in *ReadRequest, opts ...client.CallOption) (*ReadResponse, error)
 }
@@ -91,6 +93,55 @@ func (c *configService) Delete(ctx context.Context, in *DeleteRequest, opts ...c
 	return out, nil
 }
 
+func (c *configService) Watch(ctx context.Context, in *WatchRequest, opts ...client.CallOption) (Config_WatchService, error) {
+	req := c.c.NewRequest(c.name, "Config.Watch", &WatchRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &configServiceWatch{stream}, nil
+}
+
+type Config_WatchService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*WatchResponse, error)
+}
+
+type configServiceWatch struct {
+	stream client.Stream
+}
+
+func (x *configServiceWatch) Close() error {
+	return x.stream.Close()
+}
+
+func (x *configServiceWatch) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *configServiceWatch) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *configServiceWatch) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *configServiceWatch) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *configService) Read(ctx context.Context, in *ReadRequest, opts ...client.CallOption) (*ReadResponse, error) {
 	req := c.c.NewRequest(c.name, "Config.Read", in)
 	out := new(ReadResponse)
@@ -107,6 +158,8 @@ type ConfigHandler interface {
 	Get(context.Context, *GetRequest, *GetResponse) error
 	Set(context.Context, *SetRequest, *SetResponse) error
 	Delete(context.Context, *DeleteRequest, *DeleteResponse) error
+	// Watch the changes to the values at a path
+	Watch(context.Context, *WatchRequest, Config_WatchStream) error
 	// These methods are here for backwards compatibility reasons
 	Read(context.Context, *ReadRequest, *ReadResponse) error
 }
@@ -116,6 +169,7 @@ func RegisterConfigHandler(s server.Server, hdlr ConfigHandler, opts ...server.H
 		Get(ctx context.Context, in *GetRequest, out *GetResponse) error
 		Set(ctx context.Context, in *SetRequest, out *SetResponse) error
 		Delete(ctx context.Context, in *DeleteRequest, out *DeleteResponse) error
+		Watch(ctx context.Context, stream server.Stream) error
 		Read(ctx context.Context, in *ReadRequest, out *ReadResponse) error
 	}
 	type Config struct {
@@ -141,6 +195,46 @@ func (h *configHandler) Delete(ctx context.Context, in *DeleteRequest, out *Dele
 	return h.ConfigHandler.Delete(ctx, in, out)
 }
 
+func (h *configHandler) Watch(ctx context.Context, stream server.Stream) error {
+	m := new(WatchRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.ConfigHandler.Watch(ctx, m, &configWatchStream{stream})
+}
+
+type Config_WatchStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*WatchResponse) error
+}
+
+type configWatchStream struct {
+	stream server.Stream
+}
+
+func (x *configWatchStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *configWatchStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *configWatchStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *configWatchStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *configWatchStream) Send(m *WatchResponse) error {
+	return x.stream.Send(m)
+}
+
 func (h *configHandler) Read(ctx context.Context, in *ReadRequest, out *ReadResponse) error {
 	return h.ConfigHandler.Read(ctx, in, out)
 }
@@ -48,6 +48,9 @@ func (SpanType) EnumDescriptor() ([]byte, []int) {
 }
 
 type HealthRequest struct {
+	// only check the process is up without running the health checks, used when a service
+	// pings a service it depends on so the checks don't cascade
+	Shallow              bool     `protobuf:"varint,1,opt,name=shallow,proto3" json:"shallow,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -78,12 +81,21 @@ func (m *HealthRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
 
+func (m *HealthRequest) GetShallow() bool {
+	if m != nil {
+		return m.Shallow
+	}
+	return false
+}
+
 type HealthResponse struct {
-	// default: ok
-	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// ok if every check passed, otherwise unhealthy
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// the results of the health checks
+	Checks               []*HealthCheck `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
@@ -118,6 +130,80 @@ func (m *HealthResponse) GetStatus() string {
 	return ""
 }
 
+func (m *HealthResponse) GetChecks() []*HealthCheck {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+// HealthCheck is the result of a health check
+type HealthCheck struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// ok or failing
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// error the check failed with
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// duration of the check in nanoseconds
+	Duration             int64    `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthCheck) Reset()         { *m = HealthCheck{} }
+func (m *HealthCheck) String() string { return proto.CompactTextString(m) }
+func (*HealthCheck) ProtoMessage()    {}
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{2}
+}
+
+func (m *HealthCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthCheck.Unmarshal(m, b)
+}
+func (m *HealthCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthCheck.Marshal(b, m, deterministic)
+}
+func (m *HealthCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthCheck.Merge(m, src)
+}
+func (m *HealthCheck) XXX_Size() int {
+	return xxx_messageInfo_HealthCheck.Size(m)
+}
+func (m *HealthCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthCheck proto.InternalMessageInfo
+
+func (m *HealthCheck) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
 type StatsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -128,7 +214,7 @@ func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ae24eab94cb53d5, []int{2}
+	return fileDescriptor_5ae24eab94cb53d5, []int{3}
 }
 
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
@@ -165,17 +251,21 @@ type StatsResponse struct {
 	// total number of requests
 	Requests uint64 `protobuf:"varint,7,opt,name=requests,proto3" json:"requests,omitempty"`
 	// total number of errors
-	Errors               uint64   `protobuf:"varint,8,opt,name=errors,proto3" json:"errors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Errors uint64 `protobuf:"varint,8,opt,name=errors,proto3" json:"errors,omitempty"`
+	// stats of the requests to each endpoint
+	Endpoints map[string]*EndpointStats `protobuf:"bytes,9,rep,name=endpoints,proto3" json:"endpoints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// upper bounds in seconds of the latency buckets of the endpoints
+	LatencyBuckets       []float64 `protobuf:"fixed64,10,rep,packed,name=latency_buckets,json=latencyBuckets,proto3" json:"latency_buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
 func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()    {}
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ae24eab94cb53d5, []int{3}
+	return fileDescriptor_5ae24eab94cb53d5, []int{4}
 }
 
 func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
@@ -252,6 +342,78 @@ func (m *StatsResponse) GetErrors() uint64 {
 	return 0
 }
 
+func (m *StatsResponse) GetEndpoints() map[string]*EndpointStats {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+func (m *StatsResponse) GetLatencyBuckets() []float64 {
+	if m != nil {
+		return m.LatencyBuckets
+	}
+	return nil
+}
+
+type EndpointStats struct {
+	// total number of requests
+	Requests uint64 `protobuf:"varint,1,opt,name=requests,proto3" json:"requests,omitempty"`
+	// total number of errors
+	Errors uint64 `protobuf:"varint,2,opt,name=errors,proto3" json:"errors,omitempty"`
+	// total number of requests served within each latency bucket
+	Latency              []uint64 `protobuf:"varint,3,rep,packed,name=latency,proto3" json:"latency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndpointStats) Reset()         { *m = EndpointStats{} }
+func (m *EndpointStats) String() string { return proto.CompactTextString(m) }
+func (*EndpointStats) ProtoMessage()    {}
+func (*EndpointStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{5}
+}
+
+func (m *EndpointStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndpointStats.Unmarshal(m, b)
+}
+func (m *EndpointStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndpointStats.Marshal(b, m, deterministic)
+}
+func (m *EndpointStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointStats.Merge(m, src)
+}
+func (m *EndpointStats) XXX_Size() int {
+	return xxx_messageInfo_EndpointStats.Size(m)
+}
+func (m *EndpointStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointStats proto.InternalMessageInfo
+
+func (m *EndpointStats) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *EndpointStats) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *EndpointStats) GetLatency() []uint64 {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
+
 // LogRequest requests service logs
 type LogRequest struct {
 	// count of records to request
@@ -269,7 +431,7 @@ func (m *LogRequest) Reset()         { *m = LogRequest{} }
 func (m *LogRequest) String() string { return proto.CompactTextString(m) }
 func (*LogRequest) ProtoMessage()    {}
 func (*LogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ae24eab94cb53d5, []int{4}
+	return fileDescriptor_5ae24eab94cb53d5, []int{6}
 }
 
 func (m *LogRequest) XXX_Unmarshal(b []byte) error {
@@ -316,7 +478,7 @@ func (m *LogResponse) Reset()         { *m = LogResponse{} }
 func (m *LogResponse) String() string { return proto.CompactTextString(m) }
 func (*LogResponse) ProtoMessage()    {}
 func (*LogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ae24eab94cb53d5, []int{5}
+	return fileDescriptor_5ae24eab94cb53d5, []int{7}
 }
 
 func (m *LogResponse) XXX_Unmarshal(b []byte) error {
@@ -361,7 +523,7 @@ func (m *Record) Reset()         { *m = Record{} }
 func (m *Record) String() string { return proto.CompactTextString(m) }
 func (*Record) ProtoMessage()    {}
 func (*Record) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ae24eab94cb53d5, []int{6}
+	return fileDescriptor_5ae24eab94cb53d5, []int{8}
 }
 
 func (m *Record) XXX_Unmarshal(b []byte) error {
@@ -415,7 +577,7 @@ func (m *TraceRequest) Reset()         { *m = TraceRequest{} }
 func (m *TraceRequest) String() string { return proto.CompactTextString(m) }
 func (*TraceRequest) ProtoMessage()    {}
 func (*TraceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ae24eab94cb53d5, []int{7}
+	return fileDescriptor_5ae24eab94cb53d5, []int{9}
 }
 
 func (m *TraceRequest) XXX_Unmarshal(b []byte) error {
@@ -454,7 +616,7 @@ func (m *TraceResponse) Reset()         { *m = TraceResponse{} }
 func (m *TraceResponse) String() string { return proto.CompactTextString(m) }
 func (*TraceResponse) ProtoMessage()    {}
 func (*TraceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ae24eab94cb53d5, []int{8}
+	return fileDescriptor_5ae24eab94cb53d5, []int{10}
 }
 
 func (m *TraceResponse) XXX_Unmarshal(b []byte) error {
@@ -507,7 +669,7 @@ func (m *Span) Reset()         { *m = Span{} }
 func (m *Span) String() string { return proto.CompactTextString(m) }
 func (*Span) ProtoMessage()    {}
 func (*Span) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ae24eab94cb53d5, []int{9}
+	return fileDescriptor_5ae24eab94cb53d5, []int{11}
 }
 
 func (m *Span) XXX_Unmarshal(b []byte) error {
@@ -584,232 +746,4778 @@ func (m *Span) GetType() SpanType {
 	return SpanType_INBOUND
 }
 
-func init() {
-	proto.RegisterEnum("debug.SpanType", SpanType_name, SpanType_value)
-	proto.RegisterType((*HealthRequest)(nil), "debug.HealthRequest")
-	proto.RegisterType((*HealthResponse)(nil), "debug.HealthResponse")
-	proto.RegisterType((*StatsRequest)(nil), "debug.StatsRequest")
-	proto.RegisterType((*StatsResponse)(nil), "debug.StatsResponse")
-	proto.RegisterType((*LogRequest)(nil), "debug.LogRequest")
-	proto.RegisterType((*LogResponse)(nil), "debug.LogResponse")
-	proto.RegisterType((*Record)(nil), "debug.Record")
-	proto.RegisterMapType((map[string]string)(nil), "debug.Record.MetadataEntry")
-	proto.RegisterType((*TraceRequest)(nil), "debug.TraceRequest")
-	proto.RegisterType((*TraceResponse)(nil), "debug.TraceResponse")
-	proto.RegisterType((*Span)(nil), "debug.Span")
-	proto.RegisterMapType((map[string]string)(nil), "debug.Span.MetadataEntry")
+// ProfileRequest requests a profile of the service
+type ProfileRequest struct {
+	// type of profile e.g. cpu, heap, goroutine
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// duration of a cpu profile in seconds
+	Duration             int64    `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("debug/debug.proto", fileDescriptor_5ae24eab94cb53d5) }
-
-var fileDescriptor_5ae24eab94cb53d5 = []byte{
-	// 623 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xd3, 0x4c,
-	0x10, 0xad, 0xed, 0x38, 0x71, 0x26, 0x4d, 0xda, 0xee, 0xd7, 0x0f, 0x19, 0x83, 0x10, 0x18, 0x21,
-	0x2a, 0x10, 0xa9, 0x94, 0x02, 0xad, 0xe8, 0x5d, 0x55, 0x24, 0x90, 0x4a, 0x2b, 0x6d, 0xdb, 0x1b,
-	0xee, 0x36, 0xf6, 0xca, 0xb1, 0xa8, 0xbd, 0x66, 0x77, 0x5d, 0x29, 0x4f, 0xc4, 0x1b, 0xf0, 0x26,
-	0xdc, 0xf1, 0x30, 0x68, 0x7f, 0xec, 0xda, 0x20, 0xae, 0xb8, 0xb1, 0xf6, 0x9c, 0xf1, 0x99, 0x9d,
-	0x39, 0x3b, 0x1a, 0xd8, 0x49, 0xe9, 0xb2, 0xce, 0xf6, 0xf5, 0x77, 0x5e, 0x71, 0x26, 0x19, 0xf2,
-	0x35, 0x88, 0xb7, 0x60, 0xfa, 0x81, 0x92, 0x1b, 0xb9, 0xc2, 0xf4, 0x6b, 0x4d, 0x85, 0x8c, 0xf7,
-	0x60, 0xd6, 0x10, 0xa2, 0x62, 0xa5, 0xa0, 0xe8, 0x1e, 0x0c, 0x85, 0x24, 0xb2, 0x16, 0xa1, 0xf3,
-	0xd8, 0xd9, 0x1b, 0x63, 0x8b, 0xe2, 0x19, 0x6c, 0x5e, 0x4a, 0x22, 0x45, 0xa3, 0xfc, 0xe1, 0xc0,
-	0xd4, 0x12, 0x56, 0xf9, 0x10, 0xc6, 0x32, 0x2f, 0xa8, 0x90, 0xa4, 0xa8, 0xb4, 0x78, 0x80, 0xef,
-	0x08, 0x14, 0xc2, 0x48, 0x48, 0xc2, 0x25, 0x4d, 0x43, 0x57, 0xc7, 0x1a, 0xa8, 0x6e, 0xac, 0x2b,
-	0xf5, 0x63, 0xe8, 0xe9, 0x80, 0x45, 0x8a, 0x2f, 0x68, 0xc1, 0xf8, 0x3a, 0x1c, 0x18, 0xde, 0x20,
-	0x95, 0x49, 0xae, 0x38, 0x25, 0xa9, 0x08, 0x7d, 0x93, 0xc9, 0x42, 0x34, 0x03, 0x37, 0x4b, 0xc2,
-	0xa1, 0x26, 0xdd, 0x2c, 0x41, 0x11, 0x04, 0xdc, 0x94, 0x2b, 0xc2, 0x91, 0x66, 0x5b, 0xac, 0xb2,
-	0x53, 0xce, 0x19, 0x17, 0x61, 0x60, 0xb2, 0x1b, 0x14, 0x1f, 0x01, 0x9c, 0xb1, 0xcc, 0x76, 0x89,
-	0x76, 0xc1, 0x4f, 0x58, 0x5d, 0x4a, 0xdd, 0x8f, 0x87, 0x0d, 0x50, 0xac, 0xc8, 0xcb, 0x84, 0xea,
-	0x4e, 0x3c, 0x6c, 0x40, 0xfc, 0x16, 0x26, 0x5a, 0x69, 0xed, 0x78, 0x0e, 0x23, 0x4e, 0x13, 0xc6,
-	0x53, 0xe5, 0xa4, 0xb7, 0x37, 0x59, 0x4c, 0xe7, 0xe6, 0x45, 0xb0, 0x66, 0x71, 0x13, 0x8d, 0xbf,
-	0x3b, 0x30, 0x34, 0xdc, 0x9f, 0x16, 0x7a, 0x5d, 0x0b, 0x0f, 0x21, 0x28, 0xa8, 0x24, 0x29, 0x91,
-	0x24, 0x74, 0x75, 0xca, 0x07, 0xbd, 0x94, 0xf3, 0x4f, 0x36, 0xfa, 0xbe, 0x94, 0x7c, 0x8d, 0xdb,
-	0x9f, 0x95, 0x63, 0x05, 0x15, 0x82, 0x64, 0xc6, 0xe2, 0x31, 0x6e, 0x60, 0x74, 0x0c, 0xd3, 0x9e,
-	0x08, 0x6d, 0x83, 0xf7, 0x85, 0xae, 0xed, 0xdb, 0xab, 0xa3, 0x6a, 0xf6, 0x96, 0xdc, 0xd4, 0xa6,
-	0xd9, 0x31, 0x36, 0xe0, 0x9d, 0x7b, 0xe4, 0xc4, 0x8f, 0x60, 0xf3, 0x8a, 0x93, 0x84, 0x36, 0x66,
-	0xcd, 0xc0, 0xcd, 0x53, 0x2b, 0x75, 0xf3, 0x34, 0x5e, 0xc0, 0xd4, 0xc6, 0xad, 0x25, 0x4f, 0xc0,
-	0x17, 0x15, 0x29, 0x1b, 0x43, 0x26, 0xb6, 0xfa, 0xcb, 0x8a, 0x94, 0xd8, 0x44, 0xe2, 0x6f, 0x2e,
-	0x0c, 0x14, 0x56, 0xd7, 0x4a, 0x25, 0xb6, 0xf9, 0x0c, 0xb0, 0x57, 0xb8, 0xcd, 0x15, 0xea, 0x15,
-	0x2b, 0xc2, 0x69, 0x29, 0x6d, 0x63, 0x16, 0x21, 0x04, 0x83, 0x92, 0x14, 0x54, 0x4f, 0xce, 0x18,
-	0xeb, 0x73, 0x77, 0x02, 0xfd, 0xfe, 0x04, 0x46, 0x10, 0xa4, 0x35, 0x27, 0x32, 0x67, 0xa5, 0x9d,
-	0x9e, 0x16, 0xa3, 0x37, 0x1d, 0xd3, 0x47, 0xba, 0xec, 0xfb, 0x9d, 0xb2, 0xff, 0x6a, 0xf9, 0x53,
-	0x18, 0xc8, 0x75, 0x45, 0xf5, 0x70, 0xcd, 0x16, 0x5b, 0x1d, 0xc9, 0xd5, 0xba, 0xa2, 0x58, 0x07,
-	0xff, 0xc9, 0xfd, 0x17, 0xcf, 0x20, 0x68, 0xd2, 0xa1, 0x09, 0x8c, 0x3e, 0x9e, 0x9f, 0x5c, 0x5c,
-	0x9f, 0x9f, 0x6e, 0x6f, 0xa0, 0x4d, 0x08, 0x2e, 0xae, 0xaf, 0x0c, 0x72, 0x16, 0x3f, 0x1d, 0xf0,
-	0x4f, 0xd5, 0xe5, 0x68, 0x0e, 0xde, 0x19, 0xcb, 0xd0, 0x8e, 0xad, 0xe5, 0x6e, 0xca, 0x23, 0xd4,
-	0xa5, 0xcc, 0x5b, 0xc5, 0x1b, 0xe8, 0x10, 0x86, 0x66, 0x37, 0xa0, 0x5d, 0x1b, 0xef, 0xed, 0x8e,
-	0xe8, 0xff, 0xdf, 0xd8, 0x56, 0xf8, 0x1a, 0x7c, 0xbd, 0x19, 0xd0, 0x7f, 0x4d, 0xdb, 0x9d, 0xc5,
-	0x11, 0xed, 0xf6, 0xc9, 0xae, 0x4a, 0x4f, 0x4b, 0xab, 0xea, 0xce, 0x56, 0xab, 0xea, 0x0d, 0x54,
-	0xbc, 0x71, 0xf2, 0xea, 0xf3, 0xcb, 0x2c, 0x97, 0xab, 0x7a, 0x39, 0x4f, 0x58, 0xb1, 0x5f, 0xe4,
-	0x09, 0x67, 0xf6, 0x7b, 0x7b, 0x60, 0x36, 0xe0, 0xbe, 0xde, 0x80, 0xc7, 0xfa, 0xbc, 0x1c, 0x6a,
-	0x70, 0xf0, 0x2b, 0x00, 0x00, 0xff, 0xff, 0x59, 0x82, 0x51, 0x15, 0x23, 0x05, 0x00, 0x00,
+func (m *ProfileRequest) Reset()         { *m = ProfileRequest{} }
+func (m *ProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*ProfileRequest) ProtoMessage()    {}
+func (*ProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{12}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *ProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileRequest.Unmarshal(m, b)
+}
+func (m *ProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileRequest.Marshal(b, m, deterministic)
+}
+func (m *ProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileRequest.Merge(m, src)
+}
+func (m *ProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_ProfileRequest.Size(m)
+}
+func (m *ProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_ProfileRequest proto.InternalMessageInfo
 
-// DebugClient is the client API for Debug service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DebugClient interface {
-	Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
-	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
-	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
-	Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (*TraceResponse, error)
+func (m *ProfileRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
 }
 
-type debugClient struct {
-	cc *grpc.ClientConn
+func (m *ProfileRequest) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
 }
 
-func NewDebugClient(cc *grpc.ClientConn) DebugClient {
-	return &debugClient{cc}
+type ProfileResponse struct {
+	// the profile in pprof format
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *debugClient) Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error) {
-	out := new(LogResponse)
-	err := c.cc.Invoke(ctx, "/debug.Debug/Log", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ProfileResponse) Reset()         { *m = ProfileResponse{} }
+func (m *ProfileResponse) String() string { return proto.CompactTextString(m) }
+func (*ProfileResponse) ProtoMessage()    {}
+func (*ProfileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{13}
 }
 
-func (c *debugClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
-	out := new(HealthResponse)
-	err := c.cc.Invoke(ctx, "/debug.Debug/Health", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ProfileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileResponse.Unmarshal(m, b)
+}
+func (m *ProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileResponse.Marshal(b, m, deterministic)
+}
+func (m *ProfileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileResponse.Merge(m, src)
+}
+func (m *ProfileResponse) XXX_Size() int {
+	return xxx_messageInfo_ProfileResponse.Size(m)
+}
+func (m *ProfileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileResponse.DiscardUnknown(m)
 }
 
-func (c *debugClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
-	out := new(StatsResponse)
-	err := c.cc.Invoke(ctx, "/debug.Debug/Stats", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ProfileResponse proto.InternalMessageInfo
+
+func (m *ProfileResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return out, nil
+	return nil
 }
 
-func (c *debugClient) Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (*TraceResponse, error) {
-	out := new(TraceResponse)
-	err := c.cc.Invoke(ctx, "/debug.Debug/Trace", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type DumpRequest struct {
+	// type of dump, goroutine for the stacks of every goroutine or heap for a full heap dump
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// DebugServer is the server API for Debug service.
-type DebugServer interface {
-	Log(context.Context, *LogRequest) (*LogResponse, error)
-	Health(context.Context, *HealthRequest) (*HealthResponse, error)
-	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
-	Trace(context.Context, *TraceRequest) (*TraceResponse, error)
+func (m *DumpRequest) Reset()         { *m = DumpRequest{} }
+func (m *DumpRequest) String() string { return proto.CompactTextString(m) }
+func (*DumpRequest) ProtoMessage()    {}
+func (*DumpRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{14}
 }
 
-func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
-	s.RegisterService(&_Debug_serviceDesc, srv)
+func (m *DumpRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpRequest.Unmarshal(m, b)
+}
+func (m *DumpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpRequest.Marshal(b, m, deterministic)
+}
+func (m *DumpRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpRequest.Merge(m, src)
+}
+func (m *DumpRequest) XXX_Size() int {
+	return xxx_messageInfo_DumpRequest.Size(m)
+}
+func (m *DumpRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpRequest.DiscardUnknown(m)
 }
 
-func _Debug_Log_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LogRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DebugServer).Log(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/debug.Debug/Log",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DebugServer).Log(ctx, req.(*LogRequest))
+var xxx_messageInfo_DumpRequest proto.InternalMessageInfo
+
+func (m *DumpRequest) GetType() string {
+	if m != nil {
+		return m.Type
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Debug_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HealthRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DebugServer).Health(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/debug.Debug/Health",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DebugServer).Health(ctx, req.(*HealthRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+// DumpResponse is a chunk of the dump, the chunks are streamed in order
+type DumpResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _Debug_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func (m *DumpResponse) Reset()         { *m = DumpResponse{} }
+func (m *DumpResponse) String() string { return proto.CompactTextString(m) }
+func (*DumpResponse) ProtoMessage()    {}
+func (*DumpResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{15}
+}
+
+func (m *DumpResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpResponse.Unmarshal(m, b)
+}
+func (m *DumpResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpResponse.Marshal(b, m, deterministic)
+}
+func (m *DumpResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpResponse.Merge(m, src)
+}
+func (m *DumpResponse) XXX_Size() int {
+	return xxx_messageInfo_DumpResponse.Size(m)
+}
+func (m *DumpResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpResponse proto.InternalMessageInfo
+
+func (m *DumpResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type BuildInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BuildInfoRequest) Reset()         { *m = BuildInfoRequest{} }
+func (m *BuildInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*BuildInfoRequest) ProtoMessage()    {}
+func (*BuildInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{16}
+}
+
+func (m *BuildInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuildInfoRequest.Unmarshal(m, b)
+}
+func (m *BuildInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuildInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *BuildInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuildInfoRequest.Merge(m, src)
+}
+func (m *BuildInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_BuildInfoRequest.Size(m)
+}
+func (m *BuildInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuildInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuildInfoRequest proto.InternalMessageInfo
+
+// BuildInfoResponse is the build metadata of the micro binary the service runs with
+type BuildInfoResponse struct {
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// git commit the binary was built from
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// date the binary was built
+	Date string `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	// version of go the binary was built with
+	GoVersion string `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	Os        string `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`
+	Arch      string `protobuf:"bytes,6,opt,name=arch,proto3" json:"arch,omitempty"`
+	// names of the plugins registered
+	Plugins              []string `protobuf:"bytes,7,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BuildInfoResponse) Reset()         { *m = BuildInfoResponse{} }
+func (m *BuildInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*BuildInfoResponse) ProtoMessage()    {}
+func (*BuildInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{17}
+}
+
+func (m *BuildInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuildInfoResponse.Unmarshal(m, b)
+}
+func (m *BuildInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuildInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *BuildInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuildInfoResponse.Merge(m, src)
+}
+func (m *BuildInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_BuildInfoResponse.Size(m)
+}
+func (m *BuildInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuildInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuildInfoResponse proto.InternalMessageInfo
+
+func (m *BuildInfoResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *BuildInfoResponse) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *BuildInfoResponse) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *BuildInfoResponse) GetGoVersion() string {
+	if m != nil {
+		return m.GoVersion
+	}
+	return ""
+}
+
+func (m *BuildInfoResponse) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *BuildInfoResponse) GetArch() string {
+	if m != nil {
+		return m.Arch
+	}
+	return ""
+}
+
+func (m *BuildInfoResponse) GetPlugins() []string {
+	if m != nil {
+		return m.Plugins
+	}
+	return nil
+}
+
+// ProfileInfo describes a profile which was collected
+type ProfileInfo struct {
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// id of the node the profile was collected from
+	Node string `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	// type of profile e.g. cpu, heap
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// unix timestamp the profile was collected at
+	Timestamp int64 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// size of the profile in bytes
+	Size                 int64    `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProfileInfo) Reset()         { *m = ProfileInfo{} }
+func (m *ProfileInfo) String() string { return proto.CompactTextString(m) }
+func (*ProfileInfo) ProtoMessage()    {}
+func (*ProfileInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{18}
+}
+
+func (m *ProfileInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileInfo.Unmarshal(m, b)
+}
+func (m *ProfileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileInfo.Marshal(b, m, deterministic)
+}
+func (m *ProfileInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileInfo.Merge(m, src)
+}
+func (m *ProfileInfo) XXX_Size() int {
+	return xxx_messageInfo_ProfileInfo.Size(m)
+}
+func (m *ProfileInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfileInfo proto.InternalMessageInfo
+
+func (m *ProfileInfo) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ProfileInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ProfileInfo) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *ProfileInfo) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ProfileInfo) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ProfileInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type ListProfilesRequest struct {
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// type of profile, blank for all
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListProfilesRequest) Reset()         { *m = ListProfilesRequest{} }
+func (m *ListProfilesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListProfilesRequest) ProtoMessage()    {}
+func (*ListProfilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{19}
+}
+
+func (m *ListProfilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListProfilesRequest.Unmarshal(m, b)
+}
+func (m *ListProfilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListProfilesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListProfilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListProfilesRequest.Merge(m, src)
+}
+func (m *ListProfilesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListProfilesRequest.Size(m)
+}
+func (m *ListProfilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListProfilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListProfilesRequest proto.InternalMessageInfo
+
+func (m *ListProfilesRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ListProfilesRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+type ListProfilesResponse struct {
+	Profiles             []*ProfileInfo `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListProfilesResponse) Reset()         { *m = ListProfilesResponse{} }
+func (m *ListProfilesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListProfilesResponse) ProtoMessage()    {}
+func (*ListProfilesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{20}
+}
+
+func (m *ListProfilesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListProfilesResponse.Unmarshal(m, b)
+}
+func (m *ListProfilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListProfilesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListProfilesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListProfilesResponse.Merge(m, src)
+}
+func (m *ListProfilesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListProfilesResponse.Size(m)
+}
+func (m *ListProfilesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListProfilesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListProfilesResponse proto.InternalMessageInfo
+
+func (m *ListProfilesResponse) GetProfiles() []*ProfileInfo {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+type ReadProfileRequest struct {
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// type of profile e.g. cpu, heap
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// unix timestamp, the last profile collected at or before it is returned. Defaults to the
+	// latest profile.
+	At                   int64    `protobuf:"varint,3,opt,name=at,proto3" json:"at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadProfileRequest) Reset()         { *m = ReadProfileRequest{} }
+func (m *ReadProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadProfileRequest) ProtoMessage()    {}
+func (*ReadProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{21}
+}
+
+func (m *ReadProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadProfileRequest.Unmarshal(m, b)
+}
+func (m *ReadProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadProfileRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadProfileRequest.Merge(m, src)
+}
+func (m *ReadProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadProfileRequest.Size(m)
+}
+func (m *ReadProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadProfileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadProfileRequest proto.InternalMessageInfo
+
+func (m *ReadProfileRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ReadProfileRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ReadProfileRequest) GetAt() int64 {
+	if m != nil {
+		return m.At
+	}
+	return 0
+}
+
+type ReadProfileResponse struct {
+	Info *ProfileInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// the profile in pprof format
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadProfileResponse) Reset()         { *m = ReadProfileResponse{} }
+func (m *ReadProfileResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadProfileResponse) ProtoMessage()    {}
+func (*ReadProfileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{22}
+}
+
+func (m *ReadProfileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadProfileResponse.Unmarshal(m, b)
+}
+func (m *ReadProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadProfileResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadProfileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadProfileResponse.Merge(m, src)
+}
+func (m *ReadProfileResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadProfileResponse.Size(m)
+}
+func (m *ReadProfileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadProfileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadProfileResponse proto.InternalMessageInfo
+
+func (m *ReadProfileResponse) GetInfo() *ProfileInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *ReadProfileResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type QueryMetricsRequest struct {
+	// service to query, blank for all
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// metric to query e.g. memory, requests. Blank for all.
+	Metric string `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	// unix timestamps of the range to query, defaults to the last hour
+	Start int64 `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	// sum the values of the nodes of each service
+	Aggregate            bool     `protobuf:"varint,5,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryMetricsRequest) Reset()         { *m = QueryMetricsRequest{} }
+func (m *QueryMetricsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryMetricsRequest) ProtoMessage()    {}
+func (*QueryMetricsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{23}
+}
+
+func (m *QueryMetricsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryMetricsRequest.Unmarshal(m, b)
+}
+func (m *QueryMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryMetricsRequest.Marshal(b, m, deterministic)
+}
+func (m *QueryMetricsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryMetricsRequest.Merge(m, src)
+}
+func (m *QueryMetricsRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryMetricsRequest.Size(m)
+}
+func (m *QueryMetricsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryMetricsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryMetricsRequest proto.InternalMessageInfo
+
+func (m *QueryMetricsRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *QueryMetricsRequest) GetMetric() string {
+	if m != nil {
+		return m.Metric
+	}
+	return ""
+}
+
+func (m *QueryMetricsRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *QueryMetricsRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *QueryMetricsRequest) GetAggregate() bool {
+	if m != nil {
+		return m.Aggregate
+	}
+	return false
+}
+
+type QueryMetricsResponse struct {
+	Series               []*Series `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *QueryMetricsResponse) Reset()         { *m = QueryMetricsResponse{} }
+func (m *QueryMetricsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryMetricsResponse) ProtoMessage()    {}
+func (*QueryMetricsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{24}
+}
+
+func (m *QueryMetricsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryMetricsResponse.Unmarshal(m, b)
+}
+func (m *QueryMetricsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryMetricsResponse.Marshal(b, m, deterministic)
+}
+func (m *QueryMetricsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryMetricsResponse.Merge(m, src)
+}
+func (m *QueryMetricsResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryMetricsResponse.Size(m)
+}
+func (m *QueryMetricsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryMetricsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryMetricsResponse proto.InternalMessageInfo
+
+func (m *QueryMetricsResponse) GetSeries() []*Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+// Series is the values of a metric scraped from a node over time
+type Series struct {
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// id of the node, blank if the values of the nodes are aggregated
+	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	Metric               string   `protobuf:"bytes,4,opt,name=metric,proto3" json:"metric,omitempty"`
+	Points               []*Point `protobuf:"bytes,5,rep,name=points,proto3" json:"points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Series) Reset()         { *m = Series{} }
+func (m *Series) String() string { return proto.CompactTextString(m) }
+func (*Series) ProtoMessage()    {}
+func (*Series) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{25}
+}
+
+func (m *Series) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Series.Unmarshal(m, b)
+}
+func (m *Series) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Series.Marshal(b, m, deterministic)
+}
+func (m *Series) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Series.Merge(m, src)
+}
+func (m *Series) XXX_Size() int {
+	return xxx_messageInfo_Series.Size(m)
+}
+func (m *Series) XXX_DiscardUnknown() {
+	xxx_messageInfo_Series.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Series proto.InternalMessageInfo
+
+func (m *Series) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Series) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Series) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Series) GetMetric() string {
+	if m != nil {
+		return m.Metric
+	}
+	return ""
+}
+
+func (m *Series) GetPoints() []*Point {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+type Point struct {
+	// unix timestamp the value was scraped at
+	Timestamp            int64    `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Value                float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Point) Reset()         { *m = Point{} }
+func (m *Point) String() string { return proto.CompactTextString(m) }
+func (*Point) ProtoMessage()    {}
+func (*Point) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{26}
+}
+
+func (m *Point) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Point.Unmarshal(m, b)
+}
+func (m *Point) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Point.Marshal(b, m, deterministic)
+}
+func (m *Point) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Point.Merge(m, src)
+}
+func (m *Point) XXX_Size() int {
+	return xxx_messageInfo_Point.Size(m)
+}
+func (m *Point) XXX_DiscardUnknown() {
+	xxx_messageInfo_Point.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Point proto.InternalMessageInfo
+
+func (m *Point) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Point) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type ObjectivesRequest struct {
+	// service to get the objectives of, blank for all
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ObjectivesRequest) Reset()         { *m = ObjectivesRequest{} }
+func (m *ObjectivesRequest) String() string { return proto.CompactTextString(m) }
+func (*ObjectivesRequest) ProtoMessage()    {}
+func (*ObjectivesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{27}
+}
+
+func (m *ObjectivesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectivesRequest.Unmarshal(m, b)
+}
+func (m *ObjectivesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectivesRequest.Marshal(b, m, deterministic)
+}
+func (m *ObjectivesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectivesRequest.Merge(m, src)
+}
+func (m *ObjectivesRequest) XXX_Size() int {
+	return xxx_messageInfo_ObjectivesRequest.Size(m)
+}
+func (m *ObjectivesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectivesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectivesRequest proto.InternalMessageInfo
+
+func (m *ObjectivesRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+type ObjectivesResponse struct {
+	Objectives           []*Objective `protobuf:"bytes,1,rep,name=objectives,proto3" json:"objectives,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ObjectivesResponse) Reset()         { *m = ObjectivesResponse{} }
+func (m *ObjectivesResponse) String() string { return proto.CompactTextString(m) }
+func (*ObjectivesResponse) ProtoMessage()    {}
+func (*ObjectivesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{28}
+}
+
+func (m *ObjectivesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectivesResponse.Unmarshal(m, b)
+}
+func (m *ObjectivesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectivesResponse.Marshal(b, m, deterministic)
+}
+func (m *ObjectivesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectivesResponse.Merge(m, src)
+}
+func (m *ObjectivesResponse) XXX_Size() int {
+	return xxx_messageInfo_ObjectivesResponse.Size(m)
+}
+func (m *ObjectivesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectivesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectivesResponse proto.InternalMessageInfo
+
+func (m *ObjectivesResponse) GetObjectives() []*Objective {
+	if m != nil {
+		return m.Objectives
+	}
+	return nil
+}
+
+// Objective is a service level objective and its status
+type Objective struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// endpoint the objective applies to, blank for all
+	Endpoint string `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// percentage of requests which should be good e.g. 99.9
+	Target float64 `protobuf:"fixed64,4,opt,name=target,proto3" json:"target,omitempty"`
+	// latency requests should be served within e.g. 250ms, blank if requests without errors
+	// are good
+	Latency string `protobuf:"bytes,5,opt,name=latency,proto3" json:"latency,omitempty"`
+	// window the objective is measured over e.g. 720h
+	Window string `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`
+	// total and bad requests in the window
+	Total uint64 `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	Bad   uint64 `protobuf:"varint,8,opt,name=bad,proto3" json:"bad,omitempty"`
+	// percentage of requests in the window which were good
+	Compliance float64 `protobuf:"fixed64,9,opt,name=compliance,proto3" json:"compliance,omitempty"`
+	// percentage of the error budget of the window which remains
+	BudgetRemaining float64 `protobuf:"fixed64,10,opt,name=budget_remaining,json=budgetRemaining,proto3" json:"budget_remaining,omitempty"`
+	// rate the error budget burned at over the last hour, 1 being the rate which would use
+	// the budget exactly by the end of the window
+	BurnRate             float64  `protobuf:"fixed64,11,opt,name=burn_rate,json=burnRate,proto3" json:"burn_rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Objective) Reset()         { *m = Objective{} }
+func (m *Objective) String() string { return proto.CompactTextString(m) }
+func (*Objective) ProtoMessage()    {}
+func (*Objective) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{29}
+}
+
+func (m *Objective) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Objective.Unmarshal(m, b)
+}
+func (m *Objective) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Objective.Marshal(b, m, deterministic)
+}
+func (m *Objective) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Objective.Merge(m, src)
+}
+func (m *Objective) XXX_Size() int {
+	return xxx_messageInfo_Objective.Size(m)
+}
+func (m *Objective) XXX_DiscardUnknown() {
+	xxx_messageInfo_Objective.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Objective proto.InternalMessageInfo
+
+func (m *Objective) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Objective) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Objective) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *Objective) GetTarget() float64 {
+	if m != nil {
+		return m.Target
+	}
+	return 0
+}
+
+func (m *Objective) GetLatency() string {
+	if m != nil {
+		return m.Latency
+	}
+	return ""
+}
+
+func (m *Objective) GetWindow() string {
+	if m != nil {
+		return m.Window
+	}
+	return ""
+}
+
+func (m *Objective) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Objective) GetBad() uint64 {
+	if m != nil {
+		return m.Bad
+	}
+	return 0
+}
+
+func (m *Objective) GetCompliance() float64 {
+	if m != nil {
+		return m.Compliance
+	}
+	return 0
+}
+
+func (m *Objective) GetBudgetRemaining() float64 {
+	if m != nil {
+		return m.BudgetRemaining
+	}
+	return 0
+}
+
+func (m *Objective) GetBurnRate() float64 {
+	if m != nil {
+		return m.BurnRate
+	}
+	return 0
+}
+
+type TopRequest struct {
+	// service to get the stats of, blank for all
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TopRequest) Reset()         { *m = TopRequest{} }
+func (m *TopRequest) String() string { return proto.CompactTextString(m) }
+func (*TopRequest) ProtoMessage()    {}
+func (*TopRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{30}
+}
+
+func (m *TopRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopRequest.Unmarshal(m, b)
+}
+func (m *TopRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopRequest.Marshal(b, m, deterministic)
+}
+func (m *TopRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopRequest.Merge(m, src)
+}
+func (m *TopRequest) XXX_Size() int {
+	return xxx_messageInfo_TopRequest.Size(m)
+}
+func (m *TopRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopRequest proto.InternalMessageInfo
+
+func (m *TopRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+type TopResponse struct {
+	// unix timestamp of the scrape the stats are from
+	Timestamp            int64           `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Services             []*ServiceStats `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TopResponse) Reset()         { *m = TopResponse{} }
+func (m *TopResponse) String() string { return proto.CompactTextString(m) }
+func (*TopResponse) ProtoMessage()    {}
+func (*TopResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{31}
+}
+
+func (m *TopResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopResponse.Unmarshal(m, b)
+}
+func (m *TopResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopResponse.Marshal(b, m, deterministic)
+}
+func (m *TopResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopResponse.Merge(m, src)
+}
+func (m *TopResponse) XXX_Size() int {
+	return xxx_messageInfo_TopResponse.Size(m)
+}
+func (m *TopResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopResponse proto.InternalMessageInfo
+
+func (m *TopResponse) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *TopResponse) GetServices() []*ServiceStats {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+// ServiceStats are the stats of a service summed across its nodes, the rates being those since
+// the previous scrape
+type ServiceStats struct {
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// number of nodes scraped
+	Nodes int64 `protobuf:"varint,2,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	// requests per second
+	Requests float64 `protobuf:"fixed64,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	// percentage of requests which errored
+	ErrorRate float64 `protobuf:"fixed64,4,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`
+	// upper bound in seconds of the latency bucket 99% of requests were served within, 0 if
+	// there were no requests or -1 if they took longer than the largest bucket
+	P99 float64 `protobuf:"fixed64,5,opt,name=p99,proto3" json:"p99,omitempty"`
+	// in bytes
+	Memory uint64 `protobuf:"varint,6,opt,name=memory,proto3" json:"memory,omitempty"`
+	// num goroutines
+	Threads              uint64   `protobuf:"varint,7,opt,name=threads,proto3" json:"threads,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceStats) Reset()         { *m = ServiceStats{} }
+func (m *ServiceStats) String() string { return proto.CompactTextString(m) }
+func (*ServiceStats) ProtoMessage()    {}
+func (*ServiceStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{32}
+}
+
+func (m *ServiceStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceStats.Unmarshal(m, b)
+}
+func (m *ServiceStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceStats.Marshal(b, m, deterministic)
+}
+func (m *ServiceStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceStats.Merge(m, src)
+}
+func (m *ServiceStats) XXX_Size() int {
+	return xxx_messageInfo_ServiceStats.Size(m)
+}
+func (m *ServiceStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceStats proto.InternalMessageInfo
+
+func (m *ServiceStats) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ServiceStats) GetNodes() int64 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
+}
+
+func (m *ServiceStats) GetRequests() float64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *ServiceStats) GetErrorRate() float64 {
+	if m != nil {
+		return m.ErrorRate
+	}
+	return 0
+}
+
+func (m *ServiceStats) GetP99() float64 {
+	if m != nil {
+		return m.P99
+	}
+	return 0
+}
+
+func (m *ServiceStats) GetMemory() uint64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *ServiceStats) GetThreads() uint64 {
+	if m != nil {
+		return m.Threads
+	}
+	return 0
+}
+
+type EndpointsRequest struct {
+	// service to get the endpoints of, blank for all
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// endpoint to get e.g. Helloworld.Call, blank for all
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// unix timestamps of the range the requests are counted over, defaults to the last hour
+	Start                int64    `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndpointsRequest) Reset()         { *m = EndpointsRequest{} }
+func (m *EndpointsRequest) String() string { return proto.CompactTextString(m) }
+func (*EndpointsRequest) ProtoMessage()    {}
+func (*EndpointsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{33}
+}
+
+func (m *EndpointsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndpointsRequest.Unmarshal(m, b)
+}
+func (m *EndpointsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndpointsRequest.Marshal(b, m, deterministic)
+}
+func (m *EndpointsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointsRequest.Merge(m, src)
+}
+func (m *EndpointsRequest) XXX_Size() int {
+	return xxx_messageInfo_EndpointsRequest.Size(m)
+}
+func (m *EndpointsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointsRequest proto.InternalMessageInfo
+
+func (m *EndpointsRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *EndpointsRequest) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *EndpointsRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *EndpointsRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type EndpointsResponse struct {
+	Endpoints            []*EndpointHistogram `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *EndpointsResponse) Reset()         { *m = EndpointsResponse{} }
+func (m *EndpointsResponse) String() string { return proto.CompactTextString(m) }
+func (*EndpointsResponse) ProtoMessage()    {}
+func (*EndpointsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{34}
+}
+
+func (m *EndpointsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndpointsResponse.Unmarshal(m, b)
+}
+func (m *EndpointsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndpointsResponse.Marshal(b, m, deterministic)
+}
+func (m *EndpointsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointsResponse.Merge(m, src)
+}
+func (m *EndpointsResponse) XXX_Size() int {
+	return xxx_messageInfo_EndpointsResponse.Size(m)
+}
+func (m *EndpointsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointsResponse proto.InternalMessageInfo
+
+func (m *EndpointsResponse) GetEndpoints() []*EndpointHistogram {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+// EndpointHistogram is the latency of the requests to an endpoint in a range, summed across the
+// nodes of the service
+type EndpointHistogram struct {
+	Service  string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// number of requests in the range
+	Requests uint64 `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	// number of requests which errored
+	Errors uint64 `protobuf:"varint,4,opt,name=errors,proto3" json:"errors,omitempty"`
+	// upper bounds in seconds of the latency buckets
+	Buckets []float64 `protobuf:"fixed64,5,rep,packed,name=buckets,proto3" json:"buckets,omitempty"`
+	// number of requests served within each bucket
+	Latency []uint64 `protobuf:"varint,6,rep,packed,name=latency,proto3" json:"latency,omitempty"`
+	// upper bounds in seconds of the buckets the percentiles of requests were served within, -1
+	// if they took longer than the largest bucket
+	P50                  float64  `protobuf:"fixed64,7,opt,name=p50,proto3" json:"p50,omitempty"`
+	P90                  float64  `protobuf:"fixed64,8,opt,name=p90,proto3" json:"p90,omitempty"`
+	P99                  float64  `protobuf:"fixed64,9,opt,name=p99,proto3" json:"p99,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndpointHistogram) Reset()         { *m = EndpointHistogram{} }
+func (m *EndpointHistogram) String() string { return proto.CompactTextString(m) }
+func (*EndpointHistogram) ProtoMessage()    {}
+func (*EndpointHistogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{35}
+}
+
+func (m *EndpointHistogram) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndpointHistogram.Unmarshal(m, b)
+}
+func (m *EndpointHistogram) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndpointHistogram.Marshal(b, m, deterministic)
+}
+func (m *EndpointHistogram) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointHistogram.Merge(m, src)
+}
+func (m *EndpointHistogram) XXX_Size() int {
+	return xxx_messageInfo_EndpointHistogram.Size(m)
+}
+func (m *EndpointHistogram) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointHistogram.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointHistogram proto.InternalMessageInfo
+
+func (m *EndpointHistogram) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *EndpointHistogram) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *EndpointHistogram) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *EndpointHistogram) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *EndpointHistogram) GetBuckets() []float64 {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *EndpointHistogram) GetLatency() []uint64 {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
+
+func (m *EndpointHistogram) GetP50() float64 {
+	if m != nil {
+		return m.P50
+	}
+	return 0
+}
+
+func (m *EndpointHistogram) GetP90() float64 {
+	if m != nil {
+		return m.P90
+	}
+	return 0
+}
+
+func (m *EndpointHistogram) GetP99() float64 {
+	if m != nil {
+		return m.P99
+	}
+	return 0
+}
+
+// LogRecord is a structured log record shipped by a service
+type LogRecord struct {
+	// unix timestamp in nanoseconds
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// labels of the record e.g. service, level
+	Labels               map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Message              string            `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LogRecord) Reset()         { *m = LogRecord{} }
+func (m *LogRecord) String() string { return proto.CompactTextString(m) }
+func (*LogRecord) ProtoMessage()    {}
+func (*LogRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{36}
+}
+
+func (m *LogRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogRecord.Unmarshal(m, b)
+}
+func (m *LogRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogRecord.Marshal(b, m, deterministic)
+}
+func (m *LogRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogRecord.Merge(m, src)
+}
+func (m *LogRecord) XXX_Size() int {
+	return xxx_messageInfo_LogRecord.Size(m)
+}
+func (m *LogRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogRecord proto.InternalMessageInfo
+
+func (m *LogRecord) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *LogRecord) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *LogRecord) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type WriteLogsRequest struct {
+	Records              []*LogRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *WriteLogsRequest) Reset()         { *m = WriteLogsRequest{} }
+func (m *WriteLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteLogsRequest) ProtoMessage()    {}
+func (*WriteLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{37}
+}
+
+func (m *WriteLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteLogsRequest.Unmarshal(m, b)
+}
+func (m *WriteLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *WriteLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteLogsRequest.Merge(m, src)
+}
+func (m *WriteLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteLogsRequest.Size(m)
+}
+func (m *WriteLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteLogsRequest proto.InternalMessageInfo
+
+func (m *WriteLogsRequest) GetRecords() []*LogRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type WriteLogsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteLogsResponse) Reset()         { *m = WriteLogsResponse{} }
+func (m *WriteLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteLogsResponse) ProtoMessage()    {}
+func (*WriteLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{38}
+}
+
+func (m *WriteLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteLogsResponse.Unmarshal(m, b)
+}
+func (m *WriteLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *WriteLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteLogsResponse.Merge(m, src)
+}
+func (m *WriteLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_WriteLogsResponse.Size(m)
+}
+func (m *WriteLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteLogsResponse proto.InternalMessageInfo
+
+type QueryLogsRequest struct {
+	// query selecting the records by label and filtering them by message e.g.
+	// {service="helloworld", level="error"} |= "timeout"
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// unix timestamps of the range to query, defaults to the last hour
+	Start int64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// maximum number of records to return, the latest are returned. Defaults to 100.
+	Limit                int64    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryLogsRequest) Reset()         { *m = QueryLogsRequest{} }
+func (m *QueryLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryLogsRequest) ProtoMessage()    {}
+func (*QueryLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{39}
+}
+
+func (m *QueryLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryLogsRequest.Unmarshal(m, b)
+}
+func (m *QueryLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *QueryLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryLogsRequest.Merge(m, src)
+}
+func (m *QueryLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryLogsRequest.Size(m)
+}
+func (m *QueryLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryLogsRequest proto.InternalMessageInfo
+
+func (m *QueryLogsRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *QueryLogsRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *QueryLogsRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *QueryLogsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type QueryLogsResponse struct {
+	// the records ordered by timestamp
+	Records              []*LogRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *QueryLogsResponse) Reset()         { *m = QueryLogsResponse{} }
+func (m *QueryLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryLogsResponse) ProtoMessage()    {}
+func (*QueryLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{40}
+}
+
+func (m *QueryLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryLogsResponse.Unmarshal(m, b)
+}
+func (m *QueryLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *QueryLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryLogsResponse.Merge(m, src)
+}
+func (m *QueryLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryLogsResponse.Size(m)
+}
+func (m *QueryLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryLogsResponse proto.InternalMessageInfo
+
+func (m *QueryLogsResponse) GetRecords() []*LogRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type LabelsRequest struct {
+	// name of the label to list the values of, blank to list the names
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelsRequest) Reset()         { *m = LabelsRequest{} }
+func (m *LabelsRequest) String() string { return proto.CompactTextString(m) }
+func (*LabelsRequest) ProtoMessage()    {}
+func (*LabelsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{41}
+}
+
+func (m *LabelsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelsRequest.Unmarshal(m, b)
+}
+func (m *LabelsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelsRequest.Marshal(b, m, deterministic)
+}
+func (m *LabelsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelsRequest.Merge(m, src)
+}
+func (m *LabelsRequest) XXX_Size() int {
+	return xxx_messageInfo_LabelsRequest.Size(m)
+}
+func (m *LabelsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelsRequest proto.InternalMessageInfo
+
+func (m *LabelsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type LabelsResponse struct {
+	Values               []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelsResponse) Reset()         { *m = LabelsResponse{} }
+func (m *LabelsResponse) String() string { return proto.CompactTextString(m) }
+func (*LabelsResponse) ProtoMessage()    {}
+func (*LabelsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{42}
+}
+
+func (m *LabelsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelsResponse.Unmarshal(m, b)
+}
+func (m *LabelsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelsResponse.Marshal(b, m, deterministic)
+}
+func (m *LabelsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelsResponse.Merge(m, src)
+}
+func (m *LabelsResponse) XXX_Size() int {
+	return xxx_messageInfo_LabelsResponse.Size(m)
+}
+func (m *LabelsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelsResponse proto.InternalMessageInfo
+
+func (m *LabelsResponse) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type ProbeResultsRequest struct {
+	// name of the probe to get the results of, blank for all
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// location the probes were run from, blank for all
+	Location string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// unix timestamp to get the results since, defaults to the last hour
+	Start                int64    `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProbeResultsRequest) Reset()         { *m = ProbeResultsRequest{} }
+func (m *ProbeResultsRequest) String() string { return proto.CompactTextString(m) }
+func (*ProbeResultsRequest) ProtoMessage()    {}
+func (*ProbeResultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{43}
+}
+
+func (m *ProbeResultsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeResultsRequest.Unmarshal(m, b)
+}
+func (m *ProbeResultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeResultsRequest.Marshal(b, m, deterministic)
+}
+func (m *ProbeResultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeResultsRequest.Merge(m, src)
+}
+func (m *ProbeResultsRequest) XXX_Size() int {
+	return xxx_messageInfo_ProbeResultsRequest.Size(m)
+}
+func (m *ProbeResultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeResultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeResultsRequest proto.InternalMessageInfo
+
+func (m *ProbeResultsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProbeResultsRequest) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *ProbeResultsRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+type ProbeResultsResponse struct {
+	// the status of each probe at each location
+	Probes []*ProbeStatus `protobuf:"bytes,1,rep,name=probes,proto3" json:"probes,omitempty"`
+	// the results of the probe ordered by timestamp, only returned if the name is requested
+	Results              []*ProbeResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ProbeResultsResponse) Reset()         { *m = ProbeResultsResponse{} }
+func (m *ProbeResultsResponse) String() string { return proto.CompactTextString(m) }
+func (*ProbeResultsResponse) ProtoMessage()    {}
+func (*ProbeResultsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{44}
+}
+
+func (m *ProbeResultsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeResultsResponse.Unmarshal(m, b)
+}
+func (m *ProbeResultsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeResultsResponse.Marshal(b, m, deterministic)
+}
+func (m *ProbeResultsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeResultsResponse.Merge(m, src)
+}
+func (m *ProbeResultsResponse) XXX_Size() int {
+	return xxx_messageInfo_ProbeResultsResponse.Size(m)
+}
+func (m *ProbeResultsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeResultsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeResultsResponse proto.InternalMessageInfo
+
+func (m *ProbeResultsResponse) GetProbes() []*ProbeStatus {
+	if m != nil {
+		return m.Probes
+	}
+	return nil
+}
+
+func (m *ProbeResultsResponse) GetResults() []*ProbeResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// ProbeStatus is the status of a probe run from a location since the start
+type ProbeStatus struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// type of probe, rpc or http
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// target of the probe e.g. helloworld Helloworld.Call or /helloworld
+	Target   string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	Location string `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// total and failed runs since the start
+	Total    uint64 `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Failures uint64 `protobuf:"varint,6,opt,name=failures,proto3" json:"failures,omitempty"`
+	// average latency of the runs in nanoseconds
+	Latency int64 `protobuf:"varint,7,opt,name=latency,proto3" json:"latency,omitempty"`
+	// the last run of the probe
+	Last                 *ProbeResult `protobuf:"bytes,8,opt,name=last,proto3" json:"last,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ProbeStatus) Reset()         { *m = ProbeStatus{} }
+func (m *ProbeStatus) String() string { return proto.CompactTextString(m) }
+func (*ProbeStatus) ProtoMessage()    {}
+func (*ProbeStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{45}
+}
+
+func (m *ProbeStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeStatus.Unmarshal(m, b)
+}
+func (m *ProbeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeStatus.Marshal(b, m, deterministic)
+}
+func (m *ProbeStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeStatus.Merge(m, src)
+}
+func (m *ProbeStatus) XXX_Size() int {
+	return xxx_messageInfo_ProbeStatus.Size(m)
+}
+func (m *ProbeStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeStatus proto.InternalMessageInfo
+
+func (m *ProbeStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProbeStatus) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ProbeStatus) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ProbeStatus) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *ProbeStatus) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *ProbeStatus) GetFailures() uint64 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *ProbeStatus) GetLatency() int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+func (m *ProbeStatus) GetLast() *ProbeResult {
+	if m != nil {
+		return m.Last
+	}
+	return nil
+}
+
+// ProbeResult is the result of a run of a probe
+type ProbeResult struct {
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// unix timestamp of the run
+	Timestamp int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Success   bool  `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	// latency in nanoseconds
+	Latency int64 `protobuf:"varint,5,opt,name=latency,proto3" json:"latency,omitempty"`
+	// error the run failed with
+	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProbeResult) Reset()         { *m = ProbeResult{} }
+func (m *ProbeResult) String() string { return proto.CompactTextString(m) }
+func (*ProbeResult) ProtoMessage()    {}
+func (*ProbeResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{46}
+}
+
+func (m *ProbeResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeResult.Unmarshal(m, b)
+}
+func (m *ProbeResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeResult.Marshal(b, m, deterministic)
+}
+func (m *ProbeResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeResult.Merge(m, src)
+}
+func (m *ProbeResult) XXX_Size() int {
+	return xxx_messageInfo_ProbeResult.Size(m)
+}
+func (m *ProbeResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeResult proto.InternalMessageInfo
+
+func (m *ProbeResult) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProbeResult) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *ProbeResult) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ProbeResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *ProbeResult) GetLatency() int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+func (m *ProbeResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// TraceSampling is the sampling of the traces exported by services
+type TraceSampling struct {
+	// ratio of traces exported, between 0 and 1
+	Rate float64 `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	// rates of services which override the rate
+	Services map[string]float64 `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// export the traces with errors regardless of the rate
+	SampleErrors bool `protobuf:"varint,3,opt,name=sample_errors,json=sampleErrors,proto3" json:"sample_errors,omitempty"`
+	// unix timestamp the sampling was set at, zero if it's never been set in which case
+	// services sample as configured when they were started
+	Updated              int64    `protobuf:"varint,4,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TraceSampling) Reset()         { *m = TraceSampling{} }
+func (m *TraceSampling) String() string { return proto.CompactTextString(m) }
+func (*TraceSampling) ProtoMessage()    {}
+func (*TraceSampling) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{47}
+}
+
+func (m *TraceSampling) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceSampling.Unmarshal(m, b)
+}
+func (m *TraceSampling) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceSampling.Marshal(b, m, deterministic)
+}
+func (m *TraceSampling) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceSampling.Merge(m, src)
+}
+func (m *TraceSampling) XXX_Size() int {
+	return xxx_messageInfo_TraceSampling.Size(m)
+}
+func (m *TraceSampling) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceSampling.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceSampling proto.InternalMessageInfo
+
+func (m *TraceSampling) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *TraceSampling) GetServices() map[string]float64 {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+func (m *TraceSampling) GetSampleErrors() bool {
+	if m != nil {
+		return m.SampleErrors
+	}
+	return false
+}
+
+func (m *TraceSampling) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+type SamplingRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SamplingRequest) Reset()         { *m = SamplingRequest{} }
+func (m *SamplingRequest) String() string { return proto.CompactTextString(m) }
+func (*SamplingRequest) ProtoMessage()    {}
+func (*SamplingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{48}
+}
+
+func (m *SamplingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SamplingRequest.Unmarshal(m, b)
+}
+func (m *SamplingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SamplingRequest.Marshal(b, m, deterministic)
+}
+func (m *SamplingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SamplingRequest.Merge(m, src)
+}
+func (m *SamplingRequest) XXX_Size() int {
+	return xxx_messageInfo_SamplingRequest.Size(m)
+}
+func (m *SamplingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SamplingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SamplingRequest proto.InternalMessageInfo
+
+type SamplingResponse struct {
+	Sampling             *TraceSampling `protobuf:"bytes,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SamplingResponse) Reset()         { *m = SamplingResponse{} }
+func (m *SamplingResponse) String() string { return proto.CompactTextString(m) }
+func (*SamplingResponse) ProtoMessage()    {}
+func (*SamplingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{49}
+}
+
+func (m *SamplingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SamplingResponse.Unmarshal(m, b)
+}
+func (m *SamplingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SamplingResponse.Marshal(b, m, deterministic)
+}
+func (m *SamplingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SamplingResponse.Merge(m, src)
+}
+func (m *SamplingResponse) XXX_Size() int {
+	return xxx_messageInfo_SamplingResponse.Size(m)
+}
+func (m *SamplingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SamplingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SamplingResponse proto.InternalMessageInfo
+
+func (m *SamplingResponse) GetSampling() *TraceSampling {
+	if m != nil {
+		return m.Sampling
+	}
+	return nil
+}
+
+type SetSamplingRequest struct {
+	Sampling             *TraceSampling `protobuf:"bytes,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SetSamplingRequest) Reset()         { *m = SetSamplingRequest{} }
+func (m *SetSamplingRequest) String() string { return proto.CompactTextString(m) }
+func (*SetSamplingRequest) ProtoMessage()    {}
+func (*SetSamplingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{50}
+}
+
+func (m *SetSamplingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetSamplingRequest.Unmarshal(m, b)
+}
+func (m *SetSamplingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetSamplingRequest.Marshal(b, m, deterministic)
+}
+func (m *SetSamplingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSamplingRequest.Merge(m, src)
+}
+func (m *SetSamplingRequest) XXX_Size() int {
+	return xxx_messageInfo_SetSamplingRequest.Size(m)
+}
+func (m *SetSamplingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSamplingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSamplingRequest proto.InternalMessageInfo
+
+func (m *SetSamplingRequest) GetSampling() *TraceSampling {
+	if m != nil {
+		return m.Sampling
+	}
+	return nil
+}
+
+type SetSamplingResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetSamplingResponse) Reset()         { *m = SetSamplingResponse{} }
+func (m *SetSamplingResponse) String() string { return proto.CompactTextString(m) }
+func (*SetSamplingResponse) ProtoMessage()    {}
+func (*SetSamplingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{51}
+}
+
+func (m *SetSamplingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetSamplingResponse.Unmarshal(m, b)
+}
+func (m *SetSamplingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetSamplingResponse.Marshal(b, m, deterministic)
+}
+func (m *SetSamplingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSamplingResponse.Merge(m, src)
+}
+func (m *SetSamplingResponse) XXX_Size() int {
+	return xxx_messageInfo_SetSamplingResponse.Size(m)
+}
+func (m *SetSamplingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSamplingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSamplingResponse proto.InternalMessageInfo
+
+// CaptureSpan is a span recorded by a hop of a captured request
+type CaptureSpan struct {
+	// id of the trace, which is the id of the capture
+	Trace  string `protobuf:"bytes,1,opt,name=trace,proto3" json:"trace,omitempty"`
+	Id     string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Parent string `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	// name of the span e.g. helloworld.Helloworld.Call
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// type of span, inbound for a request served or outbound for a call made
+	Type string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	// service and node which recorded the span
+	Service string `protobuf:"bytes,6,opt,name=service,proto3" json:"service,omitempty"`
+	Node    string `protobuf:"bytes,7,opt,name=node,proto3" json:"node,omitempty"`
+	// unix timestamp in nanoseconds the span started at
+	Started int64 `protobuf:"varint,8,opt,name=started,proto3" json:"started,omitempty"`
+	// duration in nanoseconds
+	Duration int64 `protobuf:"varint,9,opt,name=duration,proto3" json:"duration,omitempty"`
+	// size of the request and response in bytes
+	RequestSize  int64  `protobuf:"varint,10,opt,name=request_size,json=requestSize,proto3" json:"request_size,omitempty"`
+	ResponseSize int64  `protobuf:"varint,11,opt,name=response_size,json=responseSize,proto3" json:"response_size,omitempty"`
+	Error        string `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	// metadata of the request, without credentials
+	Metadata             map[string]string `protobuf:"bytes,13,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CaptureSpan) Reset()         { *m = CaptureSpan{} }
+func (m *CaptureSpan) String() string { return proto.CompactTextString(m) }
+func (*CaptureSpan) ProtoMessage()    {}
+func (*CaptureSpan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{52}
+}
+
+func (m *CaptureSpan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CaptureSpan.Unmarshal(m, b)
+}
+func (m *CaptureSpan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CaptureSpan.Marshal(b, m, deterministic)
+}
+func (m *CaptureSpan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CaptureSpan.Merge(m, src)
+}
+func (m *CaptureSpan) XXX_Size() int {
+	return xxx_messageInfo_CaptureSpan.Size(m)
+}
+func (m *CaptureSpan) XXX_DiscardUnknown() {
+	xxx_messageInfo_CaptureSpan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CaptureSpan proto.InternalMessageInfo
+
+func (m *CaptureSpan) GetTrace() string {
+	if m != nil {
+		return m.Trace
+	}
+	return ""
+}
+
+func (m *CaptureSpan) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CaptureSpan) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *CaptureSpan) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CaptureSpan) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CaptureSpan) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *CaptureSpan) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *CaptureSpan) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *CaptureSpan) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *CaptureSpan) GetRequestSize() int64 {
+	if m != nil {
+		return m.RequestSize
+	}
+	return 0
+}
+
+func (m *CaptureSpan) GetResponseSize() int64 {
+	if m != nil {
+		return m.ResponseSize
+	}
+	return 0
+}
+
+func (m *CaptureSpan) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CaptureSpan) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// CaptureInfo summarises a capture
+type CaptureInfo struct {
+	// id of the capture, the trace id of the request
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// name of the first span e.g. helloworld.Helloworld.Call
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// unix timestamp in nanoseconds the capture started at
+	Started int64 `protobuf:"varint,3,opt,name=started,proto3" json:"started,omitempty"`
+	// duration of the first span in nanoseconds
+	Duration int64 `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	Spans    int64 `protobuf:"varint,5,opt,name=spans,proto3" json:"spans,omitempty"`
+	Errors   int64 `protobuf:"varint,6,opt,name=errors,proto3" json:"errors,omitempty"`
+	// services the request passed through
+	Services             []string `protobuf:"bytes,7,rep,name=services,proto3" json:"services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CaptureInfo) Reset()         { *m = CaptureInfo{} }
+func (m *CaptureInfo) String() string { return proto.CompactTextString(m) }
+func (*CaptureInfo) ProtoMessage()    {}
+func (*CaptureInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{53}
+}
+
+func (m *CaptureInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CaptureInfo.Unmarshal(m, b)
+}
+func (m *CaptureInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CaptureInfo.Marshal(b, m, deterministic)
+}
+func (m *CaptureInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CaptureInfo.Merge(m, src)
+}
+func (m *CaptureInfo) XXX_Size() int {
+	return xxx_messageInfo_CaptureInfo.Size(m)
+}
+func (m *CaptureInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CaptureInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CaptureInfo proto.InternalMessageInfo
+
+func (m *CaptureInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CaptureInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CaptureInfo) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *CaptureInfo) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *CaptureInfo) GetSpans() int64 {
+	if m != nil {
+		return m.Spans
+	}
+	return 0
+}
+
+func (m *CaptureInfo) GetErrors() int64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *CaptureInfo) GetServices() []string {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+type ListCapturesRequest struct {
+	// service the captures passed through, blank for all
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// maximum number of captures to return, the latest are returned. Defaults to 20.
+	Limit                int64    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCapturesRequest) Reset()         { *m = ListCapturesRequest{} }
+func (m *ListCapturesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCapturesRequest) ProtoMessage()    {}
+func (*ListCapturesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{54}
+}
+
+func (m *ListCapturesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCapturesRequest.Unmarshal(m, b)
+}
+func (m *ListCapturesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCapturesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCapturesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCapturesRequest.Merge(m, src)
+}
+func (m *ListCapturesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCapturesRequest.Size(m)
+}
+func (m *ListCapturesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCapturesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCapturesRequest proto.InternalMessageInfo
+
+func (m *ListCapturesRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ListCapturesRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListCapturesResponse struct {
+	// the captures, latest first
+	Captures             []*CaptureInfo `protobuf:"bytes,1,rep,name=captures,proto3" json:"captures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListCapturesResponse) Reset()         { *m = ListCapturesResponse{} }
+func (m *ListCapturesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCapturesResponse) ProtoMessage()    {}
+func (*ListCapturesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{55}
+}
+
+func (m *ListCapturesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCapturesResponse.Unmarshal(m, b)
+}
+func (m *ListCapturesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCapturesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCapturesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCapturesResponse.Merge(m, src)
+}
+func (m *ListCapturesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCapturesResponse.Size(m)
+}
+func (m *ListCapturesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCapturesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCapturesResponse proto.InternalMessageInfo
+
+func (m *ListCapturesResponse) GetCaptures() []*CaptureInfo {
+	if m != nil {
+		return m.Captures
+	}
+	return nil
+}
+
+type ReadCaptureRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadCaptureRequest) Reset()         { *m = ReadCaptureRequest{} }
+func (m *ReadCaptureRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadCaptureRequest) ProtoMessage()    {}
+func (*ReadCaptureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{56}
+}
+
+func (m *ReadCaptureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadCaptureRequest.Unmarshal(m, b)
+}
+func (m *ReadCaptureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadCaptureRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadCaptureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadCaptureRequest.Merge(m, src)
+}
+func (m *ReadCaptureRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadCaptureRequest.Size(m)
+}
+func (m *ReadCaptureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadCaptureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadCaptureRequest proto.InternalMessageInfo
+
+func (m *ReadCaptureRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ReadCaptureResponse struct {
+	// the spans ordered by the time they started
+	Spans                []*CaptureSpan `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ReadCaptureResponse) Reset()         { *m = ReadCaptureResponse{} }
+func (m *ReadCaptureResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadCaptureResponse) ProtoMessage()    {}
+func (*ReadCaptureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{57}
+}
+
+func (m *ReadCaptureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadCaptureResponse.Unmarshal(m, b)
+}
+func (m *ReadCaptureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadCaptureResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadCaptureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadCaptureResponse.Merge(m, src)
+}
+func (m *ReadCaptureResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadCaptureResponse.Size(m)
+}
+func (m *ReadCaptureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadCaptureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadCaptureResponse proto.InternalMessageInfo
+
+func (m *ReadCaptureResponse) GetSpans() []*CaptureSpan {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
+// ChaosExperiment injects faults into the requests to a service
+type ChaosExperiment struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// service and endpoint the faults are injected into, a blank endpoint is every endpoint
+	Service  string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Endpoint string `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// type of fault, latency, error or reset
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// percentage of requests the fault is injected into
+	Percentage float64 `protobuf:"fixed64,5,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// latency added to requests e.g. 500ms, for latency experiments
+	Latency string `protobuf:"bytes,6,opt,name=latency,proto3" json:"latency,omitempty"`
+	// code of the error returned, for error experiments. Defaults to 500.
+	ErrorCode int32 `protobuf:"varint,7,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	// how long the experiment runs for e.g. 10m, defaults to 10 minutes
+	Duration string `protobuf:"bytes,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	// unix timestamps the experiment was created at and expires at
+	Created int64 `protobuf:"varint,9,opt,name=created,proto3" json:"created,omitempty"`
+	Expires int64 `protobuf:"varint,10,opt,name=expires,proto3" json:"expires,omitempty"`
+	// id of the account which created the experiment
+	CreatedBy            string   `protobuf:"bytes,11,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaosExperiment) Reset()         { *m = ChaosExperiment{} }
+func (m *ChaosExperiment) String() string { return proto.CompactTextString(m) }
+func (*ChaosExperiment) ProtoMessage()    {}
+func (*ChaosExperiment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{58}
+}
+
+func (m *ChaosExperiment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaosExperiment.Unmarshal(m, b)
+}
+func (m *ChaosExperiment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaosExperiment.Marshal(b, m, deterministic)
+}
+func (m *ChaosExperiment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaosExperiment.Merge(m, src)
+}
+func (m *ChaosExperiment) XXX_Size() int {
+	return xxx_messageInfo_ChaosExperiment.Size(m)
+}
+func (m *ChaosExperiment) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaosExperiment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaosExperiment proto.InternalMessageInfo
+
+func (m *ChaosExperiment) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ChaosExperiment) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ChaosExperiment) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *ChaosExperiment) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ChaosExperiment) GetPercentage() float64 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
+}
+
+func (m *ChaosExperiment) GetLatency() string {
+	if m != nil {
+		return m.Latency
+	}
+	return ""
+}
+
+func (m *ChaosExperiment) GetErrorCode() int32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *ChaosExperiment) GetDuration() string {
+	if m != nil {
+		return m.Duration
+	}
+	return ""
+}
+
+func (m *ChaosExperiment) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ChaosExperiment) GetExpires() int64 {
+	if m != nil {
+		return m.Expires
+	}
+	return 0
+}
+
+func (m *ChaosExperiment) GetCreatedBy() string {
+	if m != nil {
+		return m.CreatedBy
+	}
+	return ""
+}
+
+type CreateExperimentRequest struct {
+	Experiment           *ChaosExperiment `protobuf:"bytes,1,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CreateExperimentRequest) Reset()         { *m = CreateExperimentRequest{} }
+func (m *CreateExperimentRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateExperimentRequest) ProtoMessage()    {}
+func (*CreateExperimentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{59}
+}
+
+func (m *CreateExperimentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateExperimentRequest.Unmarshal(m, b)
+}
+func (m *CreateExperimentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateExperimentRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateExperimentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateExperimentRequest.Merge(m, src)
+}
+func (m *CreateExperimentRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateExperimentRequest.Size(m)
+}
+func (m *CreateExperimentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateExperimentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateExperimentRequest proto.InternalMessageInfo
+
+func (m *CreateExperimentRequest) GetExperiment() *ChaosExperiment {
+	if m != nil {
+		return m.Experiment
+	}
+	return nil
+}
+
+type CreateExperimentResponse struct {
+	Experiment           *ChaosExperiment `protobuf:"bytes,1,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CreateExperimentResponse) Reset()         { *m = CreateExperimentResponse{} }
+func (m *CreateExperimentResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateExperimentResponse) ProtoMessage()    {}
+func (*CreateExperimentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{60}
+}
+
+func (m *CreateExperimentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateExperimentResponse.Unmarshal(m, b)
+}
+func (m *CreateExperimentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateExperimentResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateExperimentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateExperimentResponse.Merge(m, src)
+}
+func (m *CreateExperimentResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateExperimentResponse.Size(m)
+}
+func (m *CreateExperimentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateExperimentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateExperimentResponse proto.InternalMessageInfo
+
+func (m *CreateExperimentResponse) GetExperiment() *ChaosExperiment {
+	if m != nil {
+		return m.Experiment
+	}
+	return nil
+}
+
+type ListExperimentsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListExperimentsRequest) Reset()         { *m = ListExperimentsRequest{} }
+func (m *ListExperimentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListExperimentsRequest) ProtoMessage()    {}
+func (*ListExperimentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{61}
+}
+
+func (m *ListExperimentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExperimentsRequest.Unmarshal(m, b)
+}
+func (m *ListExperimentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExperimentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListExperimentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExperimentsRequest.Merge(m, src)
+}
+func (m *ListExperimentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListExperimentsRequest.Size(m)
+}
+func (m *ListExperimentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExperimentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExperimentsRequest proto.InternalMessageInfo
+
+type ListExperimentsResponse struct {
+	// the experiments which haven't expired
+	Experiments          []*ChaosExperiment `protobuf:"bytes,1,rep,name=experiments,proto3" json:"experiments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListExperimentsResponse) Reset()         { *m = ListExperimentsResponse{} }
+func (m *ListExperimentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListExperimentsResponse) ProtoMessage()    {}
+func (*ListExperimentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{62}
+}
+
+func (m *ListExperimentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExperimentsResponse.Unmarshal(m, b)
+}
+func (m *ListExperimentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExperimentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListExperimentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExperimentsResponse.Merge(m, src)
+}
+func (m *ListExperimentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListExperimentsResponse.Size(m)
+}
+func (m *ListExperimentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExperimentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExperimentsResponse proto.InternalMessageInfo
+
+func (m *ListExperimentsResponse) GetExperiments() []*ChaosExperiment {
+	if m != nil {
+		return m.Experiments
+	}
+	return nil
+}
+
+type DeleteExperimentRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteExperimentRequest) Reset()         { *m = DeleteExperimentRequest{} }
+func (m *DeleteExperimentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteExperimentRequest) ProtoMessage()    {}
+func (*DeleteExperimentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{63}
+}
+
+func (m *DeleteExperimentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteExperimentRequest.Unmarshal(m, b)
+}
+func (m *DeleteExperimentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteExperimentRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteExperimentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteExperimentRequest.Merge(m, src)
+}
+func (m *DeleteExperimentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteExperimentRequest.Size(m)
+}
+func (m *DeleteExperimentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteExperimentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteExperimentRequest proto.InternalMessageInfo
+
+func (m *DeleteExperimentRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteExperimentResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteExperimentResponse) Reset()         { *m = DeleteExperimentResponse{} }
+func (m *DeleteExperimentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteExperimentResponse) ProtoMessage()    {}
+func (*DeleteExperimentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{64}
+}
+
+func (m *DeleteExperimentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteExperimentResponse.Unmarshal(m, b)
+}
+func (m *DeleteExperimentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteExperimentResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteExperimentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteExperimentResponse.Merge(m, src)
+}
+func (m *DeleteExperimentResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteExperimentResponse.Size(m)
+}
+func (m *DeleteExperimentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteExperimentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteExperimentResponse proto.InternalMessageInfo
+
+type ChaosAuditRequest struct {
+	// maximum number of entries to return, the latest are returned. Defaults to 100.
+	Limit                int64    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaosAuditRequest) Reset()         { *m = ChaosAuditRequest{} }
+func (m *ChaosAuditRequest) String() string { return proto.CompactTextString(m) }
+func (*ChaosAuditRequest) ProtoMessage()    {}
+func (*ChaosAuditRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{65}
+}
+
+func (m *ChaosAuditRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaosAuditRequest.Unmarshal(m, b)
+}
+func (m *ChaosAuditRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaosAuditRequest.Marshal(b, m, deterministic)
+}
+func (m *ChaosAuditRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaosAuditRequest.Merge(m, src)
+}
+func (m *ChaosAuditRequest) XXX_Size() int {
+	return xxx_messageInfo_ChaosAuditRequest.Size(m)
+}
+func (m *ChaosAuditRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaosAuditRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaosAuditRequest proto.InternalMessageInfo
+
+func (m *ChaosAuditRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ChaosAuditResponse struct {
+	// the entries, latest first
+	Entries              []*ChaosAuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ChaosAuditResponse) Reset()         { *m = ChaosAuditResponse{} }
+func (m *ChaosAuditResponse) String() string { return proto.CompactTextString(m) }
+func (*ChaosAuditResponse) ProtoMessage()    {}
+func (*ChaosAuditResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{66}
+}
+
+func (m *ChaosAuditResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaosAuditResponse.Unmarshal(m, b)
+}
+func (m *ChaosAuditResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaosAuditResponse.Marshal(b, m, deterministic)
+}
+func (m *ChaosAuditResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaosAuditResponse.Merge(m, src)
+}
+func (m *ChaosAuditResponse) XXX_Size() int {
+	return xxx_messageInfo_ChaosAuditResponse.Size(m)
+}
+func (m *ChaosAuditResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaosAuditResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaosAuditResponse proto.InternalMessageInfo
+
+func (m *ChaosAuditResponse) GetEntries() []*ChaosAuditEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// ChaosAuditEntry records an experiment being created, deleted or expiring
+type ChaosAuditEntry struct {
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// action taken, create, delete or expire
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// id of the account which took the action, blank for expiry
+	Account              string           `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Experiment           *ChaosExperiment `protobuf:"bytes,4,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ChaosAuditEntry) Reset()         { *m = ChaosAuditEntry{} }
+func (m *ChaosAuditEntry) String() string { return proto.CompactTextString(m) }
+func (*ChaosAuditEntry) ProtoMessage()    {}
+func (*ChaosAuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{67}
+}
+
+func (m *ChaosAuditEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaosAuditEntry.Unmarshal(m, b)
+}
+func (m *ChaosAuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaosAuditEntry.Marshal(b, m, deterministic)
+}
+func (m *ChaosAuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaosAuditEntry.Merge(m, src)
+}
+func (m *ChaosAuditEntry) XXX_Size() int {
+	return xxx_messageInfo_ChaosAuditEntry.Size(m)
+}
+func (m *ChaosAuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaosAuditEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaosAuditEntry proto.InternalMessageInfo
+
+func (m *ChaosAuditEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ChaosAuditEntry) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ChaosAuditEntry) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *ChaosAuditEntry) GetExperiment() *ChaosExperiment {
+	if m != nil {
+		return m.Experiment
+	}
+	return nil
+}
+
+type ReadTimelineRequest struct {
+	// unix timestamps of the range to read, defaults to the last hour
+	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// sources of the events to read e.g. runtime, router, config, alert. Blank for all.
+	Sources []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+	// service the events relate to, blank for all
+	Service string `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	// maximum number of events to return, the latest are returned. Defaults to 500.
+	Limit                int64    `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadTimelineRequest) Reset()         { *m = ReadTimelineRequest{} }
+func (m *ReadTimelineRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadTimelineRequest) ProtoMessage()    {}
+func (*ReadTimelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{68}
+}
+
+func (m *ReadTimelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadTimelineRequest.Unmarshal(m, b)
+}
+func (m *ReadTimelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadTimelineRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadTimelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadTimelineRequest.Merge(m, src)
+}
+func (m *ReadTimelineRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadTimelineRequest.Size(m)
+}
+func (m *ReadTimelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadTimelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadTimelineRequest proto.InternalMessageInfo
+
+func (m *ReadTimelineRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *ReadTimelineRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *ReadTimelineRequest) GetSources() []string {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+func (m *ReadTimelineRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ReadTimelineRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ReadTimelineResponse struct {
+	// the events, oldest first
+	Events               []*TimelineEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ReadTimelineResponse) Reset()         { *m = ReadTimelineResponse{} }
+func (m *ReadTimelineResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadTimelineResponse) ProtoMessage()    {}
+func (*ReadTimelineResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{69}
+}
+
+func (m *ReadTimelineResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadTimelineResponse.Unmarshal(m, b)
+}
+func (m *ReadTimelineResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadTimelineResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadTimelineResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadTimelineResponse.Merge(m, src)
+}
+func (m *ReadTimelineResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadTimelineResponse.Size(m)
+}
+func (m *ReadTimelineResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadTimelineResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadTimelineResponse proto.InternalMessageInfo
+
+func (m *ReadTimelineResponse) GetEvents() []*TimelineEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// TimelineEvent is a change recorded on the timeline
+type TimelineEvent struct {
+	// unix timestamp in nanoseconds
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// source of the event, runtime, router, config or alert
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// type of event e.g. service.updated, route.create, value.set, goroutines
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// service the event relates to, blank if it isn't specific to one
+	Service   string `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	Namespace string `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// description of the change e.g. helloworld updated to latest
+	Description          string            `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TimelineEvent) Reset()         { *m = TimelineEvent{} }
+func (m *TimelineEvent) String() string { return proto.CompactTextString(m) }
+func (*TimelineEvent) ProtoMessage()    {}
+func (*TimelineEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{70}
+}
+
+func (m *TimelineEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimelineEvent.Unmarshal(m, b)
+}
+func (m *TimelineEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimelineEvent.Marshal(b, m, deterministic)
+}
+func (m *TimelineEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimelineEvent.Merge(m, src)
+}
+func (m *TimelineEvent) XXX_Size() int {
+	return xxx_messageInfo_TimelineEvent.Size(m)
+}
+func (m *TimelineEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimelineEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimelineEvent proto.InternalMessageInfo
+
+func (m *TimelineEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("debug.SpanType", SpanType_name, SpanType_value)
+	proto.RegisterType((*HealthRequest)(nil), "debug.HealthRequest")
+	proto.RegisterType((*HealthResponse)(nil), "debug.HealthResponse")
+	proto.RegisterType((*HealthCheck)(nil), "debug.HealthCheck")
+	proto.RegisterType((*StatsRequest)(nil), "debug.StatsRequest")
+	proto.RegisterType((*StatsResponse)(nil), "debug.StatsResponse")
+	proto.RegisterMapType((map[string]*EndpointStats)(nil), "debug.StatsResponse.EndpointsEntry")
+	proto.RegisterType((*EndpointStats)(nil), "debug.EndpointStats")
+	proto.RegisterType((*LogRequest)(nil), "debug.LogRequest")
+	proto.RegisterType((*LogResponse)(nil), "debug.LogResponse")
+	proto.RegisterType((*Record)(nil), "debug.Record")
+	proto.RegisterMapType((map[string]string)(nil), "debug.Record.MetadataEntry")
+	proto.RegisterType((*TraceRequest)(nil), "debug.TraceRequest")
+	proto.RegisterType((*TraceResponse)(nil), "debug.TraceResponse")
+	proto.RegisterType((*Span)(nil), "debug.Span")
+	proto.RegisterMapType((map[string]string)(nil), "debug.Span.MetadataEntry")
+	proto.RegisterType((*ProfileRequest)(nil), "debug.ProfileRequest")
+	proto.RegisterType((*ProfileResponse)(nil), "debug.ProfileResponse")
+	proto.RegisterType((*DumpRequest)(nil), "debug.DumpRequest")
+	proto.RegisterType((*DumpResponse)(nil), "debug.DumpResponse")
+	proto.RegisterType((*BuildInfoRequest)(nil), "debug.BuildInfoRequest")
+	proto.RegisterType((*BuildInfoResponse)(nil), "debug.BuildInfoResponse")
+	proto.RegisterType((*ProfileInfo)(nil), "debug.ProfileInfo")
+	proto.RegisterType((*ListProfilesRequest)(nil), "debug.ListProfilesRequest")
+	proto.RegisterType((*ListProfilesResponse)(nil), "debug.ListProfilesResponse")
+	proto.RegisterType((*ReadProfileRequest)(nil), "debug.ReadProfileRequest")
+	proto.RegisterType((*ReadProfileResponse)(nil), "debug.ReadProfileResponse")
+	proto.RegisterType((*QueryMetricsRequest)(nil), "debug.QueryMetricsRequest")
+	proto.RegisterType((*QueryMetricsResponse)(nil), "debug.QueryMetricsResponse")
+	proto.RegisterType((*Series)(nil), "debug.Series")
+	proto.RegisterType((*Point)(nil), "debug.Point")
+	proto.RegisterType((*ObjectivesRequest)(nil), "debug.ObjectivesRequest")
+	proto.RegisterType((*ObjectivesResponse)(nil), "debug.ObjectivesResponse")
+	proto.RegisterType((*Objective)(nil), "debug.Objective")
+	proto.RegisterType((*TopRequest)(nil), "debug.TopRequest")
+	proto.RegisterType((*TopResponse)(nil), "debug.TopResponse")
+	proto.RegisterType((*ServiceStats)(nil), "debug.ServiceStats")
+	proto.RegisterType((*EndpointsRequest)(nil), "debug.EndpointsRequest")
+	proto.RegisterType((*EndpointsResponse)(nil), "debug.EndpointsResponse")
+	proto.RegisterType((*EndpointHistogram)(nil), "debug.EndpointHistogram")
+	proto.RegisterType((*LogRecord)(nil), "debug.LogRecord")
+	proto.RegisterMapType((map[string]string)(nil), "debug.LogRecord.LabelsEntry")
+	proto.RegisterType((*WriteLogsRequest)(nil), "debug.WriteLogsRequest")
+	proto.RegisterType((*WriteLogsResponse)(nil), "debug.WriteLogsResponse")
+	proto.RegisterType((*QueryLogsRequest)(nil), "debug.QueryLogsRequest")
+	proto.RegisterType((*QueryLogsResponse)(nil), "debug.QueryLogsResponse")
+	proto.RegisterType((*LabelsRequest)(nil), "debug.LabelsRequest")
+	proto.RegisterType((*LabelsResponse)(nil), "debug.LabelsResponse")
+	proto.RegisterType((*ProbeResultsRequest)(nil), "debug.ProbeResultsRequest")
+	proto.RegisterType((*ProbeResultsResponse)(nil), "debug.ProbeResultsResponse")
+	proto.RegisterType((*ProbeStatus)(nil), "debug.ProbeStatus")
+	proto.RegisterType((*ProbeResult)(nil), "debug.ProbeResult")
+	proto.RegisterType((*TraceSampling)(nil), "debug.TraceSampling")
+	proto.RegisterMapType((map[string]float64)(nil), "debug.TraceSampling.ServicesEntry")
+	proto.RegisterType((*SamplingRequest)(nil), "debug.SamplingRequest")
+	proto.RegisterType((*SamplingResponse)(nil), "debug.SamplingResponse")
+	proto.RegisterType((*SetSamplingRequest)(nil), "debug.SetSamplingRequest")
+	proto.RegisterType((*SetSamplingResponse)(nil), "debug.SetSamplingResponse")
+	proto.RegisterType((*CaptureSpan)(nil), "debug.CaptureSpan")
+	proto.RegisterMapType((map[string]string)(nil), "debug.CaptureSpan.MetadataEntry")
+	proto.RegisterType((*CaptureInfo)(nil), "debug.CaptureInfo")
+	proto.RegisterType((*ListCapturesRequest)(nil), "debug.ListCapturesRequest")
+	proto.RegisterType((*ListCapturesResponse)(nil), "debug.ListCapturesResponse")
+	proto.RegisterType((*ReadCaptureRequest)(nil), "debug.ReadCaptureRequest")
+	proto.RegisterType((*ReadCaptureResponse)(nil), "debug.ReadCaptureResponse")
+	proto.RegisterType((*ChaosExperiment)(nil), "debug.ChaosExperiment")
+	proto.RegisterType((*CreateExperimentRequest)(nil), "debug.CreateExperimentRequest")
+	proto.RegisterType((*CreateExperimentResponse)(nil), "debug.CreateExperimentResponse")
+	proto.RegisterType((*ListExperimentsRequest)(nil), "debug.ListExperimentsRequest")
+	proto.RegisterType((*ListExperimentsResponse)(nil), "debug.ListExperimentsResponse")
+	proto.RegisterType((*DeleteExperimentRequest)(nil), "debug.DeleteExperimentRequest")
+	proto.RegisterType((*DeleteExperimentResponse)(nil), "debug.DeleteExperimentResponse")
+	proto.RegisterType((*ChaosAuditRequest)(nil), "debug.ChaosAuditRequest")
+	proto.RegisterType((*ChaosAuditResponse)(nil), "debug.ChaosAuditResponse")
+	proto.RegisterType((*ChaosAuditEntry)(nil), "debug.ChaosAuditEntry")
+	proto.RegisterType((*ReadTimelineRequest)(nil), "debug.ReadTimelineRequest")
+	proto.RegisterType((*ReadTimelineResponse)(nil), "debug.ReadTimelineResponse")
+	proto.RegisterType((*TimelineEvent)(nil), "debug.TimelineEvent")
+	proto.RegisterMapType((map[string]string)(nil), "debug.TimelineEvent.MetadataEntry")
+}
+
+func init() { proto.RegisterFile("debug/debug.proto", fileDescriptor_5ae24eab94cb53d5) }
+
+var fileDescriptor_5ae24eab94cb53d5 = []byte{
+	// 2924 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0x5b, 0x8f, 0xdb, 0xc6,
+	0xd5, 0x21, 0x25, 0x4a, 0xe2, 0xd1, 0x5e, 0x67, 0x65, 0x9b, 0xe6, 0x26, 0xce, 0x86, 0xce, 0xc5,
+	0xf6, 0x97, 0xac, 0x0d, 0xe7, 0xea, 0xdc, 0xe3, 0x5d, 0xe7, 0x4b, 0x10, 0xe7, 0x46, 0x6f, 0x5a,
+	0xa0, 0x17, 0x2c, 0x28, 0x6a, 0xac, 0x65, 0x2d, 0x91, 0x0c, 0x49, 0xd9, 0xd9, 0x3c, 0x17, 0xe8,
+	0x53, 0xd3, 0xbe, 0xf4, 0xa1, 0xe8, 0x73, 0x51, 0xa0, 0x2f, 0x4d, 0xd1, 0x22, 0x2f, 0x45, 0x7f,
+	0x4a, 0xd1, 0xc7, 0xfe, 0x8d, 0x62, 0x66, 0xce, 0x8c, 0x66, 0x28, 0xca, 0x5e, 0x3b, 0xcd, 0xcb,
+	0x42, 0xe7, 0x32, 0x67, 0xce, 0x9c, 0xdb, 0x9c, 0x39, 0x5c, 0xd8, 0x1c, 0xd1, 0xe1, 0x6c, 0x7c,
+	0x99, 0xff, 0xdd, 0xcd, 0x8b, 0xac, 0xca, 0x88, 0xc3, 0x81, 0xe0, 0x22, 0xac, 0x7e, 0x40, 0xa3,
+	0x49, 0x75, 0x14, 0xd2, 0x2f, 0x67, 0xb4, 0xac, 0x88, 0x07, 0xdd, 0xf2, 0x28, 0x9a, 0x4c, 0xb2,
+	0x7b, 0x9e, 0xb5, 0x63, 0x5d, 0xe8, 0x85, 0x12, 0x0c, 0x0e, 0x60, 0x4d, 0xb2, 0x96, 0x79, 0x96,
+	0x96, 0x94, 0x9c, 0x86, 0x4e, 0x59, 0x45, 0xd5, 0xac, 0xe4, 0xac, 0x6e, 0x88, 0x10, 0xb9, 0x04,
+	0x9d, 0xf8, 0x88, 0xc6, 0x77, 0x4a, 0xcf, 0xde, 0x69, 0x5d, 0xe8, 0x5f, 0x25, 0xbb, 0x62, 0x67,
+	0xb1, 0x7c, 0x8f, 0x91, 0x42, 0xe4, 0x08, 0xee, 0x40, 0x5f, 0x43, 0x13, 0x02, 0xed, 0x34, 0x9a,
+	0x52, 0x14, 0xc8, 0x7f, 0x6b, 0xdb, 0xd8, 0xc6, 0x36, 0x03, 0x70, 0x68, 0x51, 0x64, 0x85, 0xd7,
+	0xe2, 0x68, 0x01, 0x10, 0x1f, 0x7a, 0xa3, 0x59, 0x11, 0x55, 0x49, 0x96, 0x7a, 0xed, 0x1d, 0xeb,
+	0x42, 0x2b, 0x54, 0x70, 0xb0, 0x06, 0x2b, 0xb7, 0xaa, 0xa8, 0x2a, 0xf1, 0xb0, 0xc1, 0xef, 0x5a,
+	0xb0, 0x8a, 0x08, 0x3c, 0xd2, 0xe3, 0xe0, 0x56, 0xc9, 0x94, 0x96, 0x55, 0x34, 0xcd, 0xb9, 0x12,
+	0xed, 0x70, 0x8e, 0xe0, 0xc6, 0xa9, 0xa2, 0xa2, 0xa2, 0x23, 0xae, 0x4a, 0x3b, 0x94, 0x20, 0xd3,
+	0x71, 0x96, 0x33, 0x46, 0xae, 0x4c, 0x3b, 0x44, 0x88, 0xe1, 0xa7, 0x74, 0x9a, 0x15, 0xc7, 0x5c,
+	0x97, 0x76, 0x88, 0x10, 0x93, 0x54, 0x1d, 0x15, 0x34, 0x1a, 0x95, 0x9e, 0x23, 0x24, 0x21, 0x48,
+	0xd6, 0xc0, 0x1e, 0xc7, 0x5e, 0x87, 0x23, 0xed, 0x71, 0xcc, 0xce, 0x53, 0x08, 0x75, 0x4b, 0xaf,
+	0xcb, 0xb1, 0x0a, 0x66, 0xd2, 0xf9, 0xa1, 0x4b, 0xaf, 0x27, 0xa4, 0x0b, 0x88, 0xbc, 0x07, 0x2e,
+	0x4d, 0x47, 0x79, 0x96, 0xa4, 0x55, 0xe9, 0xb9, 0xdc, 0x07, 0xe7, 0xd1, 0x07, 0xc6, 0x71, 0x77,
+	0x6f, 0x48, 0xae, 0x1b, 0x69, 0x55, 0x1c, 0x87, 0xf3, 0x55, 0xe4, 0x39, 0x58, 0x9f, 0x44, 0x15,
+	0x4d, 0xe3, 0xe3, 0xc3, 0xe1, 0x2c, 0xbe, 0x43, 0xab, 0xd2, 0x83, 0x9d, 0xd6, 0x05, 0x2b, 0x5c,
+	0x43, 0xf4, 0x75, 0x81, 0xf5, 0x43, 0x58, 0x33, 0xa5, 0x90, 0x0d, 0x68, 0xdd, 0xa1, 0xc7, 0xe8,
+	0x42, 0xf6, 0x93, 0x5c, 0x02, 0xe7, 0x6e, 0x34, 0x99, 0x51, 0x6e, 0xb5, 0xfe, 0xd5, 0x01, 0xea,
+	0x22, 0xd7, 0x09, 0x9d, 0x04, 0xcb, 0xeb, 0xf6, 0x6b, 0x56, 0xf0, 0x73, 0x58, 0x35, 0x68, 0x86,
+	0x11, 0xac, 0xa5, 0x46, 0xb0, 0x0d, 0x23, 0x78, 0xd0, 0x45, 0x55, 0xbd, 0xd6, 0x4e, 0x8b, 0x99,
+	0x18, 0xc1, 0xe0, 0x35, 0x80, 0x9b, 0xd9, 0x58, 0x46, 0xfc, 0x00, 0x9c, 0x38, 0x9b, 0xa5, 0x15,
+	0x17, 0xdc, 0x0a, 0x05, 0xc0, 0xb0, 0x65, 0x92, 0xc6, 0x42, 0xe5, 0x56, 0x28, 0x80, 0xe0, 0x15,
+	0xe8, 0xf3, 0x95, 0x18, 0x2d, 0xcf, 0x41, 0xb7, 0xa0, 0x71, 0x56, 0x8c, 0x98, 0x56, 0xcc, 0xca,
+	0xab, 0x78, 0xb2, 0x90, 0x63, 0x43, 0x49, 0x0d, 0xbe, 0xb3, 0xa0, 0x23, 0x70, 0x8b, 0x11, 0xd6,
+	0xd2, 0x23, 0xec, 0x55, 0xe8, 0x4d, 0x69, 0x15, 0x8d, 0xa2, 0x2a, 0xc2, 0xe4, 0xd9, 0x36, 0x44,
+	0xee, 0x7e, 0x8c, 0x54, 0xe1, 0x30, 0xc5, 0xcc, 0x4e, 0x3b, 0xa5, 0x65, 0x19, 0x8d, 0x29, 0xa6,
+	0x83, 0x04, 0xfd, 0x37, 0x60, 0xd5, 0x58, 0xd4, 0xe0, 0x9f, 0x81, 0xee, 0x1f, 0x57, 0xf7, 0xc4,
+	0x39, 0x58, 0x39, 0x28, 0xa2, 0x98, 0x4a, 0x63, 0xad, 0x81, 0x9d, 0x8c, 0x70, 0xa9, 0x9d, 0x8c,
+	0x82, 0xab, 0xb0, 0x8a, 0x74, 0x34, 0xc9, 0x53, 0xe0, 0x94, 0x79, 0x94, 0x4a, 0x83, 0xf4, 0x65,
+	0xd8, 0xe5, 0x51, 0x1a, 0x0a, 0x4a, 0xf0, 0x27, 0x1b, 0xda, 0x0c, 0x66, 0xdb, 0x56, 0x6c, 0x31,
+	0xca, 0x13, 0x00, 0x6e, 0x61, 0xcb, 0x2d, 0x98, 0x7f, 0xf3, 0xa8, 0xa0, 0x69, 0x85, 0x07, 0x43,
+	0x48, 0x95, 0x8a, 0xb6, 0x56, 0x2a, 0xb4, 0x04, 0x75, 0xcc, 0x04, 0xd5, 0xcb, 0x82, 0x48, 0x2e,
+	0x05, 0x93, 0x97, 0x35, 0xa3, 0x77, 0xb9, 0xda, 0x67, 0x35, 0xb5, 0x97, 0x9a, 0xfc, 0x3c, 0xb4,
+	0xab, 0xe3, 0x9c, 0xf2, 0xdc, 0x5b, 0xbb, 0xba, 0xae, 0x2d, 0x39, 0x38, 0xce, 0x69, 0xc8, 0x89,
+	0xdf, 0xcf, 0xfa, 0xef, 0xc2, 0xda, 0x67, 0x45, 0x76, 0x3b, 0x99, 0x28, 0xfb, 0x13, 0xdc, 0x13,
+	0xeb, 0x23, 0xfb, 0x6d, 0x1c, 0xcd, 0xae, 0x55, 0xbc, 0x67, 0x60, 0x5d, 0x49, 0x40, 0x0f, 0x11,
+	0x68, 0xf3, 0x93, 0x32, 0x11, 0x2b, 0x21, 0xff, 0x1d, 0x3c, 0x05, 0xfd, 0xfd, 0xd9, 0x34, 0xbf,
+	0xcf, 0x2e, 0x41, 0x00, 0x2b, 0x82, 0xe5, 0x3e, 0x62, 0x08, 0x6c, 0x5c, 0x9f, 0x25, 0x93, 0xd1,
+	0x87, 0xe9, 0xed, 0x4c, 0xd6, 0xd8, 0xef, 0x2c, 0xd8, 0xd4, 0x90, 0xb8, 0xda, 0x83, 0xee, 0x5d,
+	0x5a, 0x94, 0x4c, 0x65, 0xb1, 0x89, 0x04, 0x99, 0xbb, 0xe3, 0x6c, 0x3a, 0x4d, 0x2a, 0x59, 0xed,
+	0x05, 0x84, 0xfb, 0xc9, 0xe8, 0xe6, 0xbf, 0xc9, 0x13, 0x00, 0xe3, 0xec, 0x50, 0x0a, 0x12, 0x81,
+	0xe0, 0x8e, 0xb3, 0x1f, 0xa1, 0xa8, 0x35, 0xb0, 0x33, 0x51, 0x5f, 0xdd, 0xd0, 0xce, 0x4a, 0x26,
+	0x22, 0x2a, 0xe2, 0x23, 0xee, 0x7f, 0x37, 0xe4, 0xbf, 0x99, 0x22, 0xf9, 0x64, 0x36, 0x4e, 0xd2,
+	0x92, 0xbb, 0xde, 0x0d, 0x25, 0x18, 0xfc, 0xc1, 0x82, 0x3e, 0xda, 0x8e, 0xa9, 0xce, 0x38, 0x4b,
+	0x5a, 0xdc, 0x4d, 0x54, 0xbc, 0x4a, 0x50, 0x3f, 0x8c, 0x6d, 0x1e, 0x86, 0xc5, 0x68, 0x36, 0x52,
+	0x4a, 0xb3, 0xdf, 0xca, 0xb8, 0x6d, 0xcd, 0x85, 0x46, 0x51, 0x70, 0xea, 0x45, 0x81, 0x40, 0xbb,
+	0x4c, 0xbe, 0xa6, 0x5c, 0xef, 0x56, 0xc8, 0x7f, 0x07, 0x7b, 0xb0, 0x75, 0x33, 0x29, 0x2b, 0x54,
+	0xb0, 0xd4, 0xaf, 0xef, 0x66, 0x25, 0xe5, 0xb6, 0xb6, 0xe6, 0xd3, 0xf7, 0x61, 0x60, 0x0a, 0x41,
+	0xef, 0xec, 0x42, 0x2f, 0x47, 0x9c, 0x67, 0x19, 0x57, 0xb8, 0x66, 0x90, 0x50, 0xf1, 0x04, 0x21,
+	0x90, 0x90, 0x46, 0xa3, 0x5a, 0xac, 0x3e, 0x94, 0x2e, 0xcc, 0x59, 0x91, 0x48, 0xf1, 0x56, 0x68,
+	0x47, 0x55, 0xf0, 0x39, 0x6c, 0x19, 0x32, 0x51, 0xb5, 0x67, 0xa1, 0x9d, 0xa4, 0xb7, 0x33, 0x2e,
+	0xb1, 0x59, 0x2d, 0x4e, 0x57, 0xe1, 0x69, 0x6b, 0xe1, 0xf9, 0x6b, 0x0b, 0xb6, 0x3e, 0x9f, 0xd1,
+	0xe2, 0xf8, 0x63, 0x5a, 0x15, 0x49, 0x7c, 0x02, 0xa3, 0xf1, 0xeb, 0x9b, 0xf1, 0xca, 0x60, 0x14,
+	0x10, 0xbf, 0x1d, 0x58, 0x61, 0x41, 0x7d, 0x05, 0xc0, 0x52, 0x9b, 0xa6, 0x23, 0xec, 0x3a, 0xd8,
+	0x4f, 0xe6, 0xd7, 0x68, 0x3c, 0x2e, 0xe8, 0x98, 0x45, 0xae, 0xc3, 0xfb, 0xa9, 0x39, 0x22, 0x78,
+	0x0b, 0x06, 0xa6, 0x3a, 0x78, 0xc6, 0x67, 0xa0, 0x53, 0xd2, 0x22, 0xa1, 0xf5, 0x5b, 0xe5, 0x16,
+	0x47, 0x86, 0x48, 0x0c, 0xbe, 0xb1, 0xa0, 0x23, 0x50, 0xff, 0xb3, 0xd8, 0x9c, 0x9f, 0xb7, 0x6d,
+	0x9c, 0xf7, 0x69, 0xe8, 0x60, 0x37, 0xe1, 0x70, 0x8d, 0x56, 0xa4, 0xdd, 0x19, 0x32, 0x44, 0x5a,
+	0xf0, 0x06, 0x38, 0x1c, 0xf1, 0x80, 0x3b, 0xce, 0xa8, 0x77, 0x16, 0xd6, 0xbb, 0xe0, 0x05, 0xd8,
+	0xfc, 0x74, 0xf8, 0x0b, 0x1a, 0x57, 0xc9, 0xdd, 0x13, 0x84, 0x73, 0xf0, 0x3e, 0x10, 0x9d, 0x1d,
+	0x2d, 0x77, 0x05, 0x20, 0x53, 0x58, 0xb4, 0xde, 0x06, 0xea, 0xaa, 0xd8, 0x43, 0x8d, 0x27, 0xf8,
+	0x8b, 0x0d, 0xae, 0xa2, 0x34, 0xb6, 0x9f, 0x9a, 0x0e, 0xb6, 0x69, 0x5b, 0x1f, 0x7a, 0xb2, 0x61,
+	0x42, 0x2b, 0x2a, 0x98, 0x59, 0xb2, 0x8a, 0x8a, 0x31, 0xad, 0xb8, 0x25, 0xad, 0x10, 0x21, 0xbd,
+	0x2b, 0x11, 0x85, 0x49, 0x82, 0x6c, 0xc5, 0xbd, 0x24, 0x1d, 0x65, 0xf7, 0xb0, 0x3e, 0x21, 0xc4,
+	0x6f, 0xc9, 0xac, 0x8a, 0x26, 0xd8, 0xfd, 0x09, 0x80, 0xc5, 0xda, 0x30, 0x1a, 0x61, 0xdf, 0xc7,
+	0x7e, 0x92, 0x73, 0x00, 0x71, 0x36, 0xcd, 0x27, 0x49, 0xc4, 0xda, 0x16, 0x97, 0xef, 0xaa, 0x61,
+	0xc8, 0x45, 0xd8, 0x18, 0xce, 0x46, 0x63, 0x5a, 0x1d, 0x16, 0x74, 0x1a, 0x25, 0x69, 0x92, 0x8e,
+	0x3d, 0xe0, 0x5c, 0xeb, 0x02, 0x1f, 0x4a, 0x34, 0xd9, 0x06, 0x77, 0x38, 0x2b, 0xd2, 0xc3, 0x82,
+	0x85, 0x6d, 0x9f, 0xf3, 0xf4, 0x18, 0x22, 0x64, 0x51, 0xfb, 0x2c, 0xc0, 0x41, 0x96, 0x3f, 0xd8,
+	0x43, 0x3f, 0x83, 0x3e, 0xe7, 0x5b, 0xd6, 0x59, 0x1b, 0x31, 0x71, 0x19, 0x7a, 0xb8, 0x4e, 0x3e,
+	0x1a, 0xb6, 0xe6, 0x41, 0xcf, 0xd0, 0xa2, 0x47, 0x54, 0x4c, 0xc1, 0x3f, 0x2d, 0x58, 0xd1, 0x49,
+	0xf7, 0x49, 0x81, 0x01, 0x38, 0x2c, 0xb8, 0x4b, 0xd9, 0xca, 0x71, 0xc0, 0x68, 0x29, 0x5b, 0xe2,
+	0x88, 0x12, 0x66, 0xf7, 0x0a, 0x6f, 0x22, 0x85, 0x01, 0x84, 0x03, 0x5d, 0x8e, 0x61, 0x16, 0x60,
+	0xb6, 0xcf, 0xaf, 0x5d, 0xe3, 0xfe, 0xb3, 0x42, 0xf6, 0x53, 0x6b, 0xf3, 0x3b, 0xcb, 0xda, 0xfc,
+	0xae, 0xd1, 0xe6, 0x07, 0x39, 0x6c, 0xa8, 0xb6, 0xf9, 0xc1, 0x75, 0x48, 0x8f, 0x34, 0xbb, 0x16,
+	0x69, 0x27, 0xac, 0x45, 0xc1, 0x47, 0xb0, 0xa9, 0xed, 0x88, 0x5e, 0x79, 0x45, 0x7f, 0x29, 0x88,
+	0x7c, 0xf1, 0x6a, 0xdd, 0xf9, 0x07, 0x49, 0x59, 0x65, 0xe3, 0x22, 0x9a, 0x6a, 0xcf, 0x83, 0xe0,
+	0x3f, 0x16, 0x6c, 0x2e, 0x30, 0x3c, 0xe2, 0x01, 0xea, 0x9e, 0x68, 0x6e, 0xee, 0xdb, 0xf5, 0xe6,
+	0x5e, 0x3e, 0x4b, 0x1c, 0xfe, 0x2c, 0x91, 0xa0, 0x9e, 0x60, 0x1d, 0xa3, 0xed, 0xe7, 0x6e, 0x7b,
+	0xf9, 0x8a, 0xd7, 0x45, 0xb7, 0xbd, 0x7c, 0x45, 0x38, 0xf2, 0x8a, 0xd7, 0x43, 0xcc, 0xb5, 0x2b,
+	0xd2, 0xb5, 0xae, 0x72, 0x6d, 0xf0, 0x77, 0x0b, 0x5c, 0xde, 0xf3, 0x9f, 0xa0, 0x7b, 0x7f, 0x09,
+	0x3a, 0x93, 0x68, 0x48, 0x27, 0x32, 0x86, 0x1f, 0x47, 0x53, 0xaa, 0xf5, 0xbb, 0x37, 0x39, 0x59,
+	0x74, 0x92, 0xc8, 0x7b, 0x9f, 0xd6, 0xfd, 0x1a, 0xf4, 0xb5, 0x05, 0x0f, 0xd5, 0x3a, 0xbe, 0x0d,
+	0x1b, 0x3f, 0x2e, 0x92, 0x8a, 0xde, 0xcc, 0xc6, 0x2a, 0xbe, 0x2e, 0xd5, 0x9f, 0x2b, 0x1b, 0x75,
+	0xfd, 0xe6, 0x2f, 0x96, 0x2d, 0xd8, 0xd4, 0xd6, 0x8b, 0x68, 0x09, 0x6e, 0xc3, 0x06, 0xbf, 0xb0,
+	0x74, 0xa1, 0x03, 0x70, 0xbe, 0x64, 0x38, 0xd9, 0xc4, 0x73, 0x60, 0x1e, 0x94, 0x76, 0x43, 0x50,
+	0xb6, 0xe6, 0x17, 0xe4, 0x00, 0x9c, 0x49, 0xc2, 0x9a, 0x3d, 0x11, 0xa8, 0x02, 0x08, 0xde, 0x81,
+	0x4d, 0x6d, 0x1f, 0x0c, 0xd5, 0x87, 0xd1, 0xfe, 0x3c, 0xac, 0x0a, 0xc3, 0x69, 0x1d, 0x6d, 0xbd,
+	0xb0, 0x07, 0x17, 0x60, 0x4d, 0x32, 0xcd, 0x07, 0x1a, 0xdc, 0x82, 0x62, 0x07, 0x37, 0x44, 0x28,
+	0xf8, 0x29, 0x6c, 0x7d, 0x56, 0x64, 0x43, 0x1a, 0xd2, 0x72, 0x36, 0xa9, 0xee, 0x27, 0x94, 0x05,
+	0xf3, 0x24, 0x8b, 0xe7, 0xcd, 0xb8, 0x1b, 0x2a, 0xb8, 0x39, 0x53, 0x83, 0x1c, 0x06, 0xa6, 0x70,
+	0x75, 0xde, 0x4e, 0xce, 0xf0, 0x0d, 0x2d, 0xd8, 0x90, 0xd7, 0xbc, 0x59, 0x19, 0x22, 0x07, 0x79,
+	0x9e, 0xd9, 0x86, 0x2f, 0xf7, 0xec, 0x45, 0x66, 0x21, 0x39, 0x94, 0x2c, 0xc1, 0xbf, 0x44, 0x67,
+	0x2b, 0xa5, 0x34, 0x9e, 0xa3, 0xa9, 0x45, 0x9b, 0xdf, 0x69, 0xf8, 0x12, 0x13, 0x90, 0x71, 0xe6,
+	0xf6, 0xe2, 0x99, 0xc5, 0xed, 0xe5, 0xe8, 0xb7, 0x97, 0x0f, 0xbd, 0xdb, 0x51, 0x32, 0x99, 0x15,
+	0xb4, 0x94, 0xaf, 0x31, 0x09, 0xeb, 0x09, 0xdc, 0xe5, 0x76, 0x92, 0x20, 0xeb, 0xfd, 0x26, 0x51,
+	0x59, 0xf1, 0x7c, 0x6d, 0x3e, 0x22, 0xa7, 0x07, 0x7f, 0x94, 0xe7, 0x13, 0xd8, 0x87, 0xf6, 0x93,
+	0x91, 0xe4, 0xad, 0x7a, 0x92, 0xb3, 0x22, 0x37, 0x8b, 0x63, 0x5a, 0x8a, 0x9a, 0xd4, 0x0b, 0x25,
+	0x58, 0xbf, 0xdb, 0x35, 0xcd, 0xd5, 0xa8, 0xaa, 0xa3, 0x8d, 0xaa, 0x82, 0x7f, 0x5b, 0xf8, 0x7a,
+	0xbe, 0x15, 0xb1, 0x5b, 0x3a, 0x1d, 0x33, 0x4d, 0xf9, 0x95, 0x63, 0xf1, 0xfa, 0xc3, 0x7f, 0x93,
+	0xb7, 0x17, 0xae, 0xc6, 0x00, 0x4f, 0x6e, 0xac, 0x95, 0x17, 0x25, 0x16, 0x17, 0xb5, 0x86, 0x9c,
+	0x87, 0xd5, 0x92, 0xf1, 0xd0, 0x43, 0xac, 0xa4, 0x2d, 0xae, 0xf5, 0x8a, 0x40, 0xde, 0x50, 0xf5,
+	0x74, 0x96, 0x8f, 0xa2, 0x8a, 0xca, 0x2b, 0x43, 0x82, 0xec, 0x01, 0x6b, 0x48, 0x7e, 0x50, 0x15,
+	0xb2, 0xf4, 0x2a, 0xb4, 0x09, 0xeb, 0x52, 0x3f, 0xf9, 0x1e, 0xdc, 0x87, 0x8d, 0x39, 0x4a, 0xb5,
+	0x6d, 0xbd, 0x12, 0x71, 0x9e, 0x65, 0x8c, 0x88, 0x8c, 0x23, 0x86, 0x8a, 0x8b, 0xb5, 0x7f, 0xb7,
+	0x68, 0x55, 0x93, 0xfd, 0x08, 0x72, 0x4e, 0xc1, 0x96, 0x21, 0x07, 0x0b, 0xdd, 0xb7, 0x2d, 0xe8,
+	0xef, 0x45, 0x79, 0x35, 0x2b, 0xe8, 0x0f, 0x34, 0xa9, 0x90, 0xf9, 0xe5, 0x68, 0xf9, 0xa5, 0x5d,
+	0x9f, 0x9d, 0x85, 0x07, 0x13, 0xef, 0xd5, 0xbb, 0x5a, 0xaf, 0xae, 0xcd, 0x3a, 0x7a, 0xc2, 0x65,
+	0x4d, 0xb3, 0x0e, 0xd7, 0x1c, 0x08, 0x90, 0xa7, 0x60, 0x05, 0x2f, 0xd7, 0x43, 0xfe, 0xa6, 0x04,
+	0x4e, 0xef, 0x23, 0xee, 0x56, 0xf2, 0x35, 0x65, 0x01, 0x53, 0xa0, 0x21, 0x04, 0x4f, 0x9f, 0xf3,
+	0xac, 0x48, 0x24, 0x67, 0x52, 0x11, 0xbd, 0xa2, 0x0f, 0x5f, 0xdf, 0xd4, 0x26, 0x29, 0xab, 0x3c,
+	0x56, 0x77, 0xd0, 0x01, 0x9a, 0x35, 0x97, 0x0d, 0x54, 0xbe, 0xdf, 0xac, 0xe4, 0xaf, 0x96, 0x72,
+	0x19, 0x7f, 0xae, 0xd7, 0x26, 0x55, 0xca, 0x09, 0x76, 0xf3, 0xb8, 0xa8, 0xb5, 0xdc, 0x84, 0xb5,
+	0x29, 0x32, 0x2f, 0xe3, 0x7c, 0xc4, 0xe5, 0x60, 0x19, 0x67, 0x80, 0xd6, 0xa9, 0x88, 0x67, 0x3a,
+	0x42, 0x4c, 0x92, 0x4a, 0x5f, 0x31, 0x61, 0x50, 0x70, 0x70, 0x43, 0x3c, 0xe2, 0x51, 0xed, 0x13,
+	0xf4, 0x81, 0xea, 0xba, 0xb4, 0xf5, 0xeb, 0x12, 0x9f, 0xf1, 0x73, 0x31, 0xf3, 0x67, 0x7c, 0x8c,
+	0xb8, 0xda, 0x1d, 0xa2, 0x19, 0x2a, 0x54, 0x3c, 0xc1, 0xd3, 0xe2, 0x19, 0x8f, 0xc4, 0x65, 0x23,
+	0xbf, 0x77, 0x60, 0xcb, 0xe0, 0xc2, 0xcd, 0x2e, 0x98, 0x83, 0x3f, 0xb2, 0xe8, 0x77, 0x39, 0xff,
+	0xfb, 0x9b, 0x0d, 0xeb, 0x7b, 0x47, 0x51, 0x56, 0xde, 0xf8, 0x2a, 0xa7, 0x45, 0x32, 0xa5, 0xe9,
+	0xc2, 0x26, 0x8f, 0xf8, 0xe8, 0x6a, 0x1a, 0xad, 0x9c, 0x03, 0xc8, 0x69, 0x11, 0xd3, 0xb4, 0x62,
+	0x0d, 0x96, 0xe8, 0xd9, 0x35, 0x8c, 0xd9, 0x2f, 0x1a, 0x0f, 0x32, 0xf5, 0x0a, 0x88, 0x65, 0xea,
+	0x39, 0xf8, 0x0a, 0xd8, 0x63, 0xf9, 0xa7, 0x87, 0x48, 0x4f, 0x28, 0x22, 0x61, 0x26, 0x34, 0x2e,
+	0x28, 0x2f, 0xa7, 0x22, 0x01, 0x25, 0xc8, 0x28, 0xf4, 0xab, 0x3c, 0x61, 0x2e, 0x11, 0xa9, 0x27,
+	0x41, 0xb6, 0x1d, 0x32, 0x1d, 0x0e, 0x8f, 0x79, 0xce, 0xb9, 0xa1, 0x8b, 0x98, 0xeb, 0xc7, 0xc1,
+	0xe7, 0x70, 0x66, 0x8f, 0x03, 0x73, 0xab, 0x49, 0x0f, 0xbd, 0x02, 0x40, 0x15, 0x12, 0x0b, 0xdf,
+	0x69, 0x69, 0x7f, 0xd3, 0xd0, 0xa1, 0xc6, 0x19, 0x84, 0xe0, 0x2d, 0x8a, 0x54, 0x0f, 0x83, 0x47,
+	0x93, 0xe9, 0xc1, 0x69, 0x16, 0x8b, 0x73, 0xaa, 0xfa, 0xd8, 0x72, 0x0b, 0xce, 0x2c, 0x50, 0x70,
+	0xb3, 0xd7, 0xa0, 0x3f, 0x17, 0x21, 0x23, 0x68, 0xd9, 0x6e, 0x3a, 0x6b, 0x70, 0x11, 0xce, 0xec,
+	0xd3, 0x09, 0x6d, 0xb2, 0x4a, 0x3d, 0x6e, 0x7d, 0xf0, 0x16, 0x59, 0xb1, 0xde, 0x5f, 0x84, 0x4d,
+	0xbe, 0xcd, 0x7b, 0xb3, 0x51, 0x52, 0x69, 0x9d, 0xad, 0x48, 0x36, 0xcb, 0x4c, 0x36, 0xa2, 0xb3,
+	0xaa, 0x1b, 0xac, 0x4b, 0xd3, 0x4a, 0x9b, 0xd9, 0x18, 0xda, 0x73, 0x5e, 0x51, 0xed, 0x24, 0x5b,
+	0xf0, 0x7b, 0x0b, 0xd6, 0x6b, 0xc4, 0x07, 0xbc, 0x2e, 0x4e, 0x43, 0x27, 0x8a, 0xb5, 0x86, 0x05,
+	0x21, 0x16, 0x52, 0x51, 0x2c, 0x3e, 0x61, 0xe0, 0xfb, 0x01, 0xc1, 0x9a, 0x13, 0xdb, 0x27, 0x76,
+	0xe2, 0xaf, 0x2c, 0x91, 0xe3, 0x07, 0xc9, 0x94, 0x4e, 0x92, 0x94, 0x6a, 0x16, 0x11, 0x0d, 0xac,
+	0xd5, 0xd0, 0xd5, 0xdb, 0xf3, 0xae, 0x9e, 0x65, 0x6f, 0x36, 0x2b, 0x58, 0xc9, 0x6b, 0x89, 0xa1,
+	0x2a, 0x82, 0x7a, 0x5e, 0xb7, 0x97, 0x94, 0x36, 0x47, 0xb7, 0xf6, 0x3e, 0x0c, 0x4c, 0x45, 0xd0,
+	0xde, 0xcf, 0x43, 0x87, 0xde, 0xd5, 0x82, 0x45, 0xdd, 0xf3, 0xc8, 0x78, 0x83, 0x11, 0x43, 0xe4,
+	0x09, 0xbe, 0xb5, 0x61, 0xd5, 0xa0, 0x3c, 0xd8, 0xd2, 0x42, 0x61, 0x69, 0x69, 0x01, 0xa9, 0xfa,
+	0xd2, 0x6a, 0xbe, 0xb4, 0x6b, 0x27, 0x7a, 0x1c, 0x5c, 0x76, 0xcb, 0x94, 0x39, 0x6b, 0x1c, 0xc4,
+	0x3d, 0x3f, 0x47, 0x90, 0x1d, 0xe8, 0x8f, 0x68, 0x19, 0x17, 0x49, 0xae, 0xbe, 0x49, 0xb8, 0xa1,
+	0x8e, 0x62, 0x8d, 0x5f, 0xed, 0xb3, 0x44, 0xd0, 0x74, 0xca, 0x1f, 0xe4, 0x3a, 0xbd, 0xf4, 0x0c,
+	0xf4, 0xe4, 0x97, 0x0c, 0xd2, 0x87, 0xee, 0x87, 0x9f, 0x5c, 0xff, 0xf4, 0x8b, 0x4f, 0xf6, 0x37,
+	0x1e, 0x23, 0x2b, 0xd0, 0xfb, 0xf4, 0x8b, 0x03, 0x01, 0x59, 0x57, 0x7f, 0xd3, 0x02, 0x67, 0x9f,
+	0xe9, 0x44, 0x76, 0xa1, 0x75, 0x33, 0x1b, 0x93, 0x4d, 0xfd, 0x51, 0xc6, 0xa3, 0xc6, 0x27, 0x3a,
+	0x0a, 0x13, 0xee, 0x31, 0xf2, 0x2a, 0x74, 0xc4, 0x87, 0x5f, 0x32, 0x30, 0x3e, 0x0f, 0xcb, 0x55,
+	0xa7, 0x6a, 0x58, 0xb5, 0xf0, 0x25, 0x70, 0xc4, 0xc4, 0x67, 0xcb, 0xfc, 0xa4, 0x29, 0x96, 0x0d,
+	0x9a, 0xbe, 0x73, 0x8a, 0x55, 0xbc, 0x07, 0x54, 0xab, 0xf4, 0xcf, 0x5a, 0xfe, 0xc0, 0x44, 0xaa,
+	0x55, 0xaf, 0x43, 0x17, 0x47, 0xcb, 0xe4, 0x94, 0x39, 0x6a, 0x96, 0x2b, 0x4f, 0xd7, 0xd1, 0x6a,
+	0xed, 0x8b, 0xd0, 0x66, 0x1f, 0x4c, 0x88, 0x3c, 0xbe, 0xf6, 0x81, 0xc5, 0xdf, 0x32, 0x70, 0x72,
+	0xc9, 0x15, 0x8b, 0xbc, 0x0b, 0xae, 0xfa, 0x58, 0x42, 0xce, 0x20, 0x57, 0xfd, 0x9b, 0x8a, 0xef,
+	0x2d, 0x12, 0xa4, 0x8c, 0xab, 0xdf, 0x58, 0xd0, 0x43, 0x65, 0xd8, 0x87, 0xe0, 0x36, 0xab, 0xb9,
+	0xc4, 0x97, 0x2e, 0x58, 0xfc, 0x64, 0xe0, 0x6f, 0x37, 0xd2, 0xd4, 0x31, 0xde, 0x81, 0x36, 0xcb,
+	0x40, 0x72, 0x56, 0x7d, 0x87, 0xac, 0x0f, 0xfa, 0x7d, 0xbf, 0x89, 0xa4, 0x14, 0xfa, 0xa5, 0x0d,
+	0x5d, 0x9c, 0x70, 0x93, 0xeb, 0xe0, 0xf0, 0x87, 0xbd, 0x52, 0xa8, 0x61, 0x1c, 0xef, 0x6f, 0x37,
+	0xd2, 0x94, 0x42, 0x7b, 0x00, 0xf3, 0xc9, 0x2f, 0xf1, 0xea, 0xd3, 0x5d, 0x25, 0xe6, 0x6c, 0x03,
+	0x45, 0x09, 0xd9, 0x85, 0xd6, 0x41, 0x96, 0xab, 0x68, 0x9d, 0x0f, 0x34, 0x7d, 0xa2, 0xa3, 0x14,
+	0xff, 0xbb, 0xe0, 0xaa, 0xe1, 0x99, 0xf2, 0x4b, 0x7d, 0x80, 0xe7, 0x7b, 0x8b, 0x04, 0x65, 0x86,
+	0x7f, 0x58, 0xd0, 0x66, 0xf3, 0x0c, 0xf2, 0x26, 0x38, 0x7c, 0xb2, 0xa2, 0xc4, 0xd4, 0xe7, 0x34,
+	0xbe, 0xb7, 0x48, 0x50, 0x8a, 0xbc, 0x29, 0x2d, 0x78, 0x46, 0xb7, 0x52, 0xd3, 0xea, 0x85, 0x09,
+	0x8a, 0x48, 0x3a, 0x31, 0xf2, 0x50, 0x49, 0x67, 0x8c, 0x49, 0xfc, 0x53, 0x35, 0xac, 0xd2, 0xfe,
+	0xb7, 0x16, 0x74, 0x59, 0x72, 0xb0, 0x47, 0xea, 0x5b, 0xd0, 0x53, 0x0f, 0x56, 0x19, 0xfe, 0xb5,
+	0x97, 0x98, 0x7f, 0x66, 0x01, 0xaf, 0x74, 0x78, 0x1f, 0xfa, 0xda, 0x93, 0x4b, 0xc5, 0xd5, 0xe2,
+	0x73, 0xce, 0xf7, 0x9b, 0x48, 0x46, 0xa0, 0xcb, 0x96, 0xb7, 0x31, 0xd0, 0x6b, 0x6d, 0xb5, 0xbf,
+	0xdd, 0x48, 0xbb, 0x6f, 0xa0, 0x9b, 0xad, 0xb0, 0xef, 0x37, 0x91, 0x94, 0x42, 0x7f, 0xb6, 0xc1,
+	0xe1, 0xb7, 0x2a, 0xf9, 0x08, 0x3a, 0xa2, 0xb1, 0x22, 0xe7, 0xe4, 0x6d, 0xdb, 0xdc, 0xba, 0xf9,
+	0x4f, 0x2e, 0xa5, 0x2b, 0xbd, 0xfe, 0x1f, 0x8f, 0xf6, 0x84, 0xa6, 0xfe, 0x62, 0x7b, 0xe5, 0x9f,
+	0x5b, 0x46, 0x56, 0x82, 0x3e, 0x82, 0x8e, 0x68, 0x80, 0x94, 0x56, 0x4b, 0x5a, 0x27, 0xff, 0xc9,
+	0xa5, 0x74, 0x25, 0xec, 0x6d, 0x70, 0x78, 0xe3, 0xa2, 0x12, 0x70, 0xa1, 0x7f, 0xf2, 0xcf, 0x36,
+	0x50, 0x94, 0xb1, 0x3e, 0x81, 0xce, 0x67, 0x62, 0x76, 0xb5, 0x0f, 0x5d, 0x1c, 0x7d, 0x29, 0xef,
+	0x35, 0x0c, 0xdb, 0xfc, 0xed, 0x46, 0x9a, 0x92, 0xf7, 0x31, 0xf4, 0xe4, 0xad, 0xc8, 0x82, 0x81,
+	0x7b, 0x52, 0x77, 0x57, 0xad, 0x95, 0xf1, 0xb7, 0x1b, 0x69, 0x52, 0xdc, 0xf5, 0x17, 0x7e, 0xf2,
+	0x7f, 0xe3, 0xa4, 0x3a, 0x9a, 0x0d, 0x77, 0xe3, 0x6c, 0x7a, 0x79, 0x9a, 0xc4, 0x45, 0x86, 0x7f,
+	0xef, 0xbe, 0x28, 0xfe, 0x8f, 0xea, 0x32, 0xff, 0x3f, 0xaa, 0x37, 0xf8, 0xef, 0x61, 0x87, 0x03,
+	0x2f, 0xfe, 0x77, 0x00, 0x21, 0x17, 0x09, 0xd9, 0x69, 0x25, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DebugClient is the client API for Debug service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DebugClient interface {
+	Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (*TraceResponse, error)
+	Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
+	Dump(ctx context.Context, in *DumpRequest, opts ...grpc.CallOption) (Debug_DumpClient, error)
+	BuildInfo(ctx context.Context, in *BuildInfoRequest, opts ...grpc.CallOption) (*BuildInfoResponse, error)
+}
+
+type debugClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDebugClient(cc *grpc.ClientConn) DebugClient {
+	return &debugClient{cc}
+}
+
+func (c *debugClient) Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error) {
+	out := new(LogResponse)
+	err := c.cc.Invoke(ctx, "/debug.Debug/Log", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/debug.Debug/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/debug.Debug/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (*TraceResponse, error) {
+	out := new(TraceResponse)
+	err := c.cc.Invoke(ctx, "/debug.Debug/Trace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error) {
+	out := new(ProfileResponse)
+	err := c.cc.Invoke(ctx, "/debug.Debug/Profile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) Dump(ctx context.Context, in *DumpRequest, opts ...grpc.CallOption) (Debug_DumpClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Debug_serviceDesc.Streams[0], "/debug.Debug/Dump", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &debugDumpClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Debug_DumpClient interface {
+	Recv() (*DumpResponse, error)
+	grpc.ClientStream
+}
+
+type debugDumpClient struct {
+	grpc.ClientStream
+}
+
+func (x *debugDumpClient) Recv() (*DumpResponse, error) {
+	m := new(DumpResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *debugClient) BuildInfo(ctx context.Context, in *BuildInfoRequest, opts ...grpc.CallOption) (*BuildInfoResponse, error) {
+	out := new(BuildInfoResponse)
+	err := c.cc.Invoke(ctx, "/debug.Debug/BuildInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DebugServer is the server API for Debug service.
+type DebugServer interface {
+	Log(context.Context, *LogRequest) (*LogResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	Trace(context.Context, *TraceRequest) (*TraceResponse, error)
+	Profile(context.Context, *ProfileRequest) (*ProfileResponse, error)
+	Dump(*DumpRequest, Debug_DumpServer) error
+	BuildInfo(context.Context, *BuildInfoRequest) (*BuildInfoResponse, error)
+}
+
+func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
+	s.RegisterService(&_Debug_serviceDesc, srv)
+}
+
+func _Debug_Log_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).Log(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Debug/Log",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).Log(ctx, req.(*LogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Debug/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DebugServer).Stats(ctx, in)
+		return srv.(DebugServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Debug/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_Trace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).Trace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Debug/Trace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).Trace(ctx, req.(*TraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_Profile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).Profile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Debug/Profile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).Profile(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_Dump_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DumpRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DebugServer).Dump(m, &debugDumpServer{stream})
+}
+
+type Debug_DumpServer interface {
+	Send(*DumpResponse) error
+	grpc.ServerStream
+}
+
+type debugDumpServer struct {
+	grpc.ServerStream
+}
+
+func (x *debugDumpServer) Send(m *DumpResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Debug_BuildInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).BuildInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Debug/BuildInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).BuildInfo(ctx, req.(*BuildInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Debug_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Debug",
+	HandlerType: (*DebugServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Log",
+			Handler:    _Debug_Log_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _Debug_Health_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _Debug_Stats_Handler,
+		},
+		{
+			MethodName: "Trace",
+			Handler:    _Debug_Trace_Handler,
+		},
+		{
+			MethodName: "Profile",
+			Handler:    _Debug_Profile_Handler,
+		},
+		{
+			MethodName: "BuildInfo",
+			Handler:    _Debug_BuildInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Dump",
+			Handler:       _Debug_Dump_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "debug/debug.proto",
+}
+
+// ProfilesClient is the client API for Profiles service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ProfilesClient interface {
+	List(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error)
+	Read(ctx context.Context, in *ReadProfileRequest, opts ...grpc.CallOption) (*ReadProfileResponse, error)
+}
+
+type profilesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewProfilesClient(cc *grpc.ClientConn) ProfilesClient {
+	return &profilesClient{cc}
+}
+
+func (c *profilesClient) List(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error) {
+	out := new(ListProfilesResponse)
+	err := c.cc.Invoke(ctx, "/debug.Profiles/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profilesClient) Read(ctx context.Context, in *ReadProfileRequest, opts ...grpc.CallOption) (*ReadProfileResponse, error) {
+	out := new(ReadProfileResponse)
+	err := c.cc.Invoke(ctx, "/debug.Profiles/Read", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProfilesServer is the server API for Profiles service.
+type ProfilesServer interface {
+	List(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error)
+	Read(context.Context, *ReadProfileRequest) (*ReadProfileResponse, error)
+}
+
+func RegisterProfilesServer(s *grpc.Server, srv ProfilesServer) {
+	s.RegisterService(&_Profiles_serviceDesc, srv)
+}
+
+func _Profiles_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfilesServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Profiles/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfilesServer).List(ctx, req.(*ListProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profiles_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfilesServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Profiles/Read",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfilesServer).Read(ctx, req.(*ReadProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Profiles_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Profiles",
+	HandlerType: (*ProfilesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Profiles_List_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _Profiles_Read_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debug/debug.proto",
+}
+
+// MetricsClient is the client API for Metrics service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MetricsClient interface {
+	Query(ctx context.Context, in *QueryMetricsRequest, opts ...grpc.CallOption) (*QueryMetricsResponse, error)
+	Objectives(ctx context.Context, in *ObjectivesRequest, opts ...grpc.CallOption) (*ObjectivesResponse, error)
+	Top(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopResponse, error)
+	Endpoints(ctx context.Context, in *EndpointsRequest, opts ...grpc.CallOption) (*EndpointsResponse, error)
+}
+
+type metricsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMetricsClient(cc *grpc.ClientConn) MetricsClient {
+	return &metricsClient{cc}
+}
+
+func (c *metricsClient) Query(ctx context.Context, in *QueryMetricsRequest, opts ...grpc.CallOption) (*QueryMetricsResponse, error) {
+	out := new(QueryMetricsResponse)
+	err := c.cc.Invoke(ctx, "/debug.Metrics/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricsClient) Objectives(ctx context.Context, in *ObjectivesRequest, opts ...grpc.CallOption) (*ObjectivesResponse, error) {
+	out := new(ObjectivesResponse)
+	err := c.cc.Invoke(ctx, "/debug.Metrics/Objectives", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricsClient) Top(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopResponse, error) {
+	out := new(TopResponse)
+	err := c.cc.Invoke(ctx, "/debug.Metrics/Top", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricsClient) Endpoints(ctx context.Context, in *EndpointsRequest, opts ...grpc.CallOption) (*EndpointsResponse, error) {
+	out := new(EndpointsResponse)
+	err := c.cc.Invoke(ctx, "/debug.Metrics/Endpoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MetricsServer is the server API for Metrics service.
+type MetricsServer interface {
+	Query(context.Context, *QueryMetricsRequest) (*QueryMetricsResponse, error)
+	Objectives(context.Context, *ObjectivesRequest) (*ObjectivesResponse, error)
+	Top(context.Context, *TopRequest) (*TopResponse, error)
+	Endpoints(context.Context, *EndpointsRequest) (*EndpointsResponse, error)
+}
+
+func RegisterMetricsServer(s *grpc.Server, srv MetricsServer) {
+	s.RegisterService(&_Metrics_serviceDesc, srv)
+}
+
+func _Metrics_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Metrics/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServer).Query(ctx, req.(*QueryMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metrics_Objectives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServer).Objectives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Metrics/Objectives",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServer).Objectives(ctx, req.(*ObjectivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metrics_Top_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServer).Top(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Metrics/Top",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServer).Top(ctx, req.(*TopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metrics_Endpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServer).Endpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Metrics/Endpoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServer).Endpoints(ctx, req.(*EndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Metrics_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Metrics",
+	HandlerType: (*MetricsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Query",
+			Handler:    _Metrics_Query_Handler,
+		},
+		{
+			MethodName: "Objectives",
+			Handler:    _Metrics_Objectives_Handler,
+		},
+		{
+			MethodName: "Top",
+			Handler:    _Metrics_Top_Handler,
+		},
+		{
+			MethodName: "Endpoints",
+			Handler:    _Metrics_Endpoints_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debug/debug.proto",
+}
+
+// LogsClient is the client API for Logs service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LogsClient interface {
+	Write(ctx context.Context, in *WriteLogsRequest, opts ...grpc.CallOption) (*WriteLogsResponse, error)
+	Query(ctx context.Context, in *QueryLogsRequest, opts ...grpc.CallOption) (*QueryLogsResponse, error)
+	Labels(ctx context.Context, in *LabelsRequest, opts ...grpc.CallOption) (*LabelsResponse, error)
+}
+
+type logsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLogsClient(cc *grpc.ClientConn) LogsClient {
+	return &logsClient{cc}
+}
+
+func (c *logsClient) Write(ctx context.Context, in *WriteLogsRequest, opts ...grpc.CallOption) (*WriteLogsResponse, error) {
+	out := new(WriteLogsResponse)
+	err := c.cc.Invoke(ctx, "/debug.Logs/Write", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logsClient) Query(ctx context.Context, in *QueryLogsRequest, opts ...grpc.CallOption) (*QueryLogsResponse, error) {
+	out := new(QueryLogsResponse)
+	err := c.cc.Invoke(ctx, "/debug.Logs/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logsClient) Labels(ctx context.Context, in *LabelsRequest, opts ...grpc.CallOption) (*LabelsResponse, error) {
+	out := new(LabelsResponse)
+	err := c.cc.Invoke(ctx, "/debug.Logs/Labels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LogsServer is the server API for Logs service.
+type LogsServer interface {
+	Write(context.Context, *WriteLogsRequest) (*WriteLogsResponse, error)
+	Query(context.Context, *QueryLogsRequest) (*QueryLogsResponse, error)
+	Labels(context.Context, *LabelsRequest) (*LabelsResponse, error)
+}
+
+func RegisterLogsServer(s *grpc.Server, srv LogsServer) {
+	s.RegisterService(&_Logs_serviceDesc, srv)
+}
+
+func _Logs_Write_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogsServer).Write(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Logs/Write",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogsServer).Write(ctx, req.(*WriteLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logs_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogsServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Logs/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogsServer).Query(ctx, req.(*QueryLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logs_Labels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogsServer).Labels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Logs/Labels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogsServer).Labels(ctx, req.(*LabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Logs_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Logs",
+	HandlerType: (*LogsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Write",
+			Handler:    _Logs_Write_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _Logs_Query_Handler,
+		},
+		{
+			MethodName: "Labels",
+			Handler:    _Logs_Labels_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debug/debug.proto",
+}
+
+// TracingClient is the client API for Tracing service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TracingClient interface {
+	Sampling(ctx context.Context, in *SamplingRequest, opts ...grpc.CallOption) (*SamplingResponse, error)
+	SetSampling(ctx context.Context, in *SetSamplingRequest, opts ...grpc.CallOption) (*SetSamplingResponse, error)
+}
+
+type tracingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTracingClient(cc *grpc.ClientConn) TracingClient {
+	return &tracingClient{cc}
+}
+
+func (c *tracingClient) Sampling(ctx context.Context, in *SamplingRequest, opts ...grpc.CallOption) (*SamplingResponse, error) {
+	out := new(SamplingResponse)
+	err := c.cc.Invoke(ctx, "/debug.Tracing/Sampling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracingClient) SetSampling(ctx context.Context, in *SetSamplingRequest, opts ...grpc.CallOption) (*SetSamplingResponse, error) {
+	out := new(SetSamplingResponse)
+	err := c.cc.Invoke(ctx, "/debug.Tracing/SetSampling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TracingServer is the server API for Tracing service.
+type TracingServer interface {
+	Sampling(context.Context, *SamplingRequest) (*SamplingResponse, error)
+	SetSampling(context.Context, *SetSamplingRequest) (*SetSamplingResponse, error)
+}
+
+func RegisterTracingServer(s *grpc.Server, srv TracingServer) {
+	s.RegisterService(&_Tracing_serviceDesc, srv)
+}
+
+func _Tracing_Sampling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SamplingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracingServer).Sampling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Tracing/Sampling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracingServer).Sampling(ctx, req.(*SamplingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tracing_SetSampling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSamplingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracingServer).SetSampling(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/debug.Debug/Stats",
+		FullMethod: "/debug.Tracing/SetSampling",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DebugServer).Stats(ctx, req.(*StatsRequest))
+		return srv.(TracingServer).SetSampling(ctx, req.(*SetSamplingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Debug_Trace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TraceRequest)
+var _Tracing_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Tracing",
+	HandlerType: (*TracingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Sampling",
+			Handler:    _Tracing_Sampling_Handler,
+		},
+		{
+			MethodName: "SetSampling",
+			Handler:    _Tracing_SetSampling_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debug/debug.proto",
+}
+
+// CapturesClient is the client API for Captures service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CapturesClient interface {
+	List(ctx context.Context, in *ListCapturesRequest, opts ...grpc.CallOption) (*ListCapturesResponse, error)
+	Read(ctx context.Context, in *ReadCaptureRequest, opts ...grpc.CallOption) (*ReadCaptureResponse, error)
+}
+
+type capturesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCapturesClient(cc *grpc.ClientConn) CapturesClient {
+	return &capturesClient{cc}
+}
+
+func (c *capturesClient) List(ctx context.Context, in *ListCapturesRequest, opts ...grpc.CallOption) (*ListCapturesResponse, error) {
+	out := new(ListCapturesResponse)
+	err := c.cc.Invoke(ctx, "/debug.Captures/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *capturesClient) Read(ctx context.Context, in *ReadCaptureRequest, opts ...grpc.CallOption) (*ReadCaptureResponse, error) {
+	out := new(ReadCaptureResponse)
+	err := c.cc.Invoke(ctx, "/debug.Captures/Read", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CapturesServer is the server API for Captures service.
+type CapturesServer interface {
+	List(context.Context, *ListCapturesRequest) (*ListCapturesResponse, error)
+	Read(context.Context, *ReadCaptureRequest) (*ReadCaptureResponse, error)
+}
+
+func RegisterCapturesServer(s *grpc.Server, srv CapturesServer) {
+	s.RegisterService(&_Captures_serviceDesc, srv)
+}
+
+func _Captures_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCapturesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DebugServer).Trace(ctx, in)
+		return srv.(CapturesServer).List(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/debug.Debug/Trace",
+		FullMethod: "/debug.Captures/List",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DebugServer).Trace(ctx, req.(*TraceRequest))
+		return srv.(CapturesServer).List(ctx, req.(*ListCapturesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Debug_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "debug.Debug",
-	HandlerType: (*DebugServer)(nil),
+func _Captures_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CapturesServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Captures/Read",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CapturesServer).Read(ctx, req.(*ReadCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Captures_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Captures",
+	HandlerType: (*CapturesServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Log",
-			Handler:    _Debug_Log_Handler,
+			MethodName: "List",
+			Handler:    _Captures_List_Handler,
 		},
 		{
-			MethodName: "Health",
-			Handler:    _Debug_Health_Handler,
+			MethodName: "Read",
+			Handler:    _Captures_Read_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debug/debug.proto",
+}
+
+// ChaosClient is the client API for Chaos service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChaosClient interface {
+	Create(ctx context.Context, in *CreateExperimentRequest, opts ...grpc.CallOption) (*CreateExperimentResponse, error)
+	List(ctx context.Context, in *ListExperimentsRequest, opts ...grpc.CallOption) (*ListExperimentsResponse, error)
+	Delete(ctx context.Context, in *DeleteExperimentRequest, opts ...grpc.CallOption) (*DeleteExperimentResponse, error)
+	Audit(ctx context.Context, in *ChaosAuditRequest, opts ...grpc.CallOption) (*ChaosAuditResponse, error)
+}
+
+type chaosClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaosClient(cc *grpc.ClientConn) ChaosClient {
+	return &chaosClient{cc}
+}
+
+func (c *chaosClient) Create(ctx context.Context, in *CreateExperimentRequest, opts ...grpc.CallOption) (*CreateExperimentResponse, error) {
+	out := new(CreateExperimentResponse)
+	err := c.cc.Invoke(ctx, "/debug.Chaos/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosClient) List(ctx context.Context, in *ListExperimentsRequest, opts ...grpc.CallOption) (*ListExperimentsResponse, error) {
+	out := new(ListExperimentsResponse)
+	err := c.cc.Invoke(ctx, "/debug.Chaos/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosClient) Delete(ctx context.Context, in *DeleteExperimentRequest, opts ...grpc.CallOption) (*DeleteExperimentResponse, error) {
+	out := new(DeleteExperimentResponse)
+	err := c.cc.Invoke(ctx, "/debug.Chaos/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosClient) Audit(ctx context.Context, in *ChaosAuditRequest, opts ...grpc.CallOption) (*ChaosAuditResponse, error) {
+	out := new(ChaosAuditResponse)
+	err := c.cc.Invoke(ctx, "/debug.Chaos/Audit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChaosServer is the server API for Chaos service.
+type ChaosServer interface {
+	Create(context.Context, *CreateExperimentRequest) (*CreateExperimentResponse, error)
+	List(context.Context, *ListExperimentsRequest) (*ListExperimentsResponse, error)
+	Delete(context.Context, *DeleteExperimentRequest) (*DeleteExperimentResponse, error)
+	Audit(context.Context, *ChaosAuditRequest) (*ChaosAuditResponse, error)
+}
+
+func RegisterChaosServer(s *grpc.Server, srv ChaosServer) {
+	s.RegisterService(&_Chaos_serviceDesc, srv)
+}
+
+func _Chaos_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExperimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Chaos/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosServer).Create(ctx, req.(*CreateExperimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chaos_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExperimentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Chaos/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosServer).List(ctx, req.(*ListExperimentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chaos_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExperimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Chaos/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosServer).Delete(ctx, req.(*DeleteExperimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chaos_Audit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChaosAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosServer).Audit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Chaos/Audit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosServer).Audit(ctx, req.(*ChaosAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Chaos_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Chaos",
+	HandlerType: (*ChaosServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _Chaos_Create_Handler,
 		},
 		{
-			MethodName: "Stats",
-			Handler:    _Debug_Stats_Handler,
+			MethodName: "List",
+			Handler:    _Chaos_List_Handler,
 		},
 		{
-			MethodName: "Trace",
-			Handler:    _Debug_Trace_Handler,
+			MethodName: "Delete",
+			Handler:    _Chaos_Delete_Handler,
+		},
+		{
+			MethodName: "Audit",
+			Handler:    _Chaos_Audit_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debug/debug.proto",
+}
+
+// ProbesClient is the client API for Probes service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ProbesClient interface {
+	Results(ctx context.Context, in *ProbeResultsRequest, opts ...grpc.CallOption) (*ProbeResultsResponse, error)
+}
+
+type probesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewProbesClient(cc *grpc.ClientConn) ProbesClient {
+	return &probesClient{cc}
+}
+
+func (c *probesClient) Results(ctx context.Context, in *ProbeResultsRequest, opts ...grpc.CallOption) (*ProbeResultsResponse, error) {
+	out := new(ProbeResultsResponse)
+	err := c.cc.Invoke(ctx, "/debug.Probes/Results", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProbesServer is the server API for Probes service.
+type ProbesServer interface {
+	Results(context.Context, *ProbeResultsRequest) (*ProbeResultsResponse, error)
+}
+
+func RegisterProbesServer(s *grpc.Server, srv ProbesServer) {
+	s.RegisterService(&_Probes_serviceDesc, srv)
+}
+
+func _Probes_Results_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProbesServer).Results(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Probes/Results",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProbesServer).Results(ctx, req.(*ProbeResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Probes_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Probes",
+	HandlerType: (*ProbesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Results",
+			Handler:    _Probes_Results_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debug/debug.proto",
+}
+
+// TimelineClient is the client API for Timeline service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TimelineClient interface {
+	Read(ctx context.Context, in *ReadTimelineRequest, opts ...grpc.CallOption) (*ReadTimelineResponse, error)
+}
+
+type timelineClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTimelineClient(cc *grpc.ClientConn) TimelineClient {
+	return &timelineClient{cc}
+}
+
+func (c *timelineClient) Read(ctx context.Context, in *ReadTimelineRequest, opts ...grpc.CallOption) (*ReadTimelineResponse, error) {
+	out := new(ReadTimelineResponse)
+	err := c.cc.Invoke(ctx, "/debug.Timeline/Read", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TimelineServer is the server API for Timeline service.
+type TimelineServer interface {
+	Read(context.Context, *ReadTimelineRequest) (*ReadTimelineResponse, error)
+}
+
+func RegisterTimelineServer(s *grpc.Server, srv TimelineServer) {
+	s.RegisterService(&_Timeline_serviceDesc, srv)
+}
+
+func _Timeline_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimelineServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Timeline/Read",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimelineServer).Read(ctx, req.(*ReadTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Timeline_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "debug.Timeline",
+	HandlerType: (*TimelineServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Read",
+			Handler:    _Timeline_Read_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
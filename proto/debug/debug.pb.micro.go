@@ -46,6 +46,9 @@ type DebugService interface {
 	Health(ctx context.Context, in *HealthRequest, opts ...client.CallOption) (*HealthResponse, error)
 	Stats(ctx context.Context, in *StatsRequest, opts ...client.CallOption) (*StatsResponse, error)
 	Trace(ctx context.Context, in *TraceRequest, opts ...client.CallOption) (*TraceResponse, error)
+	Profile(ctx context.Context, in *ProfileRequest, opts ...client.CallOption) (*ProfileResponse, error)
+	Dump(ctx context.Context, in *DumpRequest, opts ...client.CallOption) (Debug_DumpService, error)
+	BuildInfo(ctx context.Context, in *BuildInfoRequest, opts ...client.CallOption) (*BuildInfoResponse, error)
 }
 
 type debugService struct {
@@ -100,6 +103,75 @@ func (c *debugService) Trace(ctx context.Context, in *TraceRequest, opts ...clie
 	return out, nil
 }
 
+func (c *debugService) Profile(ctx context.Context, in *ProfileRequest, opts ...client.CallOption) (*ProfileResponse, error) {
+	req := c.c.NewRequest(c.name, "Debug.Profile", in)
+	out := new(ProfileResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugService) Dump(ctx context.Context, in *DumpRequest, opts ...client.CallOption) (Debug_DumpService, error) {
+	req := c.c.NewRequest(c.name, "Debug.Dump", &DumpRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &debugServiceDump{stream}, nil
+}
+
+type Debug_DumpService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*DumpResponse, error)
+}
+
+type debugServiceDump struct {
+	stream client.Stream
+}
+
+func (x *debugServiceDump) Close() error {
+	return x.stream.Close()
+}
+
+func (x *debugServiceDump) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *debugServiceDump) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *debugServiceDump) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *debugServiceDump) Recv() (*DumpResponse, error) {
+	m := new(DumpResponse)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *debugService) BuildInfo(ctx context.Context, in *BuildInfoRequest, opts ...client.CallOption) (*BuildInfoResponse, error) {
+	req := c.c.NewRequest(c.name, "Debug.BuildInfo", in)
+	out := new(BuildInfoResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Debug service
 
 type DebugHandler interface {
@@ -107,6 +179,9 @@ type DebugHandler interface {
 	Health(context.Context, *HealthRequest, *HealthResponse) error
 	Stats(context.Context, *StatsRequest, *StatsResponse) error
 	Trace(context.Context, *TraceRequest, *TraceResponse) error
+	Profile(context.Context, *ProfileRequest, *ProfileResponse) error
+	Dump(context.Context, *DumpRequest, Debug_DumpStream) error
+	BuildInfo(context.Context, *BuildInfoRequest, *BuildInfoResponse) error
 }
 
 func RegisterDebugHandler(s server.Server, hdlr DebugHandler, opts ...server.HandlerOption) error {
@@ -115,6 +190,9 @@ func RegisterDebugHandler(s server.Server, hdlr DebugHandler, opts ...server.Han
 		Health(ctx context.Context, in *HealthRequest, out *HealthResponse) error
 		Stats(ctx context.Context, in *StatsRequest, out *StatsResponse) error
 		Trace(ctx context.Context, in *TraceRequest, out *TraceResponse) error
+		Profile(ctx context.Context, in *ProfileRequest, out *ProfileResponse) error
+		Dump(ctx context.Context, stream server.Stream) error
+		BuildInfo(ctx context.Context, in *BuildInfoRequest, out *BuildInfoResponse) error
 	}
 	type Debug struct {
 		debug
@@ -142,3 +220,710 @@ func (h *debugHandler) Stats(ctx context.Context, in *StatsRequest, out *StatsRe
 func (h *debugHandler) Trace(ctx context.Context, in *TraceRequest, out *TraceResponse) error {
 	return h.DebugHandler.Trace(ctx, in, out)
 }
+
+func (h *debugHandler) Profile(ctx context.Context, in *ProfileRequest, out *ProfileResponse) error {
+	return h.DebugHandler.Profile(ctx, in, out)
+}
+
+func (h *debugHandler) Dump(ctx context.Context, stream server.Stream) error {
+	m := new(DumpRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.DebugHandler.Dump(ctx, m, &debugDumpStream{stream})
+}
+
+type Debug_DumpStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*DumpResponse) error
+}
+
+type debugDumpStream struct {
+	stream server.Stream
+}
+
+func (x *debugDumpStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *debugDumpStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *debugDumpStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *debugDumpStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *debugDumpStream) Send(m *DumpResponse) error {
+	return x.stream.Send(m)
+}
+
+func (h *debugHandler) BuildInfo(ctx context.Context, in *BuildInfoRequest, out *BuildInfoResponse) error {
+	return h.DebugHandler.BuildInfo(ctx, in, out)
+}
+
+// Api Endpoints for Profiles service
+
+func NewProfilesEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Profiles service
+
+type ProfilesService interface {
+	List(ctx context.Context, in *ListProfilesRequest, opts ...client.CallOption) (*ListProfilesResponse, error)
+	Read(ctx context.Context, in *ReadProfileRequest, opts ...client.CallOption) (*ReadProfileResponse, error)
+}
+
+type profilesService struct {
+	c    client.Client
+	name string
+}
+
+func NewProfilesService(name string, c client.Client) ProfilesService {
+	return &profilesService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *profilesService) List(ctx context.Context, in *ListProfilesRequest, opts ...client.CallOption) (*ListProfilesResponse, error) {
+	req := c.c.NewRequest(c.name, "Profiles.List", in)
+	out := new(ListProfilesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profilesService) Read(ctx context.Context, in *ReadProfileRequest, opts ...client.CallOption) (*ReadProfileResponse, error) {
+	req := c.c.NewRequest(c.name, "Profiles.Read", in)
+	out := new(ReadProfileResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Profiles service
+
+type ProfilesHandler interface {
+	List(context.Context, *ListProfilesRequest, *ListProfilesResponse) error
+	Read(context.Context, *ReadProfileRequest, *ReadProfileResponse) error
+}
+
+func RegisterProfilesHandler(s server.Server, hdlr ProfilesHandler, opts ...server.HandlerOption) error {
+	type profiles interface {
+		List(ctx context.Context, in *ListProfilesRequest, out *ListProfilesResponse) error
+		Read(ctx context.Context, in *ReadProfileRequest, out *ReadProfileResponse) error
+	}
+	type Profiles struct {
+		profiles
+	}
+	h := &profilesHandler{hdlr}
+	return s.Handle(s.NewHandler(&Profiles{h}, opts...))
+}
+
+type profilesHandler struct {
+	ProfilesHandler
+}
+
+func (h *profilesHandler) List(ctx context.Context, in *ListProfilesRequest, out *ListProfilesResponse) error {
+	return h.ProfilesHandler.List(ctx, in, out)
+}
+
+func (h *profilesHandler) Read(ctx context.Context, in *ReadProfileRequest, out *ReadProfileResponse) error {
+	return h.ProfilesHandler.Read(ctx, in, out)
+}
+
+// Api Endpoints for Metrics service
+
+func NewMetricsEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Metrics service
+
+type MetricsService interface {
+	Query(ctx context.Context, in *QueryMetricsRequest, opts ...client.CallOption) (*QueryMetricsResponse, error)
+	Objectives(ctx context.Context, in *ObjectivesRequest, opts ...client.CallOption) (*ObjectivesResponse, error)
+	Top(ctx context.Context, in *TopRequest, opts ...client.CallOption) (*TopResponse, error)
+	Endpoints(ctx context.Context, in *EndpointsRequest, opts ...client.CallOption) (*EndpointsResponse, error)
+}
+
+type metricsService struct {
+	c    client.Client
+	name string
+}
+
+func NewMetricsService(name string, c client.Client) MetricsService {
+	return &metricsService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *metricsService) Query(ctx context.Context, in *QueryMetricsRequest, opts ...client.CallOption) (*QueryMetricsResponse, error) {
+	req := c.c.NewRequest(c.name, "Metrics.Query", in)
+	out := new(QueryMetricsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricsService) Objectives(ctx context.Context, in *ObjectivesRequest, opts ...client.CallOption) (*ObjectivesResponse, error) {
+	req := c.c.NewRequest(c.name, "Metrics.Objectives", in)
+	out := new(ObjectivesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricsService) Top(ctx context.Context, in *TopRequest, opts ...client.CallOption) (*TopResponse, error) {
+	req := c.c.NewRequest(c.name, "Metrics.Top", in)
+	out := new(TopResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricsService) Endpoints(ctx context.Context, in *EndpointsRequest, opts ...client.CallOption) (*EndpointsResponse, error) {
+	req := c.c.NewRequest(c.name, "Metrics.Endpoints", in)
+	out := new(EndpointsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Metrics service
+
+type MetricsHandler interface {
+	Query(context.Context, *QueryMetricsRequest, *QueryMetricsResponse) error
+	Objectives(context.Context, *ObjectivesRequest, *ObjectivesResponse) error
+	Top(context.Context, *TopRequest, *TopResponse) error
+	Endpoints(context.Context, *EndpointsRequest, *EndpointsResponse) error
+}
+
+func RegisterMetricsHandler(s server.Server, hdlr MetricsHandler, opts ...server.HandlerOption) error {
+	type metrics interface {
+		Query(ctx context.Context, in *QueryMetricsRequest, out *QueryMetricsResponse) error
+		Objectives(ctx context.Context, in *ObjectivesRequest, out *ObjectivesResponse) error
+		Top(ctx context.Context, in *TopRequest, out *TopResponse) error
+		Endpoints(ctx context.Context, in *EndpointsRequest, out *EndpointsResponse) error
+	}
+	type Metrics struct {
+		metrics
+	}
+	h := &metricsHandler{hdlr}
+	return s.Handle(s.NewHandler(&Metrics{h}, opts...))
+}
+
+type metricsHandler struct {
+	MetricsHandler
+}
+
+func (h *metricsHandler) Query(ctx context.Context, in *QueryMetricsRequest, out *QueryMetricsResponse) error {
+	return h.MetricsHandler.Query(ctx, in, out)
+}
+
+func (h *metricsHandler) Objectives(ctx context.Context, in *ObjectivesRequest, out *ObjectivesResponse) error {
+	return h.MetricsHandler.Objectives(ctx, in, out)
+}
+
+func (h *metricsHandler) Top(ctx context.Context, in *TopRequest, out *TopResponse) error {
+	return h.MetricsHandler.Top(ctx, in, out)
+}
+
+func (h *metricsHandler) Endpoints(ctx context.Context, in *EndpointsRequest, out *EndpointsResponse) error {
+	return h.MetricsHandler.Endpoints(ctx, in, out)
+}
+
+// Api Endpoints for Logs service
+
+func NewLogsEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Logs service
+
+type LogsService interface {
+	Write(ctx context.Context, in *WriteLogsRequest, opts ...client.CallOption) (*WriteLogsResponse, error)
+	Query(ctx context.Context, in *QueryLogsRequest, opts ...client.CallOption) (*QueryLogsResponse, error)
+	Labels(ctx context.Context, in *LabelsRequest, opts ...client.CallOption) (*LabelsResponse, error)
+}
+
+type logsService struct {
+	c    client.Client
+	name string
+}
+
+func NewLogsService(name string, c client.Client) LogsService {
+	return &logsService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *logsService) Write(ctx context.Context, in *WriteLogsRequest, opts ...client.CallOption) (*WriteLogsResponse, error) {
+	req := c.c.NewRequest(c.name, "Logs.Write", in)
+	out := new(WriteLogsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logsService) Query(ctx context.Context, in *QueryLogsRequest, opts ...client.CallOption) (*QueryLogsResponse, error) {
+	req := c.c.NewRequest(c.name, "Logs.Query", in)
+	out := new(QueryLogsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logsService) Labels(ctx context.Context, in *LabelsRequest, opts ...client.CallOption) (*LabelsResponse, error) {
+	req := c.c.NewRequest(c.name, "Logs.Labels", in)
+	out := new(LabelsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Logs service
+
+type LogsHandler interface {
+	Write(context.Context, *WriteLogsRequest, *WriteLogsResponse) error
+	Query(context.Context, *QueryLogsRequest, *QueryLogsResponse) error
+	Labels(context.Context, *LabelsRequest, *LabelsResponse) error
+}
+
+func RegisterLogsHandler(s server.Server, hdlr LogsHandler, opts ...server.HandlerOption) error {
+	type logs interface {
+		Write(ctx context.Context, in *WriteLogsRequest, out *WriteLogsResponse) error
+		Query(ctx context.Context, in *QueryLogsRequest, out *QueryLogsResponse) error
+		Labels(ctx context.Context, in *LabelsRequest, out *LabelsResponse) error
+	}
+	type Logs struct {
+		logs
+	}
+	h := &logsHandler{hdlr}
+	return s.Handle(s.NewHandler(&Logs{h}, opts...))
+}
+
+type logsHandler struct {
+	LogsHandler
+}
+
+func (h *logsHandler) Write(ctx context.Context, in *WriteLogsRequest, out *WriteLogsResponse) error {
+	return h.LogsHandler.Write(ctx, in, out)
+}
+
+func (h *logsHandler) Query(ctx context.Context, in *QueryLogsRequest, out *QueryLogsResponse) error {
+	return h.LogsHandler.Query(ctx, in, out)
+}
+
+func (h *logsHandler) Labels(ctx context.Context, in *LabelsRequest, out *LabelsResponse) error {
+	return h.LogsHandler.Labels(ctx, in, out)
+}
+
+// Api Endpoints for Tracing service
+
+func NewTracingEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Tracing service
+
+type TracingService interface {
+	Sampling(ctx context.Context, in *SamplingRequest, opts ...client.CallOption) (*SamplingResponse, error)
+	SetSampling(ctx context.Context, in *SetSamplingRequest, opts ...client.CallOption) (*SetSamplingResponse, error)
+}
+
+type tracingService struct {
+	c    client.Client
+	name string
+}
+
+func NewTracingService(name string, c client.Client) TracingService {
+	return &tracingService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *tracingService) Sampling(ctx context.Context, in *SamplingRequest, opts ...client.CallOption) (*SamplingResponse, error) {
+	req := c.c.NewRequest(c.name, "Tracing.Sampling", in)
+	out := new(SamplingResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracingService) SetSampling(ctx context.Context, in *SetSamplingRequest, opts ...client.CallOption) (*SetSamplingResponse, error) {
+	req := c.c.NewRequest(c.name, "Tracing.SetSampling", in)
+	out := new(SetSamplingResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Tracing service
+
+type TracingHandler interface {
+	Sampling(context.Context, *SamplingRequest, *SamplingResponse) error
+	SetSampling(context.Context, *SetSamplingRequest, *SetSamplingResponse) error
+}
+
+func RegisterTracingHandler(s server.Server, hdlr TracingHandler, opts ...server.HandlerOption) error {
+	type tracing interface {
+		Sampling(ctx context.Context, in *SamplingRequest, out *SamplingResponse) error
+		SetSampling(ctx context.Context, in *SetSamplingRequest, out *SetSamplingResponse) error
+	}
+	type Tracing struct {
+		tracing
+	}
+	h := &tracingHandler{hdlr}
+	return s.Handle(s.NewHandler(&Tracing{h}, opts...))
+}
+
+type tracingHandler struct {
+	TracingHandler
+}
+
+func (h *tracingHandler) Sampling(ctx context.Context, in *SamplingRequest, out *SamplingResponse) error {
+	return h.TracingHandler.Sampling(ctx, in, out)
+}
+
+func (h *tracingHandler) SetSampling(ctx context.Context, in *SetSamplingRequest, out *SetSamplingResponse) error {
+	return h.TracingHandler.SetSampling(ctx, in, out)
+}
+
+// Api Endpoints for Captures service
+
+func NewCapturesEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Captures service
+
+type CapturesService interface {
+	List(ctx context.Context, in *ListCapturesRequest, opts ...client.CallOption) (*ListCapturesResponse, error)
+	Read(ctx context.Context, in *ReadCaptureRequest, opts ...client.CallOption) (*ReadCaptureResponse, error)
+}
+
+type capturesService struct {
+	c    client.Client
+	name string
+}
+
+func NewCapturesService(name string, c client.Client) CapturesService {
+	return &capturesService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *capturesService) List(ctx context.Context, in *ListCapturesRequest, opts ...client.CallOption) (*ListCapturesResponse, error) {
+	req := c.c.NewRequest(c.name, "Captures.List", in)
+	out := new(ListCapturesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *capturesService) Read(ctx context.Context, in *ReadCaptureRequest, opts ...client.CallOption) (*ReadCaptureResponse, error) {
+	req := c.c.NewRequest(c.name, "Captures.Read", in)
+	out := new(ReadCaptureResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Captures service
+
+type CapturesHandler interface {
+	List(context.Context, *ListCapturesRequest, *ListCapturesResponse) error
+	Read(context.Context, *ReadCaptureRequest, *ReadCaptureResponse) error
+}
+
+func RegisterCapturesHandler(s server.Server, hdlr CapturesHandler, opts ...server.HandlerOption) error {
+	type captures interface {
+		List(ctx context.Context, in *ListCapturesRequest, out *ListCapturesResponse) error
+		Read(ctx context.Context, in *ReadCaptureRequest, out *ReadCaptureResponse) error
+	}
+	type Captures struct {
+		captures
+	}
+	h := &capturesHandler{hdlr}
+	return s.Handle(s.NewHandler(&Captures{h}, opts...))
+}
+
+type capturesHandler struct {
+	CapturesHandler
+}
+
+func (h *capturesHandler) List(ctx context.Context, in *ListCapturesRequest, out *ListCapturesResponse) error {
+	return h.CapturesHandler.List(ctx, in, out)
+}
+
+func (h *capturesHandler) Read(ctx context.Context, in *ReadCaptureRequest, out *ReadCaptureResponse) error {
+	return h.CapturesHandler.Read(ctx, in, out)
+}
+
+// Api Endpoints for Chaos service
+
+func NewChaosEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Chaos service
+
+type ChaosService interface {
+	Create(ctx context.Context, in *CreateExperimentRequest, opts ...client.CallOption) (*CreateExperimentResponse, error)
+	List(ctx context.Context, in *ListExperimentsRequest, opts ...client.CallOption) (*ListExperimentsResponse, error)
+	Delete(ctx context.Context, in *DeleteExperimentRequest, opts ...client.CallOption) (*DeleteExperimentResponse, error)
+	Audit(ctx context.Context, in *ChaosAuditRequest, opts ...client.CallOption) (*ChaosAuditResponse, error)
+}
+
+type chaosService struct {
+	c    client.Client
+	name string
+}
+
+func NewChaosService(name string, c client.Client) ChaosService {
+	return &chaosService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *chaosService) Create(ctx context.Context, in *CreateExperimentRequest, opts ...client.CallOption) (*CreateExperimentResponse, error) {
+	req := c.c.NewRequest(c.name, "Chaos.Create", in)
+	out := new(CreateExperimentResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosService) List(ctx context.Context, in *ListExperimentsRequest, opts ...client.CallOption) (*ListExperimentsResponse, error) {
+	req := c.c.NewRequest(c.name, "Chaos.List", in)
+	out := new(ListExperimentsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosService) Delete(ctx context.Context, in *DeleteExperimentRequest, opts ...client.CallOption) (*DeleteExperimentResponse, error) {
+	req := c.c.NewRequest(c.name, "Chaos.Delete", in)
+	out := new(DeleteExperimentResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosService) Audit(ctx context.Context, in *ChaosAuditRequest, opts ...client.CallOption) (*ChaosAuditResponse, error) {
+	req := c.c.NewRequest(c.name, "Chaos.Audit", in)
+	out := new(ChaosAuditResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Chaos service
+
+type ChaosHandler interface {
+	Create(context.Context, *CreateExperimentRequest, *CreateExperimentResponse) error
+	List(context.Context, *ListExperimentsRequest, *ListExperimentsResponse) error
+	Delete(context.Context, *DeleteExperimentRequest, *DeleteExperimentResponse) error
+	Audit(context.Context, *ChaosAuditRequest, *ChaosAuditResponse) error
+}
+
+func RegisterChaosHandler(s server.Server, hdlr ChaosHandler, opts ...server.HandlerOption) error {
+	type chaos interface {
+		Create(ctx context.Context, in *CreateExperimentRequest, out *CreateExperimentResponse) error
+		List(ctx context.Context, in *ListExperimentsRequest, out *ListExperimentsResponse) error
+		Delete(ctx context.Context, in *DeleteExperimentRequest, out *DeleteExperimentResponse) error
+		Audit(ctx context.Context, in *ChaosAuditRequest, out *ChaosAuditResponse) error
+	}
+	type Chaos struct {
+		chaos
+	}
+	h := &chaosHandler{hdlr}
+	return s.Handle(s.NewHandler(&Chaos{h}, opts...))
+}
+
+type chaosHandler struct {
+	ChaosHandler
+}
+
+func (h *chaosHandler) Create(ctx context.Context, in *CreateExperimentRequest, out *CreateExperimentResponse) error {
+	return h.ChaosHandler.Create(ctx, in, out)
+}
+
+func (h *chaosHandler) List(ctx context.Context, in *ListExperimentsRequest, out *ListExperimentsResponse) error {
+	return h.ChaosHandler.List(ctx, in, out)
+}
+
+func (h *chaosHandler) Delete(ctx context.Context, in *DeleteExperimentRequest, out *DeleteExperimentResponse) error {
+	return h.ChaosHandler.Delete(ctx, in, out)
+}
+
+func (h *chaosHandler) Audit(ctx context.Context, in *ChaosAuditRequest, out *ChaosAuditResponse) error {
+	return h.ChaosHandler.Audit(ctx, in, out)
+}
+
+// Api Endpoints for Probes service
+
+func NewProbesEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Probes service
+
+type ProbesService interface {
+	Results(ctx context.Context, in *ProbeResultsRequest, opts ...client.CallOption) (*ProbeResultsResponse, error)
+}
+
+type probesService struct {
+	c    client.Client
+	name string
+}
+
+func NewProbesService(name string, c client.Client) ProbesService {
+	return &probesService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *probesService) Results(ctx context.Context, in *ProbeResultsRequest, opts ...client.CallOption) (*ProbeResultsResponse, error) {
+	req := c.c.NewRequest(c.name, "Probes.Results", in)
+	out := new(ProbeResultsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Probes service
+
+type ProbesHandler interface {
+	Results(context.Context, *ProbeResultsRequest, *ProbeResultsResponse) error
+}
+
+func RegisterProbesHandler(s server.Server, hdlr ProbesHandler, opts ...server.HandlerOption) error {
+	type probes interface {
+		Results(ctx context.Context, in *ProbeResultsRequest, out *ProbeResultsResponse) error
+	}
+	type Probes struct {
+		probes
+	}
+	h := &probesHandler{hdlr}
+	return s.Handle(s.NewHandler(&Probes{h}, opts...))
+}
+
+type probesHandler struct {
+	ProbesHandler
+}
+
+func (h *probesHandler) Results(ctx context.Context, in *ProbeResultsRequest, out *ProbeResultsResponse) error {
+	return h.ProbesHandler.Results(ctx, in, out)
+}
+
+// Api Endpoints for Timeline service
+
+func NewTimelineEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Timeline service
+
+type TimelineService interface {
+	Read(ctx context.Context, in *ReadTimelineRequest, opts ...client.CallOption) (*ReadTimelineResponse, error)
+}
+
+type timelineService struct {
+	c    client.Client
+	name string
+}
+
+func NewTimelineService(name string, c client.Client) TimelineService {
+	return &timelineService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *timelineService) Read(ctx context.Context, in *ReadTimelineRequest, opts ...client.CallOption) (*ReadTimelineResponse, error) {
+	req := c.c.NewRequest(c.name, "Timeline.Read", in)
+	out := new(ReadTimelineResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Timeline service
+
+type TimelineHandler interface {
+	Read(context.Context, *ReadTimelineRequest, *ReadTimelineResponse) error
+}
+
+func RegisterTimelineHandler(s server.Server, hdlr TimelineHandler, opts ...server.HandlerOption) error {
+	type timeline interface {
+		Read(ctx context.Context, in *ReadTimelineRequest, out *ReadTimelineResponse) error
+	}
+	type Timeline struct {
+		timeline
+	}
+	h := &timelineHandler{hdlr}
+	return s.Handle(s.NewHandler(&Timeline{h}, opts...))
+}
+
+type timelineHandler struct {
+	TimelineHandler
+}
+
+func (h *timelineHandler) Read(ctx context.Context, in *ReadTimelineRequest, out *ReadTimelineResponse) error {
+	return h.TimelineHandler.Read(ctx, in, out)
+}
@@ -165,6 +165,76 @@ func (m *ConnectResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ConnectResponse proto.InternalMessageInfo
 
+type DisconnectRequest struct {
+	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
+func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
+func (*DisconnectRequest) ProtoMessage()    {}
+func (*DisconnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{3}
+}
+
+func (m *DisconnectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisconnectRequest.Unmarshal(m, b)
+}
+func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisconnectRequest.Marshal(b, m, deterministic)
+}
+func (m *DisconnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectRequest.Merge(m, src)
+}
+func (m *DisconnectRequest) XXX_Size() int {
+	return xxx_messageInfo_DisconnectRequest.Size(m)
+}
+func (m *DisconnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectRequest proto.InternalMessageInfo
+
+func (m *DisconnectRequest) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type DisconnectResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectResponse) Reset()         { *m = DisconnectResponse{} }
+func (m *DisconnectResponse) String() string { return proto.CompactTextString(m) }
+func (*DisconnectResponse) ProtoMessage()    {}
+func (*DisconnectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{4}
+}
+
+func (m *DisconnectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisconnectResponse.Unmarshal(m, b)
+}
+func (m *DisconnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisconnectResponse.Marshal(b, m, deterministic)
+}
+func (m *DisconnectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectResponse.Merge(m, src)
+}
+func (m *DisconnectResponse) XXX_Size() int {
+	return xxx_messageInfo_DisconnectResponse.Size(m)
+}
+func (m *DisconnectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectResponse proto.InternalMessageInfo
+
 // PeerRequest requests list of peers
 type NodesRequest struct {
 	// node topology depth
@@ -178,7 +248,7 @@ func (m *NodesRequest) Reset()         { *m = NodesRequest{} }
 func (m *NodesRequest) String() string { return proto.CompactTextString(m) }
 func (*NodesRequest) ProtoMessage()    {}
 func (*NodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{3}
+	return fileDescriptor_96ad937ae012c472, []int{5}
 }
 
 func (m *NodesRequest) XXX_Unmarshal(b []byte) error {
@@ -219,7 +289,7 @@ func (m *NodesResponse) Reset()         { *m = NodesResponse{} }
 func (m *NodesResponse) String() string { return proto.CompactTextString(m) }
 func (*NodesResponse) ProtoMessage()    {}
 func (*NodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{4}
+	return fileDescriptor_96ad937ae012c472, []int{6}
 }
 
 func (m *NodesResponse) XXX_Unmarshal(b []byte) error {
@@ -259,7 +329,7 @@ func (m *GraphRequest) Reset()         { *m = GraphRequest{} }
 func (m *GraphRequest) String() string { return proto.CompactTextString(m) }
 func (*GraphRequest) ProtoMessage()    {}
 func (*GraphRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{5}
+	return fileDescriptor_96ad937ae012c472, []int{7}
 }
 
 func (m *GraphRequest) XXX_Unmarshal(b []byte) error {
@@ -288,7 +358,11 @@ func (m *GraphRequest) GetDepth() uint32 {
 }
 
 type GraphResponse struct {
-	Root                 *Peer    `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Root *Peer `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	// the nodes of the network
+	Nodes []*Node `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// the links between the nodes
+	Edges                []*Edge  `protobuf:"bytes,3,rep,name=edges,proto3" json:"edges,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -298,7 +372,7 @@ func (m *GraphResponse) Reset()         { *m = GraphResponse{} }
 func (m *GraphResponse) String() string { return proto.CompactTextString(m) }
 func (*GraphResponse) ProtoMessage()    {}
 func (*GraphResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{6}
+	return fileDescriptor_96ad937ae012c472, []int{8}
 }
 
 func (m *GraphResponse) XXX_Unmarshal(b []byte) error {
@@ -326,6 +400,78 @@ func (m *GraphResponse) GetRoot() *Peer {
 	return nil
 }
 
+func (m *GraphResponse) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *GraphResponse) GetEdges() []*Edge {
+	if m != nil {
+		return m.Edges
+	}
+	return nil
+}
+
+// Edge is a link between two nodes of the network
+type Edge struct {
+	// ids of the nodes at either end
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// round trip time of the link in nanoseconds, 0 if not measured
+	Latency              int64    `protobuf:"varint,3,opt,name=latency,proto3" json:"latency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Edge) Reset()         { *m = Edge{} }
+func (m *Edge) String() string { return proto.CompactTextString(m) }
+func (*Edge) ProtoMessage()    {}
+func (*Edge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{9}
+}
+
+func (m *Edge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Edge.Unmarshal(m, b)
+}
+func (m *Edge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Edge.Marshal(b, m, deterministic)
+}
+func (m *Edge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Edge.Merge(m, src)
+}
+func (m *Edge) XXX_Size() int {
+	return xxx_messageInfo_Edge.Size(m)
+}
+func (m *Edge) XXX_DiscardUnknown() {
+	xxx_messageInfo_Edge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Edge proto.InternalMessageInfo
+
+func (m *Edge) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *Edge) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *Edge) GetLatency() int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
 type RoutesRequest struct {
 	// filter based on
 	Query                *Query   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
@@ -338,7 +484,7 @@ func (m *RoutesRequest) Reset()         { *m = RoutesRequest{} }
 func (m *RoutesRequest) String() string { return proto.CompactTextString(m) }
 func (*RoutesRequest) ProtoMessage()    {}
 func (*RoutesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{7}
+	return fileDescriptor_96ad937ae012c472, []int{10}
 }
 
 func (m *RoutesRequest) XXX_Unmarshal(b []byte) error {
@@ -377,7 +523,7 @@ func (m *RoutesResponse) Reset()         { *m = RoutesResponse{} }
 func (m *RoutesResponse) String() string { return proto.CompactTextString(m) }
 func (*RoutesResponse) ProtoMessage()    {}
 func (*RoutesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{8}
+	return fileDescriptor_96ad937ae012c472, []int{11}
 }
 
 func (m *RoutesResponse) XXX_Unmarshal(b []byte) error {
@@ -415,7 +561,7 @@ func (m *ServicesRequest) Reset()         { *m = ServicesRequest{} }
 func (m *ServicesRequest) String() string { return proto.CompactTextString(m) }
 func (*ServicesRequest) ProtoMessage()    {}
 func (*ServicesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{9}
+	return fileDescriptor_96ad937ae012c472, []int{12}
 }
 
 func (m *ServicesRequest) XXX_Unmarshal(b []byte) error {
@@ -447,7 +593,7 @@ func (m *ServicesResponse) Reset()         { *m = ServicesResponse{} }
 func (m *ServicesResponse) String() string { return proto.CompactTextString(m) }
 func (*ServicesResponse) ProtoMessage()    {}
 func (*ServicesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{10}
+	return fileDescriptor_96ad937ae012c472, []int{13}
 }
 
 func (m *ServicesResponse) XXX_Unmarshal(b []byte) error {
@@ -485,7 +631,7 @@ func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
 func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
 func (*StatusRequest) ProtoMessage()    {}
 func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{11}
+	return fileDescriptor_96ad937ae012c472, []int{14}
 }
 
 func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
@@ -517,7 +663,7 @@ func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
 func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
 func (*StatusResponse) ProtoMessage()    {}
 func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{12}
+	return fileDescriptor_96ad937ae012c472, []int{15}
 }
 
 func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
@@ -545,349 +691,1175 @@ func (m *StatusResponse) GetStatus() *Status {
 	return nil
 }
 
-// Error tracks network errors
-type Error struct {
-	Count                uint32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	Msg                  string   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+type RotateRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Error) Reset()         { *m = Error{} }
-func (m *Error) String() string { return proto.CompactTextString(m) }
-func (*Error) ProtoMessage()    {}
-func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{13}
+func (m *RotateRequest) Reset()         { *m = RotateRequest{} }
+func (m *RotateRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateRequest) ProtoMessage()    {}
+func (*RotateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{16}
 }
 
-func (m *Error) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Error.Unmarshal(m, b)
-}
-func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+func (m *RotateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateRequest.Unmarshal(m, b)
 }
-func (m *Error) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Error.Merge(m, src)
+func (m *RotateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateRequest.Marshal(b, m, deterministic)
 }
-func (m *Error) XXX_Size() int {
-	return xxx_messageInfo_Error.Size(m)
+func (m *RotateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateRequest.Merge(m, src)
 }
-func (m *Error) XXX_DiscardUnknown() {
-	xxx_messageInfo_Error.DiscardUnknown(m)
+func (m *RotateRequest) XXX_Size() int {
+	return xxx_messageInfo_RotateRequest.Size(m)
 }
-
-var xxx_messageInfo_Error proto.InternalMessageInfo
-
-func (m *Error) GetCount() uint32 {
-	if m != nil {
-		return m.Count
-	}
-	return 0
+func (m *RotateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateRequest.DiscardUnknown(m)
 }
 
-func (m *Error) GetMsg() string {
-	if m != nil {
-		return m.Msg
-	}
-	return ""
-}
+var xxx_messageInfo_RotateRequest proto.InternalMessageInfo
 
-// Status is node status
-type Status struct {
-	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+type RotateResponse struct {
+	// the generation of the keys rotated to
+	Generation           uint64   `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Status) Reset()         { *m = Status{} }
-func (m *Status) String() string { return proto.CompactTextString(m) }
-func (*Status) ProtoMessage()    {}
-func (*Status) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{14}
+func (m *RotateResponse) Reset()         { *m = RotateResponse{} }
+func (m *RotateResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateResponse) ProtoMessage()    {}
+func (*RotateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{17}
 }
 
-func (m *Status) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Status.Unmarshal(m, b)
+func (m *RotateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateResponse.Unmarshal(m, b)
 }
-func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+func (m *RotateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateResponse.Marshal(b, m, deterministic)
 }
-func (m *Status) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Status.Merge(m, src)
+func (m *RotateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateResponse.Merge(m, src)
 }
-func (m *Status) XXX_Size() int {
-	return xxx_messageInfo_Status.Size(m)
+func (m *RotateResponse) XXX_Size() int {
+	return xxx_messageInfo_RotateResponse.Size(m)
 }
-func (m *Status) XXX_DiscardUnknown() {
-	xxx_messageInfo_Status.DiscardUnknown(m)
+func (m *RotateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Status proto.InternalMessageInfo
+var xxx_messageInfo_RotateResponse proto.InternalMessageInfo
 
-func (m *Status) GetError() *Error {
+func (m *RotateResponse) GetGeneration() uint64 {
 	if m != nil {
-		return m.Error
+		return m.Generation
 	}
-	return nil
+	return 0
 }
 
-// Node is network node
-type Node struct {
-	// node id
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// node address
-	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	// the network
-	Network string `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
-	// associated metadata
-	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// node status
-	Status               *Status  `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+type MapRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Node) Reset()         { *m = Node{} }
-func (m *Node) String() string { return proto.CompactTextString(m) }
-func (*Node) ProtoMessage()    {}
-func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{15}
+func (m *MapRequest) Reset()         { *m = MapRequest{} }
+func (m *MapRequest) String() string { return proto.CompactTextString(m) }
+func (*MapRequest) ProtoMessage()    {}
+func (*MapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{18}
 }
 
-func (m *Node) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Node.Unmarshal(m, b)
+func (m *MapRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MapRequest.Unmarshal(m, b)
 }
-func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+func (m *MapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MapRequest.Marshal(b, m, deterministic)
 }
-func (m *Node) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Node.Merge(m, src)
+func (m *MapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MapRequest.Merge(m, src)
 }
-func (m *Node) XXX_Size() int {
-	return xxx_messageInfo_Node.Size(m)
+func (m *MapRequest) XXX_Size() int {
+	return xxx_messageInfo_MapRequest.Size(m)
 }
-func (m *Node) XXX_DiscardUnknown() {
-	xxx_messageInfo_Node.DiscardUnknown(m)
+func (m *MapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MapRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Node proto.InternalMessageInfo
+var xxx_messageInfo_MapRequest proto.InternalMessageInfo
 
-func (m *Node) GetId() string {
-	if m != nil {
-		return m.Id
-	}
-	return ""
+type MapResponse struct {
+	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Node) GetAddress() string {
-	if m != nil {
-		return m.Address
-	}
-	return ""
+func (m *MapResponse) Reset()         { *m = MapResponse{} }
+func (m *MapResponse) String() string { return proto.CompactTextString(m) }
+func (*MapResponse) ProtoMessage()    {}
+func (*MapResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{19}
 }
 
-func (m *Node) GetNetwork() string {
-	if m != nil {
-		return m.Network
-	}
-	return ""
+func (m *MapResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MapResponse.Unmarshal(m, b)
 }
-
-func (m *Node) GetMetadata() map[string]string {
-	if m != nil {
-		return m.Metadata
-	}
-	return nil
+func (m *MapResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MapResponse.Marshal(b, m, deterministic)
+}
+func (m *MapResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MapResponse.Merge(m, src)
+}
+func (m *MapResponse) XXX_Size() int {
+	return xxx_messageInfo_MapResponse.Size(m)
+}
+func (m *MapResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MapResponse.DiscardUnknown(m)
 }
 
-func (m *Node) GetStatus() *Status {
+var xxx_messageInfo_MapResponse proto.InternalMessageInfo
+
+func (m *MapResponse) GetNodes() []*Node {
 	if m != nil {
-		return m.Status
+		return m.Nodes
 	}
 	return nil
 }
 
-// Connect is sent when the node connects to the network
-type Connect struct {
-	// network mode
-	Node                 *Node    `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+type TraceRequest struct {
+	// the service to trace
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Connect) Reset()         { *m = Connect{} }
-func (m *Connect) String() string { return proto.CompactTextString(m) }
-func (*Connect) ProtoMessage()    {}
-func (*Connect) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{16}
+func (m *TraceRequest) Reset()         { *m = TraceRequest{} }
+func (m *TraceRequest) String() string { return proto.CompactTextString(m) }
+func (*TraceRequest) ProtoMessage()    {}
+func (*TraceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{20}
 }
 
-func (m *Connect) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Connect.Unmarshal(m, b)
+func (m *TraceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceRequest.Unmarshal(m, b)
 }
-func (m *Connect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Connect.Marshal(b, m, deterministic)
+func (m *TraceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceRequest.Marshal(b, m, deterministic)
 }
-func (m *Connect) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Connect.Merge(m, src)
+func (m *TraceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceRequest.Merge(m, src)
 }
-func (m *Connect) XXX_Size() int {
-	return xxx_messageInfo_Connect.Size(m)
+func (m *TraceRequest) XXX_Size() int {
+	return xxx_messageInfo_TraceRequest.Size(m)
 }
-func (m *Connect) XXX_DiscardUnknown() {
-	xxx_messageInfo_Connect.DiscardUnknown(m)
+func (m *TraceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Connect proto.InternalMessageInfo
+var xxx_messageInfo_TraceRequest proto.InternalMessageInfo
 
-func (m *Connect) GetNode() *Node {
+func (m *TraceRequest) GetService() string {
 	if m != nil {
-		return m.Node
+		return m.Service
 	}
-	return nil
+	return ""
 }
 
-// Close is sent when the node disconnects from the network
-type Close struct {
-	// network node
-	Node                 *Node    `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+type TraceResponse struct {
+	// the nodes the request passes through, starting with this node
+	Hops []*Hop `protobuf:"bytes,1,rep,name=hops,proto3" json:"hops,omitempty"`
+	// the address of the service the request reaches
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Close) Reset()         { *m = Close{} }
-func (m *Close) String() string { return proto.CompactTextString(m) }
-func (*Close) ProtoMessage()    {}
-func (*Close) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{17}
+func (m *TraceResponse) Reset()         { *m = TraceResponse{} }
+func (m *TraceResponse) String() string { return proto.CompactTextString(m) }
+func (*TraceResponse) ProtoMessage()    {}
+func (*TraceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{21}
 }
 
-func (m *Close) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Close.Unmarshal(m, b)
+func (m *TraceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceResponse.Unmarshal(m, b)
+}
+func (m *TraceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceResponse.Marshal(b, m, deterministic)
+}
+func (m *TraceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceResponse.Merge(m, src)
+}
+func (m *TraceResponse) XXX_Size() int {
+	return xxx_messageInfo_TraceResponse.Size(m)
+}
+func (m *TraceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceResponse proto.InternalMessageInfo
+
+func (m *TraceResponse) GetHops() []*Hop {
+	if m != nil {
+		return m.Hops
+	}
+	return nil
+}
+
+func (m *TraceResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+// Hop is a node along the path of a request
+type Hop struct {
+	Node *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// round trip time from the previous node in nanoseconds, 0 if not measured
+	Latency              int64    `protobuf:"varint,2,opt,name=latency,proto3" json:"latency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Hop) Reset()         { *m = Hop{} }
+func (m *Hop) String() string { return proto.CompactTextString(m) }
+func (*Hop) ProtoMessage()    {}
+func (*Hop) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{22}
+}
+
+func (m *Hop) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hop.Unmarshal(m, b)
+}
+func (m *Hop) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hop.Marshal(b, m, deterministic)
+}
+func (m *Hop) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hop.Merge(m, src)
+}
+func (m *Hop) XXX_Size() int {
+	return xxx_messageInfo_Hop.Size(m)
+}
+func (m *Hop) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hop.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hop proto.InternalMessageInfo
+
+func (m *Hop) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *Hop) GetLatency() int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+type PartitionsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartitionsRequest) Reset()         { *m = PartitionsRequest{} }
+func (m *PartitionsRequest) String() string { return proto.CompactTextString(m) }
+func (*PartitionsRequest) ProtoMessage()    {}
+func (*PartitionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{23}
+}
+
+func (m *PartitionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionsRequest.Unmarshal(m, b)
+}
+func (m *PartitionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionsRequest.Marshal(b, m, deterministic)
+}
+func (m *PartitionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionsRequest.Merge(m, src)
+}
+func (m *PartitionsRequest) XXX_Size() int {
+	return xxx_messageInfo_PartitionsRequest.Size(m)
+}
+func (m *PartitionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionsRequest proto.InternalMessageInfo
+
+type PartitionsResponse struct {
+	// the partitions, starting with the one this node is in
+	Partitions           []*Partition `protobuf:"bytes,1,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PartitionsResponse) Reset()         { *m = PartitionsResponse{} }
+func (m *PartitionsResponse) String() string { return proto.CompactTextString(m) }
+func (*PartitionsResponse) ProtoMessage()    {}
+func (*PartitionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{24}
+}
+
+func (m *PartitionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionsResponse.Unmarshal(m, b)
+}
+func (m *PartitionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionsResponse.Marshal(b, m, deterministic)
+}
+func (m *PartitionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionsResponse.Merge(m, src)
+}
+func (m *PartitionsResponse) XXX_Size() int {
+	return xxx_messageInfo_PartitionsResponse.Size(m)
+}
+func (m *PartitionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionsResponse proto.InternalMessageInfo
+
+func (m *PartitionsResponse) GetPartitions() []*Partition {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+// Partition is a set of nodes which can reach each other
+type Partition struct {
+	Nodes []*Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// whether this node can reach the partition
+	Reachable            bool     `protobuf:"varint,2,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Partition) Reset()         { *m = Partition{} }
+func (m *Partition) String() string { return proto.CompactTextString(m) }
+func (*Partition) ProtoMessage()    {}
+func (*Partition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{25}
+}
+
+func (m *Partition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Partition.Unmarshal(m, b)
+}
+func (m *Partition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Partition.Marshal(b, m, deterministic)
+}
+func (m *Partition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Partition.Merge(m, src)
+}
+func (m *Partition) XXX_Size() int {
+	return xxx_messageInfo_Partition.Size(m)
+}
+func (m *Partition) XXX_DiscardUnknown() {
+	xxx_messageInfo_Partition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Partition proto.InternalMessageInfo
+
+func (m *Partition) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *Partition) GetReachable() bool {
+	if m != nil {
+		return m.Reachable
+	}
+	return false
+}
+
+// Error tracks network errors
+type Error struct {
+	Count                uint32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Msg                  string   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+func (*Error) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{26}
+}
+
+func (m *Error) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Error.Unmarshal(m, b)
+}
+func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+}
+func (m *Error) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Error.Merge(m, src)
+}
+func (m *Error) XXX_Size() int {
+	return xxx_messageInfo_Error.Size(m)
+}
+func (m *Error) XXX_DiscardUnknown() {
+	xxx_messageInfo_Error.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Error proto.InternalMessageInfo
+
+func (m *Error) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *Error) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+// Status is node status
+type Status struct {
+	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Status) Reset()         { *m = Status{} }
+func (m *Status) String() string { return proto.CompactTextString(m) }
+func (*Status) ProtoMessage()    {}
+func (*Status) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{27}
+}
+
+func (m *Status) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Status.Unmarshal(m, b)
+}
+func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+}
+func (m *Status) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Status.Merge(m, src)
+}
+func (m *Status) XXX_Size() int {
+	return xxx_messageInfo_Status.Size(m)
+}
+func (m *Status) XXX_DiscardUnknown() {
+	xxx_messageInfo_Status.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Status proto.InternalMessageInfo
+
+func (m *Status) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// Node is network node
+type Node struct {
+	// node id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// node address
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// the network
+	Network string `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	// associated metadata
+	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// node status
+	Status *Status `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// round trip time to the node peers in nanoseconds, keyed by peer id
+	Latency              map[string]int64 `protobuf:"bytes,6,rep,name=latency,proto3" json:"latency,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{28}
+}
+
+func (m *Node) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node.Unmarshal(m, b)
+}
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+}
+func (m *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(m, src)
+}
+func (m *Node) XXX_Size() int {
+	return xxx_messageInfo_Node.Size(m)
+}
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node proto.InternalMessageInfo
+
+func (m *Node) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Node) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Node) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *Node) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Node) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *Node) GetLatency() map[string]int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
+
+// Connect is sent when the node connects to the network
+type Connect struct {
+	// network mode
+	Node                 *Node    `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Connect) Reset()         { *m = Connect{} }
+func (m *Connect) String() string { return proto.CompactTextString(m) }
+func (*Connect) ProtoMessage()    {}
+func (*Connect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{29}
+}
+
+func (m *Connect) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Connect.Unmarshal(m, b)
+}
+func (m *Connect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Connect.Marshal(b, m, deterministic)
+}
+func (m *Connect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Connect.Merge(m, src)
+}
+func (m *Connect) XXX_Size() int {
+	return xxx_messageInfo_Connect.Size(m)
+}
+func (m *Connect) XXX_DiscardUnknown() {
+	xxx_messageInfo_Connect.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Connect proto.InternalMessageInfo
+
+func (m *Connect) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+// Close is sent when the node disconnects from the network
+type Close struct {
+	// network node
+	Node                 *Node    `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Close) Reset()         { *m = Close{} }
+func (m *Close) String() string { return proto.CompactTextString(m) }
+func (*Close) ProtoMessage()    {}
+func (*Close) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{30}
+}
+
+func (m *Close) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Close.Unmarshal(m, b)
 }
 func (m *Close) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_Close.Marshal(b, m, deterministic)
 }
-func (m *Close) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Close.Merge(m, src)
+func (m *Close) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Close.Merge(m, src)
+}
+func (m *Close) XXX_Size() int {
+	return xxx_messageInfo_Close.Size(m)
+}
+func (m *Close) XXX_DiscardUnknown() {
+	xxx_messageInfo_Close.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Close proto.InternalMessageInfo
+
+func (m *Close) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+// Peer is used to advertise node peers
+type Peer struct {
+	// network node
+	Node *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// node peers
+	Peers                []*Peer  `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Peer) Reset()         { *m = Peer{} }
+func (m *Peer) String() string { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()    {}
+func (*Peer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{31}
+}
+
+func (m *Peer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Peer.Unmarshal(m, b)
+}
+func (m *Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Peer.Marshal(b, m, deterministic)
+}
+func (m *Peer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Peer.Merge(m, src)
 }
-func (m *Close) XXX_Size() int {
-	return xxx_messageInfo_Close.Size(m)
+func (m *Peer) XXX_Size() int {
+	return xxx_messageInfo_Peer.Size(m)
 }
-func (m *Close) XXX_DiscardUnknown() {
-	xxx_messageInfo_Close.DiscardUnknown(m)
+func (m *Peer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Peer.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Close proto.InternalMessageInfo
+var xxx_messageInfo_Peer proto.InternalMessageInfo
 
-func (m *Close) GetNode() *Node {
+func (m *Peer) GetNode() *Node {
 	if m != nil {
 		return m.Node
 	}
 	return nil
 }
 
-// Peer is used to advertise node peers
-type Peer struct {
-	// network node
-	Node *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	// node peers
-	Peers                []*Peer  `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers,omitempty"`
+func (m *Peer) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+// Sync is network sync message
+type Sync struct {
+	// peer origin
+	Peer *Peer `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	// node routes
+	Routes               []*router.Route `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Sync) Reset()         { *m = Sync{} }
+func (m *Sync) String() string { return proto.CompactTextString(m) }
+func (*Sync) ProtoMessage()    {}
+func (*Sync) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{32}
+}
+
+func (m *Sync) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sync.Unmarshal(m, b)
+}
+func (m *Sync) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sync.Marshal(b, m, deterministic)
+}
+func (m *Sync) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sync.Merge(m, src)
+}
+func (m *Sync) XXX_Size() int {
+	return xxx_messageInfo_Sync.Size(m)
+}
+func (m *Sync) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sync.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sync proto.InternalMessageInfo
+
+func (m *Sync) GetPeer() *Peer {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+func (m *Sync) GetRoutes() []*router.Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+type HealthRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
+func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthRequest) ProtoMessage()    {}
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{33}
+}
+
+func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
+}
+func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
+}
+func (m *HealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthRequest.Merge(m, src)
+}
+func (m *HealthRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthRequest.Size(m)
+}
+func (m *HealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
+
+type HealthResponse struct {
+	// status of the node, ok or the reason it's unhealthy
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// status of the router e.g. running or stopped
+	Router string `protobuf:"bytes,2,opt,name=router,proto3" json:"router,omitempty"`
+	// number of peers the node is connected to
+	Peers int64 `protobuf:"varint,3,opt,name=peers,proto3" json:"peers,omitempty"`
+	// unix timestamps of the last adverts sent to and received from the peers, 0 if none yet
+	LastAdvertSent     int64 `protobuf:"varint,4,opt,name=last_advert_sent,json=lastAdvertSent,proto3" json:"last_advert_sent,omitempty"`
+	LastAdvertReceived int64 `protobuf:"varint,5,opt,name=last_advert_received,json=lastAdvertReceived,proto3" json:"last_advert_received,omitempty"`
+	// largest offset of the clock of a peer from the clock of the node in milliseconds
+	MaxClockSkew         int64    `protobuf:"varint,6,opt,name=max_clock_skew,json=maxClockSkew,proto3" json:"max_clock_skew,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
+func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
+func (*HealthResponse) ProtoMessage()    {}
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{34}
+}
+
+func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthResponse.Unmarshal(m, b)
+}
+func (m *HealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthResponse.Marshal(b, m, deterministic)
+}
+func (m *HealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthResponse.Merge(m, src)
+}
+func (m *HealthResponse) XXX_Size() int {
+	return xxx_messageInfo_HealthResponse.Size(m)
+}
+func (m *HealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthResponse proto.InternalMessageInfo
+
+func (m *HealthResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *HealthResponse) GetRouter() string {
+	if m != nil {
+		return m.Router
+	}
+	return ""
+}
+
+func (m *HealthResponse) GetPeers() int64 {
+	if m != nil {
+		return m.Peers
+	}
+	return 0
+}
+
+func (m *HealthResponse) GetLastAdvertSent() int64 {
+	if m != nil {
+		return m.LastAdvertSent
+	}
+	return 0
+}
+
+func (m *HealthResponse) GetLastAdvertReceived() int64 {
+	if m != nil {
+		return m.LastAdvertReceived
+	}
+	return 0
+}
+
+func (m *HealthResponse) GetMaxClockSkew() int64 {
+	if m != nil {
+		return m.MaxClockSkew
+	}
+	return 0
+}
+
+// Policy allows or denies the calls from a service to another
+type Policy struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// service the calls are from, a prefix ending in * or * for any service
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// service the calls are to, a prefix ending in * or * for any service
+	To string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// allow or deny
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// policies with a higher priority are checked first
+	Priority int32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	// unix timestamp of when the policy was added
+	Created              int64    `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Policy) Reset()         { *m = Policy{} }
+func (m *Policy) String() string { return proto.CompactTextString(m) }
+func (*Policy) ProtoMessage()    {}
+func (*Policy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{35}
+}
+
+func (m *Policy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Policy.Unmarshal(m, b)
+}
+func (m *Policy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Policy.Marshal(b, m, deterministic)
+}
+func (m *Policy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Policy.Merge(m, src)
+}
+func (m *Policy) XXX_Size() int {
+	return xxx_messageInfo_Policy.Size(m)
+}
+func (m *Policy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Policy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Policy proto.InternalMessageInfo
+
+func (m *Policy) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Policy) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *Policy) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *Policy) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *Policy) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Policy) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type AddPolicyRequest struct {
+	Policy               *Policy  `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddPolicyRequest) Reset()         { *m = AddPolicyRequest{} }
+func (m *AddPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*AddPolicyRequest) ProtoMessage()    {}
+func (*AddPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{36}
+}
+
+func (m *AddPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddPolicyRequest.Unmarshal(m, b)
+}
+func (m *AddPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddPolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *AddPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPolicyRequest.Merge(m, src)
+}
+func (m *AddPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_AddPolicyRequest.Size(m)
+}
+func (m *AddPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddPolicyRequest proto.InternalMessageInfo
+
+func (m *AddPolicyRequest) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type AddPolicyResponse struct {
+	Policy               *Policy  `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddPolicyResponse) Reset()         { *m = AddPolicyResponse{} }
+func (m *AddPolicyResponse) String() string { return proto.CompactTextString(m) }
+func (*AddPolicyResponse) ProtoMessage()    {}
+func (*AddPolicyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{37}
+}
+
+func (m *AddPolicyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddPolicyResponse.Unmarshal(m, b)
+}
+func (m *AddPolicyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddPolicyResponse.Marshal(b, m, deterministic)
+}
+func (m *AddPolicyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPolicyResponse.Merge(m, src)
+}
+func (m *AddPolicyResponse) XXX_Size() int {
+	return xxx_messageInfo_AddPolicyResponse.Size(m)
+}
+func (m *AddPolicyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPolicyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddPolicyResponse proto.InternalMessageInfo
+
+func (m *AddPolicyResponse) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type DeletePolicyRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePolicyRequest) Reset()         { *m = DeletePolicyRequest{} }
+func (m *DeletePolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*DeletePolicyRequest) ProtoMessage()    {}
+func (*DeletePolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{38}
+}
+
+func (m *DeletePolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePolicyRequest.Unmarshal(m, b)
+}
+func (m *DeletePolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *DeletePolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePolicyRequest.Merge(m, src)
+}
+func (m *DeletePolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_DeletePolicyRequest.Size(m)
+}
+func (m *DeletePolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePolicyRequest proto.InternalMessageInfo
+
+func (m *DeletePolicyRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeletePolicyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePolicyResponse) Reset()         { *m = DeletePolicyResponse{} }
+func (m *DeletePolicyResponse) String() string { return proto.CompactTextString(m) }
+func (*DeletePolicyResponse) ProtoMessage()    {}
+func (*DeletePolicyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{39}
+}
+
+func (m *DeletePolicyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePolicyResponse.Unmarshal(m, b)
+}
+func (m *DeletePolicyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePolicyResponse.Marshal(b, m, deterministic)
+}
+func (m *DeletePolicyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePolicyResponse.Merge(m, src)
+}
+func (m *DeletePolicyResponse) XXX_Size() int {
+	return xxx_messageInfo_DeletePolicyResponse.Size(m)
+}
+func (m *DeletePolicyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePolicyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePolicyResponse proto.InternalMessageInfo
+
+type ListPoliciesRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Peer) Reset()         { *m = Peer{} }
-func (m *Peer) String() string { return proto.CompactTextString(m) }
-func (*Peer) ProtoMessage()    {}
-func (*Peer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{18}
+func (m *ListPoliciesRequest) Reset()         { *m = ListPoliciesRequest{} }
+func (m *ListPoliciesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPoliciesRequest) ProtoMessage()    {}
+func (*ListPoliciesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{40}
 }
 
-func (m *Peer) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Peer.Unmarshal(m, b)
-}
-func (m *Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Peer.Marshal(b, m, deterministic)
+func (m *ListPoliciesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPoliciesRequest.Unmarshal(m, b)
 }
-func (m *Peer) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Peer.Merge(m, src)
+func (m *ListPoliciesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPoliciesRequest.Marshal(b, m, deterministic)
 }
-func (m *Peer) XXX_Size() int {
-	return xxx_messageInfo_Peer.Size(m)
+func (m *ListPoliciesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPoliciesRequest.Merge(m, src)
 }
-func (m *Peer) XXX_DiscardUnknown() {
-	xxx_messageInfo_Peer.DiscardUnknown(m)
+func (m *ListPoliciesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPoliciesRequest.Size(m)
 }
-
-var xxx_messageInfo_Peer proto.InternalMessageInfo
-
-func (m *Peer) GetNode() *Node {
-	if m != nil {
-		return m.Node
-	}
-	return nil
+func (m *ListPoliciesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPoliciesRequest.DiscardUnknown(m)
 }
 
-func (m *Peer) GetPeers() []*Peer {
-	if m != nil {
-		return m.Peers
-	}
-	return nil
-}
+var xxx_messageInfo_ListPoliciesRequest proto.InternalMessageInfo
 
-// Sync is network sync message
-type Sync struct {
-	// peer origin
-	Peer *Peer `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
-	// node routes
-	Routes               []*router.Route `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type ListPoliciesResponse struct {
+	Policies             []*Policy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *Sync) Reset()         { *m = Sync{} }
-func (m *Sync) String() string { return proto.CompactTextString(m) }
-func (*Sync) ProtoMessage()    {}
-func (*Sync) Descriptor() ([]byte, []int) {
-	return fileDescriptor_96ad937ae012c472, []int{19}
+func (m *ListPoliciesResponse) Reset()         { *m = ListPoliciesResponse{} }
+func (m *ListPoliciesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPoliciesResponse) ProtoMessage()    {}
+func (*ListPoliciesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{41}
 }
 
-func (m *Sync) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Sync.Unmarshal(m, b)
+func (m *ListPoliciesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPoliciesResponse.Unmarshal(m, b)
 }
-func (m *Sync) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Sync.Marshal(b, m, deterministic)
+func (m *ListPoliciesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPoliciesResponse.Marshal(b, m, deterministic)
 }
-func (m *Sync) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Sync.Merge(m, src)
+func (m *ListPoliciesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPoliciesResponse.Merge(m, src)
 }
-func (m *Sync) XXX_Size() int {
-	return xxx_messageInfo_Sync.Size(m)
+func (m *ListPoliciesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPoliciesResponse.Size(m)
 }
-func (m *Sync) XXX_DiscardUnknown() {
-	xxx_messageInfo_Sync.DiscardUnknown(m)
+func (m *ListPoliciesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPoliciesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Sync proto.InternalMessageInfo
-
-func (m *Sync) GetPeer() *Peer {
-	if m != nil {
-		return m.Peer
-	}
-	return nil
-}
+var xxx_messageInfo_ListPoliciesResponse proto.InternalMessageInfo
 
-func (m *Sync) GetRoutes() []*router.Route {
+func (m *ListPoliciesResponse) GetPolicies() []*Policy {
 	if m != nil {
-		return m.Routes
+		return m.Policies
 	}
 	return nil
 }
@@ -896,73 +1868,134 @@ func init() {
 	proto.RegisterType((*Query)(nil), "network.Query")
 	proto.RegisterType((*ConnectRequest)(nil), "network.ConnectRequest")
 	proto.RegisterType((*ConnectResponse)(nil), "network.ConnectResponse")
+	proto.RegisterType((*DisconnectRequest)(nil), "network.DisconnectRequest")
+	proto.RegisterType((*DisconnectResponse)(nil), "network.DisconnectResponse")
 	proto.RegisterType((*NodesRequest)(nil), "network.NodesRequest")
 	proto.RegisterType((*NodesResponse)(nil), "network.NodesResponse")
 	proto.RegisterType((*GraphRequest)(nil), "network.GraphRequest")
 	proto.RegisterType((*GraphResponse)(nil), "network.GraphResponse")
+	proto.RegisterType((*Edge)(nil), "network.Edge")
 	proto.RegisterType((*RoutesRequest)(nil), "network.RoutesRequest")
 	proto.RegisterType((*RoutesResponse)(nil), "network.RoutesResponse")
 	proto.RegisterType((*ServicesRequest)(nil), "network.ServicesRequest")
 	proto.RegisterType((*ServicesResponse)(nil), "network.ServicesResponse")
 	proto.RegisterType((*StatusRequest)(nil), "network.StatusRequest")
 	proto.RegisterType((*StatusResponse)(nil), "network.StatusResponse")
+	proto.RegisterType((*RotateRequest)(nil), "network.RotateRequest")
+	proto.RegisterType((*RotateResponse)(nil), "network.RotateResponse")
+	proto.RegisterType((*MapRequest)(nil), "network.MapRequest")
+	proto.RegisterType((*MapResponse)(nil), "network.MapResponse")
+	proto.RegisterType((*TraceRequest)(nil), "network.TraceRequest")
+	proto.RegisterType((*TraceResponse)(nil), "network.TraceResponse")
+	proto.RegisterType((*Hop)(nil), "network.Hop")
+	proto.RegisterType((*PartitionsRequest)(nil), "network.PartitionsRequest")
+	proto.RegisterType((*PartitionsResponse)(nil), "network.PartitionsResponse")
+	proto.RegisterType((*Partition)(nil), "network.Partition")
 	proto.RegisterType((*Error)(nil), "network.Error")
 	proto.RegisterType((*Status)(nil), "network.Status")
 	proto.RegisterType((*Node)(nil), "network.Node")
+	proto.RegisterMapType((map[string]int64)(nil), "network.Node.LatencyEntry")
 	proto.RegisterMapType((map[string]string)(nil), "network.Node.MetadataEntry")
 	proto.RegisterType((*Connect)(nil), "network.Connect")
 	proto.RegisterType((*Close)(nil), "network.Close")
 	proto.RegisterType((*Peer)(nil), "network.Peer")
 	proto.RegisterType((*Sync)(nil), "network.Sync")
+	proto.RegisterType((*HealthRequest)(nil), "network.HealthRequest")
+	proto.RegisterType((*HealthResponse)(nil), "network.HealthResponse")
+	proto.RegisterType((*Policy)(nil), "network.Policy")
+	proto.RegisterType((*AddPolicyRequest)(nil), "network.AddPolicyRequest")
+	proto.RegisterType((*AddPolicyResponse)(nil), "network.AddPolicyResponse")
+	proto.RegisterType((*DeletePolicyRequest)(nil), "network.DeletePolicyRequest")
+	proto.RegisterType((*DeletePolicyResponse)(nil), "network.DeletePolicyResponse")
+	proto.RegisterType((*ListPoliciesRequest)(nil), "network.ListPoliciesRequest")
+	proto.RegisterType((*ListPoliciesResponse)(nil), "network.ListPoliciesResponse")
 }
 
 func init() { proto.RegisterFile("network/network.proto", fileDescriptor_96ad937ae012c472) }
 
 var fileDescriptor_96ad937ae012c472 = []byte{
-	// 673 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x51, 0x4f, 0xd4, 0x40,
-	0x10, 0xa6, 0xbd, 0xf6, 0x0e, 0x46, 0x7a, 0xe0, 0x2a, 0x50, 0xeb, 0x0b, 0xae, 0x18, 0x89, 0x31,
-	0x6d, 0x04, 0x09, 0x28, 0x89, 0x89, 0x12, 0xe2, 0x93, 0x04, 0xcb, 0x9b, 0x6f, 0xa5, 0xdd, 0xc0,
-	0x05, 0xae, 0x7b, 0x6c, 0xb7, 0x90, 0xfb, 0x05, 0xfe, 0x56, 0xff, 0x82, 0x4f, 0x66, 0x77, 0xa7,
-	0xbd, 0x96, 0xea, 0xc9, 0xcb, 0xed, 0xcd, 0x7c, 0x33, 0xb3, 0xbb, 0xdf, 0x7c, 0xb3, 0x85, 0xb5,
-	0x9c, 0xc9, 0x3b, 0x2e, 0xae, 0x22, 0x5c, 0xc3, 0x89, 0xe0, 0x92, 0x93, 0x01, 0x9a, 0xc1, 0x13,
-	0xc1, 0x4b, 0xc9, 0x44, 0x64, 0x16, 0x83, 0xd2, 0x9f, 0x16, 0xb8, 0xdf, 0x4b, 0x26, 0xa6, 0xc4,
-	0x87, 0x41, 0xc1, 0xc4, 0xed, 0x28, 0x65, 0xbe, 0xb5, 0x69, 0x6d, 0x2f, 0xc5, 0x95, 0xa9, 0x90,
-	0x24, 0xcb, 0x04, 0x2b, 0x0a, 0xdf, 0x36, 0x08, 0x9a, 0x0a, 0xb9, 0x48, 0x24, 0xbb, 0x4b, 0xa6,
-	0x7e, 0xcf, 0x20, 0x68, 0x92, 0x75, 0xe8, 0x9b, 0x7d, 0x7c, 0x47, 0x03, 0x68, 0xa9, 0x0c, 0x3c,
-	0x8f, 0xef, 0x9a, 0x0c, 0x34, 0xe9, 0x1e, 0x0c, 0x8f, 0x78, 0x9e, 0xb3, 0x54, 0xc6, 0xec, 0xa6,
-	0x64, 0x85, 0x24, 0x2f, 0xc1, 0xcd, 0x79, 0xc6, 0x0a, 0xdf, 0xda, 0xec, 0x6d, 0x3f, 0xda, 0xf1,
-	0xc2, 0xea, 0x62, 0x27, 0x3c, 0x63, 0xb1, 0xc1, 0xe8, 0x63, 0x58, 0xa9, 0xd3, 0x8a, 0x09, 0xcf,
-	0x0b, 0x46, 0xb7, 0x60, 0x59, 0x45, 0x14, 0x55, 0x9d, 0xa7, 0xe0, 0x66, 0x6c, 0x22, 0x2f, 0xf5,
-	0xbd, 0xbc, 0xd8, 0x18, 0xf4, 0x3d, 0x78, 0x18, 0x65, 0xd2, 0x1e, 0xb6, 0xdd, 0x16, 0x2c, 0x7f,
-	0x15, 0xc9, 0xe4, 0x72, 0x7e, 0xed, 0x1d, 0xf0, 0x30, 0x0a, 0x6b, 0xbf, 0x00, 0x47, 0x70, 0x2e,
-	0x75, 0x54, 0xb3, 0xf4, 0x29, 0x63, 0x22, 0xd6, 0x10, 0xdd, 0x03, 0x2f, 0x56, 0x1c, 0xd5, 0xc7,
-	0xde, 0x02, 0xf7, 0x46, 0x75, 0x06, 0x93, 0x86, 0x75, 0x92, 0xee, 0x57, 0x6c, 0x40, 0xba, 0x0f,
-	0xc3, 0x2a, 0x0d, 0xf7, 0x7a, 0x85, 0xd4, 0xcf, 0x2e, 0x82, 0x1d, 0xd7, 0x71, 0xd8, 0x09, 0x4d,
-	0xdc, 0x99, 0x69, 0x70, 0xb5, 0x23, 0x0d, 0x61, 0x75, 0xe6, 0xc2, 0x6a, 0x01, 0x2c, 0xa2, 0x0e,
-	0x4c, 0xbd, 0xa5, 0xb8, 0xb6, 0xe9, 0x0a, 0x78, 0x67, 0x32, 0x91, 0x65, 0x5d, 0xe0, 0x03, 0x0c,
-	0x2b, 0x07, 0xa6, 0xbf, 0x86, 0x7e, 0xa1, 0x3d, 0x78, 0x8b, 0x95, 0xfa, 0x16, 0x18, 0x88, 0x30,
-	0x8d, 0xc0, 0x3d, 0x16, 0x82, 0x0b, 0xc5, 0x68, 0xca, 0xcb, 0x5c, 0x56, 0x8c, 0x6a, 0x83, 0xac,
-	0x42, 0x6f, 0x5c, 0x5c, 0xa0, 0xfe, 0xd4, 0x5f, 0x1a, 0x42, 0xdf, 0x94, 0x50, 0x44, 0x31, 0x95,
-	0xda, 0x21, 0x4a, 0x17, 0x8c, 0x0d, 0x48, 0x7f, 0x59, 0xe0, 0xa8, 0x4e, 0x92, 0x21, 0xd8, 0xa3,
-	0x0c, 0x35, 0x6e, 0x8f, 0xb2, 0xf9, 0xf2, 0xae, 0xc4, 0xda, 0x6b, 0x89, 0x95, 0xec, 0xc3, 0xe2,
-	0x98, 0xc9, 0x24, 0x4b, 0x64, 0xe2, 0x3b, 0x9a, 0xe5, 0xe7, 0x2d, 0xb9, 0x84, 0xdf, 0x10, 0x3d,
-	0xce, 0xa5, 0x98, 0xc6, 0x75, 0x70, 0x83, 0x0f, 0x77, 0x2e, 0x1f, 0xc1, 0x21, 0x78, 0xad, 0x1a,
-	0x8a, 0x81, 0x2b, 0x36, 0xc5, 0x73, 0xab, 0xbf, 0x8a, 0xa9, 0xdb, 0xe4, 0xba, 0x64, 0x78, 0x6c,
-	0x63, 0x7c, 0xb4, 0x0f, 0x2c, 0xfa, 0x16, 0x06, 0x38, 0x14, 0x4a, 0x79, 0x4a, 0xb9, 0x1d, 0xe5,
-	0x69, 0x51, 0x6b, 0x88, 0xbe, 0x01, 0xf7, 0xe8, 0x9a, 0x1b, 0x95, 0xfe, 0x2f, 0xf6, 0x04, 0x1c,
-	0xa5, 0xd9, 0x07, 0x84, 0xaa, 0x79, 0x9a, 0x30, 0x26, 0x14, 0xab, 0xbd, 0xae, 0xe8, 0x0d, 0x46,
-	0x4f, 0xc1, 0x39, 0x9b, 0xe6, 0xa9, 0xaa, 0xa7, 0x1c, 0xff, 0x18, 0x10, 0x05, 0x35, 0x74, 0x6d,
-	0xcf, 0xd1, 0xf5, 0xce, 0x6f, 0x1b, 0x06, 0x27, 0xd8, 0xa6, 0x4f, 0x33, 0x1e, 0x36, 0xea, 0x92,
-	0xed, 0x57, 0x26, 0xf0, 0xbb, 0x00, 0xbe, 0x23, 0x0b, 0xe4, 0x00, 0x5c, 0x3d, 0xc7, 0x64, 0xad,
-	0x0e, 0x6a, 0x4e, 0x7f, 0xb0, 0x7e, 0xdf, 0xdd, 0xcc, 0xd4, 0xaf, 0x4b, 0x23, 0xb3, 0xf9, 0x26,
-	0x35, 0x32, 0x5b, 0x8f, 0x10, 0x5d, 0x20, 0x87, 0xd0, 0x37, 0x03, 0x4d, 0x66, 0x31, 0xad, 0x87,
-	0x21, 0xd8, 0xe8, 0xf8, 0xeb, 0xe4, 0xcf, 0xb0, 0x58, 0x4d, 0x30, 0x99, 0x5d, 0xec, 0xde, 0x9c,
-	0x07, 0xcf, 0xfe, 0x82, 0x34, 0xf7, 0xc7, 0xb9, 0x5a, 0xbf, 0xaf, 0xcd, 0xce, 0xfe, 0xed, 0x61,
-	0xa7, 0x0b, 0x5f, 0xde, 0xfd, 0x88, 0x2e, 0x46, 0xf2, 0xb2, 0x3c, 0x0f, 0x53, 0x3e, 0x8e, 0xc6,
-	0xa3, 0x54, 0x70, 0xfc, 0xbd, 0xdd, 0x8d, 0xf4, 0x17, 0xa7, 0xfa, 0x3a, 0x1d, 0xe2, 0x7a, 0xde,
-	0xd7, 0xee, 0xdd, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x1d, 0x05, 0xe7, 0x07, 0xbf, 0x06, 0x00,
-	0x00,
+	// 1293 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x6d, 0x4f, 0x1b, 0xc7,
+	0x13, 0xff, 0xfb, 0x11, 0x98, 0xd8, 0x06, 0x16, 0xc7, 0xf8, 0x7f, 0x49, 0xaa, 0x74, 0x43, 0x14,
+	0xd4, 0x56, 0x90, 0x92, 0x44, 0x49, 0x43, 0x55, 0x29, 0x81, 0x28, 0x48, 0x0d, 0x88, 0x1e, 0x7d,
+	0xd5, 0x37, 0x68, 0xb9, 0xdb, 0xc2, 0x09, 0xfb, 0xf6, 0xb2, 0xb7, 0x86, 0x58, 0xea, 0xfb, 0x4a,
+	0xfd, 0x84, 0x91, 0xfa, 0x65, 0xaa, 0x7d, 0xbc, 0x3d, 0x1f, 0x10, 0xda, 0x37, 0xf8, 0x66, 0x7e,
+	0x33, 0xb3, 0xb3, 0xb3, 0xf3, 0x04, 0xdc, 0x4d, 0xa9, 0xb8, 0x64, 0xfc, 0x7c, 0xd3, 0xfc, 0x6e,
+	0x64, 0x9c, 0x09, 0x86, 0xe6, 0x0c, 0x19, 0xac, 0x70, 0x36, 0x11, 0x94, 0x6f, 0xea, 0x1f, 0x8d,
+	0xe2, 0x3f, 0x6b, 0xd0, 0xfa, 0x65, 0x42, 0xf9, 0x14, 0x0d, 0x61, 0x2e, 0xa7, 0xfc, 0x22, 0x89,
+	0xe8, 0xb0, 0xf6, 0xb0, 0xb6, 0xbe, 0x10, 0x5a, 0x52, 0x22, 0x24, 0x8e, 0x39, 0xcd, 0xf3, 0x61,
+	0x5d, 0x23, 0x86, 0x94, 0xc8, 0x29, 0x11, 0xf4, 0x92, 0x4c, 0x87, 0x0d, 0x8d, 0x18, 0x12, 0x0d,
+	0xa0, 0xad, 0xcf, 0x19, 0x36, 0x15, 0x60, 0x28, 0xa9, 0x61, 0xfc, 0x19, 0xb6, 0xb4, 0x86, 0x21,
+	0xf1, 0x0b, 0xe8, 0xed, 0xb0, 0x34, 0xa5, 0x91, 0x08, 0xe9, 0xc7, 0x09, 0xcd, 0x05, 0x7a, 0x04,
+	0xad, 0x94, 0xc5, 0x34, 0x1f, 0xd6, 0x1e, 0x36, 0xd6, 0xef, 0x6c, 0x75, 0x37, 0xec, 0xc5, 0x0e,
+	0x58, 0x4c, 0x43, 0x8d, 0xe1, 0x65, 0x58, 0x74, 0x6a, 0x79, 0xc6, 0xd2, 0x9c, 0xe2, 0x57, 0xb0,
+	0xbc, 0x9b, 0xe4, 0xd1, 0x7f, 0x30, 0xd6, 0x07, 0xe4, 0x6b, 0x1a, 0x7b, 0x6b, 0xd0, 0x91, 0x42,
+	0xb9, 0x35, 0xd5, 0x87, 0x56, 0x4c, 0x33, 0x71, 0xa6, 0xe2, 0xd4, 0x0d, 0x35, 0x81, 0x9f, 0x43,
+	0xd7, 0x48, 0x69, 0xb5, 0xdb, 0x9d, 0xb8, 0x06, 0x9d, 0xf7, 0x9c, 0x64, 0x67, 0x37, 0xdb, 0xfe,
+	0x03, 0xba, 0x46, 0xca, 0xd8, 0xfe, 0x1a, 0x9a, 0x9c, 0x31, 0xa1, 0xa4, 0x7c, 0xd3, 0x87, 0x94,
+	0xf2, 0x50, 0x41, 0xc5, 0xf1, 0xf5, 0xeb, 0x8f, 0x97, 0x42, 0x34, 0x3e, 0xa5, 0xf9, 0xb0, 0x31,
+	0x23, 0xf4, 0x2e, 0x3e, 0xa5, 0xa1, 0xc6, 0xf0, 0x2e, 0x34, 0x25, 0x89, 0x10, 0x34, 0x7f, 0xe7,
+	0x6c, 0x6c, 0xd2, 0x43, 0x7d, 0xa3, 0x1e, 0xd4, 0x05, 0x33, 0x69, 0x51, 0x17, 0x4c, 0xbe, 0xef,
+	0x88, 0x08, 0x9a, 0x46, 0x3a, 0x23, 0x1a, 0xa1, 0x25, 0xf1, 0x0b, 0xe8, 0x86, 0x32, 0x07, 0x5c,
+	0x18, 0xd7, 0xa0, 0xf5, 0x51, 0x66, 0x9e, 0xb9, 0x44, 0xcf, 0x9d, 0xad, 0xf2, 0x31, 0xd4, 0x20,
+	0x7e, 0x09, 0x3d, 0xab, 0x66, 0xee, 0xfe, 0xd8, 0xa4, 0x56, 0x11, 0x58, 0x93, 0xd1, 0x4a, 0xce,
+	0x64, 0x9a, 0x4a, 0x8c, 0x23, 0x9d, 0xc0, 0xf6, 0x44, 0xbc, 0x01, 0x4b, 0x05, 0xcb, 0x58, 0x0b,
+	0x60, 0xde, 0xe4, 0xb9, 0xb6, 0xb7, 0x10, 0x3a, 0x1a, 0x2f, 0x42, 0xf7, 0x48, 0x10, 0x31, 0x71,
+	0x06, 0x7e, 0x80, 0x9e, 0x65, 0x18, 0xf5, 0x27, 0xd0, 0xce, 0x15, 0xc7, 0xdc, 0x62, 0xd1, 0xdd,
+	0xc2, 0x08, 0x1a, 0x58, 0xda, 0x0a, 0x99, 0x20, 0x82, 0x5a, 0x5b, 0x4f, 0xa1, 0x67, 0x19, 0xc6,
+	0xd6, 0x57, 0x00, 0xa7, 0x34, 0xa5, 0x9c, 0x88, 0x84, 0xa5, 0xca, 0x5e, 0x33, 0xf4, 0x38, 0xb8,
+	0x03, 0xb0, 0x4f, 0x32, 0xab, 0xbf, 0x05, 0x77, 0x14, 0xf5, 0x6f, 0xb2, 0x6d, 0x1d, 0x3a, 0xbf,
+	0x72, 0x12, 0x59, 0x1f, 0xae, 0xaf, 0x79, 0xfc, 0x33, 0x74, 0x8d, 0xa4, 0xb1, 0xff, 0x10, 0x9a,
+	0x67, 0x2c, 0xb3, 0xe6, 0x3b, 0xce, 0xfc, 0x1e, 0xcb, 0x42, 0x85, 0x5c, 0xdf, 0x26, 0xf0, 0x5b,
+	0x68, 0xec, 0xb1, 0x4c, 0x26, 0xad, 0x74, 0xa3, 0x92, 0xb4, 0xca, 0x43, 0x05, 0xf9, 0xe9, 0x53,
+	0x2f, 0xa7, 0xcf, 0x0a, 0x2c, 0x1f, 0x12, 0x2e, 0x12, 0x19, 0x09, 0xf7, 0x1e, 0x7b, 0x80, 0x7c,
+	0xa6, 0x71, 0x75, 0x0b, 0x20, 0x73, 0x5c, 0xe3, 0x30, 0x2a, 0x4a, 0xc4, 0x42, 0xa1, 0x27, 0x85,
+	0x0f, 0x60, 0xc1, 0x01, 0xb7, 0x8a, 0x25, 0xba, 0x0f, 0x0b, 0x9c, 0x92, 0xe8, 0x8c, 0x9c, 0x8c,
+	0xa8, 0x72, 0x76, 0x3e, 0x2c, 0x18, 0x78, 0x13, 0x5a, 0xef, 0x38, 0x67, 0x5c, 0x16, 0x74, 0xc4,
+	0x26, 0xa9, 0xb0, 0x05, 0xad, 0x08, 0xb4, 0x04, 0x8d, 0x71, 0x7e, 0x6a, 0xe2, 0x24, 0x3f, 0xf1,
+	0x06, 0xb4, 0x75, 0xc6, 0xc8, 0xba, 0xa0, 0x52, 0xb5, 0x52, 0x17, 0xca, 0x60, 0xa8, 0x41, 0xfc,
+	0x77, 0x1d, 0x9a, 0xd2, 0x1d, 0x59, 0x81, 0x49, 0x6c, 0x9e, 0xaf, 0x9e, 0xc4, 0x37, 0x77, 0x6b,
+	0xdb, 0x7b, 0x1b, 0xa5, 0xde, 0x8b, 0x5e, 0xc2, 0xfc, 0x98, 0x0a, 0x12, 0x13, 0x41, 0x86, 0x4d,
+	0x75, 0xe7, 0x7b, 0xa5, 0x3b, 0x6f, 0xec, 0x1b, 0xf4, 0x5d, 0x2a, 0xf8, 0x34, 0x74, 0xc2, 0x5e,
+	0xfa, 0xb7, 0x6e, 0x4c, 0x7f, 0xf4, 0xbc, 0x78, 0xd8, 0xb6, 0x3a, 0x20, 0x28, 0x1f, 0xf0, 0x41,
+	0x83, 0xda, 0xbe, 0x15, 0x0d, 0xb6, 0xa1, 0x5b, 0x3a, 0x59, 0xc6, 0xed, 0x9c, 0x4e, 0xcd, 0x6d,
+	0xe5, 0xa7, 0x8c, 0xef, 0x05, 0x19, 0x4d, 0xa8, 0xb9, 0xac, 0x26, 0x5e, 0xd7, 0x5f, 0xd5, 0x82,
+	0xd7, 0xd0, 0xf1, 0xad, 0x7e, 0x49, 0xb7, 0xe1, 0xe9, 0xe2, 0xef, 0x60, 0xce, 0x4c, 0x95, 0x5b,
+	0x64, 0x2d, 0xfe, 0x06, 0x5a, 0x3b, 0x23, 0xa6, 0xdb, 0xf2, 0x97, 0x64, 0x0f, 0xa0, 0x29, 0x9b,
+	0xf4, 0x6d, 0x8a, 0xe1, 0x11, 0xb4, 0x32, 0x4a, 0x79, 0xb5, 0x83, 0xab, 0x2e, 0xaf, 0x31, 0x7c,
+	0x08, 0xcd, 0xa3, 0x69, 0x1a, 0x49, 0x7b, 0x92, 0x71, 0xcd, 0x44, 0x90, 0x90, 0xd7, 0x38, 0xeb,
+	0x37, 0x35, 0xce, 0x45, 0xe8, 0xee, 0x51, 0x32, 0x12, 0x76, 0x26, 0xe1, 0xcf, 0x35, 0xe8, 0x59,
+	0x8e, 0x29, 0xb1, 0x41, 0xa9, 0xed, 0x2d, 0xb8, 0x67, 0x2e, 0xc6, 0x7e, 0xbd, 0x34, 0xf6, 0xfb,
+	0xf6, 0x2a, 0x7a, 0x28, 0x68, 0x02, 0xad, 0xc3, 0xd2, 0x88, 0xe4, 0xe2, 0x98, 0xc4, 0x17, 0x94,
+	0x8b, 0xe3, 0x9c, 0xa6, 0x42, 0xad, 0x0b, 0x8d, 0xb0, 0x27, 0xf9, 0x6f, 0x14, 0xfb, 0x88, 0xa6,
+	0x02, 0x3d, 0x85, 0xbe, 0x2f, 0xc9, 0x69, 0x44, 0x93, 0x0b, 0x1a, 0xab, 0xac, 0x6b, 0x84, 0xa8,
+	0x90, 0x0e, 0x0d, 0x82, 0xd6, 0xa0, 0x37, 0x26, 0x9f, 0x8e, 0xa3, 0x11, 0x8b, 0xce, 0x8f, 0xf3,
+	0x73, 0x7a, 0x39, 0x6c, 0x2b, 0xd9, 0xce, 0x98, 0x7c, 0xda, 0x91, 0xcc, 0xa3, 0x73, 0x7a, 0x89,
+	0xff, 0xaa, 0x41, 0xfb, 0x90, 0x8d, 0x92, 0x68, 0x5a, 0xa9, 0x23, 0x3b, 0xed, 0xea, 0x95, 0x69,
+	0xd7, 0x70, 0xd3, 0x6e, 0x00, 0x6d, 0x12, 0xa9, 0x6e, 0x6d, 0xb6, 0x1c, 0x4d, 0xc9, 0xa1, 0x92,
+	0xf1, 0x84, 0xf1, 0x44, 0x4c, 0x95, 0x8b, 0xad, 0xd0, 0xd1, 0xb2, 0x0a, 0x23, 0x4e, 0x89, 0xa0,
+	0xb1, 0xf1, 0xc8, 0x92, 0x78, 0x1b, 0x96, 0xde, 0xc4, 0xb1, 0x76, 0xc7, 0x76, 0xe8, 0x27, 0xd0,
+	0xce, 0x14, 0xa3, 0x32, 0x5f, 0x8c, 0x9c, 0x81, 0xf1, 0x8f, 0xb0, 0xec, 0x29, 0x17, 0xd3, 0xe9,
+	0x76, 0xda, 0x8f, 0x61, 0x65, 0x97, 0x8e, 0xa8, 0xa0, 0xe5, 0xd3, 0x67, 0x62, 0x82, 0x07, 0xd0,
+	0x2f, 0x8b, 0x99, 0x0d, 0xe9, 0x2e, 0xac, 0x7c, 0x48, 0x72, 0xa1, 0xb8, 0x49, 0x31, 0x6f, 0x77,
+	0xa0, 0x5f, 0x66, 0x1b, 0xb7, 0xbe, 0x85, 0xf9, 0xcc, 0xf0, 0x4c, 0x8b, 0xad, 0x38, 0xe6, 0x04,
+	0xb6, 0x3e, 0xcf, 0xc1, 0xdc, 0x81, 0x06, 0xd1, 0x4f, 0x45, 0x59, 0xae, 0x3a, 0x8d, 0xf2, 0xd6,
+	0x18, 0x0c, 0xab, 0x80, 0xf1, 0xf2, 0x7f, 0xe8, 0x3d, 0x40, 0xb1, 0xdf, 0xa1, 0xa2, 0x05, 0x55,
+	0xd6, 0xc5, 0xe0, 0xde, 0x95, 0x98, 0x33, 0xf4, 0x0a, 0x5a, 0x6a, 0x21, 0x43, 0x77, 0x9d, 0x9c,
+	0xbf, 0xc6, 0x05, 0x83, 0x59, 0xb6, 0xaf, 0xa9, 0xd6, 0x44, 0x4f, 0xd3, 0x5f, 0x2e, 0x83, 0xc1,
+	0x2c, 0xdb, 0x69, 0x6e, 0x43, 0x5b, 0x6f, 0x42, 0xa8, 0x90, 0x29, 0x6d, 0x54, 0xc1, 0x6a, 0x85,
+	0xef, 0x94, 0xdf, 0xc0, 0xbc, 0x5d, 0x7d, 0x50, 0x11, 0xa1, 0x99, 0x05, 0x29, 0xf8, 0xff, 0x15,
+	0x88, 0x7f, 0xbe, 0x99, 0x50, 0x83, 0xd9, 0x2e, 0x5f, 0x39, 0xbf, 0xbc, 0x25, 0x59, 0xe7, 0x05,
+	0x11, 0xb4, 0xe4, 0xbc, 0xb7, 0x0f, 0x05, 0xab, 0x15, 0xbe, 0x53, 0xde, 0x82, 0xc6, 0x3e, 0xc9,
+	0xd0, 0x8a, 0x93, 0x28, 0xd6, 0xa0, 0xa0, 0x5f, 0x66, 0xfa, 0x71, 0x56, 0x0b, 0x8c, 0x17, 0x67,
+	0x7f, 0xf5, 0x09, 0x06, 0xb3, 0x6c, 0x3f, 0x49, 0x8a, 0xa5, 0xc2, 0x4b, 0x92, 0xca, 0xfa, 0x11,
+	0xdc, 0xbb, 0x12, 0xf3, 0xef, 0xac, 0xdb, 0xa6, 0x77, 0xe7, 0x52, 0x67, 0x0d, 0x56, 0x2b, 0x7c,
+	0xa7, 0xbc, 0x0b, 0x0b, 0xae, 0x9e, 0x51, 0xf1, 0x2e, 0xb3, 0x0d, 0x22, 0x08, 0xae, 0x82, 0x9c,
+	0x95, 0x7d, 0xe8, 0xf8, 0x05, 0x8b, 0xee, 0x17, 0x69, 0x5d, 0x2d, 0xf7, 0xe0, 0xc1, 0x35, 0xa8,
+	0x6f, 0xce, 0x2f, 0x68, 0xcf, 0xdc, 0x15, 0xe5, 0x1f, 0x3c, 0xb8, 0x06, 0xb5, 0xe6, 0xde, 0x7e,
+	0xff, 0xdb, 0xe6, 0x69, 0x22, 0xce, 0x26, 0x27, 0x1b, 0x11, 0x1b, 0x6f, 0x8e, 0x93, 0x88, 0x33,
+	0xf3, 0xf7, 0xe2, 0xd9, 0xa6, 0xfa, 0xff, 0xd4, 0xfe, 0x2f, 0xbb, 0x6d, 0x7e, 0x4f, 0xda, 0x8a,
+	0xfd, 0xec, 0x9f, 0x01, 0x00, 0xe6, 0xfe, 0x6f, 0xfb, 0xed, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -979,6 +2012,8 @@ const _ = grpc.SupportPackageIsVersion4
 type NetworkClient interface {
 	// Connect to the network
 	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
+	// Disconnect from nodes of the network
+	Disconnect(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*DisconnectResponse, error)
 	// Returns the entire network graph
 	Graph(ctx context.Context, in *GraphRequest, opts ...grpc.CallOption) (*GraphResponse, error)
 	// Returns a list of known nodes in the network
@@ -989,6 +2024,22 @@ type NetworkClient interface {
 	Services(ctx context.Context, in *ServicesRequest, opts ...grpc.CallOption) (*ServicesResponse, error)
 	// Status returns network status
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Rotate forces the rotation of the tunnel session keys
+	Rotate(ctx context.Context, in *RotateRequest, opts ...grpc.CallOption) (*RotateResponse, error)
+	// Map returns the nodes of the network with the latency to their peers
+	Map(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*MapResponse, error)
+	// Trace returns the path a request to a service takes through the network
+	Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (*TraceResponse, error)
+	// Partitions returns the components the network has split into
+	Partitions(ctx context.Context, in *PartitionsRequest, opts ...grpc.CallOption) (*PartitionsResponse, error)
+	// Health returns the status of the router, the peers and the adverts of the node
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// AddPolicy adds a policy allowing or denying the calls from a service to another
+	AddPolicy(ctx context.Context, in *AddPolicyRequest, opts ...grpc.CallOption) (*AddPolicyResponse, error)
+	// DeletePolicy deletes a policy
+	DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...grpc.CallOption) (*DeletePolicyResponse, error)
+	// ListPolicies returns the policies in the order they're checked
+	ListPolicies(ctx context.Context, in *ListPoliciesRequest, opts ...grpc.CallOption) (*ListPoliciesResponse, error)
 }
 
 type networkClient struct {
@@ -1008,6 +2059,15 @@ func (c *networkClient) Connect(ctx context.Context, in *ConnectRequest, opts ..
 	return out, nil
 }
 
+func (c *networkClient) Disconnect(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*DisconnectResponse, error) {
+	out := new(DisconnectResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/Disconnect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkClient) Graph(ctx context.Context, in *GraphRequest, opts ...grpc.CallOption) (*GraphResponse, error) {
 	out := new(GraphResponse)
 	err := c.cc.Invoke(ctx, "/network.Network/Graph", in, out, opts...)
@@ -1053,10 +2113,84 @@ func (c *networkClient) Status(ctx context.Context, in *StatusRequest, opts ...g
 	return out, nil
 }
 
+func (c *networkClient) Rotate(ctx context.Context, in *RotateRequest, opts ...grpc.CallOption) (*RotateResponse, error) {
+	out := new(RotateResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/Rotate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Map(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*MapResponse, error) {
+	out := new(MapResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/Map", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (*TraceResponse, error) {
+	out := new(TraceResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/Trace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Partitions(ctx context.Context, in *PartitionsRequest, opts ...grpc.CallOption) (*PartitionsResponse, error) {
+	out := new(PartitionsResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/Partitions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) AddPolicy(ctx context.Context, in *AddPolicyRequest, opts ...grpc.CallOption) (*AddPolicyResponse, error) {
+	out := new(AddPolicyResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/AddPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...grpc.CallOption) (*DeletePolicyResponse, error) {
+	out := new(DeletePolicyResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/DeletePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) ListPolicies(ctx context.Context, in *ListPoliciesRequest, opts ...grpc.CallOption) (*ListPoliciesResponse, error) {
+	out := new(ListPoliciesResponse)
+	err := c.cc.Invoke(ctx, "/network.Network/ListPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServer is the server API for Network service.
 type NetworkServer interface {
 	// Connect to the network
 	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
+	// Disconnect from nodes of the network
+	Disconnect(context.Context, *DisconnectRequest) (*DisconnectResponse, error)
 	// Returns the entire network graph
 	Graph(context.Context, *GraphRequest) (*GraphResponse, error)
 	// Returns a list of known nodes in the network
@@ -1067,6 +2201,22 @@ type NetworkServer interface {
 	Services(context.Context, *ServicesRequest) (*ServicesResponse, error)
 	// Status returns network status
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// Rotate forces the rotation of the tunnel session keys
+	Rotate(context.Context, *RotateRequest) (*RotateResponse, error)
+	// Map returns the nodes of the network with the latency to their peers
+	Map(context.Context, *MapRequest) (*MapResponse, error)
+	// Trace returns the path a request to a service takes through the network
+	Trace(context.Context, *TraceRequest) (*TraceResponse, error)
+	// Partitions returns the components the network has split into
+	Partitions(context.Context, *PartitionsRequest) (*PartitionsResponse, error)
+	// Health returns the status of the router, the peers and the adverts of the node
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	// AddPolicy adds a policy allowing or denying the calls from a service to another
+	AddPolicy(context.Context, *AddPolicyRequest) (*AddPolicyResponse, error)
+	// DeletePolicy deletes a policy
+	DeletePolicy(context.Context, *DeletePolicyRequest) (*DeletePolicyResponse, error)
+	// ListPolicies returns the policies in the order they're checked
+	ListPolicies(context.Context, *ListPoliciesRequest) (*ListPoliciesResponse, error)
 }
 
 func RegisterNetworkServer(s *grpc.Server, srv NetworkServer) {
@@ -1091,6 +2241,24 @@ func _Network_Connect_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Network_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Disconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Disconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Disconnect(ctx, req.(*DisconnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Network_Graph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GraphRequest)
 	if err := dec(in); err != nil {
@@ -1181,6 +2349,150 @@ func _Network_Status_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Network_Rotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Rotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Rotate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Rotate(ctx, req.(*RotateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Map_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Map(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Map",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Map(ctx, req.(*MapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Trace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Trace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Trace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Trace(ctx, req.(*TraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Partitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Partitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Partitions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Partitions(ctx, req.(*PartitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_AddPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).AddPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/AddPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).AddPolicy(ctx, req.(*AddPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_DeletePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).DeletePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/DeletePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).DeletePolicy(ctx, req.(*DeletePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_ListPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).ListPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/ListPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).ListPolicies(ctx, req.(*ListPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Network_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "network.Network",
 	HandlerType: (*NetworkServer)(nil),
@@ -1189,6 +2501,10 @@ var _Network_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Connect",
 			Handler:    _Network_Connect_Handler,
 		},
+		{
+			MethodName: "Disconnect",
+			Handler:    _Network_Disconnect_Handler,
+		},
 		{
 			MethodName: "Graph",
 			Handler:    _Network_Graph_Handler,
@@ -1209,6 +2525,38 @@ var _Network_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Network_Status_Handler,
 		},
+		{
+			MethodName: "Rotate",
+			Handler:    _Network_Rotate_Handler,
+		},
+		{
+			MethodName: "Map",
+			Handler:    _Network_Map_Handler,
+		},
+		{
+			MethodName: "Trace",
+			Handler:    _Network_Trace_Handler,
+		},
+		{
+			MethodName: "Partitions",
+			Handler:    _Network_Partitions_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _Network_Health_Handler,
+		},
+		{
+			MethodName: "AddPolicy",
+			Handler:    _Network_AddPolicy_Handler,
+		},
+		{
+			MethodName: "DeletePolicy",
+			Handler:    _Network_DeletePolicy_Handler,
+		},
+		{
+			MethodName: "ListPolicies",
+			Handler:    _Network_ListPolicies_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "network/network.proto",
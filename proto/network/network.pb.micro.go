@@ -45,6 +45,8 @@ func NewNetworkEndpoints() []*api.Endpoint {
 type NetworkService interface {
 	// Connect to the network
 	Connect(ctx context.Context, in *ConnectRequest, opts ...client.CallOption) (*ConnectResponse, error)
+	// Disconnect from nodes of the network
+	Disconnect(ctx context.Context, in *DisconnectRequest, opts ...client.CallOption) (*DisconnectResponse, error)
 	// Returns the entire network graph
 	Graph(ctx context.Context, in *GraphRequest, opts ...client.CallOption) (*GraphResponse, error)
 	// Returns a list of known nodes in the network
@@ -55,6 +57,22 @@ type NetworkService interface {
 	Services(ctx context.Context, in *ServicesRequest, opts ...client.CallOption) (*ServicesResponse, error)
 	// Status returns network status
 	Status(ctx context.Context, in *StatusRequest, opts ...client.CallOption) (*StatusResponse, error)
+	// Rotate forces the rotation of the tunnel session keys
+	Rotate(ctx context.Context, in *RotateRequest, opts ...client.CallOption) (*RotateResponse, error)
+	// Map returns the nodes of the network with the latency to their peers
+	Map(ctx context.Context, in *MapRequest, opts ...client.CallOption) (*MapResponse, error)
+	// Trace returns the path a request to a service takes through the network
+	Trace(ctx context.Context, in *TraceRequest, opts ...client.CallOption) (*TraceResponse, error)
+	// Partitions returns the components the network has split into
+	Partitions(ctx context.Context, in *PartitionsRequest, opts ...client.CallOption) (*PartitionsResponse, error)
+	// Health returns the status of the router, the peers and the adverts of the node
+	Health(ctx context.Context, in *HealthRequest, opts ...client.CallOption) (*HealthResponse, error)
+	// AddPolicy adds a policy allowing or denying the calls from a service to another
+	AddPolicy(ctx context.Context, in *AddPolicyRequest, opts ...client.CallOption) (*AddPolicyResponse, error)
+	// DeletePolicy deletes a policy
+	DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...client.CallOption) (*DeletePolicyResponse, error)
+	// ListPolicies returns the policies in the order they're checked
+	ListPolicies(ctx context.Context, in *ListPoliciesRequest, opts ...client.CallOption) (*ListPoliciesResponse, error)
 }
 
 type networkService struct {
@@ -79,6 +97,16 @@ func (c *networkService) Connect(ctx context.Context, in *ConnectRequest, opts .
 	return out, nil
 }
 
+func (c *networkService) Disconnect(ctx context.Context, in *DisconnectRequest, opts ...client.CallOption) (*DisconnectResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.Disconnect", in)
+	out := new(DisconnectResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkService) Graph(ctx context.Context, in *GraphRequest, opts ...client.CallOption) (*GraphResponse, error) {
 	req := c.c.NewRequest(c.name, "Network.Graph", in)
 	out := new(GraphResponse)
@@ -129,11 +157,93 @@ func (c *networkService) Status(ctx context.Context, in *StatusRequest, opts ...
 	return out, nil
 }
 
+func (c *networkService) Rotate(ctx context.Context, in *RotateRequest, opts ...client.CallOption) (*RotateResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.Rotate", in)
+	out := new(RotateResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkService) Map(ctx context.Context, in *MapRequest, opts ...client.CallOption) (*MapResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.Map", in)
+	out := new(MapResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkService) Trace(ctx context.Context, in *TraceRequest, opts ...client.CallOption) (*TraceResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.Trace", in)
+	out := new(TraceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkService) Partitions(ctx context.Context, in *PartitionsRequest, opts ...client.CallOption) (*PartitionsResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.Partitions", in)
+	out := new(PartitionsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkService) Health(ctx context.Context, in *HealthRequest, opts ...client.CallOption) (*HealthResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.Health", in)
+	out := new(HealthResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkService) AddPolicy(ctx context.Context, in *AddPolicyRequest, opts ...client.CallOption) (*AddPolicyResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.AddPolicy", in)
+	out := new(AddPolicyResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkService) DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...client.CallOption) (*DeletePolicyResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.DeletePolicy", in)
+	out := new(DeletePolicyResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkService) ListPolicies(ctx context.Context, in *ListPoliciesRequest, opts ...client.CallOption) (*ListPoliciesResponse, error) {
+	req := c.c.NewRequest(c.name, "Network.ListPolicies", in)
+	out := new(ListPoliciesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Network service
 
 type NetworkHandler interface {
 	// Connect to the network
 	Connect(context.Context, *ConnectRequest, *ConnectResponse) error
+	// Disconnect from nodes of the network
+	Disconnect(context.Context, *DisconnectRequest, *DisconnectResponse) error
 	// Returns the entire network graph
 	Graph(context.Context, *GraphRequest, *GraphResponse) error
 	// Returns a list of known nodes in the network
@@ -144,16 +254,41 @@ type NetworkHandler interface {
 	Services(context.Context, *ServicesRequest, *ServicesResponse) error
 	// Status returns network status
 	Status(context.Context, *StatusRequest, *StatusResponse) error
+	// Rotate forces the rotation of the tunnel session keys
+	Rotate(context.Context, *RotateRequest, *RotateResponse) error
+	// Map returns the nodes of the network with the latency to their peers
+	Map(context.Context, *MapRequest, *MapResponse) error
+	// Trace returns the path a request to a service takes through the network
+	Trace(context.Context, *TraceRequest, *TraceResponse) error
+	// Partitions returns the components the network has split into
+	Partitions(context.Context, *PartitionsRequest, *PartitionsResponse) error
+	// Health returns the status of the router, the peers and the adverts of the node
+	Health(context.Context, *HealthRequest, *HealthResponse) error
+	// AddPolicy adds a policy allowing or denying the calls from a service to another
+	AddPolicy(context.Context, *AddPolicyRequest, *AddPolicyResponse) error
+	// DeletePolicy deletes a policy
+	DeletePolicy(context.Context, *DeletePolicyRequest, *DeletePolicyResponse) error
+	// ListPolicies returns the policies in the order they're checked
+	ListPolicies(context.Context, *ListPoliciesRequest, *ListPoliciesResponse) error
 }
 
 func RegisterNetworkHandler(s server.Server, hdlr NetworkHandler, opts ...server.HandlerOption) error {
 	type network interface {
 		Connect(ctx context.Context, in *ConnectRequest, out *ConnectResponse) error
+		Disconnect(ctx context.Context, in *DisconnectRequest, out *DisconnectResponse) error
 		Graph(ctx context.Context, in *GraphRequest, out *GraphResponse) error
 		Nodes(ctx context.Context, in *NodesRequest, out *NodesResponse) error
 		Routes(ctx context.Context, in *RoutesRequest, out *RoutesResponse) error
 		Services(ctx context.Context, in *ServicesRequest, out *ServicesResponse) error
 		Status(ctx context.Context, in *StatusRequest, out *StatusResponse) error
+		Rotate(ctx context.Context, in *RotateRequest, out *RotateResponse) error
+		Map(ctx context.Context, in *MapRequest, out *MapResponse) error
+		Trace(ctx context.Context, in *TraceRequest, out *TraceResponse) error
+		Partitions(ctx context.Context, in *PartitionsRequest, out *PartitionsResponse) error
+		Health(ctx context.Context, in *HealthRequest, out *HealthResponse) error
+		AddPolicy(ctx context.Context, in *AddPolicyRequest, out *AddPolicyResponse) error
+		DeletePolicy(ctx context.Context, in *DeletePolicyRequest, out *DeletePolicyResponse) error
+		ListPolicies(ctx context.Context, in *ListPoliciesRequest, out *ListPoliciesResponse) error
 	}
 	type Network struct {
 		network
@@ -170,6 +305,10 @@ func (h *networkHandler) Connect(ctx context.Context, in *ConnectRequest, out *C
 	return h.NetworkHandler.Connect(ctx, in, out)
 }
 
+func (h *networkHandler) Disconnect(ctx context.Context, in *DisconnectRequest, out *DisconnectResponse) error {
+	return h.NetworkHandler.Disconnect(ctx, in, out)
+}
+
 func (h *networkHandler) Graph(ctx context.Context, in *GraphRequest, out *GraphResponse) error {
 	return h.NetworkHandler.Graph(ctx, in, out)
 }
@@ -189,3 +328,35 @@ func (h *networkHandler) Services(ctx context.Context, in *ServicesRequest, out
 func (h *networkHandler) Status(ctx context.Context, in *StatusRequest, out *StatusResponse) error {
 	return h.NetworkHandler.Status(ctx, in, out)
 }
+
+func (h *networkHandler) Rotate(ctx context.Context, in *RotateRequest, out *RotateResponse) error {
+	return h.NetworkHandler.Rotate(ctx, in, out)
+}
+
+func (h *networkHandler) Map(ctx context.Context, in *MapRequest, out *MapResponse) error {
+	return h.NetworkHandler.Map(ctx, in, out)
+}
+
+func (h *networkHandler) Trace(ctx context.Context, in *TraceRequest, out *TraceResponse) error {
+	return h.NetworkHandler.Trace(ctx, in, out)
+}
+
+func (h *networkHandler) Partitions(ctx context.Context, in *PartitionsRequest, out *PartitionsResponse) error {
+	return h.NetworkHandler.Partitions(ctx, in, out)
+}
+
+func (h *networkHandler) Health(ctx context.Context, in *HealthRequest, out *HealthResponse) error {
+	return h.NetworkHandler.Health(ctx, in, out)
+}
+
+func (h *networkHandler) AddPolicy(ctx context.Context, in *AddPolicyRequest, out *AddPolicyResponse) error {
+	return h.NetworkHandler.AddPolicy(ctx, in, out)
+}
+
+func (h *networkHandler) DeletePolicy(ctx context.Context, in *DeletePolicyRequest, out *DeletePolicyResponse) error {
+	return h.NetworkHandler.DeletePolicy(ctx, in, out)
+}
+
+func (h *networkHandler) ListPolicies(ctx context.Context, in *ListPoliciesRequest, out *ListPoliciesResponse) error {
+	return h.NetworkHandler.ListPolicies(ctx, in, out)
+}
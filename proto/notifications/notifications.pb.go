@@ -0,0 +1,666 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: notifications/notifications.proto
+
+package notifications
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Template of the subject and body of notifications, in the go text/template syntax and
+// executed with the data of the notification
+type Template struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Subject              string   `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Body                 string   `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Template) Reset()         { *m = Template{} }
+func (m *Template) String() string { return proto.CompactTextString(m) }
+func (*Template) ProtoMessage()    {}
+func (*Template) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{0}
+}
+
+func (m *Template) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Template.Unmarshal(m, b)
+}
+func (m *Template) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Template.Marshal(b, m, deterministic)
+}
+func (m *Template) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Template.Merge(m, src)
+}
+func (m *Template) XXX_Size() int {
+	return xxx_messageInfo_Template.Size(m)
+}
+func (m *Template) XXX_DiscardUnknown() {
+	xxx_messageInfo_Template.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Template proto.InternalMessageInfo
+
+func (m *Template) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Template) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *Template) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+type SendRequest struct {
+	// channel of the notification: email, sms or push
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// recipient of the notification: an email address, a phone number or a device token
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// subject and body of the notification, executed as templates with the data
+	Subject string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Body    string `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	// name of a saved template to use instead of the subject and body
+	Template string `protobuf:"bytes,5,opt,name=template,proto3" json:"template,omitempty"`
+	// data the templates are executed with
+	Data map[string]string `protobuf:"bytes,6,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// namespace of the template, defaults to the namespace of the caller
+	Namespace            string   `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendRequest) Reset()         { *m = SendRequest{} }
+func (m *SendRequest) String() string { return proto.CompactTextString(m) }
+func (*SendRequest) ProtoMessage()    {}
+func (*SendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{1}
+}
+
+func (m *SendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendRequest.Unmarshal(m, b)
+}
+func (m *SendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendRequest.Marshal(b, m, deterministic)
+}
+func (m *SendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendRequest.Merge(m, src)
+}
+func (m *SendRequest) XXX_Size() int {
+	return xxx_messageInfo_SendRequest.Size(m)
+}
+func (m *SendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendRequest proto.InternalMessageInfo
+
+func (m *SendRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *SendRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *SendRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *SendRequest) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *SendRequest) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+func (m *SendRequest) GetData() map[string]string {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SendRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type SendResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendResponse) Reset()         { *m = SendResponse{} }
+func (m *SendResponse) String() string { return proto.CompactTextString(m) }
+func (*SendResponse) ProtoMessage()    {}
+func (*SendResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{2}
+}
+
+func (m *SendResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendResponse.Unmarshal(m, b)
+}
+func (m *SendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendResponse.Marshal(b, m, deterministic)
+}
+func (m *SendResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendResponse.Merge(m, src)
+}
+func (m *SendResponse) XXX_Size() int {
+	return xxx_messageInfo_SendResponse.Size(m)
+}
+func (m *SendResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendResponse proto.InternalMessageInfo
+
+type SaveTemplateRequest struct {
+	Template             *Template `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	Namespace            string    `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SaveTemplateRequest) Reset()         { *m = SaveTemplateRequest{} }
+func (m *SaveTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*SaveTemplateRequest) ProtoMessage()    {}
+func (*SaveTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{3}
+}
+
+func (m *SaveTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveTemplateRequest.Unmarshal(m, b)
+}
+func (m *SaveTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *SaveTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveTemplateRequest.Merge(m, src)
+}
+func (m *SaveTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_SaveTemplateRequest.Size(m)
+}
+func (m *SaveTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveTemplateRequest proto.InternalMessageInfo
+
+func (m *SaveTemplateRequest) GetTemplate() *Template {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+func (m *SaveTemplateRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type SaveTemplateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SaveTemplateResponse) Reset()         { *m = SaveTemplateResponse{} }
+func (m *SaveTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*SaveTemplateResponse) ProtoMessage()    {}
+func (*SaveTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{4}
+}
+
+func (m *SaveTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveTemplateResponse.Unmarshal(m, b)
+}
+func (m *SaveTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *SaveTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveTemplateResponse.Merge(m, src)
+}
+func (m *SaveTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_SaveTemplateResponse.Size(m)
+}
+func (m *SaveTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveTemplateResponse proto.InternalMessageInfo
+
+type DeleteTemplateRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTemplateRequest) Reset()         { *m = DeleteTemplateRequest{} }
+func (m *DeleteTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteTemplateRequest) ProtoMessage()    {}
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{5}
+}
+
+func (m *DeleteTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTemplateRequest.Unmarshal(m, b)
+}
+func (m *DeleteTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTemplateRequest.Merge(m, src)
+}
+func (m *DeleteTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteTemplateRequest.Size(m)
+}
+func (m *DeleteTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTemplateRequest proto.InternalMessageInfo
+
+func (m *DeleteTemplateRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteTemplateRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type DeleteTemplateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTemplateResponse) Reset()         { *m = DeleteTemplateResponse{} }
+func (m *DeleteTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteTemplateResponse) ProtoMessage()    {}
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{6}
+}
+
+func (m *DeleteTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTemplateResponse.Unmarshal(m, b)
+}
+func (m *DeleteTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTemplateResponse.Merge(m, src)
+}
+func (m *DeleteTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteTemplateResponse.Size(m)
+}
+func (m *DeleteTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTemplateResponse proto.InternalMessageInfo
+
+type ListTemplatesRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTemplatesRequest) Reset()         { *m = ListTemplatesRequest{} }
+func (m *ListTemplatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTemplatesRequest) ProtoMessage()    {}
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{7}
+}
+
+func (m *ListTemplatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTemplatesRequest.Unmarshal(m, b)
+}
+func (m *ListTemplatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTemplatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTemplatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTemplatesRequest.Merge(m, src)
+}
+func (m *ListTemplatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTemplatesRequest.Size(m)
+}
+func (m *ListTemplatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTemplatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTemplatesRequest proto.InternalMessageInfo
+
+func (m *ListTemplatesRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ListTemplatesResponse struct {
+	Templates            []*Template `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListTemplatesResponse) Reset()         { *m = ListTemplatesResponse{} }
+func (m *ListTemplatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTemplatesResponse) ProtoMessage()    {}
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3194dcd8aab024a9, []int{8}
+}
+
+func (m *ListTemplatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTemplatesResponse.Unmarshal(m, b)
+}
+func (m *ListTemplatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTemplatesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTemplatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTemplatesResponse.Merge(m, src)
+}
+func (m *ListTemplatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTemplatesResponse.Size(m)
+}
+func (m *ListTemplatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTemplatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTemplatesResponse proto.InternalMessageInfo
+
+func (m *ListTemplatesResponse) GetTemplates() []*Template {
+	if m != nil {
+		return m.Templates
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Template)(nil), "notifications.Template")
+	proto.RegisterType((*SendRequest)(nil), "notifications.SendRequest")
+	proto.RegisterMapType((map[string]string)(nil), "notifications.SendRequest.DataEntry")
+	proto.RegisterType((*SendResponse)(nil), "notifications.SendResponse")
+	proto.RegisterType((*SaveTemplateRequest)(nil), "notifications.SaveTemplateRequest")
+	proto.RegisterType((*SaveTemplateResponse)(nil), "notifications.SaveTemplateResponse")
+	proto.RegisterType((*DeleteTemplateRequest)(nil), "notifications.DeleteTemplateRequest")
+	proto.RegisterType((*DeleteTemplateResponse)(nil), "notifications.DeleteTemplateResponse")
+	proto.RegisterType((*ListTemplatesRequest)(nil), "notifications.ListTemplatesRequest")
+	proto.RegisterType((*ListTemplatesResponse)(nil), "notifications.ListTemplatesResponse")
+}
+
+func init() { proto.RegisterFile("notifications/notifications.proto", fileDescriptor_3194dcd8aab024a9) }
+
+var fileDescriptor_3194dcd8aab024a9 = []byte{
+	// 469 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x4d, 0x8f, 0xd3, 0x30,
+	0x10, 0x25, 0x49, 0xf7, 0xa3, 0xd3, 0x6d, 0x85, 0x86, 0xee, 0x62, 0x05, 0x0e, 0x25, 0x2c, 0xd2,
+	0x9e, 0x5a, 0xa9, 0x05, 0xb1, 0x62, 0xc5, 0x01, 0xb4, 0x1c, 0x90, 0x56, 0x7b, 0xe8, 0x72, 0x01,
+	0x21, 0x21, 0x37, 0x35, 0x34, 0xd0, 0xda, 0xa5, 0x76, 0x2b, 0xf5, 0x6f, 0xf0, 0x3b, 0xf9, 0x11,
+	0x28, 0xa9, 0x9d, 0xc4, 0x6e, 0xc8, 0x5e, 0x22, 0xcf, 0xe4, 0xcd, 0x7b, 0x6f, 0x66, 0x2c, 0xc3,
+	0x33, 0x2e, 0x54, 0xf2, 0x3d, 0x89, 0xa9, 0x4a, 0x04, 0x97, 0x03, 0x2b, 0xea, 0x2f, 0x57, 0x42,
+	0x09, 0x6c, 0x5b, 0xc9, 0xe8, 0x06, 0x8e, 0x3f, 0xb1, 0xc5, 0x72, 0x4e, 0x15, 0x43, 0x84, 0x06,
+	0xa7, 0x0b, 0x46, 0xbc, 0x9e, 0x77, 0xd1, 0x1c, 0x67, 0x67, 0x24, 0x70, 0x24, 0xd7, 0x93, 0x9f,
+	0x2c, 0x56, 0xc4, 0xcf, 0xd2, 0x26, 0x4c, 0xd1, 0x13, 0x31, 0xdd, 0x92, 0x60, 0x87, 0x4e, 0xcf,
+	0xd1, 0x1f, 0x1f, 0x5a, 0x77, 0x8c, 0x4f, 0xc7, 0xec, 0xf7, 0x9a, 0x49, 0x95, 0x56, 0xc7, 0x33,
+	0xca, 0x39, 0x9b, 0x6b, 0x52, 0x13, 0x62, 0x07, 0x7c, 0x25, 0x34, 0xa5, 0xaf, 0x44, 0x59, 0x27,
+	0xa8, 0xd6, 0x69, 0x14, 0x3a, 0x18, 0xc2, 0xb1, 0xd2, 0xae, 0xc9, 0x41, 0x96, 0xcf, 0x63, 0xbc,
+	0x84, 0xc6, 0x94, 0x2a, 0x4a, 0x0e, 0x7b, 0xc1, 0x45, 0x6b, 0x78, 0xde, 0xb7, 0x87, 0x50, 0x72,
+	0xd7, 0xbf, 0xa6, 0x8a, 0x7e, 0xe0, 0x6a, 0xb5, 0x1d, 0x67, 0x15, 0xf8, 0x14, 0x9a, 0x69, 0xcf,
+	0x72, 0x49, 0x63, 0x46, 0x8e, 0x32, 0xda, 0x22, 0x11, 0xbe, 0x86, 0x66, 0x5e, 0x80, 0x0f, 0x21,
+	0xf8, 0xc5, 0xb6, 0xba, 0xa9, 0xf4, 0x88, 0x5d, 0x38, 0xd8, 0xd0, 0xf9, 0x9a, 0xe9, 0x9e, 0x76,
+	0xc1, 0x1b, 0xff, 0xd2, 0x8b, 0x3a, 0x70, 0xb2, 0x53, 0x95, 0x4b, 0xc1, 0x25, 0x8b, 0x66, 0xf0,
+	0xe8, 0x8e, 0x6e, 0x98, 0x19, 0xbb, 0x99, 0xd5, 0xa8, 0xd4, 0x53, 0xca, 0xdb, 0x1a, 0x3e, 0x76,
+	0xbc, 0xe7, 0x15, 0x45, 0xb3, 0x96, 0x65, 0xdf, 0xb1, 0x1c, 0x9d, 0x41, 0xd7, 0x56, 0xd2, 0x0e,
+	0x3e, 0xc2, 0xe9, 0x35, 0x9b, 0x33, 0xb5, 0xe7, 0xa1, 0xea, 0x06, 0xd4, 0x4b, 0x10, 0x38, 0x73,
+	0xa9, 0xb4, 0xc8, 0x4b, 0xe8, 0xde, 0x24, 0x52, 0x99, 0xbc, 0x34, 0x1a, 0x16, 0x9f, 0xe7, 0xf2,
+	0xdd, 0xc2, 0xa9, 0x53, 0xb5, 0xa3, 0xc3, 0x57, 0xd0, 0x34, 0x5d, 0x4b, 0xe2, 0xf5, 0x82, 0xba,
+	0xf9, 0x14, 0xc8, 0xe1, 0x5f, 0x1f, 0xda, 0xb7, 0x65, 0x14, 0xbe, 0x83, 0x46, 0xba, 0x0e, 0x0c,
+	0xff, 0x7f, 0x33, 0xc2, 0x27, 0x95, 0xff, 0x74, 0x63, 0x0f, 0xf0, 0x33, 0x9c, 0x94, 0xe7, 0x8a,
+	0x91, 0x0b, 0xdf, 0x5f, 0x6f, 0xf8, 0xbc, 0x16, 0x93, 0x53, 0x7f, 0x83, 0x8e, 0x3d, 0x4f, 0x74,
+	0x6f, 0x70, 0xe5, 0xe6, 0xc2, 0x17, 0xf7, 0xa0, 0x72, 0x81, 0xaf, 0xd0, 0xb6, 0x06, 0x8c, 0xae,
+	0xb1, 0xaa, 0xa5, 0x85, 0xe7, 0xf5, 0x20, 0xc3, 0xfe, 0xfe, 0xed, 0x97, 0xab, 0x1f, 0x89, 0x9a,
+	0xad, 0x27, 0xfd, 0x58, 0x2c, 0x06, 0x8b, 0x24, 0x5e, 0x09, 0xfd, 0xdd, 0x8c, 0x06, 0xd9, 0x03,
+	0x64, 0x3f, 0x4a, 0x57, 0x56, 0x34, 0x39, 0xcc, 0x20, 0xa3, 0x7f, 0x03, 0x00, 0x29, 0xa6, 0x35,
+	0x43, 0xc8, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NotificationsClient is the client API for Notifications service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NotificationsClient interface {
+	// Send a notification using the provider of its channel
+	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// SaveTemplate creates or updates a template of the notifications
+	SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*SaveTemplateResponse, error)
+	// DeleteTemplate deletes a template
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	// ListTemplates returns the templates
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+}
+
+type notificationsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNotificationsClient(cc *grpc.ClientConn) NotificationsClient {
+	return &notificationsClient{cc}
+}
+
+func (c *notificationsClient) Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := c.cc.Invoke(ctx, "/notifications.Notifications/Send", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationsClient) SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*SaveTemplateResponse, error) {
+	out := new(SaveTemplateResponse)
+	err := c.cc.Invoke(ctx, "/notifications.Notifications/SaveTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationsClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, "/notifications.Notifications/DeleteTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationsClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/notifications.Notifications/ListTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationsServer is the server API for Notifications service.
+type NotificationsServer interface {
+	// Send a notification using the provider of its channel
+	Send(context.Context, *SendRequest) (*SendResponse, error)
+	// SaveTemplate creates or updates a template of the notifications
+	SaveTemplate(context.Context, *SaveTemplateRequest) (*SaveTemplateResponse, error)
+	// DeleteTemplate deletes a template
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	// ListTemplates returns the templates
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+}
+
+func RegisterNotificationsServer(s *grpc.Server, srv NotificationsServer) {
+	s.RegisterService(&_Notifications_serviceDesc, srv)
+}
+
+func _Notifications_Send_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).Send(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notifications.Notifications/Send",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).Send(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifications_SaveTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).SaveTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notifications.Notifications/SaveTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).SaveTemplate(ctx, req.(*SaveTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifications_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notifications.Notifications/DeleteTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifications_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notifications.Notifications/ListTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Notifications_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "notifications.Notifications",
+	HandlerType: (*NotificationsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Send",
+			Handler:    _Notifications_Send_Handler,
+		},
+		{
+			MethodName: "SaveTemplate",
+			Handler:    _Notifications_SaveTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _Notifications_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _Notifications_ListTemplates_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "notifications/notifications.proto",
+}
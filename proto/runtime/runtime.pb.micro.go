@@ -47,6 +47,7 @@ type RuntimeService interface {
 	Delete(ctx context.Context, in *DeleteRequest, opts ...client.CallOption) (*DeleteResponse, error)
 	Update(ctx context.Context, in *UpdateRequest, opts ...client.CallOption) (*UpdateResponse, error)
 	Logs(ctx context.Context, in *LogsRequest, opts ...client.CallOption) (Runtime_LogsService, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...client.CallOption) (Runtime_WatchService, error)
 }
 
 type runtimeService struct {
@@ -150,6 +151,55 @@ func (x *runtimeServiceLogs) Recv() (*LogRecord, error) {
 	return m, nil
 }
 
+func (c *runtimeService) Watch(ctx context.Context, in *WatchRequest, opts ...client.CallOption) (Runtime_WatchService, error) {
+	req := c.c.NewRequest(c.name, "Runtime.Watch", &WatchRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &runtimeServiceWatch{stream}, nil
+}
+
+type Runtime_WatchService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*Event, error)
+}
+
+type runtimeServiceWatch struct {
+	stream client.Stream
+}
+
+func (x *runtimeServiceWatch) Close() error {
+	return x.stream.Close()
+}
+
+func (x *runtimeServiceWatch) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *runtimeServiceWatch) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *runtimeServiceWatch) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *runtimeServiceWatch) Recv() (*Event, error) {
+	m := new(Event)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Runtime service
 
 type RuntimeHandler interface {
@@ -158,6 +208,7 @@ type RuntimeHandler interface {
 	Delete(context.Context, *DeleteRequest, *DeleteResponse) error
 	Update(context.Context, *UpdateRequest, *UpdateResponse) error
 	Logs(context.Context, *LogsRequest, Runtime_LogsStream) error
+	Watch(context.Context, *WatchRequest, Runtime_WatchStream) error
 }
 
 func RegisterRuntimeHandler(s server.Server, hdlr RuntimeHandler, opts ...server.HandlerOption) error {
@@ -167,6 +218,7 @@ func RegisterRuntimeHandler(s server.Server, hdlr RuntimeHandler, opts ...server
 		Delete(ctx context.Context, in *DeleteRequest, out *DeleteResponse) error
 		Update(ctx context.Context, in *UpdateRequest, out *UpdateResponse) error
 		Logs(ctx context.Context, stream server.Stream) error
+		Watch(ctx context.Context, stream server.Stream) error
 	}
 	type Runtime struct {
 		runtime
@@ -235,6 +287,46 @@ func (x *runtimeLogsStream) Send(m *LogRecord) error {
 	return x.stream.Send(m)
 }
 
+func (h *runtimeHandler) Watch(ctx context.Context, stream server.Stream) error {
+	m := new(WatchRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.RuntimeHandler.Watch(ctx, m, &runtimeWatchStream{stream})
+}
+
+type Runtime_WatchStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*Event) error
+}
+
+type runtimeWatchStream struct {
+	stream server.Stream
+}
+
+func (x *runtimeWatchStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *runtimeWatchStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *runtimeWatchStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *runtimeWatchStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *runtimeWatchStream) Send(m *Event) error {
+	return x.stream.Send(m)
+}
+
 // Api Endpoints for Source service
 
 func NewSourceEndpoints() []*api.Endpoint {
@@ -502,3 +594,281 @@ func (x *buildReadStream) RecvMsg(m interface{}) error {
 func (x *buildReadStream) Send(m *BuildReadResponse) error {
 	return x.stream.Send(m)
 }
+
+// Api Endpoints for Maintenance service
+
+func NewMaintenanceEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Maintenance service
+
+type MaintenanceService interface {
+	Enable(ctx context.Context, in *EnableMaintenanceRequest, opts ...client.CallOption) (*EnableMaintenanceResponse, error)
+	Disable(ctx context.Context, in *DisableMaintenanceRequest, opts ...client.CallOption) (*DisableMaintenanceResponse, error)
+	List(ctx context.Context, in *ListMaintenanceRequest, opts ...client.CallOption) (*ListMaintenanceResponse, error)
+}
+
+type maintenanceService struct {
+	c    client.Client
+	name string
+}
+
+func NewMaintenanceService(name string, c client.Client) MaintenanceService {
+	return &maintenanceService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *maintenanceService) Enable(ctx context.Context, in *EnableMaintenanceRequest, opts ...client.CallOption) (*EnableMaintenanceResponse, error) {
+	req := c.c.NewRequest(c.name, "Maintenance.Enable", in)
+	out := new(EnableMaintenanceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maintenanceService) Disable(ctx context.Context, in *DisableMaintenanceRequest, opts ...client.CallOption) (*DisableMaintenanceResponse, error) {
+	req := c.c.NewRequest(c.name, "Maintenance.Disable", in)
+	out := new(DisableMaintenanceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maintenanceService) List(ctx context.Context, in *ListMaintenanceRequest, opts ...client.CallOption) (*ListMaintenanceResponse, error) {
+	req := c.c.NewRequest(c.name, "Maintenance.List", in)
+	out := new(ListMaintenanceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Maintenance service
+
+type MaintenanceHandler interface {
+	Enable(context.Context, *EnableMaintenanceRequest, *EnableMaintenanceResponse) error
+	Disable(context.Context, *DisableMaintenanceRequest, *DisableMaintenanceResponse) error
+	List(context.Context, *ListMaintenanceRequest, *ListMaintenanceResponse) error
+}
+
+func RegisterMaintenanceHandler(s server.Server, hdlr MaintenanceHandler, opts ...server.HandlerOption) error {
+	type maintenance interface {
+		Enable(ctx context.Context, in *EnableMaintenanceRequest, out *EnableMaintenanceResponse) error
+		Disable(ctx context.Context, in *DisableMaintenanceRequest, out *DisableMaintenanceResponse) error
+		List(ctx context.Context, in *ListMaintenanceRequest, out *ListMaintenanceResponse) error
+	}
+	type Maintenance struct {
+		maintenance
+	}
+	h := &maintenanceHandler{hdlr}
+	return s.Handle(s.NewHandler(&Maintenance{h}, opts...))
+}
+
+type maintenanceHandler struct {
+	MaintenanceHandler
+}
+
+func (h *maintenanceHandler) Enable(ctx context.Context, in *EnableMaintenanceRequest, out *EnableMaintenanceResponse) error {
+	return h.MaintenanceHandler.Enable(ctx, in, out)
+}
+
+func (h *maintenanceHandler) Disable(ctx context.Context, in *DisableMaintenanceRequest, out *DisableMaintenanceResponse) error {
+	return h.MaintenanceHandler.Disable(ctx, in, out)
+}
+
+func (h *maintenanceHandler) List(ctx context.Context, in *ListMaintenanceRequest, out *ListMaintenanceResponse) error {
+	return h.MaintenanceHandler.List(ctx, in, out)
+}
+
+// Api Endpoints for Artifact service
+
+func NewArtifactEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Artifact service
+
+type ArtifactService interface {
+	Push(ctx context.Context, opts ...client.CallOption) (Artifact_PushService, error)
+	Read(ctx context.Context, in *ReadArtifactRequest, opts ...client.CallOption) (*ReadArtifactResponse, error)
+	List(ctx context.Context, in *ListArtifactsRequest, opts ...client.CallOption) (*ListArtifactsResponse, error)
+	Delete(ctx context.Context, in *DeleteArtifactRequest, opts ...client.CallOption) (*DeleteArtifactResponse, error)
+}
+
+type artifactService struct {
+	c    client.Client
+	name string
+}
+
+func NewArtifactService(name string, c client.Client) ArtifactService {
+	return &artifactService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *artifactService) Push(ctx context.Context, opts ...client.CallOption) (Artifact_PushService, error) {
+	req := c.c.NewRequest(c.name, "Artifact.Push", &PushArtifactRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &artifactServicePush{stream}, nil
+}
+
+type Artifact_PushService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseAndRecv() (*PushArtifactResponse, error)
+	Send(*PushArtifactRequest) error
+}
+
+type artifactServicePush struct {
+	stream client.Stream
+}
+
+func (x *artifactServicePush) CloseAndRecv() (*PushArtifactResponse, error) {
+	if err := x.stream.Close(); err != nil {
+		return nil, err
+	}
+	r := new(PushArtifactResponse)
+	err := x.RecvMsg(r)
+	return r, err
+}
+
+func (x *artifactServicePush) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *artifactServicePush) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *artifactServicePush) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *artifactServicePush) Send(m *PushArtifactRequest) error {
+	return x.stream.Send(m)
+}
+
+func (c *artifactService) Read(ctx context.Context, in *ReadArtifactRequest, opts ...client.CallOption) (*ReadArtifactResponse, error) {
+	req := c.c.NewRequest(c.name, "Artifact.Read", in)
+	out := new(ReadArtifactResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artifactService) List(ctx context.Context, in *ListArtifactsRequest, opts ...client.CallOption) (*ListArtifactsResponse, error) {
+	req := c.c.NewRequest(c.name, "Artifact.List", in)
+	out := new(ListArtifactsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artifactService) Delete(ctx context.Context, in *DeleteArtifactRequest, opts ...client.CallOption) (*DeleteArtifactResponse, error) {
+	req := c.c.NewRequest(c.name, "Artifact.Delete", in)
+	out := new(DeleteArtifactResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Artifact service
+
+type ArtifactHandler interface {
+	Push(context.Context, Artifact_PushStream) error
+	Read(context.Context, *ReadArtifactRequest, *ReadArtifactResponse) error
+	List(context.Context, *ListArtifactsRequest, *ListArtifactsResponse) error
+	Delete(context.Context, *DeleteArtifactRequest, *DeleteArtifactResponse) error
+}
+
+func RegisterArtifactHandler(s server.Server, hdlr ArtifactHandler, opts ...server.HandlerOption) error {
+	type artifact interface {
+		Push(ctx context.Context, stream server.Stream) error
+		Read(ctx context.Context, in *ReadArtifactRequest, out *ReadArtifactResponse) error
+		List(ctx context.Context, in *ListArtifactsRequest, out *ListArtifactsResponse) error
+		Delete(ctx context.Context, in *DeleteArtifactRequest, out *DeleteArtifactResponse) error
+	}
+	type Artifact struct {
+		artifact
+	}
+	h := &artifactHandler{hdlr}
+	return s.Handle(s.NewHandler(&Artifact{h}, opts...))
+}
+
+type artifactHandler struct {
+	ArtifactHandler
+}
+
+func (h *artifactHandler) Push(ctx context.Context, stream server.Stream) error {
+	return h.ArtifactHandler.Push(ctx, &artifactPushStream{stream})
+}
+
+type Artifact_PushStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	SendAndClose(*PushArtifactResponse) error
+	Recv() (*PushArtifactRequest, error)
+}
+
+type artifactPushStream struct {
+	stream server.Stream
+}
+
+func (x *artifactPushStream) SendAndClose(in *PushArtifactResponse) error {
+	if err := x.SendMsg(in); err != nil {
+		return err
+	}
+	return x.stream.Close()
+}
+
+func (x *artifactPushStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *artifactPushStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *artifactPushStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *artifactPushStream) Recv() (*PushArtifactRequest, error) {
+	m := new(PushArtifactRequest)
+	if err := x.stream.Recv(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (h *artifactHandler) Read(ctx context.Context, in *ReadArtifactRequest, out *ReadArtifactResponse) error {
+	return h.ArtifactHandler.Read(ctx, in, out)
+}
+
+func (h *artifactHandler) List(ctx context.Context, in *ListArtifactsRequest, out *ListArtifactsResponse) error {
+	return h.ArtifactHandler.List(ctx, in, out)
+}
+
+func (h *artifactHandler) Delete(ctx context.Context, in *DeleteArtifactRequest, out *DeleteArtifactResponse) error {
+	return h.ArtifactHandler.Delete(ctx, in, out)
+}
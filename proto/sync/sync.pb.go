@@ -0,0 +1,694 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: sync/sync.proto
+
+package sync
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Lease is a lock held until it's released or expires
+type Lease struct {
+	// id of the lock
+	Lock string `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	// id of the lease
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// holder of the lease e.g. the id of the node
+	Holder string `protobuf:"bytes,3,opt,name=holder,proto3" json:"holder,omitempty"`
+	// fencing token, increases with each lease of the lock
+	Token int64 `protobuf:"varint,4,opt,name=token,proto3" json:"token,omitempty"`
+	// unix nano timestamp the lease expires at unless renewed
+	Expiry               int64    `protobuf:"varint,5,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Lease) Reset()         { *m = Lease{} }
+func (m *Lease) String() string { return proto.CompactTextString(m) }
+func (*Lease) ProtoMessage()    {}
+func (*Lease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{0}
+}
+
+func (m *Lease) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lease.Unmarshal(m, b)
+}
+func (m *Lease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lease.Marshal(b, m, deterministic)
+}
+func (m *Lease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lease.Merge(m, src)
+}
+func (m *Lease) XXX_Size() int {
+	return xxx_messageInfo_Lease.Size(m)
+}
+func (m *Lease) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lease proto.InternalMessageInfo
+
+func (m *Lease) GetLock() string {
+	if m != nil {
+		return m.Lock
+	}
+	return ""
+}
+
+func (m *Lease) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Lease) GetHolder() string {
+	if m != nil {
+		return m.Holder
+	}
+	return ""
+}
+
+func (m *Lease) GetToken() int64 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+func (m *Lease) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+type LockRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ttl of the lease in milliseconds
+	Ttl int64 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// how long to wait for the lock to be released in milliseconds, 0 to fail if it's held
+	Wait   int64  `protobuf:"varint,3,opt,name=wait,proto3" json:"wait,omitempty"`
+	Holder string `protobuf:"bytes,4,opt,name=holder,proto3" json:"holder,omitempty"`
+	// namespace of the lock, defaults to the namespace of the caller
+	Namespace            string   `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockRequest) Reset()         { *m = LockRequest{} }
+func (m *LockRequest) String() string { return proto.CompactTextString(m) }
+func (*LockRequest) ProtoMessage()    {}
+func (*LockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{1}
+}
+
+func (m *LockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockRequest.Unmarshal(m, b)
+}
+func (m *LockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockRequest.Marshal(b, m, deterministic)
+}
+func (m *LockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockRequest.Merge(m, src)
+}
+func (m *LockRequest) XXX_Size() int {
+	return xxx_messageInfo_LockRequest.Size(m)
+}
+func (m *LockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockRequest proto.InternalMessageInfo
+
+func (m *LockRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LockRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *LockRequest) GetWait() int64 {
+	if m != nil {
+		return m.Wait
+	}
+	return 0
+}
+
+func (m *LockRequest) GetHolder() string {
+	if m != nil {
+		return m.Holder
+	}
+	return ""
+}
+
+func (m *LockRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type LockResponse struct {
+	Lease                *Lease   `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockResponse) Reset()         { *m = LockResponse{} }
+func (m *LockResponse) String() string { return proto.CompactTextString(m) }
+func (*LockResponse) ProtoMessage()    {}
+func (*LockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{2}
+}
+
+func (m *LockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockResponse.Unmarshal(m, b)
+}
+func (m *LockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockResponse.Marshal(b, m, deterministic)
+}
+func (m *LockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockResponse.Merge(m, src)
+}
+func (m *LockResponse) XXX_Size() int {
+	return xxx_messageInfo_LockResponse.Size(m)
+}
+func (m *LockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockResponse proto.InternalMessageInfo
+
+func (m *LockResponse) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+type RenewRequest struct {
+	Lease *Lease `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	// ttl of the lease in milliseconds
+	Ttl                  int64    `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenewRequest) Reset()         { *m = RenewRequest{} }
+func (m *RenewRequest) String() string { return proto.CompactTextString(m) }
+func (*RenewRequest) ProtoMessage()    {}
+func (*RenewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{3}
+}
+
+func (m *RenewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewRequest.Unmarshal(m, b)
+}
+func (m *RenewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewRequest.Marshal(b, m, deterministic)
+}
+func (m *RenewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewRequest.Merge(m, src)
+}
+func (m *RenewRequest) XXX_Size() int {
+	return xxx_messageInfo_RenewRequest.Size(m)
+}
+func (m *RenewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewRequest proto.InternalMessageInfo
+
+func (m *RenewRequest) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+func (m *RenewRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *RenewRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type RenewResponse struct {
+	Lease                *Lease   `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenewResponse) Reset()         { *m = RenewResponse{} }
+func (m *RenewResponse) String() string { return proto.CompactTextString(m) }
+func (*RenewResponse) ProtoMessage()    {}
+func (*RenewResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{4}
+}
+
+func (m *RenewResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewResponse.Unmarshal(m, b)
+}
+func (m *RenewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewResponse.Marshal(b, m, deterministic)
+}
+func (m *RenewResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewResponse.Merge(m, src)
+}
+func (m *RenewResponse) XXX_Size() int {
+	return xxx_messageInfo_RenewResponse.Size(m)
+}
+func (m *RenewResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewResponse proto.InternalMessageInfo
+
+func (m *RenewResponse) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+type UnlockRequest struct {
+	Lease                *Lease   `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockRequest) Reset()         { *m = UnlockRequest{} }
+func (m *UnlockRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlockRequest) ProtoMessage()    {}
+func (*UnlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{5}
+}
+
+func (m *UnlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockRequest.Unmarshal(m, b)
+}
+func (m *UnlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockRequest.Marshal(b, m, deterministic)
+}
+func (m *UnlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockRequest.Merge(m, src)
+}
+func (m *UnlockRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlockRequest.Size(m)
+}
+func (m *UnlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockRequest proto.InternalMessageInfo
+
+func (m *UnlockRequest) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+func (m *UnlockRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type UnlockResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockResponse) Reset()         { *m = UnlockResponse{} }
+func (m *UnlockResponse) String() string { return proto.CompactTextString(m) }
+func (*UnlockResponse) ProtoMessage()    {}
+func (*UnlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{6}
+}
+
+func (m *UnlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockResponse.Unmarshal(m, b)
+}
+func (m *UnlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockResponse.Marshal(b, m, deterministic)
+}
+func (m *UnlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockResponse.Merge(m, src)
+}
+func (m *UnlockResponse) XXX_Size() int {
+	return xxx_messageInfo_UnlockResponse.Size(m)
+}
+func (m *UnlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockResponse proto.InternalMessageInfo
+
+type HolderRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HolderRequest) Reset()         { *m = HolderRequest{} }
+func (m *HolderRequest) String() string { return proto.CompactTextString(m) }
+func (*HolderRequest) ProtoMessage()    {}
+func (*HolderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{7}
+}
+
+func (m *HolderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HolderRequest.Unmarshal(m, b)
+}
+func (m *HolderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HolderRequest.Marshal(b, m, deterministic)
+}
+func (m *HolderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HolderRequest.Merge(m, src)
+}
+func (m *HolderRequest) XXX_Size() int {
+	return xxx_messageInfo_HolderRequest.Size(m)
+}
+func (m *HolderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HolderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HolderRequest proto.InternalMessageInfo
+
+func (m *HolderRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *HolderRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type HolderResponse struct {
+	Lease                *Lease   `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HolderResponse) Reset()         { *m = HolderResponse{} }
+func (m *HolderResponse) String() string { return proto.CompactTextString(m) }
+func (*HolderResponse) ProtoMessage()    {}
+func (*HolderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_221a5c59bc60326f, []int{8}
+}
+
+func (m *HolderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HolderResponse.Unmarshal(m, b)
+}
+func (m *HolderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HolderResponse.Marshal(b, m, deterministic)
+}
+func (m *HolderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HolderResponse.Merge(m, src)
+}
+func (m *HolderResponse) XXX_Size() int {
+	return xxx_messageInfo_HolderResponse.Size(m)
+}
+func (m *HolderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HolderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HolderResponse proto.InternalMessageInfo
+
+func (m *HolderResponse) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Lease)(nil), "sync.Lease")
+	proto.RegisterType((*LockRequest)(nil), "sync.LockRequest")
+	proto.RegisterType((*LockResponse)(nil), "sync.LockResponse")
+	proto.RegisterType((*RenewRequest)(nil), "sync.RenewRequest")
+	proto.RegisterType((*RenewResponse)(nil), "sync.RenewResponse")
+	proto.RegisterType((*UnlockRequest)(nil), "sync.UnlockRequest")
+	proto.RegisterType((*UnlockResponse)(nil), "sync.UnlockResponse")
+	proto.RegisterType((*HolderRequest)(nil), "sync.HolderRequest")
+	proto.RegisterType((*HolderResponse)(nil), "sync.HolderResponse")
+}
+
+func init() { proto.RegisterFile("sync/sync.proto", fileDescriptor_221a5c59bc60326f) }
+
+var fileDescriptor_221a5c59bc60326f = []byte{
+	// 391 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x3b, 0x6f, 0xea, 0x30,
+	0x14, 0xbe, 0x79, 0x21, 0x71, 0x78, 0x5c, 0xae, 0x2f, 0xaa, 0xa2, 0xa8, 0x03, 0xcd, 0x44, 0x55,
+	0x89, 0xa8, 0x41, 0x9d, 0xaa, 0x2e, 0x9d, 0x3a, 0x30, 0x54, 0xae, 0xba, 0x74, 0x0b, 0xc1, 0x2a,
+	0x11, 0x21, 0x0e, 0x49, 0x28, 0xcd, 0x4f, 0xed, 0xbf, 0xa9, 0x7c, 0xe2, 0x40, 0x8c, 0xfa, 0x60,
+	0xb1, 0x8e, 0x3f, 0xfb, 0xf8, 0x7b, 0x1c, 0x19, 0xfe, 0xe6, 0x65, 0x12, 0x7a, 0x62, 0x99, 0xa4,
+	0x19, 0x2f, 0x38, 0x31, 0x45, 0xed, 0x6e, 0xc0, 0x9a, 0xb1, 0x20, 0x67, 0x84, 0x80, 0x19, 0xf3,
+	0x70, 0x65, 0x6b, 0x23, 0x6d, 0xdc, 0xa6, 0x58, 0x93, 0x3e, 0xe8, 0xd1, 0xc2, 0xd6, 0x11, 0xd1,
+	0xa3, 0x05, 0x39, 0x83, 0xd6, 0x92, 0xc7, 0x0b, 0x96, 0xd9, 0x06, 0x62, 0x72, 0x47, 0x86, 0x60,
+	0x15, 0x7c, 0xc5, 0x12, 0xdb, 0x1c, 0x69, 0x63, 0x83, 0x56, 0x1b, 0x71, 0x9b, 0xbd, 0xa7, 0x51,
+	0x56, 0xda, 0x16, 0xc2, 0x72, 0xe7, 0x96, 0xd0, 0x99, 0xf1, 0x70, 0x45, 0xd9, 0x66, 0xcb, 0xf2,
+	0x42, 0x92, 0x68, 0x7b, 0x92, 0x01, 0x18, 0x45, 0x11, 0x23, 0xab, 0x41, 0x45, 0x29, 0xa4, 0xed,
+	0x82, 0xa8, 0x40, 0x52, 0x83, 0x62, 0xdd, 0x90, 0x62, 0x2a, 0x52, 0xce, 0xa1, 0x9d, 0x04, 0x6b,
+	0x96, 0xa7, 0x41, 0xc8, 0x90, 0xb7, 0x4d, 0x0f, 0x80, 0x7b, 0x0d, 0xdd, 0x8a, 0x3a, 0x4f, 0x79,
+	0x92, 0x33, 0x72, 0x01, 0x56, 0x2c, 0xdc, 0x23, 0x7d, 0xc7, 0xef, 0x4c, 0x30, 0x1f, 0x0c, 0x84,
+	0x56, 0x27, 0x6e, 0x00, 0x5d, 0xca, 0x12, 0xb6, 0xab, 0xe5, 0xfe, 0xde, 0xf2, 0x85, 0x03, 0x45,
+	0x95, 0x71, 0xac, 0xca, 0x87, 0x9e, 0xa4, 0x38, 0x5d, 0xd6, 0x23, 0xf4, 0x9e, 0x93, 0xb8, 0x11,
+	0xe3, 0x09, 0xba, 0x14, 0x15, 0xfa, 0xb1, 0x8a, 0x01, 0xf4, 0xeb, 0x17, 0x2b, 0x19, 0xee, 0x1d,
+	0xf4, 0x1e, 0x30, 0xd5, 0xef, 0x46, 0xf5, 0xf3, 0x83, 0x53, 0xe8, 0xd7, 0xed, 0x27, 0xfb, 0xf2,
+	0x3f, 0x34, 0x30, 0x9f, 0xca, 0x24, 0x24, 0x1e, 0x98, 0x62, 0x54, 0xe4, 0x9f, 0xbc, 0x74, 0xb0,
+	0xea, 0x90, 0x26, 0x24, 0xb5, 0xfe, 0x21, 0x3e, 0x58, 0x98, 0x22, 0x91, 0xc7, 0xcd, 0xa9, 0x39,
+	0xff, 0x15, 0x6c, 0xdf, 0x73, 0x03, 0xad, 0xca, 0x33, 0x91, 0x17, 0x94, 0x4c, 0x9d, 0xa1, 0x0a,
+	0x36, 0xdb, 0x2a, 0x67, 0x75, 0x9b, 0x12, 0x93, 0x33, 0x54, 0xc1, 0xba, 0xed, 0xfe, 0xea, 0xe5,
+	0xf2, 0x35, 0x2a, 0x96, 0xdb, 0xf9, 0x24, 0xe4, 0x6b, 0x6f, 0x1d, 0x85, 0x19, 0x97, 0xeb, 0xdb,
+	0xd4, 0xc3, 0x4f, 0x89, 0xff, 0xf3, 0x56, 0x2c, 0xf3, 0x16, 0x02, 0xd3, 0xcf, 0x01, 0x00, 0xec,
+	0x41, 0xc8, 0xd9, 0xb8, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SyncClient is the client API for Sync service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SyncClient interface {
+	// Lock acquires a lock, waiting for it to be released up to the wait
+	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
+	// Renew extends the lease of a lock
+	Renew(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*RenewResponse, error)
+	// Unlock releases a lock
+	Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error)
+	// Holder returns the lease of a lock, used to find the leader elected
+	Holder(ctx context.Context, in *HolderRequest, opts ...grpc.CallOption) (*HolderResponse, error)
+}
+
+type syncClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSyncClient(cc *grpc.ClientConn) SyncClient {
+	return &syncClient{cc}
+}
+
+func (c *syncClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
+	out := new(LockResponse)
+	err := c.cc.Invoke(ctx, "/sync.Sync/Lock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncClient) Renew(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*RenewResponse, error) {
+	out := new(RenewResponse)
+	err := c.cc.Invoke(ctx, "/sync.Sync/Renew", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncClient) Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error) {
+	out := new(UnlockResponse)
+	err := c.cc.Invoke(ctx, "/sync.Sync/Unlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncClient) Holder(ctx context.Context, in *HolderRequest, opts ...grpc.CallOption) (*HolderResponse, error) {
+	out := new(HolderResponse)
+	err := c.cc.Invoke(ctx, "/sync.Sync/Holder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SyncServer is the server API for Sync service.
+type SyncServer interface {
+	// Lock acquires a lock, waiting for it to be released up to the wait
+	Lock(context.Context, *LockRequest) (*LockResponse, error)
+	// Renew extends the lease of a lock
+	Renew(context.Context, *RenewRequest) (*RenewResponse, error)
+	// Unlock releases a lock
+	Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error)
+	// Holder returns the lease of a lock, used to find the leader elected
+	Holder(context.Context, *HolderRequest) (*HolderResponse, error)
+}
+
+func RegisterSyncServer(s *grpc.Server, srv SyncServer) {
+	s.RegisterService(&_Sync_serviceDesc, srv)
+}
+
+func _Sync_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sync.Sync/Lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncServer).Lock(ctx, req.(*LockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sync_Renew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncServer).Renew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sync.Sync/Renew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncServer).Renew(ctx, req.(*RenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sync_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sync.Sync/Unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncServer).Unlock(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sync_Holder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncServer).Holder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sync.Sync/Holder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncServer).Holder(ctx, req.(*HolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Sync_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sync.Sync",
+	HandlerType: (*SyncServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lock",
+			Handler:    _Sync_Lock_Handler,
+		},
+		{
+			MethodName: "Renew",
+			Handler:    _Sync_Renew_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _Sync_Unlock_Handler,
+		},
+		{
+			MethodName: "Holder",
+			Handler:    _Sync_Holder_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sync/sync.proto",
+}
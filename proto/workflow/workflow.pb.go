@@ -0,0 +1,883 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: workflow/workflow.proto
+
+package workflow
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Call is an rpc call with a json request
+type Call struct {
+	Service  string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// json encoded request
+	Request              []byte   `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Call) Reset()         { *m = Call{} }
+func (m *Call) String() string { return proto.CompactTextString(m) }
+func (*Call) ProtoMessage()    {}
+func (*Call) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{0}
+}
+
+func (m *Call) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Call.Unmarshal(m, b)
+}
+func (m *Call) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Call.Marshal(b, m, deterministic)
+}
+func (m *Call) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Call.Merge(m, src)
+}
+func (m *Call) XXX_Size() int {
+	return xxx_messageInfo_Call.Size(m)
+}
+func (m *Call) XXX_DiscardUnknown() {
+	xxx_messageInfo_Call.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Call proto.InternalMessageInfo
+
+func (m *Call) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Call) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *Call) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+// Step of a saga
+type Step struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// call made by the step
+	Call *Call `protobuf:"bytes,2,opt,name=call,proto3" json:"call,omitempty"`
+	// call undoing the step, made when a step after it fails. Optional
+	Compensation *Call `protobuf:"bytes,3,opt,name=compensation,proto3" json:"compensation,omitempty"`
+	// number of times the calls are retried after failing
+	Retries int32 `protobuf:"varint,4,opt,name=retries,proto3" json:"retries,omitempty"`
+	// timeout of each attempt at the calls in milliseconds, defaults to the timeout of the client
+	Timeout int64 `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// state of the step: pending, completed, failed, compensated
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// json encoded response of the call
+	Response []byte `protobuf:"bytes,7,opt,name=response,proto3" json:"response,omitempty"`
+	// error of the last attempt
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// number of attempts made
+	Attempts             int32    `protobuf:"varint,9,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Step) Reset()         { *m = Step{} }
+func (m *Step) String() string { return proto.CompactTextString(m) }
+func (*Step) ProtoMessage()    {}
+func (*Step) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{1}
+}
+
+func (m *Step) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Step.Unmarshal(m, b)
+}
+func (m *Step) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Step.Marshal(b, m, deterministic)
+}
+func (m *Step) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Step.Merge(m, src)
+}
+func (m *Step) XXX_Size() int {
+	return xxx_messageInfo_Step.Size(m)
+}
+func (m *Step) XXX_DiscardUnknown() {
+	xxx_messageInfo_Step.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Step proto.InternalMessageInfo
+
+func (m *Step) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Step) GetCall() *Call {
+	if m != nil {
+		return m.Call
+	}
+	return nil
+}
+
+func (m *Step) GetCompensation() *Call {
+	if m != nil {
+		return m.Compensation
+	}
+	return nil
+}
+
+func (m *Step) GetRetries() int32 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
+func (m *Step) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *Step) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Step) GetResponse() []byte {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *Step) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Step) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+type Saga struct {
+	Id    string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Steps []*Step `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	// state of the saga: running, completed, compensating, compensated, failed
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// error the saga failed with
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// unix timestamps
+	Created int64 `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
+	Updated int64 `protobuf:"varint,7,opt,name=updated,proto3" json:"updated,omitempty"`
+	// whether the saga is running or compensating but not executed e.g. because the service
+	// restarted, or failed compensating
+	Stuck                bool     `protobuf:"varint,8,opt,name=stuck,proto3" json:"stuck,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Saga) Reset()         { *m = Saga{} }
+func (m *Saga) String() string { return proto.CompactTextString(m) }
+func (*Saga) ProtoMessage()    {}
+func (*Saga) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{2}
+}
+
+func (m *Saga) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Saga.Unmarshal(m, b)
+}
+func (m *Saga) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Saga.Marshal(b, m, deterministic)
+}
+func (m *Saga) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Saga.Merge(m, src)
+}
+func (m *Saga) XXX_Size() int {
+	return xxx_messageInfo_Saga.Size(m)
+}
+func (m *Saga) XXX_DiscardUnknown() {
+	xxx_messageInfo_Saga.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Saga proto.InternalMessageInfo
+
+func (m *Saga) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Saga) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Saga) GetSteps() []*Step {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+func (m *Saga) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Saga) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Saga) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Saga) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *Saga) GetStuck() bool {
+	if m != nil {
+		return m.Stuck
+	}
+	return false
+}
+
+type StartRequest struct {
+	Name  string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Steps []*Step `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	// namespace of the workflow, defaults to the namespace of the caller
+	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartRequest) Reset()         { *m = StartRequest{} }
+func (m *StartRequest) String() string { return proto.CompactTextString(m) }
+func (*StartRequest) ProtoMessage()    {}
+func (*StartRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{3}
+}
+
+func (m *StartRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartRequest.Unmarshal(m, b)
+}
+func (m *StartRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartRequest.Marshal(b, m, deterministic)
+}
+func (m *StartRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartRequest.Merge(m, src)
+}
+func (m *StartRequest) XXX_Size() int {
+	return xxx_messageInfo_StartRequest.Size(m)
+}
+func (m *StartRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartRequest proto.InternalMessageInfo
+
+func (m *StartRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StartRequest) GetSteps() []*Step {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+func (m *StartRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type StartResponse struct {
+	Saga                 *Saga    `protobuf:"bytes,1,opt,name=saga,proto3" json:"saga,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartResponse) Reset()         { *m = StartResponse{} }
+func (m *StartResponse) String() string { return proto.CompactTextString(m) }
+func (*StartResponse) ProtoMessage()    {}
+func (*StartResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{4}
+}
+
+func (m *StartResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartResponse.Unmarshal(m, b)
+}
+func (m *StartResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartResponse.Marshal(b, m, deterministic)
+}
+func (m *StartResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartResponse.Merge(m, src)
+}
+func (m *StartResponse) XXX_Size() int {
+	return xxx_messageInfo_StartResponse.Size(m)
+}
+func (m *StartResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartResponse proto.InternalMessageInfo
+
+func (m *StartResponse) GetSaga() *Saga {
+	if m != nil {
+		return m.Saga
+	}
+	return nil
+}
+
+type ReadRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
+func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadRequest) ProtoMessage()    {}
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{5}
+}
+
+func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
+}
+func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRequest.Merge(m, src)
+}
+func (m *ReadRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadRequest.Size(m)
+}
+func (m *ReadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
+
+func (m *ReadRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ReadRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ReadResponse struct {
+	Saga                 *Saga    `protobuf:"bytes,1,opt,name=saga,proto3" json:"saga,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
+func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadResponse) ProtoMessage()    {}
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{6}
+}
+
+func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
+}
+func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadResponse.Merge(m, src)
+}
+func (m *ReadResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadResponse.Size(m)
+}
+func (m *ReadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
+
+func (m *ReadResponse) GetSaga() *Saga {
+	if m != nil {
+		return m.Saga
+	}
+	return nil
+}
+
+type ListRequest struct {
+	// only list the stuck workflows
+	Stuck                bool     `protobuf:"varint,1,opt,name=stuck,proto3" json:"stuck,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{7}
+}
+
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(m, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+func (m *ListRequest) GetStuck() bool {
+	if m != nil {
+		return m.Stuck
+	}
+	return false
+}
+
+func (m *ListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ListResponse struct {
+	Sagas                []*Saga  `protobuf:"bytes,1,rep,name=sagas,proto3" json:"sagas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListResponse) Reset()         { *m = ListResponse{} }
+func (m *ListResponse) String() string { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()    {}
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{8}
+}
+
+func (m *ListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
+}
+func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+}
+func (m *ListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResponse.Merge(m, src)
+}
+func (m *ListResponse) XXX_Size() int {
+	return xxx_messageInfo_ListResponse.Size(m)
+}
+func (m *ListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListResponse proto.InternalMessageInfo
+
+func (m *ListResponse) GetSagas() []*Saga {
+	if m != nil {
+		return m.Sagas
+	}
+	return nil
+}
+
+type ResumeRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeRequest) Reset()         { *m = ResumeRequest{} }
+func (m *ResumeRequest) String() string { return proto.CompactTextString(m) }
+func (*ResumeRequest) ProtoMessage()    {}
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{9}
+}
+
+func (m *ResumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeRequest.Unmarshal(m, b)
+}
+func (m *ResumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeRequest.Marshal(b, m, deterministic)
+}
+func (m *ResumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeRequest.Merge(m, src)
+}
+func (m *ResumeRequest) XXX_Size() int {
+	return xxx_messageInfo_ResumeRequest.Size(m)
+}
+func (m *ResumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeRequest proto.InternalMessageInfo
+
+func (m *ResumeRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ResumeRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ResumeResponse struct {
+	Saga                 *Saga    `protobuf:"bytes,1,opt,name=saga,proto3" json:"saga,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeResponse) Reset()         { *m = ResumeResponse{} }
+func (m *ResumeResponse) String() string { return proto.CompactTextString(m) }
+func (*ResumeResponse) ProtoMessage()    {}
+func (*ResumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87aff9429097fa52, []int{10}
+}
+
+func (m *ResumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeResponse.Unmarshal(m, b)
+}
+func (m *ResumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeResponse.Marshal(b, m, deterministic)
+}
+func (m *ResumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeResponse.Merge(m, src)
+}
+func (m *ResumeResponse) XXX_Size() int {
+	return xxx_messageInfo_ResumeResponse.Size(m)
+}
+func (m *ResumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeResponse proto.InternalMessageInfo
+
+func (m *ResumeResponse) GetSaga() *Saga {
+	if m != nil {
+		return m.Saga
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Call)(nil), "workflow.Call")
+	proto.RegisterType((*Step)(nil), "workflow.Step")
+	proto.RegisterType((*Saga)(nil), "workflow.Saga")
+	proto.RegisterType((*StartRequest)(nil), "workflow.StartRequest")
+	proto.RegisterType((*StartResponse)(nil), "workflow.StartResponse")
+	proto.RegisterType((*ReadRequest)(nil), "workflow.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "workflow.ReadResponse")
+	proto.RegisterType((*ListRequest)(nil), "workflow.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "workflow.ListResponse")
+	proto.RegisterType((*ResumeRequest)(nil), "workflow.ResumeRequest")
+	proto.RegisterType((*ResumeResponse)(nil), "workflow.ResumeResponse")
+}
+
+func init() { proto.RegisterFile("workflow/workflow.proto", fileDescriptor_87aff9429097fa52) }
+
+var fileDescriptor_87aff9429097fa52 = []byte{
+	// 562 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xbb, 0x6e, 0xdb, 0x30,
+	0x14, 0xad, 0x5e, 0x7e, 0xdc, 0x38, 0x19, 0x88, 0xd6, 0x21, 0x8c, 0x0e, 0x82, 0xd0, 0xc1, 0x93,
+	0x8d, 0xda, 0x01, 0x0a, 0x34, 0xc8, 0xd0, 0x76, 0xed, 0x44, 0x03, 0x2d, 0xd0, 0x8d, 0x91, 0x18,
+	0x57, 0x88, 0x25, 0xaa, 0x24, 0x95, 0xfc, 0x44, 0x3f, 0xac, 0x7f, 0xd4, 0xb5, 0x20, 0x25, 0xd2,
+	0xb4, 0x63, 0xf4, 0xb5, 0x18, 0x3c, 0x3c, 0xbc, 0xf7, 0xdc, 0x73, 0xae, 0x21, 0xb8, 0x7c, 0xe4,
+	0xe2, 0xfe, 0x6e, 0xc7, 0x1f, 0x97, 0xf6, 0xb0, 0x68, 0x04, 0x57, 0x1c, 0x8d, 0x2c, 0xce, 0x3e,
+	0x41, 0xfc, 0x81, 0xee, 0x76, 0x08, 0xc3, 0x50, 0x32, 0xf1, 0x50, 0xe6, 0x0c, 0x07, 0x69, 0x30,
+	0x1f, 0x13, 0x0b, 0xd1, 0x0c, 0x46, 0xac, 0x2e, 0x1a, 0x5e, 0xd6, 0x0a, 0x87, 0x86, 0x72, 0x58,
+	0x57, 0x09, 0xf6, 0xad, 0x65, 0x52, 0xe1, 0x28, 0x0d, 0xe6, 0x13, 0x62, 0x61, 0xf6, 0x3d, 0x84,
+	0x78, 0xa3, 0x58, 0x83, 0x10, 0xc4, 0x35, 0xad, 0x6c, 0x57, 0x73, 0x46, 0x19, 0xc4, 0x39, 0xdd,
+	0xed, 0x4c, 0xbb, 0xb3, 0xd5, 0xc5, 0xc2, 0x4d, 0xa7, 0x47, 0x21, 0x86, 0x43, 0x2b, 0x98, 0xe4,
+	0xbc, 0x6a, 0x58, 0x2d, 0xa9, 0x2a, 0x79, 0x8d, 0xa3, 0x93, 0x6f, 0x0f, 0xde, 0x74, 0xe3, 0x28,
+	0x51, 0x32, 0x89, 0xe3, 0x34, 0x98, 0x27, 0xc4, 0x42, 0xcd, 0xa8, 0xb2, 0x62, 0xbc, 0x55, 0x38,
+	0x49, 0x83, 0x79, 0x44, 0x2c, 0x44, 0x53, 0x18, 0x48, 0x45, 0x55, 0x2b, 0xf1, 0xc0, 0x4c, 0xd8,
+	0x23, 0x6d, 0x5b, 0x30, 0xd9, 0xf0, 0x5a, 0x32, 0x3c, 0x34, 0xde, 0x1c, 0x46, 0xcf, 0x21, 0x61,
+	0x42, 0x70, 0x81, 0x47, 0xa6, 0xa4, 0x03, 0xba, 0x82, 0x2a, 0xc5, 0xaa, 0x46, 0x49, 0x3c, 0x36,
+	0xf2, 0x0e, 0x67, 0x3f, 0x02, 0x88, 0x37, 0x74, 0x4b, 0xd1, 0x05, 0x84, 0x65, 0xd1, 0x87, 0x11,
+	0x96, 0x85, 0x8b, 0x27, 0xf4, 0xe2, 0x79, 0x05, 0x89, 0x54, 0xac, 0x91, 0x38, 0x4a, 0xa3, 0x43,
+	0xcf, 0x3a, 0x51, 0xd2, 0x91, 0xde, 0xe0, 0xf1, 0xc1, 0xe0, 0x6e, 0xb8, 0xc4, 0x1f, 0x0e, 0xc3,
+	0x30, 0x17, 0x8c, 0x2a, 0x56, 0x18, 0x9f, 0x11, 0xb1, 0x50, 0x33, 0x6d, 0x53, 0x18, 0x66, 0xd8,
+	0x31, 0x3d, 0xd4, 0x9d, 0xa4, 0x6a, 0xf3, 0x7b, 0x63, 0x73, 0x44, 0x3a, 0x90, 0xdd, 0xc1, 0x64,
+	0xa3, 0xa8, 0x50, 0xa4, 0xdb, 0xf4, 0xc9, 0x05, 0x3b, 0x07, 0xe1, 0xef, 0x1c, 0xbc, 0x84, 0xb1,
+	0x7e, 0x2d, 0x1b, 0x9a, 0x33, 0xb3, 0xdf, 0x31, 0xd9, 0x5f, 0x64, 0x6b, 0x38, 0xef, 0x75, 0xfa,
+	0xd4, 0x33, 0x88, 0x25, 0xdd, 0x52, 0x23, 0x74, 0xd8, 0x93, 0x6e, 0x29, 0x31, 0x5c, 0x76, 0x0d,
+	0x67, 0x84, 0xd1, 0xc2, 0xce, 0x76, 0x9c, 0xf6, 0x81, 0x62, 0x78, 0xac, 0xb8, 0x82, 0x49, 0x57,
+	0xfc, 0x0f, 0x82, 0xef, 0xe0, 0xec, 0x63, 0x29, 0x5d, 0x18, 0x2e, 0xb2, 0xc0, 0x8b, 0xec, 0x0f,
+	0xb2, 0x57, 0x30, 0xe9, 0x5a, 0xf4, 0xb2, 0x3a, 0x3c, 0xba, 0xa5, 0x12, 0x07, 0x69, 0x74, 0x42,
+	0xb7, 0x23, 0xb3, 0x1b, 0x38, 0x27, 0x4c, 0xb6, 0x15, 0xfb, 0x3f, 0xaf, 0x57, 0x70, 0x61, 0xcb,
+	0xff, 0xde, 0xed, 0xea, 0x67, 0x00, 0xa3, 0xcf, 0xfd, 0x3d, 0x7a, 0x0b, 0x89, 0x59, 0x10, 0x9a,
+	0xfa, 0xeb, 0xdd, 0xff, 0x33, 0x66, 0x97, 0x4f, 0xee, 0x3b, 0xa9, 0xec, 0x19, 0x7a, 0x03, 0xb1,
+	0x8e, 0x1a, 0xbd, 0xd8, 0x3f, 0xf1, 0xf6, 0x36, 0x9b, 0x1e, 0x5f, 0xfb, 0x85, 0x3a, 0x2c, 0xbf,
+	0xd0, 0xcb, 0x7f, 0x36, 0x3d, 0xbe, 0x76, 0x85, 0x37, 0x30, 0xe8, 0x0c, 0xa3, 0x4b, 0xbf, 0xb9,
+	0x97, 0xe0, 0x0c, 0x3f, 0x25, 0x6c, 0xf9, 0xfb, 0xf5, 0x97, 0xd7, 0xdb, 0x52, 0x7d, 0x6d, 0x6f,
+	0x17, 0x39, 0xaf, 0x96, 0x55, 0x99, 0x0b, 0xde, 0xff, 0x3e, 0xac, 0x97, 0xe6, 0xa3, 0xea, 0xbe,
+	0xb1, 0xd7, 0xf6, 0x70, 0x3b, 0x30, 0xc4, 0xfa, 0xd7, 0x00, 0xe7, 0xea, 0xec, 0x62, 0x88, 0x05,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WorkflowClient is the client API for Workflow service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WorkflowClient interface {
+	// Start executes a saga, the steps are called in order and when one of them fails the
+	// compensations of the steps before it are called in reverse
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	// Read returns a workflow and the state of its steps
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+	// List returns the workflows, most recently updated first
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Resume continues a stuck workflow from the step it stopped at
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
+}
+
+type workflowClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWorkflowClient(cc *grpc.ClientConn) WorkflowClient {
+	return &workflowClient{cc}
+}
+
+func (c *workflowClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error) {
+	out := new(StartResponse)
+	err := c.cc.Invoke(ctx, "/workflow.Workflow/Start", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
+	out := new(ReadResponse)
+	err := c.cc.Invoke(ctx, "/workflow.Workflow/Read", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/workflow.Workflow/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error) {
+	out := new(ResumeResponse)
+	err := c.cc.Invoke(ctx, "/workflow.Workflow/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkflowServer is the server API for Workflow service.
+type WorkflowServer interface {
+	// Start executes a saga, the steps are called in order and when one of them fails the
+	// compensations of the steps before it are called in reverse
+	Start(context.Context, *StartRequest) (*StartResponse, error)
+	// Read returns a workflow and the state of its steps
+	Read(context.Context, *ReadRequest) (*ReadResponse, error)
+	// List returns the workflows, most recently updated first
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Resume continues a stuck workflow from the step it stopped at
+	Resume(context.Context, *ResumeRequest) (*ResumeResponse, error)
+}
+
+func RegisterWorkflowServer(s *grpc.Server, srv WorkflowServer) {
+	s.RegisterService(&_Workflow_serviceDesc, srv)
+}
+
+func _Workflow_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflow.Workflow/Start",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowServer).Start(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Workflow_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflow.Workflow/Read",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowServer).Read(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Workflow_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflow.Workflow/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Workflow_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflow.Workflow/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Workflow_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "workflow.Workflow",
+	HandlerType: (*WorkflowServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Start",
+			Handler:    _Workflow_Start_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _Workflow_Read_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Workflow_List_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Workflow_Resume_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "workflow/workflow.proto",
+}
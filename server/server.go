@@ -23,9 +23,14 @@ var (
 		"store",    // :8002
 		"broker",   // :8003
 		"events",   // :unset
+		"id",       // :unset
+		"sync",     // :unset
+		"workflow", // :unset
+		"debug",    // :unset
 		"auth",     // :8010
 		"proxy",    // :8081
 		"api",      // :8080
+		"web",      // :8082
 	}
 )
 
@@ -54,6 +59,36 @@ func init() {
 				EnvVars: []string{"MICRO_SERVER_IMAGE"},
 				Value:   "micro/micro:latest",
 			},
+			&cli.StringFlag{
+				Name:    "tls_cert",
+				Usage:   "Set the certificate for mutual TLS between the network nodes, requires the key and CA",
+				EnvVars: []string{"MICRO_NETWORK_TLS_CERT"},
+			},
+			&cli.StringFlag{
+				Name:    "tls_key",
+				Usage:   "Set the key of the certificate for mutual TLS between the network nodes",
+				EnvVars: []string{"MICRO_NETWORK_TLS_KEY"},
+			},
+			&cli.StringFlag{
+				Name:    "tls_ca",
+				Usage:   "Set the CA the certificates of the network nodes must be signed by",
+				EnvVars: []string{"MICRO_NETWORK_TLS_CA"},
+			},
+			&cli.StringFlag{
+				Name:    "metrics_address",
+				Usage:   "Set the address the network serves its prometheus metrics on at /metrics e.g. :9090",
+				EnvVars: []string{"MICRO_NETWORK_METRICS_ADDRESS"},
+			},
+			&cli.StringFlag{
+				Name:    "routes_file",
+				Usage:   "Set the yaml file of static routes the network seeds the router with, for the services not in the registry",
+				EnvVars: []string{"MICRO_NETWORK_ROUTES_FILE"},
+			},
+			&cli.StringFlag{
+				Name:    "health_address",
+				Usage:   "Set the address the network serves its health on at /healthz for liveness and readiness probes e.g. :9091",
+				EnvVars: []string{"MICRO_NETWORK_HEALTH_ADDRESS"},
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			Run(ctx)
@@ -121,6 +156,16 @@ func Run(context *cli.Context) error {
 			env = append(env, "MICRO_PROFILE="+context.String("profile"))
 		}
 
+		// pass the mutual tls, metrics, routes and health config to the network, the flags override the env vars
+		// passed above
+		if service == "network" {
+			for _, f := range []string{"tls_cert", "tls_key", "tls_ca", "metrics_address", "routes_file", "health_address"} {
+				if v := context.String(f); len(v) > 0 {
+					env = append(env, "MICRO_NETWORK_"+strings.ToUpper(f)+"="+v)
+				}
+			}
+		}
+
 		// set the proxy addres, default to the network running locally
 		if service != "network" {
 			proxy := context.String("proxy_address")
@@ -120,7 +120,11 @@ func (a authWrapper) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	// the resource they're requesting
 	res := &auth.Resource{Type: "service", Name: resName, Endpoint: resEndpoint}
 	if err := auth.Verify(acc, res, verifyOpts...); err == nil {
-		// The account has the necessary permissions to access the resource
+		// The account has the necessary permissions to access the resource, it's set in the
+		// context for the wrappers the auth wrapper wraps e.g. the quotas
+		if acc != nil {
+			req = req.WithContext(auth.ContextWithAccount(req.Context(), acc))
+		}
 		a.handler.ServeHTTP(w, req)
 		return
 	} else if err != auth.ErrForbidden {
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/micro/micro/v3/internal/api/handler"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+var (
+	// BatchPath is the path the batches of requests are posted to
+	BatchPath = "/batch"
+	// BatchSize is the max number of requests in a batch
+	BatchSize = 50
+	// BatchTimeout is the deadline of the batches, the requests which haven't completed by then
+	// get a 504
+	BatchTimeout = time.Second * 10
+)
+
+// batchRequest is a request of a batch
+type batchRequest struct {
+	// Id the caller matches the result with, optional as the results are in the same order
+	Id string `json:"id,omitempty"`
+	// Method of the request, POST if not set
+	Method string `json:"method,omitempty"`
+	// Path of the request e.g. /helloworld/call
+	Path string `json:"path"`
+	// Header of the request, added to the header of the batch
+	Header map[string]string `json:"header,omitempty"`
+	// Body of the request, sent as json
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// batchResult is the result of a request of a batch
+type batchResult struct {
+	Id     string            `json:"id,omitempty"`
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+}
+
+// batchWriter records the response to a request of a batch
+type batchWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *batchWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *batchWriter) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+func (b *batchWriter) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+// result returns the recorded response, the body is passed on as is if it's json
+func (b *batchWriter) result(id string) *batchResult {
+	res := &batchResult{Id: id, Status: b.status, Header: make(map[string]string)}
+	if res.Status == 0 {
+		res.Status = http.StatusOK
+	}
+	for k, v := range b.header {
+		res.Header[k] = strings.Join(v, ", ")
+	}
+	if b.body.Len() == 0 {
+		return res
+	}
+	if json.Valid(b.body.Bytes()) {
+		res.Body = b.body.Bytes()
+	} else {
+		res.Body, _ = json.Marshal(b.body.String())
+	}
+	return res
+}
+
+// errorResult returns the result of a request which failed with the error
+func errorResult(id string, err error) *batchResult {
+	merr := errors.FromError(err)
+	return &batchResult{
+		Id:     id,
+		Status: int(merr.Code),
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body:   json.RawMessage(merr.Error()),
+	}
+}
+
+// batchWrapper serves the batches of requests posted to the BatchPath, the requests are served
+// concurrently by the handler with the header of the batch and the results returned in order. It
+// saves the round trips of the callers on high latency links such as mobile clients.
+func batchWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != BatchPath || r.Method != "POST" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		writeError := func(err error) {
+			merr := errors.FromError(err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(int(merr.Code))
+			w.Write([]byte(merr.Error()))
+		}
+
+		// the batch is limited to the size of a request to the handlers
+		var reqs []*batchRequest
+		body := http.MaxBytesReader(w, r.Body, handler.DefaultMaxRecvSize)
+		if err := json.NewDecoder(body).Decode(&reqs); err != nil {
+			writeError(errors.BadRequest("go.micro.api", "Invalid batch, expected an array of requests: %v", err))
+			return
+		}
+		if len(reqs) > BatchSize {
+			writeError(errors.BadRequest("go.micro.api", "Too many requests in the batch, the max is %d", BatchSize))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), BatchTimeout)
+		defer cancel()
+
+		writers := make([]*batchWriter, len(reqs))
+		done := make([]chan bool, len(reqs))
+		results := make([]*batchResult, len(reqs))
+
+		for i, br := range reqs {
+			req, err := newBatchRequest(ctx, r, br)
+			if err != nil {
+				results[i] = errorResult(br.Id, errors.BadRequest("go.micro.api", "%v", err))
+				continue
+			}
+
+			writers[i] = &batchWriter{header: make(http.Header)}
+			done[i] = make(chan bool)
+
+			go func(bw *batchWriter, req *http.Request, done chan bool) {
+				defer close(done)
+				defer func() {
+					if p := recover(); p != nil {
+						logger.Errorf("panic recovered serving %s in a batch: %v", req.URL.Path, p)
+						bw.status = http.StatusInternalServerError
+					}
+				}()
+				h.ServeHTTP(bw, req)
+			}(writers[i], req, done[i])
+		}
+
+		// wait for the requests until the deadline, the ones still running then get a timeout
+		for i, br := range reqs {
+			if results[i] != nil {
+				continue
+			}
+			select {
+			case <-done[i]:
+				results[i] = writers[i].result(br.Id)
+			case <-ctx.Done():
+				select {
+				case <-done[i]:
+					results[i] = writers[i].result(br.Id)
+				default:
+					results[i] = errorResult(br.Id, errors.GatewayTimeout("go.micro.api", "Deadline exceeded"))
+				}
+			}
+		}
+
+		b, err := json.Marshal(results)
+		if err != nil {
+			writeError(errors.InternalServerError("go.micro.api", "Error encoding the results: %v", err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+}
+
+// newBatchRequest returns the http request of a request of the batch
+func newBatchRequest(ctx context.Context, r *http.Request, br *batchRequest) (*http.Request, error) {
+	if !strings.HasPrefix(br.Path, "/") {
+		return nil, fmt.Errorf("invalid path %q", br.Path)
+	}
+	if br.Path == BatchPath || strings.HasPrefix(br.Path, BatchPath+"?") {
+		return nil, fmt.Errorf("batches can't be nested")
+	}
+
+	method := br.Method
+	if len(method) == 0 {
+		method = "POST"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, br.Path, bytes.NewReader(br.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	// the requests are made with the header of the batch, e.g. its authorization, but each
+	// needs an idempotency key of its own
+	req.Header = r.Header.Clone()
+	req.Header.Del("Content-Length")
+	req.Header.Del("Idempotency-Key")
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range br.Header {
+		req.Header.Set(k, v)
+	}
+	req.Host = r.Host
+	req.RemoteAddr = r.RemoteAddr
+	return req, nil
+}
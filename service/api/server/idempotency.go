@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/micro/micro/v3/internal/api/handler"
+	"github.com/micro/micro/v3/internal/idempotency"
+	"github.com/micro/micro/v3/internal/namespace"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+// IdempotencyTTL is how long the responses to the requests with an Idempotency-Key are kept to be
+// replayed, 0 disables the deduplication at the api
+var IdempotencyTTL = time.Hour * 24
+
+// idempotentWriter records the response written to the caller
+type idempotentWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (i *idempotentWriter) Write(p []byte) (int, error) {
+	if i.status == 0 {
+		i.status = http.StatusOK
+	}
+	i.body.Write(p)
+	return i.ResponseWriter.Write(p)
+}
+
+func (i *idempotentWriter) WriteHeader(status int) {
+	if i.status == 0 {
+		i.status = status
+	}
+	i.ResponseWriter.WriteHeader(status)
+}
+
+// idempotencyWrapper deduplicates the mutating requests with the Idempotency-Key header so the
+// retries of a caller don't create or charge twice, a retry gets the response to the first request
+// with the Idempotent-Replayed header. The keys are scoped to the namespace and account of the
+// caller and the responses kept in the store for the IdempotencyTTL. Only the successful responses
+// are recorded, the requests which fail e.g. over a quota or with a 5xx can be retried.
+func idempotencyWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idemKey := r.Header.Get("Idempotency-Key")
+		if len(idemKey) == 0 || IdempotencyTTL <= 0 || store.DefaultStore == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+		switch r.Method {
+		case "POST", "PUT", "PATCH", "DELETE":
+		default:
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		writeError := func(err error) {
+			merr := errors.FromError(err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(int(merr.Code))
+			w.Write([]byte(merr.Error()))
+		}
+
+		var caller string
+		if acc, ok := auth.AccountFromContext(r.Context()); ok {
+			caller = acc.ID
+		}
+		ns := r.Header.Get(namespace.NamespaceKey)
+		key := strings.Join([]string{"idempotency", "api", ns, caller, idemKey}, "/")
+
+		// the body is hashed before the handler limits its size, so it's limited here too
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, handler.DefaultMaxRecvSize))
+		if err != nil {
+			writeError(errors.BadRequest("go.micro.api", "Error reading the request: %v", err))
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		hash := idempotency.Hash([]byte(r.Method+" "+r.URL.RequestURI()+"\n"), body)
+
+		// replay the response to a previous request with the key
+		prev, err := idempotency.Begin(key, hash)
+		switch err {
+		case nil:
+		case idempotency.ErrMismatch:
+			writeError(errors.Conflict("go.micro.api", "Idempotency key %v was used for a different request", idemKey))
+			return
+		case idempotency.ErrInProgress:
+			writeError(errors.Conflict("go.micro.api", "A request with the idempotency key %v is in progress", idemKey))
+			return
+		default:
+			writeError(errors.InternalServerError("go.micro.api", "Error reading the idempotent response: %v", err))
+			return
+		}
+		if prev != nil {
+			for k, v := range prev.Header {
+				w.Header().Set(k, v)
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(prev.Status)
+			w.Write(prev.Body)
+			return
+		}
+
+		// the key is released unless the response is recorded, so the request can be retried
+		recorded := false
+		defer func() {
+			if recorded {
+				return
+			}
+			if err := idempotency.Abort(key); err != nil {
+				logger.Errorf("Error deleting idempotent request: %v", err)
+			}
+		}()
+		iw := &idempotentWriter{ResponseWriter: w}
+		h.ServeHTTP(iw, r)
+
+		if iw.status == 0 {
+			iw.status = http.StatusOK
+		}
+		if iw.status < 200 || iw.status > 299 {
+			return
+		}
+
+		rsp := &idempotency.Response{
+			Hash:   hash,
+			Status: iw.status,
+			Header: make(map[string]string),
+			Body:   iw.body.Bytes(),
+		}
+		for k, v := range w.Header() {
+			rsp.Header[k] = strings.Join(v, ", ")
+		}
+		recorded = true
+		if err := idempotency.Complete(key, rsp, IdempotencyTTL); err != nil {
+			logger.Errorf("Error writing idempotent response: %v", err)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/micro/micro/v3/internal/overload"
+	"github.com/micro/micro/v3/service/errors"
+)
+
+// limitWrapper serves the requests within the limits of the limiter, the requests it sheds are
+// rejected with a 503 and a Retry-After for the callers to back off
+func limitWrapper(l *overload.Limiter) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := l.Acquire(r.Context()); err != nil {
+				// the caller is gone if its context is done
+				if err != overload.ErrOverloaded {
+					return
+				}
+				e := errors.ServiceUnavailable("go.micro.api", "Overloaded, retry later")
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(e.Error()))
+				return
+			}
+			defer l.Release()
+			h.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/micro/micro/v3/internal/api/resolver"
+	"github.com/micro/micro/v3/internal/maintenance"
+	"github.com/micro/micro/v3/internal/namespace"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+var maintenancePage = template.Must(template.New("maintenance").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Service}} is down for maintenance</title></head>
+<body>
+<h1>{{.Service}} is down for maintenance</h1>
+<p>{{.Message}}</p>
+</body>
+</html>
+`))
+
+// maintenanceWrapper returns a 503 for the requests to services in maintenance, a page if the
+// caller accepts html or the error as json otherwise. The health checks are still served.
+func maintenanceWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the endpoint is resolved by the auth wrapper
+		ep, ok := r.Context().Value(resolver.Endpoint{}).(*resolver.Endpoint)
+		if !ok || len(ep.Name) == 0 || maintenance.HealthCheck(r.URL.Path) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		ns := r.Header.Get(namespace.NamespaceKey)
+		m, err := maintenance.Check(ns, ep.Name)
+		if err != nil {
+			logger.Errorf("Error checking the maintenance of %v in %v: %v", ep.Name, ns, err)
+		}
+		if m == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		msg := m.Message
+		if len(msg) == 0 {
+			msg = maintenance.DefaultMessage
+		}
+		if strings.Contains(r.Header.Get("Accept"), "text/html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			maintenancePage.Execute(w, map[string]string{"Service": ep.Name, "Message": msg})
+			return
+		}
+		e := errors.ServiceUnavailable(ep.Name, "%v", msg)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(e.Error()))
+	})
+}
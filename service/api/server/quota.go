@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/micro/micro/v3/internal/namespace"
+	"github.com/micro/micro/v3/internal/quota"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+// quotaWrapper counts the requests against the quotas of the account and namespace, requests over
+// a quota are rejected with a 429. The usage of the quota is returned in the rate limit headers.
+func quotaWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ns := r.Header.Get(namespace.NamespaceKey)
+		var id string
+		if acc, ok := auth.AccountFromContext(r.Context()); ok {
+			id = acc.ID
+		}
+
+		usage, err := quota.Check(ns, id)
+		if err != nil && err != quota.ErrExceeded {
+			// don't fail the requests if the quotas can't be read
+			logger.Errorf("Error checking the quota of %v in %v: %v", id, ns, err)
+			h.ServeHTTP(w, r)
+			return
+		}
+		if usage != nil {
+			for k, v := range usage.Headers() {
+				w.Header().Set(k, v)
+			}
+		}
+		if err == quota.ErrExceeded {
+			e := errors.TooManyRequests("go.micro.api", "Quota exceeded, it resets at %v", usage.Reset.UTC())
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(e.Error()))
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -29,6 +29,7 @@ import (
 	httpapi "github.com/micro/micro/v3/internal/api/server/http"
 	"github.com/micro/micro/v3/internal/handler"
 	"github.com/micro/micro/v3/internal/helper"
+	"github.com/micro/micro/v3/internal/overload"
 	rrmicro "github.com/micro/micro/v3/internal/resolver/api"
 	"github.com/micro/micro/v3/internal/sync/memory"
 	"github.com/micro/micro/v3/plugin"
@@ -62,7 +63,7 @@ var (
 		},
 		&cli.StringFlag{
 			Name:    "handler",
-			Usage:   "Specify the request handler to be used for mapping HTTP requests to services; {api, event, http, rpc}",
+			Usage:   "Specify the request handler to be used for mapping HTTP requests to services; {meta, api, event, http, proxy, rpc, web}",
 			EnvVars: []string{"MICRO_API_HANDLER"},
 		},
 		&cli.StringFlag{
@@ -81,6 +82,23 @@ var (
 			EnvVars: []string{"MICRO_API_ENABLE_CORS"},
 			Value:   true,
 		},
+		&cli.Int64Flag{
+			Name:    "stream_threshold",
+			Usage:   "Size in bytes of the request bodies above which they're streamed in chunks to the endpoints which support streaming",
+			EnvVars: []string{"MICRO_API_STREAM_THRESHOLD"},
+		},
+		&cli.DurationFlag{
+			Name:    "batch_timeout",
+			Usage:   "Set the deadline of the batches of requests posted to /batch",
+			EnvVars: []string{"MICRO_API_BATCH_TIMEOUT"},
+			Value:   BatchTimeout,
+		},
+		&cli.DurationFlag{
+			Name:    "idempotency_ttl",
+			Usage:   "Set how long the responses to the requests with an Idempotency-Key are replayed, 0 to disable",
+			EnvVars: []string{"MICRO_API_IDEMPOTENCY_TTL"},
+			Value:   IdempotencyTTL,
+		},
 	)
 )
 
@@ -109,6 +127,24 @@ func Run(ctx *cli.Context) error {
 	if len(ctx.String("api_address")) > 0 {
 		Address = ctx.String("api_address")
 	}
+	if ctx.Int64("stream_threshold") > 0 {
+		ahandler.DefaultStreamThreshold = ctx.Int64("stream_threshold")
+	}
+	if ctx.Duration("batch_timeout") > 0 {
+		BatchTimeout = ctx.Duration("batch_timeout")
+	}
+	if ctx.IsSet("idempotency_ttl") {
+		IdempotencyTTL = ctx.Duration("idempotency_ttl")
+	}
+
+	// proxy is the reverse proxy of the http handler
+	switch Handler {
+	case "proxy":
+		Handler = "http"
+	case "meta", "api", "event", "http", "rpc", "web":
+	default:
+		log.Fatalf("Unsupported handler %s, expected meta, api, event, http, proxy, rpc or web", Handler)
+	}
 	// initialise service
 	srv := service.New(service.Name(Name))
 
@@ -294,9 +330,43 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
+	// apply the global wrappers, the first is the outermost
+	ws := plugin.Wrappers()
+	for i := len(ws); i > 0; i-- {
+		if v := ws[i-1].HTTP; v != nil {
+			h = v(h)
+		}
+	}
+
+	// enforce the quotas of the accounts authenticated by the auth wrapper
+	h = quotaWrapper(h)
+
+	// return a 503 for the services in maintenance before the requests are counted
+	h = maintenanceWrapper(h)
+
+	// replay the responses to the retries of the requests with an idempotency key
+	h = idempotencyWrapper(h)
+
 	// append the auth wrapper
 	h = auth.Wrapper(rr, Namespace)(h)
 
+	// shed the requests over the limits before they're authenticated
+	if n := ctx.Int("max_in_flight"); n > 0 {
+		h = limitWrapper(overload.NewLimiter("api", n, ctx.Int("max_queue_depth")))(h)
+	}
+
+	// serve the batches, each of their requests goes through the wrappers
+	h = batchWrapper(h)
+
+	if n := ctx.Int("max_connections"); n > 0 {
+		opts = append(opts, server.MaxConnections(n))
+	}
+
+	// drain the in-flight requests when stopping
+	if timeout := ctx.Duration("graceful_timeout"); timeout > 0 {
+		opts = append(opts, server.GracefulTimeout(timeout))
+	}
+
 	// create a new api server with wrappers
 	api := httpapi.NewServer(Address)
 	// initialise
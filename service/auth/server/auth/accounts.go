@@ -3,9 +3,11 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"sort"
 	"strings"
 
 	"github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/pagination"
 	pb "github.com/micro/micro/v3/proto/auth"
 	"github.com/micro/micro/v3/service/auth"
 	"github.com/micro/micro/v3/service/errors"
@@ -52,9 +54,14 @@ func (a *Auth) List(ctx context.Context, req *pb.ListAccountsRequest, rsp *pb.Li
 		accounts = append(accounts, r)
 	}
 
+	// sort the accounts so the pages are stable
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+	start, end := pagination.Bounds(len(accounts), req.Offset, req.Limit)
+	rsp.Total = int64(len(accounts))
+
 	// serialize the accounts
-	rsp.Accounts = make([]*pb.Account, 0, len(recs))
-	for _, a := range accounts {
+	rsp.Accounts = make([]*pb.Account, 0, end-start)
+	for _, a := range accounts[start:end] {
 		rsp.Accounts = append(rsp.Accounts, serializeAccount(a))
 	}
 
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/pagination"
+	pb "github.com/micro/micro/v3/proto/auth"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/store"
+)
+
+// the prefix of the records written by the rules handler to the same store
+const storePrefixRules = "rules"
+
+// Namespaces processes RPC calls to manage the namespaces. A namespace exists once an account has
+// been generated in it and is deleted along with all of its accounts and rules.
+type Namespaces struct {
+	Auth *Auth
+}
+
+// List the namespaces which have accounts
+func (n *Namespaces) List(ctx context.Context, req *pb.ListNamespacesRequest, rsp *pb.ListNamespacesResponse) error {
+	// only the server can list all the namespaces
+	if err := namespace.Authorize(ctx, namespace.DefaultNamespace); err == namespace.ErrForbidden {
+		return errors.Forbidden("auth.Namespaces.List", err.Error())
+	} else if err == namespace.ErrUnauthorized {
+		return errors.Unauthorized("auth.Namespaces.List", err.Error())
+	} else if err != nil {
+		return errors.InternalServerError("auth.Namespaces.List", err.Error())
+	}
+
+	// the keys of the accounts are prefixed by their namespace
+	keys, err := n.Auth.Options.Store.List(store.ListPrefix(storePrefixAccounts + joinKey))
+	if err != nil {
+		return errors.InternalServerError("auth.Namespaces.List", "Unable to list from store: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, k := range keys {
+		comps := strings.Split(k, joinKey)
+		if len(comps) != 3 || seen[comps[1]] {
+			continue
+		}
+		seen[comps[1]] = true
+		namespaces = append(namespaces, comps[1])
+	}
+
+	sort.Strings(namespaces)
+	start, end := pagination.Bounds(len(namespaces), req.Offset, req.Limit)
+	rsp.Namespaces = namespaces[start:end]
+	rsp.Total = int64(len(namespaces))
+	return nil
+}
+
+// Delete the namespace with all of its accounts, refresh tokens and rules
+func (n *Namespaces) Delete(ctx context.Context, req *pb.DeleteNamespaceRequest, rsp *pb.DeleteNamespaceResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("auth.Namespaces.Delete", "Missing namespace")
+	}
+	if req.Namespace == namespace.DefaultNamespace {
+		return errors.BadRequest("auth.Namespaces.Delete", "Can't delete the %v namespace", namespace.DefaultNamespace)
+	}
+
+	// only the server can delete namespaces
+	if err := namespace.Authorize(ctx, namespace.DefaultNamespace); err == namespace.ErrForbidden {
+		return errors.Forbidden("auth.Namespaces.Delete", err.Error())
+	} else if err == namespace.ErrUnauthorized {
+		return errors.Unauthorized("auth.Namespaces.Delete", err.Error())
+	} else if err != nil {
+		return errors.InternalServerError("auth.Namespaces.Delete", err.Error())
+	}
+
+	for _, prefix := range []string{storePrefixAccounts, storePrefixAccountsByName, storePrefixRefreshTokens, storePrefixRules} {
+		key := strings.Join([]string{prefix, req.Namespace, ""}, joinKey)
+		keys, err := n.Auth.Options.Store.List(store.ListPrefix(key))
+		if err != nil {
+			return errors.InternalServerError("auth.Namespaces.Delete", "Unable to list from store: %v", err)
+		}
+		for _, k := range keys {
+			if err := n.Auth.Options.Store.Delete(k); err != nil {
+				return errors.InternalServerError("auth.Namespaces.Delete", "Error deleting %v: %v", k, err)
+			}
+		}
+	}
+
+	// clear the namespace cache since the namespace no longer has accounts
+	n.Auth.Lock()
+	delete(n.Auth.namespaces, req.Namespace)
+	n.Auth.Unlock()
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/pagination"
+	"github.com/micro/micro/v3/internal/quota"
+	pb "github.com/micro/micro/v3/proto/auth"
+	"github.com/micro/micro/v3/service/errors"
+)
+
+// Quotas processes RPC calls to manage the quotas enforced by the api gateway and proxy
+type Quotas struct{}
+
+// authorizeQuotas ensures only the server can manage the quotas
+func authorizeQuotas(ctx context.Context, method string) error {
+	if err := namespace.Authorize(ctx, namespace.DefaultNamespace); err == namespace.ErrForbidden {
+		return errors.Forbidden(method, err.Error())
+	} else if err == namespace.ErrUnauthorized {
+		return errors.Unauthorized(method, err.Error())
+	} else if err != nil {
+		return errors.InternalServerError(method, err.Error())
+	}
+	return nil
+}
+
+// Set the quota of an account or namespace
+func (q *Quotas) Set(ctx context.Context, req *pb.SetQuotaRequest, rsp *pb.SetQuotaResponse) error {
+	if req.Quota == nil || len(req.Quota.Namespace) == 0 {
+		return errors.BadRequest("auth.Quotas.Set", "Missing namespace")
+	}
+	if req.Quota.PerMinute < 0 || req.Quota.PerDay < 0 {
+		return errors.BadRequest("auth.Quotas.Set", "Limits can't be negative")
+	}
+	if err := authorizeQuotas(ctx, "auth.Quotas.Set"); err != nil {
+		return err
+	}
+
+	err := quota.Set(&quota.Quota{
+		Namespace: req.Quota.Namespace,
+		Account:   req.Quota.Account,
+		PerMinute: req.Quota.PerMinute,
+		PerDay:    req.Quota.PerDay,
+	})
+	if err != nil {
+		return errors.InternalServerError("auth.Quotas.Set", "Unable to write to store: %v", err)
+	}
+	return nil
+}
+
+// List the quotas of a namespace, or of all the namespaces
+func (q *Quotas) List(ctx context.Context, req *pb.ListQuotasRequest, rsp *pb.ListQuotasResponse) error {
+	if err := authorizeQuotas(ctx, "auth.Quotas.List"); err != nil {
+		return err
+	}
+
+	quotas, err := quota.List(req.Namespace)
+	if err != nil {
+		return errors.InternalServerError("auth.Quotas.List", "Unable to read from store: %v", err)
+	}
+
+	start, end := pagination.Bounds(len(quotas), req.Offset, req.Limit)
+	for _, qu := range quotas[start:end] {
+		rsp.Quotas = append(rsp.Quotas, &pb.Quota{
+			Namespace: qu.Namespace,
+			Account:   qu.Account,
+			PerMinute: qu.PerMinute,
+			PerDay:    qu.PerDay,
+		})
+	}
+	rsp.Total = int64(len(quotas))
+	return nil
+}
+
+// Delete the quota of an account or namespace
+func (q *Quotas) Delete(ctx context.Context, req *pb.DeleteQuotaRequest, rsp *pb.DeleteQuotaResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("auth.Quotas.Delete", "Missing namespace")
+	}
+	if err := authorizeQuotas(ctx, "auth.Quotas.Delete"); err != nil {
+		return err
+	}
+
+	if err := quota.Delete(req.Namespace, req.Account); err == quota.ErrNotFound {
+		return errors.NotFound("auth.Quotas.Delete", "Quota not found")
+	} else if err != nil {
+		return errors.InternalServerError("auth.Quotas.Delete", "Unable to delete from store: %v", err)
+	}
+	return nil
+}
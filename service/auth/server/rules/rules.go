@@ -3,10 +3,12 @@ package rules
 import (
 	"context"
 	"encoding/json"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/pagination"
 	pb "github.com/micro/micro/v3/proto/auth"
 	"github.com/micro/micro/v3/service/auth"
 	"github.com/micro/micro/v3/service/errors"
@@ -199,15 +201,21 @@ func (r *Rules) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListRespo
 	}
 
 	// unmarshal the records
-	rsp.Rules = make([]*pb.Rule, 0, len(recs))
+	rules := make([]*pb.Rule, 0, len(recs))
 	for _, rec := range recs {
 		var r *pb.Rule
 		if err := json.Unmarshal(rec.Value, &r); err != nil {
 			return errors.InternalServerError("auth.Ruls.List", "Error to unmarshaling json: %v. Value: %v", err, string(rec.Value))
 		}
-		rsp.Rules = append(rsp.Rules, r)
+		rules = append(rules, r)
 	}
 
+	// sort the rules so the pages are stable
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Id < rules[j].Id })
+	start, end := pagination.Bounds(len(rules), req.Offset, req.Limit)
+	rsp.Rules = rules[start:end]
+	rsp.Total = int64(len(rules))
+
 	return nil
 }
 
@@ -56,6 +56,8 @@ func Run(ctx *cli.Context) error {
 	pb.RegisterAuthHandler(srv.Server(), authH)
 	pb.RegisterRulesHandler(srv.Server(), ruleH)
 	pb.RegisterAccountsHandler(srv.Server(), authH)
+	pb.RegisterNamespacesHandler(srv.Server(), &authHandler.Namespaces{Auth: authH})
+	pb.RegisterQuotasHandler(srv.Server(), &authHandler.Quotas{})
 
 	// run service
 	if err := srv.Run(); err != nil {
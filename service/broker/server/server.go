@@ -5,6 +5,7 @@ import (
 	"time"
 
 	authns "github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/residency"
 	pb "github.com/micro/micro/v3/proto/broker"
 	"github.com/micro/micro/v3/service"
 	"github.com/micro/micro/v3/service/auth"
@@ -59,6 +60,9 @@ func (h *handler) Publish(ctx context.Context, req *pb.PublishRequest, rsp *pb.E
 	if !ok {
 		return errors.Unauthorized("broker.Broker.Publish", authns.ErrForbidden.Error())
 	}
+	if err := residency.Check(acc.Issuer); err != nil {
+		return errors.Forbidden("broker.Broker.Publish", err.Error())
+	}
 
 	// validate the request
 	if req.Message == nil {
@@ -98,6 +102,9 @@ func (h *handler) Subscribe(ctx context.Context, req *pb.SubscribeRequest, strea
 		return errors.Unauthorized("broker.Broker.Subscribe", authns.ErrForbidden.Error())
 	}
 	ns := acc.Issuer
+	if err := residency.Check(ns); err != nil {
+		return errors.Forbidden("broker.Broker.Subscribe", err.Error())
+	}
 
 	errChan := make(chan error, 1)
 
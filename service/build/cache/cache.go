@@ -0,0 +1,85 @@
+// Package cache implements a builder which caches the binaries built from source
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/micro/micro/v3/service/build"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+// namespace the binaries are written to in the blob store
+const namespace = "micro-build-cache"
+
+// NewBuilder returns a builder which caches the binaries built by b in the blob store, keyed by a
+// hash of the source and the build options. If the store is nil, the default blob store is used.
+func NewBuilder(b build.Builder, s store.BlobStore) build.Builder {
+	return &cache{builder: b, store: s}
+}
+
+type cache struct {
+	builder build.Builder
+	store   store.BlobStore
+}
+
+// Build returns the cached binary for the source if there is one, otherwise the source is built
+// and the result cached
+func (c *cache) Build(src io.Reader, opts ...build.Option) (io.Reader, error) {
+	source, err := ioutil.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := c.store
+	if blob == nil {
+		blob = store.DefaultBlobStore
+	}
+	if blob == nil {
+		return c.builder.Build(bytes.NewReader(source), opts...)
+	}
+
+	key := Key(source, opts...)
+	nsOpt := store.BlobNamespace(namespace)
+	if bin, err := blob.Read(key, nsOpt); err == nil {
+		logger.Infof("Using cached build %v", key)
+		return bin, nil
+	} else if err != store.ErrNotFound {
+		logger.Warnf("Error reading cached build %v: %v", key, err)
+	}
+
+	res, err := c.builder.Build(bytes.NewReader(source), opts...)
+	if err != nil {
+		return nil, err
+	}
+	bin, err := ioutil.ReadAll(res)
+	if err != nil {
+		return nil, err
+	}
+
+	// a failure to cache the build shouldn't fail the build
+	if err := blob.Write(key, bytes.NewReader(bin), nsOpt); err != nil {
+		logger.Warnf("Error caching build %v: %v", key, err)
+	}
+
+	return bytes.NewReader(bin), nil
+}
+
+// Key returns the key the binary built from the source using the options is cached under
+func Key(source []byte, opts ...build.Option) string {
+	var options build.Options
+	for _, o := range opts {
+		o(&options)
+	}
+
+	h := sha256.New()
+	h.Write(source)
+	fmt.Fprintf(h, "archive=%v;entrypoint=%v;os=%v;arch=%v;flags=%v",
+		options.Archive, options.Entrypoint, options.OS, options.Arch, strings.Join(options.Flags, " "))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/micro/micro/v3/service/build"
+	"github.com/micro/micro/v3/service/store/file"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBuilder returns the source prefixed with "bin:" and counts the builds
+type testBuilder struct {
+	builds int
+}
+
+func (t *testBuilder) Build(src io.Reader, opts ...build.Option) (io.Reader, error) {
+	t.builds++
+	b, err := ioutil.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(append([]byte("bin:"), b...)), nil
+}
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "build-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	blob, err := file.NewBlobStore(file.WithDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tb := new(testBuilder)
+	c := NewBuilder(tb, blob)
+
+	buildSrc := func(src string, opts ...build.Option) string {
+		res, err := c.Build(bytes.NewBufferString(src), opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(res)
+		return string(b)
+	}
+
+	assert.Equal(t, "bin:foo", buildSrc("foo"))
+	assert.Equal(t, "bin:foo", buildSrc("foo"))
+	assert.Equal(t, 1, tb.builds, "Expected the second build to be cached")
+
+	// the cache is keyed by the source and the options
+	assert.Equal(t, "bin:bar", buildSrc("bar"))
+	assert.Equal(t, "bin:foo", buildSrc("foo", build.Target("linux", "arm64")))
+	assert.Equal(t, "bin:foo", buildSrc("foo", build.Target("linux", "arm64"), build.Flags("-trimpath")))
+	assert.Equal(t, "bin:foo", buildSrc("foo", build.Target("linux", "arm64"), build.Flags("-trimpath")))
+	assert.Equal(t, 4, tb.builds)
+}
@@ -70,10 +70,22 @@ func (g *golang) Build(src io.Reader, opts ...build.Option) (io.Reader, error) {
 	if _, err := os.Stat(filepath.Join(dir, "vendor")); err == nil {
 		args = append(args, "-mod", "vendor")
 	}
+	args = append(args, options.Flags...)
 
 	// build the binary
 	cmd := exec.Command(g.cmdPath, append(args, ".")...)
 	cmd.Env = append(os.Environ(), "GO111MODULE=auto")
+
+	// cross compile for the target, cgo is disabled since it requires a cross compiler
+	if len(options.OS) > 0 || len(options.Arch) > 0 {
+		cmd.Env = append(cmd.Env, "CGO_ENABLED=0")
+	}
+	if len(options.OS) > 0 {
+		cmd.Env = append(cmd.Env, "GOOS="+options.OS)
+	}
+	if len(options.Arch) > 0 {
+		cmd.Env = append(cmd.Env, "GOARCH="+options.Arch)
+	}
 	cmd.Dir = filepath.Join(dir, options.Entrypoint)
 
 	outp := bytes.NewBuffer(nil)
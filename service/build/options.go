@@ -6,6 +6,12 @@ type Options struct {
 	Archive string
 	// Entrypoint to use, e.g. foo/main.go
 	Entrypoint string
+	// OS to build the binary for, e.g. linux. Defaults to the host OS
+	OS string
+	// Arch to build the binary for, e.g. arm64. Defaults to the host architecture
+	Arch string
+	// Flags to pass to the build, e.g. -ldflags=-s
+	Flags []string
 }
 
 // Option configures one or more options
@@ -24,3 +30,18 @@ func Entrypoint(e string) Option {
 		o.Entrypoint = e
 	}
 }
+
+// Target sets the OS and architecture to cross compile the binary for
+func Target(os, arch string) Option {
+	return func(o *Options) {
+		o.OS = os
+		o.Arch = arch
+	}
+}
+
+// Flags sets additional flags to pass to the build
+func Flags(flags ...string) Option {
+	return func(o *Options) {
+		o.Flags = flags
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/micro/micro/v3/cmd"
 	"github.com/micro/micro/v3/plugin"
@@ -24,13 +25,19 @@ import (
 	auth "github.com/micro/micro/v3/service/auth/server"
 	broker "github.com/micro/micro/v3/service/broker/server"
 	config "github.com/micro/micro/v3/service/config/server"
+	debug "github.com/micro/micro/v3/service/debug/server"
 	events "github.com/micro/micro/v3/service/events/server"
+	id "github.com/micro/micro/v3/service/id/server"
 	network "github.com/micro/micro/v3/service/network/server"
+	notifications "github.com/micro/micro/v3/service/notifications/server"
 	proxy "github.com/micro/micro/v3/service/proxy/server"
 	registry "github.com/micro/micro/v3/service/registry/server"
 	router "github.com/micro/micro/v3/service/router/server"
 	runtime "github.com/micro/micro/v3/service/runtime/server"
 	store "github.com/micro/micro/v3/service/store/server"
+	syncSrv "github.com/micro/micro/v3/service/sync/server"
+	web "github.com/micro/micro/v3/service/web/server"
+	workflow "github.com/micro/micro/v3/service/workflow/server"
 
 	// misc commands
 	"github.com/micro/micro/v3/service/handler/exec"
@@ -134,10 +141,69 @@ func Run(ctx *ccli.Context) {
 	srv.Run()
 }
 
+// allServices are the services run by micro service --all unless disabled
+var allServices = []string{"api", "auth", "broker", "config", "proxy", "registry", "runtime", "store"}
+
+// RunAll runs the services in a single process, sharing the in memory defaults of the memory
+// profile. Services are added with --enable and removed with --disable.
+func RunAll(ctx *ccli.Context) error {
+	if ctx.IsSet("address") || ctx.IsSet("endpoint") {
+		return fmt.Errorf("The address and endpoint can't be set when running all the services")
+	}
+
+	disabled := make(map[string]bool)
+	for _, name := range ctx.StringSlice("disable") {
+		disabled[name] = true
+	}
+
+	var cmds []srvCommand
+	for _, name := range append(allServices, ctx.StringSlice("enable")...) {
+		if disabled[name] {
+			continue
+		}
+		// skip services enabled twice
+		disabled[name] = true
+
+		c, ok := lookupCommand(name)
+		if !ok {
+			return fmt.Errorf("Unknown service: %s", name)
+		}
+		cmds = append(cmds, c)
+	}
+
+	// each service gets a server of its own
+	service.Unified()
+
+	var wg sync.WaitGroup
+
+	for _, c := range cmds {
+		logger.Infof("Starting %s", c.Name)
+
+		wg.Add(1)
+		go func(c srvCommand) {
+			defer wg.Done()
+			c.Command(ctx)
+		}(c)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+func lookupCommand(name string) (srvCommand, bool) {
+	for _, c := range srvCommands {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return srvCommand{}, false
+}
+
 type srvCommand struct {
-	Name    string
-	Command ccli.ActionFunc
-	Flags   []ccli.Flag
+	Name        string
+	Command     ccli.ActionFunc
+	Flags       []ccli.Flag
+	Subcommands []*ccli.Command
 }
 
 var srvCommands = []srvCommand{
@@ -160,15 +226,30 @@ var srvCommands = []srvCommand{
 		Command: config.Run,
 		Flags:   config.Flags,
 	},
+	{
+		Name:    "debug",
+		Command: debug.Run,
+		Flags:   debug.Flags,
+	},
 	{
 		Name:    "events",
 		Command: events.Run,
 	},
+	{
+		Name:    "id",
+		Command: id.Run,
+		Flags:   id.Flags,
+	},
 	{
 		Name:    "network",
 		Command: network.Run,
 		Flags:   network.Flags,
 	},
+	{
+		Name:    "notifications",
+		Command: notifications.Run,
+		Flags:   notifications.Flags,
+	},
 	{
 		Name:    "proxy",
 		Command: proxy.Run,
@@ -179,9 +260,10 @@ var srvCommands = []srvCommand{
 		Command: registry.Run,
 	},
 	{
-		Name:    "router",
-		Command: router.Run,
-		Flags:   router.Flags,
+		Name:        "router",
+		Command:     router.Run,
+		Flags:       router.Flags,
+		Subcommands: router.Commands,
 	},
 	{
 		Name:    "runtime",
@@ -192,6 +274,24 @@ var srvCommands = []srvCommand{
 		Name:    "store",
 		Command: store.Run,
 	},
+	{
+		Name:    "sync",
+		Command: syncSrv.Run,
+	},
+	{
+		Name:    "tunnel",
+		Command: network.RunTunnel,
+		Flags:   network.TunnelFlags,
+	},
+	{
+		Name:    "web",
+		Command: web.Run,
+		Flags:   web.Flags,
+	},
+	{
+		Name:    "workflow",
+		Command: workflow.Run,
+	},
 }
 
 func init() {
@@ -203,8 +303,7 @@ func init() {
 			logger.DefaultLogger.Init(logger.WithFields(map[string]interface{}{"service": c.Name}))
 
 			// run the service
-			c.Command(ctx)
-			return nil
+			return c.Command(ctx)
 		}
 	}
 
@@ -212,10 +311,11 @@ func init() {
 	for i, c := range srvCommands {
 		// construct the command
 		command := &ccli.Command{
-			Name:   c.Name,
-			Flags:  c.Flags,
-			Usage:  fmt.Sprintf("Run micro %v", c.Name),
-			Action: newAction(c),
+			Name:        c.Name,
+			Flags:       c.Flags,
+			Usage:       fmt.Sprintf("Run micro %v", c.Name),
+			Action:      newAction(c),
+			Subcommands: c.Subcommands,
 		}
 
 		// setup the plugins
@@ -237,10 +337,27 @@ func init() {
 		Name:  "service",
 		Usage: "Run a micro service",
 		Action: func(ctx *ccli.Context) error {
+			if ctx.Bool("all") {
+				return RunAll(ctx)
+			}
 			Run(ctx)
 			return nil
 		},
 		Flags: []ccli.Flag{
+			&ccli.BoolFlag{
+				Name:  "all",
+				Usage: "Run the api, auth, broker, config, proxy, registry, runtime and store services in a single process",
+			},
+			&ccli.StringSliceFlag{
+				Name:    "enable",
+				Usage:   "Comma-separated list of services to run with --all in addition to the defaults e.g. events,debug",
+				EnvVars: []string{"MICRO_SERVICE_ENABLE"},
+			},
+			&ccli.StringSliceFlag{
+				Name:    "disable",
+				Usage:   "Comma-separated list of services not to run with --all e.g. proxy",
+				EnvVars: []string{"MICRO_SERVICE_DISABLE"},
+			},
 			&ccli.StringFlag{
 				Name:    "name",
 				Usage:   "Name of the service",
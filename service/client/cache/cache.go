@@ -5,13 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/micro/micro/v3/service/client"
 	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/server"
 	cache "github.com/patrickmn/go-cache"
 )
 
+// CacheControlHeader is the response header services declare how long their responses can be
+// cached for with, it's honoured by the cache client wrapper and passed on by the API gateway
+const CacheControlHeader = "Cache-Control"
+
 // New returns an initialised cache.
 func New() *Cache {
 	return &Cache{
@@ -37,6 +44,11 @@ func (c *Cache) Get(ctx context.Context, req client.Request) (interface{}, bool)
 	return c.cache.Get(key(ctx, req))
 }
 
+// GetWithExpiry gets a response from the cache along with the time it expires
+func (c *Cache) GetWithExpiry(ctx context.Context, req client.Request) (interface{}, time.Time, bool) {
+	return c.cache.GetWithExpiration(key(ctx, req))
+}
+
 // Set a response in the cache
 func (c *Cache) Set(ctx context.Context, req client.Request, rsp interface{}, expiry time.Duration) {
 	c.cache.Set(key(ctx, req), rsp, expiry)
@@ -103,3 +115,41 @@ func CallExpiry(t time.Duration) client.CallOption {
 		Expiry: t,
 	})
 }
+
+// SetMaxAge is called by a handler to declare its response can be cached for d by the callers,
+// a duration of zero or less declares it mustn't be cached. It returns false if the server
+// doesn't support response headers.
+func SetMaxAge(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return server.SetResponseHeader(ctx, CacheControlHeader, "no-store")
+	}
+	return server.SetResponseHeader(ctx, CacheControlHeader, fmt.Sprintf("max-age=%d", int64(d/time.Second)))
+}
+
+// MaxAge returns how long a response can be cached for according to the cache control header of
+// the response, false if it mustn't be cached by a shared cache.
+func MaxAge(header map[string]string) (time.Duration, bool) {
+	var cc string
+	for k, v := range header {
+		if strings.EqualFold(k, CacheControlHeader) {
+			cc = v
+			break
+		}
+	}
+
+	var age time.Duration
+	for _, d := range strings.Split(cc, ",") {
+		d = strings.ToLower(strings.TrimSpace(d))
+		switch {
+		case d == "no-store", d == "no-cache", d == "private":
+			return 0, false
+		case strings.HasPrefix(d, "max-age="):
+			secs, err := strconv.ParseInt(strings.TrimPrefix(d, "max-age="), 10, 64)
+			if err != nil {
+				return 0, false
+			}
+			age = time.Duration(secs) * time.Second
+		}
+	}
+	return age, age > 0
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/server"
 )
 
 func TestCache(t *testing.T) {
@@ -75,3 +76,36 @@ func TestCacheKey(t *testing.T) {
 		}
 	})
 }
+
+func TestMaxAge(t *testing.T) {
+	ctx, rh := server.NewResponseContext(context.TODO())
+	if !SetMaxAge(ctx, time.Minute) {
+		t.Fatalf("Expected the max age to be set")
+	}
+	if d, ok := MaxAge(rh.Header()); !ok || d != time.Minute {
+		t.Fatalf("Expected the response to be cacheable for a minute, got %v %v", d, ok)
+	}
+	SetMaxAge(ctx, 0)
+	if _, ok := MaxAge(rh.Header()); ok {
+		t.Fatalf("Expected the response not to be cacheable")
+	}
+	if SetMaxAge(context.TODO(), time.Minute) {
+		t.Fatalf("Expected the max age not to be set without a response context")
+	}
+
+	tt := map[string]bool{
+		"":                      false,
+		"max-age=10":            true,
+		"public, max-age=10":    true,
+		"private, max-age=10":   false,
+		"max-age=10, no-cache":  false,
+		"max-age=0":             false,
+		"max-age=ten":           false,
+		"MAX-AGE=10,must-check": true,
+	}
+	for cc, cacheable := range tt {
+		if _, ok := MaxAge(map[string]string{"cache-control": cc}); ok != cacheable {
+			t.Errorf("Expected %q cacheable to be %v", cc, cacheable)
+		}
+	}
+}
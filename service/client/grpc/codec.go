@@ -27,6 +27,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/micro/micro/v3/internal/codec"
 	"github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/cbor"
+	"github.com/micro/micro/v3/internal/codec/msgpack"
 	"github.com/oxtoacart/bpool"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/encoding"
@@ -36,6 +38,8 @@ type jsonCodec struct{}
 type protoCodec struct{}
 type bytesCodec struct{}
 type wrapCodec struct{ encoding.Codec }
+type msgpackCodec struct{ msgpack.Marshaler }
+type cborCodec struct{ cbor.Marshaler }
 
 var jsonpbMarshaler = &jsonpb.Marshaler{}
 var useNumber bool
@@ -53,6 +57,10 @@ var (
 		"application/grpc+json":    jsonCodec{},
 		"application/grpc+proto":   protoCodec{},
 		"application/grpc+bytes":   bytesCodec{},
+		"application/msgpack":      msgpackCodec{},
+		"application/grpc+msgpack": msgpackCodec{},
+		"application/cbor":         cborCodec{},
+		"application/grpc+cbor":    cborCodec{},
 	}
 )
 
@@ -220,3 +228,11 @@ func (g *grpcCodec) Close() error {
 func (g *grpcCodec) String() string {
 	return g.c.Name()
 }
+
+func (msgpackCodec) Name() string {
+	return "msgpack"
+}
+
+func (cborCodec) Name() string {
+	return "cbor"
+}
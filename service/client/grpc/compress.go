@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"strings"
+
+	raw "github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/compress"
+	"github.com/micro/micro/v3/service/errors"
+	"google.golang.org/grpc/encoding"
+	gmetadata "google.golang.org/grpc/metadata"
+)
+
+// compress returns the body of the request to send, compressed if it's above the threshold and
+// the server at the address is known to accept compression. The compression is set in the header.
+func (g *grpcClient) compress(addr string, cf encoding.Codec, header map[string]string, body interface{}) (interface{}, error) {
+	g.RLock()
+	accept := g.accepts[addr]
+	g.RUnlock()
+
+	name := compress.Negotiate(accept, g.opts.Compression)
+	if len(name) == 0 {
+		return body, nil
+	}
+
+	b, err := cf.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) <= g.opts.CompressionThreshold {
+		return &raw.Frame{Data: b}, nil
+	}
+	if b, err = compress.Compress(name, b); err != nil {
+		return nil, err
+	}
+
+	header[strings.ToLower(compress.EncodingHeader)] = name
+	return &raw.Frame{Data: b}, nil
+}
+
+// decompress decodes the response into rsp, decompressing it if the header says it's compressed.
+// The compression the server accepts is kept from the header to compress the requests after.
+func (g *grpcClient) decompress(addr string, cf encoding.Codec, md gmetadata.MD, b []byte, rsp interface{}) error {
+	if v := md.Get(strings.ToLower(compress.AcceptHeader)); len(v) > 0 {
+		g.Lock()
+		g.accepts[addr] = strings.Join(v, ",")
+		g.Unlock()
+	}
+
+	if v := md.Get(strings.ToLower(compress.EncodingHeader)); len(v) > 0 {
+		var err error
+		if b, err = compress.Decompress(v[0], b); err != nil {
+			return errors.InternalServerError("go.micro.client", "Error decompressing response: %v", err)
+		}
+	}
+
+	if err := cf.Unmarshal(b, rsp); err != nil {
+		return errors.InternalServerError("go.micro.client", err.Error())
+	}
+	return nil
+}
@@ -22,12 +22,15 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"net/textproto"
 	"reflect"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	raw "github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/compress"
 	"github.com/micro/micro/v3/service/broker"
 	"github.com/micro/micro/v3/service/client"
 	"github.com/micro/micro/v3/service/context/metadata"
@@ -43,12 +46,18 @@ type grpcClient struct {
 	opts client.Options
 	pool *pool
 	once atomic.Value
+
+	sync.RWMutex
+	// compression accepted by the servers keyed by address
+	accepts map[string]string
 }
 
 func init() {
 	encoding.RegisterCodec(wrapCodec{jsonCodec{}})
 	encoding.RegisterCodec(wrapCodec{protoCodec{}})
 	encoding.RegisterCodec(wrapCodec{bytesCodec{}})
+	encoding.RegisterCodec(wrapCodec{msgpackCodec{}})
+	encoding.RegisterCodec(wrapCodec{cborCodec{}})
 }
 
 // secure returns the dial option for whether its a secure or insecure connection
@@ -98,19 +107,40 @@ func (g *grpcClient) call(ctx context.Context, addr string, req client.Request,
 		header = make(map[string]string)
 	}
 
-	// set timeout in nanoseconds
-	header["timeout"] = fmt.Sprintf("%d", opts.RequestTimeout)
+	// set the remaining budget of the request in nanoseconds, the time spent on previous attempts
+	// is taken off so the deadline isn't reset by retries
+	timeout := opts.RequestTimeout
+	if d, ok := ctx.Deadline(); ok {
+		if timeout = time.Until(d); timeout <= 0 {
+			return errors.Timeout("go.micro.client", "deadline exceeded")
+		}
+	}
+	header["timeout"] = fmt.Sprintf("%d", timeout)
 	// set the content type for the request
 	header["x-content-type"] = req.ContentType()
 
-	md := gmetadata.New(header)
-	ctx = gmetadata.NewOutgoingContext(ctx, md)
-
 	cf, err := g.newGRPCCodec(req.ContentType())
 	if err != nil {
 		return errors.InternalServerError("go.micro.client", err.Error())
 	}
 
+	// offer the compression accepted for the response
+	if len(g.opts.Compression) > 0 {
+		header[strings.ToLower(compress.AcceptHeader)] = strings.Join(g.opts.Compression, ",")
+	}
+
+	// compress the request if the server is known to accept it
+	body, err := g.compress(addr, cf, header, req.Body())
+	if err != nil {
+		return errors.InternalServerError("go.micro.client", err.Error())
+	}
+
+	// the response may be compressed if compression was offered
+	compressed := len(header[strings.ToLower(compress.AcceptHeader)]) > 0
+
+	md := gmetadata.New(header)
+	ctx = gmetadata.NewOutgoingContext(ctx, md)
+
 	maxRecvMsgSize := g.maxRecvMsgSizeValue()
 	maxSendMsgSize := g.maxSendMsgSizeValue()
 
@@ -139,21 +169,43 @@ func (g *grpcClient) call(ctx context.Context, addr string, req client.Request,
 	}()
 
 	ch := make(chan error, 1)
+	// the header of the response
+	var rmd gmetadata.MD
 
 	go func() {
 		grpcCallOptions := []grpc.CallOption{
 			grpc.ForceCodec(cf),
-			grpc.CallContentSubtype(cf.Name())}
+			grpc.CallContentSubtype(cf.Name()),
+			grpc.Header(&rmd)}
 		if opts := g.getGrpcCallOptions(); opts != nil {
 			grpcCallOptions = append(grpcCallOptions, opts...)
 		}
-		err := cc.Invoke(ctx, methodToGRPC(req.Service(), req.Endpoint()), req.Body(), rsp, grpcCallOptions...)
-		ch <- microError(err)
+		if !compressed {
+			err := cc.Invoke(ctx, methodToGRPC(req.Service(), req.Endpoint()), body, rsp, grpcCallOptions...)
+			ch <- microError(err)
+			return
+		}
+
+		// receive the raw response to decompress it
+		frame := &raw.Frame{}
+		if err := cc.Invoke(ctx, methodToGRPC(req.Service(), req.Endpoint()), body, frame, grpcCallOptions...); err != nil {
+			ch <- microError(err)
+			return
+		}
+		ch <- g.decompress(addr, cf, rmd, frame.Data, rsp)
 	}()
 
 	select {
 	case err := <-ch:
 		grr = err
+		// the response was received, pass its header back
+		if err == nil && opts.ResponseHeader != nil {
+			for k, v := range rmd {
+				if len(v) > 0 {
+					opts.ResponseHeader[textproto.CanonicalMIMEHeaderKey(k)] = v[0]
+				}
+			}
+		}
 	case <-ctx.Done():
 		grr = errors.Timeout("go.micro.client", "%v", ctx.Err())
 	}
@@ -426,7 +478,7 @@ func (g *grpcClient) Call(ctx context.Context, req client.Request, rsp interface
 	}
 
 	// balance the list of nodes
-	next, err := callOpts.Selector.Select(routes)
+	next, err := callOpts.Selector.Select(routes, callOpts.SelectOptions...)
 	if err != nil {
 		return err
 	}
@@ -451,7 +503,7 @@ func (g *grpcClient) Call(ctx context.Context, req client.Request, rsp interface
 		err = gcall(ctx, node, req, rsp, callOpts)
 
 		// record the result of the call to inform future routing decisions
-		g.opts.Selector.Record(node, err)
+		callOpts.Selector.Record(node, err)
 
 		// try and transform the error to a go-micro error
 		if verr, ok := err.(*errors.Error); ok {
@@ -541,7 +593,7 @@ func (g *grpcClient) Stream(ctx context.Context, req client.Request, opts ...cli
 	}
 
 	// balance the list of nodes
-	next, err := callOpts.Selector.Select(routes)
+	next, err := callOpts.Selector.Select(routes, callOpts.SelectOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -566,14 +618,13 @@ func (g *grpcClient) Stream(ctx context.Context, req client.Request, opts ...cli
 		err = g.stream(ctx, node, req, stream, callOpts)
 
 		// record the result of the call to inform future routing decisions
-		g.opts.Selector.Record(node, err)
+		callOpts.Selector.Record(node, err)
 
 		// try and transform the error to a go-micro error
 		if verr, ok := err.(*errors.Error); ok {
 			return nil, verr
 		}
 
-		g.opts.Selector.Record(node, err)
 		return stream, err
 	}
 
@@ -723,7 +774,8 @@ func newClient(opts ...client.Option) client.Client {
 	}
 
 	rc := &grpcClient{
-		opts: options,
+		opts:    options,
+		accepts: make(map[string]string),
 	}
 	rc.once.Store(false)
 
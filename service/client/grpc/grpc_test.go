@@ -28,20 +28,40 @@ import (
 	"github.com/micro/micro/v3/service/router"
 	regRouter "github.com/micro/micro/v3/service/router/registry"
 	pgrpc "google.golang.org/grpc"
-	pb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-// server is used to implement helloworld.GreeterServer.
-type greeterServer struct {
-	pb.UnimplementedGreeterServer
+// greeter is the helloworld.Greeter service, the name and message are string values
+type greeter interface {
+	SayHello(context.Context, *wrapperspb.StringValue) (*wrapperspb.StringValue, error)
+}
+
+var greeterDesc = pgrpc.ServiceDesc{
+	ServiceName: "helloworld.Greeter",
+	HandlerType: (*greeter)(nil),
+	Methods: []pgrpc.MethodDesc{
+		{
+			MethodName: "SayHello",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ pgrpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(wrapperspb.StringValue)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return srv.(greeter).SayHello(ctx, in)
+			},
+		},
+	},
 }
 
+// server is used to implement helloworld.GreeterServer.
+type greeterServer struct{}
+
 // SayHello implements helloworld.GreeterServer
-func (g *greeterServer) SayHello(ctx context.Context, in *pb.HelloRequest) (*pb.HelloReply, error) {
-	if in.Name == "Error" {
+func (g *greeterServer) SayHello(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+	if in.Value == "Error" {
 		return nil, &errors.Error{Id: "1", Code: 99, Detail: "detail"}
 	}
-	return &pb.HelloReply{Message: "Hello " + in.Name}, nil
+	return wrapperspb.String("Hello " + in.Value), nil
 }
 
 func TestGRPCClient(t *testing.T) {
@@ -52,7 +72,7 @@ func TestGRPCClient(t *testing.T) {
 	defer l.Close()
 
 	s := pgrpc.NewServer()
-	pb.RegisterGreeterServer(s, &greeterServer{})
+	s.RegisterService(&greeterDesc, &greeterServer{})
 
 	go s.Serve(l)
 	defer s.Stop()
@@ -87,27 +107,23 @@ func TestGRPCClient(t *testing.T) {
 	}
 
 	for _, method := range testMethods {
-		req := c.NewRequest("helloworld", method, &pb.HelloRequest{
-			Name: "John",
-		})
+		req := c.NewRequest("helloworld", method, wrapperspb.String("John"))
 
-		rsp := pb.HelloReply{}
+		rsp := wrapperspb.StringValue{}
 
 		err = c.Call(context.TODO(), req, &rsp)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		if rsp.Message != "Hello John" {
-			t.Fatalf("Got unexpected response %v", rsp.Message)
+		if rsp.Value != "Hello John" {
+			t.Fatalf("Got unexpected response %v", rsp.Value)
 		}
 	}
 
-	req := c.NewRequest("helloworld", "/helloworld.Greeter/SayHello", &pb.HelloRequest{
-		Name: "Error",
-	})
+	req := c.NewRequest("helloworld", "/helloworld.Greeter/SayHello", wrapperspb.String("Error"))
 
-	rsp := pb.HelloReply{}
+	rsp := wrapperspb.StringValue{}
 
 	err = c.Call(context.TODO(), req, &rsp)
 	if err == nil {
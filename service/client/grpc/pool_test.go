@@ -24,7 +24,7 @@ import (
 
 	"google.golang.org/grpc"
 	pgrpc "google.golang.org/grpc"
-	pb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func testPool(t *testing.T, size int, ttl time.Duration, idle int, ms int) {
@@ -36,7 +36,7 @@ func testPool(t *testing.T, size int, ttl time.Duration, idle int, ms int) {
 	defer l.Close()
 
 	s := pgrpc.NewServer()
-	pb.RegisterGreeterServer(s, &greeterServer{})
+	s.RegisterService(&greeterDesc, &greeterServer{})
 
 	go s.Serve(l)
 	defer s.Stop()
@@ -51,15 +51,15 @@ func testPool(t *testing.T, size int, ttl time.Duration, idle int, ms int) {
 			t.Fatal(err)
 		}
 
-		rsp := pb.HelloReply{}
+		rsp := wrapperspb.StringValue{}
 
-		err = cc.Invoke(context.TODO(), "/helloworld.Greeter/SayHello", &pb.HelloRequest{Name: "John"}, &rsp)
+		err = cc.Invoke(context.TODO(), "/helloworld.Greeter/SayHello", wrapperspb.String("John"), &rsp)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		if rsp.Message != "Hello John" {
-			t.Fatalf("Got unexpected response %v", rsp.Message)
+		if rsp.Value != "Hello John" {
+			t.Fatalf("Got unexpected response %v", rsp.Value)
 		}
 
 		// release the conn
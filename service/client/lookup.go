@@ -20,6 +20,7 @@ import (
 	"context"
 	"sort"
 
+	"github.com/micro/micro/v3/internal/selector"
 	"github.com/micro/micro/v3/service/errors"
 	"github.com/micro/micro/v3/service/router"
 )
@@ -56,6 +57,9 @@ func LookupRoute(ctx context.Context, req Request, opts CallOptions) ([]string,
 		return routes[i].Metric < routes[j].Metric
 	})
 
+	// pass the zones of the routes to selectors which select by zone
+	LocateRoutes(opts.Selector, routes)
+
 	var addrs []string
 
 	for _, route := range routes {
@@ -64,3 +68,15 @@ func LookupRoute(ctx context.Context, req Request, opts CallOptions) ([]string,
 
 	return addrs, nil
 }
+
+// LocateRoutes sets the zones of the routes, from the zone metadata of the nodes, if the
+// selector selects by zone
+func LocateRoutes(s selector.Selector, routes []router.Route) {
+	l, ok := s.(selector.Locator)
+	if !ok {
+		return
+	}
+	for _, route := range routes {
+		l.Locate(route.Address, route.Metadata["zone"])
+	}
+}
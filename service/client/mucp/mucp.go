@@ -96,8 +96,15 @@ func (r *rpcClient) call(ctx context.Context, addr string, req client.Request, r
 		}
 	}
 
-	// set timeout in nanoseconds
-	msg.Header["Timeout"] = fmt.Sprintf("%d", opts.RequestTimeout)
+	// set the remaining budget of the request in nanoseconds, the time spent on previous attempts
+	// is taken off so the deadline isn't reset by retries
+	timeout := opts.RequestTimeout
+	if d, ok := ctx.Deadline(); ok {
+		if timeout = time.Until(d); timeout <= 0 {
+			return errors.Timeout("go.micro.client", "deadline exceeded")
+		}
+	}
+	msg.Header["Timeout"] = fmt.Sprintf("%d", timeout)
 	// set the content type for the request
 	msg.Header["Content-Type"] = req.ContentType()
 	// set the accept header
@@ -172,6 +179,12 @@ func (r *rpcClient) call(ctx context.Context, addr string, req client.Request, r
 
 	select {
 	case err := <-ch:
+		// the response was received, pass its headers back
+		if err == nil && opts.ResponseHeader != nil {
+			for k, v := range rsp.Header() {
+				opts.ResponseHeader[k] = v
+			}
+		}
 		return err
 	case <-ctx.Done():
 		grr = errors.Timeout("go.micro.client", fmt.Sprintf("%v", ctx.Err()))
@@ -376,7 +389,7 @@ func (r *rpcClient) Call(ctx context.Context, request client.Request, response i
 	}
 
 	// balance the list of nodes
-	next, err := callOpts.Selector.Select(routes)
+	next, err := callOpts.Selector.Select(routes, callOpts.SelectOptions...)
 	if err != nil {
 		return err
 	}
@@ -401,7 +414,7 @@ func (r *rpcClient) Call(ctx context.Context, request client.Request, response i
 		err = rcall(ctx, node, request, response, callOpts)
 
 		// record the result of the call to inform future routing decisions
-		r.opts.Selector.Record(node, err)
+		callOpts.Selector.Record(node, err)
 
 		return err
 	}
@@ -484,7 +497,7 @@ func (r *rpcClient) Stream(ctx context.Context, request client.Request, opts ...
 	}
 
 	// balance the list of nodes
-	next, err := callOpts.Selector.Select(routes)
+	next, err := callOpts.Selector.Select(routes, callOpts.SelectOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -508,7 +521,7 @@ func (r *rpcClient) Stream(ctx context.Context, request client.Request, opts ...
 		stream, err := r.stream(ctx, node, request, callOpts)
 
 		// record the result of the call to inform future routing decisions
-		r.opts.Selector.Record(node, err)
+		callOpts.Selector.Record(node, err)
 
 		return stream, err
 	}
@@ -129,6 +129,9 @@ func (r *rpcStream) Recv(msg interface{}) error {
 			r.err = err
 		}
 	default:
+		if rsp, ok := r.response.(*rpcResponse); ok {
+			rsp.header = resp.Header
+		}
 		r.Unlock()
 		err = r.codec.ReadBody(msg)
 		r.Lock()
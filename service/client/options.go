@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/micro/micro/v3/internal/codec"
+	"github.com/micro/micro/v3/internal/codec/compress"
 	"github.com/micro/micro/v3/internal/network/transport"
 	thttp "github.com/micro/micro/v3/internal/network/transport/http"
 	"github.com/micro/micro/v3/internal/selector"
@@ -52,6 +53,11 @@ type Options struct {
 	PoolSize int
 	PoolTTL  time.Duration
 
+	// Compression accepted for payloads in order of preference
+	Compression []string
+	// CompressionThreshold is the size in bytes above which payloads are compressed
+	CompressionThreshold int
+
 	// Middleware for client
 	Wrappers []Wrapper
 
@@ -88,6 +94,8 @@ type CallOptions struct {
 	AuthToken bool
 	// Network to lookup the route within
 	Network string
+	// ResponseHeader is filled with the headers of the response
+	ResponseHeader map[string]string
 
 	// Middleware for low level call func
 	CallWrappers []CallWrapper
@@ -137,6 +145,8 @@ func NewOptions(options ...Option) Options {
 		Router:    regRouter.NewRouter(),
 		Selector:  roundrobin.NewSelector(),
 		Transport: thttp.NewTransport(),
+
+		CompressionThreshold: compress.DefaultThreshold,
 	}
 
 	for _, o := range options {
@@ -188,6 +198,21 @@ func PoolTTL(d time.Duration) Option {
 	}
 }
 
+// Compression sets the compression accepted for payloads in order of preference
+// e.g. gzip. Requests are only compressed once the server is known to accept it.
+func Compression(names ...string) Option {
+	return func(o *Options) {
+		o.Compression = names
+	}
+}
+
+// CompressionThreshold sets the size in bytes above which payloads are compressed
+func CompressionThreshold(n int) Option {
+	return func(o *Options) {
+		o.CompressionThreshold = n
+	}
+}
+
 // Transport to use for communication e.g http, rabbitmq, etc
 func Transport(t transport.Transport) Option {
 	return func(o *Options) {
@@ -374,6 +399,13 @@ func WithNetwork(n string) CallOption {
 	}
 }
 
+// WithResponseHeader is a CallOption which fills the map with the headers of the response
+func WithResponseHeader(h map[string]string) CallOption {
+	return func(o *CallOptions) {
+		o.ResponseHeader = h
+	}
+}
+
 // WithRouter sets the router to use for this call
 func WithRouter(r router.Router) CallOption {
 	return func(o *CallOptions) {
@@ -71,6 +71,34 @@ func (m *srv) Delete(path string, options ...config.Option) error {
 	return err
 }
 
+// Watch the value at a path for changes, secrets aren't included in the values
+func (m *srv) Watch(path string, options ...config.Option) (config.Watcher, error) {
+	stream, err := m.client.Watch(context.DefaultContext, &proto.WatchRequest{
+		Namespace: m.namespace,
+		Path:      path,
+	}, client.WithAuthToken())
+	if err != nil {
+		return nil, err
+	}
+	return &watcher{stream: stream}, nil
+}
+
+type watcher struct {
+	stream proto.Config_WatchService
+}
+
+func (w *watcher) Next() (config.Value, error) {
+	rsp, err := w.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return config.NewJSONValue([]byte(rsp.Value.Data)), nil
+}
+
+func (w *watcher) Stop() error {
+	return w.stream.Close()
+}
+
 func (m *srv) String() string {
 	return "service"
 }
@@ -16,12 +16,16 @@
 package config
 
 import (
+	"errors"
 	"time"
 )
 
 // DefaultConfig implementation
 var DefaultConfig Config
 
+// ErrWatchNotSupported is returned when watching a config which can't be watched
+var ErrWatchNotSupported = errors.New("config can't be watched")
+
 // Config is an interface abstraction for dynamic configuration
 type Config interface {
 	Get(path string, options ...Option) (Value, error)
@@ -43,6 +47,14 @@ type Value interface {
 	Bytes() []byte
 }
 
+// Watcher receives the changes to the values at a path
+type Watcher interface {
+	// Next blocks until the value at the path is changed and returns the new value
+	Next() (Value, error)
+	// Stop watching
+	Stop() error
+}
+
 type Options struct {
 	Secret bool
 }
@@ -73,3 +85,14 @@ func Set(path string, val interface{}, options ...Option) error {
 func Delete(path string, options ...Option) error {
 	return DefaultConfig.Delete(path, options...)
 }
+
+// Watch the value at a path for changes, if the default config can be watched
+func Watch(path string, options ...Option) (Watcher, error) {
+	w, ok := DefaultConfig.(interface {
+		Watch(path string, options ...Option) (Watcher, error)
+	})
+	if !ok {
+		return nil, ErrWatchNotSupported
+	}
+	return w.Watch(path, options...)
+}
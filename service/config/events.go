@@ -0,0 +1,20 @@
+package config
+
+const (
+	// EventTopic the events are published to when config is changed
+	EventTopic = "config"
+
+	// EventValueSet is the type of event published when a value is set
+	EventValueSet = "value.set"
+	// EventValueDeleted is the type of event published when a value is deleted
+	EventValueDeleted = "value.deleted"
+)
+
+// EventPayload which is published with config events. The value isn't included since it may be
+// a secret.
+type EventPayload struct {
+	Type      string
+	Namespace string
+	Path      string
+	Secret    bool
+}
@@ -14,6 +14,7 @@ import (
 	pb "github.com/micro/micro/v3/proto/config"
 	"github.com/micro/micro/v3/service/config"
 	merrors "github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/events"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/store"
 )
@@ -29,7 +30,8 @@ var (
 )
 
 type Config struct {
-	secret []byte
+	secret   []byte
+	watchers *watchers
 }
 
 func NewConfig(key string) *Config {
@@ -45,7 +47,8 @@ func NewConfig(key string) *Config {
 	}
 
 	return &Config{
-		secret: dec,
+		secret:   dec,
+		watchers: new(watchers),
 	}
 }
 
@@ -300,10 +303,14 @@ func (c *Config) Set(ctx context.Context, req *pb.SetRequest, rsp *pb.SetRespons
 		err = c.setValue(values, secret, req.Path, data)
 	}
 
-	return store.Write(&store.Record{
+	if err := store.Write(&store.Record{
 		Key:   req.Namespace,
 		Value: values.Bytes(),
-	})
+	}); err != nil {
+		return err
+	}
+	publishEvent(config.EventValueSet, ns, req.Path, secret)
+	return nil
 }
 
 func cleanNode(values *config.JSONValues, path string) {
@@ -366,8 +373,29 @@ func (c *Config) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Dele
 	values := config.NewJSONValues(ch[0].Value)
 
 	values.Delete(req.Path)
-	return store.Write(&store.Record{
+	if err := store.Write(&store.Record{
 		Key:   ns,
 		Value: values.Bytes(),
-	})
+	}); err != nil {
+		return err
+	}
+	publishEvent(config.EventValueDeleted, ns, req.Path, false)
+	return nil
+}
+
+// publishEvent publishes the change to the config, the change has been made so errors are only
+// logged
+func publishEvent(typ, ns, path string, secret bool) {
+	ev := &config.EventPayload{
+		Type:      typ,
+		Namespace: ns,
+		Path:      path,
+		Secret:    secret,
+	}
+	if err := events.Publish(config.EventTopic, ev, events.WithMetadata(map[string]string{
+		"type":      typ,
+		"namespace": ns,
+	})); err != nil {
+		logger.Warnf("Error publishing config event: %v", err)
+	}
 }
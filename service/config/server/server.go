@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/micro/micro/v3/internal/wrapper"
 	pb "github.com/micro/micro/v3/proto/config"
 	"github.com/micro/micro/v3/service"
 	"github.com/micro/micro/v3/service/logger"
@@ -27,10 +28,16 @@ var (
 
 // Run micro config
 func Run(c *cli.Context) error {
-	srv := service.New(
+	srvOpts := []service.Option{
 		service.Name(name),
 		service.Address(address),
-	)
+	}
+	// serve the control-plane requests before the watches when overloaded
+	if limit := c.Int("priority_limit"); limit > 0 {
+		srvOpts = append(srvOpts, service.WrapHandler(wrapper.PriorityHandler(limit)))
+	}
+
+	srv := service.New(srvOpts...)
 
 	mustore.DefaultStore.Init(store.Table("config"))
 
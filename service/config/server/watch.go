@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/config"
+	"github.com/micro/micro/v3/service/config"
+	merrors "github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/events"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+// watchBuffer is the number of changes buffered for a watcher, changes are dropped for watchers
+// which fall further behind
+const watchBuffer = 64
+
+// watchers fan out the config events to the open Watch streams. The events are consumed once
+// regardless of the number of watchers.
+type watchers struct {
+	sync.Mutex
+	consuming bool
+	chans     map[chan *config.EventPayload]bool
+}
+
+// add a watcher, consuming the events if this is the first
+func (w *watchers) add(ch chan *config.EventPayload) error {
+	w.Lock()
+	defer w.Unlock()
+
+	if !w.consuming {
+		evChan, err := events.Consume(config.EventTopic)
+		if err != nil {
+			return err
+		}
+		w.consuming = true
+		go w.run(evChan)
+	}
+
+	if w.chans == nil {
+		w.chans = make(map[chan *config.EventPayload]bool)
+	}
+	w.chans[ch] = true
+	return nil
+}
+
+// remove a watcher
+func (w *watchers) remove(ch chan *config.EventPayload) {
+	w.Lock()
+	defer w.Unlock()
+	delete(w.chans, ch)
+}
+
+// run sends the events to the watchers until the channel is closed
+func (w *watchers) run(evChan <-chan events.Event) {
+	for ev := range evChan {
+		var payload config.EventPayload
+		if err := ev.Unmarshal(&payload); err != nil {
+			logger.Warnf("Error unmarshaling config event: %v", err)
+			continue
+		}
+
+		w.Lock()
+		for ch := range w.chans {
+			select {
+			case ch <- &payload:
+			default:
+				logger.Warnf("Dropping config event %v, the watcher is too slow", payload.Type)
+			}
+		}
+		w.Unlock()
+	}
+
+	w.Lock()
+	w.consuming = false
+	w.Unlock()
+}
+
+// overlaps returns true if a change to one path changes the value at the other, i.e. one path is
+// the other or within it
+func overlaps(a, b string) bool {
+	if len(a) == 0 || len(b) == 0 || a == b {
+		return true
+	}
+	return strings.HasPrefix(a, b+pathSplitter) || strings.HasPrefix(b, a+pathSplitter)
+}
+
+// Watch streams the value at a path each time it's changed, so services receive the changes to
+// their config without polling
+func (c *Config) Watch(ctx context.Context, req *pb.WatchRequest, stream pb.Config_WatchStream) error {
+	if len(req.Namespace) == 0 {
+		req.Namespace = defaultNamespace
+	}
+
+	// authorize the request
+	if err := namespace.Authorize(ctx, req.Namespace); err == namespace.ErrForbidden {
+		return merrors.Forbidden("config.Config.Watch", err.Error())
+	} else if err == namespace.ErrUnauthorized {
+		return merrors.Unauthorized("config.Config.Watch", err.Error())
+	} else if err != nil {
+		return merrors.InternalServerError("config.Config.Watch", err.Error())
+	}
+
+	ch := make(chan *config.EventPayload, watchBuffer)
+	if err := c.watchers.add(ch); err != nil {
+		return merrors.InternalServerError("config.Config.Watch", "Error consuming events: %v", err)
+	}
+	defer c.watchers.remove(ch)
+	defer stream.Close()
+
+	for {
+		select {
+		case ev := <-ch:
+			if ev.Namespace != req.Namespace || !overlaps(ev.Path, req.Path) {
+				continue
+			}
+
+			// the value at the path watched, null if it was deleted
+			rsp := &pb.WatchResponse{Type: ev.Type, Path: ev.Path, Value: &pb.Value{Data: "null"}}
+			var get pb.GetResponse
+			if err := c.Get(ctx, &pb.GetRequest{Namespace: req.Namespace, Path: req.Path}, &get); err == nil {
+				rsp.Value = get.Value
+			} else if verr := merrors.FromError(err); verr.Code != 404 {
+				return err
+			}
+			if err := stream.Send(rsp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/config"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/events"
+	memStream "github.com/micro/micro/v3/service/events/stream/memory"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+// testStream records the responses sent
+type testStream struct {
+	ctx  context.Context
+	rsps chan *pb.WatchResponse
+}
+
+func (t *testStream) Context() context.Context       { return t.ctx }
+func (t *testStream) SendMsg(v interface{}) error    { return nil }
+func (t *testStream) RecvMsg(v interface{}) error    { return nil }
+func (t *testStream) Close() error                   { return nil }
+func (t *testStream) Send(r *pb.WatchResponse) error { t.rsps <- r; return nil }
+
+func TestWatch(t *testing.T) {
+	store.DefaultStore = memory.NewStore()
+	stream, err := memStream.NewStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	events.DefaultStream = stream
+
+	c := NewConfig("")
+	ctx, cancel := context.WithCancel(auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "foo", Issuer: defaultNamespace}))
+	defer cancel()
+
+	s := &testStream{ctx: ctx, rsps: make(chan *pb.WatchResponse, 10)}
+	go c.Watch(ctx, &pb.WatchRequest{Path: "foo"}, s)
+	time.Sleep(time.Millisecond * 50)
+
+	set := func(path, data string) {
+		req := &pb.SetRequest{Namespace: defaultNamespace, Path: path, Value: &pb.Value{Data: data}}
+		if err := c.Set(ctx, req, &pb.SetResponse{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := func(typ, path, data string) {
+		select {
+		case rsp := <-s.rsps:
+			if rsp.Type != typ || rsp.Path != path || rsp.Value.Data != data {
+				t.Fatalf("Expected %v of %v with %v, got %v of %v with %v", typ, path, data, rsp.Type, rsp.Path, rsp.Value.Data)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %v of %v", typ, path)
+		}
+	}
+
+	// the changes within the path watched are sent with the value at the path
+	set("foo.bar", `"baz"`)
+	expect("value.set", "foo.bar", `{"bar":"baz"}`)
+
+	// the changes to other paths aren't
+	set("food", `1`)
+	set("foo", `{"bar":"qux"}`)
+	expect("value.set", "foo", `{"bar":"qux"}`)
+
+	if err := c.Delete(ctx, &pb.DeleteRequest{Namespace: defaultNamespace, Path: "foo"}, &pb.DeleteResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	expect("value.deleted", "foo", "null")
+}
@@ -0,0 +1,36 @@
+package debug
+
+const (
+	// AlertTopic is the broker topic the debug service publishes alerts to when it detects an
+	// anomaly in the metrics of a service
+	AlertTopic = "debug.alert"
+
+	// AlertGoroutines is raised when the number of goroutines of a node doubles
+	AlertGoroutines = "goroutines"
+	// AlertHeapGrowth is raised when the heap of a node grows steadily
+	AlertHeapGrowth = "heap_growth"
+	// AlertGCPause is raised when the time a node spends paused for garbage collection spikes
+	AlertGCPause = "gc_pause"
+	// AlertSLOBurn is raised when the error budget of a service level objective is burning fast
+	AlertSLOBurn = "slo_burn"
+	// AlertProbeFailure is raised when a probe fails several times in a row
+	AlertProbeFailure = "probe_failure"
+	// AlertPartition is raised by the network when it splits into partitions or heals
+	AlertPartition = "network_partition"
+)
+
+// Alert is the body of the messages published to the alert topic, the headers of the messages
+// contain the type, service and node too so they can be filtered without decoding the body
+type Alert struct {
+	// Type of anomaly e.g. goroutines
+	Type string `json:"type"`
+	// Service and node the anomaly was detected in, the node is blank for alerts which apply to
+	// every node of the service and the service is blank for http probes
+	Service string `json:"service"`
+	Version string `json:"version"`
+	Node    string `json:"node"`
+	// Reason describes the anomaly e.g. goroutines increased from 120 to 480
+	Reason string `json:"reason"`
+	// Timestamp the anomaly was detected at
+	Timestamp int64 `json:"timestamp"`
+}
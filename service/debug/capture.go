@@ -0,0 +1,10 @@
+package debug
+
+const (
+	// CaptureHeader is the metadata which flags a request for capture, set to true every hop of
+	// the request records its span in detail and publishes it to the capture topic
+	CaptureHeader = "Micro-Debug"
+	// CaptureTopic is the broker topic the spans of captured requests are published to, the body
+	// of each message is a debug.CaptureSpan encoded in JSON
+	CaptureTopic = "debug.capture"
+)
@@ -0,0 +1,15 @@
+package debug
+
+const (
+	// ChaosTopic is the broker topic the debug service publishes the chaos experiments to when
+	// they change, and periodically so services started since receive them. The body of each
+	// message is a debug.ListExperimentsResponse encoded in JSON.
+	ChaosTopic = "debug.chaos"
+
+	// ChaosLatency experiments delay requests
+	ChaosLatency = "latency"
+	// ChaosError experiments fail requests with an error
+	ChaosError = "error"
+	// ChaosReset experiments fail requests as if the connection was reset
+	ChaosReset = "reset"
+)
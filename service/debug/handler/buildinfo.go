@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/internal/buildinfo"
+	pb "github.com/micro/micro/v3/proto/debug"
+)
+
+// BuildInfo returns the build metadata of the binary the service runs with, so the versions
+// running across a fleet can be audited
+func (d *Debug) BuildInfo(ctx context.Context, req *pb.BuildInfoRequest, rsp *pb.BuildInfoResponse) error {
+	info := buildinfo.Get()
+	rsp.Version = info.Version
+	rsp.Commit = info.Commit
+	rsp.Date = info.Date
+	rsp.GoVersion = info.GoVersion
+	rsp.Os = info.OS
+	rsp.Arch = info.Arch
+	rsp.Plugins = info.Plugins
+	return nil
+}
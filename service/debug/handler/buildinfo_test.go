@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/micro/micro/v3/internal/buildinfo"
+	pb "github.com/micro/micro/v3/proto/debug"
+)
+
+func TestBuildInfo(t *testing.T) {
+	defer func(v, c string) { buildinfo.Version, buildinfo.Commit = v, c }(buildinfo.Version, buildinfo.Commit)
+	buildinfo.Version = "v3.0.0"
+	buildinfo.Commit = "abc123"
+
+	rsp := new(pb.BuildInfoResponse)
+	if err := new(Debug).BuildInfo(context.TODO(), &pb.BuildInfoRequest{}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Version != "v3.0.0" || rsp.Commit != "abc123" {
+		t.Fatalf("Unexpected version %v and commit %v", rsp.Version, rsp.Commit)
+	}
+	if rsp.GoVersion != runtime.Version() || rsp.Os != runtime.GOOS || rsp.Arch != runtime.GOARCH {
+		t.Fatalf("Unexpected runtime %v %v %v", rsp.GoVersion, rsp.Os, rsp.Arch)
+	}
+}
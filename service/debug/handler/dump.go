@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime/debug"
+	"runtime/pprof"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/server"
+)
+
+var (
+	// DumpChunkSize is the size of the chunks dumps are streamed in
+	DumpChunkSize = 64 * 1024
+	// AdminScope is the scope an account must have to take a dump, since dumps can contain secrets
+	// held in memory
+	AdminScope = "admin"
+)
+
+// Dump streams a dump of the service, either the stacks of every goroutine or a full heap dump.
+// The debug handler is registered without the generated wrapper, so the request is read from the
+// stream.
+func (d *Debug) Dump(ctx context.Context, stream server.Stream) error {
+	if err := authorizeAdmin(ctx, "debug.Debug.Dump"); err != nil {
+		return err
+	}
+	req := new(pb.DumpRequest)
+	if err := stream.Recv(req); err != nil {
+		return errors.BadRequest("debug.Debug.Dump", "Error reading request: %v", err)
+	}
+
+	w := &dumpWriter{stream: stream}
+	switch req.Type {
+	case "", "goroutine":
+		// debug level 2 prints the stacks in the same format as an unrecovered panic
+		if err := pprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+			return errors.InternalServerError("debug.Debug.Dump", err.Error())
+		}
+	case "heap":
+		if err := heapDump(w); err != nil {
+			return errors.InternalServerError("debug.Debug.Dump", err.Error())
+		}
+	default:
+		return errors.BadRequest("debug.Debug.Dump", "Unknown dump type %v, expected goroutine or heap", req.Type)
+	}
+	return w.flush()
+}
+
+// heapDump writes a full heap dump to the writer. The dump can only be written to a file so it's
+// written to a temporary file first, which is removed once it's been copied.
+func heapDump(w io.Writer) error {
+	f, err := ioutil.TempFile("", "heap-*.dump")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	// the world is stopped while the dump is written
+	debug.WriteHeapDump(f.Fd())
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// authorizeAdmin returns an error unless the account of the request is an admin of the default
+// namespace, the admins of the other namespaces can't dump the services shared by them all
+func authorizeAdmin(ctx context.Context, method string) error {
+	acc, ok := auth.AccountFromContext(ctx)
+	if !ok {
+		return errors.Unauthorized(method, "An account is required")
+	}
+	if err := namespace.Authorize(ctx, namespace.DefaultNamespace); err == namespace.ErrForbidden {
+		return errors.Forbidden(method, err.Error())
+	} else if err != nil {
+		return errors.InternalServerError(method, err.Error())
+	}
+	for _, s := range acc.Scopes {
+		if s == AdminScope {
+			return nil
+		}
+	}
+	return errors.Forbidden(method, "Admin access is required")
+}
+
+// dumpWriter buffers a dump, sending it to the stream in chunks
+type dumpWriter struct {
+	stream server.Stream
+	buf    []byte
+}
+
+func (d *dumpWriter) Write(p []byte) (int, error) {
+	d.buf = append(d.buf, p...)
+	for len(d.buf) >= DumpChunkSize {
+		if err := d.send(d.buf[:DumpChunkSize]); err != nil {
+			return 0, err
+		}
+		d.buf = d.buf[DumpChunkSize:]
+	}
+	return len(p), nil
+}
+
+// flush sends what remains of the dump
+func (d *dumpWriter) flush() error {
+	if len(d.buf) == 0 {
+		return nil
+	}
+	err := d.send(d.buf)
+	d.buf = nil
+	return err
+}
+
+func (d *dumpWriter) send(b []byte) error {
+	// the chunk is copied since the buffer is reused
+	return d.stream.Send(&pb.DumpResponse{Data: append([]byte{}, b...)})
+}
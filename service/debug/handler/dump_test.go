@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/server"
+)
+
+// testStream is a stream which reads the request and records the responses sent
+type testStream struct {
+	req    *pb.DumpRequest
+	chunks [][]byte
+}
+
+func (t *testStream) Context() context.Context { return context.TODO() }
+func (t *testStream) Request() server.Request  { return nil }
+func (t *testStream) Error() error             { return nil }
+func (t *testStream) Close() error             { return nil }
+
+func (t *testStream) Recv(v interface{}) error {
+	*v.(*pb.DumpRequest) = *t.req
+	return nil
+}
+
+func (t *testStream) Send(v interface{}) error {
+	t.chunks = append(t.chunks, v.(*pb.DumpResponse).Data)
+	return nil
+}
+
+func TestDump(t *testing.T) {
+	defer func(size int) { DumpChunkSize = size }(DumpChunkSize)
+	DumpChunkSize = 256
+
+	d := new(Debug)
+	admin := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "admin", Issuer: "micro", Scopes: []string{"admin"}})
+
+	stream := &testStream{req: &pb.DumpRequest{Type: "goroutine"}}
+	if err := d.Dump(admin, stream); err != nil {
+		t.Fatalf("Unexpected error dumping goroutines: %v", err)
+	}
+	if len(stream.chunks) < 2 {
+		t.Fatalf("Expected the dump to be sent in chunks, got %v", len(stream.chunks))
+	}
+	for _, c := range stream.chunks[:len(stream.chunks)-1] {
+		if len(c) != DumpChunkSize {
+			t.Fatalf("Expected chunks of %v bytes, got %v", DumpChunkSize, len(c))
+		}
+	}
+	if dump := bytes.Join(stream.chunks, nil); !bytes.Contains(dump, []byte("TestDump")) {
+		t.Fatal("Expected the dump to contain the stack of the test")
+	}
+
+	if err := d.Dump(admin, &testStream{req: &pb.DumpRequest{Type: "foo"}}); err == nil {
+		t.Fatal("Expected an error for an unknown type")
+	}
+
+	tenant := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "admin", Issuer: "foo", Scopes: []string{"admin"}})
+	if err := d.Dump(tenant, &testStream{req: &pb.DumpRequest{}}); err == nil {
+		t.Fatal("Expected an error dumping as the admin of another namespace")
+	}
+	user := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "user", Issuer: "micro", Scopes: []string{"service"}})
+	if err := d.Dump(user, &testStream{req: &pb.DumpRequest{}}); err == nil {
+		t.Fatal("Expected an error dumping without the admin scope")
+	}
+	if err := d.Dump(context.TODO(), &testStream{req: &pb.DumpRequest{}}); err == nil {
+		t.Fatal("Expected an error dumping without an account")
+	}
+}
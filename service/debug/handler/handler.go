@@ -2,7 +2,10 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"runtime"
+	"runtime/pprof"
 	"time"
 
 	"github.com/micro/micro/v3/internal/debug/log"
@@ -11,14 +14,24 @@ import (
 	pb "github.com/micro/micro/v3/proto/debug"
 	"github.com/micro/micro/v3/service/client"
 	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/debug/health"
+	"github.com/micro/micro/v3/service/errors"
+)
+
+var (
+	// DefaultProfileDuration is how long a cpu profile is taken for when no duration is requested
+	DefaultProfileDuration = time.Second * 10
+	// MaxProfileDuration is the longest a cpu profile can be taken for
+	MaxProfileDuration = time.Minute
 )
 
 // NewHandler returns an instance of the Debug Handler
 func NewHandler(c client.Client) *Debug {
 	return &Debug{
-		log:   debug.DefaultLog,
-		stats: debug.DefaultStats,
-		trace: debug.DefaultTracer,
+		log:    debug.DefaultLog,
+		stats:  debug.DefaultStats,
+		trace:  debug.DefaultTracer,
+		health: health.DefaultChecks,
 	}
 }
 
@@ -31,10 +44,17 @@ type Debug struct {
 	stats stats.Stats
 	// the tracer
 	trace trace.Tracer
+	// the health checks
+	health *health.Checks
 }
 
+// Health runs the health checks registered, unless the request is shallow
 func (d *Debug) Health(ctx context.Context, req *pb.HealthRequest, rsp *pb.HealthResponse) error {
-	rsp.Status = "ok"
+	if req.Shallow {
+		rsp.Status = health.StatusOK
+		return nil
+	}
+	rsp.Status, rsp.Checks = d.health.Run(ctx)
 	return nil
 }
 
@@ -58,9 +78,28 @@ func (d *Debug) Stats(ctx context.Context, req *pb.StatsRequest, rsp *pb.StatsRe
 	rsp.Requests = stats[0].Requests
 	rsp.Errors = stats[0].Errors
 
+	rsp.Endpoints = make(map[string]*pb.EndpointStats, len(stats[0].Endpoints))
+	for name, e := range stats[0].Endpoints {
+		rsp.Endpoints[name] = &pb.EndpointStats{
+			Requests: e.Requests,
+			Errors:   e.Errors,
+			Latency:  e.Latency,
+		}
+	}
+	rsp.LatencyBuckets = latencyBuckets(stats[0].LatencyBuckets)
+
 	return nil
 }
 
+// latencyBuckets returns the upper bounds of the latency buckets in seconds
+func latencyBuckets(bounds []time.Duration) []float64 {
+	buckets := make([]float64, len(bounds))
+	for i, b := range bounds {
+		buckets[i] = b.Seconds()
+	}
+	return buckets
+}
+
 func (d *Debug) Trace(ctx context.Context, req *pb.TraceRequest, rsp *pb.TraceResponse) error {
 	traces, err := d.trace.Read(trace.ReadTrace(req.Id))
 	if err != nil {
@@ -126,3 +165,45 @@ func (d *Debug) Log(ctx context.Context, req pb.LogRequest, rsp *pb.LogResponse)
 
 	return nil
 }
+
+// Profile captures a profile of the service in pprof format. A cpu profile is taken for the
+// duration requested, any other type is a snapshot of the named runtime profile e.g. heap.
+func (d *Debug) Profile(ctx context.Context, req *pb.ProfileRequest, rsp *pb.ProfileResponse) error {
+	buf := new(bytes.Buffer)
+
+	switch req.Type {
+	case "", "cpu":
+		duration := time.Duration(req.Duration) * time.Second
+		if duration <= 0 {
+			duration = DefaultProfileDuration
+		}
+		if duration > MaxProfileDuration {
+			duration = MaxProfileDuration
+		}
+
+		// only one cpu profile can be taken at a time, e.g. if the profiler is running
+		if err := pprof.StartCPUProfile(buf); err != nil {
+			return errors.Conflict("debug.Debug.Profile", err.Error())
+		}
+		select {
+		case <-time.After(duration):
+		case <-ctx.Done():
+		}
+		pprof.StopCPUProfile()
+	default:
+		p := pprof.Lookup(req.Type)
+		if p == nil {
+			return errors.BadRequest("debug.Debug.Profile", "Unknown profile type %v", req.Type)
+		}
+		// get up to date statistics for the heap
+		if req.Type == "heap" {
+			runtime.GC()
+		}
+		if err := p.WriteTo(buf, 0); err != nil {
+			return errors.InternalServerError("debug.Debug.Profile", err.Error())
+		}
+	}
+
+	rsp.Data = buf.Bytes()
+	return nil
+}
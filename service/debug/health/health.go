@@ -0,0 +1,251 @@
+// Package health provides the checks run by the debug handler of a service when its health is
+// queried, so Debug.Health reports whether the service is ready rather than just running. Checks
+// are registered by name, e.g. in main before the service is run:
+//
+//	health.Register("database", func(ctx context.Context) error {
+//		return db.PingContext(ctx)
+//	})
+//	health.Register("users", health.Service("users"))
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/store"
+)
+
+const (
+	// StatusOK is the status of a service whose checks all passed, and of a check which passed
+	StatusOK = "ok"
+	// StatusUnhealthy is the status of a service with a failing check
+	StatusUnhealthy = "unhealthy"
+	// StatusFailing is the status of a check which failed
+	StatusFailing = "failing"
+)
+
+var (
+	// DefaultTimeout is how long a check can take before it fails, if it wasn't registered with
+	// a timeout
+	DefaultTimeout = time.Second * 5
+	// DefaultResponsiveness is the longest the scheduler can take to run a goroutine before the
+	// responsiveness check registered by default fails
+	DefaultResponsiveness = time.Second
+
+	// DefaultChecks are the checks run by the debug handler
+	DefaultChecks = NewChecks()
+)
+
+func init() {
+	DefaultChecks.Register("responsiveness", Responsiveness(DefaultResponsiveness))
+}
+
+// Check returns an error if the service isn't healthy. The context is cancelled once the timeout
+// of the check passes.
+type Check func(ctx context.Context) error
+
+// Options for a check
+type Options struct {
+	// Timeout the check must pass within
+	Timeout time.Duration
+}
+
+type Option func(o *Options)
+
+// Timeout sets how long the check can take before it fails
+func Timeout(t time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = t
+	}
+}
+
+type check struct {
+	fn   Check
+	opts Options
+}
+
+// Checks are a set of named checks
+type Checks struct {
+	sync.RWMutex
+	checks map[string]*check
+}
+
+// NewChecks returns an empty set of checks
+func NewChecks() *Checks {
+	return &Checks{checks: make(map[string]*check)}
+}
+
+// Register a check, replacing any registered with the name
+func (c *Checks) Register(name string, fn Check, opts ...Option) {
+	options := Options{Timeout: DefaultTimeout}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.checks[name] = &check{fn: fn, opts: options}
+}
+
+// Deregister the check with the name
+func (c *Checks) Deregister(name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.checks, name)
+}
+
+// Run the checks concurrently, returning the status of the service and the results of the
+// checks ordered by name
+func (c *Checks) Run(ctx context.Context) (string, []*pb.HealthCheck) {
+	c.RLock()
+	names := make([]string, 0, len(c.checks))
+	checks := make([]*check, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checks = append(checks, c.checks[name])
+	}
+	c.RUnlock()
+
+	results := make([]*pb.HealthCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func(i int, ch *check) {
+			defer wg.Done()
+			results[i] = run(ctx, names[i], ch)
+		}(i, ch)
+	}
+	wg.Wait()
+
+	status := StatusOK
+	for _, r := range results {
+		if r.Status != StatusOK {
+			status = StatusUnhealthy
+		}
+	}
+	return status, results
+}
+
+// run the check, failing it if it doesn't return within its timeout e.g. since it's deadlocked
+func run(ctx context.Context, name string, ch *check) *pb.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, ch.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	errc := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errc <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		errc <- ch.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %v", ch.opts.Timeout)
+	}
+
+	result := &pb.HealthCheck{
+		Name:     name,
+		Status:   StatusOK,
+		Duration: int64(time.Since(start)),
+	}
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Register a check with the default checks
+func Register(name string, fn Check, opts ...Option) {
+	DefaultChecks.Register(name, fn, opts...)
+}
+
+// Deregister a check from the default checks
+func Deregister(name string) {
+	DefaultChecks.Deregister(name)
+}
+
+// Run the default checks
+func Run(ctx context.Context) (string, []*pb.HealthCheck) {
+	return DefaultChecks.Run(ctx)
+}
+
+// Responsiveness returns a check which fails if the scheduler takes longer than the max to run a
+// goroutine, e.g. since the service is starved of cpu or stuck in a long garbage collection
+func Responsiveness(max time.Duration) Check {
+	return func(ctx context.Context) error {
+		start := time.Now()
+		done := make(chan time.Duration, 1)
+		go func() {
+			done <- time.Since(start)
+		}()
+
+		select {
+		case d := <-done:
+			if d > max {
+				return fmt.Errorf("goroutine took %v to be scheduled", d)
+			}
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("goroutine wasn't scheduled within %v", time.Since(start))
+		}
+	}
+}
+
+// Heartbeat returns a check which fails if the beat func isn't called within the interval, and the
+// beat func. A loop which could deadlock calls beat on each iteration so the deadlock is detected.
+func Heartbeat(interval time.Duration) (Check, func()) {
+	var last int64
+	atomic.StoreInt64(&last, time.Now().UnixNano())
+
+	beat := func() {
+		atomic.StoreInt64(&last, time.Now().UnixNano())
+	}
+	check := func(ctx context.Context) error {
+		since := time.Since(time.Unix(0, atomic.LoadInt64(&last)))
+		if since > interval {
+			return fmt.Errorf("no heartbeat for %v", since.Round(time.Millisecond))
+		}
+		return nil
+	}
+	return check, beat
+}
+
+// Service returns a check which pings a service the service depends on. The checks of the service
+// aren't run so a failing dependency doesn't fail every service which depends on it indirectly.
+func Service(name string) Check {
+	return func(ctx context.Context) error {
+		req := client.DefaultClient.NewRequest(name, "Debug.Health", &pb.HealthRequest{Shallow: true})
+		rsp := new(pb.HealthResponse)
+		if err := client.DefaultClient.Call(ctx, req, rsp); err != nil {
+			return err
+		}
+		if rsp.Status != StatusOK {
+			return fmt.Errorf("%v is %v", name, rsp.Status)
+		}
+		return nil
+	}
+}
+
+// Store returns a check which pings the store
+func Store() Check {
+	return func(ctx context.Context) error {
+		_, err := store.List(store.Limit(1))
+		return err
+	}
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	checks := NewChecks()
+	checks.Register("ok", func(ctx context.Context) error { return nil })
+	checks.Register("error", func(ctx context.Context) error { return errors.New("unreachable") })
+	checks.Register("panic", func(ctx context.Context) error { panic("boom") })
+	checks.Register("deadlock", func(ctx context.Context) error {
+		select {}
+	}, Timeout(time.Millisecond*10))
+
+	status, results := checks.Run(context.TODO())
+	if status != StatusUnhealthy {
+		t.Fatalf("Expected status %v, got %v", StatusUnhealthy, status)
+	}
+
+	expected := []struct {
+		name, status, error string
+	}{
+		{"deadlock", StatusFailing, "timed out after 10ms"},
+		{"error", StatusFailing, "unreachable"},
+		{"ok", StatusOK, ""},
+		{"panic", StatusFailing, "panic: boom"},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %v results, got %v", len(expected), len(results))
+	}
+	for i, e := range expected {
+		r := results[i]
+		if r.Name != e.name || r.Status != e.status || r.Error != e.error {
+			t.Errorf("Expected %v %v %q, got %v %v %q", e.name, e.status, e.error, r.Name, r.Status, r.Error)
+		}
+	}
+
+	for _, name := range []string{"error", "panic", "deadlock"} {
+		checks.Deregister(name)
+	}
+	if status, _ := checks.Run(context.TODO()); status != StatusOK {
+		t.Fatalf("Expected status %v, got %v", StatusOK, status)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	check, beat := Heartbeat(time.Millisecond * 50)
+	if err := check(context.TODO()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 60)
+	if err := check(context.TODO()); err == nil {
+		t.Fatal("Expected an error without a heartbeat")
+	}
+
+	beat()
+	if err := check(context.TODO()); err != nil {
+		t.Fatalf("Unexpected error after heartbeat: %v", err)
+	}
+}
+
+func TestResponsiveness(t *testing.T) {
+	if err := Responsiveness(time.Second)(context.TODO()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
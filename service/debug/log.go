@@ -0,0 +1,5 @@
+package debug
+
+// LogsTopic is the broker topic services ship their log records to, the body of each message is
+// a batch of records encoded as a debug.WriteLogsRequest in JSON
+const LogsTopic = "debug.logs"
@@ -0,0 +1,6 @@
+package debug
+
+// SamplingTopic is the broker topic the debug service publishes the trace sampling to when it's
+// set, and periodically so services started since receive it. The body of each message is a
+// debug.TraceSampling encoded in JSON.
+const SamplingTopic = "debug.sampling"
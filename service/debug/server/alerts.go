@@ -0,0 +1,195 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/debug"
+	log "github.com/micro/micro/v3/service/logger"
+)
+
+var (
+	// AlertWindow is the period of metrics anomalies are detected over
+	AlertWindow = time.Minute * 10
+	// AlertMinGoroutines is the number of goroutines below which doubling isn't an anomaly
+	AlertMinGoroutines = 100.0
+	// AlertMinHeapPoints is the number of values the heap trend is detected from
+	AlertMinHeapPoints = 5
+	// AlertHeapGrowth is the growth of the heap over the window, as a ratio of its size at the
+	// start, which is an anomaly if the trend is steady
+	AlertHeapGrowth = 0.5
+	// AlertGCPauseRatio is how many times the average pause time a pause spike is
+	AlertGCPauseRatio = 3.0
+	// AlertMinGCPause is the pause time between scrapes below which spikes aren't anomalies
+	AlertMinGCPause = time.Millisecond * 10
+)
+
+// detector detects anomalies in the metrics scraped from nodes, publishing an alert when an
+// anomaly is first detected. The alert isn't published again until the anomaly has cleared.
+type detector struct {
+	// firing are the alerts published for anomalies which haven't cleared, keyed by
+	// service/node/type
+	firing map[string]bool
+	// publish the alert, defaults to the broker
+	publish func(*debug.Alert) error
+}
+
+func newDetector() *detector {
+	return &detector{
+		firing:  make(map[string]bool),
+		publish: publishAlert,
+	}
+}
+
+// nodeMetrics are the values of the metrics of a node over the window
+type nodeMetrics struct {
+	service string
+	version string
+	node    string
+	values  map[string][]*pb.Point
+}
+
+// detect anomalies in the metrics of the nodes at the time of the last scrape
+func (d *detector) detect(nodes []*nodeMetrics, ts int64) {
+	seen := make(map[string]bool, len(d.firing))
+
+	for _, n := range nodes {
+		for _, a := range anomalies {
+			key := n.service + "/" + n.node + "/" + a.typ
+			reason, ok := a.check(n.values[a.metric])
+			if !ok {
+				continue
+			}
+			seen[key] = true
+			if d.firing[key] {
+				continue
+			}
+
+			alert := &debug.Alert{
+				Type:      a.typ,
+				Service:   n.service,
+				Version:   n.version,
+				Node:      n.node,
+				Reason:    reason,
+				Timestamp: ts,
+			}
+			if err := d.publish(alert); err != nil {
+				log.Warnf("Error publishing %v alert for %v node %v: %v", a.typ, n.service, n.node, err)
+				continue
+			}
+			d.firing[key] = true
+		}
+	}
+
+	// the anomalies which weren't detected have cleared
+	for key := range d.firing {
+		if !seen[key] {
+			delete(d.firing, key)
+		}
+	}
+}
+
+// anomalies are the types of alert and the metric each is detected in
+var anomalies = []struct {
+	typ    string
+	metric string
+	check  func([]*pb.Point) (string, bool)
+}{
+	{debug.AlertGoroutines, "threads", goroutineAnomaly},
+	{debug.AlertHeapGrowth, "memory", heapAnomaly},
+	{debug.AlertGCPause, "gc", gcPauseAnomaly},
+}
+
+// publishAlert publishes the alert to the broker
+func publishAlert(a *debug.Alert) error {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return broker.Publish(debug.AlertTopic, &broker.Message{
+		Header: map[string]string{
+			"Micro-Alert-Type":    a.Type,
+			"Micro-Alert-Service": a.Service,
+			"Micro-Alert-Node":    a.Node,
+		},
+		Body: b,
+	})
+}
+
+// goroutineAnomaly returns true if the goroutines have doubled from the least in the window
+func goroutineAnomaly(points []*pb.Point) (string, bool) {
+	if len(points) < 2 {
+		return "", false
+	}
+	least := points[0].Value
+	for _, p := range points[:len(points)-1] {
+		least = math.Min(least, p.Value)
+	}
+	curr := points[len(points)-1].Value
+	if curr < AlertMinGoroutines || curr < least*2 {
+		return "", false
+	}
+	return fmt.Sprintf("Goroutines increased from %.0f to %.0f", least, curr), true
+}
+
+// heapAnomaly returns true if the heap grows steadily over the window. The heap rises and falls
+// with each garbage collection so the trend is fitted to the values, a steady trend being one
+// which the values correlate strongly with.
+func heapAnomaly(points []*pb.Point) (string, bool) {
+	if len(points) < AlertMinHeapPoints {
+		return "", false
+	}
+
+	// least squares fit of the values to time
+	var n, sumX, sumY, sumXY, sumXX, sumYY float64
+	t0 := points[0].Timestamp
+	for _, p := range points {
+		x, y := float64(p.Timestamp-t0), p.Value
+		n++
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+		sumYY += y * y
+	}
+	cov := n*sumXY - sumX*sumY
+	varX := n*sumXX - sumX*sumX
+	varY := n*sumYY - sumY*sumY
+	if varX <= 0 || varY <= 0 || cov <= 0 {
+		return "", false
+	}
+	slope := cov / varX
+	start := (sumY - slope*sumX) / n
+	end := start + slope*float64(points[len(points)-1].Timestamp-t0)
+	corr := cov / math.Sqrt(varX*varY)
+
+	if start <= 0 || corr < 0.9 || (end-start)/start < AlertHeapGrowth {
+		return "", false
+	}
+	return fmt.Sprintf("Heap grew steadily from %.1fMB to %.1fMB", start/1024/1024, end/1024/1024), true
+}
+
+// gcPauseAnomaly returns true if the time paused for garbage collection since the last scrape
+// spiked compared to the average between the previous scrapes in the window
+func gcPauseAnomaly(points []*pb.Point) (string, bool) {
+	if len(points) < 3 {
+		return "", false
+	}
+
+	// the values are the total pause time so the pauses between scrapes are the differences
+	var total float64
+	for i := 1; i < len(points)-1; i++ {
+		total += points[i].Value - points[i-1].Value
+	}
+	avg := total / float64(len(points)-2)
+	last := points[len(points)-1].Value - points[len(points)-2].Value
+
+	if last < AlertMinGCPause.Seconds() || last < avg*AlertGCPauseRatio {
+		return "", false
+	}
+	return fmt.Sprintf("GC paused for %.3fs since the last scrape, %.3fs on average", last, avg), true
+}
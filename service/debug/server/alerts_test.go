@@ -0,0 +1,85 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func points(values ...float64) []*pb.Point {
+	var result []*pb.Point
+	for i, v := range values {
+		result = append(result, &pb.Point{Timestamp: int64(i * 30), Value: v})
+	}
+	return result
+}
+
+func TestAnomalies(t *testing.T) {
+	mb := float64(1024 * 1024)
+
+	tests := []struct {
+		name    string
+		check   func([]*pb.Point) (string, bool)
+		points  []*pb.Point
+		anomaly bool
+	}{
+		{"goroutines doubled", goroutineAnomaly, points(150, 120, 140, 250), true},
+		{"goroutines increased", goroutineAnomaly, points(150, 120, 140, 200), false},
+		{"few goroutines doubled", goroutineAnomaly, points(10, 12, 40), false},
+		{"heap grew steadily", heapAnomaly, points(10*mb, 12*mb, 14*mb, 17*mb, 19*mb, 22*mb), true},
+		{"heap fluctuated", heapAnomaly, points(10*mb, 22*mb, 11*mb, 21*mb, 10*mb, 22*mb), false},
+		{"heap grew slowly", heapAnomaly, points(10*mb, 10.5*mb, 11*mb, 11.5*mb, 12*mb, 12.5*mb), false},
+		{"heap grew briefly", heapAnomaly, points(10*mb, 20*mb), false},
+		{"gc pause spiked", gcPauseAnomaly, points(0, 0.01, 0.02, 0.03, 0.2), true},
+		{"gc pause steady", gcPauseAnomaly, points(0, 0.01, 0.02, 0.03, 0.04), false},
+		{"short gc pause spiked", gcPauseAnomaly, points(0, 0.001, 0.002, 0.003, 0.008), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, anomaly := tt.check(tt.points)
+			assert.Equal(t, tt.anomaly, anomaly)
+		})
+	}
+}
+
+func TestDetector(t *testing.T) {
+	s := newScraper("debug", nil, time.Second*30, time.Hour)
+	var alerts []*debug.Alert
+	d := &detector{
+		firing: make(map[string]bool),
+		publish: func(a *debug.Alert) error {
+			alerts = append(alerts, a)
+			return nil
+		},
+	}
+
+	srv := &registry.Service{Name: "foo", Version: "latest"}
+	node := &registry.Node{Id: "foo-1"}
+	scrape := func(ts int64, goroutines uint64) {
+		s.record(srv, node, ts, &pb.StatsResponse{Threads: goroutines})
+		d.detect(s.nodeMetrics(ts-int64(AlertWindow.Seconds()), ts), ts)
+	}
+
+	scrape(0, 100)
+	scrape(30, 250)
+	if assert.Len(t, alerts, 1) {
+		assert.Equal(t, debug.AlertGoroutines, alerts[0].Type)
+		assert.Equal(t, "foo", alerts[0].Service)
+		assert.Equal(t, "foo-1", alerts[0].Node)
+	}
+
+	// the alert isn't published again until the anomaly clears
+	scrape(60, 260)
+	assert.Len(t, alerts, 1)
+
+	// once the low value is out of the window the anomaly clears
+	scrape(30+int64(AlertWindow.Seconds()), 260)
+	assert.Len(t, alerts, 1)
+	scrape(60+int64(AlertWindow.Seconds()), 600)
+	assert.Len(t, alerts, 2)
+}
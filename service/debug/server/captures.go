@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// DefaultCaptureRetention is how long the spans of captured requests are kept
+	DefaultCaptureRetention = time.Hour * 24
+	// DefaultCapturesLimit is the number of captures listed if no limit is requested
+	DefaultCapturesLimit = 20
+)
+
+// capturePrefix is the prefix of the keys the spans of captured requests are stored under, the
+// key of a span is capture/[trace]/[span]
+const capturePrefix = "capture/"
+
+// Captures implements the handler for reading the captured requests
+type Captures struct{}
+
+// subscribe to the spans of captured requests published by services
+func (c *Captures) subscribe() (broker.Subscriber, error) {
+	return broker.Subscribe(debug.CaptureTopic, func(msg *broker.Message) error {
+		span := new(pb.CaptureSpan)
+		if err := json.Unmarshal(msg.Body, span); err != nil {
+			log.Warnf("Error decoding captured span: %v", err)
+			return nil
+		}
+		if err := writeCaptureSpan(span); err != nil {
+			log.Warnf("Error writing captured span: %v", err)
+		}
+		return nil
+	})
+}
+
+// writeCaptureSpan stores the span under the trace it's part of
+func writeCaptureSpan(span *pb.CaptureSpan) error {
+	if len(span.Trace) == 0 || len(span.Id) == 0 || strings.Contains(span.Trace, "/") {
+		return nil
+	}
+	b, err := json.Marshal(span)
+	if err != nil {
+		return err
+	}
+	return store.Write(&store.Record{Key: capturePrefix + span.Trace + "/" + span.Id, Value: b})
+}
+
+// readCaptureSpans reads the spans of every capture, or of the capture if an id is given, grouped
+// by capture
+func readCaptureSpans(id string) (map[string][]*pb.CaptureSpan, error) {
+	prefix := capturePrefix
+	if len(id) > 0 {
+		prefix += id + "/"
+	}
+	recs, err := store.Read("", store.Prefix(prefix))
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	captures := make(map[string][]*pb.CaptureSpan)
+	for _, rec := range recs {
+		span := new(pb.CaptureSpan)
+		if err := json.Unmarshal(rec.Value, span); err != nil {
+			continue
+		}
+		captures[span.Trace] = append(captures[span.Trace], span)
+	}
+	for _, spans := range captures {
+		sort.Slice(spans, func(i, j int) bool {
+			return spans[i].Started < spans[j].Started
+		})
+	}
+	return captures, nil
+}
+
+// List the captures, latest first
+func (c *Captures) List(ctx context.Context, req *pb.ListCapturesRequest, rsp *pb.ListCapturesResponse) error {
+	if err := authorize(ctx, "debug.Captures.List"); err != nil {
+		return err
+	}
+	if req.Limit <= 0 {
+		req.Limit = int64(DefaultCapturesLimit)
+	}
+
+	captures, err := readCaptureSpans("")
+	if err != nil {
+		return errors.InternalServerError("debug.Captures.List", "Error reading captures: %v", err)
+	}
+
+	for id, spans := range captures {
+		info := &pb.CaptureInfo{
+			Id:       id,
+			Name:     spans[0].Name,
+			Started:  spans[0].Started,
+			Duration: spans[0].Duration,
+			Spans:    int64(len(spans)),
+		}
+
+		seen := make(map[string]bool)
+		for _, s := range spans {
+			if len(s.Error) > 0 {
+				info.Errors++
+			}
+			if len(s.Service) > 0 && !seen[s.Service] {
+				seen[s.Service] = true
+				info.Services = append(info.Services, s.Service)
+			}
+		}
+		if len(req.Service) > 0 && !seen[req.Service] {
+			continue
+		}
+		rsp.Captures = append(rsp.Captures, info)
+	}
+
+	sort.Slice(rsp.Captures, func(i, j int) bool {
+		return rsp.Captures[i].Started > rsp.Captures[j].Started
+	})
+	if len(rsp.Captures) > int(req.Limit) {
+		rsp.Captures = rsp.Captures[:req.Limit]
+	}
+	return nil
+}
+
+// Read the spans of a capture
+func (c *Captures) Read(ctx context.Context, req *pb.ReadCaptureRequest, rsp *pb.ReadCaptureResponse) error {
+	if err := authorize(ctx, "debug.Captures.Read"); err != nil {
+		return err
+	}
+	if len(req.Id) == 0 || strings.Contains(req.Id, "/") {
+		return errors.BadRequest("debug.Captures.Read", "Invalid id")
+	}
+
+	captures, err := readCaptureSpans(req.Id)
+	if err != nil {
+		return errors.InternalServerError("debug.Captures.Read", "Error reading capture: %v", err)
+	}
+	spans, ok := captures[req.Id]
+	if !ok {
+		return errors.NotFound("debug.Captures.Read", "Capture not found")
+	}
+	rsp.Spans = spans
+	return nil
+}
+
+// pruneCaptures deletes the spans captured before the retention period every hour until exit is
+// closed
+func pruneCaptures(retention time.Duration, exit chan bool) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+		}
+
+		captures, err := readCaptureSpans("")
+		if err != nil {
+			log.Warnf("Error reading captures: %v", err)
+			continue
+		}
+		cutoff := time.Now().Add(-retention).UnixNano()
+		for _, spans := range captures {
+			for _, s := range spans {
+				if s.Started < cutoff {
+					store.Delete(capturePrefix + s.Trace + "/" + s.Id)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptures(t *testing.T) {
+	defaultStore := store.DefaultStore
+	defer func() { store.DefaultStore = defaultStore }()
+	store.DefaultStore = memory.NewStore()
+
+	now := time.Now().UnixNano()
+	for _, s := range []*pb.CaptureSpan{
+		{Trace: "foo", Id: "2", Parent: "1", Name: "bar.Bar.Read", Type: "outbound", Service: "foo", Started: now + 10, Error: "not found"},
+		{Trace: "foo", Id: "1", Name: "foo.Foo.Call", Type: "inbound", Service: "foo", Started: now, Duration: 100},
+		{Trace: "foo", Id: "3", Parent: "2", Name: "bar.Bar.Read", Type: "inbound", Service: "bar", Started: now + 20},
+		{Trace: "baz", Id: "1", Name: "baz.Baz.Call", Type: "inbound", Service: "baz", Started: now + 1000},
+		{Trace: "invalid/trace", Id: "1"},
+	} {
+		assert.NoError(t, writeCaptureSpan(s))
+	}
+
+	c := new(Captures)
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "micro"})
+
+	// the captures are listed latest first
+	list := &pb.ListCapturesResponse{}
+	assert.NoError(t, c.List(ctx, &pb.ListCapturesRequest{}, list))
+	if assert.Len(t, list.Captures, 2) {
+		assert.Equal(t, "baz", list.Captures[0].Id)
+		foo := list.Captures[1]
+		assert.Equal(t, "foo.Foo.Call", foo.Name)
+		assert.Equal(t, int64(100), foo.Duration)
+		assert.Equal(t, int64(3), foo.Spans)
+		assert.Equal(t, int64(1), foo.Errors)
+		assert.Equal(t, []string{"foo", "bar"}, foo.Services)
+	}
+
+	list = &pb.ListCapturesResponse{}
+	assert.NoError(t, c.List(ctx, &pb.ListCapturesRequest{Service: "bar"}, list))
+	assert.Len(t, list.Captures, 1)
+
+	list = &pb.ListCapturesResponse{}
+	assert.NoError(t, c.List(ctx, &pb.ListCapturesRequest{Limit: 1}, list))
+	assert.Len(t, list.Captures, 1)
+
+	// the spans of a capture are read in the order they started
+	read := &pb.ReadCaptureResponse{}
+	assert.NoError(t, c.Read(ctx, &pb.ReadCaptureRequest{Id: "foo"}, read))
+	if assert.Len(t, read.Spans, 3) {
+		assert.Equal(t, "1", read.Spans[0].Id)
+		assert.Equal(t, "3", read.Spans[2].Id)
+	}
+
+	assert.Error(t, c.Read(ctx, &pb.ReadCaptureRequest{Id: "missing"}, &pb.ReadCaptureResponse{}))
+	assert.Error(t, c.Read(ctx, &pb.ReadCaptureRequest{}, &pb.ReadCaptureResponse{}))
+
+	// only the micro issuer can read the captures
+	ctx = auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "foo"})
+	assert.Error(t, c.Read(ctx, &pb.ReadCaptureRequest{Id: "foo"}, &pb.ReadCaptureResponse{}))
+}
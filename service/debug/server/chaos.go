@@ -0,0 +1,291 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// DefaultChaosDuration is how long experiments run for if they don't specify a duration
+	DefaultChaosDuration = time.Minute * 10
+	// MaxChaosDuration is the longest an experiment can run for, so faults aren't left injected
+	MaxChaosDuration = time.Hour * 24
+	// ChaosInterval is how often the experiments are published, so services started since they
+	// were set receive them, and expired experiments are removed
+	ChaosInterval = time.Second * 30
+	// DefaultChaosAuditLimit is the number of audit entries returned if no limit is requested
+	DefaultChaosAuditLimit = 100
+)
+
+const (
+	// chaosPrefix is the prefix of the keys experiments are stored under, the key of an
+	// experiment is chaos/experiment/[id]
+	chaosPrefix = "chaos/experiment/"
+	// chaosAuditPrefix is the prefix of the keys the audit entries are stored under, the key of
+	// an entry is chaos/audit/[timestamp]/[id]
+	chaosAuditPrefix = "chaos/audit/"
+)
+
+// Chaos implements the handler for managing chaos experiments
+type Chaos struct{}
+
+// Create an experiment, which is published to the services immediately
+func (c *Chaos) Create(ctx context.Context, req *pb.CreateExperimentRequest, rsp *pb.CreateExperimentResponse) error {
+	if err := authorize(ctx, "debug.Chaos.Create"); err != nil {
+		return err
+	}
+
+	exp := req.Experiment
+	if err := validateExperiment(exp); err != nil {
+		return errors.BadRequest("debug.Chaos.Create", err.Error())
+	}
+	duration := DefaultChaosDuration
+	if len(exp.Duration) > 0 {
+		duration, _ = time.ParseDuration(exp.Duration)
+	}
+
+	now := time.Now()
+	exp.Id = uuid.New().String()
+	exp.Duration = duration.String()
+	exp.Created = now.Unix()
+	exp.Expires = now.Add(duration).Unix()
+	if acc, ok := auth.AccountFromContext(ctx); ok {
+		exp.CreatedBy = acc.ID
+	}
+
+	b, err := json.Marshal(exp)
+	if err != nil {
+		return errors.InternalServerError("debug.Chaos.Create", "Error encoding experiment: %v", err)
+	}
+	if err := store.Write(&store.Record{Key: chaosPrefix + exp.Id, Value: b}); err != nil {
+		return errors.InternalServerError("debug.Chaos.Create", "Error storing experiment: %v", err)
+	}
+	audit("create", exp.CreatedBy, exp)
+
+	if err := publishExperiments(); err != nil {
+		log.Warnf("Error publishing chaos experiments: %v", err)
+	}
+	rsp.Experiment = exp
+	return nil
+}
+
+// validateExperiment returns an error if the experiment is invalid
+func validateExperiment(exp *pb.ChaosExperiment) error {
+	if exp == nil {
+		return fmt.Errorf("Missing experiment")
+	}
+	if len(exp.Service) == 0 {
+		return fmt.Errorf("Missing service")
+	}
+	if exp.Service == name {
+		return fmt.Errorf("Faults can't be injected into the debug service")
+	}
+	if exp.Percentage <= 0 || exp.Percentage > 100 {
+		return fmt.Errorf("Percentage must be greater than 0 and at most 100")
+	}
+
+	switch exp.Type {
+	case debug.ChaosLatency:
+		if d, err := time.ParseDuration(exp.Latency); err != nil || d <= 0 {
+			return fmt.Errorf("Latency experiments require a latency e.g. 500ms")
+		}
+	case debug.ChaosError:
+		if exp.ErrorCode != 0 && (exp.ErrorCode < 400 || exp.ErrorCode > 599) {
+			return fmt.Errorf("Error code must be between 400 and 599")
+		}
+	case debug.ChaosReset:
+	default:
+		return fmt.Errorf("Type must be latency, error or reset")
+	}
+
+	if len(exp.Duration) > 0 {
+		d, err := time.ParseDuration(exp.Duration)
+		if err != nil || d <= 0 || d > MaxChaosDuration {
+			return fmt.Errorf("Duration must be at most %v", MaxChaosDuration)
+		}
+	}
+	return nil
+}
+
+// List the experiments which haven't expired
+func (c *Chaos) List(ctx context.Context, req *pb.ListExperimentsRequest, rsp *pb.ListExperimentsResponse) error {
+	if err := authorize(ctx, "debug.Chaos.List"); err != nil {
+		return err
+	}
+
+	exps, err := readExperiments()
+	if err != nil {
+		return errors.InternalServerError("debug.Chaos.List", "Error reading experiments: %v", err)
+	}
+	now := time.Now().Unix()
+	for _, exp := range exps {
+		if exp.Expires > now {
+			rsp.Experiments = append(rsp.Experiments, exp)
+		}
+	}
+	return nil
+}
+
+// Delete an experiment, stopping it before it expires
+func (c *Chaos) Delete(ctx context.Context, req *pb.DeleteExperimentRequest, rsp *pb.DeleteExperimentResponse) error {
+	if err := authorize(ctx, "debug.Chaos.Delete"); err != nil {
+		return err
+	}
+	if len(req.Id) == 0 {
+		return errors.BadRequest("debug.Chaos.Delete", "Missing id")
+	}
+
+	recs, err := store.Read(chaosPrefix + req.Id)
+	if err == store.ErrNotFound {
+		return errors.NotFound("debug.Chaos.Delete", "Experiment not found")
+	} else if err != nil {
+		return errors.InternalServerError("debug.Chaos.Delete", "Error reading experiment: %v", err)
+	}
+	exp := new(pb.ChaosExperiment)
+	json.Unmarshal(recs[0].Value, exp)
+
+	if err := store.Delete(chaosPrefix + req.Id); err != nil {
+		return errors.InternalServerError("debug.Chaos.Delete", "Error deleting experiment: %v", err)
+	}
+	var account string
+	if acc, ok := auth.AccountFromContext(ctx); ok {
+		account = acc.ID
+	}
+	audit("delete", account, exp)
+
+	if err := publishExperiments(); err != nil {
+		log.Warnf("Error publishing chaos experiments: %v", err)
+	}
+	return nil
+}
+
+// Audit returns the latest entries of the audit of the experiments
+func (c *Chaos) Audit(ctx context.Context, req *pb.ChaosAuditRequest, rsp *pb.ChaosAuditResponse) error {
+	if err := authorize(ctx, "debug.Chaos.Audit"); err != nil {
+		return err
+	}
+	if req.Limit <= 0 {
+		req.Limit = int64(DefaultChaosAuditLimit)
+	}
+
+	recs, err := store.Read("", store.Prefix(chaosAuditPrefix))
+	if err != nil && err != store.ErrNotFound {
+		return errors.InternalServerError("debug.Chaos.Audit", "Error reading audit: %v", err)
+	}
+	// the keys sort in the order the entries were written
+	sort.Slice(recs, func(i, j int) bool {
+		return recs[i].Key > recs[j].Key
+	})
+	for _, rec := range recs {
+		if len(rsp.Entries) >= int(req.Limit) {
+			break
+		}
+		entry := new(pb.ChaosAuditEntry)
+		if err := json.Unmarshal(rec.Value, entry); err != nil {
+			continue
+		}
+		rsp.Entries = append(rsp.Entries, entry)
+	}
+	return nil
+}
+
+// audit records the action taken on the experiment
+func audit(action, account string, exp *pb.ChaosExperiment) {
+	now := time.Now()
+	b, err := json.Marshal(&pb.ChaosAuditEntry{
+		Timestamp:  now.Unix(),
+		Action:     action,
+		Account:    account,
+		Experiment: exp,
+	})
+	if err != nil {
+		return
+	}
+	key := fmt.Sprintf("%v%020d/%v", chaosAuditPrefix, now.UnixNano(), exp.Id)
+	if err := store.Write(&store.Record{Key: key, Value: b}); err != nil {
+		log.Warnf("Error auditing chaos experiment %v: %v", exp.Id, err)
+	}
+}
+
+// readExperiments reads every experiment from the store, including those which have expired
+func readExperiments() ([]*pb.ChaosExperiment, error) {
+	recs, err := store.Read("", store.Prefix(chaosPrefix))
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	var exps []*pb.ChaosExperiment
+	for _, rec := range recs {
+		exp := new(pb.ChaosExperiment)
+		if err := json.Unmarshal(rec.Value, exp); err != nil {
+			continue
+		}
+		exps = append(exps, exp)
+	}
+	sort.Slice(exps, func(i, j int) bool {
+		return exps[i].Created < exps[j].Created
+	})
+	return exps, nil
+}
+
+// publishExperiments publishes the experiments which haven't expired to the services, deleting
+// and auditing those which have
+func publishExperiments() error {
+	exps, err := readExperiments()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	active := &pb.ListExperimentsResponse{}
+	for _, exp := range exps {
+		if exp.Expires > now {
+			active.Experiments = append(active.Experiments, exp)
+			continue
+		}
+		if err := store.Delete(chaosPrefix + exp.Id); err != nil {
+			log.Warnf("Error deleting chaos experiment %v: %v", exp.Id, err)
+			continue
+		}
+		audit("expire", "", exp)
+	}
+
+	b, err := json.Marshal(active)
+	if err != nil {
+		return err
+	}
+	return broker.Publish(debug.ChaosTopic, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body:   b,
+	})
+}
+
+// runChaos publishes the experiments at each interval until exit is closed
+func runChaos(exit chan bool) {
+	ticker := time.NewTicker(ChaosInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+		}
+
+		if err := publishExperiments(); err != nil {
+			log.Warnf("Error publishing chaos experiments: %v", err)
+		}
+	}
+}
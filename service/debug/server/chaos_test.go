@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/broker"
+	bmemory "github.com/micro/micro/v3/service/broker/memory"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaos(t *testing.T) {
+	defaultStore, defaultBroker := store.DefaultStore, broker.DefaultBroker
+	defer func() {
+		store.DefaultStore, broker.DefaultBroker = defaultStore, defaultBroker
+	}()
+	store.DefaultStore, broker.DefaultBroker = memory.NewStore(), bmemory.NewBroker()
+	assert.NoError(t, broker.DefaultBroker.Connect())
+
+	var published []*pb.ListExperimentsResponse
+	_, err := broker.Subscribe(debug.ChaosTopic, func(msg *broker.Message) error {
+		rsp := new(pb.ListExperimentsResponse)
+		assert.NoError(t, json.Unmarshal(msg.Body, rsp))
+		published = append(published, rsp)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	c := new(Chaos)
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "john", Issuer: "micro"})
+
+	create := &pb.CreateExperimentResponse{}
+	err = c.Create(ctx, &pb.CreateExperimentRequest{Experiment: &pb.ChaosExperiment{
+		Service: "foo", Type: debug.ChaosLatency, Latency: "500ms", Percentage: 10,
+	}}, create)
+	assert.NoError(t, err)
+	exp := create.Experiment
+	assert.NotEmpty(t, exp.Id)
+	assert.Equal(t, "john", exp.CreatedBy)
+	assert.Equal(t, DefaultChaosDuration.String(), exp.Duration)
+	assert.Equal(t, exp.Created+int64(DefaultChaosDuration.Seconds()), exp.Expires)
+
+	// the experiment is published to the services
+	if assert.Len(t, published, 1) && assert.Len(t, published[0].Experiments, 1) {
+		assert.Equal(t, exp.Id, published[0].Experiments[0].Id)
+	}
+
+	for _, e := range []*pb.ChaosExperiment{
+		nil,
+		{Type: debug.ChaosReset, Percentage: 10},
+		{Service: "debug", Type: debug.ChaosReset, Percentage: 10},
+		{Service: "foo", Type: debug.ChaosReset, Percentage: 0},
+		{Service: "foo", Type: debug.ChaosLatency, Percentage: 10},
+		{Service: "foo", Type: debug.ChaosError, Percentage: 10, ErrorCode: 200},
+		{Service: "foo", Type: "panic", Percentage: 10},
+		{Service: "foo", Type: debug.ChaosReset, Percentage: 10, Duration: "48h"},
+	} {
+		err := c.Create(ctx, &pb.CreateExperimentRequest{Experiment: e}, &pb.CreateExperimentResponse{})
+		assert.Error(t, err, "Expected an error for %v", e)
+	}
+
+	list := &pb.ListExperimentsResponse{}
+	assert.NoError(t, c.List(ctx, &pb.ListExperimentsRequest{}, list))
+	assert.Len(t, list.Experiments, 1)
+
+	// an expired experiment is removed when the experiments are next published
+	expired := &pb.ChaosExperiment{Id: "expired", Service: "bar", Type: debug.ChaosReset, Percentage: 10, Expires: time.Now().Unix() - 1}
+	b, _ := json.Marshal(expired)
+	assert.NoError(t, store.Write(&store.Record{Key: chaosPrefix + expired.Id, Value: b}))
+	assert.NoError(t, publishExperiments())
+	if assert.Len(t, published, 2) {
+		assert.Len(t, published[1].Experiments, 1)
+	}
+
+	assert.NoError(t, c.Delete(ctx, &pb.DeleteExperimentRequest{Id: exp.Id}, &pb.DeleteExperimentResponse{}))
+	assert.Error(t, c.Delete(ctx, &pb.DeleteExperimentRequest{Id: exp.Id}, &pb.DeleteExperimentResponse{}))
+	if assert.Len(t, published, 3) {
+		assert.Len(t, published[2].Experiments, 0)
+	}
+
+	// every change is audited, latest first
+	audit := &pb.ChaosAuditResponse{}
+	assert.NoError(t, c.Audit(ctx, &pb.ChaosAuditRequest{}, audit))
+	if assert.Len(t, audit.Entries, 3) {
+		assert.Equal(t, "delete", audit.Entries[0].Action)
+		assert.Equal(t, "john", audit.Entries[0].Account)
+		assert.Equal(t, "expire", audit.Entries[1].Action)
+		assert.Equal(t, "expired", audit.Entries[1].Experiment.Id)
+		assert.Equal(t, "create", audit.Entries[2].Action)
+	}
+
+	// only the micro issuer can manage experiments
+	ctx = auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "foo"})
+	assert.Error(t, c.List(ctx, &pb.ListExperimentsRequest{}, &pb.ListExperimentsResponse{}))
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+)
+
+// endpointSnapshot is the endpoint stats scraped from a node at a time
+type endpointSnapshot struct {
+	timestamp int64
+	stats     *nodeStats
+}
+
+// recordEndpoints keeps the endpoint stats scraped from the nodes so the latency of the requests
+// to each endpoint can be queried over a range
+func (s *scraper) recordEndpoints(nodes []*nodeStats, ts int64) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, n := range nodes {
+		key := n.service + "/" + n.node
+		s.endpoints[key] = append(s.endpoints[key], &endpointSnapshot{timestamp: ts, stats: n})
+	}
+}
+
+// histograms returns the latency of the requests to the endpoints between the first and last
+// scrapes of each node in the range, summed across the nodes of each service. The latency of nodes
+// whose buckets differ from those of the first node of the service isn't included.
+func (s *scraper) histograms(req *pb.EndpointsRequest) []*pb.EndpointHistogram {
+	s.RLock()
+	defer s.RUnlock()
+
+	result := make(map[string]*pb.EndpointHistogram)
+	for _, snaps := range s.endpoints {
+		i := sort.Search(len(snaps), func(i int) bool {
+			return snaps[i].timestamp >= req.Start
+		})
+		j := len(snaps) - 1
+		if req.End > 0 {
+			j = sort.Search(len(snaps), func(i int) bool {
+				return snaps[i].timestamp > req.End
+			}) - 1
+		}
+		// at least two scrapes are needed to count the requests between them
+		if i >= j {
+			continue
+		}
+
+		d := delta(snaps[i].stats, snaps[j].stats)
+		if len(req.Service) > 0 && d.service != req.Service {
+			continue
+		}
+		for name, e := range d.endpoints {
+			if len(req.Endpoint) > 0 && name != req.Endpoint {
+				continue
+			}
+
+			key := d.service + "/" + name
+			h, ok := result[key]
+			if !ok {
+				h = &pb.EndpointHistogram{
+					Service:  d.service,
+					Endpoint: name,
+					Buckets:  d.buckets,
+					Latency:  make([]uint64, len(d.buckets)),
+				}
+				result[key] = h
+			}
+			h.Requests += e.Requests
+			h.Errors += e.Errors
+			if !sameBuckets(h.Buckets, d.buckets) || len(e.Latency) != len(h.Latency) {
+				continue
+			}
+			for k, l := range e.Latency {
+				h.Latency[k] += l
+			}
+		}
+	}
+
+	out := make([]*pb.EndpointHistogram, 0, len(result))
+	for _, h := range result {
+		if h.Requests > 0 {
+			h.P50 = percentile(h.Buckets, h.Latency, h.Requests, 0.5)
+			h.P90 = percentile(h.Buckets, h.Latency, h.Requests, 0.9)
+			h.P99 = percentile(h.Buckets, h.Latency, h.Requests, 0.99)
+		}
+		out = append(out, h)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Service != out[j].Service {
+			return out[i].Service < out[j].Service
+		}
+		return out[i].Endpoint < out[j].Endpoint
+	})
+	return out
+}
+
+// sameBuckets returns true if the latency buckets are the same
+func sameBuckets(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// writeHistograms writes the latest latency of the requests to the endpoints of each node in the
+// prometheus text format, as a histogram without a sum since the total latency isn't recorded.
+// The lock of the scraper must be held.
+func (s *scraper) writeHistograms(buf *strings.Builder) {
+	var latest []*nodeStats
+	for _, snaps := range s.endpoints {
+		if len(snaps) > 0 {
+			latest = append(latest, snaps[len(snaps)-1].stats)
+		}
+	}
+	if len(latest) == 0 {
+		return
+	}
+	sort.Slice(latest, func(i, j int) bool {
+		a, b := latest[i], latest[j]
+		return a.service < b.service || (a.service == b.service && a.node < b.node)
+	})
+
+	const name = "micro_request_duration_seconds"
+	fmt.Fprintf(buf, "# HELP %s Latency of the requests to each endpoint in seconds\n# TYPE %s histogram\n", name, name)
+	for _, n := range latest {
+		endpoints := make([]string, 0, len(n.endpoints))
+		for e := range n.endpoints {
+			endpoints = append(endpoints, e)
+		}
+		sort.Strings(endpoints)
+
+		for _, e := range endpoints {
+			stats := n.endpoints[e]
+			labels := fmt.Sprintf(`service="%s",version="%s",node="%s",endpoint="%s"`, labelValue(n.service),
+				labelValue(n.version), labelValue(n.node), labelValue(e))
+			for i, b := range n.buckets {
+				if i < len(stats.Latency) {
+					fmt.Fprintf(buf, "%s_bucket{%s,le=\"%v\"} %d\n", name, labels, b, stats.Latency[i])
+				}
+			}
+			fmt.Fprintf(buf, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, stats.Requests)
+			fmt.Fprintf(buf, "%s_count{%s} %d\n", name, labels, stats.Requests)
+		}
+	}
+}
+
+// Endpoints returns the latency of the requests to the endpoints of the services, by default over
+// the last hour
+func (m *Metrics) Endpoints(ctx context.Context, req *pb.EndpointsRequest, rsp *pb.EndpointsResponse) error {
+	if err := authorize(ctx, "debug.Metrics.Endpoints"); err != nil {
+		return err
+	}
+	if req.Start == 0 {
+		req.Start = time.Now().Add(-time.Hour).Unix()
+	}
+
+	rsp.Endpoints = m.scraper.histograms(req)
+	return nil
+}
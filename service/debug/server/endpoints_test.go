@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpoints(t *testing.T) {
+	s := newScraper("debug", nil, time.Second, time.Hour)
+	node := func(id string, requests, errors uint64, latency []uint64) *nodeStats {
+		return &nodeStats{
+			service: "foo",
+			version: "latest",
+			node:    id,
+			buckets: []float64{0.1, 1},
+			endpoints: map[string]*pb.EndpointStats{
+				"Foo.Call": {Requests: requests, Errors: errors, Latency: latency},
+			},
+		}
+	}
+
+	now := time.Now().Unix()
+	s.recordEndpoints([]*nodeStats{node("foo-1", 10, 0, []uint64{10, 10}), node("foo-2", 0, 0, []uint64{0, 0})}, now-120)
+	s.recordEndpoints([]*nodeStats{node("foo-1", 60, 1, []uint64{55, 60})}, now-60)
+	s.recordEndpoints([]*nodeStats{node("foo-1", 110, 2, []uint64{100, 109}), node("foo-2", 50, 0, []uint64{50, 50})}, now)
+
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "micro"})
+	h := &Metrics{scraper: s}
+
+	// the requests of the last hour are summed across the nodes
+	var rsp pb.EndpointsResponse
+	assert.NoError(t, h.Endpoints(ctx, &pb.EndpointsRequest{Service: "foo"}, &rsp))
+	if assert.Len(t, rsp.Endpoints, 1) {
+		e := rsp.Endpoints[0]
+		assert.Equal(t, "Foo.Call", e.Endpoint)
+		assert.Equal(t, uint64(150), e.Requests)
+		assert.Equal(t, uint64(2), e.Errors)
+		assert.Equal(t, []uint64{140, 149}, e.Latency)
+		assert.Equal(t, 0.1, e.P50)
+		assert.Equal(t, 0.1, e.P90)
+		assert.Equal(t, 1.0, e.P99)
+	}
+
+	// the range is limited to the scrapes within it
+	rsp = pb.EndpointsResponse{}
+	assert.NoError(t, h.Endpoints(ctx, &pb.EndpointsRequest{Start: now - 90, Endpoint: "Foo.Call"}, &rsp))
+	if assert.Len(t, rsp.Endpoints, 1) {
+		assert.Equal(t, uint64(50), rsp.Endpoints[0].Requests)
+	}
+
+	// the latest histograms are served to prometheus
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/federate", nil))
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE micro_request_duration_seconds histogram\n")
+	assert.Contains(t, body, `micro_request_duration_seconds_bucket{service="foo",version="latest",node="foo-1",endpoint="Foo.Call",le="0.1"} 100`)
+	assert.Contains(t, body, `micro_request_duration_seconds_bucket{service="foo",version="latest",node="foo-1",endpoint="Foo.Call",le="+Inf"} 110`)
+
+	// the scrapes past retention are pruned
+	s.prune(now - 30)
+	assert.Len(t, s.endpoints["foo/foo-1"], 1)
+}
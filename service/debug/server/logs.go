@@ -0,0 +1,382 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// DefaultLogsRetention is how long the log records shipped are kept
+	DefaultLogsRetention = time.Hour * 72
+	// DefaultLogsLimit is the number of records returned by a query if no limit is requested
+	DefaultLogsLimit = 100
+	// IndexedLabels are the labels records are indexed by. Queries which match one of the labels
+	// by equality only read the records with the label value, others read every record in the
+	// range so should match the service.
+	IndexedLabels = []string{"service", "level"}
+)
+
+const (
+	// logPrefix is the prefix of the keys records are stored under, each record is stored under
+	// log/[label]/[value]/[timestamp]/[id] for each of the indexed labels it has
+	logPrefix = "log/"
+	// labelPrefix is the prefix of the keys the label values seen are stored under, the key of
+	// a value is label/[label]/[value]
+	labelPrefix = "label/"
+)
+
+// logKey returns the key the record is stored under for the indexed label, the timestamp is
+// padded so the keys sort in the order the records were logged
+func logKey(label, value string, ts int64, id string) string {
+	return fmt.Sprintf("%v%v/%v/%020d/%v", logPrefix, label, value, ts, id)
+}
+
+// logTimestamp returns the timestamp of a record from its key
+func logTimestamp(key string) (int64, bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) < 2 {
+		return 0, false
+	}
+	ts, err := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	return ts, err == nil
+}
+
+// Logs implements the handler for writing and querying the log records shipped by services
+type Logs struct {
+	// labels are the label values seen, so they're only stored once
+	sync.RWMutex
+	labels map[string]bool
+}
+
+func newLogs() *Logs {
+	return &Logs{labels: make(map[string]bool)}
+}
+
+// Write the records shipped by a service
+func (l *Logs) Write(ctx context.Context, req *pb.WriteLogsRequest, rsp *pb.WriteLogsResponse) error {
+	if err := authorize(ctx, "debug.Logs.Write"); err != nil {
+		return err
+	}
+	if err := l.write(req.Records); err != nil {
+		return errors.InternalServerError("debug.Logs.Write", "Error writing records: %v", err)
+	}
+	return nil
+}
+
+// subscribe to the records shipped via the broker
+func (l *Logs) subscribe() (broker.Subscriber, error) {
+	return broker.Subscribe(debug.LogsTopic, func(msg *broker.Message) error {
+		var req pb.WriteLogsRequest
+		if err := json.Unmarshal(msg.Body, &req); err != nil {
+			log.Warnf("Error decoding log records: %v", err)
+			return nil
+		}
+		if err := l.write(req.Records); err != nil {
+			log.Warnf("Error writing log records: %v", err)
+		}
+		return nil
+	})
+}
+
+// write the records, storing each under the indexed labels it has
+func (l *Logs) write(records []*pb.LogRecord) error {
+	for _, rec := range records {
+		if len(rec.Labels["service"]) == 0 {
+			continue
+		}
+		if rec.Timestamp == 0 {
+			rec.Timestamp = time.Now().UnixNano()
+		}
+
+		b, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+
+		id := uuid.New().String()
+		for _, label := range IndexedLabels {
+			value, ok := rec.Labels[label]
+			if !ok || len(value) == 0 || strings.Contains(value, "/") {
+				continue
+			}
+			if err := store.Write(&store.Record{Key: logKey(label, value, rec.Timestamp, id), Value: b}); err != nil {
+				return err
+			}
+		}
+
+		if err := l.index(rec.Labels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// index the label values which haven't been seen before
+func (l *Logs) index(labels map[string]string) error {
+	for name, value := range labels {
+		// the values of labels such as file contain slashes and are too many to index
+		if strings.Contains(name, "/") || strings.Contains(value, "/") {
+			continue
+		}
+		key := labelPrefix + name + "/" + value
+
+		l.RLock()
+		seen := l.labels[key]
+		l.RUnlock()
+		if seen {
+			continue
+		}
+
+		if err := store.Write(&store.Record{Key: key}); err != nil {
+			return err
+		}
+		l.Lock()
+		l.labels[key] = true
+		l.Unlock()
+	}
+	return nil
+}
+
+// Query the records matching the query, by default those logged in the last hour
+func (l *Logs) Query(ctx context.Context, req *pb.QueryLogsRequest, rsp *pb.QueryLogsResponse) error {
+	if err := authorize(ctx, "debug.Logs.Query"); err != nil {
+		return err
+	}
+
+	query, err := parseQuery(req.Query)
+	if err != nil {
+		return errors.BadRequest("debug.Logs.Query", err.Error())
+	}
+	if req.Start == 0 {
+		req.Start = time.Now().Add(-time.Hour).Unix()
+	}
+	if req.Limit <= 0 {
+		req.Limit = int64(DefaultLogsLimit)
+	}
+
+	rsp.Records, err = queryLogs(query, req.Start, req.End, int(req.Limit))
+	if err != nil {
+		return errors.InternalServerError("debug.Logs.Query", "Error querying records: %v", err)
+	}
+	return nil
+}
+
+// queryLogs returns the latest records in the range which match the query, up to the limit
+func queryLogs(query *logQuery, start, end int64, limit int) ([]*pb.LogRecord, error) {
+	// read the records of an indexed label value the query matches, otherwise every record
+	// which is indexed by service
+	prefix := logPrefix + "service/"
+	for _, label := range IndexedLabels {
+		if value, ok := query.equal(label); ok {
+			prefix = logPrefix + label + "/" + value + "/"
+			break
+		}
+	}
+
+	keys, err := store.List(store.Prefix(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	// the keys in the range, latest first
+	startNano := time.Unix(start, 0).UnixNano()
+	endNano := int64(0)
+	if end > 0 {
+		endNano = time.Unix(end, 0).UnixNano()
+	}
+	var inRange []string
+	for _, key := range keys {
+		ts, ok := logTimestamp(key)
+		if !ok || ts < startNano || (endNano > 0 && ts > endNano) {
+			continue
+		}
+		inRange = append(inRange, key)
+	}
+	sort.Slice(inRange, func(i, j int) bool {
+		ti, _ := logTimestamp(inRange[i])
+		tj, _ := logTimestamp(inRange[j])
+		return ti > tj
+	})
+
+	var records []*pb.LogRecord
+	for _, key := range inRange {
+		if len(records) >= limit {
+			break
+		}
+		recs, err := store.Read(key)
+		if err == store.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		rec := new(pb.LogRecord)
+		if err := json.Unmarshal(recs[0].Value, rec); err != nil {
+			continue
+		}
+		if query.match(rec.Labels, rec.Message) {
+			records = append(records, rec)
+		}
+	}
+
+	// return the records in the order they were logged
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	return records, nil
+}
+
+// Labels lists the names of the labels of the records, or the values of a label
+func (l *Logs) Labels(ctx context.Context, req *pb.LabelsRequest, rsp *pb.LabelsResponse) error {
+	if err := authorize(ctx, "debug.Logs.Labels"); err != nil {
+		return err
+	}
+
+	prefix := labelPrefix
+	if len(req.Name) > 0 {
+		prefix += req.Name + "/"
+	}
+	keys, err := store.List(store.Prefix(prefix))
+	if err != nil {
+		return errors.InternalServerError("debug.Logs.Labels", "Error listing labels: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		parts := strings.SplitN(strings.TrimPrefix(key, prefix), "/", 2)
+		if len(parts[0]) == 0 || seen[parts[0]] {
+			continue
+		}
+		seen[parts[0]] = true
+		rsp.Values = append(rsp.Values, parts[0])
+	}
+	sort.Strings(rsp.Values)
+	return nil
+}
+
+// pruneLogs deletes the records logged before the retention period every hour until exit is
+// closed
+func pruneLogs(retention time.Duration, exit chan bool) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+		}
+
+		keys, err := store.List(store.Prefix(logPrefix))
+		if err != nil {
+			log.Warnf("Error listing log records: %v", err)
+			continue
+		}
+		cutoff := time.Now().Add(-retention).UnixNano()
+		for _, key := range keys {
+			if ts, ok := logTimestamp(key); ok && ts < cutoff {
+				store.Delete(key)
+			}
+		}
+	}
+}
+
+var logsDashboard = template.Must(template.New("logs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Logs</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+input[type=text] { width: 60%; font-family: monospace; }
+table { border-collapse: collapse; width: 100%; font-family: monospace; font-size: 0.9em; }
+td { padding: 0.25em 0.5em; border-bottom: 1px solid #eee; vertical-align: top; white-space: nowrap; }
+td.message { white-space: pre-wrap; }
+.error { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Logs</h1>
+<form>
+<input type="text" name="query" value="{{.Query}}" placeholder='{service="helloworld"} |= "error"'>
+<select name="since">
+{{range .Ranges}}<option value="{{.}}"{{if eq . $.Since}} selected{{end}}>{{.}}</option>{{end}}
+</select>
+<input type="submit" value="Query">
+</form>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<table>
+{{range .Records}}
+<tr{{if eq (index .Labels "level") "error"}} class="error"{{end}}>
+<td>{{.Time}}</td>
+<td>{{index .Labels "service"}}</td>
+<td>{{index .Labels "level"}}</td>
+<td class="message">{{.Message}}</td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+
+// logsPage serves the dashboard for querying the logs
+func logsPage(w http.ResponseWriter, r *http.Request) {
+	type record struct {
+		*pb.LogRecord
+		Time string
+	}
+	data := struct {
+		Query   string
+		Since   string
+		Ranges  []string
+		Error   string
+		Records []record
+	}{
+		Query:  r.URL.Query().Get("query"),
+		Since:  r.URL.Query().Get("since"),
+		Ranges: []string{"5m", "15m", "1h", "6h", "24h", "72h"},
+	}
+	if len(data.Since) == 0 {
+		data.Since = "1h"
+	}
+
+	since, err := time.ParseDuration(data.Since)
+	if err != nil {
+		data.Error = "Invalid range " + data.Since
+	}
+	query, err := parseQuery(data.Query)
+	if err != nil {
+		data.Error = err.Error()
+	}
+
+	if len(data.Error) == 0 {
+		records, err := queryLogs(query, time.Now().Add(-since).Unix(), 0, DefaultLogsLimit)
+		if err != nil {
+			data.Error = err.Error()
+		}
+		for _, rec := range records {
+			t := time.Unix(0, rec.Timestamp).Format("2006-01-02 15:04:05")
+			data.Records = append(data.Records, record{LogRecord: rec, Time: t})
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := logsDashboard.Execute(w, data); err != nil {
+		log.Warnf("Error rendering the logs dashboard: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogs(t *testing.T) {
+	defaultStore := store.DefaultStore
+	defer func() { store.DefaultStore = defaultStore }()
+	store.DefaultStore = memory.NewStore()
+
+	now := time.Now()
+	record := func(ago time.Duration, service, level, message string) *pb.LogRecord {
+		return &pb.LogRecord{
+			Timestamp: now.Add(-ago).UnixNano(),
+			Labels:    map[string]string{"service": service, "level": level, "file": "foo/handler.go:10"},
+			Message:   message,
+		}
+	}
+
+	l := newLogs()
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "micro"})
+	err := l.Write(ctx, &pb.WriteLogsRequest{Records: []*pb.LogRecord{
+		record(time.Minute*3, "foo", "info", "started"),
+		record(time.Minute*2, "foo", "error", "request timeout"),
+		record(time.Minute, "bar", "info", "started"),
+		record(time.Hour*2, "foo", "error", "old timeout"),
+		{Labels: map[string]string{"level": "info"}, Message: "no service"},
+	}}, &pb.WriteLogsResponse{})
+	assert.NoError(t, err)
+
+	query := func(q string, limit int64) []string {
+		rsp := &pb.QueryLogsResponse{}
+		err := l.Query(ctx, &pb.QueryLogsRequest{Query: q, Limit: limit}, rsp)
+		assert.NoError(t, err)
+		var messages []string
+		for _, r := range rsp.Records {
+			messages = append(messages, r.Labels["service"]+": "+r.Message)
+		}
+		return messages
+	}
+
+	// the records of the last hour, in the order they were logged
+	assert.Equal(t, []string{"foo: started", "foo: request timeout", "bar: started"}, query("", 0))
+	assert.Equal(t, []string{"foo: started", "foo: request timeout"}, query(`{service="foo"}`, 0))
+	assert.Equal(t, []string{"foo: request timeout"}, query(`{level="error"} |= "timeout"`, 0))
+	assert.Equal(t, []string{"bar: started"}, query(`{service!="foo"}`, 0))
+
+	// the limit returns the latest records
+	assert.Equal(t, []string{"foo: request timeout", "bar: started"}, query("", 2))
+
+	err = l.Query(ctx, &pb.QueryLogsRequest{Query: `{service=`}, &pb.QueryLogsResponse{})
+	assert.Error(t, err, "Expected an error for an invalid query")
+
+	labels := &pb.LabelsResponse{}
+	assert.NoError(t, l.Labels(ctx, &pb.LabelsRequest{}, labels))
+	assert.Equal(t, []string{"level", "service"}, labels.Values)
+
+	labels = &pb.LabelsResponse{}
+	assert.NoError(t, l.Labels(ctx, &pb.LabelsRequest{Name: "service"}, labels))
+	assert.Equal(t, []string{"bar", "foo"}, labels.Values)
+
+	// only the micro issuer can query the logs
+	ctx = auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "foo"})
+	err = l.Query(ctx, &pb.QueryLogsRequest{}, &pb.QueryLogsResponse{})
+	assert.Error(t, err)
+}
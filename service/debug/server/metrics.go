@@ -0,0 +1,430 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/registry"
+)
+
+var (
+	// DefaultMetricsInterval is how often the stats of the services are scraped
+	DefaultMetricsInterval = time.Second * 30
+	// DefaultMetricsRetention is how long the values scraped are kept
+	DefaultMetricsRetention = time.Hour
+	// DefaultMetricsAddress is the address the prometheus federation endpoint is served on
+	DefaultMetricsAddress = ":9001"
+)
+
+// metric is a value scraped from the stats of a service
+type metric struct {
+	// name the metric is queried by
+	name string
+	// name, type and help text the metric is exported to prometheus with
+	promName string
+	promType string
+	promHelp string
+	value    func(*pb.StatsResponse) float64
+}
+
+var metrics = []metric{
+	{"uptime", "micro_uptime_seconds", "gauge", "Uptime of the service in seconds",
+		func(s *pb.StatsResponse) float64 { return float64(s.Uptime) }},
+	{"memory", "micro_memory_bytes", "gauge", "Memory allocated by the service in bytes",
+		func(s *pb.StatsResponse) float64 { return float64(s.Memory) }},
+	{"threads", "micro_threads", "gauge", "Number of goroutines of the service",
+		func(s *pb.StatsResponse) float64 { return float64(s.Threads) }},
+	{"gc", "micro_gc_seconds_total", "counter", "Total time spent in garbage collection in seconds",
+		func(s *pb.StatsResponse) float64 { return float64(s.Gc) / float64(time.Second) }},
+	{"requests", "micro_requests_total", "counter", "Total number of requests served",
+		func(s *pb.StatsResponse) float64 { return float64(s.Requests) }},
+	{"errors", "micro_errors_total", "counter", "Total number of requests which errored",
+		func(s *pb.StatsResponse) float64 { return float64(s.Errors) }},
+}
+
+// series is the values of a metric scraped from a node
+type series struct {
+	service string
+	version string
+	node    string
+	metric  string
+	points  []*pb.Point
+}
+
+// seriesKey returns the key a series is stored under
+func seriesKey(service, node, metric string) string {
+	return service + "/" + node + "/" + metric
+}
+
+// scraper periodically scrapes the stats of every node of the services via their debug handler
+// and keeps the values in memory for the retention period
+type scraper struct {
+	// name of the debug service, which isn't scraped
+	name   string
+	client client.Client
+
+	interval  time.Duration
+	retention time.Duration
+
+	// detector of anomalies in the metrics, nil if alerts are disabled
+	detector *detector
+	// tracker of the service level objectives
+	tracker *tracker
+
+	sync.RWMutex
+	series map[string]*series
+	// top are the stats of each service at the last scrape, see summarize
+	top top
+	// endpoints are the endpoint stats scraped from each node, keyed by service/node
+	endpoints map[string][]*endpointSnapshot
+}
+
+func newScraper(name string, c client.Client, interval, retention time.Duration) *scraper {
+	return &scraper{
+		name:      name,
+		client:    c,
+		interval:  interval,
+		retention: retention,
+		tracker:   newTracker(),
+		series:    make(map[string]*series),
+		endpoints: make(map[string][]*endpointSnapshot),
+	}
+}
+
+// run scrapes the services until exit is closed
+func (s *scraper) run(exit chan bool) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			s.scrape(time.Now().Unix())
+		}
+	}
+}
+
+// scrape the stats of every node of the services. The values are recorded at the same timestamp
+// so those of the nodes of a service can be aggregated.
+func (s *scraper) scrape(ts int64) {
+	srvs, err := registry.ListServices()
+	if err != nil {
+		log.Warnf("Error listing services to scrape: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	var nodes []*nodeStats
+	seen := make(map[string]bool, len(srvs))
+	for _, srv := range srvs {
+		if srv.Name == s.name || seen[srv.Name] {
+			continue
+		}
+		seen[srv.Name] = true
+
+		versions, err := registry.GetService(srv.Name)
+		if err != nil {
+			log.Warnf("Error getting service %v to scrape: %v", srv.Name, err)
+			continue
+		}
+
+		for _, srv := range versions {
+			for _, node := range srv.Nodes {
+				wg.Add(1)
+				go func(srv *registry.Service, node *registry.Node) {
+					defer wg.Done()
+					stats, err := s.scrapeNode(srv, node, ts)
+					if err != nil {
+						log.Warnf("Error scraping %v node %v: %v", srv.Name, node.Id, err)
+						return
+					}
+
+					mtx.Lock()
+					nodes = append(nodes, &nodeStats{
+						service:   srv.Name,
+						version:   srv.Version,
+						node:      node.Id,
+						endpoints: stats.Endpoints,
+						buckets:   stats.LatencyBuckets,
+						memory:    stats.Memory,
+						threads:   stats.Threads,
+					})
+					mtx.Unlock()
+				}(srv, node)
+			}
+		}
+	}
+	wg.Wait()
+
+	if s.detector != nil {
+		s.detector.detect(s.nodeMetrics(ts-int64(AlertWindow.Seconds()), ts), ts)
+	}
+	s.summarize(nodes, ts)
+	s.recordEndpoints(nodes, ts)
+	s.tracker.load()
+	s.tracker.update(nodes, ts)
+	s.prune(ts - int64(s.retention.Seconds()))
+}
+
+// scrapeNode scrapes the stats of the node and records them
+func (s *scraper) scrapeNode(srv *registry.Service, node *registry.Node, ts int64) (*pb.StatsResponse, error) {
+	req := s.client.NewRequest(srv.Name, "Debug.Stats", &pb.StatsRequest{})
+	rsp := new(pb.StatsResponse)
+	if err := s.client.Call(context.Background(), req, rsp, client.WithAddress(node.Address)); err != nil {
+		return nil, err
+	}
+	s.record(srv, node, ts, rsp)
+	return rsp, nil
+}
+
+// record the values of the stats of a node
+func (s *scraper) record(srv *registry.Service, node *registry.Node, ts int64, stats *pb.StatsResponse) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, m := range metrics {
+		key := seriesKey(srv.Name, node.Id, m.name)
+		ser, ok := s.series[key]
+		if !ok {
+			ser = &series{service: srv.Name, node: node.Id, metric: m.name}
+			s.series[key] = ser
+		}
+		ser.version = srv.Version
+		ser.points = append(ser.points, &pb.Point{Timestamp: ts, Value: m.value(stats)})
+	}
+}
+
+// nodeMetrics returns the values of the metrics of the nodes scraped at the time, since the start
+func (s *scraper) nodeMetrics(start, ts int64) []*nodeMetrics {
+	s.RLock()
+	defer s.RUnlock()
+
+	nodes := make(map[string]*nodeMetrics)
+	var result []*nodeMetrics
+	for _, ser := range s.series {
+		// skip the nodes which weren't scraped, e.g. they've gone
+		if len(ser.points) == 0 || ser.points[len(ser.points)-1].Timestamp != ts {
+			continue
+		}
+
+		key := ser.service + "/" + ser.node
+		n, ok := nodes[key]
+		if !ok {
+			n = &nodeMetrics{
+				service: ser.service,
+				version: ser.version,
+				node:    ser.node,
+				values:  make(map[string][]*pb.Point),
+			}
+			nodes[key] = n
+			result = append(result, n)
+		}
+
+		i := sort.Search(len(ser.points), func(i int) bool {
+			return ser.points[i].Timestamp >= start
+		})
+		n.values[ser.metric] = ser.points[i:]
+	}
+	return result
+}
+
+// prune deletes the values scraped before the cutoff and the series of nodes which have gone
+func (s *scraper) prune(cutoff int64) {
+	s.Lock()
+	defer s.Unlock()
+
+	for key, ser := range s.series {
+		i := sort.Search(len(ser.points), func(i int) bool {
+			return ser.points[i].Timestamp >= cutoff
+		})
+		if i == len(ser.points) {
+			delete(s.series, key)
+			continue
+		}
+		ser.points = ser.points[i:]
+	}
+
+	for key, snaps := range s.endpoints {
+		i := sort.Search(len(snaps), func(i int) bool {
+			return snaps[i].timestamp >= cutoff
+		})
+		if i == len(snaps) {
+			delete(s.endpoints, key)
+			continue
+		}
+		s.endpoints[key] = snaps[i:]
+	}
+}
+
+// query returns the series matching the request. The points are copied so the series can
+// continue to be recorded to.
+func (s *scraper) query(req *pb.QueryMetricsRequest) []*pb.Series {
+	s.RLock()
+	defer s.RUnlock()
+
+	var result []*pb.Series
+	for _, ser := range s.series {
+		if len(req.Service) > 0 && ser.service != req.Service {
+			continue
+		}
+		if len(req.Metric) > 0 && ser.metric != req.Metric {
+			continue
+		}
+
+		out := &pb.Series{
+			Service: ser.service,
+			Version: ser.version,
+			Node:    ser.node,
+			Metric:  ser.metric,
+		}
+		for _, p := range ser.points {
+			if p.Timestamp < req.Start || (req.End > 0 && p.Timestamp > req.End) {
+				continue
+			}
+			out.Points = append(out.Points, &pb.Point{Timestamp: p.Timestamp, Value: p.Value})
+		}
+		result = append(result, out)
+	}
+
+	if req.Aggregate {
+		result = aggregate(result)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Service != result[j].Service {
+			return result[i].Service < result[j].Service
+		}
+		if result[i].Metric != result[j].Metric {
+			return result[i].Metric < result[j].Metric
+		}
+		return result[i].Node < result[j].Node
+	})
+	return result
+}
+
+// aggregate sums the values of the nodes of each service scraped at the same time
+func aggregate(in []*pb.Series) []*pb.Series {
+	sums := make(map[string]map[int64]float64)
+	var out []*pb.Series
+	index := make(map[string]*pb.Series)
+
+	for _, ser := range in {
+		key := ser.Service + "/" + ser.Metric
+		if _, ok := index[key]; !ok {
+			index[key] = &pb.Series{Service: ser.Service, Version: ser.Version, Metric: ser.Metric}
+			sums[key] = make(map[int64]float64)
+			out = append(out, index[key])
+		}
+		for _, p := range ser.Points {
+			sums[key][p.Timestamp] += p.Value
+		}
+	}
+
+	for key, ser := range index {
+		for ts, v := range sums[key] {
+			ser.Points = append(ser.Points, &pb.Point{Timestamp: ts, Value: v})
+		}
+		sort.Slice(ser.Points, func(i, j int) bool {
+			return ser.Points[i].Timestamp < ser.Points[j].Timestamp
+		})
+	}
+	return out
+}
+
+// ServeHTTP serves the latest value of each series in the prometheus text format so they can be
+// federated, i.e. scraped by prometheus from the /federate path. Match selectors are ignored,
+// every series is served.
+func (s *scraper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.RLock()
+	latest := make(map[string][]*series, len(metrics))
+	for _, ser := range s.series {
+		if len(ser.points) > 0 {
+			latest[ser.metric] = append(latest[ser.metric], ser)
+		}
+	}
+
+	buf := new(strings.Builder)
+	for _, m := range metrics {
+		if len(latest[m.name]) == 0 {
+			continue
+		}
+		sort.Slice(latest[m.name], func(i, j int) bool {
+			a, b := latest[m.name][i], latest[m.name][j]
+			return a.service < b.service || (a.service == b.service && a.node < b.node)
+		})
+
+		fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", m.promName, m.promHelp, m.promName, m.promType)
+		for _, ser := range latest[m.name] {
+			p := ser.points[len(ser.points)-1]
+			fmt.Fprintf(buf, "%s{service=\"%s\",version=\"%s\",node=\"%s\"} %v %d\n", m.promName,
+				labelValue(ser.service), labelValue(ser.version), labelValue(ser.node), p.Value, p.Timestamp*1000)
+		}
+	}
+	s.writeHistograms(buf)
+	s.RUnlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, buf.String())
+}
+
+// labelValue escapes a prometheus label value
+func labelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// Metrics implements the handler for querying the metrics scraped from services
+type Metrics struct {
+	scraper *scraper
+}
+
+// Query the values of metrics scraped from the services, by default those of the last hour
+func (m *Metrics) Query(ctx context.Context, req *pb.QueryMetricsRequest, rsp *pb.QueryMetricsResponse) error {
+	if err := authorize(ctx, "debug.Metrics.Query"); err != nil {
+		return err
+	}
+	if len(req.Metric) > 0 && !validMetric(req.Metric) {
+		return errors.BadRequest("debug.Metrics.Query", "Invalid metric %v", req.Metric)
+	}
+	if req.Start == 0 {
+		req.Start = time.Now().Add(-time.Hour).Unix()
+	}
+
+	rsp.Series = m.scraper.query(req)
+	return nil
+}
+
+// Objectives returns the service level objectives and their status
+func (m *Metrics) Objectives(ctx context.Context, req *pb.ObjectivesRequest, rsp *pb.ObjectivesResponse) error {
+	if err := authorize(ctx, "debug.Metrics.Objectives"); err != nil {
+		return err
+	}
+
+	objectives, err := m.scraper.tracker.status(req.Service)
+	if err != nil {
+		return errors.InternalServerError("debug.Metrics.Objectives", "Error reading objectives: %v", err)
+	}
+	rsp.Objectives = objectives
+	return nil
+}
+
+// validMetric returns true if the metric is scraped
+func validMetric(name string) bool {
+	for _, m := range metrics {
+		if m.name == name {
+			return true
+		}
+	}
+	return false
+}
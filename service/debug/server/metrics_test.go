@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	s := newScraper("debug", nil, time.Second, time.Hour)
+	srv := &registry.Service{Name: "foo", Version: "latest"}
+	foo1, foo2 := &registry.Node{Id: "foo-1"}, &registry.Node{Id: "foo-2"}
+
+	now := time.Now().Unix()
+	s.record(srv, foo1, now-7200, &pb.StatsResponse{Requests: 1})
+	s.record(srv, foo1, now-60, &pb.StatsResponse{Requests: 10, Memory: 1024})
+	s.record(srv, foo2, now-60, &pb.StatsResponse{Requests: 5, Memory: 2048})
+	s.record(srv, foo1, now, &pb.StatsResponse{Requests: 20, Memory: 1024})
+	s.record(&registry.Service{Name: "bar"}, &registry.Node{Id: "bar-1"}, now, &pb.StatsResponse{Requests: 3})
+
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "micro"})
+	h := &Metrics{scraper: s}
+
+	// the values of the last hour are returned by default
+	var rsp pb.QueryMetricsResponse
+	assert.NoError(t, h.Query(ctx, &pb.QueryMetricsRequest{Service: "foo", Metric: "requests"}, &rsp))
+	if assert.Len(t, rsp.Series, 2) {
+		assert.Equal(t, "foo-1", rsp.Series[0].Node)
+		assert.Equal(t, []*pb.Point{{Timestamp: now - 60, Value: 10}, {Timestamp: now, Value: 20}}, rsp.Series[0].Points)
+	}
+
+	// the values of the nodes can be summed
+	rsp = pb.QueryMetricsResponse{}
+	assert.NoError(t, h.Query(ctx, &pb.QueryMetricsRequest{Service: "foo", Metric: "memory", Aggregate: true}, &rsp))
+	if assert.Len(t, rsp.Series, 1) {
+		assert.Equal(t, "", rsp.Series[0].Node)
+		assert.Equal(t, []*pb.Point{{Timestamp: now - 60, Value: 3072}, {Timestamp: now, Value: 1024}}, rsp.Series[0].Points)
+	}
+
+	err := h.Query(ctx, &pb.QueryMetricsRequest{Metric: "foo"}, &rsp)
+	assert.Error(t, err, "Expected an error querying an invalid metric")
+
+	// the latest values are served to prometheus
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/federate", nil))
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE micro_requests_total counter\n")
+	assert.Contains(t, body, `micro_requests_total{service="foo",version="latest",node="foo-1"} 20 `)
+	assert.Contains(t, body, `micro_requests_total{service="bar",version="",node="bar-1"} 3 `)
+
+	// values past retention are pruned, along with the series of nodes which have gone
+	s.prune(now - 30)
+	assert.Len(t, s.series["foo/foo-1/requests"].points, 1)
+	assert.Nil(t, s.series["foo/foo-2/requests"])
+}
@@ -0,0 +1,534 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/config"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// DefaultProbeInterval is how often probes are run if they don't specify an interval
+	DefaultProbeInterval = time.Minute
+	// DefaultProbeTimeout is how long a probe can take to succeed if it doesn't specify a timeout
+	DefaultProbeTimeout = time.Second * 10
+	// DefaultProbeRetention is how long the results of probes are kept
+	DefaultProbeRetention = time.Hour * 24
+	// DefaultProbeGateway is the address of the api gateway http probes with a path are sent to
+	DefaultProbeGateway = "http://localhost:8080"
+	// ProbeFailures is the number of times in a row a probe must fail at a location for an alert
+	// to be published
+	ProbeFailures = 3
+)
+
+// probeConfigKey is the config key the probes are set under, each probe being keyed by its name
+// e.g. micro config set debug.probes.helloworld '{"service": "helloworld", "endpoint": "Helloworld.Call"}'
+const probeConfigKey = "debug.probes"
+
+// probePrefix is the prefix of the keys the results of probes are stored under, the key of a
+// result is probe/[name]/[location]/[timestamp]
+const probePrefix = "probe/"
+
+const (
+	// ProbeRPC calls an endpoint of a service
+	ProbeRPC = "rpc"
+	// ProbeHTTP requests a url, by default a route of the api gateway
+	ProbeHTTP = "http"
+)
+
+// Probe is the definition of a synthetic check
+type Probe struct {
+	// Type of probe, rpc or http. Defaults to rpc if a service is set, otherwise http.
+	Type string `json:"type"`
+	// Service and endpoint rpc probes call
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	// Request rpc probes call the endpoint with
+	Request interface{} `json:"request"`
+	// Response the endpoint should respond with. The response can contain other fields, only
+	// those set must match.
+	Response interface{} `json:"response"`
+	// URL http probes request, a path e.g. /helloworld is a route of the api gateway
+	URL string `json:"url"`
+	// Method http probes request with, defaults to GET or POST if there's a body
+	Method string `json:"method"`
+	// Body http probes request with
+	Body string `json:"body"`
+	// Status http probes should respond with, defaults to 200
+	Status int `json:"status"`
+	// Contains is text the body of the response should contain
+	Contains string `json:"contains"`
+	// Interval the probe is run at e.g. 30s, defaults to a minute
+	Interval string `json:"interval"`
+	// Timeout the probe must succeed within e.g. 5s, defaults to 10 seconds
+	Timeout string `json:"timeout"`
+}
+
+// probe is a Probe which has been validated
+type probe struct {
+	*Probe
+	name     string
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// newProbe validates the Probe
+func newProbe(name string, p *Probe) (*probe, error) {
+	if strings.Contains(name, "/") {
+		return nil, fmt.Errorf("name can't contain a slash")
+	}
+	if len(p.Type) == 0 {
+		p.Type = ProbeHTTP
+		if len(p.Service) > 0 {
+			p.Type = ProbeRPC
+		}
+	}
+
+	switch p.Type {
+	case ProbeRPC:
+		if len(p.Service) == 0 || len(p.Endpoint) == 0 {
+			return nil, fmt.Errorf("missing service or endpoint")
+		}
+	case ProbeHTTP:
+		if len(p.URL) == 0 {
+			return nil, fmt.Errorf("missing url")
+		}
+		if len(p.Method) == 0 {
+			p.Method = http.MethodGet
+			if len(p.Body) > 0 {
+				p.Method = http.MethodPost
+			}
+		}
+		if p.Status == 0 {
+			p.Status = http.StatusOK
+		}
+	default:
+		return nil, fmt.Errorf("type must be rpc or http")
+	}
+
+	pr := &probe{Probe: p, name: name, interval: DefaultProbeInterval, timeout: DefaultProbeTimeout}
+	if len(p.Interval) > 0 {
+		d, err := time.ParseDuration(p.Interval)
+		if err != nil || d < time.Second {
+			return nil, fmt.Errorf("interval must be a duration of at least a second")
+		}
+		pr.interval = d
+	}
+	if len(p.Timeout) > 0 {
+		d, err := time.ParseDuration(p.Timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid timeout")
+		}
+		pr.timeout = d
+	}
+	return pr, nil
+}
+
+// target returns what the probe checks, for display
+func (p *probe) target() string {
+	if p.Type == ProbeRPC {
+		return p.Service + " " + p.Endpoint
+	}
+	return p.Method + " " + p.URL
+}
+
+// prober runs the probes set in config from its location, storing the results so those of every
+// location can be queried. An alert is published when a probe fails several times in a row.
+type prober struct {
+	client client.Client
+	http   *http.Client
+	// location the probes are run from, e.g. the region of the debug service
+	location string
+	// gateway the paths of http probes are requested from
+	gateway   string
+	retention time.Duration
+
+	sync.RWMutex
+	probes []*probe
+	// next is when each probe is next run
+	next map[string]time.Time
+	// failures are the number of times in a row each probe has failed
+	failures map[string]int
+	// firing are the probes alerts have been published for
+	firing map[string]bool
+	// publish the alert, nil if alerts are disabled
+	publish func(*debug.Alert) error
+}
+
+func newProber(c client.Client, location, gateway string, retention time.Duration) *prober {
+	if len(location) == 0 {
+		location, _ = os.Hostname()
+	}
+	return &prober{
+		client:    c,
+		http:      &http.Client{},
+		location:  strings.Replace(location, "/", "-", -1),
+		gateway:   strings.TrimSuffix(gateway, "/"),
+		retention: retention,
+		next:      make(map[string]time.Time),
+		failures:  make(map[string]int),
+		firing:    make(map[string]bool),
+		publish:   publishAlert,
+	}
+}
+
+// run the probes which are due until exit is closed, loading them from config each minute and
+// pruning the results every hour
+func (p *prober) run(exit chan bool) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var loaded, pruned time.Time
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		if now.Sub(loaded) >= time.Minute {
+			p.load()
+			loaded = now
+		}
+		if now.Sub(pruned) >= time.Hour {
+			p.prune()
+			pruned = now
+		}
+
+		for _, pr := range p.due(now) {
+			go p.check(pr)
+		}
+	}
+}
+
+// load the probes from config, the probes loaded previously are kept if there's an error
+func (p *prober) load() {
+	val, err := config.Get(probeConfigKey)
+	if err != nil {
+		log.Warnf("Error loading probes: %v", err)
+		return
+	}
+
+	defs := make(map[string]*Probe)
+	if val.Exists() {
+		if err := val.Scan(&defs); err != nil {
+			log.Warnf("Error loading probes: %v", err)
+			return
+		}
+	}
+
+	var probes []*probe
+	for name, def := range defs {
+		pr, err := newProbe(name, def)
+		if err != nil {
+			log.Warnf("Invalid probe %v: %v", name, err)
+			continue
+		}
+		probes = append(probes, pr)
+	}
+	sort.Slice(probes, func(i, j int) bool {
+		return probes[i].name < probes[j].name
+	})
+
+	p.Lock()
+	p.probes = probes
+	p.Unlock()
+}
+
+// due returns the probes which are due to run, scheduling their next run
+func (p *prober) due(now time.Time) []*probe {
+	p.Lock()
+	defer p.Unlock()
+
+	var due []*probe
+	for _, pr := range p.probes {
+		if next, ok := p.next[pr.name]; ok && now.Before(next) {
+			continue
+		}
+		p.next[pr.name] = now.Add(pr.interval)
+		due = append(due, pr)
+	}
+	return due
+}
+
+// check runs the probe, storing the result and publishing an alert if it has failed too many
+// times in a row
+func (p *prober) check(pr *probe) *pb.ProbeResult {
+	start := time.Now()
+	err := p.probe(pr)
+
+	result := &pb.ProbeResult{
+		Name:      pr.name,
+		Location:  p.location,
+		Timestamp: start.Unix(),
+		Success:   err == nil,
+		Latency:   int64(time.Since(start)),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	if b, err := json.Marshal(result); err == nil {
+		key := fmt.Sprintf("%v%v/%v/%020d", probePrefix, pr.name, p.location, start.UnixNano())
+		if err := store.Write(&store.Record{Key: key, Value: b}); err != nil {
+			log.Warnf("Error storing the result of probe %v: %v", pr.name, err)
+		}
+	}
+
+	p.alert(pr, result)
+	return result
+}
+
+// probe runs the probe, returning an error if it failed
+func (p *prober) probe(pr *probe) error {
+	ctx, cancel := context.WithTimeout(context.Background(), pr.timeout)
+	defer cancel()
+
+	if pr.Type == ProbeRPC {
+		request, err := json.Marshal(pr.Request)
+		if err != nil {
+			return err
+		}
+		if pr.Request == nil {
+			request = []byte(`{}`)
+		}
+		raw := json.RawMessage(request)
+
+		var response json.RawMessage
+		req := p.client.NewRequest(pr.Service, pr.Endpoint, &raw, client.WithContentType("application/json"))
+		if err := p.client.Call(ctx, req, &response, client.WithRequestTimeout(pr.timeout)); err != nil {
+			return err
+		}
+		if pr.Response == nil {
+			return nil
+		}
+
+		var rsp interface{}
+		if err := json.Unmarshal(response, &rsp); err != nil {
+			return fmt.Errorf("invalid response: %v", err)
+		}
+		if !matchResponse(pr.Response, rsp) {
+			return fmt.Errorf("unexpected response %v", string(response))
+		}
+		return nil
+	}
+
+	url := pr.URL
+	if strings.HasPrefix(url, "/") {
+		url = p.gateway + url
+	}
+	var body io.Reader
+	if len(pr.Body) > 0 {
+		body = strings.NewReader(pr.Body)
+	}
+	req, err := http.NewRequest(pr.Method, url, body)
+	if err != nil {
+		return err
+	}
+	rsp, err := p.http.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	b, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+	if rsp.StatusCode != pr.Status {
+		return fmt.Errorf("unexpected status %v", rsp.StatusCode)
+	}
+	if len(pr.Contains) > 0 && !bytes.Contains(b, []byte(pr.Contains)) {
+		return fmt.Errorf("response doesn't contain %q", pr.Contains)
+	}
+	return nil
+}
+
+// matchResponse returns true if the fields of the expected response match those of the actual
+// response. Objects can contain fields which aren't expected.
+func matchResponse(expected, actual interface{}) bool {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range e {
+			if !matchResponse(v, a[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			return false
+		}
+		for i := range e {
+			if !matchResponse(e[i], a[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
+}
+
+// alert publishes an alert once the probe has failed too many times in a row at the location,
+// the alert isn't published again until the probe succeeds
+func (p *prober) alert(pr *probe, result *pb.ProbeResult) {
+	p.Lock()
+	defer p.Unlock()
+
+	if result.Success {
+		delete(p.failures, pr.name)
+		delete(p.firing, pr.name)
+		return
+	}
+	p.failures[pr.name]++
+	if p.publish == nil || p.failures[pr.name] < ProbeFailures || p.firing[pr.name] {
+		return
+	}
+
+	alert := &debug.Alert{
+		Type:      debug.AlertProbeFailure,
+		Service:   pr.Service,
+		Reason:    fmt.Sprintf("Probe %v of %v failed %v times in a row from %v: %v", pr.name, pr.target(), p.failures[pr.name], p.location, result.Error),
+		Timestamp: result.Timestamp,
+	}
+	if err := p.publish(alert); err != nil {
+		log.Warnf("Error publishing alert for probe %v: %v", pr.name, err)
+		return
+	}
+	p.firing[pr.name] = true
+}
+
+// prune deletes the results logged before the retention period
+func (p *prober) prune() {
+	recs, err := store.Read("", store.Prefix(probePrefix))
+	if err != nil && err != store.ErrNotFound {
+		log.Warnf("Error reading probe results: %v", err)
+		return
+	}
+	cutoff := time.Now().Add(-p.retention).Unix()
+	for _, rec := range recs {
+		var r pb.ProbeResult
+		if err := json.Unmarshal(rec.Value, &r); err != nil || r.Timestamp < cutoff {
+			store.Delete(rec.Key)
+		}
+	}
+}
+
+// results returns the status of the probes at each location since the start, and the results of
+// the probe if a name is given
+func (p *prober) results(name, location string, start int64) (*pb.ProbeResultsResponse, error) {
+	prefix := probePrefix
+	if len(name) > 0 {
+		prefix += name + "/"
+	}
+	recs, err := store.Read("", store.Prefix(prefix))
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	p.RLock()
+	probes := make(map[string]*probe, len(p.probes))
+	for _, pr := range p.probes {
+		probes[pr.name] = pr
+	}
+	p.RUnlock()
+
+	rsp := new(pb.ProbeResultsResponse)
+	statuses := make(map[string]*pb.ProbeStatus)
+	latency := make(map[string]int64)
+	for _, rec := range recs {
+		r := new(pb.ProbeResult)
+		if err := json.Unmarshal(rec.Value, r); err != nil || r.Timestamp < start {
+			continue
+		}
+		if len(location) > 0 && r.Location != location {
+			continue
+		}
+		// the results of probes which have since been removed from config
+		pr, ok := probes[r.Name]
+		if !ok {
+			continue
+		}
+
+		key := r.Name + "/" + r.Location
+		s, ok := statuses[key]
+		if !ok {
+			s = &pb.ProbeStatus{Name: r.Name, Type: pr.Type, Target: pr.target(), Location: r.Location}
+			statuses[key] = s
+			rsp.Probes = append(rsp.Probes, s)
+		}
+		s.Total++
+		if !r.Success {
+			s.Failures++
+		}
+		latency[key] += r.Latency
+		if s.Last == nil || r.Timestamp >= s.Last.Timestamp {
+			s.Last = r
+		}
+
+		if len(name) > 0 {
+			rsp.Results = append(rsp.Results, r)
+		}
+	}
+
+	for key, s := range statuses {
+		s.Latency = latency[key] / int64(s.Total)
+	}
+	sort.Slice(rsp.Probes, func(i, j int) bool {
+		if rsp.Probes[i].Name != rsp.Probes[j].Name {
+			return rsp.Probes[i].Name < rsp.Probes[j].Name
+		}
+		return rsp.Probes[i].Location < rsp.Probes[j].Location
+	})
+	sort.SliceStable(rsp.Results, func(i, j int) bool {
+		return rsp.Results[i].Timestamp < rsp.Results[j].Timestamp
+	})
+	return rsp, nil
+}
+
+// Probes implements the handler for querying the results of probes
+type Probes struct {
+	prober *prober
+}
+
+// Results of the probes, by default those of the last hour
+func (p *Probes) Results(ctx context.Context, req *pb.ProbeResultsRequest, rsp *pb.ProbeResultsResponse) error {
+	if err := authorize(ctx, "debug.Probes.Results"); err != nil {
+		return err
+	}
+	if req.Start == 0 {
+		req.Start = time.Now().Add(-time.Hour).Unix()
+	}
+
+	res, err := p.prober.results(req.Name, req.Location, req.Start)
+	if err != nil {
+		return errors.InternalServerError("debug.Probes.Results", "Error reading results: %v", err)
+	}
+	rsp.Probes = res.Probes
+	rsp.Results = res.Results
+	return nil
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProbe(t *testing.T) {
+	p, err := newProbe("foo", &Probe{Service: "foo", Endpoint: "Foo.Bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, ProbeRPC, p.Type)
+	assert.Equal(t, DefaultProbeInterval, p.interval)
+	assert.Equal(t, "foo Foo.Bar", p.target())
+
+	p, err = newProbe("foo", &Probe{URL: "/foo", Body: "{}", Interval: "30s"})
+	assert.NoError(t, err)
+	assert.Equal(t, ProbeHTTP, p.Type)
+	assert.Equal(t, http.MethodPost, p.Method)
+	assert.Equal(t, http.StatusOK, p.Status)
+	assert.Equal(t, time.Second*30, p.interval)
+
+	for _, def := range []*Probe{
+		{Service: "foo"},
+		{Type: ProbeHTTP},
+		{Type: "tcp", URL: "/foo"},
+		{URL: "/foo", Interval: "1ms"},
+	} {
+		_, err := newProbe("foo", def)
+		assert.Error(t, err, "Expected an error for %+v", def)
+	}
+}
+
+func TestMatchResponse(t *testing.T) {
+	actual := map[string]interface{}{
+		"msg":   "Hello John",
+		"count": 2.0,
+		"tags":  []interface{}{"a", "b"},
+	}
+	assert.True(t, matchResponse(map[string]interface{}{"msg": "Hello John"}, actual))
+	assert.True(t, matchResponse(map[string]interface{}{"tags": []interface{}{"a", "b"}, "count": 2.0}, actual))
+	assert.False(t, matchResponse(map[string]interface{}{"msg": "Hello Jane"}, actual))
+	assert.False(t, matchResponse(map[string]interface{}{"tags": []interface{}{"a"}}, actual))
+	assert.False(t, matchResponse(map[string]interface{}{"missing": "foo"}, actual))
+}
+
+func TestProber(t *testing.T) {
+	defaultStore := store.DefaultStore
+	defer func() { store.DefaultStore = defaultStore }()
+	store.DefaultStore = memory.NewStore()
+
+	healthy := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		fmt.Fprint(w, `{"msg": "Hello John"}`)
+	}))
+	defer srv.Close()
+
+	var alerts []*debug.Alert
+	p := newProber(nil, "eu-west", srv.URL, DefaultProbeRetention)
+	p.publish = func(a *debug.Alert) error {
+		alerts = append(alerts, a)
+		return nil
+	}
+
+	hello, err := newProbe("hello", &Probe{URL: "/helloworld", Contains: "Hello John"})
+	assert.NoError(t, err)
+	missing, err := newProbe("missing", &Probe{URL: "/helloworld", Contains: "Hello Jane"})
+	assert.NoError(t, err)
+	p.probes = []*probe{hello, missing}
+
+	// both probes are due at first, then not until the interval has passed
+	now := time.Now()
+	assert.Len(t, p.due(now), 2)
+	assert.Len(t, p.due(now.Add(time.Second)), 0)
+
+	assert.True(t, p.check(hello).Success)
+	result := p.check(missing)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "Hello Jane")
+
+	// an alert is published once the probe fails too many times in a row, once
+	healthy = false
+	for i := 0; i < ProbeFailures+1; i++ {
+		assert.False(t, p.check(hello).Success)
+	}
+	if assert.Len(t, alerts, 1) {
+		assert.Equal(t, debug.AlertProbeFailure, alerts[0].Type)
+		assert.Contains(t, alerts[0].Reason, "hello")
+		assert.Contains(t, alerts[0].Reason, "unexpected status 500")
+	}
+
+	rsp, err := p.results("", "", now.Add(-time.Minute).Unix())
+	assert.NoError(t, err)
+	if assert.Len(t, rsp.Probes, 2) {
+		assert.Equal(t, "hello", rsp.Probes[0].Name)
+		assert.Equal(t, "eu-west", rsp.Probes[0].Location)
+		assert.Equal(t, "GET /helloworld", rsp.Probes[0].Target)
+		assert.Equal(t, uint64(ProbeFailures+2), rsp.Probes[0].Total)
+		assert.Equal(t, uint64(ProbeFailures+1), rsp.Probes[0].Failures)
+		assert.False(t, rsp.Probes[0].Last.Success)
+	}
+	assert.Len(t, rsp.Results, 0)
+
+	rsp, err = p.results("hello", "", now.Add(-time.Minute).Unix())
+	assert.NoError(t, err)
+	assert.Len(t, rsp.Probes, 1)
+	assert.Len(t, rsp.Results, ProbeFailures+2)
+
+	rsp, err = p.results("", "us-east", now.Add(-time.Minute).Unix())
+	assert.NoError(t, err)
+	assert.Len(t, rsp.Probes, 0)
+}
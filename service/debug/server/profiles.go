@@ -0,0 +1,291 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// DefaultProfileInterval is how often profiles are collected from services
+	DefaultProfileInterval = time.Minute * 5
+	// DefaultProfileRetention is how long collected profiles are kept
+	DefaultProfileRetention = time.Hour * 24
+	// DefaultProfileDuration is how long cpu profiles are collected for
+	DefaultProfileDuration = time.Second * 10
+	// DefaultProfileTypes are the types of profile collected
+	DefaultProfileTypes = []string{"cpu", "heap"}
+)
+
+// profilePrefix is the prefix of the keys profiles are stored under, the key of a profile is
+// profile/[service]/[type]/[timestamp]/[node] so the profiles of a service can be listed by type
+const profilePrefix = "profile/"
+
+// profileKey returns the key a profile is stored under, the timestamp is padded so the keys sort
+// in the order the profiles were collected
+func profileKey(info *pb.ProfileInfo) string {
+	return fmt.Sprintf("%v%v/%v/%020d/%v", profilePrefix, info.Service, info.Type, info.Timestamp, info.Node)
+}
+
+// keyTimestamp returns the timestamp a profile was collected at from its key
+func keyTimestamp(key string) (int64, bool) {
+	parts := strings.Split(strings.TrimPrefix(key, profilePrefix), "/")
+	if len(parts) != 4 {
+		return 0, false
+	}
+	ts, err := strconv.ParseInt(parts[2], 10, 64)
+	return ts, err == nil
+}
+
+// collector periodically collects profiles from every node of the services via their debug
+// handler and stores them in the blob store
+type collector struct {
+	// name of the debug service, which isn't profiled
+	name   string
+	client client.Client
+
+	interval  time.Duration
+	retention time.Duration
+	duration  time.Duration
+	types     []string
+}
+
+// run collects profiles and deletes those past retention until exit is closed
+func (c *collector) run(exit chan bool) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			c.collect()
+			c.prune()
+		}
+	}
+}
+
+// collect a profile of each type from every node of the services. The nodes are profiled
+// concurrently since cpu profiles take a while.
+func (c *collector) collect() {
+	srvs, err := registry.ListServices()
+	if err != nil {
+		log.Warnf("Error listing services to profile: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	seen := make(map[string]bool, len(srvs))
+	for _, s := range srvs {
+		if s.Name == c.name || seen[s.Name] {
+			continue
+		}
+		seen[s.Name] = true
+
+		versions, err := registry.GetService(s.Name)
+		if err != nil {
+			log.Warnf("Error getting service %v to profile: %v", s.Name, err)
+			continue
+		}
+
+		for _, srv := range versions {
+			for _, node := range srv.Nodes {
+				wg.Add(1)
+				go func(srv *registry.Service, node *registry.Node) {
+					defer wg.Done()
+					for _, typ := range c.types {
+						if err := c.collectProfile(srv, node, typ); err != nil {
+							log.Warnf("Error collecting %v profile from %v node %v: %v", typ, srv.Name, node.Id, err)
+						}
+					}
+				}(srv, node)
+			}
+		}
+	}
+	wg.Wait()
+}
+
+// collectProfile collects a profile from the node and stores it
+func (c *collector) collectProfile(srv *registry.Service, node *registry.Node, typ string) error {
+	req := c.client.NewRequest(srv.Name, "Debug.Profile", &pb.ProfileRequest{
+		Type:     typ,
+		Duration: int64(c.duration.Seconds()),
+	})
+	rsp := new(pb.ProfileResponse)
+
+	// cpu profiles take the duration to collect
+	err := c.client.Call(context.Background(), req, rsp,
+		client.WithAddress(node.Address),
+		client.WithRequestTimeout(c.duration+time.Second*10),
+	)
+	if err != nil {
+		return err
+	}
+
+	info := &pb.ProfileInfo{
+		Service:   srv.Name,
+		Version:   srv.Version,
+		Node:      node.Id,
+		Type:      typ,
+		Timestamp: time.Now().Unix(),
+		Size:      int64(len(rsp.Data)),
+	}
+	return writeProfile(info, rsp.Data)
+}
+
+// writeProfile writes the profile to the blob store and indexes it in the store
+func writeProfile(info *pb.ProfileInfo, data []byte) error {
+	key := profileKey(info)
+	nsOpt := store.BlobNamespace(namespace.DefaultNamespace)
+	if err := store.DefaultBlobStore.Write(key, bytes.NewReader(data), nsOpt); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return store.Write(&store.Record{Key: key, Value: b})
+}
+
+// prune deletes the profiles collected before the retention period
+func (c *collector) prune() {
+	keys, err := store.List(store.Prefix(profilePrefix))
+	if err != nil {
+		log.Warnf("Error listing profiles: %v", err)
+		return
+	}
+
+	cutoff := time.Now().Add(-c.retention).Unix()
+	nsOpt := store.BlobNamespace(namespace.DefaultNamespace)
+	for _, key := range keys {
+		if ts, ok := keyTimestamp(key); !ok || ts >= cutoff {
+			continue
+		}
+		if err := store.DefaultBlobStore.Delete(key, nsOpt); err != nil {
+			log.Warnf("Error deleting profile %v: %v", key, err)
+			continue
+		}
+		if err := store.Delete(key); err != nil {
+			log.Warnf("Error deleting profile %v: %v", key, err)
+		}
+	}
+}
+
+// Profiles implements the handler for reading the profiles collected from services
+type Profiles struct{}
+
+// List the profiles collected from a service, or all services if none is specified
+func (p *Profiles) List(ctx context.Context, req *pb.ListProfilesRequest, rsp *pb.ListProfilesResponse) error {
+	if err := authorize(ctx, "debug.Profiles.List"); err != nil {
+		return err
+	}
+
+	prefix := profilePrefix
+	if len(req.Service) > 0 {
+		prefix += req.Service + "/"
+		if len(req.Type) > 0 {
+			prefix += req.Type + "/"
+		}
+	}
+
+	recs, err := store.Read("", store.Prefix(prefix))
+	if err != nil && err != store.ErrNotFound {
+		return errors.InternalServerError("debug.Profiles.List", "Error reading profiles: %v", err)
+	}
+
+	for _, rec := range recs {
+		info := new(pb.ProfileInfo)
+		if err := json.Unmarshal(rec.Value, info); err != nil {
+			continue
+		}
+		if len(req.Type) > 0 && info.Type != req.Type {
+			continue
+		}
+		rsp.Profiles = append(rsp.Profiles, info)
+	}
+
+	sort.Slice(rsp.Profiles, func(i, j int) bool {
+		return rsp.Profiles[i].Timestamp < rsp.Profiles[j].Timestamp
+	})
+	return nil
+}
+
+// Read the profile of a service collected at or before the time requested
+func (p *Profiles) Read(ctx context.Context, req *pb.ReadProfileRequest, rsp *pb.ReadProfileResponse) error {
+	if err := authorize(ctx, "debug.Profiles.Read"); err != nil {
+		return err
+	}
+	if len(req.Service) == 0 {
+		return errors.BadRequest("debug.Profiles.Read", "Missing service")
+	}
+	if len(req.Type) == 0 {
+		req.Type = "cpu"
+	}
+
+	keys, err := store.List(store.Prefix(profilePrefix + req.Service + "/" + req.Type + "/"))
+	if err != nil {
+		return errors.InternalServerError("debug.Profiles.Read", "Error listing profiles: %v", err)
+	}
+
+	// find the latest profile collected at or before the time requested
+	var key string
+	var latest int64
+	for _, k := range keys {
+		ts, ok := keyTimestamp(k)
+		if !ok || (req.At > 0 && ts > req.At) || ts < latest {
+			continue
+		}
+		key, latest = k, ts
+	}
+	if len(key) == 0 {
+		return errors.NotFound("debug.Profiles.Read", "No %v profile of %v found", req.Type, req.Service)
+	}
+
+	recs, err := store.Read(key)
+	if err != nil {
+		return errors.InternalServerError("debug.Profiles.Read", "Error reading profile: %v", err)
+	}
+	rsp.Info = new(pb.ProfileInfo)
+	if err := json.Unmarshal(recs[0].Value, rsp.Info); err != nil {
+		return errors.InternalServerError("debug.Profiles.Read", "Error reading profile: %v", err)
+	}
+
+	blob, err := store.DefaultBlobStore.Read(key, store.BlobNamespace(namespace.DefaultNamespace))
+	if err != nil {
+		return errors.InternalServerError("debug.Profiles.Read", "Error reading profile: %v", err)
+	}
+	if rsp.Data, err = ioutil.ReadAll(blob); err != nil {
+		return errors.InternalServerError("debug.Profiles.Read", "Error reading profile: %v", err)
+	}
+	return nil
+}
+
+// authorize the request, the profiles are only available to the default namespace as they're
+// collected from the services in it
+func authorize(ctx context.Context, method string) error {
+	if err := namespace.Authorize(ctx, namespace.DefaultNamespace); err == namespace.ErrForbidden {
+		return errors.Forbidden(method, err.Error())
+	} else if err == namespace.ErrUnauthorized {
+		return errors.Unauthorized(method, err.Error())
+	} else if err != nil {
+		return errors.InternalServerError(method, err.Error())
+	}
+	return nil
+}
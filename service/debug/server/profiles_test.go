@@ -0,0 +1,92 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBlobStore struct {
+	blobs map[string][]byte
+}
+
+func (t *testBlobStore) Read(key string, opts ...store.BlobOption) (io.Reader, error) {
+	b, ok := t.blobs[key]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return bytes.NewReader(b), nil
+}
+
+func (t *testBlobStore) Write(key string, blob io.Reader, opts ...store.BlobOption) error {
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(blob)
+	t.blobs[key] = buf.Bytes()
+	return nil
+}
+
+func (t *testBlobStore) Delete(key string, opts ...store.BlobOption) error {
+	delete(t.blobs, key)
+	return nil
+}
+
+func TestProfiles(t *testing.T) {
+	defaultStore, defaultBlobStore := store.DefaultStore, store.DefaultBlobStore
+	defer func() {
+		store.DefaultStore, store.DefaultBlobStore = defaultStore, defaultBlobStore
+	}()
+	blobs := &testBlobStore{blobs: make(map[string][]byte)}
+	store.DefaultStore, store.DefaultBlobStore = memory.NewStore(), blobs
+
+	now := time.Now().Unix()
+	profiles := []*pb.ProfileInfo{
+		{Service: "foo", Type: "cpu", Node: "foo-1", Timestamp: now - 7200},
+		{Service: "foo", Type: "cpu", Node: "foo-1", Timestamp: now - 60},
+		{Service: "foo", Type: "heap", Node: "foo-1", Timestamp: now - 60},
+		{Service: "bar", Type: "cpu", Node: "bar-1", Timestamp: now - 60},
+	}
+	for _, p := range profiles {
+		assert.NoError(t, writeProfile(p, []byte(profileKey(p))))
+	}
+
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "micro"})
+	h := new(Profiles)
+
+	var listRsp pb.ListProfilesResponse
+	assert.NoError(t, h.List(ctx, &pb.ListProfilesRequest{Service: "foo", Type: "cpu"}, &listRsp))
+	assert.Len(t, listRsp.Profiles, 2)
+
+	// the latest profile is read by default
+	var readRsp pb.ReadProfileResponse
+	assert.NoError(t, h.Read(ctx, &pb.ReadProfileRequest{Service: "foo", Type: "cpu"}, &readRsp))
+	assert.Equal(t, now-60, readRsp.Info.Timestamp)
+	assert.Equal(t, profileKey(profiles[1]), string(readRsp.Data))
+
+	// otherwise the last one collected at or before the time
+	readRsp = pb.ReadProfileResponse{}
+	assert.NoError(t, h.Read(ctx, &pb.ReadProfileRequest{Service: "foo", Type: "cpu", At: now - 3600}, &readRsp))
+	assert.Equal(t, now-7200, readRsp.Info.Timestamp)
+
+	err := h.Read(ctx, &pb.ReadProfileRequest{Service: "foo", Type: "cpu", At: now - 86400}, &readRsp)
+	assert.Error(t, err, "Expected an error when no profile was collected before the time")
+
+	// profiles past retention are deleted
+	c := &collector{retention: time.Hour}
+	c.prune()
+	listRsp = pb.ListProfilesResponse{}
+	assert.NoError(t, h.List(ctx, &pb.ListProfilesRequest{}, &listRsp))
+	assert.Len(t, listRsp.Profiles, 3)
+	assert.Len(t, blobs.blobs, 3)
+
+	// other namespaces can't read the profiles
+	ctx = auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "foo"})
+	assert.Error(t, h.List(ctx, &pb.ListProfilesRequest{}, &listRsp))
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// logQuery is a parsed log query, a subset of LogQL. The query selects records by their labels
+// and optionally filters them by their message e.g. {service="foo", level!="debug"} |= "timeout"
+type logQuery struct {
+	matchers []*matcher
+	filters  []*filter
+}
+
+// matcher of a label, the op is one of =, !=, =~ or !~
+type matcher struct {
+	label string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+// filter of the message, the op is one of |=, !=, |~ or !~
+type filter struct {
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+// parseQuery parses a query, a blank query selects every record
+func parseQuery(q string) (*logQuery, error) {
+	p := &queryParser{input: strings.TrimSpace(q)}
+	query := new(logQuery)
+	if len(p.input) == 0 {
+		return query, nil
+	}
+
+	// the selector
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.consume("}") {
+		if len(query.matchers) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		m := &matcher{label: p.ident()}
+		if len(m.label) == 0 {
+			return nil, p.errorf("expected a label")
+		}
+		m.op = p.op("=~", "!~", "!=", "=")
+		if len(m.op) == 0 {
+			return nil, p.errorf("expected =, !=, =~ or !~")
+		}
+		val, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		m.value = val
+		if m.op == "=~" || m.op == "!~" {
+			if m.re, err = regexp.Compile("^(?:" + val + ")$"); err != nil {
+				return nil, p.errorf("invalid regex %q: %v", val, err)
+			}
+		}
+		query.matchers = append(query.matchers, m)
+	}
+
+	// the filters
+	for !p.done() {
+		f := &filter{op: p.op("|=", "!=", "|~", "!~")}
+		if len(f.op) == 0 {
+			return nil, p.errorf("expected |=, !=, |~ or !~")
+		}
+		val, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		f.value = val
+		if f.op == "|~" || f.op == "!~" {
+			if f.re, err = regexp.Compile(val); err != nil {
+				return nil, p.errorf("invalid regex %q: %v", val, err)
+			}
+		}
+		query.filters = append(query.filters, f)
+	}
+
+	return query, nil
+}
+
+// match returns true if the labels and message of a record match the query
+func (q *logQuery) match(labels map[string]string, message string) bool {
+	for _, m := range q.matchers {
+		v := labels[m.label]
+		var ok bool
+		switch m.op {
+		case "=":
+			ok = v == m.value
+		case "!=":
+			ok = v != m.value
+		case "=~":
+			ok = m.re.MatchString(v)
+		case "!~":
+			ok = !m.re.MatchString(v)
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	for _, f := range q.filters {
+		var ok bool
+		switch f.op {
+		case "|=":
+			ok = strings.Contains(message, f.value)
+		case "!=":
+			ok = !strings.Contains(message, f.value)
+		case "|~":
+			ok = f.re.MatchString(message)
+		case "!~":
+			ok = !f.re.MatchString(message)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// equal returns the value the label must equal to match the query, if any
+func (q *logQuery) equal(label string) (string, bool) {
+	for _, m := range q.matchers {
+		if m.label == label && m.op == "=" {
+			return m.value, true
+		}
+	}
+	return "", false
+}
+
+type queryParser struct {
+	input string
+	pos   int
+}
+
+func (p *queryParser) skip() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *queryParser) done() bool {
+	p.skip()
+	return p.pos >= len(p.input)
+}
+
+// consume the token if it's next
+func (p *queryParser) consume(tok string) bool {
+	p.skip()
+	if strings.HasPrefix(p.input[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) expect(tok string) error {
+	if !p.consume(tok) {
+		return p.errorf("expected %v", tok)
+	}
+	return nil
+}
+
+// op consumes the first of the operators which is next
+func (p *queryParser) op(ops ...string) string {
+	for _, o := range ops {
+		if p.consume(o) {
+			return o
+		}
+	}
+	return ""
+}
+
+func (p *queryParser) ident() string {
+	p.skip()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c != '_' && c != '.' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// str consumes a quoted string, double quoted strings are unescaped and backticks are raw
+func (p *queryParser) str() (string, error) {
+	p.skip()
+	if p.pos >= len(p.input) || (p.input[p.pos] != '"' && p.input[p.pos] != '`') {
+		return "", p.errorf("expected a quoted string")
+	}
+
+	quote := p.input[p.pos]
+	for end := p.pos + 1; end < len(p.input); end++ {
+		if quote == '"' && p.input[end] == '\\' {
+			end++
+			continue
+		}
+		if p.input[end] != quote {
+			continue
+		}
+
+		raw := p.input[p.pos : end+1]
+		p.pos = end + 1
+		if quote == '`' {
+			return raw[1 : len(raw)-1], nil
+		}
+		s, err := strconv.Unquote(raw)
+		if err != nil {
+			return "", p.errorf("invalid string %v", raw)
+		}
+		return s, nil
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *queryParser) errorf(format string, v ...interface{}) error {
+	return fmt.Errorf("Invalid query at position %d: %v", p.pos, fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	tt := []struct {
+		query   string
+		labels  map[string]string
+		message string
+		match   bool
+		err     bool
+	}{
+		{query: "", labels: map[string]string{"service": "foo"}, match: true},
+		{query: `{service="foo"}`, labels: map[string]string{"service": "foo"}, match: true},
+		{query: `{service="foo"}`, labels: map[string]string{"service": "bar"}, match: false},
+		{query: `{service="foo", level!="debug"}`, labels: map[string]string{"service": "foo", "level": "debug"}, match: false},
+		{query: `{service=~"fo+"}`, labels: map[string]string{"service": "foo"}, match: true},
+		{query: `{service=~"fo"}`, labels: map[string]string{"service": "foo"}, match: false},
+		{query: `{service!~"bar|baz"}`, labels: map[string]string{"service": "foo"}, match: true},
+		{query: `{service="foo"} |= "timeout"`, labels: map[string]string{"service": "foo"}, message: "request timeout", match: true},
+		{query: `{service="foo"} |= "timeout" != "retry"`, labels: map[string]string{"service": "foo"}, message: "timeout, retry", match: false},
+		{query: "{service=\"foo\"} |~ `time(out)?`", labels: map[string]string{"service": "foo"}, message: "time", match: true},
+		{query: `{service="foo"} !~ "\\d+"`, labels: map[string]string{"service": "foo"}, message: "404", match: false},
+		{query: `service="foo"`, err: true},
+		{query: `{service="foo"`, err: true},
+		{query: `{service=foo}`, err: true},
+		{query: `{service=~"("}`, err: true},
+		{query: `{service="foo"} "timeout"`, err: true},
+		{query: `{service="foo"} |= "timeout`, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := parseQuery(tc.query)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.match, q.match(tc.labels, tc.message))
+			}
+		})
+	}
+}
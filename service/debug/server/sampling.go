@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+// SamplingInterval is how often the trace sampling is published so services started since it
+// was set receive it
+var SamplingInterval = time.Minute
+
+// samplingKey is the key the trace sampling is stored under
+const samplingKey = "sampling"
+
+// Tracing implements the handler for setting the sampling of the traces services export
+type Tracing struct{}
+
+// Sampling returns the trace sampling, if it's never been set the rate is 1 and updated is zero
+func (t *Tracing) Sampling(ctx context.Context, req *pb.SamplingRequest, rsp *pb.SamplingResponse) error {
+	if err := authorize(ctx, "debug.Tracing.Sampling"); err != nil {
+		return err
+	}
+
+	s, err := readSampling()
+	if err != nil {
+		return errors.InternalServerError("debug.Tracing.Sampling", "Error reading sampling: %v", err)
+	}
+	rsp.Sampling = s
+	return nil
+}
+
+// SetSampling stores the trace sampling and publishes it to the services
+func (t *Tracing) SetSampling(ctx context.Context, req *pb.SetSamplingRequest, rsp *pb.SetSamplingResponse) error {
+	if err := authorize(ctx, "debug.Tracing.SetSampling"); err != nil {
+		return err
+	}
+
+	s := req.Sampling
+	if s == nil {
+		return errors.BadRequest("debug.Tracing.SetSampling", "Missing sampling")
+	}
+	if s.Rate < 0 || s.Rate > 1 {
+		return errors.BadRequest("debug.Tracing.SetSampling", "Rate must be between 0 and 1")
+	}
+	for name, rate := range s.Services {
+		if len(name) == 0 {
+			return errors.BadRequest("debug.Tracing.SetSampling", "Missing service name")
+		}
+		if rate < 0 || rate > 1 {
+			return errors.BadRequest("debug.Tracing.SetSampling", "Rate of %v must be between 0 and 1", name)
+		}
+	}
+	s.Updated = time.Now().Unix()
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return errors.InternalServerError("debug.Tracing.SetSampling", "Error encoding sampling: %v", err)
+	}
+	if err := store.Write(&store.Record{Key: samplingKey, Value: b}); err != nil {
+		return errors.InternalServerError("debug.Tracing.SetSampling", "Error storing sampling: %v", err)
+	}
+
+	// the sampling is published again at the next interval if this fails
+	if err := publishSampling(b); err != nil {
+		log.Warnf("Error publishing sampling: %v", err)
+	}
+	return nil
+}
+
+// readSampling reads the trace sampling from the store
+func readSampling() (*pb.TraceSampling, error) {
+	recs, err := store.Read(samplingKey)
+	if err == store.ErrNotFound {
+		return &pb.TraceSampling{Rate: 1}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	s := new(pb.TraceSampling)
+	if err := json.Unmarshal(recs[0].Value, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// publishSampling publishes the encoded trace sampling to the services
+func publishSampling(b []byte) error {
+	return broker.Publish(debug.SamplingTopic, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body:   b,
+	})
+}
+
+// runSampling publishes the trace sampling, if it's been set, at each interval until exit is
+// closed
+func runSampling(exit chan bool) {
+	ticker := time.NewTicker(SamplingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+		}
+
+		recs, err := store.Read(samplingKey)
+		if err == store.ErrNotFound {
+			continue
+		} else if err != nil {
+			log.Warnf("Error reading sampling: %v", err)
+			continue
+		}
+		if err := publishSampling(recs[0].Value); err != nil {
+			log.Warnf("Error publishing sampling: %v", err)
+		}
+	}
+}
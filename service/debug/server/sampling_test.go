@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/broker"
+	bmemory "github.com/micro/micro/v3/service/broker/memory"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampling(t *testing.T) {
+	defaultStore, defaultBroker := store.DefaultStore, broker.DefaultBroker
+	defer func() {
+		store.DefaultStore, broker.DefaultBroker = defaultStore, defaultBroker
+	}()
+	store.DefaultStore, broker.DefaultBroker = memory.NewStore(), bmemory.NewBroker()
+	assert.NoError(t, broker.DefaultBroker.Connect())
+
+	var published []*pb.TraceSampling
+	_, err := broker.Subscribe(debug.SamplingTopic, func(msg *broker.Message) error {
+		s := new(pb.TraceSampling)
+		assert.NoError(t, json.Unmarshal(msg.Body, s))
+		published = append(published, s)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	tr := new(Tracing)
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "micro"})
+
+	// every trace is sampled until the sampling is set
+	rsp := &pb.SamplingResponse{}
+	assert.NoError(t, tr.Sampling(ctx, &pb.SamplingRequest{}, rsp))
+	assert.Equal(t, 1.0, rsp.Sampling.Rate)
+	assert.Equal(t, int64(0), rsp.Sampling.Updated)
+
+	sampling := &pb.TraceSampling{Rate: 0.1, Services: map[string]float64{"foo": 1}, SampleErrors: true}
+	assert.NoError(t, tr.SetSampling(ctx, &pb.SetSamplingRequest{Sampling: sampling}, &pb.SetSamplingResponse{}))
+
+	rsp = &pb.SamplingResponse{}
+	assert.NoError(t, tr.Sampling(ctx, &pb.SamplingRequest{}, rsp))
+	assert.Equal(t, 0.1, rsp.Sampling.Rate)
+	assert.Equal(t, 1.0, rsp.Sampling.Services["foo"])
+	assert.True(t, rsp.Sampling.SampleErrors)
+	assert.NotZero(t, rsp.Sampling.Updated)
+
+	// the sampling is published to the services
+	if assert.Len(t, published, 1) {
+		assert.Equal(t, 0.1, published[0].Rate)
+		assert.Equal(t, rsp.Sampling.Updated, published[0].Updated)
+	}
+
+	for _, s := range []*pb.TraceSampling{
+		nil,
+		{Rate: 2},
+		{Rate: 0.5, Services: map[string]float64{"foo": -1}},
+		{Rate: 0.5, Services: map[string]float64{"": 1}},
+	} {
+		err := tr.SetSampling(ctx, &pb.SetSamplingRequest{Sampling: s}, &pb.SetSamplingResponse{})
+		assert.Error(t, err, "Expected an error for %v", s)
+	}
+
+	// only the micro issuer can set the sampling
+	ctx = auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "foo"})
+	err = tr.SetSampling(ctx, &pb.SetSamplingRequest{Sampling: sampling}, &pb.SetSamplingResponse{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+// Package server is the debug service which collects debug information from services
+package server
+
+import (
+	"net/http"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// name of the debug service
+	name = "debug"
+
+	// Flags specific to the debug service
+	Flags = []cli.Flag{
+		&cli.DurationFlag{
+			Name:    "profile_interval",
+			Usage:   "Set how often profiles are collected from services, 0 to disable collection",
+			EnvVars: []string{"MICRO_DEBUG_PROFILE_INTERVAL"},
+			Value:   DefaultProfileInterval,
+		},
+		&cli.DurationFlag{
+			Name:    "profile_retention",
+			Usage:   "Set how long collected profiles are kept",
+			EnvVars: []string{"MICRO_DEBUG_PROFILE_RETENTION"},
+			Value:   DefaultProfileRetention,
+		},
+		&cli.DurationFlag{
+			Name:    "profile_duration",
+			Usage:   "Set how long cpu profiles are collected for",
+			EnvVars: []string{"MICRO_DEBUG_PROFILE_DURATION"},
+			Value:   DefaultProfileDuration,
+		},
+		&cli.StringSliceFlag{
+			Name:    "profile_types",
+			Usage:   "Set the types of profile collected e.g. cpu,heap,goroutine",
+			EnvVars: []string{"MICRO_DEBUG_PROFILE_TYPES"},
+			Value:   cli.NewStringSlice(DefaultProfileTypes...),
+		},
+		&cli.DurationFlag{
+			Name:    "metrics_interval",
+			Usage:   "Set how often the stats of services are scraped, 0 to disable scraping",
+			EnvVars: []string{"MICRO_DEBUG_METRICS_INTERVAL"},
+			Value:   DefaultMetricsInterval,
+		},
+		&cli.DurationFlag{
+			Name:    "metrics_retention",
+			Usage:   "Set how long the metrics scraped are kept",
+			EnvVars: []string{"MICRO_DEBUG_METRICS_RETENTION"},
+			Value:   DefaultMetricsRetention,
+		},
+		&cli.StringFlag{
+			Name:    "metrics_address",
+			Usage:   "Set the address the prometheus federation endpoint and dashboards are served on, blank to disable them",
+			EnvVars: []string{"MICRO_DEBUG_METRICS_ADDRESS"},
+			Value:   DefaultMetricsAddress,
+		},
+		&cli.BoolFlag{
+			Name:    "alerts",
+			Usage:   "Publish alerts to the broker when anomalies are detected in the metrics of services or probes fail",
+			EnvVars: []string{"MICRO_DEBUG_ALERTS"},
+			Value:   true,
+		},
+		&cli.DurationFlag{
+			Name:    "logs_retention",
+			Usage:   "Set how long the logs shipped by services are kept",
+			EnvVars: []string{"MICRO_DEBUG_LOGS_RETENTION"},
+			Value:   DefaultLogsRetention,
+		},
+		&cli.DurationFlag{
+			Name:    "capture_retention",
+			Usage:   "Set how long the spans of requests captured with the Micro-Debug header are kept",
+			EnvVars: []string{"MICRO_DEBUG_CAPTURE_RETENTION"},
+			Value:   DefaultCaptureRetention,
+		},
+		&cli.BoolFlag{
+			Name:    "probes",
+			Usage:   "Run the probes set in config from this location",
+			EnvVars: []string{"MICRO_DEBUG_PROBES"},
+			Value:   true,
+		},
+		&cli.StringFlag{
+			Name:    "probe_location",
+			Usage:   "Set the location probes are run from e.g. the region, defaults to the hostname",
+			EnvVars: []string{"MICRO_DEBUG_PROBE_LOCATION"},
+		},
+		&cli.StringFlag{
+			Name:    "probe_gateway",
+			Usage:   "Set the address of the api gateway http probes of a path are sent to",
+			EnvVars: []string{"MICRO_DEBUG_PROBE_GATEWAY"},
+			Value:   DefaultProbeGateway,
+		},
+		&cli.DurationFlag{
+			Name:    "probe_retention",
+			Usage:   "Set how long the results of probes are kept",
+			EnvVars: []string{"MICRO_DEBUG_PROBE_RETENTION"},
+			Value:   DefaultProbeRetention,
+		},
+		&cli.DurationFlag{
+			Name:    "timeline_retention",
+			Usage:   "Set how long the deploys, route changes, config changes and alerts on the timeline are kept",
+			EnvVars: []string{"MICRO_DEBUG_TIMELINE_RETENTION"},
+			Value:   DefaultTimelineRetention,
+		},
+	}
+)
+
+// Run the debug service
+func Run(ctx *cli.Context) error {
+	if len(ctx.String("server_name")) > 0 {
+		name = ctx.String("server_name")
+	}
+
+	var srvOpts []service.Option
+	if len(ctx.String("address")) > 0 {
+		srvOpts = append(srvOpts, service.Address(ctx.String("address")))
+	}
+	srvOpts = append(srvOpts, service.Name(name))
+
+	// new service
+	srv := service.New(srvOpts...)
+
+	// collect profiles from the services
+	c := &collector{
+		name:      name,
+		client:    srv.Client(),
+		interval:  ctx.Duration("profile_interval"),
+		retention: ctx.Duration("profile_retention"),
+		duration:  ctx.Duration("profile_duration"),
+		types:     ctx.StringSlice("profile_types"),
+	}
+	exit := make(chan bool)
+	if c.interval > 0 {
+		go c.run(exit)
+	}
+
+	// scrape the stats of the services
+	s := newScraper(name, srv.Client(), ctx.Duration("metrics_interval"), ctx.Duration("metrics_retention"))
+	if ctx.Bool("alerts") {
+		s.detector = newDetector()
+	} else {
+		// the objectives are still tracked, without alerting
+		s.tracker.publish = nil
+	}
+	if s.interval > 0 {
+		go s.run(exit)
+	}
+
+	// run the synthetic checks of the services
+	p := newProber(srv.Client(), ctx.String("probe_location"), ctx.String("probe_gateway"), ctx.Duration("probe_retention"))
+	if !ctx.Bool("alerts") {
+		p.publish = nil
+	}
+	if ctx.Bool("probes") {
+		go p.run(exit)
+	}
+
+	// publish the trace sampling and chaos experiments to the services
+	go runSampling(exit)
+	go runChaos(exit)
+
+	// store the logs shipped by services via the broker
+	logs := newLogs()
+	sub, err := logs.subscribe()
+	if err != nil {
+		log.Errorf("Error subscribing to logs: %v", err)
+	}
+	go pruneLogs(ctx.Duration("logs_retention"), exit)
+
+	// store the spans of captured requests
+	captures := new(Captures)
+	captureSub, err := captures.subscribe()
+	if err != nil {
+		log.Errorf("Error subscribing to captured requests: %v", err)
+	}
+	go pruneCaptures(ctx.Duration("capture_retention"), exit)
+
+	// record the changes to the services on the timeline
+	timeline := new(Timeline)
+	go timeline.subscribe(exit)
+	go pruneTimeline(ctx.Duration("timeline_retention"), exit)
+
+	// serve the metrics to prometheus and the dashboards of the objectives, logs and timeline
+	if addr := ctx.String("metrics_address"); len(addr) > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/federate", s)
+		mux.Handle("/metrics", s)
+		mux.Handle("/slo", s.tracker)
+		mux.HandleFunc("/logs", logsPage)
+		mux.HandleFunc("/timeline", timelinePage)
+		go func() {
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				log.Errorf("Error serving metrics on %v: %v", addr, err)
+			}
+		}()
+	}
+
+	// register the handlers
+	pb.RegisterProfilesHandler(srv.Server(), new(Profiles))
+	pb.RegisterMetricsHandler(srv.Server(), &Metrics{scraper: s})
+	pb.RegisterLogsHandler(srv.Server(), logs)
+	pb.RegisterProbesHandler(srv.Server(), &Probes{prober: p})
+	pb.RegisterTracingHandler(srv.Server(), new(Tracing))
+	pb.RegisterCapturesHandler(srv.Server(), captures)
+	pb.RegisterChaosHandler(srv.Server(), new(Chaos))
+	pb.RegisterTimelineHandler(srv.Server(), timeline)
+
+	// run the service
+	if err := srv.Run(); err != nil {
+		log.Errorf("error running service: %v", err)
+	}
+
+	close(exit)
+	if sub != nil {
+		sub.Unsubscribe()
+	}
+	if captureSub != nil {
+		captureSub.Unsubscribe()
+	}
+	return nil
+}
@@ -0,0 +1,487 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/config"
+	"github.com/micro/micro/v3/service/debug"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// DefaultSLOWindow is the window objectives are measured over if they don't specify one
+	DefaultSLOWindow = time.Hour * 24 * 30
+	// SLOBurnRate is the rate the error budget must burn at over both the last hour and the
+	// last five minutes for an alert to be published. At this rate 2% of the budget of a 30 day
+	// window is used in an hour.
+	SLOBurnRate = 14.4
+)
+
+// sloConfigKey is the config key the objectives are set under, each objective being keyed by its
+// name e.g. micro config set debug.slos.helloworld '{"service": "helloworld", "target": 99.9}'
+const sloConfigKey = "debug.slos"
+
+// sloPrefix is the prefix of the keys the hourly counts of the objectives are stored under, the
+// key of a count is slo/[name]/[hour]
+const sloPrefix = "slo/"
+
+// SLO is the definition of a service level objective
+type SLO struct {
+	// Service the objective applies to
+	Service string `json:"service"`
+	// Endpoint the objective applies to, blank for every endpoint of the service
+	Endpoint string `json:"endpoint"`
+	// Target percentage of requests which should be good e.g. 99.9
+	Target float64 `json:"target"`
+	// Latency requests should be served within to be good e.g. 250ms. If blank the requests
+	// without errors are good. The latency is rounded down to the nearest latency bucket.
+	Latency string `json:"latency"`
+	// Window the objective is measured over e.g. 720h, defaults to 30 days
+	Window string `json:"window"`
+}
+
+// objective is an SLO which has been validated
+type objective struct {
+	*SLO
+	name    string
+	latency time.Duration
+	window  time.Duration
+}
+
+// count of the requests to an objective's service and endpoint
+type count struct {
+	Timestamp int64  `json:"timestamp"`
+	Total     uint64 `json:"total"`
+	Bad       uint64 `json:"bad"`
+}
+
+// nodeStats are the endpoint stats scraped from a node
+type nodeStats struct {
+	service   string
+	version   string
+	node      string
+	endpoints map[string]*pb.EndpointStats
+	buckets   []float64
+	memory    uint64
+	threads   uint64
+}
+
+// tracker tracks the requests which meet the service level objectives set in config, storing
+// hourly counts so the objectives can be measured over long windows. Alerts are published when
+// the error budget of an objective burns fast.
+type tracker struct {
+	sync.RWMutex
+	objectives []*objective
+
+	// prev are the endpoint stats of each node at the last scrape, keyed by service/node, which
+	// the requests since are counted from
+	prev map[string]*nodeStats
+	// recent are the counts of each objective at each scrape in the last hour
+	recent map[string][]*count
+	// hours are the counts of each objective in the current hour
+	hours map[string]*count
+
+	// firing are the objectives alerts have been published for
+	firing map[string]bool
+	// publish the alert, nil if alerts are disabled
+	publish func(*debug.Alert) error
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		prev:    make(map[string]*nodeStats),
+		recent:  make(map[string][]*count),
+		hours:   make(map[string]*count),
+		firing:  make(map[string]bool),
+		publish: publishAlert,
+	}
+}
+
+// load the objectives from config, the objectives loaded previously are kept if there's an error
+func (t *tracker) load() {
+	val, err := config.Get(sloConfigKey)
+	if err != nil {
+		log.Warnf("Error loading service level objectives: %v", err)
+		return
+	}
+
+	slos := make(map[string]*SLO)
+	if val.Exists() {
+		if err := val.Scan(&slos); err != nil {
+			log.Warnf("Error loading service level objectives: %v", err)
+			return
+		}
+	}
+
+	var objectives []*objective
+	for name, slo := range slos {
+		o, err := newObjective(name, slo)
+		if err != nil {
+			log.Warnf("Invalid service level objective %v: %v", name, err)
+			continue
+		}
+		objectives = append(objectives, o)
+	}
+	sort.Slice(objectives, func(i, j int) bool {
+		return objectives[i].name < objectives[j].name
+	})
+
+	t.Lock()
+	t.objectives = objectives
+	t.Unlock()
+}
+
+// newObjective validates the SLO
+func newObjective(name string, slo *SLO) (*objective, error) {
+	if len(slo.Service) == 0 {
+		return nil, fmt.Errorf("missing service")
+	}
+	if slo.Target <= 0 || slo.Target >= 100 {
+		return nil, fmt.Errorf("target must be between 0 and 100")
+	}
+
+	o := &objective{SLO: slo, name: name, window: DefaultSLOWindow}
+	if len(slo.Latency) > 0 {
+		d, err := time.ParseDuration(slo.Latency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid latency: %v", err)
+		}
+		o.latency = d
+	}
+	if len(slo.Window) > 0 {
+		d, err := time.ParseDuration(slo.Window)
+		if err != nil || d < time.Hour {
+			return nil, fmt.Errorf("window must be a duration of at least an hour")
+		}
+		o.window = d
+	}
+	return o, nil
+}
+
+// update counts the requests since the last scrape for each objective, then publishes alerts for
+// the objectives whose error budget is burning fast
+func (t *tracker) update(nodes []*nodeStats, ts int64) {
+	t.Lock()
+	defer t.Unlock()
+
+	// the requests to each endpoint since the last scrape
+	var deltas []*nodeStats
+	prev := make(map[string]*nodeStats, len(nodes))
+	for _, n := range nodes {
+		key := n.service + "/" + n.node
+		prev[key] = n
+		if p, ok := t.prev[key]; ok {
+			deltas = append(deltas, delta(p, n))
+		}
+	}
+	t.prev = prev
+
+	for _, o := range t.objectives {
+		c := &count{Timestamp: ts}
+		for _, d := range deltas {
+			if d.service != o.Service {
+				continue
+			}
+			total, bad := o.count(d)
+			c.Total += total
+			c.Bad += bad
+		}
+
+		// keep the counts of the last hour for the burn rate
+		recent := append(t.recent[o.name], c)
+		for len(recent) > 0 && recent[0].Timestamp <= ts-3600 {
+			recent = recent[1:]
+		}
+		t.recent[o.name] = recent
+
+		if err := t.store(o, c); err != nil {
+			log.Warnf("Error storing the requests to objective %v: %v", o.name, err)
+		}
+		t.alert(o, ts)
+	}
+}
+
+// delta returns the requests to each endpoint between the scrapes. The stats are reset when a
+// node restarts, in which case all the requests since are counted.
+func delta(prev, curr *nodeStats) *nodeStats {
+	sub := func(a, b uint64) uint64 {
+		if a < b {
+			return a
+		}
+		return a - b
+	}
+
+	d := &nodeStats{
+		service:   curr.service,
+		version:   curr.version,
+		node:      curr.node,
+		endpoints: make(map[string]*pb.EndpointStats, len(curr.endpoints)),
+		buckets:   curr.buckets,
+	}
+	for name, e := range curr.endpoints {
+		p, ok := prev.endpoints[name]
+		if !ok || p.Requests > e.Requests || len(p.Latency) != len(e.Latency) {
+			p = &pb.EndpointStats{Latency: make([]uint64, len(e.Latency))}
+		}
+
+		de := &pb.EndpointStats{
+			Requests: sub(e.Requests, p.Requests),
+			Errors:   sub(e.Errors, p.Errors),
+			Latency:  make([]uint64, len(e.Latency)),
+		}
+		for i := range e.Latency {
+			de.Latency[i] = sub(e.Latency[i], p.Latency[i])
+		}
+		d.endpoints[name] = de
+	}
+	return d
+}
+
+// count returns the total and bad requests to the objective's endpoints of the node
+func (o *objective) count(n *nodeStats) (uint64, uint64) {
+	// the latency bucket requests must be within to be good
+	bucket := -1
+	for i, b := range n.buckets {
+		if b <= o.latency.Seconds() {
+			bucket = i
+		}
+	}
+
+	var total, bad uint64
+	for name, e := range n.endpoints {
+		if len(o.Endpoint) > 0 && name != o.Endpoint {
+			continue
+		}
+		// the debug endpoints are called by the debug service rather than users
+		if len(o.Endpoint) == 0 && strings.HasPrefix(name, "Debug.") {
+			continue
+		}
+
+		total += e.Requests
+		switch {
+		case o.latency == 0:
+			bad += e.Errors
+		case bucket < 0 || bucket >= len(e.Latency):
+			bad += e.Requests
+		case e.Latency[bucket] < e.Requests:
+			bad += e.Requests - e.Latency[bucket]
+		}
+	}
+	return total, bad
+}
+
+// store adds the count to that of the current hour, deleting the counts which are no longer in
+// the window when the hour changes
+func (t *tracker) store(o *objective, c *count) error {
+	hour := c.Timestamp - c.Timestamp%3600
+	curr, ok := t.hours[o.name]
+
+	// load the count of the hour if it was stored before a restart
+	if !ok || curr.Timestamp != hour {
+		curr = &count{Timestamp: hour}
+		recs, err := store.Read(hourKey(o.name, hour))
+		if err == nil && len(recs) > 0 {
+			json.Unmarshal(recs[0].Value, curr)
+		} else if err != nil && err != store.ErrNotFound {
+			return err
+		}
+		t.hours[o.name] = curr
+
+		if ok {
+			t.prune(o, hour)
+		}
+	}
+
+	curr.Total += c.Total
+	curr.Bad += c.Bad
+
+	b, err := json.Marshal(curr)
+	if err != nil {
+		return err
+	}
+	return store.Write(&store.Record{Key: hourKey(o.name, hour), Value: b})
+}
+
+// prune deletes the counts of the objective which are no longer in its window
+func (t *tracker) prune(o *objective, hour int64) {
+	keys, err := store.List(store.Prefix(sloPrefix + o.name + "/"))
+	if err != nil {
+		log.Warnf("Error listing the requests to objective %v: %v", o.name, err)
+		return
+	}
+
+	cutoff := hour - int64(o.window.Seconds())
+	for _, key := range keys {
+		if ts, ok := hourTimestamp(key); ok && ts < cutoff {
+			store.Delete(key)
+		}
+	}
+}
+
+// hourKey returns the key the count of the objective in the hour is stored under, the hour is
+// padded so the keys sort in order
+func hourKey(name string, hour int64) string {
+	return fmt.Sprintf("%v%v/%020d", sloPrefix, name, hour)
+}
+
+// hourTimestamp returns the hour of a count from its key
+func hourTimestamp(key string) (int64, bool) {
+	idx := strings.LastIndex(key, "/")
+	if idx < 0 {
+		return 0, false
+	}
+	var ts int64
+	_, err := fmt.Sscanf(key[idx+1:], "%d", &ts)
+	return ts, err == nil
+}
+
+// burnRate returns the rate the error budget of the objective burned at since the time
+func (t *tracker) burnRate(o *objective, since int64) float64 {
+	var total, bad uint64
+	for _, c := range t.recent[o.name] {
+		if c.Timestamp > since {
+			total += c.Total
+			bad += c.Bad
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(bad) / float64(total) / (1 - o.Target/100)
+}
+
+// alert publishes an alert if the error budget of the objective is burning fast over both the
+// last hour and the last five minutes, so the alert clears soon after the burn stops
+func (t *tracker) alert(o *objective, ts int64) {
+	if t.publish == nil {
+		return
+	}
+
+	long, short := t.burnRate(o, ts-3600), t.burnRate(o, ts-300)
+	if long < SLOBurnRate || short < SLOBurnRate {
+		delete(t.firing, o.name)
+		return
+	}
+	if t.firing[o.name] {
+		return
+	}
+
+	alert := &debug.Alert{
+		Type:      debug.AlertSLOBurn,
+		Service:   o.Service,
+		Reason:    fmt.Sprintf("Error budget of objective %v is burning at %.1fx over the last hour", o.name, long),
+		Timestamp: ts,
+	}
+	if err := t.publish(alert); err != nil {
+		log.Warnf("Error publishing alert for objective %v: %v", o.name, err)
+		return
+	}
+	t.firing[o.name] = true
+}
+
+// status returns the objectives and their status in the window, optionally of a service
+func (t *tracker) status(service string) ([]*pb.Objective, error) {
+	t.RLock()
+	defer t.RUnlock()
+
+	now := time.Now().Unix()
+	var result []*pb.Objective
+	for _, o := range t.objectives {
+		if len(service) > 0 && o.Service != service {
+			continue
+		}
+
+		keys, err := store.Read("", store.Prefix(sloPrefix+o.name+"/"))
+		if err != nil && err != store.ErrNotFound {
+			return nil, err
+		}
+
+		obj := &pb.Objective{
+			Name:            o.name,
+			Service:         o.Service,
+			Endpoint:        o.Endpoint,
+			Target:          o.Target,
+			Latency:         o.Latency,
+			Window:          o.window.String(),
+			Compliance:      100,
+			BudgetRemaining: 100,
+			BurnRate:        t.burnRate(o, now-3600),
+		}
+		cutoff := now - int64(o.window.Seconds())
+		for _, rec := range keys {
+			var c count
+			if err := json.Unmarshal(rec.Value, &c); err != nil || c.Timestamp+3600 <= cutoff {
+				continue
+			}
+			obj.Total += c.Total
+			obj.Bad += c.Bad
+		}
+		if obj.Total > 0 {
+			errRate := float64(obj.Bad) / float64(obj.Total)
+			obj.Compliance = 100 * (1 - errRate)
+			obj.BudgetRemaining = 100 * (1 - errRate/(1-o.Target/100))
+		}
+		result = append(result, obj)
+	}
+	return result, nil
+}
+
+var dashboard = template.Must(template.New("slo").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Service Level Objectives</title>
+<meta http-equiv="refresh" content="30">
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.5em; border-bottom: 1px solid #ddd; }
+.breached { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Service Level Objectives</h1>
+{{if .}}
+<table>
+<tr><th>Name</th><th>Service</th><th>Endpoint</th><th>Objective</th><th>Window</th><th>Requests</th><th>Compliance</th><th>Error Budget</th><th>Burn Rate</th></tr>
+{{range .}}
+<tr{{if lt .BudgetRemaining 0.0}} class="breached"{{end}}>
+<td>{{.Name}}</td>
+<td>{{.Service}}</td>
+<td>{{if .Endpoint}}{{.Endpoint}}{{else}}*{{end}}</td>
+<td>{{.Target}}% {{if .Latency}}within {{.Latency}}{{else}}available{{end}}</td>
+<td>{{.Window}}</td>
+<td>{{.Total}}</td>
+<td>{{printf "%.3f" .Compliance}}%</td>
+<td>{{printf "%.1f" .BudgetRemaining}}%</td>
+<td>{{printf "%.1f" .BurnRate}}x</td>
+</tr>
+{{end}}
+</table>
+{{else}}
+<p>No objectives are set, set them in config e.g. micro config set debug.slos.helloworld '{"service": "helloworld", "target": 99.9}'</p>
+{{end}}
+</body>
+</html>
+`))
+
+// ServeHTTP serves the dashboard of the objectives and their status
+func (t *tracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	objectives, err := t.status(r.URL.Query().Get("service"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := dashboard.Execute(w, objectives); err != nil {
+		log.Warnf("Error rendering the objectives dashboard: %v", err)
+	}
+}
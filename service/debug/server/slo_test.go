@@ -0,0 +1,82 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectives(t *testing.T) {
+	defaultStore := store.DefaultStore
+	defer func() { store.DefaultStore = defaultStore }()
+	store.DefaultStore = memory.NewStore()
+
+	var alerts []*debug.Alert
+	tr := newTracker()
+	tr.publish = func(a *debug.Alert) error {
+		alerts = append(alerts, a)
+		return nil
+	}
+
+	availability, err := newObjective("foo", &SLO{Service: "foo", Target: 99})
+	assert.NoError(t, err)
+	latency, err := newObjective("foo-latency", &SLO{Service: "foo", Endpoint: "Foo.Bar", Target: 90, Latency: "100ms"})
+	assert.NoError(t, err)
+	tr.objectives = []*objective{availability, latency}
+
+	_, err = newObjective("invalid", &SLO{Service: "foo", Target: 100})
+	assert.Error(t, err, "Expected an error for a target of 100%")
+
+	// the latency buckets are 10ms, 100ms and 1s
+	buckets := []float64{0.01, 0.1, 1}
+	stats := func(requests, errors uint64, latency ...uint64) *nodeStats {
+		return &nodeStats{
+			service: "foo",
+			node:    "foo-1",
+			buckets: buckets,
+			endpoints: map[string]*pb.EndpointStats{
+				"Foo.Bar":     {Requests: requests, Errors: errors, Latency: latency},
+				"Debug.Stats": {Requests: requests, Errors: requests, Latency: latency},
+			},
+		}
+	}
+
+	now := time.Now().Unix()
+	tr.update([]*nodeStats{stats(100, 0, 50, 100, 100)}, now-120)
+	tr.update([]*nodeStats{stats(1100, 5, 800, 1050, 1100)}, now-60)
+
+	objectives, err := tr.status("foo")
+	assert.NoError(t, err)
+	if assert.Len(t, objectives, 2) {
+		// 5 of the 1000 requests since the first scrape errored, using half the error budget
+		assert.Equal(t, uint64(1000), objectives[0].Total)
+		assert.Equal(t, uint64(5), objectives[0].Bad)
+		assert.InDelta(t, 99.5, objectives[0].Compliance, 0.001)
+		assert.InDelta(t, 50, objectives[0].BudgetRemaining, 0.001)
+
+		// 50 of the requests took longer than 100ms
+		assert.Equal(t, uint64(50), objectives[1].Bad)
+		assert.InDelta(t, 50, objectives[1].BudgetRemaining, 0.001)
+	}
+	assert.Len(t, alerts, 0)
+
+	// a fast burn of the error budget publishes an alert, once
+	tr.update([]*nodeStats{stats(2100, 305, 1800, 2050, 2100)}, now)
+	if assert.Len(t, alerts, 1) {
+		assert.Equal(t, debug.AlertSLOBurn, alerts[0].Type)
+		assert.Equal(t, "foo", alerts[0].Service)
+	}
+	tr.update([]*nodeStats{stats(2200, 355, 1900, 2150, 2200)}, now+30)
+	assert.Len(t, alerts, 1)
+
+	// the stats are reset when the node restarts
+	tr.update([]*nodeStats{stats(10, 0, 10, 10, 10)}, now+60)
+	objectives, err = tr.status("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2110), objectives[0].Total)
+}
@@ -0,0 +1,477 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/config"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/events"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/router"
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// DefaultTimelineRetention is how long the events on the timeline are kept
+	DefaultTimelineRetention = time.Hour * 24 * 7
+	// DefaultTimelineLimit is the number of events read if no limit is requested
+	DefaultTimelineLimit = 500
+)
+
+// timelinePrefix is the prefix of the keys the events on the timeline are stored under, the key of
+// an event is timeline/[timestamp]/[source]/[id]
+const timelinePrefix = "timeline/"
+
+// the sources of the events on the timeline
+const (
+	sourceRuntime = "runtime"
+	sourceRouter  = "router"
+	sourceConfig  = "config"
+	sourceAlert   = "alert"
+)
+
+// Timeline implements the handler for reading the changes recorded on the timeline
+type Timeline struct{}
+
+// subscribe to the deploys, route changes, config changes and alerts, recording them on the
+// timeline until exit is closed. The sources which can't be subscribed to are logged and skipped.
+func (t *Timeline) subscribe(exit chan bool) {
+	// the events are consumed as a group so they're recorded once by the debug services
+	group := events.WithGroup(name + ".timeline")
+
+	if ch, err := events.Consume(runtime.EventTopic, group); err != nil {
+		log.Errorf("Error consuming runtime events for the timeline: %v", err)
+	} else {
+		go consumeTimeline(ch, runtimeEvent)
+	}
+
+	if ch, err := events.Consume(config.EventTopic, group); err != nil {
+		log.Errorf("Error consuming config events for the timeline: %v", err)
+	} else {
+		go consumeTimeline(ch, configEvent)
+	}
+
+	sub, err := broker.Subscribe(debug.AlertTopic, func(msg *broker.Message) error {
+		var alert debug.Alert
+		if err := json.Unmarshal(msg.Body, &alert); err != nil {
+			log.Warnf("Error decoding alert for the timeline: %v", err)
+			return nil
+		}
+		writeTimelineEvent(alertEvent(&alert))
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Error subscribing to alerts for the timeline: %v", err)
+	}
+
+	w, err := router.DefaultRouter.Watch()
+	if err != nil {
+		log.Errorf("Error watching routes for the timeline: %v", err)
+	} else {
+		go func() {
+			for {
+				ev, err := w.Next()
+				if err == router.ErrWatcherStopped {
+					return
+				} else if err != nil {
+					log.Warnf("Error watching routes for the timeline: %v", err)
+					time.Sleep(time.Second)
+					continue
+				}
+				writeTimelineEvent(routeEvent(ev))
+			}
+		}()
+	}
+
+	<-exit
+	if sub != nil {
+		sub.Unsubscribe()
+	}
+	if w != nil {
+		w.Stop()
+	}
+}
+
+// consumeTimeline records the events consumed on the timeline, converting them with the func
+func consumeTimeline(ch <-chan events.Event, convert func(*events.Event) (*pb.TimelineEvent, error)) {
+	for ev := range ch {
+		tev, err := convert(&ev)
+		if err != nil {
+			log.Warnf("Error decoding %v event for the timeline: %v", ev.Topic, err)
+			continue
+		}
+		writeTimelineEvent(tev)
+	}
+}
+
+// eventTime returns the time of the event in nanoseconds, or the current time if it isn't set
+func eventTime(t time.Time) int64 {
+	if t.IsZero() {
+		return time.Now().UnixNano()
+	}
+	return t.UnixNano()
+}
+
+// runtimeEvent converts a runtime event, e.g. a service being updated, to an event on the timeline
+func runtimeEvent(ev *events.Event) (*pb.TimelineEvent, error) {
+	var payload runtime.EventPayload
+	if err := ev.Unmarshal(&payload); err != nil {
+		return nil, err
+	}
+
+	tev := &pb.TimelineEvent{
+		Timestamp: eventTime(ev.Timestamp),
+		Source:    sourceRuntime,
+		Type:      payload.Type,
+		Namespace: payload.Namespace,
+		Metadata:  make(map[string]string),
+	}
+
+	// the events of resources other than services, e.g. namespaces, have a name instead
+	if payload.Service == nil {
+		var resource runtime.EventResourcePayload
+		if err := ev.Unmarshal(&resource); err != nil {
+			return nil, err
+		}
+		tev.Description = fmt.Sprintf("%v %v", resource.Type, resource.Name)
+		return tev, nil
+	}
+
+	srv := payload.Service
+	tev.Service = srv.Name
+	tev.Metadata["version"] = srv.Version
+	if len(srv.Source) > 0 {
+		tev.Metadata["source"] = srv.Source
+	}
+	tev.Description = fmt.Sprintf("%v:%v %v", srv.Name, srv.Version, strings.TrimPrefix(payload.Type, "service."))
+	if len(payload.Reason) > 0 {
+		tev.Description += ": " + payload.Reason
+		tev.Metadata["reason"] = payload.Reason
+	}
+	return tev, nil
+}
+
+// configEvent converts a config event, e.g. a value being set, to an event on the timeline. Config
+// is usually keyed by service so the service is the first part of the path.
+func configEvent(ev *events.Event) (*pb.TimelineEvent, error) {
+	var payload config.EventPayload
+	if err := ev.Unmarshal(&payload); err != nil {
+		return nil, err
+	}
+
+	tev := &pb.TimelineEvent{
+		Timestamp:   eventTime(ev.Timestamp),
+		Source:      sourceConfig,
+		Type:        payload.Type,
+		Service:     strings.Split(payload.Path, ".")[0],
+		Namespace:   payload.Namespace,
+		Description: fmt.Sprintf("%v %v", payload.Path, strings.TrimPrefix(payload.Type, "value.")),
+		Metadata:    map[string]string{"path": payload.Path},
+	}
+	if payload.Secret {
+		tev.Description += " (secret)"
+	}
+	return tev, nil
+}
+
+// routeEvent converts a change to the routing table to an event on the timeline
+func routeEvent(ev *router.Event) *pb.TimelineEvent {
+	r := ev.Route
+	return &pb.TimelineEvent{
+		Timestamp:   eventTime(ev.Timestamp),
+		Source:      sourceRouter,
+		Type:        "route." + ev.Type.String(),
+		Service:     r.Service,
+		Namespace:   r.Network,
+		Description: fmt.Sprintf("route to %v at %v %vd", r.Service, r.Address, ev.Type),
+		Metadata: map[string]string{
+			"address": r.Address,
+			"gateway": r.Gateway,
+			"router":  r.Router,
+			"link":    r.Link,
+		},
+	}
+}
+
+// alertEvent converts an alert published by the debug service to an event on the timeline
+func alertEvent(a *debug.Alert) *pb.TimelineEvent {
+	ts := time.Now().UnixNano()
+	if a.Timestamp > 0 {
+		ts = time.Unix(a.Timestamp, 0).UnixNano()
+	}
+	return &pb.TimelineEvent{
+		Timestamp:   ts,
+		Source:      sourceAlert,
+		Type:        a.Type,
+		Service:     a.Service,
+		Description: a.Reason,
+		Metadata: map[string]string{
+			"version": a.Version,
+			"node":    a.Node,
+		},
+	}
+}
+
+// writeTimelineEvent stores the event on the timeline
+func writeTimelineEvent(ev *pb.TimelineEvent) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	key := fmt.Sprintf("%v%020d/%v/%v", timelinePrefix, ev.Timestamp, ev.Source, uuid.New().String())
+	if err := store.Write(&store.Record{Key: key, Value: b}); err != nil {
+		log.Warnf("Error writing %v event to the timeline: %v", ev.Source, err)
+	}
+}
+
+// timelineKey returns the timestamp and source of the event stored under the key
+func timelineKey(key string) (int64, string, bool) {
+	parts := strings.Split(strings.TrimPrefix(key, timelinePrefix), "/")
+	if len(parts) != 3 {
+		return 0, "", false
+	}
+	ts, err := strconv.ParseInt(parts[0], 10, 64)
+	return ts, parts[1], err == nil
+}
+
+// readTimeline reads the latest events in the range from the sources, or every source if none are
+// given, returning them oldest first
+func readTimeline(start, end int64, sources []string, service string, limit int) ([]*pb.TimelineEvent, error) {
+	keys, err := store.List(store.Prefix(timelinePrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	include := make(map[string]bool, len(sources))
+	for _, s := range sources {
+		include[s] = true
+	}
+	startNano := time.Unix(start, 0).UnixNano()
+	endNano := int64(0)
+	if end > 0 {
+		endNano = time.Unix(end, 0).UnixNano()
+	}
+
+	var inRange []string
+	for _, key := range keys {
+		ts, source, ok := timelineKey(key)
+		if !ok || ts < startNano || (endNano > 0 && ts > endNano) {
+			continue
+		}
+		if len(include) > 0 && !include[source] {
+			continue
+		}
+		inRange = append(inRange, key)
+	}
+	// the timestamps are padded so the keys sort in order, latest first
+	sort.Sort(sort.Reverse(sort.StringSlice(inRange)))
+
+	var result []*pb.TimelineEvent
+	for _, key := range inRange {
+		if len(result) >= limit {
+			break
+		}
+		recs, err := store.Read(key)
+		if err != nil || len(recs) == 0 {
+			continue
+		}
+		ev := new(pb.TimelineEvent)
+		if err := json.Unmarshal(recs[0].Value, ev); err != nil {
+			continue
+		}
+		if len(service) > 0 && ev.Service != service {
+			continue
+		}
+		result = append(result, ev)
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+// Read the events on the timeline, by default those of the last hour
+func (t *Timeline) Read(ctx context.Context, req *pb.ReadTimelineRequest, rsp *pb.ReadTimelineResponse) error {
+	if err := authorize(ctx, "debug.Timeline.Read"); err != nil {
+		return err
+	}
+	if req.Start == 0 {
+		req.Start = time.Now().Add(-time.Hour).Unix()
+	}
+	if req.Limit <= 0 {
+		req.Limit = int64(DefaultTimelineLimit)
+	}
+
+	evs, err := readTimeline(req.Start, req.End, req.Sources, req.Service, int(req.Limit))
+	if err != nil {
+		return errors.InternalServerError("debug.Timeline.Read", "Error reading timeline: %v", err)
+	}
+	rsp.Events = evs
+	return nil
+}
+
+// pruneTimeline deletes the events before the retention period every hour until exit is closed
+func pruneTimeline(retention time.Duration, exit chan bool) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+		}
+
+		keys, err := store.List(store.Prefix(timelinePrefix))
+		if err != nil {
+			log.Warnf("Error listing timeline: %v", err)
+			continue
+		}
+		cutoff := time.Now().Add(-retention).UnixNano()
+		for _, key := range keys {
+			if ts, _, ok := timelineKey(key); ok && ts < cutoff {
+				store.Delete(key)
+			}
+		}
+	}
+}
+
+var timelineDashboard = template.Must(template.New("timeline").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Timeline</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9em; }
+td { padding: 0.25em 0.5em; border-bottom: 1px solid #eee; vertical-align: top; white-space: nowrap; }
+td.description { white-space: normal; }
+.runtime { color: #06c; }
+.router { color: #666; }
+.config { color: #960; }
+.alert { color: #c00; }
+.error { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Timeline</h1>
+<form>
+<input type="text" name="at" value="{{.At}}" placeholder="14:32 or 2020-11-01T14:32:00Z">
+<select name="window">
+{{range .Windows}}<option value="{{.}}"{{if eq . $.Window}} selected{{end}}>&plusmn;{{.}}</option>{{end}}
+</select>
+<select name="source">
+<option value="">all sources</option>
+{{range .Sources}}<option value="{{.}}"{{if eq . $.Source}} selected{{end}}>{{.}}</option>{{end}}
+</select>
+<input type="text" name="service" value="{{.Service}}" placeholder="service">
+<input type="submit" value="Show">
+</form>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<table>
+{{range .Events}}
+<tr class="{{.Source}}">
+<td>{{.Time}}</td>
+<td>{{.Source}}</td>
+<td>{{.Type}}</td>
+<td>{{.Service}}</td>
+<td class="description">{{.Description}}</td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+
+// parseClock parses a time, either as RFC3339 or a time of day today e.g. 14:32
+func parseClock(v string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.ParseInLocation(layout, v, now.Location()); err == nil {
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid time %v, expected e.g. 14:32 or 2020-11-01T14:32:00Z", v)
+}
+
+// timelinePage serves the dashboard of the timeline, showing the events around a time or those of
+// the last hour
+func timelinePage(w http.ResponseWriter, r *http.Request) {
+	type event struct {
+		*pb.TimelineEvent
+		Time string
+	}
+	data := struct {
+		At      string
+		Window  string
+		Windows []string
+		Source  string
+		Sources []string
+		Service string
+		Error   string
+		Events  []event
+	}{
+		At:      r.URL.Query().Get("at"),
+		Window:  r.URL.Query().Get("window"),
+		Windows: []string{"5m", "15m", "1h", "6h"},
+		Source:  r.URL.Query().Get("source"),
+		Sources: []string{sourceRuntime, sourceRouter, sourceConfig, sourceAlert},
+		Service: r.URL.Query().Get("service"),
+	}
+	if len(data.Window) == 0 {
+		data.Window = "15m"
+	}
+
+	now := time.Now()
+	start, end := now.Add(-time.Hour), time.Time{}
+	if len(data.At) > 0 {
+		window, err := time.ParseDuration(data.Window)
+		if err != nil {
+			data.Error = "Invalid window " + data.Window
+		}
+		at, err := parseClock(data.At, now)
+		if err != nil {
+			data.Error = err.Error()
+		}
+		start, end = at.Add(-window), at.Add(window)
+	}
+
+	if len(data.Error) == 0 {
+		var sources []string
+		if len(data.Source) > 0 {
+			sources = []string{data.Source}
+		}
+		var endUnix int64
+		if !end.IsZero() {
+			endUnix = end.Unix()
+		}
+		evs, err := readTimeline(start.Unix(), endUnix, sources, data.Service, DefaultTimelineLimit)
+		if err != nil {
+			data.Error = err.Error()
+		}
+		for _, ev := range evs {
+			t := time.Unix(0, ev.Timestamp).Format("2006-01-02 15:04:05")
+			data.Events = append(data.Events, event{TimelineEvent: ev, Time: t})
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := timelineDashboard.Execute(w, data); err != nil {
+		log.Warnf("Error rendering the timeline dashboard: %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/config"
+	"github.com/micro/micro/v3/service/debug"
+	"github.com/micro/micro/v3/service/events"
+	"github.com/micro/micro/v3/service/router"
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeline(t *testing.T) {
+	defaultStore := store.DefaultStore
+	defer func() { store.DefaultStore = defaultStore }()
+	store.DefaultStore = memory.NewStore()
+
+	now := time.Now()
+	payload := func(v interface{}) []byte {
+		b, _ := json.Marshal(v)
+		return b
+	}
+
+	deploy, err := runtimeEvent(&events.Event{
+		Timestamp: now.Add(-time.Minute * 3),
+		Payload: payload(&runtime.EventPayload{
+			Type:    runtime.EventServiceUpdated,
+			Service: &runtime.Service{Name: "foo", Version: "latest"},
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo:latest updated", deploy.Description)
+
+	change, err := configEvent(&events.Event{
+		Timestamp: now.Add(-time.Minute * 2),
+		Payload: payload(&config.EventPayload{
+			Type:   config.EventValueSet,
+			Path:   "foo.db.password",
+			Secret: true,
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", change.Service)
+	assert.Equal(t, "foo.db.password set (secret)", change.Description)
+
+	route := routeEvent(&router.Event{
+		Type:      router.Create,
+		Timestamp: now.Add(-time.Minute),
+		Route:     router.Route{Service: "bar", Address: "10.0.0.1:8080"},
+	})
+	assert.Equal(t, "route.create", route.Type)
+	assert.Equal(t, "route to bar at 10.0.0.1:8080 created", route.Description)
+
+	alert := alertEvent(&debug.Alert{Type: debug.AlertGoroutines, Service: "foo", Reason: "goroutines doubled", Timestamp: now.Unix()})
+	old := alertEvent(&debug.Alert{Type: debug.AlertGoroutines, Service: "foo", Timestamp: now.Add(-time.Hour * 2).Unix()})
+
+	for _, ev := range []*pb.TimelineEvent{alert, route, old, change, deploy} {
+		writeTimelineEvent(ev)
+	}
+
+	h := new(Timeline)
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "micro"})
+
+	// the events of the last hour are read by default, oldest first
+	rsp := &pb.ReadTimelineResponse{}
+	assert.NoError(t, h.Read(ctx, &pb.ReadTimelineRequest{}, rsp))
+	if assert.Len(t, rsp.Events, 4) {
+		assert.Equal(t, []string{"runtime", "config", "router", "alert"},
+			[]string{rsp.Events[0].Source, rsp.Events[1].Source, rsp.Events[2].Source, rsp.Events[3].Source})
+	}
+
+	// the events can be filtered by source and service, the latest are kept when limited
+	rsp = &pb.ReadTimelineResponse{}
+	assert.NoError(t, h.Read(ctx, &pb.ReadTimelineRequest{
+		Sources: []string{"runtime", "config", "alert"},
+		Service: "foo",
+		Limit:   2,
+	}, rsp))
+	if assert.Len(t, rsp.Events, 2) {
+		assert.Equal(t, "config", rsp.Events[0].Source)
+		assert.Equal(t, "alert", rsp.Events[1].Source)
+	}
+
+	err = h.Read(auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "foo"}), &pb.ReadTimelineRequest{}, rsp)
+	assert.Error(t, err, "Expected an error reading the timeline of another issuer")
+
+	// the dashboard shows the events around a time
+	w := httptest.NewRecorder()
+	at := now.Add(-time.Minute * 2).Format(time.RFC3339)
+	timelinePage(w, httptest.NewRequest("GET", "/timeline?window=5m&at="+at, nil))
+	body := w.Body.String()
+	assert.Contains(t, body, "foo.db.password set (secret)")
+	assert.Contains(t, body, "goroutines doubled")
+	assert.NotContains(t, body, "Invalid")
+}
+
+func TestParseClock(t *testing.T) {
+	now := time.Date(2020, 11, 1, 18, 0, 0, 0, time.UTC)
+	at, err := parseClock("14:32", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 11, 1, 14, 32, 0, 0, time.UTC), at)
+
+	at, err = parseClock("2020-10-31T09:00:00Z", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 10, 31, 9, 0, 0, 0, time.UTC), at)
+
+	_, err = parseClock("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+)
+
+// top are the stats of each service at the last scrape, summed across their nodes
+type top struct {
+	timestamp int64
+	services  []*pb.ServiceStats
+	// prev are the endpoint stats of each node at the last scrape, keyed by service/node, which
+	// the rates are calculated from
+	prev map[string]*nodeStats
+}
+
+// summarize the stats scraped from the nodes into the stats of each service. The rates are those
+// of the requests since the previous scrape, so they're zero after the first.
+func (s *scraper) summarize(nodes []*nodeStats, ts int64) {
+	s.Lock()
+	defer s.Unlock()
+
+	elapsed := ts - s.top.timestamp
+	services := make(map[string]*pb.ServiceStats)
+	// the requests, errors and requests within each latency bucket of each service
+	type counts struct {
+		requests, errors uint64
+		buckets          []float64
+		latency          []uint64
+	}
+	totals := make(map[string]*counts)
+
+	prev := make(map[string]*nodeStats, len(nodes))
+	for _, n := range nodes {
+		svc, ok := services[n.service]
+		if !ok {
+			svc = &pb.ServiceStats{Service: n.service}
+			services[n.service] = svc
+			totals[n.service] = &counts{buckets: n.buckets, latency: make([]uint64, len(n.buckets))}
+		}
+		svc.Nodes++
+		svc.Memory += n.memory
+		svc.Threads += n.threads
+
+		key := n.service + "/" + n.node
+		prev[key] = n
+		p, ok := s.top.prev[key]
+		if !ok || elapsed <= 0 {
+			continue
+		}
+
+		c := totals[n.service]
+		for name, e := range delta(p, n).endpoints {
+			// the debug endpoints are called by the debug service rather than users
+			if strings.HasPrefix(name, "Debug.") {
+				continue
+			}
+			c.requests += e.Requests
+			c.errors += e.Errors
+			if len(e.Latency) != len(c.latency) {
+				continue
+			}
+			for i, l := range e.Latency {
+				c.latency[i] += l
+			}
+		}
+	}
+
+	result := make([]*pb.ServiceStats, 0, len(services))
+	for name, svc := range services {
+		c := totals[name]
+		if c.requests > 0 {
+			svc.Requests = float64(c.requests) / float64(elapsed)
+			svc.ErrorRate = float64(c.errors) / float64(c.requests) * 100
+			svc.P99 = percentile(c.buckets, c.latency, c.requests, 0.99)
+		}
+		result = append(result, svc)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Service < result[j].Service
+	})
+
+	s.top = top{timestamp: ts, services: result, prev: prev}
+}
+
+// percentile returns the upper bound of the latency bucket the fraction of requests were served
+// within, or -1 if they took longer than the largest bucket
+func percentile(buckets []float64, latency []uint64, requests uint64, fraction float64) float64 {
+	for i, l := range latency {
+		if float64(l) >= fraction*float64(requests) {
+			return buckets[i]
+		}
+	}
+	return -1
+}
+
+// Top returns the stats of the services at the last scrape
+func (m *Metrics) Top(ctx context.Context, req *pb.TopRequest, rsp *pb.TopResponse) error {
+	if err := authorize(ctx, "debug.Metrics.Top"); err != nil {
+		return err
+	}
+
+	m.scraper.RLock()
+	defer m.scraper.RUnlock()
+
+	rsp.Timestamp = m.scraper.top.timestamp
+	for _, svc := range m.scraper.top.services {
+		if len(req.Service) > 0 && svc.Service != req.Service {
+			continue
+		}
+		rsp.Services = append(rsp.Services, svc)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTop(t *testing.T) {
+	s := newScraper("debug", nil, time.Second, time.Hour)
+	buckets := []float64{0.1, 1}
+	node := func(id string, requests, errors uint64, latency []uint64, memory uint64) *nodeStats {
+		return &nodeStats{
+			service: "foo",
+			node:    id,
+			buckets: buckets,
+			memory:  memory,
+			threads: 10,
+			endpoints: map[string]*pb.EndpointStats{
+				"Foo.Bar":      {Requests: requests, Errors: errors, Latency: latency},
+				"Debug.Health": {Requests: 1000, Latency: []uint64{1000, 1000}},
+			},
+		}
+	}
+
+	// the rates aren't known until the second scrape
+	s.summarize([]*nodeStats{
+		node("foo-1", 100, 0, []uint64{100, 100}, 1024),
+		node("foo-2", 100, 0, []uint64{100, 100}, 1024),
+	}, 1000)
+	if assert.Len(t, s.top.services, 1) {
+		assert.Equal(t, &pb.ServiceStats{Service: "foo", Nodes: 2, Memory: 2048, Threads: 20}, s.top.services[0])
+	}
+
+	// 200 requests over 10s, 2 of which errored and 3 of which were slower than the first bucket
+	s.summarize([]*nodeStats{
+		node("foo-1", 200, 2, []uint64{198, 200}, 1024),
+		node("foo-2", 200, 0, []uint64{199, 200}, 2048),
+	}, 1010)
+
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{Issuer: "micro"})
+	h := &Metrics{scraper: s}
+	var rsp pb.TopResponse
+	assert.NoError(t, h.Top(ctx, &pb.TopRequest{Service: "foo"}, &rsp))
+	assert.Equal(t, int64(1010), rsp.Timestamp)
+	if assert.Len(t, rsp.Services, 1) {
+		svc := rsp.Services[0]
+		assert.Equal(t, int64(2), svc.Nodes)
+		assert.Equal(t, 20.0, svc.Requests)
+		assert.Equal(t, 1.0, svc.ErrorRate)
+		assert.Equal(t, 1.0, svc.P99)
+		assert.Equal(t, uint64(3072), svc.Memory)
+	}
+
+	rsp = pb.TopResponse{}
+	assert.NoError(t, h.Top(ctx, &pb.TopRequest{Service: "bar"}, &rsp))
+	assert.Len(t, rsp.Services, 0)
+
+	assert.Equal(t, 0.1, percentile(buckets, []uint64{99, 100}, 100, 0.99))
+	assert.Equal(t, -1.0, percentile(buckets, []uint64{90, 95}, 100, 0.99))
+}
@@ -0,0 +1,42 @@
+// Package client generates the ids using the id service
+package client
+
+import (
+	pb "github.com/micro/micro/v3/proto/id"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/id"
+)
+
+// NewGenerator returns a generator which calls the id service
+func NewGenerator() id.Generator {
+	return new(srv)
+}
+
+type srv struct {
+	Client pb.IdService
+}
+
+func (s *srv) Generate() (id.ID, error) {
+	rsp, err := s.client().Generate(context.DefaultContext, &pb.GenerateRequest{Count: 1}, client.WithAuthToken())
+	if err != nil {
+		return 0, err
+	}
+	if len(rsp.Ids) == 0 {
+		return 0, errors.InternalServerError("id.Id.Generate", "No id returned")
+	}
+	return id.ID(rsp.Ids[0]), nil
+}
+
+func (s *srv) String() string {
+	return "service"
+}
+
+// this is a tmp solution since the client isn't initialized when NewGenerator is called
+func (s *srv) client() pb.IdService {
+	if s.Client == nil {
+		s.Client = pb.NewIdService("id", client.DefaultClient)
+	}
+	return s.Client
+}
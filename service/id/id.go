@@ -0,0 +1,68 @@
+// Package id is for generating ids which are unique across the cluster. The ids are 64 bit
+// snowflakes, made of the milliseconds since the Epoch, the node which generated them and a
+// sequence, so they're ordered by the time they were generated.
+package id
+
+import (
+	"strconv"
+	"time"
+)
+
+var (
+	// DefaultGenerator is the default id generator implementation
+	DefaultGenerator Generator
+
+	// Epoch the time of the ids is relative to
+	Epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+const (
+	// NodeBits is the number of bits of the node which generated the id
+	NodeBits = 10
+	// SequenceBits is the number of bits of the sequence of the ids generated in a millisecond
+	SequenceBits = 12
+
+	// MaxNode is the highest node number
+	MaxNode = 1<<NodeBits - 1
+	// MaxSequence is the highest sequence number
+	MaxSequence = 1<<SequenceBits - 1
+)
+
+// Generator generates unique ids
+type Generator interface {
+	Generate() (ID, error)
+	String() string
+}
+
+// ID is a unique id
+type ID int64
+
+// New returns the id generated by a node at a time
+func New(t time.Time, node, seq int64) ID {
+	ms := t.Sub(Epoch).Milliseconds()
+	return ID(ms<<(NodeBits+SequenceBits) | (node&MaxNode)<<SequenceBits | seq&MaxSequence)
+}
+
+// Time the id was generated, to the millisecond
+func (i ID) Time() time.Time {
+	return Epoch.Add(time.Duration(int64(i)>>(NodeBits+SequenceBits)) * time.Millisecond)
+}
+
+// Node which generated the id
+func (i ID) Node() int64 {
+	return int64(i) >> SequenceBits & MaxNode
+}
+
+// Sequence of the id among the ids generated by the node in the same millisecond
+func (i ID) Sequence() int64 {
+	return int64(i) & MaxSequence
+}
+
+func (i ID) String() string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// Generate an id using the default generator
+func Generate() (ID, error) {
+	return DefaultGenerator.Generate()
+}
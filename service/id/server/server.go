@@ -0,0 +1,155 @@
+// Package server is the id service which generates the ids unique across the cluster
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	pb "github.com/micro/micro/v3/proto/id"
+	"github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/id"
+	"github.com/micro/micro/v3/service/id/snowflake"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	name = "id"
+	// nodeKey is the metadata of the registered nodes holding their node number
+	nodeKey = "node_id"
+)
+
+var (
+	// MaxCount is the max number of ids generated per request
+	MaxCount int32 = 1000
+
+	// Flags specific to the id service
+	Flags = []cli.Flag{
+		&cli.Int64Flag{
+			Name:    "node_id",
+			Usage:   "Set the node number of the ids generated by the service, unique across the cluster. Defaults to the lowest number not used by the other nodes in the registry",
+			EnvVars: []string{"MICRO_ID_NODE_ID"},
+		},
+	}
+)
+
+// Id generates the ids of the node
+type Id struct {
+	Generator id.Generator
+}
+
+// Generate returns ids unique across the cluster and ordered by the time they were generated
+func (i *Id) Generate(ctx context.Context, req *pb.GenerateRequest, rsp *pb.GenerateResponse) error {
+	count := req.Count
+	if count <= 0 {
+		count = 1
+	}
+	if count > MaxCount {
+		return errors.BadRequest("id.Id.Generate", "Count must be %d or less", MaxCount)
+	}
+
+	rsp.Ids = make([]int64, 0, count)
+	for n := int32(0); n < count; n++ {
+		v, err := i.Generator.Generate()
+		if err != nil {
+			return errors.InternalServerError("id.Id.Generate", "Error generating id: %v", err)
+		}
+		rsp.Ids = append(rsp.Ids, int64(v))
+	}
+	return nil
+}
+
+// registeredNodes returns the node numbers of the registered nodes of the service, by node id
+func registeredNodes() (map[string]int64, error) {
+	srvs, err := registry.GetService(name)
+	if err == registry.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]int64)
+	for _, s := range srvs {
+		for _, n := range s.Nodes {
+			if v, err := strconv.ParseInt(n.Metadata[nodeKey], 10, 64); err == nil {
+				nodes[n.Id] = v
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// claimNode returns the lowest node number not used by the registered nodes
+func claimNode() (int64, error) {
+	nodes, err := registeredNodes()
+	if err != nil {
+		return 0, err
+	}
+
+	used := make(map[int64]bool, len(nodes))
+	for _, v := range nodes {
+		used[v] = true
+	}
+	for v := int64(0); v <= id.MaxNode; v++ {
+		if !used[v] {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("all the %d node numbers are used", id.MaxNode+1)
+}
+
+// checkNode ensures no other node claimed the same node number while the node started, of the
+// nodes which claimed it the one with the lowest id keeps it
+func checkNode(self string, node int64) error {
+	nodes, err := registeredNodes()
+	if err != nil {
+		return err
+	}
+	for other, v := range nodes {
+		if v == node && other < self {
+			return fmt.Errorf("node number %d was claimed by %s", node, other)
+		}
+	}
+	return nil
+}
+
+// Run the micro id service
+func Run(ctx *cli.Context) error {
+	node := ctx.Int64("node_id")
+	if !ctx.IsSet("node_id") {
+		var err error
+		if node, err = claimNode(); err != nil {
+			logger.Fatalf("Error claiming a node number: %v", err)
+		}
+	}
+
+	gen, err := snowflake.NewGenerator(node)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	var srv *service.Service
+	srv = service.New(
+		service.Name(name),
+		service.Metadata(map[string]string{nodeKey: strconv.FormatInt(node, 10)}),
+		service.AfterStart(func() error {
+			// the node numbers set explicitly are trusted to be unique
+			if ctx.IsSet("node_id") {
+				return nil
+			}
+			return checkNode(name+"-"+srv.Server().Options().Id, node)
+		}),
+	)
+
+	logger.Infof("Generating the ids of node %d", node)
+
+	pb.RegisterIdHandler(srv.Server(), &Id{Generator: gen})
+
+	if err := srv.Run(); err != nil {
+		logger.Fatal(err)
+	}
+	return nil
+}
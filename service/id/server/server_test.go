@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/micro/micro/v3/proto/id"
+	"github.com/micro/micro/v3/service/id/snowflake"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/micro/micro/v3/service/registry/memory"
+)
+
+func TestGenerate(t *testing.T) {
+	gen, _ := snowflake.NewGenerator(3)
+	h := &Id{Generator: gen}
+
+	rsp := new(pb.GenerateResponse)
+	if err := h.Generate(context.TODO(), &pb.GenerateRequest{Count: 5}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.Ids) != 5 {
+		t.Fatalf("Expected 5 ids, got %v", rsp.Ids)
+	}
+	for i := 1; i < len(rsp.Ids); i++ {
+		if rsp.Ids[i] <= rsp.Ids[i-1] {
+			t.Fatalf("Expected the ids to be ordered, got %v", rsp.Ids)
+		}
+	}
+
+	if err := h.Generate(context.TODO(), &pb.GenerateRequest{Count: MaxCount + 1}, new(pb.GenerateResponse)); err == nil {
+		t.Fatal("Expected a count above the max to be rejected")
+	}
+}
+
+func TestClaimNode(t *testing.T) {
+	defer func(r registry.Registry) { registry.DefaultRegistry = r }(registry.DefaultRegistry)
+	registry.DefaultRegistry = memory.NewRegistry()
+
+	if node, err := claimNode(); err != nil || node != 0 {
+		t.Fatalf("Expected the first node to claim 0, got %v %v", node, err)
+	}
+
+	register := func(nodeID, node string) {
+		err := registry.DefaultRegistry.Register(&registry.Service{
+			Name:  name,
+			Nodes: []*registry.Node{{Id: nodeID, Address: nodeID + ":8080", Metadata: map[string]string{nodeKey: node}}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	register("id-a", "0")
+	register("id-b", "2")
+
+	if node, err := claimNode(); err != nil || node != 1 {
+		t.Fatalf("Expected the lowest unused node 1 to be claimed, got %v %v", node, err)
+	}
+
+	// of two nodes which claimed the same number the one with the lowest id keeps it
+	register("id-c", "2")
+	if err := checkNode("id-b", 2); err != nil {
+		t.Fatalf("Expected id-b to keep node 2, got %v", err)
+	}
+	if err := checkNode("id-c", 2); err == nil {
+		t.Fatal("Expected id-c to lose node 2")
+	}
+}
@@ -0,0 +1,59 @@
+// Package snowflake generates the ids of a node from its clock
+package snowflake
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/service/id"
+)
+
+// NewGenerator returns a generator of the ids of a node, the node must be unique across the
+// cluster for the ids to be
+func NewGenerator(node int64) (id.Generator, error) {
+	if node < 0 || node > id.MaxNode {
+		return nil, fmt.Errorf("node %d out of range, expected 0 to %d", node, id.MaxNode)
+	}
+	return &snowflake{node: node}, nil
+}
+
+type snowflake struct {
+	node int64
+
+	sync.Mutex
+	// last is the millisecond the last id was generated in
+	last time.Time
+	seq  int64
+}
+
+func (s *snowflake) Generate() (id.ID, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now().Truncate(time.Millisecond)
+
+	// carry on from the last id if the clock went backwards, so the ids stay ordered and unique
+	if now.Before(s.last) {
+		now = s.last
+	}
+
+	if now.Equal(s.last) {
+		s.seq++
+		// the sequence ran out so wait for the next millisecond
+		if s.seq > id.MaxSequence {
+			now = s.last.Add(time.Millisecond)
+			time.Sleep(time.Until(now))
+			s.seq = 0
+		}
+	} else {
+		s.seq = 0
+	}
+	s.last = now
+
+	return id.New(now, s.node, s.seq), nil
+}
+
+func (s *snowflake) String() string {
+	return "snowflake"
+}
@@ -0,0 +1,58 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/id"
+)
+
+func TestGenerate(t *testing.T) {
+	if _, err := NewGenerator(id.MaxNode + 1); err == nil {
+		t.Fatal("Expected a node out of range to be rejected")
+	}
+
+	g, err := NewGenerator(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// enough ids to run out of the sequence of a millisecond
+	var last id.ID
+	seen := make(map[id.ID]bool)
+	for i := 0; i < id.MaxSequence*3; i++ {
+		v, err := g.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v <= last || seen[v] {
+			t.Fatalf("Expected the ids to be unique and ordered, got %v after %v", v, last)
+		}
+		seen[v] = true
+		last = v
+	}
+
+	if last.Node() != 7 {
+		t.Fatalf("Expected the id to be generated by node 7, got %v", last.Node())
+	}
+	if d := time.Since(last.Time()); d < 0 || d > time.Second {
+		t.Fatalf("Expected the id to be generated just now, got %v", last.Time())
+	}
+}
+
+func TestClockBackwards(t *testing.T) {
+	g, _ := NewGenerator(1)
+	s := g.(*snowflake)
+
+	// the last id was generated a second in the future
+	s.last = time.Now().Truncate(time.Millisecond).Add(time.Second)
+	s.seq = 5
+
+	v, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Time().Equal(s.last) || v.Sequence() != 6 {
+		t.Fatalf("Expected the id to carry on from the last one, got %v %v", v.Time(), v.Sequence())
+	}
+}
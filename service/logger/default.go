@@ -16,6 +16,7 @@ package logger
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"runtime"
@@ -32,8 +33,12 @@ func init() {
 	if err != nil {
 		lvl = InfoLevel
 	}
+	format, err := GetFormat(os.Getenv("MICRO_LOG_FORMAT"))
+	if err != nil {
+		format = TextFormat
+	}
 
-	DefaultLogger = NewHelper(NewLogger(WithLevel(lvl)))
+	DefaultLogger = NewHelper(NewLogger(WithLevel(lvl), WithFormat(format)))
 }
 
 type defaultLogger struct {
@@ -53,11 +58,17 @@ func (l *defaultLogger) String() string {
 	return "default"
 }
 
+// Fields returns a logger which logs the fields with the fields of the logger
 func (l *defaultLogger) Fields(fields map[string]interface{}) Logger {
-	l.Lock()
-	l.opts.Fields = copyFields(fields)
-	l.Unlock()
-	return l
+	l.RLock()
+	opts := l.opts
+	opts.Fields = copyFields(l.opts.Fields)
+	l.RUnlock()
+
+	for k, v := range fields {
+		opts.Fields[k] = v
+	}
+	return &defaultLogger{opts: opts}
 }
 
 func copyFields(src map[string]interface{}) map[string]interface{} {
@@ -97,59 +108,35 @@ func (l *defaultLogger) Log(level Level, v ...interface{}) {
 	if !l.opts.Level.Enabled(level) {
 		return
 	}
-
-	l.RLock()
-	fields := copyFields(l.opts.Fields)
-	l.RUnlock()
-
-	fields["level"] = level.String()
-
-	if _, file, line, ok := runtime.Caller(l.opts.CallerSkipCount); ok {
-		fields["file"] = fmt.Sprintf("%s:%d", logCallerfilePath(file), line)
-	}
-
-	rec := dlog.Record{
-		Timestamp: time.Now(),
-		Message:   fmt.Sprint(v...),
-		Metadata:  make(map[string]string, len(fields)),
-	}
-
-	keys := make([]string, 0, len(fields))
-	for k, v := range fields {
-		keys = append(keys, k)
-		rec.Metadata[k] = fmt.Sprintf("%v", v)
-	}
-
-	sort.Strings(keys)
-	metadata := ""
-
-	for _, k := range keys {
-		metadata += fmt.Sprintf(" %s=%v", k, fields[k])
-	}
-
-	t := rec.Timestamp.Format("2006-01-02 15:04:05")
-	fmt.Printf("%s %s %v\n", t, metadata, rec.Message)
+	l.write(level, fmt.Sprint(v...))
 }
 
 func (l *defaultLogger) Logf(level Level, format string, v ...interface{}) {
 	//	 TODO decide does we need to write message if log level not used?
-	if level < l.opts.Level {
+	if !l.opts.Level.Enabled(level) {
 		return
 	}
+	l.write(level, fmt.Sprintf(format, v...))
+}
 
+// write writes the record of the message in the format of the logger
+func (l *defaultLogger) write(level Level, msg string) {
 	l.RLock()
 	fields := copyFields(l.opts.Fields)
+	format := l.opts.Format
+	out := l.opts.Out
 	l.RUnlock()
 
 	fields["level"] = level.String()
 
-	if _, file, line, ok := runtime.Caller(l.opts.CallerSkipCount); ok {
+	// write is a frame below Log and Logf so one more frame is skipped
+	if _, file, line, ok := runtime.Caller(l.opts.CallerSkipCount + 1); ok {
 		fields["file"] = fmt.Sprintf("%s:%d", logCallerfilePath(file), line)
 	}
 
 	rec := dlog.Record{
 		Timestamp: time.Now(),
-		Message:   fmt.Sprintf(format, v...),
+		Message:   msg,
 		Metadata:  make(map[string]string, len(fields)),
 	}
 
@@ -159,15 +146,42 @@ func (l *defaultLogger) Logf(level Level, format string, v ...interface{}) {
 		rec.Metadata[k] = fmt.Sprintf("%v", v)
 	}
 
-	sort.Strings(keys)
-	metadata := ""
-
-	for _, k := range keys {
-		metadata += fmt.Sprintf(" %s=%v", k, fields[k])
+	if format == JSONFormat {
+		entry := make(map[string]interface{}, len(fields)+2)
+		for k, v := range fields {
+			// the errors don't marshal to json
+			if err, ok := v.(error); ok {
+				v = err.Error()
+			}
+			entry[k] = v
+		}
+		entry["time"] = rec.Timestamp.Format(time.RFC3339Nano)
+		entry["msg"] = rec.Message
+		b, err := json.Marshal(entry)
+		if err != nil {
+			b, _ = json.Marshal(map[string]interface{}{
+				"time":  entry["time"],
+				"level": entry["level"],
+				"msg":   rec.Message,
+				"error": fmt.Sprintf("error marshaling the fields: %v", err),
+			})
+		}
+		fmt.Fprintf(out, "%s\n", b)
+	} else {
+		sort.Strings(keys)
+		metadata := ""
+
+		for _, k := range keys {
+			metadata += fmt.Sprintf(" %s=%v", k, fields[k])
+		}
+
+		t := rec.Timestamp.Format("2006-01-02 15:04:05")
+		fmt.Fprintf(out, "%s %s %v\n", t, metadata, rec.Message)
+	}
+
+	if l.opts.Log != nil {
+		l.opts.Log.Write(rec)
 	}
-
-	t := rec.Timestamp.Format("2006-01-02 15:04:05")
-	fmt.Printf("%s %s %v\n", t, metadata, rec.Message)
 }
 
 func (l *defaultLogger) Options() Options {
@@ -185,7 +199,7 @@ func NewLogger(opts ...Option) Logger {
 	options := Options{
 		Level:           InfoLevel,
 		Fields:          make(map[string]interface{}),
-		Out:             os.Stderr,
+		Out:             os.Stdout,
 		CallerSkipCount: 2,
 		Context:         context.Background(),
 	}
@@ -15,6 +15,10 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -30,3 +34,39 @@ func TestLogger(t *testing.T) {
 
 	l.Fields(map[string]interface{}{"key3": "val4"}).Log(InfoLevel, "test_msg")
 }
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithFormat(JSONFormat), WithFields(map[string]interface{}{"service": "network"}))
+	NewHelper(l).WithFields(map[string]interface{}{"peer": "node-1"}).WithError(errors.New("gone")).Info("peer lost")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("Expected a line of json, got %q: %v", buf.String(), err)
+	}
+	for k, v := range map[string]interface{}{
+		"service": "network",
+		"peer":    "node-1",
+		"error":   "gone",
+		"level":   "info",
+		"msg":     "peer lost",
+	} {
+		if rec[k] != v {
+			t.Fatalf("Expected %v to be %v, got %v", k, v, rec[k])
+		}
+	}
+	if f, _ := rec["file"].(string); !strings.HasPrefix(f, "logger/logger_test.go:") {
+		t.Fatalf("Expected the file of the caller, got %v", rec["file"])
+	}
+
+	// the fields of a helper don't stick to the logger
+	buf.Reset()
+	l.Log(InfoLevel, "next")
+	if strings.Contains(buf.String(), "node-1") {
+		t.Fatalf("Expected the fields of the helper not to be logged, got %s", buf.String())
+	}
+
+	if _, err := GetFormat("xml"); err == nil {
+		t.Fatal("Expected an unknown format to be rejected")
+	}
+}
@@ -16,20 +16,48 @@ package logger
 
 import (
 	"context"
+	"fmt"
 	"io"
+
+	dlog "github.com/micro/micro/v3/internal/debug/log"
 )
 
 type Option func(*Options)
 
+// Format of the log records
+type Format string
+
+const (
+	// TextFormat writes the records as a line of text with the fields as key=value
+	TextFormat Format = "text"
+	// JSONFormat writes the records as a line of json, for log aggregators to parse
+	JSONFormat Format = "json"
+)
+
+// GetFormat returns the format of the name, text if blank
+func GetFormat(name string) (Format, error) {
+	switch Format(name) {
+	case "", TextFormat:
+		return TextFormat, nil
+	case JSONFormat:
+		return JSONFormat, nil
+	}
+	return TextFormat, fmt.Errorf("Unknown log format %q, expected text or json", name)
+}
+
 type Options struct {
 	// The logging level the logger should log at. default is `InfoLevel`
 	Level Level
 	// fields to always be logged
 	Fields map[string]interface{}
-	// It's common to set this to a file, or leave it default which is `os.Stderr`
+	// It's common to set this to a file, or leave it default which is `os.Stdout`
 	Out io.Writer
+	// Format the records are written in, text by default
+	Format Format
 	// Caller skip frame count for file:line info
 	CallerSkipCount int
+	// Log the records are also written to, e.g. to ship them to the debug service
+	Log dlog.Log
 	// Alternative options
 	Context context.Context
 }
@@ -55,6 +83,13 @@ func WithOutput(out io.Writer) Option {
 	}
 }
 
+// WithFormat sets the format the records are written in
+func WithFormat(f Format) Option {
+	return func(args *Options) {
+		args.Format = f
+	}
+}
+
 // WithCallerSkipCount set frame count to skip
 func WithCallerSkipCount(c int) Option {
 	return func(args *Options) {
@@ -62,6 +97,13 @@ func WithCallerSkipCount(c int) Option {
 	}
 }
 
+// WithLog sets a log the records are also written to
+func WithLog(l dlog.Log) Option {
+	return func(args *Options) {
+		args.Log = l
+	}
+}
+
 func SetOption(k, v interface{}) Option {
 	return func(o *Options) {
 		if o.Context == nil {
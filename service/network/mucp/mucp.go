@@ -21,7 +21,10 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -34,6 +37,7 @@ import (
 	"github.com/micro/micro/v3/service/client"
 	cmucp "github.com/micro/micro/v3/service/client/mucp"
 	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/metrics"
 	"github.com/micro/micro/v3/service/network"
 	pb "github.com/micro/micro/v3/service/network/mucp/proto"
 	"github.com/micro/micro/v3/service/proxy"
@@ -57,6 +61,11 @@ var (
 	// PruneTime defines time interval to periodically check nodes that need to be pruned
 	// due to their not announcing their presence within this time interval
 	PruneTime = 90 * time.Second
+	// ProbeTime defines time interval to periodically measure the round trip time to the peers
+	ProbeTime = 30 * time.Second
+	// MaxClockSkew is the offset of the clock of a peer above which a warning is logged, since the
+	// leases and ttls of the nodes expire early or late with skewed clocks
+	MaxClockSkew = 5 * time.Second
 	// MaxDepth defines max depth of peer topology
 	MaxDepth uint = 3
 	// NetworkChannel is the name of the tunnel channel for passing network messages
@@ -65,6 +74,9 @@ var (
 	ControlChannel = "control"
 	// DefaultLink is default network link
 	DefaultLink = "network"
+	// BridgeLink is the prefix of the links of the routes bridged from another network, followed
+	// by the name of the network
+	BridgeLink = "bridge:"
 	// MaxConnections is the max number of network client connections
 	MaxConnections = 3
 	// MaxPeerErrors is the max number of peer errors before we remove it from network graph
@@ -79,10 +91,21 @@ var (
 	ErrPeerLinkNotFound = errors.New("peer link not found")
 	// ErrPeerMaxExceeded is returned when peer has reached its max error count limit
 	ErrPeerMaxExceeded = errors.New("peer max errors exceeded")
+
+	// create a logger
+	log = logger.NewHelper(logger.DefaultLogger).WithFields(map[string]interface{}{"package": "network"})
 )
 
 // network implements Network interface
 type mucpNetwork struct {
+	// the adverts sent to and received from the peers, accessed atomically so they're first to
+	// be 64 bit aligned
+	advertsSent     uint64
+	advertsReceived uint64
+	// unix nanoseconds of the last advert sent and received, accessed atomically
+	lastAdvertSent     int64
+	lastAdvertReceived int64
+
 	// node is network node
 	*node
 	// options configure the network
@@ -110,6 +133,27 @@ type mucpNetwork struct {
 	closed chan bool
 	// whether we've discovered by the network
 	discovered chan bool
+	// learned is when the routes learned from the peers were last advertised, by their hash,
+	// tracked to expire them when the advertise ttl is set
+	learned map[uint64]*learnedRoute
+}
+
+// learnedRoute is a route learned from a peer
+type learnedRoute struct {
+	route    router.Route
+	lastSeen time.Time
+}
+
+// Stats are the counters of the messages of the network since it was created
+type Stats struct {
+	// AdvertsSent is the number of route adverts sent to the peers
+	AdvertsSent uint64
+	// AdvertsReceived is the number of route adverts received from the peers
+	AdvertsReceived uint64
+	// LastAdvertSent and LastAdvertReceived are when the last adverts were exchanged, zero if none
+	// have been yet
+	LastAdvertSent     time.Time
+	LastAdvertReceived time.Time
 }
 
 // message is network message
@@ -202,6 +246,7 @@ func NewNetwork(opts ...network.Option) network.Network {
 		tunClient:  make(map[string]tunnel.Session),
 		peerLinks:  make(map[string]tunnel.Link),
 		discovered: make(chan bool, 1),
+		learned:    make(map[uint64]*learnedRoute),
 	}
 
 	network.node.network = network
@@ -249,7 +294,7 @@ func (n *mucpNetwork) acceptNetConn(l tunnel.Listener, recv chan *message) {
 		conn, err := l.Accept()
 		if err != nil {
 			sleep := backoff.Do(i)
-			logger.Debugf("Network tunnel [%s] accept error: %v, backing off for %v", ControlChannel, err, sleep)
+			log.Debugf("Network tunnel [%s] accept error: %v, backing off for %v", ControlChannel, err, sleep)
 			time.Sleep(sleep)
 			i++
 			continue
@@ -258,7 +303,7 @@ func (n *mucpNetwork) acceptNetConn(l tunnel.Listener, recv chan *message) {
 		select {
 		case <-n.closed:
 			if err := conn.Close(); err != nil {
-				logger.Debugf("Network tunnel [%s] failed to close connection: %v", NetworkChannel, err)
+				log.Debugf("Network tunnel [%s] failed to close connection: %v", NetworkChannel, err)
 			}
 			return
 		default:
@@ -276,8 +321,8 @@ func (n *mucpNetwork) acceptCtrlConn(l tunnel.Listener, recv chan *message) {
 		conn, err := l.Accept()
 		if err != nil {
 			sleep := backoff.Do(i)
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Network tunnel [%s] accept error: %v, backing off for %v", ControlChannel, err, sleep)
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network tunnel [%s] accept error: %v, backing off for %v", ControlChannel, err, sleep)
 			}
 			time.Sleep(sleep)
 			i++
@@ -287,8 +332,8 @@ func (n *mucpNetwork) acceptCtrlConn(l tunnel.Listener, recv chan *message) {
 		select {
 		case <-n.closed:
 			if err := conn.Close(); err != nil {
-				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-					logger.Debugf("Network tunnel [%s] failed to close connection: %v", ControlChannel, err)
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Network tunnel [%s] failed to close connection: %v", ControlChannel, err)
 				}
 			}
 			return
@@ -317,8 +362,12 @@ func (n *mucpNetwork) maskRoute(r *pb.Route) {
 		address = fmt.Sprintf("%d", hasher.Sum64())
 	}
 
-	// calculate route metric to advertise
-	metric := n.getRouteMetric(r.Router, r.Gateway, r.Link)
+	// calculate route metric to advertise, the routes bridged from another network keep the
+	// metric they have there
+	metric := r.Metric
+	if !strings.HasPrefix(r.Link, BridgeLink) {
+		metric = n.getRouteMetric(r.Router, r.Gateway, r.Link)
+	}
 
 	// NOTE: we override Gateway, Link and Address here
 	r.Address = address
@@ -331,67 +380,174 @@ func (n *mucpNetwork) maskRoute(r *pb.Route) {
 func (n *mucpNetwork) advertise(eventChan <-chan *router.Event) {
 	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
 
+	// the changes are flushed every interval if set, only the last event of each route is sent
+	var flush <-chan time.Time
+	if d := n.router.Options().AdvertiseInterval; d > 0 {
+		t := time.NewTicker(d)
+		defer t.Stop()
+		flush = t.C
+	}
+	pending := make(map[uint64]*router.Event)
+
 	for {
 		select {
 		// process local events and randomly fire them at other nodes
 		case event := <-eventChan:
-			// create a proto advert
-			var pbEvents []*pb.Event
-
-			// make a copy of the route
-			route := &pb.Route{
-				Service: event.Route.Service,
-				Address: event.Route.Address,
-				Gateway: event.Route.Gateway,
-				Network: event.Route.Network,
-				Router:  event.Route.Router,
-				Link:    event.Route.Link,
-				Metric:  event.Route.Metric,
+			if !n.isAdvertised(event) {
+				continue
 			}
+			if flush != nil {
+				pending[event.Route.Hash()] = event
+				continue
+			}
+			n.sendAdvert(rnd, []*router.Event{event})
+		case <-flush:
+			if len(pending) == 0 {
+				continue
+			}
+			events := make([]*router.Event, 0, len(pending))
+			for _, event := range pending {
+				events = append(events, event)
+			}
+			sort.Slice(events, func(i, j int) bool {
+				return events[i].Timestamp.Before(events[j].Timestamp)
+			})
+			pending = make(map[uint64]*router.Event)
+			n.sendAdvert(rnd, events)
+		case <-n.closed:
+			return
+		}
+	}
+}
 
-			// override the various values
-			n.maskRoute(route)
-
-			e := &pb.Event{
-				Type:      pb.EventType(event.Type),
-				Timestamp: event.Timestamp.UnixNano(),
-				Route:     route,
+// isAdvertised returns true if the route of the event is advertised under the advertise strategy
+// and filter
+func (n *mucpNetwork) isAdvertised(event *router.Event) bool {
+	if !n.router.Options().AdvertiseFilter.Match(event.Route.Service) {
+		return false
+	}
+
+	switch n.router.Options().Advertise {
+	case router.AdvertiseNone:
+		return false
+	case router.AdvertiseLocal:
+		return event.Route.Router == n.Id()
+	case router.AdvertiseBest:
+		// the peers need to drop the routes which are gone whether they were the best or not
+		if event.Type == router.Delete {
+			return true
+		}
+		routes, err := n.router.Table().Read(router.ReadService(event.Route.Service))
+		if err != nil {
+			return true
+		}
+		for _, r := range routes {
+			if r.Network == event.Route.Network && r.Metric < event.Route.Metric {
+				return false
 			}
+		}
+	}
+	return true
+}
 
-			pbEvents = append(pbEvents, e)
+// advertisedRoutes returns the routes of the table which are advertised under the advertise
+// strategy and filter
+func (n *mucpNetwork) advertisedRoutes(routes []router.Route) []router.Route {
+	if f := n.router.Options().AdvertiseFilter; f != nil {
+		var filtered []router.Route
+		for _, r := range routes {
+			if f.Match(r.Service) {
+				filtered = append(filtered, r)
+			}
+		}
+		routes = filtered
+	}
 
-			msg := &pb.Advert{
-				Id:        n.Id(),
-				Type:      pb.AdvertType(event.Type),
-				Timestamp: event.Timestamp.UnixNano(),
-				Events:    pbEvents,
+	switch n.router.Options().Advertise {
+	case router.AdvertiseNone:
+		return nil
+	case router.AdvertiseLocal:
+		var local []router.Route
+		for _, r := range routes {
+			if r.Router == n.Id() {
+				local = append(local, r)
 			}
+		}
+		return local
+	case router.AdvertiseBest:
+		best := make(map[string]router.Route)
+		for _, r := range routes {
+			key := r.Service + "@" + r.Network
+			if b, ok := best[key]; !ok || r.Metric < b.Metric {
+				best[key] = r
+			}
+		}
+		routes = make([]router.Route, 0, len(best))
+		for _, r := range best {
+			routes = append(routes, r)
+		}
+		return routes
+	}
+	return routes
+}
 
-			// get a list of node peers
-			peers := n.Peers()
+// sendAdvert sends the events to max 3 random peers
+func (n *mucpNetwork) sendAdvert(rnd *rand.Rand, events []*router.Event) {
+	// create a proto advert
+	pbEvents := make([]*pb.Event, 0, len(events))
+
+	for _, event := range events {
+		// make a copy of the route
+		route := &pb.Route{
+			Service: event.Route.Service,
+			Address: event.Route.Address,
+			Gateway: event.Route.Gateway,
+			Network: event.Route.Network,
+			Router:  event.Route.Router,
+			Link:    event.Route.Link,
+			Metric:  event.Route.Metric,
+		}
 
-			// continue if there is no one to send to
-			if len(peers) == 0 {
-				continue
-			}
+		// override the various values
+		n.maskRoute(route)
 
-			// advertise to max 3 peers
-			max := len(peers)
-			if max > 3 {
-				max = 3
-			}
+		pbEvents = append(pbEvents, &pb.Event{
+			Type:      pb.EventType(event.Type),
+			Timestamp: event.Timestamp.UnixNano(),
+			Route:     route,
+		})
+	}
 
-			for i := 0; i < max; i++ {
-				if peer := n.node.GetPeerNode(peers[rnd.Intn(len(peers))].Id()); peer != nil {
-					if err := n.sendTo("advert", ControlChannel, peer, msg); err != nil {
-						if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-							logger.Debugf("Network failed to advertise routes to %s: %v", peer.Id(), err)
-						}
-					}
+	msg := &pb.Advert{
+		Id:        n.Id(),
+		Type:      pb.AdvertType(events[0].Type),
+		Timestamp: events[len(events)-1].Timestamp.UnixNano(),
+		Events:    pbEvents,
+	}
+
+	// get a list of node peers
+	peers := n.Peers()
+
+	// continue if there is no one to send to
+	if len(peers) == 0 {
+		return
+	}
+
+	// advertise to max 3 peers
+	max := len(peers)
+	if max > 3 {
+		max = 3
+	}
+
+	for i := 0; i < max; i++ {
+		if peer := n.node.GetPeerNode(peers[rnd.Intn(len(peers))].Id()); peer != nil {
+			if err := n.sendTo("advert", ControlChannel, peer, msg); err != nil {
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Network failed to advertise routes to %s: %v", peer.Id(), err)
 				}
+			} else {
+				n.sentAdvert()
 			}
-		case <-n.closed:
-			return
 		}
 	}
 }
@@ -402,8 +558,8 @@ func (n *mucpNetwork) initNodes(startup bool) {
 	// NOTE: this condition never fires
 	// as resolveNodes() never returns error
 	if err != nil && !startup {
-		if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-			logger.Debugf("Network failed to init nodes: %v", err)
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Network failed to init nodes: %v", err)
 		}
 		return
 	}
@@ -423,9 +579,9 @@ func (n *mucpNetwork) initNodes(startup bool) {
 		init = append(init, node)
 	}
 
-	if logger.V(logger.TraceLevel, logger.DefaultLogger) {
+	if logger.V(logger.TraceLevel, log) {
 		// initialize the tunnel
-		logger.Tracef("Network initialising nodes %+v\n", init)
+		log.Tracef("Network initialising nodes %+v\n", init)
 	}
 
 	n.tunnel.Init(
@@ -449,8 +605,8 @@ func (n *mucpNetwork) resolveNodes() ([]string, error) {
 		// resolve anything that looks like a host name
 		records, err := dns.Resolve(node)
 		if err != nil {
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Failed to resolve %v %v", node, err)
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Failed to resolve %v %v", node, err)
 			}
 			continue
 		}
@@ -472,8 +628,8 @@ func (n *mucpNetwork) handleNetConn(s tunnel.Session, msg chan *message) {
 	for {
 		m := new(transport.Message)
 		if err := s.Recv(m); err != nil {
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Network tunnel [%s] receive error: %v", NetworkChannel, err)
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network tunnel [%s] receive error: %v", NetworkChannel, err)
 			}
 			switch err {
 			case io.EOF, tunnel.ErrReadTimeout:
@@ -507,8 +663,8 @@ func (n *mucpNetwork) handleCtrlConn(s tunnel.Session, msg chan *message) {
 	for {
 		m := new(transport.Message)
 		if err := s.Recv(m); err != nil {
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Network tunnel [%s] receive error: %v", ControlChannel, err)
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network tunnel [%s] receive error: %v", ControlChannel, err)
 			}
 			switch err {
 			case io.EOF, tunnel.ErrReadTimeout:
@@ -587,14 +743,14 @@ func (n *mucpNetwork) getRouteMetric(router string, gateway string, link string)
 		return 2
 	}
 
-	if logger.V(logger.TraceLevel, logger.DefaultLogger) {
-		logger.Tracef("Network looking up %s link to gateway: %s", link, gateway)
+	if logger.V(logger.TraceLevel, log) {
+		log.Tracef("Network looking up %s link to gateway: %s", link, gateway)
 	}
 	// attempt to find link based on gateway address
 	lnk, ok := n.peerLinks[gateway]
 	if !ok {
-		if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-			logger.Debugf("Network failed to find a link to gateway: %s", gateway)
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Network failed to find a link to gateway: %s", gateway)
 		}
 		// no link found so infinite metric returned
 		return math.MaxInt64
@@ -613,14 +769,14 @@ func (n *mucpNetwork) getRouteMetric(router string, gateway string, link string)
 
 	// make sure length is non-zero
 	if length == 0 {
-		if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-			logger.Debugf("Link length is 0 %v %v", link, lnk.Length())
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Link length is 0 %v %v", link, lnk.Length())
 		}
 		length = 10e9
 	}
 
-	if logger.V(logger.TraceLevel, logger.DefaultLogger) {
-		logger.Tracef("Network calculated metric %v delay %v length %v distance %v", (delay*length*int64(hops))/10e6, delay, length, hops)
+	if logger.V(logger.TraceLevel, log) {
+		log.Tracef("Network calculated metric %v delay %v length %v distance %v", (delay*length*int64(hops))/10e6, delay, length, hops)
 	}
 
 	return (delay * length * int64(hops)) / 10e6
@@ -645,8 +801,8 @@ func (n *mucpNetwork) processCtrlChan(listener tunnel.Listener) {
 				pbAdvert := &pb.Advert{}
 
 				if err := proto.Unmarshal(m.msg.Body, pbAdvert); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network fail to unmarshal advert message: %v", err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network fail to unmarshal advert message: %v", err)
 					}
 					continue
 				}
@@ -656,16 +812,18 @@ func (n *mucpNetwork) processCtrlChan(listener tunnel.Listener) {
 					continue
 				}
 
-				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-					logger.Debugf("Network received advert message from: %s", pbAdvert.Id)
+				atomic.AddUint64(&n.advertsReceived, 1)
+				atomic.StoreInt64(&n.lastAdvertReceived, time.Now().UnixNano())
+				if logger.V(logger.DebugLevel, log) {
+					log.WithFields(map[string]interface{}{"peer": pbAdvert.Id}).Debugf("Network received advert message from: %s", pbAdvert.Id)
 				}
 
 				// lookup advertising node in our peer topology
 				advertNode := n.node.GetPeerNode(pbAdvert.Id)
 				if advertNode == nil {
 					// if we can't find the node in our topology (MaxDepth) we skipp prcessing adverts
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network skipping advert message from unknown peer: %s", pbAdvert.Id)
+					if logger.V(logger.DebugLevel, log) {
+						log.WithFields(map[string]interface{}{"peer": pbAdvert.Id}).Debugf("Network skipping advert message from unknown peer: %s", pbAdvert.Id)
 					}
 					continue
 				}
@@ -676,13 +834,21 @@ func (n *mucpNetwork) processCtrlChan(listener tunnel.Listener) {
 						continue
 					}
 
+					// skip the routes of the services we don't accept from the network
+					if !n.router.Options().AcceptFilter.Match(event.Route.Service) {
+						if logger.V(logger.TraceLevel, log) {
+							log.WithFields(map[string]interface{}{"peer": pbAdvert.Id, "route": event.Route.Service}).Tracef("Network skipping filtered route of %s from: %s", event.Route.Service, pbAdvert.Id)
+						}
+						continue
+					}
+
 					// we know the advertising node is not the origin of the route
 					if pbAdvert.Id != event.Route.Router {
 						// if the origin router is not the advertising node peer
 						// we can't rule out potential routing loops so we bail here
 						if peer := advertNode.GetPeerNode(event.Route.Router); peer == nil {
-							if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-								logger.Debugf("Network skipping advert message from peer: %s", pbAdvert.Id)
+							if logger.V(logger.DebugLevel, log) {
+								log.WithFields(map[string]interface{}{"peer": pbAdvert.Id, "route": event.Route.Service}).Debugf("Network skipping advert message from peer: %s", pbAdvert.Id)
 							}
 							continue
 						}
@@ -701,8 +867,8 @@ func (n *mucpNetwork) processCtrlChan(listener tunnel.Listener) {
 					// calculate route metric and add to the advertised metric
 					// we need to make sure we do not overflow math.MaxInt64
 					metric := n.getRouteMetric(event.Route.Router, event.Route.Gateway, event.Route.Link)
-					if logger.V(logger.TraceLevel, logger.DefaultLogger) {
-						logger.Tracef("Network metric for router %s and gateway %s: %v", event.Route.Router, event.Route.Gateway, metric)
+					if logger.V(logger.TraceLevel, log) {
+						log.Tracef("Network metric for router %s and gateway %s: %v", event.Route.Router, event.Route.Gateway, metric)
 					}
 
 					// check we don't overflow max int 64
@@ -716,10 +882,12 @@ func (n *mucpNetwork) processCtrlChan(listener tunnel.Listener) {
 
 					// update the local table
 					if err := n.router.Table().Update(route); err != nil {
-						if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-							logger.Debugf("Network failed to process advert %s: %v", event.Id, err)
+						if logger.V(logger.DebugLevel, log) {
+							log.WithFields(map[string]interface{}{"peer": pbAdvert.Id, "route": route.Service}).Debugf("Network failed to process advert %s: %v", event.Id, err)
 						}
+						continue
 					}
+					n.learnRoute(route, false)
 				}
 			}
 		case <-n.closed:
@@ -749,7 +917,7 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 
 				pbConnect := &pb.Connect{}
 				if err := proto.Unmarshal(m.msg.Body, pbConnect); err != nil {
-					logger.Debugf("Network tunnel [%s] connect unmarshal error: %v", NetworkChannel, err)
+					log.Debugf("Network tunnel [%s] connect unmarshal error: %v", NetworkChannel, err)
 					continue
 				}
 
@@ -758,7 +926,7 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 					continue
 				}
 
-				logger.Debugf("Network received connect message from: %s", pbConnect.Node.Id)
+				log.WithFields(map[string]interface{}{"peer": pbConnect.Node.Id}).Debugf("Network received connect message from: %s", pbConnect.Node.Id)
 
 				peer := &node{
 					id:       pbConnect.Node.Id,
@@ -774,15 +942,15 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 				// TODO: should we do this only if we manage to add a peer
 				// What should we do if the peer links failed to be updated?
 				if err := n.updatePeerLinks(peer); err != nil {
-					logger.Debugf("Network failed updating peer links: %s", err)
+					log.Debugf("Network failed updating peer links: %s", err)
 				}
 
 				// add peer to the list of node peers
 				if err := n.AddPeer(peer); err == ErrPeerExists {
-					logger.Tracef("Network peer exists, refreshing: %s", peer.id)
+					log.Tracef("Network peer exists, refreshing: %s", peer.id)
 					// update lastSeen time for the peer
 					if err := n.RefreshPeer(peer.id, peer.link, now); err != nil {
-						logger.Debugf("Network failed refreshing peer %s: %v", peer.id, err)
+						log.Debugf("Network failed refreshing peer %s: %v", peer.id, err)
 					}
 				}
 
@@ -802,14 +970,14 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 					// get a list of the best routes for each service in our routing table
 					routes, err := n.getProtoRoutes()
 					if err != nil {
-						logger.Debugf("Network node %s failed listing routes: %v", n.id, err)
+						log.Debugf("Network node %s failed listing routes: %v", n.id, err)
 					}
 					// attached the routes to the message
 					msg.Routes = routes
 
 					// send sync message to the newly connected peer
 					if err := n.sendTo("sync", NetworkChannel, peer, msg); err != nil {
-						logger.Debugf("Network failed to send sync message: %v", err)
+						log.Debugf("Network failed to send sync message: %v", err)
 					}
 				}()
 			case "peer":
@@ -818,7 +986,7 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 				pbPeer := &pb.Peer{}
 
 				if err := proto.Unmarshal(m.msg.Body, pbPeer); err != nil {
-					logger.Debugf("Network tunnel [%s] peer unmarshal error: %v", NetworkChannel, err)
+					log.Debugf("Network tunnel [%s] peer unmarshal error: %v", NetworkChannel, err)
 					continue
 				}
 
@@ -827,7 +995,7 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 					continue
 				}
 
-				logger.Debugf("Network received peer message from: %s %s", pbPeer.Node.Id, pbPeer.Node.Address)
+				log.WithFields(map[string]interface{}{"peer": pbPeer.Node.Id}).Debugf("Network received peer message from: %s %s", pbPeer.Node.Id, pbPeer.Node.Address)
 
 				peer := &node{
 					id:       pbPeer.Node.Id,
@@ -843,7 +1011,7 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 				// TODO: should we do this only if we manage to add a peer
 				// What should we do if the peer links failed to be updated?
 				if err := n.updatePeerLinks(peer); err != nil {
-					logger.Debugf("Network failed updating peer links: %s", err)
+					log.Debugf("Network failed updating peer links: %s", err)
 				}
 
 				// if it's a new peer i.e. we do not have it in our graph, we request full sync
@@ -859,29 +1027,29 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 						// get a list of the best routes for each service in our routing table
 						routes, err := n.getProtoRoutes()
 						if err != nil {
-							logger.Debugf("Network node %s failed listing routes: %v", n.id, err)
+							log.Debugf("Network node %s failed listing routes: %v", n.id, err)
 						}
 						// attached the routes to the message
 						msg.Routes = routes
 
 						// send sync message to the newly connected peer
 						if err := n.sendTo("sync", NetworkChannel, peer, msg); err != nil {
-							logger.Debugf("Network failed to send sync message: %v", err)
+							log.Debugf("Network failed to send sync message: %v", err)
 						}
 					}()
 
 					continue
 					// if we already have the peer in our graph, skip further steps
 				} else if err != ErrPeerExists {
-					logger.Debugf("Network got error adding peer %v", err)
+					log.Debugf("Network got error adding peer %v", err)
 					continue
 				}
 
-				logger.Tracef("Network peer exists, refreshing: %s", pbPeer.Node.Id)
+				log.Tracef("Network peer exists, refreshing: %s", pbPeer.Node.Id)
 
 				// update lastSeen time for the peer
 				if err := n.RefreshPeer(peer.id, peer.link, now); err != nil {
-					logger.Debugf("Network failed refreshing peer %s: %v", pbPeer.Node.Id, err)
+					log.Debugf("Network failed refreshing peer %s: %v", pbPeer.Node.Id, err)
 				}
 
 				// NOTE: we don't unpack MaxDepth topology
@@ -889,9 +1057,9 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 				// update the link
 				peer.link = m.msg.Header["Micro-Link"]
 
-				logger.Tracef("Network updating topology of node: %s", n.node.id)
+				log.Tracef("Network updating topology of node: %s", n.node.id)
 				if err := n.node.UpdatePeer(peer); err != nil {
-					logger.Debugf("Network failed to update peers: %v", err)
+					log.Debugf("Network failed to update peers: %v", err)
 				}
 
 				// tell the connect loop that we've been discovered
@@ -907,7 +1075,7 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 
 				pbSync := &pb.Sync{}
 				if err := proto.Unmarshal(m.msg.Body, pbSync); err != nil {
-					logger.Debugf("Network tunnel [%s] sync unmarshal error: %v", NetworkChannel, err)
+					log.Debugf("Network tunnel [%s] sync unmarshal error: %v", NetworkChannel, err)
 					continue
 				}
 
@@ -916,7 +1084,7 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 					continue
 				}
 
-				logger.Debugf("Network received sync message from: %s", pbSync.Peer.Node.Id)
+				log.WithFields(map[string]interface{}{"peer": pbSync.Peer.Node.Id}).Debugf("Network received sync message from: %s", pbSync.Peer.Node.Id)
 
 				peer := &node{
 					id:       pbSync.Peer.Node.Id,
@@ -932,20 +1100,20 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 				// TODO: should we do this only if we manage to add a peer
 				// What should we do if the peer links failed to be updated?
 				if err := n.updatePeerLinks(peer); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed updating peer links: %s", err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed updating peer links: %s", err)
 					}
 				}
 
 				// add peer to the list of node peers
 				if err := n.node.AddPeer(peer); err == ErrPeerExists {
-					if logger.V(logger.TraceLevel, logger.DefaultLogger) {
-						logger.Tracef("Network peer exists, refreshing: %s", peer.id)
+					if logger.V(logger.TraceLevel, log) {
+						log.Tracef("Network peer exists, refreshing: %s", peer.id)
 					}
 					// update lastSeen time for the existing node
 					if err := n.RefreshPeer(peer.id, peer.link, now); err != nil {
-						if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-							logger.Debugf("Network failed refreshing peer %s: %v", peer.id, err)
+						if logger.V(logger.DebugLevel, log) {
+							log.Debugf("Network failed refreshing peer %s: %v", peer.id, err)
 						}
 					}
 				}
@@ -959,11 +1127,15 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 					route := ProtoToRoute(pbRoute)
 					// continue if we are the originator of the route
 					if route.Router == n.router.Options().Id {
-						if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-							logger.Debugf("Network node %s skipping route addition: route already present", n.id)
+						if logger.V(logger.DebugLevel, log) {
+							log.Debugf("Network node %s skipping route addition: route already present", n.id)
 						}
 						continue
 					}
+					// continue if we don't accept the routes of the service from the network
+					if !n.router.Options().AcceptFilter.Match(route.Service) {
+						continue
+					}
 
 					metric := n.getRouteMetric(route.Router, route.Gateway, route.Link)
 					// check we don't overflow max int 64
@@ -975,14 +1147,17 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 						route.Metric = d
 					}
 
+					// refresh the route if we've already learned it
+					n.learnRoute(route, true)
+
 					q := []router.LookupOption{
 						router.LookupLink(route.Link),
 					}
 
 					routes, err := n.router.Lookup(route.Service, q...)
 					if err != nil && err != router.ErrRouteNotFound {
-						if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-							logger.Debugf("Network node %s failed listing best routes for %s: %v", n.id, route.Service, err)
+						if logger.V(logger.DebugLevel, log) {
+							log.WithFields(map[string]interface{}{"route": route.Service}).Debugf("Network node %s failed listing best routes for %s: %v", n.id, route.Service, err)
 						}
 						continue
 					}
@@ -991,10 +1166,12 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 					// create the new route we have just received
 					if len(routes) == 0 {
 						if err := n.router.Table().Create(route); err != nil && err != router.ErrDuplicateRoute {
-							if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-								logger.Debugf("Network node %s failed to add route: %v", n.id, err)
+							if logger.V(logger.DebugLevel, log) {
+								log.WithFields(map[string]interface{}{"route": route.Service}).Debugf("Network node %s failed to add route: %v", n.id, err)
 							}
+							continue
 						}
+						n.learnRoute(route, false)
 						continue
 					}
 
@@ -1017,17 +1194,19 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 
 					// add route to the routing table
 					if err := n.router.Table().Create(route); err != nil && err != router.ErrDuplicateRoute {
-						if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-							logger.Debugf("Network node %s failed to add route: %v", n.id, err)
+						if logger.V(logger.DebugLevel, log) {
+							log.WithFields(map[string]interface{}{"route": route.Service}).Debugf("Network node %s failed to add route: %v", n.id, err)
 						}
+						continue
 					}
+					n.learnRoute(route, false)
 				}
 
 				// update your sync timestamp
 				// NOTE: this might go away as we will be doing full table advert to random peer
 				if err := n.RefreshSync(now); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed refreshing sync time: %v", err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed refreshing sync time: %v", err)
 					}
 				}
 
@@ -1037,16 +1216,16 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 
 					// advertise yourself to the new node
 					if err := n.sendTo("peer", NetworkChannel, peer, msg); err != nil {
-						if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-							logger.Debugf("Network failed to advertise peers: %v", err)
+						if logger.V(logger.DebugLevel, log) {
+							log.Debugf("Network failed to advertise peers: %v", err)
 						}
 					}
 				}()
 			case "close":
 				pbClose := &pb.Close{}
 				if err := proto.Unmarshal(m.msg.Body, pbClose); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network tunnel [%s] close unmarshal error: %v", NetworkChannel, err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network tunnel [%s] close unmarshal error: %v", NetworkChannel, err)
 					}
 					continue
 				}
@@ -1056,8 +1235,8 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 					continue
 				}
 
-				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-					logger.Debugf("Network received close message from: %s", pbClose.Node.Id)
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Network received close message from: %s", pbClose.Node.Id)
 				}
 
 				peer := &node{
@@ -1066,14 +1245,14 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 				}
 
 				if err := n.DeletePeerNode(peer.id); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed to delete node %s routes: %v", peer.id, err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed to delete node %s routes: %v", peer.id, err)
 					}
 				}
 
 				if err := n.prunePeerRoutes(peer); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed pruning peer %s routes: %v", peer.id, err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed pruning peer %s routes: %v", peer.id, err)
 					}
 				}
 
@@ -1084,6 +1263,53 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 				n.Lock()
 				delete(n.peerLinks, pbClose.Node.Address)
 				n.Unlock()
+			case "probe":
+				pbProbe := &pb.Probe{}
+				if err := proto.Unmarshal(m.msg.Body, pbProbe); err != nil {
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network tunnel [%s] probe unmarshal error: %v", NetworkChannel, err)
+					}
+					continue
+				}
+
+				// don't process your own messages
+				if pbProbe.Node == nil || pbProbe.Node.Id == n.options.Id {
+					continue
+				}
+
+				// our probe was returned so record the round trip time
+				if pbProbe.Reply {
+					rtt := time.Since(time.Unix(0, pbProbe.Timestamp))
+					n.node.SetLatency(pbProbe.Node.Id, rtt)
+					// the peers which don't stamp the replies predate the skew checks
+					if pbProbe.PeerTimestamp > 0 {
+						n.checkClockSkew(pbProbe.Node.Id, pbProbe.Timestamp, pbProbe.PeerTimestamp, rtt)
+					}
+					continue
+				}
+
+				// return the probe on the link it came from
+				peer := &node{
+					id:   pbProbe.Node.Id,
+					link: m.msg.Header["Micro-Link"],
+				}
+				reply := &pb.Probe{
+					Node: &pb.Node{
+						Id:      n.options.Id,
+						Address: n.node.address,
+					},
+					Timestamp:     pbProbe.Timestamp,
+					Reply:         true,
+					PeerTimestamp: time.Now().UnixNano(),
+				}
+
+				go func() {
+					if err := n.sendTo("probe", NetworkChannel, peer, reply); err != nil {
+						if logger.V(logger.DebugLevel, log) {
+							log.Debugf("Network failed to return probe to %s: %v", peer.id, err)
+						}
+					}
+				}()
 			}
 		case <-n.closed:
 			return
@@ -1091,6 +1317,93 @@ func (n *mucpNetwork) processNetChan(listener tunnel.Listener) {
 	}
 }
 
+// learnRoute marks the route learned from a peer as seen if the advertise ttl is set, only
+// refreshing the routes already learned if refresh is true
+func (n *mucpNetwork) learnRoute(route router.Route, refresh bool) {
+	if n.router.Options().AdvertiseTTL <= 0 {
+		return
+	}
+
+	n.Lock()
+	defer n.Unlock()
+
+	sum := route.Hash()
+	if l, ok := n.learned[sum]; ok {
+		l.lastSeen = time.Now()
+	} else if !refresh {
+		n.learned[sum] = &learnedRoute{route: route, lastSeen: time.Now()}
+	}
+}
+
+// expireRoutes deletes the routes learned from the peers which haven't been advertised again
+// within the ttl
+func (n *mucpNetwork) expireRoutes(ttl time.Duration) {
+	var expired []router.Route
+
+	n.Lock()
+	for sum, l := range n.learned {
+		if time.Since(l.lastSeen) > ttl {
+			expired = append(expired, l.route)
+			delete(n.learned, sum)
+		}
+	}
+	n.Unlock()
+
+	for _, route := range expired {
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Network route to %s via %s expired", route.Service, route.Gateway)
+		}
+		n.router.Table().Delete(route)
+	}
+}
+
+// refreshRoutes advertises the routes to all the peers so they don't expire
+func (n *mucpNetwork) refreshRoutes() {
+	routes, err := n.router.Table().Read()
+	if err != nil && err != router.ErrRouteNotFound {
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Network failed listing routes to refresh: %v", err)
+		}
+		return
+	}
+
+	routes = n.advertisedRoutes(routes)
+	if len(routes) == 0 {
+		return
+	}
+
+	now := time.Now()
+	msg := &pb.Advert{
+		Id:        n.Id(),
+		Type:      pb.AdvertType_AdvertUpdate,
+		Timestamp: now.UnixNano(),
+		Events:    make([]*pb.Event, 0, len(routes)),
+	}
+	for _, route := range routes {
+		pbRoute := RouteToProto(route)
+		n.maskRoute(pbRoute)
+		msg.Events = append(msg.Events, &pb.Event{
+			Type:      pb.EventType_Update,
+			Timestamp: now.UnixNano(),
+			Route:     pbRoute,
+		})
+	}
+
+	for _, peer := range n.Peers() {
+		node := n.node.GetPeerNode(peer.Id())
+		if node == nil {
+			continue
+		}
+		if err := n.sendTo("advert", ControlChannel, node, msg); err != nil {
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network failed to refresh routes of %s: %v", peer.Id(), err)
+			}
+			continue
+		}
+		n.sentAdvert()
+	}
+}
+
 // pruneRoutes prunes routes return by given query
 func (n *mucpNetwork) pruneRoutes(q ...router.LookupOption) error {
 	routes, err := n.router.Table().Read()
@@ -1140,6 +1453,17 @@ func (n *mucpNetwork) manage() {
 	defer prune.Stop()
 	netsync := time.NewTicker(SyncTime)
 	defer netsync.Stop()
+	probe := time.NewTicker(ProbeTime)
+	defer probe.Stop()
+
+	// the routes are advertised again twice per ttl so they only expire once their router is gone
+	var refresh <-chan time.Time
+	ttl := n.router.Options().AdvertiseTTL
+	if ttl > 0 {
+		t := time.NewTicker(ttl / 2)
+		defer t.Stop()
+		refresh = t.C
+	}
 
 	// list of links we've sent to
 	links := make(map[string]time.Time)
@@ -1165,13 +1489,25 @@ func (n *mucpNetwork) manage() {
 			// garbage collected
 			links = current
 
+			// copy the peers so they're not modified while we check them
+			n.node.RLock()
+			known := make([]*node, 0, len(n.node.peers))
+			for _, peer := range n.node.peers {
+				known = append(known, &node{
+					id:      peer.id,
+					address: peer.address,
+					link:    peer.link,
+				})
+			}
+			n.node.RUnlock()
+
 			n.RLock()
 			var i int
 			// create a list of peers to send to
 			var peers []*node
 
 			// check peers to see if they need to be sent to
-			for _, peer := range n.peers {
+			for _, peer := range known {
 				if i >= 3 {
 					break
 				}
@@ -1190,8 +1526,8 @@ func (n *mucpNetwork) manage() {
 						// set the link via peer links
 						l, ok := n.peerLinks[peer.address]
 						if ok {
-							if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-								logger.Debugf("Network link not found for peer %s cannot announce", peer.id)
+							if logger.V(logger.DebugLevel, log) {
+								log.Debugf("Network link not found for peer %s cannot announce", peer.id)
 							}
 							continue
 						}
@@ -1218,8 +1554,8 @@ func (n *mucpNetwork) manage() {
 			for _, peer := range peers {
 				// advertise yourself to the network
 				if err := n.sendTo("peer", NetworkChannel, peer, msg); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed to advertise peer %s: %v", peer.id, err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed to advertise peer %s: %v", peer.id, err)
 					}
 					continue
 				}
@@ -1242,31 +1578,36 @@ func (n *mucpNetwork) manage() {
 
 				// unknown link and peer so lets do the connect flow
 				if err := n.sendTo("connect", NetworkChannel, peer, msg); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed to connect %s: %v", peer.id, err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed to connect %s: %v", peer.id, err)
 					}
 					continue
 				}
 
 				links[peer.link] = time.Now()
 			}
+		case <-probe.C:
+			n.probePeers()
+		case <-refresh:
+			n.expireRoutes(ttl)
+			go n.refreshRoutes()
 		case <-prune.C:
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Network node %s pruning stale peers", n.id)
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network node %s pruning stale peers", n.id)
 			}
 			pruned := n.PruneStalePeers(PruneTime)
 
 			for id, peer := range pruned {
-				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-					logger.Debugf("Network peer exceeded prune time: %s", id)
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Network peer exceeded prune time: %s", id)
 				}
 				n.Lock()
 				delete(n.peerLinks, peer.address)
 				n.Unlock()
 
 				if err := n.prunePeerRoutes(peer); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed pruning peer %s routes: %v", id, err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed pruning peer %s routes: %v", id, err)
 					}
 				}
 			}
@@ -1274,8 +1615,8 @@ func (n *mucpNetwork) manage() {
 			// get a list of all routes
 			routes, err := n.options.Router.Table().Read()
 			if err != nil {
-				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-					logger.Debugf("Network failed listing routes when pruning peers: %v", err)
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Network failed listing routes when pruning peers: %v", err)
 				}
 				continue
 			}
@@ -1304,8 +1645,8 @@ func (n *mucpNetwork) manage() {
 
 				// otherwise delete all the routes originated by it
 				if err := n.pruneRoutes(router.LookupRouter(route.Router)); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed deleting routes by %s: %v", route.Router, err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed deleting routes by %s: %v", route.Router, err)
 					}
 				}
 			}
@@ -1336,8 +1677,8 @@ func (n *mucpNetwork) manage() {
 				// get a list of the best routes for each service in our routing table
 				routes, err := n.getProtoRoutes()
 				if err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network node %s failed listing routes: %v", n.id, err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network node %s failed listing routes: %v", n.id, err)
 					}
 				}
 				// attached the routes to the message
@@ -1345,8 +1686,8 @@ func (n *mucpNetwork) manage() {
 
 				// send sync message to the newly connected peer
 				if err := n.sendTo("sync", NetworkChannel, peer, msg); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Network failed to send sync message: %v", err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Network failed to send sync message: %v", err)
 					}
 				}
 			}()
@@ -1354,7 +1695,7 @@ func (n *mucpNetwork) manage() {
 	}
 }
 
-// getAdvertProtoRoutes returns a list of routes to advertise to remote peer
+// getProtoRoutes returns a list of routes to advertise to remote peer
 // based on the advertisement strategy encoded in protobuf
 // It returns error if the routes failed to be retrieved from the routing table
 func (n *mucpNetwork) getProtoRoutes() ([]*pb.Route, error) {
@@ -1362,6 +1703,7 @@ func (n *mucpNetwork) getProtoRoutes() ([]*pb.Route, error) {
 	if err != nil && err != router.ErrRouteNotFound {
 		return nil, err
 	}
+	routes = n.advertisedRoutes(routes)
 
 	// encode the routes to protobuf
 	pbRoutes := make([]*pb.Route, 0, len(routes))
@@ -1377,6 +1719,56 @@ func (n *mucpNetwork) getProtoRoutes() ([]*pb.Route, error) {
 	return pbRoutes, nil
 }
 
+// probePeers sends a probe to each peer to measure the round trip time of the link to it
+func (n *mucpNetwork) probePeers() {
+	n.node.Lock()
+	// forget the peers which have gone
+	n.node.pruneLatency()
+
+	var peers []*node
+	for _, peer := range n.node.peers {
+		if len(peer.link) == 0 {
+			continue
+		}
+		peers = append(peers, &node{
+			id:   peer.id,
+			link: peer.link,
+		})
+	}
+	n.node.Unlock()
+
+	msg := &pb.Probe{
+		Node: &pb.Node{
+			Id:      n.options.Id,
+			Address: n.node.address,
+		},
+		Timestamp: time.Now().UnixNano(),
+	}
+
+	for _, peer := range peers {
+		if err := n.sendTo("probe", NetworkChannel, peer, msg); err != nil {
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network failed to probe peer %s: %v", peer.id, err)
+			}
+		}
+	}
+}
+
+// checkClockSkew records the offset of the clock of a peer from the timestamp it stamped a probe
+// reply with. The peer is assumed to have stamped it half way through the round trip.
+func (n *mucpNetwork) checkClockSkew(id string, sent, stamped int64, rtt time.Duration) {
+	skew := time.Duration(stamped-sent) - rtt/2
+	n.node.SetClockSkew(id, skew)
+
+	if metrics.IsSet() {
+		metrics.Gauge("network.clock_skew", skew.Seconds(), metrics.Tags{"network": n.options.Name, "peer": id})
+	}
+
+	if skew > MaxClockSkew || skew < -MaxClockSkew {
+		log.WithFields(map[string]interface{}{"peer": id}).Warnf("Network peer %s clock is skewed by %v, the leases and ttls expire early or late until the clocks are synchronized", id, skew)
+	}
+}
+
 func (n *mucpNetwork) sendConnect() {
 	// send connect message to NetworkChannel
 	// NOTE: in theory we could do this as soon as
@@ -1390,8 +1782,8 @@ func (n *mucpNetwork) sendConnect() {
 	}
 
 	if err := n.sendMsg("connect", NetworkChannel, msg); err != nil {
-		if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-			logger.Debugf("Network failed to send connect message: %s", err)
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Network failed to send connect message: %s", err)
 		}
 	}
 }
@@ -1406,17 +1798,22 @@ func (n *mucpNetwork) sendTo(method, channel string, peer *node, msg proto.Messa
 	}
 
 	// Create a unicast connection to the peer but don't do the open/accept flow
-	c, err := n.tunnel.Dial(channel, tunnel.DialWait(false), tunnel.DialLink(peer.link))
+	c, err := n.tunnel.Dial(
+		channel,
+		tunnel.DialWait(false),
+		tunnel.DialLink(peer.link),
+		tunnel.DialPriority(tunnel.PriorityControl),
+	)
 	if err != nil {
 		if peerNode := n.GetPeerNode(peer.id); peerNode != nil {
 			// update node status when error happens
 			peerNode.status.err.Update(err)
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Network increment peer %v error count to: %d", peerNode, peerNode, peerNode.status.Error().Count())
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network increment peer %v error count to: %d", peerNode, peerNode, peerNode.status.Error().Count())
 			}
 			if count := peerNode.status.Error().Count(); count == MaxPeerErrors {
-				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-					logger.Debugf("Network peer %v error count exceeded %d. Prunning.", peerNode, MaxPeerErrors)
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Network peer %v error count exceeded %d. Prunning.", peerNode, MaxPeerErrors)
 				}
 				n.PrunePeer(peerNode.id)
 			}
@@ -1431,8 +1828,8 @@ func (n *mucpNetwork) sendTo(method, channel string, peer *node, msg proto.Messa
 		id = peer.link
 	}
 
-	if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-		logger.Debugf("Network sending %s message from: %s to %s", method, n.options.Id, id)
+	if logger.V(logger.DebugLevel, log) {
+		log.Debugf("Network sending %s message from: %s to %s", method, n.options.Id, id)
 	}
 	tmsg := &transport.Message{
 		Header: map[string]string{
@@ -1449,17 +1846,17 @@ func (n *mucpNetwork) sendTo(method, channel string, peer *node, msg proto.Messa
 	if err := c.Send(tmsg); err != nil {
 		// TODO: Lookup peer in our graph
 		if peerNode := n.GetPeerNode(peer.id); peerNode != nil {
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Network found peer %s: %v", peer.id, peerNode)
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network found peer %s: %v", peer.id, peerNode)
 			}
 			// update node status when error happens
 			peerNode.status.err.Update(err)
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Network increment node peer %p %v count to: %d", peerNode, peerNode, peerNode.status.Error().Count())
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network increment node peer %p %v count to: %d", peerNode, peerNode, peerNode.status.Error().Count())
 			}
 			if count := peerNode.status.Error().Count(); count == MaxPeerErrors {
-				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-					logger.Debugf("Network node peer %v count exceeded %d: %d", peerNode, MaxPeerErrors, peerNode.status.Error().Count())
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Network node peer %v count exceeded %d: %d", peerNode, MaxPeerErrors, peerNode.status.Error().Count())
 				}
 				n.PrunePeer(peerNode.id)
 			}
@@ -1486,8 +1883,8 @@ func (n *mucpNetwork) sendMsg(method, channel string, msg proto.Message) error {
 	}
 	n.RUnlock()
 
-	if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-		logger.Debugf("Network sending %s message from: %s", method, n.options.Id)
+	if logger.V(logger.DebugLevel, log) {
+		log.Debugf("Network sending %s message from: %s", method, n.options.Id)
 	}
 
 	return client.Send(&transport.Message{
@@ -1505,8 +1902,8 @@ func (n *mucpNetwork) updatePeerLinks(peer *node) error {
 
 	linkId := peer.link
 
-	if logger.V(logger.TraceLevel, logger.DefaultLogger) {
-		logger.Tracef("Network looking up link %s in the peer links", linkId)
+	if logger.V(logger.TraceLevel, log) {
+		log.Tracef("Network looking up link %s in the peer links", linkId)
 	}
 
 	// lookup the peer link
@@ -1523,9 +1920,9 @@ func (n *mucpNetwork) updatePeerLinks(peer *node) error {
 		return ErrPeerLinkNotFound
 	}
 
-	if logger.V(logger.TraceLevel, logger.DefaultLogger) {
+	if logger.V(logger.TraceLevel, log) {
 		// if the peerLink is found in the returned links update peerLinks
-		logger.Tracef("Network updating peer links for peer %s", peer.address)
+		log.Tracef("Network updating peer links for peer %s", peer.address)
 	}
 
 	// lookup a link and update it if better link is available
@@ -1608,8 +2005,8 @@ func (n *mucpNetwork) connect() {
 			// well functioning tunnel clients as "discovered" will be false until the
 			// n.discovered channel is read at some point later on.
 			if err := n.createClients(); err != nil {
-				if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-					logger.Debugf("Failed to recreate network/control clients: %v", err)
+				if logger.V(logger.DebugLevel, log) {
+					log.Debugf("Failed to recreate network/control clients: %v", err)
 				}
 				continue
 			}
@@ -1664,6 +2061,7 @@ func (n *mucpNetwork) Connect() error {
 	netListener, err := n.tunnel.Listen(
 		NetworkChannel,
 		tunnel.ListenMode(tunnel.Multicast),
+		tunnel.ListenPriority(tunnel.PriorityControl),
 	)
 	if err != nil {
 		return err
@@ -1673,6 +2071,7 @@ func (n *mucpNetwork) Connect() error {
 	ctrlListener, err := n.tunnel.Listen(
 		ControlChannel,
 		tunnel.ListenMode(tunnel.Multicast),
+		tunnel.ListenPriority(tunnel.PriorityControl),
 	)
 	if err != nil {
 		return err
@@ -1682,6 +2081,7 @@ func (n *mucpNetwork) Connect() error {
 	ctrlClient, err := n.tunnel.Dial(
 		ControlChannel,
 		tunnel.DialMode(tunnel.Multicast),
+		tunnel.DialPriority(tunnel.PriorityControl),
 	)
 	if err != nil {
 		return err
@@ -1693,6 +2093,7 @@ func (n *mucpNetwork) Connect() error {
 	netClient, err := n.tunnel.Dial(
 		NetworkChannel,
 		tunnel.DialMode(tunnel.Multicast),
+		tunnel.DialPriority(tunnel.PriorityControl),
 	)
 	if err != nil {
 		return err
@@ -1758,13 +2159,13 @@ func (n *mucpNetwork) close() error {
 // createClients is used to create new clients in the event we lose all the tunnels
 func (n *mucpNetwork) createClients() error {
 	// dial into ControlChannel to send route adverts
-	ctrlClient, err := n.tunnel.Dial(ControlChannel, tunnel.DialMode(tunnel.Multicast))
+	ctrlClient, err := n.tunnel.Dial(ControlChannel, tunnel.DialMode(tunnel.Multicast), tunnel.DialPriority(tunnel.PriorityControl))
 	if err != nil {
 		return err
 	}
 
 	// dial into NetworkChannel to send network messages
-	netClient, err := n.tunnel.Dial(NetworkChannel, tunnel.DialMode(tunnel.Multicast))
+	netClient, err := n.tunnel.Dial(NetworkChannel, tunnel.DialMode(tunnel.Multicast), tunnel.DialPriority(tunnel.PriorityControl))
 	if err != nil {
 		return err
 	}
@@ -1819,8 +2220,8 @@ func (n *mucpNetwork) Close() error {
 		}
 
 		if err := n.sendMsg("close", NetworkChannel, msg); err != nil {
-			if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-				logger.Debugf("Network failed to send close message: %s", err)
+			if logger.V(logger.DebugLevel, log) {
+				log.Debugf("Network failed to send close message: %s", err)
 			}
 		}
 		<-time.After(time.Millisecond * 100)
@@ -1829,6 +2230,27 @@ func (n *mucpNetwork) Close() error {
 	return n.close()
 }
 
+// Stats returns the counters of the messages of the network
+func (n *mucpNetwork) Stats() Stats {
+	stats := Stats{
+		AdvertsSent:     atomic.LoadUint64(&n.advertsSent),
+		AdvertsReceived: atomic.LoadUint64(&n.advertsReceived),
+	}
+	if t := atomic.LoadInt64(&n.lastAdvertSent); t > 0 {
+		stats.LastAdvertSent = time.Unix(0, t)
+	}
+	if t := atomic.LoadInt64(&n.lastAdvertReceived); t > 0 {
+		stats.LastAdvertReceived = time.Unix(0, t)
+	}
+	return stats
+}
+
+// sentAdvert counts an advert sent to a peer
+func (n *mucpNetwork) sentAdvert() {
+	atomic.AddUint64(&n.advertsSent, 1)
+	atomic.StoreInt64(&n.lastAdvertSent, time.Now().UnixNano())
+}
+
 // Client returns network client
 func (n *mucpNetwork) Client() client.Client {
 	return n.client
@@ -0,0 +1,105 @@
+package mucp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/network"
+	"github.com/micro/micro/v3/service/registry/noop"
+	"github.com/micro/micro/v3/service/router"
+	"github.com/micro/micro/v3/service/router/registry"
+)
+
+func TestAdvertiseStrategy(t *testing.T) {
+	rtr := registry.NewRouter(router.Registry(noop.NewRegistry()))
+	n := NewNetwork(network.Id("local"), network.Router(rtr)).(*mucpNetwork)
+
+	routes := []router.Route{
+		{Service: "foo", Address: "10.0.0.1:8080", Network: "micro", Router: "local", Metric: 1},
+		{Service: "foo", Address: "10.0.0.2:8080", Network: "micro", Router: "remote", Metric: 10},
+		{Service: "bar", Address: "10.0.0.3:8080", Network: "micro", Router: "remote", Metric: 5},
+	}
+	for _, r := range routes {
+		if err := rtr.Table().Create(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testData := []struct {
+		strategy router.Strategy
+		routes   int
+		// whether the route of foo from the remote router is advertised when it changes
+		remote bool
+	}{
+		{router.AdvertiseAll, 3, true},
+		{router.AdvertiseBest, 2, false},
+		{router.AdvertiseLocal, 1, false},
+		{router.AdvertiseNone, 0, false},
+	}
+
+	for _, d := range testData {
+		rtr.Init(router.Advertise(d.strategy))
+
+		if got := n.advertisedRoutes(routes); len(got) != d.routes {
+			t.Errorf("Expected %d routes advertised with %s, got %d", d.routes, d.strategy, len(got))
+		}
+		if got := n.isAdvertised(&router.Event{Type: router.Update, Route: routes[1]}); got != d.remote {
+			t.Errorf("Expected the remote route advertised to be %v with %s, got %v", d.remote, d.strategy, got)
+		}
+	}
+
+	if _, err := router.ParseStrategy("some"); err == nil {
+		t.Error("Expected an error parsing an unknown strategy")
+	}
+}
+
+func TestAdvertiseFilter(t *testing.T) {
+	rtr := registry.NewRouter(router.Registry(noop.NewRegistry()))
+	n := NewNetwork(network.Id("local"), network.Router(rtr)).(*mucpNetwork)
+
+	routes := []router.Route{
+		{Service: "foo", Address: "10.0.0.1:8080", Network: "micro", Router: "local", Metric: 1},
+		{Service: "internal.auth", Address: "10.0.0.2:8080", Network: "micro", Router: "local", Metric: 1},
+		{Service: "bar", Address: "10.0.0.3:8080", Network: "micro", Router: "local", Metric: 1},
+	}
+
+	f, err := router.ParseServiceFilter("deny=internal.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rtr.Init(router.AdvertiseFilter(f))
+
+	if got := n.advertisedRoutes(routes); len(got) != 2 {
+		t.Errorf("Expected the internal routes not to be advertised, got %v", got)
+	}
+	if n.isAdvertised(&router.Event{Type: router.Update, Route: routes[1]}) {
+		t.Error("Expected the change of an internal route not to be advertised")
+	}
+	if !n.isAdvertised(&router.Event{Type: router.Update, Route: routes[0]}) {
+		t.Error("Expected the change of a route allowed by the filter to be advertised")
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	n := NewNetwork(network.Id("local")).(*mucpNetwork)
+
+	// the peer stamped the reply 3s ahead of the middle of the 100ms round trip
+	sent := time.Now().Add(-100 * time.Millisecond)
+	stamped := sent.Add(50*time.Millisecond + 3*time.Second)
+	n.checkClockSkew("peer1", sent.UnixNano(), stamped.UnixNano(), 100*time.Millisecond)
+
+	// the peer is 2s behind
+	stamped = sent.Add(50*time.Millisecond - 2*time.Second)
+	n.checkClockSkew("peer2", sent.UnixNano(), stamped.UnixNano(), 100*time.Millisecond)
+
+	skew := n.ClockSkew()
+	if skew["peer1"] != 3*time.Second || skew["peer2"] != -2*time.Second {
+		t.Fatalf("Expected the skews of 3s and -2s, got %v", skew)
+	}
+
+	// the skews of the nodes which are no longer peers are dropped
+	n.node.pruneLatency()
+	if len(n.ClockSkew()) != 0 {
+		t.Fatalf("Expected the skews to be pruned, got %v", n.ClockSkew())
+	}
+}
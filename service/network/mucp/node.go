@@ -120,6 +120,10 @@ type node struct {
 	lastSync time.Time
 	// err tracks node status
 	status *status
+	// latency is the round trip time to the peers
+	latency map[string]time.Duration
+	// skew is the offset of the clocks of the peers from ours
+	skew map[string]time.Duration
 }
 
 // Id is node ide
@@ -137,6 +141,69 @@ func (n *node) Network() network.Network {
 	return n.network
 }
 
+// Latency returns the round trip time to the node peers
+func (n *node) Latency() map[string]time.Duration {
+	n.RLock()
+	defer n.RUnlock()
+
+	// copy the map since the peers are probed concurrently
+	latency := make(map[string]time.Duration, len(n.latency))
+	for id, d := range n.latency {
+		latency[id] = d
+	}
+	return latency
+}
+
+// SetLatency records the round trip time to a peer
+func (n *node) SetLatency(id string, d time.Duration) {
+	n.Lock()
+	defer n.Unlock()
+
+	if n.latency == nil {
+		n.latency = make(map[string]time.Duration)
+	}
+	n.latency[id] = d
+}
+
+// ClockSkew returns the offset of the clocks of the node peers from the clock of the node,
+// positive when the clock of the peer is ahead
+func (n *node) ClockSkew() map[string]time.Duration {
+	n.RLock()
+	defer n.RUnlock()
+
+	skew := make(map[string]time.Duration, len(n.skew))
+	for id, d := range n.skew {
+		skew[id] = d
+	}
+	return skew
+}
+
+// SetClockSkew records the offset of the clock of a peer
+func (n *node) SetClockSkew(id string, d time.Duration) {
+	n.Lock()
+	defer n.Unlock()
+
+	if n.skew == nil {
+		n.skew = make(map[string]time.Duration)
+	}
+	n.skew[id] = d
+}
+
+// pruneLatency drops the round trip times and clock skews of nodes which are no longer peers.
+// NOTE: this function is not thread safe
+func (n *node) pruneLatency() {
+	for id := range n.latency {
+		if _, ok := n.peers[id]; !ok {
+			delete(n.latency, id)
+		}
+	}
+	for id := range n.skew {
+		if _, ok := n.peers[id]; !ok {
+			delete(n.skew, id)
+		}
+	}
+}
+
 // Status returns node status
 func (n *node) Status() network.Status {
 	n.RLock()
@@ -397,6 +464,11 @@ func (n *node) getTopology(depth uint) *node {
 		network:  n.network,
 		status:   n.status,
 		lastSeen: n.lastSeen,
+		latency:  make(map[string]time.Duration, len(n.latency)),
+	}
+
+	for id, d := range n.latency {
+		node.latency[id] = d
 	}
 
 	// return if we reach requested depth or we have no more peers
@@ -449,6 +521,7 @@ func UnpackPeerTopology(pbPeer *pb.Peer, lastSeen time.Time, depth uint) *node {
 		peers:    make(map[string]*node),
 		status:   newPeerStatus(pbPeer),
 		lastSeen: lastSeen,
+		latency:  protoToLatency(pbPeer.Node.Latency),
 	}
 
 	// return if have either reached the depth or have no more peers
@@ -480,6 +553,7 @@ func peerProtoTopology(peer network.Node, depth uint) *pb.Peer {
 				Msg:   peer.Status().Error().Msg(),
 			},
 		},
+		Latency: latencyToProto(peer.Latency()),
 	}
 
 	// set the network name if network is not nil
@@ -509,6 +583,30 @@ func peerProtoTopology(peer network.Node, depth uint) *pb.Peer {
 	return pbPeers
 }
 
+// latencyToProto encodes the round trip times as nanoseconds
+func latencyToProto(latency map[string]time.Duration) map[string]int64 {
+	if len(latency) == 0 {
+		return nil
+	}
+	pbLatency := make(map[string]int64, len(latency))
+	for id, d := range latency {
+		pbLatency[id] = int64(d)
+	}
+	return pbLatency
+}
+
+// protoToLatency decodes the round trip times from nanoseconds
+func protoToLatency(pbLatency map[string]int64) map[string]time.Duration {
+	if len(pbLatency) == 0 {
+		return nil
+	}
+	latency := make(map[string]time.Duration, len(pbLatency))
+	for id, d := range pbLatency {
+		latency[id] = time.Duration(d)
+	}
+	return latency
+}
+
 // PeersToProto returns node peers graph encoded into protobuf
 func PeersToProto(node network.Node, depth uint) *pb.Peer {
 	// network node aka root node
@@ -521,6 +619,7 @@ func PeersToProto(node network.Node, depth uint) *pb.Peer {
 				Msg:   node.Status().Error().Msg(),
 			},
 		},
+		Latency: latencyToProto(node.Latency()),
 	}
 
 	// set the network name if network is not nil
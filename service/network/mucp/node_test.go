@@ -380,3 +380,27 @@ func TestPeersToProto(t *testing.T) {
 		t.Errorf("Expected to find %d nodes, found: %d", topCount, len(protoPeers.Peers))
 	}
 }
+
+func TestLatency(t *testing.T) {
+	node := testSetup()
+	node.SetLatency("peer1", time.Millisecond)
+	node.SetLatency("peer4", time.Millisecond*2)
+	node.peers["peer1"].SetLatency("peer11", time.Millisecond*3)
+
+	// the latency of the peers is encoded along with the topology
+	protoPeers := PeersToProto(node, MaxDepth)
+	if l := protoPeers.Node.Latency["peer1"]; l != int64(time.Millisecond) {
+		t.Errorf("Expected latency of %d to peer1, found: %d", time.Millisecond, l)
+	}
+
+	peer := UnpackPeerTopology(protoPeers, time.Now(), MaxDepth)
+	if l := peer.peers["peer1"].Latency()["peer11"]; l != time.Millisecond*3 {
+		t.Errorf("Expected latency of %v from peer1 to peer11, found: %v", time.Millisecond*3, l)
+	}
+
+	// the latency of nodes which are no longer peers is dropped
+	node.pruneLatency()
+	if _, ok := node.Latency()["peer4"]; ok {
+		t.Error("Expected the latency of peer4 to be pruned")
+	}
+}
@@ -515,6 +515,8 @@ type Node struct {
 	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// node status
 	Status *Status `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// round trip time to the node peers in nanoseconds, keyed by peer id
+	Latency map[string]int64 `protobuf:"bytes,6,rep,name=latency,proto3" json:"latency,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
 func (x *Node) Reset() {
@@ -584,6 +586,13 @@ func (x *Node) GetStatus() *Status {
 	return nil
 }
 
+func (x *Node) GetLatency() map[string]int64 {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
 // Connect is sent when the node connects to the network
 type Connect struct {
 	state         protoimpl.MessageState
@@ -798,6 +807,82 @@ func (x *Sync) GetRoutes() []*Route {
 	return nil
 }
 
+// Probe is sent to the node peers to measure the round trip time
+type Probe struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the probing node
+	Node *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// unix nano timestamp the probe was sent at
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// reply marks the probe as returned by the peer
+	Reply bool `protobuf:"varint,3,opt,name=reply,proto3" json:"reply,omitempty"`
+	// unix nano timestamp of the clock of the peer when it returned the probe
+	PeerTimestamp int64 `protobuf:"varint,4,opt,name=peer_timestamp,json=peerTimestamp,proto3" json:"peer_timestamp,omitempty"`
+}
+
+func (x *Probe) Reset() {
+	*x = Probe{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_micro_go_micro_network_mucp_proto_network_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Probe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Probe) ProtoMessage() {}
+
+func (x *Probe) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_micro_go_micro_network_mucp_proto_network_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Probe.ProtoReflect.Descriptor instead.
+func (*Probe) Descriptor() ([]byte, []int) {
+	return file_github_com_micro_go_micro_network_mucp_proto_network_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Probe) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *Probe) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Probe) GetReply() bool {
+	if x != nil {
+		return x.Reply
+	}
+	return false
+}
+
+func (x *Probe) GetPeerTimestamp() int64 {
+	if x != nil {
+		return x.PeerTimestamp
+	}
+	return 0
+}
+
 var File_github_com_micro_go_micro_network_mucp_proto_network_proto protoreflect.FileDescriptor
 
 var file_github_com_micro_go_micro_network_mucp_proto_network_proto_rawDesc = []byte{
@@ -854,7 +939,7 @@ var file_github_com_micro_go_micro_network_mucp_proto_network_proto_rawDesc = []
 	0x74, 0x75, 0x73, 0x12, 0x32, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x2e, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2e, 0x6e, 0x65,
 	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x6d, 0x75, 0x63, 0x70, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x85, 0x02, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x85, 0x03, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65,
 	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
 	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65,
@@ -867,10 +952,18 @@ var file_github_com_micro_go_micro_network_mucp_proto_network_proto_rawDesc = []
 	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x6f,
 	0x2e, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x6d,
 	0x75, 0x63, 0x70, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x75, 0x73, 0x12, 0x42, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x67, 0x6f, 0x2e, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2e, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x6d, 0x75, 0x63, 0x70, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x45, 0x6e,
 	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
 	0x3a, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x2f, 0x0a, 0x04, 0x6e, 0x6f,
 	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x2e, 0x6d, 0x69,
 	0x63, 0x72, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x6d, 0x75, 0x63, 0x70,
@@ -892,13 +985,23 @@ var file_github_com_micro_go_micro_network_mucp_proto_network_proto_rawDesc = []
 	0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x2e,
 	0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x6d, 0x75,
 	0x63, 0x70, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73,
-	0x2a, 0x32, 0x0a, 0x0a, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12,
-	0x0a, 0x0e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
-	0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x10, 0x01, 0x2a, 0x2f, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x10, 0x00, 0x12, 0x0a, 0x0a,
-	0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x10, 0x02, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x93, 0x01, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x2e, 0x6d, 0x69,
+	0x63, 0x72, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x6d, 0x75, 0x63, 0x70,
+	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x70,
+	0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2a, 0x32, 0x0a, 0x0a, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x64, 0x76, 0x65,
+	0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x10, 0x01, 0x2a, 0x2f, 0x0a, 0x09, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x10, 0x02, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -914,7 +1017,7 @@ func file_github_com_micro_go_micro_network_mucp_proto_network_proto_rawDescGZIP
 }
 
 var file_github_com_micro_go_micro_network_mucp_proto_network_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_github_com_micro_go_micro_network_mucp_proto_network_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_github_com_micro_go_micro_network_mucp_proto_network_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_github_com_micro_go_micro_network_mucp_proto_network_proto_goTypes = []interface{}{
 	(AdvertType)(0), // 0: go.micro.network.mucp.AdvertType
 	(EventType)(0),  // 1: go.micro.network.mucp.EventType
@@ -928,29 +1031,33 @@ var file_github_com_micro_go_micro_network_mucp_proto_network_proto_goTypes = []
 	(*Close)(nil),   // 9: go.micro.network.mucp.Close
 	(*Peer)(nil),    // 10: go.micro.network.mucp.Peer
 	(*Sync)(nil),    // 11: go.micro.network.mucp.Sync
-	nil,             // 12: go.micro.network.mucp.Route.MetadataEntry
-	nil,             // 13: go.micro.network.mucp.Node.MetadataEntry
+	(*Probe)(nil),   // 12: go.micro.network.mucp.Probe
+	nil,             // 13: go.micro.network.mucp.Route.MetadataEntry
+	nil,             // 14: go.micro.network.mucp.Node.MetadataEntry
+	nil,             // 15: go.micro.network.mucp.Node.LatencyEntry
 }
 var file_github_com_micro_go_micro_network_mucp_proto_network_proto_depIdxs = []int32{
 	0,  // 0: go.micro.network.mucp.Advert.type:type_name -> go.micro.network.mucp.AdvertType
 	3,  // 1: go.micro.network.mucp.Advert.events:type_name -> go.micro.network.mucp.Event
 	1,  // 2: go.micro.network.mucp.Event.type:type_name -> go.micro.network.mucp.EventType
 	4,  // 3: go.micro.network.mucp.Event.route:type_name -> go.micro.network.mucp.Route
-	12, // 4: go.micro.network.mucp.Route.metadata:type_name -> go.micro.network.mucp.Route.MetadataEntry
+	13, // 4: go.micro.network.mucp.Route.metadata:type_name -> go.micro.network.mucp.Route.MetadataEntry
 	5,  // 5: go.micro.network.mucp.Status.error:type_name -> go.micro.network.mucp.Error
-	13, // 6: go.micro.network.mucp.Node.metadata:type_name -> go.micro.network.mucp.Node.MetadataEntry
+	14, // 6: go.micro.network.mucp.Node.metadata:type_name -> go.micro.network.mucp.Node.MetadataEntry
 	6,  // 7: go.micro.network.mucp.Node.status:type_name -> go.micro.network.mucp.Status
-	7,  // 8: go.micro.network.mucp.Connect.node:type_name -> go.micro.network.mucp.Node
-	7,  // 9: go.micro.network.mucp.Close.node:type_name -> go.micro.network.mucp.Node
-	7,  // 10: go.micro.network.mucp.Peer.node:type_name -> go.micro.network.mucp.Node
-	10, // 11: go.micro.network.mucp.Peer.peers:type_name -> go.micro.network.mucp.Peer
-	10, // 12: go.micro.network.mucp.Sync.peer:type_name -> go.micro.network.mucp.Peer
-	4,  // 13: go.micro.network.mucp.Sync.routes:type_name -> go.micro.network.mucp.Route
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	15, // 8: go.micro.network.mucp.Node.latency:type_name -> go.micro.network.mucp.Node.LatencyEntry
+	7,  // 9: go.micro.network.mucp.Connect.node:type_name -> go.micro.network.mucp.Node
+	7,  // 10: go.micro.network.mucp.Close.node:type_name -> go.micro.network.mucp.Node
+	7,  // 11: go.micro.network.mucp.Peer.node:type_name -> go.micro.network.mucp.Node
+	10, // 12: go.micro.network.mucp.Peer.peers:type_name -> go.micro.network.mucp.Peer
+	10, // 13: go.micro.network.mucp.Sync.peer:type_name -> go.micro.network.mucp.Peer
+	4,  // 14: go.micro.network.mucp.Sync.routes:type_name -> go.micro.network.mucp.Route
+	7,  // 15: go.micro.network.mucp.Probe.node:type_name -> go.micro.network.mucp.Node
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_github_com_micro_go_micro_network_mucp_proto_network_proto_init() }
@@ -1079,6 +1186,18 @@ func file_github_com_micro_go_micro_network_mucp_proto_network_proto_init() {
 				return nil
 			}
 		}
+		file_github_com_micro_go_micro_network_mucp_proto_network_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Probe); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1086,7 +1205,7 @@ func file_github_com_micro_go_micro_network_mucp_proto_network_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_micro_go_micro_network_mucp_proto_network_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   12,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -16,6 +16,8 @@
 package network
 
 import (
+	"time"
+
 	"github.com/micro/micro/v3/service/client"
 	"github.com/micro/micro/v3/service/server"
 )
@@ -51,6 +53,8 @@ type Node interface {
 	Network() Network
 	// Status returns node status
 	Status() Status
+	// Latency returns the round trip time to the node peers keyed by peer id
+	Latency() map[string]time.Duration
 }
 
 // Network is micro network
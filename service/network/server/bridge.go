@@ -0,0 +1,144 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/network"
+	"github.com/micro/micro/v3/service/network/mucp"
+	"github.com/micro/micro/v3/service/router"
+)
+
+// splitNodes splits the nodes by the network they're in, the nodes of the networks after the first
+// are qualified by the name of the network e.g. staging@10.0.0.1:8085
+func splitNodes(nodes []string, networks []string) (map[string][]string, error) {
+	split := make(map[string][]string, len(networks))
+	for _, node := range nodes {
+		name := networks[0]
+		if i := strings.Index(node, "@"); i >= 0 {
+			name, node = node[:i], node[i+1:]
+		}
+		if !contains(networks, name) {
+			return nil, fmt.Errorf("Node %s is in network %s which isn't joined", node, name)
+		}
+		split[name] = append(split[name], node)
+	}
+	return split, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// offsetPort returns the address with its port offset, the networks after the first listen on
+// the ports following the peer address
+func offsetPort(addr string, offset int) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(p+offset)), nil
+}
+
+// bridgedRoute returns the route learned from the peers of a network as a route of this node in
+// another, so it's advertised there with this node as the gateway and the calls are forwarded
+// over the link to the network it was learned from
+func bridgedRoute(r router.Route, network, id string) router.Route {
+	r.Router = id
+	r.Link = mucp.BridgeLink + network
+	r.Metadata = nil
+	return r
+}
+
+// isBridged returns true if the route is learned from the peers of the network, the local routes
+// are in every network joined and the bridged routes aren't bridged back
+func isBridged(r router.Route, id string) bool {
+	return r.Link == mucp.DefaultLink && r.Router != id
+}
+
+// bridge federates the routes learned from the peers of a network into the table of another
+// network until done is closed
+func bridge(from router.Router, network string, to router.Table, id string, done chan bool) error {
+	w, err := from.Watch()
+	if err != nil {
+		return err
+	}
+
+	routes, err := from.Table().Read()
+	if err != nil && err != router.ErrRouteNotFound {
+		w.Stop()
+		return err
+	}
+	for _, r := range routes {
+		if isBridged(r, id) {
+			to.Update(bridgedRoute(r, network, id))
+		}
+	}
+
+	go func() {
+		<-done
+		w.Stop()
+	}()
+
+	go func() {
+		for {
+			event, err := w.Next()
+			if err != nil {
+				if err != router.ErrWatcherStopped {
+					log.Errorf("Error bridging the routes of network %s: %v", network, err)
+				}
+				return
+			}
+			if !isBridged(event.Route, id) {
+				continue
+			}
+
+			// the events are sent asynchronously so they may be out of order, the route is
+			// bridged as it is in the table now rather than as it was in the event
+			route := bridgedRoute(event.Route, network, id)
+			if hasRoute(from.Table(), event.Route) {
+				err = to.Update(route)
+			} else {
+				err = to.Delete(route)
+			}
+			if err != nil && err != router.ErrRouteNotFound {
+				log.Errorf("Error bridging the route of %s from network %s: %v", route.Service, network, err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// hasRoute returns true if the route is in the table
+func hasRoute(t router.Table, r router.Route) bool {
+	routes, err := t.Read(router.ReadService(r.Service))
+	if err != nil {
+		return false
+	}
+	for _, route := range routes {
+		if route.Hash() == r.Hash() {
+			return true
+		}
+	}
+	return false
+}
+
+// joinedNetwork is a network joined after the first
+type joinedNetwork struct {
+	name    string
+	router  router.Router
+	network network.Network
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/network/mucp"
+	"github.com/micro/micro/v3/service/registry/noop"
+	"github.com/micro/micro/v3/service/router"
+	"github.com/micro/micro/v3/service/router/registry"
+)
+
+func TestSplitNodes(t *testing.T) {
+	nodes, err := splitNodes([]string{"10.0.0.1:8085", "staging@10.0.1.1:8085", "local@10.0.0.2:8085"}, []string{"local", "staging"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes["local"]) != 2 || len(nodes["staging"]) != 1 || nodes["staging"][0] != "10.0.1.1:8085" {
+		t.Fatalf("Expected the nodes split by network, got %v", nodes)
+	}
+	if _, err := splitNodes([]string{"prod@10.0.2.1:8085"}, []string{"local"}); err == nil {
+		t.Fatal("Expected an error for a node of a network not joined")
+	}
+
+	addr, err := offsetPort(":8085", 2)
+	if err != nil || addr != ":8087" {
+		t.Fatalf("Expected :8087, got %v %v", addr, err)
+	}
+}
+
+func TestBridge(t *testing.T) {
+	from := registry.NewRouter(router.Registry(noop.NewRegistry()))
+	to := registry.NewRouter(router.Registry(noop.NewRegistry()))
+
+	remote := router.Route{Service: "foo", Address: "1234", Gateway: "10.0.1.1:8085", Network: "micro", Router: "peer", Link: mucp.DefaultLink}
+	local := router.Route{Service: "bar", Address: "10.0.0.1:9000", Network: "micro", Router: "node", Link: router.DefaultLink}
+	if err := from.Table().Create(remote); err != nil {
+		t.Fatal(err)
+	}
+	if err := from.Table().Create(local); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan bool)
+	defer close(done)
+	if err := bridge(from, "staging", to.Table(), "node", done); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the routes learned from the peers are bridged
+	routes, err := to.Table().Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 1 || routes[0].Service != "foo" || routes[0].Router != "node" || routes[0].Link != mucp.BridgeLink+"staging" {
+		t.Fatalf("Expected the remote route to be bridged, got %+v", routes)
+	}
+
+	// the routes deleted are withdrawn
+	if err := from.Table().Delete(remote); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if routes, _ := to.Table().Read(); len(routes) == 0 {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatal("Expected the bridged route to be deleted")
+}
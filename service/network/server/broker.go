@@ -0,0 +1,214 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/internal/network/tunnel"
+	"github.com/micro/micro/v3/service/broker"
+	log "github.com/micro/micro/v3/service/logger"
+)
+
+const (
+	// BrokerChannel is the channel of the tunnel the broker messages are relayed over
+	BrokerChannel = "broker"
+	// relayHeader is set on the messages the relay publishes so they aren't relayed again
+	relayHeader = "Micro-Broker-Relay"
+)
+
+var (
+	// relaySeenTTL is how long the ids of the messages relayed are kept to drop the copies
+	relaySeenTTL = time.Minute
+)
+
+// relay relays the messages published to the topics between the brokers of the network nodes, so
+// the subscribers in one network receive the messages published in the others. Each node
+// publishes the messages relayed to it and forwards them to the nodes it's connected to, the
+// messages it has seen before are dropped so they don't loop.
+type relay struct {
+	broker  broker.Broker
+	topics  map[string]bool
+	tunnels []tunnel.Tunnel
+
+	sync.Mutex
+	sessions  []tunnel.Session
+	listeners []tunnel.Listener
+	subs      []broker.Subscriber
+	// seen are the ids of the messages relayed and when they were first seen
+	seen   map[string]time.Time
+	pruned time.Time
+}
+
+func newRelay(b broker.Broker, topics []string, tunnels ...tunnel.Tunnel) *relay {
+	r := &relay{
+		broker:  b,
+		topics:  make(map[string]bool),
+		tunnels: tunnels,
+		seen:    make(map[string]time.Time),
+		pruned:  time.Now(),
+	}
+	for _, t := range topics {
+		r.topics[t] = true
+	}
+	return r
+}
+
+// start listens for the messages relayed by the other nodes and subscribes to the topics
+func (r *relay) start() error {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, t := range r.tunnels {
+		l, err := t.Listen(BrokerChannel, tunnel.ListenMode(tunnel.Multicast))
+		if err != nil {
+			return err
+		}
+		r.listeners = append(r.listeners, l)
+		go r.accept(l)
+
+		s, err := t.Dial(BrokerChannel, tunnel.DialMode(tunnel.Multicast))
+		if err != nil {
+			return err
+		}
+		r.sessions = append(r.sessions, s)
+	}
+
+	for topic := range r.topics {
+		sub, err := r.broker.Subscribe(topic, r.handler(topic))
+		if err != nil {
+			return err
+		}
+		r.subs = append(r.subs, sub)
+	}
+	return nil
+}
+
+// stop relaying the messages
+func (r *relay) stop() {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, s := range r.subs {
+		s.Unsubscribe()
+	}
+	for _, l := range r.listeners {
+		l.Close()
+	}
+	for _, s := range r.sessions {
+		s.Close()
+	}
+	r.subs, r.listeners, r.sessions = nil, nil, nil
+}
+
+// handler relays the messages published to the local broker
+func (r *relay) handler(topic string) broker.Handler {
+	return func(m *broker.Message) error {
+		// the message was relayed from another node
+		if _, ok := m.Header[relayHeader]; ok {
+			return nil
+		}
+
+		b, err := json.Marshal(m)
+		if err != nil {
+			log.Debugf("Network broker relay failed to encode message: %v", err)
+			return nil
+		}
+		id := uuid.New().String()
+		r.see(id)
+		r.send(&transport.Message{
+			Header: map[string]string{
+				"Micro-Topic": topic,
+				relayHeader:   id,
+			},
+			Body: b,
+		})
+		return nil
+	}
+}
+
+// accept the sessions of the other nodes
+func (r *relay) accept(l tunnel.Listener) {
+	for {
+		s, err := l.Accept()
+		if err != nil {
+			// the listener is closed
+			return
+		}
+		go r.recv(s)
+	}
+}
+
+// recv publishes the messages relayed by the other nodes and forwards them on
+func (r *relay) recv(s tunnel.Session) {
+	for {
+		m := new(transport.Message)
+		if err := s.Recv(m); err != nil {
+			switch err {
+			case io.EOF, tunnel.ErrReadTimeout:
+				s.Close()
+				return
+			}
+			continue
+		}
+
+		topic, id := m.Header["Micro-Topic"], m.Header[relayHeader]
+		if !r.topics[topic] || len(id) == 0 || !r.see(id) {
+			continue
+		}
+
+		var msg broker.Message
+		if err := json.Unmarshal(m.Body, &msg); err != nil {
+			log.Debugf("Network broker relay failed to decode message: %v", err)
+			continue
+		}
+		if msg.Header == nil {
+			msg.Header = make(map[string]string)
+		}
+		msg.Header[relayHeader] = id
+		if err := r.broker.Publish(topic, &msg); err != nil {
+			log.Debugf("Network broker relay failed to publish to %s: %v", topic, err)
+		}
+
+		// the nodes connected to this one but not the sender get it from this node
+		r.send(m)
+	}
+}
+
+// send a message to the nodes connected to this one
+func (r *relay) send(m *transport.Message) {
+	r.Lock()
+	sessions := r.sessions
+	r.Unlock()
+
+	for _, s := range sessions {
+		if err := s.Send(m); err != nil {
+			log.Debugf("Network broker relay failed to send to %s: %v", s.Channel(), err)
+		}
+	}
+}
+
+// see records the id of a message, returning false if it was seen before
+func (r *relay) see(id string) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.pruned) > relaySeenTTL {
+		for k, t := range r.seen {
+			if now.Sub(t) > relaySeenTTL {
+				delete(r.seen, k)
+			}
+		}
+		r.pruned = now
+	}
+
+	if _, ok := r.seen[id]; ok {
+		return false
+	}
+	r.seen[id] = now
+	return true
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/internal/network/tunnel"
+	tmucp "github.com/micro/micro/v3/internal/network/tunnel/mucp"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/broker/memory"
+)
+
+// received records the messages a subscriber received
+type received struct {
+	sync.Mutex
+	msgs []*broker.Message
+}
+
+func (r *received) handler(m *broker.Message) error {
+	r.Lock()
+	defer r.Unlock()
+	r.msgs = append(r.msgs, m)
+	return nil
+}
+
+func (r *received) len() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.msgs)
+}
+
+func TestRelay(t *testing.T) {
+	tunB := tmucp.NewTunnel(tunnel.Address("127.0.0.1:0"))
+	if err := tunB.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunB.Close()
+	tunA := tmucp.NewTunnel(tunnel.Address("127.0.0.1:0"), tunnel.Nodes(tunB.Address()))
+	if err := tunA.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunA.Close()
+
+	brokerA, brokerB := memory.NewBroker(), memory.NewBroker()
+	for _, b := range []broker.Broker{brokerA, brokerB} {
+		if err := b.Connect(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	relayA := newRelay(brokerA, []string{"orders"}, tunA)
+	relayB := newRelay(brokerB, []string{"orders"}, tunB)
+	for _, r := range []*relay{relayA, relayB} {
+		if err := r.start(); err != nil {
+			t.Fatal(err)
+		}
+		defer r.stop()
+	}
+
+	var recvA, recvB, other received
+	brokerA.Subscribe("orders", recvA.handler)
+	brokerB.Subscribe("orders", recvB.handler)
+	brokerB.Subscribe("other", other.handler)
+
+	// wait for the nodes to announce their channels
+	time.Sleep(time.Second)
+
+	msg := &broker.Message{Header: map[string]string{"id": "1"}, Body: []byte("created")}
+	if err := brokerA.Publish("orders", msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := brokerA.Publish("other", msg); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100 && recvB.len() == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if recvB.len() != 1 {
+		t.Fatalf("Expected the message to be relayed to the other node, got %d messages", recvB.len())
+	}
+	m := recvB.msgs[0]
+	if m.Header["id"] != "1" || string(m.Body) != "created" {
+		t.Fatalf("Unexpected message %v %s", m.Header, m.Body)
+	}
+
+	// the message isn't relayed back to the node it was published on, nor the topics not relayed
+	time.Sleep(100 * time.Millisecond)
+	if recvA.len() != 1 || other.len() != 0 {
+		t.Fatalf("Expected the message to be received once and the other topic not to be relayed, got %d and %d", recvA.len(), other.len())
+	}
+}
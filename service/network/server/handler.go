@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"time"
 
 	authns "github.com/micro/micro/v3/internal/auth/namespace"
 	"github.com/micro/micro/v3/internal/namespace"
@@ -18,6 +19,11 @@ import (
 // Network implements network handler
 type Network struct {
 	Network network.Network
+	// Tracker tracks the partitions of the network
+	Tracker *util.Partitions
+	// RequirePeers marks the node unhealthy while it has no peers, set when it has nodes to
+	// connect to
+	RequirePeers bool
 }
 
 func flatten(n network.Node, visited map[string]bool) []network.Node {
@@ -96,6 +102,38 @@ func (n *Network) Connect(ctx context.Context, req *pb.ConnectRequest, resp *pb.
 	return nil
 }
 
+// Disconnect removes the nodes from the nodes the network connects to, closing the links to them
+func (n *Network) Disconnect(ctx context.Context, req *pb.DisconnectRequest, resp *pb.DisconnectResponse) error {
+	if len(req.Nodes) == 0 {
+		return nil
+	}
+
+	remove := make(map[string]bool)
+	for _, node := range req.Nodes {
+		remove[node.Address] = true
+	}
+
+	//nolint:prealloc
+	var nodes []string
+	for _, node := range n.Network.Options().Nodes {
+		if !remove[node] {
+			nodes = append(nodes, node)
+		}
+	}
+
+	log.Infof("Network.Disconnect setting peers: %v", nodes)
+
+	// reinitialise the peers
+	n.Network.Init(
+		network.Nodes(nodes...),
+	)
+
+	// reconnect to reinitialise the tunnel nodes
+	n.Network.Connect()
+
+	return nil
+}
+
 // Nodes returns the list of nodes
 func (n *Network) Nodes(ctx context.Context, req *pb.NodesRequest, resp *pb.NodesResponse) error {
 	// root node
@@ -138,6 +176,23 @@ func (n *Network) Graph(ctx context.Context, req *pb.GraphRequest, resp *pb.Grap
 	// set the root node
 	resp.Root = peers
 
+	// the nodes and links of the whole network, with the latency of the links
+	graph := util.NewGraph(n.Network)
+	for _, id := range graph.Ids() {
+		node := graph.Nodes[id]
+		resp.Nodes = append(resp.Nodes, &pb.Node{
+			Id:      node.Id(),
+			Address: node.Address(),
+		})
+	}
+	for _, e := range graph.Edges() {
+		resp.Edges = append(resp.Edges, &pb.Edge{
+			From:    e.From,
+			To:      e.To,
+			Latency: e.Latency.Nanoseconds(),
+		})
+	}
+
 	return nil
 }
 
@@ -248,3 +303,162 @@ func (n *Network) Services(ctx context.Context, req *pb.ServicesRequest, resp *p
 
 	return nil
 }
+
+// Rotate forces the rotation of the tunnel session keys. The rotation is passed on over the links
+// so the nodes of the network rotate to the same keys.
+func (n *Network) Rotate(ctx context.Context, req *pb.RotateRequest, resp *pb.RotateResponse) error {
+	// authorize the request. only accounts issued by micro (root accounts) can access this endpoint
+	if err := authns.Authorize(ctx, namespace.DefaultNamespace); err == authns.ErrForbidden {
+		return errors.Forbidden("network.Network.Rotate", err.Error())
+	} else if err == authns.ErrUnauthorized {
+		return errors.Unauthorized("network.Network.Rotate", err.Error())
+	} else if err != nil {
+		return errors.InternalServerError("network.Network.Rotate", err.Error())
+	}
+
+	tun := n.Network.Options().Tunnel
+	if tun == nil {
+		return errors.InternalServerError("network.Network.Rotate", "network has no tunnel")
+	}
+
+	resp.Generation = tun.Rotate()
+	log.Infof("Network.Rotate rotated the tunnel keys to generation %d", resp.Generation)
+
+	return nil
+}
+
+// Map returns the nodes of the network with the round trip time to their peers
+func (n *Network) Map(ctx context.Context, req *pb.MapRequest, resp *pb.MapResponse) error {
+	// authorize the request. only accounts issued by micro (root accounts) can access this endpoint
+	if err := authns.Authorize(ctx, namespace.DefaultNamespace); err == authns.ErrForbidden {
+		return errors.Forbidden("network.Network.Map", err.Error())
+	} else if err == authns.ErrUnauthorized {
+		return errors.Unauthorized("network.Network.Map", err.Error())
+	} else if err != nil {
+		return errors.InternalServerError("network.Network.Map", err.Error())
+	}
+
+	graph := util.NewGraph(n.Network)
+	for _, id := range graph.Ids() {
+		node := graph.Nodes[id]
+		resp.Nodes = append(resp.Nodes, &pb.Node{
+			Id:      node.Id(),
+			Address: node.Address(),
+			Latency: util.LatencyToProto(node.Latency()),
+		})
+	}
+
+	return nil
+}
+
+// Trace returns the path a request to a service takes through the network. The request is sent
+// to the gateway of the route with the lowest metric, as the network proxy does, beyond which it's
+// assumed to take the lowest latency path to the node the route originates from.
+func (n *Network) Trace(ctx context.Context, req *pb.TraceRequest, resp *pb.TraceResponse) error {
+	// authorize the request. only accounts issued by micro (root accounts) can access this endpoint
+	if err := authns.Authorize(ctx, namespace.DefaultNamespace); err == authns.ErrForbidden {
+		return errors.Forbidden("network.Network.Trace", err.Error())
+	} else if err == authns.ErrUnauthorized {
+		return errors.Unauthorized("network.Network.Trace", err.Error())
+	} else if err != nil {
+		return errors.InternalServerError("network.Network.Trace", err.Error())
+	}
+
+	if len(req.Service) == 0 {
+		return errors.BadRequest("network.Network.Trace", "Missing service")
+	}
+
+	routes, err := n.Network.Options().Router.Lookup(req.Service)
+	if err == router.ErrRouteNotFound || (err == nil && len(routes) == 0) {
+		return errors.NotFound("network.Network.Trace", "No route to %s", req.Service)
+	} else if err != nil {
+		return errors.InternalServerError("network.Network.Trace", "failed to lookup routes: %s", err)
+	}
+
+	route := routes[0]
+	for _, r := range routes[1:] {
+		if r.Metric < route.Metric {
+			route = r
+		}
+	}
+	resp.Address = route.Address
+	resp.Hops = traceHops(util.NewGraph(n.Network), n.Network.Id(), route)
+
+	return nil
+}
+
+// Partitions returns the components the network has split into, starting with the one this
+// node is in. The others are the nodes last seen in the network which can no longer be reached.
+func (n *Network) Partitions(ctx context.Context, req *pb.PartitionsRequest, resp *pb.PartitionsResponse) error {
+	// authorize the request. only accounts issued by micro (root accounts) can access this endpoint
+	if err := authns.Authorize(ctx, namespace.DefaultNamespace); err == authns.ErrForbidden {
+		return errors.Forbidden("network.Network.Partitions", err.Error())
+	} else if err == authns.ErrUnauthorized {
+		return errors.Unauthorized("network.Network.Partitions", err.Error())
+	} else if err != nil {
+		return errors.InternalServerError("network.Network.Partitions", err.Error())
+	}
+
+	// without a tracker only the reachable nodes are known
+	partitions := n.Tracker
+	if partitions == nil {
+		partitions = util.NewPartitions()
+		partitions.Update(n.Network, time.Now())
+	}
+
+	for i, component := range partitions.Components() {
+		partition := &pb.Partition{Reachable: i == 0}
+		for _, id := range component {
+			partition.Nodes = append(partition.Nodes, &pb.Node{
+				Id:      id,
+				Address: partitions.Address(id),
+			})
+		}
+		resp.Partitions = append(resp.Partitions, partition)
+	}
+
+	return nil
+}
+
+// traceHops returns the nodes a request over the route passes through
+func traceHops(graph *util.Graph, id string, route router.Route) []*pb.Hop {
+	hop := func(prev, id string) *pb.Hop {
+		h := &pb.Hop{Node: &pb.Node{Id: id}}
+		if node, ok := graph.Nodes[id]; ok {
+			h.Node.Address = node.Address()
+		}
+		if d, ok := graph.Latency(prev, id); ok && len(prev) > 0 {
+			h.Latency = int64(d)
+		}
+		return h
+	}
+
+	hops := []*pb.Hop{hop("", id)}
+	// the service is reached over the local network
+	if route.Link == "local" || route.Router == id {
+		return hops
+	}
+
+	// find the gateway the route was learnt from
+	var gateway string
+	for nid, node := range graph.Nodes {
+		if nid != id && node.Address() == route.Gateway {
+			gateway = nid
+			break
+		}
+	}
+	if len(gateway) == 0 {
+		return append(hops, &pb.Hop{Node: &pb.Node{Address: route.Gateway}})
+	}
+
+	path := graph.Path(gateway, route.Router)
+	if len(path) == 0 {
+		path = []string{gateway}
+	}
+	prev := id
+	for _, next := range path {
+		hops = append(hops, hop(prev, next))
+		prev = next
+	}
+	return hops
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	jsonc "github.com/micro/micro/v3/internal/codec/json"
+	pb "github.com/micro/micro/v3/proto/network"
+	"github.com/micro/micro/v3/service/network/mucp"
+	"github.com/micro/micro/v3/service/router"
+)
+
+// health returns the health of the node, it's unhealthy if the router isn't running or if it has
+// no peers when it's expected to connect to some
+func (n *Network) health() *pb.HealthResponse {
+	rsp := &pb.HealthResponse{
+		Status: "ok",
+		Router: router.Running.String(),
+		Peers:  int64(len(n.Network.Peers())),
+	}
+
+	// the routers without a status are always running
+	if r, ok := n.Network.Options().Router.(interface{ Status() router.StatusCode }); ok {
+		if status := r.Status(); status != router.Running {
+			rsp.Router = status.String()
+			rsp.Status = "router " + rsp.Router
+		}
+	}
+	if rsp.Status == "ok" && rsp.Peers == 0 && n.RequirePeers {
+		rsp.Status = "no peers"
+	}
+
+	if s, ok := n.Network.(interface{ Stats() mucp.Stats }); ok {
+		st := s.Stats()
+		if !st.LastAdvertSent.IsZero() {
+			rsp.LastAdvertSent = st.LastAdvertSent.Unix()
+		}
+		if !st.LastAdvertReceived.IsZero() {
+			rsp.LastAdvertReceived = st.LastAdvertReceived.Unix()
+		}
+	}
+
+	// skewed clocks are reported but don't make the node unhealthy, restarting it won't fix them
+	if s, ok := n.Network.(interface {
+		ClockSkew() map[string]time.Duration
+	}); ok {
+		for _, d := range s.ClockSkew() {
+			if d < 0 {
+				d = -d
+			}
+			if ms := d.Milliseconds(); ms > rsp.MaxClockSkew {
+				rsp.MaxClockSkew = ms
+			}
+		}
+	}
+
+	return rsp
+}
+
+// Health returns the status of the router, the peers and the adverts of the node. It isn't
+// authorized so it can be used for health checks.
+func (n *Network) Health(ctx context.Context, req *pb.HealthRequest, resp *pb.HealthResponse) error {
+	rsp := n.health()
+	resp.Status = rsp.Status
+	resp.Router = rsp.Router
+	resp.Peers = rsp.Peers
+	resp.LastAdvertSent = rsp.LastAdvertSent
+	resp.LastAdvertReceived = rsp.LastAdvertReceived
+	resp.MaxClockSkew = rsp.MaxClockSkew
+	return nil
+}
+
+// Healthz serves the health of the node at /healthz for the liveness and readiness probes of
+// kubernetes, with a 503 if the node is unhealthy
+type Healthz struct {
+	Network *Network
+}
+
+func (h *Healthz) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/healthz" {
+		http.NotFound(w, r)
+		return
+	}
+
+	rsp := h.Network.health()
+	b, err := jsonc.Marshaler{}.Marshal(rsp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if rsp.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(b)
+}
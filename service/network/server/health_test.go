@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/micro/micro/v3/proto/network"
+	"github.com/micro/micro/v3/service/network"
+	"github.com/micro/micro/v3/service/router"
+)
+
+type statusRouter struct {
+	testRouter
+	status router.StatusCode
+}
+
+func (s *statusRouter) Status() router.StatusCode { return s.status }
+
+func TestHealth(t *testing.T) {
+	rtr := &statusRouter{status: router.Running}
+	n := &Network{Network: &testNetwork{options: network.Options{Router: rtr}}}
+
+	rsp := new(pb.HealthResponse)
+	if err := n.Health(context.TODO(), &pb.HealthRequest{}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Status != "ok" || rsp.Router != "running" || rsp.Peers != 0 {
+		t.Fatalf("Expected the node to be healthy, got %+v", rsp)
+	}
+
+	h := &Healthz{Network: n}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"status":"ok"`) {
+		t.Fatalf("Expected a 200 for a healthy node, got %v: %v", w.Code, w.Body)
+	}
+
+	// a node which should connect to peers isn't ready until it has some
+	n.RequirePeers = true
+	if rsp := n.health(); rsp.Status != "no peers" {
+		t.Fatalf("Expected the node without peers to be unhealthy, got %v", rsp.Status)
+	}
+
+	n.RequirePeers = false
+	rtr.status = router.Stopped
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), `"router":"stopped"`) {
+		t.Fatalf("Expected a 503 for a stopped router, got %v: %v", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/other", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected a 404 for other paths, got %v", w.Code)
+	}
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	inauth "github.com/micro/micro/v3/internal/auth"
+	authns "github.com/micro/micro/v3/internal/auth/namespace"
+	jsonc "github.com/micro/micro/v3/internal/codec/json"
+	"github.com/micro/micro/v3/internal/namespace"
+	pb "github.com/micro/micro/v3/proto/network"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+)
+
+// HTTP serves the network operations as a REST+JSON API for automation which doesn't speak RPC.
+// Requests are authorized with a bearer token against the rules of the equivalent RPC endpoint.
+//
+//	GET    /v1/nodes               Network.Nodes
+//	GET    /v1/graph?depth=3       Network.Graph
+//	GET    /v1/routes?service=foo  Network.Routes
+//	GET    /v1/services            Network.Services
+//	GET    /v1/partitions          Network.Partitions
+//	POST   /v1/peers               Network.Connect with a ConnectRequest body
+//	DELETE /v1/peers/{address}     Network.Disconnect
+type HTTP struct {
+	// Name of the network service the rules are verified for
+	Name    string
+	Network *Network
+}
+
+// route is an operation of the api
+type route struct {
+	method   string
+	endpoint string
+	// call decodes the request, calls the handler and returns the response
+	call func(ctx context.Context, r *http.Request, path string) (interface{}, error)
+}
+
+func (h *HTTP) routes() map[string]route {
+	return map[string]route{
+		"nodes": {"GET", "Network.Nodes", func(ctx context.Context, r *http.Request, _ string) (interface{}, error) {
+			rsp := new(pb.NodesResponse)
+			return rsp, h.Network.Nodes(ctx, &pb.NodesRequest{}, rsp)
+		}},
+		"graph": {"GET", "Network.Graph", func(ctx context.Context, r *http.Request, _ string) (interface{}, error) {
+			req := new(pb.GraphRequest)
+			if v := r.URL.Query().Get("depth"); len(v) > 0 {
+				depth, err := strconv.ParseUint(v, 10, 32)
+				if err != nil {
+					return nil, errors.BadRequest(h.Name, "Invalid depth %v", v)
+				}
+				req.Depth = uint32(depth)
+			}
+			rsp := new(pb.GraphResponse)
+			return rsp, h.Network.Graph(ctx, req, rsp)
+		}},
+		"routes": {"GET", "Network.Routes", func(ctx context.Context, r *http.Request, _ string) (interface{}, error) {
+			q := r.URL.Query()
+			req := &pb.RoutesRequest{Query: &pb.Query{
+				Service: q.Get("service"),
+				Address: q.Get("address"),
+				Gateway: q.Get("gateway"),
+				Router:  q.Get("router"),
+				Network: q.Get("network"),
+			}}
+			rsp := new(pb.RoutesResponse)
+			return rsp, h.Network.Routes(ctx, req, rsp)
+		}},
+		"services": {"GET", "Network.Services", func(ctx context.Context, r *http.Request, _ string) (interface{}, error) {
+			rsp := new(pb.ServicesResponse)
+			return rsp, h.Network.Services(ctx, &pb.ServicesRequest{}, rsp)
+		}},
+		"partitions": {"GET", "Network.Partitions", func(ctx context.Context, r *http.Request, _ string) (interface{}, error) {
+			rsp := new(pb.PartitionsResponse)
+			return rsp, h.Network.Partitions(ctx, &pb.PartitionsRequest{}, rsp)
+		}},
+		"peers": {"POST", "Network.Connect", func(ctx context.Context, r *http.Request, _ string) (interface{}, error) {
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return nil, errors.BadRequest(h.Name, "Error reading request: %v", err)
+			}
+			req := new(pb.ConnectRequest)
+			if err := (jsonc.Marshaler{}).Unmarshal(b, req); err != nil {
+				return nil, errors.BadRequest(h.Name, "Invalid request: %v", err)
+			}
+			rsp := new(pb.ConnectResponse)
+			return rsp, h.Network.Connect(ctx, req, rsp)
+		}},
+		"peers/": {"DELETE", "Network.Disconnect", func(ctx context.Context, r *http.Request, address string) (interface{}, error) {
+			if len(address) == 0 {
+				return nil, errors.BadRequest(h.Name, "Missing the address of the peer")
+			}
+			req := &pb.DisconnectRequest{Nodes: []*pb.Node{{Address: address}}}
+			rsp := new(pb.DisconnectResponse)
+			return rsp, h.Network.Disconnect(ctx, req, rsp)
+		}},
+	}
+}
+
+func (h *HTTP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if path == r.URL.Path {
+		writeError(w, errors.NotFound(h.Name, "Not found"))
+		return
+	}
+
+	// the routes with a trailing slash take the rest of the path as an argument
+	name, arg := path, ""
+	if i := strings.Index(path, "/"); i > 0 {
+		name, arg = path[:i+1], path[i+1:]
+	}
+
+	rt, ok := h.routes()[name]
+	if !ok {
+		writeError(w, errors.NotFound(h.Name, "Not found"))
+		return
+	}
+	if r.Method != rt.method {
+		w.Header().Set("Allow", rt.method)
+		writeError(w, errors.MethodNotAllowed(h.Name, "Method %v not allowed", r.Method))
+		return
+	}
+
+	ctx, err := h.authorize(r, rt.endpoint)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	rsp, err := rt.call(ctx, r, arg)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	b, err := jsonc.Marshaler{}.Marshal(rsp)
+	if err != nil {
+		writeError(w, errors.InternalServerError(h.Name, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// authorize verifies the account of the bearer token has access to the rpc endpoint
+func (h *HTTP) authorize(r *http.Request, endpoint string) (context.Context, error) {
+	var token string
+	if header := r.Header.Get("Authorization"); len(header) > 0 {
+		if !strings.HasPrefix(header, inauth.BearerScheme) {
+			return nil, errors.Unauthorized(h.Name, "invalid authorization header. expected Bearer schema")
+		}
+		token = strings.TrimPrefix(header, inauth.BearerScheme)
+	}
+
+	// noop auth returns a blank account for a blank token
+	account, _ := auth.Inspect(token)
+	ns := authns.DefaultNamespace
+	if account != nil && len(account.Issuer) > 0 {
+		ns = account.Issuer
+	}
+
+	res := &auth.Resource{Type: "service", Name: h.Name, Endpoint: endpoint}
+	err := auth.Verify(account, res, auth.VerifyNamespace(ns))
+	if err == auth.ErrForbidden && account != nil {
+		return nil, errors.Forbidden(h.Name, "Forbidden call made to %v:%v by %v", h.Name, endpoint, account.ID)
+	} else if err == auth.ErrForbidden {
+		return nil, errors.Unauthorized(h.Name, "Unauthorized call made to %v:%v", h.Name, endpoint)
+	} else if err != nil {
+		return nil, errors.InternalServerError(h.Name, "Error authorizing request: %v", err)
+	}
+
+	ctx := namespace.ContextWithNamespace(r.Context(), ns)
+	if account != nil {
+		ctx = auth.ContextWithAccount(ctx, account)
+	}
+	return ctx, nil
+}
+
+// writeError writes the error as json with the status of its code
+func writeError(w http.ResponseWriter, err error) {
+	merr := errors.FromError(err)
+	code := int(merr.Code)
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write([]byte(merr.Error()))
+}
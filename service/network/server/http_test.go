@@ -0,0 +1,116 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/auth/jwt"
+	"github.com/micro/micro/v3/service/auth/noop"
+	"github.com/micro/micro/v3/service/network"
+)
+
+// iface is embedded so the methods which aren't used don't need implementing, the field can't be
+// named Network as it's also a method of the node
+type iface = network.Network
+
+// testNetwork is a network of a single node which records the nodes it's initialised with
+type testNetwork struct {
+	iface
+	options network.Options
+}
+
+func (t *testNetwork) Id() string               { return "node-1" }
+func (t *testNetwork) Address() string          { return "10.0.0.1:8085" }
+func (t *testNetwork) Peers() []network.Node    { return nil }
+func (t *testNetwork) Options() network.Options { return t.options }
+func (t *testNetwork) Connect() error           { return nil }
+func (t *testNetwork) Init(opts ...network.Option) error {
+	for _, o := range opts {
+		o(&t.options)
+	}
+	return nil
+}
+
+func TestHTTP(t *testing.T) {
+	defer func(a auth.Auth) { auth.DefaultAuth = a }(auth.DefaultAuth)
+	auth.DefaultAuth = noop.NewAuth()
+
+	n := &testNetwork{options: network.Options{Nodes: []string{"10.0.0.2:8085"}}}
+	h := &HTTP{Name: "network", Network: &Network{Network: n}}
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := serve("GET", "/v1/nodes", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %v: %v", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), `"id":"node-1"`) {
+		t.Fatalf("Expected the node, got %v", w.Body)
+	}
+
+	if w := serve("POST", "/v1/peers", `{"nodes": [{"address": "10.0.0.3:8085"}]}`); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %v: %v", w.Code, w.Body)
+	}
+	if nodes := n.options.Nodes; len(nodes) != 2 || nodes[1] != "10.0.0.3:8085" {
+		t.Fatalf("Expected the peer to be connected, got %v", nodes)
+	}
+
+	if w := serve("DELETE", "/v1/peers/10.0.0.2:8085", ""); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %v: %v", w.Code, w.Body)
+	}
+	if nodes := n.options.Nodes; len(nodes) != 1 || nodes[0] != "10.0.0.3:8085" {
+		t.Fatalf("Expected the peer to be disconnected, got %v", nodes)
+	}
+
+	if w := serve("GET", "/v1/peers", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected 405, got %v", w.Code)
+	}
+	if w := serve("GET", "/v1/unknown", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("Expected 404, got %v", w.Code)
+	}
+	if w := serve("GET", "/v1/graph?depth=x", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %v", w.Code)
+	}
+}
+
+func TestHTTPAuth(t *testing.T) {
+	defer func(a auth.Auth) { auth.DefaultAuth = a }(auth.DefaultAuth)
+	auth.DefaultAuth = jwt.NewAuth()
+
+	// only the nodes are public
+	auth.DefaultAuth.Grant(&auth.Rule{
+		ID:       "public-nodes",
+		Scope:    auth.ScopePublic,
+		Resource: &auth.Resource{Type: "service", Name: "network", Endpoint: "Network.Nodes"},
+		Access:   auth.AccessGranted,
+	})
+
+	h := &HTTP{Name: "network", Network: &Network{Network: &testNetwork{}}}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/v1/nodes", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %v: %v", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/v1/peers", strings.NewReader(`{}`)))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401, got %v: %v", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/v1/nodes", nil)
+	r.Header.Set("Authorization", "Basic foo")
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401, got %v: %v", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/micro/micro/v3/internal/debug/stats"
+	"github.com/micro/micro/v3/internal/network/tunnel"
+	"github.com/micro/micro/v3/service/debug"
+	net "github.com/micro/micro/v3/service/network"
+	"github.com/micro/micro/v3/service/network/mucp"
+	"github.com/micro/micro/v3/service/network/util"
+	"github.com/micro/micro/v3/service/router"
+)
+
+// Metrics serves the metrics of the network node in the prometheus text format: the size of the
+// route table, the adverts exchanged with the peers, the links of the tunnel and the requests
+// served by the node.
+type Metrics struct {
+	Network net.Network
+	Router  router.Router
+	Tunnel  tunnel.Tunnel
+	Tracker *util.Partitions
+	// Stats of the requests, defaults to those of the service
+	Stats stats.Stats
+}
+
+// writeMetric writes the help, type and samples of a metric, the samples are keyed by their labels
+func writeMetric(buf *strings.Builder, name, typ, help string, samples map[string]float64) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	labels := make([]string, 0, len(samples))
+	for l := range samples {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	for _, l := range labels {
+		if len(l) > 0 {
+			fmt.Fprintf(buf, "%s{%s} %v\n", name, l, samples[l])
+		} else {
+			fmt.Fprintf(buf, "%s %v\n", name, samples[l])
+		}
+	}
+}
+
+// labelValue escapes a prometheus label value
+func labelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	buf := new(strings.Builder)
+	network := fmt.Sprintf(`network="%s"`, labelValue(m.Network.Name()))
+
+	if routes, err := m.Router.Table().Read(); err == nil {
+		writeMetric(buf, "micro_network_routes", "gauge", "Number of routes in the route table",
+			map[string]float64{network: float64(len(routes))})
+	}
+
+	writeMetric(buf, "micro_network_peers", "gauge", "Number of peers the node is connected to",
+		map[string]float64{network: float64(len(m.Network.Peers()))})
+
+	if m.Tracker != nil {
+		writeMetric(buf, "micro_network_partitions", "gauge", "Number of partitions the network is split in",
+			map[string]float64{network: float64(len(m.Tracker.Components()))})
+	}
+
+	if s, ok := m.Network.(interface{ Stats() mucp.Stats }); ok {
+		st := s.Stats()
+		writeMetric(buf, "micro_network_adverts_total", "counter", "Route adverts exchanged with the peers",
+			map[string]float64{
+				network + `,direction="sent"`:     float64(st.AdvertsSent),
+				network + `,direction="received"`: float64(st.AdvertsReceived),
+			})
+	}
+
+	// the links of the tunnel are the connections of the transport
+	links := map[string]float64{}
+	for _, l := range m.Tunnel.Links() {
+		if l.Loopback() {
+			continue
+		}
+		links[fmt.Sprintf(`%s,state="%s"`, network, labelValue(l.State()))]++
+	}
+	writeMetric(buf, "micro_network_links", "gauge", "Number of transport connections to the peers by state", links)
+
+	st := m.Stats
+	if st == nil {
+		st = debug.DefaultStats
+	}
+	if snaps, err := st.Read(); err == nil && len(snaps) > 0 {
+		writeRequests(buf, snaps[len(snaps)-1])
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, buf.String())
+}
+
+// writeRequests writes the requests, errors and latency of each endpoint served by the node
+func writeRequests(buf *strings.Builder, s *stats.Stat) {
+	endpoints := make([]string, 0, len(s.Endpoints))
+	for e := range s.Endpoints {
+		endpoints = append(endpoints, e)
+	}
+	sort.Strings(endpoints)
+
+	requests := make(map[string]float64, len(endpoints))
+	errs := make(map[string]float64, len(endpoints))
+	for _, e := range endpoints {
+		label := fmt.Sprintf(`endpoint="%s"`, labelValue(e))
+		requests[label] = float64(s.Endpoints[e].Requests)
+		errs[label] = float64(s.Endpoints[e].Errors)
+	}
+	writeMetric(buf, "micro_network_requests_total", "counter", "Requests served by the node", requests)
+	writeMetric(buf, "micro_network_request_errors_total", "counter", "Requests served by the node which failed", errs)
+
+	const name = "micro_network_request_duration_seconds"
+	fmt.Fprintf(buf, "# HELP %s Latency of the requests served by the node in seconds\n# TYPE %s histogram\n", name, name)
+	for _, e := range endpoints {
+		stat := s.Endpoints[e]
+		label := fmt.Sprintf(`endpoint="%s"`, labelValue(e))
+		for i, b := range s.LatencyBuckets {
+			if i < len(stat.Latency) {
+				fmt.Fprintf(buf, "%s_bucket{%s,le=\"%v\"} %d\n", name, label, b.Seconds(), stat.Latency[i])
+			}
+		}
+		fmt.Fprintf(buf, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, label, stat.Requests)
+		fmt.Fprintf(buf, "%s_count{%s} %d\n", name, label, stat.Requests)
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/internal/debug/stats"
+	"github.com/micro/micro/v3/internal/network/tunnel"
+	"github.com/micro/micro/v3/service/router"
+)
+
+type testRouter struct {
+	router.Router
+	table router.Table
+}
+
+func (t *testRouter) Table() router.Table { return t.table }
+
+type testTable struct {
+	router.Table
+	routes []router.Route
+}
+
+func (t *testTable) Read(...router.ReadOption) ([]router.Route, error) { return t.routes, nil }
+
+type testTunnel struct {
+	tunnel.Tunnel
+	links []tunnel.Link
+}
+
+func (t *testTunnel) Links() []tunnel.Link { return t.links }
+
+type testLink struct {
+	tunnel.Link
+	state    string
+	loopback bool
+}
+
+func (t *testLink) State() string  { return t.state }
+func (t *testLink) Loopback() bool { return t.loopback }
+
+// namedNetwork is a test network with a name
+type namedNetwork struct {
+	testNetwork
+}
+
+func (n *namedNetwork) Name() string { return "go.micro" }
+
+type testStats struct {
+	stats.Stats
+	stat *stats.Stat
+}
+
+func (t *testStats) Read() ([]*stats.Stat, error) { return []*stats.Stat{t.stat}, nil }
+
+func TestMetrics(t *testing.T) {
+	m := &Metrics{
+		Network: &namedNetwork{},
+		Router:  &testRouter{table: &testTable{routes: make([]router.Route, 3)}},
+		Tunnel: &testTunnel{links: []tunnel.Link{
+			&testLink{state: "connected"},
+			&testLink{state: "connected"},
+			&testLink{state: "connected", loopback: true},
+		}},
+		Stats: &testStats{stat: &stats.Stat{
+			Endpoints: map[string]*stats.EndpointStat{
+				"Network.Graph": {Requests: 4, Errors: 1, Latency: []uint64{3}},
+			},
+			LatencyBuckets: []time.Duration{time.Millisecond * 5},
+		}},
+	}
+
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	for _, s := range []string{
+		`micro_network_routes{network="go.micro"} 3`,
+		`micro_network_peers{network="go.micro"} 0`,
+		`micro_network_links{network="go.micro",state="connected"} 2`,
+		`micro_network_requests_total{endpoint="Network.Graph"} 4`,
+		`micro_network_request_errors_total{endpoint="Network.Graph"} 1`,
+		`micro_network_request_duration_seconds_bucket{endpoint="Network.Graph",le="0.005"} 3`,
+	} {
+		if !strings.Contains(w.Body.String(), s) {
+			t.Fatalf("Expected %v in the metrics, got %v", s, w.Body)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+
+	authns "github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/namespace"
+	"github.com/micro/micro/v3/internal/network/policy"
+	pb "github.com/micro/micro/v3/proto/network"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/proxy"
+	"github.com/micro/micro/v3/service/server"
+)
+
+// authorizeRoot ensures the caller is a root account, only they can manage the policies
+func authorizeRoot(ctx context.Context, method string) error {
+	if err := authns.Authorize(ctx, namespace.DefaultNamespace); err == authns.ErrForbidden {
+		return errors.Forbidden(method, err.Error())
+	} else if err == authns.ErrUnauthorized {
+		return errors.Unauthorized(method, err.Error())
+	} else if err != nil {
+		return errors.InternalServerError(method, err.Error())
+	}
+	return nil
+}
+
+func policyToProto(p *policy.Policy) *pb.Policy {
+	return &pb.Policy{
+		Id:       p.ID,
+		From:     p.From,
+		To:       p.To,
+		Action:   p.Action,
+		Priority: p.Priority,
+		Created:  p.Created.Unix(),
+	}
+}
+
+// AddPolicy adds a policy allowing or denying the calls from a service to another
+func (n *Network) AddPolicy(ctx context.Context, req *pb.AddPolicyRequest, resp *pb.AddPolicyResponse) error {
+	if err := authorizeRoot(ctx, "network.Network.AddPolicy"); err != nil {
+		return err
+	}
+	if req.Policy == nil {
+		return errors.BadRequest("network.Network.AddPolicy", "Missing policy")
+	}
+	if req.Policy.Action != policy.Allow && req.Policy.Action != policy.Deny {
+		return errors.BadRequest("network.Network.AddPolicy", "Invalid action %v, expected allow or deny", req.Policy.Action)
+	}
+
+	p := &policy.Policy{
+		From:     req.Policy.From,
+		To:       req.Policy.To,
+		Action:   req.Policy.Action,
+		Priority: req.Policy.Priority,
+	}
+	if err := policy.Add(p); err != nil {
+		return errors.InternalServerError("network.Network.AddPolicy", "Unable to write to store: %v", err)
+	}
+
+	log.Infof("Network policy %v added: %v from %v to %v", p.ID, p.Action, p.From, p.To)
+	resp.Policy = policyToProto(p)
+	return nil
+}
+
+// DeletePolicy deletes a policy
+func (n *Network) DeletePolicy(ctx context.Context, req *pb.DeletePolicyRequest, resp *pb.DeletePolicyResponse) error {
+	if err := authorizeRoot(ctx, "network.Network.DeletePolicy"); err != nil {
+		return err
+	}
+	if len(req.Id) == 0 {
+		return errors.BadRequest("network.Network.DeletePolicy", "Missing id")
+	}
+
+	if err := policy.Delete(req.Id); err == policy.ErrNotFound {
+		return errors.NotFound("network.Network.DeletePolicy", "Policy %v not found", req.Id)
+	} else if err != nil {
+		return errors.InternalServerError("network.Network.DeletePolicy", "Unable to delete from store: %v", err)
+	}
+
+	log.Infof("Network policy %v deleted", req.Id)
+	return nil
+}
+
+// ListPolicies returns the policies in the order they're checked
+func (n *Network) ListPolicies(ctx context.Context, req *pb.ListPoliciesRequest, resp *pb.ListPoliciesResponse) error {
+	if err := authorizeRoot(ctx, "network.Network.ListPolicies"); err != nil {
+		return err
+	}
+
+	policies, err := policy.List()
+	if err != nil {
+		return errors.InternalServerError("network.Network.ListPolicies", "Unable to read from store: %v", err)
+	}
+	for _, p := range policies {
+		resp.Policies = append(resp.Policies, policyToProto(p))
+	}
+	return nil
+}
+
+// policyProxy enforces the network policies on the calls the network forwards, the caller is the
+// service of the account the auth wrapper set in the context
+type policyProxy struct {
+	proxy.Proxy
+}
+
+func (p *policyProxy) ServeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
+	acc, _ := auth.AccountFromContext(ctx)
+	from := policy.Caller(acc)
+	ok, pol, err := policy.Check(from, req.Service())
+	if err != nil {
+		log.Errorf("Error checking the network policies: %v", err)
+	}
+	if !ok && pol == nil {
+		return errors.InternalServerError(req.Service(), "Unable to check the network policies")
+	} else if !ok {
+		return errors.Forbidden(req.Service(), "Calls from %v to %v are denied by network policy %v", from, req.Service(), pol.ID)
+	}
+	return p.Proxy.ServeRequest(ctx, req, rsp)
+}
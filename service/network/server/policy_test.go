@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/micro/v3/internal/network/policy"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/proxy"
+	"github.com/micro/micro/v3/service/server"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+type testProxy struct {
+	proxy.Proxy
+	served int
+}
+
+func (t *testProxy) ServeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
+	t.served++
+	return nil
+}
+
+type testRequest struct {
+	server.Request
+	service string
+}
+
+func (t *testRequest) Service() string { return t.service }
+
+func TestPolicyProxy(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+
+	if err := policy.Add(&policy.Policy{From: "frontend", To: "billing", Action: policy.Deny}); err != nil {
+		t.Fatal(err)
+	}
+
+	tp := new(testProxy)
+	p := &policyProxy{tp}
+
+	frontend := &auth.Account{ID: "frontend-latest", Type: "service", Name: "frontend"}
+	ctx := auth.ContextWithAccount(context.TODO(), frontend)
+	err := p.ServeRequest(ctx, &testRequest{service: "billing"}, nil)
+	if merr := errors.FromError(err); merr == nil || merr.Code != 403 {
+		t.Fatalf("Expected the denied call to be forbidden, got %v", err)
+	}
+	if err := p.ServeRequest(ctx, &testRequest{service: "checkout"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the caller is identified by its account, not the header it sets
+	ctx = metadata.NewContext(ctx, metadata.Metadata{"Micro-From-Service": "checkout"})
+	if err := p.ServeRequest(ctx, &testRequest{service: "billing"}, nil); errors.FromError(err).Code != 403 {
+		t.Fatalf("Expected the call with a spoofed header to be forbidden, got %v", err)
+	}
+
+	checkout := &auth.Account{ID: "checkout-latest", Type: "service", Name: "checkout"}
+	ctx = auth.ContextWithAccount(context.TODO(), checkout)
+	if err := p.ServeRequest(ctx, &testRequest{service: "billing"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if tp.served != 2 {
+		t.Fatalf("Expected the 2 allowed calls to be forwarded, got %v", tp.served)
+	}
+}
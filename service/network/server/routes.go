@@ -0,0 +1,186 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/router"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// RoutesDebounce is how long to wait for the writes to the routes file to stop before it's
+	// reloaded, so it isn't read half written
+	RoutesDebounce = time.Millisecond * 500
+)
+
+// staticRoute is a route of the routes file, the services outside of micro are routed to with
+// external routes whose address is resolved on lookup e.g.
+//
+//	routes:
+//	- service: legacy
+//	  address: 10.0.0.1:8080
+//	  gateway: 10.0.0.254:8080
+//	  metric: 10
+//	- service: payments
+//	  address: dns://payments.example.com:443
+type staticRoute struct {
+	Service string `yaml:"service"`
+	Address string `yaml:"address"`
+	Gateway string `yaml:"gateway"`
+	Network string `yaml:"network"`
+	Metric  int64  `yaml:"metric"`
+}
+
+// StaticRoutes seeds the routing table with the routes of a file, for the services which aren't
+// registered in the registry, and keeps the table in sync with the file as it changes
+type StaticRoutes struct {
+	// Path of the routes file
+	Path string
+	// Table the routes are set in
+	Table router.Table
+	// Network and Router the routes belong to unless set in the file
+	Network string
+	Router  string
+
+	// routes set in the table by their hash
+	routes map[uint64]router.Route
+}
+
+// load reads the routes from the file
+func (s *StaticRoutes) load() ([]router.Route, error) {
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Routes []staticRoute `yaml:"routes"`
+	}
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("Error parsing %s: %v", s.Path, err)
+	}
+
+	routes := make([]router.Route, 0, len(file.Routes))
+	for i, r := range file.Routes {
+		if len(r.Service) == 0 || len(r.Address) == 0 {
+			return nil, fmt.Errorf("Route %d of %s requires a service and an address", i+1, s.Path)
+		}
+		if scheme, _, ok := router.ParseExternal(r.Address); ok && !router.HasResolver(scheme) {
+			return nil, fmt.Errorf("Route %d of %s has an unknown resolver %s", i+1, s.Path, scheme)
+		}
+		route := router.Route{
+			Service:  r.Service,
+			Address:  r.Address,
+			Gateway:  r.Gateway,
+			Network:  r.Network,
+			Router:   s.Router,
+			Link:     router.DefaultLink,
+			Metric:   r.Metric,
+			Metadata: map[string]string{router.StaticMetadata: "true"},
+		}
+		if len(route.Network) == 0 {
+			route.Network = s.Network
+		}
+		if route.Metric <= 0 {
+			route.Metric = router.DefaultMetric
+		}
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// Apply sets the routes of the file in the table, deleting the routes which have been removed
+// from it since it was last applied. The routes are left as they are if the file is invalid.
+func (s *StaticRoutes) Apply() error {
+	routes, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	current := make(map[uint64]router.Route, len(routes))
+	for _, r := range routes {
+		current[r.Hash()] = r
+	}
+
+	// delete the routes which have been removed or have changed metric, the metric isn't part
+	// of the hash so they're created again below to advertise the change
+	for sum, r := range s.routes {
+		if c, ok := current[sum]; ok && c.Metric == r.Metric {
+			continue
+		}
+		if err := s.Table.Delete(r); err != nil && err != router.ErrRouteNotFound {
+			return err
+		}
+		delete(s.routes, sum)
+	}
+
+	if s.routes == nil {
+		s.routes = make(map[uint64]router.Route)
+	}
+	for sum, r := range current {
+		if _, ok := s.routes[sum]; ok {
+			continue
+		}
+		if err := s.Table.Create(r); err != nil && err != router.ErrDuplicateRoute {
+			return err
+		}
+		s.routes[sum] = r
+	}
+
+	return nil
+}
+
+// Watch applies the routes each time the file changes until done is closed. The directory is
+// watched rather than the file since editors replace files rather than write to them.
+func (s *StaticRoutes) Watch(done chan bool) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(s.Path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		// the timer fires once the writes have stopped for the debounce period
+		timer := time.NewTimer(RoutesDebounce)
+		timer.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case err := <-watcher.Errors:
+				log.Errorf("Error watching the routes file %s: %v", s.Path, err)
+			case ev := <-watcher.Events:
+				if filepath.Clean(ev.Name) != filepath.Clean(s.Path) {
+					continue
+				}
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(RoutesDebounce)
+			case <-timer.C:
+				if err := s.Apply(); err != nil {
+					log.Errorf("Error reloading the routes file: %v", err)
+					continue
+				}
+				log.Infof("Reloaded the routes file %s", s.Path)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/micro/micro/v3/service/router"
+)
+
+type memoryTable struct {
+	router.Table
+	routes map[uint64]router.Route
+	events []router.EventType
+}
+
+func (m *memoryTable) Create(r router.Route) error {
+	if _, ok := m.routes[r.Hash()]; ok {
+		return router.ErrDuplicateRoute
+	}
+	m.routes[r.Hash()] = r
+	m.events = append(m.events, router.Create)
+	return nil
+}
+
+func (m *memoryTable) Delete(r router.Route) error {
+	if _, ok := m.routes[r.Hash()]; !ok {
+		return router.ErrRouteNotFound
+	}
+	delete(m.routes, r.Hash())
+	m.events = append(m.events, router.Delete)
+	return nil
+}
+
+func (m *memoryTable) list() []string {
+	var routes []string
+	for _, r := range m.routes {
+		routes = append(routes, r.Service+" "+r.Address+" "+r.Gateway+" "+r.Network)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func TestStaticRoutes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "routes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "routes.yaml")
+	write := func(data string) {
+		if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`
+routes:
+- service: legacy
+  address: 10.0.0.1:8080
+- service: billing
+  address: 10.0.0.2:8080
+  gateway: 10.0.0.254:8080
+  network: dc2
+  metric: 10
+`)
+
+	table := &memoryTable{routes: make(map[uint64]router.Route)}
+	routes := &StaticRoutes{Path: path, Table: table, Network: "micro", Router: "node"}
+	if err := routes.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := table.list()
+	if len(got) != 2 || got[0] != "billing 10.0.0.2:8080 10.0.0.254:8080 dc2" || got[1] != "legacy 10.0.0.1:8080  micro" {
+		t.Fatalf("Expected the routes of the file, got %v", got)
+	}
+	for _, r := range table.routes {
+		if r.Metadata[router.StaticMetadata] != "true" || r.Router != "node" {
+			t.Fatalf("Expected a static route of the node, got %+v", r)
+		}
+		if r.Service == "legacy" && r.Metric != router.DefaultMetric {
+			t.Fatalf("Expected the default metric, got %v", r.Metric)
+		}
+	}
+
+	// an invalid file leaves the routes as they are
+	write(`
+routes:
+- service: legacy
+`)
+	if err := routes.Apply(); err == nil {
+		t.Fatal("Expected an error for a route without an address")
+	}
+	if len(table.routes) != 2 {
+		t.Fatalf("Expected the routes to be kept, got %v", table.list())
+	}
+
+	// the removed routes are deleted and the changed metrics are set again
+	table.events = nil
+	write(`
+routes:
+- service: legacy
+  address: 10.0.0.1:8080
+  metric: 5
+`)
+	if err := routes.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	got = table.list()
+	if len(got) != 1 || got[0] != "legacy 10.0.0.1:8080  micro" {
+		t.Fatalf("Expected the removed route to be deleted, got %v", got)
+	}
+	for _, r := range table.routes {
+		if r.Metric != 5 {
+			t.Fatalf("Expected the metric to be updated, got %v", r.Metric)
+		}
+	}
+	if len(table.events) != 3 {
+		t.Fatalf("Expected 2 deletes and a create, got %v", table.events)
+	}
+}
@@ -1,33 +1,54 @@
 package server
 
 import (
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/micro/micro/v3/client"
+	"github.com/micro/micro/v3/cmd"
 	"github.com/micro/micro/v3/internal/helper"
 	"github.com/micro/micro/v3/internal/muxer"
 	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/internal/network/transport/fallback"
 	"github.com/micro/micro/v3/internal/network/transport/grpc"
+	"github.com/micro/micro/v3/internal/network/transport/quic"
+	"github.com/micro/micro/v3/internal/network/transport/wireguard"
 	"github.com/micro/micro/v3/internal/network/tunnel"
 	tmucp "github.com/micro/micro/v3/internal/network/tunnel/mucp"
+	mls "github.com/micro/micro/v3/internal/tls"
+	"github.com/micro/micro/v3/internal/wrapper"
 	"github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/debug"
 	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/metrics"
 	net "github.com/micro/micro/v3/service/network"
 	"github.com/micro/micro/v3/service/network/mucp"
+	"github.com/micro/micro/v3/service/network/util"
 	"github.com/micro/micro/v3/service/proxy"
 	grpcProxy "github.com/micro/micro/v3/service/proxy/grpc"
 	mucpProxy "github.com/micro/micro/v3/service/proxy/mucp"
 	muregistry "github.com/micro/micro/v3/service/registry"
 	"github.com/micro/micro/v3/service/router"
 	murouter "github.com/micro/micro/v3/service/router"
+	regRouter "github.com/micro/micro/v3/service/router/registry"
 	"github.com/micro/micro/v3/service/server"
 	mucpServer "github.com/micro/micro/v3/service/server/mucp"
 	"github.com/urfave/cli/v2"
 )
 
+var (
+	// PartitionInterval is how often the network is checked for partitions
+	PartitionInterval = time.Minute
+)
+
 var (
 	// name of the network service
 	name = "network"
@@ -43,7 +64,7 @@ var (
 	token = "micro"
 
 	// Flags specific to the network
-	Flags = []cli.Flag{
+	Flags = append(client.Flags,
 		&cli.StringFlag{
 			Name:    "address",
 			Usage:   "Set the address of the network service",
@@ -54,6 +75,11 @@ var (
 			Usage:   "Set the micro network address to advertise",
 			EnvVars: []string{"MICRO_NETWORK_ADVERTISE"},
 		},
+		&cli.BoolFlag{
+			Name:    "edge",
+			Usage:   "Run as an edge node which only dials out to the nodes, for nodes behind NAT or a firewall",
+			EnvVars: []string{"MICRO_NETWORK_EDGE"},
+		},
 		&cli.StringFlag{
 			Name:    "gateway",
 			Usage:   "Set the default gateway",
@@ -61,22 +87,161 @@ var (
 		},
 		&cli.StringFlag{
 			Name:    "network",
-			Usage:   "Set the micro network name: micro",
+			Aliases: []string{"network_address"},
+			Usage:   "Set the micro network name: micro. This can be a comma separated list to join several networks e.g. local,staging, the first is joined on the peer address :8085 and the nth after it on the peer and advertise ports plus n e.g. :8086",
 			EnvVars: []string{"MICRO_NETWORK"},
 		},
+		&cli.BoolFlag{
+			Name:    "bridge",
+			Usage:   "Bridge the networks joined, advertising the routes learned in each network to the others",
+			EnvVars: []string{"MICRO_NETWORK_BRIDGE"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "broker_topics",
+			Usage:   "Set the topics relayed between the brokers of the network nodes, so the messages published in one network reach the subscribers in the others. The broker is set by --broker_address. Bridged networks relay them too",
+			EnvVars: []string{"MICRO_NETWORK_BROKER_TOPICS"},
+		},
 		&cli.StringFlag{
 			Name:    "nodes",
-			Usage:   "Set the micro network nodes to connect to. This can be a comma separated list.",
+			Usage:   "Set the micro network nodes to connect to. This can be a comma separated list, the nodes of the networks after the first are prefixed by their network e.g. staging@10.0.0.1:8085",
 			EnvVars: []string{"MICRO_NETWORK_NODES"},
 		},
 		&cli.StringFlag{
 			Name:    "token",
-			Usage:   "Set the micro network token for authentication",
+			Aliases: []string{"network_token"},
+			Usage:   "Set the micro network token, the peers without it can't connect or inject routes",
 			EnvVars: []string{"MICRO_NETWORK_TOKEN"},
 		},
-	}
+		&cli.DurationFlag{
+			Name:    "key_rotation",
+			Usage:   "Set how often the tunnel session keys derived from the token are rotated, 0 to disable",
+			EnvVars: []string{"MICRO_NETWORK_KEY_ROTATION"},
+			Value:   24 * time.Hour,
+		},
+		&cli.DurationFlag{
+			Name:    "key_overlap",
+			Usage:   "Set how long the tunnel session keys are accepted after being rotated",
+			EnvVars: []string{"MICRO_NETWORK_KEY_OVERLAP"},
+			Value:   tunnel.DefaultRotateOverlap,
+		},
+		&cli.StringFlag{
+			Name:    "http_address",
+			Usage:   "Set the address to serve the REST API of the network on, disabled if blank",
+			EnvVars: []string{"MICRO_NETWORK_HTTP_ADDRESS"},
+		},
+		&cli.Int64Flag{
+			Name:    "bandwidth",
+			Usage:   "Set the bytes per second sent on each network link, 0 for unlimited",
+			EnvVars: []string{"MICRO_NETWORK_BANDWIDTH"},
+		},
+		&cli.StringFlag{
+			Name:    "server_transport",
+			Aliases: []string{"transport"},
+			Usage:   "Set the transport the nodes peer with: grpc, quic or wireguard. Dialing falls back to grpc if quic fails, wireguard requires --wireguard_key, --wireguard_peers and a --token other than the default",
+			EnvVars: []string{"MICRO_NETWORK_SERVER_TRANSPORT"},
+			Value:   "grpc",
+		},
+		&cli.StringFlag{
+			Name:    "tls_cert",
+			Usage:   "Path to the certificate of the node for mutual TLS on the peer links, requires the key and CA",
+			EnvVars: []string{"MICRO_NETWORK_TLS_CERT"},
+		},
+		&cli.StringFlag{
+			Name:    "tls_key",
+			Usage:   "Path to the key of the certificate for mutual TLS on the peer links",
+			EnvVars: []string{"MICRO_NETWORK_TLS_KEY"},
+		},
+		&cli.StringFlag{
+			Name:    "tls_ca",
+			Usage:   "Path to the CA the certificates of the peers must be signed by for mutual TLS",
+			EnvVars: []string{"MICRO_NETWORK_TLS_CA"},
+		},
+		&cli.StringFlag{
+			Name:    "wireguard_key",
+			Usage:   "Set the private key of the node for the wireguard transport, as generated by wg genkey",
+			EnvVars: []string{"MICRO_NETWORK_WIREGUARD_KEY"},
+		},
+		&cli.StringFlag{
+			Name:    "wireguard_peers",
+			Usage:   "Set the public keys of the nodes peered with over wireguard as a comma separated list, the nodes dialed are followed by the address they're dialed at e.g. key@10.0.0.1:8085",
+			EnvVars: []string{"MICRO_NETWORK_WIREGUARD_PEERS"},
+		},
+		&cli.StringFlag{
+			Name:    "metrics_address",
+			Usage:   "Set the address to serve the prometheus metrics of the node on at /metrics, disabled if blank",
+			EnvVars: []string{"MICRO_NETWORK_METRICS_ADDRESS"},
+		},
+		&cli.StringFlag{
+			Name:    "health_address",
+			Usage:   "Set the address to serve the health of the node on at /healthz for liveness and readiness probes, disabled if blank",
+			EnvVars: []string{"MICRO_NETWORK_HEALTH_ADDRESS"},
+		},
+		&cli.StringFlag{
+			Name:    "advertise_strategy",
+			Usage:   "Set the strategy the routes are advertised to the network with: all, best, local or none",
+			EnvVars: []string{"MICRO_NETWORK_ADVERTISE_STRATEGY"},
+			Value:   "all",
+		},
+		&cli.DurationFlag{
+			Name:    "advertise_interval",
+			Usage:   "Set how often the route changes are flushed to the network, 0 advertises each change at once",
+			EnvVars: []string{"MICRO_NETWORK_ADVERTISE_INTERVAL"},
+		},
+		&cli.DurationFlag{
+			Name:    "advertise_ttl",
+			Usage:   "Set how long the routes learned from the network live unless advertised again, 0 keeps them until their node leaves",
+			EnvVars: []string{"MICRO_NETWORK_ADVERTISE_TTL"},
+		},
+		&cli.StringFlag{
+			Name:    "advertise_filter",
+			Usage:   "Filter the routes advertised to the network by service e.g. service=foo*,deny=internal.*",
+			EnvVars: []string{"MICRO_NETWORK_ADVERTISE_FILTER"},
+		},
+		&cli.StringFlag{
+			Name:    "accept_filter",
+			Usage:   "Filter the routes accepted from the network by service e.g. deny=internal.*",
+			EnvVars: []string{"MICRO_NETWORK_ACCEPT_FILTER"},
+		},
+		&cli.StringFlag{
+			Name:    "routes_file",
+			Usage:   "Path to a yaml file of static routes to seed the router with, reloaded when it changes",
+			EnvVars: []string{"MICRO_NETWORK_ROUTES_FILE"},
+		},
+	)
 )
 
+// peerTLSConfig returns the config of the peer links, nil unless tls is set up. The router
+// advertisements and the messages of the network are sent over the peer links so they're all
+// encrypted by it. The tls_cert, tls_key and tls_ca flags set up mutual tls, the peers on both
+// sides of a link must present a certificate signed by the CA, as enable_tls does with a client
+// CA. Without one enable_tls only encrypts the links, the peers are authenticated by the token.
+func peerTLSConfig(ctx *cli.Context) (*tls.Config, error) {
+	if len(ctx.String("tls_cert")) > 0 || len(ctx.String("tls_key")) > 0 || len(ctx.String("tls_ca")) > 0 {
+		return mls.MutualConfig(ctx.String("tls_cert"), ctx.String("tls_key"), ctx.String("tls_ca"))
+	}
+	if !ctx.Bool("enable_tls") {
+		return nil, nil
+	}
+	if len(ctx.String("tls_client_ca_file")) > 0 {
+		return mls.MutualConfig(ctx.String("tls_cert_file"), ctx.String("tls_key_file"), ctx.String("tls_client_ca_file"))
+	}
+	config, err := helper.TLSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config.InsecureSkipVerify = true
+	return config, nil
+}
+
+// networkServerOptions returns the options of the server the network serves the calls of its
+// peers on, the calls are verified against the auth rules just as the calls to the local server are
+func networkServerOptions(router server.Router) []server.Option {
+	return []server.Option{
+		server.WithRouter(router),
+		server.WrapHandler(wrapper.AuthHandler()),
+	}
+}
+
 // Run runs the micro server
 func Run(ctx *cli.Context) error {
 	if len(ctx.String("server_name")) > 0 {
@@ -91,8 +256,11 @@ func Run(ctx *cli.Context) error {
 	if len(ctx.String("advertise")) > 0 {
 		advertise = ctx.String("advertise")
 	}
+	// the networks after the first are joined on the ports following the peer address
+	var joins []string
 	if len(ctx.String("network")) > 0 {
-		networkName = ctx.String("network")
+		names := strings.Split(ctx.String("network"), ",")
+		networkName, joins = names[0], names[1:]
 	}
 	if len(ctx.String("token")) > 0 {
 		token = ctx.String("token")
@@ -102,6 +270,28 @@ func Run(ctx *cli.Context) error {
 	if len(ctx.String("nodes")) > 0 {
 		nodes = strings.Split(ctx.String("nodes"), ",")
 	}
+	joinNodes, err := splitNodes(nodes, append([]string{networkName}, joins...))
+	if err != nil {
+		return err
+	}
+	nodes = joinNodes[networkName]
+
+	// log the name of the network with the records of the node
+	fields := log.DefaultLogger.Options().Fields
+	fields["network"] = networkName
+	log.DefaultLogger.Init(log.WithFields(fields))
+
+	// edge nodes keep a link open to the nodes which inbound requests are
+	// multiplexed over, so there's nothing to connect to without them
+	edge := ctx.Bool("edge")
+	if edge && len(nodes) == 0 {
+		return errors.New("Edge nodes require the nodes to connect to")
+	}
+	for _, name := range joins {
+		if edge && len(joinNodes[name]) == 0 {
+			return fmt.Errorf("Edge nodes require the nodes of network %s to connect to", name)
+		}
+	}
 
 	// Initialise the local service
 	service := service.New(
@@ -113,35 +303,115 @@ func Run(ctx *cli.Context) error {
 	tunOpts := []tunnel.Option{
 		tunnel.Address(peerAddress),
 		tunnel.Token(token),
+		tunnel.Edge(edge),
+		tunnel.RotateInterval(ctx.Duration("key_rotation")),
+		tunnel.RotateOverlap(ctx.Duration("key_overlap")),
+		tunnel.Bandwidth(ctx.Int64("bandwidth")),
 	}
 
-	if ctx.Bool("enable_tls") {
-		config, err := helper.TLSConfig(ctx)
-		if err != nil {
-			fmt.Println(err.Error())
-			return err
-		}
-		config.InsecureSkipVerify = true
+	var trOpts []transport.Option
+	config, err := peerTLSConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("Error loading the TLS config of the peer links: %v", err)
+	}
+	if config != nil {
+		trOpts = append(trOpts, transport.TLSConfig(config))
+	}
 
+	switch tr := ctx.String("server_transport"); tr {
+	case "", "grpc":
+		if len(trOpts) > 0 {
+			tunOpts = append(tunOpts, tunnel.Transport(grpc.NewTransport(trOpts...)))
+		}
+	case "quic":
+		// quic runs over udp which may be blocked, the nodes also listen with grpc on the same port
+		// over tcp so dialing can fall back to it
 		tunOpts = append(tunOpts, tunnel.Transport(
-			grpc.NewTransport(transport.TLSConfig(config)),
+			fallback.NewTransport(quic.NewTransport(trOpts...), grpc.NewTransport(trOpts...)),
 		))
+	case "wireguard":
+		// the peers are authenticated by their wireguard keys rather than the certs
+		if config != nil {
+			return fmt.Errorf("The wireguard server transport doesn't use TLS, disable it or use grpc or quic")
+		}
+		// the preshared key is derived from the token, which is public unless it's set
+		if token == "micro" {
+			return fmt.Errorf("The wireguard server transport requires a --token other than the default")
+		}
+		if len(ctx.String("wireguard_key")) == 0 {
+			return fmt.Errorf("The wireguard server transport requires the --wireguard_key of the node")
+		}
+		var peers []string
+		if len(ctx.String("wireguard_peers")) > 0 {
+			peers = strings.Split(ctx.String("wireguard_peers"), ",")
+		}
+		tunOpts = append(tunOpts, tunnel.Transport(wireguard.NewTransport(
+			wireguard.PrivateKey(ctx.String("wireguard_key")),
+			wireguard.Peers(peers...),
+			wireguard.Token(token),
+		)))
+	default:
+		return fmt.Errorf("Unsupported server transport %s, expected grpc, quic or wireguard", tr)
 	}
 
 	gateway := ctx.String("gateway")
 	tun := tmucp.NewTunnel(tunOpts...)
 	id := service.Server().Options().Id
 
+	// the advertise address of an edge node isn't reachable, it's only
+	// used to address the node over the tunnel so it needs to be unique
+	if edge && len(advertise) == 0 {
+		advertise = id
+	}
+
+	strategy, err := router.ParseStrategy(ctx.String("advertise_strategy"))
+	if err != nil {
+		return err
+	}
+	advertiseFilter, err := router.ParseServiceFilter(ctx.String("advertise_filter"))
+	if err != nil {
+		return err
+	}
+	acceptFilter, err := router.ParseServiceFilter(ctx.String("accept_filter"))
+	if err != nil {
+		return err
+	}
+
+	// the options of the routers of all the networks joined
+	routerOpts := []router.Option{
+		router.Id(id),
+		router.Registry(muregistry.DefaultRegistry),
+		router.Cache(),
+		router.Advertise(strategy),
+		router.AdvertiseInterval(ctx.Duration("advertise_interval")),
+		router.AdvertiseTTL(ctx.Duration("advertise_ttl")),
+		router.AdvertiseFilter(advertiseFilter),
+		router.AcceptFilter(acceptFilter),
+	}
+
 	// local tunnel router
 	rtr := murouter.DefaultRouter
 
-	rtr.Init(
+	rtr.Init(append(routerOpts,
 		router.Network(networkName),
-		router.Id(id),
-		router.Registry(muregistry.DefaultRegistry),
 		router.Gateway(gateway),
-		router.Cache(),
-	)
+	)...)
+
+	// seed the router with the static routes of the services which aren't in the registry
+	if path := ctx.String("routes_file"); len(path) > 0 {
+		if rtr.Table() == nil {
+			return fmt.Errorf("The %s router doesn't support static routes", rtr.String())
+		}
+		routes := &StaticRoutes{Path: path, Table: rtr.Table(), Network: networkName, Router: id}
+		if err := routes.Apply(); err != nil {
+			return fmt.Errorf("Error loading the routes file: %v", err)
+		}
+		stop := make(chan bool)
+		defer close(stop)
+		if err := routes.Watch(stop); err != nil {
+			return fmt.Errorf("Error watching the routes file: %v", err)
+		}
+	}
 
 	// create new network
 	netService := mucp.NewNetwork(
@@ -154,6 +424,56 @@ func Run(ctx *cli.Context) error {
 		net.Router(rtr),
 	)
 
+	// join the other networks with a tunnel and router of their own, the local services are
+	// routed to in all of them
+	joined := make([]*joinedNetwork, 0, len(joins))
+	for i, name := range joins {
+		addr, err := offsetPort(peerAddress, i+1)
+		if err != nil {
+			return fmt.Errorf("Error joining network %s: %v", name, err)
+		}
+		adv := advertise
+		if len(adv) > 0 && !edge {
+			if adv, err = offsetPort(adv, i+1); err != nil {
+				return fmt.Errorf("Error joining network %s: %v", name, err)
+			}
+		}
+
+		r := regRouter.NewRouter(append(routerOpts, router.Network(name))...)
+		joined = append(joined, &joinedNetwork{
+			name:   name,
+			router: r,
+			network: mucp.NewNetwork(
+				net.Id(id),
+				net.Name(name),
+				net.Address(addr),
+				net.Advertise(adv),
+				net.Nodes(joinNodes[name]...),
+				net.Tunnel(tmucp.NewTunnel(append(tunOpts, tunnel.Address(addr))...)),
+				net.Router(r),
+			),
+		})
+	}
+
+	// the calls to the routes bridged from the other networks are forwarded over their links
+	bridged := ctx.Bool("bridge")
+	links := map[string]net.Network{networkName: netService}
+	for _, j := range joined {
+		links[j.name] = j.network
+	}
+	linkOpts := func(network net.Network, name string) []proxy.Option {
+		opts := []proxy.Option{proxy.WithLink("network", network.Client())}
+		if !bridged {
+			return opts
+		}
+		for n, l := range links {
+			if n != name {
+				opts = append(opts, proxy.WithLink(mucp.BridgeLink+n, l.Client()))
+			}
+		}
+		return opts
+	}
+
 	// local proxy using grpc
 	// TODO: reenable after PR
 	localProxy := grpcProxy.NewProxy(
@@ -165,25 +485,27 @@ func Run(ctx *cli.Context) error {
 	// used by the network nodes to cluster
 	// and share routes or route through
 	// each other
-	networkProxy := mucpProxy.NewProxy(
+	networkProxy := mucpProxy.NewProxy(append(
+		linkOpts(netService, networkName),
 		proxy.WithRouter(rtr),
 		proxy.WithClient(service.Client()),
-		proxy.WithLink("network", netService.Client()),
-	)
+	)...)
+
+	// track the partitions of the network
+	tracker := util.NewPartitions()
 
 	// create a handler
-	h := mucpServer.DefaultRouter.NewHandler(
-		&Network{Network: netService},
-	)
+	handler := &Network{Network: netService, Tracker: tracker, RequirePeers: len(nodes) > 0}
+	h := mucpServer.DefaultRouter.NewHandler(handler)
 
 	// register the handler
 	mucpServer.DefaultRouter.Handle(h)
 
-	// local mux
-	localMux := muxer.New(name, localProxy)
+	// local mux, the network policies are enforced on the calls forwarded
+	localMux := muxer.New(name, &policyProxy{localProxy})
 
 	// network mux
-	networkMux := muxer.New(name, networkProxy)
+	networkMux := muxer.New(name, &policyProxy{networkProxy})
 
 	// init the local grpc server
 	service.Server().Init(
@@ -191,15 +513,68 @@ func Run(ctx *cli.Context) error {
 	)
 
 	// set network server to proxy
-	netService.Server().Init(
-		server.WithRouter(networkMux),
-	)
+	netService.Server().Init(networkServerOptions(networkMux)...)
 
 	// connect network
 	if err := netService.Connect(); err != nil {
 		log.Fatalf("Network failed to connect: %v", err)
 	}
 
+	for _, j := range joined {
+		jp := mucpProxy.NewProxy(append(
+			linkOpts(j.network, j.name),
+			proxy.WithRouter(j.router),
+			proxy.WithClient(service.Client()),
+		)...)
+		j.network.Server().Init(networkServerOptions(muxer.New(name, &policyProxy{jp}))...)
+		if err := j.network.Connect(); err != nil {
+			log.Fatalf("Network %s failed to connect: %v", j.name, err)
+		}
+		log.Infof("Network [%s] joined", j.name)
+	}
+
+	// relay the messages published to the topics between the brokers of the nodes
+	if topics := ctx.StringSlice("broker_topics"); len(topics) > 0 {
+		tunnels := []tunnel.Tunnel{tun}
+		if bridged {
+			for _, j := range joined {
+				tunnels = append(tunnels, j.network.Options().Tunnel)
+			}
+		}
+		relay := newRelay(broker.DefaultBroker, topics, tunnels...)
+		if err := relay.start(); err != nil {
+			return fmt.Errorf("Error relaying the broker topics: %v", err)
+		}
+		defer relay.stop()
+		log.Infof("Network relaying the broker topics %s", strings.Join(topics, ","))
+	}
+
+	// federate the routes learned in each network into the others
+	stopBridges := make(chan bool)
+	defer close(stopBridges)
+	if bridged {
+		routers := map[string]router.Router{networkName: rtr}
+		for _, j := range joined {
+			routers[j.name] = j.router
+		}
+		for from, fr := range routers {
+			for to, tr := range routers {
+				if from == to {
+					continue
+				}
+				if err := bridge(fr, from, tr.Table(), id, stopBridges); err != nil {
+					return fmt.Errorf("Error bridging network %s to %s: %v", from, to, err)
+				}
+			}
+		}
+	}
+
+	// wait for the routes to be withdrawn for the graceful timeout if set
+	closeTimeout := time.Second
+	if timeout := ctx.Duration("graceful_timeout"); timeout > 0 {
+		closeTimeout = timeout
+	}
+
 	// netClose hard exits if we have problems
 	netClose := func(net net.Network) error {
 		errChan := make(chan error, 1)
@@ -211,12 +586,138 @@ func Run(ctx *cli.Context) error {
 		select {
 		case err := <-errChan:
 			return err
-		case <-time.After(time.Second):
+		case <-time.After(closeTimeout):
 			return errors.New("Network timeout closing")
 		}
 	}
 
-	log.Infof("Network [%s] listening on %s", networkName, peerAddress)
+	if edge {
+		log.Infof("Network [%s] edge node connected to %s", networkName, strings.Join(nodes, ","))
+	} else {
+		log.Infof("Network [%s] listening on %s", networkName, peerAddress)
+	}
+
+	// serve the rest api for automation
+	if addr := ctx.String("http_address"); len(addr) > 0 {
+		srv := &http.Server{
+			Addr:    addr,
+			Handler: &HTTP{Name: name, Network: handler},
+		}
+		if ctx.Bool("enable_tls") {
+			config, err := helper.TLSConfig(ctx)
+			if err != nil {
+				return err
+			}
+			srv.TLSConfig = config
+		}
+		go func() {
+			var err error
+			if srv.TLSConfig != nil {
+				err = srv.ListenAndServeTLS("", "")
+			} else {
+				err = srv.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
+				log.Errorf("Network failed to serve the http api on %s: %v", addr, err)
+			}
+		}()
+		defer srv.Close()
+	}
+
+	// serve the metrics of the node to prometheus
+	if addr := ctx.String("metrics_address"); len(addr) > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", &Metrics{Network: netService, Router: rtr, Tunnel: tun, Tracker: tracker})
+		srv := &http.Server{Addr: addr, Handler: mux}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Errorf("Network failed to serve the metrics on %s: %v", addr, err)
+			}
+		}()
+		defer srv.Close()
+	}
+
+	// serve the health of the node to the probes of the orchestrator
+	if addr := ctx.String("health_address"); len(addr) > 0 {
+		srv := &http.Server{Addr: addr, Handler: &Healthz{Network: handler}}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Errorf("Network failed to serve the health on %s: %v", addr, err)
+			}
+		}()
+		defer srv.Close()
+	}
+
+	// apply the flags which changed when they're reloaded on SIGHUP, the address only applies once
+	// the server is restarted
+	cmd.OnReload(func(ctx *cli.Context) ([]server.Option, error) {
+		var opts []server.Option
+		if a := ctx.String("address"); len(a) > 0 && a != address {
+			log.Infof("Network service address changed to %s", a)
+			address = a
+			opts = append(opts, server.Address(a))
+		}
+		if g := ctx.String("gateway"); g != gateway {
+			log.Infof("Network [%s] gateway changed to %s", networkName, g)
+			gateway = g
+			rtr.Init(router.Gateway(g))
+		}
+		if s, err := router.ParseStrategy(ctx.String("advertise_strategy")); err != nil {
+			return nil, err
+		} else if s != strategy {
+			log.Infof("Network [%s] advertise strategy changed to %s", networkName, s)
+			strategy = s
+			rtr.Init(router.Advertise(s))
+		}
+		if f, err := router.ParseServiceFilter(ctx.String("advertise_filter")); err != nil {
+			return nil, err
+		} else if f.String() != advertiseFilter.String() {
+			log.Infof("Network [%s] advertise filter changed to %s", networkName, f)
+			advertiseFilter = f
+			rtr.Init(router.AdvertiseFilter(f))
+		}
+		if f, err := router.ParseServiceFilter(ctx.String("accept_filter")); err != nil {
+			return nil, err
+		} else if f.String() != acceptFilter.String() {
+			log.Infof("Network [%s] accept filter changed to %s", networkName, f)
+			acceptFilter = f
+			rtr.Init(router.AcceptFilter(f))
+		}
+		if n := strings.Split(ctx.String("network"), ",")[0]; len(n) > 0 && n != networkName {
+			log.Infof("Network [%s] renamed to %s", networkName, n)
+			networkName = n
+			fields := log.DefaultLogger.Options().Fields
+			fields["network"] = n
+			log.DefaultLogger.Init(log.WithFields(fields))
+			rtr.Init(router.Network(n))
+			netService.Init(net.Name(n))
+		}
+
+		var reloaded []string
+		if len(ctx.String("nodes")) > 0 {
+			split, err := splitNodes(strings.Split(ctx.String("nodes"), ","), append([]string{networkName}, joins...))
+			if err != nil {
+				return nil, err
+			}
+			reloaded = split[networkName]
+		}
+		if strings.Join(reloaded, ",") != strings.Join(nodes, ",") {
+			if edge && len(reloaded) == 0 {
+				return nil, errors.New("Edge nodes require the nodes to connect to")
+			}
+			log.Infof("Network [%s] nodes changed to %s", networkName, strings.Join(reloaded, ","))
+			nodes = reloaded
+			netService.Init(net.Nodes(nodes...))
+			if err := netService.Connect(); err != nil {
+				return nil, err
+			}
+		}
+		return opts, nil
+	})
+
+	// watch for the network splitting
+	done := make(chan bool)
+	go watchPartitions(netService, tracker, done)
 
 	if err := service.Run(); err != nil {
 		log.Errorf("Network %s failed: %v", networkName, err)
@@ -224,8 +725,80 @@ func Run(ctx *cli.Context) error {
 		os.Exit(1)
 	}
 
-	// close the network
+	// close the networks
+	close(done)
+	for _, j := range joined {
+		netClose(j.network)
+		j.router.Close()
+	}
 	netClose(netService)
 
 	return nil
 }
+
+// watchPartitions updates the partitions of the network every PartitionInterval, reporting the
+// number of partitions as a metric and publishing an alert whenever the network splits or heals
+func watchPartitions(n net.Network, tracker *util.Partitions, done chan bool) {
+	t := time.NewTicker(PartitionInterval)
+	defer t.Stop()
+
+	// the network starts out whole
+	last := 1
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+		}
+
+		tracker.Update(n, time.Now())
+
+		components := tracker.Components()
+		if metrics.IsSet() {
+			metrics.Gauge("network.partitions", float64(len(components)), metrics.Tags{"network": n.Name()})
+		}
+
+		// only alert when the network splits or heals
+		if len(components) == last {
+			continue
+		}
+		last = len(components)
+
+		reason := fmt.Sprintf("network healed, all nodes reachable from %s", n.Id())
+		if len(components) > 1 {
+			sizes := make([]string, 0, len(components))
+			for _, c := range components {
+				sizes = append(sizes, strconv.Itoa(len(c)))
+			}
+			reason = fmt.Sprintf("network split into %d partitions of %s nodes", len(components), strings.Join(sizes, ", "))
+		}
+		log.Warnf("Network [%s] %s", n.Name(), reason)
+
+		if err := publishPartition(n, reason); err != nil {
+			log.Errorf("Network failed to publish partition alert: %v", err)
+		}
+	}
+}
+
+// publishPartition publishes a partition alert to the broker
+func publishPartition(n net.Network, reason string) error {
+	b, err := json.Marshal(&debug.Alert{
+		Type:      debug.AlertPartition,
+		Service:   name,
+		Node:      n.Id(),
+		Reason:    reason,
+		Timestamp: time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	return broker.Publish(debug.AlertTopic, &broker.Message{
+		Header: map[string]string{
+			"Micro-Alert-Type":    debug.AlertPartition,
+			"Micro-Alert-Service": name,
+			"Micro-Alert-Node":    n.Id(),
+		},
+		Body: b,
+	})
+}
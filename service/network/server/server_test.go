@@ -0,0 +1,208 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	inauth "github.com/micro/micro/v3/internal/auth"
+	mls "github.com/micro/micro/v3/internal/tls"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/auth/jwt"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/server"
+	"github.com/urfave/cli/v2"
+)
+
+// testMux records the calls which reach the router of the network server
+type testMux struct {
+	server.Router
+	served []string
+}
+
+func (t *testMux) ServeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
+	t.served = append(t.served, req.Service()+" "+req.Endpoint())
+	return nil
+}
+
+type testCall struct {
+	server.Request
+	service  string
+	endpoint string
+}
+
+func (t *testCall) Service() string  { return t.service }
+func (t *testCall) Endpoint() string { return t.endpoint }
+
+func TestNetworkServerAuth(t *testing.T) {
+	defer func(a auth.Auth) { auth.DefaultAuth = a }(auth.DefaultAuth)
+
+	pub, err := ioutil.ReadFile("../../../internal/auth/token/jwt/test/sample_key.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, err := ioutil.ReadFile("../../../internal/auth/token/jwt/test/sample_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth.DefaultAuth = jwt.NewAuth(auth.PublicKey(string(pub)), auth.PrivateKey(string(priv)), auth.Issuer("micro"))
+
+	// the users may call helloworld and the nodes the network
+	auth.DefaultAuth.Grant(&auth.Rule{
+		ID:       "users",
+		Scope:    "user",
+		Resource: &auth.Resource{Type: "service", Name: "helloworld", Endpoint: "*"},
+		Access:   auth.AccessGranted,
+	})
+	auth.DefaultAuth.Grant(&auth.Rule{
+		ID:       "nodes",
+		Scope:    "service",
+		Resource: &auth.Resource{Type: "service", Name: "network", Endpoint: "*"},
+		Access:   auth.AccessGranted,
+	})
+	user, err := auth.Generate("alice", auth.WithScopes("user"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := auth.Generate("network", auth.WithType("service"), auth.WithScopes("service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// serve the calls as the server does, through the handler wrappers to the router
+	rtr := new(testMux)
+	var options server.Options
+	for _, o := range networkServerOptions(rtr) {
+		o(&options)
+	}
+	handler := func(ctx context.Context, req server.Request, rsp interface{}) error {
+		return options.Router.ServeRequest(ctx, req, nil)
+	}
+	for i := len(options.HdlrWrappers); i > 0; i-- {
+		handler = options.HdlrWrappers[i-1](handler)
+	}
+
+	call := func(token, service, endpoint string) error {
+		ctx := context.TODO()
+		if len(token) > 0 {
+			ctx = metadata.Set(ctx, "Authorization", inauth.BearerScheme+token)
+		}
+		return handler(ctx, &testCall{service: service, endpoint: endpoint}, nil)
+	}
+
+	// a call without a token is rejected
+	if err := call("", "helloworld", "Helloworld.Call"); errors.FromError(err).Code != 401 {
+		t.Fatalf("Expected the call without a token to be unauthorized, got %v", err)
+	}
+	// a call with a valid token matching a rule reaches the router
+	if err := call(user.Secret, "helloworld", "Helloworld.Call"); err != nil {
+		t.Fatalf("Expected the call of the user to succeed, got %v", err)
+	}
+	// a call with a valid token not matching a rule is forbidden
+	if err := call(user.Secret, "network", "Network.Connect"); errors.FromError(err).Code != 403 {
+		t.Fatalf("Expected the call of the user to the network to be forbidden, got %v", err)
+	}
+	// the nodes call each other with the token of their service account
+	if err := call(node.Secret, "network", "Network.Nodes"); err != nil {
+		t.Fatalf("Expected the call of the node to succeed, got %v", err)
+	}
+	if err := call(node.Secret, "network", "Network.Graph"); err != nil {
+		t.Fatalf("Expected the call of the node to succeed, got %v", err)
+	}
+
+	want := []string{"helloworld Helloworld.Call", "network Network.Nodes", "network Network.Graph"}
+	if len(rtr.served) != len(want) {
+		t.Fatalf("Expected the calls %v to be served, got %v", want, rtr.served)
+	}
+	for i := range want {
+		if rtr.served[i] != want[i] {
+			t.Fatalf("Expected the calls %v to be served, got %v", want, rtr.served)
+		}
+	}
+}
+
+func TestPeerTLSConfig(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("network", flag.ContinueOnError)
+		for _, f := range Flags {
+			if err := f.Apply(set); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := set.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+
+	// write a self signed certificate which is its own CA
+	cert, err := mls.Certificate("127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "node.crt")
+	keyFile := filepath.Join(dir, "node.key")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the peer links are plain unless tls is enabled
+	config, err := peerTLSConfig(newContext())
+	if err != nil || config != nil {
+		t.Fatalf("Expected no tls config, got %v %v", config, err)
+	}
+
+	// enable_tls without a client CA only encrypts the links, the peers don't present certificates
+	config, err = peerTLSConfig(newContext("--enable_tls", "--tls_cert_file", certFile, "--tls_key_file", keyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ClientAuth != tls.NoClientCert || len(config.Certificates) != 1 {
+		t.Fatal("Expected one way tls with the certificate")
+	}
+
+	// the tls_cert, tls_key and tls_ca flags set up mutual tls, which requires all of them
+	if _, err = peerTLSConfig(newContext("--tls_cert", certFile, "--tls_key", keyFile)); err == nil {
+		t.Fatal("Expected an error setting up mutual tls without the CA")
+	}
+	config, err = peerTLSConfig(newContext("--tls_cert", certFile, "--tls_key", keyFile, "--tls_ca", certFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ClientAuth != tls.RequireAnyClientCert || config.VerifyPeerCertificate == nil {
+		t.Fatal("Expected the certificates of the peers to be required and verified")
+	}
+
+	// the certificates of the peers on both sides of the links are verified against the CA
+	config, err = peerTLSConfig(newContext("--enable_tls", "--tls_cert_file", certFile, "--tls_key_file", keyFile, "--tls_client_ca_file", certFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ClientAuth != tls.RequireAnyClientCert || config.VerifyPeerCertificate == nil {
+		t.Fatal("Expected the certificates of the peers to be required and verified")
+	}
+	if err := config.VerifyPeerCertificate(cert.Certificate, nil); err != nil {
+		t.Fatalf("Expected a certificate signed by the CA to verify: %v", err)
+	}
+	other, err := mls.Certificate("127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.VerifyPeerCertificate(other.Certificate, nil); err == nil {
+		t.Fatal("Expected a certificate not signed by the CA to be rejected")
+	}
+}
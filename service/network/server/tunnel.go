@@ -0,0 +1,48 @@
+package server
+
+import (
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/urfave/cli/v2"
+)
+
+// TunnelFlags are the flags of the tunnel, which accepts the flags of the network too
+var TunnelFlags = append([]cli.Flag{
+	&cli.StringFlag{
+		Name:    "tunnel_address",
+		Usage:   "Set the address of the public tunnel node to dial out to, blank to run the public tunnel node the others dial",
+		EnvVars: []string{"MICRO_TUNNEL_ADDRESS"},
+	},
+	&cli.StringFlag{
+		Name:    "tunnel_token",
+		Usage:   "Set the token the tunnel is authenticated and encrypted with, the same at both ends",
+		EnvVars: []string{"MICRO_TUNNEL_TOKEN"},
+	},
+}, Flags...)
+
+// setupTunnel sets the flags of the network from the flags of the tunnel
+func setupTunnel(ctx *cli.Context) error {
+	if err := ctx.Set("token", ctx.String("tunnel_token")); err != nil {
+		return err
+	}
+	if addr := ctx.String("tunnel_address"); len(addr) > 0 {
+		if err := ctx.Set("nodes", addr); err != nil {
+			return err
+		}
+		return ctx.Set("edge", "true")
+	}
+	return nil
+}
+
+// RunTunnel runs a network node tunnelling between a private network and a public tunnel node.
+// The node in the private network dials out to the public node as an edge node, so it joins the
+// network from behind NAT and the services of each end call the others over the tunnel.
+func RunTunnel(ctx *cli.Context) error {
+	// the tunnel is only as private as its token so there's no default
+	if len(ctx.String("tunnel_token")) == 0 {
+		log.Fatal("The tunnel requires a token, set the same --tunnel_token at both ends")
+	}
+	if err := setupTunnel(ctx); err != nil {
+		log.Fatalf("Error setting up the tunnel: %v", err)
+	}
+	return Run(ctx)
+}
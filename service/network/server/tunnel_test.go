@@ -0,0 +1,41 @@
+package server
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestSetupTunnel(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("tunnel", flag.ContinueOnError)
+		for _, f := range TunnelFlags {
+			if err := f.Apply(set); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := set.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+
+	// the node behind NAT dials out to the public node as an edge node
+	ctx := newContext("--tunnel_address", "tunnel.example.com:8085", "--tunnel_token", "secret")
+	if err := setupTunnel(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.String("nodes") != "tunnel.example.com:8085" || !ctx.Bool("edge") || ctx.String("token") != "secret" {
+		t.Fatalf("Expected an edge node dialling the tunnel, got nodes %v edge %v token %v", ctx.String("nodes"), ctx.Bool("edge"), ctx.String("token"))
+	}
+
+	// the public node listens for the others
+	ctx = newContext("--tunnel_token", "secret")
+	if err := setupTunnel(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(ctx.String("nodes")) > 0 || ctx.Bool("edge") || ctx.String("token") != "secret" {
+		t.Fatalf("Expected the public node not to dial out, got nodes %v edge %v", ctx.String("nodes"), ctx.Bool("edge"))
+	}
+}
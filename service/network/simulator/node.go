@@ -0,0 +1,171 @@
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/micro/micro/v3/internal/network/tunnel"
+	tmucp "github.com/micro/micro/v3/internal/network/tunnel/mucp"
+	"github.com/micro/micro/v3/service/network"
+	"github.com/micro/micro/v3/service/network/mucp"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/micro/micro/v3/service/registry/memory"
+	"github.com/micro/micro/v3/service/router"
+	regRouter "github.com/micro/micro/v3/service/router/registry"
+)
+
+// DefaultPort is the port the nodes listen on
+var DefaultPort = "8085"
+
+// Node is a node of the simulated network
+type Node struct {
+	// Name of the node
+	Name string
+	// Address the node listens on
+	Address string
+	// Network of the node
+	Network network.Network
+	// Router of the node
+	Router router.Router
+	// Registry of the services of the node, routed to by the other nodes
+	Registry registry.Registry
+
+	sim *Simulator
+	// peers are the nodes the node connects to
+	peers []string
+}
+
+// AddNode adds a node to the network and connects it to the peers, the peers don't need to have
+// been added yet. The router options set e.g. the advertise strategy or filters of the node.
+func (s *Simulator) AddNode(name string, peers []string, opts ...router.Option) (*Node, error) {
+	if _, ok := s.Node(name); ok {
+		return nil, fmt.Errorf("node %s already exists", name)
+	}
+
+	address := s.Host(name) + ":" + DefaultPort
+	nodes := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		nodes = append(nodes, s.Host(peer)+":"+DefaultPort)
+	}
+
+	reg := memory.NewRegistry()
+	rtr := regRouter.NewRouter(append([]router.Option{
+		router.Id(name),
+		router.Registry(reg),
+		router.Cache(),
+		router.Network(s.opts.Name),
+	}, opts...)...)
+
+	tun := tmucp.NewTunnel(
+		tunnel.Id(name),
+		tunnel.Address(address),
+		tunnel.Nodes(nodes...),
+		tunnel.Token(s.opts.Token),
+		tunnel.Transport(s.Transport(name)),
+	)
+
+	net := mucp.NewNetwork(
+		network.Id(name),
+		network.Name(s.opts.Name),
+		network.Address(address),
+		network.Nodes(nodes...),
+		network.Tunnel(tun),
+		network.Router(rtr),
+	)
+
+	n := &Node{
+		Name:     name,
+		Address:  address,
+		Network:  net,
+		Router:   rtr,
+		Registry: reg,
+		sim:      s,
+		peers:    peers,
+	}
+
+	if err := net.Connect(); err != nil {
+		rtr.Close()
+		return nil, err
+	}
+
+	s.Lock()
+	s.nodes[name] = n
+	s.Unlock()
+
+	return n, nil
+}
+
+// Register registers an instance of the service with the node
+func (n *Node) Register(service string) error {
+	n.sim.Lock()
+	n.sim.services[service] = true
+	port := n.sim.nextPort()
+	n.sim.Unlock()
+
+	err := n.Registry.Register(&registry.Service{
+		Name:    service,
+		Version: "latest",
+		Nodes: []*registry.Node{{
+			Id:      service + "-" + n.Name,
+			Address: n.sim.Host(n.Name) + ":" + port,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the router picks the service up from its registry watch, which is restarted when the node
+	// is added, looking the service up loads its routes in case it was missed meanwhile
+	_, err = n.Routes(service)
+	return err
+}
+
+// Deregister deregisters the instances of the service registered with the node
+func (n *Node) Deregister(service string) error {
+	services, err := n.Registry.GetService(service)
+	if err == registry.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, srv := range services {
+		if err := n.Registry.Deregister(srv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Routes returns the routes of the node to the service
+func (n *Node) Routes(service string) ([]router.Route, error) {
+	routes, err := n.Router.Lookup(service, router.LookupLink("*"))
+	if err == router.ErrRouteNotFound {
+		return nil, nil
+	}
+	return routes, err
+}
+
+// Peers returns the names of the nodes the node is peered with
+func (n *Node) Peers() []string {
+	peers := n.Network.Peers()
+	names := make([]string, 0, len(peers))
+	for _, p := range peers {
+		names = append(names, p.Id())
+	}
+	sortNames(names)
+	return names
+}
+
+// Stats returns the counters of the adverts of the node
+func (n *Node) Stats() mucp.Stats {
+	if s, ok := n.Network.(interface{ Stats() mucp.Stats }); ok {
+		return s.Stats()
+	}
+	return mucp.Stats{}
+}
+
+// Close disconnects the node from the network
+func (n *Node) Close() error {
+	err := n.Network.Close()
+	n.Router.Close()
+	return err
+}
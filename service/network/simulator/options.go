@@ -0,0 +1,59 @@
+package simulator
+
+import (
+	"time"
+
+	"github.com/micro/micro/v3/internal/network/tunnel"
+	"github.com/micro/micro/v3/service/router"
+)
+
+// Options of the simulator
+type Options struct {
+	// Name of the network the nodes are in
+	Name string
+	// Token the tunnels of the nodes authenticate with
+	Token string
+	// Link is the conditions of the links between the nodes not set with SetLink
+	Link Link
+	// Seed of the random latency and loss of the messages, so a run can be repeated
+	Seed int64
+}
+
+type Option func(o *Options)
+
+// Name of the network the nodes are in
+func Name(n string) Option {
+	return func(o *Options) {
+		o.Name = n
+	}
+}
+
+// Token the tunnels of the nodes authenticate with
+func Token(t string) Option {
+	return func(o *Options) {
+		o.Token = t
+	}
+}
+
+// DefaultLink sets the conditions of the links between the nodes
+func DefaultLink(l Link) Option {
+	return func(o *Options) {
+		o.Link = l
+	}
+}
+
+// Seed sets the seed of the random latency and loss of the messages
+func Seed(s int64) Option {
+	return func(o *Options) {
+		o.Seed = s
+	}
+}
+
+// DefaultOptions returns the default options of the simulator
+func DefaultOptions() Options {
+	return Options{
+		Name:  router.DefaultNetwork,
+		Token: tunnel.DefaultToken,
+		Seed:  time.Now().UnixNano(),
+	}
+}
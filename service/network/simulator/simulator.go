@@ -0,0 +1,364 @@
+// Package simulator runs a network of nodes in memory, connected by links with latency and loss
+// which can be partitioned, so the routing and adverts of a topology can be validated before it's
+// run in production. The nodes are the real network, tunnel and router, only the transport
+// between them is simulated.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnreachable is returned when dialing or sending to a node on the other side of a partition
+	ErrUnreachable = errors.New("network is unreachable")
+	// ErrTimeout is returned when the network doesn't reach a state in time
+	ErrTimeout = errors.New("timed out waiting for the network")
+
+	// pollInterval is how often the conditions waited for are checked
+	pollInterval = time.Millisecond * 100
+)
+
+// Link is the conditions of the link between two nodes
+type Link struct {
+	// Latency of the messages sent over the link
+	Latency time.Duration
+	// Jitter is the max random latency added to each message
+	Jitter time.Duration
+	// Loss is the ratio of the messages dropped, between 0 and 1
+	Loss float64
+}
+
+// String returns the link in the format parsed by ParseLink
+func (l Link) String() string {
+	return fmt.Sprintf("latency=%v,jitter=%v,loss=%v", l.Latency, l.Jitter, l.Loss)
+}
+
+// ParseLink parses the conditions of a link e.g. latency=20ms,jitter=5ms,loss=0.01, the
+// conditions not set are zero
+func ParseLink(s string) (Link, error) {
+	var l Link
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return l, fmt.Errorf("invalid link condition %q, expected key=value", part)
+		}
+		var err error
+		switch kv[0] {
+		case "latency":
+			l.Latency, err = time.ParseDuration(kv[1])
+		case "jitter":
+			l.Jitter, err = time.ParseDuration(kv[1])
+		case "loss":
+			l.Loss, err = strconv.ParseFloat(kv[1], 64)
+			if err == nil && (l.Loss < 0 || l.Loss > 1) {
+				err = errors.New("must be between 0 and 1")
+			}
+		default:
+			return l, fmt.Errorf("unknown link condition %q, expected latency, jitter or loss", kv[0])
+		}
+		if err != nil {
+			return l, fmt.Errorf("invalid %s %q: %v", kv[0], kv[1], err)
+		}
+	}
+	return l, nil
+}
+
+// Simulator is a network of nodes in memory
+type Simulator struct {
+	opts Options
+
+	sync.RWMutex
+	rand *rand.Rand
+	// hosts are the addresses of the nodes by name
+	hosts map[string]string
+	// links are the conditions of the links set between two nodes, keyed by the pair of names
+	links map[[2]string]Link
+	// groups are the partitions the nodes are in, the nodes not in one are in group 0
+	groups    map[string]int
+	listeners map[string]*listener
+	sockets   map[*socket]bool
+	nodes     map[string]*Node
+	services  map[string]bool
+	port      int
+}
+
+// New returns a new simulator
+func New(opts ...Option) *Simulator {
+	options := DefaultOptions()
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return &Simulator{
+		opts:      options,
+		rand:      rand.New(rand.NewSource(options.Seed)),
+		hosts:     make(map[string]string),
+		links:     make(map[[2]string]Link),
+		groups:    make(map[string]int),
+		listeners: make(map[string]*listener),
+		sockets:   make(map[*socket]bool),
+		nodes:     make(map[string]*Node),
+		services:  make(map[string]bool),
+		port:      10000,
+	}
+}
+
+// Options returns the options of the simulator
+func (s *Simulator) Options() Options {
+	return s.opts
+}
+
+// Host returns the address of the node, assigned when a node is first seen
+func (s *Simulator) Host(node string) string {
+	s.Lock()
+	defer s.Unlock()
+	return s.host(node)
+}
+
+func (s *Simulator) host(node string) string {
+	if h, ok := s.hosts[node]; ok {
+		return h
+	}
+	n := len(s.hosts)
+	h := fmt.Sprintf("10.0.%d.%d", n/254, n%254+1)
+	s.hosts[node] = h
+	return h
+}
+
+// nextPort returns a port which isn't in use
+func (s *Simulator) nextPort() string {
+	s.port++
+	return strconv.Itoa(s.port)
+}
+
+func pair(a, b string) [2]string {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+// SetLink sets the conditions of the link between the nodes, overriding the default link
+func (s *Simulator) SetLink(a, b string, l Link) {
+	s.Lock()
+	s.links[pair(a, b)] = l
+	s.Unlock()
+}
+
+// Link returns the conditions of the link between the nodes
+func (s *Simulator) Link(a, b string) Link {
+	s.RLock()
+	defer s.RUnlock()
+	if l, ok := s.links[pair(a, b)]; ok {
+		return l
+	}
+	return s.opts.Link
+}
+
+// Partition splits the network, the nodes of each group can only reach the nodes in the same
+// group and the nodes not in any group can only reach each other. The connections between the
+// partitions are closed.
+func (s *Simulator) Partition(groups ...[]string) {
+	s.Lock()
+	s.groups = make(map[string]int)
+	for i, group := range groups {
+		for _, node := range group {
+			s.groups[node] = i + 1
+		}
+	}
+	s.Unlock()
+	s.closeUnreachable()
+}
+
+// Heal removes the partitions so every node can reach the others again
+func (s *Simulator) Heal() {
+	s.Lock()
+	s.groups = make(map[string]int)
+	s.Unlock()
+}
+
+// Reachable returns true if the nodes aren't in different partitions
+func (s *Simulator) Reachable(a, b string) bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.groups[a] == s.groups[b]
+}
+
+// closeUnreachable closes the connections between the nodes which can't reach each other
+func (s *Simulator) closeUnreachable() {
+	s.RLock()
+	var closing []*socket
+	for sock := range s.sockets {
+		if s.groups[sock.node] != s.groups[sock.peer.node] {
+			closing = append(closing, sock)
+		}
+	}
+	s.RUnlock()
+
+	for _, sock := range closing {
+		sock.Close()
+	}
+}
+
+// delay returns the latency of a message sent over the link, or false if it's lost
+func (s *Simulator) delay(a, b string) (time.Duration, bool) {
+	l := s.Link(a, b)
+
+	s.Lock()
+	defer s.Unlock()
+
+	if l.Loss > 0 && s.rand.Float64() < l.Loss {
+		return 0, false
+	}
+	d := l.Latency
+	if l.Jitter > 0 {
+		d += time.Duration(s.rand.Int63n(int64(l.Jitter)))
+	}
+	return d, true
+}
+
+// Nodes returns the names of the nodes added, in order
+func (s *Simulator) Nodes() []string {
+	s.RLock()
+	defer s.RUnlock()
+
+	names := make([]string, 0, len(s.nodes))
+	for name := range s.nodes {
+		names = append(names, name)
+	}
+	sortNames(names)
+	return names
+}
+
+// Node returns the node added with the name
+func (s *Simulator) Node(name string) (*Node, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	n, ok := s.nodes[name]
+	return n, ok
+}
+
+// Services returns the names of the services registered with the nodes
+func (s *Simulator) Services() []string {
+	s.RLock()
+	defer s.RUnlock()
+
+	services := make([]string, 0, len(s.services))
+	for name := range s.services {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+	return services
+}
+
+// Connected returns true if every node is peered with the nodes it connects to, unless they're
+// partitioned from it, and knows the peers of its peers. The routes advertised by a node are only
+// accepted by the nodes which know it's peered with the node the routes are from.
+func (s *Simulator) Connected() bool {
+	for _, name := range s.Nodes() {
+		n, _ := s.Node(name)
+		peered := make(map[string]bool)
+		for _, p := range n.Network.Peers() {
+			peered[p.Id()] = true
+
+			// the peers of the peer known to the node must be its current peers
+			peer, ok := s.Node(p.Id())
+			if !ok {
+				continue
+			}
+			var known []string
+			for _, pp := range p.Peers() {
+				known = append(known, pp.Id())
+			}
+			sortNames(known)
+			if strings.Join(known, ",") != strings.Join(peer.Peers(), ",") {
+				return false
+			}
+		}
+		for _, p := range n.peers {
+			if _, ok := s.Node(p); !ok || !s.Reachable(name, p) {
+				continue
+			}
+			if !peered[p] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Converged returns true if every node has a route to every service registered
+func (s *Simulator) Converged() bool {
+	services := s.Services()
+	for _, name := range s.Nodes() {
+		n, _ := s.Node(name)
+		for _, service := range services {
+			if routes, err := n.Routes(service); err != nil || len(routes) == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// WaitFor checks the condition until it's true, returning ErrTimeout if it isn't within the timeout
+func (s *Simulator) WaitFor(timeout time.Duration, cond func() bool) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if cond() {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrTimeout
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// Close closes the nodes and the connections between them
+func (s *Simulator) Close() error {
+	s.Lock()
+	nodes := make([]*Node, 0, len(s.nodes))
+	for _, n := range s.nodes {
+		nodes = append(nodes, n)
+	}
+	s.nodes = make(map[string]*Node)
+	s.Unlock()
+
+	var gerr error
+	for _, n := range nodes {
+		if err := n.Close(); err != nil {
+			gerr = err
+		}
+	}
+
+	s.RLock()
+	var sockets []*socket
+	for sock := range s.sockets {
+		sockets = append(sockets, sock)
+	}
+	var listeners []*listener
+	for _, l := range s.listeners {
+		listeners = append(listeners, l)
+	}
+	s.RUnlock()
+
+	for _, sock := range sockets {
+		sock.Close()
+	}
+	for _, l := range listeners {
+		l.Close()
+	}
+	return gerr
+}
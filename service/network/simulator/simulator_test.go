@@ -0,0 +1,215 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/internal/network/transport"
+	"github.com/micro/micro/v3/service/network/mucp"
+	"github.com/micro/micro/v3/service/router"
+)
+
+func TestParseLink(t *testing.T) {
+	testData := []struct {
+		link  string
+		want  Link
+		valid bool
+	}{
+		{"", Link{}, true},
+		{"latency=20ms", Link{Latency: 20 * time.Millisecond}, true},
+		{"latency=20ms, jitter=5ms,loss=0.01", Link{Latency: 20 * time.Millisecond, Jitter: 5 * time.Millisecond, Loss: 0.01}, true},
+		{"latency", Link{}, false},
+		{"latency=fast", Link{}, false},
+		{"loss=2", Link{}, false},
+		{"bandwidth=1mb", Link{}, false},
+	}
+
+	for _, d := range testData {
+		l, err := ParseLink(d.link)
+		if (err == nil) != d.valid {
+			t.Errorf("Expected %q to be valid: %v, got error %v", d.link, d.valid, err)
+			continue
+		}
+		if d.valid && l != d.want {
+			t.Errorf("Expected %q to parse to %v, got %v", d.link, d.want, l)
+		}
+	}
+
+	want := Link{Latency: time.Second, Loss: 0.5}
+	if l, err := ParseLink(want.String()); err != nil || l != want {
+		t.Errorf("Expected %v to parse back, got %v %v", want, l, err)
+	}
+}
+
+func TestTopology(t *testing.T) {
+	testData := []struct {
+		kind  string
+		peers map[string][]string
+	}{
+		{"mesh", map[string][]string{"node1": nil, "node2": {"node1"}, "node3": {"node1", "node2"}}},
+		{"ring", map[string][]string{"node1": nil, "node2": {"node1"}, "node3": {"node2", "node1"}}},
+		{"line", map[string][]string{"node1": nil, "node2": {"node1"}, "node3": {"node2"}}},
+		{"star", map[string][]string{"node1": nil, "node2": {"node1"}, "node3": {"node1"}}},
+	}
+
+	for _, d := range testData {
+		top, err := NewTopology(d.kind, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, peers := range d.peers {
+			if len(top[name]) != len(peers) {
+				t.Errorf("Expected %s of the %s to peer with %v, got %v", name, d.kind, peers, top[name])
+				continue
+			}
+			for i := range peers {
+				if top[name][i] != peers[i] {
+					t.Errorf("Expected %s of the %s to peer with %v, got %v", name, d.kind, peers, top[name])
+				}
+			}
+		}
+	}
+
+	if _, err := NewTopology("tree", 3); err == nil {
+		t.Error("Expected an error for an unknown topology")
+	}
+
+	names := []string{"node10", "node2", "node1"}
+	sortNames(names)
+	if names[0] != "node1" || names[1] != "node2" || names[2] != "node10" {
+		t.Errorf("Expected the nodes in order, got %v", names)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	sim := New(Seed(1), DefaultLink(Link{Latency: 50 * time.Millisecond}))
+	defer sim.Close()
+
+	l, err := sim.Transport("b").Listen(":8085")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Addr() != sim.Host("b")+":8085" {
+		t.Errorf("Expected to listen on the address of the node, got %s", l.Addr())
+	}
+
+	accepted := make(chan transport.Socket, 1)
+	go l.Accept(func(sock transport.Socket) {
+		accepted <- sock
+	})
+
+	tr := sim.Transport("a", transport.Timeout(time.Second))
+	c, err := tr.Dial(l.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sock := <-accepted
+
+	// the messages are delayed by the latency of the link, in order
+	start := time.Now()
+	for _, body := range []string{"1", "2", "3"} {
+		if err := c.Send(&transport.Message{Body: []byte(body)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, body := range []string{"1", "2", "3"} {
+		var m transport.Message
+		if err := sock.Recv(&m); err != nil {
+			t.Fatal(err)
+		}
+		if string(m.Body) != body {
+			t.Errorf("Expected message %s, got %s", body, m.Body)
+		}
+	}
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("Expected the messages to be delayed by the latency, received in %v", d)
+	}
+
+	// the messages lost on the link are dropped silently
+	sim.SetLink("a", "b", Link{Loss: 1})
+	if err := c.Send(&transport.Message{Body: []byte("lost")}); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := tr.Dial(l.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsock := <-accepted
+	rsock.(*socket).timeout = 100 * time.Millisecond
+	if err := rsock.Recv(&transport.Message{}); err == nil {
+		t.Error("Expected no message to be received over a lossy link")
+	}
+	rc.Close()
+
+	// a partition closes the connections across it and refuses new ones
+	sim.Partition([]string{"a"}, []string{"b"})
+	if err := c.Send(&transport.Message{}); err == nil {
+		t.Error("Expected an error sending across a partition")
+	}
+	if _, err := tr.Dial(l.Addr()); err != ErrUnreachable {
+		t.Errorf("Expected %v dialing across a partition, got %v", ErrUnreachable, err)
+	}
+
+	sim.Heal()
+	if !sim.Reachable("a", "b") {
+		t.Error("Expected the nodes to be reachable once healed")
+	}
+	c, err = tr.Dial(l.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-accepted
+	c.Close()
+}
+
+func TestNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the network simulation in short mode")
+	}
+
+	// the peers are announced again every second, rather than every 30 seconds,
+	// so the nodes learn the peers of their peers without holding up the test
+	keepAlive := mucp.KeepAliveTime
+	mucp.KeepAliveTime = time.Second
+	defer func() { mucp.KeepAliveTime = keepAlive }()
+
+	sim := New(Seed(1))
+	defer sim.Close()
+
+	top, err := NewTopology("line", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the routes are advertised again every 500ms, so one advert lost doesn't hold up the test
+	if err := sim.Start(top, router.AdvertiseTTL(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.WaitFor(30*time.Second, sim.Connected); err != nil {
+		t.Fatalf("Expected the nodes to connect: %v", err)
+	}
+
+	node1, _ := sim.Node("node1")
+	node3, _ := sim.Node("node3")
+	if err := node1.Register("foo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := node3.Register("bar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.WaitFor(20*time.Second, sim.Converged); err != nil {
+		t.Fatalf("Expected the routes to reach every node: %v", err)
+	}
+	if node1.Stats().AdvertsSent == 0 {
+		t.Error("Expected node1 to have sent adverts")
+	}
+
+	// the routes to the services behind a partition expire
+	sim.Partition([]string{"node3"})
+	err = sim.WaitFor(20*time.Second, func() bool {
+		routes, err := node1.Routes("bar")
+		return err == nil && len(routes) == 0
+	})
+	if err != nil {
+		t.Errorf("Expected the route of node1 to bar to expire: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package simulator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/micro/micro/v3/service/router"
+)
+
+// Topology is the peers of the nodes of a network, by name
+type Topology map[string][]string
+
+// NewTopology returns a topology of n nodes named node1 to nodeN, each node peers with nodes
+// named before it so they're already listening when it's added:
+//
+//	mesh: every node peers with every other node
+//	ring: every node peers with the previous, the last also with the first
+//	line: every node peers with the previous
+//	star: every node peers with node1
+func NewTopology(kind string, n int) (Topology, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid number of nodes %d", n)
+	}
+
+	name := func(i int) string {
+		return "node" + strconv.Itoa(i+1)
+	}
+
+	t := make(Topology, n)
+	for i := 0; i < n; i++ {
+		t[name(i)] = nil
+	}
+
+	switch kind {
+	case "mesh":
+		for i := 1; i < n; i++ {
+			for j := 0; j < i; j++ {
+				t[name(i)] = append(t[name(i)], name(j))
+			}
+		}
+	case "ring", "line":
+		for i := 1; i < n; i++ {
+			t[name(i)] = append(t[name(i)], name(i-1))
+		}
+		if kind == "ring" && n > 2 {
+			t[name(n-1)] = append(t[name(n-1)], name(0))
+		}
+	case "star":
+		for i := 1; i < n; i++ {
+			t[name(i)] = append(t[name(i)], name(0))
+		}
+	default:
+		return nil, fmt.Errorf("unknown topology %q, expected mesh, ring, line or star", kind)
+	}
+
+	return t, nil
+}
+
+// Nodes returns the names of the nodes of the topology, in order
+func (t Topology) Nodes() []string {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sortNames(names)
+	return names
+}
+
+// Start adds the nodes of the topology to the simulator, the router options are set on every node
+func (s *Simulator) Start(t Topology, opts ...router.Option) error {
+	for _, name := range t.Nodes() {
+		if _, err := s.AddNode(name, t[name], opts...); err != nil {
+			return fmt.Errorf("error adding node %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// sortNames sorts the names of the nodes so node2 comes before node10
+func sortNames(names []string) {
+	sort.Slice(names, func(i, j int) bool {
+		a := strings.TrimRight(names[i], "0123456789")
+		b := strings.TrimRight(names[j], "0123456789")
+		if a != b || len(names[i]) == len(names[j]) {
+			return names[i] < names[j]
+		}
+		return len(names[i]) < len(names[j])
+	})
+}
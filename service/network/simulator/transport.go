@@ -0,0 +1,312 @@
+package simulator
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	mnet "github.com/micro/micro/v3/internal/net"
+	"github.com/micro/micro/v3/internal/network/transport"
+)
+
+var (
+	errClosed = errors.New("connection closed")
+
+	// queueSize is the number of messages in flight on a connection after which sending blocks
+	queueSize = 64
+)
+
+// simTransport is the transport of a node of the simulator
+type simTransport struct {
+	sim  *Simulator
+	node string
+	opts transport.Options
+}
+
+// delivery is a message in flight
+type delivery struct {
+	msg *transport.Message
+	at  time.Time
+}
+
+// socket is one end of a connection between two nodes. The messages sent are queued and
+// delivered to the other end after the latency of the link, in order.
+type socket struct {
+	sim  *Simulator
+	node string
+	peer *socket
+
+	local  string
+	remote string
+
+	recv  chan *transport.Message
+	queue chan *delivery
+	// exit is closed when either end of the connection is closed
+	exit chan bool
+	once *sync.Once
+
+	timeout time.Duration
+
+	// last is when the last message sent is delivered, so the messages stay in order
+	sync.Mutex
+	last time.Time
+}
+
+type listener struct {
+	sim  *Simulator
+	node string
+	addr string
+	conn chan *socket
+	exit chan bool
+	once sync.Once
+}
+
+// Transport returns the transport of the node, the connections it dials and accepts have the
+// conditions of the links of the simulator
+func (s *Simulator) Transport(node string, opts ...transport.Option) transport.Transport {
+	var options transport.Options
+	for _, o := range opts {
+		o(&options)
+	}
+	s.Host(node)
+	return &simTransport{sim: s, node: node, opts: options}
+}
+
+func (t *simTransport) Init(opts ...transport.Option) error {
+	for _, o := range opts {
+		o(&t.opts)
+	}
+	return nil
+}
+
+func (t *simTransport) Options() transport.Options {
+	return t.opts
+}
+
+// Dial connects to a node, unless it's on the other side of a partition
+func (t *simTransport) Dial(addr string, opts ...transport.DialOption) (transport.Client, error) {
+	options := transport.DialOptions{
+		Timeout: transport.DefaultDialTimeout,
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	s := t.sim
+	s.Lock()
+	l, ok := s.listeners[addr]
+	if !ok {
+		s.Unlock()
+		return nil, errors.New("could not dial " + addr)
+	}
+	if s.groups[t.node] != s.groups[l.node] {
+		s.Unlock()
+		return nil, ErrUnreachable
+	}
+
+	exit := make(chan bool)
+	once := new(sync.Once)
+	local := mnet.HostPort(s.host(t.node), s.nextPort())
+	client := t.newSocket(t.node, local, addr, exit, once)
+	server := t.newSocket(l.node, addr, local, exit, once)
+	client.peer, server.peer = server, client
+	s.sockets[client] = true
+	s.Unlock()
+
+	go client.deliver()
+	go server.deliver()
+
+	select {
+	case l.conn <- server:
+		return client, nil
+	case <-l.exit:
+	case <-time.After(options.Timeout):
+	}
+	client.Close()
+	return nil, errors.New("could not dial " + addr)
+}
+
+func (t *simTransport) newSocket(node, local, remote string, exit chan bool, once *sync.Once) *socket {
+	return &socket{
+		sim:     t.sim,
+		node:    node,
+		local:   local,
+		remote:  remote,
+		recv:    make(chan *transport.Message),
+		queue:   make(chan *delivery, queueSize),
+		exit:    exit,
+		once:    once,
+		timeout: t.opts.Timeout,
+	}
+}
+
+// Listen listens on the port at the address of the node, the host of the address is ignored
+func (t *simTransport) Listen(addr string, opts ...transport.ListenOption) (transport.Listener, error) {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := t.sim
+	s.Lock()
+	defer s.Unlock()
+
+	if len(port) == 0 || port == "0" {
+		port = s.nextPort()
+	}
+	addr = mnet.HostPort(s.host(t.node), port)
+	if _, ok := s.listeners[addr]; ok {
+		return nil, errors.New("already listening on " + addr)
+	}
+
+	l := &listener{
+		sim:  s,
+		node: t.node,
+		addr: addr,
+		conn: make(chan *socket),
+		exit: make(chan bool),
+	}
+	s.listeners[addr] = l
+	return l, nil
+}
+
+func (t *simTransport) String() string {
+	return "simulator"
+}
+
+func (l *listener) Addr() string {
+	return l.addr
+}
+
+func (l *listener) Close() error {
+	l.once.Do(func() {
+		close(l.exit)
+		l.sim.Lock()
+		delete(l.sim.listeners, l.addr)
+		l.sim.Unlock()
+	})
+	return nil
+}
+
+func (l *listener) Accept(fn func(transport.Socket)) error {
+	for {
+		select {
+		case <-l.exit:
+			return nil
+		case sock := <-l.conn:
+			go fn(sock)
+		}
+	}
+}
+
+// deliver delivers the messages sent to the other end of the connection
+func (s *socket) deliver() {
+	for {
+		select {
+		case <-s.exit:
+			return
+		case d := <-s.queue:
+			if wait := time.Until(d.at); wait > 0 {
+				select {
+				case <-s.exit:
+					return
+				case <-time.After(wait):
+				}
+			}
+			select {
+			case <-s.exit:
+				return
+			case s.peer.recv <- d.msg:
+			}
+		}
+	}
+}
+
+// Send queues the message for delivery after the latency of the link, messages lost on the
+// link are dropped without an error as they would be on the wire
+func (s *socket) Send(m *transport.Message) error {
+	select {
+	case <-s.exit:
+		return errClosed
+	default:
+	}
+
+	if !s.sim.Reachable(s.node, s.peer.node) {
+		s.Close()
+		return ErrUnreachable
+	}
+
+	delay, ok := s.sim.delay(s.node, s.peer.node)
+	if !ok {
+		return nil
+	}
+
+	// copy the message since the caller may reuse it while it's in flight
+	msg := &transport.Message{
+		Header: make(map[string]string, len(m.Header)),
+		Body:   append([]byte(nil), m.Body...),
+	}
+	for k, v := range m.Header {
+		msg.Header[k] = v
+	}
+
+	s.Lock()
+	at := time.Now().Add(delay)
+	if at.Before(s.last) {
+		at = s.last
+	}
+	s.last = at
+	s.Unlock()
+
+	var timeout <-chan time.Time
+	if s.timeout > 0 {
+		timeout = time.After(s.timeout)
+	}
+
+	select {
+	case <-s.exit:
+		return errClosed
+	case <-timeout:
+		return errors.New("send timeout")
+	case s.queue <- &delivery{msg: msg, at: at}:
+	}
+	return nil
+}
+
+func (s *socket) Recv(m *transport.Message) error {
+	var timeout <-chan time.Time
+	if s.timeout > 0 {
+		timeout = time.After(s.timeout)
+	}
+
+	select {
+	case <-s.exit:
+		return errClosed
+	case <-timeout:
+		return errors.New("recv timeout")
+	case msg := <-s.recv:
+		*m = *msg
+	}
+	return nil
+}
+
+func (s *socket) Local() string {
+	return s.local
+}
+
+func (s *socket) Remote() string {
+	return s.remote
+}
+
+// Close closes both ends of the connection
+func (s *socket) Close() error {
+	s.once.Do(func() {
+		close(s.exit)
+		s.sim.Lock()
+		delete(s.sim.sockets, s)
+		delete(s.sim.sockets, s.peer)
+		s.sim.Unlock()
+	})
+	return nil
+}
@@ -0,0 +1,182 @@
+package util
+
+import (
+	"sort"
+	"time"
+
+	"github.com/micro/micro/v3/service/network"
+)
+
+var (
+	// UnmeasuredLatency is the latency assumed for links which haven't been probed yet
+	UnmeasuredLatency = time.Second
+)
+
+// Graph is the network graph as seen from a node, with the latency of the links between the nodes
+type Graph struct {
+	// Nodes are the nodes of the network keyed by id
+	Nodes map[string]network.Node
+	// links are the peers of each node
+	links map[string]map[string]bool
+	// latency is the round trip time of each link, keyed by the ids of both ends
+	latency map[string]map[string]time.Duration
+}
+
+// NewGraph builds the network graph from the topology of the node
+func NewGraph(root network.Node) *Graph {
+	g := &Graph{
+		Nodes:   make(map[string]network.Node),
+		links:   make(map[string]map[string]bool),
+		latency: make(map[string]map[string]time.Duration),
+	}
+	g.add(root)
+	return g
+}
+
+func (g *Graph) add(node network.Node) {
+	if _, ok := g.Nodes[node.Id()]; ok {
+		return
+	}
+	g.Nodes[node.Id()] = node
+
+	for _, peer := range node.Peers() {
+		g.link(node.Id(), peer.Id())
+		g.add(peer)
+	}
+	// the latency to peers beyond the depth of the topology is still known
+	for id, d := range node.Latency() {
+		g.link(node.Id(), id)
+		g.setLatency(node.Id(), id, d)
+	}
+}
+
+// link records a link between two nodes, the links are used in both directions
+func (g *Graph) link(a, b string) {
+	for _, k := range [][2]string{{a, b}, {b, a}} {
+		if g.links[k[0]] == nil {
+			g.links[k[0]] = make(map[string]bool)
+		}
+		g.links[k[0]][k[1]] = true
+	}
+}
+
+// setLatency records the latency of a link, the lowest measurement of either end is kept
+func (g *Graph) setLatency(a, b string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	if l, ok := g.Latency(a, b); ok && l <= d {
+		return
+	}
+	for _, k := range [][2]string{{a, b}, {b, a}} {
+		if g.latency[k[0]] == nil {
+			g.latency[k[0]] = make(map[string]time.Duration)
+		}
+		g.latency[k[0]][k[1]] = d
+	}
+}
+
+// Latency returns the round trip time of the link between two nodes if it's been measured
+func (g *Graph) Latency(a, b string) (time.Duration, bool) {
+	d, ok := g.latency[a][b]
+	return d, ok
+}
+
+// Path returns the ids of the nodes along the lowest latency path between two nodes, including
+// both ends, or nil if there's no path between them. Links which haven't been measured are
+// assumed to have the UnmeasuredLatency.
+func (g *Graph) Path(from, to string) []string {
+	if _, ok := g.links[from]; !ok && from != to {
+		return nil
+	}
+
+	dist := map[string]time.Duration{from: 0}
+	prev := make(map[string]string)
+	done := make(map[string]bool)
+
+	for {
+		// visit the closest node not yet visited, by id for a stable path
+		var next string
+		var found bool
+		for id, d := range dist {
+			if done[id] {
+				continue
+			}
+			if !found || d < dist[next] || (d == dist[next] && id < next) {
+				next, found = id, true
+			}
+		}
+		if !found {
+			return nil
+		}
+		if next == to {
+			break
+		}
+		done[next] = true
+
+		for peer := range g.links[next] {
+			cost, ok := g.Latency(next, peer)
+			if !ok {
+				cost = UnmeasuredLatency
+			}
+			if d, ok := dist[peer]; !ok || dist[next]+cost < d {
+				dist[peer] = dist[next] + cost
+				prev[peer] = next
+			}
+		}
+	}
+
+	path := []string{to}
+	for id := to; id != from; {
+		id = prev[id]
+		path = append(path, id)
+	}
+	// reverse the path to start from the first node
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Ids returns the ids of the nodes in the graph in order
+func (g *Graph) Ids() []string {
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Peers returns the ids of the nodes linked to the node in order
+func (g *Graph) Peers(id string) []string {
+	peers := make([]string, 0, len(g.links[id]))
+	for peer := range g.links[id] {
+		peers = append(peers, peer)
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+// Edge is a link between two nodes of the graph
+type Edge struct {
+	// From and To are the ids of the nodes, From is the lower of the two
+	From, To string
+	// Latency of the link, 0 if it hasn't been measured
+	Latency time.Duration
+}
+
+// Edges returns the links between the nodes in order, each link is returned once
+func (g *Graph) Edges() []Edge {
+	var edges []Edge
+	for _, id := range g.Ids() {
+		for _, peer := range g.Peers(id) {
+			if peer < id {
+				continue
+			}
+			d, _ := g.Latency(id, peer)
+			edges = append(edges, Edge{From: id, To: peer, Latency: d})
+		}
+	}
+	return edges
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/network"
+	"github.com/stretchr/testify/assert"
+)
+
+// testNode is a network node with fixed peers and latency
+type testNode struct {
+	id      string
+	peers   []network.Node
+	latency map[string]time.Duration
+}
+
+func (n *testNode) Id() string                        { return n.id }
+func (n *testNode) Address() string                   { return n.id + ":8085" }
+func (n *testNode) Peers() []network.Node             { return n.peers }
+func (n *testNode) Network() network.Network          { return nil }
+func (n *testNode) Status() network.Status            { return nil }
+func (n *testNode) Latency() map[string]time.Duration { return n.latency }
+
+func TestGraph(t *testing.T) {
+	// a is linked to b and c which are both linked to d, the link from c to d isn't measured
+	d := &testNode{id: "d"}
+	b := &testNode{id: "b", peers: []network.Node{d}, latency: map[string]time.Duration{"d": time.Millisecond * 50}}
+	c := &testNode{id: "c", peers: []network.Node{d}}
+	a := &testNode{
+		id:      "a",
+		peers:   []network.Node{b, c},
+		latency: map[string]time.Duration{"b": time.Millisecond * 10, "c": time.Millisecond},
+	}
+
+	g := NewGraph(a)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, g.Ids())
+
+	// the latency is known from either end of the link
+	l, ok := g.Latency("d", "b")
+	assert.True(t, ok)
+	assert.Equal(t, time.Millisecond*50, l)
+	_, ok = g.Latency("c", "d")
+	assert.False(t, ok)
+
+	// the unmeasured link is avoided
+	assert.Equal(t, []string{"a", "b", "d"}, g.Path("a", "d"))
+	assert.Equal(t, []string{"c", "a", "b", "d"}, g.Path("c", "d"))
+
+	// unless it's faster than the measured links
+	defer func(d time.Duration) { UnmeasuredLatency = d }(UnmeasuredLatency)
+	UnmeasuredLatency = time.Millisecond
+	assert.Equal(t, []string{"a", "c", "d"}, g.Path("a", "d"))
+
+	// each link is listed once with its latency
+	assert.Equal(t, []Edge{
+		{From: "a", To: "b", Latency: time.Millisecond * 10},
+		{From: "a", To: "c", Latency: time.Millisecond},
+		{From: "b", To: "d", Latency: time.Millisecond * 50},
+		{From: "c", To: "d"},
+	}, g.Edges())
+
+	assert.Equal(t, []string{"a"}, g.Path("a", "a"))
+	assert.Nil(t, g.Path("a", "e"))
+}
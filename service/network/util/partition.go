@@ -0,0 +1,137 @@
+package util
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/service/network"
+)
+
+var (
+	// PartitionExpiry is how long nodes which can't be reached are remembered
+	PartitionExpiry = time.Hour
+)
+
+// Partitions tracks the nodes of the network seen from a node to detect when the network splits
+// into components which can't reach each other. Nodes which drop out of the graph are remembered
+// with their last known peers, so the nodes lost together are reported as one component.
+type Partitions struct {
+	sync.RWMutex
+	// addresses are the last known addresses of the nodes, keyed by id
+	addresses map[string]string
+	// seen is the last time each node was reachable
+	seen map[string]time.Time
+	// links are the last known peers of each node
+	links map[string][]string
+	// components are the ids of the nodes in each partition, the reachable one first
+	components [][]string
+}
+
+// NewPartitions returns a new partition tracker
+func NewPartitions() *Partitions {
+	return &Partitions{
+		addresses: make(map[string]string),
+		seen:      make(map[string]time.Time),
+		links:     make(map[string][]string),
+	}
+}
+
+// Update updates the partitions from the graph of the node. It returns true if
+// the partitions changed i.e. the network split or nodes were reached again.
+func (p *Partitions) Update(root network.Node, now time.Time) bool {
+	graph := NewGraph(root)
+
+	p.Lock()
+	defer p.Unlock()
+
+	reachable := graph.Ids()
+	for _, id := range reachable {
+		p.addresses[id] = graph.Nodes[id].Address()
+		p.seen[id] = now
+		p.links[id] = graph.Peers(id)
+	}
+
+	// forget the nodes which haven't been reachable for a while
+	for id, seen := range p.seen {
+		if now.Sub(seen) > PartitionExpiry {
+			delete(p.addresses, id)
+			delete(p.seen, id)
+			delete(p.links, id)
+		}
+	}
+
+	components := [][]string{reachable}
+	visited := make(map[string]bool)
+	for _, id := range reachable {
+		visited[id] = true
+	}
+
+	// group the unreachable nodes by their last known links to each other
+	var lost []string
+	for id := range p.seen {
+		if !visited[id] {
+			lost = append(lost, id)
+		}
+	}
+	sort.Strings(lost)
+
+	for _, id := range lost {
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		var component []string
+		for queue := []string{id}; len(queue) > 0; queue = queue[1:] {
+			component = append(component, queue[0])
+			for _, peer := range p.links[queue[0]] {
+				if _, ok := p.seen[peer]; ok && !visited[peer] {
+					visited[peer] = true
+					queue = append(queue, peer)
+				}
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+
+	// order the lost components largest first
+	sort.SliceStable(components[1:], func(i, j int) bool {
+		return len(components[i+1]) > len(components[j+1])
+	})
+
+	changed := key(components) != key(p.components)
+	p.components = components
+	return changed
+}
+
+// Components returns the ids of the nodes in each partition. The first partition is the
+// one the node is in, the rest are the nodes it can no longer reach.
+func (p *Partitions) Components() [][]string {
+	p.RLock()
+	defer p.RUnlock()
+
+	components := make([][]string, 0, len(p.components))
+	for _, c := range p.components {
+		components = append(components, append([]string(nil), c...))
+	}
+	return components
+}
+
+// Address returns the last known address of the node
+func (p *Partitions) Address(id string) string {
+	p.RLock()
+	defer p.RUnlock()
+	return p.addresses[id]
+}
+
+// key returns a key identifying the components
+func key(components [][]string) string {
+	parts := make([]string, 0, len(components))
+	for _, c := range components {
+		parts = append(parts, strings.Join(c, ","))
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitions(t *testing.T) {
+	// a is linked to b, which is linked to c and d, and e is linked to d
+	e := &testNode{id: "e"}
+	d := &testNode{id: "d", peers: []network.Node{e}}
+	c := &testNode{id: "c"}
+	b := &testNode{id: "b", peers: []network.Node{c, d}}
+	a := &testNode{id: "a", peers: []network.Node{b}}
+
+	now := time.Now()
+	p := NewPartitions()
+
+	assert.True(t, p.Update(a, now))
+	assert.Equal(t, [][]string{{"a", "b", "c", "d", "e"}}, p.Components())
+	assert.False(t, p.Update(a, now))
+
+	// b loses its link to d, splitting d and e off
+	b.peers = []network.Node{c}
+	assert.True(t, p.Update(a, now.Add(time.Minute)))
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e"}}, p.Components())
+	assert.Equal(t, "d:8085", p.Address("d"))
+
+	// a loses b, splitting the rest off in turn
+	a.peers = nil
+	assert.True(t, p.Update(a, now.Add(time.Minute*2)))
+	assert.Equal(t, [][]string{{"a"}, {"b", "c"}, {"d", "e"}}, p.Components())
+
+	// the network heals
+	a.peers = []network.Node{b}
+	b.peers = []network.Node{c, d}
+	assert.True(t, p.Update(a, now.Add(time.Minute*3)))
+	assert.Equal(t, [][]string{{"a", "b", "c", "d", "e"}}, p.Components())
+
+	// nodes which aren't reached again are forgotten
+	a.peers = nil
+	assert.True(t, p.Update(a, now.Add(time.Minute*4)))
+	assert.True(t, p.Update(a, now.Add(time.Minute*4+PartitionExpiry)))
+	assert.Equal(t, [][]string{{"a"}}, p.Components())
+	assert.Empty(t, p.Address("b"))
+}
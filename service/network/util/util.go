@@ -1,6 +1,8 @@
 package util
 
 import (
+	"time"
+
 	pb "github.com/micro/micro/v3/proto/network"
 	rtrPb "github.com/micro/micro/v3/proto/router"
 	"github.com/micro/micro/v3/service/network"
@@ -19,6 +21,7 @@ func PeersToProto(node network.Node, depth uint) *pb.Peer {
 				Msg:   node.Status().Error().Msg(),
 			},
 		},
+		Latency: LatencyToProto(node.Latency()),
 	}
 
 	// set the network name if network is not nil
@@ -50,6 +53,7 @@ func peerProtoTopology(peer network.Node, depth uint) *pb.Peer {
 				Msg:   peer.Status().Error().Msg(),
 			},
 		},
+		Latency: LatencyToProto(peer.Latency()),
 	}
 
 	// set the network name if network is not nil
@@ -79,6 +83,18 @@ func peerProtoTopology(peer network.Node, depth uint) *pb.Peer {
 	return pbPeers
 }
 
+// LatencyToProto encodes the round trip times to the peers of a node as nanoseconds
+func LatencyToProto(latency map[string]time.Duration) map[string]int64 {
+	if len(latency) == 0 {
+		return nil
+	}
+	pbLatency := make(map[string]int64, len(latency))
+	for id, d := range latency {
+		pbLatency[id] = int64(d)
+	}
+	return pbLatency
+}
+
 // RouteToProto encodes route into protobuf and returns it
 func RouteToProto(route router.Route) *rtrPb.Route {
 	return &rtrPb.Route{
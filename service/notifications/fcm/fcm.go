@@ -0,0 +1,123 @@
+// Package fcm sends the push notifications via firebase cloud messaging
+package fcm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/micro/micro/v3/service/notifications"
+)
+
+// Options of the fcm provider
+type Options struct {
+	// ServerKey of the firebase project
+	ServerKey string
+	// URL of the fcm api
+	URL string
+}
+
+// Option sets an attribute on Options
+type Option func(o *Options)
+
+// ServerKey sets the server key of the firebase project
+func ServerKey(k string) Option {
+	return func(o *Options) {
+		o.ServerKey = k
+	}
+}
+
+// URL sets the url of the fcm api
+func URL(u string) Option {
+	return func(o *Options) {
+		o.URL = u
+	}
+}
+
+// NewProvider returns a provider sending push notifications via fcm
+func NewProvider(opts ...Option) notifications.Provider {
+	options := Options{URL: "https://fcm.googleapis.com/fcm/send"}
+	for _, o := range opts {
+		o(&options)
+	}
+	return &provider{
+		options: options,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type provider struct {
+	options Options
+	client  *http.Client
+}
+
+type message struct {
+	To           string       `json:"to"`
+	Notification notification `json:"notification"`
+}
+
+type notification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body"`
+}
+
+type response struct {
+	Failure int `json:"failure"`
+	Results []struct {
+		Error string `json:"error"`
+	} `json:"results"`
+}
+
+func (p *provider) Channel() string {
+	return notifications.ChannelPush
+}
+
+func (p *provider) Send(m *notifications.Message) error {
+	if len(m.To) == 0 {
+		return notifications.ErrMissingRecipient
+	}
+
+	b, err := json.Marshal(&message{
+		To:           m.To,
+		Notification: notification{Title: m.Subject, Body: m.Body},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, p.options.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "key="+p.options.ServerKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fcm error %d", rsp.StatusCode)
+	}
+
+	// the messages which weren't sent e.g. to unregistered devices are reported in the results
+	var r response
+	if err := json.NewDecoder(rsp.Body).Decode(&r); err != nil {
+		return fmt.Errorf("error decoding the fcm response: %v", err)
+	}
+	if r.Failure > 0 {
+		for _, res := range r.Results {
+			if len(res.Error) > 0 {
+				return fmt.Errorf("fcm error: %s", res.Error)
+			}
+		}
+		return fmt.Errorf("fcm error")
+	}
+	return nil
+}
+
+func (p *provider) String() string {
+	return "fcm"
+}
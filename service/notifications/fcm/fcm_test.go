@@ -0,0 +1,48 @@
+package fcm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/micro/micro/v3/service/notifications"
+)
+
+func TestSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "key=secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var m message
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			t.Error(err)
+		}
+		if m.To == "unregistered" {
+			w.Write([]byte(`{"success": 0, "failure": 1, "results": [{"error": "NotRegistered"}]}`))
+			return
+		}
+		if m.Notification.Title != "Order shipped" || m.Notification.Body != "Your order is on its way" {
+			t.Errorf("Unexpected notification %+v", m.Notification)
+		}
+		w.Write([]byte(`{"success": 1, "failure": 0, "results": [{"message_id": "1"}]}`))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(ServerKey("secret"), URL(srv.URL))
+	msg := &notifications.Message{To: "device", Subject: "Order shipped", Body: "Your order is on its way"}
+	if err := p.Send(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	msg.To = "unregistered"
+	if err := p.Send(msg); err == nil || err.Error() != "fcm error: NotRegistered" {
+		t.Fatalf("Expected the device not to be registered, got %v", err)
+	}
+
+	p = NewProvider(ServerKey("wrong"), URL(srv.URL))
+	if err := p.Send(msg); err == nil {
+		t.Fatal("Expected the wrong key to be rejected")
+	}
+}
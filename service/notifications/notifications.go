@@ -0,0 +1,47 @@
+// Package notifications is for sending emails, sms and push notifications. The notifications
+// service sends them using the provider configured for each channel, and services send them by
+// calling the service or by publishing them to the Topic on the broker.
+package notifications
+
+import (
+	"errors"
+)
+
+// Topic is the broker topic the notifications service sends the notifications published to, the
+// body of each message is a notifications.SendRequest encoded in JSON
+const Topic = "notifications"
+
+// The channels notifications are sent on
+const (
+	// ChannelEmail notifications are sent to an email address
+	ChannelEmail = "email"
+	// ChannelSMS notifications are sent to a phone number
+	ChannelSMS = "sms"
+	// ChannelPush notifications are sent to the token of a device
+	ChannelPush = "push"
+)
+
+var (
+	// ErrMissingRecipient is returned when sending a message without a recipient
+	ErrMissingRecipient = errors.New("missing recipient")
+)
+
+// Provider sends the notifications of a channel e.g. via an email server or an sms gateway
+type Provider interface {
+	// Channel the provider sends notifications on
+	Channel() string
+	// Send a message
+	Send(m *Message) error
+	// String returns the name of the provider
+	String() string
+}
+
+// Message is a notification sent by a provider
+type Message struct {
+	// To is the recipient: an email address, a phone number or a device token
+	To string
+	// Subject of the message, the title of push notifications and not sent by sms
+	Subject string
+	// Body of the message
+	Body string
+}
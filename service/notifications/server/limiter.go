@@ -0,0 +1,43 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// RatePeriod is the period the rate limit of the notifications sent to a recipient applies to
+var RatePeriod = time.Minute
+
+// limiter limits the notifications sent to each recipient per period, counting them in fixed
+// windows of the period
+type limiter struct {
+	sync.Mutex
+	limit  int
+	window time.Time
+	counts map[string]int
+}
+
+func newLimiter(limit int) *limiter {
+	return &limiter{limit: limit, counts: make(map[string]int)}
+}
+
+// allow returns whether a notification can be sent to a recipient, counting it if so
+func (l *limiter) allow(key string) bool {
+	if l.limit <= 0 {
+		return true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	// the counts of the window before are dropped
+	if window := time.Now().Truncate(RatePeriod); !window.Equal(l.window) {
+		l.window = window
+		l.counts = make(map[string]int)
+	}
+	if l.counts[key] >= l.limit {
+		return false
+	}
+	l.counts[key]++
+	return true
+}
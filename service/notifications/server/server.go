@@ -0,0 +1,240 @@
+// Package server is the notifications service which sends the emails, sms and push notifications
+// of the services using the provider configured for each channel
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"text/template"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/notifications"
+	"github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/metrics"
+	"github.com/micro/micro/v3/service/notifications"
+	"github.com/micro/micro/v3/service/notifications/fcm"
+	"github.com/micro/micro/v3/service/notifications/smtp"
+	"github.com/micro/micro/v3/service/notifications/twilio"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	name = "notifications"
+	// table the templates are stored in, in the database of their namespace
+	table = "templates"
+)
+
+var (
+	// Flags specific to the notifications service
+	Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:    "smtp_address",
+			Usage:   "Set the address of the smtp server the emails are sent via, host:port. Emails aren't sent if blank",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_SMTP_ADDRESS"},
+		},
+		&cli.StringFlag{
+			Name:    "smtp_username",
+			Usage:   "Set the username to authenticate with the smtp server",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_SMTP_USERNAME"},
+		},
+		&cli.StringFlag{
+			Name:    "smtp_password",
+			Usage:   "Set the password to authenticate with the smtp server",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_SMTP_PASSWORD"},
+		},
+		&cli.StringFlag{
+			Name:    "smtp_from",
+			Usage:   "Set the address the emails are sent from",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_SMTP_FROM"},
+		},
+		&cli.StringFlag{
+			Name:    "twilio_account_sid",
+			Usage:   "Set the sid of the twilio account the sms are sent via. Sms aren't sent if blank",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_TWILIO_ACCOUNT_SID"},
+		},
+		&cli.StringFlag{
+			Name:    "twilio_auth_token",
+			Usage:   "Set the auth token of the twilio account",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_TWILIO_AUTH_TOKEN"},
+		},
+		&cli.StringFlag{
+			Name:    "twilio_from",
+			Usage:   "Set the phone number the sms are sent from",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_TWILIO_FROM"},
+		},
+		&cli.StringFlag{
+			Name:    "fcm_server_key",
+			Usage:   "Set the server key of the firebase project the push notifications are sent via. Push notifications aren't sent if blank",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_FCM_SERVER_KEY"},
+		},
+		&cli.IntFlag{
+			Name:    "rate_limit",
+			Usage:   "Set the max number of notifications sent to a recipient per minute, 0 for no limit",
+			EnvVars: []string{"MICRO_NOTIFICATIONS_RATE_LIMIT"},
+			Value:   10,
+		},
+	}
+)
+
+// Run the micro notifications service
+func Run(ctx *cli.Context) error {
+	srv := service.New(service.Name(name))
+
+	h := NewNotifications(ctx.Int("rate_limit"), providers(ctx)...)
+	pb.RegisterNotificationsHandler(srv.Server(), h)
+
+	// send the notifications published by services
+	sub, err := h.subscribe()
+	if err != nil {
+		log.Errorf("Error subscribing to notifications: %v", err)
+	}
+
+	if err := srv.Run(); err != nil {
+		log.Fatal(err)
+	}
+	if sub != nil {
+		sub.Unsubscribe()
+	}
+	return nil
+}
+
+// providers returns the providers configured by the flags
+func providers(ctx *cli.Context) []notifications.Provider {
+	var ps []notifications.Provider
+	if addr := ctx.String("smtp_address"); len(addr) > 0 {
+		ps = append(ps, smtp.NewProvider(
+			smtp.Address(addr),
+			smtp.Auth(ctx.String("smtp_username"), ctx.String("smtp_password")),
+			smtp.From(ctx.String("smtp_from")),
+		))
+	}
+	if sid := ctx.String("twilio_account_sid"); len(sid) > 0 {
+		ps = append(ps, twilio.NewProvider(
+			twilio.Account(sid, ctx.String("twilio_auth_token")),
+			twilio.From(ctx.String("twilio_from")),
+		))
+	}
+	if key := ctx.String("fcm_server_key"); len(key) > 0 {
+		ps = append(ps, fcm.NewProvider(fcm.ServerKey(key)))
+	}
+	for _, p := range ps {
+		log.Infof("Sending %v notifications via %v", p.Channel(), p)
+	}
+	return ps
+}
+
+// NewNotifications returns the handler of the notifications service, sending the notifications
+// via the providers and up to the limit per recipient per minute
+func NewNotifications(limit int, providers ...notifications.Provider) *Notifications {
+	n := &Notifications{
+		providers: make(map[string]notifications.Provider),
+		limiter:   newLimiter(limit),
+	}
+	for _, p := range providers {
+		n.providers[p.Channel()] = p
+	}
+	return n
+}
+
+// Notifications processes the RPC calls for the notifications, the templates of each namespace
+// are stored in the database of the namespace
+type Notifications struct {
+	providers map[string]notifications.Provider
+	limiter   *limiter
+}
+
+// Send a notification using the provider of its channel
+func (n *Notifications) Send(ctx context.Context, req *pb.SendRequest, rsp *pb.SendResponse) error {
+	ns, err := namespace.AuthorizeRequest(ctx, "notifications.Notifications.Send", req.Namespace)
+	if err != nil {
+		return err
+	}
+	return n.send("notifications.Notifications.Send", ns, req)
+}
+
+// subscribe to the notifications published to the topic. The broker doesn't identify the
+// publisher so the notifications are sent with the templates of the default namespace.
+func (n *Notifications) subscribe() (broker.Subscriber, error) {
+	return broker.Subscribe(notifications.Topic, func(msg *broker.Message) error {
+		var req pb.SendRequest
+		if err := json.Unmarshal(msg.Body, &req); err != nil {
+			log.Warnf("Error decoding notification: %v", err)
+			return nil
+		}
+		if err := n.send("notifications.Notifications.Send", namespace.DefaultNamespace, &req); err != nil {
+			log.Warnf("Error sending %v notification: %v", req.Channel, err)
+		}
+		return nil
+	})
+}
+
+// send renders a notification and sends it via the provider of its channel
+func (n *Notifications) send(method, ns string, req *pb.SendRequest) error {
+	p, ok := n.providers[req.Channel]
+	if !ok {
+		return errors.BadRequest(method, "No provider for the %q channel", req.Channel)
+	}
+	if len(req.To) == 0 {
+		return errors.BadRequest(method, "Missing recipient")
+	}
+
+	tmpl := &pb.Template{Subject: req.Subject, Body: req.Body}
+	if len(req.Template) > 0 {
+		var err error
+		if tmpl, err = readTemplate(ns, req.Template); err == store.ErrNotFound {
+			return errors.NotFound(method, "Template not found")
+		} else if err != nil {
+			return errors.InternalServerError(method, "Error reading the template: %v", err)
+		}
+	}
+
+	msg := &notifications.Message{To: req.To}
+	var err error
+	if msg.Subject, err = render(tmpl.Subject, req.Data); err != nil {
+		return errors.BadRequest(method, "Invalid subject: %v", err)
+	}
+	if msg.Body, err = render(tmpl.Body, req.Data); err != nil {
+		return errors.BadRequest(method, "Invalid body: %v", err)
+	}
+
+	if !n.limiter.allow(ns + "/" + req.Channel + "/" + req.To) {
+		n.count(p, "rate_limited")
+		return errors.TooManyRequests(method, "Too many notifications sent to the recipient")
+	}
+
+	if err := p.Send(msg); err != nil {
+		n.count(p, "failure")
+		return errors.InternalServerError(method, "Error sending the notification: %v", err)
+	}
+	n.count(p, "success")
+	return nil
+}
+
+// count the notifications sent via a provider by their result
+func (n *Notifications) count(p notifications.Provider, result string) {
+	if metrics.IsSet() {
+		metrics.Count("notifications.sent", 1, metrics.Tags{
+			"channel":  p.Channel(),
+			"provider": p.String(),
+			"result":   result,
+		})
+	}
+}
+
+// render executes a template with the data of a notification
+func render(text string, data map[string]string) (string, error) {
+	t, err := template.New("").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	b := bytes.NewBuffer(nil)
+	if err := t.Execute(b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/notifications"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/broker"
+	bmemory "github.com/micro/micro/v3/service/broker/memory"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/notifications"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProvider records the messages sent
+type testProvider struct {
+	sync.Mutex
+	sent []*notifications.Message
+}
+
+func (p *testProvider) Channel() string {
+	return notifications.ChannelEmail
+}
+
+func (p *testProvider) Send(m *notifications.Message) error {
+	p.Lock()
+	defer p.Unlock()
+	p.sent = append(p.sent, m)
+	return nil
+}
+
+func (p *testProvider) String() string {
+	return "test"
+}
+
+func (p *testProvider) last() *notifications.Message {
+	p.Lock()
+	defer p.Unlock()
+	if len(p.sent) == 0 {
+		return nil
+	}
+	return p.sent[len(p.sent)-1]
+}
+
+func TestNotifications(t *testing.T) {
+	defaultStore, defaultBroker := store.DefaultStore, broker.DefaultBroker
+	defer func() {
+		store.DefaultStore, broker.DefaultBroker = defaultStore, defaultBroker
+	}()
+	store.DefaultStore, broker.DefaultBroker = memory.NewStore(), bmemory.NewBroker()
+	assert.NoError(t, broker.DefaultBroker.Connect())
+	defer func(d time.Duration) { RatePeriod = d }(RatePeriod)
+	RatePeriod = time.Hour
+
+	p := new(testProvider)
+	n := NewNotifications(2, p)
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "svc", Issuer: "foo"})
+
+	// the subject and body are executed as templates
+	err := n.Send(ctx, &pb.SendRequest{
+		Channel: notifications.ChannelEmail,
+		To:      "john@example.com",
+		Subject: "Hi {{.name}}",
+		Body:    "Your code is {{.code}}{{.missing}}",
+		Data:    map[string]string{"name": "John", "code": "1234"},
+	}, new(pb.SendResponse))
+	assert.NoError(t, err)
+	assert.Equal(t, &notifications.Message{To: "john@example.com", Subject: "Hi John", Body: "Your code is 1234"}, p.last())
+
+	// saved templates are used by name in the namespace they were saved in
+	err = n.SaveTemplate(ctx, &pb.SaveTemplateRequest{Template: &pb.Template{
+		Name:    "welcome",
+		Subject: "Welcome {{.name}}",
+		Body:    "Thanks for signing up",
+	}}, new(pb.SaveTemplateResponse))
+	assert.NoError(t, err)
+	err = n.Send(ctx, &pb.SendRequest{
+		Channel:  notifications.ChannelEmail,
+		To:       "jane@example.com",
+		Template: "welcome",
+		Data:     map[string]string{"name": "Jane"},
+	}, new(pb.SendResponse))
+	assert.NoError(t, err)
+	assert.Equal(t, "Welcome Jane", p.last().Subject)
+
+	bar := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "svc", Issuer: "bar"})
+	err = n.Send(bar, &pb.SendRequest{Channel: notifications.ChannelEmail, To: "jane@example.com", Template: "welcome"}, new(pb.SendResponse))
+	assert.Equal(t, int32(404), errors.FromError(err).Code)
+
+	list := new(pb.ListTemplatesResponse)
+	assert.NoError(t, n.ListTemplates(ctx, &pb.ListTemplatesRequest{}, list))
+	assert.Len(t, list.Templates, 1)
+
+	err = n.SaveTemplate(ctx, &pb.SaveTemplateRequest{Template: &pb.Template{Name: "broken", Body: "{{.name"}}, new(pb.SaveTemplateResponse))
+	assert.Equal(t, int32(400), errors.FromError(err).Code)
+
+	// the notifications to a recipient are limited
+	err = n.Send(ctx, &pb.SendRequest{Channel: notifications.ChannelEmail, To: "john@example.com", Body: "again"}, new(pb.SendResponse))
+	assert.NoError(t, err)
+	err = n.Send(ctx, &pb.SendRequest{Channel: notifications.ChannelEmail, To: "john@example.com", Body: "again"}, new(pb.SendResponse))
+	assert.Equal(t, int32(429), errors.FromError(err).Code)
+
+	// the channels without a provider are rejected
+	err = n.Send(ctx, &pb.SendRequest{Channel: notifications.ChannelSMS, To: "+15551234567", Body: "hi"}, new(pb.SendResponse))
+	assert.Equal(t, int32(400), errors.FromError(err).Code)
+
+	// the notifications published to the topic are sent
+	sub, err := n.subscribe()
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+	b, _ := json.Marshal(&pb.SendRequest{Channel: notifications.ChannelEmail, To: "bob@example.com", Body: "published"})
+	assert.NoError(t, broker.Publish(notifications.Topic, &broker.Message{Body: b}))
+	for i := 0; i < 100 && p.last().To != "bob@example.com"; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, "published", p.last().Body)
+}
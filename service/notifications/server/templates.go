@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/notifications"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/store"
+)
+
+// SaveTemplate creates or updates a template of the notifications
+func (n *Notifications) SaveTemplate(ctx context.Context, req *pb.SaveTemplateRequest, rsp *pb.SaveTemplateResponse) error {
+	if req.Template == nil || len(req.Template.Name) == 0 {
+		return errors.BadRequest("notifications.Notifications.SaveTemplate", "Missing template name")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "notifications.Notifications.SaveTemplate", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// check the templates parse so the notifications using them don't fail to
+	if _, err := render(req.Template.Subject, nil); err != nil {
+		return errors.BadRequest("notifications.Notifications.SaveTemplate", "Invalid subject: %v", err)
+	}
+	if _, err := render(req.Template.Body, nil); err != nil {
+		return errors.BadRequest("notifications.Notifications.SaveTemplate", "Invalid body: %v", err)
+	}
+
+	b, err := json.Marshal(req.Template)
+	if err != nil {
+		return errors.InternalServerError("notifications.Notifications.SaveTemplate", "Error marshaling the template: %v", err)
+	}
+	rec := &store.Record{Key: req.Template.Name, Value: b}
+	if err := store.DefaultStore.Write(rec, store.WriteTo(ns, table)); err != nil {
+		return errors.InternalServerError("notifications.Notifications.SaveTemplate", "Error writing the template: %v", err)
+	}
+	return nil
+}
+
+// DeleteTemplate deletes a template
+func (n *Notifications) DeleteTemplate(ctx context.Context, req *pb.DeleteTemplateRequest, rsp *pb.DeleteTemplateResponse) error {
+	if len(req.Name) == 0 {
+		return errors.BadRequest("notifications.Notifications.DeleteTemplate", "Missing template name")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "notifications.Notifications.DeleteTemplate", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if _, err := readTemplate(ns, req.Name); err == store.ErrNotFound {
+		return errors.NotFound("notifications.Notifications.DeleteTemplate", "Template not found")
+	} else if err != nil {
+		return errors.InternalServerError("notifications.Notifications.DeleteTemplate", "Error reading the template: %v", err)
+	}
+	if err := store.DefaultStore.Delete(req.Name, store.DeleteFrom(ns, table)); err != nil {
+		return errors.InternalServerError("notifications.Notifications.DeleteTemplate", "Error deleting the template: %v", err)
+	}
+	return nil
+}
+
+// ListTemplates returns the templates, ordered by name
+func (n *Notifications) ListTemplates(ctx context.Context, req *pb.ListTemplatesRequest, rsp *pb.ListTemplatesResponse) error {
+	ns, err := namespace.AuthorizeRequest(ctx, "notifications.Notifications.ListTemplates", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	recs, err := store.DefaultStore.Read("", store.ReadFrom(ns, table), store.ReadPrefix())
+	if err != nil && err != store.ErrNotFound {
+		return errors.InternalServerError("notifications.Notifications.ListTemplates", "Error reading the templates: %v", err)
+	}
+
+	rsp.Templates = make([]*pb.Template, 0, len(recs))
+	for _, r := range recs {
+		var t pb.Template
+		if err := json.Unmarshal(r.Value, &t); err != nil {
+			return errors.InternalServerError("notifications.Notifications.ListTemplates", "Error unmarshaling the template: %v", err)
+		}
+		rsp.Templates = append(rsp.Templates, &t)
+	}
+	sort.Slice(rsp.Templates, func(i, j int) bool {
+		return rsp.Templates[i].Name < rsp.Templates[j].Name
+	})
+	return nil
+}
+
+func readTemplate(ns, name string) (*pb.Template, error) {
+	recs, err := store.DefaultStore.Read(name, store.ReadFrom(ns, table))
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, store.ErrNotFound
+	}
+	var t pb.Template
+	if err := json.Unmarshal(recs[0].Value, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
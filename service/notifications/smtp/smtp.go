@@ -0,0 +1,106 @@
+// Package smtp sends the email notifications via an smtp server
+package smtp
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+
+	"github.com/micro/micro/v3/service/notifications"
+)
+
+// Options of the smtp provider
+type Options struct {
+	// Address of the smtp server, host:port
+	Address string
+	// Username and Password to authenticate with, no authentication if blank
+	Username string
+	Password string
+	// From is the address the emails are sent from
+	From string
+}
+
+// Option sets an attribute on Options
+type Option func(o *Options)
+
+// Address sets the address of the smtp server
+func Address(a string) Option {
+	return func(o *Options) {
+		o.Address = a
+	}
+}
+
+// Auth sets the credentials the provider authenticates with
+func Auth(username, password string) Option {
+	return func(o *Options) {
+		o.Username = username
+		o.Password = password
+	}
+}
+
+// From sets the address the emails are sent from
+func From(f string) Option {
+	return func(o *Options) {
+		o.From = f
+	}
+}
+
+// NewProvider returns a provider sending emails via an smtp server
+func NewProvider(opts ...Option) notifications.Provider {
+	options := Options{Address: "localhost:25"}
+	for _, o := range opts {
+		o(&options)
+	}
+	return &provider{options: options, send: smtp.SendMail}
+}
+
+type provider struct {
+	options Options
+	// send is smtp.SendMail, replaced in the tests
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (p *provider) Channel() string {
+	return notifications.ChannelEmail
+}
+
+func (p *provider) Send(m *notifications.Message) error {
+	if len(m.To) == 0 {
+		return notifications.ErrMissingRecipient
+	}
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return fmt.Errorf("invalid email address %q: %v", m.To, err)
+	}
+
+	var auth smtp.Auth
+	if len(p.options.Username) > 0 {
+		host, _, err := net.SplitHostPort(p.options.Address)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", p.options.Username, p.options.Password, host)
+	}
+	return p.send(p.options.Address, auth, p.options.From, []string{to.Address}, message(p.options.From, to, m))
+}
+
+func (p *provider) String() string {
+	return "smtp"
+}
+
+// message returns the email of a message, the subject is encoded so it can't add headers
+func message(from string, to *mail.Address, m *notifications.Message) []byte {
+	b := bytes.NewBuffer(nil)
+	fmt.Fprintf(b, "From: %s\r\n", from)
+	fmt.Fprintf(b, "To: %s\r\n", to.String())
+	fmt.Fprintf(b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
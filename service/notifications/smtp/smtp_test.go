@@ -0,0 +1,49 @@
+package smtp
+
+import (
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/micro/micro/v3/service/notifications"
+)
+
+func TestSend(t *testing.T) {
+	p := NewProvider(Address("mail.example.com:587"), Auth("user", "pass"), From("noreply@example.com")).(*provider)
+
+	var sent []string
+	var msg string
+	p.send = func(addr string, a smtp.Auth, from string, to []string, b []byte) error {
+		if addr != "mail.example.com:587" || a == nil || from != "noreply@example.com" {
+			t.Fatalf("Unexpected server %v auth %v from %v", addr, a, from)
+		}
+		sent = to
+		msg = string(b)
+		return nil
+	}
+
+	err := p.Send(&notifications.Message{
+		To:      "John <john@example.com>",
+		Subject: "Welcome\r\nBcc: eve@example.com",
+		Body:    "Hello\nJohn",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 || sent[0] != "john@example.com" {
+		t.Fatalf("Expected the email to be sent to john@example.com, got %v", sent)
+	}
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Fatalf("Expected the subject not to add headers, got %q", msg)
+	}
+	if !strings.HasSuffix(msg, "\r\n\r\nHello\r\nJohn") {
+		t.Fatalf("Unexpected body of %q", msg)
+	}
+
+	if err := p.Send(&notifications.Message{To: "john"}); err == nil {
+		t.Fatal("Expected an invalid address to be rejected")
+	}
+	if err := p.Send(&notifications.Message{}); err != notifications.ErrMissingRecipient {
+		t.Fatalf("Expected %v, got %v", notifications.ErrMissingRecipient, err)
+	}
+}
@@ -0,0 +1,113 @@
+// Package twilio sends the sms notifications via twilio
+package twilio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/micro/micro/v3/service/notifications"
+)
+
+// Options of the twilio provider
+type Options struct {
+	// AccountSID and AuthToken of the twilio account
+	AccountSID string
+	AuthToken  string
+	// From is the phone number the sms are sent from
+	From string
+	// URL of the twilio api
+	URL string
+}
+
+// Option sets an attribute on Options
+type Option func(o *Options)
+
+// Account sets the sid and auth token of the twilio account
+func Account(sid, token string) Option {
+	return func(o *Options) {
+		o.AccountSID = sid
+		o.AuthToken = token
+	}
+}
+
+// From sets the phone number the sms are sent from
+func From(f string) Option {
+	return func(o *Options) {
+		o.From = f
+	}
+}
+
+// URL sets the url of the twilio api
+func URL(u string) Option {
+	return func(o *Options) {
+		o.URL = u
+	}
+}
+
+// NewProvider returns a provider sending sms via twilio
+func NewProvider(opts ...Option) notifications.Provider {
+	options := Options{URL: "https://api.twilio.com"}
+	for _, o := range opts {
+		o(&options)
+	}
+	return &provider{
+		options: options,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type provider struct {
+	options Options
+	client  *http.Client
+}
+
+func (p *provider) Channel() string {
+	return notifications.ChannelSMS
+}
+
+func (p *provider) Send(m *notifications.Message) error {
+	if len(m.To) == 0 {
+		return notifications.ErrMissingRecipient
+	}
+
+	form := url.Values{}
+	form.Set("To", m.To)
+	form.Set("From", p.options.From)
+	form.Set("Body", m.Body)
+
+	u := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", p.options.URL, url.PathEscape(p.options.AccountSID))
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.options.AccountSID, p.options.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode >= 200 && rsp.StatusCode < 300 {
+		return nil
+	}
+
+	// twilio describes the errors in the body
+	b, _ := ioutil.ReadAll(rsp.Body)
+	var e struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(b, &e); err == nil && len(e.Message) > 0 {
+		return fmt.Errorf("twilio error %d: %s", rsp.StatusCode, e.Message)
+	}
+	return fmt.Errorf("twilio error %d", rsp.StatusCode)
+}
+
+func (p *provider) String() string {
+	return "twilio"
+}
@@ -0,0 +1,42 @@
+package twilio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/micro/micro/v3/service/notifications"
+)
+
+func TestSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "AC123" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+			t.Errorf("Unexpected path %v", r.URL.Path)
+		}
+		if r.FormValue("To") == "+15550000000" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": 21211, "message": "The 'To' number is not a valid phone number."}`))
+			return
+		}
+		if r.FormValue("From") != "+15551234567" || r.FormValue("Body") != "Your code is 1234" {
+			t.Errorf("Unexpected sms %v", r.Form)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	p := NewProvider(Account("AC123", "secret"), From("+15551234567"), URL(srv.URL))
+	if err := p.Send(&notifications.Message{To: "+15557654321", Body: "Your code is 1234"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := p.Send(&notifications.Message{To: "+15550000000", Body: "Your code is 1234"})
+	if err == nil || !strings.Contains(err.Error(), "not a valid phone number") {
+		t.Fatalf("Expected the error of twilio, got %v", err)
+	}
+}
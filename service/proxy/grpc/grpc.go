@@ -20,10 +20,10 @@ package grpc
 import (
 	"context"
 	"io"
-	"strings"
 
 	"github.com/micro/micro/v3/internal/codec"
 	"github.com/micro/micro/v3/internal/codec/bytes"
+	mnet "github.com/micro/micro/v3/internal/net"
 	"github.com/micro/micro/v3/service/client"
 	grpcc "github.com/micro/micro/v3/service/client/grpc"
 	"github.com/micro/micro/v3/service/errors"
@@ -110,7 +110,7 @@ func (p *Proxy) ServeRequest(ctx context.Context, req server.Request, rsp server
 	// call a specific backend
 	if len(p.Endpoint) > 0 {
 		// address:port
-		if parts := strings.Split(p.Endpoint, ":"); len(parts) > 1 {
+		if mnet.IsHostPort(p.Endpoint) {
 			opts = append(opts, client.WithAddress(p.Endpoint))
 			// use as service name
 		} else {
@@ -22,17 +22,21 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/micro/micro/v3/internal/codec"
 	"github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/debug/trace"
+	mnet "github.com/micro/micro/v3/internal/net"
 	"github.com/micro/micro/v3/internal/selector"
 	"github.com/micro/micro/v3/internal/selector/roundrobin"
 	"github.com/micro/micro/v3/service/client"
 	grpcc "github.com/micro/micro/v3/service/client/grpc"
 	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/debug"
 	"github.com/micro/micro/v3/service/errors"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/proxy"
@@ -205,26 +209,43 @@ func (p *Proxy) getLink(r router.Route) (client.Client, error) {
 }
 
 func (p *Proxy) getRoute(ctx context.Context, service string) ([]router.Route, error) {
+	// trace the lookup, the routes are only looked up in the router if they're not cached
+	_, span := debug.DefaultTracer.Start(ctx, "Router.Lookup")
+	span.Type = trace.SpanTypeRequestOutbound
+	span.Metadata["service"] = service
+	defer debug.DefaultTracer.Finish(span)
+
 	// lookup the route cache first
 	p.RLock()
 	cached, ok := p.Routes[service]
 	p.RUnlock()
 	if ok {
-		return p.filterRoutes(ctx, toSlice(cached)), nil
+		routes := p.filterRoutes(ctx, toSlice(cached))
+		span.Metadata["cached"] = "true"
+		span.Metadata["routes"] = strconv.Itoa(len(routes))
+		return routes, nil
 	}
 
 	// cache routes for the service
 	routes, err := p.cacheRoutes(service)
 	if err != nil {
+		span.Metadata["error"] = err.Error()
 		return nil, err
 	}
 
-	return p.filterRoutes(ctx, routes), nil
+	routes = p.filterRoutes(ctx, routes)
+	span.Metadata["cached"] = "false"
+	span.Metadata["routes"] = strconv.Itoa(len(routes))
+	return routes, nil
 }
 
 func (p *Proxy) cacheRoutes(service string) ([]router.Route, error) {
-	// lookup the routes in the router
-	results, err := p.Router.Lookup(service, router.LookupNetwork("*"))
+	// lookup the routes in the router, the routes of all the links if we're routing over them
+	opts := []router.LookupOption{router.LookupNetwork("*")}
+	if len(p.Links) > 0 {
+		opts = append(opts, router.LookupLink("*"))
+	}
+	results, err := p.Router.Lookup(service, opts...)
 	if err != nil {
 		// assumption that we're ok with stale routes
 		logger.Debugf("Failed to lookup route for %s: %v", service, err)
@@ -367,6 +388,28 @@ func (p *Proxy) ProcessMessage(ctx context.Context, msg server.Message) error {
 
 // ServeRequest honours the server.Router interface
 func (p *Proxy) ServeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
+	// don't store traces for debug
+	if strings.HasPrefix(req.Endpoint(), "Debug.") {
+		return p.routeRequest(ctx, req, rsp)
+	}
+
+	// trace the request through the proxy, the span is the parent of the call to the service
+	ctx, span := debug.DefaultTracer.Start(ctx, "Proxy.ServeRequest")
+	span.Type = trace.SpanTypeRequestInbound
+	span.Metadata["service"] = req.Service()
+	span.Metadata["endpoint"] = req.Endpoint()
+
+	err := p.routeRequest(ctx, req, rsp)
+	if err != nil {
+		span.Metadata["error"] = err.Error()
+	}
+	debug.DefaultTracer.Finish(span)
+
+	return err
+}
+
+// routeRequest looks up the routes of the service and forwards the request
+func (p *Proxy) routeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
 	// determine if its local routing
 	var local bool
 	// address to call
@@ -400,7 +443,7 @@ func (p *Proxy) ServeRequest(ctx context.Context, req server.Request, rsp server
 	// call a specific backend endpoint either by name or address
 	if len(p.Endpoint) > 0 {
 		// address:port
-		if parts := strings.Split(p.Endpoint, ":"); len(parts) > 1 {
+		if mnet.IsHostPort(p.Endpoint) {
 			addresses = []string{p.Endpoint}
 		} else {
 			// get route for endpoint from router
@@ -446,6 +489,7 @@ func (p *Proxy) ServeRequest(ctx context.Context, req server.Request, rsp server
 
 		// set address if available via routes or specific endpoint
 		if len(routes) > 0 {
+			client.LocateRoutes(p.Selector, routes)
 			addresses = toNodes(routes)
 			opts = append(opts, client.WithAddress(addresses...))
 		}
@@ -651,6 +695,11 @@ func NewProxy(opts ...proxy.Option) proxy.Proxy {
 		p.Router = registry.NewRouter()
 	}
 
+	// use the selector of the client so it can be set with a flag
+	if p.Selector == nil {
+		p.Selector = p.Client.Options().Selector
+	}
+
 	if p.Selector == nil {
 		p.Selector = roundrobin.NewSelector()
 	}
@@ -0,0 +1,171 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/events"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/server"
+)
+
+var (
+	// AuditTopic is the topic the audit records are published to
+	AuditTopic = "proxy.audit"
+	// AuditBuffer is the number of audit records buffered to be sent, the records are dropped
+	// once it's full rather than slow down the calls
+	AuditBuffer = 1024
+)
+
+// AuditRecord is the record of a call made through the proxy
+type AuditRecord struct {
+	// Timestamp is when the call was made
+	Timestamp time.Time `json:"timestamp"`
+	// Caller is the id of the account which made the call, blank if it wasn't authenticated
+	Caller string `json:"caller,omitempty"`
+	// CallerType is the type of the account e.g. user or service
+	CallerType string `json:"caller_type,omitempty"`
+	// Namespace the call was made in
+	Namespace string `json:"namespace"`
+	// Service and Endpoint which were called
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	// Latency of the call in milliseconds
+	Latency float64 `json:"latency_ms"`
+	// RequestBytes and ResponseBytes are the size of the bodies of the messages
+	RequestBytes  int64 `json:"request_bytes"`
+	ResponseBytes int64 `json:"response_bytes"`
+	// Code is the status code of the error the call failed with, 0 if it succeeded
+	Code int32 `json:"code"`
+	// Error is the detail of the error the call failed with
+	Error string `json:"error,omitempty"`
+}
+
+// auditor sends the audit records of a sample of the calls to the events stream or the logs
+type auditor struct {
+	// sink is events or log
+	sink string
+	// rate is the fraction of the calls recorded
+	rate    float64
+	records chan *AuditRecord
+	dropped uint64
+}
+
+func newAuditor(sink string, rate float64) *auditor {
+	a := &auditor{
+		sink:    sink,
+		rate:    rate,
+		records: make(chan *AuditRecord, AuditBuffer),
+	}
+	go a.run()
+	return a
+}
+
+// sampled returns true if the call is to be recorded
+func (a *auditor) sampled() bool {
+	return a.rate >= 1 || rand.Float64() < a.rate
+}
+
+// record queues the record to be sent, dropping it if the buffer is full
+func (a *auditor) record(r *AuditRecord) {
+	select {
+	case a.records <- r:
+	default:
+		if atomic.AddUint64(&a.dropped, 1)%uint64(AuditBuffer) == 1 {
+			logger.Warnf("Audit buffer full, %d records dropped", atomic.LoadUint64(&a.dropped))
+		}
+	}
+}
+
+func (a *auditor) run() {
+	for r := range a.records {
+		if a.sink == "log" {
+			b, _ := json.Marshal(r)
+			logger.Infof("Audit %s", b)
+			continue
+		}
+		if err := events.Publish(AuditTopic, r, events.WithTimestamp(r.Timestamp)); err != nil {
+			logger.Errorf("Error publishing the audit record of %v.%v: %v", r.Service, r.Endpoint, err)
+		}
+	}
+}
+
+// countingRequest counts the bytes of the messages read from the request
+type countingRequest struct {
+	server.Request
+	bytes int64
+}
+
+func (c *countingRequest) Read() ([]byte, error) {
+	b, err := c.Request.Read()
+	atomic.AddInt64(&c.bytes, int64(len(b)))
+	return b, err
+}
+
+// countingResponse counts the bytes of the messages written to the response
+type countingResponse struct {
+	server.Response
+	bytes int64
+}
+
+func (c *countingResponse) Write(b []byte) error {
+	atomic.AddInt64(&c.bytes, int64(len(b)))
+	return c.Response.Write(b)
+}
+
+// auditHandler wraps a server handler to record the caller, target, latency, size and result of a
+// sample of the calls. It runs after the auth handler, so the calls it rejects aren't recorded.
+func auditHandler(a *auditor) server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if !a.sampled() {
+				return h(ctx, req, rsp)
+			}
+
+			creq := &countingRequest{Request: req}
+			var crsp *countingResponse
+			if r, ok := rsp.(server.Response); ok {
+				crsp = &countingResponse{Response: r}
+				rsp = crsp
+			}
+
+			start := time.Now()
+			err := h(ctx, creq, rsp)
+
+			// the namespace is always set by the auth handler
+			ns, _ := metadata.Get(ctx, "Micro-Namespace")
+			r := &AuditRecord{
+				Timestamp:    start,
+				Namespace:    ns,
+				Service:      req.Service(),
+				Endpoint:     req.Endpoint(),
+				Latency:      float64(time.Since(start)) / float64(time.Millisecond),
+				RequestBytes: atomic.LoadInt64(&creq.bytes),
+			}
+			if crsp != nil {
+				r.ResponseBytes = atomic.LoadInt64(&crsp.bytes)
+			}
+			if acc, ok := auth.AccountFromContext(ctx); ok {
+				r.Caller = acc.ID
+				r.CallerType = acc.Type
+			}
+			if err != nil {
+				e := errors.FromError(err)
+				r.Code, r.Error = e.Code, e.Detail
+				// the errors which aren't micro errors are internal
+				if r.Code == 0 {
+					r.Code = 500
+				}
+			}
+			a.record(r)
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/server"
+)
+
+type testRequest struct {
+	server.Request
+}
+
+func (t *testRequest) Service() string       { return "foo" }
+func (t *testRequest) Endpoint() string      { return "Foo.Bar" }
+func (t *testRequest) Read() ([]byte, error) { return []byte("request"), nil }
+
+type testResponse struct {
+	server.Response
+}
+
+func (t *testResponse) Write(b []byte) error { return nil }
+
+func TestAudit(t *testing.T) {
+	a := &auditor{rate: 1, records: make(chan *AuditRecord, 1)}
+	h := auditHandler(a)(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		req.Read()
+		rsp.(server.Response).Write([]byte("response body"))
+		return errors.NotFound("foo", "not found")
+	})
+
+	ctx := metadata.Set(context.Background(), "Micro-Namespace", "micro")
+	ctx = auth.ContextWithAccount(ctx, &auth.Account{ID: "bar", Type: "service"})
+	if err := h(ctx, &testRequest{}, &testResponse{}); err == nil {
+		t.Fatal("Expected the error of the call to be returned")
+	}
+
+	r := <-a.records
+	if r.Caller != "bar" || r.CallerType != "service" || r.Namespace != "micro" {
+		t.Errorf("Expected the caller to be recorded, got %+v", r)
+	}
+	if r.Service != "foo" || r.Endpoint != "Foo.Bar" {
+		t.Errorf("Expected the target to be recorded, got %+v", r)
+	}
+	if r.RequestBytes != 7 || r.ResponseBytes != 13 {
+		t.Errorf("Expected the bytes to be counted, got %v and %v", r.RequestBytes, r.ResponseBytes)
+	}
+	if r.Code != 404 || r.Error != "not found" {
+		t.Errorf("Expected the error to be recorded, got %v %v", r.Code, r.Error)
+	}
+
+	// the calls out of the sample aren't recorded
+	a.rate = 0
+	h(ctx, &testRequest{}, &testResponse{})
+	if len(a.records) != 0 {
+		t.Error("Expected the call not to be recorded")
+	}
+}
@@ -69,7 +69,11 @@ func authHandler() server.HandlerWrapper {
 				return errors.InternalServerError("proxy", "Error authorizing request: %v", err)
 			}
 
-			// The user is authorised, allow the call
+			// The user is authorised, allow the call. The account is set in the context for the
+			// wrappers after auth e.g. the quotas
+			if account != nil {
+				ctx = auth.ContextWithAccount(ctx, account)
+			}
 			return h(ctx, req, rsp)
 		}
 	}
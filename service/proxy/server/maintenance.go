@@ -0,0 +1,39 @@
+package proxy
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/internal/maintenance"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/server"
+)
+
+// maintenanceHandler wraps a server handler to return a 503 for the calls to services in
+// maintenance, other than the health checks
+func maintenanceHandler() server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if maintenance.HealthCheck(req.Endpoint()) {
+				return h(ctx, req, rsp)
+			}
+
+			// the namespace is always set by the auth handler
+			ns, _ := metadata.Get(ctx, "Micro-Namespace")
+			m, err := maintenance.Check(ns, req.Service())
+			if err != nil {
+				logger.Errorf("Error checking the maintenance of %v in %v: %v", req.Service(), ns, err)
+			}
+			if m == nil {
+				return h(ctx, req, rsp)
+			}
+
+			msg := m.Message
+			if len(msg) == 0 {
+				msg = maintenance.DefaultMessage
+			}
+			return errors.ServiceUnavailable(req.Service(), "%v", msg)
+		}
+	}
+}
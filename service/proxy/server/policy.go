@@ -0,0 +1,32 @@
+package proxy
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/internal/network/policy"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/server"
+)
+
+// policyHandler wraps a server handler to return a 403 for the calls denied by the network
+// policies, the caller is the service of the account the authHandler set in the context
+func policyHandler() server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			acc, _ := auth.AccountFromContext(ctx)
+			from := policy.Caller(acc)
+			ok, p, err := policy.Check(from, req.Service())
+			if err != nil {
+				logger.Errorf("Error checking the network policies: %v", err)
+			}
+			if ok {
+				return h(ctx, req, rsp)
+			} else if p == nil {
+				return errors.InternalServerError(req.Service(), "Unable to check the network policies")
+			}
+			return errors.Forbidden(req.Service(), "Calls from %v to %v are denied by network policy %v", from, req.Service(), p.ID)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package proxy
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/internal/quota"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/server"
+	"google.golang.org/grpc"
+	gmetadata "google.golang.org/grpc/metadata"
+)
+
+// quotaHandler wraps a server handler to count the calls against the quotas of the account and
+// namespace, calls over a quota fail with a 429. The usage of the quota is sent in the headers.
+func quotaHandler() server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			// the namespace is always set by the auth handler
+			ns, _ := metadata.Get(ctx, "Micro-Namespace")
+			var id string
+			if acc, ok := auth.AccountFromContext(ctx); ok {
+				id = acc.ID
+			}
+
+			usage, err := quota.Check(ns, id)
+			if err != nil && err != quota.ErrExceeded {
+				// don't fail the calls if the quotas can't be read
+				logger.Errorf("Error checking the quota of %v in %v: %v", id, ns, err)
+				return h(ctx, req, rsp)
+			}
+			if usage != nil {
+				grpc.SetHeader(ctx, gmetadata.New(usage.Headers()))
+			}
+			if err == quota.ErrExceeded {
+				return errors.TooManyRequests(req.Service(), "Quota exceeded, it resets at %v", usage.Reset.UTC())
+			}
+			return h(ctx, req, rsp)
+		}
+	}
+}
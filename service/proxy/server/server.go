@@ -12,7 +12,10 @@ import (
 	"github.com/micro/micro/v3/internal/api/server/acme/certmagic"
 	"github.com/micro/micro/v3/internal/helper"
 	"github.com/micro/micro/v3/internal/muxer"
+	"github.com/micro/micro/v3/internal/overload"
 	"github.com/micro/micro/v3/internal/sync/memory"
+	"github.com/micro/micro/v3/internal/wrapper"
+	"github.com/micro/micro/v3/plugin"
 	"github.com/micro/micro/v3/service"
 	bmem "github.com/micro/micro/v3/service/broker/memory"
 	muclient "github.com/micro/micro/v3/service/client"
@@ -171,9 +174,44 @@ func Run(ctx *cli.Context) error {
 		p = grpc.NewProxy(popts...)
 	}
 
+	// limit the connections and shed the calls over the limits before they're authenticated
+	if n := ctx.Int("max_connections"); n > 0 {
+		serverOpts = append(serverOpts, sgrpc.MaxConn(n))
+	}
+	if n := ctx.Int("max_in_flight"); n > 0 {
+		limiter := overload.NewLimiter("proxy", n, ctx.Int("max_queue_depth"))
+		serverOpts = append(serverOpts, server.WrapHandler(wrapper.LimitHandler(limiter)))
+	}
+
 	// wrap the proxy using the proxy's authHandler
 	authOpt := server.WrapHandler(authHandler())
 	serverOpts = append(serverOpts, authOpt)
+
+	// record the calls of the authenticated callers, including those rejected below
+	switch sink := ctx.String("audit"); sink {
+	case "":
+	case "events", "log":
+		a := newAuditor(sink, ctx.Float64("audit_sample_rate"))
+		serverOpts = append(serverOpts, server.WrapHandler(auditHandler(a)))
+	default:
+		log.Fatalf("Unsupported audit sink %s, expected events or log", sink)
+	}
+
+	// return a 503 for the services in maintenance before the calls are counted
+	serverOpts = append(serverOpts, server.WrapHandler(maintenanceHandler()))
+
+	// reject the calls between the services the network policies deny
+	serverOpts = append(serverOpts, server.WrapHandler(policyHandler()))
+
+	// enforce the quotas of the accounts authenticated
+	serverOpts = append(serverOpts, server.WrapHandler(quotaHandler()))
+
+	// apply the global wrappers after auth
+	for _, w := range plugin.Wrappers() {
+		if w.Handler != nil {
+			serverOpts = append(serverOpts, server.WrapHandler(w.Handler))
+		}
+	}
 	serverOpts = append(serverOpts, server.WithRouter(p))
 
 	if len(Endpoint) > 0 {
@@ -228,5 +266,16 @@ var (
 			Usage:   "Set the endpoint to route to e.g greeter or localhost:9090",
 			EnvVars: []string{"MICRO_PROXY_ENDPOINT"},
 		},
+		&cli.StringFlag{
+			Name:    "audit",
+			Usage:   "Set where the audit records of the proxied calls are sent: events or log, disabled if blank",
+			EnvVars: []string{"MICRO_PROXY_AUDIT"},
+		},
+		&cli.Float64Flag{
+			Name:    "audit_sample_rate",
+			Usage:   "Set the fraction of the proxied calls audited, between 0 and 1",
+			EnvVars: []string{"MICRO_PROXY_AUDIT_SAMPLE_RATE"},
+			Value:   1,
+		},
 	)
 )
@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"time"
+)
+
+const (
+	// DeprecatedKey is the metadata key marking a service or an endpoint deprecated
+	DeprecatedKey = "deprecated"
+	// SunsetKey is the metadata key of the time a deprecated service or endpoint is removed
+	SunsetKey = "sunset"
+)
+
+// Deprecate marks the metadata of a service or endpoint deprecated and returns it. The sunset is
+// when it will be removed, zero if that isn't decided yet.
+func Deprecate(md map[string]string, sunset time.Time) map[string]string {
+	if md == nil {
+		md = make(map[string]string)
+	}
+	md[DeprecatedKey] = "true"
+	if !sunset.IsZero() {
+		md[SunsetKey] = sunset.UTC().Format(time.RFC3339)
+	}
+	return md
+}
+
+// Deprecated returns whether the metadata is marked deprecated and the sunset, zero if not set
+func Deprecated(md map[string]string) (time.Time, bool) {
+	if md[DeprecatedKey] != "true" {
+		return time.Time{}, false
+	}
+	sunset, _ := time.Parse(time.RFC3339, md[SunsetKey])
+	return sunset, true
+}
+
+// EndpointDeprecated returns whether the endpoint of the service, or the service itself, is
+// deprecated and the sunset of the one which is
+func EndpointDeprecated(s *Service, endpoint string) (time.Time, bool) {
+	for _, ep := range s.Endpoints {
+		if ep.Name != endpoint {
+			continue
+		}
+		if sunset, ok := Deprecated(ep.Metadata); ok {
+			return sunset, true
+		}
+	}
+	// the metadata of the service is set on its nodes
+	for _, node := range s.Nodes {
+		if sunset, ok := Deprecated(node.Metadata); ok {
+			return sunset, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,499 @@
+// Package file provides a registry persisted to a json file, for local development. The services
+// run locally share the file so they find each other without mdns, and the registrations survive
+// the services being restarted.
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/service/registry"
+)
+
+var (
+	// DefaultPath is the default path of the registry file
+	DefaultPath = filepath.Join(os.TempDir(), "micro", "registry.json")
+	// PollInterval is how often the file is read for the changes made by the other processes
+	PollInterval = time.Second
+
+	// lockTimeout is how long to wait for the lock of the file
+	lockTimeout = 5 * time.Second
+	// staleLock is the age of a lock left behind by a process which exited holding it
+	staleLock = 10 * time.Second
+	// sendEventTime is how long to wait for a watcher to receive an event
+	sendEventTime = 10 * time.Millisecond
+)
+
+// node is a registered node and the unix nano time it expires at, 0 if it doesn't
+type node struct {
+	Node   *registry.Node `json:"node"`
+	Expiry int64          `json:"expiry"`
+}
+
+// record is a version of a service
+type record struct {
+	Name      string               `json:"name"`
+	Version   string               `json:"version"`
+	Metadata  map[string]string    `json:"metadata"`
+	Endpoints []*registry.Endpoint `json:"endpoints"`
+	Nodes     map[string]*node     `json:"nodes"`
+}
+
+// records are the records by domain, service name and version
+type records map[string]map[string]map[string]*record
+
+type fileRegistry struct {
+	options registry.Options
+	path    string
+
+	// mtx serializes the writes of the process, the lock file those of the processes
+	mtx sync.Mutex
+
+	// pmtx serializes the polls so the events are sent in order
+	pmtx sync.Mutex
+
+	sync.RWMutex
+	watchers map[*watcher]bool
+	// last are the services of the last poll by domain, name and version
+	last    map[string]*registry.Service
+	polling bool
+}
+
+// NewRegistry returns a registry persisted to a json file
+func NewRegistry(opts ...registry.Option) registry.Registry {
+	r := &fileRegistry{
+		path:     DefaultPath,
+		watchers: make(map[*watcher]bool),
+	}
+	r.Init(opts...)
+	return r
+}
+
+func (r *fileRegistry) Init(opts ...registry.Option) error {
+	for _, o := range opts {
+		o(&r.options)
+	}
+	if r.options.Context != nil {
+		if p, ok := r.options.Context.Value(pathKey{}).(string); ok && len(p) > 0 {
+			r.path = p
+		}
+	}
+	return nil
+}
+
+func (r *fileRegistry) Options() registry.Options {
+	return r.options
+}
+
+func (r *fileRegistry) Register(s *registry.Service, opts ...registry.RegisterOption) error {
+	var options registry.RegisterOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	if len(options.Domain) == 0 {
+		options.Domain = registry.DefaultDomain
+	}
+
+	var expiry int64
+	if options.TTL > 0 {
+		expiry = time.Now().Add(options.TTL).UnixNano()
+	}
+
+	err := r.update(func(recs records) {
+		if recs[options.Domain] == nil {
+			recs[options.Domain] = make(map[string]map[string]*record)
+		}
+		if recs[options.Domain][s.Name] == nil {
+			recs[options.Domain][s.Name] = make(map[string]*record)
+		}
+		rec, ok := recs[options.Domain][s.Name][s.Version]
+		if !ok {
+			rec = &record{Name: s.Name, Version: s.Version, Nodes: make(map[string]*node)}
+			recs[options.Domain][s.Name][s.Version] = rec
+		}
+		rec.Metadata = s.Metadata
+		rec.Endpoints = s.Endpoints
+
+		for _, n := range s.Nodes {
+			// a service restarted on the same address replaces the node it left behind
+			for id, old := range rec.Nodes {
+				if id != n.Id && old.Node.Address == n.Address {
+					delete(rec.Nodes, id)
+				}
+			}
+			rec.Nodes[n.Id] = &node{Node: n, Expiry: expiry}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	r.poll()
+	return nil
+}
+
+func (r *fileRegistry) Deregister(s *registry.Service, opts ...registry.DeregisterOption) error {
+	var options registry.DeregisterOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	if len(options.Domain) == 0 {
+		options.Domain = registry.DefaultDomain
+	}
+
+	err := r.update(func(recs records) {
+		rec, ok := recs[options.Domain][s.Name][s.Version]
+		if !ok {
+			return
+		}
+		for _, n := range s.Nodes {
+			delete(rec.Nodes, n.Id)
+		}
+		prune(recs)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.poll()
+	return nil
+}
+
+func (r *fileRegistry) GetService(name string, opts ...registry.GetOption) ([]*registry.Service, error) {
+	var options registry.GetOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	if len(options.Domain) == 0 {
+		options.Domain = registry.DefaultDomain
+	}
+
+	recs, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var services []*registry.Service
+	for domain, srvs := range recs {
+		if options.Domain != registry.WildcardDomain && options.Domain != domain {
+			continue
+		}
+		for _, rec := range srvs[name] {
+			services = append(services, toService(rec, domain))
+		}
+	}
+	if len(services) == 0 {
+		return nil, registry.ErrNotFound
+	}
+	return services, nil
+}
+
+func (r *fileRegistry) ListServices(opts ...registry.ListOption) ([]*registry.Service, error) {
+	var options registry.ListOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	if len(options.Domain) == 0 {
+		options.Domain = registry.DefaultDomain
+	}
+
+	recs, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var services []*registry.Service
+	for domain, srvs := range recs {
+		if options.Domain != registry.WildcardDomain && options.Domain != domain {
+			continue
+		}
+		for _, versions := range srvs {
+			for _, rec := range versions {
+				services = append(services, toService(rec, domain))
+			}
+		}
+	}
+	return services, nil
+}
+
+func (r *fileRegistry) Watch(opts ...registry.WatchOption) (registry.Watcher, error) {
+	var options registry.WatchOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	if len(options.Domain) == 0 {
+		options.Domain = registry.DefaultDomain
+	}
+
+	w := &watcher{
+		options: options,
+		res:     make(chan *registry.Result),
+		exit:    make(chan bool),
+	}
+
+	r.Lock()
+	r.watchers[w] = true
+	start := !r.polling
+	r.polling = true
+	r.Unlock()
+
+	// the changes made by the other processes are found by polling the file
+	if start {
+		r.poll()
+		go func() {
+			for range time.Tick(PollInterval) {
+				r.poll()
+			}
+		}()
+	}
+
+	go func() {
+		<-w.exit
+		r.Lock()
+		delete(r.watchers, w)
+		r.Unlock()
+	}()
+
+	return w, nil
+}
+
+func (r *fileRegistry) String() string {
+	return "file"
+}
+
+// poll reads the file and sends the watchers the changes since the last poll
+func (r *fileRegistry) poll() {
+	r.RLock()
+	polling := r.polling
+	r.RUnlock()
+	if !polling {
+		return
+	}
+
+	r.pmtx.Lock()
+	defer r.pmtx.Unlock()
+
+	recs, err := r.read()
+	if err != nil {
+		return
+	}
+	services := make(map[string]*registry.Service)
+	for domain, srvs := range recs {
+		for _, versions := range srvs {
+			for _, rec := range versions {
+				services[domain+"/"+rec.Name+"/"+rec.Version] = toService(rec, domain)
+			}
+		}
+	}
+
+	r.Lock()
+	last := r.last
+	r.last = services
+	watchers := make([]*watcher, 0, len(r.watchers))
+	for w := range r.watchers {
+		watchers = append(watchers, w)
+	}
+	r.Unlock()
+
+	// the services registered before the first poll aren't changes
+	if last == nil {
+		return
+	}
+
+	var results []*registry.Result
+	for key, s := range services {
+		old, ok := last[key]
+		if !ok {
+			results = append(results, &registry.Result{Action: "create", Service: s})
+		} else if !equal(old, s) {
+			results = append(results, &registry.Result{Action: "update", Service: s})
+		}
+	}
+	for key, s := range last {
+		if _, ok := services[key]; !ok {
+			results = append(results, &registry.Result{Action: "delete", Service: s})
+		}
+	}
+
+	for _, res := range results {
+		for _, w := range watchers {
+			select {
+			case w.res <- res:
+			case <-w.exit:
+			case <-time.After(sendEventTime):
+			}
+		}
+	}
+}
+
+// update the records in the file, holding the lock of the file so the processes sharing it
+// don't overwrite the changes of each other
+func (r *fileRegistry) update(fn func(records)) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	recs, err := r.read()
+	if err != nil {
+		return err
+	}
+	fn(recs)
+
+	b, err := json.MarshalIndent(recs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write a temp file and rename it so the file is never read half written
+	f, err := ioutil.TempFile(filepath.Dir(r.path), ".registry")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), r.path)
+}
+
+// lock the file, returning the func to unlock it
+func (r *fileRegistry) lock() (func(), error) {
+	r.mtx.Lock()
+	if err := os.MkdirAll(filepath.Dir(r.path), 0700); err != nil {
+		r.mtx.Unlock()
+		return nil, err
+	}
+
+	path := r.path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() {
+				os.Remove(path)
+				r.mtx.Unlock()
+			}, nil
+		}
+		if !os.IsExist(err) {
+			r.mtx.Unlock()
+			return nil, err
+		}
+
+		// a process which exited holding the lock left it behind
+		if fi, err := os.Stat(path); err == nil && time.Since(fi.ModTime()) > staleLock {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			r.mtx.Unlock()
+			return nil, fmt.Errorf("timeout locking %s", r.path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// read the records in the file, without the nodes expired
+func (r *fileRegistry) read() (records, error) {
+	recs := make(records)
+	b, err := ioutil.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return recs, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &recs); err != nil {
+		return nil, fmt.Errorf("error decoding %s: %v", r.path, err)
+	}
+
+	now := time.Now().UnixNano()
+	for _, srvs := range recs {
+		for _, versions := range srvs {
+			for _, rec := range versions {
+				for id, n := range rec.Nodes {
+					if n.Expiry > 0 && n.Expiry < now {
+						delete(rec.Nodes, id)
+					}
+				}
+			}
+		}
+	}
+	prune(recs)
+	return recs, nil
+}
+
+// prune the services without nodes
+func prune(recs records) {
+	for domain, srvs := range recs {
+		for name, versions := range srvs {
+			for version, rec := range versions {
+				if len(rec.Nodes) == 0 {
+					delete(versions, version)
+				}
+			}
+			if len(versions) == 0 {
+				delete(srvs, name)
+			}
+		}
+		if len(srvs) == 0 {
+			delete(recs, domain)
+		}
+	}
+}
+
+// toService returns the service of a record, the domain is set in the metadata so the watchers
+// of the wildcard domain know the domain of the service
+func toService(rec *record, domain string) *registry.Service {
+	metadata := make(map[string]string, len(rec.Metadata)+1)
+	for k, v := range rec.Metadata {
+		metadata[k] = v
+	}
+	metadata["domain"] = domain
+
+	s := &registry.Service{
+		Name:      rec.Name,
+		Version:   rec.Version,
+		Metadata:  metadata,
+		Endpoints: rec.Endpoints,
+		Nodes:     make([]*registry.Node, 0, len(rec.Nodes)),
+	}
+	for _, n := range rec.Nodes {
+		s.Nodes = append(s.Nodes, n.Node)
+	}
+	return s
+}
+
+// equal returns whether the services registered are the same, ignoring the order of the nodes
+func equal(a, b *registry.Service) bool {
+	if len(a.Nodes) != len(b.Nodes) {
+		return false
+	}
+	nodes := make(map[string]*registry.Node, len(a.Nodes))
+	for _, n := range a.Nodes {
+		nodes[n.Id] = n
+	}
+	for _, n := range b.Nodes {
+		if !jsonEqual(nodes[n.Id], n) {
+			return false
+		}
+	}
+	return jsonEqual(a.Metadata, b.Metadata) && jsonEqual(a.Endpoints, b.Endpoints)
+}
+
+func jsonEqual(a, b interface{}) bool {
+	ab, _ := json.Marshal(a)
+	bb, _ := json.Marshal(b)
+	return string(ab) == string(bb)
+}
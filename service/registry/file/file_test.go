@@ -0,0 +1,121 @@
+package file
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/registry"
+)
+
+func testService(id, address string) *registry.Service {
+	return &registry.Service{
+		Name:    "foo",
+		Version: "latest",
+		Nodes:   []*registry.Node{{Id: id, Address: address}},
+	}
+}
+
+func TestFileRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.json")
+	// two registries sharing the file, as if in two processes
+	a, b := NewRegistry(Path(path)), NewRegistry(Path(path))
+
+	if err := a.Register(testService("foo-1", "10.0.0.1:8080")); err != nil {
+		t.Fatal(err)
+	}
+	srvs, err := b.GetService("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srvs) != 1 || len(srvs[0].Nodes) != 1 || srvs[0].Nodes[0].Id != "foo-1" {
+		t.Fatalf("Expected the service registered by the other registry, got %+v", srvs)
+	}
+
+	// the service restarted on the same address replaces its node
+	if err := b.Register(testService("foo-2", "10.0.0.1:8080")); err != nil {
+		t.Fatal(err)
+	}
+	srvs, err = a.GetService("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srvs[0].Nodes) != 1 || srvs[0].Nodes[0].Id != "foo-2" {
+		t.Fatalf("Expected the node to be replaced, got %+v", srvs[0].Nodes)
+	}
+
+	if _, err := a.GetService("foo", registry.GetDomain("other")); err != registry.ErrNotFound {
+		t.Fatalf("Expected not found in another domain, got %v", err)
+	}
+	if srvs, err := a.ListServices(registry.ListDomain(registry.WildcardDomain)); err != nil || len(srvs) != 1 {
+		t.Fatalf("Expected one service in the wildcard domain, got %v %v", srvs, err)
+	}
+
+	if err := a.Deregister(testService("foo-2", "10.0.0.1:8080")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.GetService("foo"); err != registry.ErrNotFound {
+		t.Fatalf("Expected the service to be deregistered, got %v", err)
+	}
+}
+
+func TestFileRegistryTTL(t *testing.T) {
+	r := NewRegistry(Path(filepath.Join(t.TempDir(), "registry.json")))
+	if err := r.Register(testService("foo-1", "10.0.0.1:8080"), registry.RegisterTTL(50*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetService("foo"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := r.GetService("foo"); err != registry.ErrNotFound {
+		t.Fatalf("Expected the node to expire, got %v", err)
+	}
+}
+
+func TestFileRegistryWatch(t *testing.T) {
+	defer func(d time.Duration) { PollInterval = d }(PollInterval)
+	PollInterval = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "registry.json")
+	a, b := NewRegistry(Path(path)), NewRegistry(Path(path))
+
+	w, err := a.Watch(registry.WatchService("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	results := make(chan *registry.Result, 10)
+	go func() {
+		for {
+			res, err := w.Next()
+			if err != nil {
+				return
+			}
+			results <- res
+		}
+	}()
+
+	expect := func(action string) {
+		select {
+		case res := <-results:
+			if res.Action != action || res.Service.Name != "foo" {
+				t.Fatalf("Expected %s of foo, got %s of %s", action, res.Action, res.Service.Name)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %s of foo", action)
+		}
+	}
+
+	// the changes made by the other registry are found by polling
+	b.Register(testService("foo-1", "10.0.0.1:8080"))
+	expect("create")
+	b.Register(&registry.Service{Name: "bar", Version: "latest", Nodes: []*registry.Node{{Id: "bar-1"}}})
+	b.Register(testService("foo-2", "10.0.0.2:8080"))
+	expect("update")
+	b.Deregister(testService("foo-1", "10.0.0.1:8080"))
+	expect("update")
+	b.Deregister(testService("foo-2", "10.0.0.2:8080"))
+	expect("delete")
+}
@@ -0,0 +1,19 @@
+package file
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/service/registry"
+)
+
+type pathKey struct{}
+
+// Path sets the path of the registry file, the services sharing the file find each other
+func Path(p string) registry.Option {
+	return func(o *registry.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, pathKey{}, p)
+	}
+}
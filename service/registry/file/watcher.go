@@ -0,0 +1,38 @@
+package file
+
+import (
+	"github.com/micro/micro/v3/service/registry"
+)
+
+type watcher struct {
+	options registry.WatchOptions
+	res     chan *registry.Result
+	exit    chan bool
+}
+
+func (w *watcher) Next() (*registry.Result, error) {
+	for {
+		select {
+		case r := <-w.res:
+			if len(w.options.Service) > 0 && w.options.Service != r.Service.Name {
+				continue
+			}
+
+			// only send the event if watching the wildcard or the domain of the service
+			if w.options.Domain == registry.WildcardDomain || w.options.Domain == r.Service.Metadata["domain"] {
+				return r, nil
+			}
+		case <-w.exit:
+			return nil, registry.ErrWatcherStopped
+		}
+	}
+}
+
+func (w *watcher) Stop() {
+	select {
+	case <-w.exit:
+		return
+	default:
+		close(w.exit)
+	}
+}
@@ -355,12 +355,15 @@ func (m *Registry) GetService(name string, opts ...registry.GetOption) ([]*regis
 	// if it's a wildcard domain, return from all domains
 	if options.Domain == registry.WildcardDomain {
 		m.RLock()
-		recs := m.records
+		domains := make([]string, 0, len(m.records))
+		for domain := range m.records {
+			domains = append(domains, domain)
+		}
 		m.RUnlock()
 
 		var services []*registry.Service
 
-		for domain := range recs {
+		for _, domain := range domains {
 			srvs, err := m.GetService(name, append(opts, registry.GetDomain(domain))...)
 			if err == registry.ErrNotFound {
 				continue
@@ -417,12 +420,15 @@ func (m *Registry) ListServices(opts ...registry.ListOption) ([]*registry.Servic
 	// if it's a wildcard domain, list from all domains
 	if options.Domain == registry.WildcardDomain {
 		m.RLock()
-		recs := m.records
+		domains := make([]string, 0, len(m.records))
+		for domain := range m.records {
+			domains = append(domains, domain)
+		}
 		m.RUnlock()
 
 		var services []*registry.Service
 
-		for domain := range recs {
+		for _, domain := range domains {
 			srvs, err := m.ListServices(append(opts, registry.ListDomain(domain))...)
 			if err != nil {
 				return nil, err
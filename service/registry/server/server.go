@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/micro/micro/v3/internal/wrapper"
 	pb "github.com/micro/micro/v3/proto/registry"
 	"github.com/micro/micro/v3/service"
 	log "github.com/micro/micro/v3/service/logger"
@@ -96,6 +97,11 @@ func Run(ctx *cli.Context) error {
 		srvOpts = append(srvOpts, service.Address(address))
 	}
 
+	// serve the control-plane requests before the watches when overloaded
+	if limit := ctx.Int("priority_limit"); limit > 0 {
+		srvOpts = append(srvOpts, service.WrapHandler(wrapper.PriorityHandler(limit)))
+	}
+
 	// new service
 	srv := service.New(srvOpts...)
 	// get server id
@@ -0,0 +1,167 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrUnknownResolver is returned for the external routes of a resolver which isn't registered
+	ErrUnknownResolver = errors.New("unknown resolver")
+)
+
+// Resolver resolves the addresses of the services outside of micro e.g. plain DNS names, lists of
+// IPs or kubernetes services. The routes to them are external routes, with the target of the
+// resolver as their address prefixed by its scheme e.g. dns://payments.example.com:443
+type Resolver interface {
+	// Resolve returns the addresses of the target
+	Resolve(target string) ([]string, error)
+}
+
+var (
+	resolversMtx sync.RWMutex
+	resolvers    = map[string]Resolver{
+		"dns":        dnsResolver{},
+		"static":     staticResolver{},
+		"kubernetes": kubernetesResolver{},
+	}
+)
+
+// RegisterResolver registers the resolver of the external routes with the scheme
+func RegisterResolver(scheme string, r Resolver) {
+	resolversMtx.Lock()
+	defer resolversMtx.Unlock()
+	resolvers[scheme] = r
+}
+
+// HasResolver returns true if a resolver is registered with the scheme
+func HasResolver(scheme string) bool {
+	resolversMtx.RLock()
+	defer resolversMtx.RUnlock()
+	_, ok := resolvers[scheme]
+	return ok
+}
+
+// ParseExternal returns the scheme and target of the address of an external route, ok is false
+// if the route isn't external
+func ParseExternal(address string) (scheme, target string, ok bool) {
+	i := strings.Index(address, "://")
+	if i <= 0 {
+		return "", "", false
+	}
+	return address[:i], address[i+3:], true
+}
+
+// IsExternal returns true if the route is to a service outside of micro
+func IsExternal(r Route) bool {
+	_, _, ok := ParseExternal(r.Address)
+	return ok
+}
+
+// Resolve returns the addresses of an external route
+func Resolve(address string) ([]string, error) {
+	scheme, target, ok := ParseExternal(address)
+	if !ok {
+		return []string{address}, nil
+	}
+
+	resolversMtx.RLock()
+	r, ok := resolvers[scheme]
+	resolversMtx.RUnlock()
+	if !ok {
+		return nil, ErrUnknownResolver
+	}
+	return r.Resolve(target)
+}
+
+// ResolveExternal returns the routes with the external routes replaced by a route for each of the
+// addresses they resolve to, the routes which fail to resolve are skipped unless none are left
+func ResolveExternal(routes []Route) ([]Route, error) {
+	var result []Route
+	var rerr error
+
+	for _, r := range routes {
+		if !IsExternal(r) {
+			result = append(result, r)
+			continue
+		}
+
+		addrs, err := Resolve(r.Address)
+		if err != nil {
+			rerr = fmt.Errorf("failed resolving %s: %v", r.Address, err)
+			continue
+		}
+		for _, addr := range addrs {
+			route := r
+			route.Address = addr
+			result = append(result, route)
+		}
+	}
+
+	if len(result) == 0 && rerr != nil {
+		return nil, rerr
+	}
+	return result, nil
+}
+
+// dnsResolver resolves the A records of host:port or the SRV records of a name without a port
+type dnsResolver struct{}
+
+func (dnsResolver) Resolve(target string) ([]string, error) {
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		_, srvs, err := net.LookupSRV("", "", target)
+		if err != nil {
+			return nil, err
+		}
+		addrs := make([]string, 0, len(srvs))
+		for _, srv := range srvs {
+			addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))))
+		}
+		return addrs, nil
+	}
+
+	ips, err := net.LookupHost(host)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.JoinHostPort(ip, port))
+	}
+	return addrs, nil
+}
+
+// staticResolver resolves a comma separated list of addresses
+type staticResolver struct{}
+
+func (staticResolver) Resolve(target string) ([]string, error) {
+	var addrs []string
+	for _, addr := range strings.Split(target, ",") {
+		if addr = strings.TrimSpace(addr); len(addr) > 0 {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New("no addresses")
+	}
+	return addrs, nil
+}
+
+// kubernetesResolver resolves a kubernetes service as name.namespace:port using the cluster DNS
+type kubernetesResolver struct{}
+
+func (kubernetesResolver) Resolve(target string) ([]string, error) {
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(host, ".") {
+		host += ".default"
+	}
+	return dnsResolver{}.Resolve(net.JoinHostPort(host+".svc.cluster.local", port))
+}
@@ -0,0 +1,55 @@
+package router
+
+import (
+	"errors"
+	"testing"
+)
+
+type testResolver struct{}
+
+func (testResolver) Resolve(target string) ([]string, error) {
+	if target == "down" {
+		return nil, errors.New("down")
+	}
+	return []string{target + ":1", target + ":2"}, nil
+}
+
+func TestResolveExternal(t *testing.T) {
+	RegisterResolver("test", testResolver{})
+
+	routes := []Route{
+		{Service: "foo", Address: "10.0.0.1:8080"},
+		{Service: "bar", Address: "test://bar"},
+		{Service: "bar", Address: "test://down"},
+		{Service: "bar", Address: "static://10.0.0.2:80, 10.0.0.3:80"},
+	}
+	if !IsExternal(routes[1]) || IsExternal(routes[0]) {
+		t.Fatal("Expected only the routes with a resolver target to be external")
+	}
+
+	resolved, err := ResolveExternal(routes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var addrs []string
+	for _, r := range resolved {
+		addrs = append(addrs, r.Address)
+	}
+	expected := []string{"10.0.0.1:8080", "bar:1", "bar:2", "10.0.0.2:80", "10.0.0.3:80"}
+	if len(addrs) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, addrs)
+	}
+	for i := range expected {
+		if addrs[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, addrs)
+		}
+	}
+
+	// the error is returned when none of the routes resolve
+	if _, err := ResolveExternal(routes[2:3]); err == nil {
+		t.Fatal("Expected an error when no routes resolve")
+	}
+	if _, err := Resolve("unknown://foo"); err != ErrUnknownResolver {
+		t.Fatalf("Expected an unknown resolver, got %v", err)
+	}
+}
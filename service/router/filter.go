@@ -0,0 +1,81 @@
+package router
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ServiceFilter allows or denies the routes by their service, for the routes advertised to the
+// network or accepted from it. The services are matched by patterns such as foo* or internal.*
+type ServiceFilter struct {
+	// Allow the services matching the patterns, all the services if empty
+	Allow []string
+	// Deny the services matching the patterns, even if allowed
+	Deny []string
+}
+
+// ParseServiceFilter parses a filter in the format service=foo*,deny=internal.* which allows the
+// services matching the service patterns except the ones matching the deny patterns. A blank
+// filter is nil and allows all the services.
+func ParseServiceFilter(s string) (*ServiceFilter, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, nil
+	}
+
+	f := new(ServiceFilter)
+	for _, rule := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(rule), "=", 2)
+		if len(parts) != 2 || len(parts[1]) == 0 {
+			return nil, fmt.Errorf("Invalid filter rule %q, expected service=pattern or deny=pattern", rule)
+		}
+		if _, err := path.Match(parts[1], ""); err != nil {
+			return nil, fmt.Errorf("Invalid filter pattern %q: %v", parts[1], err)
+		}
+		switch parts[0] {
+		case "service", "allow":
+			f.Allow = append(f.Allow, parts[1])
+		case "deny":
+			f.Deny = append(f.Deny, parts[1])
+		default:
+			return nil, fmt.Errorf("Invalid filter rule %q, expected service=pattern or deny=pattern", rule)
+		}
+	}
+	return f, nil
+}
+
+// Match returns true if the filter allows the service, a nil filter allows all of them
+func (f *ServiceFilter) Match(service string) bool {
+	if f == nil {
+		return true
+	}
+	for _, p := range f.Deny {
+		if ok, _ := path.Match(p, service); ok {
+			return false
+		}
+	}
+	if len(f.Allow) == 0 {
+		return true
+	}
+	for _, p := range f.Allow {
+		if ok, _ := path.Match(p, service); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the filter in the format parsed by ParseServiceFilter
+func (f *ServiceFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	rules := make([]string, 0, len(f.Allow)+len(f.Deny))
+	for _, p := range f.Allow {
+		rules = append(rules, "service="+p)
+	}
+	for _, p := range f.Deny {
+		rules = append(rules, "deny="+p)
+	}
+	return strings.Join(rules, ",")
+}
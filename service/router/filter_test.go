@@ -0,0 +1,45 @@
+package router
+
+import "testing"
+
+func TestServiceFilter(t *testing.T) {
+	f, err := ParseServiceFilter("service=foo*,service=bar,deny=foo.internal*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for service, allowed := range map[string]bool{
+		"foo":              true,
+		"foo.api":          true,
+		"bar":              true,
+		"baz":              false,
+		"foo.internal":     false,
+		"foo.internal.api": false,
+	} {
+		if f.Match(service) != allowed {
+			t.Fatalf("Expected %v to be allowed %v", service, allowed)
+		}
+	}
+	if s := f.String(); s != "service=foo*,service=bar,deny=foo.internal*" {
+		t.Fatalf("Expected the filter to round trip, got %v", s)
+	}
+
+	// only denying allows the other services
+	f, err = ParseServiceFilter("deny=internal.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Match("foo") || f.Match("internal.auth") {
+		t.Fatal("Expected only the internal services to be denied")
+	}
+
+	// a blank filter allows all the services
+	if f, err = ParseServiceFilter(""); err != nil || f != nil || !f.Match("foo") {
+		t.Fatalf("Expected a blank filter to allow all the services, got %v %v", f, err)
+	}
+
+	for _, s := range []string{"foo*", "allow=", "owner=foo", "deny=[foo"} {
+		if _, err := ParseServiceFilter(s); err == nil {
+			t.Fatalf("Expected %q to be rejected", s)
+		}
+	}
+}
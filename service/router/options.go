@@ -16,6 +16,8 @@ package router
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/micro/micro/v3/service/registry"
@@ -38,6 +40,58 @@ type Options struct {
 	Context context.Context
 	// Cache routes
 	Cache bool
+	// Advertise is the strategy the routes are advertised to the network with
+	Advertise Strategy
+	// AdvertiseInterval is how often the route changes are flushed to the network, 0 flushes them
+	// as they happen
+	AdvertiseInterval time.Duration
+	// AdvertiseTTL is how long the routes learned from the network are kept without being
+	// advertised again, 0 keeps them until their router leaves the network
+	AdvertiseTTL time.Duration
+	// AdvertiseFilter filters the routes advertised to the network, nil advertises all of them
+	AdvertiseFilter *ServiceFilter
+	// AcceptFilter filters the routes accepted from the network, nil accepts all of them
+	AcceptFilter *ServiceFilter
+}
+
+// Strategy is the strategy the routes are advertised to the network with
+type Strategy int
+
+const (
+	// AdvertiseAll advertises all the routes in the table
+	AdvertiseAll Strategy = iota
+	// AdvertiseBest advertises the best route of each service
+	AdvertiseBest
+	// AdvertiseLocal advertises the routes of the local services only
+	AdvertiseLocal
+	// AdvertiseNone doesn't advertise any routes
+	AdvertiseNone
+)
+
+// String returns human readable strategy
+func (s Strategy) String() string {
+	switch s {
+	case AdvertiseAll:
+		return "all"
+	case AdvertiseBest:
+		return "best"
+	case AdvertiseLocal:
+		return "local"
+	case AdvertiseNone:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseStrategy returns the strategy of the name: all, best, local or none
+func ParseStrategy(name string) (Strategy, error) {
+	for _, s := range []Strategy{AdvertiseAll, AdvertiseBest, AdvertiseLocal, AdvertiseNone} {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return AdvertiseAll, fmt.Errorf("Unknown advertise strategy %s, expected all, best, local or none", name)
 }
 
 // Id sets Router Id
@@ -82,6 +136,41 @@ func Cache() Option {
 	}
 }
 
+// Advertise sets the strategy the routes are advertised with
+func Advertise(s Strategy) Option {
+	return func(o *Options) {
+		o.Advertise = s
+	}
+}
+
+// AdvertiseInterval sets how often the route changes are advertised
+func AdvertiseInterval(d time.Duration) Option {
+	return func(o *Options) {
+		o.AdvertiseInterval = d
+	}
+}
+
+// AdvertiseTTL sets how long the advertised routes live
+func AdvertiseTTL(d time.Duration) Option {
+	return func(o *Options) {
+		o.AdvertiseTTL = d
+	}
+}
+
+// AdvertiseFilter sets the filter of the routes advertised to the network
+func AdvertiseFilter(f *ServiceFilter) Option {
+	return func(o *Options) {
+		o.AdvertiseFilter = f
+	}
+}
+
+// AcceptFilter sets the filter of the routes accepted from the network
+func AcceptFilter(f *ServiceFilter) Option {
+	return func(o *Options) {
+		o.AcceptFilter = f
+	}
+}
+
 // DefaultOptions returns router default options
 func DefaultOptions() Options {
 	return Options{
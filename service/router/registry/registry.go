@@ -30,6 +30,9 @@ var (
 	RefreshInterval = time.Second * 120
 	// PruneInterval is how often we prune the routing table
 	PruneInterval = time.Second * 10
+
+	// create a logger
+	log = logger.NewHelper(logger.DefaultLogger).WithFields(map[string]interface{}{"package": "router"})
 )
 
 // rtr implements router interface
@@ -178,7 +181,7 @@ func (r *rtr) manageRoutes(service *registry.Service, action, network string) er
 
 	// create the routes in the table
 	for _, route := range routes {
-		logger.Tracef("Creating route %v domain: %v", route, network)
+		log.Tracef("Creating route %v domain: %v", route, network)
 		if err := r.manageRoute(route, action); err != nil {
 			return err
 		}
@@ -205,7 +208,7 @@ func (r *rtr) loadRoutes(reg registry.Registry) error {
 
 		// if the routes exist save them
 		if len(routes) > 0 {
-			logger.Tracef("Creating routes for service %v domain: %v", service, domain)
+			log.Tracef("Creating routes for service %v domain: %v", service, domain)
 			for _, rt := range routes {
 				err := r.table.Create(rt)
 
@@ -215,7 +218,7 @@ func (r *rtr) loadRoutes(reg registry.Registry) error {
 				}
 
 				if err != nil {
-					logger.Errorf("Error creating route for service %v in domain %v: %v", service, domain, err)
+					log.Errorf("Error creating route for service %v in domain %v: %v", service, domain, err)
 				}
 			}
 			continue
@@ -226,7 +229,7 @@ func (r *rtr) loadRoutes(reg registry.Registry) error {
 		// get the service to retrieve all its info
 		srvs, err := reg.GetService(service.Name, registry.GetDomain(domain))
 		if err != nil {
-			logger.Tracef("Failed to get service %s domain: %s", service.Name, domain)
+			log.Tracef("Failed to get service %s domain: %s", service.Name, domain)
 			continue
 		}
 
@@ -235,7 +238,7 @@ func (r *rtr) loadRoutes(reg registry.Registry) error {
 			routes := r.createRoutes(srv, domain)
 
 			if len(routes) > 0 {
-				logger.Tracef("Creating routes for service %v domain: %v", srv, domain)
+				log.Tracef("Creating routes for service %v domain: %v", srv, domain)
 				for _, rt := range routes {
 					err := r.table.Create(rt)
 
@@ -245,7 +248,7 @@ func (r *rtr) loadRoutes(reg registry.Registry) error {
 					}
 
 					if err != nil {
-						logger.Errorf("Error creating route for service %v in domain %v: %v", service, domain, err)
+						log.Errorf("Error creating route for service %v in domain %v: %v", service, domain, err)
 					}
 				}
 			}
@@ -286,18 +289,19 @@ func (r *rtr) Lookup(service string, opts ...router.LookupOption) ([]router.Rout
 		if len(routes) == 0 {
 			return nil, router.ErrRouteNotFound
 		}
-		return routes, nil
+		// resolve the addresses of the services outside of micro
+		return router.ResolveExternal(routes)
 	}
 
 	// lookup the route
-	logger.Tracef("Fetching route for %s domain: %v", service, registry.WildcardDomain)
+	log.Tracef("Fetching route for %s domain: %v", service, registry.WildcardDomain)
 
 	services, err := r.options.Registry.GetService(service, registry.GetDomain(registry.WildcardDomain))
 	if err == registry.ErrNotFound {
-		logger.Tracef("Failed to find route for %s", service)
+		log.Tracef("Failed to find route for %s", service)
 		return nil, router.ErrRouteNotFound
 	} else if err != nil {
-		logger.Tracef("Failed to find route for %s: %v", service, err)
+		log.Tracef("Failed to find route for %s: %v", service, err)
 		return nil, fmt.Errorf("failed getting services: %v", err)
 	}
 
@@ -356,11 +360,11 @@ func (r *rtr) watchRegistry(w registry.Watcher) error {
 
 		// don't process nil entries
 		if res.Service == nil {
-			logger.Trace("Received a nil service")
+			log.Trace("Received a nil service")
 			continue
 		}
 
-		logger.Tracef("Router dealing with next route %s %+v\n", res.Action, res.Service)
+		log.Tracef("Router dealing with next route %s %+v\n", res.Action, res.Service)
 
 		// get the services domain from metadata. Fallback to wildcard.
 		domain := getDomain(res.Service)
@@ -436,7 +440,7 @@ func (r *rtr) start() error {
 
 				// load new routes
 				if err := r.loadRoutes(r.options.Registry); err != nil {
-					logger.Debugf("failed refreshing registry routes: %s", err)
+					log.Debugf("failed refreshing registry routes: %s", err)
 					// in this don't prune
 					continue
 				}
@@ -462,11 +466,11 @@ func (r *rtr) start() error {
 			case <-r.exit:
 				return
 			default:
-				logger.Tracef("Router starting registry watch")
+				log.Tracef("Router starting registry watch")
 				w, err := r.options.Registry.Watch(registry.WatchDomain(registry.WildcardDomain))
 				if err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("failed creating registry watcher: %v", err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("failed creating registry watcher: %v", err)
 					}
 					time.Sleep(time.Second)
 					// in the event of an error reload routes
@@ -476,8 +480,8 @@ func (r *rtr) start() error {
 
 				// watchRegistry calls stop when it's done
 				if err := r.watchRegistry(w); err != nil {
-					if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-						logger.Debugf("Error watching the registry: %v", err)
+					if logger.V(logger.DebugLevel, log) {
+						log.Debugf("Error watching the registry: %v", err)
 					}
 					time.Sleep(time.Second)
 					// in the event of an error reload routes
@@ -496,6 +500,16 @@ func (r *rtr) Watch(opts ...router.WatchOption) (router.Watcher, error) {
 }
 
 // String prints debugging information about router
+// Status returns whether the router is running or has been closed
+func (r *rtr) Status() router.StatusCode {
+	r.RLock()
+	defer r.RUnlock()
+	if r.running {
+		return router.Running
+	}
+	return router.Stopped
+}
+
 func (r *rtr) String() string {
 	return "registry"
 }
@@ -54,6 +54,10 @@ func (t *table) pruneRoutes(olderThan time.Duration) {
 	// search for all the routes
 	for _, routeList := range t.routes {
 		for _, r := range routeList {
+			// static routes aren't refreshed from the registry
+			if isStatic(r.route) {
+				continue
+			}
 			// if any route is older than
 			if time.Since(r.updated).Seconds() > olderThan.Seconds() {
 				routes = append(routes, r.route)
@@ -84,7 +88,7 @@ func (t *table) deleteService(service, network string) {
 		// TODO: check if this causes a problem
 		// with * in the network if that is a thing
 		// or blank strings
-		if rt.route.Network != network {
+		if rt.route.Network != network || isStatic(rt.route) {
 			continue
 		}
 		delete(routes, hash)
@@ -100,6 +104,11 @@ func (t *table) deleteService(service, network string) {
 	t.routes[service] = routes
 }
 
+// isStatic returns true if the route was set statically rather than discovered in the registry
+func isStatic(r router.Route) bool {
+	return r.Metadata[router.StaticMetadata] == "true"
+}
+
 // sendEvent sends events to all subscribed watchers
 func (t *table) sendEvent(e *router.Event) {
 	t.RLock()
@@ -140,8 +149,8 @@ func (t *table) Create(r router.Route) error {
 	// create the route
 	t.routes[service][sum] = &route{r, time.Now()}
 
-	if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-		logger.Debugf("Router emitting %s for route: %s", router.Create, r.Address)
+	if logger.V(logger.DebugLevel, log) {
+		log.Debugf("Router emitting %s for route: %s", router.Create, r.Address)
 	}
 
 	// send a route created event
@@ -174,8 +183,8 @@ func (t *table) Delete(r router.Route) error {
 		delete(t.routes, service)
 	}
 
-	if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-		logger.Debugf("Router emitting %s for route: %s", router.Delete, r.Address)
+	if logger.V(logger.DebugLevel, log) {
+		log.Debugf("Router emitting %s for route: %s", router.Delete, r.Address)
 	}
 	go t.sendEvent(&router.Event{Type: router.Delete, Timestamp: time.Now(), Route: r})
 
@@ -199,8 +208,8 @@ func (t *table) Update(r router.Route) error {
 		// update the route
 		t.routes[service][sum] = &route{r, time.Now()}
 
-		if logger.V(logger.DebugLevel, logger.DefaultLogger) {
-			logger.Debugf("Router emitting %s for route: %s", router.Update, r.Address)
+		if logger.V(logger.DebugLevel, log) {
+			log.Debugf("Router emitting %s for route: %s", router.Update, r.Address)
 		}
 		go t.sendEvent(&router.Event{Type: router.Update, Timestamp: time.Now(), Route: r})
 		return nil
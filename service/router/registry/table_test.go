@@ -143,3 +143,29 @@ func TestQuery(t *testing.T) {
 		t.Fatal("Mismatched routes received")
 	}
 }
+
+func TestPruneStatic(t *testing.T) {
+	table, route := testSetup()
+
+	if err := table.Create(route); err != nil {
+		t.Fatalf("error adding route: %s", err)
+	}
+
+	route.Address = "static.addr"
+	route.Metadata = map[string]string{router.StaticMetadata: "true"}
+	if err := table.Create(route); err != nil {
+		t.Fatalf("error adding route: %s", err)
+	}
+
+	// the static route is kept when the routes are pruned
+	table.pruneRoutes(0)
+	table.deleteService(route.Service, route.Network)
+
+	routes, err := table.Read()
+	if err != nil {
+		t.Fatalf("error reading routes: %s", err)
+	}
+	if len(routes) != 1 || routes[0].Address != "static.addr" {
+		t.Fatalf("expected the static route to be kept, found: %v", routes)
+	}
+}
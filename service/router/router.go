@@ -31,6 +31,9 @@ var (
 	DefaultMetric int64 = 1
 	// DefaultNetwork is default micro network
 	DefaultNetwork = "micro"
+	// StaticMetadata is the metadata key marking the routes set statically rather than discovered
+	// in the registry, which are kept in the table until they're deleted
+	StaticMetadata = "static"
 	// ErrRouteNotFound is returned when no route was found in the routing table
 	ErrRouteNotFound = errors.New("route not found")
 	// ErrDuplicateRoute is returned when the route already exists
@@ -82,6 +85,20 @@ const (
 	Error
 )
 
+// String returns human readable status
+func (s StatusCode) String() string {
+	switch s {
+	case Running:
+		return "running"
+	case Stopped:
+		return "stopped"
+	case Error:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
 // Route is a network route
 type Route struct {
 	// Service is destination service name
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/client"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/proxy"
+	"github.com/micro/micro/v3/service/proxy/grpc"
+	"github.com/micro/micro/v3/service/proxy/http"
+	"github.com/micro/micro/v3/service/proxy/mucp"
+	muregistry "github.com/micro/micro/v3/service/registry"
+	"github.com/micro/micro/v3/service/router"
+	"github.com/micro/micro/v3/service/router/registry"
+	"github.com/micro/micro/v3/service/server"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// proxyAddress is the address the data plane of the router listens on
+	proxyAddress = ":8089"
+
+	// Commands of the router
+	Commands = []*cli.Command{
+		{
+			Name:  "proxy",
+			Usage: "Run the data plane of the router, forwarding the calls to the routes it looks up",
+			Description: `The proxy relays the calls made to it to the services of the network, looking up the
+	routes of the service called in the routing table the router builds.
+
+	micro service router proxy --proxy_protocol mucp`,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "address",
+					Usage:   "Set the address the proxy listens on",
+					EnvVars: []string{"MICRO_ROUTER_PROXY_ADDRESS"},
+					Value:   proxyAddress,
+				},
+				&cli.StringFlag{
+					Name:    "proxy_protocol",
+					Usage:   "Set the protocol the calls are forwarded with: mucp, grpc or http",
+					EnvVars: []string{"MICRO_ROUTER_PROXY_PROTOCOL"},
+					Value:   "grpc",
+				},
+			},
+			Action: RunProxy,
+		},
+	}
+)
+
+// newProxy returns the proxy of the protocol
+func newProxy(protocol string, opts ...proxy.Option) (proxy.Proxy, error) {
+	switch protocol {
+	case "mucp":
+		return mucp.NewProxy(opts...), nil
+	case "grpc":
+		return grpc.NewProxy(opts...), nil
+	case "http":
+		return http.NewProxy(opts...), nil
+	default:
+		return nil, fmt.Errorf("Unsupported proxy protocol %s, expected mucp, grpc or http", protocol)
+	}
+}
+
+// RunProxy runs the data plane of the router
+func RunProxy(ctx *cli.Context) error {
+	if len(ctx.String("server_name")) > 0 {
+		name = ctx.String("server_name")
+	}
+	if len(ctx.String("address")) > 0 {
+		proxyAddress = ctx.String("address")
+	}
+	if len(ctx.String("network")) > 0 {
+		network = ctx.String("network")
+	}
+
+	srv := service.New(
+		service.Name(name+".proxy"),
+		service.Address(proxyAddress),
+	)
+
+	r := registry.NewRouter(
+		router.Id(srv.Server().Options().Id),
+		router.Address(srv.Server().Options().Id),
+		router.Network(network),
+		router.Registry(muregistry.DefaultRegistry),
+		router.Gateway(ctx.String("gateway")),
+	)
+	defer r.Close()
+
+	protocol := ctx.String("proxy_protocol")
+	p, err := newProxy(protocol, proxy.WithRouter(r), proxy.WithClient(client.DefaultClient))
+	if err != nil {
+		return err
+	}
+
+	// all the calls made to the proxy are forwarded
+	srv.Server().Init(server.WithRouter(p))
+
+	log.Infof("Router proxy [%s] forwarding calls with protocol %s", p.String(), protocol)
+
+	return srv.Run()
+}
@@ -0,0 +1,18 @@
+package server
+
+import "testing"
+
+func TestNewProxy(t *testing.T) {
+	for _, protocol := range []string{"mucp", "grpc", "http"} {
+		p, err := newProxy(protocol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.String() != protocol {
+			t.Fatalf("Expected the %s proxy, got %s", protocol, p.String())
+		}
+	}
+	if _, err := newProxy("tcp"); err == nil {
+		t.Fatal("Expected an unsupported protocol to fail")
+	}
+}
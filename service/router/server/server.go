@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/micro/micro/v3/internal/wrapper"
 	pb "github.com/micro/micro/v3/proto/router"
 	"github.com/micro/micro/v3/service"
 	muregistry "github.com/micro/micro/v3/service/registry"
@@ -49,11 +50,17 @@ func Run(ctx *cli.Context) error {
 		gateway = ctx.String("gateway")
 	}
 
-	// Initialise service
-	srv := service.New(
+	srvOpts := []service.Option{
 		service.Name(name),
 		service.Address(address),
-	)
+	}
+	// serve the control-plane requests before the watches when overloaded
+	if limit := ctx.Int("priority_limit"); limit > 0 {
+		srvOpts = append(srvOpts, service.WrapHandler(wrapper.PriorityHandler(limit)))
+	}
+
+	// Initialise service
+	srv := service.New(srvOpts...)
 
 	r := registry.NewRouter(
 		router.Id(srv.Server().Options().Id),
@@ -3,6 +3,7 @@ package client
 import (
 	"io"
 	"sync"
+	"time"
 
 	pb "github.com/micro/micro/v3/proto/runtime"
 	"github.com/micro/micro/v3/service/client"
@@ -153,17 +154,34 @@ func (s *svc) Create(resource runtime.Resource, opts ...runtime.CreateOption) er
 				},
 			},
 			Options: &pb.CreateOptions{
-				Command:    options.Command,
-				Args:       options.Args,
-				Env:        options.Env,
-				Type:       options.Type,
-				Image:      options.Image,
-				Namespace:  options.Namespace,
-				Secrets:    options.Secrets,
-				Entrypoint: options.Entrypoint,
-				Volumes:    options.Volumes,
+				Command:      options.Command,
+				Args:         options.Args,
+				Env:          options.Env,
+				Type:         options.Type,
+				Image:        options.Image,
+				Namespace:    options.Namespace,
+				Secrets:      options.Secrets,
+				Entrypoint:   options.Entrypoint,
+				Volumes:      options.Volumes,
+				Schedule:     options.Schedule,
+				Replicas:     int32(options.Replicas),
+				Dependencies: options.Dependencies,
+				Prebuilt:     options.Prebuilt,
+				ConfigRefs:   options.ConfigRefs,
+				SecretRefs:   options.SecretRefs,
+				Rotate:       options.Rotate,
+				Os:           options.OS,
+				Arch:         options.Arch,
+				BuildFlags:   options.BuildFlags,
 			},
 		}
+		if options.Resources != nil {
+			req.Options.Resources = &pb.Resources{
+				CPU:              int32(options.Resources.CPU),
+				EphemeralStorage: int32(options.Resources.Disk),
+				Memory:           int32(options.Resources.Mem),
+			}
+		}
 
 		if _, err := s.runtime.Create(context.DefaultContext, req, client.WithAuthToken()); err != nil {
 			return err
@@ -200,14 +218,19 @@ func (s *svc) Logs(resource runtime.Resource, options ...runtime.LogsOption) (ru
 			return nil, runtime.ErrInvalidResource
 		}
 
-		ls, err := s.runtime.Logs(context.DefaultContext, &pb.LogsRequest{
+		req := &pb.LogsRequest{
 			Service: service.Name,
 			Stream:  opts.Stream,
 			Count:   opts.Count,
 			Options: &pb.LogsOptions{
 				Namespace: opts.Namespace,
 			},
-		}, client.WithAuthToken())
+		}
+		if !opts.Since.IsZero() {
+			req.Since = int64(time.Since(opts.Since).Seconds())
+		}
+
+		ls, err := s.runtime.Logs(context.DefaultContext, req, client.WithAuthToken())
 		if err != nil {
 			return nil, err
 		}
@@ -247,10 +270,14 @@ func (s *svc) Logs(resource runtime.Resource, options ...runtime.LogsOption) (ru
 						logStream.Stop()
 						return
 					}
-					logStream.stream <- runtime.Log{
+					log := runtime.Log{
 						Message:  record.GetMessage(),
 						Metadata: record.GetMetadata(),
 					}
+					if record.GetTimestamp() > 0 {
+						log.Timestamp = time.Unix(record.GetTimestamp(), 0)
+					}
+					logStream.stream <- log
 				}
 			}
 		}()
@@ -446,8 +473,17 @@ func (s *svc) Update(resource runtime.Resource, opts ...runtime.UpdateOption) er
 			Options: &pb.UpdateOptions{
 				Namespace:  options.Namespace,
 				Entrypoint: options.Entrypoint,
+				Replicas:   int32(options.Replicas),
 			},
 		}
+		if p := options.Autoscale; p != nil {
+			req.Options.Autoscale = &pb.ScalePolicy{
+				Min:       int32(p.Min),
+				Max:       int32(p.Max),
+				Metric:    p.Metric,
+				Threshold: p.Threshold,
+			}
+		}
 
 		if _, err := s.runtime.Update(context.DefaultContext, req, client.WithAuthToken()); err != nil {
 			return err
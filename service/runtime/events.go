@@ -9,7 +9,15 @@ const (
 	// EventServiceUpdated is the topic events are published to when a service is updated
 	EventServiceUpdated = "service.updated"
 	// EventServiceDeleted is the topic events are published to when a service is deleted
-	EventServiceDeleted       = "service.deleted"
+	EventServiceDeleted = "service.deleted"
+	// EventServiceStarted is the topic events are published to when a service starts running
+	EventServiceStarted = "service.started"
+	// EventServiceCrashed is the topic events are published to when a service errors
+	EventServiceCrashed = "service.crashed"
+	// EventServiceRestarted is the topic events are published to when a service is running again
+	// after it errored
+	EventServiceRestarted = "service.restarted"
+
 	EventNamespaceCreated     = "namespace.created"
 	EventNamespaceDeleted     = "namespace.deleted"
 	EventNetworkPolicyCreated = "networkpolicy.created"
@@ -25,6 +33,8 @@ type EventPayload struct {
 	Type      string
 	Service   *Service
 	Namespace string
+	// Reason for the event, e.g. the error a service crashed with
+	Reason string
 }
 
 // EventResourcePayload which is published with runtime resource events
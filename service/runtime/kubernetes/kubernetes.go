@@ -16,6 +16,7 @@
 package kubernetes
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -34,6 +35,9 @@ var (
 	}
 
 	DefaultImage = "micro/cells:v3"
+
+	// ErrJobsNotSupported is returned when creating a job or a scheduled service
+	ErrJobsNotSupported = errors.New("jobs are not supported by the kubernetes runtime")
 )
 
 // action to take on runtime service
@@ -47,6 +51,10 @@ type kubernetes struct {
 	client client.Client
 	// namespaces which exist
 	namespaces []client.Namespace
+	// operator mode reconciles the services declared as custom resources
+	operator bool
+	// exit stops the reconcile loop
+	exit chan bool
 }
 
 // Init initializes runtime options
@@ -187,6 +195,11 @@ func (k *kubernetes) create(resource runtime.Resource, opts ...runtime.CreateOpt
 			return runtime.ErrInvalidResource
 		}
 
+		// jobs aren't yet implemented using kubernetes jobs / cronjobs
+		if options.Type == runtime.JobType || len(options.Schedule) > 0 {
+			return ErrJobsNotSupported
+		}
+
 		// default the service's source and version
 		if len(s.Source) == 0 {
 			s.Source = k.options.Source
@@ -205,41 +218,51 @@ func (k *kubernetes) create(resource runtime.Resource, opts ...runtime.CreateOpt
 			return err
 		}
 
-		// create some default resource requests
-		if options.Resources == nil && options.Namespace != "micro" {
-			options.Resources = DefaultServiceResources
+		// in operator mode the service is declared as a custom resource which is then reconciled
+		if k.operator {
+			return k.createMicroService(s, options)
 		}
 
-		if len(options.Image) == 0 {
-			options.Image = DefaultImage
-		}
+		return k.deploy(s, options)
+	default:
+		return runtime.ErrInvalidResource
+	}
+}
 
-		// create the deployment
-		if err := k.client.Create(client.NewDeployment(s, options), client.CreateNamespace(options.Namespace)); err != nil {
-			if parseError(err).Reason == "AlreadyExists" {
-				return runtime.ErrAlreadyExists
-			}
-			if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
-				logger.Errorf("Runtime failed to create deployment: %v", err)
-			}
-			return err
-		}
+// deploy creates the deployment and service for a micro service
+func (k *kubernetes) deploy(s *runtime.Service, options *runtime.CreateOptions) error {
+	// create some default resource requests
+	if options.Resources == nil && options.Namespace != "micro" {
+		options.Resources = DefaultServiceResources
+	}
 
-		// create the service, one could already exist for another version so ignore ErrAlreadyExists
-		if err := k.client.Create(client.NewService(s, options), client.CreateNamespace(options.Namespace)); err != nil {
-			if parseError(err).Reason == "AlreadyExists" {
-				return nil
-			}
-			if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
-				logger.Errorf("Runtime failed to create service: %v", err)
-			}
-			return err
+	if len(options.Image) == 0 {
+		options.Image = DefaultImage
+	}
+
+	// create the deployment
+	if err := k.client.Create(client.NewDeployment(s, options), client.CreateNamespace(options.Namespace)); err != nil {
+		if parseError(err).Reason == "AlreadyExists" {
+			return runtime.ErrAlreadyExists
+		}
+		if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+			logger.Errorf("Runtime failed to create deployment: %v", err)
 		}
+		return err
+	}
 
-		return nil
-	default:
-		return runtime.ErrInvalidResource
+	// create the service, one could already exist for another version so ignore ErrAlreadyExists
+	if err := k.client.Create(client.NewService(s, options), client.CreateNamespace(options.Namespace)); err != nil {
+		if parseError(err).Reason == "AlreadyExists" {
+			return nil
+		}
+		if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+			logger.Errorf("Runtime failed to create service: %v", err)
+		}
+		return err
 	}
+
+	return nil
 }
 
 // Read returns all instances of given service
@@ -312,6 +335,11 @@ func (k *kubernetes) Update(resource runtime.Resource, opts ...runtime.UpdateOpt
 			return runtime.ErrInvalidResource
 		}
 
+		// in operator mode the custom resource is updated and the deployment then reconciled
+		if k.operator {
+			return k.updateMicroService(s, options)
+		}
+
 		// construct the query
 		labels := map[string]string{}
 		if len(s.Name) > 0 {
@@ -349,8 +377,16 @@ func (k *kubernetes) Update(resource runtime.Resource, opts ...runtime.UpdateOpt
 				dep.Metadata.Annotations[k] = v
 			}
 
-			// update build time annotation
-			dep.Spec.Template.Metadata.Annotations["updated"] = fmt.Sprintf("%d", time.Now().Unix())
+			// set the number of instances
+			if options.Replicas > 0 {
+				dep.Spec.Replicas = options.Replicas
+			}
+
+			// update build time annotation, unless only scaling in which case the pods don't need
+			// to be restarted
+			if len(s.Source) > 0 || options.Replicas == 0 {
+				dep.Spec.Template.Metadata.Annotations["updated"] = fmt.Sprintf("%d", time.Now().Unix())
+			}
 
 			// update the deployment
 			res := &client.Resource{
@@ -415,6 +451,11 @@ func (k *kubernetes) Delete(resource runtime.Resource, opts ...runtime.DeleteOpt
 			return runtime.ErrInvalidResource
 		}
 
+		// in operator mode the custom resource is deleted and the deployment then reconciled
+		if k.operator {
+			return k.deleteMicroService(s, options.Namespace)
+		}
+
 		// delete the deployment
 		dep := client.NewDeployment(s, &runtime.CreateOptions{
 			Type:      k.options.Type,
@@ -467,11 +508,28 @@ func (k *kubernetes) Delete(resource runtime.Resource, opts ...runtime.DeleteOpt
 
 // Start starts the runtime
 func (k *kubernetes) Start() error {
+	k.Lock()
+	defer k.Unlock()
+
+	// only the operator runs a reconcile loop
+	if !k.operator || k.exit != nil {
+		return nil
+	}
+
+	k.exit = make(chan bool)
+	go k.run(k.exit)
 	return nil
 }
 
 // Stop shuts down the runtime
 func (k *kubernetes) Stop() error {
+	k.Lock()
+	defer k.Unlock()
+
+	if k.exit != nil {
+		close(k.exit)
+		k.exit = nil
+	}
 	return nil
 }
 
@@ -34,6 +34,9 @@ type klog struct {
 func (k *klog) podLogs(podName string, stream *kubeStream) error {
 	p := make(map[string]string)
 	p["follow"] = "true"
+	if !k.options.Since.IsZero() {
+		p["sinceSeconds"] = strconv.Itoa(int(time.Since(k.options.Since).Seconds()))
+	}
 
 	opts := []client.LogOption{
 		client.LogParams(p),
@@ -125,9 +128,9 @@ func (k *klog) Read() ([]runtime.Log, error) {
 	for _, pod := range pods {
 		logParams := make(map[string]string)
 
-		//if !opts.Since.Equal(time.Time{}) {
-		//	logParams["sinceSeconds"] = strconv.Itoa(int(time.Since(opts.Since).Seconds()))
-		//}
+		if !k.options.Since.IsZero() {
+			logParams["sinceSeconds"] = strconv.Itoa(int(time.Since(k.options.Since).Seconds()))
+		}
 
 		if k.options.Count != 0 {
 			logParams["tailLines"] = strconv.Itoa(int(k.options.Count))
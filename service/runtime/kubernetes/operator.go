@@ -0,0 +1,284 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/micro/micro/v3/internal/kubernetes/api"
+	"github.com/micro/micro/v3/internal/kubernetes/client"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/runtime"
+)
+
+var (
+	// OperatorInterval is how often the operator reconciles the micro services
+	OperatorInterval = time.Second * 10
+)
+
+const (
+	// microServiceKey is the annotation which holds the name of the custom resource a deployment
+	// was reconciled from
+	microServiceKey = "microservice"
+	// specKey is the annotation which holds the checksum of the spec a deployment was reconciled
+	// from
+	specKey = "spec"
+)
+
+// NewOperator creates a kubernetes runtime in operator mode. Services are declared as MicroService
+// custom resources and the deployments and services are reconciled from them, which allows them
+// to be managed declaratively, e.g. by GitOps tools.
+func NewOperator(opts ...runtime.Option) runtime.Runtime {
+	k := NewRuntime(opts...).(*kubernetes)
+	k.operator = true
+	return k
+}
+
+// createMicroService creates the custom resource declaring the service
+func (k *kubernetes) createMicroService(s *runtime.Service, options *runtime.CreateOptions) error {
+	if err := k.client.Create(client.NewMicroService(s, options), client.CreateNamespace(options.Namespace)); err != nil {
+		if parseError(err).Reason == "AlreadyExists" {
+			return runtime.ErrAlreadyExists
+		}
+		if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+			logger.Errorf("Runtime failed to create micro service: %v", err)
+		}
+		return err
+	}
+
+	return k.reconcile(options.Namespace)
+}
+
+// updateMicroService updates the custom resources declaring the service
+func (k *kubernetes) updateMicroService(s *runtime.Service, options runtime.UpdateOptions) error {
+	// construct the query
+	labels := map[string]string{}
+	if len(s.Name) > 0 {
+		labels["name"] = client.Format(s.Name)
+	}
+	if len(s.Version) > 0 {
+		labels["version"] = client.Format(s.Version)
+	}
+
+	msList := new(client.MicroServiceList)
+	r := &client.Resource{
+		Kind:  "microservice",
+		Value: msList,
+	}
+	if err := k.client.Get(r, client.GetNamespace(options.Namespace), client.GetLabels(labels)); err != nil {
+		return err
+	} else if len(msList.Items) == 0 {
+		return runtime.ErrNotFound
+	}
+
+	for _, ms := range msList.Items {
+		if ms.Metadata == nil || ms.Spec == nil {
+			continue
+		}
+		if ms.Spec.Metadata == nil {
+			ms.Spec.Metadata = make(map[string]string)
+		}
+
+		// update metadata
+		for k, v := range s.Metadata {
+			ms.Spec.Metadata[k] = v
+		}
+
+		// set the number of instances
+		if options.Replicas > 0 {
+			ms.Spec.Replicas = options.Replicas
+		}
+
+		// changing the update time restarts the pods, unless only scaling
+		if len(s.Source) > 0 || options.Replicas == 0 {
+			ms.Spec.Metadata["updated"] = fmt.Sprintf("%d", time.Now().Unix())
+		}
+
+		res := &client.Resource{
+			Kind:  "microservice",
+			Name:  ms.Metadata.Name,
+			Value: &ms,
+		}
+		if err := k.client.Update(res, client.UpdateNamespace(options.Namespace)); err != nil {
+			if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+				logger.Errorf("Runtime failed to update micro service: %v", err)
+			}
+			return err
+		}
+	}
+
+	return k.reconcile(options.Namespace)
+}
+
+// deleteMicroService deletes the custom resource declaring the service
+func (k *kubernetes) deleteMicroService(s *runtime.Service, namespace string) error {
+	res := &client.Resource{
+		Kind: "microservice",
+		Name: resourceName(s),
+	}
+	if err := k.client.Delete(res, client.DeleteNamespace(namespace)); err != nil {
+		if err == api.ErrNotFound {
+			return runtime.ErrNotFound
+		}
+		if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+			logger.Errorf("Runtime failed to delete micro service: %v", err)
+		}
+		return err
+	}
+
+	// the credentials only exist if the service had secrets
+	k.deleteCredentials(s, &runtime.CreateOptions{Namespace: namespace})
+
+	return k.reconcile(namespace)
+}
+
+// run reconciles the micro services in all namespaces until exit is closed
+func (k *kubernetes) run(exit chan bool) {
+	ticker := time.NewTicker(OperatorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+		}
+
+		nsList := new(client.NamespaceList)
+		if err := k.client.List(&client.Resource{Kind: "namespace", Value: nsList}); err != nil {
+			logger.Warnf("Error listing namespaces to reconcile: %v", err)
+			continue
+		}
+
+		k.Lock()
+		for _, ns := range nsList.Items {
+			if ns.Metadata == nil {
+				continue
+			}
+			if err := k.reconcile(ns.Metadata.Name); err != nil {
+				logger.Warnf("Error reconciling namespace %v: %v", ns.Metadata.Name, err)
+			}
+		}
+		k.Unlock()
+	}
+}
+
+// reconcile the deployments and services in the namespace with the micro services declared in it.
+// Deployments which are missing are created, those whose spec has changed are updated and those
+// whose micro service has been deleted are removed.
+func (k *kubernetes) reconcile(namespace string) error {
+	msList := new(client.MicroServiceList)
+	if err := k.client.Get(&client.Resource{Kind: "microservice", Value: msList}, client.GetNamespace(namespace)); err != nil {
+		return err
+	}
+
+	depList := new(client.DeploymentList)
+	if err := k.client.Get(&client.Resource{Kind: "deployment", Value: depList}, client.GetNamespace(namespace)); err != nil {
+		return err
+	}
+	deployments := make(map[string]client.Deployment, len(depList.Items))
+	for _, dep := range depList.Items {
+		if dep.Metadata != nil {
+			deployments[dep.Metadata.Name] = dep
+		}
+	}
+
+	declared := make(map[string]bool, len(msList.Items))
+	for _, ms := range msList.Items {
+		if ms.Metadata == nil || ms.Spec == nil {
+			continue
+		}
+
+		s, options := ms.Service()
+		options.Namespace = namespace
+		if len(options.Type) == 0 {
+			options.Type = k.options.Type
+		}
+		if len(s.Source) == 0 {
+			s.Source = k.options.Source
+		}
+		if options.Type == runtime.JobType {
+			logger.Warnf("Skipping micro service %v: %v", ms.Metadata.Name, ErrJobsNotSupported)
+			continue
+		}
+
+		// annotate the deployment with the spec so changes to it can be detected
+		s.Metadata[microServiceKey] = ms.Metadata.Name
+		s.Metadata[specKey] = specChecksum(ms.Spec)
+		declared[resourceName(s)] = true
+
+		dep, ok := deployments[resourceName(s)]
+		if !ok {
+			if err := k.deploy(s, options); err != nil && err != runtime.ErrAlreadyExists {
+				return err
+			}
+			continue
+		}
+		if dep.Metadata.Annotations[specKey] == s.Metadata[specKey] {
+			continue
+		}
+
+		// patch the deployment with the new spec
+		if options.Resources == nil && namespace != "micro" {
+			options.Resources = DefaultServiceResources
+		}
+		if len(options.Image) == 0 {
+			options.Image = DefaultImage
+		}
+		if err := k.client.Update(client.NewDeployment(s, options), client.UpdateNamespace(namespace)); err != nil {
+			if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+				logger.Errorf("Runtime failed to update deployment: %v", err)
+			}
+			return err
+		}
+	}
+
+	// remove the deployments whose micro service no longer exists
+	for name, dep := range deployments {
+		if len(dep.Metadata.Annotations[microServiceKey]) == 0 || declared[name] {
+			continue
+		}
+
+		res := &client.Resource{Kind: "deployment", Name: name}
+		if err := k.client.Delete(res, client.DeleteNamespace(namespace)); err != nil && err != api.ErrNotFound {
+			if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+				logger.Errorf("Runtime failed to delete deployment: %v", err)
+			}
+			return err
+		}
+
+		// the service is shared by the versions, only delete it with the last one
+		if remaining(deployments, declared, dep.Metadata.Labels["name"]) {
+			continue
+		}
+		res = &client.Resource{Kind: "service", Name: dep.Metadata.Labels["name"]}
+		if err := k.client.Delete(res, client.DeleteNamespace(namespace)); err != nil && err != api.ErrNotFound {
+			if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
+				logger.Errorf("Runtime failed to delete service: %v", err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// remaining returns true if another deployment exists for the service with the given name
+func remaining(deployments map[string]client.Deployment, declared map[string]bool, name string) bool {
+	for key, dep := range deployments {
+		if dep.Metadata.Labels["name"] != name {
+			continue
+		}
+		if declared[key] || len(dep.Metadata.Annotations[microServiceKey]) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// specChecksum returns the checksum of the spec of a micro service
+func specChecksum(spec *client.MicroServiceSpec) string {
+	b, _ := json.Marshal(spec)
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:16]
+}
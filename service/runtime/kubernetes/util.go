@@ -18,6 +18,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/micro/micro/v3/internal/kubernetes/api"
@@ -54,6 +55,8 @@ func (k *kubernetes) getServices(opts ...client.GetOption) ([]*runtime.Service,
 		delete(srv.Metadata, "name")
 		delete(srv.Metadata, "version")
 		delete(srv.Metadata, "source")
+		delete(srv.Metadata, microServiceKey)
+		delete(srv.Metadata, specKey)
 
 		// parse out deployment status and inject into service metadata
 		if len(kdep.Status.Conditions) > 0 {
@@ -106,6 +109,9 @@ func (k *kubernetes) getServices(opts ...client.GetOption) ([]*runtime.Service,
 		if v := state.Waiting; v != nil {
 			srv.Status = runtime.Pending
 		}
+
+		// set the restart history from the container
+		setRestarts(srv, item.Status.Containers[0])
 	}
 
 	// turn the map into an array
@@ -116,6 +122,34 @@ func (k *kubernetes) getServices(opts ...client.GetOption) ([]*runtime.Service,
 	return services, nil
 }
 
+// setRestarts sets the number of times the container restarted and how it last crashed in the
+// service metadata
+func setRestarts(srv *runtime.Service, status client.ContainerStatus) {
+	if status.RestartCount > 0 {
+		srv.Metadata["restarts"] = strconv.Itoa(status.RestartCount)
+	}
+
+	if v := status.State.Waiting; v != nil && v.Reason == "CrashLoopBackOff" {
+		srv.Status = runtime.Error
+		srv.Metadata["backoff"] = "true"
+		srv.Metadata["error"] = v.Message
+	}
+
+	crash := status.LastState.Terminated
+	if crash == nil {
+		return
+	}
+	if len(crash.Finished) > 0 {
+		srv.Metadata["lastCrash"] = crash.Finished
+	}
+	if crash.ExitCode != nil {
+		srv.Metadata["exitCode"] = strconv.Itoa(*crash.ExitCode)
+	}
+	if len(crash.Message) > 0 {
+		srv.Metadata["crashOutput"] = crash.Message
+	}
+}
+
 func (k *kubernetes) createCredentials(service *runtime.Service, options *runtime.CreateOptions) error {
 	if len(options.Secrets) == 0 {
 		return nil
@@ -23,8 +23,11 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hpcloud/tail"
+	"github.com/micro/micro/v3/internal/cron"
+	"github.com/micro/micro/v3/internal/residency"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/runtime"
 )
@@ -35,6 +38,8 @@ const defaultNamespace = "micro"
 var (
 	// The directory for logs to be output
 	LogDir = filepath.Join(os.TempDir(), "micro", "logs")
+	// DependencyInterval is how often the health of dependencies is checked
+	DependencyInterval = time.Second
 	// The source directory where code lives
 	SourceDir = filepath.Join(os.TempDir(), "micro", "uploads")
 )
@@ -126,6 +131,11 @@ func (r *localRuntime) Create(resource runtime.Resource, opts ...runtime.CreateO
 		if len(options.Namespace) == 0 {
 			options.Namespace = defaultNamespace
 		}
+
+		// the services only run on this node, they can't be placed in another region
+		if len(options.Region) > 0 && options.Region != residency.Region {
+			return residency.ErrWrongRegion
+		}
 		if len(options.Entrypoint) > 0 {
 			s.Source = filepath.Join(s.Source, options.Entrypoint)
 		}
@@ -145,6 +155,15 @@ func (r *localRuntime) Create(resource runtime.Resource, opts ...runtime.CreateO
 			options.Env = append(options.Env, fmt.Sprintf("%v=%v", key, value))
 		}
 
+		// parse the schedule for cron jobs
+		var schedule *cron.Schedule
+		if len(options.Schedule) > 0 {
+			var err error
+			if schedule, err = cron.Parse(options.Schedule); err != nil {
+				return err
+			}
+		}
+
 		if _, ok := r.namespaces[options.Namespace]; !ok {
 			r.namespaces[options.Namespace] = make(map[string]*service)
 		}
@@ -153,7 +172,7 @@ func (r *localRuntime) Create(resource runtime.Resource, opts ...runtime.CreateO
 		}
 
 		// create new service
-		service := newService(s, options)
+		service := newService(s, options, schedule)
 
 		f, err := os.OpenFile(logFile(service.Name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		if err != nil {
@@ -161,12 +180,25 @@ func (r *localRuntime) Create(resource runtime.Resource, opts ...runtime.CreateO
 		}
 
 		if service.output != nil {
-			service.output = io.MultiWriter(service.output, f)
+			service.output = io.MultiWriter(service.output, f, service.logs)
 		} else {
-			service.output = f
+			service.output = io.MultiWriter(f, service.logs)
 		}
-		// start the service
-		if err := service.Start(); err != nil {
+
+		// a service can't depend on itself, directly or otherwise
+		if r.dependsOn(options.Namespace, service.dependencies, s.Name, map[string]bool{}) {
+			return fmt.Errorf("Service %s has a dependency cycle", s.Name)
+		}
+
+		// run the additional instances of the service
+		if options.Replicas > 1 {
+			if service.job {
+				return errors.New("Jobs can't be run with replicas")
+			}
+			service.Scale(options.Replicas)
+		}
+
+		if err := r.launch(options.Namespace, service); err != nil {
 			return err
 		}
 		// save service
@@ -178,6 +210,92 @@ func (r *localRuntime) Create(resource runtime.Resource, opts ...runtime.CreateO
 	}
 }
 
+// launch starts the service. Services with dependencies are started once the dependencies are
+// healthy and scheduled jobs are started when the schedule fires.
+func (r *localRuntime) launch(namespace string, s *service) error {
+	if s.schedule == nil && len(s.dependencies) == 0 {
+		return s.Start()
+	}
+
+	cancel := s.Pending()
+	go func() {
+		if !r.waitForDependencies(namespace, s.dependencies, cancel) {
+			return
+		}
+		if s.schedule != nil {
+			s.Schedule()
+		} else if err := s.Start(); err != nil {
+			logger.Errorf("Runtime failed to start service %s: %v", s.Name, err)
+		}
+	}()
+
+	return nil
+}
+
+// waitForDependencies blocks until the dependencies are healthy, returning false if cancelled
+func (r *localRuntime) waitForDependencies(namespace string, deps []string, cancel chan bool) bool {
+	if len(deps) == 0 {
+		return true
+	}
+
+	ticker := time.NewTicker(DependencyInterval)
+	defer ticker.Stop()
+
+	for {
+		if r.healthy(namespace, deps) {
+			return true
+		}
+
+		select {
+		case <-cancel:
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// healthy returns true if a healthy service exists for each of the names
+func (r *localRuntime) healthy(namespace string, names []string) bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, name := range names {
+		healthy := false
+		for _, srv := range r.namespaces[namespace] {
+			if srv.Name == name && srv.Healthy() {
+				healthy = true
+				break
+			}
+		}
+		if !healthy {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dependsOn returns true if any of the dependencies depend on the named service. Assumes it's
+// called under a lock.
+func (r *localRuntime) dependsOn(namespace string, deps []string, name string, seen map[string]bool) bool {
+	for _, dep := range deps {
+		if dep == name {
+			return true
+		}
+		if seen[dep] {
+			continue
+		}
+		seen[dep] = true
+
+		for _, srv := range r.namespaces[namespace] {
+			if srv.Name == dep && r.dependsOn(namespace, srv.dependencies, name, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // exists returns whether the given file or directory exists
 func exists(path string) (bool, error) {
 	_, err := os.Stat(path)
@@ -190,10 +308,10 @@ func exists(path string) (bool, error) {
 	return true, err
 }
 
-// @todo: Getting existing lines is not supported yet.
-// The reason for this is because it's hard to calculate line offset
-// as opposed to character offset.
-// This logger streams by default and only supports the `StreamCount` option.
+// Logs returns the logs for a service. The recent output of services managed by the runtime is
+// buffered in memory, otherwise the log file is tailed. Getting existing lines from the log file
+// is an estimate since it's hard to calculate line offset as opposed to character offset and the
+// `Since` option isn't supported for it.
 func (r *localRuntime) Logs(resource runtime.Resource, options ...runtime.LogsOption) (runtime.LogStream, error) {
 	lopts := runtime.LogsOptions{}
 	for _, o := range options {
@@ -219,6 +337,11 @@ func (r *localRuntime) Logs(resource runtime.Resource, options ...runtime.LogsOp
 			return nil, runtime.ErrInvalidResource
 		}
 
+		// read from the buffer of a service managed by the runtime
+		if srv := r.find(lopts.Namespace, s); srv != nil {
+			return newBufferStream(srv.logs, lopts), nil
+		}
+
 		ret := &logStream{
 			service: s.Name,
 			stream:  make(chan runtime.Log),
@@ -278,6 +401,26 @@ func (r *localRuntime) Logs(resource runtime.Resource, options ...runtime.LogsOp
 	}
 }
 
+// find the service in the namespace matching the name and, if set, the version
+func (r *localRuntime) find(namespace string, s *runtime.Service) *service {
+	r.RLock()
+	defer r.RUnlock()
+
+	if len(namespace) == 0 {
+		namespace = defaultNamespace
+	}
+	for _, srv := range r.namespaces[namespace] {
+		if srv.Name != s.Name {
+			continue
+		}
+		if len(s.Version) > 0 && srv.Version != s.Version {
+			continue
+		}
+		return srv
+	}
+	return nil
+}
+
 type logStream struct {
 	tail    *tail.Tail
 	service string
@@ -383,6 +526,9 @@ func (r *localRuntime) Update(resource runtime.Resource, opts ...runtime.UpdateO
 			return runtime.ErrInvalidResource
 		}
 
+		// the service is only scaled if the source isn't being updated
+		scale := len(s.Source) == 0 && options.Replicas > 0
+
 		if len(options.Entrypoint) > 0 {
 			s.Source = filepath.Join(s.Source, options.Entrypoint)
 		}
@@ -405,6 +551,14 @@ func (r *localRuntime) Update(resource runtime.Resource, opts ...runtime.UpdateO
 			return errors.New("Service not found")
 		}
 
+		if scale {
+			if service.job {
+				return errors.New("Jobs can't be scaled")
+			}
+			service.Scale(options.Replicas)
+			return nil
+		}
+
 		if err := service.Stop(); err != nil && err.Error() != "no such process" {
 			logger.Errorf("Error stopping service %s: %s", service.Name, err)
 			return err
@@ -413,7 +567,15 @@ func (r *localRuntime) Update(resource runtime.Resource, opts ...runtime.UpdateO
 		// update the source to the new location and restart the service
 		service.Source = s.Source
 		service.Exec.Dir = s.Source
-		return service.Start()
+
+		// recreate the other instances from the new source
+		service.Lock()
+		replicas := len(service.replicas) + 1
+		service.replicas = nil
+		service.Unlock()
+		service.Scale(replicas)
+
+		return r.launch(options.Namespace, service)
 
 	default:
 		return runtime.ErrInvalidResource
@@ -469,6 +631,7 @@ func (r *localRuntime) Delete(resource runtime.Resource, opts ...runtime.DeleteO
 
 		// check if running
 		if !service.Running() {
+			service.Cancel()
 			delete(srvs, service.key())
 			r.namespaces[options.Namespace] = srvs
 			return nil
@@ -18,7 +18,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/micro/micro/v3/service/runtime"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,3 +47,74 @@ func TestEntrypoint(t *testing.T) {
 	assert.Nil(t, err, "Didn't expecte entrypoint to return an error")
 	assert.Equal(t, "cmd/test/main.go", result, "Expected entrypoint to return cmd/test/main.go")
 }
+
+func TestDependencies(t *testing.T) {
+	db := &service{Service: &runtime.Service{Name: "db", Status: runtime.Running}, running: true}
+	seed := &service{Service: &runtime.Service{Name: "seed", Metadata: map[string]string{}}, job: true, dependencies: []string{"db"}}
+	api := &service{Service: &runtime.Service{Name: "api"}, dependencies: []string{"db", "seed"}}
+
+	r := &localRuntime{namespaces: map[string]map[string]*service{
+		"micro": {"db:latest": db, "seed:latest": seed, "api:latest": api},
+	}}
+
+	assert.True(t, r.healthy("micro", []string{"db"}))
+	assert.False(t, r.healthy("micro", []string{"db", "seed"}), "Expected a job which hasn't completed to be unhealthy")
+	assert.False(t, r.healthy("micro", []string{"missing"}))
+
+	seed.Metadata["completed"] = time.Now().Format(time.RFC3339)
+	assert.True(t, r.healthy("micro", []string{"db", "seed"}))
+
+	// db depending on api would create a cycle via seed
+	assert.True(t, r.dependsOn("micro", []string{"api"}, "db", map[string]bool{}))
+	assert.False(t, r.dependsOn("micro", []string{"db"}, "api", map[string]bool{}))
+
+	// waiting returns once the dependencies are healthy or when cancelled
+	assert.True(t, r.waitForDependencies("micro", []string{"db", "seed"}, make(chan bool)))
+	cancel := make(chan bool)
+	close(cancel)
+	assert.False(t, r.waitForDependencies("micro", []string{"missing"}, cancel))
+}
+
+func TestScale(t *testing.T) {
+	srv := newService(&runtime.Service{Name: "foo"}, runtime.CreateOptions{Env: []string{"FOO=bar"}}, nil)
+
+	srv.Scale(3)
+	if assert.Len(t, srv.replicas, 2) {
+		assert.Equal(t, "2", srv.replicas[1].Metadata["replica"])
+		assert.Equal(t, []string{"FOO=bar", "MICRO_SERVICE_ADDRESS=:0"}, srv.replicas[0].Exec.Env)
+		assert.Equal(t, srv.logs, srv.replicas[0].logs, "Expected replicas to share the log buffer")
+	}
+	assert.Equal(t, []string{"FOO=bar"}, srv.Exec.Env)
+	assert.Equal(t, "3", srv.Metadata["replicas"])
+
+	srv.Scale(1)
+	assert.Empty(t, srv.replicas)
+}
+
+func TestCrashRestarts(t *testing.T) {
+	RestartBackoff = time.Millisecond * 10
+	defer func() { RestartBackoff = time.Second }()
+
+	srv := newService(&runtime.Service{Name: "crash"}, runtime.CreateOptions{
+		Command: []string{"sh"},
+		Args:    []string{"-c", "echo starting; echo boom >&2; exit 3"},
+		Retries: 1,
+	}, nil)
+	assert.NoError(t, srv.Start())
+
+	// the service is restarted once then given up on
+	assert.Eventually(t, func() bool {
+		srv.RLock()
+		defer srv.RUnlock()
+		return !srv.running && srv.Metadata["retries"] == "2"
+	}, time.Second*5, time.Millisecond*10)
+
+	srv.RLock()
+	defer srv.RUnlock()
+	assert.Equal(t, runtime.Error, srv.Service.Status)
+	assert.Equal(t, "1", srv.Metadata["restarts"])
+	assert.Equal(t, "3", srv.Metadata["exitCode"])
+	assert.Equal(t, "boom", srv.Metadata["crashOutput"], "Expected only stderr to be kept")
+	assert.Empty(t, srv.Metadata["backoff"], "Expected no backoff once out of retries")
+	assert.NotEmpty(t, srv.Metadata["lastCrash"])
+}
@@ -0,0 +1,188 @@
+package local
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/ring"
+	"github.com/micro/micro/v3/service/runtime"
+)
+
+// DefaultLogSize is the number of log lines kept in memory for each service
+var DefaultLogSize = 1024
+
+// logWriter splits the output of a service into lines and writes them to a ring buffer
+type logWriter struct {
+	sync.Mutex
+	buffer  *ring.Buffer
+	partial []byte
+}
+
+func newLogWriter(size int) *logWriter {
+	return &logWriter{buffer: ring.New(size)}
+}
+
+// Write implements io.Writer. Incomplete lines are held until the rest of the line is written.
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		idx := bytes.IndexByte(w.partial, '\n')
+		if idx < 0 {
+			break
+		}
+		w.buffer.Put(string(bytes.TrimRight(w.partial[:idx], "\r")))
+		w.partial = w.partial[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+// entries returns the buffered entries matching the options
+func (w *logWriter) entries(opts runtime.LogsOptions) []*ring.Entry {
+	// a count of zero returns everything in the buffer
+	if opts.Since.IsZero() && opts.Count == 0 {
+		return w.buffer.Since(time.Time{})
+	}
+	if opts.Since.IsZero() {
+		return w.buffer.Get(int(opts.Count))
+	}
+
+	entries := w.buffer.Since(opts.Since)
+	if opts.Count > 0 && len(entries) > int(opts.Count) {
+		entries = entries[len(entries)-int(opts.Count):]
+	}
+	return entries
+}
+
+// tail returns the buffered lines, including any incomplete line
+func (w *logWriter) tail() string {
+	w.Lock()
+	defer w.Unlock()
+
+	var lines []string
+	for _, e := range w.buffer.Since(time.Time{}) {
+		if msg, ok := e.Value.(string); ok {
+			lines = append(lines, msg)
+		}
+	}
+	if len(w.partial) > 0 {
+		lines = append(lines, string(w.partial))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func toLog(e *ring.Entry) runtime.Log {
+	msg, _ := e.Value.(string)
+	return runtime.Log{
+		Message:   msg,
+		Timestamp: e.Timestamp,
+	}
+}
+
+// errSlowStream is the error of a stream closed because it fell behind the logs of the service
+var errSlowStream = errors.New("log stream closed since it fell behind the service")
+
+// bufferStream is a log stream which reads from a service's log buffer
+type bufferStream struct {
+	sync.Mutex
+	stream chan runtime.Log
+	stop   chan bool
+	err    error
+	// done is closed to stop streaming from the buffer
+	done chan bool
+}
+
+func newBufferStream(w *logWriter, opts runtime.LogsOptions) *bufferStream {
+	s := &bufferStream{
+		stream: make(chan runtime.Log),
+		stop:   make(chan bool),
+	}
+
+	// subscribe before reading the existing records so nothing is missed in between
+	var stream <-chan *ring.Entry
+	if opts.Stream {
+		stream, s.done = w.buffer.Stream()
+	}
+	entries := w.entries(opts)
+
+	go func() {
+		defer close(s.stream)
+		defer s.Stop()
+
+		sent := make(map[*ring.Entry]bool, len(entries))
+		for _, e := range entries {
+			select {
+			case s.stream <- toLog(e):
+				sent[e] = true
+			case <-s.stop:
+				return
+			}
+		}
+
+		if stream == nil {
+			return
+		}
+
+		for {
+			select {
+			case e, ok := <-stream:
+				if !ok {
+					// the buffer closes the streams which fall behind rather than block the service
+					s.Lock()
+					select {
+					case <-s.stop:
+					default:
+						s.err = errSlowStream
+					}
+					s.Unlock()
+					return
+				}
+				// skip anything put between subscribing and reading the buffer
+				if sent[e] {
+					continue
+				}
+				select {
+				case s.stream <- toLog(e):
+				case <-s.stop:
+					return
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+
+	return s
+}
+
+func (s *bufferStream) Chan() chan runtime.Log {
+	return s.stream
+}
+
+func (s *bufferStream) Error() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.err
+}
+
+func (s *bufferStream) Stop() error {
+	s.Lock()
+	defer s.Unlock()
+
+	select {
+	case <-s.stop:
+		return nil
+	default:
+		close(s.stop)
+		if s.done != nil {
+			close(s.done)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogWriter(t *testing.T) {
+	w := newLogWriter(3)
+
+	// partial lines are only buffered once complete
+	w.Write([]byte("one\ntw"))
+	assert.Len(t, w.entries(runtime.LogsOptions{}), 1)
+	w.Write([]byte("o\r\nthree\nfour\n"))
+
+	// the buffer only keeps the latest lines
+	var msgs []string
+	for _, e := range w.entries(runtime.LogsOptions{}) {
+		msgs = append(msgs, e.Value.(string))
+	}
+	assert.Equal(t, []string{"two", "three", "four"}, msgs)
+
+	// count returns the last n lines
+	entries := w.entries(runtime.LogsOptions{Count: 1})
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "four", entries[0].Value)
+	}
+
+	// nothing was logged since now
+	assert.Empty(t, w.entries(runtime.LogsOptions{Since: time.Now().Add(time.Second)}))
+}
+
+func TestBufferStream(t *testing.T) {
+	w := newLogWriter(10)
+	w.Write([]byte("existing\n"))
+
+	s := newBufferStream(w, runtime.LogsOptions{Stream: true})
+	defer s.Stop()
+
+	w.Write([]byte("new\n"))
+
+	for _, expected := range []string{"existing", "new"} {
+		select {
+		case l := <-s.Chan():
+			assert.Equal(t, expected, l.Message)
+			assert.False(t, l.Timestamp.IsZero())
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for %q", expected)
+		}
+	}
+
+	// the stream is closed when stopped
+	s.Stop()
+	_, ok := <-s.Chan()
+	assert.False(t, ok)
+}
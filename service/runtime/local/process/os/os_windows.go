@@ -99,5 +99,5 @@ func (p *Process) Wait(pid *process.PID) error {
 		return nil
 	}
 
-	return fmt.Errorf(ps.String())
+	return &process.ExitError{Code: ps.ExitCode(), Status: ps.String()}
 }
@@ -44,6 +44,18 @@ type Binary struct {
 	Dir string
 }
 
+// ExitError is returned when waiting for a process which exits unsuccessfully
+type ExitError struct {
+	// Code the process exited with, -1 if it was terminated by a signal
+	Code int
+	// Status describes how the process exited e.g. exit status 1
+	Status string
+}
+
+func (e *ExitError) Error() string {
+	return e.Status
+}
+
 // PID is the running process
 type PID struct {
 	// ID of the process
@@ -23,12 +23,23 @@ import (
 	"time"
 
 	"github.com/micro/micro/v3/internal/build"
+	"github.com/micro/micro/v3/internal/cron"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/runtime"
 	"github.com/micro/micro/v3/service/runtime/local/process"
 	proc "github.com/micro/micro/v3/service/runtime/local/process/os"
 )
 
+var (
+	// RestartBackoff is how long to wait before restarting a service which crashed, the wait is
+	// doubled each time it crashes in a row
+	RestartBackoff = time.Second
+	// MaxRestartBackoff is the longest to wait before restarting a service which crashed
+	MaxRestartBackoff = time.Minute
+	// CrashOutputLines is the number of lines of stderr kept from a service which crashed
+	CrashOutputLines = 20
+)
+
 type service struct {
 	sync.RWMutex
 
@@ -39,9 +50,28 @@ type service struct {
 
 	retries    int
 	maxRetries int
+	// restarts is the number of times the service was restarted after crashing
+	restarts int
+
+	// job is true if the service runs to completion
+	job bool
+	// schedule the job is run on, nil for one-shot jobs
+	schedule *cron.Schedule
+	// dependencies which must be healthy before the service is started
+	dependencies []string
+	// cancel is closed to stop waiting to start the service, i.e. on the schedule or for dependencies
+	cancel chan bool
+	// replicas are the additional instances of the service
+	replicas []*service
 
 	// output for logs
 	output io.Writer
+	// logs buffers the most recent output
+	logs *logWriter
+	// stderr buffers the most recent errors of the process, kept when it crashes
+	stderr *logWriter
+	// stderrDone is closed once the errors of the process have been read
+	stderrDone chan bool
 
 	// service to manage
 	*runtime.Service
@@ -53,7 +83,7 @@ type service struct {
 	PID *process.PID
 }
 
-func newService(s *runtime.Service, c runtime.CreateOptions) *service {
+func newService(s *runtime.Service, c runtime.CreateOptions, schedule *cron.Schedule) *service {
 	var exec string
 	var args []string
 
@@ -63,6 +93,13 @@ func newService(s *runtime.Service, c runtime.CreateOptions) *service {
 
 	dir := s.Source
 
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]string)
+	}
+	if schedule != nil {
+		s.Metadata["schedule"] = schedule.String()
+	}
+
 	return &service{
 		Service: s,
 		Process: new(proc.Process),
@@ -75,16 +112,93 @@ func newService(s *runtime.Service, c runtime.CreateOptions) *service {
 			Args: args,
 			Dir:  dir,
 		},
+		closed:       make(chan bool),
+		output:       c.Output,
+		logs:         newLogWriter(DefaultLogSize),
+		updated:      time.Now(),
+		maxRetries:   c.Retries,
+		job:          c.Type == runtime.JobType || schedule != nil,
+		schedule:     schedule,
+		dependencies: c.Dependencies,
+		cancel:       make(chan bool),
+	}
+}
+
+// replica returns another instance of the service which shares its output
+func (s *service) replica(i int) *service {
+	exec := *s.Exec
+	// bind each instance to a random port
+	exec.Env = append(append([]string{}, s.Exec.Env...), "MICRO_SERVICE_ADDRESS=:0")
+
+	return &service{
+		Service: &runtime.Service{
+			Name:     s.Name,
+			Version:  s.Version,
+			Source:   s.Source,
+			Metadata: map[string]string{"replica": strconv.Itoa(i)},
+		},
+		Process:    new(proc.Process),
+		Exec:       &exec,
 		closed:     make(chan bool),
-		output:     c.Output,
+		output:     s.output,
+		logs:       s.logs,
 		updated:    time.Now(),
-		maxRetries: c.Retries,
+		maxRetries: s.maxRetries,
+		cancel:     make(chan bool),
+	}
+}
+
+// Scale runs n instances of the service, the service itself being the first instance
+func (s *service) Scale(n int) {
+	if n < 1 {
+		n = 1
+	}
+
+	s.Lock()
+	var add, remove []*service
+	for len(s.replicas) < n-1 {
+		r := s.replica(len(s.replicas) + 1)
+		s.replicas = append(s.replicas, r)
+		add = append(add, r)
+	}
+	if len(s.replicas) > n-1 {
+		remove = s.replicas[n-1:]
+		s.replicas = s.replicas[:n-1]
+	}
+	s.Metadata["replicas"] = strconv.Itoa(n)
+	running := s.running
+	s.Unlock()
+
+	for _, r := range remove {
+		if err := r.Stop(); err != nil {
+			logger.Errorf("Runtime failed to stop replica of %s: %v", s.Name, err)
+		}
+	}
+
+	// new replicas are started along with the service if it's not running yet
+	if !running {
+		return
+	}
+	for _, r := range add {
+		if err := r.Start(); err != nil {
+			logger.Errorf("Runtime failed to start replica of %s: %v", s.Name, err)
+		}
 	}
 }
 
 func (s *service) streamOutput() {
-	go io.Copy(s.output, s.PID.Output)
-	go io.Copy(s.output, s.PID.Error)
+	pid, done := s.PID, s.stderrDone
+
+	var stderr io.Writer = s.stderr
+	if s.output != nil {
+		go io.Copy(s.output, pid.Output)
+		stderr = io.MultiWriter(s.output, s.stderr)
+	}
+
+	go func() {
+		io.Copy(stderr, pid.Error)
+		close(done)
+	}()
 }
 
 func (s *service) shouldStart() bool {
@@ -127,6 +241,24 @@ func (s *service) Start() error {
 	if s.Metadata == nil {
 		s.Metadata = make(map[string]string)
 	}
+	if err := s.start(); err != nil {
+		return err
+	}
+
+	// start the other instances
+	for _, r := range s.replicas {
+		if err := r.Start(); err != nil {
+			logger.Errorf("Runtime failed to start replica of %s: %v", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// start forks the process of the service. Assumes it's called under a lock as it mutates state
+func (s *service) start() error {
+	delete(s.Metadata, "backoff")
+	delete(s.Metadata, "nextRestart")
 	s.Status(runtime.Starting, nil)
 
 	// TODO: pull source & build binary
@@ -148,9 +280,9 @@ func (s *service) Start() error {
 	// set started
 	s.Metadata["started"] = time.Now().Format(time.RFC3339)
 
-	if s.output != nil {
-		s.streamOutput()
-	}
+	s.stderr = newLogWriter(CrashOutputLines)
+	s.stderrDone = make(chan bool)
+	s.streamOutput()
 
 	// wait and watch
 	go s.Wait()
@@ -158,6 +290,79 @@ func (s *service) Start() error {
 	return nil
 }
 
+// Healthy returns true if services which depend on this one can be started. Jobs are healthy once
+// they have completed successfully.
+func (s *service) Healthy() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.job {
+		return len(s.Metadata["completed"]) > 0 && s.err == nil
+	}
+	return s.running && s.Service.Status == runtime.Running
+}
+
+// Schedule starts the job each time its schedule fires until the service is stopped. If the
+// previous run is still in progress when the schedule fires, the run is skipped.
+func (s *service) Schedule() {
+	s.RLock()
+	done := s.cancel
+	s.RUnlock()
+
+	for {
+		next := s.schedule.Next(time.Now())
+		if next.IsZero() {
+			return
+		}
+
+		s.Lock()
+		s.Metadata["nextRun"] = next.Format(time.RFC3339)
+		s.Unlock()
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Until(next)):
+		}
+
+		if s.Running() {
+			logger.Warnf("Runtime job %s is still running, skipping scheduled run", s.Name)
+			continue
+		}
+		if err := s.Start(); err != nil {
+			logger.Errorf("Runtime failed to start scheduled job %s: %v", s.Name, err)
+		}
+	}
+}
+
+// Pending marks the service as waiting to be started and returns the channel which is closed if
+// it's stopped before then
+func (s *service) Pending() chan bool {
+	s.Lock()
+	defer s.Unlock()
+
+	// the service may have been stopped previously
+	select {
+	case <-s.cancel:
+		s.cancel = make(chan bool)
+	default:
+	}
+	s.Status(runtime.Pending, nil)
+	return s.cancel
+}
+
+// Cancel stops waiting to start the service and any future runs of a job
+func (s *service) Cancel() {
+	s.Lock()
+	defer s.Unlock()
+
+	select {
+	case <-s.cancel:
+	default:
+		close(s.cancel)
+	}
+}
+
 // Status updates the status of the service. Assumes it's called under a lock as it mutates state
 func (s *service) Status(status runtime.ServiceStatus, err error) {
 	s.Service.Status = status
@@ -172,6 +377,18 @@ func (s *service) Status(status runtime.ServiceStatus, err error) {
 
 // Stop stops the service
 func (s *service) Stop() error {
+	s.Cancel()
+
+	// stop the other instances
+	s.RLock()
+	replicas := s.replicas
+	s.RUnlock()
+	for _, r := range replicas {
+		if err := r.Stop(); err != nil {
+			logger.Errorf("Runtime failed to stop replica of %s: %v", s.Name, err)
+		}
+	}
+
 	s.Lock()
 	defer s.Unlock()
 
@@ -216,9 +433,19 @@ func (s *service) Wait() {
 	// wait for process to exit
 	s.RLock()
 	thisPID := s.PID
+	closed := s.closed
+	stderr, stderrDone := s.stderr, s.stderrDone
 	s.RUnlock()
 	err := s.Process.Wait(thisPID)
 
+	// give the remaining errors a moment to be read so the end of them is kept
+	if err != nil {
+		select {
+		case <-stderrDone:
+		case <-time.After(time.Second):
+		}
+	}
+
 	s.Lock()
 	defer s.Unlock()
 
@@ -228,6 +455,24 @@ func (s *service) Wait() {
 		return
 	}
 
+	// the service was stopped so exiting isn't a crash
+	select {
+	case <-closed:
+		s.running = false
+		return
+	default:
+	}
+
+	// jobs are expected to exit so track the result of the run
+	if s.job {
+		s.Metadata["completed"] = time.Now().Format(time.RFC3339)
+		if err != nil {
+			s.Metadata["exitStatus"] = err.Error()
+		} else {
+			s.Metadata["exitStatus"] = "exit status 0"
+		}
+	}
+
 	// save the error
 	if err != nil {
 		if logger.V(logger.ErrorLevel, logger.DefaultLogger) {
@@ -236,6 +481,7 @@ func (s *service) Wait() {
 		s.retries++
 		s.Status(runtime.Error, err)
 		s.Metadata["retries"] = strconv.Itoa(s.retries)
+		s.crashed(err, stderr)
 
 		s.err = err
 	} else {
@@ -244,4 +490,62 @@ func (s *service) Wait() {
 
 	// no longer running
 	s.running = false
+
+	// services are restarted after crashing until they run out of retries
+	if err != nil && !s.job && s.retries <= s.maxRetries {
+		s.backoff(thisPID, closed)
+	}
+}
+
+// crashed records how the service crashed. Assumes it's called under a lock as it mutates state
+func (s *service) crashed(err error, stderr *logWriter) {
+	s.Metadata["lastCrash"] = time.Now().Format(time.RFC3339)
+	if exitErr, ok := err.(*process.ExitError); ok {
+		s.Metadata["exitCode"] = strconv.Itoa(exitErr.Code)
+	} else {
+		delete(s.Metadata, "exitCode")
+	}
+	if out := stderr.tail(); len(out) > 0 {
+		s.Metadata["crashOutput"] = out
+	} else {
+		delete(s.Metadata, "crashOutput")
+	}
+}
+
+// backoff restarts the service after it crashed, waiting twice as long each time it crashes in a
+// row. Assumes it's called under a lock as it mutates state
+func (s *service) backoff(pid *process.PID, closed chan bool) {
+	delay := RestartBackoff << uint(s.retries-1)
+	if delay <= 0 || delay > MaxRestartBackoff {
+		delay = MaxRestartBackoff
+	}
+	s.Metadata["backoff"] = "true"
+	s.Metadata["nextRestart"] = time.Now().Add(delay).Format(time.RFC3339)
+
+	go func() {
+		select {
+		case <-closed:
+			return
+		case <-time.After(delay):
+		}
+
+		s.Lock()
+		defer s.Unlock()
+
+		// the service may have been stopped or started again while waiting
+		select {
+		case <-closed:
+			return
+		default:
+		}
+		if s.running || s.PID != pid {
+			return
+		}
+
+		s.restarts++
+		s.Metadata["restarts"] = strconv.Itoa(s.restarts)
+		if err := s.start(); err != nil {
+			logger.Errorf("Runtime failed to restart service %s: %v", s.Name, err)
+		}
+	}()
 }
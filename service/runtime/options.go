@@ -17,6 +17,7 @@ package runtime
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/micro/micro/v3/service/client"
 )
@@ -99,6 +100,29 @@ type CreateOptions struct {
 	Volumes map[string]string
 	// ServiceAccount to start the container with
 	ServiceAccount string
+	// Schedule is a cron expression which a job is run on, e.g. "0 3 * * *"
+	Schedule string
+	// Replicas is the number of instances of the service to run
+	Replicas int
+	// Dependencies are the names of services which must be healthy before the service is started
+	Dependencies []string
+	// Prebuilt is the url or blob store key of a binary to run instead of building the source
+	Prebuilt string
+	// ConfigRefs are the config paths of env vars which are resolved when the service is started,
+	// keyed by the env var
+	ConfigRefs map[string]string
+	// SecretRefs are the config paths of secrets which are resolved when the service is started,
+	// keyed by the env var
+	SecretRefs map[string]string
+	// Rotate restarts the service when the secrets it references change
+	Rotate bool
+	// Region the service must run in, any region if blank
+	Region string
+	// OS and Arch to build the binary for, the host of the runtime if blank
+	OS   string
+	Arch string
+	// BuildFlags are passed to the build, e.g. -trimpath
+	BuildFlags []string
 }
 
 // ReadOptions queries runtime services
@@ -223,6 +247,13 @@ func WithPort(p string) CreateOption {
 	}
 }
 
+// WithSchedule sets the cron schedule to run a job on
+func WithSchedule(s string) CreateOption {
+	return func(o *CreateOptions) {
+		o.Schedule = s
+	}
+}
+
 // ResourceLimits sets the resources for the service to use
 func ResourceLimits(r *Resources) CreateOption {
 	return func(o *CreateOptions) {
@@ -230,6 +261,79 @@ func ResourceLimits(r *Resources) CreateOption {
 	}
 }
 
+// WithReplicas sets the number of instances of the service to run
+func WithReplicas(n int) CreateOption {
+	return func(o *CreateOptions) {
+		o.Replicas = n
+	}
+}
+
+// WithDependencies sets the services which must be healthy before the service is started
+func WithDependencies(deps ...string) CreateOption {
+	return func(o *CreateOptions) {
+		o.Dependencies = deps
+	}
+}
+
+// WithPrebuilt runs the prebuilt binary at the url or blob store key instead of building the source
+func WithPrebuilt(url string) CreateOption {
+	return func(o *CreateOptions) {
+		o.Prebuilt = url
+	}
+}
+
+// WithConfigRef sets an env var to the value at the config path when the service is started
+func WithConfigRef(env, path string) CreateOption {
+	return func(o *CreateOptions) {
+		if o.ConfigRefs == nil {
+			o.ConfigRefs = map[string]string{env: path}
+		} else {
+			o.ConfigRefs[env] = path
+		}
+	}
+}
+
+// WithSecretRef sets a secret to the value of the config secret at the path when the service is
+// started
+func WithSecretRef(env, path string) CreateOption {
+	return func(o *CreateOptions) {
+		if o.SecretRefs == nil {
+			o.SecretRefs = map[string]string{env: path}
+		} else {
+			o.SecretRefs[env] = path
+		}
+	}
+}
+
+// WithRotation restarts the service when the secrets it references change
+func WithRotation(b bool) CreateOption {
+	return func(o *CreateOptions) {
+		o.Rotate = b
+	}
+}
+
+// WithRegion places the service on the nodes of the region
+func WithRegion(r string) CreateOption {
+	return func(o *CreateOptions) {
+		o.Region = r
+	}
+}
+
+// WithTarget cross compiles the binary of the service for the OS and architecture
+func WithTarget(os, arch string) CreateOption {
+	return func(o *CreateOptions) {
+		o.OS = os
+		o.Arch = arch
+	}
+}
+
+// WithBuildFlags sets the flags passed to the build of the service
+func WithBuildFlags(flags ...string) CreateOption {
+	return func(o *CreateOptions) {
+		o.BuildFlags = flags
+	}
+}
+
 // ReadService returns services with the given name
 func ReadService(service string) ReadOption {
 	return func(o *ReadOptions) {
@@ -276,6 +380,10 @@ type UpdateOptions struct {
 	Context context.Context
 	// Secrets to use
 	Secrets map[string]string
+	// Replicas is the number of instances of the service to run
+	Replicas int
+	// Autoscale the number of instances using the policy
+	Autoscale *ScalePolicy
 }
 
 // WithSecret sets a secret to provide the service with
@@ -296,6 +404,20 @@ func UpdateNamespace(ns string) UpdateOption {
 	}
 }
 
+// UpdateReplicas sets the number of instances of the service to run
+func UpdateReplicas(n int) UpdateOption {
+	return func(o *UpdateOptions) {
+		o.Replicas = n
+	}
+}
+
+// UpdateAutoscale sets the policy to automatically scale the service by
+func UpdateAutoscale(p *ScalePolicy) UpdateOption {
+	return func(o *UpdateOptions) {
+		o.Autoscale = p
+	}
+}
+
 // UpdateContext sets the context
 func UpdateContext(ctx context.Context) UpdateOption {
 	return func(o *UpdateOptions) {
@@ -342,6 +464,8 @@ type LogsOptions struct {
 	Count int64
 	// Stream new lines?
 	Stream bool
+	// Since is the time from which to show logs
+	Since time.Time
 	// Namespace the service is running in
 	Namespace string
 	// Specify the context to use
@@ -362,6 +486,13 @@ func LogsStream(stream bool) LogsOption {
 	}
 }
 
+// LogsSince configures the time from which to show logs
+func LogsSince(t time.Time) LogsOption {
+	return func(l *LogsOptions) {
+		l.Since = t
+	}
+}
+
 // LogsNamespace sets the namespace
 func LogsNamespace(ns string) LogsOption {
 	return func(o *LogsOptions) {
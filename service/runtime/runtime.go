@@ -29,6 +29,10 @@ var (
 	ErrNotFound        = errors.New("not found")
 )
 
+// JobType is the type of service which runs to completion rather than indefinitely. Jobs are
+// run once unless a schedule is provided using the WithSchedule option.
+const JobType = "job"
+
 // Runtime is a service runtime manager
 type Runtime interface {
 	// Init initializes runtime
@@ -60,8 +64,9 @@ type LogStream interface {
 
 // Log is a log message
 type Log struct {
-	Message  string
-	Metadata map[string]string
+	Message   string
+	Metadata  map[string]string
+	Timestamp time.Time
 }
 
 // EventType defines schedule event
@@ -140,6 +145,46 @@ type Resources struct {
 	Disk int
 }
 
+// ScalePolicy is used to automatically scale the number of instances of a service based on a
+// metric, e.g. scale up when the average requests per second of the instances are above the threshold
+type ScalePolicy struct {
+	// Min is the minimum number of instances to run
+	Min int
+	// Max is the maximum number of instances to run
+	Max int
+	// Metric to scale on, memory, threads, requests or errors
+	Metric string
+	// Threshold is the value of the metric per instance at which the service is scaled up. The
+	// service is scaled down when the value is below half the threshold.
+	Threshold float64
+}
+
+// Replicas returns the number of instances which should be run given the current number of
+// instances and the total value of the metric across them
+func (p *ScalePolicy) Replicas(current int, value float64) int {
+	if current < 1 {
+		current = 1
+	}
+
+	desired := current
+	if avg := value / float64(current); avg > p.Threshold {
+		desired++
+	} else if avg < p.Threshold/2 {
+		desired--
+	}
+
+	if desired < p.Min {
+		desired = p.Min
+	}
+	if p.Max > 0 && desired > p.Max {
+		desired = p.Max
+	}
+	if desired < 1 {
+		desired = 1
+	}
+	return desired
+}
+
 // Create a resource
 func Create(resource Resource, opts ...CreateOption) error {
 	return DefaultRuntime.Create(resource, opts...)
@@ -0,0 +1,31 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalePolicy(t *testing.T) {
+	p := &ScalePolicy{Min: 2, Max: 4, Metric: "requests", Threshold: 80}
+
+	tt := []struct {
+		name     string
+		current  int
+		value    float64
+		expected int
+	}{
+		{name: "above threshold", current: 2, value: 200, expected: 3},
+		{name: "within threshold", current: 2, value: 120, expected: 2},
+		{name: "below half threshold", current: 3, value: 60, expected: 2},
+		{name: "at max", current: 4, value: 400, expected: 4},
+		{name: "at min", current: 2, value: 0, expected: 2},
+		{name: "below min", current: 1, value: 50, expected: 2},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, p.Replicas(tc.current, tc.value))
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/micro/micro/v3/internal/artifact"
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/runtime"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+)
+
+// Artifact processes RPC calls to push and pull the artifacts of services
+type Artifact struct{}
+
+func artifactToProto(a *artifact.Artifact) *pb.ArtifactInfo {
+	return &pb.ArtifactInfo{
+		Name:      a.Name,
+		Version:   a.Version,
+		Namespace: a.Namespace,
+		Image:     a.Image,
+		Digest:    a.Digest,
+		Size:      a.Size,
+		Commit:    a.Commit,
+		Metadata:  a.Metadata,
+		Created:   a.Created.Unix(),
+	}
+}
+
+// pushReader reads the binary from the data of the messages of a push
+type pushReader struct {
+	stream pb.Artifact_PushStream
+	data   []byte
+	eof    bool
+}
+
+// next receives the next message with data, returning false once there are none
+func (p *pushReader) next() (bool, error) {
+	for len(p.data) == 0 {
+		if p.eof {
+			return false, nil
+		}
+		req, err := p.stream.Recv()
+		if err == io.EOF {
+			p.eof = true
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		p.data = req.Data
+	}
+	return true, nil
+}
+
+func (p *pushReader) Read(b []byte) (int, error) {
+	if ok, err := p.next(); err != nil {
+		return 0, err
+	} else if !ok {
+		return 0, io.EOF
+	}
+	n := copy(b, p.data)
+	p.data = p.data[n:]
+	return n, nil
+}
+
+// Push an artifact, the binary is streamed after the artifact which is sent on the first message.
+// The binary is streamed to the blob store as it's received.
+func (a *Artifact) Push(ctx context.Context, stream pb.Artifact_PushStream) error {
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return errors.BadRequest("runtime.Artifact.Push", "No artifact was sent")
+	} else if err != nil {
+		return errors.InternalServerError("runtime.Artifact.Push", err.Error())
+	}
+	info := req.Artifact
+	if info == nil {
+		return errors.BadRequest("runtime.Artifact.Push", "No artifact was sent")
+	}
+	if len(info.Name) == 0 || len(info.Version) == 0 {
+		return errors.BadRequest("runtime.Artifact.Push", "Missing name or version")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "runtime.Artifact.Push", info.Namespace)
+	if err != nil {
+		return err
+	}
+	info.Namespace = ns
+
+	// the reader is only set if a binary is sent, a nil reader is an image
+	var bin io.Reader
+	r := &pushReader{stream: stream, data: req.Data}
+	if ok, err := r.next(); err != nil {
+		return errors.InternalServerError("runtime.Artifact.Push", err.Error())
+	} else if ok {
+		bin = r
+	}
+	if bin == nil && len(info.Image) == 0 {
+		return errors.BadRequest("runtime.Artifact.Push", "Missing binary or image")
+	}
+
+	art := &artifact.Artifact{
+		Name:      info.Name,
+		Version:   info.Version,
+		Namespace: info.Namespace,
+		Image:     info.Image,
+		Digest:    info.Digest,
+		Commit:    info.Commit,
+		Metadata:  info.Metadata,
+	}
+	if info.Created > 0 {
+		art.Created = time.Unix(info.Created, 0)
+	}
+
+	if err := artifact.Push(art, bin); err == artifact.ErrDigestMismatch {
+		return errors.BadRequest("runtime.Artifact.Push", "The binary doesn't match the digest %v", info.Digest)
+	} else if err == artifact.ErrTooLarge {
+		return errors.BadRequest("runtime.Artifact.Push", "The binary is larger than %v bytes", artifact.MaxSize)
+	} else if err != nil {
+		return errors.InternalServerError("runtime.Artifact.Push", "Unable to write the artifact: %v", err)
+	}
+
+	log.Infof("Pushed artifact %v@%v in %v", art.Name, art.Version, art.Namespace)
+	return stream.SendAndClose(&pb.PushArtifactResponse{Artifact: artifactToProto(art)})
+}
+
+// Read the artifact of a version of a service
+func (a *Artifact) Read(ctx context.Context, req *pb.ReadArtifactRequest, rsp *pb.ReadArtifactResponse) error {
+	if len(req.Name) == 0 || len(req.Version) == 0 {
+		return errors.BadRequest("runtime.Artifact.Read", "Missing name or version")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "runtime.Artifact.Read", req.Namespace)
+	if err != nil {
+		return err
+	}
+	req.Namespace = ns
+
+	art, err := artifact.Get(req.Namespace, req.Name, req.Version)
+	if err == artifact.ErrNotFound {
+		return errors.NotFound("runtime.Artifact.Read", "Artifact %v@%v not found", req.Name, req.Version)
+	} else if err != nil {
+		return errors.InternalServerError("runtime.Artifact.Read", "Unable to read from store: %v", err)
+	}
+	rsp.Artifact = artifactToProto(art)
+	return nil
+}
+
+// List the artifacts in a namespace, newest first for each service
+func (a *Artifact) List(ctx context.Context, req *pb.ListArtifactsRequest, rsp *pb.ListArtifactsResponse) error {
+	ns, err := namespace.AuthorizeRequest(ctx, "runtime.Artifact.List", req.Namespace)
+	if err != nil {
+		return err
+	}
+	req.Namespace = ns
+
+	arts, err := artifact.List(req.Namespace, req.Name)
+	if err != nil {
+		return errors.InternalServerError("runtime.Artifact.List", "Unable to read from store: %v", err)
+	}
+	for _, art := range arts {
+		rsp.Artifacts = append(rsp.Artifacts, artifactToProto(art))
+	}
+	return nil
+}
+
+// Delete the artifact of a version of a service
+func (a *Artifact) Delete(ctx context.Context, req *pb.DeleteArtifactRequest, rsp *pb.DeleteArtifactResponse) error {
+	if len(req.Name) == 0 || len(req.Version) == 0 {
+		return errors.BadRequest("runtime.Artifact.Delete", "Missing name or version")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "runtime.Artifact.Delete", req.Namespace)
+	if err != nil {
+		return err
+	}
+	req.Namespace = ns
+
+	if err := artifact.Delete(req.Namespace, req.Name, req.Version); err == artifact.ErrNotFound {
+		return errors.NotFound("runtime.Artifact.Delete", "Artifact %v@%v not found", req.Name, req.Version)
+	} else if err != nil {
+		return errors.InternalServerError("runtime.Artifact.Delete", "Unable to delete from store: %v", err)
+	}
+
+	log.Infof("Deleted artifact %v@%v in %v", req.Name, req.Version, req.Namespace)
+	return nil
+}
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/pagination"
 	pb "github.com/micro/micro/v3/proto/runtime"
 	"github.com/micro/micro/v3/service/auth"
 	"github.com/micro/micro/v3/service/errors"
@@ -17,6 +20,21 @@ import (
 
 type Runtime struct {
 	Runtime gorun.Runtime
+
+	// watchers of the runtime events
+	watchers watchers
+}
+
+// eventReason returns the reason for an event caused by a request, e.g. "Deleted by john"
+func eventReason(ctx context.Context, action string) string {
+	account, ok := auth.AccountFromContext(ctx)
+	if !ok {
+		return action
+	}
+	if len(account.Name) > 0 {
+		return action + " by " + account.Name
+	}
+	return action + " by " + account.ID
 }
 
 func setupServiceMeta(ctx context.Context, service *runtime.Service) {
@@ -66,8 +84,18 @@ func (r *Runtime) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadRes
 		return errors.InternalServerError("runtime.Runtime.Read", err.Error())
 	}
 
+	// sort the services so the pages are stable
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Name != services[j].Name {
+			return services[i].Name < services[j].Name
+		}
+		return services[i].Version < services[j].Version
+	})
+	start, end := pagination.Bounds(len(services), req.Offset, req.Limit)
+	rsp.Total = int64(len(services))
+
 	// serialize the response
-	for _, service := range services {
+	for _, service := range services[start:end] {
 		rsp.Services = append(rsp.Services, toProto(service))
 	}
 
@@ -101,6 +129,9 @@ func (r *Runtime) Logs(ctx context.Context, req *pb.LogsRequest, stream pb.Runti
 	if req.GetStream() {
 		opts = append(opts, runtime.LogsStream(req.GetStream()))
 	}
+	if req.GetSince() > 0 {
+		opts = append(opts, runtime.LogsSince(time.Now().Add(-time.Duration(req.GetSince())*time.Second)))
+	}
 
 	logStream, err := r.Runtime.Logs(&runtime.Service{
 		Name: req.GetService(),
@@ -119,11 +150,14 @@ func (r *Runtime) Logs(ctx context.Context, req *pb.LogsRequest, stream pb.Runti
 				return logStream.Error()
 			}
 			// send record
-			if err := stream.Send(&pb.LogRecord{
-				//Timestamp: record.Timestamp.Unix(),
+			rec := &pb.LogRecord{
 				Metadata: record.Metadata,
 				Message:  record.Message,
-			}); err != nil {
+			}
+			if !record.Timestamp.IsZero() {
+				rec.Timestamp = record.Timestamp.Unix()
+			}
+			if err := stream.Send(rec); err != nil {
 				return err
 			}
 		case <-ctx.Done():
@@ -253,6 +287,7 @@ func (r *Runtime) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.Cre
 			Service:   service,
 			Namespace: req.Options.Namespace,
 			Type:      runtime.EventServiceCreated,
+			Reason:    eventReason(ctx, "Created"),
 		}
 
 		return events.Publish(runtime.EventTopic, ev, goevents.WithMetadata(map[string]string{
@@ -384,6 +419,7 @@ func (r *Runtime) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Del
 			Type:      runtime.EventServiceDeleted,
 			Namespace: req.Options.Namespace,
 			Service:   service,
+			Reason:    eventReason(ctx, "Deleted"),
 		}
 
 		return events.Publish(runtime.EventTopic, ev, goevents.WithMetadata(map[string]string{
@@ -497,10 +533,15 @@ func (r *Runtime) Update(ctx context.Context, req *pb.UpdateRequest, rsp *pb.Upd
 		}
 
 		// publish the update event
+		action := "Updated"
+		if len(service.Source) == 0 && req.Options.Replicas > 0 {
+			action = fmt.Sprintf("Scaled to %d instances", req.Options.Replicas)
+		}
 		ev := &runtime.EventPayload{
 			Service:   service,
 			Namespace: req.Options.Namespace,
 			Type:      runtime.EventServiceUpdated,
+			Reason:    eventReason(ctx, action),
 		}
 
 		return events.Publish(runtime.EventTopic, ev, goevents.WithMetadata(map[string]string{
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/maintenance"
+	pb "github.com/micro/micro/v3/proto/runtime"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+)
+
+// Maintenance processes RPC calls to put services in maintenance
+type Maintenance struct{}
+
+// Enable the maintenance of a service
+func (m *Maintenance) Enable(ctx context.Context, req *pb.EnableMaintenanceRequest, rsp *pb.EnableMaintenanceResponse) error {
+	if len(req.Service) == 0 {
+		return errors.BadRequest("runtime.Maintenance.Enable", "Missing service")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "runtime.Maintenance.Enable", req.Namespace)
+	if err != nil {
+		return err
+	}
+	req.Namespace = ns
+
+	mt := &maintenance.Maintenance{
+		Service:   req.Service,
+		Namespace: req.Namespace,
+		Message:   req.Message,
+	}
+	if acc, ok := auth.AccountFromContext(ctx); ok {
+		mt.Owner = acc.Name
+		if len(mt.Owner) == 0 {
+			mt.Owner = acc.ID
+		}
+	}
+	if err := maintenance.Enable(mt); err != nil {
+		return errors.InternalServerError("runtime.Maintenance.Enable", "Unable to write to store: %v", err)
+	}
+
+	log.Infof("Enabled the maintenance of %v in %v", req.Service, req.Namespace)
+	return nil
+}
+
+// Disable the maintenance of a service
+func (m *Maintenance) Disable(ctx context.Context, req *pb.DisableMaintenanceRequest, rsp *pb.DisableMaintenanceResponse) error {
+	if len(req.Service) == 0 {
+		return errors.BadRequest("runtime.Maintenance.Disable", "Missing service")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "runtime.Maintenance.Disable", req.Namespace)
+	if err != nil {
+		return err
+	}
+	req.Namespace = ns
+
+	if err := maintenance.Disable(req.Namespace, req.Service); err == maintenance.ErrNotFound {
+		return errors.NotFound("runtime.Maintenance.Disable", "Service %v is not in maintenance", req.Service)
+	} else if err != nil {
+		return errors.InternalServerError("runtime.Maintenance.Disable", "Unable to delete from store: %v", err)
+	}
+
+	log.Infof("Disabled the maintenance of %v in %v", req.Service, req.Namespace)
+	return nil
+}
+
+// List the services in maintenance in a namespace
+func (m *Maintenance) List(ctx context.Context, req *pb.ListMaintenanceRequest, rsp *pb.ListMaintenanceResponse) error {
+	ns, err := namespace.AuthorizeRequest(ctx, "runtime.Maintenance.List", req.Namespace)
+	if err != nil {
+		return err
+	}
+	req.Namespace = ns
+
+	services, err := maintenance.List(req.Namespace)
+	if err != nil {
+		return errors.InternalServerError("runtime.Maintenance.List", "Unable to read from store: %v", err)
+	}
+	for _, s := range services {
+		rsp.Services = append(rsp.Services, &pb.ServiceMaintenance{
+			Service:   s.Service,
+			Namespace: s.Namespace,
+			Message:   s.Message,
+			Started:   s.Started.Unix(),
+			Owner:     s.Owner,
+		})
+	}
+	return nil
+}
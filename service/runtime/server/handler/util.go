@@ -97,6 +97,54 @@ func toCreateOptions(ctx context.Context, opts *pb.CreateOptions) []runtime.Crea
 		options = append(options, runtime.WithVolume(name, path))
 	}
 
+	// schedule a job
+	if len(opts.Schedule) > 0 {
+		options = append(options, runtime.WithSchedule(opts.Schedule))
+	}
+
+	// limit the resources
+	if r := opts.Resources; r != nil {
+		options = append(options, runtime.ResourceLimits(&runtime.Resources{
+			CPU:  int(r.CPU),
+			Mem:  int(r.Memory),
+			Disk: int(r.EphemeralStorage),
+		}))
+	}
+
+	// run multiple instances
+	if opts.Replicas > 0 {
+		options = append(options, runtime.WithReplicas(int(opts.Replicas)))
+	}
+
+	// wait for dependencies
+	if len(opts.Dependencies) > 0 {
+		options = append(options, runtime.WithDependencies(opts.Dependencies...))
+	}
+
+	// run a prebuilt binary
+	if len(opts.Prebuilt) > 0 {
+		options = append(options, runtime.WithPrebuilt(opts.Prebuilt))
+	}
+
+	// resolve env vars and secrets from config
+	for env, path := range opts.ConfigRefs {
+		options = append(options, runtime.WithConfigRef(env, path))
+	}
+	for env, path := range opts.SecretRefs {
+		options = append(options, runtime.WithSecretRef(env, path))
+	}
+	if opts.Rotate {
+		options = append(options, runtime.WithRotation(true))
+	}
+
+	// cross compile the binary
+	if len(opts.Os) > 0 || len(opts.Arch) > 0 {
+		options = append(options, runtime.WithTarget(opts.Os, opts.Arch))
+	}
+	if len(opts.BuildFlags) > 0 {
+		options = append(options, runtime.WithBuildFlags(opts.BuildFlags...))
+	}
+
 	// TODO: output options
 
 	return options
@@ -121,10 +169,25 @@ func toReadOptions(ctx context.Context, opts *pb.ReadOptions) []runtime.ReadOpti
 }
 
 func toUpdateOptions(ctx context.Context, opts *pb.UpdateOptions) []runtime.UpdateOption {
-	return []runtime.UpdateOption{
+	options := []runtime.UpdateOption{
 		runtime.UpdateNamespace(opts.Namespace),
 		runtime.UpdateEntrypoint(opts.Entrypoint),
 	}
+
+	// scale the service
+	if opts.Replicas > 0 {
+		options = append(options, runtime.UpdateReplicas(int(opts.Replicas)))
+	}
+	if p := opts.Autoscale; p != nil {
+		options = append(options, runtime.UpdateAutoscale(&runtime.ScalePolicy{
+			Min:       int(p.Min),
+			Max:       int(p.Max),
+			Metric:    p.Metric,
+			Threshold: p.Threshold,
+		}))
+	}
+
+	return options
 }
 
 func toDeleteOptions(ctx context.Context, opts *pb.DeleteOptions) []runtime.DeleteOption {
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/runtime"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/events"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/runtime"
+)
+
+// watchBuffer is the number of events buffered for a watcher, events are dropped for watchers
+// which fall further behind
+const watchBuffer = 64
+
+// watchers fan out the runtime events to the open Watch streams. The events are consumed once
+// regardless of the number of watchers.
+type watchers struct {
+	sync.Mutex
+	consuming bool
+	chans     map[chan *pb.Event]bool
+}
+
+// add a watcher, consuming the events if this is the first
+func (w *watchers) add(ch chan *pb.Event) error {
+	w.Lock()
+	defer w.Unlock()
+
+	if !w.consuming {
+		evChan, err := events.Consume(runtime.EventTopic)
+		if err != nil {
+			return err
+		}
+		w.consuming = true
+		go w.run(evChan)
+	}
+
+	if w.chans == nil {
+		w.chans = make(map[chan *pb.Event]bool)
+	}
+	w.chans[ch] = true
+	return nil
+}
+
+// remove a watcher
+func (w *watchers) remove(ch chan *pb.Event) {
+	w.Lock()
+	defer w.Unlock()
+	delete(w.chans, ch)
+}
+
+// run sends the events to the watchers until the channel is closed
+func (w *watchers) run(evChan <-chan events.Event) {
+	for ev := range evChan {
+		var payload runtime.EventPayload
+		if err := ev.Unmarshal(&payload); err != nil {
+			log.Warnf("Error unmarshaling runtime event: %v", err)
+			continue
+		}
+
+		// only the events of services are watched
+		if payload.Service == nil {
+			continue
+		}
+
+		event := &pb.Event{
+			Type:      payload.Type,
+			Timestamp: ev.Timestamp.Unix(),
+			Namespace: payload.Namespace,
+			Service:   toProto(payload.Service),
+			Reason:    payload.Reason,
+		}
+		if ev.Timestamp.IsZero() {
+			event.Timestamp = time.Now().Unix()
+		}
+
+		w.Lock()
+		for ch := range w.chans {
+			select {
+			case ch <- event:
+			default:
+				log.Warnf("Dropping runtime event %v, the watcher is too slow", event.Type)
+			}
+		}
+		w.Unlock()
+	}
+
+	w.Lock()
+	w.consuming = false
+	w.Unlock()
+}
+
+// Watch streams the events of services, e.g. when they're created, crash or are restarted
+func (r *Runtime) Watch(ctx context.Context, req *pb.WatchRequest, stream pb.Runtime_WatchStream) error {
+	// set defaults
+	if req.Options == nil {
+		req.Options = &pb.WatchOptions{}
+	}
+	if len(req.Options.Namespace) == 0 {
+		req.Options.Namespace = namespace.DefaultNamespace
+	}
+
+	// authorize the request
+	if err := namespace.Authorize(ctx, req.Options.Namespace); err == namespace.ErrForbidden {
+		return errors.Forbidden("runtime.Runtime.Watch", err.Error())
+	} else if err == namespace.ErrUnauthorized {
+		return errors.Unauthorized("runtime.Runtime.Watch", err.Error())
+	} else if err != nil {
+		return errors.InternalServerError("runtime.Runtime.Watch", err.Error())
+	}
+
+	ch := make(chan *pb.Event, watchBuffer)
+	if err := r.watchers.add(ch); err != nil {
+		return errors.InternalServerError("runtime.Runtime.Watch", "Error consuming events: %v", err)
+	}
+	defer r.watchers.remove(ch)
+	defer stream.Close()
+
+	types := make(map[string]bool, len(req.Types))
+	for _, t := range req.Types {
+		types[t] = true
+	}
+
+	for {
+		select {
+		case ev := <-ch:
+			if ev.Namespace != req.Options.Namespace {
+				continue
+			}
+			if len(req.Service) > 0 && ev.Service.Name != req.Service {
+				continue
+			}
+			if len(types) > 0 && !types[ev.Type] {
+				continue
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
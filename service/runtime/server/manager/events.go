@@ -0,0 +1,119 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/micro/micro/v3/service/events"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/runtime"
+)
+
+var (
+	// EventsInterval is how often the status of services is checked for changes to publish
+	EventsInterval = time.Second * 5
+)
+
+// serviceState is the observed state of a service in the runtime
+type serviceState struct {
+	status  runtime.ServiceStatus
+	retries string
+}
+
+// publishEvents periodically publishes the changes in status of services, e.g. when they crash,
+// until exit is closed
+func (m *manager) publishEvents(exit chan bool) {
+	ticker := time.NewTicker(EventsInterval)
+	defer ticker.Stop()
+
+	// the last observed state of the services, keyed by namespace:name:version
+	states := make(map[string]serviceState)
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			m.publishStatusEvents(states)
+		}
+	}
+}
+
+func (m *manager) publishStatusEvents(states map[string]serviceState) {
+	nss, err := m.listNamespaces()
+	if err != nil {
+		logger.Warnf("Error listing namespaces: %v", err)
+		return
+	}
+
+	seen := make(map[string]bool, len(states))
+	for _, ns := range nss {
+		srvs, err := m.Runtime.Read(runtime.ReadNamespace(ns))
+		if err != nil {
+			logger.Warnf("Error reading services from the %v namespace: %v", ns, err)
+			continue
+		}
+
+		for _, srv := range srvs {
+			key := ns + ":" + srv.Name + ":" + srv.Version
+			seen[key] = true
+
+			curr := serviceState{status: srv.Status, retries: srv.Metadata["retries"]}
+			prev, ok := states[key]
+			states[key] = curr
+
+			// the first time a service is observed there's no change to publish
+			if !ok {
+				continue
+			}
+			typ, reason := statusEvent(prev, curr, srv.Metadata["error"])
+			if len(typ) == 0 {
+				continue
+			}
+
+			ev := &runtime.EventPayload{
+				Type:      typ,
+				Namespace: ns,
+				Reason:    reason,
+				Service: &runtime.Service{
+					Name:    srv.Name,
+					Version: srv.Version,
+					Source:  srv.Source,
+					Status:  srv.Status,
+				},
+			}
+			err := events.Publish(runtime.EventTopic, ev, events.WithMetadata(map[string]string{
+				"type":      typ,
+				"namespace": ns,
+			}))
+			if err != nil {
+				logger.Warnf("Error publishing %v event for %v:%v: %v", typ, srv.Name, srv.Version, err)
+			}
+		}
+	}
+
+	// forget the services which no longer exist
+	for key := range states {
+		if !seen[key] {
+			delete(states, key)
+		}
+	}
+}
+
+// statusEvent returns the type of event and the reason for it when the state of a service changes,
+// the type is blank if there's no event to publish
+func statusEvent(prev, curr serviceState, errMsg string) (string, string) {
+	switch {
+	case curr.status == runtime.Error && prev.status != runtime.Error:
+		return runtime.EventServiceCrashed, errMsg
+	case curr.status == runtime.Running && prev.status == runtime.Error:
+		return runtime.EventServiceRestarted, fmt.Sprintf("Restarted after %v retries", curr.retries)
+	case curr.status == runtime.Running && curr.retries != prev.retries:
+		// the service crashed and was restarted between the status checks
+		return runtime.EventServiceRestarted, fmt.Sprintf("Restarted after %v retries", curr.retries)
+	case curr.status == runtime.Running && prev.status != runtime.Running:
+		return runtime.EventServiceStarted, ""
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,62 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusEvent(t *testing.T) {
+	tt := []struct {
+		name     string
+		prev     serviceState
+		curr     serviceState
+		expected string
+	}{
+		{
+			name:     "started",
+			prev:     serviceState{status: runtime.Starting},
+			curr:     serviceState{status: runtime.Running},
+			expected: runtime.EventServiceStarted,
+		},
+		{
+			name:     "crashed",
+			prev:     serviceState{status: runtime.Running},
+			curr:     serviceState{status: runtime.Error, retries: "1"},
+			expected: runtime.EventServiceCrashed,
+		},
+		{
+			name:     "restarted",
+			prev:     serviceState{status: runtime.Error, retries: "1"},
+			curr:     serviceState{status: runtime.Running, retries: "1"},
+			expected: runtime.EventServiceRestarted,
+		},
+		{
+			name:     "restarted between checks",
+			prev:     serviceState{status: runtime.Running, retries: "1"},
+			curr:     serviceState{status: runtime.Running, retries: "2"},
+			expected: runtime.EventServiceRestarted,
+		},
+		{
+			name: "unchanged",
+			prev: serviceState{status: runtime.Running},
+			curr: serviceState{status: runtime.Running},
+		},
+		{
+			name: "still crashing",
+			prev: serviceState{status: runtime.Error, retries: "1"},
+			curr: serviceState{status: runtime.Error, retries: "2"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			typ, _ := statusEvent(tc.prev, tc.curr, "exit status 1")
+			assert.Equal(t, tc.expected, typ)
+		})
+	}
+
+	_, reason := statusEvent(serviceState{status: runtime.Running}, serviceState{status: runtime.Error}, "exit status 1")
+	assert.Equal(t, "exit status 1", reason)
+}
@@ -102,6 +102,18 @@ func (m *manager) Create(resource gorun.Resource, opts ...runtime.CreateOption)
 			UpdatedAt: time.Now(),
 		}
 
+		// prebuilt binaries don't need to be built, however fetching them can take some time so
+		// we'll write the service to the store and then fetch it async
+		if len(options.Prebuilt) > 0 {
+			service.Status = gorun.Pending
+			if err := m.writeService(service); err != nil {
+				return err
+			}
+
+			go m.prebuiltAndRun(service)
+			return nil
+		}
+
 		// if there is not a build configured, start the service and then write it to the store
 		if build.DefaultBuilder == nil {
 			// the source could be a git remote or a reference to the blob store, parse it before we run
@@ -136,6 +148,13 @@ func (m *manager) Create(resource gorun.Resource, opts ...runtime.CreateOption)
 	}
 }
 
+// runtimeMetadata are the keys of metadata set by the runtime, e.g. for jobs and services which
+// crashed
+var runtimeMetadata = []string{
+	"schedule", "nextRun", "completed", "exitStatus", "replicas",
+	"retries", "restarts", "lastCrash", "exitCode", "crashOutput", "backoff", "nextRestart",
+}
+
 // Read returns the service which matches the criteria provided
 func (m *manager) Read(opts ...runtime.ReadOption) ([]*runtime.Service, error) {
 	// parse the options
@@ -197,6 +216,13 @@ func (m *manager) Read(opts ...runtime.ReadOption) ([]*runtime.Service, error) {
 		if rs.Metadata != nil && len(rs.Metadata["error"]) > 0 {
 			result[i].Metadata["status"] = rs.Metadata["error"]
 		}
+
+		// jobs track the result of their last run and when they'll next run
+		for _, key := range runtimeMetadata {
+			if v, ok := rs.Metadata[key]; ok {
+				result[i].Metadata[key] = v
+			}
+		}
 	}
 
 	return result, nil
@@ -289,8 +315,13 @@ func (m *manager) Update(resource gorun.Resource, opts ...runtime.UpdateOption)
 			return gorun.ErrNotFound
 		}
 
-		// update the service
+		// scaling doesn't change the source so the service doesn't need to be rebuilt
 		service := srvs[0]
+		if len(srv.Source) == 0 && (options.Replicas > 0 || options.Autoscale != nil) {
+			return m.scaleService(service, options)
+		}
+
+		// update the service
 		service.Service.Source = srv.Source
 		service.UpdatedAt = time.Now()
 
@@ -452,6 +483,16 @@ func (m *manager) Start() error {
 	// Watch services that were running previously. TODO: rename and run periodically
 	go m.watchServices()
 
+	// scale services which have a scale policy
+	m.exit = make(chan bool)
+	go m.autoscale(m.exit)
+
+	// restart services whose secrets have been rotated
+	go m.rotate(m.exit)
+
+	// publish the changes in status of services
+	go m.publishEvents(m.exit)
+
 	return nil
 }
 
@@ -526,6 +567,7 @@ func (m *manager) Stop() error {
 		return nil
 	}
 	m.running = false
+	close(m.exit)
 
 	return runtime.DefaultRuntime.Stop()
 }
@@ -538,6 +580,8 @@ func (m *manager) String() string {
 type manager struct {
 	// running is true after Start is called
 	running bool
+	// exit is closed when the manager is stopped
+	exit chan bool
 
 	gorun.Runtime
 }
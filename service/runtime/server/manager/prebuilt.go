@@ -0,0 +1,153 @@
+package manager
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/micro/micro/v3/internal/artifact"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	// PrebuiltDir is the directory prebuilt binaries are written to for the local runtime
+	PrebuiltDir = filepath.Join(os.TempDir(), "micro", "prebuilt")
+)
+
+func (m *manager) prebuiltAndRun(srv *service) {
+	if err := m.prebuilt(srv); err != nil {
+		logger.Warnf("Error fetching prebuilt binary %v:%v: %v", srv.Service.Name, srv.Service.Version, err)
+		srv.Status = runtime.Error
+		srv.Error = fmt.Sprintf("Error fetching prebuilt binary: %v", err)
+		m.writeService(srv)
+		return
+	}
+
+	srv.Status = runtime.Starting
+	m.writeService(srv)
+
+	if err := m.createServiceInRuntime(srv); err != nil {
+		srv.Status = runtime.Error
+		srv.Error = fmt.Sprintf("Error creating service: %v", err)
+		m.writeService(srv)
+	}
+}
+
+// prebuilt fetches the prebuilt binary of the service, replacing the build. The kubernetes runtime
+// pulls the build from the blob store whereas the local runtime executes the binary directly.
+func (m *manager) prebuilt(srv *service) error {
+	logger.Infof("Fetching prebuilt binary %v for %v:%v", srv.Options.Prebuilt, srv.Service.Name, srv.Service.Version)
+
+	// the artifacts which are images are run with the image rather than a binary
+	if artifact.IsRef(srv.Options.Prebuilt) {
+		a, err := m.readArtifact(srv)
+		if err != nil {
+			return err
+		}
+		if !a.Binary() {
+			if m.Runtime.String() == "local" {
+				return fmt.Errorf("artifact %v is an image which the local runtime can't run", srv.Options.Prebuilt)
+			}
+			srv.Options.Image = a.Image
+			return nil
+		}
+	}
+
+	bin, err := m.readPrebuilt(srv)
+	if err != nil {
+		return err
+	}
+	defer bin.Close()
+
+	if m.Runtime.String() != "local" {
+		nsOpt := store.BlobNamespace(srv.Options.Namespace)
+		key := fmt.Sprintf("build://%v:%v", srv.Service.Name, srv.Service.Version)
+		return store.DefaultBlobStore.Write(key, bin, nsOpt)
+	}
+
+	dir := filepath.Join(PrebuiltDir, srv.Options.Namespace, fmt.Sprintf("%v-%v", srv.Service.Name, srv.Service.Version))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, srv.Service.Name)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, bin); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// run the binary in place of the source
+	srv.Service.Source = dir
+	srv.Options.Entrypoint = ""
+	srv.Options.Command = []string{path}
+	srv.Options.Args = nil
+	return nil
+}
+
+// readArtifact returns the artifact the service is run from, recording its version and digest in
+// the metadata of the service
+func (m *manager) readArtifact(srv *service) (*artifact.Artifact, error) {
+	name, version, err := artifact.ParseRef(srv.Options.Prebuilt)
+	if err != nil {
+		return nil, err
+	}
+	a, err := artifact.Get(srv.Options.Namespace, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if srv.Service.Metadata == nil {
+		srv.Service.Metadata = make(map[string]string)
+	}
+	srv.Service.Metadata["artifact"] = name + "@" + version
+	if a.Binary() {
+		srv.Service.Metadata["digest"] = a.Digest
+	}
+	return a, nil
+}
+
+// readPrebuilt returns the prebuilt binary, which is either a http(s) url, an artifact or a key in
+// the blob store
+func (m *manager) readPrebuilt(srv *service) (io.ReadCloser, error) {
+	url := srv.Options.Prebuilt
+	if artifact.IsRef(url) {
+		a, err := m.readArtifact(srv)
+		if err != nil {
+			return nil, err
+		}
+		bin, err := artifact.Read(a)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bin), nil
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		nsOpt := store.BlobNamespace(srv.Options.Namespace)
+		bin, err := store.DefaultBlobStore.Read(url, nsOpt)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bin), nil
+	}
+
+	rsp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.StatusCode != http.StatusOK {
+		rsp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %v", rsp.Status)
+	}
+	return rsp.Body, nil
+}
@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/micro/micro/v3/service/config"
+	configCli "github.com/micro/micro/v3/service/config/client"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/runtime"
+)
+
+var (
+	// Config returns the config which the references of services in the namespace are resolved from
+	Config = func(namespace string) config.Config {
+		return configCli.NewConfig(namespace)
+	}
+
+	// RotationInterval is how often the secrets of services which restart on rotation are checked
+	RotationInterval = time.Minute
+)
+
+// resolveRefs resolves the config and secret references of the service, returning the env vars and
+// the secrets to start it with
+func resolveRefs(srv *service) ([]string, map[string]string, error) {
+	if len(srv.Options.ConfigRefs) == 0 && len(srv.Options.SecretRefs) == 0 {
+		return nil, nil, nil
+	}
+	conf := Config(srv.Options.Namespace)
+
+	env := make([]string, 0, len(srv.Options.ConfigRefs))
+	for key, path := range srv.Options.ConfigRefs {
+		val, err := resolveRef(conf, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		env = append(env, key+"="+val)
+	}
+	sort.Strings(env)
+
+	secrets := make(map[string]string, len(srv.Options.SecretRefs))
+	for key, path := range srv.Options.SecretRefs {
+		val, err := resolveRef(conf, path, config.Secret(true))
+		if err != nil {
+			return nil, nil, err
+		}
+		secrets[key] = val
+	}
+
+	return env, secrets, nil
+}
+
+// resolveRef returns the value at the config path
+func resolveRef(conf config.Config, path string, opts ...config.Option) (string, error) {
+	v, err := conf.Get(path, opts...)
+	if err != nil {
+		return "", fmt.Errorf("Error reading config %v: %v", path, err)
+	}
+
+	var val interface{}
+	if err := v.Scan(&val); err != nil || val == nil {
+		return "", fmt.Errorf("Config %v not found", path)
+	}
+	if s, ok := val.(string); ok {
+		return s, nil
+	}
+	return string(v.Bytes()), nil
+}
+
+// secretsChecksum returns a checksum of the secrets used to determine if they've been rotated
+func secretsChecksum(secrets map[string]string) string {
+	keys := make([]string, 0, len(secrets))
+	for k := range secrets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%v=%v;", k, secrets[k])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// rotate periodically restarts the services whose secrets have changed until exit is closed
+func (m *manager) rotate(exit chan bool) {
+	ticker := time.NewTicker(RotationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			m.rotateServices()
+		}
+	}
+}
+
+func (m *manager) rotateServices() {
+	nss, err := m.listNamespaces()
+	if err != nil {
+		logger.Warnf("Error listing namespaces: %v", err)
+		return
+	}
+
+	for _, ns := range nss {
+		srvs, err := m.readServices(ns, &runtime.Service{})
+		if err != nil {
+			logger.Warnf("Error reading services from the %v namespace: %v", ns, err)
+			continue
+		}
+
+		for _, srv := range srvs {
+			if !srv.Options.Rotate || len(srv.Options.SecretRefs) == 0 {
+				continue
+			}
+			if srv.Status == runtime.Error || srv.Status == runtime.Stopped || srv.Status == runtime.Building {
+				continue
+			}
+
+			_, secrets, err := resolveRefs(srv)
+			if err != nil {
+				logger.Warnf("Error resolving the secrets of %v:%v: %v", srv.Service.Name, srv.Service.Version, err)
+				continue
+			}
+			checksum := secretsChecksum(secrets)
+			if srv.SecretsChecksum == checksum {
+				continue
+			}
+
+			// the service was started before the checksum was recorded
+			if len(srv.SecretsChecksum) == 0 {
+				srv.SecretsChecksum = checksum
+				m.writeService(srv)
+				continue
+			}
+
+			// the secrets can't be updated in place so the service is recreated
+			logger.Infof("Restarting %v:%v, its secrets have been rotated", srv.Service.Name, srv.Service.Version)
+			err = m.Runtime.Delete(srv.Service, runtime.DeleteNamespace(ns))
+			if err != nil && err != runtime.ErrNotFound {
+				logger.Errorf("Error stopping %v:%v: %v", srv.Service.Name, srv.Service.Version, err)
+				continue
+			}
+			if err := m.createServiceInRuntime(srv); err != nil {
+				srv.Status = runtime.Error
+				srv.Error = fmt.Sprintf("Error restarting service: %v", err)
+			}
+			srv.UpdatedAt = time.Now()
+			m.writeService(srv)
+		}
+	}
+}
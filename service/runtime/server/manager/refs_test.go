@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/micro/micro/v3/service/config"
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig returns the values it was created with, secrets are prefixed with "secret:"
+type testConfig map[string]interface{}
+
+func (t testConfig) Get(path string, options ...config.Option) (config.Value, error) {
+	var opts config.Options
+	for _, o := range options {
+		o(&opts)
+	}
+	if opts.Secret {
+		path = "secret:" + path
+	}
+	b, _ := json.Marshal(t[path])
+	return config.NewJSONValue(b), nil
+}
+
+func (t testConfig) Set(path string, val interface{}, options ...config.Option) error {
+	t[path] = val
+	return nil
+}
+
+func (t testConfig) Delete(path string, options ...config.Option) error {
+	delete(t, path)
+	return nil
+}
+
+func TestResolveRefs(t *testing.T) {
+	conf := testConfig{"db.address": "localhost:5432", "db.pool": 10, "secret:db.password": "foo"}
+	defaultConfig := Config
+	defer func() { Config = defaultConfig }()
+	Config = func(namespace string) config.Config { return conf }
+
+	srv := &service{
+		Service: &runtime.Service{Name: "test"},
+		Options: &runtime.CreateOptions{
+			ConfigRefs: map[string]string{"DB_ADDRESS": "db.address", "DB_POOL": "db.pool"},
+			SecretRefs: map[string]string{"DB_PASSWORD": "db.password"},
+		},
+	}
+
+	env, secrets, err := resolveRefs(srv)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"DB_ADDRESS=localhost:5432", "DB_POOL=10"}, env)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": "foo"}, secrets)
+
+	// rotating the secret changes the checksum
+	checksum := secretsChecksum(secrets)
+	conf.Set("secret:db.password", "bar")
+	_, secrets, _ = resolveRefs(srv)
+	assert.NotEqual(t, checksum, secretsChecksum(secrets))
+
+	// missing references are an error
+	srv.Options.ConfigRefs["MISSING"] = "missing"
+	_, _, err = resolveRefs(srv)
+	assert.Error(t, err)
+}
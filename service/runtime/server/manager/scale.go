@@ -0,0 +1,201 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/debug"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/micro/micro/v3/service/runtime"
+)
+
+var (
+	// Metrics returns the total value of a metric across the instances of a service. It's used to
+	// autoscale services, by default the metrics are read from the debug stats of the instances.
+	Metrics = statsMetrics
+
+	// AutoscaleInterval is how often services with a scale policy are evaluated
+	AutoscaleInterval = time.Minute
+)
+
+// scaleMetric is a metric services can be autoscaled on
+type scaleMetric struct {
+	value func(*pb.StatsResponse) float64
+	// rate is true for counters, which are scaled on by their rate per second
+	rate bool
+}
+
+// scaleMetrics are the metrics read from the debug stats of the instances of a service
+var scaleMetrics = map[string]scaleMetric{
+	// memory allocated in MiB
+	"memory":   {value: func(s *pb.StatsResponse) float64 { return float64(s.Memory) / (1 << 20) }},
+	"threads":  {value: func(s *pb.StatsResponse) float64 { return float64(s.Threads) }},
+	"requests": {value: func(s *pb.StatsResponse) float64 { return float64(s.Requests) }, rate: true},
+	"errors":   {value: func(s *pb.StatsResponse) float64 { return float64(s.Errors) }, rate: true},
+}
+
+// sample is the value of a counter of an instance when it was last read
+type sample struct {
+	value float64
+	time  time.Time
+}
+
+var (
+	samplesMtx sync.Mutex
+	// samples of the counters, keyed by node id and metric
+	samples = make(map[string]sample)
+)
+
+// statsMetrics returns the total value of the metric across the instances of the service, read
+// from their debug stats. The counters are the rate per second since they were last read, or
+// since the instance started when they haven't been yet.
+func statsMetrics(srv *runtime.Service, namespace, metric string) (float64, error) {
+	m, ok := scaleMetrics[metric]
+	if !ok {
+		return 0, fmt.Errorf("unknown metric %v", metric)
+	}
+
+	versions, err := registry.DefaultRegistry.GetService(srv.Name, registry.GetDomain(namespace))
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, version := range versions {
+		if len(srv.Version) > 0 && version.Version != srv.Version {
+			continue
+		}
+		for _, node := range version.Nodes {
+			req := client.NewRequest(srv.Name, "Debug.Stats", &pb.StatsRequest{})
+			rsp := new(pb.StatsResponse)
+			if err := client.DefaultClient.Call(context.Background(), req, rsp, client.WithAddress(node.Address)); err != nil {
+				return 0, err
+			}
+
+			value := m.value(rsp)
+			if !m.rate {
+				total += value
+				continue
+			}
+
+			now := time.Now()
+			key := node.Id + "/" + metric
+			samplesMtx.Lock()
+			prev, ok := samples[key]
+			samples[key] = sample{value: value, time: now}
+			samplesMtx.Unlock()
+
+			if elapsed := now.Sub(prev.time).Seconds(); ok && value >= prev.value && elapsed > 0 {
+				total += (value - prev.value) / elapsed
+			} else if rsp.Uptime > 0 {
+				total += value / float64(rsp.Uptime)
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// validateScalePolicy returns an error if the service can't be autoscaled on the metric
+func validateScalePolicy(p *runtime.ScalePolicy) error {
+	if _, ok := scaleMetrics[p.Metric]; !ok {
+		return errors.BadRequest("runtime.Update", "Unknown metric %v to autoscale on, expected memory, threads, requests or errors", p.Metric)
+	}
+	if p.Threshold <= 0 {
+		return errors.BadRequest("runtime.Update", "A threshold is required to autoscale")
+	}
+	return nil
+}
+
+// scaleService sets the number of instances and the scale policy of the service
+func (m *manager) scaleService(srv *service, options runtime.UpdateOptions) error {
+	if options.Autoscale != nil {
+		if err := validateScalePolicy(options.Autoscale); err != nil {
+			return err
+		}
+		srv.Autoscale = options.Autoscale
+	}
+
+	if options.Replicas > 0 {
+		// the source isn't passed so the runtime only scales the service
+		err := m.Runtime.Update(
+			&runtime.Service{Name: srv.Service.Name, Version: srv.Service.Version},
+			runtime.UpdateNamespace(srv.Options.Namespace),
+			runtime.UpdateReplicas(options.Replicas),
+		)
+		if err != nil {
+			return err
+		}
+		srv.Options.Replicas = options.Replicas
+	}
+
+	srv.UpdatedAt = time.Now()
+	return m.writeService(srv)
+}
+
+// autoscale periodically evaluates the scale policies of services until exit is closed
+func (m *manager) autoscale(exit chan bool) {
+	ticker := time.NewTicker(AutoscaleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			if Metrics != nil {
+				m.autoscaleServices()
+			}
+		}
+	}
+}
+
+func (m *manager) autoscaleServices() {
+	nss, err := m.listNamespaces()
+	if err != nil {
+		logger.Warnf("Error listing namespaces: %v", err)
+		return
+	}
+
+	for _, ns := range nss {
+		srvs, err := m.readServices(ns, &runtime.Service{})
+		if err != nil {
+			logger.Warnf("Error reading services from the %v namespace: %v", ns, err)
+			continue
+		}
+
+		for _, srv := range srvs {
+			if srv.Autoscale == nil {
+				continue
+			}
+			if srv.Status == runtime.Error || srv.Status == runtime.Stopped || srv.Status == runtime.Building {
+				continue
+			}
+
+			value, err := Metrics(srv.Service, ns, srv.Autoscale.Metric)
+			if err != nil {
+				logger.Warnf("Error getting %v for %v:%v: %v", srv.Autoscale.Metric, srv.Service.Name, srv.Service.Version, err)
+				continue
+			}
+
+			current := srv.Options.Replicas
+			if current < 1 {
+				current = 1
+			}
+			desired := srv.Autoscale.Replicas(current, value)
+			if desired == current {
+				continue
+			}
+
+			logger.Infof("Scaling %v:%v from %d to %d instances", srv.Service.Name, srv.Service.Version, current, desired)
+			if err := m.scaleService(srv, runtime.UpdateOptions{Replicas: desired}); err != nil {
+				logger.Errorf("Error scaling %v:%v: %v", srv.Service.Name, srv.Service.Version, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/service/runtime"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRuntime records the number of instances services are scaled to
+type testRuntime struct {
+	runtime.Runtime
+	replicas map[string]int
+}
+
+func (t *testRuntime) Update(res runtime.Resource, opts ...runtime.UpdateOption) error {
+	var options runtime.UpdateOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	t.replicas[res.(*runtime.Service).Name] = options.Replicas
+	return nil
+}
+
+func TestAutoscale(t *testing.T) {
+	defaultStore, defaultMetrics := store.DefaultStore, Metrics
+	defer func() { store.DefaultStore, Metrics = defaultStore, defaultMetrics }()
+	store.DefaultStore = memory.NewStore()
+
+	rt := &testRuntime{replicas: make(map[string]int)}
+	m := &manager{Runtime: rt}
+
+	srv := &service{
+		Service: &runtime.Service{Name: "helloworld", Version: "latest"},
+		Options: &runtime.CreateOptions{Namespace: "micro", Replicas: 1},
+		Status:  runtime.Running,
+	}
+	if !assert.NoError(t, m.writeService(srv)) {
+		return
+	}
+	err := m.scaleService(srv, runtime.UpdateOptions{Autoscale: &runtime.ScalePolicy{
+		Min: 1, Max: 3, Metric: "requests", Threshold: 10,
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// 15 requests per second across 1 instance is above the threshold
+	Metrics = func(s *runtime.Service, namespace, metric string) (float64, error) {
+		assert.Equal(t, "helloworld", s.Name)
+		assert.Equal(t, "micro", namespace)
+		assert.Equal(t, "requests", metric)
+		return 15, nil
+	}
+	m.autoscaleServices()
+	assert.Equal(t, 2, rt.replicas["helloworld"])
+
+	srvs, err := m.readServices("micro", &runtime.Service{Name: "helloworld"})
+	if assert.NoError(t, err) && assert.Len(t, srvs, 1) {
+		assert.Equal(t, 2, srvs[0].Options.Replicas)
+	}
+
+	// 15 requests per second across 2 instances is within the threshold
+	delete(rt.replicas, "helloworld")
+	m.autoscaleServices()
+	_, scaled := rt.replicas["helloworld"]
+	assert.False(t, scaled)
+
+	// the policy is capped at the max instances
+	Metrics = func(*runtime.Service, string, string) (float64, error) { return 1000, nil }
+	m.autoscaleServices()
+	m.autoscaleServices()
+	assert.Equal(t, 3, rt.replicas["helloworld"])
+}
+
+func TestScalePolicyMetric(t *testing.T) {
+	for _, metric := range []string{"memory", "threads", "requests", "errors"} {
+		assert.NoError(t, validateScalePolicy(&runtime.ScalePolicy{Metric: metric, Threshold: 1}))
+	}
+	assert.Error(t, validateScalePolicy(&runtime.ScalePolicy{Metric: "cpu", Threshold: 80}))
+	assert.Error(t, validateScalePolicy(&runtime.ScalePolicy{Metric: "requests"}))
+
+	_, err := statsMetrics(&runtime.Service{Name: "helloworld"}, "micro", "cpu")
+	assert.Error(t, err)
+}
@@ -19,6 +19,9 @@ type service struct {
 	Status    runtime.ServiceStatus  `json:"status"`
 	UpdatedAt time.Time              `json:"last_updated"`
 	Error     string                 `json:"error"`
+	Autoscale *runtime.ScalePolicy   `json:"autoscale,omitempty"`
+	// SecretsChecksum is the checksum of the secret references the service was started with
+	SecretsChecksum string `json:"secrets_checksum,omitempty"`
 }
 
 const (
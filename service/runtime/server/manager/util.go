@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/micro/micro/v3/internal/residency"
 	"github.com/micro/micro/v3/service/auth"
 	"github.com/micro/micro/v3/service/build"
 	"github.com/micro/micro/v3/service/build/util/tar"
@@ -103,6 +104,8 @@ func (m *manager) build(srv *service) error {
 	build, err := build.DefaultBuilder.Build(source,
 		build.Archive("tar"),
 		build.Entrypoint(srv.Options.Entrypoint),
+		build.Target(srv.Options.OS, srv.Options.Arch),
+		build.Flags(srv.Options.BuildFlags...),
 	)
 	logger.Infof("Build finished %v:%v %v", srv.Service.Name, srv.Service.Version, err)
 	if err != nil {
@@ -150,6 +153,15 @@ func (m *manager) createServiceInRuntime(srv *service) error {
 		return err
 	}
 
+	// resolve the config and secret references
+	refEnv, refSecrets, err := resolveRefs(srv)
+	if err != nil {
+		return err
+	}
+	if srv.Options.Rotate {
+		srv.SecretsChecksum = secretsChecksum(refSecrets)
+	}
+
 	// construct the options
 	options := []gorun.CreateOption{
 		gorun.CreateEntrypoint(srv.Options.Entrypoint),
@@ -158,13 +170,26 @@ func (m *manager) createServiceInRuntime(srv *service) error {
 		gorun.CreateNamespace(srv.Options.Namespace),
 		gorun.WithArgs(srv.Options.Args...),
 		gorun.WithCommand(srv.Options.Command...),
-		gorun.WithEnv(m.runtimeEnv(srv.Service, srv.Options)),
+		gorun.WithEnv(append(m.runtimeEnv(srv.Service, srv.Options), refEnv...)),
+		gorun.WithSchedule(srv.Options.Schedule),
+		gorun.WithReplicas(srv.Options.Replicas),
+		gorun.WithDependencies(srv.Options.Dependencies...),
+	}
+	if srv.Options.Resources != nil {
+		options = append(options, gorun.ResourceLimits(srv.Options.Resources))
+	}
+	// the services of a namespace pinned to a region run in it
+	if region, ok := residency.Lookup(srv.Options.Namespace); ok {
+		options = append(options, gorun.WithRegion(region))
 	}
 
 	// add the secrets
 	for key, value := range srv.Options.Secrets {
 		options = append(options, gorun.WithSecret(key, value))
 	}
+	for key, value := range refSecrets {
+		options = append(options, gorun.WithSecret(key, value))
+	}
 
 	// inject the credentials into the service if present
 	if len(acc.ID) > 0 && len(acc.Secret) > 0 {
@@ -257,6 +282,15 @@ func (m *manager) runtimeEnv(srv *gorun.Service, options *gorun.CreateOptions) [
 		"MICRO_PROXY": client.DefaultClient.Options().Proxy,
 	}
 
+	// pass the tracing, logging, chaos and stats config of the runtime so the services export
+	// their traces to the same collector, log and ship their logs the same way, inject the same
+	// faults and count latency in the same buckets
+	for _, k := range []string{"MICRO_TRACING_ENDPOINT", "MICRO_TRACING_HEADERS", "MICRO_TRACING_SAMPLE_RATE", "MICRO_TRACING_SAMPLE_ERRORS", "MICRO_LOG_SHIPPING", "MICRO_LOG_LEVEL", "MICRO_LOG_FORMAT", "MICRO_CHAOS", "MICRO_STATS_LATENCY_BUCKETS"} {
+		if v := os.Getenv(k); len(v) > 0 {
+			env[k] = v
+		}
+	}
+
 	// bind to port 8080, this is what the k8s tcp readiness check will use
 	if runtime.DefaultRuntime.String() == "kubernetes" {
 		env["MICRO_SERVICE_ADDRESS"] = ":8080"
@@ -283,10 +317,12 @@ func (m *manager) runtimeEnv(srv *gorun.Service, options *gorun.CreateOptions) [
 func (m *manager) generateAccount(srv *service) (*auth.Account, error) {
 	accName := srv.Service.Name + "-" + srv.Service.Version
 
+	// the name identifies the caller to the network policies
 	opts := []auth.GenerateOption{
 		auth.WithIssuer(srv.Options.Namespace),
 		auth.WithScopes("service"),
 		auth.WithType("service"),
+		auth.WithName(srv.Service.Name),
 	}
 
 	acc, err := auth.Generate(accName, opts...)
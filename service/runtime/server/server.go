@@ -71,8 +71,10 @@ func Run(ctx *cli.Context) error {
 
 	// register the handlers
 	pb.RegisterRuntimeHandler(srv.Server(), &handler.Runtime{Runtime: manager})
+	pb.RegisterMaintenanceHandler(srv.Server(), new(handler.Maintenance))
 	pb.RegisterBuildHandler(srv.Server(), new(Build))
 	pb.RegisterSourceHandler(srv.Server(), new(Source))
+	pb.RegisterArtifactHandler(srv.Server(), new(handler.Artifact))
 
 	// start runtime service
 	if err := srv.Run(); err != nil {
@@ -26,6 +26,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/micro/micro/v3/internal/codec"
 	"github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/cbor"
+	"github.com/micro/micro/v3/internal/codec/msgpack"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/metadata"
@@ -35,6 +37,8 @@ type jsonCodec struct{}
 type bytesCodec struct{}
 type protoCodec struct{}
 type wrapCodec struct{ encoding.Codec }
+type msgpackCodec struct{ msgpack.Marshaler }
+type cborCodec struct{ cbor.Marshaler }
 
 var jsonpbMarshaler = &jsonpb.Marshaler{
 	EnumsAsInts:  false,
@@ -52,6 +56,10 @@ var (
 		"application/grpc+json":    jsonCodec{},
 		"application/grpc+proto":   protoCodec{},
 		"application/grpc+bytes":   bytesCodec{},
+		"application/msgpack":      msgpackCodec{},
+		"application/grpc+msgpack": msgpackCodec{},
+		"application/cbor":         cborCodec{},
+		"application/grpc+cbor":    cborCodec{},
 	}
 )
 
@@ -200,3 +208,11 @@ func (g *grpcCodec) Close() error {
 func (g *grpcCodec) String() string {
 	return "grpc"
 }
+
+func (msgpackCodec) Name() string {
+	return "msgpack"
+}
+
+func (cborCodec) Name() string {
+	return "cbor"
+}
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"strings"
+
+	"github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/compress"
+	"github.com/micro/micro/v3/service/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+)
+
+// recvCompressed receives a request compressed with the named compression and decodes it into v
+func recvCompressed(stream grpc.ServerStream, cc encoding.Codec, name string, v interface{}) error {
+	frame := &bytes.Frame{}
+	if err := stream.RecvMsg(frame); err != nil {
+		return err
+	}
+	b, err := compress.Decompress(name, frame.Data)
+	if err == compress.ErrUnsupported {
+		return errors.BadRequest("go.micro.server", "Unsupported compression: %s", name)
+	} else if err != nil {
+		return errors.BadRequest("go.micro.server", "Error decompressing request: %v", err)
+	}
+	return cc.Unmarshal(b, v)
+}
+
+// sendCompressed sends the reply, compressed with the first compression the client accepts if
+// it's above the threshold. The compression supported is sent in the header so the client can
+// compress the requests it makes after.
+func (g *grpcServer) sendCompressed(stream grpc.ServerStream, cc encoding.Codec, accept string, v interface{}) error {
+	if len(g.opts.Compression) == 0 {
+		return stream.SendMsg(v)
+	}
+
+	md := metadata.Pairs(strings.ToLower(compress.AcceptHeader), strings.Join(g.opts.Compression, ","))
+
+	name := compress.Negotiate(accept, g.opts.Compression)
+	if len(name) == 0 {
+		if err := stream.SetHeader(md); err != nil {
+			return err
+		}
+		return stream.SendMsg(v)
+	}
+
+	b, err := cc.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if len(b) > g.opts.CompressionThreshold {
+		if b, err = compress.Compress(name, b); err != nil {
+			return err
+		}
+		md.Set(strings.ToLower(compress.EncodingHeader), name)
+	}
+
+	if err := stream.SetHeader(md); err != nil {
+		return err
+	}
+	return stream.SendMsg(&bytes.Frame{Data: b})
+}
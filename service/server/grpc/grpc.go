@@ -36,6 +36,7 @@ import (
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/micro/micro/v3/internal/addr"
 	"github.com/micro/micro/v3/internal/backoff"
+	"github.com/micro/micro/v3/internal/codec/compress"
 	mgrpc "github.com/micro/micro/v3/internal/grpc"
 	mnet "github.com/micro/micro/v3/internal/net"
 	pberr "github.com/micro/micro/v3/proto/errors"
@@ -53,6 +54,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
@@ -72,6 +75,7 @@ type grpcServer struct {
 	rpc        *rServer
 	srv        *grpc.Server
 	grpcWebSrv *grpcweb.WrappedGrpcServer
+	health     *health.Server
 
 	exit chan chan error
 	wg   *sync.WaitGroup
@@ -93,6 +97,8 @@ func init() {
 	encoding.RegisterCodec(wrapCodec{jsonCodec{}})
 	encoding.RegisterCodec(wrapCodec{protoCodec{}})
 	encoding.RegisterCodec(wrapCodec{bytesCodec{}})
+	encoding.RegisterCodec(wrapCodec{msgpackCodec{}})
+	encoding.RegisterCodec(wrapCodec{cborCodec{}})
 }
 
 func newGRPCServer(opts ...server.Option) server.Server {
@@ -142,7 +148,12 @@ func (g *grpcServer) configure(opts ...server.Option) {
 		o(&g.opts)
 	}
 
-	g.wg = wait(g.opts.Context)
+	if wg := wait(g.opts.Context); wg != nil {
+		g.wg = wg
+	} else if g.wg == nil && g.opts.GracefulTimeout > 0 {
+		// track the in-flight requests so they can be drained when stopping
+		g.wg = new(sync.WaitGroup)
+	}
 
 	maxMsgSize := g.getMaxMsgSize()
 
@@ -162,6 +173,14 @@ func (g *grpcServer) configure(opts ...server.Option) {
 
 	g.rsvc = nil
 	g.srv = grpc.NewServer(gopts...)
+
+	// serve the standard health and reflection services used by load balancers and tools
+	if g.health == nil {
+		g.health = health.NewServer()
+	}
+	healthpb.RegisterHealthServer(g.srv, g.health)
+	registerReflection(g.srv, g.rpc)
+
 	g.grpcWebSrv = grpcweb.WrapServer(
 		g.srv,
 		grpcweb.WithCorsForRegisteredEndpointsOnly(false),
@@ -355,16 +374,23 @@ func (g *grpcServer) handler(srv interface{}, stream grpc.ServerStream) (err err
 		return status.New(codes.Unimplemented, fmt.Sprintf("unknown service %s.%s", serviceName, methodName)).Err()
 	}
 
+	// the compression of the request and the compression the client accepts,
+	// removed so they aren't passed on to the calls made by the handler
+	encoding := md[strings.ToLower(compress.EncodingHeader)]
+	accept := md[strings.ToLower(compress.AcceptHeader)]
+	md.Delete(strings.ToLower(compress.EncodingHeader))
+	md.Delete(strings.ToLower(compress.AcceptHeader))
+
 	// process unary
 	if !mtype.stream {
-		return g.processRequest(stream, service, mtype, ct, ctx)
+		return g.processRequest(stream, service, mtype, ct, encoding, accept, ctx)
 	}
 
 	// process stream
 	return g.processStream(stream, service, mtype, ct, ctx)
 }
 
-func (g *grpcServer) processRequest(stream grpc.ServerStream, service *service, mtype *methodType, ct string, ctx context.Context) error {
+func (g *grpcServer) processRequest(stream grpc.ServerStream, service *service, mtype *methodType, ct, encoding, accept string, ctx context.Context) error {
 	for {
 		var argv, replyv reflect.Value
 
@@ -377,8 +403,17 @@ func (g *grpcServer) processRequest(stream grpc.ServerStream, service *service,
 			argIsValue = true
 		}
 
+		cc, err := g.newGRPCCodec(ct)
+		if err != nil {
+			return errors.InternalServerError(g.opts.Name, err.Error())
+		}
+
 		// Unmarshal request
-		if err := stream.RecvMsg(argv.Interface()); err != nil {
+		if len(encoding) > 0 {
+			if err := recvCompressed(stream, cc, encoding, argv.Interface()); err != nil {
+				return err
+			}
+		} else if err := stream.RecvMsg(argv.Interface()); err != nil {
 			return err
 		}
 
@@ -392,10 +427,6 @@ func (g *grpcServer) processRequest(stream grpc.ServerStream, service *service,
 		function := mtype.method.Func
 		var returnValues []reflect.Value
 
-		cc, err := g.newGRPCCodec(ct)
-		if err != nil {
-			return errors.InternalServerError(g.opts.Name, err.Error())
-		}
 		b, err := cc.Marshal(argv.Interface())
 		if err != nil {
 			return err
@@ -430,7 +461,8 @@ func (g *grpcServer) processRequest(stream grpc.ServerStream, service *service,
 		statusCode := codes.OK
 		statusDesc := ""
 
-		// execute the handler
+		// execute the handler, the headers it sets are sent with the response
+		ctx, rh := server.NewResponseContext(ctx)
 		if appErr := fn(ctx, r, replyv.Interface()); appErr != nil {
 			var errStatus *status.Status
 			switch verr := appErr.(type) {
@@ -468,7 +500,13 @@ func (g *grpcServer) processRequest(stream grpc.ServerStream, service *service,
 			return errStatus.Err()
 		}
 
-		if err := stream.SendMsg(replyv.Interface()); err != nil {
+		if hdr := rh.Header(); len(hdr) > 0 {
+			if err := stream.SetHeader(metadata.New(hdr)); err != nil {
+				return err
+			}
+		}
+
+		if err := g.sendCompressed(stream, cc, accept, replyv.Interface()); err != nil {
 			return err
 		}
 
@@ -574,6 +612,28 @@ func (g *grpcServer) Options() server.Options {
 	return opts
 }
 
+// drain stops accepting connections and waits for the in-flight requests and messages to complete,
+// the requests still in-flight after the timeout are dropped
+func (g *grpcServer) drain(timeout time.Duration) {
+	done := make(chan bool)
+	go func() {
+		g.srv.GracefulStop()
+		if g.wg != nil {
+			g.wg.Wait()
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		if logger.V(logger.WarnLevel, logger.DefaultLogger) {
+			logger.Warnf("Server didn't drain within %v, dropping the in-flight requests", timeout)
+		}
+		g.srv.Stop()
+	}
+}
+
 func (g *grpcServer) Init(opts ...server.Option) error {
 	g.configure(opts...)
 	return nil
@@ -712,6 +772,9 @@ func (g *grpcServer) Register() error {
 	node.Metadata["server"] = g.String()
 	node.Metadata["transport"] = g.String()
 	node.Metadata["protocol"] = "grpc"
+	if len(config.Compression) > 0 {
+		node.Metadata["compression"] = strings.Join(config.Compression, ",")
+	}
 
 	g.RLock()
 	// Maps are ordered randomly, sort the keys for consistency
@@ -908,15 +971,22 @@ func (g *grpcServer) Start() error {
 	} else {
 		var err error
 
+		// listen with the inherited socket or SO_REUSEPORT if set
+		ts, err = mnet.ListenTCP(config.Address)
+		if err != nil {
+			return err
+		}
+
 		// check the tls config for secure connect
 		if tc := config.TLSConfig; tc != nil {
-			ts, err = tls.Listen("tcp", config.Address, tc)
-			// otherwise just plain tcp listener
-		} else {
-			ts, err = net.Listen("tcp", config.Address)
+			ts = tls.NewListener(ts, tc)
 		}
-		if err != nil {
-			return err
+	}
+
+	// limit the connections before they're accepted
+	if g.opts.Context != nil {
+		if c, ok := g.opts.Context.Value(maxConnKey{}).(int); ok && c > 0 {
+			ts = netutil.LimitListener(ts, c)
 		}
 	}
 
@@ -939,12 +1009,6 @@ func (g *grpcServer) Start() error {
 	go grpcWebHttpSrv.Serve(grpcl)
 	go g.srv.Serve(trpcL)
 
-	if g.opts.Context != nil {
-		if c, ok := g.opts.Context.Value(maxConnKey{}).(int); ok && c > 0 {
-			ts = netutil.LimitListener(ts, c)
-		}
-	}
-
 	if logger.V(logger.InfoLevel, logger.DefaultLogger) {
 		logger.Infof("Server [grpc] Listening on %s", ts.Addr().String())
 	}
@@ -1018,23 +1082,30 @@ func (g *grpcServer) Start() error {
 			}
 		}
 
-		// wait for waitgroup
-		if g.wg != nil {
-			g.wg.Wait()
-		}
+		// stop load balancers sending requests while draining
+		g.health.Shutdown()
+
+		if timeout := g.opts.GracefulTimeout; timeout > 0 {
+			g.drain(timeout)
+		} else {
+			// wait for waitgroup
+			if g.wg != nil {
+				g.wg.Wait()
+			}
 
-		// stop the grpc server
-		exit := make(chan bool)
+			// stop the grpc server
+			exit := make(chan bool)
 
-		go func() {
-			g.srv.GracefulStop()
-			close(exit)
-		}()
+			go func() {
+				g.srv.GracefulStop()
+				close(exit)
+			}()
 
-		select {
-		case <-exit:
-		case <-time.After(time.Second):
-			g.srv.Stop()
+			select {
+			case <-exit:
+			case <-time.After(time.Second):
+				g.srv.Stop()
+			}
 		}
 
 		// close transport
@@ -1056,6 +1127,10 @@ func (g *grpcServer) Start() error {
 	g.started = true
 	g.Unlock()
 
+	// report the server and its service as serving to health checks
+	g.health.Resume()
+	g.health.SetServingStatus(config.Name, healthpb.HealthCheckResponse_SERVING)
+
 	return nil
 }
 
@@ -19,8 +19,14 @@ package grpc_test
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	raw "github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/compress"
 	tgrpc "github.com/micro/micro/v3/internal/network/transport/grpc"
 	pberr "github.com/micro/micro/v3/proto/errors"
 	bmemory "github.com/micro/micro/v3/service/broker/memory"
@@ -34,6 +40,9 @@ import (
 	gsrv "github.com/micro/micro/v3/service/server/grpc"
 	pb "github.com/micro/micro/v3/service/server/grpc/proto"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
 )
 
@@ -81,6 +90,10 @@ func (s *testServer) Call(ctx context.Context, req *pb.Request, rsp *pb.Response
 		panic("handler panic")
 	}
 
+	if req.Name == "Cached" {
+		server.SetResponseHeader(ctx, "Cache-Control", "max-age=60")
+	}
+
 	rsp.Msg = "Hello " + req.Name
 	return nil
 }
@@ -317,3 +330,276 @@ func TestGRPCServerWithPanicHandler(t *testing.T) {
 		t.Fatal("this must return error, as handler should be panic")
 	}
 }
+
+func TestGRPCServerCompression(t *testing.T) {
+	r := rmemory.NewRegistry()
+	b := bmemory.NewBroker()
+	tr := tgrpc.NewTransport()
+	rtr := rtreg.NewRouter(router.Registry(r))
+
+	s := gsrv.NewServer(
+		server.Broker(b),
+		server.Name("foo"),
+		server.Registry(r),
+		server.Transport(tr),
+		server.Compression("gzip"),
+		server.CompressionThreshold(64),
+	)
+
+	c := gcli.NewClient(
+		client.Router(rtr),
+		client.Broker(b),
+		client.Transport(tr),
+		client.Compression("gzip"),
+		client.CompressionThreshold(64),
+	)
+
+	h := &testServer{}
+	pb.RegisterTestHandler(s, h)
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+
+	defer func() {
+		if err := s.Stop(); err != nil {
+			t.Fatalf("failed to stop: %v", err)
+		}
+	}()
+
+	// the compression is advertised in the node metadata
+	services, err := r.GetService("foo")
+	if err != nil || len(services) == 0 {
+		t.Fatalf("failed to get service: %v # %d", err, len(services))
+	}
+	if v := services[0].Nodes[0].Metadata["compression"]; v != "gzip" {
+		t.Fatalf("Expected gzip compression in metadata, got %q", v)
+	}
+
+	cc, err := grpc.Dial(s.Options().Address, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("failed to dial server: %v", err)
+	}
+
+	call := func(name, accept string) (*pb.Response, metadata.MD) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "micro-accept-encoding", accept)
+		frame := &raw.Frame{}
+		var md metadata.MD
+		if err := cc.Invoke(ctx, "/test.Test/Call", &pb.Request{Name: name}, frame, grpc.CallContentSubtype("proto"), grpc.Header(&md)); err != nil {
+			t.Fatalf("error calling server: %v", err)
+		}
+		data := frame.Data
+		if enc := md.Get("micro-content-encoding"); len(enc) > 0 {
+			if data, err = compress.Decompress(enc[0], data); err != nil {
+				t.Fatalf("error decompressing response: %v", err)
+			}
+		}
+		rsp := &pb.Response{}
+		if err := proto.Unmarshal(data, rsp); err != nil {
+			t.Fatalf("error decoding response: %v", err)
+		}
+		return rsp, md
+	}
+
+	long := strings.Repeat("John", 64)
+
+	// responses above the threshold are compressed if accepted
+	rsp, md := call(long, "snappy, gzip")
+	if rsp.Msg != "Hello "+long {
+		t.Fatalf("Got unexpected response %v", rsp.Msg)
+	}
+	if enc := md.Get("micro-content-encoding"); len(enc) == 0 || enc[0] != "gzip" {
+		t.Fatalf("Expected gzip response, got %v", enc)
+	}
+	if accept := md.Get("micro-accept-encoding"); len(accept) == 0 || accept[0] != "gzip" {
+		t.Fatalf("Expected the server to accept gzip, got %v", accept)
+	}
+
+	// small responses and those not accepted aren't
+	for _, tc := range [][2]string{{"John", "gzip"}, {long, "snappy"}} {
+		_, md := call(tc[0], tc[1])
+		if enc := md.Get("micro-content-encoding"); len(enc) > 0 {
+			t.Fatalf("Expected uncompressed response, got %v", enc)
+		}
+	}
+
+	// the client compresses its requests once it knows the server accepts it
+	for i := 0; i < 2; i++ {
+		rsp := &pb.Response{}
+		req := c.NewRequest("foo", "Test.Call", &pb.Request{Name: long})
+		if err := c.Call(context.TODO(), req, rsp); err != nil {
+			t.Fatal(err)
+		}
+		if rsp.Msg != "Hello "+long {
+			t.Fatalf("Got unexpected response %v", rsp.Msg)
+		}
+	}
+}
+
+func TestGRPCServerResponseHeader(t *testing.T) {
+	r := rmemory.NewRegistry()
+	b := bmemory.NewBroker()
+	tr := tgrpc.NewTransport()
+	rtr := rtreg.NewRouter(router.Registry(r))
+
+	s := gsrv.NewServer(
+		server.Broker(b),
+		server.Name("foo"),
+		server.Registry(r),
+		server.Transport(tr),
+	)
+
+	c := gcli.NewClient(
+		client.Router(rtr),
+		client.Broker(b),
+		client.Transport(tr),
+	)
+
+	h := &testServer{}
+	pb.RegisterTestHandler(s, h)
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+
+	defer func() {
+		if err := s.Stop(); err != nil {
+			t.Fatalf("failed to stop: %v", err)
+		}
+	}()
+
+	// the headers set by the handler are sent back with the response
+	header := make(map[string]string)
+	rsp := &pb.Response{}
+	req := c.NewRequest("foo", "Test.Call", &pb.Request{Name: "Cached"})
+	if err := c.Call(context.TODO(), req, rsp, client.WithResponseHeader(header)); err != nil {
+		t.Fatal(err)
+	}
+	if v := header["Cache-Control"]; v != "max-age=60" {
+		t.Fatalf("Expected the cache control header to be returned, got %v", header)
+	}
+}
+
+func TestGRPCServerHealthAndReflection(t *testing.T) {
+	s := gsrv.NewServer(
+		server.Broker(bmemory.NewBroker()),
+		server.Name("foo"),
+		server.Registry(rmemory.NewRegistry()),
+	)
+	pb.RegisterTestHandler(s, &testServer{})
+	if err := s.Start(); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	defer s.Stop()
+
+	cc, err := grpc.Dial(s.Options().Address, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("failed to dial server: %v", err)
+	}
+	defer cc.Close()
+
+	// the server and its service report serving
+	for _, name := range []string{"", "foo"} {
+		rsp, err := healthpb.NewHealthClient(cc).Check(context.TODO(), &healthpb.HealthCheckRequest{Service: name})
+		if err != nil {
+			t.Fatalf("error checking health: %v", err)
+		}
+		if rsp.Status != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("Expected %q to be serving, got %v", name, rsp.Status)
+		}
+	}
+
+	// the services are listed by reflection
+	stream, err := rpb.NewServerReflectionClient(cc).ServerReflectionInfo(context.TODO())
+	if err != nil {
+		t.Fatalf("error calling reflection: %v", err)
+	}
+	if err := stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rsp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var services []string
+	for _, svc := range rsp.GetListServicesResponse().GetService() {
+		services = append(services, svc.Name)
+	}
+	if !strings.Contains(strings.Join(services, ","), "grpc.health.v1.Health") {
+		t.Fatalf("Expected the health service to be listed, got %v", services)
+	}
+	if !strings.Contains(strings.Join(services, ","), "Test") {
+		t.Fatalf("Expected the service of the handler to be listed, got %v", services)
+	}
+
+	// the descriptor of the service of the handler is returned
+	if err := stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "Test"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if rsp, err = stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.GetFileDescriptorResponse().GetFileDescriptorProto()) == 0 {
+		t.Fatalf("Expected the file descriptor of the service, got %v", rsp)
+	}
+}
+
+type slowServer struct {
+	testServer
+	started chan bool
+}
+
+func (s *slowServer) Call(ctx context.Context, req *pb.Request, rsp *pb.Response) error {
+	close(s.started)
+	time.Sleep(time.Millisecond * 200)
+	rsp.Msg = "Hello " + req.Name
+	return nil
+}
+
+func TestGRPCServerGracefulTimeout(t *testing.T) {
+	r := rmemory.NewRegistry()
+	s := gsrv.NewServer(
+		server.Broker(bmemory.NewBroker()),
+		server.Name("foo"),
+		server.Registry(r),
+		server.GracefulTimeout(time.Second),
+	)
+	h := &slowServer{started: make(chan bool)}
+	pb.RegisterTestHandler(s, h)
+	if err := s.Start(); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+
+	cc, err := grpc.Dial(s.Options().Address, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("failed to dial server: %v", err)
+	}
+	defer cc.Close()
+
+	// the in-flight request completes while the server stops
+	errs := make(chan error, 1)
+	go func() {
+		rsp := pb.Response{}
+		errs <- cc.Invoke(context.TODO(), "/test.Test/Call", &pb.Request{Name: "John"}, &rsp)
+	}()
+	<-h.started
+
+	if err := s.Stop(); err != nil {
+		t.Fatalf("failed to stop: %v", err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Expected the in-flight request to complete, got %v", err)
+	}
+
+	// the server deregistered and no longer accepts connections
+	if services, err := r.GetService("foo"); err == nil && len(services) > 0 {
+		t.Fatalf("Expected the service to be deregistered, got %v", services)
+	}
+	if _, err := net.DialTimeout("tcp", s.Options().Address, time.Second); err == nil {
+		t.Fatal("Expected the server to stop accepting connections")
+	}
+}
@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// reflectionServices lists the services registered with the grpc server, such as health, along
+// with the proto services of the handlers so tools such as grpcurl can discover them. A handler is
+// listed if its requests are proto messages of a file which defines a service named as the handler,
+// the handlers of other messages e.g. json can't be described.
+type reflectionServices struct {
+	srv *grpc.Server
+	rpc *rServer
+}
+
+func (r *reflectionServices) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := r.srv.GetServiceInfo()
+
+	r.rpc.mu.Lock()
+	defer r.rpc.mu.Unlock()
+
+	for name, svc := range r.rpc.serviceMap {
+		if desc := protoService(name, svc); desc != nil {
+			info[string(desc.FullName())] = grpc.ServiceInfo{}
+		}
+	}
+	return info
+}
+
+// protoService returns the descriptor of the proto service of the handler, or nil if it has none
+func protoService(name string, svc *service) protoreflect.ServiceDescriptor {
+	for _, m := range svc.method {
+		if m.ArgType.Kind() != reflect.Ptr {
+			continue
+		}
+		msg, ok := reflect.New(m.ArgType.Elem()).Interface().(proto.Message)
+		if !ok {
+			continue
+		}
+		file := proto.MessageV2(msg).ProtoReflect().Descriptor().ParentFile()
+		if desc := file.Services().ByName(protoreflect.Name(name)); desc != nil {
+			return desc
+		}
+	}
+	return nil
+}
+
+// registerReflection registers the reflection service, the descriptors of the services listed are
+// resolved from the files registered by the generated proto packages
+func registerReflection(srv *grpc.Server, rpc *rServer) {
+	rpb.RegisterServerReflectionServer(srv, reflection.NewServer(reflection.ServerOptions{
+		Services: &reflectionServices{srv: srv, rpc: rpc},
+	}))
+}
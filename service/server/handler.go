@@ -16,7 +16,12 @@
 
 package server
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/micro/micro/v3/service/registry"
+)
 
 type HandlerOption func(*HandlerOptions)
 
@@ -44,6 +49,14 @@ func EndpointMetadata(name string, md map[string]string) HandlerOption {
 	}
 }
 
+// DeprecatedEndpoint is a Handler option marking the endpoint deprecated in the registry, the
+// sunset is when it will be removed or zero if that isn't decided yet
+func DeprecatedEndpoint(name string, sunset time.Time) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.Metadata[name] = registry.Deprecate(o.Metadata[name], sunset)
+	}
+}
+
 // Internal Handler options specifies that a handler is not advertised
 // to the discovery system. In the future this may also limit request
 // to the internal network or authorised user.
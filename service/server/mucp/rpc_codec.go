@@ -22,9 +22,11 @@ import (
 
 	"github.com/micro/micro/v3/internal/codec"
 	raw "github.com/micro/micro/v3/internal/codec/bytes"
+	"github.com/micro/micro/v3/internal/codec/cbor"
 	"github.com/micro/micro/v3/internal/codec/grpc"
 	"github.com/micro/micro/v3/internal/codec/json"
 	"github.com/micro/micro/v3/internal/codec/jsonrpc"
+	"github.com/micro/micro/v3/internal/codec/msgpack"
 	"github.com/micro/micro/v3/internal/codec/proto"
 	"github.com/micro/micro/v3/internal/codec/protorpc"
 	"github.com/micro/micro/v3/internal/network/transport"
@@ -63,6 +65,8 @@ var (
 		"application/protobuf":     proto.NewCodec,
 		"application/proto-rpc":    protorpc.NewCodec,
 		"application/octet-stream": raw.NewCodec,
+		"application/msgpack":      msgpack.NewCodec,
+		"application/cbor":         cbor.NewCodec,
 	}
 
 	// TODO: remove legacy codec list
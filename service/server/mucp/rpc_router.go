@@ -202,9 +202,10 @@ func prepareMethod(method reflect.Method) *methodType {
 	return &methodType{method: method, ArgType: argType, ReplyType: replyType, ContextType: contextType, stream: stream}
 }
 
-func (router *router) sendResponse(sending sync.Locker, req *request, reply interface{}, cc codec.Writer, last bool) error {
+func (router *router) sendResponse(sending sync.Locker, req *request, reply interface{}, cc codec.Writer, header map[string]string) error {
 	msg := new(codec.Message)
 	msg.Type = codec.Response
+	msg.Header = header
 	resp := router.getResponse()
 	resp.msg = msg
 
@@ -253,13 +254,14 @@ func (s *service) call(ctx context.Context, router *router, sending *sync.Mutex,
 			fn = router.hdlrWrappers[i-1](fn)
 		}
 
-		// execute handler
+		// execute handler, the headers it sets are sent with the response
+		ctx, rh := server.NewResponseContext(ctx)
 		if err := fn(ctx, r, replyv.Interface()); err != nil {
 			return err
 		}
 
 		// send response
-		return router.sendResponse(sending, req, replyv.Interface(), cc, true)
+		return router.sendResponse(sending, req, replyv.Interface(), cc, rh.Header())
 	}
 
 	// declare a local error to see if we errored out already
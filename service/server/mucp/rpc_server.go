@@ -77,13 +77,32 @@ func wait(ctx context.Context) *sync.WaitGroup {
 	return wg
 }
 
+// waitTimeout waits for the wait group until the timeout, it returns false if it timed out
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	if wg == nil {
+		return true
+	}
+	done := make(chan bool)
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
 func newServer(opts ...server.Option) server.Server {
 	options := newOptions(opts...)
 	router := newRpcRouter()
 	router.hdlrWrappers = options.HdlrWrappers
 	router.subWrappers = options.SubWrappers
 
-	return &rpcServer{
+	s := &rpcServer{
 		opts:        options,
 		router:      router,
 		handlers:    make(map[string]server.Handler),
@@ -91,6 +110,11 @@ func newServer(opts ...server.Option) server.Server {
 		exit:        make(chan chan error),
 		wg:          wait(options.Context),
 	}
+	// track the in-flight requests so they can be drained when stopping
+	if s.wg == nil && options.GracefulTimeout > 0 {
+		s.wg = new(sync.WaitGroup)
+	}
+	return s
 }
 
 // HandleEvent handles inbound messages to the service directly
@@ -480,6 +504,9 @@ func (s *rpcServer) Init(opts ...server.Option) error {
 	for _, opt := range opts {
 		opt(&s.opts)
 	}
+	if s.wg == nil && s.opts.GracefulTimeout > 0 {
+		s.wg = new(sync.WaitGroup)
+	}
 	// update router if its the default
 	if s.opts.Router == nil {
 		r := newRpcRouter()
@@ -974,13 +1001,22 @@ func (s *rpcServer) Start() error {
 		swg := s.wg
 		s.Unlock()
 
-		// wait for requests to finish
-		if swg != nil {
-			swg.Wait()
-		}
+		if timeout := s.opts.GracefulTimeout; timeout > 0 {
+			// stop accepting connections then drain the in-flight requests
+			err := ts.Close()
+			if !waitTimeout(swg, timeout) && logger.V(logger.WarnLevel, logger.DefaultLogger) {
+				log.Warnf("Server %s-%s didn't drain within %v, dropping the in-flight requests", config.Name, config.Id, timeout)
+			}
+			ch <- err
+		} else {
+			// wait for requests to finish
+			if swg != nil {
+				swg.Wait()
+			}
 
-		// close transport listener
-		ch <- ts.Close()
+			// close transport listener
+			ch <- ts.Close()
+		}
 
 		if logger.V(logger.InfoLevel, logger.DefaultLogger) {
 			log.Infof("Broker [%s] Disconnected from %s", bname, config.Broker.Address())
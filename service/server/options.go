@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/micro/micro/v3/internal/codec"
+	"github.com/micro/micro/v3/internal/codec/compress"
 	"github.com/micro/micro/v3/internal/debug/trace"
 	"github.com/micro/micro/v3/internal/network/transport"
 	thttp "github.com/micro/micro/v3/internal/network/transport/http"
@@ -63,6 +64,15 @@ type Options struct {
 	// TLSConfig specifies tls.Config for secure serving
 	TLSConfig *tls.Config
 
+	// Compression supported for payloads in order of preference
+	Compression []string
+	// CompressionThreshold is the size in bytes above which payloads are compressed
+	CompressionThreshold int
+
+	// GracefulTimeout is how long to wait for in-flight requests to complete when stopping, the
+	// server stops accepting connections and deregisters first
+	GracefulTimeout time.Duration
+
 	// Other options for implementations of the interface
 	// can be stored in a context
 	Context context.Context
@@ -74,6 +84,8 @@ func newOptions(opt ...Option) Options {
 		Metadata:         map[string]string{},
 		RegisterInterval: DefaultRegisterInterval,
 		RegisterTTL:      DefaultRegisterTTL,
+
+		CompressionThreshold: compress.DefaultThreshold,
 	}
 
 	for _, o := range opt {
@@ -215,6 +227,14 @@ func Metadata(md map[string]string) Option {
 	}
 }
 
+// Deprecated marks the service deprecated in the registry, the sunset is when it will be removed or
+// zero if that isn't decided yet. It's set on the metadata so must come after the Metadata option.
+func Deprecated(sunset time.Time) Option {
+	return func(o *Options) {
+		o.Metadata = registry.Deprecate(o.Metadata, sunset)
+	}
+}
+
 // RegisterCheck run func before registry service
 func RegisterCheck(fn func(context.Context) error) Option {
 	return func(o *Options) {
@@ -256,6 +276,28 @@ func TLSConfig(t *tls.Config) Option {
 	}
 }
 
+// Compression sets the compression supported for payloads in order of preference
+// e.g. gzip. Responses are compressed with the first the client accepts.
+func Compression(names ...string) Option {
+	return func(o *Options) {
+		o.Compression = names
+	}
+}
+
+// CompressionThreshold sets the size in bytes above which payloads are compressed
+func CompressionThreshold(n int) Option {
+	return func(o *Options) {
+		o.CompressionThreshold = n
+	}
+}
+
+// GracefulTimeout sets how long to drain in-flight requests for when stopping
+func GracefulTimeout(t time.Duration) Option {
+	return func(o *Options) {
+		o.GracefulTimeout = t
+	}
+}
+
 // WithRouter sets the request router
 func WithRouter(r Router) Option {
 	return func(o *Options) {
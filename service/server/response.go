@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// DeprecationHeader is the response header set when the endpoint called is deprecated
+	DeprecationHeader = "Deprecation"
+	// SunsetHeader is the response header of the time a deprecated endpoint is removed
+	SunsetHeader = "Sunset"
+)
+
+// responseHeaderKey is the context key of the headers sent with a response
+type responseHeaderKey struct{}
+
+// ResponseHeader holds the headers the handler of a request sets on its response
+type ResponseHeader struct {
+	sync.Mutex
+	header map[string]string
+}
+
+// Header returns a copy of the headers set
+func (r *ResponseHeader) Header() map[string]string {
+	r.Lock()
+	defer r.Unlock()
+
+	hdr := make(map[string]string, len(r.header))
+	for k, v := range r.header {
+		hdr[k] = v
+	}
+	return hdr
+}
+
+// NewResponseContext returns a context the handler can set the headers of its response on. It's
+// used by the server implementations which send the headers back with the response.
+func NewResponseContext(ctx context.Context) (context.Context, *ResponseHeader) {
+	rh := &ResponseHeader{header: make(map[string]string)}
+	return context.WithValue(ctx, responseHeaderKey{}, rh), rh
+}
+
+// SetResponseHeader sets a header sent back to the caller with the response to the request. It
+// returns false if the server doesn't support response headers, e.g. for streams.
+func SetResponseHeader(ctx context.Context, k, v string) bool {
+	rh, ok := ctx.Value(responseHeaderKey{}).(*ResponseHeader)
+	if !ok {
+		return false
+	}
+	rh.Lock()
+	rh.header[k] = v
+	rh.Unlock()
+	return true
+}
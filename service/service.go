@@ -9,6 +9,7 @@ import (
 	"runtime"
 
 	"github.com/micro/micro/v3/cmd"
+	"github.com/micro/micro/v3/internal/daemon"
 	signalutil "github.com/micro/micro/v3/internal/signal"
 	"github.com/micro/micro/v3/service/client"
 	mudebug "github.com/micro/micro/v3/service/debug"
@@ -27,6 +28,8 @@ var (
 // Service is a Micro Service which honours the go-micro/service interface
 type Service struct {
 	opts Options
+	// server of the service if it isn't the default server
+	server server.Server
 }
 
 // Run the default service and waits for it to exist
@@ -65,6 +68,14 @@ func New(opts ...Option) *Service {
 		return nil
 	}
 
+	// services run in a single process only parse the command line once
+	unifiedMtx.Lock()
+	u := unified
+	unifiedMtx.Unlock()
+	if u {
+		return newUnified(opts...)
+	}
+
 	// setup micro, this triggers the Before
 	// function which parses CLI flags.
 	cmd.New(cmd.SetupOnly(), cmd.Before(before)).Run()
@@ -94,6 +105,17 @@ func (s *Service) Subscribe(topic string, v interface{}) error {
 }
 
 func (s *Service) Init(opts ...Option) {
+	if s.server != nil {
+		unifiedMtx.Lock()
+		defer unifiedMtx.Unlock()
+		s.withServer(func() {
+			for _, o := range opts {
+				o(&s.opts)
+			}
+		})
+		return
+	}
+
 	for _, o := range opts {
 		o(&s.opts)
 	}
@@ -108,6 +130,9 @@ func (s *Service) Client() client.Client {
 }
 
 func (s *Service) Server() server.Server {
+	if s.server != nil {
+		return s.server
+	}
 	return server.DefaultServer
 }
 
@@ -144,7 +169,7 @@ func (s *Service) Stop() error {
 		}
 	}
 
-	if err := server.DefaultServer.Stop(); err != nil {
+	if err := s.Server().Stop(); err != nil {
 		return err
 	}
 
@@ -195,15 +220,60 @@ func (s *Service) Run() error {
 	}
 
 	ch := make(chan os.Signal, 1)
+	reload := make(chan os.Signal, 1)
 	if s.opts.Signal {
 		signal.Notify(ch, signalutil.Shutdown()...)
+		signal.Notify(reload, signalutil.Reload()...)
 	}
 
-	// wait on kill signal
-	<-ch
+	// tell systemd or windows we've started if they're running us
+	if err := daemon.Ready(); err != nil {
+		logger.Errorf("Error notifying the service manager: %v", err)
+	}
+
+	// wait on kill signal or the service manager stopping us, reloading the flags on SIGHUP
+wait:
+	for {
+		select {
+		case <-reload:
+			if err := s.reload(); err != nil {
+				logger.Errorf("Error reloading [service] %s: %v", s.Name(), err)
+			}
+		case <-ch:
+			break wait
+		case <-daemon.Stop():
+			break wait
+		}
+	}
+
+	daemon.Stopping()
+	defer daemon.Stopped()
+
 	return s.Stop()
 }
 
+// reload reloads the flags of the service, restarting the server if any of its options changed as
+// they only apply once it's started
+func (s *Service) reload() error {
+	opts, err := cmd.Reload()
+	if err != nil {
+		return err
+	}
+	logger.Infof("Reloaded [service] %s", s.Name())
+	if len(opts) == 0 {
+		return nil
+	}
+
+	logger.Infof("Restarting the server of [service] %s", s.Name())
+	if err := s.Server().Stop(); err != nil {
+		return err
+	}
+	if err := s.Server().Init(opts...); err != nil {
+		return err
+	}
+	return s.Server().Start()
+}
+
 // Handle is syntactic sugar for registering a handler
 func Handle(h interface{}, opts ...server.HandlerOption) error {
 	return server.DefaultServer.Handle(server.DefaultServer.NewHandler(h, opts...))
@@ -7,6 +7,7 @@ import (
 
 	authns "github.com/micro/micro/v3/internal/auth/namespace"
 	"github.com/micro/micro/v3/internal/namespace"
+	"github.com/micro/micro/v3/internal/residency"
 	pb "github.com/micro/micro/v3/proto/store"
 	"github.com/micro/micro/v3/service/errors"
 	"github.com/micro/micro/v3/service/store"
@@ -33,6 +34,9 @@ func (b *blobHandler) Read(ctx context.Context, req *pb.BlobReadRequest, stream
 	} else if err != nil {
 		return errors.InternalServerError("store.Blob.Read", err.Error())
 	}
+	if err := residency.Check(req.Options.Namespace); err != nil {
+		return errors.Forbidden("store.Blob.Read", err.Error())
+	}
 
 	// execute the request
 	blob, err := store.DefaultBlobStore.Read(req.Key, gostore.BlobNamespace(req.Options.Namespace))
@@ -96,6 +100,9 @@ func (b *blobHandler) Write(ctx context.Context, stream pb.BlobStore_WriteStream
 			} else if err != nil {
 				return errors.InternalServerError("store.Blob.Write", err.Error())
 			}
+			if err := residency.Check(options.Namespace); err != nil {
+				return errors.Forbidden("store.Blob.Write", err.Error())
+			}
 		} else {
 			// subsequent message recieved from the stream
 			buf.Write(req.Blob)
@@ -135,6 +142,9 @@ func (b *blobHandler) Delete(ctx context.Context, req *pb.BlobDeleteRequest, rsp
 	} else if err != nil {
 		return errors.InternalServerError("store.Blob.Delete", err.Error())
 	}
+	if err := residency.Check(req.Options.Namespace); err != nil {
+		return errors.Forbidden("store.Blob.Delete", err.Error())
+	}
 
 	// execute the request
 	err := store.DefaultBlobStore.Delete(req.Key, gostore.BlobNamespace(req.Options.Namespace))
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/micro/micro/v3/internal/auth/namespace"
+	"github.com/micro/micro/v3/internal/residency"
 	pb "github.com/micro/micro/v3/proto/store"
 	"github.com/micro/micro/v3/service/errors"
 	"github.com/micro/micro/v3/service/store"
@@ -48,6 +49,9 @@ func (h *handler) List(ctx context.Context, req *pb.ListRequest, stream pb.Store
 	} else if err != nil {
 		return errors.InternalServerError("store.Store.List", err.Error())
 	}
+	if err := residency.Check(req.Options.Database); err != nil {
+		return errors.Forbidden("store.Store.List", err.Error())
+	}
 
 	// setup the store
 	if err := h.setupTable(req.Options.Database, req.Options.Table); err != nil {
@@ -114,6 +118,9 @@ func (h *handler) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadRes
 	} else if err != nil {
 		return errors.InternalServerError("store.Store.Read", err.Error())
 	}
+	if err := residency.Check(req.Options.Database); err != nil {
+		return errors.Forbidden("store.Store.Read", err.Error())
+	}
 
 	// setup the store
 	if err := h.setupTable(req.Options.Database, req.Options.Table); err != nil {
@@ -187,6 +194,9 @@ func (h *handler) Write(ctx context.Context, req *pb.WriteRequest, rsp *pb.Write
 	} else if err != nil {
 		return errors.InternalServerError("store.Store.Write", err.Error())
 	}
+	if err := residency.Check(req.Options.Database); err != nil {
+		return errors.Forbidden("store.Store.Write", err.Error())
+	}
 
 	// setup the store
 	if err := h.setupTable(req.Options.Database, req.Options.Table); err != nil {
@@ -241,6 +251,9 @@ func (h *handler) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Del
 	} else if err != nil {
 		return errors.InternalServerError("store.Store.Delete", err.Error())
 	}
+	if err := residency.Check(req.Options.Database); err != nil {
+		return errors.Forbidden("store.Store.Delete", err.Error())
+	}
 
 	// setup the store
 	if err := h.setupTable(req.Options.Database, req.Options.Table); err != nil {
@@ -306,6 +319,9 @@ func (h *handler) Tables(ctx context.Context, req *pb.TablesRequest, rsp *pb.Tab
 	} else if err != nil {
 		return errors.InternalServerError("store.Store.Tables", err.Error())
 	}
+	if err := residency.Check(req.Database); err != nil {
+		return errors.Forbidden("store.Store.Tables", err.Error())
+	}
 
 	// construct the options
 	opts := []store.ReadOption{
@@ -0,0 +1,116 @@
+// Package client implements the locks using the sync service
+package client
+
+import (
+	"net/http"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/sync"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/sync"
+)
+
+// NewSync returns a sync which calls the sync service
+func NewSync() sync.Sync {
+	return new(srv)
+}
+
+type srv struct {
+	Client pb.SyncService
+}
+
+func (s *srv) Lock(id string, opts ...sync.LockOption) (*sync.Lease, error) {
+	options := sync.NewLockOptions(opts...)
+
+	// the service waits for the lock before responding
+	rsp, err := s.client().Lock(context.DefaultContext, &pb.LockRequest{
+		Id:     id,
+		Ttl:    options.TTL.Milliseconds(),
+		Wait:   options.Wait.Milliseconds(),
+		Holder: options.Holder,
+	}, client.WithAuthToken(), client.WithRequestTimeout(options.Wait+client.DefaultRequestTimeout))
+	if err != nil {
+		return nil, fromError(err)
+	}
+	return deserializeLease(rsp.Lease), nil
+}
+
+func (s *srv) Renew(lease *sync.Lease, ttl time.Duration) error {
+	rsp, err := s.client().Renew(context.DefaultContext, &pb.RenewRequest{
+		Lease: serializeLease(lease),
+		Ttl:   ttl.Milliseconds(),
+	}, client.WithAuthToken())
+	if err != nil {
+		return fromError(err)
+	}
+	lease.Expiry = time.Unix(0, rsp.Lease.Expiry)
+	return nil
+}
+
+func (s *srv) Unlock(lease *sync.Lease) error {
+	_, err := s.client().Unlock(context.DefaultContext, &pb.UnlockRequest{
+		Lease: serializeLease(lease),
+	}, client.WithAuthToken())
+	return fromError(err)
+}
+
+func (s *srv) Holder(id string) (*sync.Lease, error) {
+	rsp, err := s.client().Holder(context.DefaultContext, &pb.HolderRequest{Id: id}, client.WithAuthToken())
+	if err != nil {
+		return nil, fromError(err)
+	}
+	return deserializeLease(rsp.Lease), nil
+}
+
+func (s *srv) String() string {
+	return "service"
+}
+
+// this is a tmp solution since the client isn't initialized when NewSync is called
+func (s *srv) client() pb.SyncService {
+	if s.Client == nil {
+		s.Client = pb.NewSyncService("sync", client.DefaultClient)
+	}
+	return s.Client
+}
+
+// fromError returns the sync errors for the errors of the service
+func fromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch errors.FromError(err).Code {
+	case http.StatusRequestTimeout:
+		return sync.ErrLockTimeout
+	case http.StatusConflict:
+		return sync.ErrNotHeld
+	case http.StatusNotFound:
+		return sync.ErrNotLocked
+	}
+	return err
+}
+
+func serializeLease(l *sync.Lease) *pb.Lease {
+	return &pb.Lease{
+		Lock:   l.Lock,
+		Id:     l.ID,
+		Holder: l.Holder,
+		Token:  l.Token,
+		Expiry: l.Expiry.UnixNano(),
+	}
+}
+
+func deserializeLease(l *pb.Lease) *sync.Lease {
+	if l == nil {
+		return nil
+	}
+	return &sync.Lease{
+		Lock:   l.Lock,
+		ID:     l.Id,
+		Holder: l.Holder,
+		Token:  l.Token,
+		Expiry: time.Unix(0, l.Expiry),
+	}
+}
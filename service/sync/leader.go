@@ -0,0 +1,102 @@
+package sync
+
+import (
+	gosync "sync"
+	"time"
+)
+
+// Leader is elected by holding the lock of the election, it renews the lease of the lock until
+// it resigns
+type Leader struct {
+	sync  Sync
+	lease *Lease
+	ttl   time.Duration
+
+	once gosync.Once
+	exit chan struct{}
+	done chan struct{}
+	lost chan struct{}
+}
+
+// Elect blocks until elected the leader of the id, or until the wait if it's set. The lease is
+// renewed every third of the ttl, the leadership is lost if it can't be renewed before it expires.
+func Elect(id string, opts ...LockOption) (*Leader, error) {
+	return NewLeader(DefaultSync, id, opts...)
+}
+
+// NewLeader elects a leader using the sync
+func NewLeader(s Sync, id string, opts ...LockOption) (*Leader, error) {
+	options := NewLockOptions(opts...)
+
+	// without a wait the candidate waits for as long as it takes, a ttl at a time
+	wait := options.Wait
+	if wait <= 0 {
+		wait = options.TTL
+	}
+
+	for {
+		lease, err := s.Lock(id, append(opts, LockWait(wait))...)
+		if err == ErrLockTimeout && options.Wait <= 0 {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		l := &Leader{
+			sync:  s,
+			lease: lease,
+			ttl:   options.TTL,
+			exit:  make(chan struct{}),
+			done:  make(chan struct{}),
+			lost:  make(chan struct{}),
+		}
+		go l.renew()
+		return l, nil
+	}
+}
+
+func (l *Leader) renew() {
+	defer close(l.done)
+
+	t := time.NewTicker(l.ttl / 3)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-l.exit:
+			return
+		case <-t.C:
+		}
+
+		err := l.sync.Renew(l.lease, l.ttl)
+		if err == nil {
+			continue
+		}
+		// retry until the lease expires unless another candidate holds it already
+		if err == ErrNotHeld || time.Now().After(l.lease.Expiry) {
+			close(l.lost)
+			return
+		}
+	}
+}
+
+// Token returns the fencing token of the leadership
+func (l *Leader) Token() int64 {
+	return l.lease.Token
+}
+
+// Lost is closed when the leadership is lost
+func (l *Leader) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Resign the leadership, releasing the lock so another candidate is elected
+func (l *Leader) Resign() error {
+	l.once.Do(func() { close(l.exit) })
+	<-l.done
+
+	if err := l.sync.Unlock(l.lease); err != nil && err != ErrNotHeld {
+		return err
+	}
+	return nil
+}
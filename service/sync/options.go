@@ -0,0 +1,50 @@
+package sync
+
+import "time"
+
+// LockOptions configure a lock
+type LockOptions struct {
+	// TTL of the lease, DefaultTTL if not set
+	TTL time.Duration
+	// Wait is how long to wait for the lock to be released, the lock fails if it's held when
+	// the wait is not set
+	Wait time.Duration
+	// Holder of the lease e.g. the id of the node
+	Holder string
+}
+
+// LockOption sets an option of a lock
+type LockOption func(o *LockOptions)
+
+// LockTTL sets the ttl of the lease
+func LockTTL(t time.Duration) LockOption {
+	return func(o *LockOptions) {
+		o.TTL = t
+	}
+}
+
+// LockWait sets how long to wait for the lock to be released
+func LockWait(t time.Duration) LockOption {
+	return func(o *LockOptions) {
+		o.Wait = t
+	}
+}
+
+// LockHolder sets the holder of the lease
+func LockHolder(h string) LockOption {
+	return func(o *LockOptions) {
+		o.Holder = h
+	}
+}
+
+// NewLockOptions returns the options of a lock with the defaults set
+func NewLockOptions(opts ...LockOption) LockOptions {
+	options := LockOptions{TTL: DefaultTTL}
+	for _, o := range opts {
+		o(&options)
+	}
+	if options.TTL <= 0 {
+		options.TTL = DefaultTTL
+	}
+	return options
+}
@@ -0,0 +1,189 @@
+// Package server is the sync service which serves the distributed locks and leader election
+package server
+
+import (
+	"context"
+	gosync "sync"
+	"time"
+
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/sync"
+	"github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/sync"
+	syncStore "github.com/micro/micro/v3/service/sync/store"
+	"github.com/urfave/cli/v2"
+)
+
+const name = "sync"
+
+var (
+	// MaxWait is the longest a caller waits for a lock to be released
+	MaxWait = time.Minute
+)
+
+// Run the micro sync service. The locks are serialized by the service so a single instance of
+// it must run.
+func Run(ctx *cli.Context) error {
+	srv := service.New(service.Name(name))
+
+	pb.RegisterSyncHandler(srv.Server(), NewSync())
+
+	if err := srv.Run(); err != nil {
+		log.Fatal(err)
+	}
+	return nil
+}
+
+// NewSync returns the handler of the sync service
+func NewSync() *Sync {
+	return &Sync{syncs: make(map[string]sync.Sync)}
+}
+
+// Sync processes the RPC calls for the locks, the locks of each namespace are stored in the
+// database of the namespace
+type Sync struct {
+	mtx   gosync.RWMutex
+	syncs map[string]sync.Sync
+}
+
+// namespace defaults the namespace to the one of the caller, ensures the caller can access it and
+// returns the sync of the namespace
+func (s *Sync) namespace(ctx context.Context, method string, ns string) (sync.Sync, error) {
+	ns, err := namespace.AuthorizeRequest(ctx, method, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mtx.RLock()
+	sy, ok := s.syncs[ns]
+	s.mtx.RUnlock()
+	if ok {
+		return sy, nil
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if sy, ok = s.syncs[ns]; !ok {
+		sy = syncStore.NewSync(syncStore.WithDatabase(ns))
+		s.syncs[ns] = sy
+	}
+	return sy, nil
+}
+
+// toError returns the errors of the service for the sync errors
+func toError(method string, err error) error {
+	switch err {
+	case sync.ErrLockTimeout:
+		return errors.Timeout(method, err.Error())
+	case sync.ErrNotHeld:
+		return errors.Conflict(method, err.Error())
+	case sync.ErrNotLocked:
+		return errors.NotFound(method, err.Error())
+	}
+	return errors.InternalServerError(method, err.Error())
+}
+
+// Lock acquires a lock, waiting for it to be released up to the wait
+func (s *Sync) Lock(ctx context.Context, req *pb.LockRequest, rsp *pb.LockResponse) error {
+	if len(req.Id) == 0 {
+		return errors.BadRequest("sync.Sync.Lock", "Missing id")
+	}
+	sy, err := s.namespace(ctx, "sync.Sync.Lock", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	wait := time.Duration(req.Wait) * time.Millisecond
+	if wait > MaxWait {
+		wait = MaxWait
+	}
+	// don't hold the lock for a caller which stopped waiting
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		wait = time.Until(deadline)
+	}
+
+	lease, err := sy.Lock(req.Id,
+		sync.LockTTL(time.Duration(req.Ttl)*time.Millisecond),
+		sync.LockWait(wait),
+		sync.LockHolder(req.Holder),
+	)
+	if err != nil {
+		return toError("sync.Sync.Lock", err)
+	}
+	rsp.Lease = serializeLease(lease)
+	return nil
+}
+
+// Renew extends the lease of a lock
+func (s *Sync) Renew(ctx context.Context, req *pb.RenewRequest, rsp *pb.RenewResponse) error {
+	if req.Lease == nil {
+		return errors.BadRequest("sync.Sync.Renew", "Missing lease")
+	}
+	sy, err := s.namespace(ctx, "sync.Sync.Renew", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	lease := deserializeLease(req.Lease)
+	if err := sy.Renew(lease, time.Duration(req.Ttl)*time.Millisecond); err != nil {
+		return toError("sync.Sync.Renew", err)
+	}
+	rsp.Lease = serializeLease(lease)
+	return nil
+}
+
+// Unlock releases a lock
+func (s *Sync) Unlock(ctx context.Context, req *pb.UnlockRequest, rsp *pb.UnlockResponse) error {
+	if req.Lease == nil {
+		return errors.BadRequest("sync.Sync.Unlock", "Missing lease")
+	}
+	sy, err := s.namespace(ctx, "sync.Sync.Unlock", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := sy.Unlock(deserializeLease(req.Lease)); err != nil {
+		return toError("sync.Sync.Unlock", err)
+	}
+	return nil
+}
+
+// Holder returns the lease of a lock
+func (s *Sync) Holder(ctx context.Context, req *pb.HolderRequest, rsp *pb.HolderResponse) error {
+	if len(req.Id) == 0 {
+		return errors.BadRequest("sync.Sync.Holder", "Missing id")
+	}
+	sy, err := s.namespace(ctx, "sync.Sync.Holder", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	lease, err := sy.Holder(req.Id)
+	if err != nil {
+		return toError("sync.Sync.Holder", err)
+	}
+	rsp.Lease = serializeLease(lease)
+	return nil
+}
+
+func serializeLease(l *sync.Lease) *pb.Lease {
+	return &pb.Lease{
+		Lock:   l.Lock,
+		Id:     l.ID,
+		Holder: l.Holder,
+		Token:  l.Token,
+		Expiry: l.Expiry.UnixNano(),
+	}
+}
+
+func deserializeLease(l *pb.Lease) *sync.Lease {
+	return &sync.Lease{
+		Lock:   l.Lock,
+		ID:     l.Id,
+		Holder: l.Holder,
+		Token:  l.Token,
+		Expiry: time.Unix(0, l.Expiry),
+	}
+}
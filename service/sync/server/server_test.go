@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/micro/micro/v3/proto/sync"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+func TestSync(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+
+	h := NewSync()
+	foo := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "svc", Issuer: "foo"})
+	bar := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "svc", Issuer: "bar"})
+
+	lock := new(pb.LockResponse)
+	if err := h.Lock(foo, &pb.LockRequest{Id: "jobs", Ttl: 10000, Holder: "node-1"}, lock); err != nil {
+		t.Fatal(err)
+	}
+
+	// the locks of each namespace are separate
+	if err := h.Lock(bar, &pb.LockRequest{Id: "jobs"}, new(pb.LockResponse)); err != nil {
+		t.Fatalf("Expected the lock of another namespace to be acquired, got %v", err)
+	}
+	err := h.Lock(foo, &pb.LockRequest{Id: "jobs"}, new(pb.LockResponse))
+	if merr := errors.FromError(err); merr.Code != 408 {
+		t.Fatalf("Expected the held lock to time out, got %v", err)
+	}
+	err = h.Lock(bar, &pb.LockRequest{Id: "jobs", Namespace: "foo"}, new(pb.LockResponse))
+	if merr := errors.FromError(err); merr.Code != 403 {
+		t.Fatalf("Expected the lock of another namespace to be forbidden, got %v", err)
+	}
+
+	holder := new(pb.HolderResponse)
+	if err := h.Holder(foo, &pb.HolderRequest{Id: "jobs"}, holder); err != nil || holder.Lease.Holder != "node-1" {
+		t.Fatalf("Expected node-1 to hold the lock, got %+v %v", holder.Lease, err)
+	}
+
+	renew := new(pb.RenewResponse)
+	if err := h.Renew(foo, &pb.RenewRequest{Lease: lock.Lease, Ttl: 20000}, renew); err != nil {
+		t.Fatal(err)
+	}
+	if renew.Lease.Expiry <= lock.Lease.Expiry {
+		t.Fatalf("Expected the lease to be extended, got %v after %v", renew.Lease.Expiry, lock.Lease.Expiry)
+	}
+
+	if err := h.Unlock(foo, &pb.UnlockRequest{Lease: lock.Lease}, new(pb.UnlockResponse)); err != nil {
+		t.Fatal(err)
+	}
+	err = h.Unlock(foo, &pb.UnlockRequest{Lease: lock.Lease}, new(pb.UnlockResponse))
+	if merr := errors.FromError(err); merr.Code != 409 {
+		t.Fatalf("Expected the released lease not to be unlocked again, got %v", err)
+	}
+	err = h.Holder(foo, &pb.HolderRequest{Id: "jobs"}, new(pb.HolderResponse))
+	if merr := errors.FromError(err); merr.Code != 404 {
+		t.Fatalf("Expected the released lock not to be held, got %v", err)
+	}
+}
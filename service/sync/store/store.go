@@ -0,0 +1,225 @@
+// Package store implements the locks on top of the store. The locks are serialized in memory so
+// they must all be served by the same process e.g. the sync service.
+package store
+
+import (
+	"encoding/json"
+	gosync "sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/sync"
+)
+
+// Option sets an option of the sync
+type Option func(o *Options)
+
+// Options of the sync
+type Options struct {
+	// Database and Table the locks are stored in
+	Database string
+	Table    string
+}
+
+// WithDatabase sets the database the locks are stored in
+func WithDatabase(db string) Option {
+	return func(o *Options) {
+		o.Database = db
+	}
+}
+
+// WithTable sets the table the locks are stored in
+func WithTable(t string) Option {
+	return func(o *Options) {
+		o.Table = t
+	}
+}
+
+// NewSync returns a sync storing the locks in the default store
+func NewSync(opts ...Option) sync.Sync {
+	options := Options{
+		Database: "micro",
+		Table:    "sync",
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return &storeSync{
+		options:  options,
+		released: make(chan struct{}),
+	}
+}
+
+type storeSync struct {
+	options Options
+
+	// mtx serializes the changes to the locks
+	mtx gosync.Mutex
+	// released is closed when a lock is released, to wake the callers waiting for one
+	released chan struct{}
+}
+
+// record of a lock, kept once the lock is released so the next lease gets the next token
+type record struct {
+	Lock string `json:"lock"`
+	// ID of the lease, blank once released
+	ID     string    `json:"id"`
+	Holder string    `json:"holder"`
+	Token  int64     `json:"token"`
+	Expiry time.Time `json:"expiry"`
+}
+
+func (r *record) held() bool {
+	return len(r.ID) > 0 && time.Now().Before(r.Expiry)
+}
+
+func (r *record) lease() *sync.Lease {
+	return &sync.Lease{
+		Lock:   r.Lock,
+		ID:     r.ID,
+		Holder: r.Holder,
+		Token:  r.Token,
+		Expiry: r.Expiry,
+	}
+}
+
+func (s *storeSync) read(id string) (*record, error) {
+	recs, err := store.DefaultStore.Read(id, store.ReadFrom(s.options.Database, s.options.Table))
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var r *record
+	if err := json.Unmarshal(recs[0].Value, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *storeSync) write(r *record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return store.DefaultStore.Write(&store.Record{Key: r.Lock, Value: b}, store.WriteTo(s.options.Database, s.options.Table))
+}
+
+// held returns the record of the lease if it's still held
+func (s *storeSync) held(lease *sync.Lease) (*record, error) {
+	r, err := s.read(lease.Lock)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil || r.ID != lease.ID || !r.held() {
+		return nil, sync.ErrNotHeld
+	}
+	return r, nil
+}
+
+func (s *storeSync) Lock(id string, opts ...sync.LockOption) (*sync.Lease, error) {
+	options := sync.NewLockOptions(opts...)
+	deadline := time.Now().Add(options.Wait)
+
+	for {
+		s.mtx.Lock()
+		r, err := s.read(id)
+		if err != nil {
+			s.mtx.Unlock()
+			return nil, err
+		}
+		if r == nil {
+			r = &record{Lock: id}
+		}
+
+		if !r.held() {
+			r.ID = uuid.New().String()
+			r.Holder = options.Holder
+			r.Token++
+			r.Expiry = time.Now().Add(options.TTL)
+			err := s.write(r)
+			s.mtx.Unlock()
+			if err != nil {
+				return nil, err
+			}
+			return r.lease(), nil
+		}
+
+		released, expiry := s.released, r.Expiry
+		s.mtx.Unlock()
+
+		// wait for a lock to be released or the lease to expire
+		now := time.Now()
+		if !now.Before(deadline) {
+			return nil, sync.ErrLockTimeout
+		}
+		wake := deadline
+		if expiry.Before(wake) {
+			wake = expiry
+		}
+		t := time.NewTimer(wake.Sub(now))
+		select {
+		case <-released:
+		case <-t.C:
+		}
+		t.Stop()
+	}
+}
+
+func (s *storeSync) Renew(lease *sync.Lease, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = sync.DefaultTTL
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	r, err := s.held(lease)
+	if err != nil {
+		return err
+	}
+	r.Expiry = time.Now().Add(ttl)
+	if err := s.write(r); err != nil {
+		return err
+	}
+	lease.Expiry = r.Expiry
+	return nil
+}
+
+func (s *storeSync) Unlock(lease *sync.Lease) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	r, err := s.held(lease)
+	if err != nil {
+		return err
+	}
+	r.ID, r.Holder = "", ""
+	if err := s.write(r); err != nil {
+		return err
+	}
+
+	close(s.released)
+	s.released = make(chan struct{})
+	return nil
+}
+
+func (s *storeSync) Holder(id string) (*sync.Lease, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	r, err := s.read(id)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil || !r.held() {
+		return nil, sync.ErrNotLocked
+	}
+	return r.lease(), nil
+}
+
+func (s *storeSync) String() string {
+	return "store"
+}
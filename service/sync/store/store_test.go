@@ -0,0 +1,120 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/micro/micro/v3/service/sync"
+)
+
+func TestLock(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+	s := NewSync()
+
+	first, err := s.Lock("jobs", sync.LockHolder("node-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Lock("jobs"); err != sync.ErrLockTimeout {
+		t.Fatalf("Expected the held lock to time out, got %v", err)
+	}
+	if l, err := s.Holder("jobs"); err != nil || l.Holder != "node-1" {
+		t.Fatalf("Expected node-1 to hold the lock, got %+v %v", l, err)
+	}
+
+	// the lock is acquired once it's released
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		s.Unlock(first)
+	}()
+	second, err := s.Lock("jobs", sync.LockWait(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Token <= first.Token {
+		t.Fatalf("Expected the fencing token to increase, got %v after %v", second.Token, first.Token)
+	}
+
+	// the first lease was released so it can't be used again
+	if err := s.Renew(first, time.Second); err != sync.ErrNotHeld {
+		t.Fatalf("Expected the released lease not to be renewed, got %v", err)
+	}
+	if err := s.Unlock(first); err != sync.ErrNotHeld {
+		t.Fatalf("Expected the released lease not to be unlocked, got %v", err)
+	}
+	if err := s.Unlock(second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Holder("jobs"); err != sync.ErrNotLocked {
+		t.Fatalf("Expected the lock not to be held, got %v", err)
+	}
+}
+
+func TestLockExpiry(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+	s := NewSync()
+
+	first, err := s.Lock("jobs", sync.LockTTL(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Renew(first, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	// the lease which isn't renewed expires
+	if _, err := s.Lock("jobs", sync.LockWait(time.Second)); err != nil {
+		t.Fatalf("Expected the lock to be acquired once the lease expired, got %v", err)
+	}
+	if err := s.Renew(first, time.Second); err != sync.ErrNotHeld {
+		t.Fatalf("Expected the expired lease not to be renewed, got %v", err)
+	}
+}
+
+func TestLeader(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+	s := NewSync()
+
+	leader, err := sync.NewLeader(s, "scheduler", sync.LockTTL(30*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the leader keeps the lock by renewing it
+	time.Sleep(100 * time.Millisecond)
+	if _, err := sync.NewLeader(s, "scheduler", sync.LockWait(10*time.Millisecond)); err != sync.ErrLockTimeout {
+		t.Fatalf("Expected the leader to keep the lock, got %v", err)
+	}
+	select {
+	case <-leader.Lost():
+		t.Fatal("Expected the leadership not to be lost")
+	default:
+	}
+
+	elected := make(chan *sync.Leader)
+	go func() {
+		l, err := sync.NewLeader(s, "scheduler", sync.LockTTL(30*time.Millisecond))
+		if err != nil {
+			t.Error(err)
+		}
+		elected <- l
+	}()
+
+	if err := leader.Resign(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case l := <-elected:
+		if l.Token() <= leader.Token() {
+			t.Fatalf("Expected the fencing token to increase, got %v after %v", l.Token(), leader.Token())
+		}
+		l.Resign()
+	case <-time.After(time.Second):
+		t.Fatal("Expected another candidate to be elected once the leader resigned")
+	}
+}
@@ -0,0 +1,73 @@
+// Package sync is for the distributed locks and leader election of services. The locks are
+// leased, the holder renews the lease until it unlocks it and the lock is released if it
+// doesn't. Each lease of a lock has a fencing token greater than the token of the lease before,
+// so the writes of a holder whose lease expired can be rejected by the resources it writes to.
+package sync
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// DefaultSync is the default sync implementation
+	DefaultSync Sync
+
+	// DefaultTTL is the ttl of the leases if none is set
+	DefaultTTL = 30 * time.Second
+
+	// ErrLockTimeout is returned when the lock wasn't released within the wait
+	ErrLockTimeout = errors.New("lock timeout")
+	// ErrNotHeld is returned when renewing or unlocking a lease which expired or was released
+	ErrNotHeld = errors.New("lease not held")
+	// ErrNotLocked is returned when the lock isn't held
+	ErrNotLocked = errors.New("not locked")
+)
+
+// Sync is an interface for distributed locks
+type Sync interface {
+	// Lock acquires a lock, waiting for it to be released up to the wait
+	Lock(id string, opts ...LockOption) (*Lease, error)
+	// Renew extends the lease of a lock by the ttl
+	Renew(lease *Lease, ttl time.Duration) error
+	// Unlock releases a lock
+	Unlock(lease *Lease) error
+	// Holder returns the lease of a lock
+	Holder(id string) (*Lease, error)
+	// String returns the name of the implementation
+	String() string
+}
+
+// Lease is a lock held until it's released or expires
+type Lease struct {
+	// Lock is the id of the lock
+	Lock string
+	// ID of the lease
+	ID string
+	// Holder of the lease e.g. the id of the node
+	Holder string
+	// Token is the fencing token, greater than the tokens of the leases of the lock before
+	Token int64
+	// Expiry is when the lease expires unless renewed
+	Expiry time.Time
+}
+
+// Lock acquires a lock using the default sync
+func Lock(id string, opts ...LockOption) (*Lease, error) {
+	return DefaultSync.Lock(id, opts...)
+}
+
+// Renew extends the lease of a lock using the default sync
+func Renew(lease *Lease, ttl time.Duration) error {
+	return DefaultSync.Renew(lease, ttl)
+}
+
+// Unlock releases a lock using the default sync
+func Unlock(lease *Lease) error {
+	return DefaultSync.Unlock(lease)
+}
+
+// Holder returns the lease of a lock using the default sync
+func Holder(id string) (*Lease, error) {
+	return DefaultSync.Holder(id)
+}
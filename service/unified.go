@@ -0,0 +1,65 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/service/server"
+	"github.com/micro/micro/v3/service/server/grpc"
+)
+
+var (
+	unifiedMtx sync.Mutex
+	// unified is set when services are run in a single process
+	unified bool
+)
+
+// Unified is used to run multiple services in a single process e.g. micro service --all. The
+// command line is parsed once, before calling it, and each service created after gets a server
+// of its own, configured like the default server, rather than sharing the default server.
+func Unified() {
+	unifiedMtx.Lock()
+	unified = true
+	unifiedMtx.Unlock()
+}
+
+// newUnified creates a service with a server of its own
+func newUnified(opts ...Option) *Service {
+	unifiedMtx.Lock()
+	defer unifiedMtx.Unlock()
+
+	base := server.DefaultServer.Options()
+
+	// the service options configure the default server so it's replaced while they're applied
+	srv := grpc.NewServer(func(o *server.Options) {
+		*o = base
+		o.Id = uuid.New().String()
+		o.Name = server.DefaultName
+		o.Address = server.DefaultAddress
+		o.Advertise = ""
+		o.Metadata = map[string]string{}
+		o.Router = nil
+		o.HdlrWrappers = append([]server.HandlerWrapper(nil), base.HdlrWrappers...)
+		o.SubWrappers = append([]server.SubscriberWrapper(nil), base.SubWrappers...)
+		for k, v := range base.Metadata {
+			o.Metadata[k] = v
+		}
+	})
+
+	s := &Service{server: srv}
+	s.withServer(func() {
+		s.opts = newOptions(opts...)
+	})
+	return s
+}
+
+// withServer calls fn with the server of the service as the default server, since the service
+// options configure the default server. The caller must hold the lock.
+func (s *Service) withServer(fn func()) {
+	prev := server.DefaultServer
+	server.DefaultServer = s.server
+	defer func() {
+		server.DefaultServer = prev
+	}()
+	fn()
+}
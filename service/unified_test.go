@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/profile"
+	"github.com/micro/micro/v3/service/server"
+)
+
+func TestUnified(t *testing.T) {
+	profile.Test.Setup(nil)
+
+	Unified()
+	defer func() {
+		unified = false
+	}()
+
+	def := server.DefaultServer
+
+	s1 := New(Name("foo"))
+	s2 := New(Name("bar"))
+
+	if s1.Server() == s2.Server() || s1.Server() == def {
+		t.Fatal("Expected each service to have a server of its own")
+	}
+	if server.DefaultServer != def {
+		t.Fatal("Expected the default server to be unchanged")
+	}
+
+	// the service options configure the server of the service
+	if name := s1.Server().Options().Name; name != "foo" {
+		t.Fatalf("Expected server name foo, got %s", name)
+	}
+	s2.Init(Version("v2"))
+	if v := s2.Server().Options().Version; v != "v2" {
+		t.Fatalf("Expected server version v2, got %s", v)
+	}
+	if def.Options().Name == "foo" || def.Options().Version == "v2" {
+		t.Fatal("Expected the default server not to be configured")
+	}
+}
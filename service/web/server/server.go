@@ -0,0 +1,47 @@
+// Package server is the web dashboard for browsing the services and routes of the network
+package server
+
+import (
+	"net/http"
+
+	"github.com/micro/micro/v3/service"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// Name of the web service
+	Name = "web"
+	// Address the dashboard is served on
+	Address = ":8082"
+
+	// Flags specific to the web service
+	Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:    "address",
+			Usage:   "Set the address the dashboard is served on",
+			EnvVars: []string{"MICRO_WEB_ADDRESS"},
+		},
+	}
+)
+
+// Run the web dashboard
+func Run(ctx *cli.Context) error {
+	if len(ctx.String("address")) > 0 {
+		Address = ctx.String("address")
+	}
+
+	srv := service.New(service.Name(Name))
+
+	go func() {
+		log.Infof("Serving the dashboard on %v", Address)
+		if err := http.ListenAndServe(Address, NewHandler()); err != nil {
+			log.Fatalf("Error serving the dashboard on %v: %v", Address, err)
+		}
+	}()
+
+	if err := srv.Run(); err != nil {
+		log.Fatal(err)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+
+	inauth "github.com/micro/micro/v3/internal/auth"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context/metadata"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/micro/micro/v3/service/router"
+)
+
+// NewHandler returns the handler serving the dashboard, the services are listed at /, a service
+// and the form to call it at /service/[name] and the route table at /routes
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", indexPage)
+	mux.HandleFunc("/service/", servicePage)
+	mux.HandleFunc("/routes", routesPage)
+	return mux
+}
+
+func render(w http.ResponseWriter, name string, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ExecuteTemplate(w, name, data); err != nil {
+		log.Warnf("Error rendering the %v page: %v", name, err)
+	}
+}
+
+// indexPage lists the registered services
+func indexPage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	type service struct {
+		Name     string
+		Versions []string
+		Nodes    int
+	}
+	data := struct {
+		Services []*service
+		Error    string
+	}{}
+
+	srvs, err := registry.ListServices()
+	if err != nil {
+		data.Error = err.Error()
+	}
+
+	// the registry lists each version of a service separately
+	byName := make(map[string]*service)
+	for _, s := range srvs {
+		srv, ok := byName[s.Name]
+		if !ok {
+			srv = &service{Name: s.Name}
+			byName[s.Name] = srv
+			data.Services = append(data.Services, srv)
+		}
+		if len(s.Version) > 0 {
+			srv.Versions = append(srv.Versions, s.Version)
+		}
+		// the nodes aren't always listed, they're counted on the page of the service
+		srv.Nodes += len(s.Nodes)
+	}
+	sort.Slice(data.Services, func(i, j int) bool { return data.Services[i].Name < data.Services[j].Name })
+
+	render(w, "index", data)
+}
+
+// servicePage shows the versions, nodes and endpoints of a service and calls its endpoints with
+// the request posted. The calls are made with the token posted rather than the token of the web
+// service, so the callers can't call more than their own accounts can.
+func servicePage(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/service/")
+	if len(name) == 0 {
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+
+	data := struct {
+		Name      string
+		Services  []*registry.Service
+		Endpoints []string
+		Endpoint  string
+		Request   string
+		Response  string
+		Error     string
+	}{
+		Name:     name,
+		Endpoint: r.FormValue("endpoint"),
+		Request:  r.FormValue("request"),
+	}
+
+	srvs, err := registry.GetService(name)
+	if err == registry.ErrNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		data.Error = "Service " + name + " not found"
+	} else if err != nil {
+		data.Error = err.Error()
+	}
+	data.Services = srvs
+
+	seen := make(map[string]bool)
+	for _, s := range srvs {
+		for _, e := range s.Endpoints {
+			if !seen[e.Name] {
+				seen[e.Name] = true
+				data.Endpoints = append(data.Endpoints, e.Name)
+			}
+		}
+	}
+	sort.Strings(data.Endpoints)
+
+	if r.Method == http.MethodPost && len(data.Error) == 0 {
+		rsp, err := call(name, data.Endpoint, data.Request, r.FormValue("token"))
+		if err != nil {
+			data.Error = err.Error()
+		}
+		data.Response = rsp
+	}
+	if len(data.Request) == 0 {
+		data.Request = "{}"
+	}
+
+	render(w, "service", data)
+}
+
+// call an endpoint of a service with a json request, as the account of the token if one is set
+func call(service, endpoint, request, token string) (string, error) {
+	if len(endpoint) == 0 {
+		return "", fmt.Errorf("Missing endpoint")
+	}
+	var req json.RawMessage
+	if err := json.Unmarshal([]byte(request), &req); err != nil {
+		return "", fmt.Errorf("Invalid request: %v", err)
+	}
+
+	ctx := context.Background()
+	if len(token) > 0 {
+		ctx = metadata.Set(ctx, "Authorization", inauth.BearerScheme+token)
+	}
+
+	var rsp json.RawMessage
+	creq := client.DefaultClient.NewRequest(service, endpoint, &req, client.WithContentType("application/json"))
+	if err := client.DefaultClient.Call(ctx, creq, &rsp); err != nil {
+		return "", err
+	}
+
+	b, err := json.MarshalIndent(rsp, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// routesPage shows the route table of the router, optionally of a service
+func routesPage(w http.ResponseWriter, r *http.Request) {
+	data := struct {
+		Service string
+		Routes  []router.Route
+		Error   string
+	}{
+		Service: r.URL.Query().Get("service"),
+	}
+
+	var opts []router.ReadOption
+	if len(data.Service) > 0 {
+		opts = append(opts, router.ReadService(data.Service))
+	}
+	routes, err := router.DefaultRouter.Table().Read(opts...)
+	if err != nil && err != router.ErrRouteNotFound {
+		data.Error = err.Error()
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Service != routes[j].Service {
+			return routes[i].Service < routes[j].Service
+		}
+		return routes[i].Metric < routes[j].Metric
+	})
+	data.Routes = routes
+
+	render(w, "routes", data)
+}
+
+// formatValue formats the fields of a request or response e.g. Request {name string, age int32}
+func formatValue(v *registry.Value) string {
+	if v == nil {
+		return ""
+	}
+	if len(v.Values) == 0 {
+		return v.Type
+	}
+	fields := make([]string, 0, len(v.Values))
+	for _, f := range v.Values {
+		fields = append(fields, f.Name+" "+formatValue(f))
+	}
+	return v.Type + " {" + strings.Join(fields, ", ") + "}"
+}
+
+var templates = template.Must(template.New("web").Funcs(template.FuncMap{
+	"format": formatValue,
+}).Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html>
+<head>
+<title>Micro</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+nav a { margin-right: 1em; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9em; margin-bottom: 1em; }
+th { text-align: left; }
+td, th { padding: 0.25em 0.5em; border-bottom: 1px solid #eee; vertical-align: top; }
+textarea { width: 100%; font-family: monospace; }
+pre { background: #f6f6f6; padding: 1em; }
+.error { color: #c00; }
+</style>
+</head>
+<body>
+<nav><a href="/">Services</a><a href="/routes">Routes</a></nav>
+{{end}}
+
+{{define "footer"}}</body>
+</html>
+{{end}}
+
+{{define "index"}}{{template "header"}}
+<h1>Services</h1>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<table>
+<tr><th>Name</th><th>Versions</th><th>Nodes</th></tr>
+{{range .Services}}
+<tr>
+<td><a href="/service/{{.Name}}">{{.Name}}</a></td>
+<td>{{range .Versions}}{{.}} {{end}}</td>
+<td>{{.Nodes}}</td>
+</tr>
+{{end}}
+</table>
+{{template "footer"}}{{end}}
+
+{{define "service"}}{{template "header"}}
+<h1>{{.Name}}</h1>
+<p><a href="/routes?service={{.Name}}">Routes</a></p>
+{{range .Services}}
+<h2>Version {{.Version}}</h2>
+<h3>Nodes</h3>
+<table>
+<tr><th>Id</th><th>Address</th><th>Metadata</th></tr>
+{{range .Nodes}}
+<tr><td>{{.Id}}</td><td>{{.Address}}</td><td>{{range $k, $v := .Metadata}}{{$k}}={{$v}} {{end}}</td></tr>
+{{end}}
+</table>
+<h3>Endpoints</h3>
+<table>
+<tr><th>Name</th><th>Request</th><th>Response</th></tr>
+{{range .Endpoints}}
+<tr><td>{{.Name}}</td><td>{{format .Request}}</td><td>{{format .Response}}</td></tr>
+{{end}}
+</table>
+{{end}}
+<h2>Call</h2>
+<form method="post">
+<select name="endpoint">
+{{range .Endpoints}}<option value="{{.}}"{{if eq . $.Endpoint}} selected{{end}}>{{.}}</option>{{end}}
+</select>
+<input type="password" name="token" placeholder="token, blank to call anonymously">
+<textarea name="request" rows="6">{{.Request}}</textarea>
+<input type="submit" value="Call">
+</form>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+{{if .Response}}<pre>{{.Response}}</pre>{{end}}
+{{template "footer"}}{{end}}
+
+{{define "routes"}}{{template "header"}}
+<h1>Routes</h1>
+<form>
+<input type="text" name="service" value="{{.Service}}" placeholder="service">
+<input type="submit" value="Show">
+</form>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<table>
+<tr><th>Service</th><th>Address</th><th>Gateway</th><th>Network</th><th>Router</th><th>Link</th><th>Metric</th></tr>
+{{range .Routes}}
+<tr><td><a href="/service/{{.Service}}">{{.Service}}</a></td><td>{{.Address}}</td><td>{{.Gateway}}</td><td>{{.Network}}</td><td>{{.Router}}</td><td>{{.Link}}</td><td>{{.Metric}}</td></tr>
+{{end}}
+</table>
+{{template "footer"}}{{end}}
+`))
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/micro/micro/v3/service/registry"
+	"github.com/micro/micro/v3/service/registry/memory"
+	"github.com/micro/micro/v3/service/router"
+	regRouter "github.com/micro/micro/v3/service/router/registry"
+)
+
+func TestDashboard(t *testing.T) {
+	defer func(reg registry.Registry, rtr router.Router) {
+		registry.DefaultRegistry, router.DefaultRouter = reg, rtr
+	}(registry.DefaultRegistry, router.DefaultRouter)
+
+	registry.DefaultRegistry = memory.NewRegistry()
+	router.DefaultRouter = regRouter.NewRouter(router.Registry(registry.DefaultRegistry))
+
+	err := registry.DefaultRegistry.Register(&registry.Service{
+		Name:    "greeter",
+		Version: "latest",
+		Nodes:   []*registry.Node{{Id: "greeter-1", Address: "10.0.0.1:8080"}},
+		Endpoints: []*registry.Endpoint{{
+			Name:     "Greeter.Hello",
+			Request:  &registry.Value{Type: "Request", Values: []*registry.Value{{Name: "name", Type: "string"}}},
+			Response: &registry.Value{Type: "Response"},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = router.DefaultRouter.Table().Create(router.Route{Service: "greeter", Address: "10.0.0.1:8080", Network: "micro", Router: "local"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHandler()
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	if w := get("/"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `href="/service/greeter"`) {
+		t.Fatalf("Expected the services to be listed, got %v: %v", w.Code, w.Body)
+	}
+
+	w := get("/service/greeter")
+	for _, s := range []string{"10.0.0.1:8080", "Greeter.Hello", "Request {name string}"} {
+		if !strings.Contains(w.Body.String(), s) {
+			t.Fatalf("Expected the service page to contain %v, got %v", s, w.Body)
+		}
+	}
+
+	if w := get("/service/missing"); w.Code != http.StatusNotFound {
+		t.Fatalf("Expected a 404 for a missing service, got %v", w.Code)
+	}
+
+	if w := get("/routes?service=greeter"); !strings.Contains(w.Body.String(), "<td>10.0.0.1:8080</td>") {
+		t.Fatalf("Expected the routes of the service to be listed, got %v", w.Body)
+	}
+
+	// invalid requests are rejected before the service is called
+	w = httptest.NewRecorder()
+	form := url.Values{"endpoint": {"Greeter.Hello"}, "request": {"{name"}}
+	req := httptest.NewRequest("POST", "/service/greeter", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	h.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), "Invalid request") {
+		t.Fatalf("Expected the invalid request to be rejected, got %v", w.Body)
+	}
+}
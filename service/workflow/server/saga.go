@@ -0,0 +1,223 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/workflow"
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+)
+
+// the states of a saga
+const (
+	sagaRunning      = "running"
+	sagaCompensating = "compensating"
+	sagaCompleted    = "completed"
+	sagaCompensated  = "compensated"
+	sagaFailed       = "failed"
+)
+
+// the states of a step
+const (
+	stepPending     = "pending"
+	stepCompleted   = "completed"
+	stepFailed      = "failed"
+	stepCompensated = "compensated"
+)
+
+// RetryDelay is the delay before retrying a call, multiplied by the number of attempts made
+var RetryDelay = time.Second
+
+// callFunc makes an rpc call with a json request, returning the json response
+type callFunc func(ctx context.Context, c *pb.Call, timeout time.Duration) ([]byte, error)
+
+// call makes an rpc call using the default client
+func call(ctx context.Context, c *pb.Call, timeout time.Duration) ([]byte, error) {
+	req := json.RawMessage(c.Request)
+	var rsp json.RawMessage
+
+	// the steps are retried by the saga
+	opts := []client.CallOption{client.WithRetries(0)}
+	if timeout > 0 {
+		opts = append(opts, client.WithRequestTimeout(timeout))
+	}
+
+	creq := client.DefaultClient.NewRequest(c.Service, c.Endpoint, &req, client.WithContentType("application/json"))
+	if err := client.DefaultClient.Call(ctx, creq, &rsp, opts...); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// callContext returns the context the calls of a saga are made with. It isn't cancelled with the
+// request which started the saga but carries the token of its caller, so the steps are called as
+// the account which started the saga rather than as the workflow service.
+func callContext(ctx context.Context) context.Context {
+	cctx := context.Background()
+	if token, ok := metadata.Get(ctx, "Authorization"); ok {
+		cctx = metadata.Set(cctx, "Authorization", token)
+	}
+	return cctx
+}
+
+// validateSteps ensures the steps of a saga can be executed
+func validateSteps(steps []*pb.Step) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("Missing steps")
+	}
+	if len(steps) > MaxSteps {
+		return fmt.Errorf("A saga has %d steps at most", MaxSteps)
+	}
+	for i, s := range steps {
+		if err := validateCall(s.Call); err != nil {
+			return fmt.Errorf("Step %d: %v", i+1, err)
+		}
+		if s.Compensation != nil {
+			if err := validateCall(s.Compensation); err != nil {
+				return fmt.Errorf("Step %d compensation: %v", i+1, err)
+			}
+		}
+		if s.Retries < 0 || s.Retries > MaxRetries {
+			return fmt.Errorf("Step %d: retries must be between 0 and %d", i+1, MaxRetries)
+		}
+		if s.Timeout < 0 {
+			return fmt.Errorf("Step %d: invalid timeout", i+1)
+		}
+	}
+	return nil
+}
+
+func validateCall(c *pb.Call) error {
+	if c == nil {
+		return fmt.Errorf("Missing call")
+	}
+	if len(c.Service) == 0 || len(c.Endpoint) == 0 {
+		return fmt.Errorf("Missing service or endpoint")
+	}
+	if len(c.Request) > 0 && !json.Valid(c.Request) {
+		return fmt.Errorf("Invalid json request")
+	}
+	return nil
+}
+
+// execute runs a saga from the state it's in until it's completed, compensated or fails
+// compensating. The state is written after each step so a saga stuck because the service
+// stopped is resumed from the step it was at.
+func (w *Workflow) execute(ctx context.Context, ns string, saga *pb.Saga) {
+	defer func() {
+		w.mtx.Lock()
+		delete(w.running, ns+"/"+saga.Id)
+		w.mtx.Unlock()
+	}()
+
+	for saga.Status == sagaRunning {
+		w.next(ctx, saga)
+		if !w.save(ns, saga) {
+			return
+		}
+	}
+	for saga.Status == sagaCompensating {
+		w.compensate(ctx, saga)
+		if !w.save(ns, saga) {
+			return
+		}
+	}
+}
+
+// next calls the first step which isn't completed, the saga is completed when there's none and
+// compensated when the step fails
+func (w *Workflow) next(ctx context.Context, saga *pb.Saga) {
+	for _, s := range saga.Steps {
+		if s.Status == stepCompleted {
+			continue
+		}
+		rsp, err := w.attempt(ctx, s, s.Call)
+		if err != nil {
+			s.Status = stepFailed
+			s.Error = err.Error()
+			saga.Status = sagaCompensating
+			saga.Error = fmt.Sprintf("Step %v failed: %v", stepName(saga, s), err)
+			return
+		}
+		s.Status = stepCompleted
+		s.Response = rsp
+		s.Error = ""
+		return
+	}
+	saga.Status = sagaCompleted
+}
+
+// compensate undoes the last step completed, the saga is compensated when there's none and fails
+// when the compensation does
+func (w *Workflow) compensate(ctx context.Context, saga *pb.Saga) {
+	for i := len(saga.Steps) - 1; i >= 0; i-- {
+		s := saga.Steps[i]
+		if s.Status != stepCompleted {
+			continue
+		}
+		if s.Compensation != nil {
+			if _, err := w.attempt(ctx, s, s.Compensation); err != nil {
+				s.Error = err.Error()
+				saga.Status = sagaFailed
+				saga.Error = fmt.Sprintf("Compensating step %v failed: %v", stepName(saga, s), err)
+				return
+			}
+		}
+		s.Status = stepCompensated
+		s.Error = ""
+		return
+	}
+	saga.Status = sagaCompensated
+}
+
+// attempt makes a call of a step, retrying it up to the retries of the step
+func (w *Workflow) attempt(ctx context.Context, s *pb.Step, c *pb.Call) ([]byte, error) {
+	timeout := time.Duration(s.Timeout) * time.Millisecond
+	for n := int32(0); ; n++ {
+		s.Attempts++
+		rsp, err := w.call(ctx, c, timeout)
+		if err == nil {
+			return rsp, nil
+		}
+		if n >= s.Retries || !retryable(err) {
+			return nil, err
+		}
+		time.Sleep(RetryDelay * time.Duration(n+1))
+	}
+}
+
+// retryable returns whether a call which failed with an error could succeed if retried, the calls
+// rejected by the service aren't
+func retryable(err error) bool {
+	code := errors.FromError(err).Code
+	return code == 0 || code == 408 || code >= 500
+}
+
+// save writes the state of a saga, returning whether it was written
+func (w *Workflow) save(ns string, saga *pb.Saga) bool {
+	saga.Updated = time.Now().Unix()
+	if err := writeSaga(ns, saga); err != nil {
+		// the saga is left as it was stored and reported as stuck once it's no longer running
+		log.Errorf("Error writing saga %v: %v", saga.Id, err)
+		return false
+	}
+	return true
+}
+
+// stepName returns the name of a step, or its number if it has none
+func stepName(saga *pb.Saga, s *pb.Step) string {
+	if len(s.Name) > 0 {
+		return s.Name
+	}
+	for i, step := range saga.Steps {
+		if step == s {
+			return fmt.Sprintf("%d", i+1)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,253 @@
+// Package server is the workflow service which executes sagas, the steps of a saga are rpc calls
+// made in order and when one of them fails the compensations of the steps before it are made in
+// reverse to undo them
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	gosync "sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/micro/v3/internal/auth/namespace"
+	pb "github.com/micro/micro/v3/proto/workflow"
+	"github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/errors"
+	log "github.com/micro/micro/v3/service/logger"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	name = "workflow"
+	// table the sagas are stored in, in the database of their namespace
+	table = "workflow"
+)
+
+var (
+	// MaxSteps is the max number of steps of a saga
+	MaxSteps = 100
+	// MaxRetries is the max number of times the calls of a step are retried
+	MaxRetries int32 = 10
+)
+
+// Run the micro workflow service. The sagas being executed are tracked by the service so a
+// single instance of it must run.
+func Run(ctx *cli.Context) error {
+	srv := service.New(service.Name(name))
+
+	pb.RegisterWorkflowHandler(srv.Server(), NewWorkflow())
+
+	if err := srv.Run(); err != nil {
+		log.Fatal(err)
+	}
+	return nil
+}
+
+// NewWorkflow returns the handler of the workflow service
+func NewWorkflow() *Workflow {
+	return &Workflow{
+		running: make(map[string]bool),
+		call:    call,
+	}
+}
+
+// Workflow processes the RPC calls for the sagas, the sagas of each namespace are stored in the
+// database of the namespace
+type Workflow struct {
+	mtx gosync.Mutex
+	// running are the sagas being executed, by namespace and id
+	running map[string]bool
+	// call makes the calls of the steps
+	call callFunc
+}
+
+// Start executes a saga, the steps are called in order and when one of them fails the
+// compensations of the steps before it are called in reverse
+func (w *Workflow) Start(ctx context.Context, req *pb.StartRequest, rsp *pb.StartResponse) error {
+	if err := validateSteps(req.Steps); err != nil {
+		return errors.BadRequest("workflow.Workflow.Start", err.Error())
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "workflow.Workflow.Start", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range req.Steps {
+		if len(s.Call.Request) == 0 {
+			s.Call.Request = []byte("{}")
+		}
+		if s.Compensation != nil && len(s.Compensation.Request) == 0 {
+			s.Compensation.Request = []byte("{}")
+		}
+		s.Status = stepPending
+		s.Response = nil
+		s.Error = ""
+		s.Attempts = 0
+	}
+
+	now := time.Now().Unix()
+	saga := &pb.Saga{
+		Id:      uuid.New().String(),
+		Name:    req.Name,
+		Steps:   req.Steps,
+		Status:  sagaRunning,
+		Created: now,
+		Updated: now,
+	}
+	if err := writeSaga(ns, saga); err != nil {
+		return errors.InternalServerError("workflow.Workflow.Start", "Error writing the saga: %v", err)
+	}
+
+	w.mtx.Lock()
+	w.running[ns+"/"+saga.Id] = true
+	w.mtx.Unlock()
+
+	rsp.Saga = copySaga(saga)
+	go w.execute(callContext(ctx), ns, saga)
+	return nil
+}
+
+// Read returns a workflow and the state of its steps
+func (w *Workflow) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
+	if len(req.Id) == 0 {
+		return errors.BadRequest("workflow.Workflow.Read", "Missing id")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "workflow.Workflow.Read", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	saga, err := readSaga(ns, req.Id)
+	if err == store.ErrNotFound {
+		return errors.NotFound("workflow.Workflow.Read", "Workflow not found")
+	} else if err != nil {
+		return errors.InternalServerError("workflow.Workflow.Read", "Error reading the saga: %v", err)
+	}
+	saga.Stuck = w.stuck(ns, saga)
+	rsp.Saga = saga
+	return nil
+}
+
+// List returns the workflows, most recently updated first
+func (w *Workflow) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) error {
+	ns, err := namespace.AuthorizeRequest(ctx, "workflow.Workflow.List", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	recs, err := store.DefaultStore.Read("", store.ReadFrom(ns, table), store.ReadPrefix())
+	if err != nil && err != store.ErrNotFound {
+		return errors.InternalServerError("workflow.Workflow.List", "Error reading the sagas: %v", err)
+	}
+
+	rsp.Sagas = make([]*pb.Saga, 0, len(recs))
+	for _, r := range recs {
+		var saga pb.Saga
+		if err := json.Unmarshal(r.Value, &saga); err != nil {
+			return errors.InternalServerError("workflow.Workflow.List", "Error unmarshaling the saga: %v", err)
+		}
+		saga.Stuck = w.stuck(ns, &saga)
+		if req.Stuck && !saga.Stuck {
+			continue
+		}
+		rsp.Sagas = append(rsp.Sagas, &saga)
+	}
+	sort.SliceStable(rsp.Sagas, func(i, j int) bool {
+		return rsp.Sagas[i].Updated > rsp.Sagas[j].Updated
+	})
+	return nil
+}
+
+// Resume continues a stuck workflow from the step it stopped at. A saga which failed compensating
+// retries the compensation which failed. The calls are made as the account resuming the saga.
+func (w *Workflow) Resume(ctx context.Context, req *pb.ResumeRequest, rsp *pb.ResumeResponse) error {
+	if len(req.Id) == 0 {
+		return errors.BadRequest("workflow.Workflow.Resume", "Missing id")
+	}
+	ns, err := namespace.AuthorizeRequest(ctx, "workflow.Workflow.Resume", req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// hold the lock until the saga is marked as running so it's only resumed once
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	key := ns + "/" + req.Id
+	if w.running[key] {
+		return errors.Conflict("workflow.Workflow.Resume", "Workflow is running")
+	}
+	saga, err := readSaga(ns, req.Id)
+	if err == store.ErrNotFound {
+		return errors.NotFound("workflow.Workflow.Resume", "Workflow not found")
+	} else if err != nil {
+		return errors.InternalServerError("workflow.Workflow.Resume", "Error reading the saga: %v", err)
+	}
+
+	switch saga.Status {
+	case sagaRunning, sagaCompensating:
+	case sagaFailed:
+		saga.Status = sagaCompensating
+	default:
+		return errors.Conflict("workflow.Workflow.Resume", "Workflow is %v", saga.Status)
+	}
+	saga.Updated = time.Now().Unix()
+	if err := writeSaga(ns, saga); err != nil {
+		return errors.InternalServerError("workflow.Workflow.Resume", "Error writing the saga: %v", err)
+	}
+	w.running[key] = true
+
+	rsp.Saga = copySaga(saga)
+	go w.execute(callContext(ctx), ns, saga)
+	return nil
+}
+
+// stuck returns whether a saga won't progress unless it's resumed
+func (w *Workflow) stuck(ns string, saga *pb.Saga) bool {
+	switch saga.Status {
+	case sagaFailed:
+		return true
+	case sagaRunning, sagaCompensating:
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+		return !w.running[ns+"/"+saga.Id]
+	}
+	return false
+}
+
+func readSaga(ns, id string) (*pb.Saga, error) {
+	recs, err := store.DefaultStore.Read(id, store.ReadFrom(ns, table))
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, store.ErrNotFound
+	}
+	var saga pb.Saga
+	if err := json.Unmarshal(recs[0].Value, &saga); err != nil {
+		return nil, err
+	}
+	return &saga, nil
+}
+
+func writeSaga(ns string, saga *pb.Saga) error {
+	b, err := json.Marshal(saga)
+	if err != nil {
+		return err
+	}
+	return store.DefaultStore.Write(&store.Record{Key: saga.Id, Value: b}, store.WriteTo(ns, table))
+}
+
+// copySaga returns a copy of a saga which isn't changed by its execution
+func copySaga(saga *pb.Saga) *pb.Saga {
+	s := *saga
+	s.Steps = make([]*pb.Step, len(saga.Steps))
+	for i, step := range saga.Steps {
+		c := *step
+		s.Steps[i] = &c
+	}
+	return &s
+}
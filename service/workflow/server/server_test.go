@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	gosync "sync"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v3/proto/workflow"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+// testCalls records the calls made and fails the endpoints set to fail
+type testCalls struct {
+	sync  gosync.Mutex
+	calls []string
+	fail  map[string]error
+}
+
+func (t *testCalls) call(ctx context.Context, c *pb.Call, timeout time.Duration) ([]byte, error) {
+	t.sync.Lock()
+	defer t.sync.Unlock()
+	t.calls = append(t.calls, c.Endpoint)
+	if err := t.fail[c.Endpoint]; err != nil {
+		return nil, err
+	}
+	return []byte(`{"ok":true}`), nil
+}
+
+func (t *testCalls) made() []string {
+	t.sync.Lock()
+	defer t.sync.Unlock()
+	return append([]string{}, t.calls...)
+}
+
+func step(name string) *pb.Step {
+	return &pb.Step{
+		Name:         name,
+		Call:         &pb.Call{Service: "test", Endpoint: name},
+		Compensation: &pb.Call{Service: "test", Endpoint: "undo-" + name},
+		Retries:      1,
+	}
+}
+
+// wait returns the saga once it's no longer executed
+func wait(t *testing.T, w *Workflow, ctx context.Context, id string) *pb.Saga {
+	for i := 0; i < 100; i++ {
+		rsp := new(pb.ReadResponse)
+		if err := w.Read(ctx, &pb.ReadRequest{Id: id}, rsp); err != nil {
+			t.Fatal(err)
+		}
+		if rsp.Saga.Status != sagaRunning && rsp.Saga.Status != sagaCompensating {
+			return rsp.Saga
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Saga %v wasn't executed", id)
+	return nil
+}
+
+func TestWorkflow(t *testing.T) {
+	defer func(s store.Store) { store.DefaultStore = s }(store.DefaultStore)
+	store.DefaultStore = memory.NewStore()
+	defer func(d time.Duration) { RetryDelay = d }(RetryDelay)
+	RetryDelay = time.Millisecond
+
+	ctx := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "svc", Issuer: "foo"})
+	calls := &testCalls{fail: map[string]error{}}
+	w := NewWorkflow()
+	w.call = calls.call
+
+	// the steps are called in order
+	start := new(pb.StartResponse)
+	if err := w.Start(ctx, &pb.StartRequest{Name: "order", Steps: []*pb.Step{step("a"), step("b")}}, start); err != nil {
+		t.Fatal(err)
+	}
+	saga := wait(t, w, ctx, start.Saga.Id)
+	if saga.Status != sagaCompleted || fmt.Sprint(calls.made()) != "[a b]" {
+		t.Fatalf("Expected the saga to complete calling a then b, got %v calling %v", saga.Status, calls.made())
+	}
+	if string(saga.Steps[1].Response) != `{"ok":true}` {
+		t.Fatalf("Expected the response of the step to be stored, got %s", saga.Steps[1].Response)
+	}
+
+	// the steps before the one which failed are compensated in reverse, the failure is retried
+	calls.calls = nil
+	calls.fail["c"] = errors.InternalServerError("test", "unavailable")
+	if err := w.Start(ctx, &pb.StartRequest{Steps: []*pb.Step{step("a"), step("b"), step("c")}}, start); err != nil {
+		t.Fatal(err)
+	}
+	saga = wait(t, w, ctx, start.Saga.Id)
+	if saga.Status != sagaCompensated || fmt.Sprint(calls.made()) != "[a b c c undo-b undo-a]" {
+		t.Fatalf("Expected the saga to be compensated, got %v calling %v", saga.Status, calls.made())
+	}
+
+	// a compensation which fails leaves the saga stuck until it's resumed
+	calls.calls = nil
+	calls.fail["c"] = errors.BadRequest("test", "rejected")
+	calls.fail["undo-a"] = errors.InternalServerError("test", "unavailable")
+	if err := w.Start(ctx, &pb.StartRequest{Steps: []*pb.Step{step("a"), step("b"), step("c")}}, start); err != nil {
+		t.Fatal(err)
+	}
+	saga = wait(t, w, ctx, start.Saga.Id)
+	if saga.Status != sagaFailed || !saga.Stuck || fmt.Sprint(calls.made()) != "[a b c undo-b undo-a undo-a]" {
+		t.Fatalf("Expected the saga to fail compensating, got %v calling %v", saga.Status, calls.made())
+	}
+	list := new(pb.ListResponse)
+	if err := w.List(ctx, &pb.ListRequest{Stuck: true}, list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Sagas) != 1 || list.Sagas[0].Id != saga.Id {
+		t.Fatalf("Expected the failed saga to be listed as stuck, got %v", list.Sagas)
+	}
+
+	calls.calls = nil
+	delete(calls.fail, "undo-a")
+	if err := w.Resume(ctx, &pb.ResumeRequest{Id: saga.Id}, new(pb.ResumeResponse)); err != nil {
+		t.Fatal(err)
+	}
+	saga = wait(t, w, ctx, saga.Id)
+	if saga.Status != sagaCompensated || fmt.Sprint(calls.made()) != "[undo-a]" {
+		t.Fatalf("Expected the resumed saga to retry the compensation, got %v calling %v", saga.Status, calls.made())
+	}
+	err := w.Resume(ctx, &pb.ResumeRequest{Id: saga.Id}, new(pb.ResumeResponse))
+	if merr := errors.FromError(err); merr.Code != 409 {
+		t.Fatalf("Expected a compensated saga not to be resumed, got %v", err)
+	}
+
+	// a saga left running by a service which stopped continues from the step it was at
+	saga.Id = "stopped"
+	saga.Status = sagaRunning
+	saga.Steps = []*pb.Step{step("a"), step("b")}
+	saga.Steps[0].Status = stepCompleted
+	saga.Steps[1].Status = stepPending
+	if err := writeSaga("foo", saga); err != nil {
+		t.Fatal(err)
+	}
+	calls.calls = nil
+	w = NewWorkflow()
+	w.call = calls.call
+	if err := w.List(ctx, &pb.ListRequest{Stuck: true}, list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Sagas) != 1 || list.Sagas[0].Id != "stopped" {
+		t.Fatalf("Expected the stopped saga to be listed as stuck, got %v", list.Sagas)
+	}
+	if err := w.Resume(ctx, &pb.ResumeRequest{Id: "stopped"}, new(pb.ResumeResponse)); err != nil {
+		t.Fatal(err)
+	}
+	saga = wait(t, w, ctx, "stopped")
+	if saga.Status != sagaCompleted || fmt.Sprint(calls.made()) != "[b]" {
+		t.Fatalf("Expected the resumed saga to complete calling b, got %v calling %v", saga.Status, calls.made())
+	}
+
+	// the sagas of other namespaces can't be accessed
+	bar := auth.ContextWithAccount(context.TODO(), &auth.Account{ID: "svc", Issuer: "bar"})
+	err = w.Read(bar, &pb.ReadRequest{Id: "stopped"}, new(pb.ReadResponse))
+	if merr := errors.FromError(err); merr.Code != 404 {
+		t.Fatalf("Expected the saga not to be found in another namespace, got %v", err)
+	}
+	err = w.Read(bar, &pb.ReadRequest{Id: "stopped", Namespace: "foo"}, new(pb.ReadResponse))
+	if merr := errors.FromError(err); merr.Code != 403 {
+		t.Fatalf("Expected the namespace of another account to be forbidden, got %v", err)
+	}
+
+	err = w.Start(ctx, &pb.StartRequest{Steps: []*pb.Step{{Call: &pb.Call{Service: "test"}}}}, start)
+	if merr := errors.FromError(err); merr.Code != 400 {
+		t.Fatalf("Expected a step without an endpoint to be rejected, got %v", err)
+	}
+}